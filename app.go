@@ -1,25 +1,49 @@
 package main
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/run-bigpig/jcp/internal/adk"
 	"github.com/run-bigpig/jcp/internal/adk/mcp"
+	"github.com/run-bigpig/jcp/internal/adk/ollama"
 	"github.com/run-bigpig/jcp/internal/adk/tools"
 	"github.com/run-bigpig/jcp/internal/agent"
+	"github.com/run-bigpig/jcp/internal/backtest"
+	"github.com/run-bigpig/jcp/internal/engine"
+	"github.com/run-bigpig/jcp/internal/localapi"
 	"github.com/run-bigpig/jcp/internal/logger"
 	"github.com/run-bigpig/jcp/internal/meeting"
 	"github.com/run-bigpig/jcp/internal/memory"
 	"github.com/run-bigpig/jcp/internal/models"
 	"github.com/run-bigpig/jcp/internal/openclaw"
+	"github.com/run-bigpig/jcp/internal/pkg/apperr"
+	"github.com/run-bigpig/jcp/internal/pkg/coord"
+	"github.com/run-bigpig/jcp/internal/pkg/diagnostics"
+	"github.com/run-bigpig/jcp/internal/pkg/hotkey"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+	"github.com/run-bigpig/jcp/internal/pkg/metrics"
 	"github.com/run-bigpig/jcp/internal/pkg/paths"
 	"github.com/run-bigpig/jcp/internal/pkg/proxy"
+	"github.com/run-bigpig/jcp/internal/pkg/supervisor"
+	"github.com/run-bigpig/jcp/internal/pkg/tray"
 	"github.com/run-bigpig/jcp/internal/services"
 	"github.com/run-bigpig/jcp/internal/services/hottrend"
 
 	"github.com/wailsapp/wails/v2/pkg/runtime"
+	"google.golang.org/adk/model"
 )
 
 var log = logger.New("app")
@@ -30,40 +54,98 @@ type App struct {
 	configService     *services.ConfigService
 	marketService     *services.MarketService
 	newsService       *services.NewsService
+	researchService   *services.ResearchReportService
 	hotTrendService   *hottrend.HotTrendService
 	longHuBangService *services.LongHuBangService
+	ipoService        *services.IPOService
+	gubaService       *services.GubaService
+	marginService     *services.MarginService
+	shareholderSvc    *services.ShareholderService
+	lockupService     *services.LockupService
+	optionService     *services.OptionService
+	lookThroughSvc    *services.LookThroughService
+	riskService       *services.RiskService
+	journalService    *services.JournalService
 	marketPusher      *services.MarketDataPusher
 	meetingService    *meeting.Service
 	sessionService    *services.SessionService
+	discussionService *services.DiscussionService
 	strategyService   *services.StrategyService
+	drawingService    *services.DrawingService
 	agentContainer    *agent.Container
 	toolRegistry      *tools.Registry
 	mcpManager        *mcp.Manager
 	memoryManager     *memory.Manager
 	updateService     *services.UpdateService
 	openClawServer    *openclaw.Server
+	coordinator       *coord.Coordinator
+	syncService       *services.SyncService
+	paperService      *services.PaperTradingService
+	conditionService  *services.ConditionOrderService
+	exportService     *services.ExportService
+	reportService     *services.ReportService
+	briefingService   *services.BriefingService
+	intradayArchive   *services.IntradayArchive
+	notifications     *services.NotificationService
+	limitWatcher      *services.LimitWatcher
+	memoryGuard       *services.MemoryGuard
+	localAPIServer    *localapi.Server
+	detachedWindows   *services.DetachedWindows
+
+	// 根上下文取消函数（shutdown 时调用）
+	rootCancel context.CancelFunc
 
 	// 会议取消管理
-	meetingCancels   map[string]context.CancelFunc
-	meetingCancelsMu sync.RWMutex
+	meetingCancels *meeting.Cancels
+
+	// 远程数据引擎客户端
+	engineClient *engine.Client
+	engineCfg    models.EngineConfig
+	engineMu     sync.Mutex
+
+	// 全局快捷键与窗口显示状态
+	hotkeys      hotkey.Manager
+	windowHidden atomic.Bool
+	notifyMuted  atomic.Bool // 老板键隐藏窗口期间静音通知
+
+	// 系统托盘（滚动行情与快捷菜单）
+	tray *tray.Tray
+
+	// 分离窗口进程：本进程显示的窗口订阅与推送服务，主进程为 nil
+	detached     *services.WindowSubscription
+	windowPusher *services.WindowPusher
 }
 
 // NewApp creates a new App application struct
 func NewApp() *App {
 	dataDir := paths.GetDataDir()
 
-	// 初始化文件日志
-	if err := logger.InitFileLogger(filepath.Join(dataDir, "logs")); err != nil {
-		log.Error("初始化文件日志失败: %v", err)
-	}
-	logger.SetGlobalLevel(logger.DEBUG)
-
 	// 初始化配置服务
 	configService, err := services.NewConfigService(dataDir)
 	if err != nil {
 		panic(err)
 	}
 
+	// 初始化文件日志（按大小轮转，启动时清理过期日志）
+	logCfg := configService.GetConfig().Log
+	if err := logger.InitFileLogger(filepath.Join(dataDir, "logs"), logger.FileOptions{
+		MaxSizeMB:     logCfg.MaxSizeMB,
+		RetentionDays: logCfg.RetentionDays,
+	}); err != nil {
+		log.Error("初始化文件日志失败: %v", err)
+	}
+	logger.SetGlobalLevel(logger.DEBUG)
+	i18n.SetLang(configService.GetConfig().Language)
+
+	// 初始化 AI 用量跟踪（费用预算）
+	usageTracker := adk.GetUsageTracker()
+	usageTracker.Load(dataDir)
+	usageTracker.SetBudget(configService.GetConfig().AIBudget)
+
+	// 初始化工具执行沙箱（超时、限流、审计日志）
+	adk.GetToolSandbox().SetConfig(configService.GetConfig().ToolSandbox)
+	adk.GetToolSandbox().Load(dataDir)
+
 	// 初始化研报服务
 	researchReportService := services.NewResearchReportService()
 
@@ -74,11 +156,28 @@ func NewApp() *App {
 	}
 
 	marketService := services.NewMarketService()
+	marketService.SetIndexCodes(configService.GetConfig().MarketIndices)
 	newsService := services.NewNewsService()
+	newsService.SetArchive(services.NewNewsArchive(dataDir))
 
 	// 初始化龙虎榜服务
 	longHuBangService := services.NewLongHuBangService()
 
+	// 初始化新股日历服务
+	ipoService := services.NewIPOService(configService)
+
+	// 初始化股吧关注度服务
+	gubaService := services.NewGubaService()
+
+	// 初始化融资融券服务
+	marginService := services.NewMarginService(marketService)
+
+	// 初始化ETF期权服务
+	optionService := services.NewOptionService(marketService)
+
+	// 初始化ETF穿透分析服务
+	lookThroughSvc := services.NewLookThroughService()
+
 	// 初始化工具注册中心
 	toolRegistry := tools.NewRegistry(marketService, newsService, configService, researchReportService, hotTrendSvc, longHuBangService)
 
@@ -100,6 +199,7 @@ func NewApp() *App {
 			MaxKeyFacts:       memConfig.MaxKeyFacts,
 			MaxSummaryLength:  memConfig.MaxSummaryLength,
 			CompressThreshold: memConfig.CompressThreshold,
+			Prompts:           memoryPromptConfig(memConfig.Prompts),
 		})
 		meetingService.SetMemoryManager(memoryManager)
 
@@ -112,6 +212,16 @@ func NewApp() *App {
 				}
 			}
 		}
+
+		if memConfig.EmbeddingAIID != "" && memConfig.EmbeddingModel != "" {
+			for i := range configService.GetConfig().AIConfigs {
+				if configService.GetConfig().AIConfigs[i].ID == memConfig.EmbeddingAIID {
+					memoryManager.SetEmbedder(adk.NewModelFactory().CreateEmbedder(&configService.GetConfig().AIConfigs[i], memConfig.EmbeddingModel))
+					log.Info("Memory embedding model: %s", memConfig.EmbeddingModel)
+					break
+				}
+			}
+		}
 		log.Info("Memory manager enabled")
 	}
 
@@ -131,13 +241,17 @@ func NewApp() *App {
 
 	// 初始化策略服务
 	strategyService := services.NewStrategyService(dataDir)
+	strategyService.SetPersonas(configService.GetPersonas())
+
+	// 初始化画线服务
+	drawingService := services.NewDrawingService(dataDir)
 
 	// 初始化Agent容器（直接从StrategyService获取数据）
 	agentContainer := agent.NewContainer()
 	agentContainer.LoadAgents(strategyService.GetAllAgents())
 
 	// 初始化更新服务
-	updateService := services.NewUpdateService("run-bigpig", "jcp", Version)
+	updateService := services.NewUpdateService("run-bigpig", "jcp", Version, dataDir)
 
 	// 初始化 OpenClaw 服务
 	openClawServer := openclaw.NewServer(meetingService, agentContainer, func(aiConfigID string) *models.AIConfig {
@@ -151,8 +265,8 @@ func NewApp() *App {
 			}
 		}
 		return nil
-	}, func(code string) (*models.Stock, error) {
-		stocks, err := marketService.GetStockRealTimeData(code)
+	}, func(ctx context.Context, code string) (*models.Stock, error) {
+		stocks, err := marketService.GetStockRealTimeData(ctx, code)
 		if err != nil {
 			return nil, err
 		}
@@ -162,34 +276,64 @@ func NewApp() *App {
 		return &stocks[0], nil
 	})
 
+	// 模拟交易（条件单可触发模拟委托）
+	paperService := services.NewPaperTradingService(dataDir, marketService)
+
 	log.Info("所有服务初始化完成")
 
 	return &App{
 		configService:     configService,
 		marketService:     marketService,
 		newsService:       newsService,
+		researchService:   researchReportService,
 		hotTrendService:   hotTrendSvc,
 		longHuBangService: longHuBangService,
+		ipoService:        ipoService,
+		gubaService:       gubaService,
+		marginService:     marginService,
+		shareholderSvc:    services.NewShareholderService(),
+		lockupService:     services.NewLockupService(dataDir, configService),
+		optionService:     optionService,
+		lookThroughSvc:    lookThroughSvc,
+		riskService:       services.NewRiskService(),
+		journalService:    services.NewJournalService(dataDir),
 		meetingService:    meetingService,
 		sessionService:    sessionService,
+		discussionService: services.NewDiscussionService(dataDir),
 		strategyService:   strategyService,
+		drawingService:    drawingService,
 		agentContainer:    agentContainer,
 		toolRegistry:      toolRegistry,
 		mcpManager:        mcpManager,
 		memoryManager:     memoryManager,
 		updateService:     updateService,
 		openClawServer:    openClawServer,
-		meetingCancels:    make(map[string]context.CancelFunc),
+		syncService:       services.NewSyncService(dataDir, configService),
+		paperService:      paperService,
+		conditionService:  services.NewConditionOrderService(dataDir, marketService, paperService),
+		exportService:     services.NewExportService(marketService, configService, paperService),
+		reportService:     services.NewReportService(dataDir, sessionService, marketService),
+		briefingService:   services.NewBriefingService(dataDir, configService, marketService, newsService),
+		intradayArchive:   services.NewIntradayArchive(dataDir, configService, marketService),
+		notifications:     services.NewNotificationService(dataDir),
+		limitWatcher:      services.NewLimitWatcher(),
+		memoryGuard:       services.NewMemoryGuard(0),
+		detachedWindows:   services.NewDetachedWindows(),
+		meetingCancels:    meeting.NewCancels(),
 	}
 }
 
 // startup is called when the app starts. The context is saved
 // so we can call the runtime methods
 func (a *App) startup(ctx context.Context) {
+	// 根上下文：退出时取消，中断进行中的行情请求、AI 会话与后台任务
+	ctx, a.rootCancel = context.WithCancel(ctx)
 	a.ctx = ctx
 
-	// 初始化代理配置
+	// 初始化代理配置、上游限流规则与连接池参数
 	proxy.GetManager().SetConfig(&a.configService.GetConfig().Proxy)
+	proxy.GetManager().SetRateLimits(a.configService.GetConfig().RateLimits)
+	proxy.GetManager().SetConnPools(a.configService.GetConfig().ConnPools)
 
 	// 初始化 MCP 管理器（绑定主 context，预创建 toolset）
 	if a.mcpManager != nil {
@@ -198,6 +342,19 @@ func (a *App) startup(ctx context.Context) {
 		}
 	}
 
+	// AI 费用超出预算时通知前端
+	adk.GetUsageTracker().SetOnExceeded(func(ev adk.BudgetExceededEvent) {
+		runtime.EventsEmit(a.ctx, "ai:budget:exceeded", ev)
+	})
+
+	// AI 用量更新时推送统计
+	adk.GetUsageTracker().SetOnUpdate(func(summary adk.UsageSummary) {
+		runtime.EventsEmit(a.ctx, "ai:usage:update", summary)
+	})
+
+	// 日志实时推送到诊断面板
+	a.startLogStream(ctx)
+
 	// 设置 Meeting 服务的 AI 配置解析器
 	if a.meetingService != nil {
 		a.meetingService.SetAIConfigResolver(a.getAIConfigByID)
@@ -206,13 +363,50 @@ func (a *App) startup(ctx context.Context) {
 	// 初始化更新服务
 	if a.updateService != nil {
 		a.updateService.Startup(ctx)
+		if cfg := a.configService.GetConfig().Update; !cfg.DisableAutoCheck {
+			a.updateService.AutoCheck(ctx, cfg.SkipVersion)
+		}
 	}
 
+	// 启动自检：结果写入日志，首次运行向导通过 RunDiagnostics 获取完整报告
+	go a.RunDiagnostics()
+
+	// 远程数据引擎（需在推送服务启动前切换数据源）
+	a.applyEngineConfig(&a.configService.GetConfig().Engine)
+	a.applyRetryConfig(a.configService.GetConfig().Retry)
+
 	// 初始化并启动市场数据推送服务（需要 context）
+	// 同机多实例协调：仅 leader 轮询全市场数据，其余实例共享快照
+	a.coordinator = coord.New(filepath.Join(paths.GetDataDir(), "jcp.sock"))
+	a.coordinator.Start()
+
 	a.marketPusher = services.NewMarketDataPusher(a.marketService, a.configService, a.newsService)
+	a.marketPusher.SetCoordinator(a.coordinator)
+	a.marketPusher.SetDrawingService(a.drawingService)
+	// 画线变更广播到所有窗口
+	a.drawingService.SetOnChange(func(update services.DrawingsUpdate) {
+		runtime.EventsEmit(a.ctx, services.EventDrawingsUpdate, update)
+	})
+	if iv := a.configService.GetConfig().PushIntervals; iv != nil {
+		if err := a.marketPusher.SetPushIntervals(*iv); err != nil {
+			log.Warn("推送频率配置无效，使用默认值: %v", err)
+		}
+	}
 	a.marketPusher.Start(ctx)
 	log.Info("市场数据推送服务已启动")
 
+	// 系统托盘：滚动显示自选股行情，菜单可显示/隐藏窗口、暂停推送与退出
+	a.tray = tray.New(tray.Actions{
+		ToggleWindow: a.toggleWindow,
+		SetPaused:    a.marketPusher.SetPaused,
+		Quit:         func() { runtime.Quit(a.ctx) },
+	})
+	a.marketPusher.SetOnPausedChange(func(paused bool) {
+		runtime.EventsEmit(a.ctx, "market:push:paused", paused)
+	})
+	a.tray.Start()
+	supervisor.Go(ctx, "tray-ticker", a.runTrayTicker)
+
 	// 启动 OpenClaw 服务（如果已启用）
 	cfg := a.configService.GetConfig()
 	if cfg.OpenClaw.Enabled && cfg.OpenClaw.Port > 0 {
@@ -220,20 +414,193 @@ func (a *App) startup(ctx context.Context) {
 			log.Warn("OpenClaw 启动失败: %v", err)
 		}
 	}
+
+	// 启动本地 REST API 服务（如果已启用）
+	a.localAPIServer = localapi.NewServer(localAPISource{a})
+	a.applyLocalAPIConfig(&cfg.LocalAPI)
+	a.applyHotkeyConfig(&cfg.Hotkeys)
+	a.newsService.SetDisabledSources(cfg.News.DisabledSources)
+	a.newsService.SetAlertKeywords(cfg.News.AlertKeywords)
+	a.newsService.SetDigestConfig(cfg.News.Digest)
+
+	// 通知中心：汇总各类提醒，免打扰时段外推送给前端
+	a.notifications.SetConfig(cfg.Notifications)
+	a.notifications.SetOnNotify(func(n models.Notification) {
+		runtime.EventsEmit(a.ctx, services.EventNotification, n)
+		if !a.notifyMuted.Load() {
+			a.tray.Alert(n.Title)
+		}
+	})
+	a.marketPusher.SetTelegraphAlertObserver(func(t services.Telegraph) {
+		a.notifications.Notify(models.Notification{
+			Category: models.NotificationNews,
+			Title:    "快讯提醒：" + strings.Join(t.Keywords, "、"),
+			Body:     t.Content,
+		})
+	})
+
+	// 模拟交易：成交时通知前端，交易时段撮合挂单
+	a.paperService.SetOnFill(func(order models.PaperOrder) {
+		runtime.EventsEmit(a.ctx, "paper:filled", order)
+	})
+	a.paperService.Start(ctx)
+
+	// 条件单：随实时行情推送评估，触发时通知前端
+	a.conditionService.SetOnTrigger(func(order models.ConditionOrder) {
+		runtime.EventsEmit(a.ctx, "condition:triggered", order)
+		title := "条件单触发：" + cmp.Or(order.Name, order.Symbol)
+		if order.TriggerPrice > 0 {
+			title += fmt.Sprintf(" @ %.2f", order.TriggerPrice)
+		}
+		a.notifications.Notify(models.Notification{
+			Category: models.NotificationPrice,
+			Title:    title,
+			Body:     cmp.Or(order.Result, order.Note),
+			Symbol:   order.Symbol,
+		})
+	})
+	a.marketPusher.SetQuoteObserver(a.observedSymbols, a.observeQuotes)
+
+	// 每日收盘简报：交易日收盘后自动生成，完成时通知前端
+	a.briefingService.SetLLMProvider(a.createLLM)
+	a.briefingService.SetSessionProvider(a.sessionHeatReport)
+	a.briefingService.SetNearTargetsProvider(a.GetNearTargets)
+	a.briefingService.SetOnReady(func(briefing models.DailyBriefing) {
+		runtime.EventsEmit(a.ctx, "ai:briefing:ready", briefing)
+		a.notifications.Notify(models.Notification{
+			Category: models.NotificationBriefing,
+			Title:    briefing.Date + " 收盘简报已生成",
+			Body:     briefing.Summary,
+		})
+	})
+	a.briefingService.Start(ctx)
+
+	// 限售解禁提醒：自选股大额解禁前 N 天提醒
+	a.lockupService.SetOnAlert(func(items []models.LockupExpiry) {
+		for _, item := range items {
+			a.notifications.Notify(models.Notification{
+				Category: models.NotificationLockup,
+				Title:    fmt.Sprintf("%s %s 限售解禁", item.Name, item.Date),
+				Body:     fmt.Sprintf("%d天后解禁%.2f万股，占流通股%.2f%%", item.DaysUntil, item.Shares/1e4, item.FloatRatio),
+				Symbol:   item.Symbol,
+			})
+		}
+	})
+	a.lockupService.Start(ctx)
+
+	// 新股申购提醒：每个申购日开盘前提醒一次
+	a.ipoService.SetOnReminder(func(items []models.IPOItem) {
+		for _, item := range items {
+			a.notifications.Notify(models.Notification{
+				Category: models.NotificationIPO,
+				Title:    fmt.Sprintf("%s 今日申购", item.Name),
+				Body:     fmt.Sprintf("申购代码%s，发行价%.2f元，申购上限%.0f股", item.ApplyCode, item.IssuePrice, item.ApplyUpper),
+			})
+		}
+	})
+	a.ipoService.Start(ctx)
+
+	// 快讯 AI 摘要：按配置间隔汇总新增快讯
+	a.newsService.SetDigestLLMProvider(a.createLLM)
+	a.newsService.StartDigest(ctx, func(digest services.TelegraphDigest) {
+		runtime.EventsEmit(a.ctx, services.EventTelegraphDigest, digest)
+		a.notifications.Notify(models.Notification{
+			Category: models.NotificationBriefing,
+			Title:    "快讯摘要",
+			Body:     digest.Summary,
+		})
+	})
+
+	// 历史分时归档：交易日收盘后保存自选股当日分时
+	a.intradayArchive.Start(ctx)
+
+	// 内存守护：堆内存超过上限时收缩行情缓存并归还系统
+	a.memoryGuard.Register("market", a.marketService.TrimCaches)
+	a.memoryGuard.Start(ctx)
+	supervisor.WatchLeaks(ctx)
+
+	// 配置热更新：设置页保存、云同步与外部编辑配置文件都会触发
+	a.configService.SetOnChange(a.onConfigChanged)
+	a.configService.Watch(ctx)
+
+	// 启用云同步时启动后同步一次，拉取其他设备上的修改
+	if cfg.Sync.Enabled {
+		go a.SyncNow()
+	}
 }
 
 // shutdown 应用关闭时调用
 func (a *App) shutdown(ctx context.Context) {
 	log.Info("应用正在关闭...")
+	a.hotkeys.Close()
+	a.detachedWindows.CloseAll()
+	if a.tray != nil {
+		a.tray.Stop()
+	}
 	if a.openClawServer != nil {
 		a.openClawServer.Stop()
 	}
+	if a.localAPIServer != nil {
+		a.localAPIServer.Stop()
+	}
+	// 取消根上下文：推送、AI 会话与定时任务随之退出
+	if a.rootCancel != nil {
+		a.rootCancel()
+	}
 	if a.marketPusher != nil {
 		a.marketPusher.Stop()
 	}
+	a.drainBackground()
+	a.newsService.CloseArchive()
+	a.intradayArchive.Close()
+	if a.coordinator != nil {
+		a.coordinator.Stop()
+	}
+	if a.memoryManager != nil {
+		a.memoryManager.Close()
+	}
+	a.applyEngineConfig(&models.EngineConfig{})
+	// 已下载的更新在退出时安装，下次启动即为新版本
+	if a.updateService != nil {
+		if err := a.updateService.ApplyPending(); err != nil {
+			log.Error("安装更新失败: %v", err)
+		}
+	}
 	logger.Close()
 }
 
+// shutdownDrainTimeout 退出时等待后台任务与写入完成的最长时间
+const shutdownDrainTimeout = 5 * time.Second
+
+// drainBackground 等待常驻任务退出与进行中的写入完成（有上限），再保存内存中的状态
+func (a *App) drainBackground() {
+	a.marketService.Close()
+	deadline := time.Now().Add(shutdownDrainTimeout)
+	if !supervisor.Default.Wait(time.Until(deadline)) {
+		log.Warn("等待后台任务退出超时: %v", supervisor.Default.Running())
+	}
+	if !services.WaitWrites(max(time.Until(deadline), 0)) {
+		log.Warn("等待数据写入超时")
+	}
+	if err := a.paperService.Flush(); err != nil {
+		log.Warn("保存模拟账户失败: %v", err)
+	}
+}
+
+// memoryPromptConfig 转换记忆摘要提示词配置
+func memoryPromptConfig(c models.MemoryPromptConfig) memory.PromptConfig {
+	return memory.PromptConfig{
+		SummaryLength:       c.SummaryLength,
+		FactLength:          c.FactLength,
+		MaxFacts:            c.MaxFacts,
+		KeyPointLength:      c.KeyPointLength,
+		SummarizeTemplate:   c.SummarizeTemplate,
+		ExtractTemplate:     c.ExtractTemplate,
+		KeyPointsTemplate:   c.KeyPointsTemplate,
+		ConsolidateTemplate: c.ConsolidateTemplate,
+	}
+}
+
 // Greet returns a greeting for the given name
 func (a *App) Greet(name string) string {
 	return "Hello " + name + ", It's show time!"
@@ -244,19 +611,47 @@ func (a *App) GetConfig() *models.AppConfig {
 	return a.configService.GetConfig()
 }
 
-// UpdateConfig 更新配置
+// UpdateConfig 更新配置，变更通过 onConfigChanged 应用到各服务
 func (a *App) UpdateConfig(config *models.AppConfig) string {
 	if err := a.configService.UpdateConfig(config); err != nil {
 		return err.Error()
 	}
+	return "success"
+}
+
+// onConfigChanged 配置变更（设置页保存、云同步拉取、外部编辑配置文件）后应用到各服务并通知前端
+func (a *App) onConfigChanged(old, config *models.AppConfig) {
+	log.Info("配置已变更，正在应用")
+	a.applyConfig(old, config)
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, "config:changed", config)
+	}
+}
+
+// applyConfig 将配置应用到代理、AI、推送等服务
+func (a *App) applyConfig(old, config *models.AppConfig) {
 	// 重新加载 MCP 配置
 	if a.mcpManager != nil && config.MCPServers != nil {
 		if err := a.mcpManager.LoadConfigs(config.MCPServers); err != nil {
 			log.Warn("MCP reload error: %v", err)
 		}
 	}
-	// 更新代理配置
+	i18n.SetLang(config.Language)
+	// 更新代理配置、上游限流规则与连接池参数
 	proxy.GetManager().SetConfig(&config.Proxy)
+	proxy.GetManager().SetRateLimits(config.RateLimits)
+	proxy.GetManager().SetConnPools(config.ConnPools)
+	// 更新 AI 费用预算
+	adk.GetUsageTracker().SetBudget(config.AIBudget)
+	adk.GetToolSandbox().SetConfig(config.ToolSandbox)
+	// 切换远程数据引擎
+	a.applyEngineConfig(&config.Engine)
+	// 更新大盘指数配置并立即推送
+	a.marketService.SetIndexCodes(config.MarketIndices)
+	if a.marketPusher != nil {
+		a.marketPusher.RefreshMarketIndices()
+		a.marketPusher.RefreshStockData()
+	}
 	// 更新记忆管理器的 LLM 配置
 	if a.meetingService != nil && config.Memory.AIConfigID != "" {
 		for i := range config.AIConfigs {
@@ -266,6 +661,20 @@ func (a *App) UpdateConfig(config *models.AppConfig) string {
 			}
 		}
 	}
+	// 更新记忆检索的向量模型与摘要提示词
+	if a.memoryManager != nil {
+		a.memoryManager.SetPrompts(memoryPromptConfig(config.Memory.Prompts))
+		var embedder memory.Embedder
+		if config.Memory.EmbeddingAIID != "" && config.Memory.EmbeddingModel != "" {
+			for i := range config.AIConfigs {
+				if config.AIConfigs[i].ID == config.Memory.EmbeddingAIID {
+					embedder = adk.NewModelFactory().CreateEmbedder(&config.AIConfigs[i], config.Memory.EmbeddingModel)
+					break
+				}
+			}
+		}
+		a.memoryManager.SetEmbedder(embedder)
+	}
 	// 更新 Moderator AI 配置
 	if a.meetingService != nil && config.ModeratorAIID != "" {
 		for i := range config.AIConfigs {
@@ -277,7 +686,40 @@ func (a *App) UpdateConfig(config *models.AppConfig) string {
 	}
 	// 更新 OpenClaw 服务配置（热更新）
 	a.applyOpenClawConfig(&config.OpenClaw)
-	return "success"
+	a.applyLocalAPIConfig(&config.LocalAPI)
+	if old == nil || old.Hotkeys != config.Hotkeys {
+		a.applyHotkeyConfig(&config.Hotkeys)
+	}
+	a.newsService.SetDisabledSources(config.News.DisabledSources)
+	a.newsService.SetAlertKeywords(config.News.AlertKeywords)
+	a.newsService.SetDigestConfig(config.News.Digest)
+	a.notifications.SetConfig(config.Notifications)
+	a.memoryGuard.SetLimit(config.MemoryLimitMB)
+	// 重试策略同样仅在配置变化时应用
+	if old == nil || !reflect.DeepEqual(old.Retry, config.Retry) {
+		a.applyRetryConfig(config.Retry)
+	}
+	// 推送频率仅在配置中的值变化时应用，避免覆盖运行时调整
+	if a.marketPusher != nil && config.PushIntervals != nil && (old == nil || old.PushIntervals == nil || *old.PushIntervals != *config.PushIntervals) {
+		if err := a.marketPusher.SetPushIntervals(*config.PushIntervals); err != nil {
+			log.Warn("推送频率配置无效: %v", err)
+		}
+	}
+	a.reloadPersonas()
+}
+
+// applyRetryConfig 应用行情请求重试策略，未配置或配置无效时使用默认策略
+func (a *App) applyRetryConfig(cfg *models.RetryConfig) {
+	policy := services.DefaultRetryPolicy
+	if cfg != nil {
+		p, err := services.RetryPolicyFromConfig(*cfg)
+		if err != nil {
+			log.Warn("重试策略配置无效，使用默认值: %v", err)
+		} else {
+			policy = p
+		}
+	}
+	a.marketService.SetRetryPolicy(policy)
 }
 
 // applyOpenClawConfig 应用 OpenClaw 配置变更
@@ -289,130 +731,1279 @@ func (a *App) applyOpenClawConfig(cfg *models.OpenClawConfig) {
 		a.openClawServer.Stop()
 		return
 	}
-	if cfg.Port <= 0 {
-		return
+	if cfg.Port <= 0 {
+		return
+	}
+	// 端口或密钥变更时重启
+	if a.openClawServer.IsRunning() {
+		if a.openClawServer.GetPort() != cfg.Port {
+			a.openClawServer.Restart(cfg.Port, cfg.APIKey)
+		}
+	} else {
+		a.openClawServer.Start(cfg.Port, cfg.APIKey)
+	}
+}
+
+// applyLocalAPIConfig 应用本地 REST API 配置变更，端口或令牌变更时重启
+func (a *App) applyLocalAPIConfig(cfg *models.LocalAPIConfig) {
+	if a.localAPIServer == nil {
+		return
+	}
+	if !cfg.Enabled || cfg.Port <= 0 {
+		a.localAPIServer.Stop()
+		return
+	}
+	var err error
+	if a.localAPIServer.IsRunning() {
+		if port, token := a.localAPIServer.Config(); port != cfg.Port || token != cfg.Token {
+			err = a.localAPIServer.Restart(cfg.Port, cfg.Token)
+		}
+	} else {
+		err = a.localAPIServer.Start(cfg.Port, cfg.Token)
+	}
+	if err != nil {
+		log.Warn("本地 API 服务启动失败: %v", err)
+	}
+}
+
+// applyHotkeyConfig 按配置重新注册全局快捷键，无效或注册失败的项记录日志，其余仍生效
+func (a *App) applyHotkeyConfig(cfg *models.HotkeyConfig) {
+	if !cfg.Enabled {
+		a.hotkeys.Close()
+		return
+	}
+	entries := []struct {
+		name    string
+		spec    string
+		handler func()
+	}{
+		{"显示/隐藏窗口", cfg.ShowHide, a.toggleWindow},
+		{"老板键", cfg.BossKey, a.toggleBossKey},
+		{"快速搜索", cfg.QuickSearch, a.quickSearch},
+	}
+	var bindings []hotkey.Binding
+	for _, e := range entries {
+		if e.spec == "" {
+			continue
+		}
+		key, err := hotkey.Parse(e.spec)
+		if err != nil {
+			log.Warn("%s: %v", e.name, err)
+			continue
+		}
+		bindings = append(bindings, hotkey.Binding{Name: e.name, Key: key, Handler: e.handler})
+	}
+	if err := a.hotkeys.Set(bindings); err != nil {
+		log.Warn("全局快捷键注册失败: %v", err)
+	}
+}
+
+// localAPISource 本地 API 数据来源，与桌面端共用同一份服务数据
+type localAPISource struct {
+	a *App
+}
+
+func (s localAPISource) Quotes(ctx context.Context, codes []string) ([]models.Stock, error) {
+	return s.a.marketService.GetStockRealTimeData(ctx, codes...)
+}
+
+func (s localAPISource) KLines(ctx context.Context, code, period string, days int) ([]models.KLineData, error) {
+	return s.a.marketService.GetKLineData(ctx, code, period, days)
+}
+
+func (s localAPISource) Watchlist() models.WatchlistGroups {
+	return s.a.configService.GetWatchlistGroups()
+}
+
+func (s localAPISource) WatchlistStocks() []models.Stock {
+	return s.a.configService.GetWatchlist()
+}
+
+func (s localAPISource) PriceTargets() []models.PriceTarget {
+	return s.a.configService.GetPriceTargets()
+}
+
+func (s localAPISource) ConditionOrders() []models.ConditionOrder {
+	return s.a.conditionService.List()
+}
+
+func (s localAPISource) Notifications(filter models.NotificationFilter) []models.Notification {
+	return s.a.notifications.List(filter)
+}
+
+// applyEngineConfig 应用远程数据引擎配置，关闭时恢复直连上游
+func (a *App) applyEngineConfig(cfg *models.EngineConfig) {
+	if !cfg.Enabled || cfg.Address == "" {
+		a.setEngineClient(nil, *cfg)
+		return
+	}
+
+	client, err := engine.NewClient(*cfg)
+	if err != nil {
+		log.Warn("连接远程数据引擎失败: %v", err)
+		a.setEngineClient(nil, *cfg)
+		return
+	}
+	a.setEngineClient(client, *cfg)
+}
+
+// setEngineClient 替换当前引擎客户端，client 为 nil 时恢复直连上游
+func (a *App) setEngineClient(client *engine.Client, cfg models.EngineConfig) {
+	a.engineMu.Lock()
+	defer a.engineMu.Unlock()
+
+	if a.engineClient != nil {
+		a.marketService.SetRemoteSource(nil)
+		a.engineClient.Close()
+	}
+	a.engineClient = client
+	a.engineCfg = cfg
+	if client == nil {
+		return
+	}
+	a.marketService.SetRemoteSource(client)
+	log.Info("行情数据已切换到远程引擎 %s", cfg.Address)
+	go a.syncEngineRecordings()
+}
+
+// currentEngine 获取当前引擎客户端，未启用时为 nil
+func (a *App) currentEngine() *engine.Client {
+	a.engineMu.Lock()
+	defer a.engineMu.Unlock()
+	return a.engineClient
+}
+
+// syncEngineRecordings 把自选股同步给远程引擎，由引擎录制收盘分时
+func (a *App) syncEngineRecordings() {
+	client := a.currentEngine()
+	if client == nil {
+		return
+	}
+	watchlist := a.configService.GetWatchlist()
+	codes := make([]string, 0, len(watchlist))
+	for _, stock := range watchlist {
+		codes = append(codes, stock.Symbol)
+	}
+	ctx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+	if _, err := client.SetRecordCodes(ctx, codes); err != nil {
+		log.Warn("同步引擎录制列表失败: %v", err)
+	}
+}
+
+// switchEngine 切换行情数据源，远程引擎需连通后才切换，失败时保持当前数据源
+func (a *App) switchEngine(cfg models.EngineConfig) error {
+	if !cfg.Enabled {
+		a.setEngineClient(nil, cfg)
+		return nil
+	}
+	client, err := engine.NewClient(cfg)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(a.ctx, 3*time.Second)
+	defer cancel()
+	if _, err := client.Ping(ctx); err != nil {
+		client.Close()
+		return i18n.Errorf("error.engine.unavailable", cfg.Address, err)
+	}
+	a.setEngineClient(client, cfg)
+	return nil
+}
+
+// runtimeStep 运行时设置的单个可回滚步骤
+type runtimeStep struct {
+	name     string
+	apply    func() error
+	rollback func()
+}
+
+// ApplyRuntimeSettings 运行时调整日志级别（全局与按模块）、行情数据源、盘中记录、推送频率与暂停推送，无需重启
+// 先整体校验，再逐项应用；任一项失败则按相反顺序回滚已应用的项，盘中记录数据不受影响
+// 设置仅对本次运行生效，不写入配置文件
+func (a *App) ApplyRuntimeSettings(settings models.RuntimeSettings) models.RuntimeSettingsResult {
+	result := models.RuntimeSettingsResult{Applied: []string{}, RolledBack: []string{}}
+	if a.marketPusher == nil {
+		result.Error = "推送服务未初始化"
+		return result
+	}
+
+	steps, err := a.buildRuntimeSteps(settings)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	for i, step := range steps {
+		if err := step.apply(); err != nil {
+			log.Error("应用运行时设置 %s 失败: %v", step.name, err)
+			a.emitError("ApplyRuntimeSettings", err)
+			for j := i - 1; j >= 0; j-- {
+				steps[j].rollback()
+				result.RolledBack = append(result.RolledBack, steps[j].name)
+			}
+			result.Applied = []string{}
+			result.Error = fmt.Sprintf("%s: %v", step.name, err)
+			return result
+		}
+		result.Applied = append(result.Applied, step.name)
+	}
+	result.Success = true
+	log.Info("运行时设置已应用: %v", result.Applied)
+	return result
+}
+
+// buildRuntimeSteps 校验运行时设置并生成应用步骤
+func (a *App) buildRuntimeSteps(settings models.RuntimeSettings) ([]runtimeStep, error) {
+	var steps []runtimeStep
+
+	if settings.LogLevel != "" {
+		level, err := logger.ParseLevel(settings.LogLevel)
+		if err != nil {
+			return nil, err
+		}
+		prev := logger.GetGlobalLevel()
+		steps = append(steps, runtimeStep{
+			name:     "logLevel",
+			apply:    func() error { logger.SetGlobalLevel(level); return nil },
+			rollback: func() { logger.SetGlobalLevel(prev) },
+		})
+	}
+
+	if settings.ModuleLevels != nil {
+		levels, err := logger.ParseModuleLevels(*settings.ModuleLevels)
+		if err != nil {
+			return nil, err
+		}
+		prev := logger.GetModuleLevels()
+		steps = append(steps, runtimeStep{
+			name:     "moduleLevels",
+			apply:    func() error { logger.SetModuleLevels(levels); return nil },
+			rollback: func() { logger.SetModuleLevels(prev) },
+		})
+	}
+
+	if settings.Recording != nil {
+		enabled := *settings.Recording
+		prev := a.marketPusher.RecordingEnabled()
+		steps = append(steps, runtimeStep{
+			name:     "recording",
+			apply:    func() error { a.marketPusher.SetRecording(enabled); return nil },
+			rollback: func() { a.marketPusher.SetRecording(prev) },
+		})
+	}
+
+	if settings.PushIntervals != nil {
+		iv := *settings.PushIntervals
+		if err := services.ValidatePushIntervals(iv); err != nil {
+			return nil, err
+		}
+		prev := a.marketPusher.GetPushIntervals()
+		steps = append(steps, runtimeStep{
+			name:     "pushIntervals",
+			apply:    func() error { return a.marketPusher.SetPushIntervals(iv) },
+			rollback: func() { a.marketPusher.SetPushIntervals(prev) },
+		})
+	}
+
+	if settings.Retry != nil {
+		policy, err := services.RetryPolicyFromConfig(*settings.Retry)
+		if err != nil {
+			return nil, err
+		}
+		prev := a.marketService.GetRetryPolicy()
+		steps = append(steps, runtimeStep{
+			name:     "retry",
+			apply:    func() error { a.marketService.SetRetryPolicy(policy); return nil },
+			rollback: func() { a.marketService.SetRetryPolicy(prev) },
+		})
+	}
+
+	if settings.PushPaused != nil {
+		paused := *settings.PushPaused
+		prev := a.marketPusher.Paused()
+		steps = append(steps, runtimeStep{
+			name:     "pushPaused",
+			apply:    func() error { a.marketPusher.SetPaused(paused); return nil },
+			rollback: func() { a.marketPusher.SetPaused(prev) },
+		})
+	}
+
+	// 数据源切换需要网络校验，放在最后以便失败时回滚前面的本地设置
+	if settings.Engine != nil {
+		cfg := *settings.Engine
+		if cfg.Enabled && cfg.Address == "" {
+			return nil, i18n.Errorf("error.engine.address_required")
+		}
+		a.engineMu.Lock()
+		prev := a.engineCfg
+		a.engineMu.Unlock()
+		steps = append(steps, runtimeStep{
+			name:     "engine",
+			apply:    func() error { return a.switchEngine(cfg) },
+			rollback: func() { a.applyEngineConfig(&prev) },
+		})
+	}
+
+	if len(steps) == 0 {
+		return nil, i18n.Errorf("error.settings.empty")
+	}
+	return steps, nil
+}
+
+// GetEngineStatus 获取远程数据引擎连接状态
+func (a *App) GetEngineStatus() map[string]any {
+	client := a.currentEngine()
+
+	if client == nil {
+		return map[string]any{"enabled": false}
+	}
+	ctx, cancel := context.WithTimeout(a.ctx, 3*time.Second)
+	defer cancel()
+	version, err := client.Ping(ctx)
+	if err != nil {
+		return map[string]any{"enabled": true, "address": client.Addr(), "connected": false, "error": err.Error()}
+	}
+	return map[string]any{"enabled": true, "address": client.Addr(), "connected": true, "version": version}
+}
+
+// SyncNow 立即与 WebDAV 同步配置、自选股、分组、目标价与条件单
+// 拉取到远端修改时配置由 Reload 触发变更回调重新应用，这里重新加载条件单并刷新推送订阅，完成后推送 sync:done 事件
+func (a *App) SyncNow() models.SyncResult {
+	result, err := a.syncService.Sync(a.ctx)
+	if err != nil {
+		log.Error("云同步失败: %v", err)
+		a.emitError("SyncNow", err)
+		result.Error = err.Error()
+	}
+	if len(result.Pulled) > 0 {
+		if err := a.conditionService.Reload(); err != nil && !os.IsNotExist(err) {
+			log.Error("重新加载条件单失败: %v", err)
+		}
+		a.syncGroupSubscriptions(a.configService.GetWatchlistGroups().Active)
+	}
+	runtime.EventsEmit(a.ctx, "sync:done", result)
+	return result
+}
+
+// ExportSettings 导出设置备份（配置、自选股、分组、目标价与条件单），不含 API Key、令牌与密码
+// 返回 success，用户取消时返回空字符串
+func (a *App) ExportSettings() string {
+	data, err := a.configService.ExportSettings(Version)
+	if err != nil {
+		return err.Error()
+	}
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "导出设置",
+		DefaultFilename: "jcp-settings-" + time.Now().Format("20060102") + ".json",
+		Filters:         []runtime.FileFilter{{DisplayName: "设置备份 (*.json)", Pattern: "*.json"}},
+	})
+	if err != nil {
+		return err.Error()
+	}
+	if path == "" {
+		return ""
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		log.Error("导出设置失败: %v", err)
+		return err.Error()
+	}
+	return "success"
+}
+
+// ImportSettings 从备份文件导入设置，覆盖本机配置、自选股、分组与目标价
+// 返回 success，用户取消时返回空字符串
+func (a *App) ImportSettings() string {
+	path, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title:   "导入设置",
+		Filters: []runtime.FileFilter{{DisplayName: "设置备份 (*.json)", Pattern: "*.json"}},
+	})
+	if err != nil {
+		return err.Error()
+	}
+	if path == "" {
+		return ""
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err.Error()
+	}
+	if err := a.configService.ImportSettings(data); err != nil {
+		log.Error("导入设置失败: %v", err)
+		return err.Error()
+	}
+	log.Info("已导入设置: %s", filepath.Base(path))
+	if err := a.conditionService.Reload(); err != nil && !os.IsNotExist(err) {
+		log.Error("重新加载条件单失败: %v", err)
+	}
+	a.syncGroupSubscriptions(a.configService.GetWatchlistGroups().Active)
+	return "success"
+}
+
+// ResetToDefaults 恢复默认配置（自选股与目标价保留），重置前自动备份当前设置
+// 返回备份文件路径
+func (a *App) ResetToDefaults() models.ReportResult {
+	path, err := a.configService.ResetToDefaults()
+	if err != nil {
+		log.Error("恢复默认设置失败: %v", err)
+		return models.ReportResult{Error: err.Error()}
+	}
+	log.Info("已恢复默认设置，原设置备份到 %s", path)
+	return models.ReportResult{Path: path}
+}
+
+// PlacePaperOrder 模拟交易下单
+func (a *App) PlacePaperOrder(req models.PaperOrderRequest) models.PaperOrder {
+	order, err := a.paperService.PlaceOrder(a.ctx, req)
+	if err != nil {
+		log.Warn("模拟下单失败: %v", err)
+		if order.Status == "" {
+			order.Status = models.PaperStatusRejected
+			order.Reason = err.Error()
+		}
+	}
+	return order
+}
+
+// CancelPaperOrder 模拟交易撤单
+func (a *App) CancelPaperOrder(id string) string {
+	if err := a.paperService.CancelOrder(id); err != nil {
+		return err.Error()
+	}
+	return "success"
+}
+
+// GetPaperAccount 获取模拟账户资金、持仓与每日盈亏
+func (a *App) GetPaperAccount() models.PaperAccount {
+	return a.paperService.GetAccount(a.ctx)
+}
+
+// GetPaperOrders 获取模拟委托记录（最新在前）
+func (a *App) GetPaperOrders() []models.PaperOrder {
+	return a.paperService.GetOrders()
+}
+
+// ResetPaperAccount 重置模拟账户，initialCash <= 0 时使用默认 100 万
+func (a *App) ResetPaperAccount(initialCash float64) string {
+	if err := a.paperService.Reset(initialCash); err != nil {
+		return err.Error()
+	}
+	return "success"
+}
+
+// CreateConditionOrder 创建条件单
+func (a *App) CreateConditionOrder(req models.ConditionOrderRequest) models.ConditionOrder {
+	order, err := a.conditionService.Create(req)
+	if err != nil {
+		log.Warn("创建条件单失败: %v", err)
+		order.Status = models.ConditionFailed
+		order.Result = err.Error()
+	}
+	return order
+}
+
+// CancelConditionOrder 撤销未触发的条件单
+func (a *App) CancelConditionOrder(id string) string {
+	if err := a.conditionService.Cancel(id); err != nil {
+		return err.Error()
+	}
+	return "success"
+}
+
+// DeleteConditionOrder 删除条件单记录
+func (a *App) DeleteConditionOrder(id string) string {
+	if err := a.conditionService.Delete(id); err != nil {
+		return err.Error()
+	}
+	return "success"
+}
+
+// GetConditionOrders 获取条件单列表（最新在前）
+func (a *App) GetConditionOrders() []models.ConditionOrder {
+	return a.conditionService.List()
+}
+
+// RunBacktest 使用历史K线回测规则策略（均线交叉 / 突破 / RSI 阈值）
+func (a *App) RunBacktest(req models.BacktestRequest) models.BacktestResult {
+	if req.Period == "" {
+		req.Period = "1d"
+	}
+	if req.Days <= 0 {
+		req.Days = 500
+	}
+	result := models.BacktestResult{Symbol: req.Symbol, Rule: req.Rule, Trades: []models.BacktestTrade{}, Equity: []models.EquityPoint{}}
+	bars, err := a.marketService.GetKLineData(a.ctx, req.Symbol, req.Period, req.Days)
+	if err != nil {
+		log.Error("回测获取K线失败: %v", err)
+		a.emitError("RunBacktest", err)
+		result.Error = err.Error()
+		return result
+	}
+	result, err = backtest.Run(bars, req.Rule, req.InitialCash)
+	result.Symbol = req.Symbol
+	if err != nil {
+		log.Warn("回测失败: %v", err)
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// GetInstanceRole 获取当前实例在多实例协调中的角色（leader / follower）
+func (a *App) GetInstanceRole() string {
+	if a.coordinator == nil {
+		return ""
+	}
+	return string(a.coordinator.Role())
+}
+
+// GetOpenClawStatus 获取 OpenClaw 服务状态
+func (a *App) GetOpenClawStatus() map[string]any {
+	if a.openClawServer == nil {
+		return map[string]any{"running": false}
+	}
+	return map[string]any{
+		"running": a.openClawServer.IsRunning(),
+		"port":    a.openClawServer.GetPort(),
+	}
+}
+
+// GetLocalAPIStatus 获取本地 REST API 服务状态
+func (a *App) GetLocalAPIStatus() map[string]any {
+	if a.localAPIServer == nil {
+		return map[string]any{"running": false}
+	}
+	port, _ := a.localAPIServer.Config()
+	return map[string]any{
+		"running": a.localAPIServer.IsRunning(),
+		"port":    port,
+	}
+}
+
+// mergeRealtimeStock 合并实时行情字段，保留本地静态字段
+func (a *App) mergeRealtimeStock(base models.Stock, rt models.Stock) models.Stock {
+	merged := base
+	if rt.Name != "" {
+		merged.Name = rt.Name
+	}
+	merged.Price = rt.Price
+	merged.Change = rt.Change
+	merged.ChangePercent = rt.ChangePercent
+	merged.Volume = rt.Volume
+	merged.Amount = rt.Amount
+	merged.Open = rt.Open
+	merged.High = rt.High
+	merged.Low = rt.Low
+	merged.PreClose = rt.PreClose
+	return merged
+}
+
+// GetWatchlist 获取自选股列表（附带实时行情）
+func (a *App) GetWatchlist() []models.Stock {
+	list := a.configService.GetWatchlist()
+	if len(list) == 0 {
+		return list
+	}
+
+	// 收集所有股票代码，拉一次实时行情
+	codes := make([]string, len(list))
+	for i, s := range list {
+		codes[i] = s.Symbol
+	}
+	realtime, err := a.marketService.GetStockRealTimeData(a.ctx, codes...)
+	if err != nil || len(realtime) == 0 {
+		return list
+	}
+
+	// 用实时数据填充
+	rtMap := make(map[string]models.Stock, len(realtime))
+	for _, s := range realtime {
+		rtMap[s.Symbol] = s
+	}
+	result := make([]models.Stock, len(list))
+	for i, s := range list {
+		if rt, ok := rtMap[s.Symbol]; ok {
+			result[i] = a.mergeRealtimeStock(s, rt)
+		} else {
+			result[i] = s
+		}
+	}
+	return result
+}
+
+// GetPortfolioExposure 获取持仓组合的穿透敞口（ETF按成分股拆分到个股与行业）
+func (a *App) GetPortfolioExposure() *models.PortfolioExposure {
+	return a.lookThroughSvc.Analyze(a.portfolioHoldings())
+}
+
+// GetPortfolioAttribution 获取持仓组合当日收益归因报告（个股与行业贡献、ETF穿透后的真实集中度）
+func (a *App) GetPortfolioAttribution() *models.PortfolioAttribution {
+	return a.lookThroughSvc.Attribution(a.portfolioHoldings())
+}
+
+// portfolioHoldings 自选股中填写了持仓的股票
+func (a *App) portfolioHoldings() []models.PortfolioHolding {
+	var holdings []models.PortfolioHolding
+	for _, s := range a.GetWatchlist() {
+		position := a.sessionService.GetPosition(s.Symbol)
+		if position == nil || position.Shares <= 0 {
+			continue
+		}
+		holdings = append(holdings, models.PortfolioHolding{
+			Symbol:        s.Symbol,
+			Name:          s.Name,
+			Shares:        position.Shares,
+			Price:         s.Price,
+			ChangePercent: s.ChangePercent,
+		})
+	}
+	return holdings
+}
+
+// CalculatePositionSize 根据账户资金、单笔风险比例、买入价与止损价计算建议仓位
+func (a *App) CalculatePositionSize(req models.PositionSizeRequest) *models.PositionSizeResult {
+	result, err := a.riskService.SuggestPositionSize(req)
+	if err != nil {
+		a.emitError("CalculatePositionSize", err)
+		return nil
+	}
+	return result
+}
+
+// CheckPortfolioRisk 检查当前持仓的总仓位、单只与行业集中度及止损设置
+// 持仓取自选股中填写了持仓的股票，止损价取跟踪线，行业按 ETF 穿透计算
+func (a *App) CheckPortfolioRisk(accountSize float64, limits models.RiskLimits) *models.PortfolioRisk {
+	stops := make(map[string]float64)
+	for _, t := range a.configService.GetPriceTargets() {
+		stops[t.Symbol] = t.StopPrice
+	}
+	var holdings []models.HoldingRisk
+	for _, s := range a.GetWatchlist() {
+		position := a.sessionService.GetPosition(s.Symbol)
+		if position == nil || position.Shares <= 0 {
+			continue
+		}
+		holdings = append(holdings, models.HoldingRisk{
+			Symbol:    s.Symbol,
+			Name:      s.Name,
+			Shares:    position.Shares,
+			Price:     s.Price,
+			CostPrice: position.CostPrice,
+			StopPrice: stops[s.Symbol],
+		})
+	}
+	var exposure *models.PortfolioExposure
+	if len(holdings) > 0 {
+		portfolio := make([]models.PortfolioHolding, len(holdings))
+		for i, h := range holdings {
+			portfolio[i] = models.PortfolioHolding{Symbol: h.Symbol, Name: h.Name, Shares: h.Shares, Price: h.Price}
+		}
+		exposure = a.lookThroughSvc.Analyze(portfolio)
+	}
+	risk, err := a.riskService.CheckPortfolio(accountSize, limits, holdings, exposure)
+	if err != nil {
+		a.emitError("CheckPortfolioRisk", err)
+		return nil
+	}
+	return risk
+}
+
+// GetETFConstituents 获取ETF最新披露的重仓股
+func (a *App) GetETFConstituents(symbol string) []models.ETFConstituent {
+	items, err := a.lookThroughSvc.GetETFConstituents(symbol)
+	if err != nil {
+		log.Error("获取ETF成分股失败: %v", err)
+		a.emitError("GetETFConstituents", err)
+		return []models.ETFConstituent{}
+	}
+	return items
+}
+
+// SetWatchlistSort 设置自选股推送排序（field 为空时保持自选顺序，order: asc/desc）
+func (a *App) SetWatchlistSort(field, order string) string {
+	sortCfg := a.configService.GetConfig().WatchlistSort
+	sortCfg.Field = field
+	sortCfg.Order = order
+	if err := a.configService.SetWatchlistSort(sortCfg); err != nil {
+		log.Error("设置自选股排序失败: %v", err)
+		return err.Error()
+	}
+	if a.marketPusher != nil {
+		a.marketPusher.RefreshStockData()
+	}
+	return "success"
+}
+
+// AddToWatchlist 添加自选股
+func (a *App) AddToWatchlist(stock models.Stock) string {
+	if err := a.configService.AddToWatchlist(stock); err != nil {
+		return err.Error()
+	}
+	// 新股票加入"自选"分组，当前分组为自选时同步添加到推送订阅
+	if a.configService.GetWatchlistGroups().Active == models.WatchlistGroupDefault {
+		a.marketPusher.AddSubscription(stock.Symbol)
+	}
+	go a.syncEngineRecordings()
+	return "success"
+}
+
+// RemoveFromWatchlist 移除自选股
+func (a *App) RemoveFromWatchlist(symbol string) string {
+	if err := a.configService.RemoveFromWatchlist(symbol); err != nil {
+		return err.Error()
+	}
+	// 同步移除推送订阅
+	a.marketPusher.RemoveSubscription(symbol)
+	go a.syncEngineRecordings()
+	// 清空该股票的聊天记录
+	a.sessionService.ClearMessages(symbol)
+	// 清空该股票的图表画线
+	if err := a.drawingService.ClearDrawings(symbol, ""); err != nil {
+		log.Error("clear drawings error: %v", err)
+	}
+	// 同步清除该股票的记忆
+	if a.memoryManager != nil {
+		if err := a.memoryManager.DeleteMemory(symbol); err != nil {
+			log.Error("delete memory error: %v", err)
+		}
+	}
+	return "success"
+}
+
+// GetWatchlistGroups 获取自选分组、当前分组与标签备注
+func (a *App) GetWatchlistGroups() models.WatchlistGroups {
+	return a.configService.GetWatchlistGroups()
+}
+
+// GetGroupStocks 按分组顺序获取自选股
+func (a *App) GetGroupStocks(groupID string) []models.Stock {
+	return a.configService.GetGroupStocks(groupID)
+}
+
+// SetActiveWatchlistGroup 切换当前自选分组，推送订阅随之切换
+func (a *App) SetActiveWatchlistGroup(groupID string) string {
+	if err := a.configService.SetActiveGroup(groupID); err != nil {
+		return err.Error()
+	}
+	a.syncGroupSubscriptions(groupID)
+	return "success"
+}
+
+// CreateWatchlistGroup 新建自选分组
+func (a *App) CreateWatchlistGroup(name string) *models.WatchlistGroup {
+	group, err := a.configService.CreateWatchlistGroup(name)
+	if err != nil {
+		log.Error("新建自选分组失败: %v", err)
+		return nil
+	}
+	return &group
+}
+
+// RenameWatchlistGroup 重命名自选分组
+func (a *App) RenameWatchlistGroup(groupID, name string) string {
+	if err := a.configService.RenameWatchlistGroup(groupID, name); err != nil {
+		return err.Error()
+	}
+	return "success"
+}
+
+// DeleteWatchlistGroup 删除自选分组（删除当前分组时回到"自选"）
+func (a *App) DeleteWatchlistGroup(groupID string) string {
+	active := a.configService.GetWatchlistGroups().Active
+	if err := a.configService.DeleteWatchlistGroup(groupID); err != nil {
+		return err.Error()
+	}
+	if active == groupID {
+		a.syncGroupSubscriptions(models.WatchlistGroupDefault)
+	}
+	return "success"
+}
+
+// AddToWatchlistGroup 将自选股加入分组
+func (a *App) AddToWatchlistGroup(groupID, symbol string) string {
+	if err := a.configService.AddToGroup(groupID, symbol); err != nil {
+		return err.Error()
+	}
+	a.syncGroupSubscriptions(groupID)
+	return "success"
+}
+
+// RemoveFromWatchlistGroup 将股票移出分组
+func (a *App) RemoveFromWatchlistGroup(groupID, symbol string) string {
+	if err := a.configService.RemoveFromGroup(groupID, symbol); err != nil {
+		return err.Error()
+	}
+	a.syncGroupSubscriptions(groupID)
+	return "success"
+}
+
+// ReorderWatchlistGroup 保存分组内拖拽排序
+func (a *App) ReorderWatchlistGroup(groupID string, symbols []string) string {
+	if err := a.configService.ReorderGroup(groupID, symbols); err != nil {
+		return err.Error()
+	}
+	a.syncGroupSubscriptions(groupID)
+	return "success"
+}
+
+// SetStockMeta 设置自选股标签与备注
+func (a *App) SetStockMeta(symbol string, tags []string, note string) string {
+	if err := a.configService.SetStockMeta(symbol, tags, note); err != nil {
+		return err.Error()
+	}
+	return "success"
+}
+
+// ImportWatchlist 从文件导入自选股到指定分组
+// format: auto/txt/csv/ths/tdx，auto 按扩展名识别（.blk 为通达信）
+func (a *App) ImportWatchlist(groupID, format string) models.WatchlistImportResult {
+	path, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "导入自选股",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "自选股文件 (*.txt;*.csv;*.blk)", Pattern: "*.txt;*.csv;*.blk"},
+		},
+	})
+	if err != nil {
+		return models.WatchlistImportResult{Error: err.Error()}
+	}
+	if path == "" {
+		return models.WatchlistImportResult{}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return models.WatchlistImportResult{Error: err.Error()}
+	}
+	symbols, invalid := services.ParseWatchlistFile(path, data, format)
+	result, err := a.configService.ImportWatchlist(groupID, symbols)
+	result.Invalid = invalid
+	if err != nil {
+		log.Error("导入自选股失败: %v", err)
+		result.Error = err.Error()
+	}
+	log.Info("导入自选股 %s: 新增%d 已存在%d 无效%d", filepath.Base(path), len(result.Imported), len(result.Skipped), len(invalid))
+
+	// 导入的股票可能属于当前分组，按当前分组刷新订阅
+	a.syncGroupSubscriptions(a.configService.GetWatchlistGroups().Active)
+	go a.syncEngineRecordings()
+	return result
+}
+
+// ExportWatchlist 导出指定分组的自选股，format: txt/csv/ths/tdx
+// 返回 success，用户取消时返回空字符串
+func (a *App) ExportWatchlist(groupID, format string) string {
+	ext, ok := services.WatchlistFormatExt[format]
+	if !ok {
+		return "不支持的导出格式: " + format
+	}
+	data, err := services.FormatWatchlistFile(a.configService.GetGroupStocks(groupID), a.configService.GetWatchlistGroups().Meta, format)
+	if err != nil {
+		return err.Error()
+	}
+
+	filename := "自选股" + ext
+	if format == services.WatchlistFormatTDX {
+		filename = "zxg.blk"
+	}
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "导出自选股",
+		DefaultFilename: filename,
+	})
+	if err != nil {
+		return err.Error()
+	}
+	if path == "" {
+		return ""
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		log.Error("导出自选股失败: %v", err)
+		return err.Error()
+	}
+	return "success"
+}
+
+// ExportQuotes 导出当前分组自选股实时行情（csv / xlsx）
+func (a *App) ExportQuotes(format string) string {
+	table, err := a.exportService.QuotesTable(a.ctx)
+	if err != nil {
+		log.Error("导出行情失败: %v", err)
+		return err.Error()
+	}
+	return a.saveExportTable("导出自选行情", "自选行情_"+time.Now().Format("20060102"), table, format)
+}
+
+// ExportKLines 导出K线数据（csv / xlsx）
+func (a *App) ExportKLines(code, period string, days int, format string) string {
+	table, err := a.exportService.KLineTable(a.ctx, code, period, days)
+	if err != nil {
+		log.Error("导出K线失败: %v", err)
+		return err.Error()
+	}
+	return a.saveExportTable("导出K线", fmt.Sprintf("%s_%s_K线", code, period), table, format)
+}
+
+// ExportTransactions 导出模拟交易成交记录（csv / xlsx）
+func (a *App) ExportTransactions(format string) string {
+	return a.saveExportTable("导出成交记录", "成交记录_"+time.Now().Format("20060102"), a.exportService.TransactionsTable(), format)
+}
+
+// GetJournalEntries 获取交易日志，按记录日期倒序
+func (a *App) GetJournalEntries(filter models.JournalFilter) []models.JournalEntry {
+	return a.journalService.List(filter)
+}
+
+// SaveJournalEntry 新增或更新交易日志（ID 为空时新增），返回保存后的条目
+func (a *App) SaveJournalEntry(entry models.JournalEntry) models.JournalEntry {
+	saved, err := a.journalService.Save(entry)
+	if err != nil {
+		log.Error("保存交易日志失败: %v", err)
+		a.emitError("SaveJournalEntry", err)
+	}
+	return saved
+}
+
+// DeleteJournalEntry 删除交易日志
+func (a *App) DeleteJournalEntry(id string) string {
+	if err := a.journalService.Delete(id); err != nil {
+		return err.Error()
+	}
+	return "success"
+}
+
+// GetJournalTags 获取交易日志已使用的标签，按使用次数降序
+func (a *App) GetJournalTags() []string {
+	return a.journalService.Tags()
+}
+
+// GetJournalStats 获取交易日志统计：胜率、盈亏与平均持仓天数（整体及按标签、情绪）
+func (a *App) GetJournalStats(filter models.JournalFilter) models.JournalStats {
+	return a.journalService.Stats(filter)
+}
+
+// ExportJournal 导出交易日志（csv / xlsx）
+func (a *App) ExportJournal(filter models.JournalFilter, format string) string {
+	return a.saveExportTable("导出交易日志", "交易日志_"+time.Now().Format("20060102"), a.journalService.Table(filter), format)
+}
+
+// saveExportTable 编码表格并通过保存对话框写入文件，用户取消时返回空字符串
+func (a *App) saveExportTable(title, name string, table services.ExportTable, format string) string {
+	data, err := services.EncodeTable(table, format)
+	if err != nil {
+		return err.Error()
+	}
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           title,
+		DefaultFilename: name + "." + format,
+	})
+	if err != nil {
+		return err.Error()
+	}
+	if path == "" {
+		return ""
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		log.Error("%s失败: %v", title, err)
+		return err.Error()
+	}
+	return "success"
+}
+
+// GenerateStockReport 将股票最近一次 AI 讨论结论与关键行情生成报告，format 为 md 或 pdf
+func (a *App) GenerateStockReport(code, format string) models.ReportResult {
+	path, err := a.reportService.Generate(a.ctx, code, format)
+	if err != nil {
+		log.Error("生成分析报告失败: %v", err)
+		return models.ReportResult{Error: err.Error()}
 	}
-	// 端口或密钥变更时重启
-	if a.openClawServer.IsRunning() {
-		if a.openClawServer.GetPort() != cfg.Port {
-			a.openClawServer.Restart(cfg.Port, cfg.APIKey)
+	return models.ReportResult{Path: path}
+}
+
+// observedSymbols 行情观察需要额外拉取的代码：条件单标的与自选股（涨跌停提醒）
+func (a *App) observedSymbols() []string {
+	codes := a.conditionService.ActiveSymbols()
+	for _, st := range a.configService.GetWatchlist() {
+		if !slices.Contains(codes, st.Symbol) {
+			codes = append(codes, st.Symbol)
 		}
-	} else {
-		a.openClawServer.Start(cfg.Port, cfg.APIKey)
 	}
+	return codes
 }
 
-// GetOpenClawStatus 获取 OpenClaw 服务状态
-func (a *App) GetOpenClawStatus() map[string]any {
-	if a.openClawServer == nil {
-		return map[string]any{"running": false}
+// observeQuotes 每轮行情回调：评估条件单，并对自选股新封涨停/跌停发出通知
+func (a *App) observeQuotes(ctx context.Context, stocks []models.Stock) {
+	a.conditionService.Evaluate(ctx, stocks)
+
+	watched := make(map[string]bool)
+	for _, st := range a.configService.GetWatchlist() {
+		watched[st.Symbol] = true
 	}
-	return map[string]any{
-		"running": a.openClawServer.IsRunning(),
-		"port":    a.openClawServer.GetPort(),
+	var own []models.Stock
+	for _, st := range stocks {
+		if watched[st.Symbol] {
+			own = append(own, st)
+		}
+	}
+	for _, ev := range a.limitWatcher.Observe(own) {
+		kind, title := models.NotificationLimitDown, "跌停："
+		if ev.Up {
+			kind, title = models.NotificationLimitUp, "涨停："
+		}
+		a.notifications.Notify(models.Notification{
+			Category: models.NotificationLimit,
+			Kind:     kind,
+			Title:    title + cmp.Or(ev.Stock.Name, ev.Stock.Symbol),
+			Body:     fmt.Sprintf("%.2f（%+.2f%%）", ev.Stock.Price, ev.Stock.ChangePercent),
+			Symbol:   ev.Stock.Symbol,
+		})
 	}
 }
 
-// mergeRealtimeStock 合并实时行情字段，保留本地静态字段
-func (a *App) mergeRealtimeStock(base models.Stock, rt models.Stock) models.Stock {
-	merged := base
-	if rt.Name != "" {
-		merged.Name = rt.Name
+// PreviewNotificationSound 试听提示音，key 为分类或子类型（price/news/briefing/limit_up/limit_down）
+func (a *App) PreviewNotificationSound(key string) string {
+	if err := a.notifications.PreviewSound(key); err != nil {
+		return err.Error()
 	}
-	merged.Price = rt.Price
-	merged.Change = rt.Change
-	merged.ChangePercent = rt.ChangePercent
-	merged.Volume = rt.Volume
-	merged.Amount = rt.Amount
-	merged.Open = rt.Open
-	merged.High = rt.High
-	merged.Low = rt.Low
-	merged.PreClose = rt.PreClose
-	return merged
+	return "success"
 }
 
-// GetWatchlist 获取自选股列表（附带实时行情）
-func (a *App) GetWatchlist() []models.Stock {
-	list := a.configService.GetWatchlist()
-	if len(list) == 0 {
-		return list
+// SelectSoundFile 选择自定义提示音文件，返回文件路径，用户取消时返回空字符串
+func (a *App) SelectSoundFile() string {
+	path, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title:   "选择提示音",
+		Filters: []runtime.FileFilter{{DisplayName: "音频文件 (*.wav;*.mp3)", Pattern: "*.wav;*.mp3"}},
+	})
+	if err != nil {
+		log.Warn("选择提示音失败: %v", err)
+		return ""
 	}
+	return path
+}
 
-	// 收集所有股票代码，拉一次实时行情
-	codes := make([]string, len(list))
-	for i, s := range list {
-		codes[i] = s.Symbol
-	}
-	realtime, err := a.marketService.GetStockRealTimeData(codes...)
-	if err != nil || len(realtime) == 0 {
-		return list
-	}
+// GetNotifications 获取通知中心历史，按时间倒序
+func (a *App) GetNotifications(filter models.NotificationFilter) []models.Notification {
+	return a.notifications.List(filter)
+}
 
-	// 用实时数据填充
-	rtMap := make(map[string]models.Stock, len(realtime))
-	for _, s := range realtime {
-		rtMap[s.Symbol] = s
-	}
-	result := make([]models.Stock, len(list))
-	for i, s := range list {
-		if rt, ok := rtMap[s.Symbol]; ok {
-			result[i] = a.mergeRealtimeStock(s, rt)
-		} else {
-			result[i] = s
-		}
+// GetUnreadNotificationCount 获取未读通知数
+func (a *App) GetUnreadNotificationCount() int {
+	return a.notifications.UnreadCount()
+}
+
+// MarkNotificationsRead 标记通知为已读，ids 为空时全部标记
+func (a *App) MarkNotificationsRead(ids []string) string {
+	if err := a.notifications.MarkRead(ids); err != nil {
+		return err.Error()
 	}
-	return result
+	return "success"
 }
 
-// AddToWatchlist 添加自选股
-func (a *App) AddToWatchlist(stock models.Stock) string {
-	if err := a.configService.AddToWatchlist(stock); err != nil {
+// ClearNotifications 清空通知历史
+func (a *App) ClearNotifications() string {
+	if err := a.notifications.Clear(); err != nil {
 		return err.Error()
 	}
-	// 同步添加到推送订阅
-	a.marketPusher.AddSubscription(stock.Symbol)
 	return "success"
 }
 
-// RemoveFromWatchlist 移除自选股
-func (a *App) RemoveFromWatchlist(symbol string) string {
-	if err := a.configService.RemoveFromWatchlist(symbol); err != nil {
+// GetLatestBriefing 获取最近一份每日收盘简报
+func (a *App) GetLatestBriefing() *models.DailyBriefing {
+	return a.briefingService.GetLatest()
+}
+
+// GenerateBriefingNow 立即生成当日收盘简报，完成后通过 ai:briefing:ready 事件推送
+func (a *App) GenerateBriefingNow() string {
+	if _, err := a.briefingService.Generate(a.ctx); err != nil {
+		log.Error("生成每日简报失败: %v", err)
 		return err.Error()
 	}
-	// 同步移除推送订阅
-	a.marketPusher.RemoveSubscription(symbol)
-	// 清空该股票的聊天记录
-	a.sessionService.ClearMessages(symbol)
-	// 同步清除该股票的记忆
-	if a.memoryManager != nil {
-		if err := a.memoryManager.DeleteMemory(symbol); err != nil {
-			log.Error("delete memory error: %v", err)
+	return "success"
+}
+
+// GetIntradayHistory 获取某个交易日归档的分时数据
+// date: 日期，格式 2006-01-02；本地未归档时从远程引擎的录制中读取，均无时返回空列表
+func (a *App) GetIntradayHistory(code string, date string) []models.KLineData {
+	klines, err := a.intradayArchive.Get(code, date)
+	if err != nil {
+		log.Error("读取历史分时失败: %v", err)
+		return []models.KLineData{}
+	}
+	if len(klines) > 0 {
+		return klines
+	}
+	if client := a.currentEngine(); client != nil {
+		ctx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+		defer cancel()
+		remote, err := client.GetRecording(ctx, code, date)
+		if err != nil {
+			log.Warn("读取引擎录制分时失败: %v", err)
+		} else if len(remote) > 0 {
+			return remote
 		}
 	}
-	return "success"
+	return klines
+}
+
+// GetIntradayHistoryDates 获取指定股票已归档分时的日期（含远程引擎录制的日期），按日期倒序
+func (a *App) GetIntradayHistoryDates(code string) []string {
+	dates := a.intradayArchive.Dates(code)
+	client := a.currentEngine()
+	if client == nil {
+		return dates
+	}
+	ctx, cancel := context.WithTimeout(a.ctx, 5*time.Second)
+	defer cancel()
+	remote, err := client.GetRecordingDates(ctx, code)
+	if err != nil {
+		log.Warn("读取引擎录制日期失败: %v", err)
+		return dates
+	}
+	for _, date := range remote {
+		if !slices.Contains(dates, date) {
+			dates = append(dates, date)
+		}
+	}
+	slices.SortFunc(dates, func(x, y string) int { return strings.Compare(y, x) })
+	return dates
+}
+
+// GetKLineIntegrityStatus 获取历史分时完整性检查结果（收盘归档后自动执行）
+func (a *App) GetKLineIntegrityStatus() models.KLineIntegrityStatus {
+	return a.intradayArchive.IntegrityStatus()
+}
+
+// RunKLineIntegrityCheck 立即检查并修复历史分时缺口，返回检查结果
+func (a *App) RunKLineIntegrityCheck() models.KLineIntegrityStatus {
+	return a.intradayArchive.CheckIntegrity(a.ctx)
+}
+
+// syncGroupSubscriptions 分组为当前分组时，按其股票与顺序刷新推送订阅
+func (a *App) syncGroupSubscriptions(groupID string) {
+	if a.marketPusher == nil || a.configService.GetWatchlistGroups().Active != groupID {
+		return
+	}
+	a.marketPusher.SetSubscriptions(a.configService.GetActiveGroupSymbols())
 }
 
 // GetStockRealTimeData 获取股票实时数据
 func (a *App) GetStockRealTimeData(codes []string) []models.Stock {
-	stocks, _ := a.marketService.GetStockRealTimeData(codes...)
+	stocks, _ := a.marketService.GetStockRealTimeData(a.ctx, codes...)
 	return stocks
 }
 
 // GetKLineData 获取K线数据
 func (a *App) GetKLineData(code string, period string, days int) []models.KLineData {
-	data, _ := a.marketService.GetKLineData(code, period, days)
+	data, _ := a.marketService.GetKLineData(a.ctx, code, period, days)
 	return data
 }
 
+// GetComparisonSeries 获取多只股票相对走势对比数据（按时间对齐并以共同基准归一化为涨跌幅）
+// period: 1m/1d/1w/1mo；rng: 1m/3m/6m/1y/3y/5y/ytd，空为近6个月
+func (a *App) GetComparisonSeries(codes []string, period string, rng string) *models.ComparisonSeries {
+	series, err := a.marketService.GetComparisonSeries(a.ctx, codes, period, rng)
+	if err != nil {
+		log.Error("获取对比数据失败: %v", err)
+		a.emitError("GetComparisonSeries", err)
+		return nil
+	}
+	return series
+}
+
+// GetStockStats 获取个股52周高低点、历史最高价与连涨连跌天数
+func (a *App) GetStockStats(code string) *models.StockStats {
+	stats, err := a.marketService.GetStockStats(a.ctx, code)
+	if err != nil {
+		log.Error("获取个股统计失败: %v", err)
+		return nil
+	}
+	return stats
+}
+
 // GetOrderBook 获取盘口数据（真实五档）
 func (a *App) GetOrderBook(code string) models.OrderBook {
-	orderBook, _ := a.marketService.GetRealOrderBook(code)
+	orderBook, _ := a.marketService.GetRealOrderBook(a.ctx, code)
 	return orderBook
 }
 
-// SearchStocks 搜索股票
-func (a *App) SearchStocks(keyword string) []services.StockSearchResult {
-	return a.configService.SearchStocks(keyword, 20)
+// GetMarketHeatmap 获取涨跌幅热力图，scope: market（全市场按行业）/ watchlist（自选股）
+func (a *App) GetMarketHeatmap(scope string) *models.MarketHeatmap {
+	var symbols []string
+	if scope == models.HeatmapScopeWatchlist {
+		for _, s := range a.configService.GetWatchlist() {
+			symbols = append(symbols, s.Symbol)
+		}
+	}
+	heatmap, err := a.marketService.GetMarketHeatmap(a.ctx, scope, symbols)
+	if err != nil {
+		log.Error("获取热力图失败: %v", err)
+		a.emitError("GetMarketHeatmap", err)
+		return nil
+	}
+	return heatmap
+}
+
+// GetResearchReports 获取个股研报评级与目标价汇总（按日缓存）
+func (a *App) GetResearchReports(symbol string) *models.AnalystConsensus {
+	consensus, err := a.researchService.GetAnalystConsensus(symbol)
+	if err != nil {
+		log.Error("获取研报失败: %v", err)
+		a.emitError("GetResearchReports", err)
+		return nil
+	}
+	return consensus
+}
+
+// GetIndexConstituents 获取指数成分股及涨跌贡献
+func (a *App) GetIndexConstituents(indexCode string) []models.IndexConstituent {
+	constituents, err := a.marketService.GetIndexConstituents(a.ctx, indexCode)
+	if err != nil {
+		log.Error("获取指数成分股失败: %v", err)
+		a.emitError("GetIndexConstituents", err)
+		return []models.IndexConstituent{}
+	}
+	return constituents
+}
+
+// GetMarketBreadth 获取全市场涨跌家数统计
+func (a *App) GetMarketBreadth() *models.MarketBreadth {
+	breadth, err := a.marketService.GetMarketBreadth(a.ctx)
+	if err != nil {
+		log.Error("获取涨跌家数失败: %v", err)
+		a.emitError("GetMarketBreadth", err)
+		return nil
+	}
+	return breadth
+}
+
+// GetInterestRates 获取国债逆回购、SHIBOR 与 10 年期国债收益率
+func (a *App) GetInterestRates() *models.InterestRates {
+	rates, err := a.marketService.GetInterestRates(a.ctx)
+	if err != nil {
+		log.Error("获取利率数据失败: %v", err)
+		a.emitError("GetInterestRates", err)
+		return nil
+	}
+	return rates
+}
+
+// GetGlobalIndices 获取全球主要指数（美股、欧股、港股、日股）及当地交易状态
+func (a *App) GetGlobalIndices() []models.GlobalIndex {
+	indices, err := a.marketService.GetGlobalIndices(a.ctx)
+	if err != nil {
+		log.Error("获取全球指数失败: %v", err)
+		a.emitError("GetGlobalIndices", err)
+		return nil
+	}
+	return indices
+}
+
+// GetAvailableMarketIndices 获取可配置的大盘指数列表
+func (a *App) GetAvailableMarketIndices() []services.MarketIndexOption {
+	return services.AvailableMarketIndices
+}
+
+// SearchStocks 搜索股票，支持代码前缀、中文名称、拼音首字母与模糊匹配
+// limit <= 0 时默认返回 20 条
+func (a *App) SearchStocks(query string, limit int) []services.StockSearchResult {
+	return a.configService.SearchStocks(query, limit)
 }
 
 // getDefaultAIConfig 获取默认AI配置
@@ -431,6 +2022,15 @@ func (a *App) getDefaultAIConfig(config *models.AppConfig) *models.AIConfig {
 	return nil
 }
 
+// createLLM 按AI配置ID创建模型，找不到则使用默认AI
+func (a *App) createLLM(ctx context.Context, aiConfigID string) (model.LLM, error) {
+	aiConfig := a.getAIConfigByID(aiConfigID)
+	if aiConfig == nil {
+		return nil, i18n.Errorf("error.ai.not_configured")
+	}
+	return adk.NewModelFactory().CreateModel(ctx, aiConfig)
+}
+
 // getAIConfigByID 根据ID获取AI配置，找不到则返回默认配置
 func (a *App) getAIConfigByID(aiConfigID string) *models.AIConfig {
 	config := a.configService.GetConfig()
@@ -473,6 +2073,7 @@ func (a *App) ClearSessionMessages(stockCode string) string {
 	if err := a.sessionService.ClearMessages(stockCode); err != nil {
 		return err.Error()
 	}
+	adk.GetUsageTracker().ResetSession(stockCode)
 	// 同步清除该股票的记忆
 	if a.memoryManager != nil {
 		if err := a.memoryManager.DeleteMemory(stockCode); err != nil {
@@ -493,6 +2094,72 @@ func (a *App) UpdateStockPosition(stockCode string, shares int64, costPrice floa
 	return "success"
 }
 
+// GetPriceTargets 获取所有目标价/止损价跟踪线
+func (a *App) GetPriceTargets() []models.PriceTarget {
+	return a.configService.GetPriceTargets()
+}
+
+// SetPriceTarget 设置股票的目标价/止损价（均为 0 时清除）
+func (a *App) SetPriceTarget(symbol string, targetPrice, stopPrice float64) string {
+	if err := a.configService.SetPriceTarget(symbol, targetPrice, stopPrice); err != nil {
+		log.Error("设置目标价失败: %v", err)
+		return err.Error()
+	}
+	return "success"
+}
+
+// GetNearTargets 获取今日价格曾进入目标价/止损价 thresholdPct% 以内的股票
+func (a *App) GetNearTargets(thresholdPct float64) []models.TargetProximity {
+	if a.marketPusher == nil {
+		return []models.TargetProximity{}
+	}
+	return a.marketPusher.Recorder().NearTargets(thresholdPct)
+}
+
+// ========== Drawing API ==========
+
+// GetChartDrawings 获取指定股票和周期的图表画线
+func (a *App) GetChartDrawings(symbol, period string) []models.ChartDrawing {
+	if a.drawingService == nil {
+		return []models.ChartDrawing{}
+	}
+	return a.drawingService.GetDrawings(symbol, period)
+}
+
+// SaveChartDrawing 新增或更新图表画线，返回保存后的画线（含ID）
+func (a *App) SaveChartDrawing(drawing models.ChartDrawing) models.ChartDrawing {
+	if a.drawingService == nil {
+		return drawing
+	}
+	saved, err := a.drawingService.SaveDrawing(drawing)
+	if err != nil {
+		log.Error("保存画线失败: %v", err)
+	}
+	return saved
+}
+
+// DeleteChartDrawing 删除图表画线
+func (a *App) DeleteChartDrawing(symbol, period, id string) string {
+	if a.drawingService == nil {
+		return "service not ready"
+	}
+	if err := a.drawingService.DeleteDrawing(symbol, period, id); err != nil {
+		return err.Error()
+	}
+	return "success"
+}
+
+// ClearChartDrawings 清空图表画线（period 为空则清空该股票全部周期）
+func (a *App) ClearChartDrawings(symbol, period string) string {
+	if a.drawingService == nil {
+		return "service not ready"
+	}
+	if err := a.drawingService.ClearDrawings(symbol, period); err != nil {
+		return err.Error()
+	}
+	return "success"
+}
+
 // ========== Agent Config API ==========
 
 // GetAgentConfigs 获取所有已启用的Agent配置
@@ -549,6 +2216,66 @@ func (a *App) DeleteAgentConfig(id string) string {
 	return "success"
 }
 
+// ========== Persona API ==========
+
+// GetPersonas 获取自定义专家人设列表
+func (a *App) GetPersonas() []models.AgentPersona {
+	personas := a.configService.GetPersonas()
+	if personas == nil {
+		return []models.AgentPersona{}
+	}
+	return personas
+}
+
+// CreatePersona 新建自定义专家人设
+func (a *App) CreatePersona(persona models.AgentPersona) string {
+	if err := a.checkPersonaTools(persona); err != nil {
+		return err.Error()
+	}
+	if _, err := a.configService.CreatePersona(persona); err != nil {
+		return err.Error()
+	}
+	a.reloadPersonas()
+	return "success"
+}
+
+// UpdatePersona 更新自定义专家人设
+func (a *App) UpdatePersona(persona models.AgentPersona) string {
+	if err := a.checkPersonaTools(persona); err != nil {
+		return err.Error()
+	}
+	if err := a.configService.UpdatePersona(persona); err != nil {
+		return err.Error()
+	}
+	a.reloadPersonas()
+	return "success"
+}
+
+// DeletePersona 删除自定义专家人设
+func (a *App) DeletePersona(id string) string {
+	if err := a.configService.DeletePersona(id); err != nil {
+		return err.Error()
+	}
+	a.reloadPersonas()
+	return "success"
+}
+
+// checkPersonaTools 校验人设引用的内置工具是否存在
+func (a *App) checkPersonaTools(persona models.AgentPersona) error {
+	for _, name := range persona.Tools {
+		if len(a.toolRegistry.GetToolInfosByNames([]string{name})) == 0 {
+			return i18n.Errorf("error.tool.not_found", name)
+		}
+	}
+	return nil
+}
+
+// reloadPersonas 将自定义专家同步到讨论引擎
+func (a *App) reloadPersonas() {
+	a.strategyService.SetPersonas(a.configService.GetPersonas())
+	a.agentContainer.LoadAgents(a.strategyService.GetAllAgents())
+}
+
 // ========== Strategy API ==========
 
 // GetStrategies 获取所有策略
@@ -761,16 +2488,13 @@ type MeetingMessageRequest struct {
 	MentionIds   []string `json:"mentionIds"`
 	ReplyToId    string   `json:"replyToId"`
 	ReplyContent string   `json:"replyContent"`
+	// Orchestration 智能模式的讨论编排（为空则单轮轮流发言）
+	Orchestration *models.OrchestrationConfig `json:"orchestration,omitempty"`
 }
 
-// cancelMeetingInternal 内部取消会议方法
-func (a *App) cancelMeetingInternal(stockCode string) {
-	a.meetingCancelsMu.Lock()
-	if cancel, ok := a.meetingCancels[stockCode]; ok {
-		cancel()
-		delete(a.meetingCancels, stockCode)
-	}
-	a.meetingCancelsMu.Unlock()
+// cancelMeetingInternal 内部取消会议方法（含单专家重试），返回是否有进行中的会议
+func (a *App) cancelMeetingInternal(stockCode string) bool {
+	return a.meetingCancels.Cancel(stockCode)
 }
 
 // CancelMeeting 取消指定股票的会议（前端调用）
@@ -780,6 +2504,17 @@ func (a *App) CancelMeeting(stockCode string) bool {
 	return true
 }
 
+// CancelDiscussion 停止指定会话（股票代码）进行中的讨论
+// 取消所有进行中的模型流式请求，专家已输出的部分内容作为已停止的发言推送并保存
+// 返回 false 表示当前没有进行中的讨论
+func (a *App) CancelDiscussion(sessionID string) bool {
+	if !a.cancelMeetingInternal(sessionID) {
+		return false
+	}
+	log.Info("讨论已停止: %s", sessionID)
+	return true
+}
+
 // SendMeetingMessage 发送会议室消息（@指定成员回复）
 func (a *App) SendMeetingMessage(req MeetingMessageRequest) []models.ChatMessage {
 	// 获取Session
@@ -789,21 +2524,10 @@ func (a *App) SendMeetingMessage(req MeetingMessageRequest) []models.ChatMessage
 		return []models.ChatMessage{}
 	}
 
-	// 取消之前该股票的会议（如果有）
-	a.cancelMeetingInternal(req.StockCode)
-
-	// 创建可取消的 context
-	meetingCtx, cancel := context.WithCancel(a.ctx)
-	a.meetingCancelsMu.Lock()
-	a.meetingCancels[req.StockCode] = cancel
-	a.meetingCancelsMu.Unlock()
-
+	// 创建可取消的 context（费用计入该股票会话），同时取消之前该股票的会议（如果有）
+	meetingCtx, release := a.meetingCancels.StartMeeting(adk.WithUsageSession(a.ctx, req.StockCode), req.StockCode)
 	// 会议结束后清理
-	defer func() {
-		a.meetingCancelsMu.Lock()
-		delete(a.meetingCancels, req.StockCode)
-		a.meetingCancelsMu.Unlock()
-	}()
+	defer release()
 
 	// 先保存用户消息
 	userMsg := models.ChatMessage{
@@ -816,7 +2540,7 @@ func (a *App) SendMeetingMessage(req MeetingMessageRequest) []models.ChatMessage
 	a.sessionService.AddMessage(req.StockCode, userMsg)
 
 	// 获取股票数据
-	stocks, _ := a.marketService.GetStockRealTimeData(req.StockCode)
+	stocks, _ := a.marketService.GetStockRealTimeData(a.ctx, req.StockCode)
 	var stock models.Stock
 	if len(stocks) > 0 {
 		stock = stocks[0]
@@ -835,7 +2559,7 @@ func (a *App) SendMeetingMessage(req MeetingMessageRequest) []models.ChatMessage
 
 	// 判断是否为智能模式（无 @ 任何人）
 	if len(req.MentionIds) == 0 {
-		return a.runSmartMeeting(meetingCtx, req.StockCode, stock, req.Content, aiConfig, position)
+		return a.runSmartMeeting(meetingCtx, req.StockCode, stock, req.Content, aiConfig, position, req.Orchestration)
 	}
 
 	// 原有逻辑：@ 指定专家
@@ -843,40 +2567,29 @@ func (a *App) SendMeetingMessage(req MeetingMessageRequest) []models.ChatMessage
 }
 
 // runSmartMeeting 智能会议模式
-func (a *App) runSmartMeeting(ctx context.Context, stockCode string, stock models.Stock, query string, aiConfig *models.AIConfig, position *models.StockPosition) []models.ChatMessage {
+func (a *App) runSmartMeeting(ctx context.Context, stockCode string, stock models.Stock, query string, aiConfig *models.AIConfig, position *models.StockPosition, orchestration *models.OrchestrationConfig) []models.ChatMessage {
 	allAgents := a.strategyService.GetEnabledAgents()
 	chatReq := meeting.ChatRequest{
-		StockCode: stockCode,
-		Stock:     stock,
-		Query:     query,
-		AllAgents: allAgents,
-		Position:  position,
-	}
-
-	// 响应回调：每次发言完成后推送
-	respCallback := func(resp meeting.ChatResponse) {
-		msg := models.ChatMessage{
-			AgentID:     resp.AgentID,
-			AgentName:   resp.AgentName,
-			Role:        resp.Role,
-			Content:     resp.Content,
-			Round:       resp.Round,
-			MsgType:     resp.MsgType,
-			Error:       resp.Error,
-			MeetingMode: resp.MeetingMode,
-		}
-		a.sessionService.AddMessage(stockCode, msg)
-		runtime.EventsEmit(a.ctx, "meeting:message:"+stockCode, msg)
+		StockCode:     stockCode,
+		Stock:         stock,
+		Query:         query,
+		AllAgents:     allAgents,
+		Position:      position,
+		Orchestration: orchestration,
 	}
 
-	// 进度回调：工具调用、流式输出等细粒度事件
-	progressCallback := func(event meeting.ProgressEvent) {
-		runtime.EventsEmit(a.ctx, "meeting:progress:"+stockCode, event)
+	// 记录完整讨论过程，用于回看与中断后恢复
+	discussionID, err := a.discussionService.Start(stockCode, stock.Name, query, orchestration)
+	if err != nil {
+		log.Warn("start discussion record error: %v", err)
 	}
+	respCallback, progressCallback := a.discussionCallbacks(stockCode, discussionID)
 
 	responses, err := a.meetingService.RunSmartMeetingWithCallback(ctx, aiConfig, chatReq, respCallback, progressCallback)
-	if err != nil {
+	a.finishDiscussion(discussionID, stockCode, responses, err)
+	if err != nil && !errors.Is(err, meeting.ErrCancelled) {
 		log.Error("runSmartMeeting error: %v", err)
+		a.emitError("SendMeetingMessage", err)
 		return []models.ChatMessage{}
 	}
 
@@ -891,7 +2604,9 @@ func (a *App) runSmartMeeting(ctx context.Context, stockCode string, stock model
 			Round:       resp.Round,
 			MsgType:     resp.MsgType,
 			Error:       resp.Error,
+			ErrorCode:   resp.ErrorCode,
 			MeetingMode: resp.MeetingMode,
+			Cancelled:   resp.Cancelled,
 		})
 	}
 	return messages
@@ -913,8 +2628,9 @@ func (a *App) runDirectMeeting(ctx context.Context, req MeetingMessageRequest, s
 	}
 
 	responses, err := a.meetingService.SendMessage(ctx, aiConfig, chatReq)
-	if err != nil {
+	if err != nil && !errors.Is(err, meeting.ErrCancelled) {
 		log.Error("runDirectMeeting error: %v", err)
+		a.emitError("SendMeetingMessage", err)
 		return []models.ChatMessage{}
 	}
 
@@ -935,7 +2651,9 @@ func (a *App) convertSaveAndEmitResponses(stockCode string, responses []meeting.
 			Round:       resp.Round,
 			MsgType:     resp.MsgType,
 			Error:       resp.Error,
+			ErrorCode:   resp.ErrorCode,
 			MeetingMode: resp.MeetingMode,
+			Cancelled:   resp.Cancelled,
 		}
 		// 保存单条消息
 		a.sessionService.AddMessage(stockCode, msg)
@@ -949,7 +2667,7 @@ func (a *App) convertSaveAndEmitResponses(stockCode string, responses []meeting.
 // RetryAgent 重试单个失败的专家（前端手动触发）
 func (a *App) RetryAgent(stockCode string, agentId string, query string) models.ChatMessage {
 	// 获取股票数据
-	stocks, _ := a.marketService.GetStockRealTimeData(stockCode)
+	stocks, _ := a.marketService.GetStockRealTimeData(a.ctx, stockCode)
 	var stock models.Stock
 	if len(stocks) > 0 {
 		stock = stocks[0]
@@ -978,7 +2696,11 @@ func (a *App) RetryAgent(stockCode string, agentId string, query string) models.
 		runtime.EventsEmit(a.ctx, "meeting:progress:"+stockCode, event)
 	}
 
-	resp, err := a.meetingService.RetrySingleAgent(a.ctx, aiConfig, &agentCfg, &stock, query, progressCallback, position)
+	// 重试单独登记取消函数（可通过 CancelDiscussion 停止），不影响进行中的讨论
+	retryCtx, release := a.meetingCancels.StartRetry(adk.WithUsageSession(a.ctx, stockCode), stockCode)
+	defer release()
+
+	resp, err := a.meetingService.RetrySingleAgent(retryCtx, aiConfig, &agentCfg, &stock, query, progressCallback, position)
 
 	msg := models.ChatMessage{
 		AgentID:     resp.AgentID,
@@ -988,10 +2710,15 @@ func (a *App) RetryAgent(stockCode string, agentId string, query string) models.
 		Round:       resp.Round,
 		MsgType:     resp.MsgType,
 		Error:       resp.Error,
+		ErrorCode:   resp.ErrorCode,
 		MeetingMode: resp.MeetingMode,
+		Cancelled:   resp.Cancelled,
 	}
 
-	if err != nil {
+	if errors.Is(err, meeting.ErrCancelled) && msg.Content == "" {
+		return msg
+	}
+	if err != nil && !errors.Is(err, meeting.ErrCancelled) {
 		log.Error("RetryAgent failed: %v", err)
 		runtime.EventsEmit(a.ctx, "meeting:message:"+stockCode, msg)
 		return msg
@@ -1006,45 +2733,30 @@ func (a *App) RetryAgent(stockCode string, agentId string, query string) models.
 // RetryAgentAndContinue 重试失败专家并继续执行剩余专家（前端手动触发）
 func (a *App) RetryAgentAndContinue(stockCode string) []models.ChatMessage {
 	if !a.meetingService.HasInterruptedMeeting(stockCode) {
+		// 内存中的中断状态已过期或应用已重启，从讨论记录恢复
+		if list := a.discussionService.List(stockCode); len(list) > 0 && list[0].Resumable {
+			return a.ResumeDiscussion(list[0].ID)
+		}
 		log.Warn("RetryAgentAndContinue: no interrupted meeting for %s", stockCode)
 		return []models.ChatMessage{}
 	}
 
-	// 创建可取消的 context
-	meetingCtx, cancel := context.WithCancel(a.ctx)
-	a.meetingCancelsMu.Lock()
-	a.meetingCancels[stockCode] = cancel
-	a.meetingCancelsMu.Unlock()
-
-	defer func() {
-		a.meetingCancelsMu.Lock()
-		delete(a.meetingCancels, stockCode)
-		a.meetingCancelsMu.Unlock()
-	}()
+	// 创建可取消的 context（费用计入该股票会话）
+	meetingCtx, release := a.meetingCancels.StartMeeting(adk.WithUsageSession(a.ctx, stockCode), stockCode)
+	defer release()
 
-	// 响应回调
-	respCallback := func(resp meeting.ChatResponse) {
-		msg := models.ChatMessage{
-			AgentID:     resp.AgentID,
-			AgentName:   resp.AgentName,
-			Role:        resp.Role,
-			Content:     resp.Content,
-			Round:       resp.Round,
-			MsgType:     resp.MsgType,
-			Error:       resp.Error,
-			MeetingMode: resp.MeetingMode,
+	// 继续写入该股票最近一次被中断的讨论记录
+	var discussionID string
+	if list := a.discussionService.List(stockCode); len(list) > 0 && list[0].Resumable {
+		if _, err := a.discussionService.Resume(list[0].ID); err == nil {
+			discussionID = list[0].ID
 		}
-		a.sessionService.AddMessage(stockCode, msg)
-		runtime.EventsEmit(a.ctx, "meeting:message:"+stockCode, msg)
-	}
-
-	// 进度回调
-	progressCallback := func(event meeting.ProgressEvent) {
-		runtime.EventsEmit(a.ctx, "meeting:progress:"+stockCode, event)
 	}
+	respCallback, progressCallback := a.discussionCallbacks(stockCode, discussionID)
 
 	responses, err := a.meetingService.ContinueMeeting(meetingCtx, stockCode, respCallback, progressCallback)
-	if err != nil {
+	a.finishDiscussion(discussionID, stockCode, responses, err)
+	if err != nil && !errors.Is(err, meeting.ErrCancelled) {
 		log.Error("RetryAgentAndContinue error: %v", err)
 		return []models.ChatMessage{}
 	}
@@ -1059,7 +2771,9 @@ func (a *App) RetryAgentAndContinue(stockCode string) []models.ChatMessage {
 			Round:       resp.Round,
 			MsgType:     resp.MsgType,
 			Error:       resp.Error,
+			ErrorCode:   resp.ErrorCode,
 			MeetingMode: resp.MeetingMode,
+			Cancelled:   resp.Cancelled,
 		})
 	}
 	return messages
@@ -1071,12 +2785,183 @@ func (a *App) CancelInterruptedMeeting(stockCode string) bool {
 	return true
 }
 
+// ========== Discussion Transcript API ==========
+
+// ListDiscussions 获取讨论记录列表，stockCode 为空时返回全部
+func (a *App) ListDiscussions(stockCode string) []models.DiscussionSummary {
+	return a.discussionService.List(stockCode)
+}
+
+// GetDiscussion 获取讨论完整记录（含思考与工具调用）
+func (a *App) GetDiscussion(id string) *models.Discussion {
+	d, err := a.discussionService.Get(id)
+	if err != nil {
+		log.Warn("GetDiscussion %s: %v", id, err)
+		return nil
+	}
+	return d
+}
+
+// ResumeDiscussion 从最后完成的轮次恢复被中断或停止的讨论
+func (a *App) ResumeDiscussion(id string) []models.ChatMessage {
+	d, err := a.discussionService.Resume(id)
+	if err != nil {
+		log.Warn("ResumeDiscussion %s: %v", id, err)
+		a.emitError("ResumeDiscussion", err)
+		return []models.ChatMessage{}
+	}
+	stockCode := d.StockCode
+
+	config := a.configService.GetConfig()
+	aiConfig := a.getDefaultAIConfig(config)
+	if aiConfig == nil {
+		a.discussionService.Finish(id, models.DiscussionInterrupted, "未配置 AI 服务")
+		return []models.ChatMessage{}
+	}
+
+	// 内存中的中断状态与讨论记录二选一，避免重复续跑
+	a.meetingService.CancelInterruptedMeeting(stockCode)
+	meetingCtx, release := a.meetingCancels.StartMeeting(adk.WithUsageSession(a.ctx, stockCode), stockCode)
+	defer release()
+
+	stocks, _ := a.marketService.GetStockRealTimeData(a.ctx, stockCode)
+	stock := models.Stock{Symbol: stockCode, Name: d.StockName}
+	if len(stocks) > 0 {
+		stock = stocks[0]
+	}
+
+	req := meeting.ResumeRequest{
+		ChatRequest: meeting.ChatRequest{
+			StockCode:     stockCode,
+			Stock:         stock,
+			Query:         d.Query,
+			AllAgents:     a.strategyService.GetAllAgents(),
+			Position:      a.sessionService.GetPosition(stockCode),
+			Orchestration: d.Orchestration,
+		},
+		Decision: &meeting.ModeratorDecision{Selected: d.SelectedAgents, Topic: d.Topic, Tasks: d.Tasks},
+		Messages: d.Messages,
+	}
+	respCallback, progressCallback := a.discussionCallbacks(stockCode, id)
+
+	responses, err := a.meetingService.ResumeDiscussion(meetingCtx, aiConfig, req, respCallback, progressCallback)
+	a.finishDiscussion(id, stockCode, responses, err)
+	if err != nil && !errors.Is(err, meeting.ErrCancelled) {
+		log.Error("ResumeDiscussion error: %v", err)
+		a.emitError("ResumeDiscussion", err)
+	}
+
+	messages := make([]models.ChatMessage, 0, len(responses))
+	for _, resp := range responses {
+		messages = append(messages, chatMessageFromResponse(resp))
+	}
+	return messages
+}
+
+// discussionCallbacks 创建讨论回调：保存到会话并推送前端，同时写入讨论记录
+func (a *App) discussionCallbacks(stockCode, discussionID string) (meeting.ResponseCallback, meeting.ProgressCallback) {
+	respCallback := func(resp meeting.ChatResponse) {
+		msg := chatMessageFromResponse(resp)
+		a.sessionService.AddMessage(stockCode, msg)
+		a.discussionService.AddMessage(discussionID, msg)
+		runtime.EventsEmit(a.ctx, "meeting:message:"+stockCode, msg)
+	}
+
+	progressCallback := func(event meeting.ProgressEvent) {
+		runtime.EventsEmit(a.ctx, "meeting:progress:"+stockCode, event)
+		switch event.Type {
+		case "agents_selected":
+			var decision meeting.ModeratorDecision
+			if err := json.Unmarshal([]byte(event.Content), &decision); err == nil {
+				a.discussionService.SetPlan(discussionID, decision.Selected, decision.Topic, decision.Tasks)
+			}
+		case "thinking", "tool_call", "tool_result", "agent_error", "meeting_interrupted":
+			a.discussionService.AddEvent(discussionID, models.DiscussionEvent{
+				Type:      event.Type,
+				AgentID:   event.AgentID,
+				AgentName: event.AgentName,
+				Detail:    event.Detail,
+				Content:   event.Content,
+			})
+		}
+	}
+	return respCallback, progressCallback
+}
+
+// finishDiscussion 按会议结果更新讨论记录状态
+func (a *App) finishDiscussion(discussionID, stockCode string, responses []meeting.ChatResponse, err error) {
+	if discussionID == "" {
+		return
+	}
+	status, errMsg := models.DiscussionCompleted, ""
+	switch {
+	case errors.Is(err, meeting.ErrCancelled):
+		status = models.DiscussionCancelled
+	case err != nil:
+		status, errMsg = models.DiscussionInterrupted, err.Error()
+	case a.meetingService.HasInterruptedMeeting(stockCode):
+		status = models.DiscussionInterrupted
+	default:
+		// 有专家发言但没有总结（总结失败或超时），保留为可恢复
+		spoke, summarized := false, false
+		for _, resp := range responses {
+			switch {
+			case resp.MsgType == "summary":
+				summarized = true
+			case resp.AgentID != "moderator":
+				spoke = true
+			}
+		}
+		if spoke && !summarized {
+			status = models.DiscussionInterrupted
+		}
+	}
+	a.discussionService.Finish(discussionID, status, errMsg)
+}
+
+// chatMessageFromResponse 会议响应转换为会话消息
+func chatMessageFromResponse(resp meeting.ChatResponse) models.ChatMessage {
+	return models.ChatMessage{
+		AgentID:     resp.AgentID,
+		AgentName:   resp.AgentName,
+		Role:        resp.Role,
+		Content:     resp.Content,
+		Round:       resp.Round,
+		MsgType:     resp.MsgType,
+		Error:       resp.Error,
+		ErrorCode:   resp.ErrorCode,
+		MeetingMode: resp.MeetingMode,
+		Cancelled:   resp.Cancelled,
+	}
+}
+
 // ========== News API ==========
 
 // GetTelegraphList 获取快讯列表
 func (a *App) GetTelegraphList() []services.Telegraph {
-	telegraphs, err := a.newsService.GetTelegraphList()
+	telegraphs, err := a.newsService.GetTelegraphList(a.ctx)
+	if err != nil {
+		return []services.Telegraph{}
+	}
+	return telegraphs
+}
+
+// SearchNews 检索历史快讯，keyword 空格分隔多词，日期格式 2006-01-02（为空时默认最近 30 天）
+func (a *App) SearchNews(keyword, startDate, endDate string) []services.Telegraph {
+	telegraphs, err := a.newsService.SearchHistory(keyword, startDate, endDate)
+	if err != nil {
+		log.Warn("检索历史快讯失败: %v", err)
+		return []services.Telegraph{}
+	}
+	return telegraphs
+}
+
+// GetStockNews 获取提及某只股票的资讯（多来源与本地归档合并去重，按时间倒序）
+func (a *App) GetStockNews(symbol string, limit int) []services.Telegraph {
+	info, _ := a.configService.LookupStock(symbol)
+	telegraphs, err := a.newsService.GetStockNews(a.ctx, symbol, info.Name, limit)
 	if err != nil {
+		log.Warn("获取个股资讯失败: %v", err)
 		return []services.Telegraph{}
 	}
 	return telegraphs
@@ -1166,6 +3051,37 @@ func (a *App) TestMCPConnection(serverID string) *mcp.ServerStatus {
 	return a.mcpManager.TestConnection(serverID)
 }
 
+// ListOllamaModels 列出 Ollama 本地已下载的模型
+func (a *App) ListOllamaModels(baseURL string) []string {
+	names, err := ollama.ListModels(a.ctx, &http.Client{Timeout: 10 * time.Second}, baseURL)
+	if err != nil {
+		log.Warn("获取 Ollama 模型列表失败: %v", err)
+		return []string{}
+	}
+	return names
+}
+
+// PullOllamaModel 拉取 Ollama 模型，进度通过 ollama:pull 事件推送
+func (a *App) PullOllamaModel(baseURL, modelName string) string {
+	if modelName == "" {
+		return "模型名称不能为空"
+	}
+	err := ollama.Pull(a.ctx, &http.Client{}, baseURL, modelName, func(status ollama.PullStatus) {
+		runtime.EventsEmit(a.ctx, "ollama:pull", map[string]any{
+			"model":     modelName,
+			"status":    status.Status,
+			"total":     status.Total,
+			"completed": status.Completed,
+		})
+	})
+	if err != nil {
+		log.Error("拉取 Ollama 模型失败 [%s]: %v", modelName, err)
+		return err.Error()
+	}
+	log.Info("Ollama 模型拉取完成 [%s]", modelName)
+	return "success"
+}
+
 // TestAIConnection 测试 AI 配置连通性
 // 连接成功后自动检测是否支持 system role，并持久化结果
 func (a *App) TestAIConnection(config models.AIConfig) string {
@@ -1173,6 +3089,7 @@ func (a *App) TestAIConnection(config models.AIConfig) string {
 	ctx := context.Background()
 	if err := factory.TestConnection(ctx, &config); err != nil {
 		log.Error("AI 连接测试失败 [%s]: %v", config.Name, err)
+		a.emitError("TestAIConnection", err)
 		return err.Error()
 	}
 	log.Info("AI 连接测试成功 [%s]", config.Name)
@@ -1225,6 +3142,81 @@ func (a *App) WindowClose() {
 	runtime.Quit(a.ctx)
 }
 
+// OpenDetachedWindow 在独立窗口打开股票的K线图（kind=kline）或盘口（kind=orderbook）
+// 返回 success 或错误信息
+func (a *App) OpenDetachedWindow(kind, code, name string) string {
+	if _, err := a.detachedWindows.Open(kind, code, name); err != nil {
+		log.Warn("打开分离窗口失败: %v", err)
+		return err.Error()
+	}
+	return "success"
+}
+
+// GetDetachedWindow 获取本进程显示的分离窗口，主窗口返回 nil
+func (a *App) GetDetachedWindow() *services.WindowSubscription {
+	return a.detached
+}
+
+// showWindow 显示并还原窗口，同时解除老板键的通知静音
+func (a *App) showWindow() {
+	runtime.WindowShow(a.ctx)
+	runtime.WindowUnminimise(a.ctx)
+	a.windowHidden.Store(false)
+	if a.notifyMuted.Swap(false) {
+		runtime.EventsEmit(a.ctx, "notify:muted", false)
+	}
+}
+
+// toggleWindow 全局快捷键：窗口隐藏或最小化时显示，否则隐藏
+func (a *App) toggleWindow() {
+	if a.windowHidden.Load() || runtime.WindowIsMinimised(a.ctx) {
+		a.showWindow()
+		return
+	}
+	runtime.WindowHide(a.ctx)
+	a.windowHidden.Store(true)
+}
+
+// toggleBossKey 老板键：立即隐藏窗口并静音通知，再按一次恢复
+func (a *App) toggleBossKey() {
+	if a.notifyMuted.Load() {
+		a.showWindow()
+		return
+	}
+	runtime.WindowHide(a.ctx)
+	a.windowHidden.Store(true)
+	a.notifyMuted.Store(true)
+	runtime.EventsEmit(a.ctx, "notify:muted", true)
+}
+
+// quickSearch 全局快捷键：显示窗口并聚焦股票搜索框
+func (a *App) quickSearch() {
+	a.showWindow()
+	runtime.EventsEmit(a.ctx, "hotkey:quickSearch")
+}
+
+// trayTickerInterval 托盘行情滚动间隔
+const trayTickerInterval = 3 * time.Second
+
+// runTrayTicker 定时把最新自选股行情滚动到托盘
+func (a *App) runTrayTicker(ctx context.Context) {
+	ticker := time.NewTicker(trayTickerInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			a.tray.Update(a.marketPusher.LatestStocks(), a.marketPusher.Paused())
+		}
+	}
+}
+
+// HotkeysSupported 当前系统是否支持全局快捷键
+func (a *App) HotkeysSupported() bool {
+	return hotkey.Supported()
+}
+
 // ========== HotTrend API ==========
 
 // GetHotTrendPlatforms 获取支持的热点平台列表
@@ -1258,7 +3250,7 @@ func (a *App) CheckForUpdate() services.UpdateInfo {
 	return a.updateService.CheckForUpdate()
 }
 
-// DoUpdate 执行更新
+// DoUpdate 下载并校验最新版本，重启或退出时安装
 func (a *App) DoUpdate() string {
 	if a.updateService == nil {
 		return "更新服务未初始化"
@@ -1269,7 +3261,7 @@ func (a *App) DoUpdate() string {
 	return "success"
 }
 
-// RestartApp 重启应用
+// RestartApp 安装已下载的更新并重启应用
 func (a *App) RestartApp() string {
 	if a.updateService == nil {
 		return "更新服务未初始化"
@@ -1280,6 +3272,16 @@ func (a *App) RestartApp() string {
 	return "success"
 }
 
+// SkipUpdateVersion 跳过指定版本，自动检查不再提醒该版本
+func (a *App) SkipUpdateVersion(version string) string {
+	cfg := a.configService.GetConfig().Update
+	cfg.SkipVersion = version
+	if err := a.configService.SetUpdateConfig(cfg); err != nil {
+		return err.Error()
+	}
+	return "success"
+}
+
 // GetCurrentVersion 获取当前版本
 func (a *App) GetCurrentVersion() string {
 	if a.updateService == nil {
@@ -1309,6 +3311,35 @@ func (a *App) GetTradingSchedule() *services.TradingSchedule {
 	return &schedule
 }
 
+// GetMarketStatus 获取指定代码的交易状态（期货按品种交易时段与夜盘判断）
+func (a *App) GetMarketStatus(symbol string) services.MarketStatus {
+	return a.marketService.GetMarketStatusFor(symbol)
+}
+
+// GetFuturesQuotes 获取期货行情，股指期货附带与现货指数的基差
+// symbols: 新浪期货代码，如 nf_IF0（主连）、nf_IF2406、nf_RB0
+func (a *App) GetFuturesQuotes(symbols []string) []models.FuturesQuote {
+	quotes, err := a.marketService.GetFuturesQuotes(a.ctx, symbols...)
+	if err != nil {
+		log.Error("获取期货行情失败: %v", err)
+		a.emitError("GetFuturesQuotes", err)
+		return nil
+	}
+	return quotes
+}
+
+// GetOptionChain 获取ETF期权T型报价（含隐含波动率）
+// underlying: sh510050 / sh510300；month: 合约月份 YYYY-MM，为空时取最近月份
+func (a *App) GetOptionChain(underlying, month string) *models.OptionChain {
+	chain, err := a.optionService.GetOptionChain(a.ctx, underlying, month)
+	if err != nil {
+		log.Error("获取期权T型报价失败: %v", err)
+		a.emitError("GetOptionChain", err)
+		return nil
+	}
+	return chain
+}
+
 // GetLongHuBangList 获取龙虎榜列表
 func (a *App) GetLongHuBangList(pageSize, pageNumber int, tradeDate string) *services.LongHuBangListResult {
 	if a.longHuBangService == nil {
@@ -1317,11 +3348,82 @@ func (a *App) GetLongHuBangList(pageSize, pageNumber int, tradeDate string) *ser
 	result, err := a.longHuBangService.GetLongHuBangList(pageSize, pageNumber, tradeDate)
 	if err != nil {
 		log.Error("获取龙虎榜失败: %v", err)
+		a.emitError("GetLongHuBangList", err)
 		return nil
 	}
 	return result
 }
 
+// GetIPOCalendar 获取新股日历（待申购、待上市）
+func (a *App) GetIPOCalendar() []models.IPOItem {
+	items, err := a.ipoService.GetIPOCalendar()
+	if err != nil {
+		log.Error("获取新股日历失败: %v", err)
+		a.emitError("GetIPOCalendar", err)
+		return []models.IPOItem{}
+	}
+	return items
+}
+
+// GetGubaSentiment 获取个股股吧发帖量趋势（散户关注度）
+func (a *App) GetGubaSentiment(code string) *models.GubaSentiment {
+	sentiment, err := a.gubaService.GetSentiment(code)
+	if err != nil {
+		log.Error("获取股吧关注度失败: %v", err)
+		a.emitError("GetGubaSentiment", err)
+		return nil
+	}
+	return sentiment
+}
+
+// GetMarginData 获取个股融资融券数据
+func (a *App) GetMarginData(symbol string, days int) []models.MarginRecord {
+	records, err := a.marginService.GetMarginData(a.ctx, symbol, days)
+	if err != nil {
+		log.Error("获取融资融券数据失败: %v", err)
+		a.emitError("GetMarginData", err)
+		return []models.MarginRecord{}
+	}
+	return records
+}
+
+// GetMarketMarginTrend 获取两市融资融券余额走势
+func (a *App) GetMarketMarginTrend(days int) []models.MarginRecord {
+	records, err := a.marginService.GetMarketMarginTrend(a.ctx, days)
+	if err != nil {
+		log.Error("获取两市融资融券数据失败: %v", err)
+		a.emitError("GetMarketMarginTrend", err)
+		return []models.MarginRecord{}
+	}
+	return records
+}
+
+// GetShareholders 获取个股股东数据：十大股东、机构持仓与股东户数趋势
+func (a *App) GetShareholders(symbol string) *models.ShareholderData {
+	data, err := a.shareholderSvc.GetShareholders(a.ctx, symbol)
+	if err != nil {
+		log.Error("获取股东数据失败: %v", err)
+		a.emitError("GetShareholders", err)
+		return nil
+	}
+	return data
+}
+
+// GetLockupCalendar 获取自选股未来 days 天内的限售解禁日历，days<=0 时默认 90 天
+func (a *App) GetLockupCalendar(days int) []models.LockupExpiry {
+	var symbols []string
+	for _, stock := range a.configService.GetWatchlist() {
+		symbols = append(symbols, stock.Symbol)
+	}
+	items, err := a.lockupService.GetLockupCalendar(a.ctx, symbols, days)
+	if err != nil {
+		log.Error("获取限售解禁日历失败: %v", err)
+		a.emitError("GetLockupCalendar", err)
+		return []models.LockupExpiry{}
+	}
+	return items
+}
+
 // GetLongHuBangDetail 获取龙虎榜营业部明细
 func (a *App) GetLongHuBangDetail(code, tradeDate string) []models.LongHuBangDetail {
 	if a.longHuBangService == nil {
@@ -1330,11 +3432,216 @@ func (a *App) GetLongHuBangDetail(code, tradeDate string) []models.LongHuBangDet
 	details, err := a.longHuBangService.GetStockDetail(code, tradeDate)
 	if err != nil {
 		log.Error("获取龙虎榜明细失败: %v", err)
+		a.emitError("GetLongHuBangDetail", err)
 		return nil
 	}
 	return details
 }
 
+// GetRetainedEvents 获取指定推送事件最近保留的消息（面板挂载时拉取）
+func (a *App) GetRetainedEvents(event string) []any {
+	if a.marketPusher == nil {
+		return []any{}
+	}
+	return a.marketPusher.GetRetainedEvents(event)
+}
+
+// SetPushEncoding 协商大列表推送编码（json/compact），返回实际生效的编码
+// 前端在 NotifyFrontendReady 前调用；旧前端不调用时保持 json
+func (a *App) SetPushEncoding(encoding string) string {
+	if a.marketPusher == nil {
+		return services.PushEncodingJSON
+	}
+	return a.marketPusher.SetEncoding(encoding)
+}
+
+// GetSessionHeatReport 获取收盘复盘数据：最活跃分钟、盘口突变、提醒时间线与回放跳转点
+func (a *App) GetSessionHeatReport(code string, topK int) *models.SessionHeatReport {
+	return a.sessionHeatReport(a.ctx, code, topK)
+}
+
+// sessionHeatReport 生成复盘数据，收盘简报与前端复盘共用
+func (a *App) sessionHeatReport(ctx context.Context, code string, topK int) *models.SessionHeatReport {
+	minutes, err := a.marketService.GetKLineData(ctx, code, "1m", 240)
+	if err != nil {
+		log.Error("获取分时数据失败: %v", err)
+		minutes = nil
+	}
+	recorder := services.NewIntradayRecorder()
+	if a.marketPusher != nil {
+		recorder = a.marketPusher.Recorder()
+	}
+	var alerts []models.Notification
+	if a.notifications != nil {
+		alerts = a.notifications.List(models.NotificationFilter{})
+	}
+	return recorder.BuildSessionHeatReport(code, minutes, alerts, topK)
+}
+
+// GetAIUsage 获取 AI 费用与 token 用量统计（按模型、Agent、会话汇总）
+func (a *App) GetAIUsage() adk.UsageSummary {
+	return adk.GetUsageTracker().Summary()
+}
+
+// OverrideAIBudget 临时放行 AI 预算上限
+// minutes: 放行时长（分钟），<=0 时默认30分钟
+func (a *App) OverrideAIBudget(minutes int) string {
+	if minutes <= 0 {
+		minutes = 30
+	}
+	until := adk.GetUsageTracker().Override(time.Duration(minutes) * time.Minute)
+	log.Info("AI 预算临时放行至 %s", until.Format("15:04:05"))
+	return "success"
+}
+
+// GetToolAuditLog 查询工具调用审计日志
+// date: 日期 2006-01-02，为空查询当天；toolName 为空不过滤；limit<=0 返回全部
+func (a *App) GetToolAuditLog(date, toolName string, limit int) []models.ToolAuditEntry {
+	return adk.GetToolSandbox().AuditLog(date, toolName, limit)
+}
+
+// logStreamInterval 日志推送合并间隔
+const logStreamInterval = 500 * time.Millisecond
+
+// logStreamMaxBatch 单次推送的最大日志条数，超出部分可通过 GetRecentLogs 查看
+const logStreamMaxBatch = 500
+
+// startLogStream 将新日志合并后定时通过 logs:append 事件推送给前端
+func (a *App) startLogStream(ctx context.Context) {
+	var mu sync.Mutex
+	var pending []logger.Entry
+	logger.SetOnAppend(func(e logger.Entry) {
+		mu.Lock()
+		if len(pending) < logStreamMaxBatch {
+			pending = append(pending, e)
+		}
+		mu.Unlock()
+	})
+	supervisor.Go(ctx, "log-stream", func(ctx context.Context) {
+		ticker := time.NewTicker(logStreamInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				logger.SetOnAppend(nil)
+				return
+			case <-ticker.C:
+				mu.Lock()
+				batch := pending
+				pending = nil
+				mu.Unlock()
+				if len(batch) > 0 {
+					runtime.EventsEmit(a.ctx, "logs:append", batch)
+				}
+			}
+		}
+	})
+}
+
+// GetRecentLogs 获取内存中最近的日志（按时间正序）
+// level: 最低级别，为空表示全部；module: 模块名前缀，为空不过滤；limit<=0 返回全部
+func (a *App) GetRecentLogs(level, module string, limit int) []logger.Entry {
+	minLevel := logger.DEBUG
+	if level != "" {
+		parsed, err := logger.ParseLevel(level)
+		if err != nil {
+			return []logger.Entry{}
+		}
+		minLevel = parsed
+	}
+	return logger.Recent(minLevel, module, limit)
+}
+
+// GenerateDiagnosticsBundle 生成诊断包（日志、脱敏配置、系统信息、协程堆栈），用于反馈问题
+func (a *App) GenerateDiagnosticsBundle() models.ReportResult {
+	path, err := a.writeDiagnostics("", nil)
+	if err != nil {
+		log.Error("生成诊断包失败: %v", err)
+		return models.ReportResult{Error: err.Error()}
+	}
+	log.Info("诊断包已生成: %s", path)
+	return models.ReportResult{Path: path}
+}
+
+// writeDiagnostics 写入诊断包，reason 和 stack 为空表示手动生成
+func (a *App) writeDiagnostics(reason string, stack []byte) (string, error) {
+	opts := diagnostics.Options{
+		DataDir: paths.GetDataDir(),
+		Version: Version,
+		Reason:  reason,
+		Stack:   stack,
+	}
+	if a != nil && a.configService != nil {
+		opts.Config = a.configService.GetConfig()
+	}
+	return diagnostics.WriteBundle(opts)
+}
+
+// RunDiagnostics 健康检查：上游连通性、代理、数据目录可写与 AI 模型配置，供首次运行向导展示
+func (a *App) RunDiagnostics() diagnostics.Report {
+	ctx := a.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	report := services.RunHealthCheck(ctx, a.configService.GetConfig(), paths.GetDataDir())
+	for _, c := range report.Checks {
+		if c.Status == diagnostics.StatusFail {
+			log.Warn("自检失败 [%s] %s: %s", c.Category, c.Name, c.Message)
+		}
+	}
+	return report
+}
+
+// DetectSystemProxy 重新检测操作系统代理设置，用于代理设置页展示
+func (a *App) DetectSystemProxy() proxy.SystemProxy {
+	return proxy.GetManager().DetectSystemProxy()
+}
+
+// GetRateLimitStats 获取上游域名限流使用情况（调试用）
+func (a *App) GetRateLimitStats() []proxy.HostRateStats {
+	return proxy.GetManager().GetRateLimitStats()
+}
+
+// GetConnectionStats 获取各上游主机的连接复用率与延迟（调试用）
+func (a *App) GetConnectionStats() []proxy.HostConnStats {
+	return proxy.GetManager().GetConnStats()
+}
+
+// ResetConnectionStats 清空连接统计（调试用）
+func (a *App) ResetConnectionStats() {
+	proxy.GetManager().ResetConnStats()
+}
+
+// GetDataSourceHealth 获取各上游数据源的健康状态（错误率、延迟、熔断状态）
+func (a *App) GetDataSourceHealth() []services.SourceHealth {
+	return a.marketService.GetDataSourceHealth()
+}
+
+// DebugGoroutines 获取常驻任务状态与 goroutine 分组统计（排查泄漏用）
+func (a *App) DebugGoroutines() supervisor.Report {
+	return supervisor.Default.Debug()
+}
+
+// GetMetrics 获取内部运行指标（上游延迟、缓存命中、推送耗时、事件队列等，调试用）
+func (a *App) GetMetrics() []metrics.Family {
+	return metrics.Default.Snapshot()
+}
+
+// emitError 向前端推送结构化错误，前端按错误码显示本地化提示与重试按钮
+func (a *App) emitError(op string, err error) {
+	if a.ctx == nil || err == nil {
+		return
+	}
+	code := apperr.CodeOf(err)
+	runtime.EventsEmit(a.ctx, "app:error", models.ErrorInfo{
+		Op:        op,
+		Code:      string(code),
+		Message:   err.Error(),
+		Hint:      i18n.T("error." + string(code)),
+		Retryable: code.Retryable(),
+	})
+}
+
 // NotifyFrontendReady 前端通知已准备好，开始推送数据
 func (a *App) NotifyFrontendReady() {
 	if a.marketPusher != nil {
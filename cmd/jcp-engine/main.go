@@ -0,0 +1,63 @@
+// jcp-engine 无界面远程数据引擎
+// 部署在网络更稳定的家庭服务器/NAS 上，桌面端在设置中填写引擎地址后通过 gRPC 获取行情与录制的分时
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/run-bigpig/jcp/internal/engine"
+	"github.com/run-bigpig/jcp/internal/pkg/paths"
+	"github.com/run-bigpig/jcp/internal/services"
+)
+
+// Version 版本号，通过 ldflags 注入
+var Version = "dev"
+
+func main() {
+	addr := flag.String("listen", "127.0.0.1:50051", "监听地址，未配置 TLS 时只能监听本机回环地址")
+	token := flag.String("token", os.Getenv("JCP_ENGINE_TOKEN"), "客户端鉴权令牌（也可通过 JCP_ENGINE_TOKEN 设置）")
+	certFile := flag.String("tls-cert", "", "TLS 证书文件（PEM）")
+	keyFile := flag.String("tls-key", "", "TLS 私钥文件（PEM）")
+	dataDir := flag.String("data", filepath.Join(paths.GetDataDir(), "engine"), "分时录制数据目录")
+	flag.Parse()
+
+	var tlsCfg *tls.Config
+	if *certFile != "" || *keyFile != "" {
+		cfg, err := engine.LoadServerTLS(*certFile, *keyFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		tlsCfg = cfg
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	marketService := services.NewMarketService()
+	recorder := engine.NewRecorder(*dataDir, marketService)
+	recorder.Start(ctx)
+	defer recorder.Close()
+
+	server := engine.NewServer(marketService, Version)
+	server.SetRecorder(recorder)
+	if err := server.Start(*addr, *token, tlsCfg); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if *token == "" {
+		fmt.Println("警告: 未设置 -token，任何能访问该端口的客户端都可以读取数据")
+	}
+	fmt.Printf("jcp-engine %s 已启动: %s\n", Version, server.Addr())
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	<-sig
+	server.Stop()
+}
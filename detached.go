@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"embed"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+	"github.com/run-bigpig/jcp/internal/pkg/paths"
+	"github.com/run-bigpig/jcp/internal/pkg/proxy"
+	"github.com/run-bigpig/jcp/internal/services"
+	"github.com/wailsapp/wails/v2"
+	"github.com/wailsapp/wails/v2/pkg/options"
+	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
+)
+
+// runDetached 分离窗口子进程：以独立的原生窗口显示一只股票的K线图或盘口
+// 只初始化配置与行情服务，窗口订阅由本进程的 WindowPusher 推送
+func runDetached(sub services.WindowSubscription, assets embed.FS) error {
+	app := newDetachedApp(sub)
+
+	title := sub.Name
+	if title == "" {
+		title = sub.Code
+	}
+	width, height := 900, 560
+	if sub.Kind == services.WindowKindOrderBook {
+		title += " 盘口"
+		width, height = 360, 520
+	} else {
+		title += " K线"
+	}
+
+	return wails.Run(&options.App{
+		Title:     title,
+		Width:     width,
+		Height:    height,
+		MinWidth:  280,
+		MinHeight: 240,
+		AssetServer: &assetserver.Options{
+			Assets: assets,
+		},
+		BackgroundColour: &options.RGBA{R: 27, G: 38, B: 54, A: 1},
+		OnStartup:        app.startupDetached,
+		OnShutdown:       app.shutdownDetached,
+		Bind: []interface{}{
+			app,
+		},
+	})
+}
+
+// newDetachedApp 创建分离窗口进程的 App，前端只会调用配置读取与窗口信息接口
+func newDetachedApp(sub services.WindowSubscription) *App {
+	dataDir := paths.GetDataDir()
+	configService, err := services.NewConfigService(dataDir)
+	if err != nil {
+		panic(err)
+	}
+	i18n.SetLang(configService.GetConfig().Language)
+
+	marketService := services.NewMarketService()
+	return &App{
+		configService: configService,
+		marketService: marketService,
+		detached:      &sub,
+	}
+}
+
+// startupDetached 分离窗口进程启动：应用代理与数据源配置，启动窗口推送
+func (a *App) startupDetached(ctx context.Context) {
+	ctx, a.rootCancel = context.WithCancel(ctx)
+	a.ctx = ctx
+
+	proxy.GetManager().SetConfig(&a.configService.GetConfig().Proxy)
+	proxy.GetManager().SetRateLimits(a.configService.GetConfig().RateLimits)
+	proxy.GetManager().SetConnPools(a.configService.GetConfig().ConnPools)
+	a.applyEngineConfig(&a.configService.GetConfig().Engine)
+
+	// 画线由主窗口编辑，跨进程不共享缓存，每次全量推送时从磁盘读取
+	dataDir := paths.GetDataDir()
+	a.windowPusher = services.NewWindowPusher(a.marketService, func(code, period string) []models.ChartDrawing {
+		return services.NewDrawingService(dataDir).GetDrawings(code, period)
+	})
+	a.windowPusher.Start(ctx)
+	log.Info("分离窗口已启动: %s %s", a.detached.Kind, a.detached.Code)
+}
+
+// shutdownDetached 分离窗口进程退出
+func (a *App) shutdownDetached(ctx context.Context) {
+	if a.windowPusher != nil {
+		a.windowPusher.Stop()
+	}
+	if a.rootCancel != nil {
+		a.rootCancel()
+	}
+	a.setEngineClient(nil, a.engineCfg)
+}
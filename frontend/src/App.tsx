@@ -7,21 +7,33 @@ import { SettingsDialog } from './components/SettingsDialog';
 import { PositionDialog } from './components/PositionDialog';
 import { HotTrendDialog } from './components/HotTrendDialog';
 import { LongHuBangDialog } from './components/LongHuBangDialog';
+import { RiskDialog } from './components/RiskDialog';
+import { JournalDialog } from './components/JournalDialog';
+import { NotificationDrawer } from './components/NotificationDrawer';
+import { OptionChainDialog } from './components/OptionChainDialog';
+import { ShareholderDialog } from './components/ShareholderDialog';
+import { LogViewerDialog } from './components/LogViewerDialog';
+import { UpdateNotice } from './components/UpdateNotice';
+import { ErrorNotice } from './components/ErrorNotice';
+import { DetachedKind } from './hooks/useDetachedWindow';
 import { WelcomePage } from './components/WelcomePage';
 import { ThemeSwitcher } from './components/ThemeSwitcher';
 import { useTheme } from './contexts/ThemeContext';
 import { useCandleColor } from './contexts/CandleColorContext';
 import { ResizeHandle } from './components/ResizeHandle';
 import { getWatchlist, addToWatchlist, removeFromWatchlist } from './services/watchlistService';
-import { getKLineData, getOrderBook } from './services/stockService';
+import { getKLineData, getOrderBook, setPushPaused, onPushPausedChange, getIntradayHistory, getIntradayHistoryDates, getStockStats, isFutures, getFuturesQuotes } from './services/stockService';
 import { getOrCreateSession, StockSession, updateStockPosition } from './services/sessionService';
-import { getConfig, updateConfig } from './services/configService';
+import { getConfig, updateConfig, onNotifyMuted } from './services/configService';
+import { getUnreadNotificationCount, onNotification } from './services/notificationService';
+import { getChartDrawings, onDrawingsUpdate } from './services/drawingService';
 import { useMarketEvents } from './hooks/useMarketEvents';
 import { useMarketStatus } from './hooks/useMarketStatus';
-import { Stock, KLineData, OrderBook, TimePeriod, Telegraph, MarketIndex } from './types';
-import { Radio, Settings, List, Minus, Square, X, Copy, Briefcase, TrendingUp, BarChart3 } from 'lucide-react';
+import { Stock, KLineData, OrderBook, TimePeriod, Telegraph, MarketIndex, InterestRates, GlobalIndex } from './types';
+import { Radio, Settings, List, Minus, Square, X, Copy, Briefcase, TrendingUp, BarChart3, ScrollText, Pause, Play, ExternalLink, ShieldAlert, BookOpen, Layers, Bell, Users } from 'lucide-react';
 import logo from './assets/images/logo.png';
-import { GetTelegraphList, OpenURL, WindowMinimize, WindowMaximize, WindowClose } from '../wailsjs/go/main/App';
+import { GetTelegraphList, OpenURL, OpenDetachedWindow, WindowMinimize, WindowMaximize, WindowClose } from '../wailsjs/go/main/App';
+import type { models } from '../wailsjs/go/models';
 import { WindowIsMaximised, WindowSetSize, WindowGetSize } from '../wailsjs/runtime/runtime';
 
 // 布局配置常量
@@ -51,7 +63,12 @@ const App: React.FC = () => {
   const [currentSession, setCurrentSession] = useState<StockSession | null>(null);
   const [timePeriod, setTimePeriod] = useState<TimePeriod>('1m');
   const [kLineData, setKLineData] = useState<KLineData[]>([]);
+  const [chartDrawings, setChartDrawings] = useState<models.ChartDrawing[]>([]);
   const [kLineUpdateMode, setKLineUpdateMode] = useState<KLineUpdateMode>('full');
+  const [intradayDate, setIntradayDate] = useState('');
+  const [intradayDates, setIntradayDates] = useState<string[]>([]);
+  const [stockStats, setStockStats] = useState<models.StockStats | null>(null);
+  const [futuresQuote, setFuturesQuote] = useState<models.FuturesQuote | null>(null);
   const [orderBook, setOrderBook] = useState<OrderBook>({ bids: [], asks: [] });
   const [marketMessage, setMarketMessage] = useState<string>('市场数据加载中...');
   const [telegraphList, setTelegraphList] = useState<Telegraph[]>([]);
@@ -62,7 +79,17 @@ const App: React.FC = () => {
   const [showPosition, setShowPosition] = useState(false);
   const [showHotTrend, setShowHotTrend] = useState(false);
   const [showLongHuBang, setShowLongHuBang] = useState(false);
+  const [showRisk, setShowRisk] = useState(false);
+  const [showNotifications, setShowNotifications] = useState(false);
+  const [unreadCount, setUnreadCount] = useState(0);
+  const [showJournal, setShowJournal] = useState(false);
+  const [showOptionChain, setShowOptionChain] = useState(false);
+  const [showShareholders, setShowShareholders] = useState(false);
+  const [showLogs, setShowLogs] = useState(false);
+  const [pushPaused, setPushPausedState] = useState(false);
   const [marketIndices, setMarketIndices] = useState<MarketIndex[]>([]);
+  const [interestRates, setInterestRates] = useState<InterestRates | null>(null);
+  const [globalIndices, setGlobalIndices] = useState<GlobalIndex[]>([]);
   const [isMaximized, setIsMaximized] = useState(false);
   const klineRequestIdRef = useRef(0);
 
@@ -103,6 +130,55 @@ const App: React.FC = () => {
     }
   }, []);
 
+  // 老板键隐藏窗口期间不弹出系统通知
+  const notifyMutedRef = useRef(false);
+  useEffect(() => {
+    return onNotifyMuted((muted) => {
+      notifyMutedRef.current = muted;
+    });
+  }, []);
+
+  // 通知中心：新通知到达时刷新未读数并弹出系统通知（快讯提醒需开启桌面通知，免打扰时段后端不推送）
+  const refreshUnread = useCallback(() => {
+    getUnreadNotificationCount().then(setUnreadCount);
+  }, []);
+  useEffect(() => {
+    refreshUnread();
+    return onNotification(async (n) => {
+      refreshUnread();
+      if (notifyMutedRef.current || typeof Notification === 'undefined') return;
+      if (n.category === 'news') {
+        const config = await getConfig();
+        if (!config.news?.desktopNotify) return;
+      }
+      if (Notification.permission === 'default') {
+        await Notification.requestPermission();
+      }
+      if (Notification.permission === 'granted') {
+        new Notification(n.title, { body: n.body });
+      }
+    });
+  }, [refreshUnread]);
+
+  // 在独立的原生窗口打开K线图/盘口，每个窗口独立订阅
+  const openDetached = useCallback(async (kind: DetachedKind, stock: Stock) => {
+    const res = await OpenDetachedWindow(kind, stock.symbol, stock.name);
+    if (res !== 'success') console.error('打开分离窗口失败:', res);
+  }, []);
+
+  // 暂停/恢复行情推送
+  const togglePushPaused = useCallback(async () => {
+    const err = await setPushPaused(!pushPaused);
+    if (err) {
+      console.error('切换行情推送失败:', err);
+      return;
+    }
+    setPushPausedState(!pushPaused);
+  }, [pushPaused]);
+
+  // 托盘菜单等其他入口切换暂停时同步按钮状态
+  useEffect(() => onPushPausedChange(setPushPausedState), []);
+
   // 处理大盘指数更新（来自后端推送）
   const handleMarketIndicesUpdate = useCallback((indices: MarketIndex[]) => {
     if (indices) {
@@ -110,24 +186,40 @@ const App: React.FC = () => {
     }
   }, []);
 
+  // 处理资金面利率更新
+  const handleInterestRatesUpdate = useCallback((rates: InterestRates) => {
+    if (rates) {
+      setInterestRates(rates);
+    }
+  }, []);
+
+  // 处理全球指数更新
+  const handleGlobalIndicesUpdate = useCallback((indices: GlobalIndex[]) => {
+    if (indices) {
+      setGlobalIndices(indices);
+    }
+  }, []);
+
   // 处理K线数据更新（来自后端推送，支持增量）
-  const handleKLineUpdate = useCallback((data: { code: string; period: string; data: KLineData[]; incremental?: boolean }) => {
-    if (!data || data.code !== selectedSymbol || data.period !== timePeriod) return;
+  const handleKLineUpdate = useCallback((data: { code: string; period: string; data: KLineData[]; incremental?: boolean; drawings?: models.ChartDrawing[] }) => {
+    if (!data || data.code !== selectedSymbol || data.period !== timePeriod || intradayDate) return;
 
     if (data.incremental && data.data.length > 0) {
       setKLineUpdateMode('incremental');
-      // 增量更新：合并最新K线
+      // 增量更新：逐根合并最新K线
       setKLineData(prev => {
         if (prev.length === 0) return data.data;
-        const newBar = data.data[0];
-        const lastIdx = prev.length - 1;
-        // 同一时间戳则更新，否则追加
-        if (prev[lastIdx].time === newBar.time) {
-          const updated = [...prev];
-          updated[lastIdx] = newBar;
-          return updated;
+        let merged = prev;
+        for (const newBar of data.data) {
+          const lastIdx = merged.length - 1;
+          // 同一时间戳则更新，否则追加
+          if (merged[lastIdx].time === newBar.time) {
+            merged = [...merged.slice(0, -1), newBar];
+          } else {
+            merged = [...merged.slice(-239), newBar]; // 保持240根
+          }
         }
-        return [...prev.slice(-239), newBar]; // 保持240根
+        return merged;
       });
     } else {
       // 后端定时推送：用 refresh 模式更新数据但保留用户缩放状态
@@ -135,8 +227,9 @@ const App: React.FC = () => {
         setKLineUpdateMode('refresh');
         setKLineData(data.data);
       }
+      if (data.drawings) setChartDrawings(data.drawings);
     }
-  }, [selectedSymbol, timePeriod]);
+  }, [selectedSymbol, timePeriod, intradayDate]);
 
   const syncWindowMaximizedState = useCallback(async () => {
     try {
@@ -262,6 +355,8 @@ const App: React.FC = () => {
     onOrderBookUpdate: handleOrderBookUpdate,
     onTelegraphUpdate: handleTelegraphUpdate,
     onMarketIndicesUpdate: handleMarketIndicesUpdate,
+    onInterestRatesUpdate: handleInterestRatesUpdate,
+    onGlobalIndicesUpdate: handleGlobalIndicesUpdate,
     onKLineUpdate: handleKLineUpdate,
   });
 
@@ -356,6 +451,15 @@ const App: React.FC = () => {
     loadWatchlist();
   }, [subscribeOrderBook]);
 
+  // 其他窗口修改画线后同步
+  useEffect(() => {
+    if (!selectedSymbol) return;
+    return onDrawingsUpdate((update) => {
+      if (update.symbol !== selectedSymbol || (update.period !== '' && update.period !== timePeriod)) return;
+      setChartDrawings(update.drawings || []);
+    });
+  }, [selectedSymbol, timePeriod]);
+
   // Load K-line data when symbol or period changes
   useEffect(() => {
     if (!selectedSymbol) return;
@@ -364,10 +468,22 @@ const App: React.FC = () => {
     setKLineUpdateMode('full');
     // 清空旧数据，避免切换期间出现“新股票 + 旧K线”错配
     setKLineData([]);
+    setChartDrawings([]);
     // 订阅K线推送
     subscribeKLine(selectedSymbol, timePeriod);
+    getChartDrawings(selectedSymbol, timePeriod).then((drawings) => {
+      if (requestId === klineRequestIdRef.current) setChartDrawings(drawings);
+    });
 
     const loadKLineData = async () => {
+      // 查看历史分时：读取本地归档，不再重试
+      if (timePeriod === '1m' && intradayDate) {
+        const data = await getIntradayHistory(selectedSymbol, intradayDate);
+        if (requestId !== klineRequestIdRef.current) return;
+        setKLineUpdateMode('full');
+        setKLineData(Array.isArray(data) ? data : []);
+        return;
+      }
       // 与后端推送统一数据长度，降低周/月K空响应概率
       const dataLen = timePeriod === '1m' ? 250 : 240;
       const maxRetries = 2;
@@ -393,7 +509,33 @@ const App: React.FC = () => {
     };
 
     void loadKLineData();
-  }, [selectedSymbol, timePeriod, subscribeKLine]);
+  }, [selectedSymbol, timePeriod, intradayDate, subscribeKLine]);
+
+  // 切换股票时回到当日分时，并加载已归档的历史日期与区间统计
+  useEffect(() => {
+    setIntradayDate('');
+    setIntradayDates([]);
+    setStockStats(null);
+    setFuturesQuote(null);
+    if (!selectedSymbol) return;
+    let cancelled = false;
+    if (isFutures(selectedSymbol)) {
+      // 期货：定时刷新持仓量与基差
+      const loadQuote = () => getFuturesQuotes([selectedSymbol])
+        .then((quotes) => { if (!cancelled) setFuturesQuote(quotes[0] || null); })
+        .catch(() => {});
+      loadQuote();
+      const timer = setInterval(loadQuote, 10000);
+      return () => { cancelled = true; clearInterval(timer); };
+    }
+    getIntradayHistoryDates(selectedSymbol)
+      .then((dates) => { if (!cancelled) setIntradayDates(dates || []); })
+      .catch(() => {});
+    getStockStats(selectedSymbol)
+      .then((stats) => { if (!cancelled) setStockStats(stats); })
+      .catch(() => {});
+    return () => { cancelled = true; };
+  }, [selectedSymbol]);
 
   // 初始化窗口最大化状态
   useEffect(() => {
@@ -486,6 +628,53 @@ const App: React.FC = () => {
           >
             <TrendingUp className="h-4 w-4" />
           </button>
+          <button
+            onClick={() => setShowOptionChain(true)}
+            className={`p-2 rounded-lg fin-panel border fin-divider transition-colors ${colors.isDark ? 'text-slate-300 hover:text-white' : 'text-slate-600 hover:text-slate-900'} hover:border-accent/40`}
+            title="期权T型报价"
+          >
+            <Layers className="h-4 w-4" />
+          </button>
+          <button
+            onClick={() => setShowJournal(true)}
+            className={`p-2 rounded-lg fin-panel border fin-divider transition-colors ${colors.isDark ? 'text-slate-300 hover:text-white' : 'text-slate-600 hover:text-slate-900'} hover:border-accent/40`}
+            title="交易日志"
+          >
+            <BookOpen className="h-4 w-4" />
+          </button>
+          <button
+            onClick={() => setShowRisk(true)}
+            className={`p-2 rounded-lg fin-panel border fin-divider transition-colors ${colors.isDark ? 'text-slate-300 hover:text-white' : 'text-slate-600 hover:text-slate-900'} hover:border-accent/40`}
+            title="仓位与风险"
+          >
+            <ShieldAlert className="h-4 w-4" />
+          </button>
+          <button
+            onClick={() => setShowNotifications(true)}
+            className={`relative p-2 rounded-lg fin-panel border fin-divider transition-colors ${colors.isDark ? 'text-slate-300 hover:text-white' : 'text-slate-600 hover:text-slate-900'} hover:border-accent/40`}
+            title="通知中心"
+          >
+            <Bell className="h-4 w-4" />
+            {unreadCount > 0 && (
+              <span className="absolute -top-1 -right-1 min-w-[16px] h-4 px-1 rounded-full bg-red-500 text-white text-[10px] leading-4 text-center">
+                {unreadCount > 99 ? '99+' : unreadCount}
+              </span>
+            )}
+          </button>
+          <button
+            onClick={togglePushPaused}
+            className={`p-2 rounded-lg fin-panel border fin-divider transition-colors ${pushPaused ? 'text-amber-400 border-amber-400/40' : colors.isDark ? 'text-slate-300 hover:text-white' : 'text-slate-600 hover:text-slate-900'} hover:border-accent/40`}
+            title={pushPaused ? '恢复行情推送' : '暂停行情推送'}
+          >
+            {pushPaused ? <Play className="h-4 w-4" /> : <Pause className="h-4 w-4" />}
+          </button>
+          <button
+            onClick={() => setShowLogs(true)}
+            className={`p-2 rounded-lg fin-panel border fin-divider transition-colors ${colors.isDark ? 'text-slate-300 hover:text-white' : 'text-slate-600 hover:text-slate-900'} hover:border-accent/40`}
+            title="运行日志"
+          >
+            <ScrollText className="h-4 w-4" />
+          </button>
           <ThemeSwitcher />
           <button
             onClick={() => setShowSettings(true)}
@@ -542,6 +731,8 @@ const App: React.FC = () => {
             onAddStock={handleAddStock}
             onRemoveStock={handleRemoveStock}
             marketIndices={marketIndices}
+            interestRates={interestRates}
+            globalIndices={globalIndices}
           />
         </div>
 
@@ -580,6 +771,14 @@ const App: React.FC = () => {
                     <span>设置持仓</span>
                   )}
                 </button>
+                <button
+                  onClick={() => setShowShareholders(true)}
+                  className={`flex items-center gap-1 px-2 py-1 rounded text-xs transition-colors ${colors.isDark ? 'text-slate-400 hover:bg-slate-700/50' : 'text-slate-500 hover:bg-slate-200/50'} hover:text-accent-2`}
+                  title="股东数据"
+                >
+                  <Users className="h-3.5 w-3.5" />
+                  <span>股东</span>
+                </button>
               </div>
               <div className={`text-3xl font-mono font-bold ${cc.getColorClass(selectedStock.change >= 0)}`}>
                 {selectedStock.price.toFixed(2)}
@@ -594,8 +793,21 @@ const App: React.FC = () => {
                   {selectedStock.change >= 0 ? '+' : ''}{selectedStock.changePercent.toFixed(2)}%
                 </span>
               </div>
-              <div className={`text-xs ${colors.isDark ? 'text-slate-500' : 'text-slate-400'}`}>
-                {new Date().toLocaleTimeString('zh-CN', { hour: '2-digit', minute: '2-digit', second: '2-digit' })}
+              <div className={`flex items-center gap-3 text-xs ${colors.isDark ? 'text-slate-500' : 'text-slate-400'}`}>
+                {(['kline', 'orderbook'] as const).map(kind => (
+                  <button
+                    key={kind}
+                    onClick={() => openDetached(kind, selectedStock)}
+                    className="flex items-center gap-1 hover:text-accent-2 transition-colors"
+                    title={kind === 'kline' ? '在独立窗口打开K线图' : '在独立窗口打开盘口'}
+                  >
+                    <ExternalLink className="h-3 w-3" />
+                    {kind === 'kline' ? 'K线' : '盘口'}
+                  </button>
+                ))}
+                <span>
+                  {new Date().toLocaleTimeString('zh-CN', { hour: '2-digit', minute: '2-digit', second: '2-digit' })}
+                </span>
               </div>
             </div>
           </div>
@@ -609,6 +821,26 @@ const App: React.FC = () => {
             <AStockStatItem label="最低" value={selectedStock.low} preClose={selectedStock.preClose} isDark={colors.isDark} />
             <AStockStatItem label="成交额" value={formatAmount(selectedStock.amount)} isPlain isDark={colors.isDark} />
             <AStockStatItem label="振幅" value={selectedStock.preClose > 0 ? ((selectedStock.high - selectedStock.low) / selectedStock.preClose * 100).toFixed(2) + '%' : '--'} isPlain isDark={colors.isDark} />
+            {isFutures(selectedStock.symbol) ? (
+              <>
+                <AStockStatItem label="持仓量" value={futuresQuote ? formatVolume(futuresQuote.openInterest) : '--'} isPlain isDark={colors.isDark} />
+                <AStockStatItem label="昨结算" value={futuresQuote ? futuresQuote.preSettle : '--'} isPlain isDark={colors.isDark} />
+                {futuresQuote?.spotCode && (
+                  <>
+                    <AStockStatItem label="现货指数" value={futuresQuote.spotPrice || '--'} isPlain isDark={colors.isDark} />
+                    <AStockStatItem label="基差" value={futuresQuote.spotPrice ? `${futuresQuote.basis.toFixed(2)} (${futuresQuote.basisPercent.toFixed(2)}%)` : '--'} isPlain isDark={colors.isDark} />
+                  </>
+                )}
+              </>
+            ) : (
+              <>
+                <AStockStatItem label="52周高" value={stockStats ? stockStats.high52w : '--'} isPlain isDark={colors.isDark} />
+                <AStockStatItem label="52周低" value={stockStats ? stockStats.low52w : '--'} isPlain isDark={colors.isDark} />
+                <AStockStatItem label="距52周高" value={stockStats ? stockStats.fromHigh52w.toFixed(2) + '%' : '--'} isPlain isDark={colors.isDark} />
+                <AStockStatItem label="历史最高" value={stockStats ? stockStats.allTimeHigh : '--'} isPlain isDark={colors.isDark} />
+                <AStockStatItem label={stockStats && stockStats.streak < 0 ? '连跌' : '连涨'} value={stockStats ? `${Math.abs(stockStats.streak)}天` : '--'} isPlain isDark={colors.isDark} />
+              </>
+            )}
           </div>
 
           <div className="flex-1 flex flex-col min-h-0">
@@ -619,7 +851,11 @@ const App: React.FC = () => {
                   updateMode={kLineUpdateMode}
                   period={timePeriod}
                   onPeriodChange={setTimePeriod}
-                  stock={selectedStock}
+                  stock={intradayDate && timePeriod === '1m' ? { ...selectedStock, preClose: 0 } : selectedStock}
+                  historyDates={intradayDates}
+                  historyDate={intradayDate}
+                  onHistoryDateChange={setIntradayDate}
+                  drawings={intradayDate ? undefined : chartDrawings}
                />
             </div>
 
@@ -665,6 +901,35 @@ const App: React.FC = () => {
       />
       <HotTrendDialog isOpen={showHotTrend} onClose={() => setShowHotTrend(false)} />
       <LongHuBangDialog isOpen={showLongHuBang} onClose={() => setShowLongHuBang(false)} />
+      <OptionChainDialog isOpen={showOptionChain} onClose={() => setShowOptionChain(false)} />
+      <ShareholderDialog
+        isOpen={showShareholders}
+        symbol={selectedStock.symbol}
+        name={selectedStock.name}
+        onClose={() => setShowShareholders(false)}
+      />
+      <NotificationDrawer
+        isOpen={showNotifications}
+        onClose={() => setShowNotifications(false)}
+        onChanged={refreshUnread}
+      />
+      <JournalDialog
+        isOpen={showJournal}
+        onClose={() => setShowJournal(false)}
+        symbol={selectedStock.symbol}
+        stockName={selectedStock.name}
+        currentPrice={selectedStock.price}
+      />
+      <RiskDialog
+        isOpen={showRisk}
+        onClose={() => setShowRisk(false)}
+        stockName={selectedStock.name}
+        currentPrice={selectedStock.price}
+        stopPrice={selectedStock.stopPrice}
+      />
+      <LogViewerDialog isOpen={showLogs} onClose={() => setShowLogs(false)} />
+      <UpdateNotice />
+      <ErrorNotice />
     </div>
   );
 };
@@ -1,14 +1,14 @@
 import React, { useState, useEffect, useRef } from 'react';
 import { Stock, KLineData } from '../types';
 import { getAgentConfigs, AgentConfig } from '../services/strategyService';
-import { StockSession, ChatMessage, sendMeetingMessage, MeetingMessageRequest, getSessionMessages, retryAgent, retryAgentAndContinue, cancelInterruptedMeeting } from '../services/sessionService';
+import { StockSession, ChatMessage, sendMeetingMessage, MeetingMessageRequest, OrchestrationConfig, OrchestrationMode, getSessionMessages, retryAgent, retryAgentAndContinue, cancelInterruptedMeeting, listDiscussions, resumeDiscussion, DiscussionSummary } from '../services/sessionService';
 import { MessageSquare, Loader2, Send, User, Users, X, Reply, Trash2, Wrench, CheckCircle2, AlertCircle, Copy, Check, RotateCcw, Pencil, Square } from 'lucide-react';
 import { clearSessionMessages } from '../services/sessionService';
 import { NodeRenderer } from 'markstream-react';
 import { EventsOn, EventsOff } from '../../wailsjs/runtime/runtime';
 import { useMentionPicker } from '../hooks/useMentionPicker';
 import { useTheme } from '../contexts/ThemeContext';
-import { CancelMeeting } from '../../wailsjs/go/main/App';
+import { CancelDiscussion } from '../../wailsjs/go/main/App';
 import 'markstream-react/index.css';
 
 // 进度事件类型
@@ -41,6 +41,8 @@ export const AgentRoom: React.FC<AgentRoomProps> = ({ session, onSessionUpdate }
   const [messages, setMessages] = useState<ChatMessage[]>([]);
   const [simulatingMap, setSimulatingMap] = useState<Record<string, boolean>>({});
   const [userQuery, setUserQuery] = useState('');
+  const [orchestration, setOrchestration] = useState<OrchestrationConfig>({ mode: 'round_robin', maxRounds: 1, earlyStop: true });
+  const [resumable, setResumable] = useState<DiscussionSummary | null>(null);
   const scrollRef = useRef<HTMLDivElement>(null);
   const inputRef = useRef<HTMLInputElement>(null);
 
@@ -101,8 +103,8 @@ export const AgentRoom: React.FC<AgentRoomProps> = ({ session, onSessionUpdate }
 
   // 取消指定股票的会议
   const cancelMeeting = (stockCode: string) => {
-    // 调用后端取消 API
-    CancelMeeting(stockCode).catch(err => {
+    // 调用后端取消 API（专家已输出的部分内容会以 cancelled 消息推送）
+    CancelDiscussion(stockCode).catch(err => {
       console.error('[AgentRoom] 取消会议失败:', err);
     });
     // 前端状态重置
@@ -171,6 +173,19 @@ export const AgentRoom: React.FC<AgentRoomProps> = ({ session, onSessionUpdate }
     currentStockCodeRef.current = newStockCode;
   }, [session?.stockCode]);
 
+  // 讨论结束或切换股票后，检查最近一次讨论是否可恢复
+  useEffect(() => {
+    if (!session?.stockCode || isSimulating) {
+      setResumable(null);
+      return;
+    }
+    const stockCode = session.stockCode;
+    listDiscussions(stockCode).then(list => {
+      if (currentStockCodeRef.current !== stockCode) return;
+      setResumable(list?.[0]?.resumable ? list[0] : null);
+    });
+  }, [session?.stockCode, isSimulating]);
+
   // 订阅会议消息事件（实时接收发言）
   useEffect(() => {
     if (!session?.stockCode) return;
@@ -178,8 +193,8 @@ export const AgentRoom: React.FC<AgentRoomProps> = ({ session, onSessionUpdate }
     const stockCode = session.stockCode;
     const eventName = `meeting:message:${stockCode}`;
     const cleanup = EventsOn(eventName, (msg: ChatMessage) => {
-      // 检查是否已取消或切换了股票
-      if (meetingCancelledRef.current[stockCode]) return;
+      // 检查是否已取消或切换了股票（停止时推送的部分内容仍需展示）
+      if (meetingCancelledRef.current[stockCode] && !msg.cancelled) return;
       if (currentStockCodeRef.current === stockCode) {
         setMessages(prev => [...prev, { ...msg, id: `msg-${Date.now()}-${Math.random()}`, timestamp: Date.now() }]);
       }
@@ -284,7 +299,8 @@ export const AgentRoom: React.FC<AgentRoomProps> = ({ session, onSessionUpdate }
         content: query,
         mentionIds: mentions,
         replyToId: replyTo?.id || '',
-        replyContent: replyTo?.content || ''
+        replyContent: replyTo?.content || '',
+        orchestration
       };
 
       // 统一模式：无论智能模式还是直接@模式，消息都通过事件实时推送
@@ -426,6 +442,25 @@ export const AgentRoom: React.FC<AgentRoomProps> = ({ session, onSessionUpdate }
     }
   };
 
+  // 从讨论记录恢复未完成的讨论
+  const handleResumeDiscussion = async () => {
+    if (!session || !resumable || isSimulating) return;
+    const stockCode = session.stockCode;
+
+    meetingCancelledRef.current[stockCode] = false;
+    setSimulatingMap(prev => ({ ...prev, [stockCode]: true }));
+    // 移除失败的消息，恢复后重新推送
+    setMessages(prev => prev.filter(m => !(m.error && m.meetingMode === 'smart')));
+    try {
+      await resumeDiscussion(resumable.id);
+    } catch (e) {
+      console.error('[AgentRoom] resumeDiscussion error:', e);
+      addSystemMessage('恢复讨论失败');
+    } finally {
+      setSimulatingMap(prev => ({ ...prev, [stockCode]: false }));
+    }
+  };
+
   // 放弃中断的会议（串行模式下用户放弃剩余专家）
   const handleAbandonMeeting = async (msg: ChatMessage) => {
     if (!session) return;
@@ -613,9 +648,17 @@ export const AgentRoom: React.FC<AgentRoomProps> = ({ session, onSessionUpdate }
                 <div className="flex items-baseline gap-2 mb-1">
                   <span className={`text-xs font-bold ${msg.error ? 'text-red-400' : (colors.isDark ? 'text-slate-300' : 'text-slate-600')}`}>{msg.agentName || agent?.name}</span>
                   <span className={`text-[9px] uppercase border fin-divider px-1 rounded fin-chip ${colors.isDark ? 'text-slate-500' : 'text-slate-400'}`}>{msg.role || agent?.role}</span>
+                  {(msg.round ?? 0) > 1 && (
+                    <span className={`text-[9px] border fin-divider px-1 rounded ${colors.isDark ? 'text-slate-400' : 'text-slate-500'}`}>
+                      第{msg.round}轮{msg.msgType === 'rebuttal' ? '·反驳' : msg.msgType === 'vote' ? '·投票' : ''}
+                    </span>
+                  )}
                   {msg.error && (
                     <span className="text-[9px] px-1 rounded bg-red-500/20 text-red-400 border border-red-500/30">失败</span>
                   )}
+                  {msg.cancelled && (
+                    <span className={`text-[9px] px-1 rounded border fin-divider ${colors.isDark ? 'text-slate-400' : 'text-slate-500'}`}>已停止</span>
+                  )}
                 </div>
                 <div className="relative">
                   {msg.error ? (
@@ -826,8 +869,59 @@ export const AgentRoom: React.FC<AgentRoomProps> = ({ session, onSessionUpdate }
             )}
           </form>
         </div>
-        <div className="mt-1 text-center">
+        <div className="mt-1 flex items-center justify-center gap-2">
           <span className={`text-[10px] ${colors.isDark ? 'text-slate-600' : 'text-slate-400'}`}>直接提问由小韭菜安排韭菜专家，@ 可指定韭菜专家</span>
+          {resumable && !isSimulating && (
+            <button
+              type="button"
+              onClick={handleResumeDiscussion}
+              className="text-[10px] text-accent-2 hover:underline"
+              title={`「${resumable.query}」未完成，从第${Math.max(resumable.rounds, 1)}轮继续`}
+            >
+              继续未完成的讨论
+            </button>
+          )}
+          <select
+            value={orchestration.mode}
+            disabled={isSimulating}
+            onChange={(e) => {
+              const mode = e.target.value as OrchestrationMode;
+              setOrchestration(prev => ({ ...prev, mode, maxRounds: mode === 'debate' ? Math.max(prev.maxRounds, 2) : mode === 'round_robin' ? prev.maxRounds : 1 }));
+            }}
+            className={`text-[10px] bg-transparent border fin-divider rounded px-1 ${colors.isDark ? 'text-slate-400' : 'text-slate-500'}`}
+            title="讨论编排模式"
+          >
+            <option value="round_robin">轮流发言</option>
+            <option value="debate">辩论</option>
+            <option value="vote">投票表决</option>
+            <option value="parallel">并行综合</option>
+          </select>
+          {(orchestration.mode === 'round_robin' || orchestration.mode === 'debate') && (
+            <>
+              <select
+                value={orchestration.maxRounds}
+                disabled={isSimulating}
+                onChange={(e) => setOrchestration(prev => ({ ...prev, maxRounds: parseInt(e.target.value) }))}
+                className={`text-[10px] bg-transparent border fin-divider rounded px-1 ${colors.isDark ? 'text-slate-400' : 'text-slate-500'}`}
+                title="最大讨论轮数"
+              >
+                {[1, 2, 3, 4, 5].filter(n => orchestration.mode !== 'debate' || n >= 2).map(n => (
+                  <option key={n} value={n}>{n}轮</option>
+                ))}
+              </select>
+              {orchestration.maxRounds > 1 && (
+                <label className={`flex items-center gap-1 text-[10px] ${colors.isDark ? 'text-slate-500' : 'text-slate-400'}`} title="每轮结束后检测共识，达成共识提前结束">
+                  <input
+                    type="checkbox"
+                    checked={orchestration.earlyStop}
+                    disabled={isSimulating}
+                    onChange={(e) => setOrchestration(prev => ({ ...prev, earlyStop: e.target.checked }))}
+                  />
+                  共识即停
+                </label>
+              )}
+            </>
+          )}
         </div>
       </div>
 
@@ -0,0 +1,33 @@
+import React, { useState } from 'react';
+import { StockChartLW } from './StockChartLW';
+import { OrderBook } from './OrderBook';
+import { useDetachedKLine, useDetachedOrderBook } from '../hooks/useDetachedWindow';
+import { Stock, TimePeriod } from '../types';
+import { services } from '../../wailsjs/go/models';
+
+interface DetachedWindowViewProps {
+  window: services.WindowSubscription;
+}
+
+const DetachedKLine: React.FC<{ id: string; stock: Stock }> = ({ id, stock }) => {
+  const [period, setPeriod] = useState<TimePeriod>('1d');
+  const { data, drawings, updateMode } = useDetachedKLine(id, stock.symbol, period);
+  return <StockChartLW data={data} updateMode={updateMode} period={period} onPeriodChange={setPeriod} stock={stock} drawings={drawings} />;
+};
+
+const DetachedOrderBook: React.FC<{ id: string; stock: Stock }> = ({ id, stock }) => {
+  const orderBook = useDetachedOrderBook(id, stock.symbol);
+  return <OrderBook data={orderBook} />;
+};
+
+// 分离窗口进程的页面：整个原生窗口显示一只股票的K线图或盘口，拥有独立的行情订阅
+export const DetachedWindowView: React.FC<DetachedWindowViewProps> = ({ window: win }) => {
+  const stock = { symbol: win.code, name: win.name || win.code } as Stock;
+  return (
+    <div className="h-screen w-screen flex flex-col overflow-hidden fin-app">
+      <div className="flex-1 min-h-0 relative">
+        {win.kind === 'kline' ? <DetachedKLine id={win.id} stock={stock} /> : <DetachedOrderBook id={win.id} stock={stock} />}
+      </div>
+    </div>
+  );
+};
@@ -0,0 +1,65 @@
+import React, { useCallback, useEffect, useState } from 'react';
+import { X, AlertCircle, RotateCcw } from 'lucide-react';
+import { useTheme } from '../contexts/ThemeContext';
+import { AppError, canRetry, onAppError, retryOp } from '../services/errorService';
+
+const MAX_ERRORS = 3;
+const HIDE_MS = 8000;
+
+interface ErrorItem extends AppError {
+  id: number;
+}
+
+// 后端接口出错时的提示：显示错误码与说明，可重试的错误提供重试按钮
+export const ErrorNotice: React.FC = () => {
+  const { colors } = useTheme();
+  const [errors, setErrors] = useState<ErrorItem[]>([]);
+
+  const dismiss = useCallback((id: number) => {
+    setErrors(prev => prev.filter(e => e.id !== id));
+  }, []);
+
+  useEffect(() => {
+    return onAppError((err) => {
+      const id = Date.now() + Math.random();
+      // 同一接口的同类错误只保留最新一条
+      setErrors(prev => [...prev.filter(e => e.op !== err.op || e.code !== err.code), { ...err, id }].slice(-MAX_ERRORS));
+      setTimeout(() => dismiss(id), HIDE_MS);
+    });
+  }, [dismiss]);
+
+  if (errors.length === 0) return null;
+
+  const textMuted = colors.isDark ? 'text-slate-400' : 'text-slate-500';
+
+  return (
+    <div className="fixed bottom-16 right-4 z-[95] flex flex-col gap-2 w-80">
+      {errors.map(err => (
+        <div key={err.id} className="fin-panel border border-red-500/30 rounded-xl shadow-2xl p-3 text-left">
+          <div className="flex items-start justify-between gap-2">
+            <div className="flex items-center gap-2 min-w-0">
+              <AlertCircle className="h-4 w-4 text-red-400 shrink-0" />
+              <span className="px-1.5 py-0.5 rounded bg-red-500/10 text-red-400 text-[10px] font-mono">{err.code}</span>
+              <span className={`text-xs truncate ${textMuted}`}>{err.op}</span>
+            </div>
+            <button onClick={() => dismiss(err.id)} className={`${textMuted} hover:text-white`} title="关闭">
+              <X className="h-4 w-4" />
+            </button>
+          </div>
+          <p className={`text-sm mt-1.5 ${colors.isDark ? 'text-slate-200' : 'text-slate-700'}`}>{err.hint || err.message}</p>
+          {err.hint && <p className={`text-xs mt-0.5 break-all ${textMuted}`}>{err.message}</p>}
+          {err.retryable && canRetry(err.op) && (
+            <div className="flex justify-end mt-2">
+              <button
+                onClick={() => { retryOp(err.op); dismiss(err.id); }}
+                className="flex items-center gap-1.5 px-3 py-1 bg-accent text-white rounded-lg text-xs"
+              >
+                <RotateCcw className="h-3 w-3" />重试
+              </button>
+            </div>
+          )}
+        </div>
+      ))}
+    </div>
+  );
+};
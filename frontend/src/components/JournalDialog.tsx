@@ -0,0 +1,273 @@
+import React, { useState, useEffect, useCallback } from 'react';
+import { X, BookOpen, Plus, Trash2, Download } from 'lucide-react';
+import { GetJournalEntries, SaveJournalEntry, DeleteJournalEntry, GetJournalTags, GetJournalStats, ExportJournal } from '../../wailsjs/go/main/App';
+import { models } from '../../wailsjs/go/models';
+import { useTheme } from '../contexts/ThemeContext';
+import { useCandleColor } from '../contexts/CandleColorContext';
+
+interface JournalDialogProps {
+  isOpen: boolean;
+  onClose: () => void;
+  symbol: string;
+  stockName: string;
+  currentPrice: number;
+}
+
+const EMOTIONS = ['冷静', '自信', '犹豫', '贪婪', '恐惧', '冲动'];
+
+const OUTCOME_LABELS: Record<string, string> = {
+  win: '盈利',
+  loss: '亏损',
+  breakeven: '持平',
+  open: '持仓中',
+};
+
+const today = () => new Date().toISOString().slice(0, 10);
+
+// 空白表单
+const emptyForm = (symbol: string, name: string, price: number) => ({
+  kind: 'trade',
+  symbol,
+  name,
+  side: 'buy',
+  entryDate: today(),
+  entryPrice: price > 0 ? price.toFixed(2) : '',
+  exitDate: '',
+  exitPrice: '',
+  shares: '',
+  tags: '',
+  emotion: '',
+  notes: '',
+});
+
+export const JournalDialog: React.FC<JournalDialogProps> = ({ isOpen, onClose, symbol, stockName, currentPrice }) => {
+  const { colors } = useTheme();
+  const cc = useCandleColor();
+  const [entries, setEntries] = useState<models.JournalEntry[]>([]);
+  const [stats, setStats] = useState<models.JournalStats | null>(null);
+  const [tags, setTags] = useState<string[]>([]);
+  const [tagFilter, setTagFilter] = useState('');
+  const [onlyCurrent, setOnlyCurrent] = useState(false);
+  const [showForm, setShowForm] = useState(false);
+  const [form, setForm] = useState(emptyForm(symbol, stockName, currentPrice));
+
+  const filter = useCallback(() => models.JournalFilter.createFrom({
+    symbol: onlyCurrent ? symbol : '',
+    tag: tagFilter,
+  }), [onlyCurrent, symbol, tagFilter]);
+
+  const reload = useCallback(async () => {
+    const f = filter();
+    const [list, st, allTags] = await Promise.all([GetJournalEntries(f), GetJournalStats(f), GetJournalTags()]);
+    setEntries(list || []);
+    setStats(st);
+    setTags(allTags || []);
+  }, [filter]);
+
+  useEffect(() => {
+    if (isOpen) void reload();
+  }, [isOpen, reload]);
+
+  if (!isOpen) return null;
+
+  const mutedClass = colors.isDark ? 'text-slate-400' : 'text-slate-500';
+  const textClass = colors.isDark ? 'text-slate-200' : 'text-slate-700';
+
+  const openForm = () => {
+    setForm(emptyForm(symbol, stockName, currentPrice));
+    setShowForm(true);
+  };
+
+  const handleSave = async () => {
+    const saved = await SaveJournalEntry(models.JournalEntry.createFrom({
+      kind: form.kind,
+      symbol: form.symbol,
+      name: form.name,
+      date: form.exitDate || form.entryDate || today(),
+      side: form.kind === 'trade' ? form.side : '',
+      entryDate: form.entryDate,
+      exitDate: form.exitDate,
+      entryPrice: parseFloat(form.entryPrice) || 0,
+      exitPrice: parseFloat(form.exitPrice) || 0,
+      shares: parseInt(form.shares) || 0,
+      tags: form.tags.split(/[,，\s]+/).filter(Boolean),
+      emotion: form.emotion,
+      notes: form.notes,
+    }));
+    if (saved?.id) {
+      setShowForm(false);
+      void reload();
+    }
+  };
+
+  const handleDelete = async (id: string) => {
+    if ((await DeleteJournalEntry(id)) === 'success') void reload();
+  };
+
+  const input = (key: keyof typeof form, placeholder: string, type = 'text') => (
+    <input
+      type={type}
+      value={form[key]}
+      onChange={(e) => setForm({ ...form, [key]: e.target.value })}
+      placeholder={placeholder}
+      className="w-full fin-input rounded-lg px-2 py-1.5 text-xs"
+    />
+  );
+
+  return (
+    <div className="fixed inset-0 z-50 flex items-center justify-center">
+      <div className="absolute inset-0 bg-black/60" onClick={onClose} />
+      <div className="relative w-[720px] max-h-[85vh] flex flex-col fin-panel border fin-divider rounded-xl shadow-2xl">
+        {/* Header */}
+        <div className="flex items-center justify-between p-4 border-b fin-divider">
+          <div className="flex items-center gap-2">
+            <BookOpen className="h-5 w-5 text-accent-2" />
+            <span className={`font-bold ${colors.isDark ? 'text-slate-100' : 'text-slate-800'}`}>交易日志</span>
+          </div>
+          <div className="flex items-center gap-2">
+            {(['csv', 'xlsx'] as const).map((format) => (
+              <button
+                key={format}
+                onClick={() => ExportJournal(filter(), format)}
+                className={`flex items-center gap-1 px-2 py-1 rounded text-xs transition-colors ${colors.isDark ? 'text-slate-400 hover:bg-slate-700' : 'text-slate-500 hover:bg-slate-200'}`}
+              >
+                <Download className="h-3.5 w-3.5" />{format.toUpperCase()}
+              </button>
+            ))}
+            <button
+              onClick={onClose}
+              className={`p-1 rounded transition-colors ${colors.isDark ? 'hover:bg-slate-700 text-slate-400 hover:text-white' : 'hover:bg-slate-200 text-slate-500 hover:text-slate-700'}`}
+            >
+              <X className="h-5 w-5" />
+            </button>
+          </div>
+        </div>
+
+        <div className="flex-1 overflow-y-auto p-4 space-y-4 text-left text-sm">
+          {/* 统计 */}
+          {stats && (
+            <div className={`p-3 rounded-lg ${colors.isDark ? 'bg-slate-800/50' : 'bg-slate-100'}`}>
+              <div className="grid grid-cols-5 gap-2 text-xs">
+                <div><div className={mutedClass}>已平仓</div><div className={`font-mono ${textClass}`}>{stats.overall.trades}</div></div>
+                <div><div className={mutedClass}>持仓中</div><div className={`font-mono ${textClass}`}>{stats.open}</div></div>
+                <div><div className={mutedClass}>胜率</div><div className={`font-mono ${textClass}`}>{stats.overall.winRate.toFixed(1)}%</div></div>
+                <div><div className={mutedClass}>累计盈亏</div><div className={`font-mono ${cc.getColorClass(stats.overall.totalPnl >= 0)}`}>{stats.overall.totalPnl.toFixed(2)}</div></div>
+                <div><div className={mutedClass}>平均持仓</div><div className={`font-mono ${textClass}`}>{stats.overall.avgHoldingDays.toFixed(1)}天</div></div>
+              </div>
+              {(stats.byTag.length > 0 || stats.byEmotion.length > 0) && (
+                <div className="mt-2 flex flex-wrap gap-1.5 text-xs">
+                  {[...stats.byTag.map((g) => ({ ...g, prefix: '#' })), ...stats.byEmotion.map((g) => ({ ...g, prefix: '' }))].map((g) => (
+                    <span key={g.prefix + g.key} className={`px-2 py-0.5 rounded ${colors.isDark ? 'bg-slate-700/60' : 'bg-white'} ${mutedClass}`}>
+                      {g.prefix}{g.key} <span className="font-mono">{g.trades}笔 · {g.winRate.toFixed(0)}%</span>
+                    </span>
+                  ))}
+                </div>
+              )}
+            </div>
+          )}
+
+          {/* 筛选与新增 */}
+          <div className="flex items-center gap-2 text-xs">
+            <select value={tagFilter} onChange={(e) => setTagFilter(e.target.value)} className="fin-input rounded-lg px-2 py-1">
+              <option value="">全部标签</option>
+              {tags.map((t) => <option key={t} value={t}>{t}</option>)}
+            </select>
+            <label className={`flex items-center gap-1 ${mutedClass}`}>
+              <input type="checkbox" checked={onlyCurrent} onChange={(e) => setOnlyCurrent(e.target.checked)} />
+              仅 {stockName}
+            </label>
+            <div className="flex-1" />
+            <button onClick={openForm} className="flex items-center gap-1 px-3 py-1 rounded-lg bg-accent text-white">
+              <Plus className="h-3.5 w-3.5" />记一笔
+            </button>
+          </div>
+
+          {showForm && (
+            <div className={`p-3 rounded-lg space-y-2 border fin-divider ${colors.isDark ? 'bg-slate-800/30' : 'bg-slate-50'}`}>
+              <div className="grid grid-cols-4 gap-2">
+                <select value={form.kind} onChange={(e) => setForm({ ...form, kind: e.target.value })} className="fin-input rounded-lg px-2 py-1.5 text-xs">
+                  <option value="trade">交易</option>
+                  <option value="idea">想法</option>
+                </select>
+                {input('symbol', '代码')}
+                {input('name', '名称')}
+                {form.kind === 'trade' ? (
+                  <select value={form.side} onChange={(e) => setForm({ ...form, side: e.target.value })} className="fin-input rounded-lg px-2 py-1.5 text-xs">
+                    <option value="buy">做多</option>
+                    <option value="sell">融券/做空</option>
+                  </select>
+                ) : <div />}
+              </div>
+              {form.kind === 'trade' && (
+                <div className="grid grid-cols-5 gap-2">
+                  {input('entryDate', '建仓日期', 'date')}
+                  {input('entryPrice', '建仓价', 'number')}
+                  {input('exitDate', '平仓日期', 'date')}
+                  {input('exitPrice', '平仓价', 'number')}
+                  {input('shares', '数量', 'number')}
+                </div>
+              )}
+              <div className="grid grid-cols-2 gap-2">
+                {input('tags', '标签，逗号或空格分隔')}
+                <select value={form.emotion} onChange={(e) => setForm({ ...form, emotion: e.target.value })} className="fin-input rounded-lg px-2 py-1.5 text-xs">
+                  <option value="">情绪（可选）</option>
+                  {EMOTIONS.map((e) => <option key={e} value={e}>{e}</option>)}
+                </select>
+              </div>
+              <textarea
+                value={form.notes}
+                onChange={(e) => setForm({ ...form, notes: e.target.value })}
+                placeholder="交易理由、复盘心得..."
+                rows={2}
+                className="w-full fin-input rounded-lg px-2 py-1.5 text-xs"
+              />
+              <div className="flex justify-end gap-2 text-xs">
+                <button onClick={() => setShowForm(false)} className={`px-3 py-1 rounded-lg ${mutedClass}`}>取消</button>
+                <button onClick={handleSave} className="px-3 py-1 rounded-lg bg-accent text-white">保存</button>
+              </div>
+            </div>
+          )}
+
+          {/* 日志列表 */}
+          {entries.length === 0 ? (
+            <div className={`text-center text-xs py-6 ${mutedClass}`}>暂无日志</div>
+          ) : (
+            <div className="space-y-2">
+              {entries.map((e) => (
+                <div key={e.id} className={`group p-2.5 rounded-lg border fin-divider-soft ${colors.isDark ? 'bg-slate-800/20' : 'bg-white'}`}>
+                  <div className="flex items-center gap-2 text-xs">
+                    <span className={`font-mono ${mutedClass}`}>{e.date}</span>
+                    <span className={textClass}>{e.name || e.symbol}</span>
+                    {e.kind === 'idea' ? (
+                      <span className={`px-1.5 rounded ${colors.isDark ? 'bg-slate-700' : 'bg-slate-200'} ${mutedClass}`}>想法</span>
+                    ) : (
+                      <>
+                        <span className={`font-mono ${mutedClass}`}>
+                          {e.entryPrice ? e.entryPrice.toFixed(2) : '--'} → {e.exitPrice ? e.exitPrice.toFixed(2) : '--'}
+                        </span>
+                        <span className={`font-mono ${e.outcome === 'open' ? mutedClass : cc.getColorClass((e.pnl || 0) >= 0)}`}>
+                          {OUTCOME_LABELS[e.outcome || ''] || e.outcome}{e.pnl ? ` ${e.pnl > 0 ? '+' : ''}${e.pnl.toFixed(2)}` : ''}
+                        </span>
+                      </>
+                    )}
+                    {e.emotion && <span className={mutedClass}>· {e.emotion}</span>}
+                    <div className="flex-1" />
+                    {e.tags?.map((t) => <span key={t} className="text-accent-2">#{t}</span>)}
+                    <button
+                      onClick={() => handleDelete(e.id)}
+                      className="opacity-0 group-hover:opacity-100 p-0.5 text-red-400 hover:bg-red-500/10 rounded transition-opacity"
+                      title="删除"
+                    >
+                      <Trash2 className="h-3.5 w-3.5" />
+                    </button>
+                  </div>
+                  {e.notes && <div className={`mt-1 text-xs whitespace-pre-wrap ${mutedClass}`}>{e.notes}</div>}
+                </div>
+              ))}
+            </div>
+          )}
+        </div>
+      </div>
+    </div>
+  );
+};
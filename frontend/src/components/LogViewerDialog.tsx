@@ -0,0 +1,176 @@
+import React, { useState, useEffect, useRef, useCallback } from 'react';
+import { X, ScrollText, RefreshCw, Pause, Play, Trash2, PackageOpen } from 'lucide-react';
+import { GetRecentLogs, GenerateDiagnosticsBundle } from '../../wailsjs/go/main/App';
+import { logger } from '../../wailsjs/go/models';
+import { EventsOn } from '../../wailsjs/runtime/runtime';
+import { useTheme } from '../contexts/ThemeContext';
+
+interface LogViewerDialogProps {
+  isOpen: boolean;
+  onClose: () => void;
+}
+
+type LogLevel = 'DEBUG' | 'INFO' | 'WARN' | 'ERROR';
+
+const LEVELS: LogLevel[] = ['DEBUG', 'INFO', 'WARN', 'ERROR'];
+
+// 面板中最多保留的日志条数
+const MAX_ENTRIES = 2000;
+
+const levelColors: Record<string, string> = {
+  DEBUG: 'text-cyan-500',
+  INFO: 'text-green-500',
+  WARN: 'text-yellow-500',
+  ERROR: 'text-red-500',
+};
+
+const matches = (e: logger.Entry, level: LogLevel, module: string) =>
+  LEVELS.indexOf(e.level as LogLevel) >= LEVELS.indexOf(level) &&
+  (!module || e.module.toLowerCase().startsWith(module.toLowerCase()));
+
+const formatTime = (ms: number) => {
+  const d = new Date(ms);
+  const pad = (n: number, w = 2) => String(n).padStart(w, '0');
+  return `${pad(d.getHours())}:${pad(d.getMinutes())}:${pad(d.getSeconds())}.${pad(d.getMilliseconds(), 3)}`;
+};
+
+export const LogViewerDialog: React.FC<LogViewerDialogProps> = ({ isOpen, onClose }) => {
+  const { colors } = useTheme();
+  const [entries, setEntries] = useState<logger.Entry[]>([]);
+  const [level, setLevel] = useState<LogLevel>('INFO');
+  const [module, setModule] = useState('');
+  const [paused, setPaused] = useState(false);
+  const [loading, setLoading] = useState(false);
+  const [bundling, setBundling] = useState(false);
+  const [bundleMsg, setBundleMsg] = useState('');
+  const listRef = useRef<HTMLDivElement>(null);
+  const stickToBottom = useRef(true);
+
+  const loadLogs = useCallback(async () => {
+    setLoading(true);
+    try {
+      setEntries(await GetRecentLogs(level, module.trim(), MAX_ENTRIES) || []);
+      stickToBottom.current = true;
+    } finally {
+      setLoading(false);
+    }
+  }, [level, module]);
+
+  const handleBundle = async () => {
+    setBundling(true);
+    try {
+      const res = await GenerateDiagnosticsBundle();
+      setBundleMsg(res.error ? `生成失败: ${res.error}` : `诊断包已保存: ${res.path}`);
+    } finally {
+      setBundling(false);
+    }
+  };
+
+  useEffect(() => {
+    if (isOpen) loadLogs();
+  }, [isOpen, loadLogs]);
+
+  // 订阅实时日志，暂停时不追加
+  useEffect(() => {
+    if (!isOpen || paused) return;
+    const filter = module.trim();
+    return EventsOn('logs:append', (batch: logger.Entry[]) => {
+      const added = (batch || []).filter(e => matches(e, level, filter));
+      if (added.length === 0) return;
+      setEntries(prev => [...prev, ...added].slice(-MAX_ENTRIES));
+    });
+  }, [isOpen, paused, level, module]);
+
+  // 位于底部时自动滚动到最新日志
+  useEffect(() => {
+    const el = listRef.current;
+    if (el && stickToBottom.current) el.scrollTop = el.scrollHeight;
+  }, [entries]);
+
+  const handleScroll = () => {
+    const el = listRef.current;
+    if (el) stickToBottom.current = el.scrollHeight - el.scrollTop - el.clientHeight < 24;
+  };
+
+  if (!isOpen) return null;
+
+  const buttonClass = `p-2 rounded-lg transition-colors disabled:opacity-50 ${colors.isDark ? 'hover:bg-slate-700/50 text-slate-400 hover:text-white' : 'hover:bg-slate-200/50 text-slate-500 hover:text-slate-700'}`;
+
+  return (
+    <div className="fixed inset-0 z-50 flex items-center justify-center">
+      {/* 背景遮罩 */}
+      <div className="absolute inset-0 bg-black/60 backdrop-blur-sm" onClick={onClose} />
+
+      {/* 弹窗内容 */}
+      <div className="relative w-[900px] h-[600px] fin-panel border fin-divider rounded-xl shadow-2xl flex flex-col overflow-hidden">
+        {/* 头部 */}
+        <div className="flex items-center justify-between px-5 py-4 border-b fin-divider shrink-0">
+          <div className="flex items-center gap-3">
+            <div className="p-2 rounded-lg bg-gradient-to-br from-slate-500 to-slate-700">
+              <ScrollText className="h-5 w-5 text-white" />
+            </div>
+            <div>
+              <h2 className={`text-lg font-bold ${colors.isDark ? 'text-white' : 'text-slate-800'}`}>运行日志</h2>
+              <p className={`text-xs ${colors.isDark ? 'text-slate-400' : 'text-slate-500'}`}>最近 {MAX_ENTRIES} 条，实时更新</p>
+            </div>
+          </div>
+          <div className="flex items-center gap-2">
+            <select
+              value={level}
+              onChange={(e) => setLevel(e.target.value as LogLevel)}
+              className={`fin-input rounded-lg px-2 py-1 text-sm ${colors.isDark ? 'text-white' : 'text-slate-800'}`}
+            >
+              {LEVELS.map(l => <option key={l} value={l}>{l} 及以上</option>)}
+            </select>
+            <input
+              type="text"
+              value={module}
+              onChange={(e) => setModule(e.target.value)}
+              placeholder="模块，如 pusher"
+              className={`w-36 fin-input rounded-lg px-2 py-1 text-sm ${colors.isDark ? 'text-white' : 'text-slate-800'}`}
+            />
+            <button onClick={() => setPaused(p => !p)} className={buttonClass} title={paused ? '继续' : '暂停'}>
+              {paused ? <Play className="h-4 w-4" /> : <Pause className="h-4 w-4" />}
+            </button>
+            <button onClick={() => setEntries([])} className={buttonClass} title="清空面板">
+              <Trash2 className="h-4 w-4" />
+            </button>
+            <button onClick={loadLogs} disabled={loading} className={buttonClass} title="刷新">
+              <RefreshCw className={`h-4 w-4 ${loading ? 'animate-spin' : ''}`} />
+            </button>
+            <button onClick={handleBundle} disabled={bundling} className={buttonClass} title="生成诊断包（日志、脱敏配置、系统信息），用于反馈问题">
+              <PackageOpen className={`h-4 w-4 ${bundling ? 'animate-pulse' : ''}`} />
+            </button>
+            <button onClick={onClose} className={buttonClass}>
+              <X className="h-4 w-4" />
+            </button>
+          </div>
+        </div>
+
+        {bundleMsg && (
+          <div className={`px-5 py-2 border-b fin-divider text-xs break-all select-text ${colors.isDark ? 'text-slate-300' : 'text-slate-600'}`}>
+            {bundleMsg}
+          </div>
+        )}
+
+        {/* 日志列表 */}
+        <div ref={listRef} onScroll={handleScroll} className="flex-1 overflow-y-auto px-4 py-2 font-mono text-xs">
+          {entries.length === 0 ? (
+            <div className={`text-center py-12 font-sans text-sm ${colors.isDark ? 'text-slate-500' : 'text-slate-400'}`}>
+              {loading ? '加载中...' : '暂无日志'}
+            </div>
+          ) : (
+            entries.map((e, i) => (
+              <div key={`${e.time}-${i}`} className="flex gap-2 py-0.5 leading-5">
+                <span className={colors.isDark ? 'text-slate-500' : 'text-slate-400'}>{formatTime(e.time)}</span>
+                <span className={`w-12 shrink-0 ${levelColors[e.level] || ''}`}>{e.level}</span>
+                <span className="text-accent-2 shrink-0">{e.module}</span>
+                <span className={`whitespace-pre-wrap break-all ${colors.isDark ? 'text-slate-300' : 'text-slate-700'}`}>{e.message}</span>
+              </div>
+            ))
+          )}
+        </div>
+      </div>
+    </div>
+  );
+};
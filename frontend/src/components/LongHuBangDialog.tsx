@@ -3,6 +3,7 @@ import { X, TrendingUp, RefreshCw, Calendar, ChevronDown } from 'lucide-react';
 import { GetLongHuBangList, GetLongHuBangDetail, GetTradeDates } from '../../wailsjs/go/main/App';
 import { models } from '../../wailsjs/go/models';
 import { useCandleColor } from '../contexts/CandleColorContext';
+import { registerRetry } from '../services/errorService';
 
 interface LongHuBangDialogProps {
   isOpen: boolean;
@@ -73,6 +74,14 @@ export const LongHuBangDialog: React.FC<LongHuBangDialogProps> = ({ isOpen, onCl
     }
   }, [isOpen]);
 
+  useEffect(() => {
+    if (!isOpen || !tradeDate) return;
+    return registerRetry('GetLongHuBangList', () => {
+      setPageNumber(1);
+      loadList(1, tradeDate, false);
+    });
+  }, [isOpen, tradeDate]);
+
   const handleDateChange = (date: string) => {
     setTradeDate(date);
     setPageNumber(1);
@@ -1,5 +1,5 @@
 import React from 'react';
-import { MarketIndex } from '../types';
+import { MarketIndex, InterestRates, InterestRate, GlobalIndex } from '../types';
 import { useCandleColor } from '../contexts/CandleColorContext';
 
 interface MarketIndicesProps {
@@ -46,3 +46,66 @@ const MarketIndexItem: React.FC<MarketIndexItemProps> = ({ index }) => {
     </div>
   );
 };
+
+
+// 利率条中展示的品种，其余期限在悬浮提示中查看
+const RATE_BAR_CODES = ['sh204001', 'sz131810', 'shibor_on', 'cn10y'];
+
+const RATE_CATEGORY_LABELS: Record<string, string> = {
+  repo: '逆回购',
+  shibor: 'SHIBOR',
+  treasury: '国债',
+};
+
+// formatRate 利率与基点变动，如 "1.85% +3.2bp"
+const formatRate = (rate: InterestRate) => {
+  const sign = rate.changeBp >= 0 ? '+' : '';
+  return `${rate.rate.toFixed(3)}% ${sign}${rate.changeBp.toFixed(1)}bp`;
+};
+
+interface InterestRateBarProps {
+  rates: InterestRates;
+}
+
+export const InterestRateBar: React.FC<InterestRateBarProps> = ({ rates }) => {
+  const cc = useCandleColor();
+  const items = rates.items.filter((r) => RATE_BAR_CODES.includes(r.code));
+  if (items.length === 0) return null;
+
+  const tooltip = rates.items
+    .map((r) => `${RATE_CATEGORY_LABELS[r.category] || r.category} ${r.name}: ${formatRate(r)}${r.date ? ` (${r.date})` : ''}`)
+    .join('\n');
+
+  return (
+    <div className="flex items-center gap-3 text-[11px] cursor-default" title={tooltip}>
+      {items.map((r) => (
+        <span key={r.code} className="flex items-center gap-1">
+          <span className="text-slate-400">{r.code === 'shibor_on' ? 'SHIBOR' : r.category === 'treasury' ? '10Y国债' : r.name}</span>
+          <span className={`font-mono ${cc.getColorClass(r.changeBp >= 0)}`}>{r.rate.toFixed(3)}%</span>
+        </span>
+      ))}
+    </div>
+  );
+};
+
+interface GlobalIndexBarProps {
+  indices: GlobalIndex[];
+}
+
+// GlobalIndexBar 全球指数条：交易中的市场以圆点标记，悬浮显示当地时间
+export const GlobalIndexBar: React.FC<GlobalIndexBarProps> = ({ indices }) => {
+  const cc = useCandleColor();
+  return (
+    <div className="flex flex-wrap justify-center items-center gap-x-3 gap-y-0.5 text-[11px] cursor-default">
+      {indices.map((index) => (
+        <span key={index.code} className="flex items-center gap-1" title={`${index.market} 当地时间 ${index.localTime} · ${index.price.toFixed(2)}`}>
+          {index.status === 'trading' && <span className="h-1.5 w-1.5 rounded-full bg-emerald-400" />}
+          <span className="text-slate-400">{index.name}</span>
+          <span className={`font-mono ${cc.getColorClass(index.change >= 0)}`}>
+            {index.changePercent >= 0 ? '+' : ''}{index.changePercent.toFixed(2)}%
+          </span>
+        </span>
+      ))}
+    </div>
+  );
+};
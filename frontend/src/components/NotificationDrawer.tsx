@@ -0,0 +1,118 @@
+import React, { useState, useEffect, useCallback } from 'react';
+import { X, Bell, CheckCheck, Trash2, MoonStar } from 'lucide-react';
+import { useTheme } from '../contexts/ThemeContext';
+import {
+  AppNotification,
+  NOTIFICATION_CATEGORIES,
+  getNotifications,
+  markNotificationsRead,
+  clearNotifications,
+  onNotification,
+} from '../services/notificationService';
+
+interface NotificationDrawerProps {
+  isOpen: boolean;
+  onClose: () => void;
+  onChanged: () => void; // 已读状态变化后刷新未读数
+}
+
+const formatTime = (ms: number) => {
+  const d = new Date(ms);
+  const pad = (n: number) => String(n).padStart(2, '0');
+  return `${pad(d.getMonth() + 1)}-${pad(d.getDate())} ${pad(d.getHours())}:${pad(d.getMinutes())}`;
+};
+
+export const NotificationDrawer: React.FC<NotificationDrawerProps> = ({ isOpen, onClose, onChanged }) => {
+  const { colors } = useTheme();
+  const [category, setCategory] = useState('');
+  const [unreadOnly, setUnreadOnly] = useState(false);
+  const [items, setItems] = useState<AppNotification[]>([]);
+
+  const load = useCallback(async () => {
+    setItems(await getNotifications(category, unreadOnly, 200));
+  }, [category, unreadOnly]);
+
+  useEffect(() => {
+    if (!isOpen) return;
+    load();
+    return onNotification(() => { load(); });
+  }, [isOpen, load]);
+
+  if (!isOpen) return null;
+
+  const mutedClass = colors.isDark ? 'text-slate-400' : 'text-slate-500';
+
+  const markRead = async (ids: string[]) => {
+    await markNotificationsRead(ids);
+    await load();
+    onChanged();
+  };
+
+  const handleClear = async () => {
+    await clearNotifications();
+    setItems([]);
+    onChanged();
+  };
+
+  return (
+    <div className="fixed inset-0 z-50 flex justify-end">
+      <div className="absolute inset-0 bg-black/40" onClick={onClose} />
+      <div className="relative w-[380px] h-full flex flex-col fin-panel border-l fin-divider shadow-2xl">
+        <div className="flex items-center justify-between p-4 border-b fin-divider">
+          <div className="flex items-center gap-2">
+            <Bell className="h-5 w-5 text-accent-2" />
+            <span className={`font-bold ${colors.isDark ? 'text-slate-100' : 'text-slate-800'}`}>通知中心</span>
+          </div>
+          <div className="flex items-center gap-1">
+            <button onClick={() => markRead([])} className={`p-1 rounded ${mutedClass} hover:text-accent`} title="全部已读">
+              <CheckCheck className="h-4 w-4" />
+            </button>
+            <button onClick={handleClear} className={`p-1 rounded ${mutedClass} hover:text-red-400`} title="清空">
+              <Trash2 className="h-4 w-4" />
+            </button>
+            <button onClick={onClose} className={`p-1 rounded ${mutedClass} hover:text-accent`}>
+              <X className="h-5 w-5" />
+            </button>
+          </div>
+        </div>
+
+        <div className="flex items-center gap-2 px-4 py-2 border-b fin-divider text-xs">
+          {[['', '全部'], ...Object.entries(NOTIFICATION_CATEGORIES)].map(([key, label]) => (
+            <button
+              key={key}
+              onClick={() => setCategory(key)}
+              className={`px-2 py-1 rounded ${category === key ? 'bg-accent text-white' : mutedClass}`}
+            >
+              {label}
+            </button>
+          ))}
+          <label className={`ml-auto flex items-center gap-1 ${mutedClass}`}>
+            <input type="checkbox" checked={unreadOnly} onChange={(e) => setUnreadOnly(e.target.checked)} />
+            仅未读
+          </label>
+        </div>
+
+        <div className="flex-1 overflow-y-auto text-left text-sm">
+          {items.length === 0 ? (
+            <div className={`p-6 text-center text-xs ${mutedClass}`}>暂无通知</div>
+          ) : items.map((n) => (
+            <div
+              key={n.id}
+              onClick={() => !n.read && markRead([n.id])}
+              className={`px-4 py-3 border-b fin-divider-soft cursor-pointer ${n.read ? 'opacity-60' : ''}`}
+            >
+              <div className="flex items-center gap-2">
+                {!n.read && <span className="h-1.5 w-1.5 rounded-full bg-accent shrink-0" />}
+                <span className={`font-medium truncate ${colors.isDark ? 'text-slate-200' : 'text-slate-700'}`}>{n.title}</span>
+                {n.silent && <span title="免打扰时段内产生"><MoonStar className={`h-3.5 w-3.5 shrink-0 ${mutedClass}`} /></span>}
+                <span className={`ml-auto text-xs shrink-0 ${mutedClass}`}>{formatTime(n.createdAt)}</span>
+              </div>
+              {n.body && <div className={`mt-1 text-xs line-clamp-3 ${mutedClass}`}>{n.body}</div>}
+              <div className={`mt-1 text-[10px] ${mutedClass}`}>{NOTIFICATION_CATEGORIES[n.category] || n.category}</div>
+            </div>
+          ))}
+        </div>
+      </div>
+    </div>
+  );
+};
@@ -0,0 +1,156 @@
+import React, { useState, useEffect, useCallback } from 'react';
+import { X, Layers, RefreshCw } from 'lucide-react';
+import { GetOptionChain } from '../../wailsjs/go/main/App';
+import { models } from '../../wailsjs/go/models';
+import { useTheme } from '../contexts/ThemeContext';
+import { useCandleColor } from '../contexts/CandleColorContext';
+import { registerRetry } from '../services/errorService';
+
+interface OptionChainDialogProps {
+  isOpen: boolean;
+  onClose: () => void;
+}
+
+const UNDERLYINGS = [
+  { code: 'sh510050', name: '50ETF' },
+  { code: 'sh510300', name: '300ETF' },
+];
+
+// 交易时段刷新间隔，与后端缓存时间一致
+const REFRESH_MS = 3000;
+
+export const OptionChainDialog: React.FC<OptionChainDialogProps> = ({ isOpen, onClose }) => {
+  const { colors } = useTheme();
+  const cc = useCandleColor();
+  const [underlying, setUnderlying] = useState(UNDERLYINGS[0].code);
+  const [month, setMonth] = useState('');
+  const [chain, setChain] = useState<models.OptionChain | null>(null);
+  const [loading, setLoading] = useState(false);
+
+  const load = useCallback(async () => {
+    setLoading(true);
+    try {
+      const data = await GetOptionChain(underlying, month);
+      if (data) setChain(data);
+    } finally {
+      setLoading(false);
+    }
+  }, [underlying, month]);
+
+  useEffect(() => {
+    if (!isOpen) return;
+    void load();
+    const timer = setInterval(load, REFRESH_MS);
+    const offRetry = registerRetry('GetOptionChain', () => void load());
+    return () => {
+      clearInterval(timer);
+      offRetry();
+    };
+  }, [isOpen, load]);
+
+  if (!isOpen) return null;
+
+  const mutedClass = colors.isDark ? 'text-slate-400' : 'text-slate-500';
+  const textClass = colors.isDark ? 'text-slate-200' : 'text-slate-700';
+  const spot = chain?.underlyingPrice || 0;
+  // 平值行：行权价最接近标的价格
+  const atmStrike = chain?.rows.reduce((best, r) => (Math.abs(r.strike - spot) < Math.abs(best - spot) ? r.strike : best), chain.rows[0]?.strike ?? 0);
+
+  const cell = (q: models.OptionQuote | undefined, field: 'price' | 'changePercent' | 'iv' | 'delta' | 'volume' | 'openInterest') => {
+    if (!q) return <span className={mutedClass}>--</span>;
+    switch (field) {
+      case 'price':
+        return <span className={cc.getColorClass(q.change >= 0)}>{q.price.toFixed(4)}</span>;
+      case 'changePercent':
+        return <span className={cc.getColorClass(q.change >= 0)}>{q.changePercent >= 0 ? '+' : ''}{q.changePercent.toFixed(2)}%</span>;
+      case 'iv':
+        return q.iv > 0 ? `${q.iv.toFixed(2)}%` : '--';
+      case 'delta':
+        return q.iv > 0 ? q.delta.toFixed(3) : '--';
+      default:
+        return q[field];
+    }
+  };
+
+  const sideFields = ['openInterest', 'volume', 'delta', 'iv', 'changePercent', 'price'] as const;
+  const sideLabels: Record<string, string> = { openInterest: '持仓', volume: '成交', delta: 'Delta', iv: '隐波', changePercent: '涨跌', price: '最新' };
+
+  return (
+    <div className="fixed inset-0 z-50 flex items-center justify-center">
+      <div className="absolute inset-0 bg-black/60" onClick={onClose} />
+      <div className="relative w-[960px] max-h-[88vh] flex flex-col fin-panel border fin-divider rounded-xl shadow-2xl">
+        {/* Header */}
+        <div className="flex items-center justify-between p-4 border-b fin-divider">
+          <div className="flex items-center gap-3">
+            <Layers className="h-5 w-5 text-accent-2" />
+            <span className={`font-bold ${colors.isDark ? 'text-slate-100' : 'text-slate-800'}`}>期权T型报价</span>
+            <select value={underlying} onChange={(e) => { setUnderlying(e.target.value); setMonth(''); setChain(null); }} className="fin-input rounded-lg px-2 py-1 text-xs">
+              {UNDERLYINGS.map((u) => <option key={u.code} value={u.code}>{u.name}</option>)}
+            </select>
+            {chain && (
+              <select value={chain.month} onChange={(e) => setMonth(e.target.value)} className="fin-input rounded-lg px-2 py-1 text-xs">
+                {chain.months.map((m) => <option key={m} value={m}>{m}</option>)}
+              </select>
+            )}
+            {chain && (
+              <span className={`text-xs ${mutedClass}`}>
+                {chain.underlyingName} <span className={`font-mono ${textClass}`}>{spot.toFixed(3)}</span>
+                {' · '}到期 {chain.expiry}（{chain.daysToExpiry}天）
+                {' · '}无风险利率 {chain.riskFreeRate.toFixed(2)}%
+              </span>
+            )}
+          </div>
+          <div className="flex items-center gap-2">
+            <RefreshCw className={`h-4 w-4 ${mutedClass} ${loading ? 'animate-spin' : ''}`} />
+            <button
+              onClick={onClose}
+              className={`p-1 rounded transition-colors ${colors.isDark ? 'hover:bg-slate-700 text-slate-400 hover:text-white' : 'hover:bg-slate-200 text-slate-500 hover:text-slate-700'}`}
+            >
+              <X className="h-5 w-5" />
+            </button>
+          </div>
+        </div>
+
+        <div className="flex-1 overflow-y-auto p-4">
+          {!chain ? (
+            <div className={`text-center text-xs py-10 ${mutedClass}`}>{loading ? '加载中...' : '暂无数据'}</div>
+          ) : (
+            <table className="w-full text-xs font-mono">
+              <thead className={mutedClass}>
+                <tr>
+                  <th colSpan={sideFields.length} className="py-1 font-normal text-center">认购</th>
+                  <th className="font-normal">行权价</th>
+                  <th colSpan={sideFields.length} className="font-normal text-center">认沽</th>
+                </tr>
+                <tr>
+                  {sideFields.map((f) => <th key={`c-${f}`} className="py-1 font-normal text-right">{sideLabels[f]}</th>)}
+                  <th />
+                  {[...sideFields].reverse().map((f) => <th key={`p-${f}`} className="font-normal text-left">{sideLabels[f]}</th>)}
+                </tr>
+              </thead>
+              <tbody>
+                {chain.rows.map((row) => {
+                  const atm = row.strike === atmStrike;
+                  // 实值一侧加底色：认购行权价低于标的、认沽行权价高于标的
+                  const callItm = row.strike < spot;
+                  const itmBg = colors.isDark ? 'bg-slate-700/30' : 'bg-slate-100';
+                  return (
+                    <tr key={row.strike} className={`border-t fin-divider-soft ${textClass}`}>
+                      {sideFields.map((f) => (
+                        <td key={`c-${f}`} className={`py-1 text-right pr-2 ${callItm ? itmBg : ''}`}>{cell(row.call, f)}</td>
+                      ))}
+                      <td className={`text-center px-3 font-bold ${atm ? 'text-accent-2' : ''}`}>{row.strike.toFixed(3)}</td>
+                      {[...sideFields].reverse().map((f) => (
+                        <td key={`p-${f}`} className={`py-1 text-left pl-2 ${!callItm && row.strike !== spot ? itmBg : ''}`}>{cell(row.put, f)}</td>
+                      ))}
+                    </tr>
+                  );
+                })}
+              </tbody>
+            </table>
+          )}
+        </div>
+      </div>
+    </div>
+  );
+};
@@ -0,0 +1,213 @@
+import React, { useState, useEffect } from 'react';
+import { X, ShieldAlert, Calculator, RefreshCw, AlertTriangle } from 'lucide-react';
+import { CalculatePositionSize, CheckPortfolioRisk } from '../../wailsjs/go/main/App';
+import { models } from '../../wailsjs/go/models';
+import { useTheme } from '../contexts/ThemeContext';
+import { useCandleColor } from '../contexts/CandleColorContext';
+
+interface RiskDialogProps {
+  isOpen: boolean;
+  onClose: () => void;
+  stockName: string;
+  currentPrice: number;
+  stopPrice?: number;
+}
+
+const LIMITED_BY_LABELS: Record<string, string> = {
+  risk: '单笔风险',
+  position: '单只仓位上限',
+  cash: '账户资金',
+};
+
+export const RiskDialog: React.FC<RiskDialogProps> = ({ isOpen, onClose, stockName, currentPrice, stopPrice }) => {
+  const { colors } = useTheme();
+  const cc = useCandleColor();
+  const [accountSize, setAccountSize] = useState('100000');
+  const [riskPercent, setRiskPercent] = useState('1');
+  const [maxPositionPercent, setMaxPositionPercent] = useState('20');
+  const [entryPrice, setEntryPrice] = useState('');
+  const [stop, setStop] = useState('');
+  const [sizing, setSizing] = useState<models.PositionSizeResult | null>(null);
+  const [portfolio, setPortfolio] = useState<models.PortfolioRisk | null>(null);
+  const [checking, setChecking] = useState(false);
+
+  // 打开时以当前股票价格与止损跟踪线预填
+  useEffect(() => {
+    if (!isOpen) return;
+    setEntryPrice(currentPrice > 0 ? currentPrice.toFixed(2) : '');
+    setStop(stopPrice && stopPrice > 0 ? stopPrice.toFixed(2) : '');
+    setSizing(null);
+  }, [isOpen, currentPrice, stopPrice]);
+
+  if (!isOpen) return null;
+
+  const labelClass = `block text-xs mb-1 ${colors.isDark ? 'text-slate-400' : 'text-slate-500'}`;
+  const mutedClass = colors.isDark ? 'text-slate-400' : 'text-slate-500';
+  const valueClass = `font-mono ${colors.isDark ? 'text-slate-200' : 'text-slate-700'}`;
+
+  const handleCalculate = async () => {
+    const result = await CalculatePositionSize(models.PositionSizeRequest.createFrom({
+      accountSize: parseFloat(accountSize) || 0,
+      riskPercent: parseFloat(riskPercent) || 0,
+      entryPrice: parseFloat(entryPrice) || 0,
+      stopPrice: parseFloat(stop) || 0,
+      maxPositionPercent: parseFloat(maxPositionPercent) || 0,
+    }));
+    setSizing(result);
+  };
+
+  const handleCheck = async () => {
+    setChecking(true);
+    try {
+      const limits = models.RiskLimits.createFrom({ maxPositionPercent: parseFloat(maxPositionPercent) || 0 });
+      setPortfolio(await CheckPortfolioRisk(parseFloat(accountSize) || 0, limits));
+    } finally {
+      setChecking(false);
+    }
+  };
+
+  return (
+    <div className="fixed inset-0 z-50 flex items-center justify-center">
+      <div className="absolute inset-0 bg-black/60" onClick={onClose} />
+      <div className="relative w-[560px] max-h-[85vh] flex flex-col fin-panel border fin-divider rounded-xl shadow-2xl">
+        {/* Header */}
+        <div className="flex items-center justify-between p-4 border-b fin-divider">
+          <div className="flex items-center gap-2">
+            <ShieldAlert className="h-5 w-5 text-accent-2" />
+            <span className={`font-bold ${colors.isDark ? 'text-slate-100' : 'text-slate-800'}`}>仓位与风险</span>
+          </div>
+          <button
+            onClick={onClose}
+            className={`p-1 rounded transition-colors ${colors.isDark ? 'hover:bg-slate-700 text-slate-400 hover:text-white' : 'hover:bg-slate-200 text-slate-500 hover:text-slate-700'}`}
+          >
+            <X className="h-5 w-5" />
+          </button>
+        </div>
+
+        <div className="flex-1 overflow-y-auto p-4 space-y-5 text-left text-sm">
+          {/* 账户参数 */}
+          <div className="grid grid-cols-3 gap-3">
+            <div>
+              <label className={labelClass}>账户资金（元）</label>
+              <input type="number" value={accountSize} onChange={(e) => setAccountSize(e.target.value)} className="w-full fin-input rounded-lg px-3 py-2 text-sm" min="0" />
+            </div>
+            <div>
+              <label className={labelClass}>单笔风险（%）</label>
+              <input type="number" value={riskPercent} onChange={(e) => setRiskPercent(e.target.value)} className="w-full fin-input rounded-lg px-3 py-2 text-sm" min="0" step="0.5" />
+            </div>
+            <div>
+              <label className={labelClass}>单只仓位上限（%）</label>
+              <input type="number" value={maxPositionPercent} onChange={(e) => setMaxPositionPercent(e.target.value)} className="w-full fin-input rounded-lg px-3 py-2 text-sm" min="0" />
+            </div>
+          </div>
+
+          {/* 仓位计算 */}
+          <div>
+            <div className={`flex items-center gap-2 mb-2 font-medium ${colors.isDark ? 'text-slate-200' : 'text-slate-700'}`}>
+              <Calculator className="h-4 w-4" />
+              仓位计算 <span className={`text-xs font-normal ${mutedClass}`}>{stockName}</span>
+            </div>
+            <div className="grid grid-cols-3 gap-3 items-end">
+              <div>
+                <label className={labelClass}>买入价</label>
+                <input type="number" value={entryPrice} onChange={(e) => setEntryPrice(e.target.value)} className="w-full fin-input rounded-lg px-3 py-2 text-sm" min="0" step="0.01" />
+              </div>
+              <div>
+                <label className={labelClass}>止损价</label>
+                <input type="number" value={stop} onChange={(e) => setStop(e.target.value)} className="w-full fin-input rounded-lg px-3 py-2 text-sm" min="0" step="0.01" />
+              </div>
+              <button onClick={handleCalculate} className="px-4 py-2 rounded-lg text-sm bg-accent hover:bg-accent text-white transition-colors">
+                计算
+              </button>
+            </div>
+            {sizing && (
+              <div className={`mt-3 p-3 rounded-lg space-y-2 ${colors.isDark ? 'bg-slate-800/50' : 'bg-slate-100'}`}>
+                <div className="flex justify-between">
+                  <span className={mutedClass}>建议买入</span>
+                  <span className={valueClass}>{sizing.shares} 股（{sizing.lots} 手）</span>
+                </div>
+                <div className="flex justify-between">
+                  <span className={mutedClass}>买入金额 / 仓位</span>
+                  <span className={valueClass}>{sizing.positionValue.toFixed(2)} / {sizing.positionPercent.toFixed(1)}%</span>
+                </div>
+                <div className="flex justify-between">
+                  <span className={mutedClass}>止损亏损 / 占账户</span>
+                  <span className={`font-mono ${cc.getColorClass(false)}`}>-{sizing.riskAmount.toFixed(2)} / {sizing.riskPercent.toFixed(2)}%</span>
+                </div>
+                <div className="flex justify-between">
+                  <span className={mutedClass}>约束条件</span>
+                  <span className={valueClass}>{LIMITED_BY_LABELS[sizing.limitedBy] || sizing.limitedBy}</span>
+                </div>
+                {sizing.warnings?.map((w) => (
+                  <div key={w} className="flex items-center gap-1 text-xs text-amber-400">
+                    <AlertTriangle className="h-3.5 w-3.5 shrink-0" />{w}
+                  </div>
+                ))}
+              </div>
+            )}
+          </div>
+
+          {/* 组合风险 */}
+          <div>
+            <div className="flex items-center justify-between mb-2">
+              <span className={`font-medium ${colors.isDark ? 'text-slate-200' : 'text-slate-700'}`}>组合风险</span>
+              <button
+                onClick={handleCheck}
+                disabled={checking}
+                className={`flex items-center gap-1 text-xs disabled:opacity-50 ${colors.isDark ? 'text-slate-400 hover:text-white' : 'text-slate-500 hover:text-slate-800'}`}
+              >
+                <RefreshCw className={`h-3.5 w-3.5 ${checking ? 'animate-spin' : ''}`} />
+                检查持仓
+              </button>
+            </div>
+            {portfolio && (
+              <div className="space-y-3">
+                <div className={`grid grid-cols-4 gap-2 p-3 rounded-lg text-xs ${colors.isDark ? 'bg-slate-800/50' : 'bg-slate-100'}`}>
+                  <div><div className={mutedClass}>总仓位</div><div className={valueClass}>{portfolio.exposurePercent.toFixed(1)}%</div></div>
+                  <div><div className={mutedClass}>最大单只</div><div className={valueClass}>{portfolio.topWeight.toFixed(1)}%</div></div>
+                  <div><div className={mutedClass}>集中度HHI</div><div className={valueClass}>{portfolio.hhi.toFixed(0)}</div></div>
+                  <div><div className={mutedClass}>触及止损亏损</div><div className={`font-mono ${cc.getColorClass(false)}`}>-{portfolio.riskToStop.toFixed(0)}</div></div>
+                </div>
+                {portfolio.warnings.length > 0 && (
+                  <div className="space-y-1">
+                    {portfolio.warnings.map((w) => (
+                      <div key={`${w.kind}-${w.target}`} className={`flex items-center gap-1 text-xs ${w.kind === 'no_stop' ? mutedClass : 'text-amber-400'}`}>
+                        <AlertTriangle className="h-3.5 w-3.5 shrink-0" />{w.message}
+                      </div>
+                    ))}
+                  </div>
+                )}
+                {portfolio.holdings.length === 0 ? (
+                  <div className={`text-xs ${mutedClass}`}>暂无持仓，可在自选股的持仓设置中填写</div>
+                ) : (
+                  <table className="w-full text-xs">
+                    <thead>
+                      <tr className={mutedClass}>
+                        <th className="text-left font-normal py-1">股票</th>
+                        <th className="text-right font-normal">市值</th>
+                        <th className="text-right font-normal">占比</th>
+                        <th className="text-right font-normal">盈亏</th>
+                        <th className="text-right font-normal">止损</th>
+                      </tr>
+                    </thead>
+                    <tbody>
+                      {portfolio.holdings.map((h) => (
+                        <tr key={h.symbol} className="border-t fin-divider-soft">
+                          <td className={`py-1 ${colors.isDark ? 'text-slate-200' : 'text-slate-700'}`}>{h.name}</td>
+                          <td className={`text-right ${valueClass}`}>{h.marketValue.toFixed(0)}</td>
+                          <td className={`text-right ${valueClass}`}>{h.weight.toFixed(1)}%</td>
+                          <td className={`text-right font-mono ${cc.getColorClass(h.pnl >= 0)}`}>{h.pnlPercent >= 0 ? '+' : ''}{h.pnlPercent.toFixed(2)}%</td>
+                          <td className={`text-right ${valueClass}`}>{h.stopPrice ? h.stopPrice.toFixed(2) : '--'}</td>
+                        </tr>
+                      ))}
+                    </tbody>
+                  </table>
+                )}
+              </div>
+            )}
+          </div>
+        </div>
+      </div>
+    </div>
+  );
+};
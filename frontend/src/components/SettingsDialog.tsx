@@ -1,10 +1,11 @@
 import React, { useState, useEffect, useCallback, useRef } from 'react';
-import { X, Cpu, ChevronLeft, Plug, Plus, Trash2, Wrench, Check, Loader2, Brain, RefreshCw, Download, RotateCcw, Globe, Layers, Sliders, Star, MessageSquare, Copy, Sparkles } from 'lucide-react';
-import { getConfig, updateConfig, getAvailableTools, ToolInfo, testAIConnection } from '../services/configService';
+import { X, Cpu, ChevronLeft, Plug, Plus, Trash2, Wrench, Check, Loader2, Brain, RefreshCw, Download, RotateCcw, Globe, Layers, Sliders, Star, MessageSquare, Copy, Sparkles, ShieldCheck, Archive, Upload, Keyboard, Bell, Play, FolderOpen } from 'lucide-react';
+import { getConfig, updateConfig, getAvailableTools, ToolInfo, testAIConnection, pullOllamaModel, getToolAuditLog, ToolAuditEntry, detectSystemProxy, SystemProxy, exportSettings, importSettings, resetToDefaults, hotkeysSupported } from '../services/configService';
 import { getAgentConfigs } from '../services/strategyService';
 import { getMCPServers, MCPServerConfig, MCPServerStatus, testMCPConnection, getMCPServerTools, MCPToolInfo } from '../services/mcpService';
+import { NOTIFICATION_CATEGORIES, previewNotificationSound, selectSoundFile } from '../services/notificationService';
 import { checkForUpdate, doUpdate, restartApp, getCurrentVersion, onUpdateProgress, UpdateInfo, UpdateProgress } from '../services/updateService';
-import { getStrategies, getActiveStrategyID, setActiveStrategy, deleteStrategy, generateStrategy, updateStrategy, enhancePrompt, Strategy, StrategyAgent } from '../services/strategyService';
+import { getStrategies, getActiveStrategyID, setActiveStrategy, deleteStrategy, generateStrategy, updateStrategy, enhancePrompt, Strategy, StrategyAgent, AgentPersona, getPersonas, createPersona, updatePersona, deletePersona } from '../services/strategyService';
 import { useTheme } from '../contexts/ThemeContext';
 import { useCandleColor, CandleColorMode } from '../contexts/CandleColorContext';
 import { useIndicator, IndicatorConfig, IndicatorType, DEFAULT_INDICATORS } from '../contexts/IndicatorContext';
@@ -22,6 +23,12 @@ interface AIConfig {
   isDefault: boolean;
   // OpenAI Responses API 开关
   useResponses: boolean;
+  // Ollama 模型驻留时长
+  keepAlive: string;
+  // Gemini / Vertex AI 原生能力
+  thinkingBudget?: number;
+  safetyThreshold: string;
+  googleSearch: boolean;
   // Vertex AI 专用字段
   project: string;
   location: string;
@@ -35,15 +42,30 @@ interface MemoryConfig {
   maxKeyFacts: number;
   maxSummaryLength: number;
   compressThreshold: number;
+  embeddingAiId: string;
+  embeddingModel: string;
+  prompts: MemoryPromptConfig;
+}
+
+interface MemoryPromptConfig {
+  summaryLength: number;
+  factLength: number;
+  maxFacts: number;
+  keyPointLength: number;
+  summarizeTemplate: string;
+  extractTemplate: string;
+  keyPointsTemplate: string;
+  consolidateTemplate: string;
 }
 
 // 代理模式类型
-type ProxyMode = 'none' | 'system' | 'custom';
+type ProxyMode = 'none' | 'system' | 'custom' | 'pac';
 
 // 代理配置接口
 interface ProxyConfig {
   mode: ProxyMode;
   customUrl: string;
+  pacUrl: string;
 }
 
 // OpenClaw 配置接口
@@ -53,7 +75,51 @@ interface OpenClawConfig {
   apiKey: string;
 }
 
-type TabType = 'provider' | 'intent' | 'strategy' | 'mcp' | 'memory' | 'chart' | 'proxy' | 'openclaw' | 'update';
+// 全局快捷键配置接口
+interface HotkeyConfig {
+  enabled: boolean;
+  showHide: string;
+  bossKey: string;
+  quickSearch: string;
+}
+
+// 通知中心配置接口
+interface NotificationConfig {
+  disabledCategories: string[];
+  quietHours: { enabled: boolean; start: string; end: string };
+  maxHistory: number;
+  sound: { enabled: boolean; volume: number; sounds: Record<string, string> };
+}
+
+const DEFAULT_NOTIFICATION_CONFIG: NotificationConfig = {
+  disabledCategories: [],
+  quietHours: { enabled: false, start: '22:00', end: '08:00' },
+  maxHistory: 0,
+  sound: { enabled: false, volume: 80, sounds: {} },
+};
+
+// 限售解禁提醒配置接口
+interface LockupAlertConfig {
+  enabled: boolean;
+  daysBefore: number;
+  minFloatRatio: number;
+}
+
+// 自动更新配置接口
+interface UpdateConfig {
+  disableAutoCheck: boolean;
+  skipVersion: string;
+}
+
+// 工具执行沙箱配置接口
+interface ToolSandboxConfig {
+  defaultTimeout: number;
+  defaultRateLimit: number;
+  policies: { tool: string; timeout: number; rateLimit: number }[];
+  auditDays: number;
+}
+
+type TabType = 'provider' | 'intent' | 'strategy' | 'persona' | 'mcp' | 'memory' | 'chart' | 'proxy' | 'openclaw' | 'audit' | 'hotkey' | 'notify' | 'backup' | 'update';
 
 interface SettingsDialogProps {
   isOpen: boolean;
@@ -99,16 +165,39 @@ export const SettingsDialog: React.FC<SettingsDialogProps> = ({ isOpen, onClose
     maxKeyFacts: 20,
     maxSummaryLength: 300,
     compressThreshold: 5,
+    embeddingAiId: '',
+    embeddingModel: '',
+    prompts: {
+      summaryLength: 150,
+      factLength: 50,
+      maxFacts: 5,
+      keyPointLength: 30,
+      summarizeTemplate: '',
+      extractTemplate: '',
+      keyPointsTemplate: '',
+      consolidateTemplate: '',
+    },
   });
   const [proxyConfig, setProxyConfig] = useState<ProxyConfig>({
     mode: 'none',
     customUrl: '',
+    pacUrl: '',
   });
   const [openClawConfig, setOpenClawConfig] = useState<OpenClawConfig>({
     enabled: false,
     port: 51888,
     apiKey: '',
   });
+  const [toolSandboxConfig, setToolSandboxConfig] = useState<ToolSandboxConfig>({
+    defaultTimeout: 0,
+    defaultRateLimit: 0,
+    policies: [],
+    auditDays: 0,
+  });
+  const [updateConfig, setUpdateConfig] = useState<UpdateConfig>({ disableAutoCheck: false, skipVersion: '' });
+  const [hotkeyConfig, setHotkeyConfig] = useState<HotkeyConfig>({ enabled: false, showHide: '', bossKey: '', quickSearch: '' });
+  const [notificationConfig, setNotificationConfig] = useState<NotificationConfig>(DEFAULT_NOTIFICATION_CONFIG);
+  const [lockupAlertConfig, setLockupAlertConfig] = useState<LockupAlertConfig>({ enabled: false, daysBefore: 7, minFloatRatio: 5 });
   const [strategies, setStrategies] = useState<Strategy[]>([]);
   const [activeStrategyId, setActiveStrategyId] = useState<string>('');
   const [moderatorAiId, setModeratorAiId] = useState<string>('');
@@ -133,6 +222,7 @@ export const SettingsDialog: React.FC<SettingsDialogProps> = ({ isOpen, onClose
       setProxyConfig({
         mode: config.proxy.mode as ProxyMode,
         customUrl: config.proxy.customUrl || '',
+        pacUrl: config.proxy.pacUrl || '',
       });
     }
     if (config.openClaw) {
@@ -142,6 +232,26 @@ export const SettingsDialog: React.FC<SettingsDialogProps> = ({ isOpen, onClose
         apiKey: config.openClaw.apiKey || '',
       });
     }
+    if (config.toolSandbox) {
+      setToolSandboxConfig({ ...config.toolSandbox, policies: config.toolSandbox.policies || [] });
+    }
+    if (config.update) setUpdateConfig(config.update);
+    if (config.hotkeys) setHotkeyConfig(config.hotkeys);
+    if (config.notifications) {
+      setNotificationConfig({
+        disabledCategories: config.notifications.disabledCategories || [],
+        quietHours: { ...DEFAULT_NOTIFICATION_CONFIG.quietHours, ...config.notifications.quietHours },
+        maxHistory: config.notifications.maxHistory || 0,
+        sound: { ...DEFAULT_NOTIFICATION_CONFIG.sound, ...config.notifications.sound, sounds: config.notifications.sound?.sounds || {} },
+      });
+    }
+    if (config.lockupAlert) {
+      setLockupAlertConfig({
+        enabled: config.lockupAlert.enabled || false,
+        daysBefore: config.lockupAlert.daysBefore || 7,
+        minFloatRatio: config.lockupAlert.minFloatRatio || 5,
+      });
+    }
     if (config.moderatorAiId) setModeratorAiId(config.moderatorAiId);
     if (config.strategyAiId) setStrategyAiId(config.strategyAiId);
 
@@ -172,6 +282,11 @@ export const SettingsDialog: React.FC<SettingsDialogProps> = ({ isOpen, onClose
     mcpServers: MCPServerConfig[];
     memory: MemoryConfig;
     proxy: ProxyConfig;
+    toolSandbox: ToolSandboxConfig;
+    update: UpdateConfig;
+    hotkeys: HotkeyConfig;
+    notifications: NotificationConfig;
+    lockupAlert: LockupAlertConfig;
     moderatorAiId: string;
     strategyAiId: string;
     indicators: any;
@@ -185,11 +300,16 @@ export const SettingsDialog: React.FC<SettingsDialogProps> = ({ isOpen, onClose
     showToast('loading', '保存中...');
     try {
       const currentConfig = await getConfig();
-      await updateConfig({
+      const result = await updateConfig({
         ...currentConfig,
         ...updates,
         defaultAiId: (updates.aiConfigs || currentConfig.aiConfigs)?.find(c => c.isDefault)?.id || '',
       } as any);
+      if (result !== 'success') {
+        hideToast();
+        showToast('error', result);
+        return;
+      }
       pendingUpdatesRef.current = {};
       hideToast();
       showToast('success', '已保存');
@@ -206,9 +326,15 @@ export const SettingsDialog: React.FC<SettingsDialogProps> = ({ isOpen, onClose
     memory: MemoryConfig;
     proxy: ProxyConfig;
     openClaw: OpenClawConfig;
+    toolSandbox: ToolSandboxConfig;
+    update: UpdateConfig;
+    hotkeys: HotkeyConfig;
+    notifications: NotificationConfig;
+    lockupAlert: LockupAlertConfig;
     moderatorAiId: string;
     strategyAiId: string;
     candleColorMode: string;
+    language: string;
     indicators: any;
   }>) => {
     // 合并待保存的更新
@@ -242,11 +368,16 @@ export const SettingsDialog: React.FC<SettingsDialogProps> = ({ isOpen, onClose
     { id: 'provider', label: '模型基座', icon: <Cpu className="h-4 w-4" /> },
     { id: 'intent', label: '意图配置', icon: <MessageSquare className="h-4 w-4" /> },
     { id: 'strategy', label: '策略管理', icon: <Layers className="h-4 w-4" /> },
+    { id: 'persona', label: '自定义专家', icon: <Sparkles className="h-4 w-4" /> },
     { id: 'mcp', label: 'MCP服务', icon: <Plug className="h-4 w-4" /> },
     { id: 'memory', label: '记忆管理', icon: <Brain className="h-4 w-4" /> },
     { id: 'chart', label: '图表设置', icon: <Sliders className="h-4 w-4" /> },
     { id: 'proxy', label: '网络代理', icon: <Globe className="h-4 w-4" /> },
     { id: 'openclaw', label: 'OpenClaw', icon: <Plug className="h-4 w-4" /> },
+    { id: 'audit', label: '工具审计', icon: <ShieldCheck className="h-4 w-4" /> },
+    { id: 'hotkey', label: '快捷键', icon: <Keyboard className="h-4 w-4" /> },
+    { id: 'notify', label: '通知提醒', icon: <Bell className="h-4 w-4" /> },
+    { id: 'backup', label: '备份恢复', icon: <Archive className="h-4 w-4" /> },
     { id: 'update', label: '软件更新', icon: <RefreshCw className="h-4 w-4" /> },
   ];
 
@@ -316,6 +447,13 @@ export const SettingsDialog: React.FC<SettingsDialogProps> = ({ isOpen, onClose
                 showToast={showToast}
               />
             )}
+            {activeTab === 'persona' && (
+              <PersonaSettings
+                mcpServers={mcpServers}
+                aiConfigs={aiConfigs}
+                showToast={showToast}
+              />
+            )}
             {activeTab === 'mcp' && (
               <MCPSettings
                 servers={mcpServers}
@@ -369,8 +507,60 @@ export const SettingsDialog: React.FC<SettingsDialogProps> = ({ isOpen, onClose
                 }}
               />
             )}
+            {activeTab === 'audit' && (
+              <ToolAuditSettings
+                config={toolSandboxConfig}
+                onChange={(config) => {
+                  setToolSandboxConfig(config);
+                  saveConfig({ toolSandbox: config });
+                }}
+              />
+            )}
+            {activeTab === 'hotkey' && (
+              <HotkeySettings
+                config={hotkeyConfig}
+                onChange={(config) => {
+                  setHotkeyConfig(config);
+                  saveConfig({ hotkeys: config });
+                }}
+              />
+            )}
+            {activeTab === 'notify' && (
+              <NotificationSettings
+                config={notificationConfig}
+                onChange={(config) => {
+                  setNotificationConfig(config);
+                  saveConfig({ notifications: config });
+                }}
+                lockupAlert={lockupAlertConfig}
+                onLockupAlertChange={(config) => {
+                  setLockupAlertConfig(config);
+                  saveConfig({ lockupAlert: config });
+                }}
+              />
+            )}
+            {activeTab === 'backup' && (
+              <BackupSettings
+                showToast={showToast}
+                onRestored={() => {
+                  // 丢弃未保存的修改，避免覆盖导入或重置后的配置
+                  if (saveTimerRef.current) {
+                    clearTimeout(saveTimerRef.current);
+                    saveTimerRef.current = null;
+                  }
+                  pendingUpdatesRef.current = {};
+                  loadAllConfigs();
+                }}
+              />
+            )}
             {activeTab === 'update' && (
-              <UpdateSettings />
+              <UpdateSettings
+                config={updateConfig}
+                onChange={(config) => {
+                  setUpdateConfig(config);
+                  saveConfig({ update: config });
+                }}
+              />
             )}
           </div>
         </div>
@@ -408,7 +598,7 @@ const Header: React.FC<{ onClose: () => void }> = ({ onClose }) => {
 };
 
 // ========== Provider 设置选项卡 ==========
-const PROVIDERS = ['openai', 'gemini', 'vertexai', 'anthropic'] as const;
+const PROVIDERS = ['openai', 'gemini', 'vertexai', 'anthropic', 'ollama'] as const;
 type ProviderType = typeof PROVIDERS[number];
 
 const PROVIDER_LABELS: Record<ProviderType, string> = {
@@ -416,6 +606,7 @@ const PROVIDER_LABELS: Record<ProviderType, string> = {
   gemini: 'Gemini',
   vertexai: 'Vertex AI',
   anthropic: 'Anthropic',
+  ollama: 'Ollama',
 };
 
 interface ProviderSettingsProps {
@@ -450,6 +641,9 @@ const ProviderSettings: React.FC<ProviderSettingsProps> = ({ configs, onChange,
       timeout: 60,
       isDefault: configs.length === 0,
       useResponses: false,
+      keepAlive: '',
+      safetyThreshold: '',
+      googleSearch: false,
       project: '',
       location: 'us-central1',
       credentialsJson: '',
@@ -571,6 +765,19 @@ const ProviderListView: React.FC<ProviderListViewProps> = ({
   const { colors } = useTheme();
   const defaultCount = configs.filter(c => c.isDefault).length;
 
+  const handlePullModel = async () => {
+    setPulling(true);
+    setTestResult(null);
+    try {
+      const result = await pullOllamaModel(config.baseUrl, config.modelName);
+      setTestResult(result === 'success' ? { success: true } : { success: false, error: result });
+    } catch (e: any) {
+      setTestResult({ success: false, error: e.message || '未知错误' });
+    } finally {
+      setPulling(false);
+    }
+  };
+
   return (
     <div className="space-y-4">
       {/* 头部 */}
@@ -766,7 +973,10 @@ const ProviderEditView: React.FC<ProviderEditViewProps> = ({
 }) => {
   const { colors } = useTheme();
   const isVertexAI = config.provider === 'vertexai';
+  const isOllama = config.provider === 'ollama';
+  const isGeminiNative = config.provider === 'gemini' || isVertexAI;
   const [testing, setTesting] = useState(false);
+  const [pulling, setPulling] = useState(false);
   const [testResult, setTestResult] = useState<{ success: boolean; error?: string } | null>(null);
 
   const handleTestConnection = async () => {
@@ -805,6 +1015,22 @@ const ProviderEditView: React.FC<ProviderEditViewProps> = ({
           </div>
         </div>
         <div className="flex items-center gap-2">
+          {isOllama && (
+            <button
+              onClick={handlePullModel}
+              disabled={pulling}
+              className={`flex items-center gap-1.5 px-3 py-1.5 text-xs rounded-lg disabled:opacity-50 transition-colors shrink-0 ${colors.isDark ? 'bg-slate-700 hover:bg-slate-600 text-slate-300' : 'bg-slate-200 hover:bg-slate-300 text-slate-600'}`}
+            >
+              {pulling ? (
+                <>
+                  <Loader2 className="h-3 w-3 animate-spin" />
+                  拉取中...
+                </>
+              ) : (
+                '拉取模型'
+              )}
+            </button>
+          )}
           <button
             onClick={handleTestConnection}
             disabled={testing}
@@ -850,7 +1076,11 @@ const ProviderEditView: React.FC<ProviderEditViewProps> = ({
         {!isVertexAI && (
           <>
             <FormField label="Base URL" value={config.baseUrl} onChange={v => onChange({ ...config, baseUrl: v })} />
-            <FormField label="API Key" value={config.apiKey} onChange={v => onChange({ ...config, apiKey: v })} type="password" />
+            {isOllama ? (
+              <FormField label="模型驻留时长 (keep_alive，如 5m / 1h / -1)" value={config.keepAlive || ''} onChange={v => onChange({ ...config, keepAlive: v })} />
+            ) : (
+              <FormField label="API Key" value={config.apiKey} onChange={v => onChange({ ...config, apiKey: v })} type="password" />
+            )}
           </>
         )}
 
@@ -880,6 +1110,37 @@ const ProviderEditView: React.FC<ProviderEditViewProps> = ({
 
         <FormField label="模型名称" value={config.modelName} onChange={v => onChange({ ...config, modelName: v })} />
 
+        {isGeminiNative && (
+          <>
+            <FormField
+              label="思考预算 (token，留空为模型默认，0 关闭，-1 动态)"
+              value={config.thinkingBudget === undefined || config.thinkingBudget === null ? '' : String(config.thinkingBudget)}
+              onChange={v => {
+                const n = parseInt(v, 10);
+                onChange({ ...config, thinkingBudget: v.trim() === '' || isNaN(n) ? undefined : n });
+              }}
+            />
+            <div>
+              <label className={`block text-sm mb-1.5 ${colors.isDark ? 'text-slate-400' : 'text-slate-500'}`}>安全过滤阈值</label>
+              <select
+                value={config.safetyThreshold || ''}
+                onChange={e => onChange({ ...config, safetyThreshold: e.target.value })}
+                className={`w-full fin-input rounded-lg px-3 py-2 text-sm ${colors.isDark ? 'text-white' : 'text-slate-800'}`}
+              >
+                <option value="">默认</option>
+                <option value="BLOCK_LOW_AND_ABOVE">严格 (BLOCK_LOW_AND_ABOVE)</option>
+                <option value="BLOCK_MEDIUM_AND_ABOVE">中等 (BLOCK_MEDIUM_AND_ABOVE)</option>
+                <option value="BLOCK_ONLY_HIGH">宽松 (BLOCK_ONLY_HIGH)</option>
+                <option value="BLOCK_NONE">不过滤 (BLOCK_NONE)</option>
+              </select>
+            </div>
+            <div className="flex items-center justify-between">
+              <label className={`text-sm ${colors.isDark ? 'text-slate-400' : 'text-slate-500'}`}>Google 搜索 grounding（无工具调用时生效）</label>
+              <ToggleSwitch checked={config.googleSearch} onChange={v => onChange({ ...config, googleSearch: v })} />
+            </div>
+          </>
+        )}
+
         {/* 温度配置 */}
         <div>
           <label className={`block text-sm mb-1.5 ${colors.isDark ? 'text-slate-400' : 'text-slate-500'}`}>
@@ -1043,6 +1304,7 @@ const getDefaultBaseUrl = (provider: string): string => {
     case 'openai': return 'https://api.openai.com/v1';
     case 'gemini': return 'https://generativelanguage.googleapis.com';
     case 'anthropic': return 'https://api.anthropic.com';
+    case 'ollama': return 'http://localhost:11434';
     default: return '';
   }
 };
@@ -1053,6 +1315,7 @@ const getDefaultModel = (provider: string): string => {
     case 'gemini': return 'gemini-2.5-flash';
     case 'vertexai': return 'gemini-2.5-flash';
     case 'anthropic': return 'claude-sonnet-4-20250514';
+    case 'ollama': return 'qwen3:8b';
     default: return '';
   }
 };
@@ -1179,6 +1442,85 @@ const MemorySettings: React.FC<MemorySettingsProps> = ({ config, aiConfigs, onCh
               className={`w-full h-2 rounded-lg appearance-none cursor-pointer accent-[var(--accent)] ${colors.isDark ? 'bg-slate-700' : 'bg-slate-300'}`}
             />
           </div>
+
+          <div>
+            <label className={`block text-sm mb-2 ${colors.isDark ? 'text-slate-300' : 'text-slate-600'}`}>
+              向量检索
+              <span className={`ml-2 ${colors.isDark ? 'text-slate-500' : 'text-slate-400'}`}>(按语义相似度检索历史事实)</span>
+            </label>
+            <select
+              value={config.embeddingAiId || ''}
+              onChange={(e) => onChange({ ...config, embeddingAiId: e.target.value })}
+              className={`w-full fin-input rounded-lg px-3 py-2 text-sm ${colors.isDark ? 'text-white' : 'text-slate-800'}`}
+            >
+              <option value="">不使用（关键词匹配）</option>
+              {aiConfigs.map(ai => (
+                <option key={ai.id} value={ai.id}>
+                  {ai.name} ({ai.provider})
+                </option>
+              ))}
+            </select>
+            {config.embeddingAiId && (
+              <input
+                type="text"
+                value={config.embeddingModel || ''}
+                onChange={(e) => onChange({ ...config, embeddingModel: e.target.value.trim() })}
+                placeholder="向量模型名称，如 text-embedding-3-small"
+                className={`w-full fin-input rounded-lg px-3 py-2 text-sm mt-2 ${colors.isDark ? 'text-white' : 'text-slate-800'}`}
+              />
+            )}
+            <p className={`text-xs mt-1 ${colors.isDark ? 'text-slate-500' : 'text-slate-400'}`}>
+              使用所选配置的地址与密钥调用 OpenAI 兼容的 /embeddings 接口
+            </p>
+          </div>
+
+          <details className={`rounded-lg border px-3 py-2 ${colors.isDark ? 'border-slate-700' : 'border-slate-300'}`}>
+            <summary className={`text-sm cursor-pointer ${colors.isDark ? 'text-slate-300' : 'text-slate-600'}`}>
+              摘要提示词（高级）
+            </summary>
+            <div className="space-y-3 mt-3">
+              <div className="grid grid-cols-2 gap-3">
+                {([
+                  ['summaryLength', '摘要字数'],
+                  ['factLength', '单条事实字数'],
+                  ['maxFacts', '每次提取事实数'],
+                  ['keyPointLength', '单条观点字数'],
+                ] as const).map(([key, label]) => (
+                  <label key={key} className={`text-xs ${colors.isDark ? 'text-slate-400' : 'text-slate-500'}`}>
+                    {label}
+                    <input
+                      type="number"
+                      min="0"
+                      value={config.prompts?.[key] || 0}
+                      onChange={(e) => onChange({ ...config, prompts: { ...config.prompts, [key]: parseInt(e.target.value) || 0 } })}
+                      className={`w-full fin-input rounded-lg px-3 py-1.5 text-sm mt-1 ${colors.isDark ? 'text-white' : 'text-slate-800'}`}
+                    />
+                  </label>
+                ))}
+              </div>
+              {([
+                ['summarizeTemplate', '讨论压缩模板', '{{.Rounds}} {{.MaxLength}}'],
+                ['extractTemplate', '事实提取模板', '{{.Content}} {{.MaxFacts}} {{.MaxLength}}'],
+                ['keyPointsTemplate', '观点提取模板', '{{.Discussions}} {{.MaxLength}}'],
+                ['consolidateTemplate', '事实归并模板', '{{.Facts}} {{.MaxLength}}'],
+              ] as const).map(([key, label, vars]) => (
+                <label key={key} className={`block text-xs ${colors.isDark ? 'text-slate-400' : 'text-slate-500'}`}>
+                  {label}
+                  <span className="ml-2 font-mono">{vars}</span>
+                  <textarea
+                    rows={4}
+                    value={config.prompts?.[key] || ''}
+                    onChange={(e) => onChange({ ...config, prompts: { ...config.prompts, [key]: e.target.value } })}
+                    placeholder="留空使用内置模板"
+                    className={`w-full fin-input rounded-lg px-3 py-2 text-xs font-mono mt-1 ${colors.isDark ? 'text-white' : 'text-slate-800'}`}
+                  />
+                </label>
+              ))}
+              <p className={`text-xs ${colors.isDark ? 'text-slate-500' : 'text-slate-400'}`}>
+                模板使用 Go text/template 语法，可改写为其他语言以调整摘要语言与风格；数值为 0 时使用默认值
+              </p>
+            </div>
+          </details>
         </div>
       )}
     </div>
@@ -1507,6 +1849,16 @@ const ChartSettings: React.FC<{ saveConfig: (updates: any) => void }> = ({ saveC
     { value: 'green-up', label: '绿涨红跌', upLabel: '涨', downLabel: '跌', upCls: 'text-green-500', downCls: 'text-red-500' },
   ];
 
+  // 后端生成文本（市场状态、交易时段、节假日、错误提示）的语言
+  const [language, setLanguage] = useState('zh-CN');
+  useEffect(() => {
+    getConfig().then(cfg => setLanguage(cfg.language || 'zh-CN'));
+  }, []);
+  const languageOptions = [
+    { value: 'zh-CN', label: '简体中文' },
+    { value: 'en-US', label: 'English' },
+  ];
+
   const inputCls = `w-full px-2 py-1 text-xs rounded border ${
     colors.isDark ? 'bg-slate-800 border-slate-600 text-slate-200' : 'bg-white border-slate-300 text-slate-700'
   }`;
@@ -1514,6 +1866,28 @@ const ChartSettings: React.FC<{ saveConfig: (updates: any) => void }> = ({ saveC
 
   return (
     <div className="space-y-6 overflow-y-auto max-h-[420px] pr-1">
+      {/* ===== 显示语言 ===== */}
+      <div className="flex items-center justify-between">
+        <div>
+          <h3 className={`font-medium ${colors.isDark ? 'text-white' : 'text-slate-800'}`}>行情文本语言</h3>
+          <p className={`text-xs mt-1 ${colors.isDark ? 'text-slate-400' : 'text-slate-500'}`}>
+            市场状态、交易时段、节假日名称与错误提示的显示语言
+          </p>
+        </div>
+        <select
+          value={language}
+          onChange={(e) => {
+            setLanguage(e.target.value);
+            saveConfig({ language: e.target.value });
+          }}
+          className={`fin-input rounded-lg px-3 py-1.5 text-sm ${colors.isDark ? 'text-white' : 'text-slate-800'}`}
+        >
+          {languageOptions.map(opt => (
+            <option key={opt.value} value={opt.value}>{opt.label}</option>
+          ))}
+        </select>
+      </div>
+
       {/* ===== 涨跌颜色 ===== */}
       <div>
         <h3 className={`font-medium ${colors.isDark ? 'text-white' : 'text-slate-800'}`}>涨跌颜色</h3>
@@ -1764,7 +2138,37 @@ const ProxySettings: React.FC<ProxySettingsProps> = ({ config, onChange }) => {
     { value: 'none', label: '无代理', desc: '直接连接，不使用任何代理' },
     { value: 'system', label: '系统代理', desc: '使用操作系统的代理设置' },
     { value: 'custom', label: '自定义代理', desc: '手动指定代理服务器地址' },
+    { value: 'pac', label: 'PAC 脚本', desc: '按自动配置脚本为每个地址选择代理' },
   ];
+  const [detected, setDetected] = useState<SystemProxy | null>(null);
+  const [detecting, setDetecting] = useState(false);
+
+  const handleDetect = useCallback(async () => {
+    setDetecting(true);
+    try {
+      setDetected(await detectSystemProxy());
+    } finally {
+      setDetecting(false);
+    }
+  }, []);
+
+  useEffect(() => {
+    if (config.mode === 'system') handleDetect();
+  }, [config.mode, handleDetect]);
+
+  const sourceLabels: Record<string, string> = {
+    env: '环境变量',
+    windows: 'Windows 设置',
+    macos: 'macOS 设置',
+    gnome: 'GNOME 设置',
+  };
+  const detectedRows = detected ? [
+    { label: 'PAC 脚本', value: detected.pacUrl },
+    { label: 'HTTP', value: detected.http },
+    { label: 'HTTPS', value: detected.https },
+    { label: 'SOCKS', value: detected.socks },
+    { label: '例外', value: (detected.bypass || []).join(', ') },
+  ].filter(row => row.value) : [];
 
   return (
     <div className="space-y-6">
@@ -1820,6 +2224,213 @@ const ProxySettings: React.FC<ProxySettingsProps> = ({ config, onChange }) => {
           </p>
         </div>
       )}
+
+      {/* 系统代理检测结果 */}
+      {config.mode === 'system' && (
+        <div className={`pt-4 border-t ${colors.isDark ? 'border-slate-700' : 'border-slate-300'}`}>
+          <div className="flex items-center justify-between mb-2">
+            <span className={`text-sm ${colors.isDark ? 'text-slate-300' : 'text-slate-600'}`}>
+              检测结果{detected?.source ? `（${sourceLabels[detected.source] || detected.source}）` : ''}
+            </span>
+            <button
+              onClick={handleDetect}
+              disabled={detecting}
+              className={`flex items-center gap-1 text-xs px-2 py-1 rounded transition-colors disabled:opacity-50 ${colors.isDark ? 'text-slate-400 hover:text-white hover:bg-slate-700/50' : 'text-slate-500 hover:text-slate-700 hover:bg-slate-200/50'}`}
+            >
+              <RefreshCw className={`h-3 w-3 ${detecting ? 'animate-spin' : ''}`} />
+              重新检测
+            </button>
+          </div>
+          {detectedRows.length === 0 ? (
+            <p className={`text-xs ${colors.isDark ? 'text-slate-500' : 'text-slate-400'}`}>
+              {detecting ? '检测中...' : '未检测到系统代理，将直接连接'}
+            </p>
+          ) : (
+            <div className="space-y-1">
+              {detectedRows.map(row => (
+                <div key={row.label} className="flex gap-3 text-xs">
+                  <span className={`w-16 shrink-0 ${colors.isDark ? 'text-slate-500' : 'text-slate-400'}`}>{row.label}</span>
+                  <span className={`font-mono break-all ${colors.isDark ? 'text-slate-300' : 'text-slate-700'}`}>{row.value}</span>
+                </div>
+              ))}
+            </div>
+          )}
+        </div>
+      )}
+
+      {/* PAC 脚本地址输入 */}
+      {config.mode === 'pac' && (
+        <div className={`pt-4 border-t ${colors.isDark ? 'border-slate-700' : 'border-slate-300'}`}>
+          <label className={`block text-sm mb-2 ${colors.isDark ? 'text-slate-300' : 'text-slate-600'}`}>
+            PAC 脚本地址
+          </label>
+          <input
+            type="text"
+            value={config.pacUrl}
+            onChange={(e) => onChange({ ...config, pacUrl: e.target.value })}
+            placeholder="http://wpad.example.com/proxy.pac"
+            className={`w-full fin-input rounded-lg px-3 py-2 text-sm ${colors.isDark ? 'text-white' : 'text-slate-800'}`}
+          />
+          <p className={`text-xs mt-2 ${colors.isDark ? 'text-slate-500' : 'text-slate-400'}`}>
+            支持 http(s):// 地址或本地文件路径；脚本加载或执行失败时直接连接
+          </p>
+        </div>
+      )}
+    </div>
+  );
+};
+
+// ========== 工具审计选项卡 ==========
+interface ToolAuditSettingsProps {
+  config: ToolSandboxConfig;
+  onChange: (config: ToolSandboxConfig) => void;
+}
+
+const auditStatusLabels: Record<string, { label: string; className: string }> = {
+  ok: { label: '成功', className: 'text-green-500 bg-green-500/10' },
+  error: { label: '失败', className: 'text-red-500 bg-red-500/10' },
+  timeout: { label: '超时', className: 'text-orange-500 bg-orange-500/10' },
+  invalid: { label: '参数错误', className: 'text-yellow-500 bg-yellow-500/10' },
+  rate_limited: { label: '限流', className: 'text-purple-500 bg-purple-500/10' },
+};
+
+const formatLocalDate = (d: Date) => {
+  const pad = (n: number) => String(n).padStart(2, '0');
+  return `${d.getFullYear()}-${pad(d.getMonth() + 1)}-${pad(d.getDate())}`;
+};
+
+const ToolAuditSettings: React.FC<ToolAuditSettingsProps> = ({ config, onChange }) => {
+  const { colors } = useTheme();
+  const [date, setDate] = useState(() => formatLocalDate(new Date()));
+  const [toolName, setToolName] = useState('');
+  const [tools, setTools] = useState<ToolInfo[]>([]);
+  const [entries, setEntries] = useState<ToolAuditEntry[]>([]);
+  const [loading, setLoading] = useState(false);
+  const [expanded, setExpanded] = useState<number | null>(null);
+
+  const loadEntries = useCallback(async () => {
+    setLoading(true);
+    try {
+      setEntries(await getToolAuditLog(date, toolName, 200) || []);
+      setExpanded(null);
+    } finally {
+      setLoading(false);
+    }
+  }, [date, toolName]);
+
+  useEffect(() => {
+    getAvailableTools().then(list => setTools(list || []));
+  }, []);
+
+  useEffect(() => {
+    loadEntries();
+  }, [loadEntries]);
+
+  const labelClass = `block text-sm mb-1 ${colors.isDark ? 'text-slate-300' : 'text-slate-600'}`;
+  const inputClass = `w-full fin-input rounded-lg px-3 py-1.5 text-sm ${colors.isDark ? 'text-white' : 'text-slate-800'}`;
+  const mutedClass = colors.isDark ? 'text-slate-400' : 'text-slate-500';
+
+  return (
+    <div className="space-y-5">
+      <div>
+        <h3 className={`font-medium ${colors.isDark ? 'text-white' : 'text-slate-800'}`}>工具审计</h3>
+        <p className={`text-sm mt-1 ${mutedClass}`}>
+          专家调用工具时统一限制执行时长与调用频率、校验参数，并记录每次调用与结果
+        </p>
+      </div>
+
+      <div className="grid grid-cols-3 gap-3">
+        <div>
+          <label className={labelClass}>默认超时(秒)</label>
+          <input
+            type="number"
+            min={0}
+            value={config.defaultTimeout || ''}
+            placeholder="30"
+            onChange={(e) => onChange({ ...config, defaultTimeout: Math.max(0, parseInt(e.target.value) || 0) })}
+            className={inputClass}
+          />
+        </div>
+        <div>
+          <label className={labelClass}>每分钟调用上限</label>
+          <input
+            type="number"
+            min={0}
+            value={config.defaultRateLimit || ''}
+            placeholder="不限"
+            onChange={(e) => onChange({ ...config, defaultRateLimit: Math.max(0, parseInt(e.target.value) || 0) })}
+            className={inputClass}
+          />
+        </div>
+        <div>
+          <label className={labelClass}>日志保留(天)</label>
+          <input
+            type="number"
+            min={0}
+            value={config.auditDays || ''}
+            placeholder="30"
+            onChange={(e) => onChange({ ...config, auditDays: Math.max(0, parseInt(e.target.value) || 0) })}
+            className={inputClass}
+          />
+        </div>
+      </div>
+
+      <div className={`pt-4 border-t ${colors.isDark ? 'border-slate-700' : 'border-slate-300'}`}>
+        <div className="flex items-center gap-2 mb-3">
+          <input
+            type="date"
+            value={date}
+            onChange={(e) => setDate(e.target.value)}
+            className={`fin-input rounded-lg px-2 py-1 text-sm ${colors.isDark ? 'text-white' : 'text-slate-800'}`}
+          />
+          <select
+            value={toolName}
+            onChange={(e) => setToolName(e.target.value)}
+            className={`flex-1 fin-input rounded-lg px-2 py-1 text-sm ${colors.isDark ? 'text-white' : 'text-slate-800'}`}
+          >
+            <option value="">全部工具</option>
+            {tools.map(t => <option key={t.name} value={t.name}>{t.name}</option>)}
+          </select>
+          <button
+            onClick={loadEntries}
+            className={`p-1.5 rounded-lg transition-colors ${colors.isDark ? 'hover:bg-slate-700 text-slate-400' : 'hover:bg-slate-200 text-slate-500'}`}
+            title="刷新"
+          >
+            <RefreshCw className={`h-4 w-4 ${loading ? 'animate-spin' : ''}`} />
+          </button>
+        </div>
+
+        {entries.length === 0 ? (
+          <div className={`text-sm text-center py-8 ${mutedClass}`}>{loading ? '加载中...' : '暂无调用记录'}</div>
+        ) : (
+          <div className="space-y-1">
+            {entries.map((e, i) => {
+              const status = auditStatusLabels[e.status] || { label: e.status, className: mutedClass };
+              return (
+                <div key={`${e.time}-${i}`} className={`rounded-lg border ${colors.isDark ? 'border-slate-700' : 'border-slate-200'}`}>
+                  <button
+                    onClick={() => setExpanded(expanded === i ? null : i)}
+                    className="w-full flex items-center gap-2 px-3 py-1.5 text-xs text-left"
+                  >
+                    <span className={`font-mono ${mutedClass}`}>{new Date(e.time).toLocaleTimeString()}</span>
+                    <span className={`font-mono ${colors.isDark ? 'text-white' : 'text-slate-800'}`}>{e.tool}</span>
+                    <span className={mutedClass}>{e.agent}{e.session ? ` · ${e.session}` : ''}</span>
+                    <span className={`ml-auto px-1.5 py-0.5 rounded ${status.className}`}>{status.label}</span>
+                    <span className={`w-14 text-right font-mono ${mutedClass}`}>{e.duration}ms</span>
+                  </button>
+                  {expanded === i && (
+                    <div className={`px-3 pb-2 space-y-1 text-xs font-mono break-all ${mutedClass}`}>
+                      <div><span className="font-sans">参数：</span>{e.args || '{}'}</div>
+                      {e.error && <div className="text-red-500"><span className="font-sans">错误：</span>{e.error}</div>}
+                      {e.result && <div className="max-h-32 overflow-y-auto"><span className="font-sans">结果：</span>{e.result}</div>}
+                    </div>
+                  )}
+                </div>
+              );
+            })}
+          </div>
+        )}
+      </div>
     </div>
   );
 };
@@ -1930,8 +2541,310 @@ const OpenClawSettings: React.FC<OpenClawSettingsProps> = ({ config, onChange })
   );
 };
 
+// ========== 快捷键设置选项卡 ==========
+const DEFAULT_HOTKEYS = { showHide: 'Ctrl+Alt+J', bossKey: 'Ctrl+Alt+H', quickSearch: 'Ctrl+Alt+F' };
+
+// formatHotkey 将按键事件转换为 "Ctrl+Alt+J" 形式，仅按下修饰键时返回空
+const formatHotkey = (e: React.KeyboardEvent): string => {
+  const named: Record<string, string> = {
+    ' ': 'Space', Escape: 'Esc', ArrowUp: 'Up', ArrowDown: 'Down', ArrowLeft: 'Left', ArrowRight: 'Right',
+  };
+  if (['Control', 'Alt', 'Shift', 'Meta'].includes(e.key)) return '';
+  let key = named[e.key] || e.key;
+  if (/^Key[A-Z]$/.test(e.code)) key = e.code.slice(3);
+  else if (/^Digit[0-9]$/.test(e.code)) key = e.code.slice(5);
+  else if (key.length === 1) key = key.toUpperCase();
+  const mods = [e.ctrlKey && 'Ctrl', e.altKey && 'Alt', e.shiftKey && 'Shift', e.metaKey && 'Win'].filter(Boolean);
+  return [...mods, key].join('+');
+};
+
+interface HotkeySettingsProps {
+  config: HotkeyConfig;
+  onChange: (config: HotkeyConfig) => void;
+}
+
+const HotkeySettings: React.FC<HotkeySettingsProps> = ({ config, onChange }) => {
+  const { colors } = useTheme();
+  const [supported, setSupported] = useState(true);
+
+  useEffect(() => {
+    hotkeysSupported().then(setSupported);
+  }, []);
+
+  const items: { field: keyof typeof DEFAULT_HOTKEYS; label: string; desc: string }[] = [
+    { field: 'showHide', label: '显示/隐藏窗口', desc: '窗口隐藏或最小化时显示，否则隐藏' },
+    { field: 'bossKey', label: '老板键', desc: '立即隐藏窗口并静音通知，再按一次恢复' },
+    { field: 'quickSearch', label: '快速搜索', desc: '显示窗口并聚焦股票搜索框' },
+  ];
+
+  const toggleEnabled = () => {
+    if (config.enabled) {
+      onChange({ ...config, enabled: false });
+      return;
+    }
+    // 首次启用时填入默认快捷键
+    const unset = !config.showHide && !config.bossKey && !config.quickSearch;
+    onChange({ ...config, ...(unset ? DEFAULT_HOTKEYS : {}), enabled: true });
+  };
+
+  return (
+    <div className="space-y-6">
+      <div>
+        <h3 className={`font-medium ${colors.isDark ? 'text-white' : 'text-slate-800'}`}>全局快捷键</h3>
+        <p className={`text-sm mt-1 ${colors.isDark ? 'text-slate-400' : 'text-slate-500'}`}>
+          应用在后台时也能响应，点击输入框后按下组合键录入，Backspace 清除
+        </p>
+        {!supported && <p className="text-xs mt-1 text-amber-400">当前系统暂不支持全局快捷键（目前仅支持 Windows）</p>}
+      </div>
+
+      <div className={`flex items-center justify-between p-3 rounded-lg border ${
+        colors.isDark ? 'border-slate-700' : 'border-slate-300'
+      }`}>
+        <div className={`text-sm font-medium ${colors.isDark ? 'text-white' : 'text-slate-800'}`}>启用全局快捷键</div>
+        <button
+          onClick={toggleEnabled}
+          className={`relative w-11 h-6 rounded-full transition-colors cursor-pointer ${
+            config.enabled ? 'bg-[var(--accent)]' : (colors.isDark ? 'bg-slate-600' : 'bg-slate-300')
+          }`}
+        >
+          <div className={`absolute top-1 w-4 h-4 rounded-full bg-white shadow transition-transform ${
+            config.enabled ? 'translate-x-6' : 'translate-x-1'
+          }`} />
+        </button>
+      </div>
+
+      {config.enabled && (
+        <div className="space-y-4">
+          {items.map(({ field, label, desc }) => (
+            <div key={field} className="flex items-center justify-between gap-4">
+              <div>
+                <div className={`text-sm ${colors.isDark ? 'text-slate-300' : 'text-slate-600'}`}>{label}</div>
+                <div className={`text-xs mt-0.5 ${colors.isDark ? 'text-slate-500' : 'text-slate-400'}`}>{desc}</div>
+              </div>
+              <input
+                readOnly
+                value={config[field]}
+                placeholder="未设置"
+                onKeyDown={(e) => {
+                  if (e.key === 'Tab') return;
+                  e.preventDefault();
+                  if ((e.key === 'Backspace' || e.key === 'Delete') && !e.ctrlKey && !e.altKey && !e.shiftKey && !e.metaKey) {
+                    onChange({ ...config, [field]: '' });
+                    return;
+                  }
+                  const hotkey = formatHotkey(e);
+                  if (hotkey) onChange({ ...config, [field]: hotkey });
+                }}
+                className={`w-44 fin-input rounded-lg px-3 py-2 text-sm text-center cursor-pointer ${colors.isDark ? 'text-white placeholder-slate-500' : 'text-slate-800 placeholder-slate-400'}`}
+              />
+            </div>
+          ))}
+        </div>
+      )}
+    </div>
+  );
+};
+
+// ========== 通知提醒选项卡 ==========
+interface NotificationSettingsProps {
+  config: NotificationConfig;
+  onChange: (config: NotificationConfig) => void;
+  lockupAlert: LockupAlertConfig;
+  onLockupAlertChange: (config: LockupAlertConfig) => void;
+}
+
+// 可单独配置提示音的分类，涨跌停按方向区分
+const SOUND_ITEMS: { key: string; label: string }[] = [
+  { key: 'price', label: '价格提醒' },
+  { key: 'news', label: '快讯提醒' },
+  { key: 'briefing', label: 'AI 简报' },
+  { key: 'limit_up', label: '自选股涨停' },
+  { key: 'limit_down', label: '自选股跌停' },
+  { key: 'lockup', label: '限售解禁' },
+  { key: 'ipo', label: '新股申购' },
+];
+
+const NotificationSettings: React.FC<NotificationSettingsProps> = ({ config, onChange, lockupAlert, onLockupAlertChange }) => {
+  const { colors } = useTheme();
+  const labelClass = `text-sm ${colors.isDark ? 'text-slate-300' : 'text-slate-600'}`;
+  const descClass = `text-xs mt-0.5 ${colors.isDark ? 'text-slate-500' : 'text-slate-400'}`;
+  const titleClass = `font-medium ${colors.isDark ? 'text-white' : 'text-slate-800'}`;
+
+  const toggleCategory = (category: string, enabled: boolean) => {
+    const disabled = config.disabledCategories.filter(c => c !== category);
+    onChange({ ...config, disabledCategories: enabled ? disabled : [...disabled, category] });
+  };
+
+  const setSound = (key: string, value: string) => {
+    onChange({ ...config, sound: { ...config.sound, sounds: { ...config.sound.sounds, [key]: value } } });
+  };
+
+  const chooseFile = async (key: string) => {
+    const path = await selectSoundFile();
+    if (path) setSound(key, path);
+  };
+
+  return (
+    <div className="space-y-6">
+      <div>
+        <h3 className={titleClass}>通知分类</h3>
+        <p className={`text-sm mt-1 ${colors.isDark ? 'text-slate-400' : 'text-slate-500'}`}>
+          关闭的分类不记录到通知中心，也不弹出提醒
+        </p>
+        <div className="mt-3 space-y-3">
+          {Object.entries(NOTIFICATION_CATEGORIES).map(([key, label]) => (
+            <div key={key} className="flex items-center justify-between">
+              <span className={labelClass}>{label}</span>
+              <ToggleSwitch checked={!config.disabledCategories.includes(key)} onChange={(v) => toggleCategory(key, v)} />
+            </div>
+          ))}
+        </div>
+      </div>
+
+      <div>
+        <div className="flex items-center justify-between">
+          <div>
+            <h3 className={titleClass}>限售解禁提醒</h3>
+            <div className={descClass}>自选股在提前天数内有大额解禁时提醒一次</div>
+          </div>
+          <ToggleSwitch
+            checked={lockupAlert.enabled}
+            onChange={(v) => onLockupAlertChange({ ...lockupAlert, enabled: v })}
+          />
+        </div>
+        {lockupAlert.enabled && (
+          <div className="flex items-center gap-2 mt-3">
+            <span className={labelClass}>提前</span>
+            <input
+              type="number"
+              min={1}
+              max={90}
+              value={lockupAlert.daysBefore}
+              onChange={(e) => onLockupAlertChange({ ...lockupAlert, daysBefore: Number(e.target.value) || 7 })}
+              className="fin-input rounded-lg px-3 py-2 text-sm w-20"
+            />
+            <span className={labelClass}>天，解禁占流通股 ≥</span>
+            <input
+              type="number"
+              min={0}
+              step={0.5}
+              value={lockupAlert.minFloatRatio}
+              onChange={(e) => onLockupAlertChange({ ...lockupAlert, minFloatRatio: Number(e.target.value) || 5 })}
+              className="fin-input rounded-lg px-3 py-2 text-sm w-20"
+            />
+            <span className={labelClass}>%</span>
+          </div>
+        )}
+      </div>
+
+      <div>
+        <div className="flex items-center justify-between">
+          <div>
+            <h3 className={titleClass}>免打扰时段</h3>
+            <div className={descClass}>时段内的通知仅记录，不弹出也不响铃（北京时间，可跨午夜）</div>
+          </div>
+          <ToggleSwitch
+            checked={config.quietHours.enabled}
+            onChange={(v) => onChange({ ...config, quietHours: { ...config.quietHours, enabled: v } })}
+          />
+        </div>
+        {config.quietHours.enabled && (
+          <div className="flex items-center gap-2 mt-3">
+            <input
+              type="time"
+              value={config.quietHours.start}
+              onChange={(e) => onChange({ ...config, quietHours: { ...config.quietHours, start: e.target.value } })}
+              className="fin-input rounded-lg px-3 py-2 text-sm"
+            />
+            <span className={labelClass}>至</span>
+            <input
+              type="time"
+              value={config.quietHours.end}
+              onChange={(e) => onChange({ ...config, quietHours: { ...config.quietHours, end: e.target.value } })}
+              className="fin-input rounded-lg px-3 py-2 text-sm"
+            />
+          </div>
+        )}
+      </div>
+
+      <div>
+        <div className="flex items-center justify-between">
+          <div>
+            <h3 className={titleClass}>提示音</h3>
+            <div className={descClass}>支持 wav / mp3 自定义音频，未设置时使用内置提示音</div>
+          </div>
+          <ToggleSwitch
+            checked={config.sound.enabled}
+            onChange={(v) => onChange({ ...config, sound: { ...config.sound, enabled: v } })}
+          />
+        </div>
+        {config.sound.enabled && (
+          <div className="mt-3 space-y-3">
+            <div className="flex items-center gap-3">
+              <span className={`${labelClass} w-24`}>音量</span>
+              <input
+                type="range"
+                min={1}
+                max={100}
+                value={config.sound.volume || 80}
+                onChange={(e) => onChange({ ...config, sound: { ...config.sound, volume: parseInt(e.target.value) } })}
+                className="flex-1"
+              />
+              <span className={`${labelClass} w-10 text-right`}>{config.sound.volume || 80}</span>
+            </div>
+            {SOUND_ITEMS.map(({ key, label }) => {
+              const value = config.sound.sounds[key] || '';
+              const mode = value === '' ? 'builtin' : value === 'none' ? 'none' : 'custom';
+              return (
+                <div key={key} className="flex items-center gap-2">
+                  <span className={`${labelClass} w-24 shrink-0`}>{label}</span>
+                  <select
+                    value={mode}
+                    onChange={(e) => {
+                      if (e.target.value === 'custom') chooseFile(key);
+                      else setSound(key, e.target.value === 'none' ? 'none' : '');
+                    }}
+                    className="fin-input rounded-lg px-2 py-1.5 text-sm"
+                  >
+                    <option value="builtin">内置</option>
+                    <option value="custom">自定义</option>
+                    <option value="none">静音</option>
+                  </select>
+                  {mode === 'custom' && (
+                    <button
+                      onClick={() => chooseFile(key)}
+                      className={`flex-1 min-w-0 flex items-center gap-1 text-xs ${colors.isDark ? 'text-slate-400' : 'text-slate-500'}`}
+                      title={value}
+                    >
+                      <FolderOpen className="h-3.5 w-3.5 shrink-0" />
+                      <span className="truncate">{value}</span>
+                    </button>
+                  )}
+                  <button
+                    onClick={() => previewNotificationSound(key)}
+                    disabled={mode === 'none'}
+                    className={`ml-auto p-1 rounded transition-colors disabled:opacity-40 ${colors.isDark ? 'text-slate-400 hover:text-white' : 'text-slate-500 hover:text-slate-800'}`}
+                    title="试听"
+                  >
+                    <Play className="h-4 w-4" />
+                  </button>
+                </div>
+              );
+            })}
+          </div>
+        )}
+      </div>
+    </div>
+  );
+};
+
 // ========== 更新设置选项卡 ==========
-const UpdateSettings: React.FC = () => {
+interface UpdateSettingsProps {
+  config: UpdateConfig;
+  onChange: (config: UpdateConfig) => void;
+}
+
+const UpdateSettings: React.FC<UpdateSettingsProps> = ({ config, onChange }) => {
   const { colors } = useTheme();
   const [currentVersion, setCurrentVersion] = useState<string>('');
   const [updateInfo, setUpdateInfo] = useState<UpdateInfo | null>(null);
@@ -1977,7 +2890,28 @@ const UpdateSettings: React.FC = () => {
     <div className="space-y-6">
       <div>
         <h3 className={`font-medium ${colors.isDark ? 'text-white' : 'text-slate-800'}`}>软件更新</h3>
-        <p className={`text-sm mt-1 ${colors.isDark ? 'text-slate-400' : 'text-slate-500'}`}>检查并安装最新版本</p>
+        <p className={`text-sm mt-1 ${colors.isDark ? 'text-slate-400' : 'text-slate-500'}`}>检查并安装最新版本，安装包经 SHA256 校验后在重启时替换</p>
+      </div>
+
+      <div className={`flex items-center justify-between p-3 rounded-lg border ${
+        colors.isDark ? 'border-slate-700' : 'border-slate-300'
+      }`}>
+        <div>
+          <div className={`text-sm font-medium ${colors.isDark ? 'text-white' : 'text-slate-800'}`}>启动时自动检查更新</div>
+          <div className={`text-xs mt-0.5 ${colors.isDark ? 'text-slate-400' : 'text-slate-500'}`}>
+            {config.skipVersion ? `已跳过 v${config.skipVersion}` : '发现新版本时在左下角提示'}
+          </div>
+        </div>
+        <button
+          onClick={() => onChange({ ...config, disableAutoCheck: !config.disableAutoCheck })}
+          className={`relative w-11 h-6 rounded-full transition-colors cursor-pointer ${
+            !config.disableAutoCheck ? 'bg-[var(--accent)]' : (colors.isDark ? 'bg-slate-600' : 'bg-slate-300')
+          }`}
+        >
+          <div className={`absolute top-1 w-4 h-4 rounded-full bg-white shadow transition-transform ${
+            !config.disableAutoCheck ? 'translate-x-6' : 'translate-x-1'
+          }`} />
+        </button>
       </div>
 
       <div className="fin-panel rounded-lg p-4 border fin-divider">
@@ -2051,6 +2985,118 @@ const UpdateSettings: React.FC = () => {
   );
 };
 
+// ========== 备份恢复选项卡 ==========
+interface BackupSettingsProps {
+  showToast: (type: 'success' | 'error' | 'loading', message: string) => void;
+  onRestored: () => void;
+}
+
+const BackupSettings: React.FC<BackupSettingsProps> = ({ showToast, onRestored }) => {
+  const { colors } = useTheme();
+  const [busy, setBusy] = useState(false);
+  const [confirmReset, setConfirmReset] = useState(false);
+  const [backupPath, setBackupPath] = useState('');
+
+  const handleExport = async () => {
+    setBusy(true);
+    try {
+      const res = await exportSettings();
+      if (res === 'success') showToast('success', '设置已导出');
+      else if (res) showToast('error', res);
+    } finally {
+      setBusy(false);
+    }
+  };
+
+  const handleImport = async () => {
+    setBusy(true);
+    try {
+      const res = await importSettings();
+      if (res === 'success') {
+        showToast('success', '设置已导入');
+        onRestored();
+      } else if (res) {
+        showToast('error', res);
+      }
+    } finally {
+      setBusy(false);
+    }
+  };
+
+  const handleReset = async () => {
+    setBusy(true);
+    try {
+      const res = await resetToDefaults();
+      if (res.error) {
+        showToast('error', res.error);
+        return;
+      }
+      setBackupPath(res.path);
+      setConfirmReset(false);
+      showToast('success', '已恢复默认设置');
+      onRestored();
+    } finally {
+      setBusy(false);
+    }
+  };
+
+  const buttonClass = `flex items-center gap-2 px-4 py-2 rounded-lg text-sm disabled:opacity-50 transition-colors ${colors.isDark ? 'bg-slate-700 hover:bg-slate-600 text-white' : 'bg-slate-200 hover:bg-slate-300 text-slate-700'}`;
+  const hintClass = `text-xs mt-1 ${colors.isDark ? 'text-slate-400' : 'text-slate-500'}`;
+
+  return (
+    <div className="space-y-6">
+      <div>
+        <h3 className={`font-medium ${colors.isDark ? 'text-white' : 'text-slate-800'}`}>备份恢复</h3>
+        <p className={`text-sm mt-1 ${colors.isDark ? 'text-slate-400' : 'text-slate-500'}`}>迁移到其他电脑或排查问题时使用</p>
+      </div>
+
+      <div className="fin-panel rounded-lg p-4 border fin-divider space-y-4">
+        <div className="flex items-center justify-between">
+          <div>
+            <span className={`text-sm ${colors.isDark ? 'text-white' : 'text-slate-800'}`}>导出设置</span>
+            <p className={hintClass}>配置、自选股、分组、目标价与自定义专家，不含 API Key、令牌与密码</p>
+          </div>
+          <button onClick={handleExport} disabled={busy} className={buttonClass}>
+            <Download className="h-4 w-4" />导出
+          </button>
+        </div>
+        <div className="flex items-center justify-between pt-4 border-t fin-divider">
+          <div>
+            <span className={`text-sm ${colors.isDark ? 'text-white' : 'text-slate-800'}`}>导入设置</span>
+            <p className={hintClass}>覆盖本机设置与自选股，备份中没有的密钥保留本机的值</p>
+          </div>
+          <button onClick={handleImport} disabled={busy} className={buttonClass}>
+            <Upload className="h-4 w-4" />导入
+          </button>
+        </div>
+      </div>
+
+      <div className="fin-panel rounded-lg p-4 border border-red-500/30 space-y-3">
+        <div className="flex items-center justify-between">
+          <div>
+            <span className="text-sm text-red-400">恢复默认设置</span>
+            <p className={hintClass}>清空模型、MCP、代理等全部配置，自选股与目标价保留；原设置自动备份，可通过导入恢复</p>
+          </div>
+          {!confirmReset ? (
+            <button onClick={() => setConfirmReset(true)} disabled={busy} className={buttonClass}>
+              <RotateCcw className="h-4 w-4" />重置
+            </button>
+          ) : (
+            <div className="flex items-center gap-2">
+              <button onClick={() => setConfirmReset(false)} disabled={busy} className={buttonClass}>取消</button>
+              <button onClick={handleReset} disabled={busy}
+                className="flex items-center gap-2 px-4 py-2 rounded-lg text-sm bg-red-500 hover:bg-red-600 text-white disabled:opacity-50">
+                {busy ? <Loader2 className="h-4 w-4 animate-spin" /> : <RotateCcw className="h-4 w-4" />}确认重置
+              </button>
+            </div>
+          )}
+        </div>
+        {backupPath && <p className={`${hintClass} break-all`}>原设置已备份到 {backupPath}</p>}
+      </div>
+    </div>
+  );
+};
+
 // ========== 策略配置选项卡 ==========
 interface StrategySettingsProps {
   strategies: Strategy[];
@@ -2844,3 +3890,190 @@ const AgentToolsConfig: React.FC<AgentToolsConfigProps> = ({
     </div>
   );
 };
+
+// ========== 自定义专家 ==========
+interface PersonaSettingsProps {
+  mcpServers: MCPServerConfig[];
+  aiConfigs: AIConfig[];
+  showToast: (type: 'success' | 'error' | 'loading', message: string) => void;
+}
+
+const PERSONA_COLORS = ['#3B82F6', '#10B981', '#F59E0B', '#EF4444', '#8B5CF6', '#EC4899'];
+
+const newPersona = (): AgentPersona => ({
+  id: '',
+  name: '',
+  role: '',
+  avatar: '',
+  color: PERSONA_COLORS[Math.floor(Math.random() * PERSONA_COLORS.length)],
+  instruction: '',
+  tools: [],
+  mcpServers: [],
+  enabled: true,
+  aiConfigId: '',
+  createdAt: 0,
+  updatedAt: 0,
+});
+
+const PersonaSettings: React.FC<PersonaSettingsProps> = ({ mcpServers, aiConfigs, showToast }) => {
+  const { colors } = useTheme();
+  const [personas, setPersonas] = useState<AgentPersona[]>([]);
+  const [editing, setEditing] = useState<AgentPersona | null>(null);
+  const [activeTab, setActiveTab] = useState<AgentEditTab>('basic');
+  const [availableTools, setAvailableTools] = useState<ToolInfo[]>([]);
+  const [saving, setSaving] = useState(false);
+
+  const reload = useCallback(async () => {
+    setPersonas(await getPersonas() || []);
+  }, []);
+
+  useEffect(() => {
+    reload();
+    getAvailableTools().then(setAvailableTools);
+  }, [reload]);
+
+  const handleSave = async () => {
+    if (!editing) return;
+    setSaving(true);
+    const result = editing.id ? await updatePersona(editing) : await createPersona(editing);
+    setSaving(false);
+    if (result !== 'success') {
+      showToast('error', result);
+      return;
+    }
+    showToast('success', '已保存');
+    setEditing(null);
+    await reload();
+  };
+
+  const handleToggle = async (persona: AgentPersona) => {
+    const result = await updatePersona({ ...persona, enabled: !persona.enabled });
+    if (result !== 'success') {
+      showToast('error', result);
+    }
+    await reload();
+  };
+
+  const handleDelete = async () => {
+    if (!editing?.id) return;
+    const result = await deletePersona(editing.id);
+    if (result !== 'success') {
+      showToast('error', result);
+      return;
+    }
+    setEditing(null);
+    await reload();
+  };
+
+  const handleChange = <K extends keyof StrategyAgent>(field: K, value: StrategyAgent[K]) => {
+    setEditing(prev => prev ? { ...prev, [field]: value } : prev);
+  };
+
+  const toggleItem = (field: 'tools' | 'mcpServers', value: string) => {
+    const current = editing?.[field] || [];
+    handleChange(field, current.includes(value) ? current.filter(v => v !== value) : [...current, value]);
+  };
+
+  if (editing) {
+    return (
+      <div className="space-y-4">
+        <div className="flex items-center justify-between">
+          <div className="flex items-center gap-3">
+            <button
+              onClick={() => setEditing(null)}
+              className={`p-1.5 rounded-lg transition-colors ${colors.isDark ? 'hover:bg-slate-700/60 text-slate-400 hover:text-white' : 'hover:bg-slate-200/60 text-slate-500 hover:text-slate-700'}`}
+            >
+              <ChevronLeft className="h-5 w-5" />
+            </button>
+            <h3 className={`font-medium ${colors.isDark ? 'text-white' : 'text-slate-800'}`}>{editing.id ? '编辑专家' : '新建专家'}</h3>
+          </div>
+          <div className="flex items-center gap-2">
+            {editing.id && (
+              <button
+                onClick={handleDelete}
+                className="p-1.5 rounded-lg text-red-400 hover:bg-red-500/10 transition-colors"
+              >
+                <Trash2 className="h-4 w-4" />
+              </button>
+            )}
+            <button
+              onClick={handleSave}
+              disabled={saving}
+              className="flex items-center gap-1.5 px-3 py-1.5 text-sm bg-gradient-to-br from-[var(--accent)] to-[var(--accent-2)] text-white rounded-lg disabled:opacity-50"
+            >
+              {saving ? <Loader2 className="h-4 w-4 animate-spin" /> : <Check className="h-4 w-4" />}
+              保存
+            </button>
+          </div>
+        </div>
+
+        <div className="grid grid-cols-2 gap-3">
+          <div>
+            <label className={`block text-sm mb-1.5 ${colors.isDark ? 'text-slate-400' : 'text-slate-500'}`}>名称</label>
+            <input
+              value={editing.name}
+              onChange={e => handleChange('name', e.target.value)}
+              placeholder="如：宏观分析师"
+              className={`w-full fin-input rounded-lg px-3 py-2 text-sm ${colors.isDark ? 'text-white' : 'text-slate-800'}`}
+            />
+          </div>
+          <div>
+            <label className={`block text-sm mb-1.5 ${colors.isDark ? 'text-slate-400' : 'text-slate-500'}`}>角色</label>
+            <input
+              value={editing.role}
+              onChange={e => handleChange('role', e.target.value)}
+              placeholder="如：关注政策与流动性"
+              className={`w-full fin-input rounded-lg px-3 py-2 text-sm ${colors.isDark ? 'text-white' : 'text-slate-800'}`}
+            />
+          </div>
+        </div>
+
+        <AgentEditTabs
+          activeTab={activeTab}
+          selectedToolsCount={(editing.tools || []).length}
+          selectedMCPCount={(editing.mcpServers || []).length}
+          onTabChange={setActiveTab}
+        />
+        {activeTab === 'basic' && (
+          <AgentBasicConfig agent={editing} aiConfigs={aiConfigs} onChange={handleChange} />
+        )}
+        {activeTab === 'tools' && (
+          <AgentToolsConfig
+            agent={editing}
+            availableTools={availableTools}
+            mcpServers={mcpServers}
+            onToggleTool={name => toggleItem('tools', name)}
+            onToggleMCPServer={id => toggleItem('mcpServers', id)}
+          />
+        )}
+      </div>
+    );
+  }
+
+  return (
+    <div className="space-y-3">
+      <div className="flex items-center justify-between">
+        <p className={`text-xs ${colors.isDark ? 'text-slate-500' : 'text-slate-400'}`}>自定义专家独立于策略，启用后参与所有讨论</p>
+        <button
+          onClick={() => { setActiveTab('basic'); setEditing(newPersona()); }}
+          className="flex items-center gap-1.5 px-3 py-1.5 text-sm bg-gradient-to-br from-[var(--accent)] to-[var(--accent-2)] text-white rounded-lg"
+        >
+          <Plus className="h-4 w-4" />
+          新建专家
+        </button>
+      </div>
+      {personas.length === 0 ? (
+        <div className={`text-center py-8 text-sm ${colors.isDark ? 'text-slate-500' : 'text-slate-400'}`}>暂无自定义专家</div>
+      ) : (
+        personas.map(persona => (
+          <StrategyAgentListItem
+            key={persona.id}
+            agent={persona}
+            onSelect={() => { setActiveTab('basic'); setEditing(persona); }}
+            onToggle={() => handleToggle(persona)}
+          />
+        ))
+      )}
+    </div>
+  );
+};
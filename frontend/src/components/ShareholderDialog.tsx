@@ -0,0 +1,145 @@
+import React, { useState, useEffect } from 'react';
+import { X, Users, RefreshCw } from 'lucide-react';
+import { GetShareholders } from '../../wailsjs/go/main/App';
+import { models } from '../../wailsjs/go/models';
+import { useTheme } from '../contexts/ThemeContext';
+import { registerRetry } from '../services/errorService';
+
+interface ShareholderDialogProps {
+  isOpen: boolean;
+  symbol: string;
+  name: string;
+  onClose: () => void;
+}
+
+// 股数格式化为万股/亿股
+const formatShares = (v: number) => {
+  if (Math.abs(v) >= 1e8) return `${(v / 1e8).toFixed(2)}亿`;
+  if (Math.abs(v) >= 1e4) return `${(v / 1e4).toFixed(2)}万`;
+  return v.toFixed(0);
+};
+
+export const ShareholderDialog: React.FC<ShareholderDialogProps> = ({ isOpen, symbol, name, onClose }) => {
+  const { colors } = useTheme();
+  const [data, setData] = useState<models.ShareholderData | null>(null);
+  const [loading, setLoading] = useState(false);
+  const [reloadKey, setReloadKey] = useState(0);
+
+  useEffect(() => {
+    if (!isOpen) return;
+    return registerRetry('GetShareholders', () => setReloadKey(k => k + 1));
+  }, [isOpen]);
+
+  useEffect(() => {
+    if (!isOpen) return;
+    let cancelled = false;
+    setData(null);
+    setLoading(true);
+    GetShareholders(symbol)
+      .then((d) => { if (!cancelled && d) setData(d); })
+      .finally(() => { if (!cancelled) setLoading(false); });
+    return () => { cancelled = true; };
+  }, [isOpen, symbol, reloadKey]);
+
+  if (!isOpen) return null;
+
+  const mutedClass = colors.isDark ? 'text-slate-400' : 'text-slate-500';
+  const textClass = colors.isDark ? 'text-slate-200' : 'text-slate-700';
+  const counts = data?.holderCounts ?? [];
+  const maxCount = Math.max(1, ...counts.map((c) => c.count));
+
+  return (
+    <div className="fixed inset-0 z-50 flex items-center justify-center">
+      <div className="absolute inset-0 bg-black/60" onClick={onClose} />
+      <div className="relative w-[820px] max-h-[88vh] flex flex-col fin-panel border fin-divider rounded-xl shadow-2xl">
+        {/* Header */}
+        <div className="flex items-center justify-between p-4 border-b fin-divider">
+          <div className="flex items-center gap-3">
+            <Users className="h-5 w-5 text-accent-2" />
+            <span className={`font-bold ${colors.isDark ? 'text-slate-100' : 'text-slate-800'}`}>股东数据</span>
+            <span className={`text-xs ${mutedClass}`}>{name} {symbol}</span>
+          </div>
+          <div className="flex items-center gap-2">
+            <RefreshCw className={`h-4 w-4 ${mutedClass} ${loading ? 'animate-spin' : ''}`} />
+            <button
+              onClick={onClose}
+              className={`p-1 rounded transition-colors ${colors.isDark ? 'hover:bg-slate-700 text-slate-400 hover:text-white' : 'hover:bg-slate-200 text-slate-500 hover:text-slate-700'}`}
+            >
+              <X className="h-5 w-5" />
+            </button>
+          </div>
+        </div>
+
+        <div className="flex-1 overflow-y-auto p-4 space-y-5">
+          {!data ? (
+            <div className={`text-center text-xs py-10 ${mutedClass}`}>{loading ? '加载中...' : '暂无数据'}</div>
+          ) : (
+            <>
+              {/* 股东户数趋势：户数减少通常意味着筹码集中 */}
+              <section>
+                <div className={`text-xs mb-2 ${mutedClass}`}>股东户数</div>
+                {counts.length === 0 ? (
+                  <div className={`text-xs ${mutedClass}`}>暂无数据</div>
+                ) : (
+                  <div className="flex items-end gap-1 h-24">
+                    {counts.map((c) => (
+                      <div key={c.date} className="flex-1 flex flex-col items-center justify-end h-full" title={`${c.date} ${c.count}户 户均${formatShares(c.avgShares)}股`}>
+                        <span className={`text-[10px] font-mono ${c.changeRatio <= 0 ? 'text-red-400' : 'text-green-400'}`}>
+                          {c.changeRatio > 0 ? '+' : ''}{c.changeRatio.toFixed(1)}%
+                        </span>
+                        <div className="w-full bg-accent/40 rounded-t" style={{ height: `${(c.count / maxCount) * 70}%` }} />
+                        <span className={`text-[10px] ${mutedClass}`}>{c.date.slice(2, 7)}</span>
+                      </div>
+                    ))}
+                  </div>
+                )}
+              </section>
+
+              {/* 机构持仓 */}
+              <section>
+                <div className={`text-xs mb-2 ${mutedClass}`}>
+                  机构持仓 {data.institutionDate && `（${data.institutionDate}）`}
+                  <span className={`ml-2 font-mono ${textClass}`}>合计占流通股 {data.institutionRatio.toFixed(2)}%</span>
+                </div>
+                <div className="flex flex-wrap gap-2">
+                  {data.institutions.map((inst) => (
+                    <span key={inst.type} className={`fin-chip px-2 py-1 rounded text-xs ${textClass}`}>
+                      {inst.type} {inst.orgCount}家 · {inst.floatRatio.toFixed(2)}%
+                    </span>
+                  ))}
+                </div>
+              </section>
+
+              {/* 十大股东 */}
+              <section>
+                <div className={`text-xs mb-2 ${mutedClass}`}>十大股东 {data.reportDate && `（${data.reportDate}）`}</div>
+                <table className="w-full text-xs">
+                  <thead className={mutedClass}>
+                    <tr>
+                      <th className="py-1 font-normal text-left w-8">#</th>
+                      <th className="font-normal text-left">股东名称</th>
+                      <th className="font-normal text-right">持股数</th>
+                      <th className="font-normal text-right">占总股本</th>
+                      <th className="font-normal text-right">较上期</th>
+                    </tr>
+                  </thead>
+                  <tbody>
+                    {data.topHolders.map((h) => (
+                      <tr key={`${h.rank}-${h.name}`} className={`border-t fin-divider-soft ${textClass}`}>
+                        <td className="py-1 font-mono">{h.rank}</td>
+                        <td className="truncate max-w-[320px]" title={h.type}>{h.name}</td>
+                        <td className="text-right font-mono">{formatShares(h.shares)}</td>
+                        <td className="text-right font-mono">{h.ratio.toFixed(2)}%</td>
+                        <td className={`text-right ${mutedClass}`}>{h.change || '--'}</td>
+                      </tr>
+                    ))}
+                  </tbody>
+                </table>
+              </section>
+            </>
+          )}
+        </div>
+      </div>
+    </div>
+  );
+};
@@ -15,8 +15,13 @@ import {
   HistogramSeries,
   SeriesType,
   MouseEventParams,
+  IPriceLine,
+  LineWidth,
+  SeriesMarker,
+  createSeriesMarkers,
 } from 'lightweight-charts';
 import { KLineData, TimePeriod, Stock } from '../types';
+import { models } from '../../wailsjs/go/models';
 import { useTheme } from '../contexts/ThemeContext';
 import { useCandleColor } from '../contexts/CandleColorContext';
 import { ResizeHandle } from './ResizeHandle';
@@ -41,8 +46,14 @@ interface StockChartProps {
   period: TimePeriod;
   onPeriodChange: (p: TimePeriod) => void;
   stock?: Stock;
+  historyDates?: string[];        // 已归档分时的日期
+  historyDate?: string;           // 当前查看的历史日期，空为当日
+  onHistoryDateChange?: (date: string) => void;
+  drawings?: models.ChartDrawing[]; // 已保存的画线（水平线、趋势线、文字标注）
 }
 
+const DRAWING_DEFAULT_COLOR = '#f59e0b';
+
 // 副图类型
 type SubChartType = 'volume' | 'macd' | 'rsi' | 'kdj';
 
@@ -79,7 +90,7 @@ function formatTimeDisplay(timeStr: string): string {
   return timeStr.slice(0, 10) + ' 00:00:00';
 }
 
-export const StockChartLW: React.FC<StockChartProps> = ({ data, updateMode, period, onPeriodChange, stock }) => {
+export const StockChartLW: React.FC<StockChartProps> = ({ data, updateMode, period, onPeriodChange, stock, historyDates, historyDate, onHistoryDateChange, drawings }) => {
   const { colors } = useTheme();
   const cc = useCandleColor();
   const { config: indicatorConfig, updateIndicator } = useIndicator();
@@ -541,6 +552,50 @@ export const StockChartLW: React.FC<StockChartProps> = ({ data, updateMode, peri
     }
   }, [safeData, updateMode, preClose, isIntraday, chartColors, clearAllSeries, clearSubChart, renderSubChart, indicatorConfig]);
 
+  // ========== 已保存画线 ==========
+  // 水平线绘制为价格线，趋势线与射线按两锚点绘制线段，文字标注绘制为标记
+  const hasData = safeData.length > 0;
+  useEffect(() => {
+    const chart = chartRef.current;
+    const main = mainSeriesRef.current;
+    if (!chart || !main || !drawings || drawings.length === 0) return;
+
+    const priceLines: IPriceLine[] = [];
+    const lineSeries: ISeriesApi<SeriesType, Time>[] = [];
+    const markers: { t: string; marker: SeriesMarker<Time> }[] = [];
+    for (const d of drawings) {
+      const color = d.color || DRAWING_DEFAULT_COLOR;
+      const lineWidth = (d.width || 1) as LineWidth;
+      const points = [...(d.points || [])].sort((a, b) => a.t.localeCompare(b.t));
+      if (d.type === 'hline' && points.length > 0) {
+        priceLines.push(main.createPriceLine({
+          price: points[0].p, color, lineWidth, lineStyle: LineStyle.Solid, axisLabelVisible: true, title: d.text || '',
+        }));
+      } else if ((d.type === 'trendline' || d.type === 'ray') && points.length >= 2 && points[0].t !== points[1].t) {
+        const series = chart.addSeries(LineSeries, {
+          color, lineWidth, priceLineVisible: false, lastValueVisible: false, crosshairMarkerVisible: false,
+        });
+        series.setData(points.slice(0, 2).map(p => ({ time: parseTime(p.t), value: p.p })));
+        lineSeries.push(series);
+      } else if (d.type === 'text' && points.length > 0 && d.text) {
+        markers.push({ t: points[0].t, marker: { time: parseTime(points[0].t), position: 'aboveBar', color, shape: 'arrowDown', text: d.text } });
+      }
+    }
+    // 标记需按时间升序
+    markers.sort((a, b) => a.t.localeCompare(b.t));
+    const markerApi = markers.length > 0 ? createSeriesMarkers(main, markers.map(m => m.marker)) : null;
+
+    return () => {
+      for (const line of priceLines) {
+        try { main.removePriceLine(line); } catch { /* series removed */ }
+      }
+      for (const series of lineSeries) {
+        try { chart.removeSeries(series); } catch { /* already removed */ }
+      }
+      markerApi?.detach();
+    };
+  }, [drawings, hasData, isIntraday]);
+
   // ========== 副图指标禁用时自动回退到成交量 ==========
   useEffect(() => {
     const cur = subChartTypeRef.current;
@@ -672,6 +727,21 @@ export const StockChartLW: React.FC<StockChartProps> = ({ data, updateMode, peri
               {p.label}
             </button>
           ))}
+          {isIntraday && onHistoryDateChange && historyDates && historyDates.length > 0 && (
+            <select
+              value={historyDate || ''}
+              onChange={(e) => onHistoryDateChange(e.target.value)}
+              className={`ml-2 text-xs px-1 py-0.5 rounded border focus:outline-none ${
+                colors.isDark ? 'bg-slate-800 border-slate-700 text-slate-300' : 'bg-white border-slate-300 text-slate-600'
+              }`}
+              title="查看历史分时"
+            >
+              <option value="">今日</option>
+              {historyDates.map((d) => (
+                <option key={d} value={d}>{d}</option>
+              ))}
+            </select>
+          )}
           {!isIntraday && (
             <div className={`flex items-center gap-2 ml-3 pl-3 border-l ${colors.isDark ? 'border-slate-700' : 'border-slate-300'}`}>
               <div className={`flex items-center gap-1 text-xs ${colors.isDark ? 'text-slate-500' : 'text-slate-400'}`}>
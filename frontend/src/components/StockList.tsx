@@ -1,8 +1,9 @@
 import React, { useState, useEffect, useRef } from 'react';
-import { Stock, MarketIndex } from '../types';
+import { Stock, MarketIndex, InterestRates, GlobalIndex } from '../types';
 import { searchStocks, StockSearchResult } from '../services/stockService';
-import { TrendingUp, TrendingDown, Search, X } from 'lucide-react';
-import { MarketIndices } from './MarketIndices';
+import { onQuickSearch } from '../services/configService';
+import { TrendingUp, TrendingDown, Search, X, Clock } from 'lucide-react';
+import { MarketIndices, InterestRateBar, GlobalIndexBar } from './MarketIndices';
 import { useTheme } from '../contexts/ThemeContext';
 import { useCandleColor } from '../contexts/CandleColorContext';
 
@@ -13,6 +14,8 @@ interface StockListProps {
   onAddStock: (stock: Stock) => void;
   onRemoveStock?: (symbol: string) => void;
   marketIndices?: MarketIndex[];
+  interestRates?: InterestRates | null;
+  globalIndices?: GlobalIndex[];
 }
 
 export const StockList: React.FC<StockListProps> = ({
@@ -21,7 +24,9 @@ export const StockList: React.FC<StockListProps> = ({
   onSelect,
   onAddStock,
   onRemoveStock,
-  marketIndices
+  marketIndices,
+  interestRates,
+  globalIndices
 }) => {
   const { colors } = useTheme();
   const cc = useCandleColor();
@@ -30,8 +35,17 @@ export const StockList: React.FC<StockListProps> = ({
   const [showDropdown, setShowDropdown] = useState(false);
   const [isSearching, setIsSearching] = useState(false);
   const searchRef = useRef<HTMLDivElement>(null);
+  const inputRef = useRef<HTMLInputElement>(null);
   const debounceRef = useRef<ReturnType<typeof setTimeout>>();
 
+  // 快速搜索快捷键：聚焦并选中搜索框
+  useEffect(() => {
+    return onQuickSearch(() => {
+      inputRef.current?.focus();
+      inputRef.current?.select();
+    });
+  }, []);
+
   // 点击外部关闭下拉
   useEffect(() => {
     const handleClickOutside = (e: MouseEvent) => {
@@ -99,13 +113,16 @@ export const StockList: React.FC<StockListProps> = ({
     <div className="flex flex-col h-full relative">
       <div className="p-4 border-b fin-divider-soft">
         {/* 大盘指数 */}
-        <div className="mb-4 pb-3 border-b fin-divider-soft flex justify-center">
+        <div className="mb-4 pb-3 border-b fin-divider-soft flex flex-col items-center gap-1">
           <MarketIndices indices={marketIndices || []} />
+          {interestRates && <InterestRateBar rates={interestRates} />}
+          {globalIndices && globalIndices.length > 0 && <GlobalIndexBar indices={globalIndices} />}
         </div>
         <div ref={searchRef} className="relative z-50">
           <div className="relative">
             <Search className={`absolute left-3 top-2.5 h-4 w-4 ${colors.isDark ? 'text-slate-400' : 'text-slate-500'}`} />
             <input
+              ref={inputRef}
               type="text"
               value={searchTerm}
               onChange={(e) => setSearchTerm(e.target.value)}
@@ -159,6 +176,12 @@ export const StockList: React.FC<StockListProps> = ({
                 <div className="flex-1 min-w-0">
                   <div className="flex items-center gap-2">
                     <span className={`font-bold ${colors.isDark ? 'text-slate-100' : 'text-slate-800'}`}>{stock.name}</span>
+                    {stock.status === 'suspended' && (
+                      <span className="px-1 rounded text-[10px] bg-slate-500/20 text-slate-400">停牌</span>
+                    )}
+                    {stock.status === 'delisted' && (
+                      <span className="px-1 rounded text-[10px] bg-red-500/20 text-red-400">退市</span>
+                    )}
                     {onRemoveStock && (
                       <button
                         onClick={(e) => {
@@ -174,7 +197,15 @@ export const StockList: React.FC<StockListProps> = ({
                   <div className={`text-xs font-mono truncate text-left ${colors.isDark ? 'text-slate-400' : 'text-slate-500'}`}>{stock.symbol}</div>
                 </div>
                 <div className="text-right">
-                  <div className={`font-mono ${cc.getColorClass(isPositive)}`}>
+                  <div
+                    className={`font-mono flex items-center justify-end gap-1 ${cc.getColorClass(isPositive)} ${stock.stale ? 'opacity-50' : ''}`}
+                    title={stock.stale
+                      ? `行情已 ${stock.staleSeconds ?? 0} 秒未更新`
+                      : stock.limitUpPrice
+                        ? `涨停 ${stock.limitUpPrice.toFixed(2)} / 跌停 ${stock.limitDownPrice?.toFixed(2)}`
+                        : undefined}
+                  >
+                    {stock.stale && <Clock size={12} className="text-amber-400" />}
                     {stock.price.toFixed(2)}
                   </div>
                   <div className={`text-xs font-mono flex items-center justify-end ${cc.getColorClass(isPositive)}`}>
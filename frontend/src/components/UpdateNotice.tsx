@@ -0,0 +1,94 @@
+import React, { useEffect, useState } from 'react';
+import { X, Download, RotateCcw, Loader2, ChevronDown, ChevronUp } from 'lucide-react';
+import { useTheme } from '../contexts/ThemeContext';
+import { doUpdate, restartApp, skipUpdateVersion, onUpdateAvailable, onUpdateProgress, UpdateInfo, UpdateProgress } from '../services/updateService';
+
+// 启动后自动检查发现新版本时的更新提示
+export const UpdateNotice: React.FC = () => {
+  const { colors } = useTheme();
+  const [info, setInfo] = useState<UpdateInfo | null>(null);
+  const [progress, setProgress] = useState<UpdateProgress | null>(null);
+  const [showNotes, setShowNotes] = useState(false);
+
+  useEffect(() => {
+    const offAvailable = onUpdateAvailable(setInfo);
+    const offProgress = onUpdateProgress(setProgress);
+    return () => {
+      offAvailable();
+      offProgress();
+    };
+  }, []);
+
+  if (!info) return null;
+
+  const downloading = progress?.status === 'checking' || progress?.status === 'downloading' || progress?.status === 'installing';
+  const ready = info.downloaded || progress?.status === 'completed';
+
+  const handleUpdate = async () => {
+    const res = await doUpdate();
+    if (res !== 'success') {
+      setProgress({ status: 'error', message: res, percent: 0 });
+    }
+  };
+
+  const handleSkip = async () => {
+    await skipUpdateVersion(info.latestVersion);
+    setInfo(null);
+  };
+
+  const textMuted = colors.isDark ? 'text-slate-400' : 'text-slate-500';
+
+  return (
+    <div className="fixed bottom-4 left-4 z-[90] w-80 fin-panel border fin-divider rounded-xl shadow-2xl p-4 text-left">
+      <div className="flex items-start justify-between">
+        <div>
+          <span className="text-accent-2 text-sm font-medium">发现新版本 v{info.latestVersion}</span>
+          <p className={`text-xs mt-0.5 ${textMuted}`}>
+            当前 v{info.currentVersion}
+            {info.publishedAt ? ` · 发布于 ${new Date(info.publishedAt).toLocaleDateString()}` : ''}
+          </p>
+        </div>
+        <button onClick={() => setInfo(null)} className={`${textMuted} hover:text-white`} title="稍后提醒">
+          <X className="h-4 w-4" />
+        </button>
+      </div>
+
+      {info.releaseNotes && (
+        <div className="mt-2">
+          <button onClick={() => setShowNotes(!showNotes)} className={`flex items-center gap-1 text-xs ${textMuted}`}>
+            更新说明{showNotes ? <ChevronUp className="h-3 w-3" /> : <ChevronDown className="h-3 w-3" />}
+          </button>
+          {showNotes && (
+            <p className={`text-xs mt-1 max-h-40 overflow-y-auto fin-scrollbar whitespace-pre-wrap ${colors.isDark ? 'text-slate-300' : 'text-slate-600'}`}>
+              {info.releaseNotes}
+            </p>
+          )}
+        </div>
+      )}
+
+      {progress && !ready && (
+        <p className={`text-xs mt-2 ${progress.status === 'error' ? 'text-red-400' : textMuted}`}>{progress.message}</p>
+      )}
+
+      <div className="flex items-center justify-end gap-2 mt-3">
+        {ready ? (
+          <button onClick={() => restartApp()}
+            className="flex items-center gap-1.5 px-3 py-1.5 bg-accent text-white rounded-lg text-xs">
+            <RotateCcw className="h-3 w-3" />重启安装
+          </button>
+        ) : (
+          <>
+            <button onClick={handleSkip} disabled={downloading} className={`px-3 py-1.5 rounded-lg text-xs disabled:opacity-50 ${textMuted}`}>
+              跳过此版本
+            </button>
+            <button onClick={handleUpdate} disabled={downloading}
+              className="flex items-center gap-1.5 px-3 py-1.5 bg-gradient-to-br from-[var(--accent)] to-[var(--accent-2)] text-white rounded-lg text-xs disabled:opacity-50">
+              {downloading ? <Loader2 className="h-3 w-3 animate-spin" /> : <Download className="h-3 w-3" />}
+              {downloading ? `下载中 ${progress?.percent ?? 0}%` : '下载更新'}
+            </button>
+          </>
+        )}
+      </div>
+    </div>
+  );
+};
@@ -1,7 +1,9 @@
 import React, { useState, useEffect, useRef } from 'react';
 import { Stock } from '../types';
 import { searchStocks, StockSearchResult } from '../services/stockService';
-import { Search, TrendingUp, X } from 'lucide-react';
+import { runDiagnostics } from '../services/configService';
+import type { diagnostics } from '../../wailsjs/go/models';
+import { Search, TrendingUp, X, CheckCircle2, AlertTriangle, XCircle, RefreshCw } from 'lucide-react';
 import { WindowClose } from '../../wailsjs/go/main/App';
 import { useTheme } from '../contexts/ThemeContext';
 import logo from '../assets/images/logo.png';
@@ -18,6 +20,22 @@ export const WelcomePage: React.FC<WelcomePageProps> = ({ onAddStock }) => {
   const [isSearching, setIsSearching] = useState(false);
   const searchRef = useRef<HTMLDivElement>(null);
   const debounceRef = useRef<ReturnType<typeof setTimeout>>();
+  const [report, setReport] = useState<diagnostics.Report | null>(null);
+  const [checking, setChecking] = useState(false);
+
+  // 环境自检
+  const runCheck = async () => {
+    setChecking(true);
+    try {
+      setReport(await runDiagnostics());
+    } finally {
+      setChecking(false);
+    }
+  };
+
+  useEffect(() => {
+    runCheck();
+  }, []);
 
   // 点击外部关闭下拉
   useEffect(() => {
@@ -159,6 +177,44 @@ export const WelcomePage: React.FC<WelcomePageProps> = ({ onAddStock }) => {
         <TrendingUp className="h-4 w-4" />
         <span>搜索并添加您的第一只自选股开始使用</span>
       </div>
+
+      {/* 环境检查 */}
+      <div className={`mt-8 w-96 rounded-xl p-4 text-sm ${colors.isDark ? 'bg-slate-800/60 border border-slate-700' : 'bg-white/70 border border-slate-200'}`}>
+        <div className="flex items-center justify-between mb-2">
+          <span className={`font-medium ${colors.isDark ? 'text-slate-200' : 'text-slate-700'}`}>环境检查</span>
+          <button
+            onClick={runCheck}
+            disabled={checking}
+            className={`flex items-center gap-1 text-xs disabled:opacity-50 ${colors.isDark ? 'text-slate-400 hover:text-white' : 'text-slate-500 hover:text-slate-800'}`}
+          >
+            <RefreshCw className={`h-3.5 w-3.5 ${checking ? 'animate-spin' : ''}`} />
+            重新检查
+          </button>
+        </div>
+        {!report && checking && (
+          <div className={colors.isDark ? 'text-slate-500' : 'text-slate-400'}>正在检查...</div>
+        )}
+        {report && (
+          <div className="space-y-1 max-h-48 overflow-y-auto">
+            {report.checks.map((c) => (
+              <div key={`${c.category}-${c.name}`} className="flex items-center gap-2">
+                {c.status === 'ok' && <CheckCircle2 className="h-4 w-4 shrink-0 text-green-500" />}
+                {c.status === 'warn' && <AlertTriangle className="h-4 w-4 shrink-0 text-yellow-500" />}
+                {c.status === 'fail' && <XCircle className="h-4 w-4 shrink-0 text-red-500" />}
+                <span className={colors.isDark ? 'text-slate-300' : 'text-slate-700'}>{c.name}</span>
+                <span className={`ml-auto truncate text-xs ${colors.isDark ? 'text-slate-500' : 'text-slate-400'}`} title={c.message}>
+                  {c.message || (c.latencyMs ? `${c.latencyMs}ms` : '')}
+                </span>
+              </div>
+            ))}
+          </div>
+        )}
+        {report?.firstRun && (
+          <div className={`mt-3 text-xs ${colors.isDark ? 'text-slate-400' : 'text-slate-500'}`}>
+            提示：尚未配置 AI 模型，添加自选股后可在设置中配置，以启用专家讨论与策略生成
+          </div>
+        )}
+      </div>
     </div>
   );
 };
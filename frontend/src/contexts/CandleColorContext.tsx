@@ -1,5 +1,5 @@
 import React, { createContext, useContext, useState, useEffect, ReactNode, useCallback } from 'react';
-import { getConfig } from '../services/configService';
+import { getConfig, onConfigChanged } from '../services/configService';
 
 export type CandleColorMode = 'red-up' | 'green-up';
 
@@ -30,6 +30,13 @@ export const CandleColorProvider: React.FC<{ children: ReactNode }> = ({ childre
     }).catch(() => {});
   }, []);
 
+  useEffect(() => {
+    return onConfigChanged((config) => {
+      const changed = config.candleColorMode as CandleColorMode;
+      if (changed && COLOR_MAP[changed]) setModeState(changed);
+    });
+  }, []);
+
   const setMode = useCallback((newMode: CandleColorMode) => {
     setModeState(newMode);
   }, []);
@@ -1,5 +1,5 @@
 import React, { createContext, useContext, useState, useEffect, ReactNode } from 'react';
-import { getConfig, updateConfig } from '../services/configService';
+import { getConfig, updateConfig, onConfigChanged } from '../services/configService';
 
 // 主题类型定义
 export type ThemeType =
@@ -217,6 +217,14 @@ export const ThemeProvider: React.FC<{ children: ReactNode }> = ({ children }) =
     });
   }, []);
 
+  // 配置在其他地方被修改时同步主题
+  useEffect(() => {
+    return onConfigChanged((config) => {
+      const changed = config.theme as ThemeType;
+      if (changed && themes[changed]) setThemeState(changed);
+    });
+  }, []);
+
   const setTheme = async (newTheme: ThemeType) => {
     setThemeState(newTheme);
     try {
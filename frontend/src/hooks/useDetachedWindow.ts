@@ -0,0 +1,113 @@
+import { useEffect, useRef, useState } from 'react';
+import { EventsOn, EventsEmit } from '@wailsjs/runtime/runtime';
+import { OrderBook, KLineData, TimePeriod } from '../types';
+import { models } from '../../wailsjs/go/models';
+import { decodeRows, CompactTable } from '../utils/compactPayload';
+import { onDrawingsUpdate } from '../services/drawingService';
+
+// 事件名称常量，与后端保持一致；分离窗口的推送事件为 <事件>@<窗口ID>
+// 分离窗口运行在独立进程，订阅只发给本进程的窗口推送服务
+const EVENT_WINDOW_SUBSCRIBE = 'market:window:subscribe';
+const EVENT_WINDOW_UNSUBSCRIBE = 'market:window:unsubscribe';
+const EVENT_ORDERBOOK_UPDATE = 'market:orderbook:update';
+const EVENT_KLINE_UPDATE = 'market:kline:update';
+
+export type DetachedKind = 'kline' | 'orderbook';
+
+interface KLineUpdateData {
+  code: string;
+  period: string;
+  data: KLineData[];
+  incremental?: boolean;
+  seq?: number;
+  drawings?: models.ChartDrawing[];
+}
+
+// 窗口关闭时取消后端订阅
+function useWindowUnsubscribe(windowId: string) {
+  useEffect(() => {
+    return () => EventsEmit(EVENT_WINDOW_UNSUBSCRIBE, windowId);
+  }, [windowId]);
+}
+
+/**
+ * 分离窗口的 K 线订阅，与主界面的 K 线订阅互不影响
+ */
+export function useDetachedKLine(windowId: string, code: string, period: TimePeriod) {
+  const [data, setData] = useState<KLineData[]>([]);
+  const [drawings, setDrawings] = useState<models.ChartDrawing[]>([]);
+  const [updateMode, setUpdateMode] = useState<'full' | 'incremental' | 'refresh'>('full');
+  const loadedRef = useRef(false);
+  const seqRef = useRef(0);
+
+  useEffect(() => {
+    loadedRef.current = false;
+    seqRef.current = 0;
+    setData([]);
+    setDrawings([]);
+    const subscribe = () => EventsEmit(EVENT_WINDOW_SUBSCRIBE, { id: windowId, kind: 'kline', code, period });
+    const off = EventsOn(`${EVENT_KLINE_UPDATE}@${windowId}`, (raw: Omit<KLineUpdateData, 'data'> & { data: KLineData[] | CompactTable }) => {
+      if (!raw) return;
+      const msg: KLineUpdateData = { ...raw, data: decodeRows(raw.data) };
+      if (msg.code !== code || msg.period !== period || !Array.isArray(msg.data)) return;
+      // 增量推送断号时重新订阅获取全量
+      if (msg.incremental && msg.seq !== undefined && msg.seq !== seqRef.current + 1) {
+        subscribe();
+        return;
+      }
+      if (msg.seq !== undefined) seqRef.current = msg.seq;
+      if (msg.incremental && msg.data.length > 0) {
+        setUpdateMode('incremental');
+        setData(prev => {
+          if (prev.length === 0) return msg.data;
+          let merged = prev;
+          for (const newBar of msg.data) {
+            if (merged[merged.length - 1].time === newBar.time) {
+              merged = [...merged.slice(0, -1), newBar];
+            } else {
+              merged = [...merged.slice(-239), newBar];
+            }
+          }
+          return merged;
+        });
+        return;
+      }
+      // 首次推送适配视图，之后保留用户缩放状态
+      setUpdateMode(loadedRef.current ? 'refresh' : 'full');
+      loadedRef.current = true;
+      setData(msg.data);
+      if (msg.drawings) setDrawings(msg.drawings);
+    });
+    // 其他窗口修改画线后同步
+    const offDrawings = onDrawingsUpdate((update) => {
+      if (update.symbol !== code || (update.period !== '' && update.period !== period)) return;
+      setDrawings(update.drawings || []);
+    });
+    subscribe();
+    return () => {
+      off();
+      offDrawings();
+    };
+  }, [windowId, code, period]);
+
+  useWindowUnsubscribe(windowId);
+  return { data, drawings, updateMode };
+}
+
+/**
+ * 分离窗口的盘口订阅，与主界面的盘口订阅互不影响
+ */
+export function useDetachedOrderBook(windowId: string, code: string) {
+  const [orderBook, setOrderBook] = useState<OrderBook>({ bids: [], asks: [] });
+
+  useEffect(() => {
+    const off = EventsOn(`${EVENT_ORDERBOOK_UPDATE}@${windowId}`, (data: OrderBook) => {
+      if (data) setOrderBook(data);
+    });
+    EventsEmit(EVENT_WINDOW_SUBSCRIBE, { id: windowId, kind: 'orderbook', code });
+    return off;
+  }, [windowId, code]);
+
+  useWindowUnsubscribe(windowId);
+  return orderBook;
+}
@@ -1,7 +1,9 @@
 import { useEffect, useCallback, useRef } from 'react';
 import { EventsOn, EventsOff, EventsEmit } from '@wailsjs/runtime/runtime';
-import { NotifyFrontendReady } from '../../wailsjs/go/main/App';
-import { Stock, OrderBook, Telegraph, MarketIndex, KLineData } from '../types';
+import { NotifyFrontendReady, SetPushEncoding } from '../../wailsjs/go/main/App';
+import { Stock, OrderBook, Telegraph, MarketIndex, KLineData, InterestRates, GlobalIndex, StockStatusChange } from '../types';
+import { models } from '../../wailsjs/go/models';
+import { decodeRows, CompactTable } from '../utils/compactPayload';
 
 // K线推送数据结构
 interface KLineUpdateData {
@@ -9,23 +11,38 @@ interface KLineUpdateData {
   period: string;
   data: KLineData[];
   incremental?: boolean; // 是否增量推送
+  seq?: number; // 推送序号，日/周/月增量推送据此检测丢失
+  drawings?: models.ChartDrawing[]; // 图表画线，仅全量推送时附带
 }
 
 // 事件名称常量，与后端保持一致
 const EVENT_STOCK_UPDATE = 'market:stock:update';
+const EVENT_STOCK_STATUS = 'market:stock:status';
 const EVENT_ORDERBOOK_UPDATE = 'market:orderbook:update';
 const EVENT_TELEGRAPH_UPDATE = 'market:telegraph:update';
+const EVENT_TELEGRAPH_ALERT = 'market:telegraph:alert';
 const EVENT_MARKET_INDICES_UPDATE = 'market:indices:update';
+const EVENT_INTEREST_RATES_UPDATE = 'market:rates:update';
+const EVENT_GLOBAL_INDICES_UPDATE = 'market:global:update';
 const EVENT_MARKET_SUBSCRIBE = 'market:subscribe';
 const EVENT_ORDERBOOK_SUBSCRIBE = 'market:orderbook:subscribe';
 const EVENT_KLINE_UPDATE = 'market:kline:update';
 const EVENT_KLINE_SUBSCRIBE = 'market:kline:subscribe';
+const EVENT_SUBSCRIPTION_HEARTBEAT = 'market:subscription:heartbeat';
+const EVENT_SUBSCRIPTION_EXPIRED = 'market:subscription:expired';
+
+// 订阅心跳间隔，后端租约为 90 秒
+const HEARTBEAT_INTERVAL_MS = 20_000;
 
 interface UseMarketEventsOptions {
   onStockUpdate?: (stocks: Stock[]) => void;
+  onStockStatusChange?: (changes: StockStatusChange[]) => void;
   onOrderBookUpdate?: (orderBook: OrderBook) => void;
   onTelegraphUpdate?: (telegraph: Telegraph) => void;
+  onTelegraphAlert?: (telegraph: Telegraph) => void;
   onMarketIndicesUpdate?: (indices: MarketIndex[]) => void;
+  onInterestRatesUpdate?: (rates: InterestRates) => void;
+  onGlobalIndicesUpdate?: (indices: GlobalIndex[]) => void;
   onKLineUpdate?: (data: KLineUpdateData) => void;
 }
 
@@ -34,28 +51,41 @@ interface UseMarketEventsOptions {
  * 监听后端推送的实时市场数据
  */
 export function useMarketEvents(options: UseMarketEventsOptions) {
-  const { onStockUpdate, onOrderBookUpdate, onTelegraphUpdate, onMarketIndicesUpdate, onKLineUpdate } = options;
+  const { onStockUpdate, onStockStatusChange, onOrderBookUpdate, onTelegraphUpdate, onTelegraphAlert, onMarketIndicesUpdate, onInterestRatesUpdate, onGlobalIndicesUpdate, onKLineUpdate } = options;
 
   // 使用 ref 保存回调，避免重复注册
   const stockCallbackRef = useRef(onStockUpdate);
+  const stockStatusCallbackRef = useRef(onStockStatusChange);
   const orderBookCallbackRef = useRef(onOrderBookUpdate);
   const telegraphCallbackRef = useRef(onTelegraphUpdate);
+  const telegraphAlertCallbackRef = useRef(onTelegraphAlert);
   const marketIndicesCallbackRef = useRef(onMarketIndicesUpdate);
+  const interestRatesCallbackRef = useRef(onInterestRatesUpdate);
+  const globalIndicesCallbackRef = useRef(onGlobalIndicesUpdate);
   const klineCallbackRef = useRef(onKLineUpdate);
+  const klineSeqRef = useRef(0);
+  // 最近一次盘口/K线订阅参数，租约过期后据此重新订阅
+  const orderBookSubRef = useRef<string | null>(null);
+  const klineSubRef = useRef<[string, string] | null>(null);
 
   // 更新 ref
   useEffect(() => {
     stockCallbackRef.current = onStockUpdate;
+    stockStatusCallbackRef.current = onStockStatusChange;
     orderBookCallbackRef.current = onOrderBookUpdate;
     telegraphCallbackRef.current = onTelegraphUpdate;
+    telegraphAlertCallbackRef.current = onTelegraphAlert;
     marketIndicesCallbackRef.current = onMarketIndicesUpdate;
+    interestRatesCallbackRef.current = onInterestRatesUpdate;
+    globalIndicesCallbackRef.current = onGlobalIndicesUpdate;
     klineCallbackRef.current = onKLineUpdate;
-  }, [onStockUpdate, onOrderBookUpdate, onTelegraphUpdate, onMarketIndicesUpdate, onKLineUpdate]);
+  }, [onStockUpdate, onStockStatusChange, onOrderBookUpdate, onTelegraphUpdate, onTelegraphAlert, onMarketIndicesUpdate, onInterestRatesUpdate, onGlobalIndicesUpdate, onKLineUpdate]);
 
   // 注册事件监听
   useEffect(() => {
     // 监听股票数据更新
-    EventsOn(EVENT_STOCK_UPDATE, (stocks: Stock[]) => {
+    EventsOn(EVENT_STOCK_UPDATE, (payload: Stock[] | CompactTable) => {
+      const stocks = decodeRows(payload);
       stockCallbackRef.current?.(stocks);
     });
 
@@ -69,14 +99,41 @@ export function useMarketEvents(options: UseMarketEventsOptions) {
       telegraphCallbackRef.current?.(telegraph);
     });
 
+    // 监听停牌、复牌等交易状态变化
+    EventsOn(EVENT_STOCK_STATUS, (changes: StockStatusChange[]) => {
+      stockStatusCallbackRef.current?.(changes);
+    });
+
+    // 监听快讯关键词提醒
+    EventsOn(EVENT_TELEGRAPH_ALERT, (telegraph: Telegraph) => {
+      telegraphAlertCallbackRef.current?.(telegraph);
+    });
+
     // 监听大盘指数更新
     EventsOn(EVENT_MARKET_INDICES_UPDATE, (indices: MarketIndex[]) => {
       marketIndicesCallbackRef.current?.(indices);
     });
 
+    // 监听资金面利率更新
+    EventsOn(EVENT_INTEREST_RATES_UPDATE, (rates: InterestRates) => {
+      interestRatesCallbackRef.current?.(rates);
+    });
+
+    // 监听全球指数更新
+    EventsOn(EVENT_GLOBAL_INDICES_UPDATE, (indices: GlobalIndex[]) => {
+      globalIndicesCallbackRef.current?.(indices);
+    });
+
     // 监听K线数据更新
-    EventsOn(EVENT_KLINE_UPDATE, (data: KLineUpdateData) => {
-      klineCallbackRef.current?.(data);
+    EventsOn(EVENT_KLINE_UPDATE, (msg: Omit<KLineUpdateData, 'data'> & { data: KLineData[] | CompactTable }) => {
+      if (!msg) return;
+      // 增量推送断号时重新订阅，后端随即推送全量
+      if (msg.incremental && msg.seq !== undefined && msg.seq !== klineSeqRef.current + 1) {
+        EventsEmit(EVENT_KLINE_SUBSCRIBE, msg.code, msg.period);
+        return;
+      }
+      if (msg.seq !== undefined) klineSeqRef.current = msg.seq;
+      klineCallbackRef.current?.({ ...msg, data: decodeRows(msg.data) });
     });
 
     // 通知后端前端已准备好，循环调用直到成功
@@ -84,6 +141,8 @@ export function useMarketEvents(options: UseMarketEventsOptions) {
       let success = false;
       while (!success) {
         try {
+          // 大列表使用紧凑编码推送，减少桥接序列化开销
+          await SetPushEncoding('compact');
           await NotifyFrontendReady();
           success = true;
         } catch {
@@ -93,13 +152,39 @@ export function useMarketEvents(options: UseMarketEventsOptions) {
     };
     notifyReady();
 
+    // 订阅心跳：后端移除长时间无心跳的订阅
+    const heartbeat = setInterval(() => {
+      const keys = ['stocks'];
+      if (orderBookSubRef.current) keys.push('orderbook');
+      if (klineSubRef.current) keys.push('kline');
+      EventsEmit(EVENT_SUBSCRIPTION_HEARTBEAT, keys);
+    }, HEARTBEAT_INTERVAL_MS);
+
+    // 租约已过期（如页面被挂起）时重新订阅
+    // 分离窗口也监听该事件，清理时只移除本监听
+    const offExpired = EventsOn(EVENT_SUBSCRIPTION_EXPIRED, (keys: string[]) => {
+      if (!Array.isArray(keys)) return;
+      if (keys.includes('orderbook') && orderBookSubRef.current) {
+        EventsEmit(EVENT_ORDERBOOK_SUBSCRIBE, orderBookSubRef.current);
+      }
+      if (keys.includes('kline') && klineSubRef.current) {
+        EventsEmit(EVENT_KLINE_SUBSCRIBE, ...klineSubRef.current);
+      }
+    });
+
     // 清理函数
     return () => {
       EventsOff(EVENT_STOCK_UPDATE);
+      EventsOff(EVENT_STOCK_STATUS);
       EventsOff(EVENT_ORDERBOOK_UPDATE);
       EventsOff(EVENT_TELEGRAPH_UPDATE);
+      EventsOff(EVENT_TELEGRAPH_ALERT);
       EventsOff(EVENT_MARKET_INDICES_UPDATE);
+      EventsOff(EVENT_INTEREST_RATES_UPDATE);
+      EventsOff(EVENT_GLOBAL_INDICES_UPDATE);
       EventsOff(EVENT_KLINE_UPDATE);
+      offExpired();
+      clearInterval(heartbeat);
     };
   }, []);
 
@@ -110,11 +195,13 @@ export function useMarketEvents(options: UseMarketEventsOptions) {
 
   // 订阅盘口（指定当前选中的股票）
   const subscribeOrderBook = useCallback((code: string) => {
+    orderBookSubRef.current = code;
     EventsEmit(EVENT_ORDERBOOK_SUBSCRIBE, code);
   }, []);
 
   // 订阅K线（指定股票代码和周期）
   const subscribeKLine = useCallback((code: string, period: string) => {
+    klineSubRef.current = [code, period];
     EventsEmit(EVENT_KLINE_SUBSCRIBE, code, period);
   }, []);
 
@@ -1,5 +1,6 @@
 import { useState, useEffect, useCallback, useRef } from 'react';
 import { GetTradingSchedule } from '@wailsjs/go/main/App';
+import { onConfigChanged } from '../services/configService';
 
 // 交易时段
 interface TradingPeriod {
@@ -13,6 +14,7 @@ interface TradingPeriod {
 interface TradingSchedule {
   isTradeDay: boolean;
   holidayName: string;
+  closedText?: string; // 非交易日状态描述（按界面语言）
   periods: TradingPeriod[];
 }
 
@@ -33,8 +35,8 @@ function parseTimeToMinutes(time: string): number {
 // 根据当前时间和时间表计算市场状态
 function calculateStatus(schedule: TradingSchedule): MarketStatus {
   if (!schedule.isTradeDay) {
-    let statusText = '休市';
-    if (schedule.holidayName) {
+    let statusText = schedule.closedText || '休市';
+    if (!schedule.closedText && schedule.holidayName) {
       statusText = `${schedule.holidayName}休市`;
     }
     return {
@@ -64,7 +66,7 @@ function calculateStatus(schedule: TradingSchedule): MarketStatus {
 
   return {
     status: 'closed',
-    statusText: '已收盘',
+    statusText: schedule.periods[schedule.periods.length - 1]?.text || '已收盘',
     isTradeDay: true,
     holidayName: '',
   };
@@ -95,9 +97,10 @@ export function useMarketStatus() {
     }
   }, []);
 
-  // 启动时循环获取时间表
+  // 启动时循环获取时间表，切换语言等配置变更后重新获取
   useEffect(() => {
     fetchScheduleWithRetry();
+    return onConfigChanged(() => fetchScheduleWithRetry());
   }, [fetchScheduleWithRetry]);
 
   // 用 ref 记录上次状态，避免值不变时触发无效渲染
@@ -2,6 +2,8 @@ import React from 'react'
 import {createRoot} from 'react-dom/client'
 import './style.css'
 import App from './App'
+import { DetachedWindowView } from './components/DetachedWindowView'
+import { GetDetachedWindow } from '../wailsjs/go/main/App'
 import { ThemeProvider } from './contexts/ThemeContext'
 import { CandleColorProvider } from './contexts/CandleColorContext'
 import { IndicatorProvider } from './contexts/IndicatorContext'
@@ -10,14 +12,17 @@ const container = document.getElementById('root')
 
 const root = createRoot(container!)
 
-root.render(
-    <React.StrictMode>
-        <ThemeProvider>
-            <CandleColorProvider>
-                <IndicatorProvider>
-                    <App/>
-                </IndicatorProvider>
-            </CandleColorProvider>
-        </ThemeProvider>
-    </React.StrictMode>
-)
+// 分离窗口进程只显示一只股票的K线图或盘口，主进程显示完整界面
+GetDetachedWindow().then(detached => {
+    root.render(
+        <React.StrictMode>
+            <ThemeProvider>
+                <CandleColorProvider>
+                    <IndicatorProvider>
+                        {detached ? <DetachedWindowView window={detached}/> : <App/>}
+                    </IndicatorProvider>
+                </CandleColorProvider>
+            </ThemeProvider>
+        </React.StrictMode>
+    )
+})
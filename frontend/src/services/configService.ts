@@ -1,8 +1,12 @@
 // 配置服务 - 调用后端API
-import { GetConfig, UpdateConfig, GetAvailableTools, TestAIConnection } from '@wailsjs/go/main/App';
-import type { models } from '@wailsjs/go/models';
+import { GetConfig, UpdateConfig, GetAvailableTools, TestAIConnection, PullOllamaModel, GetToolAuditLog, DetectSystemProxy, ExportSettings, ImportSettings, ResetToDefaults, HotkeysSupported, RunDiagnostics } from '@wailsjs/go/main/App';
+import type { models, proxy, diagnostics } from '@wailsjs/go/models';
+import { EventsOn } from '../../wailsjs/runtime/runtime';
 
 export type AppConfig = models.AppConfig;
+export type ToolAuditEntry = models.ToolAuditEntry;
+export type SystemProxy = proxy.SystemProxy;
+export type HotkeyConfig = models.HotkeyConfig;
 
 // 内置工具信息
 export interface ToolInfo {
@@ -27,3 +31,58 @@ export const getAvailableTools = async (): Promise<ToolInfo[]> => {
 export const testAIConnection = async (config: models.AIConfig): Promise<string> => {
   return await TestAIConnection(config);
 };
+
+// 拉取 Ollama 模型（进度通过 ollama:pull 事件推送）
+export const pullOllamaModel = async (baseUrl: string, modelName: string): Promise<string> => {
+  return await PullOllamaModel(baseUrl, modelName);
+};
+
+// 查询工具调用审计日志（date 为空查询当天，toolName 为空不过滤）
+export const getToolAuditLog = async (date: string, toolName: string, limit: number): Promise<ToolAuditEntry[]> => {
+  return await GetToolAuditLog(date, toolName, limit);
+};
+
+// 重新检测操作系统代理设置
+export const detectSystemProxy = async (): Promise<SystemProxy> => {
+  return await DetectSystemProxy();
+};
+
+// 导出设置备份（不含密钥），返回 success，取消时返回空字符串
+export const exportSettings = async (): Promise<string> => {
+  return await ExportSettings();
+};
+
+// 从备份文件导入设置，返回 success，取消时返回空字符串
+export const importSettings = async (): Promise<string> => {
+  return await ImportSettings();
+};
+
+// 恢复默认配置，返回自动备份的原设置文件路径
+export const resetToDefaults = async (): Promise<models.ReportResult> => {
+  return await ResetToDefaults();
+};
+
+// 订阅配置变更（设置保存、云同步、外部编辑配置文件），返回取消订阅函数
+export const onConfigChanged = (callback: (config: AppConfig) => void): (() => void) => {
+  return EventsOn('config:changed', callback);
+};
+
+// 当前系统是否支持全局快捷键
+export const hotkeysSupported = async (): Promise<boolean> => {
+  return await HotkeysSupported();
+};
+
+// 订阅通知静音状态（老板键隐藏窗口时静音），返回取消订阅函数
+export const onNotifyMuted = (callback: (muted: boolean) => void): (() => void) => {
+  return EventsOn('notify:muted', callback);
+};
+
+// 订阅快速搜索快捷键，返回取消订阅函数
+export const onQuickSearch = (callback: () => void): (() => void) => {
+  return EventsOn('hotkey:quickSearch', callback);
+};
+
+// 环境自检：上游连通性、代理、数据目录与 AI 模型配置
+export const runDiagnostics = async (): Promise<diagnostics.Report> => {
+  return await RunDiagnostics();
+};
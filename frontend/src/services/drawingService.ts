@@ -0,0 +1,39 @@
+// 图表画线服务 - 调用后端API
+import { GetChartDrawings, SaveChartDrawing, DeleteChartDrawing, ClearChartDrawings } from '@wailsjs/go/main/App';
+import { models } from '@wailsjs/go/models';
+import { EventsOn } from '../../wailsjs/runtime/runtime';
+
+export type ChartDrawing = models.ChartDrawing;
+
+// 画线变更事件，与后端保持一致
+const EVENT_DRAWINGS_UPDATE = 'chart:drawings:update';
+
+// 画线变更通知，period 为空表示该股票所有周期已清空
+export interface DrawingsUpdate {
+  symbol: string;
+  period: string;
+  drawings: ChartDrawing[];
+}
+
+export const getChartDrawings = async (symbol: string, period: string): Promise<ChartDrawing[]> => {
+  return (await GetChartDrawings(symbol, period)) || [];
+};
+
+// 新增或更新画线（id 为空时新增），返回保存后的画线
+export const saveChartDrawing = async (drawing: ChartDrawing): Promise<ChartDrawing> => {
+  return await SaveChartDrawing(drawing);
+};
+
+export const deleteChartDrawing = async (symbol: string, period: string, id: string): Promise<string> => {
+  return await DeleteChartDrawing(symbol, period, id);
+};
+
+// 清空画线，period 为空时清空该股票全部周期
+export const clearChartDrawings = async (symbol: string, period = ''): Promise<string> => {
+  return await ClearChartDrawings(symbol, period);
+};
+
+// 订阅画线变更（任一窗口保存、删除后广播），返回取消订阅函数
+export const onDrawingsUpdate = (callback: (update: DrawingsUpdate) => void): (() => void) => {
+  return EventsOn(EVENT_DRAWINGS_UPDATE, callback);
+};
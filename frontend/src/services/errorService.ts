@@ -0,0 +1,33 @@
+import { EventsOn } from '../../wailsjs/runtime/runtime';
+import { models } from '../../wailsjs/go/models';
+
+export type AppError = models.ErrorInfo;
+
+// 接口出错后的重试回调，由正在展示该接口数据的组件注册
+const retryHandlers = new Map<string, () => void>();
+
+// 注册接口的重试回调，返回注销函数
+export function registerRetry(op: string, retry: () => void): () => void {
+  retryHandlers.set(op, retry);
+  return () => {
+    if (retryHandlers.get(op) === retry) retryHandlers.delete(op);
+  };
+}
+
+// 是否有组件可以重试该接口
+export function canRetry(op: string): boolean {
+  return retryHandlers.has(op);
+}
+
+// 重试出错的接口，没有注册回调时返回 false
+export function retryOp(op: string): boolean {
+  const retry = retryHandlers.get(op);
+  if (!retry) return false;
+  retry();
+  return true;
+}
+
+// 后端接口出错时触发（含错误码、本地化说明与是否可重试）
+export function onAppError(callback: (err: AppError) => void): () => void {
+  return EventsOn('app:error', callback);
+}
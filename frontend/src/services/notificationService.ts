@@ -0,0 +1,50 @@
+// 通知中心服务 - 调用后端API
+import { GetNotifications, GetUnreadNotificationCount, MarkNotificationsRead, ClearNotifications, PreviewNotificationSound, SelectSoundFile } from '@wailsjs/go/main/App';
+import { models } from '@wailsjs/go/models';
+import { EventsOn } from '../../wailsjs/runtime/runtime';
+
+export type AppNotification = models.Notification;
+
+// 通知分类
+export const NOTIFICATION_CATEGORIES: Record<string, string> = {
+  price: '价格提醒',
+  news: '快讯提醒',
+  briefing: 'AI 简报',
+  limit: '涨跌停',
+  lockup: '限售解禁',
+  ipo: '新股申购',
+};
+
+// 获取通知历史（按时间倒序），category 为空时不筛选
+export const getNotifications = async (category = '', unreadOnly = false, limit = 0): Promise<AppNotification[]> => {
+  const filter = models.NotificationFilter.createFrom({ category, unreadOnly, limit });
+  return (await GetNotifications(filter)) || [];
+};
+
+export const getUnreadNotificationCount = async (): Promise<number> => {
+  return await GetUnreadNotificationCount();
+};
+
+// 标记已读，ids 为空时全部标记
+export const markNotificationsRead = async (ids: string[] = []): Promise<string> => {
+  return await MarkNotificationsRead(ids);
+};
+
+export const clearNotifications = async (): Promise<string> => {
+  return await ClearNotifications();
+};
+
+// 订阅新通知（免打扰时段内不会推送），返回取消订阅函数
+export const onNotification = (callback: (n: AppNotification) => void): (() => void) => {
+  return EventsOn('notification:new', callback);
+};
+
+// 试听提示音，key 为分类或涨跌停子类型（limit_up / limit_down）
+export const previewNotificationSound = async (key: string): Promise<string> => {
+  return await PreviewNotificationSound(key);
+};
+
+// 选择自定义提示音文件，取消时返回空字符串
+export const selectSoundFile = async (): Promise<string> => {
+  return await SelectSoundFile();
+};
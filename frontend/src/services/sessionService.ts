@@ -1,4 +1,4 @@
-import { GetOrCreateSession, GetSessionMessages, ClearSessionMessages, SendMeetingMessage, UpdateStockPosition, RetryAgent, RetryAgentAndContinue, CancelInterruptedMeeting } from '../../wailsjs/go/main/App';
+import { GetOrCreateSession, GetSessionMessages, ClearSessionMessages, SendMeetingMessage, UpdateStockPosition, RetryAgent, RetryAgentAndContinue, CancelInterruptedMeeting, ListDiscussions, GetDiscussion, ResumeDiscussion } from '../../wailsjs/go/main/App';
 import type { StockPosition } from '../types';
 
 export interface StockSession {
@@ -24,6 +24,7 @@ export interface ChatMessage {
   msgType?: string;
   error?: string;  // 失败时的错误信息
   meetingMode?: string; // smart=串行, direct=独立
+  cancelled?: boolean;  // 讨论被停止，内容为已输出的部分
 }
 
 // 会议室消息请求
@@ -33,6 +34,17 @@ export interface MeetingMessageRequest {
   mentionIds: string[];
   replyToId: string;
   replyContent: string;
+  orchestration?: OrchestrationConfig; // 智能模式讨论编排，为空则单轮轮流发言
+}
+
+// 讨论编排模式：轮流发言 / 辩论 / 投票 / 并行综合
+export type OrchestrationMode = 'round_robin' | 'debate' | 'vote' | 'parallel';
+
+// 讨论编排配置
+export interface OrchestrationConfig {
+  mode: OrchestrationMode;
+  maxRounds: number;  // 最大轮数（轮流/辩论有效）
+  earlyStop: boolean; // 达成共识时提前结束
 }
 
 // 获取或创建Session
@@ -74,3 +86,65 @@ export const retryAgentAndContinue = async (stockCode: string): Promise<ChatMess
 export const cancelInterruptedMeeting = async (stockCode: string): Promise<boolean> => {
   return await CancelInterruptedMeeting(stockCode);
 };
+
+// 讨论状态
+export type DiscussionStatus = 'running' | 'completed' | 'interrupted' | 'cancelled';
+
+// 讨论列表项
+export interface DiscussionSummary {
+  id: string;
+  stockCode: string;
+  stockName: string;
+  query: string;
+  mode: OrchestrationMode;
+  status: DiscussionStatus;
+  rounds: number;
+  messageCount: number;
+  resumable: boolean; // 已中断或被停止且尚未总结
+  createdAt: number;
+  updatedAt: number;
+}
+
+// 讨论过程事件（思考、工具调用等）
+export interface DiscussionEvent {
+  type: string;
+  agentId: string;
+  agentName: string;
+  detail?: string;
+  content?: string;
+  timestamp: number;
+}
+
+// 讨论完整记录
+export interface Discussion {
+  id: string;
+  stockCode: string;
+  stockName: string;
+  query: string;
+  orchestration?: OrchestrationConfig;
+  topic?: string;
+  selectedAgents: string[];
+  tasks?: Record<string, string>;
+  status: DiscussionStatus;
+  error?: string;
+  messages: ChatMessage[];
+  events: DiscussionEvent[];
+  resumeCount: number;
+  createdAt: number;
+  updatedAt: number;
+}
+
+// 获取讨论记录列表
+export const listDiscussions = async (stockCode: string): Promise<DiscussionSummary[]> => {
+  return (await ListDiscussions(stockCode)) as DiscussionSummary[];
+};
+
+// 获取讨论完整记录
+export const getDiscussion = async (id: string): Promise<Discussion | null> => {
+  return (await GetDiscussion(id)) as Discussion | null;
+};
+
+// 从最后完成的轮次恢复讨论
+export const resumeDiscussion = async (id: string): Promise<ChatMessage[]> => {
+  return await ResumeDiscussion(id);
+};
@@ -1,5 +1,7 @@
 // 市场数据服务 - 调用后端API
-import { GetStockRealTimeData, GetKLineData, GetOrderBook, SearchStocks } from '@wailsjs/go/main/App';
+import { GetStockRealTimeData, GetKLineData, GetOrderBook, SearchStocks, ApplyRuntimeSettings, GetIntradayHistory, GetIntradayHistoryDates, GetKLineIntegrityStatus, RunKLineIntegrityCheck, GetComparisonSeries, GetStockStats, GetFuturesQuotes, GetMarketStatus } from '@wailsjs/go/main/App';
+import { models, services } from '@wailsjs/go/models';
+import { EventsOn } from '../../wailsjs/runtime/runtime';
 import type { Stock, KLineData, OrderBook } from '../types';
 
 // 股票搜索结果类型
@@ -18,13 +20,73 @@ export const getKLineData = async (code: string, period: string, days: number):
   return await GetKLineData(code, period, days);
 };
 
+// 获取某个交易日归档的分时数据（date: YYYY-MM-DD）
+export const getIntradayHistory = async (code: string, date: string): Promise<KLineData[]> => {
+  return await GetIntradayHistory(code, date);
+};
+
+// 获取已归档分时的日期列表（倒序）
+export const getIntradayHistoryDates = async (code: string): Promise<string[]> => {
+  return await GetIntradayHistoryDates(code);
+};
+
+// 获取历史分时完整性检查结果
+export const getKLineIntegrityStatus = async (): Promise<models.KLineIntegrityStatus> => {
+  return await GetKLineIntegrityStatus();
+};
+
+// 立即检查并补齐历史分时缺口
+export const runKLineIntegrityCheck = async (): Promise<models.KLineIntegrityStatus> => {
+  return await RunKLineIntegrityCheck();
+};
+
+// 获取多只股票相对走势对比数据（已对齐并归一化为涨跌幅%）
+// period: 1m/1d/1w/1mo；range: 1m/3m/6m/1y/3y/5y/ytd
+export const getComparisonSeries = async (codes: string[], period: string, range = '6m'): Promise<models.ComparisonSeries | null> => {
+  return await GetComparisonSeries(codes, period, range);
+};
+
+// 获取个股52周高低点、历史最高价与连涨连跌天数
+export const getStockStats = async (code: string): Promise<models.StockStats | null> => {
+  return await GetStockStats(code);
+};
+
+// 期货代码（新浪 nf_ 前缀，如 nf_IF0 主连）
+export const isFutures = (code: string): boolean => code.startsWith('nf_');
+
+// 获取期货行情，股指期货附带与现货指数的基差
+export const getFuturesQuotes = async (codes: string[]): Promise<models.FuturesQuote[]> => {
+  return (await GetFuturesQuotes(codes)) || [];
+};
+
+// 获取指定代码的交易状态（期货含夜盘）
+export const getMarketStatus = async (code: string): Promise<services.MarketStatus> => {
+  return await GetMarketStatus(code);
+};
+
 // 获取真实五档盘口数据
 export const getOrderBook = async (code: string): Promise<OrderBook> => {
   return await GetOrderBook(code);
 };
 
 // 搜索股票
-export const searchStocks = async (keyword: string): Promise<StockSearchResult[]> => {
+export const searchStocks = async (keyword: string, limit = 20): Promise<StockSearchResult[]> => {
   if (!keyword.trim()) return [];
-  return await SearchStocks(keyword) as StockSearchResult[];
+  return await SearchStocks(keyword, limit) as StockSearchResult[];
+};
+
+// 暂停/恢复行情推送，返回错误信息，成功时为空字符串
+export const setPushPaused = async (paused: boolean): Promise<string> => {
+  const res = await ApplyRuntimeSettings(models.RuntimeSettings.createFrom({ pushPaused: paused }));
+  return res.success ? '' : res.error || '设置失败';
+};
+
+// 订阅暂停状态变化（按钮、托盘菜单切换后广播），返回取消订阅函数
+export const onPushPausedChange = (callback: (paused: boolean) => void): (() => void) => {
+  return EventsOn('market:push:paused', callback);
+};
+
+// 订阅条件单触发事件，返回取消订阅函数
+export const onConditionTriggered = (callback: (order: models.ConditionOrder) => void): (() => void) => {
+  return EventsOn('condition:triggered', callback);
 };
@@ -1,4 +1,4 @@
-import { GetStrategies, GetActiveStrategyID, SetActiveStrategy, AddStrategy, UpdateStrategy, DeleteStrategy, GenerateStrategy, EnhancePrompt, GetAgentConfigs, AddAgentConfig, UpdateAgentConfig, DeleteAgentConfig } from '../../wailsjs/go/main/App';
+import { GetStrategies, GetActiveStrategyID, SetActiveStrategy, AddStrategy, UpdateStrategy, DeleteStrategy, GenerateStrategy, EnhancePrompt, GetAgentConfigs, AddAgentConfig, UpdateAgentConfig, DeleteAgentConfig, GetPersonas, CreatePersona, UpdatePersona, DeletePersona } from '../../wailsjs/go/main/App';
 
 // 策略专属专家配置
 export interface StrategyAgent {
@@ -125,3 +125,29 @@ export const updateAgentConfig = async (config: AgentConfig): Promise<string> =>
 export const deleteAgentConfig = async (id: string): Promise<string> => {
   return await DeleteAgentConfig(id);
 };
+
+// 自定义专家人设（独立于策略，启用后参与所有讨论）
+export interface AgentPersona extends StrategyAgent {
+  createdAt: number;
+  updatedAt: number;
+}
+
+// 获取自定义专家人设列表
+export const getPersonas = async (): Promise<AgentPersona[]> => {
+  return await GetPersonas();
+};
+
+// 新建自定义专家人设
+export const createPersona = async (persona: AgentPersona): Promise<string> => {
+  return await CreatePersona(persona as any);
+};
+
+// 更新自定义专家人设
+export const updatePersona = async (persona: AgentPersona): Promise<string> => {
+  return await UpdatePersona(persona as any);
+};
+
+// 删除自定义专家人设
+export const deletePersona = async (id: string): Promise<string> => {
+  return await DeletePersona(id);
+};
@@ -1,5 +1,5 @@
-import { CheckForUpdate, DoUpdate, RestartApp, GetCurrentVersion } from '../../wailsjs/go/main/App';
-import { EventsOn, EventsOff } from '../../wailsjs/runtime/runtime';
+import { CheckForUpdate, DoUpdate, RestartApp, GetCurrentVersion, SkipUpdateVersion } from '../../wailsjs/go/main/App';
+import { EventsOn } from '../../wailsjs/runtime/runtime';
 
 export interface UpdateInfo {
   hasUpdate: boolean;
@@ -7,6 +7,8 @@ export interface UpdateInfo {
   currentVersion: string;
   releaseUrl: string;
   releaseNotes: string;
+  publishedAt?: number;
+  downloaded?: boolean; // 已下载，重启后生效
   error?: string;
 }
 
@@ -33,6 +35,14 @@ export async function getCurrentVersion(): Promise<string> {
 }
 
 export function onUpdateProgress(callback: (progress: UpdateProgress) => void): () => void {
-  EventsOn('update:progress', callback);
-  return () => EventsOff('update:progress');
+  return EventsOn('update:progress', callback);
+}
+
+export async function skipUpdateVersion(version: string): Promise<string> {
+  return await SkipUpdateVersion(version);
+}
+
+// 启动后自动检查发现新版本时触发
+export function onUpdateAvailable(callback: (info: UpdateInfo) => void): () => void {
+  return EventsOn('update:available', callback);
 }
@@ -12,6 +12,32 @@ export interface Stock {
   high: number;
   low: number;
   preClose: number;
+  // 目标价/止损价跟踪线（仅推送行情时填充）
+  targetPrice?: number;
+  stopPrice?: number;
+  targetDistance?: number;
+  stopDistance?: number;
+  // 行情时间（毫秒）与延迟，交易时段行情冻结时 stale 为 true
+  quoteTime?: number;
+  staleSeconds?: number;
+  stale?: boolean;
+  // 交易状态：suspended 停牌、delisted 退市，正常交易为空；st 为名称带 ST/*ST
+  status?: 'suspended' | 'delisted';
+  st?: boolean;
+  // 涨停价/跌停价，新股上市初期等无涨跌幅限制时缺省
+  limitUpPrice?: number;
+  limitDownPrice?: number;
+}
+
+// 股票交易状态变化（停牌、复牌、退市、戴帽摘帽）
+export interface StockStatusChange {
+  symbol: string;
+  name: string;
+  status: string;
+  prevStatus: string;
+  st: boolean;
+  prevSt: boolean;
+  resumed: boolean;
 }
 
 // 股票持仓信息
@@ -85,6 +111,9 @@ export interface Telegraph {
   time: string;
   content: string;
   url: string;
+  source: string;
+  timestamp: number;
+  keywords?: string[];
 }
 
 // MCP 传输类型
@@ -113,6 +142,29 @@ export interface MarketIndex {
   amount: number;        // 成交额(万元)
 }
 
+// 全球主要指数（附当地交易状态）
+export interface GlobalIndex extends MarketIndex {
+  region: string;    // us/uk/de/hk/jp
+  market: string;    // 市场名称
+  status: 'trading' | 'lunch_break' | 'closed';
+  localTime: string; // 当地时间 HH:MM
+}
+
+// 资金面利率报价
+export interface InterestRate {
+  code: string;
+  name: string;
+  category: 'repo' | 'shibor' | 'treasury';
+  rate: number;     // 利率/收益率(%)
+  changeBp: number; // 较上一交易日变动(基点)
+  date: string;
+}
+
+export interface InterestRates {
+  items: InterestRate[];
+  updatedAt: number;
+}
+
 // 市场状态
 export interface MarketStatus {
   status: string;        // trading, closed, pre_market, lunch_break
@@ -0,0 +1,27 @@
+// 推送紧凑编码解码
+// 后端在协商 compact 编码后，大列表以 { $c, fields, rows } 形式推送：字段名只出现一次，
+// 每行按字段顺序给出值，null 表示该字段被省略
+
+export interface CompactTable {
+  $c: number;
+  fields: string[];
+  rows: unknown[][];
+}
+
+function isCompactTable(payload: unknown): payload is CompactTable {
+  return typeof payload === 'object' && payload !== null && '$c' in payload;
+}
+
+/** 还原对象数组，兼容未压缩的普通数组 */
+export function decodeRows<T>(payload: T[] | CompactTable | null | undefined): T[] {
+  if (!payload) return [];
+  if (!isCompactTable(payload)) return payload;
+  const { fields, rows } = payload;
+  return rows.map(row => {
+    const obj: Record<string, unknown> = {};
+    for (let i = 0; i < fields.length; i++) {
+      if (row[i] !== null && row[i] !== undefined) obj[fields[i]] = row[i];
+    }
+    return obj as T;
+  });
+}
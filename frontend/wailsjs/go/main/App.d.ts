@@ -6,6 +6,12 @@ import {main} from '../models';
 import {hottrend} from '../models';
 import {tools} from '../models';
 import {mcp} from '../models';
+import {adk} from '../models';
+import {proxy} from '../models';
+import {logger} from '../models';
+import {diagnostics} from '../models';
+import {metrics} from '../models';
+import {supervisor} from '../models';
 
 export function AddAgentConfig(arg1:models.AgentConfig):Promise<string>;
 
@@ -15,44 +21,156 @@ export function AddStrategy(arg1:models.Strategy):Promise<string>;
 
 export function AddToWatchlist(arg1:models.Stock):Promise<string>;
 
+export function AddToWatchlistGroup(arg1:string,arg2:string):Promise<string>;
+
+export function ApplyRuntimeSettings(arg1:models.RuntimeSettings):Promise<models.RuntimeSettingsResult>;
+
+export function CalculatePositionSize(arg1:models.PositionSizeRequest):Promise<models.PositionSizeResult>;
+
+export function CancelConditionOrder(arg1):string:Promise<string>;
+
+export function CancelDiscussion(arg1:string):Promise<boolean>;
+
 export function CancelInterruptedMeeting(arg1:string):Promise<boolean>;
 
 export function CancelMeeting(arg1:string):Promise<boolean>;
 
+export function CancelPaperOrder(arg1:string):Promise<string>;
+
 export function CheckForUpdate():Promise<services.UpdateInfo>;
 
+export function CheckPortfolioRisk(arg1:number,arg2:models.RiskLimits):Promise<models.PortfolioRisk>;
+
+export function ClearChartDrawings(arg1:string,arg2:string):Promise<string>;
+
+export function ClearNotifications():Promise<string>;
+
 export function ClearSessionMessages(arg1:string):Promise<string>;
 
+export function CreateConditionOrder(arg1):models.ConditionOrderRequest:Promise<models.ConditionOrder>;
+
+export function CreatePersona(arg1:models.AgentPersona):Promise<string>;
+
+export function CreateWatchlistGroup(arg1:string):Promise<models.WatchlistGroup>;
+
+export function DebugGoroutines():Promise<supervisor.Report>;
+
 export function DeleteAgentConfig(arg1:string):Promise<string>;
 
+export function DeleteChartDrawing(arg1:string,arg2:string,arg3:string):Promise<string>;
+
+export function DeleteConditionOrder(arg1):string:Promise<string>;
+
+export function DeleteJournalEntry(arg1:string):Promise<string>;
+
 export function DeleteMCPServer(arg1:string):Promise<string>;
 
+export function DeletePersona(arg1:string):Promise<string>;
+
 export function DeleteStrategy(arg1:string):Promise<string>;
 
+export function DeleteWatchlistGroup(arg1:string):Promise<string>;
+
+export function DetectSystemProxy():Promise<proxy.SystemProxy>;
+
 export function DoUpdate():Promise<string>;
 
 export function EnhancePrompt(arg1:main.EnhancePromptRequest):Promise<main.EnhancePromptResponse>;
 
+export function ExportJournal(arg1:models.JournalFilter,arg2:string):Promise<string>;
+
+export function ExportKLines(arg1:string,arg2:string,arg3:number,arg4:string):Promise<string>;
+
+export function ExportQuotes(arg1:string):Promise<string>;
+
+export function ExportSettings():Promise<string>;
+
+export function ExportTransactions(arg1:string):Promise<string>;
+
+export function ExportWatchlist(arg1:string,arg2:string):Promise<string>;
+
+export function GenerateBriefingNow():Promise<string>;
+
+export function GenerateDiagnosticsBundle():Promise<models.ReportResult>;
+
+export function GenerateStockReport(arg1:string,arg2:string):Promise<models.ReportResult>;
+
 export function GenerateStrategy(arg1:main.GenerateStrategyRequest):Promise<main.GenerateStrategyResponse>;
 
+export function GetAIUsage():Promise<adk.UsageSummary>;
+
 export function GetActiveStrategyID():Promise<string>;
 
 export function GetAgentConfigs():Promise<Array<models.AgentConfig>>;
 
 export function GetAllHotTrends():Promise<Array<hottrend.HotTrendResult>>;
 
+export function GetAvailableMarketIndices():Promise<Array<services.MarketIndexOption>>;
+
 export function GetAvailableTools():Promise<Array<tools.ToolInfo>>;
 
+export function GetChartDrawings(arg1:string,arg2:string):Promise<Array<models.ChartDrawing>>;
+
+export function GetComparisonSeries(arg1:Array<string>,arg2:string,arg3:string):Promise<models.ComparisonSeries>;
+
+export function GetConditionOrders():Promise<Array<models.ConditionOrder>>;
+
 export function GetConfig():Promise<models.AppConfig>;
 
+export function GetConnectionStats():Promise<Array<proxy.HostConnStats>>;
+
 export function GetCurrentVersion():Promise<string>;
 
+export function GetDataSourceHealth():Promise<Array<services.SourceHealth>>;
+
+export function GetDetachedWindow():Promise<services.WindowSubscription>;
+
+export function GetDiscussion(arg1:string):Promise<models.Discussion>;
+
+export function GetETFConstituents(arg1:string):Promise<Array<models.ETFConstituent>>;
+
+export function GetEngineStatus():Promise<Record<string, any>>;
+
+export function GetFuturesQuotes(arg1:Array<string>):Promise<Array<models.FuturesQuote>>;
+
+export function GetGlobalIndices():Promise<Array<models.GlobalIndex>>;
+
+export function GetGroupStocks(arg1:string):Promise<Array<models.Stock>>;
+
+export function GetGubaSentiment(arg1:string):Promise<models.GubaSentiment>;
+
 export function GetHotTrend(arg1:string):Promise<hottrend.HotTrendResult>;
 
 export function GetHotTrendPlatforms():Promise<Array<hottrend.PlatformInfo>>;
 
+export function GetIPOCalendar():Promise<Array<models.IPOItem>>;
+
+export function GetIndexConstituents(arg1:string):Promise<Array<models.IndexConstituent>>;
+
+export function GetInstanceRole():Promise<string>;
+
+export function GetInterestRates():Promise<models.InterestRates>;
+
+export function GetIntradayHistory(arg1:string,arg2:string):Promise<Array<models.KLineData>>;
+
+export function GetIntradayHistoryDates(arg1:string):Promise<Array<string>>;
+
+export function GetJournalEntries(arg1:models.JournalFilter):Promise<Array<models.JournalEntry>>;
+
+export function GetJournalStats(arg1:models.JournalFilter):Promise<models.JournalStats>;
+
+export function GetJournalTags():Promise<Array<string>>;
+
 export function GetKLineData(arg1:string,arg2:string,arg3:number):Promise<Array<models.KLineData>>;
 
+export function GetKLineIntegrityStatus():Promise<models.KLineIntegrityStatus>;
+
+export function GetLatestBriefing():Promise<models.DailyBriefing>;
+
+export function GetLocalAPIStatus():Promise<Record<string, any>>;
+
+export function GetLockupCalendar(arg1:number):Promise<Array<models.LockupExpiry>>;
+
 export function GetLongHuBangDetail(arg1:string,arg2:string):Promise<Array<models.LongHuBangDetail>>;
 
 export function GetLongHuBangList(arg1:number,arg2:number,arg3:string):Promise<services.LongHuBangListResult>;
@@ -63,46 +181,162 @@ export function GetMCPServers():Promise<Array<models.MCPServerConfig>>;
 
 export function GetMCPStatus():Promise<Array<mcp.ServerStatus>>;
 
+export function GetMarginData(arg1:string,arg2:number):Promise<Array<models.MarginRecord>>;
+
+export function GetMarketBreadth():Promise<models.MarketBreadth>;
+
+export function GetMarketHeatmap(arg1:string):Promise<models.MarketHeatmap>;
+
+export function GetMarketMarginTrend(arg1:number):Promise<Array<models.MarginRecord>>;
+
+export function GetMarketStatus(arg1:string):Promise<services.MarketStatus>;
+
+export function GetMetrics():Promise<Array<metrics.Family>>;
+
+export function GetNearTargets(arg1:number):Promise<Array<models.TargetProximity>>;
+
+export function GetNotifications(arg1:models.NotificationFilter):Promise<Array<models.Notification>>;
+
 export function GetOpenClawStatus():Promise<Record<string, any>>;
 
+export function GetOptionChain(arg1:string,arg2:string):Promise<models.OptionChain>;
+
 export function GetOrCreateSession(arg1:string,arg2:string):Promise<models.StockSession>;
 
 export function GetOrderBook(arg1:string):Promise<models.OrderBook>;
 
+export function GetPaperAccount():Promise<models.PaperAccount>;
+
+export function GetPaperOrders():Promise<Array<models.PaperOrder>>;
+
+export function GetPersonas():Promise<Array<models.AgentPersona>>;
+
+export function GetPortfolioAttribution():Promise<models.PortfolioAttribution>;
+
+export function GetPortfolioExposure():Promise<models.PortfolioExposure>;
+
+export function GetPriceTargets():Promise<Array<models.PriceTarget>>;
+
+export function GetRateLimitStats():Promise<Array<proxy.HostRateStats>>;
+
+export function GetRecentLogs(arg1:string,arg2:string,arg3:number):Promise<Array<logger.Entry>>;
+
+export function GetResearchReports(arg1:string):Promise<models.AnalystConsensus>;
+
+export function GetRetainedEvents(arg1:string):Promise<Array<any>>;
+
+export function GetSessionHeatReport(arg1:string,arg2:number):Promise<models.SessionHeatReport>;
+
 export function GetSessionMessages(arg1:string):Promise<Array<models.ChatMessage>>;
 
+export function GetShareholders(arg1:string):Promise<models.ShareholderData>;
+
+export function GetStockNews(arg1:string,arg2:number):Promise<Array<services.Telegraph>>;
+
 export function GetStockRealTimeData(arg1:Array<string>):Promise<Array<models.Stock>>;
 
+export function GetStockStats(arg1:string):Promise<models.StockStats>;
+
 export function GetStrategies():Promise<Array<models.Strategy>>;
 
 export function GetTelegraphList():Promise<Array<services.Telegraph>>;
 
+export function GetToolAuditLog(arg1:string,arg2:string,arg3:number):Promise<Array<models.ToolAuditEntry>>;
+
 export function GetTradeDates(arg1:number):Promise<Array<string>>;
 
 export function GetTradingSchedule():Promise<services.TradingSchedule>;
 
+export function GetUnreadNotificationCount():Promise<number>;
+
 export function GetWatchlist():Promise<Array<models.Stock>>;
 
+export function GetWatchlistGroups():Promise<models.WatchlistGroups>;
+
 export function Greet(arg1:string):Promise<string>;
 
+export function HotkeysSupported():Promise<boolean>;
+
+export function ImportSettings():Promise<string>;
+
+export function ImportWatchlist(arg1:string,arg2:string):Promise<models.WatchlistImportResult>;
+
+export function ListDiscussions(arg1:string):Promise<Array<models.DiscussionSummary>>;
+
+export function ListOllamaModels(arg1:string):Promise<Array<string>>;
+
+export function MarkNotificationsRead(arg1:Array<string>):Promise<string>;
+
 export function NotifyFrontendReady():Promise<void>;
 
+export function OpenDetachedWindow(arg1:string,arg2:string,arg3:string):Promise<string>;
+
 export function OpenURL(arg1:string):Promise<void>;
 
+export function OverrideAIBudget(arg1:number):Promise<string>;
+
+export function PlacePaperOrder(arg1:models.PaperOrderRequest):Promise<models.PaperOrder>;
+
+export function PreviewNotificationSound(arg1:string):Promise<string>;
+
+export function PullOllamaModel(arg1:string,arg2:string):Promise<string>;
+
 export function RemoveFromWatchlist(arg1:string):Promise<string>;
 
+export function RemoveFromWatchlistGroup(arg1:string,arg2:string):Promise<string>;
+
+export function RenameWatchlistGroup(arg1:string,arg2:string):Promise<string>;
+
+export function ReorderWatchlistGroup(arg1:string,arg2:Array<string>):Promise<string>;
+
+export function ResetConnectionStats():Promise<void>;
+
+export function ResetPaperAccount(arg1:number):Promise<string>;
+
+export function ResetToDefaults():Promise<models.ReportResult>;
+
 export function RestartApp():Promise<string>;
 
+export function ResumeDiscussion(arg1:string):Promise<Array<models.ChatMessage>>;
+
 export function RetryAgent(arg1:string,arg2:string,arg3:string):Promise<models.ChatMessage>;
 
 export function RetryAgentAndContinue(arg1:string):Promise<Array<models.ChatMessage>>;
 
-export function SearchStocks(arg1:string):Promise<Array<services.StockSearchResult>>;
+export function RunBacktest(arg1:models.BacktestRequest):Promise<models.BacktestResult>;
+
+export function RunDiagnostics():Promise<diagnostics.Report>;
+
+export function RunKLineIntegrityCheck():Promise<models.KLineIntegrityStatus>;
+
+export function SaveChartDrawing(arg1:models.ChartDrawing):Promise<models.ChartDrawing>;
+
+export function SaveJournalEntry(arg1:models.JournalEntry):Promise<models.JournalEntry>;
+
+export function SearchNews(arg1:string,arg2:string,arg3:string):Promise<Array<services.Telegraph>>;
+
+export function SearchStocks(arg1:string,arg2:number):Promise<Array<services.StockSearchResult>>;
+
+export function SelectSoundFile():Promise<string>;
 
 export function SendMeetingMessage(arg1:main.MeetingMessageRequest):Promise<Array<models.ChatMessage>>;
 
 export function SetActiveStrategy(arg1:string):Promise<string>;
 
+export function SetActiveWatchlistGroup(arg1:string):Promise<string>;
+
+export function SetPriceTarget(arg1:string,arg2:number,arg3:number):Promise<string>;
+
+export function SetPushEncoding(arg1:string):Promise<string>;
+
+export function SetStockMeta(arg1:string,arg2:Array<string>,arg3:string):Promise<string>;
+
+export function SetWatchlistSort(arg1:string,arg2:string):Promise<string>;
+
+export function SkipUpdateVersion(arg1:string):Promise<string>;
+
+export function SyncNow():Promise<models.SyncResult>;
+
 export function TestAIConnection(arg1:models.AIConfig):Promise<string>;
 
 export function TestMCPConnection(arg1:string):Promise<mcp.ServerStatus>;
@@ -113,6 +347,8 @@ export function UpdateConfig(arg1:models.AppConfig):Promise<string>;
 
 export function UpdateMCPServer(arg1:models.MCPServerConfig):Promise<string>;
 
+export function UpdatePersona(arg1:models.AgentPersona):Promise<string>;
+
 export function UpdateStockPosition(arg1:string,arg2:number,arg3:number):Promise<string>;
 
 export function UpdateStrategy(arg1:models.Strategy):Promise<string>;
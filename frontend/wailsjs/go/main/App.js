@@ -18,6 +18,26 @@ export function AddToWatchlist(arg1) {
   return window['go']['main']['App']['AddToWatchlist'](arg1);
 }
 
+export function AddToWatchlistGroup(arg1, arg2) {
+  return window['go']['main']['App']['AddToWatchlistGroup'](arg1, arg2);
+}
+
+export function ApplyRuntimeSettings(arg1) {
+  return window['go']['main']['App']['ApplyRuntimeSettings'](arg1);
+}
+
+export function CalculatePositionSize(arg1) {
+  return window['go']['main']['App']['CalculatePositionSize'](arg1);
+}
+
+export function CancelConditionOrder(arg1) {
+  return window['go']['main']['App']['CancelConditionOrder'](arg1);
+}
+
+export function CancelDiscussion(arg1) {
+  return window['go']['main']['App']['CancelDiscussion'](arg1);
+}
+
 export function CancelInterruptedMeeting(arg1) {
   return window['go']['main']['App']['CancelInterruptedMeeting'](arg1);
 }
@@ -26,26 +46,82 @@ export function CancelMeeting(arg1) {
   return window['go']['main']['App']['CancelMeeting'](arg1);
 }
 
+export function CancelPaperOrder(arg1) {
+  return window['go']['main']['App']['CancelPaperOrder'](arg1);
+}
+
 export function CheckForUpdate() {
   return window['go']['main']['App']['CheckForUpdate']();
 }
 
+export function CheckPortfolioRisk(arg1, arg2) {
+  return window['go']['main']['App']['CheckPortfolioRisk'](arg1, arg2);
+}
+
+export function ClearChartDrawings(arg1, arg2) {
+  return window['go']['main']['App']['ClearChartDrawings'](arg1, arg2);
+}
+
+export function ClearNotifications() {
+  return window['go']['main']['App']['ClearNotifications']();
+}
+
 export function ClearSessionMessages(arg1) {
   return window['go']['main']['App']['ClearSessionMessages'](arg1);
 }
 
+export function CreateConditionOrder(arg1) {
+  return window['go']['main']['App']['CreateConditionOrder'](arg1);
+}
+
+export function CreatePersona(arg1) {
+  return window['go']['main']['App']['CreatePersona'](arg1);
+}
+
+export function CreateWatchlistGroup(arg1) {
+  return window['go']['main']['App']['CreateWatchlistGroup'](arg1);
+}
+
+export function DebugGoroutines() {
+  return window['go']['main']['App']['DebugGoroutines']();
+}
+
 export function DeleteAgentConfig(arg1) {
   return window['go']['main']['App']['DeleteAgentConfig'](arg1);
 }
 
+export function DeleteChartDrawing(arg1, arg2, arg3) {
+  return window['go']['main']['App']['DeleteChartDrawing'](arg1, arg2, arg3);
+}
+
+export function DeleteConditionOrder(arg1) {
+  return window['go']['main']['App']['DeleteConditionOrder'](arg1);
+}
+
+export function DeleteJournalEntry(arg1) {
+  return window['go']['main']['App']['DeleteJournalEntry'](arg1);
+}
+
 export function DeleteMCPServer(arg1) {
   return window['go']['main']['App']['DeleteMCPServer'](arg1);
 }
 
+export function DeletePersona(arg1) {
+  return window['go']['main']['App']['DeletePersona'](arg1);
+}
+
 export function DeleteStrategy(arg1) {
   return window['go']['main']['App']['DeleteStrategy'](arg1);
 }
 
+export function DeleteWatchlistGroup(arg1) {
+  return window['go']['main']['App']['DeleteWatchlistGroup'](arg1);
+}
+
+export function DetectSystemProxy() {
+  return window['go']['main']['App']['DetectSystemProxy']();
+}
+
 export function DoUpdate() {
   return window['go']['main']['App']['DoUpdate']();
 }
@@ -54,10 +130,50 @@ export function EnhancePrompt(arg1) {
   return window['go']['main']['App']['EnhancePrompt'](arg1);
 }
 
+export function ExportJournal(arg1, arg2) {
+  return window['go']['main']['App']['ExportJournal'](arg1, arg2);
+}
+
+export function ExportKLines(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['ExportKLines'](arg1, arg2, arg3, arg4);
+}
+
+export function ExportQuotes(arg1) {
+  return window['go']['main']['App']['ExportQuotes'](arg1);
+}
+
+export function ExportSettings() {
+  return window['go']['main']['App']['ExportSettings']();
+}
+
+export function ExportTransactions(arg1) {
+  return window['go']['main']['App']['ExportTransactions'](arg1);
+}
+
+export function ExportWatchlist(arg1, arg2) {
+  return window['go']['main']['App']['ExportWatchlist'](arg1, arg2);
+}
+
+export function GenerateBriefingNow() {
+  return window['go']['main']['App']['GenerateBriefingNow']();
+}
+
+export function GenerateDiagnosticsBundle() {
+  return window['go']['main']['App']['GenerateDiagnosticsBundle']();
+}
+
+export function GenerateStockReport(arg1, arg2) {
+  return window['go']['main']['App']['GenerateStockReport'](arg1, arg2);
+}
+
 export function GenerateStrategy(arg1) {
   return window['go']['main']['App']['GenerateStrategy'](arg1);
 }
 
+export function GetAIUsage() {
+  return window['go']['main']['App']['GetAIUsage']();
+}
+
 export function GetActiveStrategyID() {
   return window['go']['main']['App']['GetActiveStrategyID']();
 }
@@ -70,18 +186,74 @@ export function GetAllHotTrends() {
   return window['go']['main']['App']['GetAllHotTrends']();
 }
 
+export function GetAvailableMarketIndices() {
+  return window['go']['main']['App']['GetAvailableMarketIndices']();
+}
+
 export function GetAvailableTools() {
   return window['go']['main']['App']['GetAvailableTools']();
 }
 
+export function GetChartDrawings(arg1, arg2) {
+  return window['go']['main']['App']['GetChartDrawings'](arg1, arg2);
+}
+
+export function GetComparisonSeries(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetComparisonSeries'](arg1, arg2, arg3);
+}
+
+export function GetConditionOrders() {
+  return window['go']['main']['App']['GetConditionOrders']();
+}
+
 export function GetConfig() {
   return window['go']['main']['App']['GetConfig']();
 }
 
+export function GetConnectionStats() {
+  return window['go']['main']['App']['GetConnectionStats']();
+}
+
 export function GetCurrentVersion() {
   return window['go']['main']['App']['GetCurrentVersion']();
 }
 
+export function GetDataSourceHealth() {
+  return window['go']['main']['App']['GetDataSourceHealth']();
+}
+
+export function GetDetachedWindow() {
+  return window['go']['main']['App']['GetDetachedWindow']();
+}
+
+export function GetDiscussion(arg1) {
+  return window['go']['main']['App']['GetDiscussion'](arg1);
+}
+
+export function GetETFConstituents(arg1) {
+  return window['go']['main']['App']['GetETFConstituents'](arg1);
+}
+
+export function GetEngineStatus() {
+  return window['go']['main']['App']['GetEngineStatus']();
+}
+
+export function GetFuturesQuotes(arg1) {
+  return window['go']['main']['App']['GetFuturesQuotes'](arg1);
+}
+
+export function GetGlobalIndices() {
+  return window['go']['main']['App']['GetGlobalIndices']();
+}
+
+export function GetGroupStocks(arg1) {
+  return window['go']['main']['App']['GetGroupStocks'](arg1);
+}
+
+export function GetGubaSentiment(arg1) {
+  return window['go']['main']['App']['GetGubaSentiment'](arg1);
+}
+
 export function GetHotTrend(arg1) {
   return window['go']['main']['App']['GetHotTrend'](arg1);
 }
@@ -90,10 +262,62 @@ export function GetHotTrendPlatforms() {
   return window['go']['main']['App']['GetHotTrendPlatforms']();
 }
 
+export function GetIPOCalendar() {
+  return window['go']['main']['App']['GetIPOCalendar']();
+}
+
+export function GetIndexConstituents(arg1) {
+  return window['go']['main']['App']['GetIndexConstituents'](arg1);
+}
+
+export function GetInstanceRole() {
+  return window['go']['main']['App']['GetInstanceRole']();
+}
+
+export function GetInterestRates() {
+  return window['go']['main']['App']['GetInterestRates']();
+}
+
+export function GetIntradayHistory(arg1, arg2) {
+  return window['go']['main']['App']['GetIntradayHistory'](arg1, arg2);
+}
+
+export function GetIntradayHistoryDates(arg1) {
+  return window['go']['main']['App']['GetIntradayHistoryDates'](arg1);
+}
+
+export function GetJournalEntries(arg1) {
+  return window['go']['main']['App']['GetJournalEntries'](arg1);
+}
+
+export function GetJournalStats(arg1) {
+  return window['go']['main']['App']['GetJournalStats'](arg1);
+}
+
+export function GetJournalTags() {
+  return window['go']['main']['App']['GetJournalTags']();
+}
+
 export function GetKLineData(arg1, arg2, arg3) {
   return window['go']['main']['App']['GetKLineData'](arg1, arg2, arg3);
 }
 
+export function GetKLineIntegrityStatus() {
+  return window['go']['main']['App']['GetKLineIntegrityStatus']();
+}
+
+export function GetLatestBriefing() {
+  return window['go']['main']['App']['GetLatestBriefing']();
+}
+
+export function GetLocalAPIStatus() {
+  return window['go']['main']['App']['GetLocalAPIStatus']();
+}
+
+export function GetLockupCalendar(arg1) {
+  return window['go']['main']['App']['GetLockupCalendar'](arg1);
+}
+
 export function GetLongHuBangDetail(arg1, arg2) {
   return window['go']['main']['App']['GetLongHuBangDetail'](arg1, arg2);
 }
@@ -114,10 +338,46 @@ export function GetMCPStatus() {
   return window['go']['main']['App']['GetMCPStatus']();
 }
 
+export function GetMarginData(arg1, arg2) {
+  return window['go']['main']['App']['GetMarginData'](arg1, arg2);
+}
+
+export function GetMarketBreadth() {
+  return window['go']['main']['App']['GetMarketBreadth']();
+}
+
+export function GetMarketHeatmap(arg1) {
+  return window['go']['main']['App']['GetMarketHeatmap'](arg1);
+}
+
+export function GetMarketMarginTrend(arg1) {
+  return window['go']['main']['App']['GetMarketMarginTrend'](arg1);
+}
+
+export function GetMarketStatus(arg1) {
+  return window['go']['main']['App']['GetMarketStatus'](arg1);
+}
+
+export function GetMetrics() {
+  return window['go']['main']['App']['GetMetrics']();
+}
+
+export function GetNearTargets(arg1) {
+  return window['go']['main']['App']['GetNearTargets'](arg1);
+}
+
+export function GetNotifications(arg1) {
+  return window['go']['main']['App']['GetNotifications'](arg1);
+}
+
 export function GetOpenClawStatus() {
   return window['go']['main']['App']['GetOpenClawStatus']();
 }
 
+export function GetOptionChain(arg1, arg2) {
+  return window['go']['main']['App']['GetOptionChain'](arg1, arg2);
+}
+
 export function GetOrCreateSession(arg1, arg2) {
   return window['go']['main']['App']['GetOrCreateSession'](arg1, arg2);
 }
@@ -126,14 +386,70 @@ export function GetOrderBook(arg1) {
   return window['go']['main']['App']['GetOrderBook'](arg1);
 }
 
+export function GetPaperAccount() {
+  return window['go']['main']['App']['GetPaperAccount']();
+}
+
+export function GetPaperOrders() {
+  return window['go']['main']['App']['GetPaperOrders']();
+}
+
+export function GetPersonas() {
+  return window['go']['main']['App']['GetPersonas']();
+}
+
+export function GetPortfolioAttribution() {
+  return window['go']['main']['App']['GetPortfolioAttribution']();
+}
+
+export function GetPortfolioExposure() {
+  return window['go']['main']['App']['GetPortfolioExposure']();
+}
+
+export function GetPriceTargets() {
+  return window['go']['main']['App']['GetPriceTargets']();
+}
+
+export function GetRateLimitStats() {
+  return window['go']['main']['App']['GetRateLimitStats']();
+}
+
+export function GetRecentLogs(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetRecentLogs'](arg1, arg2, arg3);
+}
+
+export function GetResearchReports(arg1) {
+  return window['go']['main']['App']['GetResearchReports'](arg1);
+}
+
+export function GetRetainedEvents(arg1) {
+  return window['go']['main']['App']['GetRetainedEvents'](arg1);
+}
+
+export function GetSessionHeatReport(arg1, arg2) {
+  return window['go']['main']['App']['GetSessionHeatReport'](arg1, arg2);
+}
+
 export function GetSessionMessages(arg1) {
   return window['go']['main']['App']['GetSessionMessages'](arg1);
 }
 
+export function GetShareholders(arg1) {
+  return window['go']['main']['App']['GetShareholders'](arg1);
+}
+
+export function GetStockNews(arg1, arg2) {
+  return window['go']['main']['App']['GetStockNews'](arg1, arg2);
+}
+
 export function GetStockRealTimeData(arg1) {
   return window['go']['main']['App']['GetStockRealTimeData'](arg1);
 }
 
+export function GetStockStats(arg1) {
+  return window['go']['main']['App']['GetStockStats'](arg1);
+}
+
 export function GetStrategies() {
   return window['go']['main']['App']['GetStrategies']();
 }
@@ -142,6 +458,10 @@ export function GetTelegraphList() {
   return window['go']['main']['App']['GetTelegraphList']();
 }
 
+export function GetToolAuditLog(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetToolAuditLog'](arg1, arg2, arg3);
+}
+
 export function GetTradeDates(arg1) {
   return window['go']['main']['App']['GetTradeDates'](arg1);
 }
@@ -150,30 +470,110 @@ export function GetTradingSchedule() {
   return window['go']['main']['App']['GetTradingSchedule']();
 }
 
+export function GetUnreadNotificationCount() {
+  return window['go']['main']['App']['GetUnreadNotificationCount']();
+}
+
 export function GetWatchlist() {
   return window['go']['main']['App']['GetWatchlist']();
 }
 
+export function GetWatchlistGroups() {
+  return window['go']['main']['App']['GetWatchlistGroups']();
+}
+
 export function Greet(arg1) {
   return window['go']['main']['App']['Greet'](arg1);
 }
 
+export function HotkeysSupported() {
+  return window['go']['main']['App']['HotkeysSupported']();
+}
+
+export function ImportSettings() {
+  return window['go']['main']['App']['ImportSettings']();
+}
+
+export function ImportWatchlist(arg1, arg2) {
+  return window['go']['main']['App']['ImportWatchlist'](arg1, arg2);
+}
+
+export function ListDiscussions(arg1) {
+  return window['go']['main']['App']['ListDiscussions'](arg1);
+}
+
+export function ListOllamaModels(arg1) {
+  return window['go']['main']['App']['ListOllamaModels'](arg1);
+}
+
+export function MarkNotificationsRead(arg1) {
+  return window['go']['main']['App']['MarkNotificationsRead'](arg1);
+}
+
 export function NotifyFrontendReady() {
   return window['go']['main']['App']['NotifyFrontendReady']();
 }
 
+export function OpenDetachedWindow(arg1, arg2, arg3) {
+  return window['go']['main']['App']['OpenDetachedWindow'](arg1, arg2, arg3);
+}
+
 export function OpenURL(arg1) {
   return window['go']['main']['App']['OpenURL'](arg1);
 }
 
+export function OverrideAIBudget(arg1) {
+  return window['go']['main']['App']['OverrideAIBudget'](arg1);
+}
+
+export function PlacePaperOrder(arg1) {
+  return window['go']['main']['App']['PlacePaperOrder'](arg1);
+}
+
+export function PreviewNotificationSound(arg1) {
+  return window['go']['main']['App']['PreviewNotificationSound'](arg1);
+}
+
+export function PullOllamaModel(arg1, arg2) {
+  return window['go']['main']['App']['PullOllamaModel'](arg1, arg2);
+}
+
 export function RemoveFromWatchlist(arg1) {
   return window['go']['main']['App']['RemoveFromWatchlist'](arg1);
 }
 
+export function RemoveFromWatchlistGroup(arg1, arg2) {
+  return window['go']['main']['App']['RemoveFromWatchlistGroup'](arg1, arg2);
+}
+
+export function RenameWatchlistGroup(arg1, arg2) {
+  return window['go']['main']['App']['RenameWatchlistGroup'](arg1, arg2);
+}
+
+export function ReorderWatchlistGroup(arg1, arg2) {
+  return window['go']['main']['App']['ReorderWatchlistGroup'](arg1, arg2);
+}
+
+export function ResetConnectionStats() {
+  return window['go']['main']['App']['ResetConnectionStats']();
+}
+
+export function ResetPaperAccount(arg1) {
+  return window['go']['main']['App']['ResetPaperAccount'](arg1);
+}
+
+export function ResetToDefaults() {
+  return window['go']['main']['App']['ResetToDefaults']();
+}
+
 export function RestartApp() {
   return window['go']['main']['App']['RestartApp']();
 }
 
+export function ResumeDiscussion(arg1) {
+  return window['go']['main']['App']['ResumeDiscussion'](arg1);
+}
+
 export function RetryAgent(arg1, arg2, arg3) {
   return window['go']['main']['App']['RetryAgent'](arg1, arg2, arg3);
 }
@@ -182,8 +582,36 @@ export function RetryAgentAndContinue(arg1) {
   return window['go']['main']['App']['RetryAgentAndContinue'](arg1);
 }
 
-export function SearchStocks(arg1) {
-  return window['go']['main']['App']['SearchStocks'](arg1);
+export function RunBacktest(arg1) {
+  return window['go']['main']['App']['RunBacktest'](arg1);
+}
+
+export function RunDiagnostics() {
+  return window['go']['main']['App']['RunDiagnostics']();
+}
+
+export function RunKLineIntegrityCheck() {
+  return window['go']['main']['App']['RunKLineIntegrityCheck']();
+}
+
+export function SaveChartDrawing(arg1) {
+  return window['go']['main']['App']['SaveChartDrawing'](arg1);
+}
+
+export function SaveJournalEntry(arg1) {
+  return window['go']['main']['App']['SaveJournalEntry'](arg1);
+}
+
+export function SearchNews(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SearchNews'](arg1, arg2, arg3);
+}
+
+export function SearchStocks(arg1, arg2) {
+  return window['go']['main']['App']['SearchStocks'](arg1, arg2);
+}
+
+export function SelectSoundFile() {
+  return window['go']['main']['App']['SelectSoundFile']();
 }
 
 export function SendMeetingMessage(arg1) {
@@ -194,6 +622,34 @@ export function SetActiveStrategy(arg1) {
   return window['go']['main']['App']['SetActiveStrategy'](arg1);
 }
 
+export function SetActiveWatchlistGroup(arg1) {
+  return window['go']['main']['App']['SetActiveWatchlistGroup'](arg1);
+}
+
+export function SetPriceTarget(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetPriceTarget'](arg1, arg2, arg3);
+}
+
+export function SetPushEncoding(arg1) {
+  return window['go']['main']['App']['SetPushEncoding'](arg1);
+}
+
+export function SetStockMeta(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetStockMeta'](arg1, arg2, arg3);
+}
+
+export function SetWatchlistSort(arg1, arg2) {
+  return window['go']['main']['App']['SetWatchlistSort'](arg1, arg2);
+}
+
+export function SkipUpdateVersion(arg1) {
+  return window['go']['main']['App']['SkipUpdateVersion'](arg1);
+}
+
+export function SyncNow() {
+  return window['go']['main']['App']['SyncNow']();
+}
+
 export function TestAIConnection(arg1) {
   return window['go']['main']['App']['TestAIConnection'](arg1);
 }
@@ -214,6 +670,10 @@ export function UpdateMCPServer(arg1) {
   return window['go']['main']['App']['UpdateMCPServer'](arg1);
 }
 
+export function UpdatePersona(arg1) {
+  return window['go']['main']['App']['UpdatePersona'](arg1);
+}
+
 export function UpdateStockPosition(arg1, arg2, arg3) {
   return window['go']['main']['App']['UpdateStockPosition'](arg1, arg2, arg3);
 }
@@ -1,3 +1,135 @@
+export namespace adk {
+	
+	export class UsageStats {
+	    calls: number;
+	    promptTokens: number;
+	    completionTokens: number;
+	    thoughtsTokens: number;
+	    totalTokens: number;
+	    cost: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new UsageStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.calls = source["calls"];
+	        this.promptTokens = source["promptTokens"];
+	        this.completionTokens = source["completionTokens"];
+	        this.thoughtsTokens = source["thoughtsTokens"];
+	        this.totalTokens = source["totalTokens"];
+	        this.cost = source["cost"];
+	    }
+	}
+	export class UsageSummary {
+	    date: string;
+	    dailyCost: number;
+	    sessionCosts: {[key: string]: number};
+	    budget: models.AIBudgetConfig;
+	    overrideUntil: number;
+	    models: {[key: string]: UsageStats};
+	    agents: {[key: string]: UsageStats};
+	    sessions: {[key: string]: UsageStats};
+	
+	    static createFrom(source: any = {}) {
+	        return new UsageSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.dailyCost = source["dailyCost"];
+	        this.sessionCosts = source["sessionCosts"];
+	        this.budget = this.convertValues(source["budget"], models.AIBudgetConfig);
+	        this.overrideUntil = source["overrideUntil"];
+	        this.models = this.convertValues(source["models"], UsageStats, true);
+	        this.agents = this.convertValues(source["agents"], UsageStats, true);
+	        this.sessions = this.convertValues(source["sessions"], UsageStats, true);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace diagnostics {
+	
+	export class Check {
+	    category: string;
+	    name: string;
+	    status: string;
+	    message?: string;
+	    latencyMs?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Check(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.category = source["category"];
+	        this.name = source["name"];
+	        this.status = source["status"];
+	        this.message = source["message"];
+	        this.latencyMs = source["latencyMs"];
+	    }
+	}
+	export class Report {
+	    time: number;
+	    ok: boolean;
+	    firstRun: boolean;
+	    checks: Check[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Report(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.time = source["time"];
+	        this.ok = source["ok"];
+	        this.firstRun = source["firstRun"];
+	        this.checks = this.convertValues(source["checks"], Check);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
 export namespace hottrend {
 	
 	export class HotItem {
@@ -84,6 +216,29 @@ export namespace hottrend {
 
 }
 
+export namespace logger {
+	
+	export class Entry {
+	    time: number;
+	    level: string;
+	    module: string;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Entry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.time = source["time"];
+	        this.level = source["level"];
+	        this.module = source["module"];
+	        this.message = source["message"];
+	    }
+	}
+
+}
+
 export namespace main {
 	
 	export class EnhancePromptRequest {
@@ -172,6 +327,7 @@ export namespace main {
 	    mentionIds: string[];
 	    replyToId: string;
 	    replyContent: string;
+	    orchestration?: models.OrchestrationConfig;
 	
 	    static createFrom(source: any = {}) {
 	        return new MeetingMessageRequest(source);
@@ -184,7 +340,26 @@ export namespace main {
 	        this.mentionIds = source["mentionIds"];
 	        this.replyToId = source["replyToId"];
 	        this.replyContent = source["replyContent"];
+	        this.orchestration = this.convertValues(source["orchestration"], models.OrchestrationConfig);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
 
 }
@@ -228,8 +403,121 @@ export namespace mcp {
 
 }
 
+export namespace metrics {
+	
+	export class Bucket {
+	    le: number;
+	    count: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Bucket(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.le = source["le"];
+	        this.count = source["count"];
+	    }
+	}
+	export class Sample {
+	    labels?: {[key: string]: string};
+	    value: number;
+	    count?: number;
+	    sum?: number;
+	    buckets?: Bucket[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Sample(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.labels = source["labels"];
+	        this.value = source["value"];
+	        this.count = source["count"];
+	        this.sum = source["sum"];
+	        this.buckets = this.convertValues(source["buckets"], Bucket);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Family {
+	    name: string;
+	    help: string;
+	    type: string;
+	    samples: Sample[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Family(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.help = source["help"];
+	        this.type = source["type"];
+	        this.samples = this.convertValues(source["samples"], Sample);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
 export namespace models {
 	
+	export class ModelCapabilities {
+	    vision: boolean;
+	    tools: boolean;
+	    systemRole: boolean;
+	    thinking: boolean;
+	    maxContext: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ModelCapabilities(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.vision = source["vision"];
+	        this.tools = source["tools"];
+	        this.systemRole = source["systemRole"];
+	        this.thinking = source["thinking"];
+	        this.maxContext = source["maxContext"];
+	    }
+	}
 	export class AIConfig {
 	    id: string;
 	    name: string;
@@ -243,9 +531,16 @@ export namespace models {
 	    isDefault: boolean;
 	    useResponses: boolean;
 	    noSystemRole: boolean;
+	    capabilities?: ModelCapabilities;
+	    keepAlive: string;
+	    thinkingBudget?: number;
+	    safetyThreshold: string;
+	    googleSearch: boolean;
 	    project: string;
 	    location: string;
 	    credentialsJson: string;
+	    inputPrice: number;
+	    outputPrice: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new AIConfig(source);
@@ -265,10 +560,35 @@ export namespace models {
 	        this.isDefault = source["isDefault"];
 	        this.useResponses = source["useResponses"];
 	        this.noSystemRole = source["noSystemRole"];
+	        this.capabilities = this.convertValues(source["capabilities"], ModelCapabilities);
+	        this.keepAlive = source["keepAlive"];
+	        this.thinkingBudget = source["thinkingBudget"];
+	        this.safetyThreshold = source["safetyThreshold"];
+	        this.googleSearch = source["googleSearch"];
 	        this.project = source["project"];
 	        this.location = source["location"];
 	        this.credentialsJson = source["credentialsJson"];
+	        this.inputPrice = source["inputPrice"];
+	        this.outputPrice = source["outputPrice"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
 	export class AgentConfig {
 	    id: string;
@@ -473,6 +793,7 @@ export namespace models {
 	export class ProxyConfig {
 	    mode: string;
 	    customUrl: string;
+	    pacUrl: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new ProxyConfig(source);
@@ -482,6 +803,33 @@ export namespace models {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.mode = source["mode"];
 	        this.customUrl = source["customUrl"];
+	        this.pacUrl = source["pacUrl"];
+	    }
+	}
+	export class MemoryPromptConfig {
+	    summaryLength: number;
+	    factLength: number;
+	    maxFacts: number;
+	    keyPointLength: number;
+	    summarizeTemplate: string;
+	    extractTemplate: string;
+	    keyPointsTemplate: string;
+	    consolidateTemplate: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MemoryPromptConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.summaryLength = source["summaryLength"];
+	        this.factLength = source["factLength"];
+	        this.maxFacts = source["maxFacts"];
+	        this.keyPointLength = source["keyPointLength"];
+	        this.summarizeTemplate = source["summarizeTemplate"];
+	        this.extractTemplate = source["extractTemplate"];
+	        this.keyPointsTemplate = source["keyPointsTemplate"];
+	        this.consolidateTemplate = source["consolidateTemplate"];
 	    }
 	}
 	export class MemoryConfig {
@@ -491,6 +839,9 @@ export namespace models {
 	    maxKeyFacts: number;
 	    maxSummaryLength: number;
 	    compressThreshold: number;
+	    embeddingAiId: string;
+	    embeddingModel: string;
+	    prompts: MemoryPromptConfig;
 	
 	    static createFrom(source: any = {}) {
 	        return new MemoryConfig(source);
@@ -504,6 +855,43 @@ export namespace models {
 	        this.maxKeyFacts = source["maxKeyFacts"];
 	        this.maxSummaryLength = source["maxSummaryLength"];
 	        this.compressThreshold = source["compressThreshold"];
+	        this.embeddingAiId = source["embeddingAiId"];
+	        this.embeddingModel = source["embeddingModel"];
+	        this.prompts = this.convertValues(source["prompts"], MemoryPromptConfig);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class HostRateLimit {
+	    host: string;
+	    rate: number;
+	    burst: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new HostRateLimit(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.host = source["host"];
+	        this.rate = source["rate"];
+	        this.burst = source["burst"];
 	    }
 	}
 	export class MCPServerConfig {
@@ -532,38 +920,38 @@ export namespace models {
 	        this.enabled = source["enabled"];
 	    }
 	}
-	export class AppConfig {
-	    theme: string;
-	    candleColorMode: string;
-	    aiConfigs: AIConfig[];
-	    defaultAiId: string;
-	    strategyAiId: string;
-	    moderatorAiId: string;
-	    mcpServers: MCPServerConfig[];
-	    memory: MemoryConfig;
-	    proxy: ProxyConfig;
-	    layout: LayoutConfig;
-	    openClaw: OpenClawConfig;
-	    indicators: IndicatorConfig;
+	export class ModelPrice {
+	    model: string;
+	    inputPrice: number;
+	    outputPrice: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new AppConfig(source);
+	        return new ModelPrice(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.theme = source["theme"];
-	        this.candleColorMode = source["candleColorMode"];
-	        this.aiConfigs = this.convertValues(source["aiConfigs"], AIConfig);
-	        this.defaultAiId = source["defaultAiId"];
-	        this.strategyAiId = source["strategyAiId"];
-	        this.moderatorAiId = source["moderatorAiId"];
-	        this.mcpServers = this.convertValues(source["mcpServers"], MCPServerConfig);
-	        this.memory = this.convertValues(source["memory"], MemoryConfig);
-	        this.proxy = this.convertValues(source["proxy"], ProxyConfig);
-	        this.layout = this.convertValues(source["layout"], LayoutConfig);
-	        this.openClaw = this.convertValues(source["openClaw"], OpenClawConfig);
-	        this.indicators = this.convertValues(source["indicators"], IndicatorConfig);
+	        this.model = source["model"];
+	        this.inputPrice = source["inputPrice"];
+	        this.outputPrice = source["outputPrice"];
+	    }
+	}
+	export class AIBudgetConfig {
+	    enabled: boolean;
+	    dailyLimit: number;
+	    sessionLimit: number;
+	    prices: ModelPrice[];
+	
+	    static createFrom(source: any = {}) {
+	        return new AIBudgetConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.dailyLimit = source["dailyLimit"];
+	        this.sessionLimit = source["sessionLimit"];
+	        this.prices = this.convertValues(source["prices"], ModelPrice);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -584,192 +972,198 @@ export namespace models {
 		    return a;
 		}
 	}
-	
-	export class ChatMessage {
-	    id: string;
-	    agentId: string;
-	    agentName: string;
-	    role: string;
-	    content: string;
-	    timestamp: number;
-	    replyTo?: string;
-	    mentions?: string[];
-	    round?: number;
-	    msgType?: string;
-	    error?: string;
-	    meetingMode?: string;
+	export class WatchlistSortConfig {
+	    field: string;
+	    order: string;
+	    topN: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new ChatMessage(source);
+	        return new WatchlistSortConfig(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.agentId = source["agentId"];
-	        this.agentName = source["agentName"];
-	        this.role = source["role"];
-	        this.content = source["content"];
-	        this.timestamp = source["timestamp"];
-	        this.replyTo = source["replyTo"];
-	        this.mentions = source["mentions"];
-	        this.round = source["round"];
-	        this.msgType = source["msgType"];
-	        this.error = source["error"];
-	        this.meetingMode = source["meetingMode"];
+	        this.field = source["field"];
+	        this.order = source["order"];
+	        this.topN = source["topN"];
 	    }
 	}
+	export class EngineConfig {
+	    enabled: boolean;
+	    address: string;
+	    token: string;
+	    tls: boolean;
+	    caCert: string;
 	
+	    static createFrom(source: any = {}) {
+	        return new EngineConfig(source);
+	    }
 	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.address = source["address"];
+	        this.token = source["token"];
+	        this.tls = source["tls"];
+	        this.caCert = source["caCert"];
+	    }
+	}
+	export class SyncConfig {
+	    enabled: boolean;
+	    url: string;
+	    username: string;
+	    password: string;
 	
-	export class KLineData {
-	    time: string;
-	    open: number;
-	    high: number;
-	    low: number;
-	    close: number;
-	    volume: number;
-	    amount?: number;
-	    avg?: number;
-	    ma5?: number;
-	    ma10?: number;
-	    ma20?: number;
+	    static createFrom(source: any = {}) {
+	        return new SyncConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.url = source["url"];
+	        this.username = source["username"];
+	        this.password = source["password"];
+	    }
+	}
+	export class SyncResult {
+	    pushed: string[];
+	    pulled: string[];
+	    conflicts: string[];
+	    time: number;
+	    error?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new KLineData(source);
+	        return new SyncResult(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.pushed = source["pushed"];
+	        this.pulled = source["pulled"];
+	        this.conflicts = source["conflicts"];
 	        this.time = source["time"];
-	        this.open = source["open"];
-	        this.high = source["high"];
-	        this.low = source["low"];
-	        this.close = source["close"];
-	        this.volume = source["volume"];
-	        this.amount = source["amount"];
-	        this.avg = source["avg"];
-	        this.ma5 = source["ma5"];
-	        this.ma10 = source["ma10"];
-	        this.ma20 = source["ma20"];
+	        this.error = source["error"];
 	    }
 	}
-	
-	export class LongHuBangDetail {
-	    rank: number;
-	    operName: string;
-	    buyAmt: number;
-	    buyPercent: number;
-	    sellAmt: number;
-	    sellPercent: number;
-	    netAmt: number;
-	    direction: string;
+	export class LocalAPIConfig {
+	    enabled: boolean;
+	    port: number;
+	    token: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new LongHuBangDetail(source);
+	        return new LocalAPIConfig(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.rank = source["rank"];
-	        this.operName = source["operName"];
-	        this.buyAmt = source["buyAmt"];
-	        this.buyPercent = source["buyPercent"];
-	        this.sellAmt = source["sellAmt"];
-	        this.sellPercent = source["sellPercent"];
-	        this.netAmt = source["netAmt"];
-	        this.direction = source["direction"];
+	        this.enabled = source["enabled"];
+	        this.port = source["port"];
+	        this.token = source["token"];
 	    }
 	}
-	export class LongHuBangItem {
-	    tradeDate: string;
-	    code: string;
-	    secuCode: string;
-	    name: string;
-	    closePrice: number;
-	    changePercent: number;
-	    netBuyAmt: number;
-	    buyAmt: number;
-	    sellAmt: number;
-	    totalAmt: number;
-	    turnoverRate: number;
-	    freeCap: number;
-	    reason: string;
-	    reasonDetail: string;
-	    accumAmount: number;
-	    dealRatio: number;
-	    netRatio: number;
-	    d1Change: number;
-	    d2Change: number;
-	    d5Change: number;
-	    d10Change: number;
-	    securityType: string;
+	export class NewsDigestConfig {
+	    interval: number;
+	    aiConfigId: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new LongHuBangItem(source);
+	        return new NewsDigestConfig(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.tradeDate = source["tradeDate"];
-	        this.code = source["code"];
-	        this.secuCode = source["secuCode"];
-	        this.name = source["name"];
-	        this.closePrice = source["closePrice"];
-	        this.changePercent = source["changePercent"];
-	        this.netBuyAmt = source["netBuyAmt"];
-	        this.buyAmt = source["buyAmt"];
-	        this.sellAmt = source["sellAmt"];
-	        this.totalAmt = source["totalAmt"];
-	        this.turnoverRate = source["turnoverRate"];
-	        this.freeCap = source["freeCap"];
-	        this.reason = source["reason"];
-	        this.reasonDetail = source["reasonDetail"];
-	        this.accumAmount = source["accumAmount"];
-	        this.dealRatio = source["dealRatio"];
-	        this.netRatio = source["netRatio"];
-	        this.d1Change = source["d1Change"];
-	        this.d2Change = source["d2Change"];
-	        this.d5Change = source["d5Change"];
-	        this.d10Change = source["d10Change"];
-	        this.securityType = source["securityType"];
+	        this.interval = source["interval"];
+	        this.aiConfigId = source["aiConfigId"];
 	    }
 	}
+	export class NewsConfig {
+	    disabledSources: string[];
+	    alertKeywords: string[];
+	    desktopNotify: boolean;
+	    digest: NewsDigestConfig;
 	
+	    static createFrom(source: any = {}) {
+	        return new NewsConfig(source);
+	    }
 	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.disabledSources = source["disabledSources"];
+	        this.alertKeywords = source["alertKeywords"];
+	        this.desktopNotify = source["desktopNotify"];
+	        this.digest = this.convertValues(source["digest"], NewsDigestConfig);
+	    }
 	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BriefingConfig {
+	    enabled: boolean;
+	    time: string;
+	    aiConfigId: string;
+	    maxStocks: number;
+	    nearTargetPct: number;
 	
+	    static createFrom(source: any = {}) {
+	        return new BriefingConfig(source);
+	    }
 	
-	export class OrderBookItem {
-	    price: number;
-	    size: number;
-	    total: number;
-	    percent: number;
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.time = source["time"];
+	        this.aiConfigId = source["aiConfigId"];
+	        this.maxStocks = source["maxStocks"];
+	        this.nearTargetPct = source["nearTargetPct"];
+	    }
+	}
+	export class ToolPolicy {
+	    tool: string;
+	    timeout: number;
+	    rateLimit: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new OrderBookItem(source);
+	        return new ToolPolicy(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.price = source["price"];
-	        this.size = source["size"];
-	        this.total = source["total"];
-	        this.percent = source["percent"];
+	        this.tool = source["tool"];
+	        this.timeout = source["timeout"];
+	        this.rateLimit = source["rateLimit"];
 	    }
 	}
-	export class OrderBook {
-	    bids: OrderBookItem[];
-	    asks: OrderBookItem[];
+	export class ToolSandboxConfig {
+	    defaultTimeout: number;
+	    defaultRateLimit: number;
+	    policies: ToolPolicy[];
+	    auditDays: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new OrderBook(source);
+	        return new ToolSandboxConfig(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.bids = this.convertValues(source["bids"], OrderBookItem);
-	        this.asks = this.convertValues(source["asks"], OrderBookItem);
+	        this.defaultTimeout = source["defaultTimeout"];
+	        this.defaultRateLimit = source["defaultRateLimit"];
+	        this.policies = this.convertValues(source["policies"], ToolPolicy);
+	        this.auditDays = source["auditDays"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -790,80 +1184,3182 @@ export namespace models {
 		    return a;
 		}
 	}
-	
-	
-	
-	export class Stock {
-	    symbol: string;
+	export class HotkeyConfig {
+	    enabled: boolean;
+	    showHide: string;
+	    bossKey: string;
+	    quickSearch: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new HotkeyConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.showHide = source["showHide"];
+	        this.bossKey = source["bossKey"];
+	        this.quickSearch = source["quickSearch"];
+	    }
+	}
+	export class LogConfig {
+	    maxSizeMB: number;
+	    retentionDays: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new LogConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.maxSizeMB = source["maxSizeMB"];
+	        this.retentionDays = source["retentionDays"];
+	    }
+	}
+	export class HostConnPool {
+	    host: string;
+	    maxIdleConnsPerHost: number;
+	    maxConnsPerHost: number;
+	    idleTimeout: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new HostConnPool(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.host = source["host"];
+	        this.maxIdleConnsPerHost = source["maxIdleConnsPerHost"];
+	        this.maxConnsPerHost = source["maxConnsPerHost"];
+	        this.idleTimeout = source["idleTimeout"];
+	    }
+	}
+	export class UpdateConfig {
+	    disableAutoCheck: boolean;
+	    skipVersion: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new UpdateConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.disableAutoCheck = source["disableAutoCheck"];
+	        this.skipVersion = source["skipVersion"];
+	    }
+	}
+	export class QuietHours {
+	    enabled: boolean;
+	    start: string;
+	    end: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new QuietHours(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.start = source["start"];
+	        this.end = source["end"];
+	    }
+	}
+	export class SoundConfig {
+	    enabled: boolean;
+	    volume: number;
+	    sounds: {[key: string]: string};
+	
+	    static createFrom(source: any = {}) {
+	        return new SoundConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.volume = source["volume"];
+	        this.sounds = source["sounds"];
+	    }
+	}
+	export class NotificationConfig {
+	    disabledCategories: string[];
+	    quietHours: QuietHours;
+	    maxHistory: number;
+	    sound: SoundConfig;
+	
+	    static createFrom(source: any = {}) {
+	        return new NotificationConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.disabledCategories = source["disabledCategories"];
+	        this.quietHours = this.convertValues(source["quietHours"], QuietHours);
+	        this.maxHistory = source["maxHistory"];
+	        this.sound = this.convertValues(source["sound"], SoundConfig);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class LockupAlertConfig {
+	    enabled: boolean;
+	    daysBefore: number;
+	    minFloatRatio: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new LockupAlertConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.daysBefore = source["daysBefore"];
+	        this.minFloatRatio = source["minFloatRatio"];
+	    }
+	}
+	export class AppConfig {
+	    theme: string;
+	    candleColorMode: string;
+	    aiConfigs: AIConfig[];
+	    defaultAiId: string;
+	    strategyAiId: string;
+	    moderatorAiId: string;
+	    mcpServers: MCPServerConfig[];
+	    memory: MemoryConfig;
+	    proxy: ProxyConfig;
+	    layout: LayoutConfig;
+	    openClaw: OpenClawConfig;
+	    indicators: IndicatorConfig;
+	    marketIndices: string[];
+	    ipoReminder: boolean;
+	    aiBudget: AIBudgetConfig;
+	    rateLimits: HostRateLimit[];
+	    connPools: HostConnPool[];
+	    pushIntervals?: PushIntervals;
+	    retry?: RetryConfig;
+	    watchlistSort: WatchlistSortConfig;
+	    engine: EngineConfig;
+	    sync: SyncConfig;
+	    localApi: LocalAPIConfig;
+	    news: NewsConfig;
+	    personas: AgentPersona[];
+	    briefing: BriefingConfig;
+	    toolSandbox: ToolSandboxConfig;
+	    log: LogConfig;
+	    update: UpdateConfig;
+	    hotkeys: HotkeyConfig;
+	    language: string;
+	    notifications: NotificationConfig;
+	    memoryLimitMb: number;
+	    lockupAlert: LockupAlertConfig;
+	
+	    static createFrom(source: any = {}) {
+	        return new AppConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.theme = source["theme"];
+	        this.candleColorMode = source["candleColorMode"];
+	        this.aiConfigs = this.convertValues(source["aiConfigs"], AIConfig);
+	        this.defaultAiId = source["defaultAiId"];
+	        this.strategyAiId = source["strategyAiId"];
+	        this.moderatorAiId = source["moderatorAiId"];
+	        this.mcpServers = this.convertValues(source["mcpServers"], MCPServerConfig);
+	        this.memory = this.convertValues(source["memory"], MemoryConfig);
+	        this.proxy = this.convertValues(source["proxy"], ProxyConfig);
+	        this.layout = this.convertValues(source["layout"], LayoutConfig);
+	        this.openClaw = this.convertValues(source["openClaw"], OpenClawConfig);
+	        this.indicators = this.convertValues(source["indicators"], IndicatorConfig);
+	        this.marketIndices = source["marketIndices"];
+	        this.ipoReminder = source["ipoReminder"];
+	        this.aiBudget = this.convertValues(source["aiBudget"], AIBudgetConfig);
+	        this.rateLimits = this.convertValues(source["rateLimits"], HostRateLimit);
+	        this.connPools = this.convertValues(source["connPools"], HostConnPool);
+	        this.pushIntervals = this.convertValues(source["pushIntervals"], PushIntervals);
+	        this.retry = this.convertValues(source["retry"], RetryConfig);
+	        this.watchlistSort = this.convertValues(source["watchlistSort"], WatchlistSortConfig);
+	        this.engine = this.convertValues(source["engine"], EngineConfig);
+	        this.sync = this.convertValues(source["sync"], SyncConfig);
+	        this.localApi = this.convertValues(source["localApi"], LocalAPIConfig);
+	        this.news = this.convertValues(source["news"], NewsConfig);
+	        this.personas = this.convertValues(source["personas"], AgentPersona);
+	        this.briefing = this.convertValues(source["briefing"], BriefingConfig);
+	        this.toolSandbox = this.convertValues(source["toolSandbox"], ToolSandboxConfig);
+	        this.log = this.convertValues(source["log"], LogConfig);
+	        this.update = this.convertValues(source["update"], UpdateConfig);
+	        this.hotkeys = this.convertValues(source["hotkeys"], HotkeyConfig);
+	        this.language = source["language"];
+	        this.notifications = this.convertValues(source["notifications"], NotificationConfig);
+	        this.memoryLimitMb = source["memoryLimitMb"];
+	        this.lockupAlert = this.convertValues(source["lockupAlert"], LockupAlertConfig);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class DrawingPoint {
+	    t: string;
+	    p: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DrawingPoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.t = source["t"];
+	        this.p = source["p"];
+	    }
+	}
+	export class ChartDrawing {
+	    id: string;
+	    symbol: string;
+	    period: string;
+	    type: string;
+	    points: DrawingPoint[];
+	    color?: string;
+	    width?: number;
+	    text?: string;
+	    levels?: number[];
+	    locked?: boolean;
+	    createdAt: number;
+	    updatedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ChartDrawing(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.symbol = source["symbol"];
+	        this.period = source["period"];
+	        this.type = source["type"];
+	        this.points = this.convertValues(source["points"], DrawingPoint);
+	        this.color = source["color"];
+	        this.width = source["width"];
+	        this.text = source["text"];
+	        this.levels = source["levels"];
+	        this.locked = source["locked"];
+	        this.createdAt = source["createdAt"];
+	        this.updatedAt = source["updatedAt"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ChatMessage {
+	    id: string;
+	    agentId: string;
+	    agentName: string;
+	    role: string;
+	    content: string;
+	    timestamp: number;
+	    replyTo?: string;
+	    mentions?: string[];
+	    round?: number;
+	    msgType?: string;
+	    error?: string;
+	    errorCode?: string;
+	    meetingMode?: string;
+	    cancelled?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ChatMessage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.agentId = source["agentId"];
+	        this.agentName = source["agentName"];
+	        this.role = source["role"];
+	        this.content = source["content"];
+	        this.timestamp = source["timestamp"];
+	        this.replyTo = source["replyTo"];
+	        this.mentions = source["mentions"];
+	        this.round = source["round"];
+	        this.msgType = source["msgType"];
+	        this.error = source["error"];
+	        this.errorCode = source["errorCode"];
+	        this.meetingMode = source["meetingMode"];
+	        this.cancelled = source["cancelled"];
+	    }
+	}
+	
+	
+	
+	export class IndexConstituent {
+	    symbol: string;
+	    name: string;
+	    price: number;
+	    preClose: number;
+	    changePercent: number;
+	    floatCap: number;
+	    weight: number;
+	    contribution: number;
+	    contributionPoints: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new IndexConstituent(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.symbol = source["symbol"];
+	        this.name = source["name"];
+	        this.price = source["price"];
+	        this.preClose = source["preClose"];
+	        this.changePercent = source["changePercent"];
+	        this.floatCap = source["floatCap"];
+	        this.weight = source["weight"];
+	        this.contribution = source["contribution"];
+	        this.contributionPoints = source["contributionPoints"];
+	    }
+	}
+	export class KLineData {
+	    time: string;
+	    open: number;
+	    high: number;
+	    low: number;
+	    close: number;
+	    volume: number;
+	    amount?: number;
+	    avg?: number;
+	    ma5?: number;
+	    ma10?: number;
+	    ma20?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new KLineData(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.time = source["time"];
+	        this.open = source["open"];
+	        this.high = source["high"];
+	        this.low = source["low"];
+	        this.close = source["close"];
+	        this.volume = source["volume"];
+	        this.amount = source["amount"];
+	        this.avg = source["avg"];
+	        this.ma5 = source["ma5"];
+	        this.ma10 = source["ma10"];
+	        this.ma20 = source["ma20"];
+	    }
+	}
+	
+	export class LongHuBangDetail {
+	    rank: number;
+	    operName: string;
+	    buyAmt: number;
+	    buyPercent: number;
+	    sellAmt: number;
+	    sellPercent: number;
+	    netAmt: number;
+	    direction: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LongHuBangDetail(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.rank = source["rank"];
+	        this.operName = source["operName"];
+	        this.buyAmt = source["buyAmt"];
+	        this.buyPercent = source["buyPercent"];
+	        this.sellAmt = source["sellAmt"];
+	        this.sellPercent = source["sellPercent"];
+	        this.netAmt = source["netAmt"];
+	        this.direction = source["direction"];
+	    }
+	}
+	export class LongHuBangItem {
+	    tradeDate: string;
+	    code: string;
+	    secuCode: string;
+	    name: string;
+	    closePrice: number;
+	    changePercent: number;
+	    netBuyAmt: number;
+	    buyAmt: number;
+	    sellAmt: number;
+	    totalAmt: number;
+	    turnoverRate: number;
+	    freeCap: number;
+	    reason: string;
+	    reasonDetail: string;
+	    accumAmount: number;
+	    dealRatio: number;
+	    netRatio: number;
+	    d1Change: number;
+	    d2Change: number;
+	    d5Change: number;
+	    d10Change: number;
+	    securityType: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LongHuBangItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.tradeDate = source["tradeDate"];
+	        this.code = source["code"];
+	        this.secuCode = source["secuCode"];
+	        this.name = source["name"];
+	        this.closePrice = source["closePrice"];
+	        this.changePercent = source["changePercent"];
+	        this.netBuyAmt = source["netBuyAmt"];
+	        this.buyAmt = source["buyAmt"];
+	        this.sellAmt = source["sellAmt"];
+	        this.totalAmt = source["totalAmt"];
+	        this.turnoverRate = source["turnoverRate"];
+	        this.freeCap = source["freeCap"];
+	        this.reason = source["reason"];
+	        this.reasonDetail = source["reasonDetail"];
+	        this.accumAmount = source["accumAmount"];
+	        this.dealRatio = source["dealRatio"];
+	        this.netRatio = source["netRatio"];
+	        this.d1Change = source["d1Change"];
+	        this.d2Change = source["d2Change"];
+	        this.d5Change = source["d5Change"];
+	        this.d10Change = source["d10Change"];
+	        this.securityType = source["securityType"];
+	    }
+	}
+	
+	
+	
+	
+	
+	export class OrderBookItem {
+	    price: number;
+	    size: number;
+	    total: number;
+	    percent: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new OrderBookItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.price = source["price"];
+	        this.size = source["size"];
+	        this.total = source["total"];
+	        this.percent = source["percent"];
+	    }
+	}
+	export class OrderBook {
+	    bids: OrderBookItem[];
+	    asks: OrderBookItem[];
+	
+	    static createFrom(source: any = {}) {
+	        return new OrderBook(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.bids = this.convertValues(source["bids"], OrderBookItem);
+	        this.asks = this.convertValues(source["asks"], OrderBookItem);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	
+	export class Stock {
+	    symbol: string;
+	    name: string;
+	    price: number;
+	    change: number;
+	    changePercent: number;
+	    volume: number;
+	    amount: number;
+	    marketCap: string;
+	    sector: string;
+	    open: number;
+	    high: number;
+	    low: number;
+	    preClose: number;
+	    targetPrice?: number;
+	    stopPrice?: number;
+	    targetDistance?: number;
+	    stopDistance?: number;
+	    quoteTime?: number;
+	    staleSeconds?: number;
+	    stale?: boolean;
+	    status?: string;
+	    st?: boolean;
+	    limitUpPrice?: number;
+	    limitDownPrice?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Stock(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.symbol = source["symbol"];
+	        this.name = source["name"];
+	        this.price = source["price"];
+	        this.change = source["change"];
+	        this.changePercent = source["changePercent"];
+	        this.volume = source["volume"];
+	        this.amount = source["amount"];
+	        this.marketCap = source["marketCap"];
+	        this.sector = source["sector"];
+	        this.open = source["open"];
+	        this.high = source["high"];
+	        this.low = source["low"];
+	        this.preClose = source["preClose"];
+	        this.targetPrice = source["targetPrice"];
+	        this.stopPrice = source["stopPrice"];
+	        this.targetDistance = source["targetDistance"];
+	        this.stopDistance = source["stopDistance"];
+	        this.quoteTime = source["quoteTime"];
+	        this.staleSeconds = source["staleSeconds"];
+	        this.stale = source["stale"];
+	        this.status = source["status"];
+	        this.st = source["st"];
+	        this.limitUpPrice = source["limitUpPrice"];
+	        this.limitDownPrice = source["limitDownPrice"];
+	    }
+	}
+	export class StockPosition {
+	    shares: number;
+	    costPrice: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new StockPosition(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.shares = source["shares"];
+	        this.costPrice = source["costPrice"];
+	    }
+	}
+	export class StockSession {
+	    id: string;
+	    stockCode: string;
+	    stockName: string;
+	    messages: ChatMessage[];
+	    position?: StockPosition;
+	    createdAt: number;
+	    updatedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new StockSession(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.stockCode = source["stockCode"];
+	        this.stockName = source["stockName"];
+	        this.messages = this.convertValues(source["messages"], ChatMessage);
+	        this.position = this.convertValues(source["position"], StockPosition);
+	        this.createdAt = source["createdAt"];
+	        this.updatedAt = source["updatedAt"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class StrategyAgent {
+	    id: string;
+	    name: string;
+	    role: string;
+	    avatar: string;
+	    color: string;
+	    instruction: string;
+	    tools: string[];
+	    mcpServers: string[];
+	    enabled: boolean;
+	    aiConfigId: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new StrategyAgent(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.role = source["role"];
+	        this.avatar = source["avatar"];
+	        this.color = source["color"];
+	        this.instruction = source["instruction"];
+	        this.tools = source["tools"];
+	        this.mcpServers = source["mcpServers"];
+	        this.enabled = source["enabled"];
+	        this.aiConfigId = source["aiConfigId"];
+	    }
+	}
+	export class Strategy {
+	    id: string;
+	    name: string;
+	    description: string;
+	    color: string;
+	    agents: StrategyAgent[];
+	    isBuiltin: boolean;
+	    source: string;
+	    sourceMeta: string;
+	    createdAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Strategy(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.color = source["color"];
+	        this.agents = this.convertValues(source["agents"], StrategyAgent);
+	        this.isBuiltin = source["isBuiltin"];
+	        this.source = source["source"];
+	        this.sourceMeta = source["sourceMeta"];
+	        this.createdAt = source["createdAt"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BreadthBucket {
+	    label: string;
+	    count: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new BreadthBucket(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.label = source["label"];
+	        this.count = source["count"];
+	    }
+	}
+	export class MarketBreadth {
+	    advancers: number;
+	    decliners: number;
+	    unchanged: number;
+	    suspended: number;
+	    limitUp: number;
+	    limitDown: number;
+	    distribution: BreadthBucket[];
+	    updatedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MarketBreadth(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.advancers = source["advancers"];
+	        this.decliners = source["decliners"];
+	        this.unchanged = source["unchanged"];
+	        this.suspended = source["suspended"];
+	        this.limitUp = source["limitUp"];
+	        this.limitDown = source["limitDown"];
+	        this.distribution = this.convertValues(source["distribution"], BreadthBucket);
+	        this.updatedAt = source["updatedAt"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class IPOItem {
+	    code: string;
+	    name: string;
+	    market: string;
+	    applyCode: string;
+	    applyDate: string;
+	    ballotDate: string;
+	    listingDate: string;
+	    issuePrice: number;
+	    issuePE: number;
+	    industryPE: number;
+	    applyUpper: number;
+	    lotRate: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new IPOItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.code = source["code"];
+	        this.name = source["name"];
+	        this.market = source["market"];
+	        this.applyCode = source["applyCode"];
+	        this.applyDate = source["applyDate"];
+	        this.ballotDate = source["ballotDate"];
+	        this.listingDate = source["listingDate"];
+	        this.issuePrice = source["issuePrice"];
+	        this.issuePE = source["issuePE"];
+	        this.industryPE = source["industryPE"];
+	        this.applyUpper = source["applyUpper"];
+	        this.lotRate = source["lotRate"];
+	    }
+	}
+	export class HeatMinute {
+	    time: string;
+	    volume: number;
+	    amount: number;
+	    changePercent: number;
+	    volumeRatio: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new HeatMinute(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.time = source["time"];
+	        this.volume = source["volume"];
+	        this.amount = source["amount"];
+	        this.changePercent = source["changePercent"];
+	        this.volumeRatio = source["volumeRatio"];
+	    }
+	}
+	export class OrderBookShift {
+	    time: string;
+	    price: number;
+	    bidVolume: number;
+	    askVolume: number;
+	    imbalance: number;
+	    delta: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new OrderBookShift(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.time = source["time"];
+	        this.price = source["price"];
+	        this.bidVolume = source["bidVolume"];
+	        this.askVolume = source["askVolume"];
+	        this.imbalance = source["imbalance"];
+	        this.delta = source["delta"];
+	    }
+	}
+	export class SessionEvent {
+	    time: string;
+	    category: string;
+	    kind?: string;
+	    title: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SessionEvent(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.time = source["time"];
+	        this.category = source["category"];
+	        this.kind = source["kind"];
+	        this.title = source["title"];
+	    }
+	}
+	export class SessionHeatReport {
+	    code: string;
+	    date: string;
+	    hotMinutes: HeatMinute[];
+	    orderBookShifts: OrderBookShift[];
+	    timeline: SessionEvent[];
+	    jumpPoints: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SessionHeatReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.code = source["code"];
+	        this.date = source["date"];
+	        this.hotMinutes = this.convertValues(source["hotMinutes"], HeatMinute);
+	        this.orderBookShifts = this.convertValues(source["orderBookShifts"], OrderBookShift);
+	        this.timeline = this.convertValues(source["timeline"], SessionEvent);
+	        this.jumpPoints = source["jumpPoints"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GubaPost {
+	    id: string;
+	    title: string;
+	    publishTime: string;
+	    clickCount: number;
+	    replyCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new GubaPost(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.title = source["title"];
+	        this.publishTime = source["publishTime"];
+	        this.clickCount = source["clickCount"];
+	        this.replyCount = source["replyCount"];
+	    }
+	}
+	export class GubaDailyCount {
+	    date: string;
+	    posts: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new GubaDailyCount(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.posts = source["posts"];
+	    }
+	}
+	export class GubaSentiment {
+	    code: string;
+	    totalPosts: number;
+	    trend: GubaDailyCount[];
+	    latest: GubaPost[];
+	    updatedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new GubaSentiment(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.code = source["code"];
+	        this.totalPosts = source["totalPosts"];
+	        this.trend = this.convertValues(source["trend"], GubaDailyCount);
+	        this.latest = this.convertValues(source["latest"], GubaPost);
+	        this.updatedAt = source["updatedAt"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MarginRecord {
+	    date: string;
+	    close: number;
+	    changePercent: number;
+	    financingBalance: number;
+	    financingBuy: number;
+	    financingRepay: number;
+	    financingNetBuy: number;
+	    securitiesBalance: number;
+	    securitiesVolume: number;
+	    totalBalance: number;
+	    financingBalanceRatio: number;
+	    financingBuyRatio?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MarginRecord(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.close = source["close"];
+	        this.changePercent = source["changePercent"];
+	        this.financingBalance = source["financingBalance"];
+	        this.financingBuy = source["financingBuy"];
+	        this.financingRepay = source["financingRepay"];
+	        this.financingNetBuy = source["financingNetBuy"];
+	        this.securitiesBalance = source["securitiesBalance"];
+	        this.securitiesVolume = source["securitiesVolume"];
+	        this.totalBalance = source["totalBalance"];
+	        this.financingBalanceRatio = source["financingBalanceRatio"];
+	        this.financingBuyRatio = source["financingBuyRatio"];
+	    }
+	}
+	export class Shareholder {
+	    rank: number;
+	    name: string;
+	    type?: string;
+	    shares: number;
+	    ratio: number;
+	    change?: string;
+	    changeRatio?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Shareholder(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.rank = source["rank"];
+	        this.name = source["name"];
+	        this.type = source["type"];
+	        this.shares = source["shares"];
+	        this.ratio = source["ratio"];
+	        this.change = source["change"];
+	        this.changeRatio = source["changeRatio"];
+	    }
+	}
+	export class HolderCount {
+	    date: string;
+	    count: number;
+	    changeRatio: number;
+	    avgShares: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new HolderCount(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.count = source["count"];
+	        this.changeRatio = source["changeRatio"];
+	        this.avgShares = source["avgShares"];
+	    }
+	}
+	export class InstitutionHolding {
+	    type: string;
+	    orgCount: number;
+	    shares: number;
+	    floatRatio: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new InstitutionHolding(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.orgCount = source["orgCount"];
+	        this.shares = source["shares"];
+	        this.floatRatio = source["floatRatio"];
+	    }
+	}
+	export class ShareholderData {
+	    symbol: string;
+	    reportDate: string;
+	    topHolders: Shareholder[];
+	    institutionDate: string;
+	    institutionRatio: number;
+	    institutions: InstitutionHolding[];
+	    holderCounts: HolderCount[];
+	    updatedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ShareholderData(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.symbol = source["symbol"];
+	        this.reportDate = source["reportDate"];
+	        this.topHolders = this.convertValues(source["topHolders"], Shareholder);
+	        this.institutionDate = source["institutionDate"];
+	        this.institutionRatio = source["institutionRatio"];
+	        this.institutions = this.convertValues(source["institutions"], InstitutionHolding);
+	        this.holderCounts = this.convertValues(source["holderCounts"], HolderCount);
+	        this.updatedAt = source["updatedAt"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class LockupExpiry {
+	    symbol: string;
+	    name: string;
+	    date: string;
+	    daysUntil: number;
+	    shares: number;
+	    floatRatio: number;
+	    marketValue: number;
+	    type: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LockupExpiry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.symbol = source["symbol"];
+	        this.name = source["name"];
+	        this.date = source["date"];
+	        this.daysUntil = source["daysUntil"];
+	        this.shares = source["shares"];
+	        this.floatRatio = source["floatRatio"];
+	        this.marketValue = source["marketValue"];
+	        this.type = source["type"];
+	    }
+	}
+	export class ETFConstituent {
+	    symbol: string;
+	    name: string;
+	    weight: number;
+	    sector: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ETFConstituent(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.symbol = source["symbol"];
+	        this.name = source["name"];
+	        this.weight = source["weight"];
+	        this.sector = source["sector"];
+	    }
+	}
+	export class StockExposure {
+	    symbol: string;
+	    name: string;
+	    sector: string;
+	    value: number;
+	    weight: number;
+	    sources: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new StockExposure(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.symbol = source["symbol"];
+	        this.name = source["name"];
+	        this.sector = source["sector"];
+	        this.value = source["value"];
+	        this.weight = source["weight"];
+	        this.sources = source["sources"];
+	    }
+	}
+	export class SectorExposure {
+	    sector: string;
+	    value: number;
+	    weight: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SectorExposure(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sector = source["sector"];
+	        this.value = source["value"];
+	        this.weight = source["weight"];
+	    }
+	}
+	export class PortfolioExposure {
+	    totalValue: number;
+	    byStock: StockExposure[];
+	    bySector: SectorExposure[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PortfolioExposure(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.totalValue = source["totalValue"];
+	        this.byStock = this.convertValues(source["byStock"], StockExposure);
+	        this.bySector = this.convertValues(source["bySector"], SectorExposure);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class HoldingAttribution {
+	    symbol: string;
+	    name: string;
+	    value: number;
+	    weight: number;
+	    changePercent: number;
+	    dayPnl: number;
+	    contribution: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new HoldingAttribution(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.symbol = source["symbol"];
+	        this.name = source["name"];
+	        this.value = source["value"];
+	        this.weight = source["weight"];
+	        this.changePercent = source["changePercent"];
+	        this.dayPnl = source["dayPnl"];
+	        this.contribution = source["contribution"];
+	    }
+	}
+	export class SectorAttribution {
+	    sector: string;
+	    weight: number;
+	    dayPnl: number;
+	    contribution: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SectorAttribution(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sector = source["sector"];
+	        this.weight = source["weight"];
+	        this.dayPnl = source["dayPnl"];
+	        this.contribution = source["contribution"];
+	    }
+	}
+	export class PortfolioAttribution {
+	    date: string;
+	    totalValue: number;
+	    dayPnl: number;
+	    returnPercent: number;
+	    byHolding: HoldingAttribution[];
+	    bySector: SectorAttribution[];
+	    exposure: PortfolioExposure;
+	    markdown: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PortfolioAttribution(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.totalValue = source["totalValue"];
+	        this.dayPnl = source["dayPnl"];
+	        this.returnPercent = source["returnPercent"];
+	        this.byHolding = this.convertValues(source["byHolding"], HoldingAttribution);
+	        this.bySector = this.convertValues(source["bySector"], SectorAttribution);
+	        this.exposure = this.convertValues(source["exposure"], PortfolioExposure);
+	        this.markdown = source["markdown"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PriceTarget {
+	    symbol: string;
+	    targetPrice: number;
+	    stopPrice: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PriceTarget(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.symbol = source["symbol"];
+	        this.targetPrice = source["targetPrice"];
+	        this.stopPrice = source["stopPrice"];
+	    }
+	}
+	export class TargetProximity {
+	    symbol: string;
+	    kind: string;
+	    line: number;
+	    price: number;
+	    distance: number;
+	    time: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TargetProximity(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.symbol = source["symbol"];
+	        this.kind = source["kind"];
+	        this.line = source["line"];
+	        this.price = source["price"];
+	        this.distance = source["distance"];
+	        this.time = source["time"];
+	    }
+	}
+	export class PushIntervals {
+	    fastMs: number;
+	    normalMs: number;
+	    slowMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PushIntervals(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.fastMs = source["fastMs"];
+	        this.normalMs = source["normalMs"];
+	        this.slowMs = source["slowMs"];
+	    }
+	}
+	export class RetryConfig {
+	    maxAttempts: number;
+	    baseDelayMs: number;
+	    maxDelayMs: number;
+	    retryableStatus: number[];
+	
+	    static createFrom(source: any = {}) {
+	        return new RetryConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.maxAttempts = source["maxAttempts"];
+	        this.baseDelayMs = source["baseDelayMs"];
+	        this.maxDelayMs = source["maxDelayMs"];
+	        this.retryableStatus = source["retryableStatus"];
+	    }
+	}
+	export class RuntimeSettings {
+	    logLevel?: string;
+	    moduleLevels?: string;
+	    engine?: EngineConfig;
+	    recording?: boolean;
+	    pushIntervals?: PushIntervals;
+	    pushPaused?: boolean;
+	    retry?: RetryConfig;
+	
+	    static createFrom(source: any = {}) {
+	        return new RuntimeSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.logLevel = source["logLevel"];
+	        this.moduleLevels = source["moduleLevels"];
+	        this.engine = this.convertValues(source["engine"], EngineConfig);
+	        this.recording = source["recording"];
+	        this.pushIntervals = this.convertValues(source["pushIntervals"], PushIntervals);
+	        this.pushPaused = source["pushPaused"];
+	        this.retry = this.convertValues(source["retry"], RetryConfig);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RuntimeSettingsResult {
+	    success: boolean;
+	    applied: string[];
+	    rolledBack: string[];
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RuntimeSettingsResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.applied = source["applied"];
+	        this.rolledBack = source["rolledBack"];
+	        this.error = source["error"];
+	    }
+	}
+	export class WatchlistGroup {
+	    id: string;
+	    name: string;
+	    symbols: string[];
+	    builtIn?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new WatchlistGroup(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.symbols = source["symbols"];
+	        this.builtIn = source["builtIn"];
+	    }
+	}
+	export class StockMeta {
+	    tags?: string[];
+	    note?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new StockMeta(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.tags = source["tags"];
+	        this.note = source["note"];
+	    }
+	}
+	export class WatchlistGroups {
+	    active: string;
+	    groups: WatchlistGroup[];
+	    meta: {[key: string]: StockMeta};
+	
+	    static createFrom(source: any = {}) {
+	        return new WatchlistGroups(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.active = source["active"];
+	        this.groups = this.convertValues(source["groups"], WatchlistGroup);
+	        this.meta = this.convertValues(source["meta"], StockMeta, true);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class WatchlistImportResult {
+	    imported: string[];
+	    skipped: string[];
+	    invalid?: string[];
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new WatchlistImportResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.imported = source["imported"];
+	        this.skipped = source["skipped"];
+	        this.invalid = source["invalid"];
+	        this.error = source["error"];
+	    }
+	}
+	export class PaperOrderRequest {
+	    symbol: string;
+	    side: string;
+	    type: string;
+	    price: number;
+	    shares: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PaperOrderRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.symbol = source["symbol"];
+	        this.side = source["side"];
+	        this.type = source["type"];
+	        this.price = source["price"];
+	        this.shares = source["shares"];
+	    }
+	}
+	export class PaperOrder {
+	    id: string;
+	    symbol: string;
+	    name: string;
+	    side: string;
+	    type: string;
+	    price: number;
+	    shares: number;
+	    status: string;
+	    filledPrice?: number;
+	    fee?: number;
+	    reason?: string;
+	    createdAt: number;
+	    filledAt?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PaperOrder(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.symbol = source["symbol"];
+	        this.name = source["name"];
+	        this.side = source["side"];
+	        this.type = source["type"];
+	        this.price = source["price"];
+	        this.shares = source["shares"];
+	        this.status = source["status"];
+	        this.filledPrice = source["filledPrice"];
+	        this.fee = source["fee"];
+	        this.reason = source["reason"];
+	        this.createdAt = source["createdAt"];
+	        this.filledAt = source["filledAt"];
+	    }
+	}
+	export class PaperPosition {
+	    symbol: string;
+	    name: string;
+	    shares: number;
+	    available: number;
+	    costPrice: number;
+	    price: number;
+	    marketValue: number;
+	    pnl: number;
+	    pnlPercent: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PaperPosition(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.symbol = source["symbol"];
+	        this.name = source["name"];
+	        this.shares = source["shares"];
+	        this.available = source["available"];
+	        this.costPrice = source["costPrice"];
+	        this.price = source["price"];
+	        this.marketValue = source["marketValue"];
+	        this.pnl = source["pnl"];
+	        this.pnlPercent = source["pnlPercent"];
+	    }
+	}
+	export class PaperDailyPnL {
+	    date: string;
+	    totalAsset: number;
+	    pnl: number;
+	    pnlPercent: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PaperDailyPnL(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.totalAsset = source["totalAsset"];
+	        this.pnl = source["pnl"];
+	        this.pnlPercent = source["pnlPercent"];
+	    }
+	}
+	export class PaperAccount {
+	    initialCash: number;
+	    cash: number;
+	    frozen: number;
+	    marketValue: number;
+	    totalAsset: number;
+	    totalPnl: number;
+	    dailyPnl: number;
+	    dailyPnlPercent: number;
+	    positions: PaperPosition[];
+	    history: PaperDailyPnL[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PaperAccount(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.initialCash = source["initialCash"];
+	        this.cash = source["cash"];
+	        this.frozen = source["frozen"];
+	        this.marketValue = source["marketValue"];
+	        this.totalAsset = source["totalAsset"];
+	        this.totalPnl = source["totalPnl"];
+	        this.dailyPnl = source["dailyPnl"];
+	        this.dailyPnlPercent = source["dailyPnlPercent"];
+	        this.positions = this.convertValues(source["positions"], PaperPosition);
+	        this.history = this.convertValues(source["history"], PaperDailyPnL);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BacktestRule {
+	    type: string;
+	    fast?: number;
+	    slow?: number;
+	    lookback?: number;
+	    period?: number;
+	    lower?: number;
+	    upper?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new BacktestRule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.fast = source["fast"];
+	        this.slow = source["slow"];
+	        this.lookback = source["lookback"];
+	        this.period = source["period"];
+	        this.lower = source["lower"];
+	        this.upper = source["upper"];
+	    }
+	}
+	export class BacktestRequest {
+	    symbol: string;
+	    period: string;
+	    days: number;
+	    rule: BacktestRule;
+	    initialCash: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new BacktestRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.symbol = source["symbol"];
+	        this.period = source["period"];
+	        this.days = source["days"];
+	        this.rule = this.convertValues(source["rule"], BacktestRule);
+	        this.initialCash = source["initialCash"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BacktestTrade {
+	    entryTime: string;
+	    entryPrice: number;
+	    exitTime: string;
+	    exitPrice: number;
+	    shares: number;
+	    fee: number;
+	    pnl: number;
+	    pnlPercent: number;
+	    open?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new BacktestTrade(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.entryTime = source["entryTime"];
+	        this.entryPrice = source["entryPrice"];
+	        this.exitTime = source["exitTime"];
+	        this.exitPrice = source["exitPrice"];
+	        this.shares = source["shares"];
+	        this.fee = source["fee"];
+	        this.pnl = source["pnl"];
+	        this.pnlPercent = source["pnlPercent"];
+	        this.open = source["open"];
+	    }
+	}
+	export class EquityPoint {
+	    time: string;
+	    equity: number;
+	    drawdown: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new EquityPoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.time = source["time"];
+	        this.equity = source["equity"];
+	        this.drawdown = source["drawdown"];
+	    }
+	}
+	export class BacktestResult {
+	    symbol: string;
+	    rule: BacktestRule;
+	    initialCash: number;
+	    finalEquity: number;
+	    totalReturn: number;
+	    benchmarkReturn: number;
+	    maxDrawdown: number;
+	    winRate: number;
+	    tradeCount: number;
+	    trades: BacktestTrade[];
+	    equity: EquityPoint[];
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BacktestResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.symbol = source["symbol"];
+	        this.rule = this.convertValues(source["rule"], BacktestRule);
+	        this.initialCash = source["initialCash"];
+	        this.finalEquity = source["finalEquity"];
+	        this.totalReturn = source["totalReturn"];
+	        this.benchmarkReturn = source["benchmarkReturn"];
+	        this.maxDrawdown = source["maxDrawdown"];
+	        this.winRate = source["winRate"];
+	        this.tradeCount = source["tradeCount"];
+	        this.trades = this.convertValues(source["trades"], BacktestTrade);
+	        this.equity = this.convertValues(source["equity"], EquityPoint);
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ConditionTrigger {
+	    type: string;
+	    price?: number;
+	    time?: string;
+	    indicator?: string;
+	    above?: boolean;
+	    value?: number;
+	    period?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ConditionTrigger(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.price = source["price"];
+	        this.time = source["time"];
+	        this.indicator = source["indicator"];
+	        this.above = source["above"];
+	        this.value = source["value"];
+	        this.period = source["period"];
+	    }
+	}
+	export class ConditionAction {
+	    type: string;
+	    order?: PaperOrderRequest;
+	
+	    static createFrom(source: any = {}) {
+	        return new ConditionAction(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.order = this.convertValues(source["order"], PaperOrderRequest);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ConditionOrderRequest {
+	    symbol: string;
+	    trigger: ConditionTrigger;
+	    action: ConditionAction;
+	    note?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ConditionOrderRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.symbol = source["symbol"];
+	        this.trigger = this.convertValues(source["trigger"], ConditionTrigger);
+	        this.action = this.convertValues(source["action"], ConditionAction);
+	        this.note = source["note"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ConditionOrder {
+	    id: string;
+	    symbol: string;
+	    name: string;
+	    trigger: ConditionTrigger;
+	    action: ConditionAction;
+	    note?: string;
+	    status: string;
+	    lastPrice?: number;
+	    createdAt: number;
+	    triggeredAt?: number;
+	    triggerPrice?: number;
+	    result?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ConditionOrder(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.symbol = source["symbol"];
+	        this.name = source["name"];
+	        this.trigger = this.convertValues(source["trigger"], ConditionTrigger);
+	        this.action = this.convertValues(source["action"], ConditionAction);
+	        this.note = source["note"];
+	        this.status = source["status"];
+	        this.lastPrice = source["lastPrice"];
+	        this.createdAt = source["createdAt"];
+	        this.triggeredAt = source["triggeredAt"];
+	        this.triggerPrice = source["triggerPrice"];
+	        this.result = source["result"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ReportResult {
+	    path: string;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ReportResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.error = source["error"];
+	    }
+	}
+	export class HeatmapStock {
+	    symbol: string;
+	    name: string;
+	    cap: number;
+	    changePercent: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new HeatmapStock(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.symbol = source["symbol"];
+	        this.name = source["name"];
+	        this.cap = source["cap"];
+	        this.changePercent = source["changePercent"];
+	    }
+	}
+	export class HeatmapSector {
+	    name: string;
+	    cap: number;
+	    changePercent: number;
+	    count: number;
+	    stocks: HeatmapStock[];
+	
+	    static createFrom(source: any = {}) {
+	        return new HeatmapSector(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.cap = source["cap"];
+	        this.changePercent = source["changePercent"];
+	        this.count = source["count"];
+	        this.stocks = this.convertValues(source["stocks"], HeatmapStock);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MarketHeatmap {
+	    scope: string;
+	    sectors: HeatmapSector[];
+	    updatedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MarketHeatmap(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.scope = source["scope"];
+	        this.sectors = this.convertValues(source["sectors"], HeatmapSector);
+	        this.updatedAt = source["updatedAt"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class AnalystRating {
+	    title: string;
+	    orgName: string;
+	    researcher: string;
+	    publishDate: string;
+	    rating: string;
+	    lastRating: string;
+	    ratingChange: string;
+	    targetPrice: number;
+	    infoCode: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AnalystRating(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.title = source["title"];
+	        this.orgName = source["orgName"];
+	        this.researcher = source["researcher"];
+	        this.publishDate = source["publishDate"];
+	        this.rating = source["rating"];
+	        this.lastRating = source["lastRating"];
+	        this.ratingChange = source["ratingChange"];
+	        this.targetPrice = source["targetPrice"];
+	        this.infoCode = source["infoCode"];
+	    }
+	}
+	export class AnalystConsensus {
+	    symbol: string;
+	    reports: AnalystRating[];
+	    ratingCounts: {[key: string]: number};
+	    orgCount: number;
+	    avgTargetPrice: number;
+	    highTargetPrice: number;
+	    lowTargetPrice: number;
+	    upgradeCount: number;
+	    downgradeCount: number;
+	    updatedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new AnalystConsensus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.symbol = source["symbol"];
+	        this.reports = this.convertValues(source["reports"], AnalystRating);
+	        this.ratingCounts = source["ratingCounts"];
+	        this.orgCount = source["orgCount"];
+	        this.avgTargetPrice = source["avgTargetPrice"];
+	        this.highTargetPrice = source["highTargetPrice"];
+	        this.lowTargetPrice = source["lowTargetPrice"];
+	        this.upgradeCount = source["upgradeCount"];
+	        this.downgradeCount = source["downgradeCount"];
+	        this.updatedAt = source["updatedAt"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class OrchestrationConfig {
+	    mode: string;
+	    maxRounds: number;
+	    earlyStop: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new OrchestrationConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.mode = source["mode"];
+	        this.maxRounds = source["maxRounds"];
+	        this.earlyStop = source["earlyStop"];
+	    }
+	}
+	export class AgentPersona {
+	    id: string;
+	    name: string;
+	    role: string;
+	    avatar: string;
+	    color: string;
+	    instruction: string;
+	    tools: string[];
+	    mcpServers: string[];
+	    enabled: boolean;
+	    aiConfigId: string;
+	    createdAt: number;
+	    updatedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new AgentPersona(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.role = source["role"];
+	        this.avatar = source["avatar"];
+	        this.color = source["color"];
+	        this.instruction = source["instruction"];
+	        this.tools = source["tools"];
+	        this.mcpServers = source["mcpServers"];
+	        this.enabled = source["enabled"];
+	        this.aiConfigId = source["aiConfigId"];
+	        this.createdAt = source["createdAt"];
+	        this.updatedAt = source["updatedAt"];
+	    }
+	}
+	export class DiscussionEvent {
+	    type: string;
+	    agentId: string;
+	    agentName: string;
+	    detail?: string;
+	    content?: string;
+	    timestamp: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DiscussionEvent(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.agentId = source["agentId"];
+	        this.agentName = source["agentName"];
+	        this.detail = source["detail"];
+	        this.content = source["content"];
+	        this.timestamp = source["timestamp"];
+	    }
+	}
+	export class Discussion {
+	    id: string;
+	    stockCode: string;
+	    stockName: string;
+	    query: string;
+	    orchestration?: OrchestrationConfig;
+	    topic?: string;
+	    selectedAgents: string[];
+	    tasks?: {[key: string]: string};
+	    status: string;
+	    error?: string;
+	    messages: ChatMessage[];
+	    events: DiscussionEvent[];
+	    resumeCount: number;
+	    createdAt: number;
+	    updatedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Discussion(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.stockCode = source["stockCode"];
+	        this.stockName = source["stockName"];
+	        this.query = source["query"];
+	        this.orchestration = this.convertValues(source["orchestration"], OrchestrationConfig);
+	        this.topic = source["topic"];
+	        this.selectedAgents = source["selectedAgents"];
+	        this.tasks = source["tasks"];
+	        this.status = source["status"];
+	        this.error = source["error"];
+	        this.messages = this.convertValues(source["messages"], ChatMessage);
+	        this.events = this.convertValues(source["events"], DiscussionEvent);
+	        this.resumeCount = source["resumeCount"];
+	        this.createdAt = source["createdAt"];
+	        this.updatedAt = source["updatedAt"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DiscussionSummary {
+	    id: string;
+	    stockCode: string;
+	    stockName: string;
+	    query: string;
+	    mode: string;
+	    status: string;
+	    rounds: number;
+	    messageCount: number;
+	    resumable: boolean;
+	    createdAt: number;
+	    updatedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DiscussionSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.stockCode = source["stockCode"];
+	        this.stockName = source["stockName"];
+	        this.query = source["query"];
+	        this.mode = source["mode"];
+	        this.status = source["status"];
+	        this.rounds = source["rounds"];
+	        this.messageCount = source["messageCount"];
+	        this.resumable = source["resumable"];
+	        this.createdAt = source["createdAt"];
+	        this.updatedAt = source["updatedAt"];
+	    }
+	}
+	export class BriefingStock {
+	    code: string;
+	    name: string;
+	    price: number;
+	    changePercent: number;
+	    amount: number;
+	    news: string[];
+	    watchPoints: string;
+	    session?: SessionHeatReport;
+	
+	    static createFrom(source: any = {}) {
+	        return new BriefingStock(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.code = source["code"];
+	        this.name = source["name"];
+	        this.price = source["price"];
+	        this.changePercent = source["changePercent"];
+	        this.amount = source["amount"];
+	        this.news = source["news"];
+	        this.watchPoints = source["watchPoints"];
+	        this.session = this.convertValues(source["session"], SessionHeatReport);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DailyBriefing {
+	    date: string;
+	    summary: string;
+	    stocks: BriefingStock[];
+	    nearTargets: TargetProximity[];
+	    path: string;
+	    generatedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DailyBriefing(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.summary = source["summary"];
+	        this.stocks = this.convertValues(source["stocks"], BriefingStock);
+	        this.nearTargets = this.convertValues(source["nearTargets"], TargetProximity);
+	        this.path = source["path"];
+	        this.generatedAt = source["generatedAt"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ToolAuditEntry {
+	    time: number;
+	    tool: string;
+	    agent: string;
+	    session?: string;
+	    args: string;
+	    result?: string;
+	    error?: string;
+	    status: string;
+	    duration: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ToolAuditEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.time = source["time"];
+	        this.tool = source["tool"];
+	        this.agent = source["agent"];
+	        this.session = source["session"];
+	        this.args = source["args"];
+	        this.result = source["result"];
+	        this.error = source["error"];
+	        this.status = source["status"];
+	        this.duration = source["duration"];
+	    }
+	}
+	export class ErrorInfo {
+	    op: string;
+	    code: string;
+	    message: string;
+	    hint: string;
+	    retryable: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ErrorInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.op = source["op"];
+	        this.code = source["code"];
+	        this.message = source["message"];
+	        this.hint = source["hint"];
+	        this.retryable = source["retryable"];
+	    }
+	}
+	export class KLineGap {
+	    code: string;
+	    date: string;
+	    kind: string;
+	    count: number;
+	    detail?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new KLineGap(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.code = source["code"];
+	        this.date = source["date"];
+	        this.kind = source["kind"];
+	        this.count = source["count"];
+	        this.detail = source["detail"];
+	    }
+	}
+	export class KLineIntegrityStatus {
+	    running: boolean;
+	    lastRun: number;
+	    scanned: number;
+	    repaired: number;
+	    gaps: KLineGap[];
+	
+	    static createFrom(source: any = {}) {
+	        return new KLineIntegrityStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.running = source["running"];
+	        this.lastRun = source["lastRun"];
+	        this.scanned = source["scanned"];
+	        this.repaired = source["repaired"];
+	        this.gaps = this.convertValues(source["gaps"], KLineGap);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ComparisonLine {
+	    code: string;
+	    values: number[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ComparisonLine(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.code = source["code"];
+	        this.values = source["values"];
+	    }
+	}
+	export class ComparisonSeries {
+	    period: string;
+	    range: string;
+	    baseTime: string;
+	    times: string[];
+	    lines: ComparisonLine[];
+	    failed?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ComparisonSeries(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.period = source["period"];
+	        this.range = source["range"];
+	        this.baseTime = source["baseTime"];
+	        this.times = source["times"];
+	        this.lines = this.convertValues(source["lines"], ComparisonLine);
+	        this.failed = source["failed"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class StockStats {
+	    code: string;
+	    price: number;
+	    high52w: number;
+	    high52wDate: string;
+	    low52w: number;
+	    low52wDate: string;
+	    fromHigh52w: number;
+	    fromLow52w: number;
+	    allTimeHigh: number;
+	    allTimeHighDate: string;
+	    fromAllTimeHigh: number;
+	    streak: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new StockStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.code = source["code"];
+	        this.price = source["price"];
+	        this.high52w = source["high52w"];
+	        this.high52wDate = source["high52wDate"];
+	        this.low52w = source["low52w"];
+	        this.low52wDate = source["low52wDate"];
+	        this.fromHigh52w = source["fromHigh52w"];
+	        this.fromLow52w = source["fromLow52w"];
+	        this.allTimeHigh = source["allTimeHigh"];
+	        this.allTimeHighDate = source["allTimeHighDate"];
+	        this.fromAllTimeHigh = source["fromAllTimeHigh"];
+	        this.streak = source["streak"];
+	    }
+	}
+	export class PositionSizeRequest {
+	    accountSize: number;
+	    riskPercent: number;
+	    entryPrice: number;
+	    stopPrice: number;
+	    maxPositionPercent?: number;
+	    lotSize?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PositionSizeRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.accountSize = source["accountSize"];
+	        this.riskPercent = source["riskPercent"];
+	        this.entryPrice = source["entryPrice"];
+	        this.stopPrice = source["stopPrice"];
+	        this.maxPositionPercent = source["maxPositionPercent"];
+	        this.lotSize = source["lotSize"];
+	    }
+	}
+	export class PositionSizeResult {
+	    shares: number;
+	    lots: number;
+	    positionValue: number;
+	    positionPercent: number;
+	    riskPerShare: number;
+	    riskAmount: number;
+	    riskPercent: number;
+	    limitedBy: string;
+	    warnings: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PositionSizeResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.shares = source["shares"];
+	        this.lots = source["lots"];
+	        this.positionValue = source["positionValue"];
+	        this.positionPercent = source["positionPercent"];
+	        this.riskPerShare = source["riskPerShare"];
+	        this.riskAmount = source["riskAmount"];
+	        this.riskPercent = source["riskPercent"];
+	        this.limitedBy = source["limitedBy"];
+	        this.warnings = source["warnings"];
+	    }
+	}
+	export class RiskLimits {
+	    maxPositionPercent: number;
+	    maxSectorPercent: number;
+	    maxExposurePercent: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RiskLimits(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.maxPositionPercent = source["maxPositionPercent"];
+	        this.maxSectorPercent = source["maxSectorPercent"];
+	        this.maxExposurePercent = source["maxExposurePercent"];
+	    }
+	}
+	export class HoldingRisk {
+	    symbol: string;
+	    name: string;
+	    shares: number;
+	    price: number;
+	    costPrice: number;
+	    marketValue: number;
+	    weight: number;
+	    pnl: number;
+	    pnlPercent: number;
+	    stopPrice?: number;
+	    riskToStop?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new HoldingRisk(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.symbol = source["symbol"];
+	        this.name = source["name"];
+	        this.shares = source["shares"];
+	        this.price = source["price"];
+	        this.costPrice = source["costPrice"];
+	        this.marketValue = source["marketValue"];
+	        this.weight = source["weight"];
+	        this.pnl = source["pnl"];
+	        this.pnlPercent = source["pnlPercent"];
+	        this.stopPrice = source["stopPrice"];
+	        this.riskToStop = source["riskToStop"];
+	    }
+	}
+	export class RiskWarning {
+	    kind: string;
+	    target: string;
+	    value: number;
+	    limit: number;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RiskWarning(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.target = source["target"];
+	        this.value = source["value"];
+	        this.limit = source["limit"];
+	        this.message = source["message"];
+	    }
+	}
+	export class PortfolioRisk {
+	    accountSize: number;
+	    marketValue: number;
+	    exposurePercent: number;
+	    cash: number;
+	    topWeight: number;
+	    hhi: number;
+	    riskToStop: number;
+	    holdings: HoldingRisk[];
+	    sectors: SectorExposure[];
+	    limits: RiskLimits;
+	    warnings: RiskWarning[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PortfolioRisk(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.accountSize = source["accountSize"];
+	        this.marketValue = source["marketValue"];
+	        this.exposurePercent = source["exposurePercent"];
+	        this.cash = source["cash"];
+	        this.topWeight = source["topWeight"];
+	        this.hhi = source["hhi"];
+	        this.riskToStop = source["riskToStop"];
+	        this.holdings = this.convertValues(source["holdings"], HoldingRisk);
+	        this.sectors = this.convertValues(source["sectors"], SectorExposure);
+	        this.limits = this.convertValues(source["limits"], RiskLimits);
+	        this.warnings = this.convertValues(source["warnings"], RiskWarning);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class JournalEntry {
+	    id: string;
+	    kind: string;
+	    symbol: string;
+	    name: string;
+	    date: string;
+	    side?: string;
+	    entryDate?: string;
+	    exitDate?: string;
+	    entryPrice?: number;
+	    exitPrice?: number;
+	    shares?: number;
+	    pnl?: number;
+	    outcome?: string;
+	    tags: string[];
+	    emotion?: string;
+	    notes?: string;
+	    createdAt: number;
+	    updatedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new JournalEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.kind = source["kind"];
+	        this.symbol = source["symbol"];
+	        this.name = source["name"];
+	        this.date = source["date"];
+	        this.side = source["side"];
+	        this.entryDate = source["entryDate"];
+	        this.exitDate = source["exitDate"];
+	        this.entryPrice = source["entryPrice"];
+	        this.exitPrice = source["exitPrice"];
+	        this.shares = source["shares"];
+	        this.pnl = source["pnl"];
+	        this.outcome = source["outcome"];
+	        this.tags = source["tags"];
+	        this.emotion = source["emotion"];
+	        this.notes = source["notes"];
+	        this.createdAt = source["createdAt"];
+	        this.updatedAt = source["updatedAt"];
+	    }
+	}
+	export class Notification {
+	    id: string;
+	    category: string;
+	    kind?: string;
+	    title: string;
+	    body: string;
+	    symbol?: string;
+	    read: boolean;
+	    silent: boolean;
+	    createdAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Notification(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.category = source["category"];
+	        this.kind = source["kind"];
+	        this.title = source["title"];
+	        this.body = source["body"];
+	        this.symbol = source["symbol"];
+	        this.read = source["read"];
+	        this.silent = source["silent"];
+	        this.createdAt = source["createdAt"];
+	    }
+	}
+	export class NotificationFilter {
+	    category: string;
+	    unreadOnly: boolean;
+	    limit: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new NotificationFilter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.category = source["category"];
+	        this.unreadOnly = source["unreadOnly"];
+	        this.limit = source["limit"];
+	    }
+	}
+	export class JournalFilter {
+	    symbol: string;
+	    tag: string;
+	    kind: string;
+	    startDate: string;
+	    endDate: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new JournalFilter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.symbol = source["symbol"];
+	        this.tag = source["tag"];
+	        this.kind = source["kind"];
+	        this.startDate = source["startDate"];
+	        this.endDate = source["endDate"];
+	    }
+	}
+	export class JournalGroupStats {
+	    key: string;
+	    trades: number;
+	    wins: number;
+	    losses: number;
+	    winRate: number;
+	    totalPnl: number;
+	    avgHoldingDays: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new JournalGroupStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.key = source["key"];
+	        this.trades = source["trades"];
+	        this.wins = source["wins"];
+	        this.losses = source["losses"];
+	        this.winRate = source["winRate"];
+	        this.totalPnl = source["totalPnl"];
+	        this.avgHoldingDays = source["avgHoldingDays"];
+	    }
+	}
+	export class JournalStats {
+	    entries: number;
+	    open: number;
+	    overall: JournalGroupStats;
+	    byTag: JournalGroupStats[];
+	    byEmotion: JournalGroupStats[];
+	
+	    static createFrom(source: any = {}) {
+	        return new JournalStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.entries = source["entries"];
+	        this.open = source["open"];
+	        this.overall = this.convertValues(source["overall"], JournalGroupStats);
+	        this.byTag = this.convertValues(source["byTag"], JournalGroupStats);
+	        this.byEmotion = this.convertValues(source["byEmotion"], JournalGroupStats);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class InterestRate {
+	    code: string;
+	    name: string;
+	    category: string;
+	    rate: number;
+	    changeBp: number;
+	    date: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new InterestRate(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.code = source["code"];
+	        this.name = source["name"];
+	        this.category = source["category"];
+	        this.rate = source["rate"];
+	        this.changeBp = source["changeBp"];
+	        this.date = source["date"];
+	    }
+	}
+	export class InterestRates {
+	    items: InterestRate[];
+	    updatedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new InterestRates(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.items = this.convertValues(source["items"], InterestRate);
+	        this.updatedAt = source["updatedAt"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class FuturesQuote {
+	    symbol: string;
+	    name: string;
+	    product: string;
+	    exchange: string;
+	    price: number;
+	    open: number;
+	    high: number;
+	    low: number;
+	    preClose: number;
+	    preSettle: number;
+	    change: number;
+	    changePercent: number;
+	    volume: number;
+	    openInterest: number;
+	    date: string;
+	    time: string;
+	    spotCode?: string;
+	    spotPrice?: number;
+	    basis?: number;
+	    basisPercent?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new FuturesQuote(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.symbol = source["symbol"];
+	        this.name = source["name"];
+	        this.product = source["product"];
+	        this.exchange = source["exchange"];
+	        this.price = source["price"];
+	        this.open = source["open"];
+	        this.high = source["high"];
+	        this.low = source["low"];
+	        this.preClose = source["preClose"];
+	        this.preSettle = source["preSettle"];
+	        this.change = source["change"];
+	        this.changePercent = source["changePercent"];
+	        this.volume = source["volume"];
+	        this.openInterest = source["openInterest"];
+	        this.date = source["date"];
+	        this.time = source["time"];
+	        this.spotCode = source["spotCode"];
+	        this.spotPrice = source["spotPrice"];
+	        this.basis = source["basis"];
+	        this.basisPercent = source["basisPercent"];
+	    }
+	}
+	export class OptionQuote {
+	    code: string;
 	    name: string;
+	    type: string;
+	    strike: number;
 	    price: number;
+	    bid: number;
+	    ask: number;
+	    preClose: number;
 	    change: number;
 	    changePercent: number;
 	    volume: number;
-	    amount: number;
-	    marketCap: string;
-	    sector: string;
-	    open: number;
-	    high: number;
-	    low: number;
-	    preClose: number;
+	    openInterest: number;
+	    iv: number;
+	    delta: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new Stock(source);
+	        return new OptionQuote(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.symbol = source["symbol"];
+	        this.code = source["code"];
 	        this.name = source["name"];
+	        this.type = source["type"];
+	        this.strike = source["strike"];
 	        this.price = source["price"];
+	        this.bid = source["bid"];
+	        this.ask = source["ask"];
+	        this.preClose = source["preClose"];
 	        this.change = source["change"];
 	        this.changePercent = source["changePercent"];
 	        this.volume = source["volume"];
-	        this.amount = source["amount"];
-	        this.marketCap = source["marketCap"];
-	        this.sector = source["sector"];
-	        this.open = source["open"];
-	        this.high = source["high"];
-	        this.low = source["low"];
-	        this.preClose = source["preClose"];
+	        this.openInterest = source["openInterest"];
+	        this.iv = source["iv"];
+	        this.delta = source["delta"];
 	    }
 	}
-	export class StockPosition {
-	    shares: number;
-	    costPrice: number;
+	export class OptionStrikeRow {
+	    strike: number;
+	    call?: OptionQuote;
+	    put?: OptionQuote;
 	
 	    static createFrom(source: any = {}) {
-	        return new StockPosition(source);
+	        return new OptionStrikeRow(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.shares = source["shares"];
-	        this.costPrice = source["costPrice"];
+	        this.strike = source["strike"];
+	        this.call = this.convertValues(source["call"], OptionQuote);
+	        this.put = this.convertValues(source["put"], OptionQuote);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class StockSession {
-	    id: string;
-	    stockCode: string;
-	    stockName: string;
-	    messages: ChatMessage[];
-	    position?: StockPosition;
-	    createdAt: number;
+	export class OptionChain {
+	    underlying: string;
+	    underlyingName: string;
+	    underlyingPrice: number;
+	    month: string;
+	    months: string[];
+	    expiry: string;
+	    daysToExpiry: number;
+	    riskFreeRate: number;
+	    rows: OptionStrikeRow[];
 	    updatedAt: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new StockSession(source);
+	        return new OptionChain(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.stockCode = source["stockCode"];
-	        this.stockName = source["stockName"];
-	        this.messages = this.convertValues(source["messages"], ChatMessage);
-	        this.position = this.convertValues(source["position"], StockPosition);
-	        this.createdAt = source["createdAt"];
+	        this.underlying = source["underlying"];
+	        this.underlyingName = source["underlyingName"];
+	        this.underlyingPrice = source["underlyingPrice"];
+	        this.month = source["month"];
+	        this.months = source["months"];
+	        this.expiry = source["expiry"];
+	        this.daysToExpiry = source["daysToExpiry"];
+	        this.riskFreeRate = source["riskFreeRate"];
+	        this.rows = this.convertValues(source["rows"], OptionStrikeRow);
 	        this.updatedAt = source["updatedAt"];
 	    }
 	
@@ -885,81 +4381,118 @@ export namespace models {
 		    return a;
 		}
 	}
-	export class StrategyAgent {
-	    id: string;
+	export class GlobalIndex {
+	    code: string;
 	    name: string;
-	    role: string;
-	    avatar: string;
-	    color: string;
-	    instruction: string;
-	    tools: string[];
-	    mcpServers: string[];
-	    enabled: boolean;
-	    aiConfigId: string;
+	    price: number;
+	    change: number;
+	    changePercent: number;
+	    volume: number;
+	    amount: number;
+	    region: string;
+	    market: string;
+	    status: string;
+	    localTime: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new StrategyAgent(source);
+	        return new GlobalIndex(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
+	        this.code = source["code"];
 	        this.name = source["name"];
-	        this.role = source["role"];
-	        this.avatar = source["avatar"];
-	        this.color = source["color"];
-	        this.instruction = source["instruction"];
-	        this.tools = source["tools"];
-	        this.mcpServers = source["mcpServers"];
-	        this.enabled = source["enabled"];
-	        this.aiConfigId = source["aiConfigId"];
+	        this.price = source["price"];
+	        this.change = source["change"];
+	        this.changePercent = source["changePercent"];
+	        this.volume = source["volume"];
+	        this.amount = source["amount"];
+	        this.region = source["region"];
+	        this.market = source["market"];
+	        this.status = source["status"];
+	        this.localTime = source["localTime"];
 	    }
 	}
-	export class Strategy {
-	    id: string;
-	    name: string;
-	    description: string;
-	    color: string;
-	    agents: StrategyAgent[];
-	    isBuiltin: boolean;
+
+}
+
+export namespace proxy {
+	
+	export class HostRateStats {
+	    host: string;
+	    rate: number;
+	    burst: number;
+	    tokens: number;
+	    requests: number;
+	    throttled: number;
+	    waitMillis: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new HostRateStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.host = source["host"];
+	        this.rate = source["rate"];
+	        this.burst = source["burst"];
+	        this.tokens = source["tokens"];
+	        this.requests = source["requests"];
+	        this.throttled = source["throttled"];
+	        this.waitMillis = source["waitMillis"];
+	    }
+	}
+	export class SystemProxy {
 	    source: string;
-	    sourceMeta: string;
-	    createdAt: number;
+	    http: string;
+	    https: string;
+	    socks: string;
+	    pacUrl: string;
+	    bypass: string[];
 	
 	    static createFrom(source: any = {}) {
-	        return new Strategy(source);
+	        return new SystemProxy(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.name = source["name"];
-	        this.description = source["description"];
-	        this.color = source["color"];
-	        this.agents = this.convertValues(source["agents"], StrategyAgent);
-	        this.isBuiltin = source["isBuiltin"];
 	        this.source = source["source"];
-	        this.sourceMeta = source["sourceMeta"];
-	        this.createdAt = source["createdAt"];
+	        this.http = source["http"];
+	        this.https = source["https"];
+	        this.socks = source["socks"];
+	        this.pacUrl = source["pacUrl"];
+	        this.bypass = source["bypass"];
 	    }
+	}
+	export class HostConnStats {
+	    host: string;
+	    requests: number;
+	    errors: number;
+	    active: number;
+	    newConns: number;
+	    reusedConns: number;
+	    reuseRate: number;
+	    avgLatencyMs: number;
+	    maxLatencyMs: number;
+	    avgConnectMs: number;
 	
-		convertValues(a: any, classs: any, asMap: boolean = false): any {
-		    if (!a) {
-		        return a;
-		    }
-		    if (a.slice && a.map) {
-		        return (a as any[]).map(elem => this.convertValues(elem, classs));
-		    } else if ("object" === typeof a) {
-		        if (asMap) {
-		            for (const key of Object.keys(a)) {
-		                a[key] = new classs(a[key]);
-		            }
-		            return a;
-		        }
-		        return new classs(a);
-		    }
-		    return a;
-		}
+	    static createFrom(source: any = {}) {
+	        return new HostConnStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.host = source["host"];
+	        this.requests = source["requests"];
+	        this.errors = source["errors"];
+	        this.active = source["active"];
+	        this.newConns = source["newConns"];
+	        this.reusedConns = source["reusedConns"];
+	        this.reuseRate = source["reuseRate"];
+	        this.avgLatencyMs = source["avgLatencyMs"];
+	        this.maxLatencyMs = source["maxLatencyMs"];
+	        this.avgConnectMs = source["avgConnectMs"];
+	    }
 	}
 
 }
@@ -998,6 +4531,22 @@ export namespace services {
 		    return a;
 		}
 	}
+	export class MarketIndexOption {
+	    code: string;
+	    name: string;
+	    region: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MarketIndexOption(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.code = source["code"];
+	        this.name = source["name"];
+	        this.region = source["region"];
+	    }
+	}
 	export class StockSearchResult {
 	    symbol: string;
 	    name: string;
@@ -1020,6 +4569,9 @@ export namespace services {
 	    time: string;
 	    content: string;
 	    url: string;
+	    source: string;
+	    timestamp: number;
+	    keywords?: string[];
 	
 	    static createFrom(source: any = {}) {
 	        return new Telegraph(source);
@@ -1030,6 +4582,9 @@ export namespace services {
 	        this.time = source["time"];
 	        this.content = source["content"];
 	        this.url = source["url"];
+	        this.source = source["source"];
+	        this.timestamp = source["timestamp"];
+	        this.keywords = source["keywords"];
 	    }
 	}
 	export class TradingPeriod {
@@ -1053,6 +4608,7 @@ export namespace services {
 	export class TradingSchedule {
 	    isTradeDay: boolean;
 	    holidayName: string;
+	    closedText: string;
 	    periods: TradingPeriod[];
 	
 	    static createFrom(source: any = {}) {
@@ -1063,6 +4619,7 @@ export namespace services {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.isTradeDay = source["isTradeDay"];
 	        this.holidayName = source["holidayName"];
+	        this.closedText = source["closedText"];
 	        this.periods = this.convertValues(source["periods"], TradingPeriod);
 	    }
 	
@@ -1090,6 +4647,8 @@ export namespace services {
 	    currentVersion: string;
 	    releaseUrl: string;
 	    releaseNotes: string;
+	    publishedAt?: number;
+	    downloaded?: boolean;
 	    error?: string;
 	
 	    static createFrom(source: any = {}) {
@@ -1103,9 +4662,161 @@ export namespace services {
 	        this.currentVersion = source["currentVersion"];
 	        this.releaseUrl = source["releaseUrl"];
 	        this.releaseNotes = source["releaseNotes"];
+	        this.publishedAt = source["publishedAt"];
+	        this.downloaded = source["downloaded"];
 	        this.error = source["error"];
 	    }
 	}
+	export class SourceHealth {
+	    source: string;
+	    state: string;
+	    requests: number;
+	    failures: number;
+	    errorRate: number;
+	    avgLatencyMs: number;
+	    lastLatencyMs: number;
+	    consecutiveFails: number;
+	    lastError?: string;
+	    lastErrorAt?: number;
+	    lastSuccessAt?: number;
+	    openUntil?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SourceHealth(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.source = source["source"];
+	        this.state = source["state"];
+	        this.requests = source["requests"];
+	        this.failures = source["failures"];
+	        this.errorRate = source["errorRate"];
+	        this.avgLatencyMs = source["avgLatencyMs"];
+	        this.lastLatencyMs = source["lastLatencyMs"];
+	        this.consecutiveFails = source["consecutiveFails"];
+	        this.lastError = source["lastError"];
+	        this.lastErrorAt = source["lastErrorAt"];
+	        this.lastSuccessAt = source["lastSuccessAt"];
+	        this.openUntil = source["openUntil"];
+	    }
+	}
+	export class MarketStatus {
+	    status: string;
+	    statusText: string;
+	    isTradeDay: boolean;
+	    holidayName: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MarketStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.status = source["status"];
+	        this.statusText = source["statusText"];
+	        this.isTradeDay = source["isTradeDay"];
+	        this.holidayName = source["holidayName"];
+	    }
+	}
+	export class WindowSubscription {
+	    id: string;
+	    kind: string;
+	    code: string;
+	    name?: string;
+	    period?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new WindowSubscription(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.kind = source["kind"];
+	        this.code = source["code"];
+	        this.name = source["name"];
+	        this.period = source["period"];
+	    }
+	}
+}
+
+export namespace supervisor {
+	
+	export class GoroutineGroup {
+	    function: string;
+	    count: number;
+	    growth: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new GoroutineGroup(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.function = source["function"];
+	        this.count = source["count"];
+	        this.growth = source["growth"];
+	    }
+	}
+	export class Status {
+	    name: string;
+	    state: string;
+	    restarts: number;
+	    lastError?: string;
+	    startedAt: number;
+	    lastPanicAt?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Status(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.state = source["state"];
+	        this.restarts = source["restarts"];
+	        this.lastError = source["lastError"];
+	        this.startedAt = source["startedAt"];
+	        this.lastPanicAt = source["lastPanicAt"];
+	    }
+	}
+	export class Report {
+	    total: number;
+	    tasks: Status[];
+	    groups: GoroutineGroup[];
+	    leakSuspect: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Report(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.total = source["total"];
+	        this.tasks = this.convertValues(source["tasks"], Status);
+	        this.groups = this.convertValues(source["groups"], GoroutineGroup);
+	        this.leakSuspect = source["leakSuspect"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 
 }
 
@@ -104,11 +104,61 @@ func toAnthropicRequest(req *model.LLMRequest, modelName string, noSystemRole bo
 		if len(req.Config.StopSequences) > 0 {
 			ar.StopSequences = req.Config.StopSequences
 		}
+		applyThinking(ar, req.Config.ThinkingConfig)
 	}
 
 	return ar, nil
 }
 
+// 扩展思考预算：Anthropic 最小预算为 1024，未指定预算时按思考等级映射
+const (
+	minThinkingBudget     = 1024
+	defaultThinkingBudget = 8192
+	thinkingAnswerReserve = 4096 // 开启思考后为正文预留的输出 token
+)
+
+// thinkingLevelBudgets 思考等级对应的预算
+var thinkingLevelBudgets = map[genai.ThinkingLevel]int{
+	genai.ThinkingLevelMinimal: minThinkingBudget,
+	genai.ThinkingLevelLow:     4096,
+	genai.ThinkingLevelMedium:  defaultThinkingBudget,
+	genai.ThinkingLevelHigh:    16384,
+}
+
+// thinkingBudget 将 genai ThinkingConfig 换算为 budget_tokens，0 表示不开启
+// ThinkingBudget 优先：0 关闭，-1（动态）使用默认预算，>0 按原值并不低于最小预算
+func thinkingBudget(tc *genai.ThinkingConfig) int {
+	if tc == nil {
+		return 0
+	}
+	if tc.ThinkingBudget != nil {
+		switch b := int(*tc.ThinkingBudget); {
+		case b == 0:
+			return 0
+		case b < 0:
+			return defaultThinkingBudget
+		default:
+			return max(b, minThinkingBudget)
+		}
+	}
+	return thinkingLevelBudgets[tc.ThinkingLevel]
+}
+
+// applyThinking 写入扩展思考参数
+// Anthropic 要求 budget_tokens < max_tokens，且开启思考时不能修改 temperature / top_p
+func applyThinking(ar *MessagesRequest, tc *genai.ThinkingConfig) {
+	budget := thinkingBudget(tc)
+	if budget == 0 {
+		return
+	}
+	ar.Thinking = &ThinkingParam{Type: "enabled", BudgetTokens: budget}
+	if ar.MaxTokens <= budget {
+		ar.MaxTokens = budget + thinkingAnswerReserve
+	}
+	ar.Temperature = nil
+	ar.TopP = nil
+}
+
 // toAnthropicMessages 将 genai.Content 列表转换为 Anthropic messages
 func toAnthropicMessages(contents []*genai.Content) ([]Message, error) {
 	var msgs []Message
@@ -126,8 +176,11 @@ func toAnthropicMessages(contents []*genai.Content) ([]Message, error) {
 		var blocks []ContentBlock
 
 		for _, part := range content.Parts {
-			// 跳过 thought parts（不回传给 API）
+			// 带签名的思考内容原样回传（开启思考的工具调用轮次要求携带），其余 thought parts 跳过
 			if part.Thought {
+				if block, ok := thinkingBlock(part); ok {
+					blocks = append(blocks, block)
+				}
 				continue
 			}
 
@@ -182,6 +235,33 @@ func toAnthropicMessages(contents []*genai.Content) ([]Message, error) {
 	return msgs, nil
 }
 
+// thinkingBlock 将带签名的 thought part 还原为 thinking / redacted_thinking 块
+// 无文本的 thought part 对应 redacted_thinking，签名中保存的是加密数据
+func thinkingBlock(part *genai.Part) (ContentBlock, bool) {
+	if len(part.ThoughtSignature) == 0 {
+		return ContentBlock{}, false
+	}
+	if part.Text == "" {
+		return ContentBlock{Type: "redacted_thinking", Data: string(part.ThoughtSignature)}, true
+	}
+	return ContentBlock{Type: "thinking", Thinking: part.Text, Signature: string(part.ThoughtSignature)}, true
+}
+
+// thoughtPart 将 thinking / redacted_thinking 块转换为 thought part，签名或加密数据保存在 ThoughtSignature
+func thoughtPart(blockType, thinking, signature, data string) *genai.Part {
+	switch {
+	case blockType == "redacted_thinking" && data != "":
+		return &genai.Part{Thought: true, ThoughtSignature: []byte(data)}
+	case blockType == "thinking" && thinking != "":
+		part := &genai.Part{Text: thinking, Thought: true}
+		if signature != "" {
+			part.ThoughtSignature = []byte(signature)
+		}
+		return part
+	}
+	return nil
+}
+
 // convertTools 将 genai.Tool 转换为 Anthropic Tool
 func convertTools(genaiTools []*genai.Tool) ([]Tool, error) {
 	var tools []Tool
@@ -291,9 +371,9 @@ func convertAnthropicResponse(resp *MessagesResponse) (*model.LLMResponse, error
 			if block.Text != "" {
 				content.Parts = append(content.Parts, &genai.Part{Text: block.Text})
 			}
-		case "thinking":
-			if block.Thinking != "" {
-				content.Parts = append(content.Parts, &genai.Part{Text: block.Thinking, Thought: true})
+		case "thinking", "redacted_thinking":
+			if part := thoughtPart(block.Type, block.Thinking, block.Signature, block.Data); part != nil {
+				content.Parts = append(content.Parts, part)
 			}
 		case "tool_use":
 			args := make(map[string]any)
@@ -333,14 +413,16 @@ func convertUsage(u *Usage) *genai.GenerateContentResponseUsageMetadata {
 }
 
 // convertStopReason 转换停止原因
+// tool_use 与 Gemini 函数调用响应保持一致映射为 STOP：本轮模型输出已完整（TurnComplete），
+// 由 ADK 执行响应中的 FunctionCall 后发起下一轮请求
 func convertStopReason(reason string) genai.FinishReason {
 	switch reason {
-	case "end_turn", "stop_sequence":
+	case "end_turn", "stop_sequence", "tool_use":
 		return genai.FinishReasonStop
 	case "max_tokens":
 		return genai.FinishReasonMaxTokens
-	case "tool_use":
-		return genai.FinishReasonStop
+	case "refusal":
+		return genai.FinishReasonSafety
 	default:
 		return genai.FinishReasonUnspecified
 	}
@@ -14,7 +14,9 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/run-bigpig/jcp/internal/adk/tokencount"
 	"github.com/run-bigpig/jcp/internal/logger"
+	"github.com/run-bigpig/jcp/internal/pkg/apperr"
 	"google.golang.org/adk/model"
 	"google.golang.org/genai"
 )
@@ -57,6 +59,7 @@ func (m *AnthropicModel) Name() string {
 
 // GenerateContent 实现 model.LLM 接口
 func (m *AnthropicModel) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	req = tokencount.Fit(req, m.modelName)
 	if stream {
 		return m.generateStream(ctx, req)
 	}
@@ -94,7 +97,7 @@ func (m *AnthropicModel) doRequest(ctx context.Context, ar *MessagesRequest) (*h
 		body, _ := io.ReadAll(io.LimitReader(resp.Body, 2048))
 		resp.Body.Close()
 		modelLog.Error("API 响应异常: status=%d, body=%s", resp.StatusCode, string(body))
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return nil, apperr.FromHTTPStatus(resp.StatusCode, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body)))
 	}
 
 	return resp, nil
@@ -167,7 +170,10 @@ type blockState struct {
 	toolName  string
 	text      string
 	thinking  string
-	toolArgs  string
+	signature string // thinking 签名 / redacted_thinking 加密数据
+	toolArgs  string // input_json_delta 分片拼接
+	// content_block_start 中携带的完整参数（无增量时使用）
+	initialArgs string
 }
 
 // processStream 处理 SSE 事件流
@@ -199,7 +205,14 @@ func (m *AnthropicModel) processStream(body io.Reader, yield func(*model.LLMResp
 			continue
 		}
 
-		if err := m.handleSSEEvent(eventType, []byte(data), blocks, &stopReason, &usage, yield); err != nil {
+		// 部分兼容接口不发送 event 行，从数据中的 type 字段推断事件类型
+		if eventType == "" {
+			eventType = sseEventType([]byte(data))
+		}
+
+		err := m.handleSSEEvent(eventType, []byte(data), blocks, &stopReason, &usage, yield)
+		eventType = ""
+		if err != nil {
 			if errors.Is(err, errStopIteration) {
 				return
 			}
@@ -221,6 +234,17 @@ func (m *AnthropicModel) processStream(body io.Reader, yield func(*model.LLMResp
 
 var errStopIteration = errors.New("stop iteration")
 
+// sseEventType 从 SSE 数据的 type 字段读取事件类型
+func sseEventType(data []byte) string {
+	var ev struct {
+		Type string `json:"type"`
+	}
+	if json.Unmarshal(data, &ev) != nil {
+		return ""
+	}
+	return ev.Type
+}
+
 // handleSSEEvent 处理单个 SSE 事件
 func (m *AnthropicModel) handleSSEEvent(
 	eventType string, data []byte,
@@ -243,9 +267,21 @@ func (m *AnthropicModel) handleSSEEvent(
 			return nil
 		}
 		bs := &blockState{blockType: ev.ContentBlock.Type}
-		if ev.ContentBlock.Type == "tool_use" {
+		switch ev.ContentBlock.Type {
+		case "tool_use":
 			bs.toolID = ev.ContentBlock.ID
 			bs.toolName = ev.ContentBlock.Name
+			// 通常为 {}，参数随后以 input_json_delta 分片到达；少数兼容接口直接在此给出完整参数
+			if input := string(ev.ContentBlock.Input); input != "" && input != "{}" {
+				bs.initialArgs = input
+			}
+		case "text":
+			bs.text = ev.ContentBlock.Text
+		case "thinking":
+			bs.thinking = ev.ContentBlock.Thinking
+			bs.signature = ev.ContentBlock.Signature
+		case "redacted_thinking":
+			bs.signature = ev.ContentBlock.Data
 		}
 		blocks[ev.Index] = bs
 
@@ -322,6 +358,9 @@ func (m *AnthropicModel) handleDelta(
 			return errStopIteration
 		}
 
+	case "signature_delta":
+		bs.signature += ev.Delta.Signature
+
 	case "input_json_delta":
 		bs.toolArgs += ev.Delta.PartialJSON
 	}
@@ -347,10 +386,12 @@ func (m *AnthropicModel) emitFinalResponse(
 
 		switch bs.blockType {
 		case "thinking":
-			if bs.thinking != "" {
-				aggregated.Parts = append(aggregated.Parts, &genai.Part{
-					Text: bs.thinking, Thought: true,
-				})
+			if part := thoughtPart(bs.blockType, bs.thinking, bs.signature, ""); part != nil {
+				aggregated.Parts = append(aggregated.Parts, part)
+			}
+		case "redacted_thinking":
+			if part := thoughtPart(bs.blockType, "", "", bs.signature); part != nil {
+				aggregated.Parts = append(aggregated.Parts, part)
 			}
 		case "text":
 			if bs.text != "" {
@@ -359,10 +400,16 @@ func (m *AnthropicModel) emitFinalResponse(
 				})
 			}
 		case "tool_use":
+			raw := bs.toolArgs
+			if raw == "" {
+				raw = bs.initialArgs
+			}
 			args := make(map[string]any)
-			if bs.toolArgs != "" {
-				if err := json.Unmarshal([]byte(bs.toolArgs), &args); err != nil {
-					modelLog.Warn("解析 tool_use args 失败: %v", err)
+			if raw != "" {
+				if err := json.Unmarshal([]byte(raw), &args); err != nil {
+					// 参数被截断（如 max_tokens）时不能执行，丢弃该调用
+					modelLog.Warn("解析 tool_use args 失败，丢弃调用 %s: %v", bs.toolName, err)
+					continue
 				}
 			}
 			aggregated.Parts = append(aggregated.Parts, &genai.Part{
@@ -210,6 +210,75 @@ func TestConvertAnthropicResponse_TextAndToolUse(t *testing.T) {
 	}
 }
 
+func TestToAnthropicRequest_Thinking(t *testing.T) {
+	budget := func(v int32) *int32 { return &v }
+	temp := float32(0.3)
+	tests := []struct {
+		name       string
+		tc         *genai.ThinkingConfig
+		wantBudget int
+		wantMax    int
+	}{
+		{"nil", nil, 0, 2048},
+		{"disabled", &genai.ThinkingConfig{ThinkingBudget: budget(0)}, 0, 2048},
+		{"fixed below min", &genai.ThinkingConfig{ThinkingBudget: budget(500)}, 1024, 2048},
+		{"dynamic", &genai.ThinkingConfig{ThinkingBudget: budget(-1)}, 8192, 8192 + 4096},
+		{"level high", &genai.ThinkingConfig{ThinkingLevel: genai.ThinkingLevelHigh}, 16384, 16384 + 4096},
+	}
+	for _, tt := range tests {
+		req := &model.LLMRequest{
+			Contents: []*genai.Content{{Role: "user", Parts: []*genai.Part{{Text: "hi"}}}},
+			Config:   &genai.GenerateContentConfig{MaxOutputTokens: 2048, Temperature: &temp, ThinkingConfig: tt.tc},
+		}
+		ar, err := toAnthropicRequest(req, "claude", false)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if tt.wantBudget == 0 {
+			if ar.Thinking != nil || ar.Temperature == nil {
+				t.Errorf("%s: thinking should be off, got %+v", tt.name, ar.Thinking)
+			}
+			continue
+		}
+		if ar.Thinking == nil || ar.Thinking.BudgetTokens != tt.wantBudget || ar.MaxTokens != tt.wantMax || ar.Temperature != nil {
+			t.Errorf("%s: got thinking=%+v max=%d temp=%v", tt.name, ar.Thinking, ar.MaxTokens, ar.Temperature)
+		}
+	}
+}
+
+func TestThinkingBlocks_RoundTrip(t *testing.T) {
+	resp, err := convertAnthropicResponse(&MessagesResponse{Content: []ContentBlock{
+		{Type: "thinking", Thinking: "先查行情", Signature: "sig1"},
+		{Type: "redacted_thinking", Data: "enc"},
+		{Type: "tool_use", ID: "tu_1", Name: "get_news", Input: json.RawMessage(`{}`)},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	parts := resp.Content.Parts
+	if len(parts) != 3 || !parts[0].Thought || string(parts[0].ThoughtSignature) != "sig1" || !parts[1].Thought || string(parts[1].ThoughtSignature) != "enc" {
+		t.Fatalf("parts unexpected: %+v", parts)
+	}
+
+	// 回传时还原为 thinking / redacted_thinking，无签名的思考内容被丢弃
+	contents := []*genai.Content{
+		{Role: "user", Parts: []*genai.Part{{Text: "hi"}}},
+		{Role: "model", Parts: append([]*genai.Part{{Text: "无签名", Thought: true}}, parts...)},
+	}
+	msgs, err := toAnthropicMessages(contents)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	blocks := msgs[1].Content
+	if len(blocks) != 3 || blocks[0].Type != "thinking" || blocks[0].Signature != "sig1" || blocks[1].Type != "redacted_thinking" || blocks[1].Data != "enc" {
+		t.Fatalf("blocks unexpected: %+v", blocks)
+	}
+	b, _ := json.Marshal(blocks[1])
+	if string(b) != `{"type":"redacted_thinking","data":"enc"}` {
+		t.Errorf("redacted_thinking json = %s", b)
+	}
+}
+
 func TestConvertStopReason(t *testing.T) {
 	tests := []struct {
 		reason string
@@ -219,6 +288,7 @@ func TestConvertStopReason(t *testing.T) {
 		{"max_tokens", genai.FinishReasonMaxTokens},
 		{"tool_use", genai.FinishReasonStop},
 		{"stop_sequence", genai.FinishReasonStop},
+		{"refusal", genai.FinishReasonSafety},
 		{"unknown", genai.FinishReasonUnspecified},
 	}
 	for _, tt := range tests {
@@ -229,6 +299,57 @@ func TestConvertStopReason(t *testing.T) {
 	}
 }
 
+func TestProcessStream_ToolUseAggregation(t *testing.T) {
+	// 不带 event 行的兼容接口：参数分片到达，第二个调用被 max_tokens 截断
+	stream := strings.Join([]string{
+		`data: {"type":"message_start","message":{"usage":{"input_tokens":12,"output_tokens":1}}}`,
+		`data: {"type":"content_block_start","index":0,"content_block":{"type":"thinking","thinking":""}}`,
+		`data: {"type":"content_block_delta","index":0,"delta":{"type":"thinking_delta","thinking":"查行情"}}`,
+		`data: {"type":"content_block_start","index":1,"content_block":{"type":"text","text":""}}`,
+		`data: {"type":"content_block_delta","index":1,"delta":{"type":"text_delta","text":"稍等"}}`,
+		`data: {"type":"content_block_start","index":2,"content_block":{"type":"tool_use","id":"tu_1","name":"get_stock_realtime","input":{}}}`,
+		`data: {"type":"content_block_delta","index":2,"delta":{"type":"input_json_delta","partial_json":"{\"codes\":"}}`,
+		`data: {"type":"content_block_delta","index":2,"delta":{"type":"input_json_delta","partial_json":"\"sh600519\"}"}}`,
+		`data: {"type":"content_block_start","index":3,"content_block":{"type":"tool_use","id":"tu_2","name":"get_news","input":{}}}`,
+		`data: {"type":"content_block_delta","index":3,"delta":{"type":"input_json_delta","partial_json":"{\"lim"}}`,
+		`data: {"type":"message_delta","delta":{"stop_reason":"tool_use"},"usage":{"input_tokens":12,"output_tokens":30}}`,
+		`data: {"type":"message_stop"}`,
+	}, "\n\n")
+
+	m := &AnthropicModel{}
+	var partials []*genai.Part
+	var final *model.LLMResponse
+	m.processStream(strings.NewReader(stream), func(resp *model.LLMResponse, err error) bool {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Partial {
+			partials = append(partials, resp.Content.Parts...)
+		} else {
+			final = resp
+		}
+		return true
+	})
+
+	if len(partials) != 2 || !partials[0].Thought || partials[1].Text != "稍等" {
+		t.Fatalf("partials unexpected: %+v", partials)
+	}
+	if final == nil || !final.TurnComplete || final.FinishReason != genai.FinishReasonStop {
+		t.Fatalf("final unexpected: %+v", final)
+	}
+	parts := final.Content.Parts
+	if len(parts) != 3 {
+		t.Fatalf("got %d parts, want 3 (thinking, text, one complete tool call)", len(parts))
+	}
+	fc := parts[2].FunctionCall
+	if fc == nil || fc.ID != "tu_1" || fc.Args["codes"] != "sh600519" {
+		t.Errorf("function call unexpected: %+v", fc)
+	}
+	if final.UsageMetadata.CandidatesTokenCount != 30 {
+		t.Errorf("usage unexpected: %+v", final.UsageMetadata)
+	}
+}
+
 func TestToAnthropicMessages_MergeConsecutiveRoles(t *testing.T) {
 	// Anthropic 要求 user/assistant 交替，相同 role 应合并
 	contents := []*genai.Content{
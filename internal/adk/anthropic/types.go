@@ -13,6 +13,13 @@ type MessagesRequest struct {
 	Stream      bool      `json:"stream,omitempty"`
 	Tools       []Tool    `json:"tools,omitempty"`
 	StopSequences []string `json:"stop_sequences,omitempty"`
+	Thinking    *ThinkingParam `json:"thinking,omitempty"`
+}
+
+// ThinkingParam 扩展思考参数
+type ThinkingParam struct {
+	Type         string `json:"type"` // enabled
+	BudgetTokens int    `json:"budget_tokens"`
 }
 
 // Message 消息
@@ -24,13 +31,17 @@ type Message struct {
 // ContentBlock 内容块（多态）
 // 使用自定义 MarshalJSON 按 Type 输出不同字段，避免序列化冲突
 type ContentBlock struct {
-	Type string `json:"type"` // text / image / tool_use / tool_result / thinking
+	Type string `json:"type"` // text / image / tool_use / tool_result / thinking / redacted_thinking
 
 	// text
 	Text string `json:"text,omitempty"`
 
 	// thinking
-	Thinking string `json:"thinking,omitempty"`
+	Thinking  string `json:"thinking,omitempty"`
+	Signature string `json:"signature,omitempty"`
+
+	// redacted_thinking（加密的思考内容）
+	Data string `json:"data,omitempty"`
 
 	// tool_use
 	ID    string          `json:"id,omitempty"`
@@ -53,9 +64,15 @@ func (b ContentBlock) MarshalJSON() ([]byte, error) {
 		}{b.Type, b.Text})
 	case "thinking":
 		return json.Marshal(struct {
-			Type     string `json:"type"`
-			Thinking string `json:"thinking"`
-		}{b.Type, b.Thinking})
+			Type      string `json:"type"`
+			Thinking  string `json:"thinking"`
+			Signature string `json:"signature,omitempty"`
+		}{b.Type, b.Thinking, b.Signature})
+	case "redacted_thinking":
+		return json.Marshal(struct {
+			Type string `json:"type"`
+			Data string `json:"data"`
+		}{b.Type, b.Data})
 	case "tool_use":
 		return json.Marshal(struct {
 			Type  string          `json:"type"`
@@ -128,9 +145,10 @@ type SSEContentBlockDelta struct {
 
 // Delta 增量内容
 type Delta struct {
-	Type     string          `json:"type"` // text_delta / input_json_delta / thinking_delta
+	Type     string          `json:"type"` // text_delta / input_json_delta / thinking_delta / signature_delta
 	Text     string          `json:"text,omitempty"`
 	Thinking string          `json:"thinking,omitempty"`
+	Signature string         `json:"signature,omitempty"`
 	PartialJSON string       `json:"partial_json,omitempty"`
 }
 
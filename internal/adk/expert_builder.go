@@ -46,14 +46,14 @@ func (b *ExpertAgentBuilder) BuildAgentWithContext(config *models.AgentConfig, s
 	// 获取 Agent 配置的工具
 	var agentTools []tool.Tool
 	if b.toolRegistry != nil && len(config.Tools) > 0 {
-		agentTools = b.toolRegistry.GetTools(config.Tools)
+		agentTools = GetToolSandbox().WrapTools(b.toolRegistry.GetTools(config.Tools))
 	}
 
 	// 获取 MCP toolsets
 	var toolsets []tool.Toolset
 	if b.mcpManager != nil && len(config.MCPServers) > 0 {
 		log.Info("Agent %s 请求 MCP servers: %v", config.ID, config.MCPServers)
-		toolsets = b.mcpManager.GetToolsetsByIDs(config.MCPServers)
+		toolsets = GetToolSandbox().WrapToolsets(b.mcpManager.GetToolsetsByIDs(config.MCPServers))
 		log.Info("Agent %s 获取到 %d 个 toolsets", config.ID, len(toolsets))
 		// 打印每个 toolset 的名称
 		for i, ts := range toolsets {
@@ -231,7 +231,7 @@ func (b *ExpertAgentBuilder) isSearchTool(name, description string, keywords []s
 
 // isDataTool 判断是否为数据查询工具
 func (b *ExpertAgentBuilder) isDataTool(name string) bool {
-	dataKeywords := []string{"kline", "k线", "realtime", "实时", "orderbook", "盘口", "news", "新闻"}
+	dataKeywords := []string{"kline", "k线", "realtime", "实时", "orderbook", "盘口", "money_flow", "资金", "news", "新闻"}
 	nameLower := strings.ToLower(name)
 	for _, kw := range dataKeywords {
 		if strings.Contains(nameLower, kw) {
@@ -263,7 +263,7 @@ func (b *ExpertAgentBuilder) formatToolsInstruction(searchTools, dataTools, othe
 		for _, t := range dataTools {
 			result.WriteString(t + "\n")
 		}
-		result.WriteString("\n")
+		result.WriteString("\n**注意**: 提示中的股票价格为会话开始时的快照，引用价格、K线、盘口或资金流向前应调用数据工具获取最新数据。\n\n")
 	}
 
 	// 其他工具
@@ -0,0 +1,95 @@
+// Package gemini 基于 genai SDK 的 Gemini / Vertex AI 原生模型
+// 在 adk 内置 Gemini 模型之上注入原生能力：思考预算、安全过滤阈值与 Google 搜索 grounding
+package gemini
+
+import (
+	"context"
+	"iter"
+
+	"google.golang.org/adk/model"
+	adkgemini "google.golang.org/adk/model/gemini"
+	"google.golang.org/genai"
+)
+
+// Options Gemini 原生能力配置
+type Options struct {
+	// ThinkingBudget 思考预算 token：nil 使用模型默认，0 关闭思考，-1 动态，>0 固定预算
+	ThinkingBudget *int
+	// SafetyThreshold 安全过滤阈值（如 BLOCK_NONE、BLOCK_ONLY_HIGH），应用于常见危害类别，为空使用默认
+	SafetyThreshold string
+	// GoogleSearch 启用 Google 搜索 grounding
+	GoogleSearch bool
+}
+
+// safetyCategories 安全阈值作用的危害类别
+var safetyCategories = []genai.HarmCategory{
+	genai.HarmCategoryHarassment,
+	genai.HarmCategoryHateSpeech,
+	genai.HarmCategorySexuallyExplicit,
+	genai.HarmCategoryDangerousContent,
+}
+
+// 确保实现 model.LLM 接口
+var _ model.LLM = &Model{}
+
+// Model Gemini 原生模型
+type Model struct {
+	model.LLM
+	opts Options
+}
+
+// NewModel 创建 Gemini 原生模型，clientConfig 决定使用 Gemini API 还是 Vertex AI
+func NewModel(ctx context.Context, modelName string, clientConfig *genai.ClientConfig, opts Options) (*Model, error) {
+	llm, err := adkgemini.NewModel(ctx, modelName, clientConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &Model{LLM: llm, opts: opts}, nil
+}
+
+// GenerateContent 注入原生配置后调用 genai
+func (m *Model) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	m.applyOptions(req)
+	return m.LLM.GenerateContent(ctx, req, stream)
+}
+
+// applyOptions 将原生能力写入请求配置（复制配置，不修改调用方共享的对象）
+func (m *Model) applyOptions(req *model.LLMRequest) {
+	cfg := &genai.GenerateContentConfig{}
+	if req.Config != nil {
+		copied := *req.Config
+		cfg = &copied
+	}
+
+	if b := m.opts.ThinkingBudget; b != nil {
+		budget := int32(*b)
+		cfg.ThinkingConfig = &genai.ThinkingConfig{
+			ThinkingBudget:  &budget,
+			IncludeThoughts: budget != 0, // 开启思考时返回思考内容，供界面展示
+		}
+	}
+
+	if m.opts.SafetyThreshold != "" && len(cfg.SafetySettings) == 0 {
+		threshold := genai.HarmBlockThreshold(m.opts.SafetyThreshold)
+		for _, category := range safetyCategories {
+			cfg.SafetySettings = append(cfg.SafetySettings, &genai.SafetySetting{Category: category, Threshold: threshold})
+		}
+	}
+
+	// Gemini 不支持在同一请求中同时使用内置搜索与函数调用，有函数工具时跳过 grounding
+	if m.opts.GoogleSearch && !hasFunctionTools(cfg.Tools) {
+		cfg.Tools = append(append([]*genai.Tool{}, cfg.Tools...), &genai.Tool{GoogleSearch: &genai.GoogleSearch{}})
+	}
+
+	req.Config = cfg
+}
+
+// hasFunctionTools 是否包含函数声明
+func hasFunctionTools(tools []*genai.Tool) bool {
+	for _, t := range tools {
+		if t != nil && len(t.FunctionDeclarations) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package gemini
+
+import (
+	"testing"
+
+	"google.golang.org/adk/model"
+	"google.golang.org/genai"
+)
+
+func TestApplyOptions(t *testing.T) {
+	budget := 1024
+	m := &Model{opts: Options{ThinkingBudget: &budget, SafetyThreshold: "BLOCK_ONLY_HIGH", GoogleSearch: true}}
+
+	shared := &genai.GenerateContentConfig{MaxOutputTokens: 100}
+	req := &model.LLMRequest{Config: shared}
+	m.applyOptions(req)
+
+	cfg := req.Config
+	if cfg == shared || shared.ThinkingConfig != nil {
+		t.Error("caller config should not be modified")
+	}
+	if cfg.MaxOutputTokens != 100 || cfg.ThinkingConfig == nil || *cfg.ThinkingConfig.ThinkingBudget != 1024 || !cfg.ThinkingConfig.IncludeThoughts {
+		t.Errorf("thinking config unexpected: %+v", cfg.ThinkingConfig)
+	}
+	if len(cfg.SafetySettings) != len(safetyCategories) || cfg.SafetySettings[0].Threshold != genai.HarmBlockThresholdBlockOnlyHigh {
+		t.Errorf("safety settings unexpected: %+v", cfg.SafetySettings)
+	}
+	if len(cfg.Tools) != 1 || cfg.Tools[0].GoogleSearch == nil {
+		t.Errorf("google search tool missing: %+v", cfg.Tools)
+	}
+
+	// 有函数工具时不注入搜索
+	req = &model.LLMRequest{Config: &genai.GenerateContentConfig{Tools: []*genai.Tool{
+		{FunctionDeclarations: []*genai.FunctionDeclaration{{Name: "get_news"}}},
+	}}}
+	m.applyOptions(req)
+	if len(req.Config.Tools) != 1 || req.Config.Tools[0].GoogleSearch != nil {
+		t.Errorf("google search should be skipped with function tools: %+v", req.Config.Tools)
+	}
+}
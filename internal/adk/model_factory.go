@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"iter"
 	"net/http"
 	"net/url"
 	"strings"
@@ -15,14 +16,18 @@ import (
 	"cloud.google.com/go/auth/credentials"
 	"cloud.google.com/go/auth/httptransport"
 	"github.com/run-bigpig/jcp/internal/adk/anthropic"
+	"github.com/run-bigpig/jcp/internal/adk/gemini"
+	"github.com/run-bigpig/jcp/internal/adk/modelcaps"
+	"github.com/run-bigpig/jcp/internal/adk/ollama"
 	"github.com/run-bigpig/jcp/internal/adk/openai"
+	"github.com/run-bigpig/jcp/internal/memory"
 	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/apperr"
 	"github.com/run-bigpig/jcp/internal/pkg/proxy"
 
 	"github.com/run-bigpig/jcp/internal/logger"
 	go_openai "github.com/sashabaranov/go-openai"
 	"google.golang.org/adk/model"
-	"google.golang.org/adk/model/gemini"
 	"google.golang.org/genai"
 )
 
@@ -49,7 +54,34 @@ func NewModelFactory() *ModelFactory {
 }
 
 // CreateModel 根据 AI 配置创建对应的模型
+// 返回的模型统一按模型能力调整请求，并经过 UsageTracker 做预算检查和费用统计
 func (f *ModelFactory) CreateModel(ctx context.Context, config *models.AIConfig) (model.LLM, error) {
+	llm, err := f.createModel(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	llm = &capableModel{LLM: llm, caps: modelcaps.Resolve(config)}
+	return &trackedModel{LLM: llm, config: config, tracker: GetUsageTracker()}, nil
+}
+
+// capableModel 按模型能力调整请求（移除不支持的工具、思考配置与图片）
+type capableModel struct {
+	model.LLM
+	caps models.ModelCapabilities
+}
+
+// GenerateContent 调整请求后调用原始模型
+func (m *capableModel) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return m.LLM.GenerateContent(ctx, modelcaps.Adapt(req, m.caps), stream)
+}
+
+// noSystemRole 模型是否需要将系统指令降级为用户消息（检测结果或能力表）
+func noSystemRole(config *models.AIConfig) bool {
+	return !modelcaps.Resolve(config).SystemRole
+}
+
+// createModel 按 provider 创建原始模型
+func (f *ModelFactory) createModel(ctx context.Context, config *models.AIConfig) (model.LLM, error) {
 	switch config.Provider {
 	case models.AIProviderGemini:
 		return f.createGeminiModel(ctx, config)
@@ -62,6 +94,8 @@ func (f *ModelFactory) CreateModel(ctx context.Context, config *models.AIConfig)
 		return f.createOpenAIModel(config)
 	case models.AIProviderAnthropic:
 		return f.createAnthropicModel(config)
+	case models.AIProviderOllama:
+		return f.createOllamaModel(config), nil
 	default:
 		return nil, fmt.Errorf("unsupported provider: %s", config.Provider)
 	}
@@ -78,7 +112,16 @@ func (f *ModelFactory) createGeminiModel(ctx context.Context, config *models.AIC
 		},
 	}
 
-	return gemini.NewModel(ctx, config.ModelName, clientConfig)
+	return gemini.NewModel(ctx, config.ModelName, clientConfig, geminiOptions(config))
+}
+
+// geminiOptions 提取 Gemini 原生能力配置
+func geminiOptions(config *models.AIConfig) gemini.Options {
+	return gemini.Options{
+		ThinkingBudget:  config.ThinkingBudget,
+		SafetyThreshold: config.SafetyThreshold,
+		GoogleSearch:    config.GoogleSearch,
+	}
 }
 
 // createVertexAIModel 创建 Vertex AI 模型
@@ -118,7 +161,7 @@ func (f *ModelFactory) createVertexAIModel(ctx context.Context, config *models.A
 		HTTPClient:  httpClient,
 	}
 
-	return gemini.NewModel(ctx, config.ModelName, clientConfig)
+	return gemini.NewModel(ctx, config.ModelName, clientConfig, geminiOptions(config))
 }
 
 // normalizeOpenAIBaseURL 规范化 OpenAI BaseURL
@@ -143,7 +186,17 @@ func (f *ModelFactory) createOpenAIModel(config *models.AIConfig) (model.LLM, er
 		Transport: &uaTransport{base: proxy.GetManager().GetTransport()},
 	}
 
-	return openai.NewOpenAIModel(config.ModelName, openaiCfg, config.NoSystemRole), nil
+	return openai.NewOpenAIModel(config.ModelName, openaiCfg, noSystemRole(config)), nil
+}
+
+// CreateEmbedder 创建记忆检索使用的向量模型（OpenAI 兼容 /embeddings 接口）
+func (f *ModelFactory) CreateEmbedder(config *models.AIConfig, modelName string) memory.Embedder {
+	openaiCfg := go_openai.DefaultConfig(config.APIKey)
+	openaiCfg.BaseURL = normalizeOpenAIBaseURL(config.BaseURL)
+	openaiCfg.HTTPClient = &http.Client{
+		Transport: &uaTransport{base: proxy.GetManager().GetTransport()},
+	}
+	return memory.NewOpenAIEmbedder(modelName, openaiCfg)
 }
 
 // normalizeAnthropicBaseURL 规范化 Anthropic BaseURL
@@ -162,7 +215,13 @@ func (f *ModelFactory) createAnthropicModel(config *models.AIConfig) (model.LLM,
 	httpClient := &http.Client{
 		Transport: &uaTransport{base: proxy.GetManager().GetTransport()},
 	}
-	return anthropic.NewAnthropicModel(config.ModelName, config.APIKey, baseURL, httpClient, config.NoSystemRole), nil
+	return anthropic.NewAnthropicModel(config.ModelName, config.APIKey, baseURL, httpClient, noSystemRole(config)), nil
+}
+
+// createOllamaModel 创建 Ollama 原生 API 模型
+// 本地服务不经过代理，超时由调用方 ctx 控制（本地模型首次加载可能较慢）
+func (f *ModelFactory) createOllamaModel(config *models.AIConfig) model.LLM {
+	return ollama.NewOllamaModel(config.ModelName, config.BaseURL, config.KeepAlive, &http.Client{})
 }
 
 // createOpenAIResponsesModel 创建使用 Responses API 的 OpenAI 模型
@@ -173,7 +232,7 @@ func (f *ModelFactory) createOpenAIResponsesModel(config *models.AIConfig) (mode
 	httpClient := &http.Client{
 		Transport: &uaTransport{base: proxy.GetManager().GetTransport()},
 	}
-	return openai.NewResponsesModel(config.ModelName, config.APIKey, baseURL, httpClient, config.NoSystemRole), nil
+	return openai.NewResponsesModel(config.ModelName, config.APIKey, baseURL, httpClient, noSystemRole(config)), nil
 }
 
 // TestConnection 测试 AI 配置的连通性
@@ -191,6 +250,8 @@ func (f *ModelFactory) TestConnection(ctx context.Context, config *models.AIConf
 		return f.testVertexAIConnection(ctx, config)
 	case models.AIProviderAnthropic:
 		return f.testAnthropicConnection(ctx, config)
+	case models.AIProviderOllama:
+		return f.testOllamaConnection(ctx, config)
 	default:
 		return fmt.Errorf("不支持的 provider: %s", config.Provider)
 	}
@@ -209,7 +270,7 @@ func (f *ModelFactory) DetectSystemRoleSupport(ctx context.Context, config *mode
 	case models.AIProviderAnthropic:
 		return f.detectAnthropicSystemRole(ctx, config)
 	default:
-		return false // Gemini/VertexAI 原生支持
+		return false // Gemini/VertexAI/Ollama 原生支持
 	}
 }
 
@@ -398,7 +459,7 @@ func (f *ModelFactory) testOpenAIConnection(ctx context.Context, config *models.
 	}
 
 	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
-	return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(respBody))
+	return apperr.FromHTTPStatus(resp.StatusCode, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(respBody)))
 }
 
 // testGeminiConnection 测试 Gemini 连通性
@@ -462,7 +523,19 @@ func (f *ModelFactory) testAnthropicConnection(ctx context.Context, config *mode
 	}
 
 	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
-	return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(respBody))
+	return apperr.FromHTTPStatus(resp.StatusCode, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(respBody)))
+}
+
+// testOllamaConnection 测试 Ollama 连通性，模型未下载时返回 ollama.ErrModelNotFound
+func (f *ModelFactory) testOllamaConnection(ctx context.Context, config *models.AIConfig) error {
+	installed, err := ollama.ListModels(ctx, &http.Client{}, config.BaseURL)
+	if err != nil {
+		return err
+	}
+	if !ollama.HasModel(installed, config.ModelName) {
+		return fmt.Errorf("%w: %s", ollama.ErrModelNotFound, config.ModelName)
+	}
+	return f.testViaGenerate(ctx, f.createOllamaModel(config))
 }
 
 // testViaGenerate 通过 GenerateContent 发送最小请求测试连通性
@@ -0,0 +1,81 @@
+package modelcaps
+
+import (
+	"github.com/run-bigpig/jcp/internal/logger"
+	"github.com/run-bigpig/jcp/internal/models"
+	"google.golang.org/adk/model"
+	"google.golang.org/genai"
+)
+
+var log = logger.New("modelcaps")
+
+// unsupportedImageText 不支持图片输入的模型用文字占位替代图片
+const unsupportedImageText = "[图片：当前模型不支持图像输入]"
+
+// Adapt 按模型能力调整请求：移除不支持的工具声明、思考配置与图片
+// 无需调整时原样返回，否则返回浅拷贝，不修改调用方的请求
+func Adapt(req *model.LLMRequest, c models.ModelCapabilities) *model.LLMRequest {
+	if req == nil {
+		return req
+	}
+	cfg := req.Config
+	dropTools := !c.Tools && cfg != nil && len(cfg.Tools) > 0
+	dropThinking := !c.Thinking && cfg != nil && cfg.ThinkingConfig != nil
+	dropImages := !c.Vision && hasMedia(req.Contents)
+	if !dropTools && !dropThinking && !dropImages {
+		return req
+	}
+
+	adapted := *req
+	if dropTools || dropThinking {
+		copied := *cfg
+		if dropTools {
+			log.Debug("模型不支持函数调用，移除 %d 个工具声明", len(cfg.Tools))
+			copied.Tools = nil
+		}
+		if dropThinking {
+			copied.ThinkingConfig = nil
+		}
+		adapted.Config = &copied
+	}
+	if dropImages {
+		adapted.Contents = replaceMedia(req.Contents)
+	}
+	return &adapted
+}
+
+// hasMedia 是否包含图片等多模态内容
+func hasMedia(contents []*genai.Content) bool {
+	for _, c := range contents {
+		if c == nil {
+			continue
+		}
+		for _, p := range c.Parts {
+			if p != nil && (p.InlineData != nil || p.FileData != nil) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// replaceMedia 将多模态内容替换为文字占位，复制受影响的消息
+func replaceMedia(contents []*genai.Content) []*genai.Content {
+	out := make([]*genai.Content, len(contents))
+	for i, c := range contents {
+		out[i] = c
+		if c == nil || !hasMedia([]*genai.Content{c}) {
+			continue
+		}
+		copied := *c
+		copied.Parts = make([]*genai.Part, len(c.Parts))
+		for j, p := range c.Parts {
+			if p != nil && (p.InlineData != nil || p.FileData != nil) {
+				p = &genai.Part{Text: unsupportedImageText}
+			}
+			copied.Parts[j] = p
+		}
+		out[i] = &copied
+	}
+	return out
+}
@@ -0,0 +1,128 @@
+// Package modelcaps 模型能力注册表
+// 按模型名描述视觉、函数调用、system 角色、思考与上下文窗口等能力，
+// 请求转换时据此自动降级，而不依赖用户手动设置的开关
+package modelcaps
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/run-bigpig/jcp/internal/adk/tokencount"
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// defaultCapabilities 未知模型的能力：假定支持函数调用与 system 角色，不发送图片与思考配置
+var defaultCapabilities = models.ModelCapabilities{Tools: true, SystemRole: true}
+
+// entry 能力表条目，prefix 为小写模型名前缀
+type entry struct {
+	prefix string
+	caps   models.ModelCapabilities
+}
+
+var (
+	mu sync.RWMutex
+	// builtin 内置能力表，按顺序匹配（更具体的前缀在前）
+	builtin = []entry{
+		{"o1-mini", caps(false, false, false, true)},
+		{"o1-preview", caps(false, false, false, true)},
+		{"o1", caps(true, true, true, true)},
+		{"o3", caps(true, true, true, true)},
+		{"o4", caps(true, true, true, true)},
+		{"gpt-5", caps(true, true, true, true)},
+		{"gpt-4.1", caps(true, true, true, false)},
+		{"gpt-4o", caps(true, true, true, false)},
+		{"gpt-4-turbo", caps(true, true, true, false)},
+		{"gpt-4", caps(false, true, true, false)},
+		{"gpt-3.5", caps(false, true, true, false)},
+		{"claude-3-5", caps(true, true, true, false)},
+		{"claude-3-haiku", caps(true, true, true, false)},
+		{"claude-3-opus", caps(true, true, true, false)},
+		{"claude-3-sonnet", caps(true, true, true, false)},
+		{"claude", caps(true, true, true, true)},
+		{"gemini-1", caps(true, true, true, false)},
+		{"gemini-2.0", caps(true, true, true, false)},
+		{"gemini", caps(true, true, true, true)},
+		{"gemma", caps(true, false, false, false)},
+		{"deepseek-r1", caps(false, false, true, true)},
+		{"deepseek-reasoner", caps(false, true, true, true)},
+		{"deepseek", caps(false, true, true, false)},
+		{"qvq", caps(true, false, true, true)},
+		{"qwq", caps(false, true, true, true)},
+		{"qwen-vl", caps(true, true, true, false)},
+		{"qwen2.5-vl", caps(true, true, true, false)},
+		{"qwen3", caps(false, true, true, true)},
+		{"qwen", caps(false, true, true, false)},
+		{"glm-4v", caps(true, true, true, false)},
+		{"glm-4.5v", caps(true, true, true, true)},
+		{"glm-4.5", caps(false, true, true, true)},
+		{"glm-4.6", caps(false, true, true, true)},
+		{"glm", caps(false, true, true, false)},
+		{"kimi", caps(false, true, true, false)},
+		{"moonshot", caps(false, true, true, false)},
+		{"doubao", caps(false, true, true, false)},
+		{"llava", caps(true, false, true, false)},
+		{"llama", caps(false, true, true, false)},
+	}
+	// custom 运行时注册的条目，优先于内置表
+	custom []entry
+)
+
+func caps(vision, tools, systemRole, thinking bool) models.ModelCapabilities {
+	return models.ModelCapabilities{Vision: vision, Tools: tools, SystemRole: systemRole, Thinking: thinking}
+}
+
+// Register 注册模型能力，prefix 为模型名前缀（忽略大小写），优先于内置表匹配
+func Register(prefix string, c models.ModelCapabilities) {
+	mu.Lock()
+	defer mu.Unlock()
+	custom = append([]entry{{strings.ToLower(prefix), c}}, custom...)
+}
+
+// Lookup 按模型名查询能力，忽略厂商前缀（如 Qwen/Qwen2.5-VL-72B）与大小写
+func Lookup(modelName string) models.ModelCapabilities {
+	name := strings.ToLower(strings.TrimSpace(modelName))
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+
+	c, ok := match(name)
+	if !ok {
+		c = defaultCapabilities
+	}
+	if c.MaxContext == 0 {
+		c.MaxContext = tokencount.ContextWindow(modelName)
+	}
+	return c
+}
+
+// match 依次匹配自定义与内置条目
+func match(name string) (models.ModelCapabilities, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, list := range [][]entry{custom, builtin} {
+		for _, e := range list {
+			if strings.HasPrefix(name, e.prefix) {
+				return e.caps, true
+			}
+		}
+	}
+	return models.ModelCapabilities{}, false
+}
+
+// Resolve 解析 AI 配置对应模型的能力
+// 配置中显式填写的能力优先；检测到不支持 system 角色（NoSystemRole）时始终降级
+func Resolve(config *models.AIConfig) models.ModelCapabilities {
+	c := Lookup(config.ModelName)
+	if config.Capabilities != nil {
+		maxContext := c.MaxContext
+		c = *config.Capabilities
+		if c.MaxContext == 0 {
+			c.MaxContext = maxContext
+		}
+	}
+	if config.NoSystemRole {
+		c.SystemRole = false
+	}
+	return c
+}
@@ -0,0 +1,64 @@
+package modelcaps
+
+import (
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"google.golang.org/adk/model"
+	"google.golang.org/genai"
+)
+
+func TestLookupAndResolve(t *testing.T) {
+	if c := Lookup("Qwen/Qwen2.5-VL-72B-Instruct"); !c.Vision || !c.Tools || c.MaxContext != 128000 {
+		t.Errorf("qwen2.5-vl caps = %+v", c)
+	}
+	if c := Lookup("o1-mini"); c.SystemRole || c.Tools {
+		t.Errorf("o1-mini caps = %+v", c)
+	}
+	if c := Lookup("my-local-model"); c != (models.ModelCapabilities{Tools: true, SystemRole: true, MaxContext: 32768}) {
+		t.Errorf("unknown caps = %+v", c)
+	}
+
+	// 检测到不支持 system role 时覆盖能力表；显式配置优先
+	c := Resolve(&models.AIConfig{ModelName: "gpt-4o", NoSystemRole: true})
+	if c.SystemRole || !c.Vision {
+		t.Errorf("resolved caps = %+v", c)
+	}
+	c = Resolve(&models.AIConfig{ModelName: "my-local-model", Capabilities: &models.ModelCapabilities{Vision: true, SystemRole: true}})
+	if !c.Vision || c.Tools || c.MaxContext != 32768 {
+		t.Errorf("override caps = %+v", c)
+	}
+}
+
+func TestAdapt(t *testing.T) {
+	req := &model.LLMRequest{
+		Contents: []*genai.Content{{Role: "user", Parts: []*genai.Part{
+			{Text: "看图"},
+			{InlineData: &genai.Blob{MIMEType: "image/png", Data: []byte{1}}},
+		}}},
+		Config: &genai.GenerateContentConfig{
+			Tools:          []*genai.Tool{{FunctionDeclarations: []*genai.FunctionDeclaration{{Name: "get_news"}}}},
+			ThinkingConfig: &genai.ThinkingConfig{ThinkingLevel: genai.ThinkingLevelHigh},
+		},
+	}
+
+	if got := Adapt(req, Lookup("gpt-5")); got != req {
+		t.Error("capable model should keep request as is")
+	}
+
+	got := Adapt(req, Lookup("gemma3:12b"))
+	if got.Config.Tools != nil || got.Config.ThinkingConfig != nil {
+		t.Errorf("tools/thinking should be removed: %+v", got.Config)
+	}
+	if got.Contents[0].Parts[1].InlineData == nil {
+		t.Error("gemma supports vision, image should be kept")
+	}
+
+	got = Adapt(req, Lookup("deepseek-chat"))
+	if got.Contents[0].Parts[1].Text != unsupportedImageText || got.Config.Tools == nil {
+		t.Errorf("image should be replaced: %+v", got.Contents[0].Parts[1])
+	}
+	if req.Contents[0].Parts[1].InlineData == nil || req.Config.ThinkingConfig == nil {
+		t.Error("original request should not be modified")
+	}
+}
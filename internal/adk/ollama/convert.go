@@ -0,0 +1,196 @@
+package ollama
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/adk/model"
+	"google.golang.org/genai"
+)
+
+// toChatRequest 将 ADK LLMRequest 转换为 Ollama /api/chat 请求
+func toChatRequest(req *model.LLMRequest, modelName, keepAlive string) (*ChatRequest, error) {
+	cr := &ChatRequest{
+		Model:     modelName,
+		KeepAlive: keepAlive,
+	}
+
+	if req.Config != nil && req.Config.SystemInstruction != nil {
+		if text := extractText(req.Config.SystemInstruction); text != "" {
+			cr.Messages = append(cr.Messages, Message{Role: "system", Content: text})
+		}
+	}
+
+	msgs, err := toMessages(req.Contents)
+	if err != nil {
+		return nil, err
+	}
+	cr.Messages = append(cr.Messages, msgs...)
+
+	if req.Config != nil {
+		if len(req.Config.Tools) > 0 {
+			tools, err := convertTools(req.Config.Tools)
+			if err != nil {
+				return nil, err
+			}
+			cr.Tools = tools
+		}
+
+		opts := &Options{
+			NumPredict: int(req.Config.MaxOutputTokens),
+			Stop:       req.Config.StopSequences,
+		}
+		if req.Config.Temperature != nil {
+			t := float64(*req.Config.Temperature)
+			opts.Temperature = &t
+		}
+		if req.Config.TopP != nil {
+			p := float64(*req.Config.TopP)
+			opts.TopP = &p
+		}
+		if opts.Temperature != nil || opts.TopP != nil || opts.NumPredict > 0 || len(opts.Stop) > 0 {
+			cr.Options = opts
+		}
+	}
+
+	return cr, nil
+}
+
+// extractText 提取 genai.Content 中的纯文本
+func extractText(content *genai.Content) string {
+	if content == nil {
+		return ""
+	}
+	var texts []string
+	for _, part := range content.Parts {
+		if part.Text != "" && !part.Thought {
+			texts = append(texts, part.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// toMessages 将 genai.Content 列表转换为 Ollama messages
+// 函数响应各自成为一条 role=tool 消息，通过 tool_name 关联调用
+func toMessages(contents []*genai.Content) ([]Message, error) {
+	var msgs []Message
+	for _, content := range contents {
+		if content == nil {
+			continue
+		}
+
+		role := "user"
+		if content.Role == genai.RoleModel {
+			role = "assistant"
+		}
+
+		msg := Message{Role: role}
+		var texts []string
+		for _, part := range content.Parts {
+			if part.Thought {
+				continue
+			}
+			if part.Text != "" {
+				texts = append(texts, part.Text)
+			}
+			if part.FunctionCall != nil {
+				msg.ToolCalls = append(msg.ToolCalls, ToolCall{Function: ToolCallFunction{
+					Name:      part.FunctionCall.Name,
+					Arguments: part.FunctionCall.Args,
+				}})
+			}
+			if part.FunctionResponse != nil {
+				result, err := toToolContent(part.FunctionResponse.Response)
+				if err != nil {
+					return nil, fmt.Errorf("marshal function response: %w", err)
+				}
+				msgs = append(msgs, Message{Role: "tool", Content: result, ToolName: part.FunctionResponse.Name})
+			}
+		}
+		msg.Content = strings.Join(texts, "\n")
+		if msg.Content != "" || len(msg.ToolCalls) > 0 {
+			msgs = append(msgs, msg)
+		}
+	}
+	return msgs, nil
+}
+
+// toToolContent 函数返回值转为字符串内容
+func toToolContent(resp any) (string, error) {
+	if s, ok := resp.(string); ok {
+		return s, nil
+	}
+	b, err := json.Marshal(resp)
+	return string(b), err
+}
+
+// convertTools 将 genai.Tool 转换为 Ollama Tool
+func convertTools(genaiTools []*genai.Tool) ([]Tool, error) {
+	var tools []Tool
+	for _, gt := range genaiTools {
+		if gt == nil {
+			continue
+		}
+		for _, fd := range gt.FunctionDeclarations {
+			schema := fd.ParametersJsonSchema
+			if schema == nil {
+				schema = fd.Parameters
+			}
+			if schema == nil {
+				return nil, fmt.Errorf("parameters is nil for tool %s", fd.Name)
+			}
+			schemaJSON, err := json.Marshal(schema)
+			if err != nil {
+				return nil, fmt.Errorf("marshal tool schema: %w", err)
+			}
+			tools = append(tools, Tool{
+				Type:     "function",
+				Function: ToolFunction{Name: fd.Name, Description: fd.Description, Parameters: schemaJSON},
+			})
+		}
+	}
+	return tools, nil
+}
+
+// convertToolCalls 将 Ollama 工具调用转换为 genai 函数调用，补充调用 ID 供 ADK 关联响应
+func convertToolCalls(calls []ToolCall) []*genai.Part {
+	parts := make([]*genai.Part, 0, len(calls))
+	for _, call := range calls {
+		args := call.Function.Arguments
+		if args == nil {
+			args = map[string]any{}
+		}
+		parts = append(parts, &genai.Part{FunctionCall: &genai.FunctionCall{
+			ID:   "call_" + uuid.New().String(),
+			Name: call.Function.Name,
+			Args: args,
+		}})
+	}
+	return parts
+}
+
+// convertUsage 转换 token 用量
+func convertUsage(resp *ChatResponse) *genai.GenerateContentResponseUsageMetadata {
+	if resp.PromptEvalCount == 0 && resp.EvalCount == 0 {
+		return nil
+	}
+	return &genai.GenerateContentResponseUsageMetadata{
+		PromptTokenCount:     int32(resp.PromptEvalCount),
+		CandidatesTokenCount: int32(resp.EvalCount),
+		TotalTokenCount:      int32(resp.PromptEvalCount + resp.EvalCount),
+	}
+}
+
+// convertDoneReason 转换停止原因
+func convertDoneReason(reason string) genai.FinishReason {
+	switch reason {
+	case "stop":
+		return genai.FinishReasonStop
+	case "length":
+		return genai.FinishReasonMaxTokens
+	default:
+		return genai.FinishReasonUnspecified
+	}
+}
@@ -0,0 +1,211 @@
+// Package ollama 实现 Ollama 原生 API（/api/chat）的 adk 模型，支持 keep_alive 与模型拉取
+package ollama
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/adk/tokencount"
+	"github.com/run-bigpig/jcp/internal/logger"
+	"github.com/run-bigpig/jcp/internal/pkg/apperr"
+	"google.golang.org/adk/model"
+	"google.golang.org/genai"
+)
+
+var modelLog = logger.New("ollama:model")
+
+// DefaultBaseURL 本地 Ollama 默认地址
+const DefaultBaseURL = "http://localhost:11434"
+
+// 确保实现 model.LLM 接口
+var _ model.LLM = &OllamaModel{}
+
+// OllamaModel Ollama 原生 API 模型
+type OllamaModel struct {
+	httpClient *http.Client
+	baseURL    string
+	modelName  string
+	keepAlive  string
+}
+
+// NormalizeBaseURL 规范化 BaseURL，兼容填写了 OpenAI 兼容地址（/v1）或 /api 的情况
+func NormalizeBaseURL(baseURL string) string {
+	baseURL = strings.TrimSpace(strings.TrimRight(baseURL, "/"))
+	if baseURL == "" {
+		return DefaultBaseURL
+	}
+	baseURL = strings.TrimSuffix(baseURL, "/v1")
+	baseURL = strings.TrimSuffix(baseURL, "/api")
+	return baseURL
+}
+
+// NewOllamaModel 创建 Ollama 模型
+func NewOllamaModel(modelName, baseURL, keepAlive string, httpClient *http.Client) *OllamaModel {
+	return &OllamaModel{
+		httpClient: httpClient,
+		baseURL:    NormalizeBaseURL(baseURL),
+		modelName:  modelName,
+		keepAlive:  keepAlive,
+	}
+}
+
+// Name 返回模型名称
+func (m *OllamaModel) Name() string {
+	return m.modelName
+}
+
+// GenerateContent 实现 model.LLM 接口
+func (m *OllamaModel) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	req = tokencount.Fit(req, m.modelName)
+	return func(yield func(*model.LLMResponse, error) bool) {
+		cr, err := toChatRequest(req, m.modelName, m.keepAlive)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		cr.Stream = stream
+
+		resp, err := m.doRequest(ctx, cr)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer resp.Body.Close()
+
+		if !stream {
+			var chatResp ChatResponse
+			if err := json.NewDecoder(io.LimitReader(resp.Body, 10*1024*1024)).Decode(&chatResp); err != nil {
+				yield(nil, fmt.Errorf("unmarshal response: %w", err))
+				return
+			}
+			if chatResp.Error != "" {
+				yield(nil, fmt.Errorf("Ollama error: %s", chatResp.Error))
+				return
+			}
+			yield(finalResponse(&chatResp, chatResp.Message.Content, chatResp.Message.Thinking, chatResp.Message.ToolCalls), nil)
+			return
+		}
+		m.processStream(resp.Body, yield)
+	}
+}
+
+// doRequest 发送 /api/chat 请求
+func (m *OllamaModel) doRequest(ctx context.Context, cr *ChatRequest) (*http.Response, error) {
+	jsonBody, err := json.Marshal(cr)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+
+	endpoint, err := url.JoinPath(m.baseURL, "api", "chat")
+	if err != nil {
+		return nil, fmt.Errorf("build endpoint: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := m.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 2048))
+		resp.Body.Close()
+		modelLog.Error("API 响应异常: status=%d, body=%s", resp.StatusCode, string(body))
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("%w: %s", ErrModelNotFound, m.modelName)
+		}
+		return nil, apperr.FromHTTPStatus(resp.StatusCode, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body)))
+	}
+
+	return resp, nil
+}
+
+// processStream 处理 NDJSON 流：文本与思考增量逐条推送，结束时聚合为完整响应
+func (m *OllamaModel) processStream(body io.Reader, yield func(*model.LLMResponse, error) bool) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 1024*1024)
+
+	var text, thinking strings.Builder
+	var toolCalls []ToolCall
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var chunk ChatResponse
+		if err := json.Unmarshal(line, &chunk); err != nil {
+			continue // 忽略解析错误
+		}
+		if chunk.Error != "" {
+			yield(nil, fmt.Errorf("Ollama error: %s", chunk.Error))
+			return
+		}
+
+		if t := chunk.Message.Thinking; t != "" {
+			thinking.WriteString(t)
+			if !yield(partialResponse(&genai.Part{Text: t, Thought: true}), nil) {
+				return
+			}
+		}
+		if t := chunk.Message.Content; t != "" {
+			text.WriteString(t)
+			if !yield(partialResponse(&genai.Part{Text: t}), nil) {
+				return
+			}
+		}
+		toolCalls = append(toolCalls, chunk.Message.ToolCalls...)
+
+		if chunk.Done {
+			yield(finalResponse(&chunk, text.String(), thinking.String(), toolCalls), nil)
+			return
+		}
+	}
+
+	if err := scanner.Err(); err != nil && !errors.Is(err, context.Canceled) {
+		yield(nil, fmt.Errorf("流读取错误: %w", err))
+		return
+	}
+	// 连接提前关闭但未收到 done，仍返回已聚合内容
+	yield(finalResponse(&ChatResponse{}, text.String(), thinking.String(), toolCalls), nil)
+}
+
+// partialResponse 构造流式增量响应
+func partialResponse(part *genai.Part) *model.LLMResponse {
+	return &model.LLMResponse{
+		Content: &genai.Content{Role: genai.RoleModel, Parts: []*genai.Part{part}},
+		Partial: true,
+	}
+}
+
+// finalResponse 构造完整响应
+func finalResponse(resp *ChatResponse, text, thinking string, toolCalls []ToolCall) *model.LLMResponse {
+	content := &genai.Content{Role: genai.RoleModel, Parts: []*genai.Part{}}
+	if thinking != "" {
+		content.Parts = append(content.Parts, &genai.Part{Text: thinking, Thought: true})
+	}
+	if text != "" {
+		content.Parts = append(content.Parts, &genai.Part{Text: text})
+	}
+	content.Parts = append(content.Parts, convertToolCalls(toolCalls)...)
+
+	return &model.LLMResponse{
+		Content:       content,
+		UsageMetadata: convertUsage(resp),
+		FinishReason:  convertDoneReason(resp.DoneReason),
+		TurnComplete:  true,
+	}
+}
@@ -0,0 +1,113 @@
+package ollama
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/adk/model"
+	"google.golang.org/genai"
+)
+
+func TestToChatRequest_ToolRoundTrip(t *testing.T) {
+	req := &model.LLMRequest{
+		Contents: []*genai.Content{
+			{Role: "user", Parts: []*genai.Part{{Text: "茅台多少钱"}}},
+			{Role: "model", Parts: []*genai.Part{{FunctionCall: &genai.FunctionCall{
+				ID: "call_1", Name: "get_stock_realtime", Args: map[string]any{"codes": "sh600519"},
+			}}}},
+			{Role: "user", Parts: []*genai.Part{{FunctionResponse: &genai.FunctionResponse{
+				ID: "call_1", Name: "get_stock_realtime", Response: map[string]any{"data": "1500"},
+			}}}},
+		},
+		Config: &genai.GenerateContentConfig{
+			MaxOutputTokens:   256,
+			SystemInstruction: &genai.Content{Parts: []*genai.Part{{Text: "你是分析师"}}},
+		},
+	}
+
+	cr, err := toChatRequest(req, "qwen3:8b", "10m")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cr.KeepAlive != "10m" || cr.Options == nil || cr.Options.NumPredict != 256 {
+		t.Errorf("keep_alive/options unexpected: %+v %+v", cr.KeepAlive, cr.Options)
+	}
+	roles := make([]string, len(cr.Messages))
+	for i, m := range cr.Messages {
+		roles[i] = m.Role
+	}
+	if strings.Join(roles, ",") != "system,user,assistant,tool" {
+		t.Fatalf("roles = %v", roles)
+	}
+	if cr.Messages[2].ToolCalls[0].Function.Name != "get_stock_realtime" {
+		t.Errorf("tool call unexpected: %+v", cr.Messages[2])
+	}
+	if tool := cr.Messages[3]; tool.ToolName != "get_stock_realtime" || tool.Content != `{"data":"1500"}` {
+		t.Errorf("tool message unexpected: %+v", tool)
+	}
+}
+
+func TestGenerateContent_Stream(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/chat" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"message":{"role":"assistant","content":"你"},"done":false}
+{"message":{"role":"assistant","content":"好"},"done":false}
+{"message":{"role":"assistant","content":"","tool_calls":[{"function":{"name":"get_news","arguments":{}}}]},"done":false}
+{"message":{"role":"assistant","content":""},"done":true,"done_reason":"stop","prompt_eval_count":10,"eval_count":3}
+`))
+	}))
+	defer srv.Close()
+
+	m := NewOllamaModel("qwen3:8b", srv.URL+"/v1", "", srv.Client())
+	req := &model.LLMRequest{Contents: []*genai.Content{{Role: "user", Parts: []*genai.Part{{Text: "hi"}}}}}
+
+	var partials int
+	var final *model.LLMResponse
+	for resp, err := range m.GenerateContent(context.Background(), req, true) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Partial {
+			partials++
+		} else {
+			final = resp
+		}
+	}
+	if partials != 2 || final == nil {
+		t.Fatalf("partials=%d final=%v", partials, final)
+	}
+	if len(final.Content.Parts) != 2 || final.Content.Parts[0].Text != "你好" || final.Content.Parts[1].FunctionCall.Name != "get_news" {
+		t.Errorf("final parts unexpected: %+v", final.Content.Parts)
+	}
+	if final.Content.Parts[1].FunctionCall.ID == "" {
+		t.Error("function call ID should be generated")
+	}
+	if final.UsageMetadata.TotalTokenCount != 13 || final.FinishReason != genai.FinishReasonStop {
+		t.Errorf("usage/finish unexpected: %+v %v", final.UsageMetadata, final.FinishReason)
+	}
+}
+
+func TestPullAndHasModel(t *testing.T) {
+	var statuses []string
+	err := readPullStream(strings.NewReader(`{"status":"pulling manifest"}
+{"status":"downloading","total":100,"completed":50}
+{"status":"success"}
+`), func(s PullStatus) { statuses = append(statuses, s.Status) })
+	if err != nil || len(statuses) != 3 {
+		t.Fatalf("statuses=%v err=%v", statuses, err)
+	}
+	if err := readPullStream(strings.NewReader(`{"error":"pull model manifest: file does not exist"}`), nil); err == nil {
+		t.Error("expected error")
+	}
+
+	installed := []string{"qwen3:8b", "llama3.2:latest"}
+	if !HasModel(installed, "llama3.2") || !HasModel(installed, "qwen3:8b") || HasModel(installed, "qwen3") {
+		t.Error("HasModel unexpected")
+	}
+}
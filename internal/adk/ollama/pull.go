@@ -0,0 +1,110 @@
+package ollama
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ErrModelNotFound 本地未下载该模型
+var ErrModelNotFound = errors.New("模型未下载，请先拉取")
+
+// ListModels 列出本地已下载的模型
+func ListModels(ctx context.Context, client *http.Client, baseURL string) ([]string, error) {
+	endpoint, err := url.JoinPath(NormalizeBaseURL(baseURL), "api", "tags")
+	if err != nil {
+		return nil, fmt.Errorf("无效 BaseURL: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("连接 Ollama 失败: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	var tags tagsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tags); err != nil {
+		return nil, fmt.Errorf("解析模型列表失败: %w", err)
+	}
+	names := make([]string, 0, len(tags.Models))
+	for _, m := range tags.Models {
+		names = append(names, m.Name)
+	}
+	return names, nil
+}
+
+// HasModel 判断模型是否已下载，未写标签时按 latest 匹配
+func HasModel(installed []string, name string) bool {
+	if !strings.Contains(name, ":") {
+		name += ":latest"
+	}
+	for _, m := range installed {
+		if m == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Pull 拉取模型，onProgress 接收每条进度（可为 nil），完成后返回
+func Pull(ctx context.Context, client *http.Client, baseURL, name string, onProgress func(PullStatus)) error {
+	endpoint, err := url.JoinPath(NormalizeBaseURL(baseURL), "api", "pull")
+	if err != nil {
+		return fmt.Errorf("无效 BaseURL: %w", err)
+	}
+	body, _ := json.Marshal(map[string]any{"model": name, "stream": true})
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("连接 Ollama 失败: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(msg))
+	}
+
+	return readPullStream(resp.Body, onProgress)
+}
+
+// readPullStream 读取拉取进度流，直到 success 或 error
+func readPullStream(r io.Reader, onProgress func(PullStatus)) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		var status PullStatus
+		if err := json.Unmarshal(scanner.Bytes(), &status); err != nil {
+			continue
+		}
+		if status.Error != "" {
+			return fmt.Errorf("拉取失败: %s", status.Error)
+		}
+		if onProgress != nil {
+			onProgress(status)
+		}
+		if status.Status == "success" {
+			return nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("拉取中断")
+}
@@ -0,0 +1,82 @@
+package ollama
+
+import "encoding/json"
+
+// ChatRequest Ollama /api/chat 请求
+type ChatRequest struct {
+	Model     string    `json:"model"`
+	Messages  []Message `json:"messages"`
+	Tools     []Tool    `json:"tools,omitempty"`
+	Stream    bool      `json:"stream"`
+	KeepAlive string    `json:"keep_alive,omitempty"` // 模型驻留内存时长，如 5m、1h，-1 表示常驻
+	Options   *Options  `json:"options,omitempty"`
+}
+
+// Options 生成参数
+type Options struct {
+	Temperature *float64 `json:"temperature,omitempty"`
+	TopP        *float64 `json:"top_p,omitempty"`
+	NumPredict  int      `json:"num_predict,omitempty"` // 最大输出 token 数
+	Stop        []string `json:"stop,omitempty"`
+}
+
+// Message 消息
+type Message struct {
+	Role      string     `json:"role"` // system / user / assistant / tool
+	Content   string     `json:"content"`
+	Thinking  string     `json:"thinking,omitempty"`
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+	ToolName  string     `json:"tool_name,omitempty"` // role=tool 时对应的工具名
+}
+
+// ToolCall 工具调用（Ollama 不返回调用 ID）
+type ToolCall struct {
+	Function ToolCallFunction `json:"function"`
+}
+
+// ToolCallFunction 工具调用函数
+type ToolCallFunction struct {
+	Name      string         `json:"name"`
+	Arguments map[string]any `json:"arguments"`
+}
+
+// Tool 工具定义
+type Tool struct {
+	Type     string       `json:"type"` // function
+	Function ToolFunction `json:"function"`
+}
+
+// ToolFunction 工具函数定义
+type ToolFunction struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Parameters  json.RawMessage `json:"parameters"`
+}
+
+// ChatResponse 非流式响应，流式时每行一个（NDJSON）
+type ChatResponse struct {
+	Model           string  `json:"model"`
+	Message         Message `json:"message"`
+	Done            bool    `json:"done"`
+	DoneReason      string  `json:"done_reason,omitempty"` // stop / length / load / unload
+	PromptEvalCount int     `json:"prompt_eval_count,omitempty"`
+	EvalCount       int     `json:"eval_count,omitempty"`
+	Error           string  `json:"error,omitempty"`
+}
+
+// PullStatus /api/pull 进度
+type PullStatus struct {
+	Status    string `json:"status"` // pulling manifest / downloading / verifying sha256 digest / success
+	Digest    string `json:"digest,omitempty"`
+	Total     int64  `json:"total,omitempty"`
+	Completed int64  `json:"completed,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// tagsResponse /api/tags 响应
+type tagsResponse struct {
+	Models []struct {
+		Name  string `json:"name"`
+		Model string `json:"model"`
+	} `json:"models"`
+}
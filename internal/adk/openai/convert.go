@@ -1,9 +1,10 @@
 package openai
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"regexp"
+	"net/http"
 	"strings"
 
 	"github.com/sashabaranov/go-openai"
@@ -15,161 +16,6 @@ import (
 
 var convertLog = logger.New("openai:convert")
 
-// 匹配第三方特殊工具调用格式
-// 格式1: <vendor:tool_call> <invoke name="xxx"> <parameter name="yyy">zzz</parameter> </invoke> </vendor:tool_call>
-var vendorToolCallStartRegex = regexp.MustCompile(`<(\w+):tool_call>`)
-var invokeRegex = regexp.MustCompile(`(?s)<invoke\s+name="([^"]+)">\s*(.*?)\s*</invoke>`)
-var paramRegex = regexp.MustCompile(`(?s)<parameter\s+name="([^"]+)">(.*?)</parameter>`)
-
-// 格式2: <tool_call_begin>tool_name <param name="xxx">yyy</param> </tool_call_end>
-var toolCallBeginRegex = regexp.MustCompile(`(?s)<tool_call_begin>\s*(\w+)\s*(.*?)\s*</tool_call_end>`)
-var paramAltRegex = regexp.MustCompile(`(?s)<param\s+name="([^"]+)">(.*?)</param>`)
-
-// 格式3: <tool_call> <tool name="xxx"> <param name="yyy">zzz</param> </tool> </tool_call>
-var toolCallWrapRegex = regexp.MustCompile(`(?s)<tool_call>\s*(.*?)\s*</tool_call>`)
-var toolTagRegex = regexp.MustCompile(`(?s)<tool\s+name="([^"]+)">\s*(.*?)\s*</tool>`)
-
-// VendorToolCall 第三方工具调用解析结果
-type VendorToolCall struct {
-	Name string
-	Args map[string]any
-}
-
-// FilterVendorToolCallMarkers 过滤文本中的第三方工具调用标记（导出供外部使用）
-func FilterVendorToolCallMarkers(text string) string {
-	_, cleaned := parseVendorToolCalls(text)
-	return cleaned
-}
-
-// parseVendorToolCalls 解析文本中的第三方工具调用标记
-// 返回解析出的工具调用列表和清理后的文本
-func parseVendorToolCalls(text string) ([]VendorToolCall, string) {
-	if text == "" {
-		return nil, text
-	}
-
-	var toolCalls []VendorToolCall
-	cleanedText := text
-
-	// 查找所有 vendor:tool_call 开始标签
-	startMatches := vendorToolCallStartRegex.FindAllStringSubmatchIndex(text, -1)
-	for _, match := range startMatches {
-		if len(match) < 4 {
-			continue
-		}
-		// match[0]:match[1] 是整个匹配，match[2]:match[3] 是 vendor 名称
-		vendor := text[match[2]:match[3]]
-		startPos := match[0]
-		endTag := "</" + vendor + ":tool_call>"
-
-		// 查找对应的结束标签
-		endPos := strings.Index(text[match[1]:], endTag)
-		if endPos == -1 {
-			continue
-		}
-		endPos += match[1]
-
-		// 提取内部内容
-		innerContent := text[match[1]:endPos]
-		fullMatch := text[startPos : endPos+len(endTag)]
-
-		// 解析 invoke 标签
-		invokeMatches := invokeRegex.FindAllStringSubmatch(innerContent, -1)
-		for _, invokeMatch := range invokeMatches {
-			if len(invokeMatch) < 3 {
-				continue
-			}
-			toolName := invokeMatch[1]
-			paramsContent := invokeMatch[2]
-
-			// 解析参数
-			args := make(map[string]any)
-			paramMatches := paramRegex.FindAllStringSubmatch(paramsContent, -1)
-			for _, paramMatch := range paramMatches {
-				if len(paramMatch) >= 3 {
-					args[paramMatch[1]] = paramMatch[2]
-				}
-			}
-
-			toolCalls = append(toolCalls, VendorToolCall{
-				Name: toolName,
-				Args: args,
-			})
-		}
-
-		// 从文本中移除已解析的工具调用块
-		cleanedText = strings.Replace(cleanedText, fullMatch, "", 1)
-	}
-
-	// 格式2: <tool_call_begin>tool_name <param name="xxx">yyy</param> </tool_call_end>
-	beginMatches := toolCallBeginRegex.FindAllStringSubmatch(cleanedText, -1)
-	for _, match := range beginMatches {
-		if len(match) < 3 {
-			continue
-		}
-		toolName := match[1]
-		paramsContent := match[2]
-
-		// 解析参数
-		args := make(map[string]any)
-		paramMatches := paramAltRegex.FindAllStringSubmatch(paramsContent, -1)
-		for _, paramMatch := range paramMatches {
-			if len(paramMatch) >= 3 {
-				// 去除参数值两端的引号
-				val := strings.Trim(paramMatch[2], "\"")
-				args[paramMatch[1]] = val
-			}
-		}
-
-		toolCalls = append(toolCalls, VendorToolCall{
-			Name: toolName,
-			Args: args,
-		})
-
-		// 从文本中移除已解析的工具调用块
-		cleanedText = strings.Replace(cleanedText, match[0], "", 1)
-	}
-
-	// 格式3: <tool_call> <tool name="xxx"> <param name="yyy">zzz</param> </tool> </tool_call>
-	wrapMatches := toolCallWrapRegex.FindAllStringSubmatch(cleanedText, -1)
-	for _, match := range wrapMatches {
-		if len(match) < 2 {
-			continue
-		}
-		innerContent := match[1]
-
-		// 解析多个 tool 标签
-		toolMatches := toolTagRegex.FindAllStringSubmatch(innerContent, -1)
-		for _, toolMatch := range toolMatches {
-			if len(toolMatch) < 3 {
-				continue
-			}
-			toolName := toolMatch[1]
-			paramsContent := toolMatch[2]
-
-			// 解析参数
-			args := make(map[string]any)
-			paramMatches := paramAltRegex.FindAllStringSubmatch(paramsContent, -1)
-			for _, paramMatch := range paramMatches {
-				if len(paramMatch) >= 3 {
-					val := strings.Trim(paramMatch[2], "\"")
-					args[paramMatch[1]] = val
-				}
-			}
-
-			toolCalls = append(toolCalls, VendorToolCall{
-				Name: toolName,
-				Args: args,
-			})
-		}
-
-		// 从文本中移除已解析的工具调用块
-		cleanedText = strings.Replace(cleanedText, match[0], "", 1)
-	}
-
-	return toolCalls, strings.TrimSpace(cleanedText)
-}
-
 // toOpenAIChatCompletionRequest 将 ADK 请求转换为 OpenAI 请求
 func toOpenAIChatCompletionRequest(req *model.LLMRequest, modelName string, noSystemRole bool) (openai.ChatCompletionRequest, error) {
 	openaiMessages := make([]openai.ChatCompletionMessage, 0, len(req.Contents))
@@ -230,7 +76,13 @@ func toOpenAIChatCompletionRequest(req *model.LLMRequest, modelName string, noSy
 				injected := false
 				for i, msg := range openaiMessages {
 					if msg.Role == openai.ChatMessageRoleUser {
-						openaiMessages[i].Content = systemText + "\n\n" + msg.Content
+						if len(msg.MultiContent) > 0 {
+							// 多模态消息：作为首个文本块插入
+							systemPart := openai.ChatMessagePart{Type: openai.ChatMessagePartTypeText, Text: systemText}
+							openaiMessages[i].MultiContent = append([]openai.ChatMessagePart{systemPart}, msg.MultiContent...)
+						} else {
+							openaiMessages[i].Content = systemText + "\n\n" + msg.Content
+						}
 						injected = true
 						break
 					}
@@ -300,6 +152,7 @@ func toOpenAIChatCompletionMessage(content *genai.Content) ([]openai.ChatComplet
 	var textContent string
 	var reasoningContent string
 	var toolCalls []openai.ToolCall
+	var imageParts []openai.ChatMessagePart
 
 	for _, part := range parts {
 		// 处理 thinking/reasoning 内容
@@ -313,6 +166,11 @@ func toOpenAIChatCompletionMessage(content *genai.Content) ([]openai.ChatComplet
 			textContent += part.Text
 		}
 
+		// 处理图片（内联数据或文件 URL）
+		if imagePart, ok := toImagePart(part); ok {
+			imageParts = append(imageParts, imagePart)
+		}
+
 		// 处理函数调用
 		if part.FunctionCall != nil {
 			argsJSON, err := json.Marshal(part.FunctionCall.Args)
@@ -331,8 +189,16 @@ func toOpenAIChatCompletionMessage(content *genai.Content) ([]openai.ChatComplet
 		}
 	}
 
-	// 设置消息内容
-	if textContent != "" {
+	// 设置消息内容：含图片时使用多段 content（Content 与 MultiContent 不能同时设置）
+	if len(imageParts) > 0 {
+		if textContent != "" {
+			openaiMsg.MultiContent = append(openaiMsg.MultiContent, openai.ChatMessagePart{
+				Type: openai.ChatMessagePartTypeText,
+				Text: textContent,
+			})
+		}
+		openaiMsg.MultiContent = append(openaiMsg.MultiContent, imageParts...)
+	} else if textContent != "" {
 		openaiMsg.Content = textContent
 	}
 
@@ -348,6 +214,35 @@ func toOpenAIChatCompletionMessage(content *genai.Content) ([]openai.ChatComplet
 	return append(toolRespMessages, openaiMsg), nil
 }
 
+// toImagePart 将 InlineData / FileData 图片转换为 image_url 内容块
+// 内联数据编码为 base64 data URI；非图片类型 OpenAI Chat 接口不支持，记录后跳过
+func toImagePart(part *genai.Part) (openai.ChatMessagePart, bool) {
+	var mimeType, url string
+	switch {
+	case part.InlineData != nil && len(part.InlineData.Data) > 0:
+		mimeType = part.InlineData.MIMEType
+		if mimeType == "" {
+			mimeType = http.DetectContentType(part.InlineData.Data)
+		}
+		url = "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(part.InlineData.Data)
+	case part.FileData != nil && part.FileData.FileURI != "":
+		mimeType = part.FileData.MIMEType
+		url = part.FileData.FileURI
+	default:
+		return openai.ChatMessagePart{}, false
+	}
+
+	// FileData 可能未标注类型，按 URL 交给模型处理
+	if mimeType != "" && !strings.HasPrefix(mimeType, "image/") {
+		convertLog.Warn("跳过不支持的多模态内容: %s", mimeType)
+		return openai.ChatMessagePart{}, false
+	}
+	return openai.ChatMessagePart{
+		Type:     openai.ChatMessagePartTypeImageURL,
+		ImageURL: &openai.ChatMessageImageURL{URL: url, Detail: openai.ImageURLDetailAuto},
+	}, true
+}
+
 // convertRoleToOpenAI 转换角色
 func convertRoleToOpenAI(role string) string {
 	switch role {
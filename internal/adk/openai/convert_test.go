@@ -0,0 +1,41 @@
+package openai
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"google.golang.org/genai"
+)
+
+func TestToOpenAIChatCompletionMessage_Image(t *testing.T) {
+	content := &genai.Content{Role: "user", Parts: []*genai.Part{
+		{Text: "分析这张K线图"},
+		{InlineData: &genai.Blob{MIMEType: "image/png", Data: []byte{0x89, 'P', 'N', 'G'}}},
+		{FileData: &genai.FileData{FileURI: "https://example.com/chart.jpg"}},
+		{InlineData: &genai.Blob{MIMEType: "application/pdf", Data: []byte("%PDF")}},
+	}}
+
+	msgs, err := toOpenAIChatCompletionMessage(content)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	msg := msgs[0]
+	if msg.Content != "" || len(msg.MultiContent) != 3 {
+		t.Fatalf("multi content unexpected: %+v", msg)
+	}
+	if msg.MultiContent[0].Text != "分析这张K线图" {
+		t.Errorf("text part = %+v", msg.MultiContent[0])
+	}
+	if url := msg.MultiContent[1].ImageURL.URL; !strings.HasPrefix(url, "data:image/png;base64,") {
+		t.Errorf("inline image url = %q", url)
+	}
+	if url := msg.MultiContent[2].ImageURL.URL; url != "https://example.com/chart.jpg" {
+		t.Errorf("file image url = %q", url)
+	}
+
+	b, err := json.Marshal(msg)
+	if err != nil || !strings.Contains(string(b), `"type":"image_url"`) {
+		t.Errorf("marshal = %s, err = %v", b, err)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"google.golang.org/adk/model"
 	"google.golang.org/genai"
 
+	"github.com/run-bigpig/jcp/internal/adk/tokencount"
 	"github.com/run-bigpig/jcp/internal/logger"
 )
 
@@ -47,6 +48,7 @@ func (o *OpenAIModel) Name() string {
 
 // GenerateContent 实现 model.LLM 接口
 func (o *OpenAIModel) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	req = tokencount.Fit(req, o.ModelName)
 	if stream {
 		return o.generateStream(ctx, req)
 	}
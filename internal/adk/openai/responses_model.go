@@ -14,7 +14,9 @@ import (
 	"google.golang.org/adk/model"
 	"google.golang.org/genai"
 
+	"github.com/run-bigpig/jcp/internal/adk/tokencount"
 	"github.com/run-bigpig/jcp/internal/logger"
+	"github.com/run-bigpig/jcp/internal/pkg/apperr"
 )
 
 var respLog = logger.New("openai:responses")
@@ -59,6 +61,7 @@ func (r *ResponsesModel) Name() string {
 
 // GenerateContent 实现 model.LLM 接口
 func (r *ResponsesModel) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	req = tokencount.Fit(req, r.modelName)
 	if stream {
 		return r.generateStream(ctx, req)
 	}
@@ -112,7 +115,7 @@ func (r *ResponsesModel) generate(ctx context.Context, req *model.LLMRequest) it
 
 		if resp.StatusCode < 200 || resp.StatusCode >= 400 {
 			respBody, _ := io.ReadAll(resp.Body)
-			yield(nil, fmt.Errorf("Responses API 错误 (HTTP %d): %s", resp.StatusCode, string(respBody)))
+			yield(nil, apperr.FromHTTPStatus(resp.StatusCode, fmt.Errorf("Responses API 错误 (HTTP %d): %s", resp.StatusCode, string(respBody))))
 			return
 		}
 
@@ -156,7 +159,7 @@ func (r *ResponsesModel) generateStream(ctx context.Context, req *model.LLMReque
 
 		if resp.StatusCode < 200 || resp.StatusCode >= 400 {
 			respBody, _ := io.ReadAll(resp.Body)
-			yield(nil, fmt.Errorf("Responses API 流式错误 (HTTP %d): %s", resp.StatusCode, string(respBody)))
+			yield(nil, apperr.FromHTTPStatus(resp.StatusCode, fmt.Errorf("Responses API 流式错误 (HTTP %d): %s", resp.StatusCode, string(respBody))))
 			return
 		}
 
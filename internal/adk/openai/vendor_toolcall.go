@@ -0,0 +1,347 @@
+package openai
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// VendorToolCall 第三方工具调用解析结果
+type VendorToolCall struct {
+	Name string
+	Args map[string]any
+}
+
+// VendorToolCallParser 第三方工具调用格式解析器
+// 部分模型或中转服务不走标准 tool_calls 字段，而是把工具调用以特殊标记写在文本中
+type VendorToolCallParser interface {
+	// Name 格式名称（用于日志与排查）
+	Name() string
+	// Parse 解析文本中该格式的工具调用，返回解析结果与移除标记后的文本
+	Parse(text string) ([]VendorToolCall, string)
+}
+
+var (
+	vendorParsersMu sync.RWMutex
+	vendorParsers   = []VendorToolCallParser{
+		vendorTagParser{},
+		toolCallBeginParser{},
+		toolCallWrapParser{},
+		pipeMarkerParser{},
+		fencedJSONParser{},
+	}
+)
+
+// RegisterVendorToolCallParser 注册第三方工具调用解析器，按注册顺序在内置解析器之后执行
+func RegisterVendorToolCallParser(p VendorToolCallParser) {
+	vendorParsersMu.Lock()
+	defer vendorParsersMu.Unlock()
+	vendorParsers = append(vendorParsers, p)
+}
+
+// FilterVendorToolCallMarkers 过滤文本中的第三方工具调用标记（导出供外部使用）
+func FilterVendorToolCallMarkers(text string) string {
+	_, cleaned := parseVendorToolCalls(text)
+	return cleaned
+}
+
+// parseVendorToolCalls 依次使用已注册的解析器解析文本中的第三方工具调用标记
+// 返回解析出的工具调用列表和清理后的文本
+func parseVendorToolCalls(text string) ([]VendorToolCall, string) {
+	if text == "" {
+		return nil, text
+	}
+
+	vendorParsersMu.RLock()
+	parsers := vendorParsers
+	vendorParsersMu.RUnlock()
+
+	var toolCalls []VendorToolCall
+	cleanedText := text
+	for _, p := range parsers {
+		calls, cleaned := p.Parse(cleanedText)
+		if len(calls) > 0 {
+			convertLog.Debug("解析到 %d 个 %s 格式工具调用", len(calls), p.Name())
+		}
+		toolCalls = append(toolCalls, calls...)
+		cleanedText = cleaned
+	}
+	return toolCalls, strings.TrimSpace(cleanedText)
+}
+
+// parseParams 解析 <param name="x">y</param> 形式的参数，trimQuotes 去除值两端引号
+func parseParams(re *regexp.Regexp, content string, trimQuotes bool) map[string]any {
+	args := make(map[string]any)
+	for _, m := range re.FindAllStringSubmatch(content, -1) {
+		val := m[2]
+		if trimQuotes {
+			val = strings.Trim(val, "\"")
+		}
+		args[m[1]] = val
+	}
+	return args
+}
+
+// ---- 格式1: <vendor:tool_call> <invoke name="xxx"> <parameter name="yyy">zzz</parameter> </invoke> </vendor:tool_call>
+
+var vendorToolCallStartRegex = regexp.MustCompile(`<(\w+):tool_call>`)
+var invokeRegex = regexp.MustCompile(`(?s)<invoke\s+name="([^"]+)">\s*(.*?)\s*</invoke>`)
+var paramRegex = regexp.MustCompile(`(?s)<parameter\s+name="([^"]+)">(.*?)</parameter>`)
+
+type vendorTagParser struct{}
+
+func (vendorTagParser) Name() string { return "vendor:tool_call" }
+
+func (vendorTagParser) Parse(text string) ([]VendorToolCall, string) {
+	var toolCalls []VendorToolCall
+	cleanedText := text
+
+	for _, match := range vendorToolCallStartRegex.FindAllStringSubmatchIndex(text, -1) {
+		// match[0]:match[1] 是整个开始标签，match[2]:match[3] 是 vendor 名称
+		endTag := "</" + text[match[2]:match[3]] + ":tool_call>"
+		endPos := strings.Index(text[match[1]:], endTag)
+		if endPos == -1 {
+			continue
+		}
+		endPos += match[1]
+
+		for _, invoke := range invokeRegex.FindAllStringSubmatch(text[match[1]:endPos], -1) {
+			toolCalls = append(toolCalls, VendorToolCall{
+				Name: invoke[1],
+				Args: parseParams(paramRegex, invoke[2], false),
+			})
+		}
+
+		// 从文本中移除已解析的工具调用块
+		cleanedText = strings.Replace(cleanedText, text[match[0]:endPos+len(endTag)], "", 1)
+	}
+	return toolCalls, cleanedText
+}
+
+// ---- 格式2: <tool_call_begin>tool_name <param name="xxx">yyy</param> </tool_call_end>
+
+var toolCallBeginRegex = regexp.MustCompile(`(?s)<tool_call_begin>\s*(\w+)\s*(.*?)\s*</tool_call_end>`)
+var paramAltRegex = regexp.MustCompile(`(?s)<param\s+name="([^"]+)">(.*?)</param>`)
+
+type toolCallBeginParser struct{}
+
+func (toolCallBeginParser) Name() string { return "tool_call_begin" }
+
+func (toolCallBeginParser) Parse(text string) ([]VendorToolCall, string) {
+	var toolCalls []VendorToolCall
+	for _, match := range toolCallBeginRegex.FindAllStringSubmatch(text, -1) {
+		toolCalls = append(toolCalls, VendorToolCall{
+			Name: match[1],
+			Args: parseParams(paramAltRegex, match[2], true),
+		})
+		text = strings.Replace(text, match[0], "", 1)
+	}
+	return toolCalls, text
+}
+
+// ---- 格式3: <tool_call> <tool name="xxx"> <param name="yyy">zzz</param> </tool> </tool_call>
+// 同时兼容 Qwen / Hermes 原生格式：<tool_call>{"name": "xxx", "arguments": {...}}</tool_call>
+
+var toolCallWrapRegex = regexp.MustCompile(`(?s)<tool_call>\s*(.*?)\s*</tool_call>`)
+var toolTagRegex = regexp.MustCompile(`(?s)<tool\s+name="([^"]+)">\s*(.*?)\s*</tool>`)
+
+type toolCallWrapParser struct{}
+
+func (toolCallWrapParser) Name() string { return "tool_call" }
+
+func (toolCallWrapParser) Parse(text string) ([]VendorToolCall, string) {
+	var toolCalls []VendorToolCall
+	for _, match := range toolCallWrapRegex.FindAllStringSubmatch(text, -1) {
+		inner := match[1]
+		if calls, ok := parseJSONToolCalls(inner); ok {
+			toolCalls = append(toolCalls, calls...)
+		} else {
+			for _, tool := range toolTagRegex.FindAllStringSubmatch(inner, -1) {
+				toolCalls = append(toolCalls, VendorToolCall{
+					Name: tool[1],
+					Args: parseParams(paramAltRegex, tool[2], true),
+				})
+			}
+		}
+		text = strings.Replace(text, match[0], "", 1)
+	}
+	return toolCalls, text
+}
+
+// ---- 格式4: Qwen / GLM / Kimi 风格的 <|...|> 特殊标记
+// <|tool_call|>{"name": "xxx", "arguments": {...}}<|/tool_call|>（结束标记可省略）
+// <|tool_call_begin|>functions.xxx:0<|tool_call_argument_begin|>{...}<|tool_call_end|>
+
+var pipeSectionRegex = regexp.MustCompile(`<\|tool_calls?_section_(?:begin|end)\|>`)
+var pipeCallRegex = regexp.MustCompile(`(?s)<\|tool_call_begin\|>\s*(?:functions\.)?([\w.-]+?)(?::\d+)?\s*<\|tool_call_argument_begin\|>(.*?)<\|tool_call_end\|>`)
+
+const (
+	pipeCallMarker = "<|tool_call|>"
+	pipeCallEnd    = "<|/tool_call|>"
+)
+
+type pipeMarkerParser struct{}
+
+func (pipeMarkerParser) Name() string { return "<|tool_call|>" }
+
+func (pipeMarkerParser) Parse(text string) ([]VendorToolCall, string) {
+	if !strings.Contains(text, "<|tool_call") {
+		return nil, text
+	}
+
+	var toolCalls []VendorToolCall
+	for _, match := range pipeCallRegex.FindAllStringSubmatch(text, -1) {
+		args := make(map[string]any)
+		if err := json.Unmarshal([]byte(strings.TrimSpace(match[2])), &args); err != nil {
+			convertLog.Warn("解析 %s 参数失败: %v", match[1], err)
+			continue
+		}
+		toolCalls = append(toolCalls, VendorToolCall{Name: match[1], Args: args})
+		text = strings.Replace(text, match[0], "", 1)
+	}
+
+	// <|tool_call|> 后紧跟一个 JSON 对象，用 Decoder 读取以支持嵌套参数与省略结束标记
+	for {
+		start := strings.Index(text, pipeCallMarker)
+		if start == -1 {
+			break
+		}
+		rest := text[start+len(pipeCallMarker):]
+		dec := json.NewDecoder(strings.NewReader(rest))
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			// 无法解析时仅移除标记，避免原样展示给用户
+			text = text[:start] + rest
+			continue
+		}
+		if calls, ok := parseJSONToolCalls(string(raw)); ok {
+			toolCalls = append(toolCalls, calls...)
+		}
+		rest = strings.TrimSpace(rest[dec.InputOffset():])
+		rest = strings.TrimPrefix(rest, pipeCallEnd)
+		text = text[:start] + rest
+	}
+
+	return toolCalls, pipeSectionRegex.ReplaceAllString(text, "")
+}
+
+// ---- 格式5: Markdown 代码块中的 JSON 工具调用
+// ```json
+// {"name": "xxx", "arguments": {...}}
+// ```
+// 仅当代码块内容整体是工具调用结构时才视为调用，避免误伤正文中的 JSON 示例
+
+var fencedJSONRegex = regexp.MustCompile("(?s)```(?:json|tool_call|tool_code)?[ \\t]*\\n(.*?)\\n?```")
+
+type fencedJSONParser struct{}
+
+func (fencedJSONParser) Name() string { return "fenced json" }
+
+func (fencedJSONParser) Parse(text string) ([]VendorToolCall, string) {
+	if !strings.Contains(text, "```") {
+		return nil, text
+	}
+	var toolCalls []VendorToolCall
+	for _, match := range fencedJSONRegex.FindAllStringSubmatch(text, -1) {
+		calls, ok := parseJSONToolCalls(match[1])
+		if !ok {
+			continue
+		}
+		toolCalls = append(toolCalls, calls...)
+		text = strings.Replace(text, match[0], "", 1)
+	}
+	return toolCalls, text
+}
+
+// jsonToolCall JSON 形式的工具调用，兼容 arguments / parameters 以及 OpenAI 的 function 包装
+type jsonToolCall struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	Name       string          `json:"name"`
+	Arguments  json.RawMessage `json:"arguments"`
+	Parameters json.RawMessage `json:"parameters"`
+	Function   *jsonToolCall   `json:"function"`
+}
+
+// toolNameRegex 合法的工具名
+var toolNameRegex = regexp.MustCompile(`^[A-Za-z_][\w.-]*$`)
+
+// parseJSONToolCalls 解析 JSON 形式的工具调用：单个对象、对象数组或 {"tool_calls": [...]}
+// 只接受完全由工具调用字段组成且带参数的 JSON，第二个返回值表示内容是否为工具调用
+func parseJSONToolCalls(content string) ([]VendorToolCall, bool) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return nil, false
+	}
+
+	var items []json.RawMessage
+	switch content[0] {
+	case '[':
+		if json.Unmarshal([]byte(content), &items) != nil {
+			return nil, false
+		}
+	case '{':
+		var wrapper map[string]json.RawMessage
+		if json.Unmarshal([]byte(content), &wrapper) != nil {
+			return nil, false
+		}
+		if tc, ok := wrapper["tool_calls"]; ok && len(wrapper) == 1 {
+			if json.Unmarshal(tc, &items) != nil {
+				return nil, false
+			}
+		} else {
+			items = []json.RawMessage{json.RawMessage(content)}
+		}
+	default:
+		return nil, false
+	}
+
+	calls := make([]VendorToolCall, 0, len(items))
+	for _, item := range items {
+		call, ok := parseJSONToolCall(item)
+		if !ok {
+			return nil, false
+		}
+		calls = append(calls, call)
+	}
+	return calls, len(calls) > 0
+}
+
+// parseJSONToolCall 解析单个 JSON 工具调用
+func parseJSONToolCall(raw json.RawMessage) (VendorToolCall, bool) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	var tc jsonToolCall
+	if dec.Decode(&tc) != nil {
+		return VendorToolCall{}, false
+	}
+	if tc.Function != nil {
+		tc = *tc.Function
+	}
+	if !toolNameRegex.MatchString(tc.Name) {
+		return VendorToolCall{}, false
+	}
+
+	rawArgs := tc.Arguments
+	if len(rawArgs) == 0 {
+		rawArgs = tc.Parameters
+	}
+	// 必须带参数字段，避免把 {"name": "..."} 之类的普通 JSON 当成调用
+	if len(rawArgs) == 0 {
+		return VendorToolCall{}, false
+	}
+	args := make(map[string]any)
+	if string(rawArgs) != "null" {
+		// arguments 可能是 JSON 字符串（OpenAI 风格）或对象
+		var s string
+		if json.Unmarshal(rawArgs, &s) == nil {
+			rawArgs = json.RawMessage(s)
+		}
+		if json.Unmarshal(rawArgs, &args) != nil {
+			return VendorToolCall{}, false
+		}
+	}
+	return VendorToolCall{Name: tc.Name, Args: args}, true
+}
@@ -0,0 +1,96 @@
+package openai
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseVendorToolCalls(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		wantNames []string
+		wantText  string
+	}{
+		{
+			name:      "vendor invoke",
+			text:      `查一下<minimax:tool_call><invoke name="get_news"><parameter name="limit">5</parameter></invoke></minimax:tool_call>`,
+			wantNames: []string{"get_news"},
+			wantText:  "查一下",
+		},
+		{
+			name:      "tool_call_begin",
+			text:      `<tool_call_begin>get_stock_realtime <param name="codes">"sh600519"</param></tool_call_end>`,
+			wantNames: []string{"get_stock_realtime"},
+		},
+		{
+			name:      "hermes json",
+			text:      "<tool_call>\n{\"name\": \"get_news\", \"arguments\": {\"limit\": 5}}\n</tool_call>",
+			wantNames: []string{"get_news"},
+		},
+		{
+			name:      "pipe marker without end tag",
+			text:      `好的<|tool_call|>{"name":"get_kline","arguments":{"code":"sh600519","period":"1d"}}`,
+			wantNames: []string{"get_kline"},
+			wantText:  "好的",
+		},
+		{
+			name:      "kimi section",
+			text:      `<|tool_calls_section_begin|><|tool_call_begin|>functions.get_news:0<|tool_call_argument_begin|>{"limit":3}<|tool_call_end|><|tool_calls_section_end|>`,
+			wantNames: []string{"get_news"},
+		},
+		{
+			name:      "fenced json",
+			text:      "我来查询\n```json\n{\"type\":\"function\",\"function\":{\"name\":\"get_news\",\"arguments\":\"{\\\"limit\\\":3}\"}}\n```",
+			wantNames: []string{"get_news"},
+			wantText:  "我来查询",
+		},
+		{
+			name:     "fenced json that is not a call",
+			text:     "示例：\n```json\n{\"name\": \"茅台\", \"price\": 1500}\n```",
+			wantText: "示例：\n```json\n{\"name\": \"茅台\", \"price\": 1500}\n```",
+		},
+	}
+
+	for _, tt := range tests {
+		calls, cleaned := parseVendorToolCalls(tt.text)
+		var names []string
+		for _, c := range calls {
+			names = append(names, c.Name)
+		}
+		if strings.Join(names, ",") != strings.Join(tt.wantNames, ",") {
+			t.Errorf("%s: names = %v, want %v", tt.name, names, tt.wantNames)
+		}
+		if cleaned != tt.wantText {
+			t.Errorf("%s: cleaned = %q, want %q", tt.name, cleaned, tt.wantText)
+		}
+	}
+}
+
+type upperParser struct{}
+
+func (upperParser) Name() string { return "test" }
+
+func (upperParser) Parse(text string) ([]VendorToolCall, string) {
+	if !strings.Contains(text, "CALL:ping") {
+		return nil, text
+	}
+	return []VendorToolCall{{Name: "ping", Args: map[string]any{}}}, strings.ReplaceAll(text, "CALL:ping", "")
+}
+
+func TestRegisterVendorToolCallParser(t *testing.T) {
+	vendorParsersMu.RLock()
+	saved := vendorParsers
+	vendorParsersMu.RUnlock()
+	defer func() {
+		vendorParsersMu.Lock()
+		vendorParsers = saved
+		vendorParsersMu.Unlock()
+	}()
+
+	RegisterVendorToolCallParser(upperParser{})
+	calls, cleaned := parseVendorToolCalls("hi CALL:ping")
+	if len(calls) != 1 || calls[0].Name != "ping" || cleaned != "hi" {
+		t.Errorf("calls=%v cleaned=%q", calls, cleaned)
+	}
+}
@@ -0,0 +1,108 @@
+// Package tokencount 估算大模型 token 用量并按上下文窗口裁剪请求
+// 采用近似 tiktoken BPE 的切分规则估算（不依赖词表），用于在请求发出前预判是否超出窗口。
+//
+// 计数是估算值，不是真实分词结果：接入的模型（OpenAI、Claude、DeepSeek、Qwen、本地 Ollama 等）
+// 各自使用不同的分词器，内置某一家的 BPE 词表既不能覆盖其他模型，也会显著增大安装包。
+// 估算与实际计费 token 存在偏差，因此只用于裁剪判断，用量统计以模型返回的 usage 为准
+package tokencount
+
+import (
+	"encoding/json"
+	"unicode"
+	"unicode/utf8"
+
+	"google.golang.org/genai"
+)
+
+// 估算参数
+const (
+	charsPerWordToken = 4   // 英文单词约 4 个字符一个 token
+	digitsPerToken    = 3   // 数字按 3 位一组切分
+	messageOverhead   = 4   // 每条消息的角色与分隔开销
+	imageTokens       = 765 // 单张图片按中等分辨率估算
+)
+
+// Count 估算文本 token 数（估算值，见包说明）
+// 规则与 cl100k/o200k 的预切分一致：字母串按长度折算，数字 3 位一组，CJK 字符与标点各计 1 个，空白并入相邻 token
+func Count(text string) int {
+	tokens := 0
+	wordLen, digitLen := 0, 0
+	flush := func() {
+		tokens += ceilDiv(wordLen, charsPerWordToken) + ceilDiv(digitLen, digitsPerToken)
+		wordLen, digitLen = 0, 0
+	}
+
+	for len(text) > 0 {
+		r, size := utf8.DecodeRuneInString(text)
+		text = text[size:]
+		switch {
+		case r < utf8.RuneSelf && (unicode.IsLetter(r) || r == '\''):
+			if digitLen > 0 {
+				flush()
+			}
+			wordLen++
+		case unicode.IsDigit(r):
+			if wordLen > 0 {
+				flush()
+			}
+			digitLen++
+		case unicode.IsSpace(r):
+			flush()
+		default:
+			// CJK、其他非 ASCII 字符与标点
+			flush()
+			tokens++
+		}
+	}
+	flush()
+	return tokens
+}
+
+// CountContent 估算单条消息 token 数，包含函数调用、函数响应与图片
+func CountContent(content *genai.Content) int {
+	if content == nil {
+		return 0
+	}
+	tokens := messageOverhead
+	for _, part := range content.Parts {
+		if part == nil {
+			continue
+		}
+		tokens += Count(part.Text)
+		if fc := part.FunctionCall; fc != nil {
+			tokens += Count(fc.Name) + countJSON(fc.Args)
+		}
+		if fr := part.FunctionResponse; fr != nil {
+			tokens += Count(fr.Name) + countJSON(fr.Response)
+		}
+		if part.InlineData != nil || part.FileData != nil {
+			tokens += imageTokens
+		}
+	}
+	return tokens
+}
+
+// CountContents 估算消息列表 token 数
+func CountContents(contents []*genai.Content) int {
+	total := 0
+	for _, c := range contents {
+		total += CountContent(c)
+	}
+	return total
+}
+
+// countJSON 按 JSON 序列化结果估算
+func countJSON(v any) int {
+	if v == nil {
+		return 0
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return 0
+	}
+	return Count(string(b))
+}
+
+func ceilDiv(n, d int) int {
+	return (n + d - 1) / d
+}
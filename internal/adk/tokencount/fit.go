@@ -0,0 +1,155 @@
+package tokencount
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/run-bigpig/jcp/internal/logger"
+	"google.golang.org/adk/model"
+	"google.golang.org/genai"
+)
+
+var log = logger.New("tokencount")
+
+// defaultOutputReserve 未设置 MaxOutputTokens 时为输出预留的 token
+const defaultOutputReserve = 4096
+
+const (
+	digestReserve      = 512 // 为被丢弃轮次的摘要预留的 token
+	digestSnippetRunes = 60  // 摘要中每条问答截取的字符数
+)
+
+// Fit 按模型上下文窗口裁剪请求消息
+// 超出窗口时从最早的轮次开始丢弃，保证保留部分以用户消息开头且函数调用与响应不被拆开；
+// 被丢弃的轮次压缩为摘要（轮数与各轮问答节选），附在保留部分的第一条用户消息前，让模型知道省略了什么。
+// 未超出时原样返回，超出时返回浅拷贝，不修改调用方的请求
+func Fit(req *model.LLMRequest, modelName string) *model.LLMRequest {
+	if req == nil || len(req.Contents) == 0 {
+		return req
+	}
+
+	budget := ContextWindow(modelName) - fixedTokens(req)
+	total := CountContents(req.Contents)
+	if total <= budget {
+		return req
+	}
+
+	start := trimStart(req.Contents, total, budget-digestReserve)
+	if start == 0 {
+		return req
+	}
+	dropped := splitTurns(req.Contents[:start])
+	log.Warn("模型 [%s] 上下文超出窗口（估算 %d > %d），丢弃最早 %d 轮（%d 条消息）并以摘要代替",
+		modelName, total, budget, len(dropped), start)
+
+	first := req.Contents[start]
+	withDigest := &genai.Content{Role: first.Role, Parts: append([]*genai.Part{{Text: digest(dropped)}}, first.Parts...)}
+	fitted := *req
+	fitted.Contents = append([]*genai.Content{withDigest}, req.Contents[start+1:]...)
+	return &fitted
+}
+
+// fixedTokens 系统指令、工具声明与输出预留占用的 token
+func fixedTokens(req *model.LLMRequest) int {
+	cfg := req.Config
+	if cfg == nil {
+		return defaultOutputReserve
+	}
+	tokens := defaultOutputReserve
+	if cfg.MaxOutputTokens > 0 {
+		tokens = int(cfg.MaxOutputTokens)
+	}
+	tokens += CountContent(cfg.SystemInstruction)
+	for _, t := range cfg.Tools {
+		if t != nil {
+			tokens += countJSON(t.FunctionDeclarations)
+		}
+	}
+	return tokens
+}
+
+// trimStart 计算需要丢弃的消息数，只在用户发起的新轮次处截断；最后一轮始终保留
+func trimStart(contents []*genai.Content, total, budget int) int {
+	start := 0
+	for i := 1; i < len(contents) && total > budget; i++ {
+		if isTurnStart(contents[i]) {
+			total -= CountContents(contents[start:i])
+			start = i
+		}
+	}
+	return start
+}
+
+// isTurnStart 是否为用户发起的新轮次（用户文本，而非函数响应）
+func isTurnStart(c *genai.Content) bool {
+	if c == nil || c.Role != genai.RoleUser {
+		return false
+	}
+	for _, p := range c.Parts {
+		if p != nil && p.FunctionResponse != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// splitTurns 按用户发起的新轮次切分消息
+func splitTurns(contents []*genai.Content) [][]*genai.Content {
+	var turns [][]*genai.Content
+	for i, c := range contents {
+		if i == 0 || isTurnStart(c) {
+			turns = append(turns, nil)
+		}
+		turns[len(turns)-1] = append(turns[len(turns)-1], c)
+	}
+	return turns
+}
+
+// digest 生成被丢弃轮次的摘要：每轮一行（提问与最后的回答节选），超出预留时只保留较近的轮次
+func digest(turns [][]*genai.Content) string {
+	header := fmt.Sprintf("[上下文摘要] 为适应模型上下文窗口，已省略较早的 %d 轮对话：", len(turns))
+	budget := digestReserve - Count(header)
+	var lines []string
+	for i := len(turns) - 1; i >= 0; i-- {
+		line := "- 问：" + snippet(turnText(turns[i], genai.RoleUser, false))
+		if answer := turnText(turns[i], genai.RoleModel, true); answer != "" {
+			line += " 答：" + snippet(answer)
+		}
+		if budget -= Count(line); budget < 0 {
+			break
+		}
+		lines = append(lines, line)
+	}
+	slices.Reverse(lines)
+	return strings.Join(append([]string{header}, lines...), "\n")
+}
+
+// turnText 轮次中指定角色的第一条（last 为 true 时取最后一条）文本
+func turnText(turn []*genai.Content, role string, last bool) string {
+	text := ""
+	for _, c := range turn {
+		if c == nil || c.Role != role {
+			continue
+		}
+		for _, p := range c.Parts {
+			if p != nil && strings.TrimSpace(p.Text) != "" {
+				text = p.Text
+				if !last {
+					return text
+				}
+			}
+		}
+	}
+	return text
+}
+
+// snippet 截取单行节选
+func snippet(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= digestSnippetRunes {
+		return text
+	}
+	return string([]rune(text)[:digestSnippetRunes]) + "…"
+}
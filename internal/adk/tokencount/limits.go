@@ -0,0 +1,47 @@
+package tokencount
+
+import "strings"
+
+// DefaultContextWindow 未知模型的上下文窗口
+const DefaultContextWindow = 32768
+
+// contextWindows 模型名前缀对应的上下文窗口，按顺序匹配（更具体的前缀在前）
+var contextWindows = []struct {
+	prefix string
+	tokens int
+}{
+	{"gpt-5", 400000},
+	{"gpt-4.1", 1000000},
+	{"gpt-4o", 128000},
+	{"gpt-4-turbo", 128000},
+	{"gpt-4", 8192},
+	{"gpt-3.5", 16385},
+	{"o1", 200000},
+	{"o3", 200000},
+	{"o4", 200000},
+	{"claude", 200000},
+	{"gemini", 1000000},
+	{"deepseek", 128000},
+	{"qwen", 128000},
+	{"glm", 128000},
+	{"kimi", 128000},
+	{"moonshot", 128000},
+	{"doubao", 128000},
+	{"llama", 128000},
+	{"mistral", 32768},
+}
+
+// ContextWindow 返回模型的上下文窗口大小
+// 忽略厂商前缀（如 deepseek-ai/DeepSeek-V3）与大小写
+func ContextWindow(modelName string) int {
+	name := strings.ToLower(modelName)
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	for _, w := range contextWindows {
+		if strings.HasPrefix(name, w.prefix) {
+			return w.tokens
+		}
+	}
+	return DefaultContextWindow
+}
@@ -0,0 +1,105 @@
+package tokencount
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"google.golang.org/adk/model"
+	"google.golang.org/genai"
+)
+
+func TestCount(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"", 0},
+		{"hello world", 4},
+		{"贵州茅台", 4},
+		{"price 1500.25", 6},
+	}
+	for _, tt := range tests {
+		if got := Count(tt.text); got != tt.want {
+			t.Errorf("Count(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestContextWindow(t *testing.T) {
+	tests := map[string]int{
+		"gpt-4o-mini":              128000,
+		"gpt-4":                    8192,
+		"claude-sonnet-4-5":        200000,
+		"deepseek-ai/DeepSeek-V3":  128000,
+		"some-unknown-local-model": DefaultContextWindow,
+	}
+	for name, want := range tests {
+		if got := ContextWindow(name); got != want {
+			t.Errorf("ContextWindow(%q) = %d, want %d", name, got, want)
+		}
+	}
+}
+
+func TestFit(t *testing.T) {
+	long := strings.Repeat("行情", 3000) // 约 6000 token
+	user := func(text string) *genai.Content {
+		return &genai.Content{Role: genai.RoleUser, Parts: []*genai.Part{{Text: text}}}
+	}
+	contents := []*genai.Content{
+		user(long),
+		{Role: genai.RoleModel, Parts: []*genai.Part{{FunctionCall: &genai.FunctionCall{Name: "get_news"}}}},
+		{Role: genai.RoleUser, Parts: []*genai.Part{{FunctionResponse: &genai.FunctionResponse{Name: "get_news", Response: map[string]any{"data": long}}}}},
+		{Role: genai.RoleModel, Parts: []*genai.Part{{Text: "ok"}}},
+		user(long),
+		{Role: genai.RoleModel, Parts: []*genai.Part{{Text: "ok"}}},
+		user("继续"),
+	}
+	req := &model.LLMRequest{Contents: contents, Config: &genai.GenerateContentConfig{MaxOutputTokens: 1000}}
+
+	// 8192 窗口：只能保留最后两轮，丢弃的一轮以摘要附在第一条用户消息前
+	fitted := Fit(req, "gpt-4")
+	if fitted == req || len(fitted.Contents) != 3 || fitted.Contents[1] != contents[5] {
+		t.Fatalf("fitted contents = %d, want last 3", len(fitted.Contents))
+	}
+	first := fitted.Contents[0]
+	if first.Role != genai.RoleUser || len(first.Parts) != 2 || first.Parts[1] != contents[4].Parts[0] {
+		t.Fatalf("first content = %+v", first)
+	}
+	if d := first.Parts[0].Text; !strings.Contains(d, "已省略较早的 1 轮对话") || !strings.Contains(d, "答：ok") {
+		t.Errorf("digest = %q", d)
+	}
+	if total := CountContents(fitted.Contents); total > ContextWindow("gpt-4")-1000 {
+		t.Errorf("fitted total %d exceeds budget", total)
+	}
+	if len(req.Contents) != len(contents) || len(contents[4].Parts) != 1 {
+		t.Error("original request should not be modified")
+	}
+
+	// 窗口足够时原样返回
+	if got := Fit(req, "claude-sonnet-4-5"); got != req {
+		t.Error("request within window should be returned as is")
+	}
+}
+
+func TestDigest(t *testing.T) {
+	var turns [][]*genai.Content
+	for i := range 200 {
+		turns = append(turns, []*genai.Content{
+			{Role: genai.RoleUser, Parts: []*genai.Part{{Text: fmt.Sprintf("第%d轮提问 %s", i, strings.Repeat("行情", 50))}}},
+			{Role: genai.RoleModel, Parts: []*genai.Part{{Text: "中间"}}},
+			{Role: genai.RoleModel, Parts: []*genai.Part{{Text: fmt.Sprintf("第%d轮回答", i)}}},
+		})
+	}
+	d := digest(turns)
+	if !strings.Contains(d, "已省略较早的 200 轮对话") {
+		t.Errorf("digest header = %q", d)
+	}
+	// 超出预留时保留较近的轮次，节选截断并取最后的回答
+	if !strings.Contains(d, "第199轮提问") || strings.Contains(d, "第0轮提问") || !strings.Contains(d, "答：第199轮回答") {
+		t.Errorf("digest should keep the most recent turns: %q", d)
+	}
+	if Count(d) > digestReserve {
+		t.Errorf("digest tokens %d > %d", Count(d), digestReserve)
+	}
+}
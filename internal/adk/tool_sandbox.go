@@ -0,0 +1,414 @@
+package adk
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/run-bigpig/jcp/internal/models"
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+	"google.golang.org/genai"
+)
+
+const (
+	defaultToolTimeout   = 30 * time.Second
+	defaultToolAuditDays = 30
+	toolAuditMaxResult   = 2000 // 审计结果最多保留字节数
+	toolRateWindow       = time.Minute
+)
+
+// functionTool ADK 可执行工具需实现的接口（与 toolinternal.FunctionTool 一致）
+type functionTool interface {
+	tool.Tool
+	Declaration() *genai.FunctionDeclaration
+	Run(ctx tool.Context, args any) (map[string]any, error)
+}
+
+// requestProcessor 向 LLM 请求注册工具声明
+type requestProcessor interface {
+	ProcessRequest(ctx tool.Context, req *model.LLMRequest) error
+}
+
+// ToolSandbox 工具执行沙箱
+// 所有 Agent 工具调用都会经过它：按工具限流、校验参数、限制执行时长，并将调用与结果写入审计日志
+type ToolSandbox struct {
+	mu       sync.Mutex
+	dir      string
+	config   models.ToolSandboxConfig
+	policies map[string]models.ToolPolicy
+	calls    map[string][]time.Time // 各工具最近一分钟的调用时间
+}
+
+var (
+	sandboxInstance *ToolSandbox
+	sandboxOnce     sync.Once
+)
+
+// GetToolSandbox 获取工具沙箱单例
+func GetToolSandbox() *ToolSandbox {
+	sandboxOnce.Do(func() {
+		sandboxInstance = &ToolSandbox{
+			policies: make(map[string]models.ToolPolicy),
+			calls:    make(map[string][]time.Time),
+		}
+	})
+	return sandboxInstance
+}
+
+// Load 设置审计日志目录并清理过期日志
+func (s *ToolSandbox) Load(dataDir string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dir = filepath.Join(dataDir, "tool_audit")
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		log.Warn("创建工具审计目录失败: %v", err)
+		return
+	}
+	s.pruneLocked(time.Now())
+}
+
+// SetConfig 更新沙箱配置
+func (s *ToolSandbox) SetConfig(cfg models.ToolSandboxConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.config = cfg
+	s.policies = make(map[string]models.ToolPolicy, len(cfg.Policies))
+	for _, p := range cfg.Policies {
+		s.policies[p.Tool] = p
+	}
+}
+
+// WrapTools 为工具套上沙箱
+func (s *ToolSandbox) WrapTools(tools []tool.Tool) []tool.Tool {
+	result := make([]tool.Tool, 0, len(tools))
+	for _, t := range tools {
+		result = append(result, s.wrap(t))
+	}
+	return result
+}
+
+// WrapToolsets 为工具集（如 MCP）中的工具套上沙箱
+func (s *ToolSandbox) WrapToolsets(toolsets []tool.Toolset) []tool.Toolset {
+	result := make([]tool.Toolset, 0, len(toolsets))
+	for _, ts := range toolsets {
+		result = append(result, &sandboxedToolset{Toolset: ts, sandbox: s})
+	}
+	return result
+}
+
+// wrap 仅包装可执行的函数工具，其他工具原样返回
+func (s *ToolSandbox) wrap(t tool.Tool) tool.Tool {
+	ft, ok := t.(functionTool)
+	if !ok {
+		return t
+	}
+	if _, wrapped := t.(*sandboxedTool); wrapped {
+		return t
+	}
+	return &sandboxedTool{functionTool: ft, sandbox: s}
+}
+
+// limits 获取工具的超时与限流设置
+func (s *ToolSandbox) limits(name string) (time.Duration, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	timeout := defaultToolTimeout
+	if s.config.DefaultTimeout > 0 {
+		timeout = time.Duration(s.config.DefaultTimeout) * time.Second
+	}
+	rate := s.config.DefaultRateLimit
+	if p, ok := s.policies[name]; ok {
+		if p.Timeout > 0 {
+			timeout = time.Duration(p.Timeout) * time.Second
+		}
+		if p.RateLimit > 0 {
+			rate = p.RateLimit
+		}
+	}
+	return timeout, rate
+}
+
+// allow 滑动窗口限流，rate<=0 表示不限
+func (s *ToolSandbox) allow(name string, rate int, now time.Time) bool {
+	if rate <= 0 {
+		return true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	recent := s.calls[name][:0]
+	for _, t := range s.calls[name] {
+		if now.Sub(t) < toolRateWindow {
+			recent = append(recent, t)
+		}
+	}
+	if len(recent) >= rate {
+		s.calls[name] = recent
+		return false
+	}
+	s.calls[name] = append(recent, now)
+	return true
+}
+
+// record 追加一条审计记录到当日日志
+func (s *ToolSandbox) record(entry models.ToolAuditEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.dir == "" {
+		return
+	}
+	date := time.UnixMilli(entry.Time).Format("2006-01-02")
+	f, err := os.OpenFile(filepath.Join(s.dir, date+".jsonl"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		log.Warn("写入工具审计日志失败: %v", err)
+		return
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		log.Warn("写入工具审计日志失败: %v", err)
+	}
+}
+
+// AuditLog 查询审计日志（按时间倒序）
+// date 为空时查询当天，toolName 为空时不过滤，limit<=0 时返回全部
+func (s *ToolSandbox) AuditLog(date, toolName string, limit int) []models.ToolAuditEntry {
+	if date == "" {
+		date = time.Now().Format("2006-01-02")
+	}
+	s.mu.Lock()
+	dir := s.dir
+	s.mu.Unlock()
+	result := []models.ToolAuditEntry{}
+	if dir == "" || strings.ContainsAny(date, `/\`) {
+		return result
+	}
+	f, err := os.Open(filepath.Join(dir, date+".jsonl"))
+	if err != nil {
+		return result
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry models.ToolAuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		if toolName == "" || entry.Tool == toolName {
+			result = append(result, entry)
+		}
+	}
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
+// pruneLocked 删除超过保留天数的审计日志(需要已持有锁)
+func (s *ToolSandbox) pruneLocked(now time.Time) {
+	days := s.config.AuditDays
+	if days <= 0 {
+		days = defaultToolAuditDays
+	}
+	cutoff := now.AddDate(0, 0, -days).Format("2006-01-02")
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		date, ok := strings.CutSuffix(entry.Name(), ".jsonl")
+		if ok && date < cutoff {
+			os.Remove(filepath.Join(s.dir, entry.Name()))
+		}
+	}
+}
+
+// sandboxedTool 经过沙箱的函数工具
+type sandboxedTool struct {
+	functionTool
+	sandbox *ToolSandbox
+
+	schemaOnce sync.Once
+	schema     *jsonschema.Resolved
+}
+
+// ProcessRequest 注册工具声明，并将请求中的工具替换为沙箱包装后的自身
+func (t *sandboxedTool) ProcessRequest(ctx tool.Context, req *model.LLMRequest) error {
+	if p, ok := t.functionTool.(requestProcessor); ok {
+		if err := p.ProcessRequest(ctx, req); err != nil {
+			return err
+		}
+	}
+	if req.Tools == nil {
+		req.Tools = make(map[string]any)
+	}
+	req.Tools[t.Name()] = t
+	return nil
+}
+
+// Run 限流、校验参数后在超时限制内执行工具，并记录审计日志
+func (t *sandboxedTool) Run(ctx tool.Context, args any) (result map[string]any, err error) {
+	start := time.Now()
+	name := t.Name()
+	entry := models.ToolAuditEntry{
+		Time:    start.UnixMilli(),
+		Tool:    name,
+		Agent:   ctx.AgentName(),
+		Session: usageSessionFromContext(ctx),
+		Args:    compactJSON(args),
+		Status:  models.ToolCallOK,
+	}
+	defer func() {
+		entry.Duration = time.Since(start).Milliseconds()
+		if err != nil {
+			entry.Error = err.Error()
+		} else {
+			entry.Result = truncateBytes(compactJSON(result), toolAuditMaxResult)
+		}
+		t.sandbox.record(entry)
+	}()
+
+	timeout, rate := t.sandbox.limits(name)
+	if !t.sandbox.allow(name, rate, start) {
+		entry.Status = models.ToolCallRateLimited
+		return nil, fmt.Errorf("工具 %s 调用过于频繁（每分钟最多 %d 次），请稍后再试或改用已获取的数据", name, rate)
+	}
+	if err := t.validate(args); err != nil {
+		entry.Status = models.ToolCallInvalid
+		return nil, fmt.Errorf("工具 %s 参数校验失败: %v", name, err)
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	type runResult struct {
+		result map[string]any
+		err    error
+	}
+	done := make(chan runResult, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- runResult{err: fmt.Errorf("工具 %s 执行异常: %v", name, r)}
+			}
+		}()
+		res, err := t.functionTool.Run(&timeoutToolContext{Context: ctx, ctx: runCtx}, args)
+		done <- runResult{res, err}
+	}()
+
+	select {
+	case r := <-done:
+		if r.err != nil {
+			entry.Status = models.ToolCallError
+		}
+		return r.result, r.err
+	case <-runCtx.Done():
+		if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+			entry.Status = models.ToolCallTimeout
+			return nil, fmt.Errorf("工具 %s 执行超时（%s）", name, timeout)
+		}
+		entry.Status = models.ToolCallError
+		return nil, runCtx.Err()
+	}
+}
+
+// validate 按工具声明的参数 schema 校验调用参数，schema 无法解析时跳过校验
+func (t *sandboxedTool) validate(args any) error {
+	t.schemaOnce.Do(func() {
+		t.schema = resolveToolSchema(t.Declaration())
+	})
+	if t.schema == nil {
+		return nil
+	}
+	return t.schema.Validate(args)
+}
+
+// resolveToolSchema 解析工具参数 schema，兼容 jsonschema 对象与 MCP 返回的任意 JSON
+func resolveToolSchema(decl *genai.FunctionDeclaration) *jsonschema.Resolved {
+	if decl == nil || decl.ParametersJsonSchema == nil {
+		return nil
+	}
+	data, err := json.Marshal(decl.ParametersJsonSchema)
+	if err != nil {
+		return nil
+	}
+	var schema jsonschema.Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		log.Warn("解析工具 %s 参数 schema 失败: %v", decl.Name, err)
+		return nil
+	}
+	// 仅支持 draft 2020-12 校验，其他版本声明按同等规则处理
+	schema.Schema = ""
+	resolved, err := schema.Resolve(nil)
+	if err != nil {
+		log.Warn("解析工具 %s 参数 schema 失败: %v", decl.Name, err)
+		return nil
+	}
+	return resolved
+}
+
+// timeoutToolContext 为工具上下文附加超时
+type timeoutToolContext struct {
+	tool.Context
+	ctx context.Context
+}
+
+func (c *timeoutToolContext) Deadline() (time.Time, bool) { return c.ctx.Deadline() }
+func (c *timeoutToolContext) Done() <-chan struct{}       { return c.ctx.Done() }
+func (c *timeoutToolContext) Err() error                  { return c.ctx.Err() }
+func (c *timeoutToolContext) Value(key any) any           { return c.ctx.Value(key) }
+
+// sandboxedToolset 经过沙箱的工具集
+type sandboxedToolset struct {
+	tool.Toolset
+	sandbox *ToolSandbox
+}
+
+// Tools 返回包装后的工具
+func (ts *sandboxedToolset) Tools(ctx agent.ReadonlyContext) ([]tool.Tool, error) {
+	tools, err := ts.Toolset.Tools(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ts.sandbox.WrapTools(tools), nil
+}
+
+// compactJSON 序列化为紧凑 JSON，失败时返回空字符串
+func compactJSON(v any) string {
+	if v == nil {
+		return ""
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// truncateBytes 按字节截断字符串，保证不截断多字节字符
+func truncateBytes(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n] + "..."
+}
@@ -0,0 +1,93 @@
+package adk
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// fakeToolContext 仅提供沙箱用到的上下文能力
+type fakeToolContext struct {
+	tool.Context
+	ctx context.Context
+}
+
+func (c *fakeToolContext) AgentName() string                  { return "tester" }
+func (c *fakeToolContext) Deadline() (time.Time, bool)        { return c.ctx.Deadline() }
+func (c *fakeToolContext) Done() <-chan struct{}              { return c.ctx.Done() }
+func (c *fakeToolContext) Err() error                         { return c.ctx.Err() }
+func (c *fakeToolContext) Value(key any) any                  { return c.ctx.Value(key) }
+func newFakeToolContext(ctx context.Context) *fakeToolContext { return &fakeToolContext{ctx: ctx} }
+
+type sleepInput struct {
+	Code string `json:"code" jsonschema:"股票代码"`
+	Wait int    `json:"wait,omitzero" jsonschema:"等待毫秒数"`
+}
+
+type sleepOutput struct {
+	Data string `json:"data"`
+}
+
+func newSleepTool(t *testing.T) tool.Tool {
+	t.Helper()
+	st, err := functiontool.New(functiontool.Config{Name: "sleep", Description: "test"},
+		func(ctx tool.Context, in sleepInput) (sleepOutput, error) {
+			select {
+			case <-time.After(time.Duration(in.Wait) * time.Millisecond):
+				return sleepOutput{Data: in.Code}, nil
+			case <-ctx.Done():
+				return sleepOutput{}, ctx.Err()
+			}
+		})
+	if err != nil {
+		t.Fatalf("functiontool.New: %v", err)
+	}
+	return st
+}
+
+func TestToolSandboxRun(t *testing.T) {
+	sb := &ToolSandbox{calls: make(map[string][]time.Time)}
+	sb.SetConfig(models.ToolSandboxConfig{
+		Policies: []models.ToolPolicy{{Tool: "sleep", Timeout: 1, RateLimit: 3}},
+	})
+	sb.Load(t.TempDir())
+
+	wrapped := sb.WrapTools([]tool.Tool{newSleepTool(t)})[0].(functionTool)
+	ctx := newFakeToolContext(WithUsageSession(context.Background(), "sh600519"))
+
+	res, err := wrapped.Run(ctx, map[string]any{"code": "sh600519", "wait": float64(1)})
+	if err != nil || res["data"] != "sh600519" {
+		t.Fatalf("Run = %v, %v", res, err)
+	}
+	if _, err := wrapped.Run(ctx, map[string]any{"code": "sh600519", "wait": "soon"}); err == nil || !strings.Contains(err.Error(), "参数校验失败") {
+		t.Fatalf("invalid args err = %v", err)
+	}
+	if _, err := wrapped.Run(ctx, map[string]any{"code": "sh600519", "wait": float64(3000)}); err == nil || !strings.Contains(err.Error(), "超时") {
+		t.Fatalf("timeout err = %v", err)
+	}
+	if _, err := wrapped.Run(ctx, map[string]any{"code": "sh600519"}); err == nil || !strings.Contains(err.Error(), "过于频繁") {
+		t.Fatalf("rate limit err = %v", err)
+	}
+
+	entries := sb.AuditLog("", "sleep", 0)
+	want := []string{models.ToolCallRateLimited, models.ToolCallTimeout, models.ToolCallInvalid, models.ToolCallOK}
+	if len(entries) != len(want) {
+		t.Fatalf("audit entries = %d, want %d", len(entries), len(want))
+	}
+	for i, e := range entries {
+		if e.Status != want[i] || e.Agent != "tester" || e.Session != "sh600519" {
+			t.Errorf("entry %d = %+v, want status %s", i, e, want[i])
+		}
+	}
+	if entries[3].Result != `{"data":"sh600519"}` {
+		t.Errorf("result = %q", entries[3].Result)
+	}
+	if got := sb.AuditLog("", "", 2); len(got) != 2 {
+		t.Errorf("AuditLog limit = %d, want 2", len(got))
+	}
+}
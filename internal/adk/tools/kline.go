@@ -38,7 +38,7 @@ func (r *Registry) createKLineTool() (tool.Tool, error) {
 			days = 30
 		}
 
-		klines, err := r.marketService.GetKLineData(input.Code, period, days)
+		klines, err := r.marketService.GetKLineData(ctx, input.Code, period, days)
 		if err != nil {
 			fmt.Printf("[Tool:get_kline_data] 错误: %v\n", err)
 			return GetKLineOutput{}, err
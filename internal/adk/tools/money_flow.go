@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"fmt"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// GetMoneyFlowInput 资金流向输入参数
+type GetMoneyFlowInput struct {
+	Code string `json:"code" jsonschema:"股票代码，如 sh600519"`
+	Days int    `json:"days,omitzero" jsonschema:"获取交易日数，默认10"`
+}
+
+// GetMoneyFlowOutput 资金流向输出
+type GetMoneyFlowOutput struct {
+	Data string `json:"data" jsonschema:"每日资金流向数据"`
+}
+
+// createMoneyFlowTool 创建资金流向工具
+func (r *Registry) createMoneyFlowTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetMoneyFlowInput) (GetMoneyFlowOutput, error) {
+		fmt.Printf("[Tool:get_money_flow] 调用开始, code=%s, days=%d\n", input.Code, input.Days)
+
+		if input.Code == "" {
+			fmt.Println("[Tool:get_money_flow] 错误: 未提供股票代码")
+			return GetMoneyFlowOutput{Data: "请提供股票代码"}, nil
+		}
+
+		days := input.Days
+		if days == 0 {
+			days = 10
+		}
+
+		flows, err := r.marketService.GetMoneyFlow(ctx, input.Code, days)
+		if err != nil {
+			fmt.Printf("[Tool:get_money_flow] 错误: %v\n", err)
+			return GetMoneyFlowOutput{}, err
+		}
+
+		// 格式化输出（金额换算为万元）
+		var result string
+		for _, f := range flows {
+			result += fmt.Sprintf("%s: 主力净流入%.0f万(占比%.2f%%) 超大单%.0f万 大单%.0f万 中单%.0f万 小单%.0f万 收%.2f(%+.2f%%)\n",
+				f.Date, f.MainNet/1e4, f.MainNetPercent, f.SuperLargeNet/1e4, f.LargeNet/1e4,
+				f.MediumNet/1e4, f.SmallNet/1e4, f.Close, f.ChangePercent)
+		}
+		if result == "" {
+			result = "暂无资金流向数据"
+		}
+
+		fmt.Printf("[Tool:get_money_flow] 调用完成, 返回%d条数据\n", len(flows))
+		return GetMoneyFlowOutput{Data: result}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_money_flow",
+		Description: "获取个股每日资金流向，包括主力、超大单、大单、中单、小单净流入金额",
+	}, handler)
+}
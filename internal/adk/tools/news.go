@@ -22,7 +22,7 @@ func (r *Registry) createNewsTool() (tool.Tool, error) {
 	handler := func(ctx tool.Context, input GetNewsInput) (GetNewsOutput, error) {
 		fmt.Printf("[Tool:get_news] 调用开始, limit=%d\n", input.Limit)
 
-		news, err := r.newsService.GetTelegraphList()
+		news, err := r.newsService.GetTelegraphList(ctx)
 		if err != nil {
 			fmt.Printf("[Tool:get_news] 错误: %v\n", err)
 			return GetNewsOutput{}, err
@@ -27,7 +27,7 @@ func (r *Registry) createOrderBookTool() (tool.Tool, error) {
 			return GetOrderBookOutput{Data: "请提供股票代码"}, nil
 		}
 
-		ob, err := r.marketService.GetRealOrderBook(input.Code)
+		ob, err := r.marketService.GetRealOrderBook(ctx, input.Code)
 		if err != nil {
 			fmt.Printf("[Tool:get_orderbook] 错误: %v\n", err)
 			return GetOrderBookOutput{}, err
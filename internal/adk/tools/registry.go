@@ -59,6 +59,9 @@ func (r *Registry) registerAllTools() {
 	// 注册盘口数据工具
 	r.registerTool("get_orderbook", "获取股票五档盘口数据，包括买卖五档价格和数量", r.createOrderBookTool)
 
+	// 注册资金流向工具
+	r.registerTool("get_money_flow", "获取个股每日资金流向，包括主力、超大单、大单、中单、小单净流入金额", r.createMoneyFlowTool)
+
 	// 注册快讯工具
 	r.registerTool("get_news", "获取最新财经快讯，来源于财联社", r.createNewsTool)
 
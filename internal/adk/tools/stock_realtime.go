@@ -28,7 +28,7 @@ func (r *Registry) createStockRealtimeTool() (tool.Tool, error) {
 			return GetStockRealtimeOutput{Data: "请提供股票代码"}, nil
 		}
 
-		stocks, err := r.marketService.GetStockRealTimeData(input.Codes...)
+		stocks, err := r.marketService.GetStockRealTimeData(ctx, input.Codes...)
 		if err != nil {
 			fmt.Printf("[Tool:get_stock_realtime] 错误: %v\n", err)
 			return GetStockRealtimeOutput{}, err
@@ -43,7 +43,7 @@ func (r *Registry) createStockRealtimeTool() (tool.Tool, error) {
 
 		// 获取大盘指数数据
 		var marketIndexResult string
-		indices, err := r.marketService.GetMarketIndices()
+		indices, err := r.marketService.GetMarketIndices(ctx)
 		if err != nil {
 			fmt.Printf("[Tool:get_stock_realtime] 获取大盘指数失败: %v\n", err)
 		} else {
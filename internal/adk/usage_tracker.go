@@ -0,0 +1,416 @@
+package adk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/apperr"
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/model"
+	"google.golang.org/genai"
+)
+
+// ErrBudgetExceeded AI 费用超出预算
+var ErrBudgetExceeded = apperr.New(apperr.CodeBudget, "AI 费用已超出预算")
+
+// 预算超限范围
+const (
+	BudgetScopeDaily   = "daily"
+	BudgetScopeSession = "session"
+)
+
+// BudgetExceededEvent 预算超限事件
+type BudgetExceededEvent struct {
+	Scope     string  `json:"scope"`               // daily / session
+	SessionID string  `json:"sessionId,omitempty"` // 超限的会话（股票代码）
+	Spent     float64 `json:"spent"`               // 已花费(元)
+	Limit     float64 `json:"limit"`               // 上限(元)
+}
+
+// UsageStats token 用量与估算费用
+type UsageStats struct {
+	Calls            int     `json:"calls"`
+	PromptTokens     int64   `json:"promptTokens"`
+	CompletionTokens int64   `json:"completionTokens"`
+	ThoughtsTokens   int64   `json:"thoughtsTokens"`
+	TotalTokens      int64   `json:"totalTokens"`
+	Cost             float64 `json:"cost"` // 估算费用(元)
+}
+
+// add 累加一次调用的用量
+func (s *UsageStats) add(usage *genai.GenerateContentResponseUsageMetadata, cost float64) {
+	s.Calls++
+	s.PromptTokens += int64(usage.PromptTokenCount)
+	s.CompletionTokens += int64(usage.CandidatesTokenCount)
+	s.ThoughtsTokens += int64(usage.ThoughtsTokenCount)
+	s.TotalTokens += int64(usage.PromptTokenCount + usage.CandidatesTokenCount + usage.ThoughtsTokenCount)
+	s.Cost += cost
+}
+
+// UsageSummary 费用统计摘要
+type UsageSummary struct {
+	Date          string                `json:"date"`
+	DailyCost     float64               `json:"dailyCost"`    // 今日花费(元)
+	SessionCosts  map[string]float64    `json:"sessionCosts"` // 各会话累计花费(元)
+	Budget        models.AIBudgetConfig `json:"budget"`
+	OverrideUntil int64                 `json:"overrideUntil"` // 临时放行截止时间(毫秒)，0 表示未放行
+	// 今日 token 用量明细
+	Models   map[string]UsageStats `json:"models"`   // 按模型（provider:model）
+	Agents   map[string]UsageStats `json:"agents"`   // 按 Agent 名称
+	Sessions map[string]UsageStats `json:"sessions"` // 按会话（股票代码）
+}
+
+// usageFile 持久化的费用数据
+type usageFile struct {
+	Date         string                `json:"date"`
+	DailyCost    float64               `json:"dailyCost"`
+	SessionCosts map[string]float64    `json:"sessionCosts"`
+	Models       map[string]UsageStats `json:"models,omitempty"`
+	Agents       map[string]UsageStats `json:"agents,omitempty"`
+	Sessions     map[string]UsageStats `json:"sessions,omitempty"`
+}
+
+// UsageTracker 大模型用量与费用跟踪器
+// 所有由 ModelFactory 创建的模型都会经过它：调用前检查预算，调用后按单价累计费用
+type UsageTracker struct {
+	mu            sync.Mutex
+	filePath      string
+	date          string
+	dailyCost     float64
+	sessionCosts  map[string]float64
+	budget        models.AIBudgetConfig
+	overrideUntil time.Time
+	notified      map[string]bool // 已通知的超限范围，避免并发 Agent 重复弹出
+	onExceeded    func(BudgetExceededEvent)
+	onUpdate      func(UsageSummary)
+	// 今日 token 用量明细
+	modelStats   map[string]UsageStats
+	agentStats   map[string]UsageStats
+	sessionStats map[string]UsageStats
+}
+
+var (
+	trackerInstance *UsageTracker
+	trackerOnce     sync.Once
+)
+
+// GetUsageTracker 获取用量跟踪器单例
+func GetUsageTracker() *UsageTracker {
+	trackerOnce.Do(func() {
+		trackerInstance = &UsageTracker{
+			sessionCosts: make(map[string]float64),
+			notified:     make(map[string]bool),
+		}
+	})
+	return trackerInstance
+}
+
+// Load 从数据目录加载已记录的费用
+func (t *UsageTracker) Load(dataDir string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.filePath = filepath.Join(dataDir, "ai_usage.json")
+
+	data, err := os.ReadFile(t.filePath)
+	if err != nil {
+		return
+	}
+	var f usageFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		log.Warn("解析 AI 用量文件失败: %v", err)
+		return
+	}
+	t.date = f.Date
+	t.dailyCost = f.DailyCost
+	if f.SessionCosts != nil {
+		t.sessionCosts = f.SessionCosts
+	}
+	t.modelStats, t.agentStats, t.sessionStats = f.Models, f.Agents, f.Sessions
+	t.rollover(time.Now())
+}
+
+// SetBudget 更新预算配置
+func (t *UsageTracker) SetBudget(budget models.AIBudgetConfig) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.budget = budget
+	t.notified = make(map[string]bool)
+}
+
+// SetOnExceeded 设置预算超限回调
+func (t *UsageTracker) SetOnExceeded(fn func(BudgetExceededEvent)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onExceeded = fn
+}
+
+// SetOnUpdate 设置用量更新回调，每次模型调用计费后触发
+func (t *UsageTracker) SetOnUpdate(fn func(UsageSummary)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onUpdate = fn
+}
+
+// Override 临时放行，在指定时长内忽略预算上限
+func (t *UsageTracker) Override(d time.Duration) time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.overrideUntil = time.Now().Add(d)
+	t.notified = make(map[string]bool)
+	return t.overrideUntil
+}
+
+// ResetSession 清除会话的累计费用（会话被清空时调用）
+func (t *UsageTracker) ResetSession(sessionID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.sessionCosts, sessionID)
+	delete(t.sessionStats, sessionID)
+	delete(t.notified, BudgetScopeSession+":"+sessionID)
+	t.save()
+}
+
+// Summary 获取费用统计摘要
+func (t *UsageTracker) Summary() UsageSummary {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rollover(time.Now())
+	return t.summary()
+}
+
+// summary 构造统计摘要（调用方需持有锁）
+func (t *UsageTracker) summary() UsageSummary {
+
+	sessions := make(map[string]float64, len(t.sessionCosts))
+	for k, v := range t.sessionCosts {
+		sessions[k] = v
+	}
+	var overrideUntil int64
+	if time.Now().Before(t.overrideUntil) {
+		overrideUntil = t.overrideUntil.UnixMilli()
+	}
+	return UsageSummary{
+		Date:          t.date,
+		DailyCost:     t.dailyCost,
+		SessionCosts:  sessions,
+		Budget:        t.budget,
+		OverrideUntil: overrideUntil,
+		Models:        copyStats(t.modelStats),
+		Agents:        copyStats(t.agentStats),
+		Sessions:      copyStats(t.sessionStats),
+	}
+}
+
+// copyStats 复制用量明细
+func copyStats(src map[string]UsageStats) map[string]UsageStats {
+	dst := make(map[string]UsageStats, len(src))
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
+
+// checkBudget 调用前检查预算，超限时触发回调并返回 ErrBudgetExceeded
+func (t *UsageTracker) checkBudget(sessionID string) error {
+	t.mu.Lock()
+	now := time.Now()
+	t.rollover(now)
+
+	if !t.budget.Enabled || now.Before(t.overrideUntil) {
+		t.mu.Unlock()
+		return nil
+	}
+
+	var ev *BudgetExceededEvent
+	var key string
+	if t.budget.DailyLimit > 0 && t.dailyCost >= t.budget.DailyLimit {
+		ev = &BudgetExceededEvent{Scope: BudgetScopeDaily, Spent: t.dailyCost, Limit: t.budget.DailyLimit}
+		key = BudgetScopeDaily
+	} else if cost := t.sessionCosts[sessionID]; sessionID != "" && t.budget.SessionLimit > 0 && cost >= t.budget.SessionLimit {
+		ev = &BudgetExceededEvent{Scope: BudgetScopeSession, SessionID: sessionID, Spent: cost, Limit: t.budget.SessionLimit}
+		key = BudgetScopeSession + ":" + sessionID
+	}
+	if ev == nil {
+		t.mu.Unlock()
+		return nil
+	}
+
+	notify := t.onExceeded
+	if t.notified[key] {
+		notify = nil
+	}
+	t.notified[key] = true
+	t.mu.Unlock()
+
+	if notify != nil {
+		notify(*ev)
+	}
+	return fmt.Errorf("%w: 已花费 %.2f 元，上限 %.2f 元", ErrBudgetExceeded, ev.Spent, ev.Limit)
+}
+
+// record 按模型单价累计一次调用的用量与费用，并通知用量更新
+func (t *UsageTracker) record(config *models.AIConfig, sessionID, agentName string, usage *genai.GenerateContentResponseUsageMetadata) {
+	t.mu.Lock()
+	t.rollover(time.Now())
+	cost := usageCost(config, t.budget.Prices, usage)
+	if cost > 0 {
+		t.dailyCost += cost
+		if sessionID != "" {
+			t.sessionCosts[sessionID] += cost
+		}
+	}
+
+	if config != nil {
+		addStats(&t.modelStats, string(config.Provider)+":"+config.ModelName, usage, cost)
+	}
+	if agentName != "" {
+		addStats(&t.agentStats, agentName, usage, cost)
+	}
+	if sessionID != "" {
+		addStats(&t.sessionStats, sessionID, usage, cost)
+	}
+	t.save()
+
+	notify := t.onUpdate
+	var summary UsageSummary
+	if notify != nil {
+		summary = t.summary()
+	}
+	t.mu.Unlock()
+
+	if notify != nil {
+		notify(summary)
+	}
+}
+
+// addStats 累加指定维度的用量（调用方需持有锁）
+func addStats(stats *map[string]UsageStats, key string, usage *genai.GenerateContentResponseUsageMetadata, cost float64) {
+	if *stats == nil {
+		*stats = make(map[string]UsageStats)
+	}
+	s := (*stats)[key]
+	s.add(usage, cost)
+	(*stats)[key] = s
+}
+
+// rollover 跨日重置每日费用（调用方需持有锁）
+func (t *UsageTracker) rollover(now time.Time) {
+	today := now.Format("2006-01-02")
+	if t.date == today {
+		return
+	}
+	t.date = today
+	t.dailyCost = 0
+	t.modelStats, t.agentStats, t.sessionStats = nil, nil, nil
+	delete(t.notified, BudgetScopeDaily)
+}
+
+// save 持久化费用数据（调用方需持有锁）
+func (t *UsageTracker) save() {
+	if t.filePath == "" {
+		return
+	}
+	data, err := json.Marshal(usageFile{
+		Date:         t.date,
+		DailyCost:    t.dailyCost,
+		SessionCosts: t.sessionCosts,
+		Models:       t.modelStats,
+		Agents:       t.agentStats,
+		Sessions:     t.sessionStats,
+	})
+	if err != nil {
+		return
+	}
+	if err := os.WriteFile(t.filePath, data, 0644); err != nil {
+		log.Warn("保存 AI 用量失败: %v", err)
+	}
+}
+
+// usageCost 计算单次调用费用(元)，思考 token 按输出计费
+// AI 配置未填写单价时，按单价表中最长匹配的模型名前缀估算
+func usageCost(config *models.AIConfig, prices []models.ModelPrice, usage *genai.GenerateContentResponseUsageMetadata) float64 {
+	if config == nil || usage == nil {
+		return 0
+	}
+	inputPrice, outputPrice := config.InputPrice, config.OutputPrice
+	if inputPrice == 0 && outputPrice == 0 {
+		if p, ok := matchPrice(prices, config.ModelName); ok {
+			inputPrice, outputPrice = p.InputPrice, p.OutputPrice
+		}
+	}
+	input := float64(usage.PromptTokenCount)
+	output := float64(usage.CandidatesTokenCount + usage.ThoughtsTokenCount)
+	return (input*inputPrice + output*outputPrice) / 1e6
+}
+
+// matchPrice 按模型名前缀（忽略大小写）查找单价，取最长匹配
+func matchPrice(prices []models.ModelPrice, modelName string) (models.ModelPrice, bool) {
+	name := strings.ToLower(modelName)
+	var best models.ModelPrice
+	bestLen := 0
+	for _, p := range prices {
+		prefix := strings.ToLower(strings.TrimSpace(p.Model))
+		if prefix != "" && strings.HasPrefix(name, prefix) && len(prefix) > bestLen {
+			best, bestLen = p, len(prefix)
+		}
+	}
+	return best, bestLen > 0
+}
+
+type usageSessionKey struct{}
+
+// WithUsageSession 在 context 中标记费用归属的会话（股票代码）
+func WithUsageSession(ctx context.Context, sessionID string) context.Context {
+	return context.WithValue(ctx, usageSessionKey{}, sessionID)
+}
+
+func usageSessionFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(usageSessionKey{}).(string)
+	return id
+}
+
+// trackedModel 带预算检查和用量统计的模型包装
+type trackedModel struct {
+	model.LLM
+	config  *models.AIConfig
+	tracker *UsageTracker
+}
+
+// GenerateContent 调用前检查预算，结束后按最后一次返回的用量计费
+func (m *trackedModel) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		sessionID := usageSessionFromContext(ctx)
+		var agentName string
+		if ictx, ok := ctx.(agent.InvocationContext); ok && ictx.Agent() != nil {
+			agentName = ictx.Agent().Name()
+		}
+		if err := m.tracker.checkBudget(sessionID); err != nil {
+			yield(nil, err)
+			return
+		}
+
+		var usage *genai.GenerateContentResponseUsageMetadata
+		defer func() {
+			if usage != nil {
+				m.tracker.record(m.config, sessionID, agentName, usage)
+			}
+		}()
+
+		for resp, err := range m.LLM.GenerateContent(ctx, req, stream) {
+			if resp != nil && resp.UsageMetadata != nil {
+				usage = resp.UsageMetadata
+			}
+			if !yield(resp, err) {
+				return
+			}
+		}
+	}
+}
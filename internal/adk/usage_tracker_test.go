@@ -0,0 +1,109 @@
+package adk
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"testing"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"google.golang.org/adk/model"
+	"google.golang.org/genai"
+)
+
+// fakeLLM 返回固定用量的模型
+type fakeLLM struct{ calls int }
+
+func (f *fakeLLM) Name() string { return "fake" }
+
+func (f *fakeLLM) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		f.calls++
+		yield(&model.LLMResponse{UsageMetadata: &genai.GenerateContentResponseUsageMetadata{
+			PromptTokenCount:     1_000_000,
+			CandidatesTokenCount: 500_000,
+		}}, nil)
+	}
+}
+
+func TestUsageTrackerBudget(t *testing.T) {
+	tracker := &UsageTracker{sessionCosts: make(map[string]float64), notified: make(map[string]bool)}
+	tracker.SetBudget(models.AIBudgetConfig{Enabled: true, DailyLimit: 10, SessionLimit: 3})
+
+	var events []BudgetExceededEvent
+	tracker.SetOnExceeded(func(ev BudgetExceededEvent) { events = append(events, ev) })
+
+	inner := &fakeLLM{}
+	llm := &trackedModel{LLM: inner, config: &models.AIConfig{InputPrice: 2, OutputPrice: 4}, tracker: tracker}
+	ctx := WithUsageSession(context.Background(), "sh600519")
+
+	call := func() error {
+		for _, err := range llm.GenerateContent(ctx, &model.LLMRequest{}, false) {
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// 单次费用 = 1M*2 + 0.5M*4 = 4 元，第一次调用后会话超限
+	if err := call(); err != nil {
+		t.Fatalf("首次调用不应被拦截: %v", err)
+	}
+	if got := tracker.Summary().SessionCosts["sh600519"]; got != 4 {
+		t.Fatalf("会话费用 = %.2f, want 4", got)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := call(); !errors.Is(err, ErrBudgetExceeded) {
+			t.Fatalf("超限后应拦截调用, got %v", err)
+		}
+	}
+	if inner.calls != 1 {
+		t.Errorf("超限后不应调用模型, calls = %d", inner.calls)
+	}
+	if len(events) != 1 || events[0].Scope != BudgetScopeSession {
+		t.Errorf("超限事件应只通知一次: %+v", events)
+	}
+
+	tracker.Override(time.Minute)
+	if err := call(); err != nil {
+		t.Fatalf("临时放行后应允许调用: %v", err)
+	}
+}
+
+func TestUsageTrackerStats(t *testing.T) {
+	tracker := &UsageTracker{sessionCosts: make(map[string]float64), notified: make(map[string]bool)}
+	tracker.SetBudget(models.AIBudgetConfig{Prices: []models.ModelPrice{
+		{Model: "deepseek", InputPrice: 1, OutputPrice: 1},
+		{Model: "deepseek-chat", InputPrice: 2, OutputPrice: 4},
+	}})
+	var updates []UsageSummary
+	tracker.SetOnUpdate(func(s UsageSummary) { updates = append(updates, s) })
+
+	llm := &trackedModel{LLM: &fakeLLM{}, config: &models.AIConfig{Provider: models.AIProviderOpenAI, ModelName: "deepseek-chat"}, tracker: tracker}
+	ctx := WithUsageSession(context.Background(), "sh600519")
+	for i := 0; i < 2; i++ {
+		for range llm.GenerateContent(ctx, &model.LLMRequest{}, false) {
+		}
+	}
+
+	summary := tracker.Summary()
+	// 未填写单价，按最长前缀 deepseek-chat 计费：单次 4 元
+	m := summary.Models["openai:deepseek-chat"]
+	if m.Calls != 2 || m.TotalTokens != 3_000_000 || m.Cost != 8 {
+		t.Errorf("model stats = %+v", m)
+	}
+	if s := summary.Sessions["sh600519"]; s.Calls != 2 || s.Cost != 8 {
+		t.Errorf("session stats = %+v", s)
+	}
+	if len(updates) != 2 || updates[1].DailyCost != 8 {
+		t.Errorf("updates = %d", len(updates))
+	}
+
+	tracker.ResetSession("sh600519")
+	if _, ok := tracker.Summary().Sessions["sh600519"]; ok {
+		t.Error("session stats should be reset")
+	}
+}
@@ -0,0 +1,178 @@
+// Package backtest 提供基于历史K线的规则策略回测
+// 信号在K线收盘时产生，于下一根K线开盘价成交，避免使用未来数据；
+// 每次满仓买入（按 100 股整手）、清仓卖出，费用与模拟交易一致
+package backtest
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+const (
+	DefaultInitialCash = 100_000.0
+	commissionRate     = 0.00025 // 佣金费率（双向）
+	minCommission      = 5.0     // 最低佣金
+	stampTaxRate       = 0.0005  // 印花税（仅卖出）
+	lotSize            = 100
+)
+
+// position 当前持仓
+type position struct {
+	shares     int64
+	entryTime  string
+	entryPrice float64
+	cost       float64 // 买入金额 + 买入费用
+	fee        float64
+}
+
+// Run 对K线序列执行回测，rule 中未填写的参数会回填为默认值
+func Run(bars []models.KLineData, rule models.BacktestRule, initialCash float64) (models.BacktestResult, error) {
+	if initialCash <= 0 {
+		initialCash = DefaultInitialCash
+	}
+	result := models.BacktestResult{
+		InitialCash: initialCash,
+		Trades:      []models.BacktestTrade{},
+		Equity:      []models.EquityPoint{},
+	}
+	strategy, err := NewStrategy(&rule)
+	result.Rule = rule
+	if err != nil {
+		return result, err
+	}
+	if len(bars) < 2 {
+		return result, fmt.Errorf("K线数据不足")
+	}
+
+	cash := initialCash
+	var pos *position
+	pending := Hold
+	peak := initialCash
+	for i, bar := range bars {
+		// 执行上一根K线收盘产生的信号
+		price := bar.Open
+		if price <= 0 {
+			price = bar.Close
+		}
+		switch {
+		case pending == Buy && pos == nil:
+			shares := affordableShares(cash, price)
+			if shares > 0 {
+				amount := price * float64(shares)
+				fee := buyFee(amount)
+				cash -= amount + fee
+				pos = &position{shares: shares, entryTime: bar.Time, entryPrice: price, cost: amount + fee, fee: fee}
+			}
+		case pending == Sell && pos != nil:
+			trade := closePosition(pos, bar.Time, price, false)
+			cash += trade.proceeds
+			result.Trades = append(result.Trades, trade.BacktestTrade)
+			pos = nil
+		}
+		pending = strategy.Signal(bars, i)
+
+		equity := cash
+		if pos != nil {
+			equity += bar.Close * float64(pos.shares)
+		}
+		peak = math.Max(peak, equity)
+		result.Equity = append(result.Equity, models.EquityPoint{
+			Time:     bar.Time,
+			Equity:   round2(equity),
+			Drawdown: round2((equity - peak) / peak * 100),
+		})
+	}
+
+	last := bars[len(bars)-1]
+	if pos != nil {
+		result.Trades = append(result.Trades, closePosition(pos, last.Time, last.Close, true).BacktestTrade)
+	}
+	summarize(&result, bars)
+	return result, nil
+}
+
+// closedTrade 平仓结果，proceeds 为扣除卖出费用后的回笼资金
+type closedTrade struct {
+	models.BacktestTrade
+	proceeds float64
+}
+
+// closePosition 按给定价格平仓
+func closePosition(pos *position, t string, price float64, open bool) closedTrade {
+	amount := price * float64(pos.shares)
+	fee := sellFee(amount)
+	proceeds := amount - fee
+	pnl := proceeds - pos.cost
+	return closedTrade{
+		BacktestTrade: models.BacktestTrade{
+			EntryTime:  pos.entryTime,
+			EntryPrice: pos.entryPrice,
+			ExitTime:   t,
+			ExitPrice:  price,
+			Shares:     pos.shares,
+			Fee:        round2(pos.fee + fee),
+			PnL:        round2(pnl),
+			PnLPercent: round2(pnl / pos.cost * 100),
+			Open:       open,
+		},
+		proceeds: proceeds,
+	}
+}
+
+// summarize 计算收益率、最大回撤与胜率（仅统计已平仓交易）
+func summarize(result *models.BacktestResult, bars []models.KLineData) {
+	if n := len(result.Equity); n > 0 {
+		result.FinalEquity = result.Equity[n-1].Equity
+	}
+	result.TotalReturn = round2((result.FinalEquity - result.InitialCash) / result.InitialCash * 100)
+	if first := bars[0].Close; first > 0 {
+		result.BenchmarkReturn = round2((bars[len(bars)-1].Close - first) / first * 100)
+	}
+	for _, p := range result.Equity {
+		result.MaxDrawdown = math.Min(result.MaxDrawdown, p.Drawdown)
+	}
+
+	wins, closed := 0, 0
+	for _, t := range result.Trades {
+		if t.Open {
+			continue
+		}
+		closed++
+		if t.PnL > 0 {
+			wins++
+		}
+	}
+	result.TradeCount = closed
+	if closed > 0 {
+		result.WinRate = round2(float64(wins) / float64(closed) * 100)
+	}
+}
+
+// affordableShares 可用资金按整手最多可买数量（含费用）
+func affordableShares(cash, price float64) int64 {
+	if price <= 0 {
+		return 0
+	}
+	lots := int64(cash / (price * lotSize))
+	for ; lots > 0; lots-- {
+		amount := price * float64(lots*lotSize)
+		if amount+buyFee(amount) <= cash {
+			return lots * lotSize
+		}
+	}
+	return 0
+}
+
+func buyFee(amount float64) float64 {
+	return math.Max(amount*commissionRate, minCommission)
+}
+
+func sellFee(amount float64) float64 {
+	return math.Max(amount*commissionRate, minCommission) + amount*stampTaxRate
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
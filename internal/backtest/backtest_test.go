@@ -0,0 +1,65 @@
+package backtest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// makeBars 按收盘价序列构造日K，开盘价等于前一日收盘价
+func makeBars(closes ...float64) []models.KLineData {
+	bars := make([]models.KLineData, len(closes))
+	for i, c := range closes {
+		open := c
+		if i > 0 {
+			open = closes[i-1]
+		}
+		bars[i] = models.KLineData{Time: fmt.Sprintf("2024-01-%02d", i+1), Open: open, Close: c, High: c, Low: c}
+	}
+	return bars
+}
+
+// TestRunMACross 测试均线交叉回测的成交、资金曲线与统计
+func TestRunMACross(t *testing.T) {
+	// 先跌后涨再跌：产生一次金叉买入与一次死叉卖出
+	bars := makeBars(10, 9, 8, 7, 6, 7, 8, 9, 10, 11, 12, 11, 10, 9, 8, 7)
+	res, err := Run(bars, models.BacktestRule{Type: models.BacktestMACross, Fast: 2, Slow: 4}, 10000)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(res.Trades) != 1 || res.Trades[0].Open {
+		t.Fatalf("应有 1 笔已平仓交易, got %+v", res.Trades)
+	}
+	tr := res.Trades[0]
+	// 第 7 根金叉，第 8 根开盘价 8 买入；第 13 根死叉，第 14 根开盘价 10 卖出
+	if tr.EntryPrice != 8 || tr.ExitPrice != 10 || tr.Shares != 1200 {
+		t.Errorf("成交不符: %+v", tr)
+	}
+	if res.WinRate != 100 || res.TradeCount != 1 || tr.PnL <= 0 {
+		t.Errorf("统计不符: winRate=%v count=%d pnl=%v", res.WinRate, res.TradeCount, tr.PnL)
+	}
+	if len(res.Equity) != len(bars) || res.FinalEquity != 10000+tr.PnL {
+		t.Errorf("资金曲线不符: final=%v pnl=%v", res.FinalEquity, tr.PnL)
+	}
+	if res.MaxDrawdown >= 0 {
+		t.Errorf("持仓期间回落应产生回撤, got %v", res.MaxDrawdown)
+	}
+	if res.BenchmarkReturn != -30 {
+		t.Errorf("买入持有收益应为 -30%%, got %v", res.BenchmarkReturn)
+	}
+}
+
+// TestNewStrategyDefaults 测试参数默认值与非法参数
+func TestNewStrategyDefaults(t *testing.T) {
+	rule := models.BacktestRule{Type: models.BacktestRSI}
+	if _, err := NewStrategy(&rule); err != nil || rule.Period != 14 || rule.Lower != 30 || rule.Upper != 70 {
+		t.Errorf("RSI 默认参数不符: %+v err=%v", rule, err)
+	}
+	if _, err := NewStrategy(&models.BacktestRule{Type: models.BacktestMACross, Fast: 20, Slow: 5}); err == nil {
+		t.Error("快线大于慢线应报错")
+	}
+	if _, err := NewStrategy(&models.BacktestRule{Type: "unknown"}); err == nil {
+		t.Error("未知策略应报错")
+	}
+}
@@ -0,0 +1,168 @@
+package backtest
+
+import (
+	"fmt"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// Signal 交易信号
+type Signal int
+
+const (
+	Hold Signal = iota
+	Buy
+	Sell
+)
+
+// Strategy 规则策略：根据截至第 i 根K线（含）的数据给出信号
+type Strategy interface {
+	Signal(bars []models.KLineData, i int) Signal
+}
+
+// NewStrategy 根据规则创建策略，并回填默认参数
+func NewStrategy(rule *models.BacktestRule) (Strategy, error) {
+	switch rule.Type {
+	case models.BacktestMACross:
+		rule.Fast = defaultInt(rule.Fast, 5)
+		rule.Slow = defaultInt(rule.Slow, 20)
+		if rule.Fast >= rule.Slow {
+			return nil, fmt.Errorf("快线周期必须小于慢线周期")
+		}
+		return maCross{fast: rule.Fast, slow: rule.Slow}, nil
+	case models.BacktestBreakout:
+		rule.Lookback = defaultInt(rule.Lookback, 20)
+		return breakout{lookback: rule.Lookback}, nil
+	case models.BacktestRSI:
+		rule.Period = defaultInt(rule.Period, 14)
+		if rule.Lower == 0 {
+			rule.Lower = 30
+		}
+		if rule.Upper == 0 {
+			rule.Upper = 70
+		}
+		if rule.Lower >= rule.Upper || rule.Upper > 100 {
+			return nil, fmt.Errorf("RSI 阈值无效: %.0f / %.0f", rule.Lower, rule.Upper)
+		}
+		return &rsiThreshold{period: rule.Period, lower: rule.Lower, upper: rule.Upper}, nil
+	}
+	return nil, fmt.Errorf("不支持的策略类型: %s", rule.Type)
+}
+
+func defaultInt(v, def int) int {
+	if v <= 0 {
+		return def
+	}
+	return v
+}
+
+// maCross 均线交叉
+type maCross struct {
+	fast, slow int
+}
+
+func (s maCross) Signal(bars []models.KLineData, i int) Signal {
+	if i < s.slow {
+		return Hold
+	}
+	prevFast, prevSlow := sma(bars, i-1, s.fast), sma(bars, i-1, s.slow)
+	fast, slow := sma(bars, i, s.fast), sma(bars, i, s.slow)
+	switch {
+	case prevFast <= prevSlow && fast > slow:
+		return Buy
+	case prevFast >= prevSlow && fast < slow:
+		return Sell
+	}
+	return Hold
+}
+
+// breakout 通道突破（不含当根K线的前 N 根最高/最低收盘价）
+type breakout struct {
+	lookback int
+}
+
+func (s breakout) Signal(bars []models.KLineData, i int) Signal {
+	if i < s.lookback {
+		return Hold
+	}
+	high, low := bars[i-1].Close, bars[i-1].Close
+	for _, b := range bars[i-s.lookback : i] {
+		high = max(high, b.Close)
+		low = min(low, b.Close)
+	}
+	switch {
+	case bars[i].Close > high:
+		return Buy
+	case bars[i].Close < low:
+		return Sell
+	}
+	return Hold
+}
+
+// rsiThreshold RSI 阈值，使用 Wilder 平滑，按K线顺序增量计算
+type rsiThreshold struct {
+	period       int
+	lower, upper float64
+	values       []float64
+}
+
+func (s *rsiThreshold) Signal(bars []models.KLineData, i int) Signal {
+	if s.values == nil {
+		s.values = rsi(bars, s.period)
+	}
+	if i <= s.period {
+		return Hold
+	}
+	switch v := s.values[i]; {
+	case v < s.lower:
+		return Buy
+	case v > s.upper:
+		return Sell
+	}
+	return Hold
+}
+
+// sma 以第 i 根K线结尾的 n 日收盘均价
+func sma(bars []models.KLineData, i, n int) float64 {
+	sum := 0.0
+	for _, b := range bars[i-n+1 : i+1] {
+		sum += b.Close
+	}
+	return sum / float64(n)
+}
+
+// rsi 计算 RSI 序列，前 period 根无值（为 0）
+func rsi(bars []models.KLineData, period int) []float64 {
+	values := make([]float64, len(bars))
+	if len(bars) <= period {
+		return values
+	}
+	var gain, loss float64
+	for i := 1; i <= period; i++ {
+		d := bars[i].Close - bars[i-1].Close
+		if d > 0 {
+			gain += d
+		} else {
+			loss -= d
+		}
+	}
+	gain /= float64(period)
+	loss /= float64(period)
+	values[period] = rsiValue(gain, loss)
+
+	for i := period + 1; i < len(bars); i++ {
+		d := bars[i].Close - bars[i-1].Close
+		up, down := max(d, 0), max(-d, 0)
+		gain = (gain*float64(period-1) + up) / float64(period)
+		loss = (loss*float64(period-1) + down) / float64(period)
+		values[i] = rsiValue(gain, loss)
+	}
+	return values
+}
+
+func rsiValue(gain, loss float64) float64 {
+	if loss == 0 {
+		return 100
+	}
+	return 100 - 100/(1+gain/loss)
+}
@@ -1,7 +1,7 @@
 package embed
 
 import (
-	_ "embed"
+	"embed"
 )
 
 // StockBasicJSON 嵌入的股票基础数据
@@ -9,3 +9,9 @@ import (
 //
 //go:embed stock_basic.json
 var StockBasicJSON []byte
+
+// HolidayFS 嵌入的节假日数据（holiday-cn 格式，按年份命名，如 holiday/2026.json）
+// CDN 及镜像均不可用时作为兜底，新一年安排公布后放入对应文件即可
+//
+//go:embed holiday/*.json
+var HolidayFS embed.FS
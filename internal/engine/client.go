@@ -0,0 +1,191 @@
+package engine
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"os"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/apperr"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+	"github.com/run-bigpig/jcp/internal/services"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Client 远程数据引擎客户端，实现 services.RemoteMarketSource
+type Client struct {
+	conn  *grpc.ClientConn
+	addr  string
+	token string
+}
+
+// NewClient 创建引擎客户端（连接在首次调用时建立）
+// 令牌以 metadata 传输，未启用 TLS 时只允许连接本机回环地址
+func NewClient(cfg models.EngineConfig) (*Client, error) {
+	creds, err := clientCredentials(cfg)
+	if err != nil {
+		return nil, err
+	}
+	c := &Client{addr: cfg.Address, token: cfg.Token}
+	conn, err := grpc.NewClient(cfg.Address,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultCallOptions(grpc.CallContentSubtype(codecName)),
+		grpc.WithUnaryInterceptor(c.tokenInterceptor),
+	)
+	if err != nil {
+		return nil, err
+	}
+	c.conn = conn
+	return c, nil
+}
+
+// clientCredentials 按配置选择传输凭据
+func clientCredentials(cfg models.EngineConfig) (credentials.TransportCredentials, error) {
+	if !cfg.TLS {
+		if !isLoopback(cfg.Address) {
+			return nil, i18n.Errorf("error.engine.tls_required", cfg.Address)
+		}
+		return insecure.NewCredentials(), nil
+	}
+	tlsCfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if cfg.CACert != "" {
+		pem, err := os.ReadFile(cfg.CACert)
+		if err != nil {
+			return nil, i18n.Errorf("error.engine.ca_read_failed", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, i18n.Errorf("error.engine.ca_invalid", cfg.CACert)
+		}
+		tlsCfg.RootCAs = pool
+	}
+	return credentials.NewTLS(tlsCfg), nil
+}
+
+// isLoopback 地址是否为本机回环地址
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// Addr 引擎地址
+func (c *Client) Addr() string {
+	return c.addr
+}
+
+// Close 关闭连接
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// tokenInterceptor 附加鉴权令牌，并把 gRPC 状态码映射为应用错误码
+func (c *Client) tokenInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if c.token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, tokenHeader, c.token)
+	}
+	return wrapStatus(invoker(ctx, method, req, reply, cc, opts...))
+}
+
+// wrapStatus gRPC 错误转换为 apperr
+func wrapStatus(err error) error {
+	switch status.Code(err) {
+	case codes.OK:
+		return err
+	case codes.Unauthenticated, codes.PermissionDenied:
+		return apperr.Wrap(apperr.CodeAuth, err, "数据引擎鉴权失败")
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return apperr.Wrap(apperr.CodeNetwork, err, "数据引擎不可用")
+	case codes.ResourceExhausted:
+		return apperr.Wrap(apperr.CodeRateLimited, err, "")
+	default:
+		return err
+	}
+}
+
+// Ping 检查引擎连通性，返回引擎版本
+func (c *Client) Ping(ctx context.Context) (string, error) {
+	var resp PingResponse
+	if err := c.conn.Invoke(ctx, fullMethod("Ping"), &struct{}{}, &resp); err != nil {
+		return "", err
+	}
+	return resp.Version, nil
+}
+
+// GetStockRealTimeData 获取实时行情
+func (c *Client) GetStockRealTimeData(ctx context.Context, codes ...string) ([]models.Stock, error) {
+	var resp StocksResponse
+	if err := c.conn.Invoke(ctx, fullMethod("GetStockRealTime"), &StocksRequest{Codes: codes}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Stocks, nil
+}
+
+// GetStockDataWithOrderBook 获取实时行情（含盘口）
+func (c *Client) GetStockDataWithOrderBook(ctx context.Context, codes ...string) ([]services.StockWithOrderBook, error) {
+	var resp OrderBookResponse
+	if err := c.conn.Invoke(ctx, fullMethod("GetStockWithOrderBook"), &StocksRequest{Codes: codes}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Stocks, nil
+}
+
+// GetKLineData 获取K线数据
+func (c *Client) GetKLineData(ctx context.Context, code string, period string, days int) ([]models.KLineData, error) {
+	var resp KLineResponse
+	req := &KLineRequest{Code: code, Period: period, Days: days}
+	if err := c.conn.Invoke(ctx, fullMethod("GetKLine"), req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.KLines, nil
+}
+
+// GetMarketIndicesByCodes 获取指定大盘指数
+func (c *Client) GetMarketIndicesByCodes(ctx context.Context, codes []string) ([]models.MarketIndex, error) {
+	var resp IndicesResponse
+	if err := c.conn.Invoke(ctx, fullMethod("GetIndices"), &StocksRequest{Codes: codes}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Indices, nil
+}
+
+// SetRecordCodes 同步需录制的股票列表，返回引擎保存后的列表
+func (c *Client) SetRecordCodes(ctx context.Context, codes []string) ([]string, error) {
+	var resp RecordCodesResponse
+	if err := c.conn.Invoke(ctx, fullMethod("SetRecordCodes"), &StocksRequest{Codes: codes}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Codes, nil
+}
+
+// GetRecording 获取引擎录制的某日分时
+func (c *Client) GetRecording(ctx context.Context, code, date string) ([]models.KLineData, error) {
+	var resp KLineResponse
+	if err := c.conn.Invoke(ctx, fullMethod("GetRecording"), &RecordingRequest{Code: code, Date: date}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.KLines, nil
+}
+
+// GetRecordingDates 获取引擎已录制分时的日期，按日期倒序
+func (c *Client) GetRecordingDates(ctx context.Context, code string) ([]string, error) {
+	var resp DatesResponse
+	if err := c.conn.Invoke(ctx, fullMethod("GetRecordingDates"), &RecordingRequest{Code: code}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Dates, nil
+}
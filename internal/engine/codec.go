@@ -0,0 +1,30 @@
+package engine
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// codecName gRPC content-subtype，客户端通过 CallContentSubtype 指定
+const codecName = "json"
+
+// jsonCodec gRPC JSON 编解码
+// 消息直接复用 models 中的结构体，无需 protoc 生成代码
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return codecName
+}
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
@@ -0,0 +1,201 @@
+package engine
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/apperr"
+	"github.com/run-bigpig/jcp/internal/services"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestEnginePingAndAuth 测试引擎连通性与令牌鉴权
+func TestEnginePingAndAuth(t *testing.T) {
+	server := NewServer(services.NewMarketService(), "v-test")
+	if err := server.Start("127.0.0.1:0", "secret", nil); err != nil {
+		t.Fatalf("启动引擎失败: %v", err)
+	}
+	defer server.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client, err := NewClient(models.EngineConfig{Address: server.Addr(), Token: "secret"})
+	if err != nil {
+		t.Fatalf("创建客户端失败: %v", err)
+	}
+	defer client.Close()
+	version, err := client.Ping(ctx)
+	if err != nil {
+		t.Fatalf("Ping 失败: %v", err)
+	}
+	if version != "v-test" {
+		t.Errorf("version = %q, want v-test", version)
+	}
+
+	bad, err := NewClient(models.EngineConfig{Address: server.Addr(), Token: "wrong"})
+	if err != nil {
+		t.Fatalf("创建客户端失败: %v", err)
+	}
+	defer bad.Close()
+	_, err = bad.Ping(ctx)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("错误令牌应返回 Unauthenticated, got %v", err)
+	}
+	if apperr.CodeOf(err) != apperr.CodeAuth {
+		t.Errorf("错误码应为 AUTH, got %s", apperr.CodeOf(err))
+	}
+}
+
+// writeTestCert 生成 127.0.0.1 的自签名证书，返回证书与私钥文件路径
+func writeTestCert(t *testing.T) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "jcp-engine"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	certFile = filepath.Join(dir, "server.crt")
+	keyFile = filepath.Join(dir, "server.key")
+	os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600)
+	return certFile, keyFile
+}
+
+// TestEngineTLS 测试 TLS 连接，以及未配置 TLS 时只允许本机回环地址
+func TestEngineTLS(t *testing.T) {
+	if err := NewServer(services.NewMarketService(), "v-test").Start("0.0.0.0:0", "secret", nil); err == nil {
+		t.Error("未配置 TLS 时不应监听非回环地址")
+	}
+	if _, err := NewClient(models.EngineConfig{Address: "192.0.2.1:50051", Token: "secret"}); err == nil {
+		t.Error("未启用 TLS 时不应连接非本机引擎")
+	}
+
+	certFile, keyFile := writeTestCert(t)
+	tlsCfg, err := LoadServerTLS(certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := NewServer(services.NewMarketService(), "v-tls")
+	if err := server.Start("127.0.0.1:0", "secret", tlsCfg); err != nil {
+		t.Fatalf("启动引擎失败: %v", err)
+	}
+	defer server.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client, err := NewClient(models.EngineConfig{Address: server.Addr(), Token: "secret", TLS: true, CACert: certFile})
+	if err != nil {
+		t.Fatalf("创建客户端失败: %v", err)
+	}
+	defer client.Close()
+	if version, err := client.Ping(ctx); err != nil || version != "v-tls" {
+		t.Fatalf("TLS Ping = %q, %v", version, err)
+	}
+
+	// 明文客户端无法连接 TLS 服务
+	plain, err := NewClient(models.EngineConfig{Address: server.Addr(), Token: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer plain.Close()
+	if _, err := plain.Ping(ctx); err == nil {
+		t.Error("明文客户端不应连通 TLS 服务")
+	}
+}
+
+// TestEngineRecordings 测试同步录制列表与读取引擎录制的分时
+func TestEngineRecordings(t *testing.T) {
+	dataDir := t.TempDir()
+	recorder := NewRecorder(dataDir, nil)
+	defer recorder.Close()
+	bars := []models.KLineData{
+		{Time: "2026-03-02 09:31", Open: 10, High: 10.2, Low: 9.9, Close: 10.1, Volume: 100},
+		{Time: "2026-03-02 09:32", Open: 10.1, High: 10.3, Low: 10, Close: 10.2, Volume: 120},
+	}
+	if err := recorder.archive.Save("sh600519", "2026-03-02", bars); err != nil {
+		t.Fatal(err)
+	}
+
+	server := NewServer(services.NewMarketService(), "v-test")
+	server.SetRecorder(recorder)
+	if err := server.Start("127.0.0.1:0", "", nil); err != nil {
+		t.Fatalf("启动引擎失败: %v", err)
+	}
+	defer server.Stop()
+	client, err := NewClient(models.EngineConfig{Address: server.Addr()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	saved, err := client.SetRecordCodes(ctx, []string{"sh600519", "sz000001", "sh600519"})
+	if err != nil || len(saved) != 2 {
+		t.Fatalf("SetRecordCodes = %v, %v", saved, err)
+	}
+	if reloaded := NewRecorder(dataDir, nil).Codes(); len(reloaded) != 2 || reloaded[1] != "sz000001" {
+		t.Errorf("录制列表应持久化, got %v", reloaded)
+	}
+
+	klines, err := client.GetRecording(ctx, "sh600519", "2026-03-02")
+	if err != nil || len(klines) != 2 || klines[1].Close != 10.2 {
+		t.Fatalf("GetRecording = %+v, %v", klines, err)
+	}
+	if _, err := client.GetRecording(ctx, "sh600519", "bad-date"); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("无效日期应返回 InvalidArgument, got %v", err)
+	}
+	dates, err := client.GetRecordingDates(ctx, "sh600519")
+	if err != nil || len(dates) != 1 || dates[0] != "2026-03-02" {
+		t.Errorf("GetRecordingDates = %v, %v", dates, err)
+	}
+
+	// 未启用录制的引擎
+	plain := NewServer(services.NewMarketService(), "v-test")
+	if err := plain.Start("127.0.0.1:0", "", nil); err != nil {
+		t.Fatal(err)
+	}
+	defer plain.Stop()
+	pc, err := NewClient(models.EngineConfig{Address: plain.Addr()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pc.Close()
+	if _, err := pc.GetRecordingDates(ctx, "sh600519"); status.Code(err) != codes.Unimplemented {
+		t.Errorf("未启用录制应返回 Unimplemented, got %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/services"
+)
+
+// recordCodesFile 需录制的股票列表文件
+const recordCodesFile = "recordings.json"
+
+// Recorder 引擎端分时录制
+// 桌面端连接后同步自选股列表，引擎在每个交易日收盘后归档这些股票的 1 分钟K线，
+// 笔记本离线或休眠期间的分时也能从引擎补回
+type Recorder struct {
+	archive *services.IntradayArchive
+	path    string
+
+	mu    sync.RWMutex
+	codes []string
+}
+
+// NewRecorder 创建分时录制，数据保存在 dataDir 下
+func NewRecorder(dataDir string, marketService *services.MarketService) *Recorder {
+	r := &Recorder{
+		archive: services.NewIntradayArchive(dataDir, nil, marketService),
+		path:    filepath.Join(dataDir, recordCodesFile),
+		codes:   []string{},
+	}
+	if data, err := os.ReadFile(r.path); err == nil {
+		if err := json.Unmarshal(data, &r.codes); err != nil {
+			log.Warn("读取录制列表失败: %v", err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		log.Warn("读取录制列表失败: %v", err)
+	}
+	r.archive.SetSymbolSource(r.Codes)
+	return r
+}
+
+// Start 启动收盘归档
+func (r *Recorder) Start(ctx context.Context) {
+	r.archive.Start(ctx)
+}
+
+// Close 关闭归档数据库
+func (r *Recorder) Close() error {
+	return r.archive.Close()
+}
+
+// Codes 获取需录制的股票
+func (r *Recorder) Codes() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return slices.Clone(r.codes)
+}
+
+// SetCodes 替换需录制的股票并保存，重复代码只保留一个
+func (r *Recorder) SetCodes(codes []string) error {
+	unique := make([]string, 0, len(codes))
+	for _, code := range codes {
+		if code != "" && !slices.Contains(unique, code) {
+			unique = append(unique, code)
+		}
+	}
+	data, err := json.Marshal(unique)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(r.path, data, 0644); err != nil {
+		return err
+	}
+	r.codes = unique
+	return nil
+}
+
+// Get 读取某日录制的分时
+func (r *Recorder) Get(code, date string) ([]models.KLineData, error) {
+	return r.archive.Get(code, date)
+}
+
+// Dates 获取已录制的日期，按日期倒序
+func (r *Recorder) Dates(code string) []string {
+	return r.archive.Dates(code)
+}
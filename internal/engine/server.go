@@ -0,0 +1,136 @@
+package engine
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+
+	"github.com/run-bigpig/jcp/internal/logger"
+	"github.com/run-bigpig/jcp/internal/services"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var log = logger.New("engine")
+
+// tokenHeader 鉴权令牌所在的 metadata 键
+const tokenHeader = "x-jcp-token"
+
+// Server 远程数据引擎 gRPC 服务
+type Server struct {
+	mu            sync.Mutex
+	grpcServer    *grpc.Server
+	addr          string
+	token         string
+	version       string
+	marketService *services.MarketService
+	recorder      atomic.Pointer[Recorder] // 不受 mu 保护：Stop 持锁等待进行中的请求
+}
+
+// NewServer 创建引擎服务
+func NewServer(marketService *services.MarketService, version string) *Server {
+	return &Server{marketService: marketService, version: version}
+}
+
+// market 实现 marketDataHandler
+func (s *Server) market() *services.MarketService {
+	return s.marketService
+}
+
+// SetRecorder 设置分时录制，未设置时录制相关接口返回 Unimplemented
+func (s *Server) SetRecorder(r *Recorder) {
+	s.recorder.Store(r)
+}
+
+// recordings 实现 marketDataHandler
+func (s *Server) recordings() (*Recorder, error) {
+	r := s.recorder.Load()
+	if r == nil {
+		return nil, status.Error(codes.Unimplemented, "引擎未启用分时录制")
+	}
+	return r, nil
+}
+
+// LoadServerTLS 加载服务端证书与私钥（PEM）
+func LoadServerTLS(certFile, keyFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("加载 TLS 证书失败: %w", err)
+	}
+	return &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}, nil
+}
+
+// Start 启动服务，token 为空时不鉴权
+// 令牌以 metadata 传输，tlsCfg 为空时明文传输，因此只允许监听本机回环地址
+func (s *Server) Start(addr, token string, tlsCfg *tls.Config) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.grpcServer != nil {
+		return fmt.Errorf("引擎服务已在运行")
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("监听 %s 失败: %w", addr, err)
+	}
+	if tcp, ok := ln.Addr().(*net.TCPAddr); tlsCfg == nil && (!ok || !tcp.IP.IsLoopback()) {
+		ln.Close()
+		return fmt.Errorf("未配置 TLS 时只能监听本机回环地址（如 127.0.0.1:50051）: %s", addr)
+	}
+
+	opts := []grpc.ServerOption{grpc.UnaryInterceptor(s.authInterceptor)}
+	if tlsCfg != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsCfg)))
+	}
+	s.addr = ln.Addr().String()
+	s.token = token
+	s.grpcServer = grpc.NewServer(opts...)
+	s.grpcServer.RegisterService(&serviceDesc, s)
+
+	go func(gs *grpc.Server) {
+		log.Info("数据引擎服务启动于 %s", s.addr)
+		if err := gs.Serve(ln); err != nil {
+			log.Error("引擎服务异常: %v", err)
+		}
+	}(s.grpcServer)
+	return nil
+}
+
+// Stop 停止服务
+func (s *Server) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.grpcServer == nil {
+		return
+	}
+	s.grpcServer.GracefulStop()
+	s.grpcServer = nil
+	log.Info("数据引擎服务已停止")
+}
+
+// Addr 获取实际监听地址
+func (s *Server) Addr() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addr
+}
+
+// authInterceptor 校验客户端令牌
+func (s *Server) authInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if s.token != "" {
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get(tokenHeader)
+		if len(values) == 0 || subtle.ConstantTimeCompare([]byte(values[0]), []byte(s.token)) != 1 {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+	}
+	return handler(ctx, req)
+}
@@ -0,0 +1,163 @@
+// Package engine 远程数据引擎
+// 在网络条件更好的家庭服务器/NAS 上运行无界面引擎，通过 gRPC 提供行情数据，
+// 桌面端配置远程地址后作为瘦客户端使用
+//
+// 引擎提供实时行情、盘口、K线、大盘指数，并按桌面端同步的自选股录制收盘分时。
+// AI 编排仍在桌面进程内运行：它依赖桌面端的模型配置、会话与记忆，
+// 需要先把这些存储服务化，作为后续需求单独实现
+package engine
+
+import (
+	"context"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/services"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// serviceName gRPC 服务名
+const serviceName = "jcp.engine.MarketData"
+
+// StocksRequest 股票代码请求
+type StocksRequest struct {
+	Codes []string `json:"codes"`
+}
+
+// StocksResponse 实时行情响应
+type StocksResponse struct {
+	Stocks []models.Stock `json:"stocks"`
+}
+
+// OrderBookResponse 实时行情（含盘口）响应
+type OrderBookResponse struct {
+	Stocks []services.StockWithOrderBook `json:"stocks"`
+}
+
+// KLineRequest K线请求
+type KLineRequest struct {
+	Code   string `json:"code"`
+	Period string `json:"period"`
+	Days   int    `json:"days"`
+}
+
+// KLineResponse K线响应
+type KLineResponse struct {
+	KLines []models.KLineData `json:"klines"`
+}
+
+// IndicesResponse 大盘指数响应
+type IndicesResponse struct {
+	Indices []models.MarketIndex `json:"indices"`
+}
+
+// RecordingRequest 录制分时请求，Date 为空时只按股票查询
+type RecordingRequest struct {
+	Code string `json:"code"`
+	Date string `json:"date"`
+}
+
+// RecordCodesResponse 需录制的股票列表
+type RecordCodesResponse struct {
+	Codes []string `json:"codes"`
+}
+
+// DatesResponse 已录制日期响应
+type DatesResponse struct {
+	Dates []string `json:"dates"`
+}
+
+// PingResponse 连通性检查响应
+type PingResponse struct {
+	Version string `json:"version"`
+}
+
+// marketDataHandler gRPC 服务实现需满足的接口
+type marketDataHandler interface {
+	market() *services.MarketService
+	recordings() (*Recorder, error)
+}
+
+// serviceDesc 手写的服务描述（对应 JSON 编解码，无 .proto 文件）
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*marketDataHandler)(nil),
+	Methods: []grpc.MethodDesc{
+		unary("Ping", func(ctx context.Context, s *Server, _ *struct{}) (any, error) {
+			return &PingResponse{Version: s.version}, nil
+		}),
+		unary("GetStockRealTime", func(ctx context.Context, s *Server, req *StocksRequest) (any, error) {
+			stocks, err := s.market().GetStockRealTimeData(ctx, req.Codes...)
+			return &StocksResponse{Stocks: stocks}, err
+		}),
+		unary("GetStockWithOrderBook", func(ctx context.Context, s *Server, req *StocksRequest) (any, error) {
+			stocks, err := s.market().GetStockDataWithOrderBook(ctx, req.Codes...)
+			return &OrderBookResponse{Stocks: stocks}, err
+		}),
+		unary("GetKLine", func(ctx context.Context, s *Server, req *KLineRequest) (any, error) {
+			klines, err := s.market().GetKLineData(ctx, req.Code, req.Period, req.Days)
+			return &KLineResponse{KLines: klines}, err
+		}),
+		unary("GetIndices", func(ctx context.Context, s *Server, req *StocksRequest) (any, error) {
+			indices, err := s.market().GetMarketIndicesByCodes(ctx, req.Codes)
+			return &IndicesResponse{Indices: indices}, err
+		}),
+		unary("SetRecordCodes", func(ctx context.Context, s *Server, req *StocksRequest) (any, error) {
+			r, err := s.recordings()
+			if err != nil {
+				return nil, err
+			}
+			if err := r.SetCodes(req.Codes); err != nil {
+				return nil, status.Errorf(codes.Internal, "保存录制列表失败: %v", err)
+			}
+			return &RecordCodesResponse{Codes: r.Codes()}, nil
+		}),
+		unary("GetRecording", func(ctx context.Context, s *Server, req *RecordingRequest) (any, error) {
+			r, err := s.recordings()
+			if err != nil {
+				return nil, err
+			}
+			klines, err := r.Get(req.Code, req.Date)
+			if err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+			return &KLineResponse{KLines: klines}, nil
+		}),
+		unary("GetRecordingDates", func(ctx context.Context, s *Server, req *RecordingRequest) (any, error) {
+			r, err := s.recordings()
+			if err != nil {
+				return nil, err
+			}
+			return &DatesResponse{Dates: r.Dates(req.Code)}, nil
+		}),
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+// unary 构造一元方法描述
+func unary[Req any](method string, call func(context.Context, *Server, *Req) (any, error)) grpc.MethodDesc {
+	return grpc.MethodDesc{
+		MethodName: method,
+		Handler: func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+			req := new(Req)
+			if err := dec(req); err != nil {
+				return nil, err
+			}
+			s := srv.(*Server)
+			if interceptor == nil {
+				return call(ctx, s, req)
+			}
+			info := &grpc.UnaryServerInfo{Server: srv, FullMethod: fullMethod(method)}
+			return interceptor(ctx, req, info, func(ctx context.Context, r any) (any, error) {
+				return call(ctx, s, r.(*Req))
+			})
+		},
+	}
+}
+
+// fullMethod 完整方法名
+func fullMethod(method string) string {
+	return "/" + serviceName + "/" + method
+}
@@ -0,0 +1,166 @@
+package localapi
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/metrics"
+)
+
+const (
+	maxKLineDays = 1000
+	// 默认返回最近触发的提醒条数
+	defaultTriggeredLimit = 50
+)
+
+// routes 注册路由
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /health", s.handleHealth)
+	mux.HandleFunc("GET /api/quotes", s.withAuth(s.handleQuotes))
+	mux.HandleFunc("GET /api/kline", s.withAuth(s.handleKLine))
+	mux.HandleFunc("GET /api/watchlist", s.withAuth(s.handleWatchlist))
+	mux.HandleFunc("GET /api/alerts", s.withAuth(s.handleAlerts))
+	mux.HandleFunc("GET /metrics", s.withAuth(s.handleMetrics))
+	return withCORS(mux)
+}
+
+// withCORS 允许浏览器中的个人看板跨域访问（数据仍需令牌）
+func withCORS(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Headers", "Authorization")
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// withAuth 令牌鉴权：Authorization: Bearer <token> 或 ?token=<token>
+func (s *Server) withAuth(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, token := s.Config()
+		got := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if got == "" {
+			got = r.URL.Query().Get("token")
+		}
+		if token == "" || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{"status": "ok"})
+}
+
+// handleQuotes 实时行情，codes 为逗号分隔的代码，缺省为当前分组自选股
+func (s *Server) handleQuotes(w http.ResponseWriter, r *http.Request) {
+	var codes []string
+	for _, c := range strings.Split(r.URL.Query().Get("codes"), ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			codes = append(codes, c)
+		}
+	}
+	if len(codes) == 0 {
+		groups := s.source.Watchlist()
+		for _, g := range groups.Groups {
+			if g.ID == groups.Active {
+				codes = g.Symbols
+			}
+		}
+	}
+	if len(codes) == 0 {
+		writeJSON(w, http.StatusOK, map[string]any{"data": []any{}})
+		return
+	}
+
+	stocks, err := s.source.Quotes(r.Context(), codes)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"data": stocks})
+}
+
+// handleKLine K线数据：code 必填，period 默认 1d，days 默认 120
+func (s *Server) handleKLine(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	code := q.Get("code")
+	if code == "" {
+		writeError(w, http.StatusBadRequest, "code is required")
+		return
+	}
+	period := q.Get("period")
+	if period == "" {
+		period = "1d"
+	}
+	days := 120
+	if v := q.Get("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > maxKLineDays {
+			writeError(w, http.StatusBadRequest, "invalid days")
+			return
+		}
+		days = n
+	}
+
+	klines, err := s.source.KLines(r.Context(), code, period, days)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"data": klines})
+}
+
+// handleWatchlist 自选股及分组
+func (s *Server) handleWatchlist(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"data":   s.source.WatchlistStocks(),
+		"groups": s.source.Watchlist(),
+	})
+}
+
+// handleAlerts 提醒：目标价/止损价跟踪线、条件单与最近触发的提醒
+// category 按通知分类筛选触发记录（price/news/limit/lockup 等），limit 默认 50
+func (s *Server) handleAlerts(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	limit := defaultTriggeredLimit
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			writeError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		limit = n
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"priceTargets":    s.source.PriceTargets(),
+		"conditionOrders": s.source.ConditionOrders(),
+		"triggered":       s.source.Notifications(models.NotificationFilter{Category: q.Get("category"), Limit: limit}),
+	})
+}
+
+// handleMetrics 内部运行指标（Prometheus 文本格式）
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	metrics.Default.WritePrometheus(w)
+}
+
+func writeJSON(w http.ResponseWriter, code int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(data)
+}
+
+func writeError(w http.ResponseWriter, code int, msg string) {
+	writeJSON(w, code, map[string]any{"error": msg})
+}
@@ -0,0 +1,129 @@
+// Package localapi 提供本地 REST API 服务
+// 仅监听 127.0.0.1，以 JSON 输出与桌面端一致的行情、K线、自选股与提醒数据，供外部脚本或个人看板使用
+package localapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/logger"
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+)
+
+var log = logger.New("localapi")
+
+// DataSource 数据来源（由应用层注入，保持与桌面端同一份数据）
+type DataSource interface {
+	Quotes(ctx context.Context, codes []string) ([]models.Stock, error)
+	KLines(ctx context.Context, code, period string, days int) ([]models.KLineData, error)
+	Watchlist() models.WatchlistGroups
+	WatchlistStocks() []models.Stock
+	PriceTargets() []models.PriceTarget
+	ConditionOrders() []models.ConditionOrder
+	// Notifications 已触发的提醒（通知中心历史，按时间倒序）
+	Notifications(filter models.NotificationFilter) []models.Notification
+}
+
+// Server 本地 REST API 服务
+type Server struct {
+	mu     sync.RWMutex
+	server *http.Server
+	port   int
+	token  string
+	source DataSource
+}
+
+// NewServer 创建本地 API 服务
+func NewServer(source DataSource) *Server {
+	return &Server{source: source}
+}
+
+// Start 启动服务，必须设置访问令牌
+func (s *Server) Start(port int, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.server != nil {
+		return i18n.Errorf("error.localapi.running")
+	}
+	if token == "" {
+		return i18n.Errorf("error.localapi.token_required")
+	}
+
+	ln, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		if isAddrInUse(err) {
+			return i18n.Errorf("error.localapi.port_in_use", port, err)
+		}
+		return i18n.Errorf("error.localapi.listen_failed", port, err)
+	}
+
+	s.port = port
+	s.token = token
+	// Serve 在锁外运行，Stop 会将 s.server 置空，这里持有本次启动的实例
+	srv := &http.Server{Handler: s.routes(), ReadHeaderTimeout: 10 * time.Second}
+	s.server = srv
+
+	go func() {
+		log.Info("本地 API 服务启动于 127.0.0.1:%d", port)
+		if err := srv.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+			log.Error("服务异常: %v", err)
+		}
+	}()
+	return nil
+}
+
+// wsaeaddrinuse Windows 下端口被占用的错误码（WSAEADDRINUSE）
+const wsaeaddrinuse = syscall.Errno(10048)
+
+// isAddrInUse 监听错误是否为端口被占用
+func isAddrInUse(err error) bool {
+	return errors.Is(err, syscall.EADDRINUSE) || errors.Is(err, wsaeaddrinuse)
+}
+
+// Stop 停止服务
+func (s *Server) Stop() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.server == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := s.server.Shutdown(ctx)
+	s.server = nil
+	log.Info("本地 API 服务已停止")
+	return err
+}
+
+// Restart 重启服务（端口或令牌变更时调用）
+func (s *Server) Restart(port int, token string) error {
+	if err := s.Stop(); err != nil {
+		return err
+	}
+	return s.Start(port, token)
+}
+
+// IsRunning 检查服务是否运行中
+func (s *Server) IsRunning() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.server != nil
+}
+
+// Config 获取当前端口与令牌
+func (s *Server) Config() (int, string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.port, s.token
+}
@@ -0,0 +1,145 @@
+package localapi
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+type fakeSource struct {
+	requested []string
+}
+
+func (f *fakeSource) Quotes(ctx context.Context, codes []string) ([]models.Stock, error) {
+	f.requested = codes
+	stocks := make([]models.Stock, len(codes))
+	for i, c := range codes {
+		stocks[i] = models.Stock{Symbol: c, Price: 10}
+	}
+	return stocks, nil
+}
+
+func (f *fakeSource) KLines(ctx context.Context, code, period string, days int) ([]models.KLineData, error) {
+	return make([]models.KLineData, days), nil
+}
+
+func (f *fakeSource) Watchlist() models.WatchlistGroups {
+	return models.WatchlistGroups{Active: "g1", Groups: []models.WatchlistGroup{{ID: "g1", Symbols: []string{"sh600000", "sz000001"}}}}
+}
+
+func (f *fakeSource) WatchlistStocks() []models.Stock          { return nil }
+func (f *fakeSource) PriceTargets() []models.PriceTarget       { return nil }
+func (f *fakeSource) ConditionOrders() []models.ConditionOrder { return nil }
+func (f *fakeSource) Notifications(filter models.NotificationFilter) []models.Notification {
+	return []models.Notification{{ID: "n1", Category: filter.Category}}
+}
+
+// TestRoutes 测试令牌鉴权与默认取当前分组行情
+func TestRoutes(t *testing.T) {
+	src := &fakeSource{}
+	s := NewServer(src)
+	s.token = "secret"
+	h := s.routes()
+
+	do := func(path, auth string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if auth != "" {
+			req.Header.Set("Authorization", "Bearer "+auth)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := do("/health", ""); rec.Code != http.StatusOK {
+		t.Errorf("/health 无需鉴权, got %d", rec.Code)
+	}
+	if rec := do("/api/quotes", "wrong"); rec.Code != http.StatusUnauthorized {
+		t.Errorf("错误令牌应返回 401, got %d", rec.Code)
+	}
+	if rec := do("/api/quotes?token=secret", ""); rec.Code != http.StatusOK {
+		t.Errorf("查询参数令牌应通过, got %d", rec.Code)
+	}
+	if len(src.requested) != 2 || src.requested[0] != "sh600000" {
+		t.Errorf("缺省 codes 应取当前分组, got %v", src.requested)
+	}
+
+	rec := do("/api/kline?code=sh600000&days=5", "secret")
+	var body struct {
+		Data []models.KLineData `json:"data"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil || len(body.Data) != 5 {
+		t.Errorf("kline 返回不符: code=%d len=%d err=%v", rec.Code, len(body.Data), err)
+	}
+	if rec := do("/api/kline?code=sh600000&days=-1", "secret"); rec.Code != http.StatusBadRequest {
+		t.Errorf("非法 days 应返回 400, got %d", rec.Code)
+	}
+	if rec := do("/metrics", ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("/metrics 需要鉴权, got %d", rec.Code)
+	}
+	if rec := do("/metrics", "secret"); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "# TYPE go_goroutines gauge") {
+		t.Errorf("/metrics 输出不符: code=%d", rec.Code)
+	}
+}
+
+// TestAlertsTriggered 测试提醒接口返回通知中心的触发记录
+func TestAlertsTriggered(t *testing.T) {
+	s := NewServer(&fakeSource{})
+	s.token = "secret"
+	req := httptest.NewRequest(http.MethodGet, "/api/alerts?category=limit", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	s.routes().ServeHTTP(rec, req)
+
+	var body struct {
+		Triggered []models.Notification `json:"triggered"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil || len(body.Triggered) != 1 || body.Triggered[0].Category != "limit" {
+		t.Errorf("triggered 返回不符: %+v %v", body.Triggered, err)
+	}
+}
+
+// TestStartPortInUse 测试端口被占用时返回可识别的底层错误
+func TestStartPortInUse(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip(err)
+	}
+	defer ln.Close()
+	port := ln.Addr().(*net.TCPAddr).Port
+
+	s := NewServer(&fakeSource{})
+	err = s.Start(port, "secret")
+	if err == nil {
+		s.Stop()
+		t.Fatal("端口被占用时应返回错误")
+	}
+	if !isAddrInUse(err) || !strings.Contains(err.Error(), "被占用") {
+		t.Errorf("错误应包装 EADDRINUSE: %v", err)
+	}
+}
+
+// TestRestart 测试停止后立即重启不会因 Serve 读取已置空的实例而崩溃
+func TestRestart(t *testing.T) {
+	s := NewServer(&fakeSource{})
+	if err := s.Start(0, "secret"); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := s.Restart(0, "secret"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	if s.IsRunning() {
+		t.Error("Stop 后不应处于运行状态")
+	}
+}
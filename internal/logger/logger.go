@@ -1,9 +1,12 @@
 package logger
 
 import (
+	"compress/gzip"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 )
@@ -34,13 +37,33 @@ var levelColors = map[Level]string{
 
 const resetColor = "\033[0m"
 
+// 文件日志默认值
+const (
+	DefaultMaxSizeMB     = 20
+	DefaultRetentionDays = 14
+)
+
+// FileOptions 文件日志选项
+type FileOptions struct {
+	MaxSizeMB     int // 单个日志文件大小上限(MB)，超出后轮转并压缩，0 使用默认值
+	RetentionDays int // 日志保留天数，启动时清理更早的日志，0 使用默认值
+}
+
 // 全局配置
 var (
 	globalLevel   = INFO
 	globalFile    *os.File
 	globalMu      sync.Mutex
-	enableConsole = true  // 是否输出到控制台
-	enableFile    = false // 是否输出到文件
+	enableConsole = true               // 是否输出到控制台
+	enableFile    = false              // 是否输出到文件
+	moduleLevels  = map[string]Level{} // 按模块覆盖的日志级别，键为小写模块名
+
+	// 文件日志状态
+	fileDir     string
+	fileDate    string // 当前日志文件日期
+	fileSize    int64  // 当前日志文件大小
+	fileMaxSize int64
+	compressWg  sync.WaitGroup // 后台压缩任务
 )
 
 // Logger 日志记录器
@@ -55,27 +78,249 @@ func SetGlobalLevel(level Level) {
 	globalLevel = level
 }
 
+// GetGlobalLevel 获取全局日志级别
+func GetGlobalLevel() Level {
+	globalMu.Lock()
+	defer globalMu.Unlock()
+	return globalLevel
+}
+
+// ParseLevel 解析日志级别名称（DEBUG/INFO/WARN/ERROR，不区分大小写）
+func ParseLevel(name string) (Level, error) {
+	for level, levelName := range levelNames {
+		if strings.EqualFold(name, levelName) {
+			return level, nil
+		}
+	}
+	return INFO, fmt.Errorf("未知日志级别: %s", name)
+}
+
+// SetModuleLevels 设置按模块覆盖的日志级别，对已创建的日志记录器立即生效；传入空表清除全部覆盖
+// 模块名不区分大小写，"openai" 同时匹配 "openai:model"、"openai:convert" 等子模块
+func SetModuleLevels(levels map[string]Level) {
+	normalized := make(map[string]Level, len(levels))
+	for module, level := range levels {
+		normalized[strings.ToLower(module)] = level
+	}
+	globalMu.Lock()
+	defer globalMu.Unlock()
+	moduleLevels = normalized
+}
+
+// GetModuleLevels 获取按模块覆盖的日志级别
+func GetModuleLevels() map[string]Level {
+	globalMu.Lock()
+	defer globalMu.Unlock()
+	levels := make(map[string]Level, len(moduleLevels))
+	for module, level := range moduleLevels {
+		levels[module] = level
+	}
+	return levels
+}
+
+// ParseModuleLevels 解析模块日志级别，格式如 "pusher=debug, market=warn"
+func ParseModuleLevels(spec string) (map[string]Level, error) {
+	levels := make(map[string]Level)
+	for _, item := range strings.FieldsFunc(spec, func(r rune) bool { return r == ',' || r == ';' }) {
+		module, name, ok := strings.Cut(item, "=")
+		module = strings.TrimSpace(module)
+		if !ok || module == "" {
+			return nil, fmt.Errorf("模块日志级别格式错误: %q，应为 模块=级别", strings.TrimSpace(item))
+		}
+		level, err := ParseLevel(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+		levels[strings.ToLower(module)] = level
+	}
+	return levels, nil
+}
+
+// levelForLocked 模块生效的日志级别：精确匹配 > 父模块（冒号前缀）> 全局级别(需要已持有锁)
+func levelForLocked(module string) Level {
+	if len(moduleLevels) == 0 {
+		return globalLevel
+	}
+	module = strings.ToLower(module)
+	if level, ok := moduleLevels[module]; ok {
+		return level
+	}
+	if parent, _, ok := strings.Cut(module, ":"); ok {
+		if level, ok := moduleLevels[parent]; ok {
+			return level
+		}
+	}
+	return globalLevel
+}
+
+// String 日志级别名称
+func (l Level) String() string {
+	return levelNames[l]
+}
+
 // InitFileLogger 初始化文件日志
-func InitFileLogger(logDir string) error {
+// 日志按日期命名，单个文件超过大小上限时轮转为 日期.序号.log 并后台压缩为 .gz，启动时清理超过保留天数的日志
+func InitFileLogger(logDir string, opts FileOptions) error {
 	globalMu.Lock()
 	defer globalMu.Unlock()
 
 	if err := os.MkdirAll(logDir, 0755); err != nil {
 		return fmt.Errorf("创建日志目录失败: %w", err)
 	}
+	if opts.MaxSizeMB <= 0 {
+		opts.MaxSizeMB = DefaultMaxSizeMB
+	}
+	if opts.RetentionDays <= 0 {
+		opts.RetentionDays = DefaultRetentionDays
+	}
+	fileDir = logDir
+	fileMaxSize = int64(opts.MaxSizeMB) << 20
+	pruneLogs(logDir, time.Now().AddDate(0, 0, -opts.RetentionDays))
+
+	if err := openLogFileLocked(time.Now()); err != nil {
+		return err
+	}
+	enableFile = true
+	return nil
+}
+
+// openLogFileLocked 打开当日日志文件，已超过大小上限时先轮转(需要已持有锁)
+func openLogFileLocked(now time.Time) error {
+	date := now.Format("2006-01-02")
+	logFile := filepath.Join(fileDir, date+".log")
+	if info, err := os.Stat(logFile); err == nil && info.Size() >= fileMaxSize {
+		rotateLocked(date)
+	}
 
-	// 按日期命名日志文件
-	logFile := filepath.Join(logDir, time.Now().Format("2006-01-02")+".log")
 	f, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
 	if err != nil {
 		return fmt.Errorf("打开日志文件失败: %w", err)
 	}
-
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("打开日志文件失败: %w", err)
+	}
 	globalFile = f
-	enableFile = true
+	fileDate = date
+	fileSize = info.Size()
 	return nil
 }
 
+// rotateLocked 将当日日志重命名为下一个序号并在后台压缩(需要已持有锁，且日志文件已关闭)
+func rotateLocked(date string) {
+	src := filepath.Join(fileDir, date+".log")
+	for i := 1; ; i++ {
+		dst := filepath.Join(fileDir, fmt.Sprintf("%s.%d.log", date, i))
+		if fileExists(dst) || fileExists(dst+".gz") {
+			continue
+		}
+		if err := os.Rename(src, dst); err != nil {
+			fmt.Fprintf(os.Stderr, "日志轮转失败: %v\n", err)
+			return
+		}
+		compressWg.Add(1)
+		go func() {
+			defer compressWg.Done()
+			if err := compressFile(dst); err != nil {
+				fmt.Fprintf(os.Stderr, "压缩日志失败: %v\n", err)
+			}
+		}()
+		return
+	}
+}
+
+// checkRotateLocked 跨日或超出大小上限时切换日志文件(需要已持有锁)
+func checkRotateLocked(now time.Time, pending int) {
+	date := now.Format("2006-01-02")
+	if date == fileDate && fileSize+int64(pending) <= fileMaxSize {
+		return
+	}
+	// 空文件不轮转，避免单行超过上限时反复生成空文件
+	if date == fileDate && fileSize == 0 {
+		return
+	}
+	globalFile.Close()
+	globalFile = nil
+	if date == fileDate {
+		rotateLocked(date)
+	}
+	if err := openLogFileLocked(now); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		enableFile = false
+	}
+}
+
+// compressFile 将文件压缩为 .gz 并删除原文件
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	tmp := path + ".gz.tmp"
+	dst, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(dst)
+	_, err = io.Copy(zw, src)
+	if cerr := zw.Close(); err == nil {
+		err = cerr
+	}
+	if cerr := dst.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, path+".gz"); err != nil {
+		return err
+	}
+	src.Close()
+	return os.Remove(path)
+}
+
+// pruneLogs 删除早于 cutoff 的日志，并压缩上次未完成压缩的轮转日志
+func pruneLogs(dir string, cutoff time.Time) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	cutoffDate := cutoff.Format("2006-01-02")
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || len(name) < 10 {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", name[:10]); err != nil {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		switch {
+		case name[:10] < cutoffDate:
+			os.Remove(path)
+		case strings.HasSuffix(name, ".gz.tmp"):
+			os.Remove(path)
+		case strings.Count(name, ".") == 2 && strings.HasSuffix(name, ".log"):
+			// 日期.序号.log：轮转后未来得及压缩
+			compressWg.Add(1)
+			go func() {
+				defer compressWg.Done()
+				compressFile(path)
+			}()
+		}
+	}
+}
+
+// fileExists 文件是否存在
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
 // SetConsoleOutput 设置是否输出到控制台
 func SetConsoleOutput(enable bool) {
 	globalMu.Lock()
@@ -92,6 +337,8 @@ func Close() {
 		globalFile = nil
 	}
 	enableFile = false
+	fileDate, fileSize = "", 0
+	compressWg.Wait()
 }
 
 // New 创建新的日志记录器
@@ -104,15 +351,16 @@ func New(module string) *Logger {
 // log 内部日志方法
 func (l *Logger) log(level Level, format string, args ...any) {
 	// 先在锁外准备数据，减少锁持有时间
-	timestamp := time.Now().Format("15:04:05.000")
+	now := time.Now()
+	timestamp := now.Format("15:04:05.000")
 	msg := fmt.Sprintf(format, args...)
 	levelName := levelNames[level]
 
 	globalMu.Lock()
-	defer globalMu.Unlock()
 
-	// 检查日志级别
-	if level < globalLevel {
+	// 检查日志级别（每次读取，修改全局或模块级别后立即生效）
+	if level < levelForLocked(l.module) {
+		globalMu.Unlock()
 		return
 	}
 
@@ -126,8 +374,22 @@ func (l *Logger) log(level Level, format string, args ...any) {
 
 	// 输出到文件（无颜色）
 	if enableFile && globalFile != nil {
-		fmt.Fprintf(globalFile, "%s [%s] %s: %s\n",
-			levelName, timestamp, l.module, msg)
+		line := fmt.Sprintf("%s [%s] %s: %s\n", levelName, timestamp, l.module, msg)
+		checkRotateLocked(now, len(line))
+		if globalFile != nil {
+			n, _ := globalFile.WriteString(line)
+			fileSize += int64(n)
+		}
+	}
+
+	// 写入内存环形缓冲，回调在锁外执行，避免回调中再次记录日志时死锁
+	entry := Entry{Time: now.UnixMilli(), Level: levelName, Module: l.module, Message: msg}
+	ring.add(entry)
+	onAppend := appendHook
+	globalMu.Unlock()
+
+	if onAppend != nil {
+		onAppend(entry)
 	}
 }
 
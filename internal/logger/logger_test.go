@@ -0,0 +1,120 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileLoggerRotation(t *testing.T) {
+	dir := t.TempDir()
+	old := time.Now().AddDate(0, 0, -30).Format("2006-01-02")
+	os.WriteFile(filepath.Join(dir, old+".log"), []byte("old"), 0644)
+	os.WriteFile(filepath.Join(dir, old+".1.log.gz"), []byte("old"), 0644)
+	os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("keep"), 0644)
+
+	SetConsoleOutput(false)
+	defer SetConsoleOutput(true)
+	if err := InitFileLogger(dir, FileOptions{RetentionDays: 7}); err != nil {
+		t.Fatalf("InitFileLogger: %v", err)
+	}
+	globalMu.Lock()
+	fileMaxSize = 200
+	globalMu.Unlock()
+
+	l := New("test")
+	for i := 0; i < 10; i++ {
+		l.Info("%s", strings.Repeat("x", 50))
+	}
+	Close()
+
+	today := time.Now().Format("2006-01-02")
+	var names []string
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	for _, gone := range []string{old + ".log", old + ".1.log.gz"} {
+		if fileExists(filepath.Join(dir, gone)) {
+			t.Errorf("%s should be pruned, got %v", gone, names)
+		}
+	}
+	for _, want := range []string{"notes.txt", today + ".log", today + ".1.log.gz", today + ".2.log.gz"} {
+		if !fileExists(filepath.Join(dir, want)) {
+			t.Errorf("missing %s, got %v", want, names)
+		}
+	}
+	if fileExists(filepath.Join(dir, today+".1.log")) {
+		t.Errorf("rotated log should be compressed, got %v", names)
+	}
+	if info, err := os.Stat(filepath.Join(dir, today+".log")); err != nil {
+		t.Errorf("stat current log: %v", err)
+	} else if info.Size() > 200 {
+		t.Errorf("current log size = %d, want <= 200", info.Size())
+	}
+}
+
+func TestModuleLevels(t *testing.T) {
+	levels, err := ParseModuleLevels("pusher=debug, OpenAI=warn;market = ERROR")
+	if err != nil {
+		t.Fatalf("ParseModuleLevels: %v", err)
+	}
+	if _, err := ParseModuleLevels("pusher"); err == nil {
+		t.Error("missing level should fail")
+	}
+	if _, err := ParseModuleLevels("pusher=verbose"); err == nil {
+		t.Error("unknown level should fail")
+	}
+
+	SetGlobalLevel(INFO)
+	SetModuleLevels(levels)
+	defer SetModuleLevels(nil)
+
+	globalMu.Lock()
+	defer globalMu.Unlock()
+	cases := map[string]Level{
+		"pusher":         DEBUG,
+		"openai:model":   WARN, // 父模块覆盖
+		"Market":         ERROR,
+		"app":            INFO,
+		"anthropic:test": INFO,
+	}
+	for module, want := range cases {
+		if got := levelForLocked(module); got != want {
+			t.Errorf("levelForLocked(%q) = %v, want %v", module, got, want)
+		}
+	}
+}
+
+func TestRecentLogs(t *testing.T) {
+	SetConsoleOutput(false)
+	defer SetConsoleOutput(true)
+	SetGlobalLevel(DEBUG)
+	defer SetGlobalLevel(INFO)
+
+	var hooked []Entry
+	SetOnAppend(func(e Entry) { hooked = append(hooked, e) })
+	defer SetOnAppend(nil)
+
+	for i := 0; i < ringCapacity+5; i++ {
+		New("ring:test").Debug("line %d", i)
+	}
+	New("ring:test").Warn("warn")
+	New("other").Error("boom")
+
+	if len(hooked) != ringCapacity+7 {
+		t.Fatalf("hook calls = %d", len(hooked))
+	}
+	all := Recent(DEBUG, "ring", 0)
+	if len(all) != ringCapacity-1 || all[len(all)-1].Message != "warn" {
+		t.Fatalf("Recent ring = %d, last %+v", len(all), all[len(all)-1])
+	}
+	if got := Recent(WARN, "", 0); len(got) != 2 || got[1].Module != "other" {
+		t.Fatalf("Recent WARN = %+v", got)
+	}
+	if got := Recent(DEBUG, "", 3); len(got) != 3 || got[2].Message != "boom" {
+		t.Fatalf("Recent limit = %+v", got)
+	}
+}
@@ -0,0 +1,77 @@
+package logger
+
+import "strings"
+
+// ringCapacity 内存中保留的最近日志条数
+const ringCapacity = 2000
+
+// Entry 日志条目
+type Entry struct {
+	Time    int64  `json:"time"` // 毫秒时间戳
+	Level   string `json:"level"`
+	Module  string `json:"module"`
+	Message string `json:"message"`
+}
+
+// ringBuffer 固定容量的环形缓冲，写满后覆盖最旧的条目
+type ringBuffer struct {
+	entries []Entry
+	next    int
+	full    bool
+}
+
+var (
+	ring       = &ringBuffer{entries: make([]Entry, ringCapacity)}
+	appendHook func(Entry)
+)
+
+// add 追加条目(需要已持有 globalMu)
+func (r *ringBuffer) add(e Entry) {
+	r.entries[r.next] = e
+	r.next = (r.next + 1) % len(r.entries)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// snapshot 按时间顺序返回全部条目(需要已持有 globalMu)
+func (r *ringBuffer) snapshot() []Entry {
+	if !r.full {
+		return append([]Entry(nil), r.entries[:r.next]...)
+	}
+	out := make([]Entry, 0, len(r.entries))
+	out = append(out, r.entries[r.next:]...)
+	return append(out, r.entries[:r.next]...)
+}
+
+// SetOnAppend 设置日志追加回调，每条写出的日志都会触发，传入 nil 取消
+// 回调在日志锁外同步执行，耗时操作应自行异步处理
+func SetOnAppend(fn func(Entry)) {
+	globalMu.Lock()
+	defer globalMu.Unlock()
+	appendHook = fn
+}
+
+// Recent 获取内存中最近的日志（按时间正序）
+// minLevel 为最低级别；module 为空不过滤，否则按模块名前缀匹配（不区分大小写）；limit<=0 返回全部
+func Recent(minLevel Level, module string, limit int) []Entry {
+	globalMu.Lock()
+	all := ring.snapshot()
+	globalMu.Unlock()
+
+	module = strings.ToLower(module)
+	result := make([]Entry, 0, len(all))
+	for _, e := range all {
+		if level, err := ParseLevel(e.Level); err == nil && level < minLevel {
+			continue
+		}
+		if module != "" && !strings.HasPrefix(strings.ToLower(e.Module), module) {
+			continue
+		}
+		result = append(result, e)
+	}
+	if limit > 0 && len(result) > limit {
+		result = result[len(result)-limit:]
+	}
+	return result
+}
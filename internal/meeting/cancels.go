@@ -0,0 +1,94 @@
+package meeting
+
+import (
+	"context"
+	"sync"
+)
+
+// Cancels 按会话（股票代码）登记进行中讨论与单专家重试的取消函数
+// 讨论每个会话只保留最新一次，重试各自独立登记，互不覆盖；
+// 结束时只移除自己的登记，不会误删同一会话后来发起的讨论
+type Cancels struct {
+	mu       sync.Mutex
+	meetings map[string]*cancelEntry
+	retries  map[string]map[*cancelEntry]struct{}
+}
+
+// cancelEntry 单次登记，用指针区分同一会话先后发起的任务
+type cancelEntry struct {
+	cancel context.CancelFunc
+}
+
+// NewCancels 创建取消函数登记表
+func NewCancels() *Cancels {
+	return &Cancels{
+		meetings: make(map[string]*cancelEntry),
+		retries:  make(map[string]map[*cancelEntry]struct{}),
+	}
+}
+
+// StartMeeting 为会话创建可取消的讨论 context，并取消该会话之前的讨论
+// 返回的 release 在讨论结束时调用，取消 context 并移除本次登记
+func (c *Cancels) StartMeeting(parent context.Context, sessionID string) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(parent)
+	entry := &cancelEntry{cancel: cancel}
+
+	c.mu.Lock()
+	if prev, ok := c.meetings[sessionID]; ok {
+		prev.cancel()
+	}
+	c.meetings[sessionID] = entry
+	c.mu.Unlock()
+
+	return ctx, func() {
+		c.mu.Lock()
+		if c.meetings[sessionID] == entry {
+			delete(c.meetings, sessionID)
+		}
+		c.mu.Unlock()
+		cancel()
+	}
+}
+
+// StartRetry 为单专家重试创建可取消的 context，不影响该会话进行中的讨论
+func (c *Cancels) StartRetry(parent context.Context, sessionID string) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(parent)
+	entry := &cancelEntry{cancel: cancel}
+
+	c.mu.Lock()
+	if c.retries[sessionID] == nil {
+		c.retries[sessionID] = make(map[*cancelEntry]struct{})
+	}
+	c.retries[sessionID][entry] = struct{}{}
+	c.mu.Unlock()
+
+	return ctx, func() {
+		c.mu.Lock()
+		if set := c.retries[sessionID]; set != nil {
+			delete(set, entry)
+			if len(set) == 0 {
+				delete(c.retries, sessionID)
+			}
+		}
+		c.mu.Unlock()
+		cancel()
+	}
+}
+
+// Cancel 取消会话进行中的讨论和所有重试，返回是否有进行中的任务
+func (c *Cancels) Cancel(sessionID string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	found := false
+	if entry, ok := c.meetings[sessionID]; ok {
+		entry.cancel()
+		delete(c.meetings, sessionID)
+		found = true
+	}
+	for entry := range c.retries[sessionID] {
+		entry.cancel()
+		found = true
+	}
+	delete(c.retries, sessionID)
+	return found
+}
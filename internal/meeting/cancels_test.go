@@ -0,0 +1,60 @@
+package meeting
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCancelsMeetingRelease(t *testing.T) {
+	c := NewCancels()
+	first, releaseFirst := c.StartMeeting(context.Background(), "sh600519")
+	second, releaseSecond := c.StartMeeting(context.Background(), "sh600519")
+	if first.Err() == nil {
+		t.Fatal("新讨论应取消同一会话之前的讨论")
+	}
+
+	// 旧讨论结束时不能移除新讨论的登记
+	releaseFirst()
+	if !c.Cancel("sh600519") {
+		t.Fatal("新讨论的登记被旧讨论误删")
+	}
+	if second.Err() == nil {
+		t.Fatal("CancelDiscussion 应取消进行中的讨论")
+	}
+	releaseSecond()
+	if c.Cancel("sh600519") {
+		t.Error("讨论结束后不应再有进行中的任务")
+	}
+}
+
+func TestCancelsRetry(t *testing.T) {
+	c := NewCancels()
+	meetingCtx, releaseMeeting := c.StartMeeting(context.Background(), "sh600519")
+	retryA, releaseA := c.StartRetry(context.Background(), "sh600519")
+	retryB, releaseB := c.StartRetry(context.Background(), "sh600519")
+
+	// 重试结束不影响讨论和其他重试
+	releaseA()
+	if retryA.Err() == nil || meetingCtx.Err() != nil || retryB.Err() != nil {
+		t.Fatalf("重试释放只应取消自身: retryA=%v meeting=%v retryB=%v", retryA.Err(), meetingCtx.Err(), retryB.Err())
+	}
+
+	// 停止讨论同时停止该会话的重试，其他会话不受影响
+	other, releaseOther := c.StartRetry(context.Background(), "sz000001")
+	defer releaseOther()
+	if !c.Cancel("sh600519") {
+		t.Fatal("应有进行中的任务")
+	}
+	if meetingCtx.Err() == nil || retryB.Err() == nil || other.Err() != nil {
+		t.Fatalf("取消结果错误: meeting=%v retryB=%v other=%v", meetingCtx.Err(), retryB.Err(), other.Err())
+	}
+	releaseB()
+	releaseMeeting()
+
+	// 只有重试在进行时也能停止
+	retry, release := c.StartRetry(context.Background(), "sh600519")
+	defer release()
+	if !c.Cancel("sh600519") || retry.Err() == nil {
+		t.Error("只有重试时 Cancel 应返回 true 并取消重试")
+	}
+}
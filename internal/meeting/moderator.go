@@ -57,6 +57,94 @@ func (m *Moderator) Summarize(ctx context.Context, stock *models.Stock, query st
 	return m.generate(ctx, prompt)
 }
 
+// ConsensusResult 共识检测结果
+type ConsensusResult struct {
+	Consensus bool   `json:"consensus"`
+	Reason    string `json:"reason"`
+}
+
+// CheckConsensus 判断专家是否已就核心问题达成共识
+func (m *Moderator) CheckConsensus(ctx context.Context, stock *models.Stock, query string, history []DiscussionEntry) (*ConsensusResult, error) {
+	var sb strings.Builder
+	sb.WriteString("你是会议小韭菜，请判断专家们是否已就老韭菜的问题达成共识。\n\n")
+	fmt.Fprintf(&sb, "## 股票：%s (%s)\n\n", stock.Name, stock.Symbol)
+	sb.WriteString("## 老韭菜问题\n")
+	sb.WriteString(query + "\n\n")
+	sb.WriteString("## 讨论记录\n")
+	writeHistory(&sb, history)
+	sb.WriteString("## 判断标准\n")
+	sb.WriteString("核心结论方向一致（如同为看多/看空）且无实质分歧时视为达成共识；继续讨论难以产生新信息时也视为达成共识。\n\n")
+	sb.WriteString("## 输出格式（仅输出JSON）\n")
+	sb.WriteString(`{"consensus":true,"reason":"一句话说明共识内容或主要分歧"}`)
+
+	content, err := m.generate(ctx, sb.String())
+	if err != nil {
+		return nil, fmt.Errorf("moderator consensus error: %w", err)
+	}
+	jsonStr := m.extractJSON(content)
+	if jsonStr == "" {
+		return nil, fmt.Errorf("无法从响应中提取 JSON: %s", truncateString(content, 200))
+	}
+	var result ConsensusResult
+	if err := json.Unmarshal([]byte(jsonStr), &result); err != nil {
+		return nil, fmt.Errorf("JSON 解析失败: %w, 原文: %s", err, truncateString(jsonStr, 200))
+	}
+	return &result, nil
+}
+
+// VoteMotion 投票议题
+type VoteMotion struct {
+	Motion  string   `json:"motion"`
+	Options []string `json:"options"`
+}
+
+// defaultVoteOptions 默认投票选项
+var defaultVoteOptions = []string{"看多", "中性", "看空"}
+
+// ProposeVote 根据首轮讨论拟定投票议题
+// 解析失败时退回到以用户问题为议题、看多/中性/看空为选项
+func (m *Moderator) ProposeVote(ctx context.Context, stock *models.Stock, query string, history []DiscussionEntry) *VoteMotion {
+	fallback := &VoteMotion{Motion: query, Options: defaultVoteOptions}
+
+	var sb strings.Builder
+	sb.WriteString("你是会议小韭菜，请根据专家讨论拟定一个投票议题，让专家表决。\n\n")
+	fmt.Fprintf(&sb, "## 股票：%s (%s)\n\n", stock.Name, stock.Symbol)
+	sb.WriteString("## 老韭菜问题\n")
+	sb.WriteString(query + "\n\n")
+	sb.WriteString("## 讨论记录\n")
+	writeHistory(&sb, history)
+	sb.WriteString("## 要求\n")
+	sb.WriteString("1. 议题是一个可以直接表决的问题，紧扣老韭菜的问题\n")
+	sb.WriteString("2. 给出 2-4 个互斥的简短选项（每个不超过6字）\n\n")
+	sb.WriteString("## 输出格式（仅输出JSON）\n")
+	sb.WriteString(`{"motion":"议题","options":["看多","中性","看空"]}`)
+
+	content, err := m.generate(ctx, sb.String())
+	if err != nil {
+		log.Warn("propose vote error, using default motion: %v", err)
+		return fallback
+	}
+	var motion VoteMotion
+	jsonStr := m.extractJSON(content)
+	if jsonStr == "" || json.Unmarshal([]byte(jsonStr), &motion) != nil || motion.Motion == "" || len(motion.Options) < 2 {
+		log.Warn("parse vote motion failed, using default: %s", truncateString(content, 200))
+		return fallback
+	}
+	return &motion
+}
+
+// writeHistory 写入讨论记录（多轮讨论时标注轮次）
+func writeHistory(sb *strings.Builder, history []DiscussionEntry) {
+	multiRound := len(history) > 0 && history[len(history)-1].Round > 1
+	for _, e := range history {
+		if multiRound {
+			fmt.Fprintf(sb, "【第%d轮 %s（%s）】\n%s\n\n", e.Round, e.AgentName, e.Role, e.Content)
+		} else {
+			fmt.Fprintf(sb, "【%s（%s）】\n%s\n\n", e.AgentName, e.Role, e.Content)
+		}
+	}
+}
+
 // generate 调用 LLM 生成内容
 func (m *Moderator) generate(ctx context.Context, prompt string) (string, error) {
 	req := &model.LLMRequest{
@@ -115,9 +203,7 @@ func (m *Moderator) buildSummarizePrompt(stock *models.Stock, query string, hist
 	sb.WriteString("## 老韭菜问题\n")
 	sb.WriteString(query + "\n\n")
 	sb.WriteString("## 讨论记录\n")
-	for _, e := range history {
-		fmt.Fprintf(&sb, "【%s（%s）】\n%s\n\n", e.AgentName, e.Role, e.Content)
-	}
+	writeHistory(&sb, history)
 	sb.WriteString("## 输出要求\n")
 	sb.WriteString("1. 核心结论（直接回答老韭菜）\n")
 	sb.WriteString("2. 各方观点摘要\n")
@@ -0,0 +1,478 @@
+package meeting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/adk"
+	"github.com/run-bigpig/jcp/internal/memory"
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/apperr"
+)
+
+// 编排轮数配置
+const (
+	DefaultDebateRounds    = 2 // 辩论模式默认轮数（首轮观点 + 一轮反驳）
+	MaxOrchestrationRounds = 5 // 单次讨论最大轮数
+)
+
+// 消息类型（多轮编排新增）
+const (
+	MsgTypeRebuttal   = "rebuttal"    // 辩论反驳
+	MsgTypeVote       = "vote"        // 专家投票
+	MsgTypeVoteResult = "vote_result" // 投票结果
+	MsgTypeConsensus  = "consensus"   // 达成共识提前结束
+)
+
+// normalizeOrchestration 补全编排配置默认值
+func normalizeOrchestration(cfg *models.OrchestrationConfig) models.OrchestrationConfig {
+	var o models.OrchestrationConfig
+	if cfg != nil {
+		o = *cfg
+	}
+
+	switch o.Mode {
+	case models.OrchestrationDebate:
+		if o.MaxRounds < 2 {
+			o.MaxRounds = DefaultDebateRounds
+		}
+	case models.OrchestrationVote:
+		// 投票固定为观点轮 + 投票轮
+		o.MaxRounds = 2
+	case models.OrchestrationParallel:
+		o.MaxRounds = 1
+	default:
+		o.Mode = models.OrchestrationRoundRobin
+		if o.MaxRounds < 1 {
+			o.MaxRounds = 1
+		}
+	}
+	if o.MaxRounds > MaxOrchestrationRounds {
+		o.MaxRounds = MaxOrchestrationRounds
+	}
+	return o
+}
+
+// isDefaultOrchestration 是否为原有的单轮串行模式（支持中断恢复）
+func isDefaultOrchestration(o models.OrchestrationConfig) bool {
+	return o.Mode == models.OrchestrationRoundRobin && o.MaxRounds == 1
+}
+
+// meetingTimeout 按轮数放宽会议超时
+func meetingTimeout(o models.OrchestrationConfig) time.Duration {
+	return MeetingTimeout * time.Duration(o.MaxRounds)
+}
+
+// orchestration 多轮讨论编排状态
+type orchestration struct {
+	s                *Service
+	ctx              context.Context // 用户请求 ctx，用于区分停止与超时
+	meetingCtx       context.Context
+	aiConfig         *models.AIConfig
+	req              ChatRequest
+	cfg              models.OrchestrationConfig
+	moderator        *Moderator
+	decision         *ModeratorDecision
+	agents           []models.AgentConfig
+	memoryContext    string
+	respCallback     ResponseCallback
+	progressCallback ProgressCallback
+
+	mu         sync.Mutex
+	builders   map[string]*adk.ExpertAgentBuilder
+	responses  []ChatResponse
+	history    []DiscussionEntry
+	startRound int // 恢复讨论时的起始轮次，之前的轮次已完成
+}
+
+// runOrchestrated 按编排模式运行讨论，结束后由小韭菜总结
+// 专家失败时记录错误并继续（多轮模式不缓存中断状态）
+// history 与 startRound 用于恢复讨论：已在历史中发言的专家不再重复发言
+func (s *Service) runOrchestrated(
+	ctx, meetingCtx context.Context,
+	aiConfig *models.AIConfig,
+	req ChatRequest,
+	cfg models.OrchestrationConfig,
+	moderator *Moderator,
+	decision *ModeratorDecision,
+	agents []models.AgentConfig,
+	memoryContext string,
+	stockMemory *memory.StockMemory,
+	responses []ChatResponse,
+	history []DiscussionEntry,
+	startRound int,
+	respCallback ResponseCallback,
+	progressCallback ProgressCallback,
+) ([]ChatResponse, error) {
+	o := &orchestration{
+		s: s, ctx: ctx, meetingCtx: meetingCtx, aiConfig: aiConfig, req: req, cfg: cfg,
+		moderator: moderator, decision: decision, agents: agents, memoryContext: memoryContext,
+		respCallback: respCallback, progressCallback: progressCallback,
+		builders: make(map[string]*adk.ExpertAgentBuilder), responses: responses,
+		history: history, startRound: max(startRound, 1),
+	}
+	log.Info("orchestrated meeting: mode=%s, rounds=%d, earlyStop=%v, agents=%d", cfg.Mode, cfg.MaxRounds, cfg.EarlyStop, len(agents))
+
+	var err error
+	switch cfg.Mode {
+	case models.OrchestrationParallel:
+		err = o.runParallelRound()
+	case models.OrchestrationVote:
+		if err = o.runSerialRound(1, "opinion", o.firstRoundQuery); err == nil {
+			err = o.runVote()
+		}
+	default:
+		err = o.runRounds()
+	}
+	if err != nil {
+		return o.responses, err
+	}
+
+	if isCancelled(ctx) {
+		return o.responses, ErrCancelled
+	}
+	state := &MeetingState{
+		Stock:       req.Stock,
+		Query:       req.Query,
+		StockMemory: stockMemory,
+		Moderator:   moderator,
+	}
+	return s.runMeetingSummary(meetingCtx, state, o.history, o.responses, respCallback, progressCallback)
+}
+
+// runRounds 轮流发言/辩论：首轮观点后进入后续轮次，可按共识提前结束
+func (o *orchestration) runRounds() error {
+	msgType := "opinion"
+	queryFn := o.followUpQuery
+	if o.cfg.Mode == models.OrchestrationDebate {
+		msgType = MsgTypeRebuttal
+		queryFn = o.rebuttalQuery
+	}
+
+	for round := o.startRound; round <= o.cfg.MaxRounds; round++ {
+		if round == 1 {
+			if err := o.runSerialRound(1, "opinion", o.firstRoundQuery); err != nil {
+				return err
+			}
+		} else if err := o.runSerialRound(round, msgType, queryFn); err != nil {
+			return err
+		}
+
+		if round < o.cfg.MaxRounds && o.cfg.EarlyStop && o.consensusReached(round) {
+			break
+		}
+	}
+	return nil
+}
+
+// runSerialRound 专家串行发言一轮，后发言者参考此前全部讨论
+func (o *orchestration) runSerialRound(round int, msgType string, queryFn func(round int, cfg *models.AgentConfig) string) error {
+	for i := range o.agents {
+		agentCfg := o.agents[i]
+		if o.spoke(round, agentCfg.ID) {
+			continue
+		}
+		if err := o.checkMeeting(); err != nil {
+			return err
+		}
+
+		previousContext := o.s.buildPreviousContext(o.history)
+		if o.memoryContext != "" {
+			previousContext = o.memoryContext + "\n" + previousContext
+		}
+		content, ok, err := o.speak(&agentCfg, round, msgType, queryFn(round, &agentCfg), previousContext)
+		if err != nil {
+			return err
+		}
+		if ok {
+			o.record(&agentCfg, round, content)
+		}
+	}
+	return nil
+}
+
+// runParallelRound 专家并行独立发言（互不参考），按完成顺序推送
+func (o *orchestration) runParallelRound() error {
+	var wg sync.WaitGroup
+	errCh := make(chan error, len(o.agents))
+	for i := range o.agents {
+		if o.spoke(1, o.agents[i].ID) {
+			continue
+		}
+		wg.Add(1)
+		go func(agentCfg models.AgentConfig) {
+			defer wg.Done()
+			content, ok, err := o.speak(&agentCfg, 1, "opinion", o.firstRoundQuery(1, &agentCfg), o.memoryContext)
+			if err != nil {
+				errCh <- err
+				return
+			}
+			if ok {
+				o.record(&agentCfg, 1, content)
+			}
+		}(o.agents[i])
+	}
+	wg.Wait()
+	close(errCh)
+
+	if err := <-errCh; err != nil {
+		return err
+	}
+	return o.checkMeeting()
+}
+
+// runVote 小韭菜拟定议题，专家依次投票后统计结果
+func (o *orchestration) runVote() error {
+	if len(o.history) == 0 {
+		return nil
+	}
+	if err := o.checkMeeting(); err != nil {
+		return err
+	}
+
+	emitProgress(o.progressCallback, ProgressEvent{
+		Type: "agent_start", AgentID: "moderator", AgentName: "小韭菜", Detail: "发起投票",
+	})
+	voteCtx, voteCancel := context.WithTimeout(o.meetingCtx, ModeratorTimeout)
+	motion := o.moderator.ProposeVote(voteCtx, &o.req.Stock, o.req.Query, o.history)
+	voteCancel()
+	emitProgress(o.progressCallback, ProgressEvent{
+		Type: "agent_done", AgentID: "moderator", AgentName: "小韭菜",
+	})
+
+	voteQuery := func(round int, cfg *models.AgentConfig) string {
+		return fmt.Sprintf("小韭菜发起投票：%s\n选项：%s\n请在第一行以「投票：选项」的格式给出你的选择，随后用不超过100字说明理由。",
+			motion.Motion, strings.Join(motion.Options, " / "))
+	}
+	start := len(o.history)
+	if err := o.runSerialRound(2, MsgTypeVote, voteQuery); err != nil {
+		return err
+	}
+
+	// 统计票数
+	voters := make(map[string][]string, len(motion.Options))
+	for _, entry := range o.history[start:] {
+		choice := parseVote(entry.Content, motion.Options)
+		voters[choice] = append(voters[choice], entry.AgentName)
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "**议题**：%s\n\n", motion.Motion)
+	for _, opt := range append(append([]string{}, motion.Options...), voteAbstain) {
+		names := voters[opt]
+		if opt == voteAbstain && len(names) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "- %s：%d 票", opt, len(names))
+		if len(names) > 0 {
+			fmt.Fprintf(&sb, "（%s）", strings.Join(names, "、"))
+		}
+		sb.WriteString("\n")
+	}
+
+	result := ChatResponse{
+		AgentID: "moderator", AgentName: "小韭菜", Role: "投票结果",
+		Content: sb.String(), Round: 2, MsgType: MsgTypeVoteResult, MeetingMode: MeetingModeSmart,
+	}
+	o.emit(result)
+	o.history = append(o.history, DiscussionEntry{
+		Round: 2, AgentID: "moderator", AgentName: "小韭菜", Role: "投票结果", Content: result.Content,
+	})
+	return nil
+}
+
+// voteAbstain 无法识别投票选项时计为弃权
+const voteAbstain = "弃权"
+
+// parseVote 从专家回复中识别投票选项：优先匹配「投票：」所在行，其次匹配全文首个出现的选项
+func parseVote(content string, options []string) string {
+	for _, line := range strings.Split(content, "\n") {
+		if strings.Contains(line, "投票") {
+			if opt := firstOption(line, options); opt != "" {
+				return opt
+			}
+		}
+	}
+	if opt := firstOption(content, options); opt != "" {
+		return opt
+	}
+	return voteAbstain
+}
+
+// firstOption 返回文本中最先出现的选项
+func firstOption(text string, options []string) string {
+	best, bestIdx := "", -1
+	for _, opt := range options {
+		if idx := strings.Index(text, opt); idx >= 0 && (bestIdx < 0 || idx < bestIdx) {
+			best, bestIdx = opt, idx
+		}
+	}
+	return best
+}
+
+// consensusReached 检测共识，达成时推送小韭菜说明
+func (o *orchestration) consensusReached(round int) bool {
+	emitProgress(o.progressCallback, ProgressEvent{
+		Type: "agent_start", AgentID: "moderator", AgentName: "小韭菜", Detail: "检查共识",
+	})
+	checkCtx, checkCancel := context.WithTimeout(o.meetingCtx, ModeratorTimeout)
+	result, err := o.moderator.CheckConsensus(checkCtx, &o.req.Stock, o.req.Query, o.history)
+	checkCancel()
+	emitProgress(o.progressCallback, ProgressEvent{
+		Type: "agent_done", AgentID: "moderator", AgentName: "小韭菜",
+	})
+
+	if err != nil {
+		// 检测失败按未达成共识处理，继续讨论
+		log.Warn("check consensus error: %v", err)
+		return false
+	}
+	if !result.Consensus {
+		log.Debug("round %d no consensus: %s", round, result.Reason)
+		return false
+	}
+
+	log.Info("consensus reached after round %d: %s", round, result.Reason)
+	o.emit(ChatResponse{
+		AgentID: "moderator", AgentName: "小韭菜", Role: "达成共识",
+		Content: fmt.Sprintf("第%d轮后专家已达成共识，提前结束讨论：%s", round, result.Reason),
+		Round:   round, MsgType: MsgTypeConsensus, MeetingMode: MeetingModeSmart,
+	})
+	return true
+}
+
+// firstRoundQuery 首轮使用小韭菜分配的专属任务，若无则为用户原始问题
+func (o *orchestration) firstRoundQuery(round int, cfg *models.AgentConfig) string {
+	if o.decision != nil && o.decision.Tasks != nil {
+		if task, ok := o.decision.Tasks[cfg.ID]; ok && task != "" {
+			return task
+		}
+	}
+	return o.req.Query
+}
+
+// followUpQuery 轮流发言后续轮次的提问
+func (o *orchestration) followUpQuery(round int, cfg *models.AgentConfig) string {
+	return fmt.Sprintf("这是第%d轮讨论。请结合其他专家的最新发言，补充或修正你对「%s」的判断，避免重复已有内容。", round, o.req.Query)
+}
+
+// rebuttalQuery 辩论反驳轮的提问
+func (o *orchestration) rebuttalQuery(round int, cfg *models.AgentConfig) string {
+	return fmt.Sprintf("这是第%d轮辩论。请针对其他专家观点中你不认同之处逐条反驳，并用数据支撑你的立场；认同的观点简要说明即可。原始问题：%s", round, o.req.Query)
+}
+
+// checkMeeting 检查会议是否被停止或超时
+func (o *orchestration) checkMeeting() error {
+	select {
+	case <-o.meetingCtx.Done():
+		if isCancelled(o.ctx) {
+			return ErrCancelled
+		}
+		log.Warn("meeting timeout, got %d responses", len(o.responses))
+		return ErrMeetingTimeout
+	default:
+		return nil
+	}
+}
+
+// speak 运行单个专家发言
+// 返回 ok=false 表示该专家失败已记录错误；返回 error 表示需要中止整个会议
+func (o *orchestration) speak(cfg *models.AgentConfig, round int, msgType, query, previousContext string) (string, bool, error) {
+	builder, err := o.builder(cfg)
+	if err != nil {
+		log.Error("create agent LLM error: %v", err)
+		return "", false, nil
+	}
+
+	emitProgress(o.progressCallback, ProgressEvent{
+		Type: "agent_start", AgentID: cfg.ID, AgentName: cfg.Name, Detail: cfg.Role,
+	})
+	content, err := retryRun(o.meetingCtx, MaxAgentRetries, func() (string, error) {
+		agentCtx, agentCancel := context.WithTimeout(o.meetingCtx, AgentTimeout)
+		defer agentCancel()
+		return o.s.runSingleAgent(agentCtx, builder, cfg, &o.req.Stock, query, previousContext, o.progressCallback, o.req.Position)
+	})
+
+	if err != nil && isCancelled(o.ctx) {
+		o.mu.Lock()
+		o.responses, _ = o.s.flushCancelled(o.responses, cfg, content, MeetingModeSmart, o.respCallback, o.progressCallback)
+		o.mu.Unlock()
+		return "", false, ErrCancelled
+	}
+	emitProgress(o.progressCallback, ProgressEvent{
+		Type: "agent_done", AgentID: cfg.ID, AgentName: cfg.Name,
+	})
+
+	resp := ChatResponse{
+		AgentID: cfg.ID, AgentName: cfg.Name, Role: cfg.Role,
+		Content: content, Round: round, MsgType: msgType, MeetingMode: MeetingModeSmart,
+	}
+	if err != nil {
+		log.Error("agent %s round %d failed after retries: %v", cfg.ID, round, err)
+		emitProgress(o.progressCallback, ProgressEvent{
+			Type: "agent_error", AgentID: cfg.ID, AgentName: cfg.Name, Detail: err.Error(),
+		})
+		resp.Content = ""
+		resp.Error = err.Error()
+		resp.ErrorCode = string(apperr.CodeOf(err))
+		o.emit(resp)
+		if errors.Is(err, context.DeadlineExceeded) && o.meetingCtx.Err() != nil {
+			return "", false, ErrMeetingTimeout
+		}
+		return "", false, nil
+	}
+
+	o.emit(resp)
+	return content, true, nil
+}
+
+// builder 获取专家的 Agent 构建器（同一会议内复用模型）
+func (o *orchestration) builder(cfg *models.AgentConfig) (*adk.ExpertAgentBuilder, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if b, ok := o.builders[cfg.ID]; ok {
+		return b, nil
+	}
+	agentAIConfig := o.s.resolveAgentAIConfig(cfg, o.aiConfig)
+	agentLLM, err := o.s.modelFactory.CreateModel(o.meetingCtx, agentAIConfig)
+	if err != nil {
+		return nil, err
+	}
+	b := o.s.createBuilder(agentLLM, agentAIConfig)
+	o.builders[cfg.ID] = b
+	return b, nil
+}
+
+// spoke 专家在该轮是否已发言（恢复讨论时跳过）
+func (o *orchestration) spoke(round int, agentID string) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, entry := range o.history {
+		if entry.Round == round && entry.AgentID == agentID {
+			return true
+		}
+	}
+	return false
+}
+
+// emit 记录响应并回调
+func (o *orchestration) emit(resp ChatResponse) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.responses = append(o.responses, resp)
+	if o.respCallback != nil {
+		o.respCallback(resp)
+	}
+}
+
+// record 记录到讨论历史
+func (o *orchestration) record(cfg *models.AgentConfig, round int, content string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.history = append(o.history, DiscussionEntry{
+		Round: round, AgentID: cfg.ID, AgentName: cfg.Name, Role: cfg.Role, Content: content,
+	})
+}
@@ -0,0 +1,45 @@
+package meeting
+
+import (
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+func TestNormalizeOrchestration(t *testing.T) {
+	cases := []struct {
+		in        *models.OrchestrationConfig
+		mode      string
+		maxRounds int
+	}{
+		{nil, models.OrchestrationRoundRobin, 1},
+		{&models.OrchestrationConfig{Mode: "unknown"}, models.OrchestrationRoundRobin, 1},
+		{&models.OrchestrationConfig{Mode: models.OrchestrationRoundRobin, MaxRounds: 9}, models.OrchestrationRoundRobin, MaxOrchestrationRounds},
+		{&models.OrchestrationConfig{Mode: models.OrchestrationDebate}, models.OrchestrationDebate, DefaultDebateRounds},
+		{&models.OrchestrationConfig{Mode: models.OrchestrationVote, MaxRounds: 4}, models.OrchestrationVote, 2},
+		{&models.OrchestrationConfig{Mode: models.OrchestrationParallel, MaxRounds: 3}, models.OrchestrationParallel, 1},
+	}
+	for _, c := range cases {
+		got := normalizeOrchestration(c.in)
+		if got.Mode != c.mode || got.MaxRounds != c.maxRounds {
+			t.Errorf("normalizeOrchestration(%+v) = %s/%d, want %s/%d", c.in, got.Mode, got.MaxRounds, c.mode, c.maxRounds)
+		}
+	}
+	if !isDefaultOrchestration(normalizeOrchestration(nil)) {
+		t.Error("nil config should keep the default single-round mode")
+	}
+}
+
+func TestParseVote(t *testing.T) {
+	options := []string{"看多", "中性", "看空"}
+	cases := map[string]string{
+		"短期看多情绪较浓，但估值偏高。\n投票：看空": "看空",
+		"我倾向中性，等待财报确认":           "中性",
+		"暂不表态":                   voteAbstain,
+	}
+	for content, want := range cases {
+		if got := parseVote(content, options); got != want {
+			t.Errorf("parseVote(%q) = %s, want %s", content, got, want)
+		}
+	}
+}
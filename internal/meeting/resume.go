@@ -0,0 +1,99 @@
+package meeting
+
+import (
+	"context"
+	"encoding/json"
+	"unicode/utf8"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// maxToolContentLen 工具参数与结果在进度事件中保留的最大字节数
+const maxToolContentLen = 2000
+
+// ResumeRequest 从讨论记录恢复讨论的请求
+type ResumeRequest struct {
+	ChatRequest
+	Decision *ModeratorDecision   // 原讨论中小韭菜的选择与任务分配
+	Messages []models.ChatMessage // 原讨论已产生的全部消息
+}
+
+// ResumeDiscussion 从讨论记录恢复被中断的讨论
+// 保留已完成的发言，从最后完成的轮次继续：跳过本轮已成功发言的专家，投票轮整体重来，最后由小韭菜总结
+func (s *Service) ResumeDiscussion(ctx context.Context, aiConfig *models.AIConfig, req ResumeRequest, respCallback ResponseCallback, progressCallback ProgressCallback) ([]ChatResponse, error) {
+	if aiConfig == nil {
+		return nil, ErrNoAIConfig
+	}
+	if req.Decision == nil {
+		return nil, ErrNoAgents
+	}
+	selectedAgents := s.filterAgentsOrdered(req.AllAgents, req.Decision.Selected)
+	if len(selectedAgents) == 0 {
+		return nil, ErrNoAgents
+	}
+	orch := normalizeOrchestration(req.Orchestration)
+
+	meetingCtx, meetingCancel := context.WithTimeout(ctx, meetingTimeout(orch))
+	defer meetingCancel()
+
+	moderator, stockMemory, memoryContext, err := s.prepareMeeting(meetingCtx, aiConfig, req.ChatRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	history, startRound := historyFromMessages(req.Messages, orch)
+	log.Info("resume meeting: stock=%s, mode=%s, history=%d, startRound=%d", req.Stock.Symbol, orch.Mode, len(history), startRound)
+
+	return s.runOrchestrated(ctx, meetingCtx, aiConfig, req.ChatRequest, orch, moderator, req.Decision, selectedAgents,
+		memoryContext, stockMemory, nil, history, startRound, respCallback, progressCallback)
+}
+
+// historyFromMessages 从讨论消息重建已完成的讨论历史，返回历史与继续的起始轮次
+// 失败、被停止的发言不计入；投票轮需要统一议题，未完成时整体重来
+func historyFromMessages(messages []models.ChatMessage, orch models.OrchestrationConfig) ([]DiscussionEntry, int) {
+	var history []DiscussionEntry
+	startRound := 1
+	for _, m := range messages {
+		if m.MsgType == MsgTypeConsensus {
+			// 已达成共识，不再进行后续轮次
+			startRound = orch.MaxRounds + 1
+			continue
+		}
+		if m.AgentID == "user" || m.AgentID == "moderator" || m.Error != "" || m.Cancelled || m.Content == "" {
+			continue
+		}
+		if orch.Mode == models.OrchestrationVote && m.Round >= 2 {
+			continue
+		}
+		round := max(m.Round, 1)
+		history = append(history, DiscussionEntry{
+			Round: round, AgentID: m.AgentID, AgentName: m.AgentName, Role: m.Role, Content: m.Content,
+		})
+		if round > startRound && startRound <= orch.MaxRounds {
+			startRound = round
+		}
+	}
+	return history, startRound
+}
+
+// compactJSON 序列化为单行 JSON，失败返回空串
+func compactJSON(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// toolContent 工具参数或结果的 JSON 摘要，超长时按字符边界截断
+func toolContent(v any) string {
+	s := compactJSON(v)
+	if len(s) <= maxToolContentLen {
+		return s
+	}
+	cut := maxToolContentLen
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + "..."
+}
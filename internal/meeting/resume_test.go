@@ -0,0 +1,36 @@
+package meeting
+
+import (
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+func TestHistoryFromMessages(t *testing.T) {
+	messages := []models.ChatMessage{
+		{AgentID: "user", Content: "问题"},
+		{AgentID: "moderator", Content: "开场", MsgType: "opening"},
+		{AgentID: "a", Content: "观点A", Round: 1, MsgType: "opinion"},
+		{AgentID: "b", Content: "观点B", Round: 1, MsgType: "opinion"},
+		{AgentID: "a", Content: "反驳A", Round: 2, MsgType: MsgTypeRebuttal},
+		{AgentID: "b", Error: "timeout", Round: 2, MsgType: MsgTypeRebuttal},
+		{AgentID: "c", Content: "半句", Round: 2, Cancelled: true},
+	}
+
+	debate := normalizeOrchestration(&models.OrchestrationConfig{Mode: models.OrchestrationDebate, MaxRounds: 3})
+	history, start := historyFromMessages(messages, debate)
+	if len(history) != 3 || start != 2 {
+		t.Fatalf("debate: history=%d start=%d, want 3/2", len(history), start)
+	}
+
+	vote := normalizeOrchestration(&models.OrchestrationConfig{Mode: models.OrchestrationVote})
+	history, _ = historyFromMessages(messages, vote)
+	if len(history) != 2 {
+		t.Fatalf("vote: history=%d, want 2 (vote round is redone)", len(history))
+	}
+
+	consensus := append(messages, models.ChatMessage{AgentID: "moderator", MsgType: MsgTypeConsensus, Round: 2})
+	if _, start := historyFromMessages(consensus, debate); start != debate.MaxRounds+1 {
+		t.Fatalf("consensus: start=%d, want %d", start, debate.MaxRounds+1)
+	}
+}
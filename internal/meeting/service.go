@@ -15,6 +15,7 @@ import (
 	"github.com/run-bigpig/jcp/internal/logger"
 	"github.com/run-bigpig/jcp/internal/memory"
 	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/apperr"
 
 	"google.golang.org/adk/agent"
 	"google.golang.org/adk/model"
@@ -47,8 +48,14 @@ var (
 	ErrModeratorTimeout = errors.New("小韭菜响应超时")
 	ErrNoAIConfig       = errors.New("未配置 AI 服务")
 	ErrNoAgents         = errors.New("没有可用的专家")
+	ErrCancelled        = errors.New("讨论已停止")
 )
 
+// isCancelled 讨论是否被用户主动停止（区别于会议超时）
+func isCancelled(ctx context.Context) bool {
+	return errors.Is(ctx.Err(), context.Canceled)
+}
+
 // isRetryableError 判断错误是否可重试
 // 超时、主动取消、配置错误不重试；网络错误、API 临时错误可重试
 func isRetryableError(err error) bool {
@@ -178,6 +185,8 @@ type ChatRequest struct {
 	ReplyContent string                `json:"replyContent"`
 	AllAgents    []models.AgentConfig  `json:"allAgents"` // 所有可用专家（智能模式用）
 	Position     *models.StockPosition `json:"position"`  // 用户持仓信息
+	// Orchestration 讨论编排配置（智能模式用，nil 为单轮轮流发言）
+	Orchestration *models.OrchestrationConfig `json:"orchestration"`
 }
 
 // 会议模式常量
@@ -195,7 +204,23 @@ type ChatResponse struct {
 	Round       int    `json:"round"`
 	MsgType     string `json:"msgType"`               // opening/opinion/summary
 	Error       string `json:"error,omitempty"`       // 失败时的错误信息，前端据此显示重试按钮
+	ErrorCode   string `json:"errorCode,omitempty"`   // 错误码（apperr.Code），前端据此本地化提示
 	MeetingMode string `json:"meetingMode,omitempty"` // smart=串行, direct=独立
+	Cancelled   bool   `json:"cancelled,omitempty"`   // 讨论被停止，Content 为已输出的部分内容
+}
+
+// cancelledResponse 讨论被停止时专家已输出的部分内容
+func cancelledResponse(cfg *models.AgentConfig, content, mode string) ChatResponse {
+	return ChatResponse{
+		AgentID:     cfg.ID,
+		AgentName:   cfg.Name,
+		Role:        cfg.Role,
+		Content:     content,
+		Round:       1,
+		MsgType:     "opinion",
+		MeetingMode: mode,
+		Cancelled:   true,
+	}
 }
 
 // ResponseCallback 响应回调函数类型
@@ -204,7 +229,7 @@ type ResponseCallback func(resp ChatResponse)
 
 // ProgressEvent 进度事件（细粒度实时反馈）
 type ProgressEvent struct {
-	Type      string `json:"type"`      // thinking/tool_call/tool_result/streaming/agent_start/agent_done
+	Type      string `json:"type"`      // thinking/tool_call/tool_result/streaming/agent_start/agent_done/agents_selected
 	AgentID   string `json:"agentId"`   // 当前专家 ID
 	AgentName string `json:"agentName"` // 当前专家名称
 	Detail    string `json:"detail"`    // 工具名称或阶段描述
@@ -239,6 +264,62 @@ func (s *Service) RunSmartMeeting(ctx context.Context, aiConfig *models.AIConfig
 	return s.RunSmartMeetingWithCallback(ctx, aiConfig, req, nil, nil)
 }
 
+// prepareMeeting 创建会议所需的小韭菜、记忆 LLM，并加载股票记忆
+func (s *Service) prepareMeeting(meetingCtx context.Context, aiConfig *models.AIConfig, req ChatRequest) (*Moderator, *memory.StockMemory, string, error) {
+	// 创建模型（带超时）
+	modelCtx, modelCancel := context.WithTimeout(meetingCtx, ModelCreationTimeout)
+	llm, err := s.modelFactory.CreateModel(modelCtx, aiConfig)
+	modelCancel()
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("create model error: %w", err)
+	}
+
+	// 创建 Moderator LLM（优先使用独立配置）
+	var moderatorLLM model.LLM
+	if s.moderatorAIConfig != nil {
+		moderatorLLM, err = s.modelFactory.CreateModel(meetingCtx, s.moderatorAIConfig)
+		if err != nil {
+			log.Warn("create moderator LLM error, fallback to default: %v", err)
+			moderatorLLM = llm
+		} else {
+			log.Debug("using dedicated moderator LLM: %s", s.moderatorAIConfig.ModelName)
+		}
+	} else {
+		moderatorLLM = llm
+	}
+	moderator := NewModerator(moderatorLLM)
+
+	// 设置 LLM 到记忆管理器（启用摘要功能）
+	if s.memoryManager != nil {
+		// 优先使用配置的记忆 LLM，否则使用会议 LLM
+		if s.memoryAIConfig != nil {
+			memoryLLM, err := s.modelFactory.CreateModel(meetingCtx, s.memoryAIConfig)
+			if err == nil {
+				s.memoryManager.SetLLM(memoryLLM)
+				log.Debug("using dedicated memory LLM: %s", s.memoryAIConfig.ModelName)
+			} else {
+				log.Warn("create memory LLM error, fallback to meeting LLM: %v", err)
+				s.memoryManager.SetLLM(llm)
+			}
+		} else {
+			s.memoryManager.SetLLM(llm)
+		}
+	}
+
+	// 加载股票记忆（如果启用了记忆管理）
+	var stockMemory *memory.StockMemory
+	var memoryContext string
+	if s.memoryManager != nil {
+		stockMemory, _ = s.memoryManager.GetOrCreate(req.Stock.Symbol, req.Stock.Name)
+		memoryContext = s.memoryManager.BuildContext(meetingCtx, stockMemory, req.Query)
+		if memoryContext != "" {
+			log.Debug("loaded memory context for %s, len: %d", req.Stock.Symbol, len(memoryContext))
+		}
+	}
+
+	return moderator, stockMemory, memoryContext, nil
+}
+
 // RunSmartMeetingSync OpenClaw 专用：串行分析，只返回最终总结结果
 // 不使用流式回调，不缓存中断状态，专家失败时跳过继续
 func (s *Service) RunSmartMeetingSync(ctx context.Context, aiConfig *models.AIConfig, req ChatRequest) (string, error) {
@@ -293,7 +374,7 @@ func (s *Service) RunSmartMeetingSync(ctx context.Context, aiConfig *models.AICo
 	var memoryContext string
 	if s.memoryManager != nil {
 		stockMemory, _ = s.memoryManager.GetOrCreate(req.Stock.Symbol, req.Stock.Name)
-		memoryContext = s.memoryManager.BuildContext(stockMemory, req.Query)
+		memoryContext = s.memoryManager.BuildContext(meetingCtx, stockMemory, req.Query)
 	}
 
 	log.Info("[OpenClaw] stock: %s, query: %s, agents: %d", req.Stock.Symbol, req.Query, len(req.AllAgents))
@@ -401,64 +482,19 @@ func (s *Service) RunSmartMeetingWithCallback(ctx context.Context, aiConfig *mod
 	if len(req.AllAgents) == 0 {
 		return nil, ErrNoAgents
 	}
+	orch := normalizeOrchestration(req.Orchestration)
 
-	// 设置整个会议的超时上下文
-	meetingCtx, meetingCancel := context.WithTimeout(ctx, MeetingTimeout)
+	// 设置整个会议的超时上下文（多轮编排按轮数放宽）
+	meetingCtx, meetingCancel := context.WithTimeout(ctx, meetingTimeout(orch))
 	defer meetingCancel()
 
-	// 创建模型（带超时）
-	modelCtx, modelCancel := context.WithTimeout(meetingCtx, ModelCreationTimeout)
-	llm, err := s.modelFactory.CreateModel(modelCtx, aiConfig)
-	modelCancel()
+	moderator, stockMemory, memoryContext, err := s.prepareMeeting(meetingCtx, aiConfig, req)
 	if err != nil {
-		return nil, fmt.Errorf("create model error: %w", err)
+		return nil, err
 	}
 
 	var responses []ChatResponse
 
-	// 创建 Moderator LLM（优先使用独立配置）
-	var moderatorLLM model.LLM
-	if s.moderatorAIConfig != nil {
-		moderatorLLM, err = s.modelFactory.CreateModel(meetingCtx, s.moderatorAIConfig)
-		if err != nil {
-			log.Warn("create moderator LLM error, fallback to default: %v", err)
-			moderatorLLM = llm
-		} else {
-			log.Debug("using dedicated moderator LLM: %s", s.moderatorAIConfig.ModelName)
-		}
-	} else {
-		moderatorLLM = llm
-	}
-	moderator := NewModerator(moderatorLLM)
-
-	// 设置 LLM 到记忆管理器（启用摘要功能）
-	if s.memoryManager != nil {
-		// 优先使用配置的记忆 LLM，否则使用会议 LLM
-		if s.memoryAIConfig != nil {
-			memoryLLM, err := s.modelFactory.CreateModel(meetingCtx, s.memoryAIConfig)
-			if err == nil {
-				s.memoryManager.SetLLM(memoryLLM)
-				log.Debug("using dedicated memory LLM: %s", s.memoryAIConfig.ModelName)
-			} else {
-				log.Warn("create memory LLM error, fallback to meeting LLM: %v", err)
-				s.memoryManager.SetLLM(llm)
-			}
-		} else {
-			s.memoryManager.SetLLM(llm)
-		}
-	}
-
-	// 加载股票记忆（如果启用了记忆管理）
-	var stockMemory *memory.StockMemory
-	var memoryContext string
-	if s.memoryManager != nil {
-		stockMemory, _ = s.memoryManager.GetOrCreate(req.Stock.Symbol, req.Stock.Name)
-		memoryContext = s.memoryManager.BuildContext(stockMemory, req.Query)
-		if memoryContext != "" {
-			log.Debug("loaded memory context for %s, len: %d", req.Stock.Symbol, len(memoryContext))
-		}
-	}
-
 	log.Info("stock: %s, query: %s, agents: %d", req.Stock.Symbol, req.Query, len(req.AllAgents))
 
 	// 第0轮：小韭菜分析意图并选择专家（带超时）
@@ -474,6 +510,9 @@ func (s *Service) RunSmartMeetingWithCallback(ctx context.Context, aiConfig *mod
 		emitProgress(progressCallback, ProgressEvent{
 			Type: "agent_done", AgentID: "moderator", AgentName: "小韭菜",
 		})
+		if isCancelled(ctx) {
+			return nil, ErrCancelled
+		}
 		if errors.Is(err, context.DeadlineExceeded) {
 			return nil, fmt.Errorf("%w: 小韭菜分析超时", ErrModeratorTimeout)
 		}
@@ -506,6 +545,16 @@ func (s *Service) RunSmartMeetingWithCallback(ctx context.Context, aiConfig *mod
 	if len(selectedAgents) == 0 {
 		return responses, nil
 	}
+	emitProgress(progressCallback, ProgressEvent{
+		Type: "agents_selected", AgentID: "moderator", AgentName: "小韭菜",
+		Detail: decision.Topic, Content: compactJSON(decision),
+	})
+
+	// 多轮/辩论/投票/并行模式交给编排器
+	if !isDefaultOrchestration(orch) {
+		return s.runOrchestrated(ctx, meetingCtx, aiConfig, req, orch, moderator, decision, selectedAgents,
+			memoryContext, stockMemory, responses, nil, 1, respCallback, progressCallback)
+	}
 
 	// 第1轮：专家串行发言，后一个参考前面的内容
 	var history []DiscussionEntry
@@ -514,6 +563,9 @@ func (s *Service) RunSmartMeetingWithCallback(ctx context.Context, aiConfig *mod
 		// 检查会议是否已超时
 		select {
 		case <-meetingCtx.Done():
+			if isCancelled(ctx) {
+				return responses, ErrCancelled
+			}
 			log.Warn("meeting timeout, got %d responses", len(responses))
 			return responses, ErrMeetingTimeout
 		default:
@@ -559,6 +611,9 @@ func (s *Service) RunSmartMeetingWithCallback(ctx context.Context, aiConfig *mod
 			return s.runSingleAgent(agentCtx, builder, &agentCfg, &req.Stock, agentQuery, previousContext, progressCallback, req.Position)
 		})
 
+		if err != nil && isCancelled(ctx) {
+			return s.flushCancelled(responses, &agentCfg, content, MeetingModeSmart, respCallback, progressCallback)
+		}
 		if err != nil {
 			emitProgress(progressCallback, ProgressEvent{
 				Type: "agent_error", AgentID: agentCfg.ID, AgentName: agentCfg.Name, Detail: err.Error(),
@@ -577,6 +632,7 @@ func (s *Service) RunSmartMeetingWithCallback(ctx context.Context, aiConfig *mod
 				Round:       1,
 				MsgType:     "opinion",
 				Error:       err.Error(),
+				ErrorCode:   string(apperr.CodeOf(err)),
 				MeetingMode: MeetingModeSmart,
 			}
 			responses = append(responses, failedResp)
@@ -674,6 +730,9 @@ func (s *Service) RunSmartMeetingWithCallback(ctx context.Context, aiConfig *mod
 		Type: "agent_done", AgentID: "moderator", AgentName: "小韭菜",
 	})
 
+	if err != nil && isCancelled(ctx) {
+		return responses, ErrCancelled
+	}
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
 			log.Warn("summary timeout, returning partial results")
@@ -760,6 +819,14 @@ func (s *Service) runAgentsParallel(ctx context.Context, defaultLLM model.LLM, d
 				defer agentCancel()
 				return s.runSingleAgent(agentCtx, builder, &cfg, &req.Stock, req.Query, req.ReplyContent, nil, req.Position)
 			})
+			if err != nil && isCancelled(ctx) {
+				if content != "" {
+					mu.Lock()
+					responses = append(responses, cancelledResponse(&cfg, content, MeetingModeDirect))
+					mu.Unlock()
+				}
+				return
+			}
 			if err != nil {
 				log.Error("agent %s failed after retries: %v", cfg.ID, err)
 				mu.Lock()
@@ -769,6 +836,7 @@ func (s *Service) runAgentsParallel(ctx context.Context, defaultLLM model.LLM, d
 					Role:        cfg.Role,
 					MsgType:     "opinion",
 					Error:       err.Error(),
+					ErrorCode:   string(apperr.CodeOf(err)),
 					MeetingMode: MeetingModeDirect,
 				})
 				mu.Unlock()
@@ -789,6 +857,10 @@ func (s *Service) runAgentsParallel(ctx context.Context, defaultLLM model.LLM, d
 	}
 
 	wg.Wait()
+	if isCancelled(ctx) {
+		log.Info("discussion cancelled, got %d responses", len(responses))
+		return responses, ErrCancelled
+	}
 	log.Info("all agents done, got %d responses", len(responses))
 	return responses, nil
 }
@@ -843,25 +915,33 @@ func (s *Service) runSingleAgent(
 	var sb strings.Builder
 	for event, err := range r.Run(ctx, "user", sessionID, userMsg, runCfg) {
 		if err != nil {
-			return "", err
+			// 返回已输出的部分内容，讨论被停止时由调用方推送
+			return openai.FilterVendorToolCallMarkers(sb.String()), err
 		}
 		if event == nil || event.LLMResponse.Content == nil {
 			continue
 		}
 		for _, part := range event.LLMResponse.Content.Parts {
 			if part.Thought {
+				// 思考内容不计入发言，仅推送供讨论记录保存
+				if part.Text != "" && progressCallback != nil {
+					progressCallback(ProgressEvent{
+						Type: "thinking", AgentID: cfg.ID, AgentName: cfg.Name,
+						Content: part.Text,
+					})
+				}
 				continue
 			}
 			if part.FunctionCall != nil && progressCallback != nil {
 				progressCallback(ProgressEvent{
 					Type: "tool_call", AgentID: cfg.ID, AgentName: cfg.Name,
-					Detail: part.FunctionCall.Name,
+					Detail: part.FunctionCall.Name, Content: toolContent(part.FunctionCall.Args),
 				})
 			}
 			if part.FunctionResponse != nil && progressCallback != nil {
 				progressCallback(ProgressEvent{
 					Type: "tool_result", AgentID: cfg.ID, AgentName: cfg.Name,
-					Detail: part.FunctionResponse.Name,
+					Detail: part.FunctionResponse.Name, Content: toolContent(part.FunctionResponse.Response),
 				})
 			}
 			if part.Text != "" {
@@ -881,9 +961,35 @@ func (s *Service) runSingleAgent(
 		}
 	}
 
+	// 部分模型适配器在取消时静默结束流，这里统一返回取消错误
+	if err := ctx.Err(); err != nil {
+		return openai.FilterVendorToolCallMarkers(sb.String()), err
+	}
 	return openai.FilterVendorToolCallMarkers(sb.String()), nil
 }
 
+// flushCancelled 讨论被停止时结束当前专家并推送其已输出的部分内容
+func (s *Service) flushCancelled(
+	responses []ChatResponse,
+	cfg *models.AgentConfig,
+	content, mode string,
+	respCallback ResponseCallback,
+	progressCallback ProgressCallback,
+) ([]ChatResponse, error) {
+	emitProgress(progressCallback, ProgressEvent{
+		Type: "agent_done", AgentID: cfg.ID, AgentName: cfg.Name,
+	})
+	if content != "" {
+		resp := cancelledResponse(cfg, content, mode)
+		responses = append(responses, resp)
+		if respCallback != nil {
+			respCallback(resp)
+		}
+	}
+	log.Info("discussion cancelled during agent %s, partial len: %d", cfg.ID, len(content))
+	return responses, ErrCancelled
+}
+
 // filterAgentsOrdered 按指定顺序筛选专家（保持小韭菜选择的顺序）
 func (s *Service) filterAgentsOrdered(all []models.AgentConfig, ids []string) []models.AgentConfig {
 	agentMap := make(map[string]models.AgentConfig)
@@ -907,6 +1013,10 @@ func (s *Service) buildPreviousContext(history []DiscussionEntry) string {
 	var sb strings.Builder
 	sb.WriteString("【前面专家的发言】\n")
 	for _, entry := range history {
+		if entry.Round > 1 {
+			fmt.Fprintf(&sb, "- [第%d轮] %s（%s）：%s\n\n", entry.Round, entry.AgentName, entry.Role, entry.Content)
+			continue
+		}
 		fmt.Fprintf(&sb, "- %s（%s）：%s\n\n", entry.AgentName, entry.Role, entry.Content)
 	}
 	return sb.String()
@@ -1001,6 +1111,9 @@ func (s *Service) RetrySingleAgent(
 		Type: "agent_done", AgentID: agentCfg.ID, AgentName: agentCfg.Name,
 	})
 
+	if err != nil && isCancelled(ctx) {
+		return cancelledResponse(agentCfg, content, MeetingModeDirect), ErrCancelled
+	}
 	if err != nil {
 		return ChatResponse{
 			AgentID:     agentCfg.ID,
@@ -1008,6 +1121,7 @@ func (s *Service) RetrySingleAgent(
 			Role:        agentCfg.Role,
 			MsgType:     "opinion",
 			Error:       err.Error(),
+			ErrorCode:   string(apperr.CodeOf(err)),
 			MeetingMode: MeetingModeDirect,
 		}, err
 	}
@@ -1088,6 +1202,9 @@ func (s *Service) ContinueMeeting(
 	for i := startIndex; i < len(state.SelectedAgents); i++ {
 		select {
 		case <-meetingCtx.Done():
+			if isCancelled(ctx) {
+				return responses, ErrCancelled
+			}
 			log.Warn("continue meeting timeout, got %d responses", len(responses))
 			return responses, ErrMeetingTimeout
 		default:
@@ -1121,6 +1238,9 @@ func (s *Service) ContinueMeeting(
 			return s.runSingleAgent(agentCtx, builder, &agentCfg, &state.Stock, state.Query, previousContext, progressCallback, state.Position)
 		})
 
+		if err != nil && isCancelled(ctx) {
+			return s.flushCancelled(responses, &agentCfg, content, MeetingModeSmart, respCallback, progressCallback)
+		}
 		if err != nil {
 			emitProgress(progressCallback, ProgressEvent{Type: "agent_error", AgentID: agentCfg.ID, AgentName: agentCfg.Name, Detail: err.Error()})
 			emitProgress(progressCallback, ProgressEvent{Type: "agent_done", AgentID: agentCfg.ID, AgentName: agentCfg.Name})
@@ -1128,7 +1248,7 @@ func (s *Service) ContinueMeeting(
 
 			failedResp := ChatResponse{
 				AgentID: agentCfg.ID, AgentName: agentCfg.Name, Role: agentCfg.Role,
-				Round: 1, MsgType: "opinion", Error: err.Error(), MeetingMode: MeetingModeSmart,
+				Round: 1, MsgType: "opinion", Error: err.Error(), ErrorCode: string(apperr.CodeOf(err)), MeetingMode: MeetingModeSmart,
 			}
 			responses = append(responses, failedResp)
 			if respCallback != nil {
@@ -1191,7 +1311,7 @@ func (s *Service) ContinueMeeting(
 	return s.runMeetingSummary(meetingCtx, state, history, responses, respCallback, progressCallback)
 }
 
-// runMeetingSummary 执行小韭菜总结（ContinueMeeting 专用）
+// runMeetingSummary 执行小韭菜总结（ContinueMeeting 与多轮编排共用）
 func (s *Service) runMeetingSummary(
 	ctx context.Context,
 	state *MeetingState,
@@ -1214,18 +1334,23 @@ func (s *Service) runMeetingSummary(
 
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
-			log.Warn("continue summary timeout")
+			log.Warn("summary timeout, returning partial results")
 		} else {
-			log.Error("continue summary error: %v", err)
+			log.Error("summary error: %v", err)
 		}
 		return responses, nil
 	}
 
 	if summary != "" {
+		// 总结轮次为最后一轮讨论之后
+		round := 2
+		if n := len(history); n > 0 {
+			round = history[n-1].Round + 1
+		}
 		summaryResp := ChatResponse{
 			AgentID: "moderator", AgentName: "小韭菜",
 			Role: "会议主持", Content: summary,
-			Round: 2, MsgType: "summary", MeetingMode: MeetingModeSmart,
+			Round: round, MsgType: "summary", MeetingMode: MeetingModeSmart,
 		}
 		responses = append(responses, summaryResp)
 		if respCallback != nil {
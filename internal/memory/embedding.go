@@ -0,0 +1,220 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// Embedder 文本向量化接口
+type Embedder interface {
+	// Embed 批量计算文本向量，返回结果与输入一一对应
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+	// ModelName 返回向量模型名称（模型变化时索引需要重建）
+	ModelName() string
+}
+
+// OpenAIEmbedder 基于 OpenAI 兼容 /embeddings 接口的向量化实现
+type OpenAIEmbedder struct {
+	client *openai.Client
+	model  string
+}
+
+// NewOpenAIEmbedder 创建 OpenAI 兼容的向量化器
+func NewOpenAIEmbedder(modelName string, cfg openai.ClientConfig) *OpenAIEmbedder {
+	return &OpenAIEmbedder{
+		client: openai.NewClientWithConfig(cfg),
+		model:  modelName,
+	}
+}
+
+// Embed 批量计算文本向量
+func (e *OpenAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	resp, err := e.client.CreateEmbeddings(ctx, openai.EmbeddingRequestStrings{
+		Input: texts,
+		Model: openai.EmbeddingModel(e.model),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Data) != len(texts) {
+		return nil, fmt.Errorf("embedding count mismatch: want %d, got %d", len(texts), len(resp.Data))
+	}
+
+	vectors := make([][]float32, len(texts))
+	for _, d := range resp.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, fmt.Errorf("embedding index out of range: %d", d.Index)
+		}
+		vectors[d.Index] = d.Embedding
+	}
+	return vectors, nil
+}
+
+// ModelName 返回向量模型名称
+func (e *OpenAIEmbedder) ModelName() string {
+	return e.model
+}
+
+// vectorIndex 单只股票的向量索引（持久化到磁盘）
+type vectorIndex struct {
+	Model   string               `json:"model"`
+	Vectors map[string][]float32 `json:"vectors"` // 记忆条目 ID -> 向量
+}
+
+// EmbeddingStore 基于向量相似度的记忆检索
+// 向量索引按股票会话隔离，保存在 memories/vectors/{stockCode}.json
+type EmbeddingStore struct {
+	embedder Embedder
+	dir      string
+	cache    map[string]*vectorIndex
+	mu       sync.Mutex
+}
+
+// NewEmbeddingStore 创建向量检索存储
+func NewEmbeddingStore(dataDir string, embedder Embedder) *EmbeddingStore {
+	dir := filepath.Join(dataDir, "memories", "vectors")
+	os.MkdirAll(dir, 0755)
+	return &EmbeddingStore{
+		embedder: embedder,
+		dir:      dir,
+		cache:    make(map[string]*vectorIndex),
+	}
+}
+
+// getPath 获取索引路径
+func (s *EmbeddingStore) getPath(stockCode string) string {
+	return filepath.Join(s.dir, stockCode+".json")
+}
+
+// load 加载索引，模型不一致时丢弃旧向量（调用方持有锁）
+func (s *EmbeddingStore) load(stockCode string) *vectorIndex {
+	if idx, ok := s.cache[stockCode]; ok {
+		return idx
+	}
+
+	idx := &vectorIndex{}
+	if data, err := os.ReadFile(s.getPath(stockCode)); err == nil {
+		json.Unmarshal(data, idx)
+	}
+	if idx.Model != s.embedder.ModelName() || idx.Vectors == nil {
+		idx = &vectorIndex{Model: s.embedder.ModelName(), Vectors: make(map[string][]float32)}
+	}
+	s.cache[stockCode] = idx
+	return idx
+}
+
+// save 写入索引（调用方持有锁）
+func (s *EmbeddingStore) save(stockCode string, idx *vectorIndex) error {
+	data, err := json.Marshal(idx)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.getPath(stockCode), data, 0644)
+}
+
+// FindRelevant 按余弦相似度查找相关的记忆条目
+// 缺失向量的条目与查询一起批量计算，已删除条目的向量同步清理
+func (s *EmbeddingStore) FindRelevant(ctx context.Context, stockCode string, facts []MemoryEntry, query string, limit int) ([]MemoryEntry, error) {
+	if len(facts) == 0 || query == "" {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idx := s.load(stockCode)
+
+	texts := []string{query}
+	var missing []string
+	alive := make(map[string]bool, len(facts))
+	for _, fact := range facts {
+		alive[fact.ID] = true
+		if _, ok := idx.Vectors[fact.ID]; !ok {
+			missing = append(missing, fact.ID)
+			texts = append(texts, fact.Content)
+		}
+	}
+
+	vectors, err := s.embedder.Embed(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	queryVec := vectors[0]
+
+	dirty := len(missing) > 0
+	for i, id := range missing {
+		idx.Vectors[id] = vectors[i+1]
+	}
+	for id := range idx.Vectors {
+		if !alive[id] {
+			delete(idx.Vectors, id)
+			dirty = true
+		}
+	}
+	if dirty {
+		if err := s.save(stockCode, idx); err != nil {
+			log.Warn("保存向量索引失败: %v", err)
+		}
+	}
+
+	scored := make([]ScoredEntry, 0, len(facts))
+	for _, fact := range facts {
+		sim := cosineSimilarity(queryVec, idx.Vectors[fact.ID])
+		if sim < minSimilarity {
+			continue
+		}
+		score := sim * math.Max(0.5, fact.Weight) * timeDecay(fact.Timestamp)
+		scored = append(scored, ScoredEntry{Entry: fact, Score: score})
+	}
+
+	sort.Slice(scored, func(i, j int) bool {
+		return scored[i].Score > scored[j].Score
+	})
+
+	result := make([]MemoryEntry, 0, limit)
+	for i := 0; i < len(scored) && i < limit; i++ {
+		result = append(result, scored[i].Entry)
+	}
+	return result, nil
+}
+
+// Delete 删除股票的向量索引
+func (s *EmbeddingStore) Delete(stockCode string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.cache, stockCode)
+	err := os.Remove(s.getPath(stockCode))
+	if err != nil && os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// minSimilarity 最低相似度，低于该值的条目视为无关
+const minSimilarity = 0.3
+
+// cosineSimilarity 计算余弦相似度，维度不一致时返回 0
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeEmbedder 按关键字生成固定向量
+type fakeEmbedder struct {
+	calls int
+}
+
+func (e *fakeEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	e.calls++
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		switch {
+		case strings.Contains(text, "业绩") || strings.Contains(text, "财报"):
+			vectors[i] = []float32{1, 0.1, 0}
+		case strings.Contains(text, "北向"):
+			vectors[i] = []float32{0, 1, 0.1}
+		default:
+			vectors[i] = []float32{0, 0, 1}
+		}
+	}
+	return vectors, nil
+}
+
+func (e *fakeEmbedder) ModelName() string { return "fake" }
+
+func TestEmbeddingStore_FindRelevant(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now().UnixMilli()
+	facts := []MemoryEntry{
+		{ID: "a", Content: "三季度业绩超预期", Timestamp: now, Weight: 0.8},
+		{ID: "b", Content: "北向资金连续五日净流入", Timestamp: now, Weight: 0.8},
+	}
+
+	// 查询与事实没有共同关键词，依靠语义相似度命中
+	embedder := &fakeEmbedder{}
+	store := NewEmbeddingStore(dir, embedder)
+	got, err := store.FindRelevant(context.Background(), "600519", facts, "最新财报怎么样", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].ID != "a" {
+		t.Fatalf("got %+v, want fact a", got)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "memories", "vectors", "600519.json")); err != nil {
+		t.Fatalf("vector index not persisted: %v", err)
+	}
+
+	// 重新加载后复用磁盘上的向量索引
+	embedder2 := &fakeEmbedder{}
+	store2 := NewEmbeddingStore(dir, embedder2)
+	got, _ = store2.FindRelevant(context.Background(), "600519", facts, "北向资金动向", 5)
+	if len(got) != 1 || got[0].ID != "b" {
+		t.Fatalf("got %+v, want fact b", got)
+	}
+	if idx := store2.load("600519"); len(idx.Vectors) != 2 {
+		t.Errorf("index vectors = %d, want 2", len(idx.Vectors))
+	}
+
+	if err := store2.Delete("600519"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCosineSimilarity(t *testing.T) {
+	if s := cosineSimilarity([]float32{1, 0}, []float32{1, 0}); s < 0.999 {
+		t.Errorf("identical vectors = %v", s)
+	}
+	if s := cosineSimilarity([]float32{1, 0}, []float32{0, 1}); s != 0 {
+		t.Errorf("orthogonal vectors = %v", s)
+	}
+	if s := cosineSimilarity([]float32{1, 0}, []float32{1, 0, 0}); s != 0 {
+		t.Errorf("dimension mismatch = %v", s)
+	}
+}
@@ -0,0 +1,127 @@
+package memory
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// errNoJSONArray 响应中找不到 JSON 数组
+var errNoJSONArray = errors.New("no json array found")
+
+// extractJSONArray 从模型输出中提取第一个括号配对完整的 JSON 数组
+// 输出被截断时返回从 '[' 开始的剩余部分，交由 decodeArrayItems 尽量解析
+func extractJSONArray(text string) (string, error) {
+	start := strings.IndexByte(text, '[')
+	if start < 0 {
+		return "", errNoJSONArray
+	}
+
+	depth := 0
+	inString := false
+	escaped := false
+	for i := start; i < len(text); i++ {
+		c := text[i]
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			inString = true
+		case '[', '{':
+			depth++
+		case ']', '}':
+			depth--
+			if depth == 0 {
+				return text[start : i+1], nil
+			}
+		}
+	}
+	return text[start:], nil
+}
+
+// sanitizeJSON 去掉字符串外的 // 与 /* */ 注释以及尾随逗号
+func sanitizeJSON(text string) string {
+	var sb strings.Builder
+	sb.Grow(len(text))
+
+	inString := false
+	escaped := false
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if inString {
+			sb.WriteByte(c)
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+
+		switch {
+		case c == '"':
+			inString = true
+			sb.WriteByte(c)
+		case c == '/' && i+1 < len(text) && text[i+1] == '/':
+			for i < len(text) && text[i] != '\n' {
+				i++
+			}
+			if i < len(text) {
+				sb.WriteByte('\n')
+			}
+		case c == '/' && i+1 < len(text) && text[i+1] == '*':
+			end := strings.Index(text[i+2:], "*/")
+			if end < 0 {
+				i = len(text)
+			} else {
+				i += end + 3
+			}
+		case c == ',':
+			// 下一个非空白字符是 ] 或 } 时丢弃逗号
+			j := i + 1
+			for j < len(text) && strings.IndexByte(" \t\r\n", text[j]) >= 0 {
+				j++
+			}
+			if j < len(text) && (text[j] == ']' || text[j] == '}') {
+				continue
+			}
+			sb.WriteByte(c)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+// decodeArrayItems 逐个解析数组元素
+// 数组被截断或中途出现语法错误时，返回已解析的元素和错误
+func decodeArrayItems(text string) ([]json.RawMessage, error) {
+	dec := json.NewDecoder(bytes.NewReader([]byte(text)))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+		return nil, errNoJSONArray
+	}
+
+	var items []json.RawMessage
+	for dec.More() {
+		var item json.RawMessage
+		if err := dec.Decode(&item); err != nil {
+			// 语法错误后解码器无法继续，返回已解析部分
+			return items, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
@@ -0,0 +1,45 @@
+package memory
+
+import "testing"
+
+// stubTokenizer 测试用分词器
+type stubTokenizer struct{}
+
+func (stubTokenizer) Extract(text string, topK int) []string { return nil }
+func (stubTokenizer) Cut(text string) []string               { return nil }
+
+func TestParseFacts_Tolerant(t *testing.T) {
+	s := NewLLMSummarizer(nil, stubTokenizer{})
+
+	tests := []struct {
+		name   string
+		output string
+		want   int
+	}{
+		{"prose and fence", "好的，提取结果如下：\n```json\n[{\"content\": \"营收增长20%\", \"type\": \"fact\", \"weight\": 0.8}]\n```\n以上。", 1},
+		{"trailing comma and comments", "[\n  {\"content\": \"估值偏高 // 市盈率\", \"type\": \"opinion\", \"weight\": 0.6,}, // 第一条\n  /* 第二条 */ {\"content\": \"继续持有\", \"type\": \"decision\", \"weight\": 0.7},\n]", 2},
+		{"bad item skipped", `[{"content": "放量突破", "weight": "high"}, {"content": "北向流入", "type": "fact", "weight": 0.5}]`, 1},
+		{"truncated", `[{"content": "分红提升", "type": "fact", "weight": 0.9}, {"content": "渠道库`, 1},
+		{"empty array", `[]`, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			facts, err := s.parseFacts(tt.output, "analyst")
+			if err != nil {
+				t.Fatalf("parseFacts error: %v", err)
+			}
+			if len(facts) != tt.want {
+				t.Fatalf("got %d facts, want %d: %+v", len(facts), tt.want, facts)
+			}
+		})
+	}
+
+	facts, _ := s.parseFacts("[{\"content\": \"估值偏高 // 市盈率\", \"weight\": 3,}]", "analyst")
+	if facts[0].Content != "估值偏高 // 市盈率" || facts[0].Type != EntryTypeFact || facts[0].Weight != 1 {
+		t.Errorf("normalized fact = %+v", facts[0])
+	}
+
+	if _, err := s.parseFacts("无法提取事实", "analyst"); err == nil {
+		t.Error("expected error without json array")
+	}
+}
@@ -0,0 +1,180 @@
+package memory
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaintenanceInterval 两次维护的最小间隔
+	MaintenanceInterval = 24 * time.Hour
+	// factHalfLifeDays 事实权重半衰期（天）
+	factHalfLifeDays = 30.0
+	// minFactWeight 权重低于该值的事实直接淘汰
+	minFactWeight = 0.05
+	// duplicateThreshold 判定近似重复的词集合相似度
+	duplicateThreshold = 0.8
+	// consolidateAfter 超过该时长的事实参与归并
+	consolidateAfter = 30 * 24 * time.Hour
+	// minConsolidateFacts 触发归并的最少旧事实数
+	minConsolidateFacts = 5
+)
+
+// needsMaintenance 距上次维护是否已超过间隔
+func needsMaintenance(mem *StockMemory, now time.Time) bool {
+	return now.Sub(time.UnixMilli(mem.MaintainedAt)) >= MaintenanceInterval
+}
+
+// Maintain 维护记忆：合并近似重复事实、按时间衰减权重、将旧事实归并为摘要条目
+func (m *Manager) Maintain(ctx context.Context, mem *StockMemory) {
+	now := time.Now()
+	facts := m.dedupeFacts(mem.KeyFacts)
+	facts = decayFacts(facts, mem.MaintainedAt, now)
+
+	if m.summarizer != nil {
+		consolidated, err := m.consolidateFacts(ctx, facts, now)
+		if err != nil {
+			// 归并失败保留原事实，下次维护再试
+			log.Warn("归并记忆事实失败: %v", err)
+		} else {
+			facts = consolidated
+		}
+	}
+
+	mem.KeyFacts = facts
+	mem.MaintainedAt = now.UnixMilli()
+}
+
+// dedupeFacts 合并近似重复的事实：保留较新的内容，权重取较大值，关键词取并集
+func (m *Manager) dedupeFacts(facts []MemoryEntry) []MemoryEntry {
+	result := make([]MemoryEntry, 0, len(facts))
+	tokens := make([]map[string]bool, 0, len(facts))
+
+	for _, fact := range facts {
+		set := tokenSet(m.tokenizer.Cut(fact.Content))
+		merged := false
+		for i := range result {
+			if result[i].Type != fact.Type || jaccard(tokens[i], set) < duplicateThreshold {
+				continue
+			}
+			if fact.Timestamp >= result[i].Timestamp {
+				result[i].Content = fact.Content
+				result[i].Source = fact.Source
+				result[i].Timestamp = fact.Timestamp
+				tokens[i] = set
+			}
+			result[i].Weight = math.Max(result[i].Weight, fact.Weight)
+			result[i].Keywords = unionKeywords(result[i].Keywords, fact.Keywords)
+			merged = true
+			break
+		}
+		if !merged {
+			result = append(result, fact)
+			tokens = append(tokens, set)
+		}
+	}
+	return result
+}
+
+// decayFacts 按距上次维护经过的时间衰减权重，淘汰过低权重的事实
+func decayFacts(facts []MemoryEntry, since int64, now time.Time) []MemoryEntry {
+	if since == 0 {
+		return facts
+	}
+	days := now.Sub(time.UnixMilli(since)).Hours() / 24
+	if days <= 0 {
+		return facts
+	}
+	factor := math.Pow(0.5, days/factHalfLifeDays)
+
+	result := facts[:0]
+	for _, fact := range facts {
+		fact.Weight *= factor
+		if fact.Weight >= minFactWeight {
+			result = append(result, fact)
+		}
+	}
+	return result
+}
+
+// consolidateFacts 将旧事实归并为一条摘要条目
+func (m *Manager) consolidateFacts(ctx context.Context, facts []MemoryEntry, now time.Time) ([]MemoryEntry, error) {
+	cutoff := now.Add(-consolidateAfter).UnixMilli()
+	var old, keep []MemoryEntry
+	for _, fact := range facts {
+		if fact.Timestamp < cutoff && fact.Type != EntryTypeSummary {
+			old = append(old, fact)
+		} else {
+			keep = append(keep, fact)
+		}
+	}
+	if len(old) < minConsolidateFacts {
+		return facts, nil
+	}
+
+	sort.Slice(old, func(i, j int) bool {
+		return old[i].Timestamp < old[j].Timestamp
+	})
+	content, err := m.summarizer.ConsolidateFacts(ctx, old)
+	if err != nil {
+		return nil, err
+	}
+	if content == "" {
+		return facts, nil
+	}
+
+	weight := 0.0
+	for _, fact := range old {
+		weight = math.Max(weight, fact.Weight)
+	}
+	summary := MemoryEntry{
+		ID:        uuid.New().String(),
+		Type:      EntryTypeSummary,
+		Content:   content,
+		Source:    "consolidation",
+		Keywords:  m.tokenizer.Extract(content, 5),
+		Timestamp: old[len(old)-1].Timestamp,
+		Weight:    weight,
+	}
+	return append([]MemoryEntry{summary}, keep...), nil
+}
+
+// tokenSet 构建词集合
+func tokenSet(words []string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[w] = true
+	}
+	return set
+}
+
+// jaccard 计算两个词集合的 Jaccard 相似度
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	inter := 0
+	for w := range a {
+		if b[w] {
+			inter++
+		}
+	}
+	return float64(inter) / float64(len(a)+len(b)-inter)
+}
+
+// unionKeywords 合并关键词并去重
+func unionKeywords(a, b []string) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	result := make([]string, 0, len(a)+len(b))
+	for _, k := range append(append([]string{}, a...), b...) {
+		if !seen[k] {
+			seen[k] = true
+			result = append(result, k)
+		}
+	}
+	return result
+}
@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// fakeSummarizer 归并时返回固定摘要
+type fakeSummarizer struct {
+	Summarizer
+	consolidated int
+}
+
+func (s *fakeSummarizer) ConsolidateFacts(ctx context.Context, facts []MemoryEntry) (string, error) {
+	s.consolidated = len(facts)
+	return "早期讨论认为估值偏高", nil
+}
+
+func TestManager_Maintain(t *testing.T) {
+	m := NewManager(t.TempDir())
+	defer m.Close()
+	summarizer := &fakeSummarizer{}
+	m.summarizer = summarizer
+
+	now := time.Now()
+	day := int64(24 * time.Hour / time.Millisecond)
+	mem := NewStockMemory("600519", "贵州茅台")
+	mem.MaintainedAt = now.UnixMilli() - 30*day
+	mem.KeyFacts = []MemoryEntry{
+		{ID: "d1", Type: EntryTypeFact, Content: "北向资金连续五日净流入", Timestamp: now.UnixMilli() - 2*day, Weight: 0.6},
+		{ID: "d2", Type: EntryTypeFact, Content: "北向资金连续五日净流入", Timestamp: now.UnixMilli() - day, Weight: 0.9},
+		{ID: "low", Type: EntryTypeFact, Content: "盘中出现异动", Timestamp: now.UnixMilli() - day, Weight: 0.08},
+	}
+	oldFacts := []string{"市盈率处于历史高位", "渠道库存压力较大", "批价出现回落", "分红比例有望提升", "机构持仓比例下降"}
+	for i, content := range oldFacts {
+		mem.KeyFacts = append(mem.KeyFacts, MemoryEntry{
+			ID: "old", Type: EntryTypeOpinion, Content: content,
+			Timestamp: now.UnixMilli() - int64(40+i)*day, Weight: 0.8,
+		})
+	}
+
+	m.Maintain(context.Background(), mem)
+
+	if summarizer.consolidated != minConsolidateFacts {
+		t.Errorf("consolidated %d facts, want %d", summarizer.consolidated, minConsolidateFacts)
+	}
+	if len(mem.KeyFacts) != 2 {
+		t.Fatalf("facts = %+v, want summary + merged duplicate", mem.KeyFacts)
+	}
+	if mem.KeyFacts[0].Type != EntryTypeSummary {
+		t.Errorf("first fact type = %s, want summary", mem.KeyFacts[0].Type)
+	}
+	merged := mem.KeyFacts[1]
+	if merged.ID != "d1" || merged.Timestamp != now.UnixMilli()-day {
+		t.Errorf("duplicate not merged into newest: %+v", merged)
+	}
+	// 一个半衰期后权重减半
+	if merged.Weight < 0.44 || merged.Weight > 0.46 {
+		t.Errorf("decayed weight = %v, want ~0.45", merged.Weight)
+	}
+	if needsMaintenance(mem, now) {
+		t.Error("maintenance timestamp not updated")
+	}
+}
@@ -2,23 +2,30 @@ package memory
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
+	"github.com/run-bigpig/jcp/internal/logger"
 	"google.golang.org/adk/model"
 )
 
+var log = logger.New("memory")
+
 // Manager 记忆管理器
 type Manager struct {
 	config     Config
 	storage    Storage
 	tokenizer  Tokenizer
 	relevance  *Relevance
+	embeddings *EmbeddingStore // 向量检索（未配置向量模型时为 nil）
 	summarizer Summarizer
 	dataDir    string
 	saveCh     chan *StockMemory // 异步保存通道
 	closeCh    chan struct{}     // 关闭信号
+	doneCh     chan struct{}     // 保存协程退出信号
 }
 
 // NewManager 创建记忆管理器（无 LLM，摘要功能禁用）
@@ -26,12 +33,13 @@ func NewManager(dataDir string) *Manager {
 	tokenizer := NewJiebaTokenizer()
 	m := &Manager{
 		config:    DefaultConfig(),
-		storage:   NewFileStorage(dataDir),
+		storage:   NewSQLiteStorage(dataDir),
 		tokenizer: tokenizer,
 		relevance: NewRelevance(tokenizer),
 		dataDir:   dataDir,
 		saveCh:    make(chan *StockMemory, 100), // 缓冲通道
 		closeCh:   make(chan struct{}),
+		doneCh:    make(chan struct{}),
 	}
 	go m.asyncSaveLoop()
 	return m
@@ -39,7 +47,21 @@ func NewManager(dataDir string) *Manager {
 
 // SetLLM 设置 LLM（启用摘要功能）
 func (m *Manager) SetLLM(llm model.LLM) {
-	m.summarizer = NewLLMSummarizer(llm, m.tokenizer)
+	m.summarizer = NewLLMSummarizerWithPrompts(llm, m.tokenizer, m.config.Prompts)
+}
+
+// SetPrompts 更新摘要提示词配置（下次 SetLLM 时生效）
+func (m *Manager) SetPrompts(prompts PromptConfig) {
+	m.config.Prompts = prompts
+}
+
+// SetEmbedder 设置向量模型（启用语义检索，传入 nil 时恢复关键词匹配）
+func (m *Manager) SetEmbedder(embedder Embedder) {
+	if embedder == nil {
+		m.embeddings = nil
+		return
+	}
+	m.embeddings = NewEmbeddingStore(m.dataDir, embedder)
 }
 
 // NewManagerWithConfig 使用自定义配置创建记忆管理器
@@ -50,9 +72,13 @@ func NewManagerWithConfig(dataDir string, config Config) *Manager {
 }
 
 // GetOrCreate 获取或创建股票记忆
+// 记忆按股票持久化，之后任意会话讨论同一股票时自动召回历史事实与结论
 func (m *Manager) GetOrCreate(stockCode, stockName string) (*StockMemory, error) {
 	mem, err := m.storage.Load(stockCode)
 	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Warn("加载 %s 的记忆失败，重新开始: %v", stockCode, err)
+		}
 		// 不存在则创建新的
 		mem = NewStockMemory(stockCode, stockName)
 	}
@@ -72,24 +98,27 @@ func (m *Manager) SaveAsync(mem *StockMemory) {
 	case m.saveCh <- mem:
 	default:
 		// 通道满时丢弃，避免阻塞
-		fmt.Printf("memory save channel full, dropping save for %s\n", mem.StockCode)
+		log.Warn("记忆保存队列已满，丢弃 %s 的本次保存", mem.StockCode)
 	}
 }
 
 // asyncSaveLoop 异步保存循环
 func (m *Manager) asyncSaveLoop() {
+	defer close(m.doneCh)
 	for {
 		select {
 		case mem := <-m.saveCh:
 			if err := m.storage.Save(mem); err != nil {
-				fmt.Printf("async save memory error: %v\n", err)
+				log.Error("异步保存记忆失败: %v", err)
 			}
 		case <-m.closeCh:
 			// 退出前保存剩余的
 			for {
 				select {
 				case mem := <-m.saveCh:
-					m.storage.Save(mem)
+					if err := m.storage.Save(mem); err != nil {
+						log.Error("异步保存记忆失败: %v", err)
+					}
 				default:
 					return
 				}
@@ -99,7 +128,7 @@ func (m *Manager) asyncSaveLoop() {
 }
 
 // BuildContext 构建上下文（核心方法）
-func (m *Manager) BuildContext(mem *StockMemory, currentQuery string) string {
+func (m *Manager) BuildContext(ctx context.Context, mem *StockMemory, currentQuery string) string {
 	var sb strings.Builder
 
 	// 1. 历史摘要
@@ -109,8 +138,8 @@ func (m *Manager) BuildContext(mem *StockMemory, currentQuery string) string {
 		sb.WriteString("\n\n")
 	}
 
-	// 2. 相关的关键事实（基于关键词匹配）
-	relevantFacts := m.relevance.FindRelevant(mem.KeyFacts, currentQuery, 5)
+	// 2. 相关的关键事实
+	relevantFacts := m.findRelevantFacts(ctx, mem, currentQuery, 5)
 	if len(relevantFacts) > 0 {
 		sb.WriteString("【相关历史信息】\n")
 		for _, fact := range relevantFacts {
@@ -133,6 +162,18 @@ func (m *Manager) BuildContext(mem *StockMemory, currentQuery string) string {
 	return sb.String()
 }
 
+// findRelevantFacts 查找相关事实：优先向量相似度，失败时降级为关键词匹配
+func (m *Manager) findRelevantFacts(ctx context.Context, mem *StockMemory, query string, limit int) []MemoryEntry {
+	if m.embeddings != nil {
+		facts, err := m.embeddings.FindRelevant(ctx, mem.StockCode, mem.KeyFacts, query, limit)
+		if err == nil {
+			return facts
+		}
+		log.Warn("向量检索失败，回退为关键词匹配: %v", err)
+	}
+	return m.relevance.FindRelevant(mem.KeyFacts, query, limit)
+}
+
 // AddRound 添加新一轮讨论并触发压缩检查
 func (m *Manager) AddRound(ctx context.Context, mem *StockMemory, query, consensus string, keyPoints []string) error {
 	mem.TotalRounds++
@@ -149,10 +190,15 @@ func (m *Manager) AddRound(ctx context.Context, mem *StockMemory, query, consens
 	if len(mem.RecentRounds) >= m.config.CompressThreshold {
 		if err := m.compress(ctx, mem); err != nil {
 			// 压缩失败不影响主流程，记录日志即可
-			fmt.Printf("compress memory error: %v\n", err)
+			log.Warn("压缩记忆失败: %v", err)
 		}
 	}
 
+	// 定期维护：去重、衰减、归并旧事实
+	if needsMaintenance(mem, time.Now()) {
+		m.Maintain(ctx, mem)
+	}
+
 	// 异步保存，不阻塞主流程
 	m.SaveAsync(mem)
 	return nil
@@ -249,13 +295,22 @@ func (m *Manager) fallbackExtractKeyPoints(discussions []DiscussionInput) []stri
 
 // DeleteMemory 删除指定股票的记忆
 func (m *Manager) DeleteMemory(stockCode string) error {
+	if m.embeddings != nil {
+		if err := m.embeddings.Delete(stockCode); err != nil {
+			log.Warn("删除向量索引失败: %v", err)
+		}
+	}
 	return m.storage.Delete(stockCode)
 }
 
-// Close 释放资源
+// Close 释放资源（等待未完成的异步保存写入磁盘）
 func (m *Manager) Close() {
 	// 关闭异步保存协程
 	close(m.closeCh)
+	<-m.doneCh
+	if err := m.storage.Close(); err != nil {
+		log.Warn("关闭记忆数据库失败: %v", err)
+	}
 
 	if jt, ok := m.tokenizer.(*GseTokenizer); ok {
 		jt.Free()
@@ -0,0 +1,171 @@
+package memory
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+)
+
+// PromptConfig 摘要生成器的提示词与长度配置
+// 模板使用 text/template 语法，为空时使用内置默认模板
+type PromptConfig struct {
+	SummaryLength       int    // 摘要最大字数，默认 150
+	FactLength          int    // 单条事实最大字数，默认 50
+	MaxFacts            int    // 单次最多提取事实数，默认 5
+	KeyPointLength      int    // 单条观点最大字数，默认 30
+	SummarizeTemplate   string // 可用变量：{{.Rounds}} {{.MaxLength}}
+	ExtractTemplate     string // 可用变量：{{.Content}} {{.MaxFacts}} {{.MaxLength}}
+	KeyPointsTemplate   string // 可用变量：{{.Discussions}} {{.MaxLength}}
+	ConsolidateTemplate string // 可用变量：{{.Facts}} {{.MaxLength}}
+}
+
+// DefaultPromptConfig 默认提示词配置
+func DefaultPromptConfig() PromptConfig {
+	return PromptConfig{
+		SummaryLength:       150,
+		FactLength:          50,
+		MaxFacts:            5,
+		KeyPointLength:      30,
+		SummarizeTemplate:   DefaultSummarizeTemplate,
+		ExtractTemplate:     DefaultExtractTemplate,
+		KeyPointsTemplate:   DefaultKeyPointsTemplate,
+		ConsolidateTemplate: DefaultConsolidateTemplate,
+	}
+}
+
+// DefaultSummarizeTemplate 多轮讨论压缩模板
+const DefaultSummarizeTemplate = `请将以下多轮股票讨论压缩为简洁摘要。
+
+要求：
+1. 保留关键结论和观点
+2. 去除重复信息
+3. 控制在{{.MaxLength}}字以内
+
+讨论记录：
+{{.Rounds}}摘要：`
+
+// DefaultExtractTemplate 关键事实提取模板
+const DefaultExtractTemplate = `从以下讨论内容中提取关键事实（最多{{.MaxFacts}}条）。
+
+内容：
+{{.Content}}
+
+请以JSON数组格式输出，每个事实包含：
+- content: 事实内容（简洁，不超过{{.MaxLength}}字）
+- type: 类型（fact/opinion/decision）
+- weight: 重要性 0-1
+
+只输出JSON数组，不要其他内容：`
+
+// DefaultKeyPointsTemplate 专家观点提取模板
+const DefaultKeyPointsTemplate = `从以下专家讨论中提取核心观点，每位专家提取1-2个最重要的观点。
+
+{{.Discussions}}要求：
+1. 每条观点简洁明了，不超过{{.MaxLength}}字
+2. 保留具体数据和结论
+3. 格式：专家名: 观点内容
+4. 每行一条，直接输出，不要编号
+`
+
+// DefaultConsolidateTemplate 旧事实归并模板
+const DefaultConsolidateTemplate = `请将以下关于同一只股票的历史记忆归并为一段简洁摘要。
+
+要求：
+1. 保留仍有参考价值的事实和结论，注明大致时间
+2. 合并重复或相互印证的内容，后来的信息优先
+3. 控制在{{.MaxLength}}字以内
+
+历史记忆：
+{{.Facts}}
+摘要：`
+
+// promptData 模板变量
+type promptData struct {
+	Content     string
+	Rounds      string
+	Discussions string
+	Facts       string
+	MaxLength   int
+	MaxFacts    int
+}
+
+// promptTemplates 解析后的模板
+type promptTemplates struct {
+	summarize   *template.Template
+	extract     *template.Template
+	keyPoints   *template.Template
+	consolidate *template.Template
+}
+
+// withDefaults 补全未配置的字段
+func (c PromptConfig) withDefaults() PromptConfig {
+	def := DefaultPromptConfig()
+	if c.SummaryLength <= 0 {
+		c.SummaryLength = def.SummaryLength
+	}
+	if c.FactLength <= 0 {
+		c.FactLength = def.FactLength
+	}
+	if c.MaxFacts <= 0 {
+		c.MaxFacts = def.MaxFacts
+	}
+	if c.KeyPointLength <= 0 {
+		c.KeyPointLength = def.KeyPointLength
+	}
+	return c
+}
+
+// ValidatePromptTemplates 校验自定义模板语法与变量
+func ValidatePromptTemplates(c PromptConfig) error {
+	for name, text := range map[string]string{
+		"summarize":   c.SummarizeTemplate,
+		"extract":     c.ExtractTemplate,
+		"keyPoints":   c.KeyPointsTemplate,
+		"consolidate": c.ConsolidateTemplate,
+	} {
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		tmpl, err := template.New(name).Parse(text)
+		if err == nil {
+			// 试渲染一次，提前发现引用了不存在的变量
+			err = tmpl.Execute(io.Discard, promptData{})
+		}
+		if err != nil {
+			return fmt.Errorf("%s 模板格式错误: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// parsePromptTemplates 解析模板，为空或语法错误时使用默认模板
+func parsePromptTemplates(c PromptConfig) promptTemplates {
+	return promptTemplates{
+		summarize:   parseTemplate("summarize", c.SummarizeTemplate, DefaultSummarizeTemplate),
+		extract:     parseTemplate("extract", c.ExtractTemplate, DefaultExtractTemplate),
+		keyPoints:   parseTemplate("keyPoints", c.KeyPointsTemplate, DefaultKeyPointsTemplate),
+		consolidate: parseTemplate("consolidate", c.ConsolidateTemplate, DefaultConsolidateTemplate),
+	}
+}
+
+// parseTemplate 解析单个模板
+func parseTemplate(name, text, fallback string) *template.Template {
+	if strings.TrimSpace(text) != "" {
+		tmpl, err := template.New(name).Parse(text)
+		if err == nil {
+			return tmpl
+		}
+		log.Warn("解析 %s 提示词模板失败，使用默认模板: %v", name, err)
+	}
+	return template.Must(template.New(name).Parse(fallback))
+}
+
+// render 渲染模板
+func render(tmpl *template.Template, data promptData) (string, error) {
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("render %s prompt error: %w", tmpl.Name(), err)
+	}
+	return sb.String(), nil
+}
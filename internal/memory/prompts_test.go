@@ -0,0 +1,37 @@
+package memory
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPromptTemplates(t *testing.T) {
+	s := NewLLMSummarizerWithPrompts(nil, nil, PromptConfig{
+		SummaryLength:     80,
+		SummarizeTemplate: "Summarize in {{.MaxLength}} words:\n{{.Rounds}}",
+	})
+
+	prompt, err := s.buildSummarizePrompt([]RoundMemory{{Round: 1, Query: "后市", Consensus: "谨慎"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(prompt, "Summarize in 80 words:\n【第1轮】问题: 后市") {
+		t.Errorf("summarize prompt = %q", prompt)
+	}
+
+	// 未配置的模板与长度使用默认值
+	prompt, _ = s.buildExtractPrompt("内容")
+	if !strings.Contains(prompt, "最多5条") || !strings.Contains(prompt, "不超过50字") {
+		t.Errorf("extract prompt = %q", prompt)
+	}
+
+	if err := ValidatePromptTemplates(PromptConfig{ExtractTemplate: "{{.Content"}); err == nil {
+		t.Error("expected syntax error")
+	}
+	if err := ValidatePromptTemplates(PromptConfig{KeyPointsTemplate: "{{.Unknown}}"}); err == nil {
+		t.Error("expected unknown variable error")
+	}
+	if err := ValidatePromptTemplates(DefaultPromptConfig()); err != nil {
+		t.Errorf("default templates invalid: %v", err)
+	}
+}
@@ -85,13 +85,13 @@ func (r *Relevance) calculateScore(queryKeywords []string, fact MemoryEntry) flo
 	score *= math.Max(0.5, fact.Weight)
 
 	// 乘以时间衰减
-	score *= r.timeDecay(fact.Timestamp)
+	score *= timeDecay(fact.Timestamp)
 
 	return score
 }
 
 // timeDecay 时间衰减函数
-func (r *Relevance) timeDecay(timestamp int64) float64 {
+func timeDecay(timestamp int64) float64 {
 	age := time.Now().UnixMilli() - timestamp
 	days := float64(age) / (24 * 60 * 60 * 1000)
 	// 7天内权重为1，之后逐渐衰减，最低0.3
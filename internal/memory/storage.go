@@ -1,10 +1,14 @@
 package memory
 
 import (
+	"database/sql"
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"sync"
+
+	"github.com/run-bigpig/jcp/internal/pkg/sqlitedb"
 )
 
 // Storage 存储接口
@@ -13,32 +17,101 @@ type Storage interface {
 	Save(mem *StockMemory) error
 	Delete(stockCode string) error
 	List() ([]string, error)
+	Close() error
+}
+
+// memorySchema 记忆表：会话按股票隔离（会话 ID 即股票代码），轮次与事实逐条存储
+var memorySchema = []string{
+	`CREATE TABLE IF NOT EXISTS memories (
+		stock_code    TEXT PRIMARY KEY,
+		stock_name    TEXT NOT NULL,
+		summary       TEXT NOT NULL,
+		total_rounds  INTEGER NOT NULL,
+		maintained_at INTEGER NOT NULL,
+		created_at    INTEGER NOT NULL,
+		updated_at    INTEGER NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS memory_rounds (
+		stock_code TEXT NOT NULL REFERENCES memories(stock_code) ON DELETE CASCADE,
+		round      INTEGER NOT NULL,
+		query      TEXT NOT NULL,
+		consensus  TEXT NOT NULL,
+		key_points TEXT NOT NULL,
+		timestamp  INTEGER NOT NULL,
+		PRIMARY KEY (stock_code, round)
+	)`,
+	`CREATE TABLE IF NOT EXISTS memory_facts (
+		stock_code TEXT NOT NULL REFERENCES memories(stock_code) ON DELETE CASCADE,
+		pos        INTEGER NOT NULL,
+		id         TEXT NOT NULL,
+		type       TEXT NOT NULL,
+		content    TEXT NOT NULL,
+		source     TEXT NOT NULL,
+		keywords   TEXT NOT NULL,
+		timestamp  INTEGER NOT NULL,
+		weight     REAL NOT NULL,
+		PRIMARY KEY (stock_code, pos)
+	)`,
 }
 
-// FileStorage 文件存储（按股票隔离）
-type FileStorage struct {
+// SQLiteStorage SQLite 存储（<dataDir>/memories/memory.db），数据库在首次使用时打开
+type SQLiteStorage struct {
 	dir   string
+	db    *sql.DB
 	cache map[string]*StockMemory
 	mu    sync.RWMutex
 }
 
-// NewFileStorage 创建文件存储
-func NewFileStorage(dataDir string) *FileStorage {
-	memDir := filepath.Join(dataDir, "memories")
-	os.MkdirAll(memDir, 0755)
-	return &FileStorage{
-		dir:   memDir,
+// NewSQLiteStorage 创建 SQLite 存储
+func NewSQLiteStorage(dataDir string) *SQLiteStorage {
+	return &SQLiteStorage{
+		dir:   filepath.Join(dataDir, "memories"),
 		cache: make(map[string]*StockMemory),
 	}
 }
 
-// getPath 获取存储路径
-func (s *FileStorage) getPath(stockCode string) string {
-	return filepath.Join(s.dir, stockCode+".json")
+// openLocked 打开数据库，首次打开时导入旧版按股票存储的 JSON 文件（需要已持有写锁）
+func (s *SQLiteStorage) openLocked() (*sql.DB, error) {
+	if s.db != nil {
+		return s.db, nil
+	}
+	db, err := sqlitedb.Open(filepath.Join(s.dir, "memory.db"), memorySchema...)
+	if err != nil {
+		return nil, err
+	}
+	s.db = db
+	s.importLegacyLocked()
+	return db, nil
 }
 
-// Load 加载股票记忆
-func (s *FileStorage) Load(stockCode string) (*StockMemory, error) {
+// importLegacyLocked 导入旧版 <stockCode>.json 记忆文件，导入成功后删除
+func (s *SQLiteStorage) importLegacyLocked() {
+	files, _ := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	imported := 0
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var mem StockMemory
+		if err := json.Unmarshal(data, &mem); err != nil || mem.StockCode == "" {
+			log.Warn("跳过无法解析的旧版记忆文件 %s: %v", filepath.Base(path), err)
+			continue
+		}
+		if err := s.saveLocked(&mem); err != nil {
+			log.Warn("导入旧版记忆 %s 失败: %v", mem.StockCode, err)
+			continue
+		}
+		os.Remove(path)
+		imported++
+	}
+	if imported > 0 {
+		log.Info("已导入 %d 个旧版股票记忆文件", imported)
+	}
+}
+
+// Load 加载股票记忆，不存在时返回 os.ErrNotExist
+func (s *SQLiteStorage) Load(stockCode string) (*StockMemory, error) {
 	s.mu.RLock()
 	if mem, ok := s.cache[stockCode]; ok {
 		s.mu.RUnlock()
@@ -46,76 +119,162 @@ func (s *FileStorage) Load(stockCode string) (*StockMemory, error) {
 	}
 	s.mu.RUnlock()
 
-	// 从文件加载
-	data, err := os.ReadFile(s.getPath(stockCode))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	db, err := s.openLocked()
+	if err != nil {
+		return nil, err
+	}
+
+	mem := &StockMemory{StockCode: stockCode, KeyFacts: []MemoryEntry{}, RecentRounds: []RoundMemory{}}
+	err = db.QueryRow(`SELECT stock_name, summary, total_rounds, maintained_at, created_at, updated_at FROM memories WHERE stock_code = ?`, stockCode).
+		Scan(&mem.StockName, &mem.Summary, &mem.TotalRounds, &mem.MaintainedAt, &mem.CreatedAt, &mem.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("股票 %s 暂无记忆: %w", stockCode, os.ErrNotExist)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	var mem StockMemory
-	if err := json.Unmarshal(data, &mem); err != nil {
+	rounds, err := db.Query(`SELECT round, query, consensus, key_points, timestamp FROM memory_rounds WHERE stock_code = ? ORDER BY round`, stockCode)
+	if err != nil {
+		return nil, err
+	}
+	defer rounds.Close()
+	for rounds.Next() {
+		var r RoundMemory
+		var keyPoints string
+		if err := rounds.Scan(&r.Round, &r.Query, &r.Consensus, &keyPoints, &r.Timestamp); err != nil {
+			return nil, err
+		}
+		json.Unmarshal([]byte(keyPoints), &r.KeyPoints)
+		mem.RecentRounds = append(mem.RecentRounds, r)
+	}
+	if err := rounds.Err(); err != nil {
 		return nil, err
 	}
 
-	// 缓存
-	s.mu.Lock()
-	s.cache[stockCode] = &mem
-	s.mu.Unlock()
+	facts, err := db.Query(`SELECT id, type, content, source, keywords, timestamp, weight FROM memory_facts WHERE stock_code = ? ORDER BY pos`, stockCode)
+	if err != nil {
+		return nil, err
+	}
+	defer facts.Close()
+	for facts.Next() {
+		var f MemoryEntry
+		var keywords string
+		if err := facts.Scan(&f.ID, &f.Type, &f.Content, &f.Source, &keywords, &f.Timestamp, &f.Weight); err != nil {
+			return nil, err
+		}
+		json.Unmarshal([]byte(keywords), &f.Keywords)
+		mem.KeyFacts = append(mem.KeyFacts, f)
+	}
+	if err := facts.Err(); err != nil {
+		return nil, err
+	}
 
-	return &mem, nil
+	s.cache[stockCode] = mem
+	return mem, nil
 }
 
-// Save 保存股票记忆
-func (s *FileStorage) Save(mem *StockMemory) error {
+// Save 保存股票记忆（整体替换该股票的轮次与事实）
+func (s *SQLiteStorage) Save(mem *StockMemory) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-
-	data, err := json.MarshalIndent(mem, "", "  ")
-	if err != nil {
+	if _, err := s.openLocked(); err != nil {
 		return err
 	}
-
-	if err := os.WriteFile(s.getPath(mem.StockCode), data, 0644); err != nil {
+	if err := s.saveLocked(mem); err != nil {
 		return err
 	}
-
 	s.cache[mem.StockCode] = mem
 	return nil
 }
 
-// Delete 删除股票记忆
-func (s *FileStorage) Delete(stockCode string) error {
+// saveLocked 在事务中写入记忆（需要已持有写锁且数据库已打开）
+func (s *SQLiteStorage) saveLocked(mem *StockMemory) error {
+	return sqlitedb.InTx(s.db, func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`INSERT INTO memories(stock_code, stock_name, summary, total_rounds, maintained_at, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT(stock_code) DO UPDATE SET stock_name = excluded.stock_name, summary = excluded.summary,
+				total_rounds = excluded.total_rounds, maintained_at = excluded.maintained_at, updated_at = excluded.updated_at`,
+			mem.StockCode, mem.StockName, mem.Summary, mem.TotalRounds, mem.MaintainedAt, mem.CreatedAt, mem.UpdatedAt); err != nil {
+			return err
+		}
+		for _, table := range []string{"memory_rounds", "memory_facts"} {
+			if _, err := tx.Exec(`DELETE FROM `+table+` WHERE stock_code = ?`, mem.StockCode); err != nil {
+				return err
+			}
+		}
+		for _, r := range mem.RecentRounds {
+			keyPoints, _ := json.Marshal(r.KeyPoints)
+			if _, err := tx.Exec(`INSERT OR REPLACE INTO memory_rounds(stock_code, round, query, consensus, key_points, timestamp) VALUES (?, ?, ?, ?, ?, ?)`,
+				mem.StockCode, r.Round, r.Query, r.Consensus, string(keyPoints), r.Timestamp); err != nil {
+				return err
+			}
+		}
+		for i, f := range mem.KeyFacts {
+			keywords, _ := json.Marshal(f.Keywords)
+			if _, err := tx.Exec(`INSERT INTO memory_facts(stock_code, pos, id, type, content, source, keywords, timestamp, weight) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				mem.StockCode, i, f.ID, string(f.Type), f.Content, f.Source, string(keywords), f.Timestamp, f.Weight); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Delete 删除股票记忆（轮次与事实级联删除）
+func (s *SQLiteStorage) Delete(stockCode string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-
 	delete(s.cache, stockCode)
-	err := os.Remove(s.getPath(stockCode))
-	if err != nil && os.IsNotExist(err) {
-		return nil // 文件不存在，无需删除
+	db, err := s.openLocked()
+	if err != nil {
+		return err
 	}
+	_, err = db.Exec(`DELETE FROM memories WHERE stock_code = ?`, stockCode)
 	return err
 }
 
-// List 列出所有股票记忆
-func (s *FileStorage) List() ([]string, error) {
-	entries, err := os.ReadDir(s.dir)
+// List 列出所有有记忆的股票代码
+func (s *SQLiteStorage) List() ([]string, error) {
+	s.mu.Lock()
+	db, err := s.openLocked()
+	s.mu.Unlock()
 	if err != nil {
 		return nil, err
 	}
-
-	codes := make([]string, 0, len(entries))
-	for _, e := range entries {
-		if !e.IsDir() && filepath.Ext(e.Name()) == ".json" {
-			code := e.Name()[:len(e.Name())-5]
-			codes = append(codes, code)
+	rows, err := db.Query(`SELECT stock_code FROM memories ORDER BY stock_code`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	codes := make([]string, 0)
+	for rows.Next() {
+		var code string
+		if err := rows.Scan(&code); err != nil {
+			return nil, err
 		}
+		codes = append(codes, code)
 	}
-	return codes, nil
+	return codes, rows.Err()
 }
 
 // Invalidate 清除缓存
-func (s *FileStorage) Invalidate(stockCode string) {
+func (s *SQLiteStorage) Invalidate(stockCode string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	delete(s.cache, stockCode)
 }
+
+// Close 关闭数据库
+func (s *SQLiteStorage) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.db == nil {
+		return nil
+	}
+	err := s.db.Close()
+	s.db = nil
+	return err
+}
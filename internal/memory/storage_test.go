@@ -0,0 +1,99 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestManager_RecallAcrossRestart 关闭后重新打开仍能召回历史结论与事实
+func TestManager_RecallAcrossRestart(t *testing.T) {
+	dir := t.TempDir()
+
+	m := NewManager(dir)
+	mem, _ := m.GetOrCreate("600519", "贵州茅台")
+	m.AddFacts(mem, []MemoryEntry{{ID: "f1", Type: EntryTypeFact, Content: "提价预期落地", Weight: 0.9}})
+	if err := m.AddRound(context.Background(), mem, "后市怎么看", "维持谨慎乐观", nil); err != nil {
+		t.Fatal(err)
+	}
+	m.Close()
+
+	m2 := NewManager(dir)
+	defer m2.Close()
+	got, _ := m2.GetOrCreate("600519", "贵州茅台")
+	if got.TotalRounds != 1 || len(got.RecentRounds) != 1 || got.RecentRounds[0].Consensus != "维持谨慎乐观" {
+		t.Fatalf("rounds not recalled: %+v", got.RecentRounds)
+	}
+	if len(got.KeyFacts) != 1 || got.KeyFacts[0].Content != "提价预期落地" {
+		t.Fatalf("facts not recalled: %+v", got.KeyFacts)
+	}
+
+	codes, err := m2.storage.List()
+	if err != nil || len(codes) != 1 {
+		t.Errorf("List() = %v, %v", codes, err)
+	}
+}
+
+// TestSQLiteStorage_DeleteAndOrder 事实保持写入顺序，删除后轮次与事实一并清除
+func TestSQLiteStorage_DeleteAndOrder(t *testing.T) {
+	s := NewSQLiteStorage(t.TempDir())
+	defer s.Close()
+
+	mem := NewStockMemory("000001", "平安银行")
+	mem.KeyFacts = []MemoryEntry{
+		{ID: "b", Type: EntryTypeFact, Content: "后写入", Keywords: []string{"银行"}},
+		{ID: "a", Type: EntryTypeDecision, Content: "先写入"},
+	}
+	mem.RecentRounds = []RoundMemory{{Round: 1, Query: "q", Consensus: "c", KeyPoints: []string{"p1", "p2"}}}
+	if err := s.Save(mem); err != nil {
+		t.Fatal(err)
+	}
+	s.Invalidate("000001")
+	got, err := s.Load("000001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.KeyFacts[0].ID != "b" || got.KeyFacts[0].Keywords[0] != "银行" || got.KeyFacts[1].Type != EntryTypeDecision {
+		t.Errorf("facts = %+v", got.KeyFacts)
+	}
+	if len(got.RecentRounds[0].KeyPoints) != 2 {
+		t.Errorf("rounds = %+v", got.RecentRounds)
+	}
+
+	if err := s.Delete("000001"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Load("000001"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("删除后 Load 应返回 ErrNotExist, got %v", err)
+	}
+	var n int
+	s.db.QueryRow(`SELECT (SELECT COUNT(*) FROM memory_rounds) + (SELECT COUNT(*) FROM memory_facts)`).Scan(&n)
+	if n != 0 {
+		t.Errorf("轮次与事实应级联删除, 剩余 %d 条", n)
+	}
+}
+
+// TestSQLiteStorage_ImportLegacy 旧版 JSON 记忆文件导入后删除
+func TestSQLiteStorage_ImportLegacy(t *testing.T) {
+	dir := t.TempDir()
+	memDir := filepath.Join(dir, "memories")
+	os.MkdirAll(memDir, 0755)
+	legacy := filepath.Join(memDir, "600519.json")
+	os.WriteFile(legacy, []byte(`{"stock_code":"600519","stock_name":"贵州茅台","summary":"长期看好","total_rounds":4,"key_facts":[{"id":"f1","content":"提价"}],"recent_rounds":[]}`), 0644)
+	os.WriteFile(filepath.Join(memDir, "broken.json"), []byte("{"), 0644)
+
+	s := NewSQLiteStorage(dir)
+	defer s.Close()
+	got, err := s.Load("600519")
+	if err != nil || got.Summary != "长期看好" || got.TotalRounds != 4 || len(got.KeyFacts) != 1 {
+		t.Fatalf("Load = %+v, %v", got, err)
+	}
+	if _, err := os.Stat(legacy); !os.IsNotExist(err) {
+		t.Error("导入后应删除旧版文件")
+	}
+	if _, err := os.Stat(filepath.Join(memDir, "broken.json")); err != nil {
+		t.Error("无法解析的文件应保留")
+	}
+}
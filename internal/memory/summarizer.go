@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
@@ -17,6 +18,7 @@ type Summarizer interface {
 	SummarizeRounds(ctx context.Context, rounds []RoundMemory) (string, error)
 	ExtractFacts(ctx context.Context, content, agentName string) ([]MemoryEntry, error)
 	ExtractKeyPoints(ctx context.Context, discussions []DiscussionInput) ([]string, error)
+	ConsolidateFacts(ctx context.Context, facts []MemoryEntry) (string, error)
 }
 
 // DiscussionInput 讨论输入（用于关键点提取）
@@ -30,13 +32,23 @@ type DiscussionInput struct {
 type LLMSummarizer struct {
 	llm       model.LLM
 	tokenizer Tokenizer
+	prompts   PromptConfig
+	templates promptTemplates
 }
 
-// NewLLMSummarizer 创建 LLM 摘要生成器
+// NewLLMSummarizer 创建 LLM 摘要生成器（使用默认提示词）
 func NewLLMSummarizer(llm model.LLM, tokenizer Tokenizer) *LLMSummarizer {
+	return NewLLMSummarizerWithPrompts(llm, tokenizer, DefaultPromptConfig())
+}
+
+// NewLLMSummarizerWithPrompts 使用自定义提示词创建 LLM 摘要生成器
+func NewLLMSummarizerWithPrompts(llm model.LLM, tokenizer Tokenizer, prompts PromptConfig) *LLMSummarizer {
+	prompts = prompts.withDefaults()
 	return &LLMSummarizer{
 		llm:       llm,
 		tokenizer: tokenizer,
+		prompts:   prompts,
+		templates: parsePromptTemplates(prompts),
 	}
 }
 
@@ -76,86 +88,133 @@ func (s *LLMSummarizer) SummarizeRounds(ctx context.Context, rounds []RoundMemor
 		return "", nil
 	}
 
-	prompt := s.buildSummarizePrompt(rounds)
+	prompt, err := s.buildSummarizePrompt(rounds)
+	if err != nil {
+		return "", err
+	}
 	return s.generate(ctx, prompt)
 }
 
-func (s *LLMSummarizer) buildSummarizePrompt(rounds []RoundMemory) string {
+func (s *LLMSummarizer) buildSummarizePrompt(rounds []RoundMemory) (string, error) {
 	var sb strings.Builder
-	sb.WriteString("请将以下多轮股票讨论压缩为简洁摘要。\n\n")
-	sb.WriteString("要求：\n")
-	sb.WriteString("1. 保留关键结论和观点\n")
-	sb.WriteString("2. 去除重复信息\n")
-	sb.WriteString("3. 控制在150字以内\n\n")
-	sb.WriteString("讨论记录：\n")
-
 	for _, r := range rounds {
 		sb.WriteString(fmt.Sprintf("【第%d轮】问题: %s\n", r.Round, r.Query))
 		sb.WriteString(fmt.Sprintf("结论: %s\n\n", r.Consensus))
 	}
+	return render(s.templates.summarize, promptData{Rounds: sb.String(), MaxLength: s.prompts.SummaryLength})
+}
 
-	sb.WriteString("摘要：")
-	return sb.String()
+// ConsolidateFacts 将多条旧事实归并为一条摘要
+func (s *LLMSummarizer) ConsolidateFacts(ctx context.Context, facts []MemoryEntry) (string, error) {
+	if len(facts) == 0 {
+		return "", nil
+	}
+
+	var sb strings.Builder
+	for _, f := range facts {
+		sb.WriteString(fmt.Sprintf("- [%s] %s\n", time.UnixMilli(f.Timestamp).Format("2006-01-02"), f.Content))
+	}
+	prompt, err := render(s.templates.consolidate, promptData{Facts: sb.String(), MaxLength: s.prompts.SummaryLength})
+	if err != nil {
+		return "", err
+	}
+
+	result, err := s.generate(ctx, prompt)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(result), nil
 }
 
 // ExtractFacts 从讨论内容中提取关键事实
 func (s *LLMSummarizer) ExtractFacts(ctx context.Context, content, agentName string) ([]MemoryEntry, error) {
-	prompt := s.buildExtractPrompt(content)
+	prompt, err := s.buildExtractPrompt(content)
+	if err != nil {
+		return nil, err
+	}
 	result, err := s.generate(ctx, prompt)
 	if err != nil {
 		return nil, err
 	}
-	return s.parseFacts(result, agentName)
+
+	facts, err := s.parseFacts(result, agentName)
+	if err == nil || len(facts) > 0 {
+		// 部分解析成功时直接返回已提取的事实
+		return facts, nil
+	}
+
+	// 完全无法解析时让模型修复一次
+	repaired, genErr := s.generate(ctx, buildRepairPrompt(result))
+	if genErr != nil {
+		return nil, err
+	}
+	return s.parseFacts(repaired, agentName)
 }
 
-func (s *LLMSummarizer) buildExtractPrompt(content string) string {
-	return fmt.Sprintf(`从以下讨论内容中提取关键事实（最多5条）。
+// buildRepairPrompt 构建 JSON 修复提示词
+func buildRepairPrompt(output string) string {
+	return fmt.Sprintf(`下面的内容应当是一个JSON数组，但格式有误无法解析。
+请修正为合法的JSON数组，保持原有内容不变，每个元素包含 content、type、weight 字段。
 
-内容：
+原始内容：
 %s
 
-请以JSON数组格式输出，每个事实包含：
-- content: 事实内容（简洁，不超过50字）
-- type: 类型（fact/opinion/decision）
-- weight: 重要性 0-1
-
-只输出JSON数组，不要其他内容：`, content)
+只输出JSON数组，不要其他内容：`, output)
 }
 
-func (s *LLMSummarizer) parseFacts(jsonStr, source string) ([]MemoryEntry, error) {
-	// 清理 JSON
-	jsonStr = strings.TrimSpace(jsonStr)
-	jsonStr = strings.TrimPrefix(jsonStr, "```json")
-	jsonStr = strings.TrimPrefix(jsonStr, "```")
-	jsonStr = strings.TrimSuffix(jsonStr, "```")
-	jsonStr = strings.TrimSpace(jsonStr)
-
-	var raw []struct {
-		Content string    `json:"content"`
-		Type    string    `json:"type"`
-		Weight  float64   `json:"weight"`
-	}
+func (s *LLMSummarizer) buildExtractPrompt(content string) (string, error) {
+	return render(s.templates.extract, promptData{
+		Content:   content,
+		MaxFacts:  s.prompts.MaxFacts,
+		MaxLength: s.prompts.FactLength,
+	})
+}
 
-	if err := json.Unmarshal([]byte(jsonStr), &raw); err != nil {
+// parseFacts 容错解析事实列表
+// 支持前后夹杂说明文字、注释与尾随逗号；单个元素无效时跳过，
+// 只有一个有效事实都解析不出时才返回错误
+func (s *LLMSummarizer) parseFacts(output, source string) ([]MemoryEntry, error) {
+	arrayStr, err := extractJSONArray(output)
+	if err != nil {
 		return nil, fmt.Errorf("parse facts json error: %w", err)
 	}
+	items, decodeErr := decodeArrayItems(sanitizeJSON(arrayStr))
 
 	now := time.Now().UnixMilli()
-	entries := make([]MemoryEntry, 0, len(raw))
-	for _, r := range raw {
-		// 使用分词器提取关键词
-		keywords := s.tokenizer.Extract(r.Content, 5)
+	entries := make([]MemoryEntry, 0, len(items))
+	for _, item := range items {
+		var r struct {
+			Content string  `json:"content"`
+			Type    string  `json:"type"`
+			Weight  float64 `json:"weight"`
+		}
+		if err := json.Unmarshal(item, &r); err != nil {
+			continue
+		}
+		r.Content = strings.TrimSpace(r.Content)
+		if r.Content == "" {
+			continue
+		}
+
+		entryType := EntryType(r.Type)
+		if entryType != EntryTypeFact && entryType != EntryTypeOpinion && entryType != EntryTypeDecision {
+			entryType = EntryTypeFact
+		}
 
 		entries = append(entries, MemoryEntry{
 			ID:        uuid.New().String(),
-			Type:      EntryType(r.Type),
+			Type:      entryType,
 			Content:   r.Content,
 			Source:    source,
-			Keywords:  keywords,
+			Keywords:  s.tokenizer.Extract(r.Content, 5), // 使用分词器提取关键词
 			Timestamp: now,
-			Weight:    r.Weight,
+			Weight:    math.Min(math.Max(r.Weight, 0), 1),
 		})
 	}
+
+	if len(entries) == 0 && decodeErr != nil {
+		return nil, fmt.Errorf("parse facts json error: %w", decodeErr)
+	}
 	return entries, nil
 }
 
@@ -165,7 +224,10 @@ func (s *LLMSummarizer) ExtractKeyPoints(ctx context.Context, discussions []Disc
 		return []string{}, nil
 	}
 
-	prompt := s.buildKeyPointsPrompt(discussions)
+	prompt, err := s.buildKeyPointsPrompt(discussions)
+	if err != nil {
+		return nil, err
+	}
 	result, err := s.generate(ctx, prompt)
 	if err != nil {
 		return nil, err
@@ -173,20 +235,12 @@ func (s *LLMSummarizer) ExtractKeyPoints(ctx context.Context, discussions []Disc
 	return s.parseKeyPoints(result), nil
 }
 
-func (s *LLMSummarizer) buildKeyPointsPrompt(discussions []DiscussionInput) string {
+func (s *LLMSummarizer) buildKeyPointsPrompt(discussions []DiscussionInput) (string, error) {
 	var sb strings.Builder
-	sb.WriteString("从以下专家讨论中提取核心观点，每位专家提取1-2个最重要的观点。\n\n")
-
 	for _, d := range discussions {
 		sb.WriteString(fmt.Sprintf("【%s（%s）】\n%s\n\n", d.AgentName, d.Role, d.Content))
 	}
-
-	sb.WriteString("要求：\n")
-	sb.WriteString("1. 每条观点简洁明了，不超过30字\n")
-	sb.WriteString("2. 保留具体数据和结论\n")
-	sb.WriteString("3. 格式：专家名: 观点内容\n")
-	sb.WriteString("4. 每行一条，直接输出，不要编号\n")
-	return sb.String()
+	return render(s.templates.keyPoints, promptData{Discussions: sb.String(), MaxLength: s.prompts.KeyPointLength})
 }
 
 func (s *LLMSummarizer) parseKeyPoints(result string) []string {
@@ -10,6 +10,7 @@ const (
 	EntryTypeFact     EntryType = "fact"     // 事实
 	EntryTypeOpinion  EntryType = "opinion"  // 观点
 	EntryTypeDecision EntryType = "decision" // 决策
+	EntryTypeSummary  EntryType = "summary"  // 旧事实归并后的摘要
 )
 
 // MemoryEntry 记忆条目
@@ -40,6 +41,7 @@ type StockMemory struct {
 	KeyFacts     []MemoryEntry `json:"key_facts"`     // 关键事实
 	RecentRounds []RoundMemory `json:"recent_rounds"` // 最近几轮讨论
 	TotalRounds  int           `json:"total_rounds"`  // 总讨论轮次
+	MaintainedAt int64         `json:"maintained_at"` // 上次维护时间
 	CreatedAt    int64         `json:"created_at"`
 	UpdatedAt    int64         `json:"updated_at"`
 }
@@ -59,10 +61,11 @@ func NewStockMemory(stockCode, stockName string) *StockMemory {
 
 // Config 记忆管理配置
 type Config struct {
-	MaxRecentRounds   int // 保留最近几轮讨论，默认 3
-	MaxKeyFacts       int // 最大关键事实数，默认 20
-	MaxSummaryLength  int // 摘要最大字数，默认 300
-	CompressThreshold int // 触发压缩的轮次数，默认 5
+	MaxRecentRounds   int          // 保留最近几轮讨论，默认 3
+	MaxKeyFacts       int          // 最大关键事实数，默认 20
+	MaxSummaryLength  int          // 摘要最大字数，默认 300
+	CompressThreshold int          // 触发压缩的轮次数，默认 5
+	Prompts           PromptConfig // 摘要提示词与长度配置
 }
 
 // DefaultConfig 默认配置
@@ -72,5 +75,6 @@ func DefaultConfig() Config {
 		MaxKeyFacts:       20,
 		MaxSummaryLength:  300,
 		CompressThreshold: 5,
+		Prompts:           DefaultPromptConfig(),
 	}
 }
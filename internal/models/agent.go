@@ -13,3 +13,18 @@ type AgentConfig struct {
 	Enabled     bool     `json:"enabled"`
 	AIConfigID  string   `json:"aiConfigId"` // 可选，空则用默认AI
 }
+
+// 讨论编排模式
+const (
+	OrchestrationRoundRobin = "round_robin" // 轮流发言（默认），后发言者参考前面的内容
+	OrchestrationDebate     = "debate"      // 辩论：首轮观点后进入反驳轮
+	OrchestrationVote       = "vote"        // 首轮观点后由小韭菜发起投票
+	OrchestrationParallel   = "parallel"    // 专家并行独立发言，小韭菜综合
+)
+
+// OrchestrationConfig 单次讨论的编排配置
+type OrchestrationConfig struct {
+	Mode      string `json:"mode"`      // 编排模式，空则为轮流发言
+	MaxRounds int    `json:"maxRounds"` // 最大讨论轮数（轮流/辩论模式有效），0 使用默认值
+	EarlyStop bool   `json:"earlyStop"` // 每轮结束后检测共识，达成共识提前结束
+}
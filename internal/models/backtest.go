@@ -0,0 +1,64 @@
+package models
+
+// 回测策略类型
+const (
+	BacktestMACross  = "ma_cross" // 均线交叉：快线上穿慢线买入，下穿卖出
+	BacktestBreakout = "breakout" // 突破：收盘价突破前 N 日最高买入，跌破前 N 日最低卖出
+	BacktestRSI      = "rsi"      // RSI 阈值：低于下限买入，高于上限卖出
+)
+
+// BacktestRule 回测策略规则，按 Type 使用对应参数，未填写的参数取默认值
+type BacktestRule struct {
+	Type     string  `json:"type"`
+	Fast     int     `json:"fast,omitempty"`     // 均线交叉：快线周期，默认 5
+	Slow     int     `json:"slow,omitempty"`     // 均线交叉：慢线周期，默认 20
+	Lookback int     `json:"lookback,omitempty"` // 突破：回看周期，默认 20
+	Period   int     `json:"period,omitempty"`   // RSI：周期，默认 14
+	Lower    float64 `json:"lower,omitempty"`    // RSI：超卖阈值，默认 30
+	Upper    float64 `json:"upper,omitempty"`    // RSI：超买阈值，默认 70
+}
+
+// BacktestRequest 回测请求
+type BacktestRequest struct {
+	Symbol      string       `json:"symbol"`
+	Period      string       `json:"period"` // K线周期，默认 1d
+	Days        int          `json:"days"`   // K线数量，默认 500
+	Rule        BacktestRule `json:"rule"`
+	InitialCash float64      `json:"initialCash"` // 初始资金，默认 10 万
+}
+
+// BacktestTrade 回测成交（一次完整的买入-卖出）
+type BacktestTrade struct {
+	EntryTime  string  `json:"entryTime"`
+	EntryPrice float64 `json:"entryPrice"`
+	ExitTime   string  `json:"exitTime"`
+	ExitPrice  float64 `json:"exitPrice"`
+	Shares     int64   `json:"shares"`
+	Fee        float64 `json:"fee"`
+	PnL        float64 `json:"pnl"` // 扣除费用后的盈亏
+	PnLPercent float64 `json:"pnlPercent"`
+	Open       bool    `json:"open,omitempty"` // 回测结束时仍持仓，按最后收盘价计算
+}
+
+// EquityPoint 资金曲线点
+type EquityPoint struct {
+	Time     string  `json:"time"`
+	Equity   float64 `json:"equity"`
+	Drawdown float64 `json:"drawdown"` // 相对历史最高点的回撤百分比（<=0）
+}
+
+// BacktestResult 回测结果
+type BacktestResult struct {
+	Symbol          string          `json:"symbol"`
+	Rule            BacktestRule    `json:"rule"`
+	InitialCash     float64         `json:"initialCash"`
+	FinalEquity     float64         `json:"finalEquity"`
+	TotalReturn     float64         `json:"totalReturn"`     // 总收益率（%）
+	BenchmarkReturn float64         `json:"benchmarkReturn"` // 同期买入持有收益率（%）
+	MaxDrawdown     float64         `json:"maxDrawdown"`     // 最大回撤（%，<=0）
+	WinRate         float64         `json:"winRate"`         // 胜率（%）
+	TradeCount      int             `json:"tradeCount"`
+	Trades          []BacktestTrade `json:"trades"`
+	Equity          []EquityPoint   `json:"equity"`
+	Error           string          `json:"error,omitempty"`
+}
@@ -0,0 +1,34 @@
+package models
+
+// BriefingConfig 每日 AI 收盘简报配置
+type BriefingConfig struct {
+	Enabled    bool   `json:"enabled"`
+	Time       string `json:"time"`       // 生成时间 HH:MM（北京时间），默认 15:30，早于收盘按收盘后处理
+	AIConfigID string `json:"aiConfigId"` // 使用的 AI 配置，空则用默认AI
+	MaxStocks  int    `json:"maxStocks"`  // 最多分析的自选股数量，0 使用默认值
+
+	NearTargetPct float64 `json:"nearTargetPct"` // 当日进入目标价/止损价该比例(%)以内的股票计入简报，0 使用默认值
+}
+
+// BriefingStock 简报中的单只股票
+type BriefingStock struct {
+	Code          string   `json:"code"`
+	Name          string   `json:"name"`
+	Price         float64  `json:"price"`
+	ChangePercent float64  `json:"changePercent"`
+	Amount        float64  `json:"amount"`
+	News          []string `json:"news"`        // 当日相关快讯
+	WatchPoints   string   `json:"watchPoints"` // AI 给出的次日关注点
+
+	Session *SessionHeatReport `json:"session,omitempty"` // 盘面复盘，获取失败时为空
+}
+
+// DailyBriefing 每日收盘简报
+type DailyBriefing struct {
+	Date        string            `json:"date"`    // 交易日 2006-01-02
+	Summary     string            `json:"summary"` // AI 总览
+	Stocks      []BriefingStock   `json:"stocks"`
+	NearTargets []TargetProximity `json:"nearTargets"` // 当日接近跟踪线的股票
+	Path        string            `json:"path"`        // Markdown 报告路径
+	GeneratedAt int64             `json:"generatedAt"`
+}
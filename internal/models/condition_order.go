@@ -0,0 +1,70 @@
+package models
+
+// 条件单触发类型
+const (
+	TriggerPriceAbove = "price_above" // 价格向上突破
+	TriggerPriceBelow = "price_below" // 价格向下跌破
+	TriggerTime       = "time"        // 到达指定时间（交易日 HH:MM）
+	TriggerIndicator  = "indicator"   // 指标条件
+)
+
+// 条件单指标
+const (
+	IndicatorChangePercent = "change_percent" // 涨跌幅(%)与 Value 比较
+	IndicatorMA            = "ma"             // 现价与 Period 日均线比较
+)
+
+// 条件单动作
+const (
+	ConditionActionNotify = "notify" // 仅通知
+	ConditionActionPaper  = "paper"  // 提交模拟交易委托
+)
+
+// 条件单状态
+const (
+	ConditionActive    = "active"
+	ConditionTriggered = "triggered"
+	ConditionFailed    = "failed" // 已触发但动作执行失败
+	ConditionCancelled = "cancelled"
+)
+
+// ConditionTrigger 条件单触发条件
+type ConditionTrigger struct {
+	Type      string  `json:"type"`
+	Price     float64 `json:"price,omitempty"`     // 价格突破/跌破
+	Time      string  `json:"time,omitempty"`      // 时间条件，如 14:50
+	Indicator string  `json:"indicator,omitempty"` // 指标条件：change_percent / ma
+	Above     bool    `json:"above,omitempty"`     // 指标条件：true 高于，false 低于
+	Value     float64 `json:"value,omitempty"`     // 涨跌幅阈值(%)
+	Period    int     `json:"period,omitempty"`    // 均线周期（日）
+}
+
+// ConditionAction 条件单触发后的动作
+type ConditionAction struct {
+	Type  string            `json:"type"`            // notify / paper
+	Order PaperOrderRequest `json:"order,omitempty"` // 模拟委托（代码取条件单代码）
+}
+
+// ConditionOrderRequest 创建条件单请求
+type ConditionOrderRequest struct {
+	Symbol  string           `json:"symbol"`
+	Trigger ConditionTrigger `json:"trigger"`
+	Action  ConditionAction  `json:"action"`
+	Note    string           `json:"note,omitempty"`
+}
+
+// ConditionOrder 条件单
+type ConditionOrder struct {
+	ID           string           `json:"id"`
+	Symbol       string           `json:"symbol"`
+	Name         string           `json:"name"`
+	Trigger      ConditionTrigger `json:"trigger"`
+	Action       ConditionAction  `json:"action"`
+	Note         string           `json:"note,omitempty"`
+	Status       string           `json:"status"`
+	LastPrice    float64          `json:"lastPrice,omitempty"` // 最近一次评估价格，用于判断穿越
+	CreatedAt    int64            `json:"createdAt"`
+	TriggeredAt  int64            `json:"triggeredAt,omitempty"`
+	TriggerPrice float64          `json:"triggerPrice,omitempty"`
+	Result       string           `json:"result,omitempty"` // 委托编号或失败原因
+}
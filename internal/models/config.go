@@ -8,6 +8,7 @@ const (
 	AIProviderGemini    AIProvider = "gemini"
 	AIProviderVertexAI  AIProvider = "vertexai"
 	AIProviderAnthropic AIProvider = "anthropic"
+	AIProviderOllama    AIProvider = "ollama"
 )
 
 // AIConfig AI服务配置
@@ -26,10 +27,30 @@ type AIConfig struct {
 	UseResponses bool `json:"useResponses"`
 	// 不支持 system role（自动检测，用户不可见）
 	NoSystemRole bool `json:"noSystemRole"`
+	// 模型能力，为空时按模型名从内置能力表推断
+	Capabilities *ModelCapabilities `json:"capabilities,omitempty"`
+	// Ollama 专用字段：模型驻留内存时长（如 5m、1h，-1 常驻），为空使用 Ollama 默认值
+	KeepAlive string `json:"keepAlive"`
+	// Gemini / Vertex AI 原生能力
+	ThinkingBudget  *int   `json:"thinkingBudget,omitempty"` // 思考预算 token：为空使用模型默认，0 关闭，-1 动态
+	SafetyThreshold string `json:"safetyThreshold"`          // 安全过滤阈值，如 BLOCK_NONE / BLOCK_ONLY_HIGH
+	GoogleSearch    bool   `json:"googleSearch"`             // 启用 Google 搜索 grounding
 	// Vertex AI 专用字段
 	Project         string `json:"project"`
 	Location        string `json:"location"`
 	CredentialsJSON string `json:"credentialsJson"`
+	// 计费单价（元/百万 tokens），用于费用预算统计
+	InputPrice  float64 `json:"inputPrice"`
+	OutputPrice float64 `json:"outputPrice"`
+}
+
+// ModelCapabilities 模型能力描述，决定请求转换时的降级策略
+type ModelCapabilities struct {
+	Vision     bool `json:"vision"`     // 支持图片输入
+	Tools      bool `json:"tools"`      // 支持函数调用
+	SystemRole bool `json:"systemRole"` // 支持 system 角色
+	Thinking   bool `json:"thinking"`   // 支持思考/推理配置
+	MaxContext int  `json:"maxContext"` // 上下文窗口(token)，0 表示按模型名推断
 }
 
 // MCPTransportType MCP传输类型
@@ -55,18 +76,54 @@ type MCPServerConfig struct {
 
 // AppConfig 应用配置
 type AppConfig struct {
-	Theme           string            `json:"theme"`           // 主题色: military, ocean, purple, orange, dark
-	CandleColorMode string            `json:"candleColorMode"` // 涨跌颜色模式: red-up(红涨绿跌) / green-up(绿涨红跌)
-	AIConfigs       []AIConfig        `json:"aiConfigs"`
-	DefaultAIID     string            `json:"defaultAiId"`
-	StrategyAIID    string            `json:"strategyAiId"`  // 策略生成用AI
-	ModeratorAIID   string            `json:"moderatorAiId"` // 意图分析(小韭菜)用AI
-	MCPServers      []MCPServerConfig `json:"mcpServers"`    // MCP服务器配置列表
-	Memory          MemoryConfig      `json:"memory"`        // 记忆管理配置
-	Proxy           ProxyConfig       `json:"proxy"`         // 代理配置
-	Layout          LayoutConfig      `json:"layout"`        // 界面布局配置
-	OpenClaw        OpenClawConfig    `json:"openClaw"`      // OpenClaw 服务配置
-	Indicators      IndicatorConfig   `json:"indicators"`    // 技术指标配置
+	Theme           string              `json:"theme"`           // 主题色: military, ocean, purple, orange, dark
+	CandleColorMode string              `json:"candleColorMode"` // 涨跌颜色模式: red-up(红涨绿跌) / green-up(绿涨红跌)
+	AIConfigs       []AIConfig          `json:"aiConfigs"`
+	DefaultAIID     string              `json:"defaultAiId"`
+	StrategyAIID    string              `json:"strategyAiId"`  // 策略生成用AI
+	ModeratorAIID   string              `json:"moderatorAiId"` // 意图分析(小韭菜)用AI
+	MCPServers      []MCPServerConfig   `json:"mcpServers"`    // MCP服务器配置列表
+	Memory          MemoryConfig        `json:"memory"`        // 记忆管理配置
+	Proxy           ProxyConfig         `json:"proxy"`         // 代理配置
+	Layout          LayoutConfig        `json:"layout"`        // 界面布局配置
+	OpenClaw        OpenClawConfig      `json:"openClaw"`      // OpenClaw 服务配置
+	Indicators      IndicatorConfig     `json:"indicators"`    // 技术指标配置
+	MarketIndices   []string            `json:"marketIndices"` // 顶部展示的大盘指数代码列表
+	IPOReminder     bool                `json:"ipoReminder"`   // 新股申购日提醒
+	AIBudget        AIBudgetConfig      `json:"aiBudget"`      // AI 费用预算
+	RateLimits      []HostRateLimit     `json:"rateLimits"`    // 上游域名限流规则
+	ConnPools       []HostConnPool      `json:"connPools"`     // 上游域名连接池参数
+	PushIntervals   *PushIntervals      `json:"pushIntervals"` // 行情推送频率，为空使用默认值
+	Retry           *RetryConfig        `json:"retry"`         // 行情请求重试策略，为空使用默认值
+	WatchlistSort   WatchlistSortConfig `json:"watchlistSort"` // 自选股推送排序
+	Engine          EngineConfig        `json:"engine"`        // 远程数据引擎
+	Sync            SyncConfig          `json:"sync"`          // WebDAV 云同步
+	LocalAPI        LocalAPIConfig      `json:"localApi"`      // 本地 REST API 服务
+	News            NewsConfig          `json:"news"`          // 快讯来源
+	Personas        []AgentPersona      `json:"personas"`      // 自定义专家人设
+	Briefing        BriefingConfig      `json:"briefing"`      // 每日收盘简报
+	ToolSandbox     ToolSandboxConfig   `json:"toolSandbox"`   // 工具执行沙箱（超时、限流、审计）
+	Log             LogConfig           `json:"log"`           // 文件日志轮转与保留
+	Update          UpdateConfig        `json:"update"`        // 自动更新
+	Hotkeys         HotkeyConfig        `json:"hotkeys"`       // 全局快捷键
+	Language        string              `json:"language"`      // 后端展示文本语言: zh-CN / en-US，为空使用简体中文
+	Notifications   NotificationConfig  `json:"notifications"` // 通知中心（分类开关、免打扰）
+	MemoryLimitMB   int                 `json:"memoryLimitMb"` // 内存软上限（MB），超出时收缩缓存并归还系统，0 使用默认值
+	LockupAlert     LockupAlertConfig   `json:"lockupAlert"`   // 自选股限售解禁提醒
+}
+
+// LockupAlertConfig 限售解禁提醒：自选股在 DaysBefore 天内有大额解禁时提醒一次
+type LockupAlertConfig struct {
+	Enabled       bool    `json:"enabled"`
+	DaysBefore    int     `json:"daysBefore"`    // 提前天数，0 使用默认值 7
+	MinFloatRatio float64 `json:"minFloatRatio"` // 解禁股数占流通股比例(%)达到该值才提醒，0 使用默认值 5
+}
+
+// WatchlistSortConfig 自选股排序配置（由推送服务在后端排序）
+type WatchlistSortConfig struct {
+	Field string `json:"field"` // 排序字段: changePercent/price/change/volume/amount，为空保持自选顺序
+	Order string `json:"order"` // asc / desc
+	TopN  int    `json:"topN"`  // 仅推送前N只，0 表示全部
 }
 
 // ProxyMode 代理模式
@@ -76,22 +133,55 @@ const (
 	ProxyModeNone   ProxyMode = "none"   // 无代理，直连
 	ProxyModeSystem ProxyMode = "system" // 使用系统代理
 	ProxyModeCustom ProxyMode = "custom" // 自定义代理
+	ProxyModePAC    ProxyMode = "pac"    // 使用 PAC 脚本自动选择代理
 )
 
 // ProxyConfig 代理配置
 type ProxyConfig struct {
 	Mode      ProxyMode `json:"mode"`
 	CustomURL string    `json:"customUrl"` // 自定义代理地址
+	PacURL    string    `json:"pacUrl"`    // PAC 脚本地址，支持 http(s):// 和本地文件
+}
+
+// HostRateLimit 上游域名限流规则（令牌桶）
+type HostRateLimit struct {
+	Host  string  `json:"host"`  // 域名，按后缀匹配，如 sinajs.cn
+	Rate  float64 `json:"rate"`  // 每秒请求数
+	Burst int     `json:"burst"` // 突发容量
+}
+
+// HostConnPool 上游域名连接池参数，高频轮询的域名需要更多空闲连接以复用
+type HostConnPool struct {
+	Host                string `json:"host"`                // 域名，按后缀匹配
+	MaxIdleConnsPerHost int    `json:"maxIdleConnsPerHost"` // 每个主机保留的空闲连接数
+	MaxConnsPerHost     int    `json:"maxConnsPerHost"`     // 每个主机的最大连接数，0 表示不限制
+	IdleTimeout         int    `json:"idleTimeout"`         // 空闲连接保留时间(秒)，0 使用默认值
 }
 
 // MemoryConfig 记忆管理配置
 type MemoryConfig struct {
-	Enabled           bool   `json:"enabled"`           // 是否启用记忆管理
-	AIConfigID        string `json:"aiConfigId"`        // 使用的 LLM 配置 ID（空则使用默认）
-	MaxRecentRounds   int    `json:"maxRecentRounds"`   // 保留最近几轮讨论
-	MaxKeyFacts       int    `json:"maxKeyFacts"`       // 最大关键事实数
-	MaxSummaryLength  int    `json:"maxSummaryLength"`  // 摘要最大字数
-	CompressThreshold int    `json:"compressThreshold"` // 触发压缩的轮次数
+	Enabled           bool               `json:"enabled"`           // 是否启用记忆管理
+	AIConfigID        string             `json:"aiConfigId"`        // 使用的 LLM 配置 ID（空则使用默认）
+	MaxRecentRounds   int                `json:"maxRecentRounds"`   // 保留最近几轮讨论
+	MaxKeyFacts       int                `json:"maxKeyFacts"`       // 最大关键事实数
+	MaxSummaryLength  int                `json:"maxSummaryLength"`  // 摘要最大字数
+	CompressThreshold int                `json:"compressThreshold"` // 触发压缩的轮次数
+	EmbeddingAIID     string             `json:"embeddingAiId"`     // 向量模型使用的 AI 配置 ID（提供 BaseURL 与 APIKey）
+	EmbeddingModel    string             `json:"embeddingModel"`    // 向量模型名称（空则使用关键词检索）
+	Prompts           MemoryPromptConfig `json:"prompts"`           // 摘要提示词配置
+}
+
+// MemoryPromptConfig 记忆摘要提示词配置
+// 模板为空时使用内置默认模板，长度为 0 时使用默认值
+type MemoryPromptConfig struct {
+	SummaryLength       int    `json:"summaryLength"`       // 摘要最大字数
+	FactLength          int    `json:"factLength"`          // 单条事实最大字数
+	MaxFacts            int    `json:"maxFacts"`            // 单次最多提取事实数
+	KeyPointLength      int    `json:"keyPointLength"`      // 单条观点最大字数
+	SummarizeTemplate   string `json:"summarizeTemplate"`   // 讨论压缩模板，变量 {{.Rounds}} {{.MaxLength}}
+	ExtractTemplate     string `json:"extractTemplate"`     // 事实提取模板，变量 {{.Content}} {{.MaxFacts}} {{.MaxLength}}
+	KeyPointsTemplate   string `json:"keyPointsTemplate"`   // 观点提取模板，变量 {{.Discussions}} {{.MaxLength}}
+	ConsolidateTemplate string `json:"consolidateTemplate"` // 事实归并模板，变量 {{.Facts}} {{.MaxLength}}
 }
 
 // LayoutConfig 界面布局配置
@@ -110,6 +200,89 @@ type OpenClawConfig struct {
 	APIKey  string `json:"apiKey"`  // API 鉴权密钥（可选）
 }
 
+// NewsConfig 快讯配置
+type NewsConfig struct {
+	DisabledSources []string         `json:"disabledSources"` // 禁用的快讯来源（默认全部启用：cls/sina/eastmoney）
+	AlertKeywords   []string         `json:"alertKeywords"`   // 关键词提醒，如 "减持"、"重组"、股票名称
+	DesktopNotify   bool             `json:"desktopNotify"`   // 命中关键词时弹出桌面通知
+	Digest          NewsDigestConfig `json:"digest"`          // 快讯 AI 摘要
+}
+
+// NewsDigestConfig 快讯 AI 摘要配置：每隔固定分钟数汇总新增快讯，生成市场脉络
+type NewsDigestConfig struct {
+	Interval   int    `json:"interval"`   // 汇总间隔（分钟）：0 关闭，可选 15 / 30
+	AIConfigID string `json:"aiConfigId"` // 使用的 AI 配置，空则用默认AI
+}
+
+// LocalAPIConfig 本地 REST API 服务配置（仅监听 127.0.0.1，供外部脚本或个人看板读取行情与自选）
+type LocalAPIConfig struct {
+	Enabled bool   `json:"enabled"` // 是否启用
+	Port    int    `json:"port"`    // 监听端口
+	Token   string `json:"token"`   // 访问令牌（必填）
+}
+
+// EngineConfig 远程数据引擎配置（启用后行情数据经由 jcp-engine 获取）
+type EngineConfig struct {
+	Enabled bool   `json:"enabled"` // 是否使用远程引擎
+	Address string `json:"address"` // 引擎地址，如 192.168.1.10:50051
+	Token   string `json:"token"`   // 鉴权令牌（可选）
+	TLS     bool   `json:"tls"`     // 是否使用 TLS 连接，非本机地址必须启用
+	CACert  string `json:"caCert"`  // 校验引擎证书的 CA 证书文件（PEM），为空时使用系统根证书
+}
+
+// SyncConfig WebDAV 云同步配置（配置、自选股、分组、目标价与条件单）
+type SyncConfig struct {
+	Enabled  bool   `json:"enabled"`
+	URL      string `json:"url"` // WebDAV 目录地址，如 https://dav.jianguoyun.com/dav/jcp/
+	Username string `json:"username"`
+	Password string `json:"password"` // 应用密码
+}
+
+// SyncResult 同步结果
+type SyncResult struct {
+	Pushed    []string `json:"pushed"`    // 上传到远端的文件
+	Pulled    []string `json:"pulled"`    // 从远端拉取的文件
+	Conflicts []string `json:"conflicts"` // 两端都有修改的文件（按最后修改时间取新，旧版本已备份）
+	Time      int64    `json:"time"`      // 同步时间(ms)
+	Error     string   `json:"error,omitempty"`
+}
+
+// UpdateConfig 自动更新配置
+type UpdateConfig struct {
+	DisableAutoCheck bool   `json:"disableAutoCheck"` // 关闭启动时自动检查更新
+	SkipVersion      string `json:"skipVersion"`      // 用户选择跳过的版本，不再提醒
+}
+
+// HotkeyConfig 全局快捷键配置，快捷键为空表示不启用该项
+type HotkeyConfig struct {
+	Enabled     bool   `json:"enabled"`
+	ShowHide    string `json:"showHide"`    // 显示/隐藏窗口
+	BossKey     string `json:"bossKey"`     // 老板键：立即隐藏窗口并静音通知，再按一次恢复
+	QuickSearch string `json:"quickSearch"` // 显示窗口并聚焦股票搜索
+}
+
+// LogConfig 文件日志配置，修改后下次启动生效
+type LogConfig struct {
+	MaxSizeMB     int `json:"maxSizeMB"`     // 单个日志文件大小上限(MB)，超出后轮转压缩，0 使用默认 20MB
+	RetentionDays int `json:"retentionDays"` // 日志保留天数，0 使用默认 14 天
+}
+
+// AIBudgetConfig AI 费用预算配置，超出上限后暂停调用大模型
+type AIBudgetConfig struct {
+	Enabled      bool    `json:"enabled"`
+	DailyLimit   float64 `json:"dailyLimit"`   // 每日费用上限(元)，0 表示不限
+	SessionLimit float64 `json:"sessionLimit"` // 单个股票会话费用上限(元)，0 表示不限
+	// Prices 模型单价表，AI 配置未填写单价时按模型名前缀匹配
+	Prices []ModelPrice `json:"prices"`
+}
+
+// ModelPrice 模型计费单价（元/百万 tokens）
+type ModelPrice struct {
+	Model       string  `json:"model"` // 模型名前缀，如 gpt-4o、deepseek-chat
+	InputPrice  float64 `json:"inputPrice"`
+	OutputPrice float64 `json:"outputPrice"`
+}
+
 // IndicatorConfig 技术指标配置
 type IndicatorConfig struct {
 	MA   MAConfig   `json:"ma"`
@@ -154,3 +327,37 @@ type KDJConfig struct {
 	K       int  `json:"k"`      // 默认 3
 	D       int  `json:"d"`      // 默认 3
 }
+
+// PushIntervals 行情推送频率（毫秒）
+type PushIntervals struct {
+	FastMs   int `json:"fastMs"`   // 盘口（交易时段）
+	NormalMs int `json:"normalMs"` // 股票、指数、分时K线
+	SlowMs   int `json:"slowMs"`   // 快讯
+}
+
+// RetryConfig 行情请求重试策略（指数退避，每次等待在上限内翻倍）
+type RetryConfig struct {
+	MaxAttempts     int   `json:"maxAttempts"`     // 最大尝试次数（含首次请求）
+	BaseDelayMs     int   `json:"baseDelayMs"`     // 首次重试前的等待时间
+	MaxDelayMs      int   `json:"maxDelayMs"`      // 单次等待上限
+	RetryableStatus []int `json:"retryableStatus"` // 需要重试的HTTP状态码，为空时使用默认列表
+}
+
+// RuntimeSettings 运行时设置，字段为空表示不修改
+type RuntimeSettings struct {
+	LogLevel      string         `json:"logLevel,omitempty"`      // DEBUG/INFO/WARN/ERROR
+	ModuleLevels  *string        `json:"moduleLevels,omitempty"`  // 按模块覆盖日志级别，如 "pusher=debug, market=warn"，空字符串清除
+	Engine        *EngineConfig  `json:"engine,omitempty"`        // 行情数据源：直连上游或远程引擎
+	Recording     *bool          `json:"recording,omitempty"`     // 是否记录盘中数据（收盘复盘用）
+	PushIntervals *PushIntervals `json:"pushIntervals,omitempty"` // 推送频率
+	PushPaused    *bool          `json:"pushPaused,omitempty"`    // 暂停行情推送
+	Retry         *RetryConfig   `json:"retry,omitempty"`         // 行情请求重试策略
+}
+
+// RuntimeSettingsResult 运行时设置应用结果
+type RuntimeSettingsResult struct {
+	Success    bool     `json:"success"`
+	Applied    []string `json:"applied"`         // 已生效的设置项
+	RolledBack []string `json:"rolledBack"`      // 因后续失败而回滚的设置项
+	Error      string   `json:"error,omitempty"` // 校验或应用失败原因
+}
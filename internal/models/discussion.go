@@ -0,0 +1,53 @@
+package models
+
+// 讨论状态
+const (
+	DiscussionRunning     = "running"     // 进行中
+	DiscussionCompleted   = "completed"   // 已完成（含总结）
+	DiscussionInterrupted = "interrupted" // 专家失败、超时或应用退出导致中断，可恢复
+	DiscussionCancelled   = "cancelled"   // 用户停止，可恢复
+)
+
+// DiscussionEvent 讨论过程事件（思考、工具调用等）
+type DiscussionEvent struct {
+	Type      string `json:"type"` // thinking/tool_call/tool_result/agent_error/meeting_interrupted
+	AgentID   string `json:"agentId"`
+	AgentName string `json:"agentName"`
+	Detail    string `json:"detail,omitempty"`
+	Content   string `json:"content,omitempty"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// Discussion 一次多专家讨论的完整记录
+type Discussion struct {
+	ID             string               `json:"id"`
+	StockCode      string               `json:"stockCode"`
+	StockName      string               `json:"stockName"`
+	Query          string               `json:"query"`
+	Orchestration  *OrchestrationConfig `json:"orchestration,omitempty"`
+	Topic          string               `json:"topic,omitempty"`
+	SelectedAgents []string             `json:"selectedAgents"`  // 小韭菜选中的专家（发言顺序）
+	Tasks          map[string]string    `json:"tasks,omitempty"` // 专家ID -> 专属分析任务
+	Status         string               `json:"status"`
+	Error          string               `json:"error,omitempty"`
+	Messages       []ChatMessage        `json:"messages"`
+	Events         []DiscussionEvent    `json:"events"`
+	ResumeCount    int                  `json:"resumeCount"`
+	CreatedAt      int64                `json:"createdAt"`
+	UpdatedAt      int64                `json:"updatedAt"`
+}
+
+// DiscussionSummary 讨论列表项
+type DiscussionSummary struct {
+	ID           string `json:"id"`
+	StockCode    string `json:"stockCode"`
+	StockName    string `json:"stockName"`
+	Query        string `json:"query"`
+	Mode         string `json:"mode"`
+	Status       string `json:"status"`
+	Rounds       int    `json:"rounds"` // 已进行的讨论轮数
+	MessageCount int    `json:"messageCount"`
+	Resumable    bool   `json:"resumable"`
+	CreatedAt    int64  `json:"createdAt"`
+	UpdatedAt    int64  `json:"updatedAt"`
+}
@@ -0,0 +1,35 @@
+package models
+
+// DrawingType 图表画线类型
+type DrawingType string
+
+const (
+	DrawingTrendLine DrawingType = "trendline" // 趋势线（两点）
+	DrawingRay       DrawingType = "ray"       // 射线
+	DrawingHLine     DrawingType = "hline"     // 水平线
+	DrawingRect      DrawingType = "rect"      // 矩形
+	DrawingFib       DrawingType = "fib"       // 斐波那契回撤
+	DrawingText      DrawingType = "text"      // 文字标注
+)
+
+// DrawingPoint 画线锚点（K线时间 + 价格）
+type DrawingPoint struct {
+	Time  string  `json:"t"` // K线时间，与 KLineData.Time 一致
+	Price float64 `json:"p"` // 价格
+}
+
+// ChartDrawing 图表画线对象
+type ChartDrawing struct {
+	ID        string         `json:"id"`
+	Symbol    string         `json:"symbol"`           // 股票代码
+	Period    string         `json:"period"`           // K线周期: 1m, 1d, 1w, 1mo
+	Type      DrawingType    `json:"type"`             // 画线类型
+	Points    []DrawingPoint `json:"points"`           // 锚点列表
+	Color     string         `json:"color,omitempty"`  // 线条颜色
+	Width     int            `json:"width,omitempty"`  // 线宽(px)
+	Text      string         `json:"text,omitempty"`   // 文字内容（text 类型）
+	Levels    []float64      `json:"levels,omitempty"` // 斐波那契分位（fib 类型，空则使用默认）
+	Locked    bool           `json:"locked,omitempty"` // 是否锁定
+	CreatedAt int64          `json:"createdAt"`
+	UpdatedAt int64          `json:"updatedAt"`
+}
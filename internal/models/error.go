@@ -0,0 +1,10 @@
+package models
+
+// ErrorInfo 推送给前端的结构化错误（事件 app:error）
+type ErrorInfo struct {
+	Op        string `json:"op"`        // 出错的接口，如 GetIndexConstituents
+	Code      string `json:"code"`      // 错误码: NETWORK/RATE_LIMITED/PROVIDER_PARSE/AUTH/BUDGET/UNKNOWN
+	Message   string `json:"message"`   // 原始错误信息
+	Hint      string `json:"hint"`      // 按界面语言的错误码说明
+	Retryable bool   `json:"retryable"` // 是否可重试（前端据此显示重试按钮）
+}
@@ -0,0 +1,66 @@
+package models
+
+// 交易日志条目类型
+const (
+	JournalKindTrade = "trade" // 实际交易
+	JournalKindIdea  = "idea"  // 交易想法/观察
+)
+
+// 交易结果
+const (
+	JournalOutcomeWin       = "win"
+	JournalOutcomeLoss      = "loss"
+	JournalOutcomeBreakeven = "breakeven"
+	JournalOutcomeOpen      = "open" // 尚未平仓
+)
+
+// JournalEntry 交易日志条目
+type JournalEntry struct {
+	ID         string   `json:"id"`
+	Kind       string   `json:"kind"` // trade / idea
+	Symbol     string   `json:"symbol"`
+	Name       string   `json:"name"`
+	Date       string   `json:"date"`                 // 记录日期 2006-01-02
+	Side       string   `json:"side,omitempty"`       // buy / sell，想法可为空
+	EntryDate  string   `json:"entryDate,omitempty"`  // 建仓日期
+	ExitDate   string   `json:"exitDate,omitempty"`   // 平仓日期，未平仓为空
+	EntryPrice float64  `json:"entryPrice,omitempty"` // 建仓均价
+	ExitPrice  float64  `json:"exitPrice,omitempty"`  // 平仓均价
+	Shares     int64    `json:"shares,omitempty"`
+	PnL        float64  `json:"pnl,omitempty"`     // 已实现盈亏(元)，未填写时按价格与数量计算
+	Outcome    string   `json:"outcome,omitempty"` // win / loss / breakeven / open，未填写时按盈亏推断
+	Tags       []string `json:"tags"`
+	Emotion    string   `json:"emotion,omitempty"` // 交易时的情绪，如 冷静/贪婪/恐惧/冲动
+	Notes      string   `json:"notes,omitempty"`
+	CreatedAt  int64    `json:"createdAt"`
+	UpdatedAt  int64    `json:"updatedAt"`
+}
+
+// JournalFilter 交易日志筛选条件，空字段不筛选
+type JournalFilter struct {
+	Symbol    string `json:"symbol"`
+	Tag       string `json:"tag"`
+	Kind      string `json:"kind"`
+	StartDate string `json:"startDate"`
+	EndDate   string `json:"endDate"`
+}
+
+// JournalGroupStats 按标签或情绪分组的交易统计
+type JournalGroupStats struct {
+	Key            string  `json:"key"`
+	Trades         int     `json:"trades"` // 已有结果的交易数
+	Wins           int     `json:"wins"`
+	Losses         int     `json:"losses"`
+	WinRate        float64 `json:"winRate"` // 胜率(%)，不含持平
+	TotalPnL       float64 `json:"totalPnl"`
+	AvgHoldingDays float64 `json:"avgHoldingDays"` // 平均持仓天数（自然日）
+}
+
+// JournalStats 交易日志统计
+type JournalStats struct {
+	Entries   int                 `json:"entries"` // 条目总数（含想法）
+	Open      int                 `json:"open"`    // 未平仓交易数
+	Overall   JournalGroupStats   `json:"overall"`
+	ByTag     []JournalGroupStats `json:"byTag"`     // 按交易数降序
+	ByEmotion []JournalGroupStats `json:"byEmotion"` // 按交易数降序
+}
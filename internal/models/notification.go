@@ -0,0 +1,61 @@
+package models
+
+// 通知分类
+const (
+	NotificationPrice    = "price"    // 条件单、价格提醒
+	NotificationNews     = "news"     // 快讯关键词提醒
+	NotificationBriefing = "briefing" // AI 收盘简报、快讯摘要
+	NotificationLimit    = "limit"    // 自选股涨停/跌停
+	NotificationLockup   = "lockup"   // 自选股限售解禁
+	NotificationIPO      = "ipo"      // 新股申购日
+)
+
+// 涨跌停通知子类型，同时作为提示音配置的 key
+const (
+	NotificationLimitUp   = "limit_up"
+	NotificationLimitDown = "limit_down"
+)
+
+// Notification 通知中心的一条通知
+type Notification struct {
+	ID        string `json:"id"`
+	Category  string `json:"category"`       // price / news / briefing / limit / lockup
+	Kind      string `json:"kind,omitempty"` // 子类型，如 limit_up / limit_down
+	Title     string `json:"title"`
+	Body      string `json:"body"`
+	Symbol    string `json:"symbol,omitempty"` // 关联股票代码
+	Read      bool   `json:"read"`
+	Silent    bool   `json:"silent"` // 免打扰时段内产生，未弹出提醒
+	CreatedAt int64  `json:"createdAt"`
+}
+
+// NotificationConfig 通知中心配置
+type NotificationConfig struct {
+	DisabledCategories []string    `json:"disabledCategories"` // 关闭的通知分类，不记录也不提醒
+	QuietHours         QuietHours  `json:"quietHours"`         // 免打扰时段
+	MaxHistory         int         `json:"maxHistory"`         // 最多保留的历史条数，0 使用默认值
+	Sound              SoundConfig `json:"sound"`              // 提示音
+}
+
+// SoundConfig 通知提示音配置
+// Sounds 以分类或子类型为 key（price/news/briefing/limit_up/limit_down）：
+// 为空使用内置提示音（涨停上行音、跌停下行音，其余单音），"none" 不播放，其他值为自定义音频文件路径
+type SoundConfig struct {
+	Enabled bool              `json:"enabled"`
+	Volume  int               `json:"volume"` // 0-100，0 使用默认音量
+	Sounds  map[string]string `json:"sounds"`
+}
+
+// QuietHours 免打扰时段（北京时间），期间的通知仅记录不弹出；Start 晚于 End 时表示跨午夜
+type QuietHours struct {
+	Enabled bool   `json:"enabled"`
+	Start   string `json:"start"` // HH:MM，如 22:00
+	End     string `json:"end"`   // HH:MM，如 08:00
+}
+
+// NotificationFilter 通知筛选条件，空字段不筛选
+type NotificationFilter struct {
+	Category   string `json:"category"`
+	UnreadOnly bool   `json:"unreadOnly"`
+	Limit      int    `json:"limit"` // 0 表示全部
+}
@@ -0,0 +1,46 @@
+package models
+
+// 期权类型
+const (
+	OptionTypeCall = "call" // 认购
+	OptionTypePut  = "put"  // 认沽
+)
+
+// OptionQuote 期权合约行情
+type OptionQuote struct {
+	Code          string  `json:"code"` // 新浪合约代码，如 CON_OP_10007001
+	Name          string  `json:"name"` // 合约简称，如 50ETF购6月2450
+	Type          string  `json:"type"` // call / put
+	Strike        float64 `json:"strike"`
+	Price         float64 `json:"price"`
+	Bid           float64 `json:"bid"`
+	Ask           float64 `json:"ask"`
+	PreClose      float64 `json:"preClose"`
+	Change        float64 `json:"change"`
+	ChangePercent float64 `json:"changePercent"`
+	Volume        int64   `json:"volume"`       // 成交量(张)
+	OpenInterest  int64   `json:"openInterest"` // 持仓量(张)
+	IV            float64 `json:"iv"`           // 隐含波动率(%)，无法求解时为 0
+	Delta         float64 `json:"delta"`
+}
+
+// OptionStrikeRow T型报价中的一行：同一行权价的认购与认沽
+type OptionStrikeRow struct {
+	Strike float64      `json:"strike"`
+	Call   *OptionQuote `json:"call,omitempty"`
+	Put    *OptionQuote `json:"put,omitempty"`
+}
+
+// OptionChain 期权T型报价
+type OptionChain struct {
+	Underlying      string            `json:"underlying"` // 标的代码，如 sh510050
+	UnderlyingName  string            `json:"underlyingName"`
+	UnderlyingPrice float64           `json:"underlyingPrice"`
+	Month           string            `json:"month"`        // 合约月份 YYYY-MM
+	Months          []string          `json:"months"`       // 可选合约月份
+	Expiry          string            `json:"expiry"`       // 到期日 YYYY-MM-DD
+	DaysToExpiry    int               `json:"daysToExpiry"` // 剩余自然日
+	RiskFreeRate    float64           `json:"riskFreeRate"` // 计算隐含波动率使用的无风险利率(%)
+	Rows            []OptionStrikeRow `json:"rows"`         // 按行权价升序
+	UpdatedAt       int64             `json:"updatedAt"`
+}
@@ -0,0 +1,79 @@
+package models
+
+// 模拟交易委托方向与类型
+const (
+	PaperSideBuy  = "buy"
+	PaperSideSell = "sell"
+
+	PaperOrderMarket = "market" // 市价：按对手方一档价格成交
+	PaperOrderLimit  = "limit"  // 限价：对手方一档价格优于限价时成交
+)
+
+// 模拟委托状态
+const (
+	PaperStatusPending   = "pending"
+	PaperStatusFilled    = "filled"
+	PaperStatusCancelled = "cancelled"
+	PaperStatusRejected  = "rejected"
+)
+
+// PaperOrderRequest 模拟下单请求
+type PaperOrderRequest struct {
+	Symbol string  `json:"symbol"`
+	Side   string  `json:"side"`  // buy / sell
+	Type   string  `json:"type"`  // market / limit
+	Price  float64 `json:"price"` // 限价单价格
+	Shares int64   `json:"shares"`
+}
+
+// PaperOrder 模拟委托
+type PaperOrder struct {
+	ID          string  `json:"id"`
+	Symbol      string  `json:"symbol"`
+	Name        string  `json:"name"`
+	Side        string  `json:"side"`
+	Type        string  `json:"type"`
+	Price       float64 `json:"price"` // 限价单价格
+	Shares      int64   `json:"shares"`
+	Status      string  `json:"status"`
+	FilledPrice float64 `json:"filledPrice,omitempty"`
+	Fee         float64 `json:"fee,omitempty"`    // 佣金 + 印花税
+	Reason      string  `json:"reason,omitempty"` // 拒绝或撤销原因
+	CreatedAt   int64   `json:"createdAt"`
+	FilledAt    int64   `json:"filledAt,omitempty"`
+}
+
+// PaperPosition 模拟持仓
+type PaperPosition struct {
+	Symbol      string  `json:"symbol"`
+	Name        string  `json:"name"`
+	Shares      int64   `json:"shares"`
+	Available   int64   `json:"available"` // 可卖数量（T+1，当日买入不可卖）
+	CostPrice   float64 `json:"costPrice"` // 摊薄成本（含费用）
+	Price       float64 `json:"price"`
+	MarketValue float64 `json:"marketValue"`
+	PnL         float64 `json:"pnl"`
+	PnLPercent  float64 `json:"pnlPercent"`
+}
+
+// PaperDailyPnL 模拟账户每日盈亏
+type PaperDailyPnL struct {
+	Date       string  `json:"date"`
+	TotalAsset float64 `json:"totalAsset"`
+	PnL        float64 `json:"pnl"`
+	PnLPercent float64 `json:"pnlPercent"`
+}
+
+// PaperAccount 模拟账户概览
+type PaperAccount struct {
+	InitialCash     float64         `json:"initialCash"`
+	Cash            float64         `json:"cash"`   // 可用资金
+	Frozen          float64         `json:"frozen"` // 未成交买单冻结资金
+	MarketValue     float64         `json:"marketValue"`
+	TotalAsset      float64         `json:"totalAsset"`
+	TotalPnL        float64         `json:"totalPnl"`
+	DailyPnL        float64         `json:"dailyPnl"`
+	DailyPnLPercent float64         `json:"dailyPnlPercent"`
+	Positions       []PaperPosition `json:"positions"`
+	History         []PaperDailyPnL `json:"history"` // 历史每日盈亏
+}
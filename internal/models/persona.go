@@ -0,0 +1,34 @@
+package models
+
+// AgentPersona 用户自定义专家人设（独立于策略，保存在应用配置中）
+// 启用后与当前策略的专家一起参与讨论
+type AgentPersona struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Role        string   `json:"role"`
+	Avatar      string   `json:"avatar"`
+	Color       string   `json:"color"`
+	Instruction string   `json:"instruction"` // 系统提示词
+	Tools       []string `json:"tools"`
+	MCPServers  []string `json:"mcpServers"`
+	Enabled     bool     `json:"enabled"`
+	AIConfigID  string   `json:"aiConfigId"` // 偏好模型，空则用默认AI
+	CreatedAt   int64    `json:"createdAt"`
+	UpdatedAt   int64    `json:"updatedAt"`
+}
+
+// ToAgentConfig 转换为讨论引擎使用的 Agent 配置
+func (p AgentPersona) ToAgentConfig() AgentConfig {
+	return AgentConfig{
+		ID:          p.ID,
+		Name:        p.Name,
+		Role:        p.Role,
+		Avatar:      p.Avatar,
+		Color:       p.Color,
+		Instruction: p.Instruction,
+		Tools:       p.Tools,
+		MCPServers:  p.MCPServers,
+		Enabled:     p.Enabled,
+		AIConfigID:  p.AIConfigID,
+	}
+}
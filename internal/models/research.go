@@ -0,0 +1,36 @@
+package models
+
+// 评级变动
+const (
+	RatingChangeNew       = "new"       // 首次覆盖
+	RatingChangeUpgrade   = "upgrade"   // 上调
+	RatingChangeDowngrade = "downgrade" // 下调
+	RatingChangeMaintain  = "maintain"  // 维持
+)
+
+// AnalystRating 单篇研报的评级与目标价
+type AnalystRating struct {
+	Title        string  `json:"title"`
+	OrgName      string  `json:"orgName"` // 券商简称
+	Researcher   string  `json:"researcher"`
+	PublishDate  string  `json:"publishDate"`
+	Rating       string  `json:"rating"`       // 本次评级，如 买入/增持/中性
+	LastRating   string  `json:"lastRating"`   // 上次评级
+	RatingChange string  `json:"ratingChange"` // new / upgrade / downgrade / maintain
+	TargetPrice  float64 `json:"targetPrice"`  // 目标价，未给出时为 0
+	InfoCode     string  `json:"infoCode"`     // 研报唯一标识码
+}
+
+// AnalystConsensus 个股机构一致预期（近 90 天每家券商取最新一篇）
+type AnalystConsensus struct {
+	Symbol          string          `json:"symbol"`
+	Reports         []AnalystRating `json:"reports"`      // 近期研报（按发布日期倒序）
+	RatingCounts    map[string]int  `json:"ratingCounts"` // 评级 -> 券商家数
+	OrgCount        int             `json:"orgCount"`     // 参与统计的券商家数
+	AvgTargetPrice  float64         `json:"avgTargetPrice"`
+	HighTargetPrice float64         `json:"highTargetPrice"`
+	LowTargetPrice  float64         `json:"lowTargetPrice"`
+	UpgradeCount    int             `json:"upgradeCount"`
+	DowngradeCount  int             `json:"downgradeCount"`
+	UpdatedAt       int64           `json:"updatedAt"`
+}
@@ -0,0 +1,85 @@
+package models
+
+// PositionSizeRequest 仓位计算参数
+type PositionSizeRequest struct {
+	AccountSize        float64 `json:"accountSize"`                  // 账户总资金(元)
+	RiskPercent        float64 `json:"riskPercent"`                  // 单笔最大亏损占账户比例(%)
+	EntryPrice         float64 `json:"entryPrice"`                   // 计划买入价
+	StopPrice          float64 `json:"stopPrice"`                    // 止损价，需低于买入价
+	MaxPositionPercent float64 `json:"maxPositionPercent,omitempty"` // 单只最大仓位(%)，0 为不限制
+	LotSize            int64   `json:"lotSize,omitempty"`            // 每手股数，默认100
+}
+
+// 仓位上限来源
+const (
+	SizeLimitedByRisk     = "risk"     // 单笔风险
+	SizeLimitedByPosition = "position" // 单只仓位上限
+	SizeLimitedByCash     = "cash"     // 账户资金
+)
+
+// PositionSizeResult 仓位计算结果
+type PositionSizeResult struct {
+	Shares          int64    `json:"shares"` // 建议买入股数（整手）
+	Lots            int64    `json:"lots"`
+	PositionValue   float64  `json:"positionValue"`   // 买入金额(元)
+	PositionPercent float64  `json:"positionPercent"` // 占账户比例(%)
+	RiskPerShare    float64  `json:"riskPerShare"`    // 每股止损亏损(元)
+	RiskAmount      float64  `json:"riskAmount"`      // 触及止损时的亏损(元)
+	RiskPercent     float64  `json:"riskPercent"`     // 实际风险占账户比例(%)
+	LimitedBy       string   `json:"limitedBy"`       // 决定仓位的约束，见 SizeLimitedBy*
+	Warnings        []string `json:"warnings"`
+}
+
+// RiskLimits 组合风险阈值(%)，0 使用默认值
+type RiskLimits struct {
+	MaxPositionPercent float64 `json:"maxPositionPercent"` // 单只持仓占账户上限，默认20
+	MaxSectorPercent   float64 `json:"maxSectorPercent"`   // 单一行业（ETF穿透后）占账户上限，默认40
+	MaxExposurePercent float64 `json:"maxExposurePercent"` // 总持仓占账户上限，默认100
+}
+
+// HoldingRisk 单只持仓风险
+type HoldingRisk struct {
+	Symbol      string  `json:"symbol"`
+	Name        string  `json:"name"`
+	Shares      int64   `json:"shares"`
+	Price       float64 `json:"price"`
+	CostPrice   float64 `json:"costPrice"`
+	MarketValue float64 `json:"marketValue"`
+	Weight      float64 `json:"weight"` // 占账户比例(%)
+	PnL         float64 `json:"pnl"`    // 浮动盈亏(元)
+	PnLPercent  float64 `json:"pnlPercent"`
+	StopPrice   float64 `json:"stopPrice,omitempty"`
+	RiskToStop  float64 `json:"riskToStop,omitempty"` // 跌至止损价的亏损(元)
+}
+
+// 组合风险提示类型
+const (
+	RiskWarnPosition = "position" // 单只持仓超限
+	RiskWarnSector   = "sector"   // 行业集中度超限
+	RiskWarnExposure = "exposure" // 总仓位超限
+	RiskWarnNoStop   = "no_stop"  // 持仓未设置止损
+)
+
+// RiskWarning 组合风险提示
+type RiskWarning struct {
+	Kind    string  `json:"kind"`
+	Target  string  `json:"target"` // 股票代码或行业名称
+	Value   float64 `json:"value"`  // 当前比例(%)
+	Limit   float64 `json:"limit"`  // 阈值(%)
+	Message string  `json:"message"`
+}
+
+// PortfolioRisk 组合风险检查结果
+type PortfolioRisk struct {
+	AccountSize     float64          `json:"accountSize"`
+	MarketValue     float64          `json:"marketValue"`     // 持仓总市值
+	ExposurePercent float64          `json:"exposurePercent"` // 总仓位(%)
+	Cash            float64          `json:"cash"`            // 账户资金减持仓市值，可能为负
+	TopWeight       float64          `json:"topWeight"`       // 最大单只持仓占比(%)
+	HHI             float64          `json:"hhi"`             // 持仓集中度（赫芬达尔指数，0~10000）
+	RiskToStop      float64          `json:"riskToStop"`      // 全部触及止损的亏损(元)，未设止损的持仓不计入
+	Holdings        []HoldingRisk    `json:"holdings"`        // 按市值降序
+	Sectors         []SectorExposure `json:"sectors"`         // 行业占账户比例，ETF按成分股穿透
+	Limits          RiskLimits       `json:"limits"`          // 实际使用的阈值
+	Warnings        []RiskWarning    `json:"warnings"`
+}
@@ -30,5 +30,13 @@ type ChatMessage struct {
 	Round     int      `json:"round,omitempty"`     // 讨论轮次
 	MsgType   string   `json:"msgType,omitempty"`   // 消息类型: opening/opinion/summary
 	Error       string   `json:"error,omitempty"`       // 失败时的错误信息
+	ErrorCode   string   `json:"errorCode,omitempty"`   // 错误码，见 apperr.Code
 	MeetingMode string   `json:"meetingMode,omitempty"` // smart=串行, direct=独立
+	Cancelled   bool     `json:"cancelled,omitempty"`   // 讨论被停止，内容为已输出的部分
+}
+
+// ReportResult 分析报告生成结果
+type ReportResult struct {
+	Path  string `json:"path"` // 报告文件路径
+	Error string `json:"error,omitempty"`
 }
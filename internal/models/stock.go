@@ -15,8 +15,33 @@ type Stock struct {
 	High          float64 `json:"high"`
 	Low           float64 `json:"low"`
 	PreClose      float64 `json:"preClose"`
+
+	// 目标价/止损价跟踪线（仅推送行情时填充）
+	TargetPrice    float64 `json:"targetPrice,omitempty"`
+	StopPrice      float64 `json:"stopPrice,omitempty"`
+	TargetDistance float64 `json:"targetDistance,omitempty"` // 距目标价(%)，正数表示还需上涨
+	StopDistance   float64 `json:"stopDistance,omitempty"`   // 距止损价(%)，正数表示高于止损价
+
+	// 行情时间（毫秒时间戳，来自行情源）与相对本机时钟的延迟，交易时段延迟过大时 Stale 为 true
+	QuoteTime    int64 `json:"quoteTime,omitempty"`
+	StaleSeconds int64 `json:"staleSeconds,omitempty"`
+	Stale        bool  `json:"stale,omitempty"`
+
+	// 交易状态：正常交易为空；名称带 ST/*ST 标记时 ST 为 true
+	Status string `json:"status,omitempty"`
+	ST     bool   `json:"st,omitempty"`
+
+	// 涨停价/跌停价（仅推送行情时填充），新股上市初期等无涨跌幅限制时为 0
+	LimitUpPrice   float64 `json:"limitUpPrice,omitempty"`
+	LimitDownPrice float64 `json:"limitDownPrice,omitempty"`
 }
 
+// 股票交易状态
+const (
+	StockStatusSuspended = "suspended" // 停牌
+	StockStatusDelisted  = "delisted"  // 退市或退市整理
+)
+
 // KLineData K线数据
 type KLineData struct {
 	Time   string  `json:"time"`
@@ -33,6 +58,63 @@ type KLineData struct {
 	MA20 float64 `json:"ma20,omitempty"`
 }
 
+// K线缺口类型
+const (
+	KLineGapMissingDay  = "missing_day"  // 整日缺失
+	KLineGapMissingBars = "missing_bars" // 日内缺少分钟K线
+	KLineGapZeroVolume  = "zero_volume"  // 价格有波动但成交量为0
+)
+
+// KLineGap K线数据缺口
+type KLineGap struct {
+	Code   string `json:"code"`
+	Date   string `json:"date"`
+	Kind   string `json:"kind"`
+	Count  int    `json:"count"` // 缺失或异常的K线根数
+	Detail string `json:"detail,omitempty"`
+}
+
+// KLineIntegrityStatus 历史K线完整性检查状态
+type KLineIntegrityStatus struct {
+	Running  bool       `json:"running"`
+	LastRun  int64      `json:"lastRun"`  // 最近一次完成时间（毫秒）
+	Scanned  int        `json:"scanned"`  // 检查的股票日数
+	Repaired int        `json:"repaired"` // 重新拉取后修复的股票日数
+	Gaps     []KLineGap `json:"gaps"`     // 无法修复的缺口
+}
+
+// ComparisonLine 对比图中单只股票的归一化序列
+type ComparisonLine struct {
+	Code   string    `json:"code"`
+	Values []float64 `json:"values"` // 相对基准时间收盘价的涨跌幅(%)，与 Times 一一对应，停牌沿用前值
+}
+
+// ComparisonSeries 多股票相对走势对比数据
+type ComparisonSeries struct {
+	Period   string           `json:"period"`
+	Range    string           `json:"range"`
+	BaseTime string           `json:"baseTime"` // 所有股票都有数据的第一个时间点
+	Times    []string         `json:"times"`
+	Lines    []ComparisonLine `json:"lines"`
+	Failed   []string         `json:"failed,omitempty"` // 获取数据失败的代码
+}
+
+// StockStats 个股区间统计：52周高低点、历史最高价与连涨连跌天数
+type StockStats struct {
+	Code            string  `json:"code"`
+	Price           float64 `json:"price"` // 计算所用的最新收盘价
+	High52w         float64 `json:"high52w"`
+	High52wDate     string  `json:"high52wDate"`
+	Low52w          float64 `json:"low52w"`
+	Low52wDate      string  `json:"low52wDate"`
+	FromHigh52w     float64 `json:"fromHigh52w"` // 距52周最高(%)，负数表示低于高点
+	FromLow52w      float64 `json:"fromLow52w"`  // 距52周最低(%)
+	AllTimeHigh     float64 `json:"allTimeHigh"`
+	AllTimeHighDate string  `json:"allTimeHighDate"`
+	FromAllTimeHigh float64 `json:"fromAllTimeHigh"` // 距历史最高(%)
+	Streak          int     `json:"streak"`          // 连涨天数为正、连跌天数为负，平盘为0
+}
+
 // OrderBookItem 盘口单项
 type OrderBookItem struct {
 	Price   float64 `json:"price"`
@@ -58,6 +140,15 @@ type MarketIndex struct {
 	Amount        float64 `json:"amount"`        // 成交额(万元)
 }
 
+// GlobalIndex 全球主要指数（附当地交易时段状态）
+type GlobalIndex struct {
+	MarketIndex
+	Region    string `json:"region"`    // 市场：us/uk/de/hk/jp
+	Market    string `json:"market"`    // 市场名称，如 美股
+	Status    string `json:"status"`    // trading / lunch_break / closed（按当地时间，不含当地节假日）
+	LocalTime string `json:"localTime"` // 当地时间 HH:MM
+}
+
 // LongHuBangItem 龙虎榜单条数据
 type LongHuBangItem struct {
 	TradeDate     string  `json:"tradeDate"`     // 交易日期
@@ -95,3 +186,358 @@ type LongHuBangDetail struct {
 	NetAmt      float64 `json:"netAmt"`      // 净买入(元)
 	Direction   string  `json:"direction"`   // 方向: buy/sell
 }
+
+// IndexConstituent 指数成分股及贡献
+type IndexConstituent struct {
+	Symbol             string  `json:"symbol"`             // 股票代码，如 sh600519
+	Name               string  `json:"name"`               // 股票名称
+	Price              float64 `json:"price"`              // 最新价
+	PreClose           float64 `json:"preClose"`           // 昨收
+	ChangePercent      float64 `json:"changePercent"`      // 涨跌幅(%)
+	FloatCap           float64 `json:"floatCap"`           // 流通市值(元)
+	Weight             float64 `json:"weight"`             // 指数权重(%)，按流通市值近似
+	Contribution       float64 `json:"contribution"`       // 对指数涨跌幅的贡献(%)
+	ContributionPoints float64 `json:"contributionPoints"` // 对指数的贡献点数
+}
+
+// BreadthBucket 涨跌幅分布区间
+type BreadthBucket struct {
+	Label string `json:"label"` // 区间标签，如 "0~3%"
+	Count int    `json:"count"` // 个股数量
+}
+
+// MarketBreadth 全市场涨跌家数统计
+type MarketBreadth struct {
+	Advancers    int             `json:"advancers"`    // 上涨家数
+	Decliners    int             `json:"decliners"`    // 下跌家数
+	Unchanged    int             `json:"unchanged"`    // 平盘家数
+	Suspended    int             `json:"suspended"`    // 停牌/无报价家数
+	LimitUp      int             `json:"limitUp"`      // 涨停家数
+	LimitDown    int             `json:"limitDown"`    // 跌停家数
+	Distribution []BreadthBucket `json:"distribution"` // 涨跌幅分布直方图
+	UpdatedAt    int64           `json:"updatedAt"`    // 统计时间(毫秒)
+}
+
+// 热力图范围
+const (
+	HeatmapScopeMarket    = "market"    // 全市场按行业
+	HeatmapScopeWatchlist = "watchlist" // 自选股按行业
+)
+
+// HeatmapStock 热力图个股节点
+type HeatmapStock struct {
+	Symbol        string  `json:"symbol"`
+	Name          string  `json:"name"`
+	Cap           float64 `json:"cap"`           // 流通市值（节点面积）
+	ChangePercent float64 `json:"changePercent"` // 涨跌幅(%)（节点颜色）
+}
+
+// HeatmapSector 热力图行业节点
+type HeatmapSector struct {
+	Name          string         `json:"name"`
+	Cap           float64        `json:"cap"`           // 行业内全部个股流通市值合计
+	ChangePercent float64        `json:"changePercent"` // 按流通市值加权的涨跌幅(%)
+	Count         int            `json:"count"`         // 行业内个股数量
+	Stocks        []HeatmapStock `json:"stocks"`        // 按市值降序，全市场范围仅保留前若干只
+}
+
+// MarketHeatmap 涨跌幅热力图（行业 → 个股，供 treemap 渲染）
+type MarketHeatmap struct {
+	Scope     string          `json:"scope"`
+	Sectors   []HeatmapSector `json:"sectors"` // 按市值降序
+	UpdatedAt int64           `json:"updatedAt"`
+}
+
+// IPOItem 新股发行日历条目
+type IPOItem struct {
+	Code        string  `json:"code"`        // 股票代码
+	Name        string  `json:"name"`        // 股票名称
+	Market      string  `json:"market"`      // 上市板块，如 上交所主板、创业板
+	ApplyCode   string  `json:"applyCode"`   // 申购代码
+	ApplyDate   string  `json:"applyDate"`   // 申购日期 YYYY-MM-DD
+	BallotDate  string  `json:"ballotDate"`  // 中签号公布日期
+	ListingDate string  `json:"listingDate"` // 上市日期，未定时为空
+	IssuePrice  float64 `json:"issuePrice"`  // 发行价，未定价时为0
+	IssuePE     float64 `json:"issuePE"`     // 发行市盈率
+	IndustryPE  float64 `json:"industryPE"`  // 行业市盈率
+	ApplyUpper  float64 `json:"applyUpper"`  // 网上申购上限(股)
+	LotRate     float64 `json:"lotRate"`     // 网上中签率(%)
+}
+
+// HeatMinute 分时活跃分钟
+type HeatMinute struct {
+	Time          string  `json:"time"`          // 分钟时间 YYYY-MM-DD HH:MM:SS
+	Volume        int64   `json:"volume"`        // 该分钟成交量(股)
+	Amount        float64 `json:"amount"`        // 该分钟成交额(元)
+	ChangePercent float64 `json:"changePercent"` // 该分钟价格变动(%)
+	VolumeRatio   float64 `json:"volumeRatio"`   // 相对全天分钟均量的倍数
+}
+
+// OrderBookShift 盘口力量突变点
+type OrderBookShift struct {
+	Time      string  `json:"time"`      // 快照时间 HH:MM:SS
+	Price     float64 `json:"price"`     // 买一价
+	BidVolume int64   `json:"bidVolume"` // 五档买盘总量
+	AskVolume int64   `json:"askVolume"` // 五档卖盘总量
+	Imbalance float64 `json:"imbalance"` // 买卖力量差 (买-卖)/(买+卖)，范围 -1~1
+	Delta     float64 `json:"delta"`     // 相对上一快照的力量差变化
+}
+
+// SessionEvent 复盘时间线上的提醒/异动（来自通知中心）
+type SessionEvent struct {
+	Time     string `json:"time"`           // 发生时间 HH:MM:SS
+	Category string `json:"category"`       // 通知分类 price / limit / news ...
+	Kind     string `json:"kind,omitempty"` // 子类型，如 limit_up
+	Title    string `json:"title"`
+}
+
+// SessionHeatReport 收盘复盘：盘中最活跃时段、盘口突变与提醒时间线
+type SessionHeatReport struct {
+	Code            string           `json:"code"`
+	Date            string           `json:"date"`
+	HotMinutes      []HeatMinute     `json:"hotMinutes"`      // 成交最活跃的K个分钟（按时间排序）
+	OrderBookShifts []OrderBookShift `json:"orderBookShifts"` // 盘口力量变化最大的K个时点（按时间排序）
+	Timeline        []SessionEvent   `json:"timeline"`        // 当日该股的提醒与异动（按时间排序）
+	JumpPoints      []string         `json:"jumpPoints"`      // 分时回放跳转点 HH:MM，汇总以上三类时点并去重排序
+}
+
+// PriceTarget 目标价/止损价跟踪线（与持仓、提醒相互独立）
+type PriceTarget struct {
+	Symbol      string  `json:"symbol"`
+	TargetPrice float64 `json:"targetPrice"` // 目标价，0 表示未设置
+	StopPrice   float64 `json:"stopPrice"`   // 止损价，0 表示未设置
+}
+
+// TargetProximity 当日价格接近目标价/止损价的记录
+type TargetProximity struct {
+	Symbol   string  `json:"symbol"`
+	Kind     string  `json:"kind"`     // target / stop
+	Line     float64 `json:"line"`     // 目标价或止损价
+	Price    float64 `json:"price"`    // 最接近时的价格
+	Distance float64 `json:"distance"` // 最接近时的距离(%)，绝对值
+	Time     int64   `json:"time"`     // 最接近时刻(毫秒)
+}
+
+// GubaPost 股吧帖子
+type GubaPost struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	PublishTime string `json:"publishTime"` // YYYY-MM-DD HH:MM:SS
+	ClickCount  int    `json:"clickCount"`  // 阅读数
+	ReplyCount  int    `json:"replyCount"`  // 评论数
+}
+
+// GubaDailyCount 股吧每日发帖量
+type GubaDailyCount struct {
+	Date  string `json:"date"`  // YYYY-MM-DD
+	Posts int    `json:"posts"` // 当日发帖数
+}
+
+// GubaSentiment 股吧关注度（散户关注度代理指标）
+type GubaSentiment struct {
+	Code       string           `json:"code"`
+	TotalPosts int              `json:"totalPosts"` // 股吧累计帖子数
+	Trend      []GubaDailyCount `json:"trend"`      // 近期每日发帖量（按日期升序）
+	Latest     []GubaPost       `json:"latest"`     // 最新帖子
+	UpdatedAt  int64            `json:"updatedAt"`  // 抓取时间(毫秒)
+}
+
+// MarginRecord 融资融券单日数据（个股或两市汇总）
+type MarginRecord struct {
+	Date                  string  `json:"date"`                        // 交易日期 YYYY-MM-DD
+	Close                 float64 `json:"close"`                       // 收盘价（两市汇总为上证指数点位）
+	ChangePercent         float64 `json:"changePercent"`               // 涨跌幅(%)
+	FinancingBalance      float64 `json:"financingBalance"`            // 融资余额(元)
+	FinancingBuy          float64 `json:"financingBuy"`                // 融资买入额(元)
+	FinancingRepay        float64 `json:"financingRepay"`              // 融资偿还额(元)
+	FinancingNetBuy       float64 `json:"financingNetBuy"`             // 融资净买入(元)
+	SecuritiesBalance     float64 `json:"securitiesBalance"`           // 融券余额(元)
+	SecuritiesVolume      float64 `json:"securitiesVolume"`            // 融券余量(股)
+	TotalBalance          float64 `json:"totalBalance"`                // 融资融券余额(元)
+	FinancingBalanceRatio float64 `json:"financingBalanceRatio"`       // 融资余额占流通市值比(%)
+	FinancingBuyRatio     float64 `json:"financingBuyRatio,omitempty"` // 融资买入额占当日成交额比(%)
+}
+
+// Shareholder 十大股东中的一位
+type Shareholder struct {
+	Rank        int     `json:"rank"`
+	Name        string  `json:"name"`
+	Type        string  `json:"type,omitempty"`        // 股东性质，如 投资公司、证券投资基金
+	Shares      float64 `json:"shares"`                // 持股数(股)
+	Ratio       float64 `json:"ratio"`                 // 占总股本比例(%)
+	Change      string  `json:"change,omitempty"`      // 较上期变动：新进、不变或增减股数
+	ChangeRatio float64 `json:"changeRatio,omitempty"` // 持股变动比例(%)
+}
+
+// HolderCount 单个报告期的股东户数
+type HolderCount struct {
+	Date        string  `json:"date"`        // 截止日期 YYYY-MM-DD
+	Count       int64   `json:"count"`       // 股东户数
+	ChangeRatio float64 `json:"changeRatio"` // 较上期变化(%)，户数减少通常意味着筹码集中
+	AvgShares   float64 `json:"avgShares"`   // 户均持股(股)
+}
+
+// InstitutionHolding 某类机构的持仓
+type InstitutionHolding struct {
+	Type       string  `json:"type"`       // 机构类型：基金、QFII、社保、保险、券商、信托等
+	OrgCount   int     `json:"orgCount"`   // 持仓机构数
+	Shares     float64 `json:"shares"`     // 持股数(股)
+	FloatRatio float64 `json:"floatRatio"` // 占流通股比例(%)
+}
+
+// ShareholderData 个股股东数据（基本面面板）
+type ShareholderData struct {
+	Symbol           string               `json:"symbol"`
+	ReportDate       string               `json:"reportDate"`       // 十大股东报告期
+	TopHolders       []Shareholder        `json:"topHolders"`       // 十大股东
+	InstitutionDate  string               `json:"institutionDate"`  // 机构持仓报告期
+	InstitutionRatio float64              `json:"institutionRatio"` // 机构合计持股占流通股比例(%)
+	Institutions     []InstitutionHolding `json:"institutions"`     // 按机构类型拆分
+	HolderCounts     []HolderCount        `json:"holderCounts"`     // 股东户数（按报告期升序）
+	UpdatedAt        int64                `json:"updatedAt"`        // 抓取时间(毫秒)
+}
+
+// LockupExpiry 限售股解禁
+type LockupExpiry struct {
+	Symbol      string  `json:"symbol"` // 股票代码，如 sh600519
+	Name        string  `json:"name"`
+	Date        string  `json:"date"`        // 解禁日期 YYYY-MM-DD
+	DaysUntil   int     `json:"daysUntil"`   // 距今天数，0 表示当日解禁
+	Shares      float64 `json:"shares"`      // 解禁股数(股)
+	FloatRatio  float64 `json:"floatRatio"`  // 占解禁前流通股比例(%)
+	MarketValue float64 `json:"marketValue"` // 解禁市值(元)
+	Type        string  `json:"type"`        // 限售股类型，如 首发原股东限售股份、定向增发机构配售股份
+}
+
+// ETFConstituent ETF成分股（最新披露的重仓股）
+type ETFConstituent struct {
+	Symbol string  `json:"symbol"` // 股票代码，如 sh600030
+	Name   string  `json:"name"`
+	Weight float64 `json:"weight"` // 占基金净值比例(%)
+	Sector string  `json:"sector"` // 所属行业
+}
+
+// PortfolioHolding 组合持仓（穿透计算输入）
+type PortfolioHolding struct {
+	Symbol        string  `json:"symbol"`
+	Name          string  `json:"name"`
+	Shares        int64   `json:"shares"`
+	Price         float64 `json:"price"`
+	ChangePercent float64 `json:"changePercent"` // 当日涨跌幅(%)，用于收益归因
+}
+
+// StockExposure 穿透后的个股敞口
+type StockExposure struct {
+	Symbol  string   `json:"symbol"` // 股票代码；ETF未披露部分为 ETF 代码
+	Name    string   `json:"name"`
+	Sector  string   `json:"sector"`
+	Value   float64  `json:"value"`   // 敞口市值(元)
+	Weight  float64  `json:"weight"`  // 占组合比例(%)
+	Sources []string `json:"sources"` // 敞口来源（直接持有的股票或ETF代码）
+}
+
+// SectorExposure 穿透后的行业敞口
+type SectorExposure struct {
+	Sector string  `json:"sector"`
+	Value  float64 `json:"value"`
+	Weight float64 `json:"weight"` // 占组合比例(%)
+}
+
+// PortfolioExposure 组合穿透敞口
+type PortfolioExposure struct {
+	TotalValue float64          `json:"totalValue"` // 组合总市值(元)
+	ByStock    []StockExposure  `json:"byStock"`    // 按敞口市值降序
+	BySector   []SectorExposure `json:"bySector"`   // 按敞口市值降序
+}
+
+// HoldingAttribution 单个持仓的当日收益贡献
+type HoldingAttribution struct {
+	Symbol        string  `json:"symbol"`
+	Name          string  `json:"name"`
+	Value         float64 `json:"value"`         // 持仓市值(元)
+	Weight        float64 `json:"weight"`        // 占组合比例(%)
+	ChangePercent float64 `json:"changePercent"` // 当日涨跌幅(%)
+	DayPnL        float64 `json:"dayPnl"`        // 当日盈亏(元)
+	Contribution  float64 `json:"contribution"`  // 对组合收益率的贡献(百分点)
+}
+
+// SectorAttribution 行业当日收益贡献，ETF按成分股权重穿透分摊
+type SectorAttribution struct {
+	Sector       string  `json:"sector"`
+	Weight       float64 `json:"weight"` // 穿透后占组合比例(%)
+	DayPnL       float64 `json:"dayPnl"`
+	Contribution float64 `json:"contribution"`
+}
+
+// PortfolioAttribution 持仓组合当日收益归因报告
+type PortfolioAttribution struct {
+	Date          string               `json:"date"`
+	TotalValue    float64              `json:"totalValue"`
+	DayPnL        float64              `json:"dayPnl"`
+	ReturnPercent float64              `json:"returnPercent"` // 组合当日收益率(%)
+	ByHolding     []HoldingAttribution `json:"byHolding"`     // 按贡献绝对值降序
+	BySector      []SectorAttribution  `json:"bySector"`      // 按贡献绝对值降序
+	Exposure      *PortfolioExposure   `json:"exposure"`      // 穿透集中度
+	Markdown      string               `json:"markdown"`      // 报告正文
+}
+
+// MoneyFlow 个股单日资金流向（金额单位：元）
+type MoneyFlow struct {
+	Date           string  `json:"date"`
+	MainNet        float64 `json:"mainNet"`        // 主力净流入（超大单+大单）
+	SuperLargeNet  float64 `json:"superLargeNet"`  // 超大单净流入
+	LargeNet       float64 `json:"largeNet"`       // 大单净流入
+	MediumNet      float64 `json:"mediumNet"`      // 中单净流入
+	SmallNet       float64 `json:"smallNet"`       // 小单净流入
+	MainNetPercent float64 `json:"mainNetPercent"` // 主力净占比(%)
+	Close          float64 `json:"close"`
+	ChangePercent  float64 `json:"changePercent"`
+}
+
+// 利率品种分类
+const (
+	RateCategoryRepo     = "repo"     // 国债逆回购
+	RateCategoryShibor   = "shibor"   // 上海银行间同业拆放利率
+	RateCategoryTreasury = "treasury" // 国债收益率
+)
+
+// InterestRate 单个利率品种报价
+type InterestRate struct {
+	Code     string  `json:"code"`     // 品种代码，如 sh204001、shibor_on、cn10y
+	Name     string  `json:"name"`     // 名称，如 GC001、隔夜、10年期国债
+	Category string  `json:"category"` // repo / shibor / treasury
+	Rate     float64 `json:"rate"`     // 利率/收益率(%)
+	ChangeBP float64 `json:"changeBp"` // 较上一交易日变动(基点)
+	Date     string  `json:"date"`     // 报价日期 YYYY-MM-DD
+}
+
+// InterestRates 资金面利率汇总
+type InterestRates struct {
+	Items     []InterestRate `json:"items"`     // 按逆回购、SHIBOR、国债收益率顺序
+	UpdatedAt int64          `json:"updatedAt"` // 更新时间(毫秒)
+}
+
+// FuturesQuote 期货行情（股指期货附带与现货指数的基差）
+type FuturesQuote struct {
+	Symbol        string  `json:"symbol"`        // 新浪期货代码，如 nf_IF0（主连）、nf_IF2406
+	Name          string  `json:"name"`          // 合约名称
+	Product       string  `json:"product"`       // 品种代码，如 IF、RB
+	Exchange      string  `json:"exchange"`      // 交易所：CFFEX/SHFE/INE/DCE/CZCE
+	Price         float64 `json:"price"`         // 最新价
+	Open          float64 `json:"open"`          // 开盘价
+	High          float64 `json:"high"`          // 最高价
+	Low           float64 `json:"low"`           // 最低价
+	PreClose      float64 `json:"preClose"`      // 昨收
+	PreSettle     float64 `json:"preSettle"`     // 昨结算
+	Change        float64 `json:"change"`        // 涨跌（相对昨结算）
+	ChangePercent float64 `json:"changePercent"` // 涨跌幅(%)（相对昨结算）
+	Volume        int64   `json:"volume"`        // 成交量(手)
+	OpenInterest  float64 `json:"openInterest"`  // 持仓量(手)
+	Date          string  `json:"date"`          // 行情日期 YYYY-MM-DD
+	Time          string  `json:"time"`          // 行情时间 HH:MM:SS
+
+	SpotCode     string  `json:"spotCode,omitempty"`     // 对应现货指数，如 sh000300
+	SpotPrice    float64 `json:"spotPrice,omitempty"`    // 现货指数点位
+	Basis        float64 `json:"basis,omitempty"`        // 基差 = 期货 - 现货
+	BasisPercent float64 `json:"basisPercent,omitempty"` // 基差率(%)
+}
@@ -0,0 +1,38 @@
+package models
+
+// ToolSandboxConfig 工具执行沙箱配置
+type ToolSandboxConfig struct {
+	DefaultTimeout   int          `json:"defaultTimeout"`   // 默认超时(秒)，0 使用 30 秒
+	DefaultRateLimit int          `json:"defaultRateLimit"` // 单个工具每分钟最多调用次数，0 表示不限
+	Policies         []ToolPolicy `json:"policies"`         // 按工具覆盖默认值
+	AuditDays        int          `json:"auditDays"`        // 审计日志保留天数，0 使用 30 天
+}
+
+// ToolPolicy 单个工具的执行策略
+type ToolPolicy struct {
+	Tool      string `json:"tool"`
+	Timeout   int    `json:"timeout"`   // 超时(秒)，0 使用默认值
+	RateLimit int    `json:"rateLimit"` // 每分钟最多调用次数，0 使用默认值
+}
+
+// 工具调用审计状态
+const (
+	ToolCallOK          = "ok"
+	ToolCallError       = "error"
+	ToolCallTimeout     = "timeout"
+	ToolCallInvalid     = "invalid" // 参数未通过 schema 校验
+	ToolCallRateLimited = "rate_limited"
+)
+
+// ToolAuditEntry 工具调用审计记录
+type ToolAuditEntry struct {
+	Time     int64  `json:"time"` // 调用时间(毫秒)
+	Tool     string `json:"tool"`
+	Agent    string `json:"agent"`
+	Session  string `json:"session,omitempty"` // 会话（股票代码）
+	Args     string `json:"args"`              // 调用参数(JSON)
+	Result   string `json:"result,omitempty"`  // 返回结果(JSON)，过长时截断
+	Error    string `json:"error,omitempty"`
+	Status   string `json:"status"`
+	Duration int64  `json:"duration"` // 耗时(毫秒)
+}
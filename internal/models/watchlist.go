@@ -0,0 +1,50 @@
+package models
+
+// 内置自选分组 ID
+const (
+	WatchlistGroupDefault = "default" // 自选（包含全部自选股）
+	WatchlistGroupHolding = "holding" // 持仓
+	WatchlistGroupWatch   = "watch"   // 观察
+)
+
+// WatchlistGroup 自选股分组，Symbols 顺序即展示顺序（支持拖拽排序）
+type WatchlistGroup struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Symbols []string `json:"symbols"`
+	BuiltIn bool     `json:"builtIn,omitempty"` // 内置分组不可删除
+}
+
+// StockMeta 自选股标签与备注
+type StockMeta struct {
+	Tags []string `json:"tags,omitempty"`
+	Note string   `json:"note,omitempty"`
+}
+
+// WatchlistGroups 自选分组数据（watchlist_groups.json）
+type WatchlistGroups struct {
+	Active string               `json:"active"` // 当前分组，推送订阅跟随该分组
+	Groups []WatchlistGroup     `json:"groups"`
+	Meta   map[string]StockMeta `json:"meta"` // symbol -> 标签与备注
+}
+
+// WatchlistImportResult 自选股导入结果
+type WatchlistImportResult struct {
+	Imported []string `json:"imported"`          // 新加入自选的代码
+	Skipped  []string `json:"skipped"`           // 已在自选中的代码
+	Invalid  []string `json:"invalid,omitempty"` // 无法识别的条目
+	Error    string   `json:"error,omitempty"`
+}
+
+// SettingsBundle 设置备份（配置、自选股、分组、目标价与条件单），用于迁移到其他电脑
+// 导出时清空 API Key、令牌与密码，导入时保留本机已有的密钥
+type SettingsBundle struct {
+	Version         int              `json:"version"`
+	ExportedAt      int64            `json:"exportedAt"` // 导出时间(ms)
+	AppVersion      string           `json:"appVersion"`
+	Config          *AppConfig       `json:"config"`
+	Watchlist       []Stock          `json:"watchlist"`
+	Groups          WatchlistGroups  `json:"groups"`
+	PriceTargets    []PriceTarget    `json:"priceTargets"`
+	ConditionOrders []ConditionOrder `json:"conditionOrders"` // 条件单（提醒规则），旧版备份为空
+}
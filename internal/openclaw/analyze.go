@@ -41,7 +41,7 @@ func (s *Server) handleAnalyze(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 获取股票实时数据
-	stock, err := s.stockResolver(req.StockCode)
+	stock, err := s.stockResolver(r.Context(), req.StockCode)
 	if err != nil || stock == nil {
 		log.Error("获取股票数据失败: %s, %v", req.StockCode, err)
 		writeJSON(w, http.StatusBadRequest, AnalyzeResponse{Error: "failed to get stock data"})
@@ -16,7 +16,7 @@ import (
 var log = logger.New("OpenClaw")
 
 // StockResolver 根据股票代码获取实时数据
-type StockResolver func(code string) (*models.Stock, error)
+type StockResolver func(ctx context.Context, code string) (*models.Stock, error)
 
 // Server OpenClaw HTTP 服务
 type Server struct {
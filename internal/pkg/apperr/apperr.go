@@ -0,0 +1,100 @@
+// Package apperr 定义前端可识别的结构化错误码
+// 服务层用 New/Wrap 标注错误类别，App 层通过 CodeOf 提取错误码推送给前端，
+// 前端据此展示本地化提示和重试按钮
+package apperr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+)
+
+// Code 错误码
+type Code string
+
+const (
+	CodeNetwork       Code = "NETWORK"        // 网络错误、超时、上游不可用
+	CodeRateLimited   Code = "RATE_LIMITED"   // 上游限流
+	CodeProviderParse Code = "PROVIDER_PARSE" // 上游返回数据无法解析（接口变更）
+	CodeAuth          Code = "AUTH"           // 鉴权失败（API Key、引擎令牌）
+	CodeBudget        Code = "BUDGET"         // AI 费用超出预算
+	CodeUnknown       Code = "UNKNOWN"
+)
+
+// Retryable 该类错误稍后重试是否可能成功
+func (c Code) Retryable() bool {
+	return c == CodeNetwork || c == CodeRateLimited
+}
+
+// Error 带错误码的错误
+type Error struct {
+	Code Code
+	Msg  string
+	Err  error
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.Err == nil:
+		return e.Msg
+	case e.Msg == "":
+		return e.Err.Error()
+	default:
+		return e.Msg + ": " + e.Err.Error()
+	}
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// New 创建带错误码的错误
+func New(code Code, msg string) *Error {
+	return &Error{Code: code, Msg: msg}
+}
+
+// Wrap 为已有错误标注错误码，err 为 nil 时返回 nil
+func Wrap(code Code, err error, msg string) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Code: code, Msg: msg, Err: err}
+}
+
+// FromHTTPStatus 按 HTTP 状态码标注错误
+func FromHTTPStatus(status int, err error) error {
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return Wrap(CodeAuth, err, "")
+	case status == http.StatusTooManyRequests:
+		return Wrap(CodeRateLimited, err, "")
+	case status >= 500:
+		return Wrap(CodeNetwork, err, "")
+	default:
+		return err
+	}
+}
+
+// CodeOf 提取错误码，未标注的错误按类型推断
+func CodeOf(err error) Code {
+	if err == nil {
+		return ""
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+
+	var netErr net.Error
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr):
+		return CodeNetwork
+	case errors.As(err, &syntaxErr), errors.As(err, &typeErr):
+		return CodeProviderParse
+	}
+	return CodeUnknown
+}
@@ -0,0 +1,36 @@
+package apperr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCodeOf(t *testing.T) {
+	var syntaxErr error = json.Unmarshal([]byte("{"), &struct{}{})
+
+	tests := []struct {
+		name string
+		err  error
+		want Code
+	}{
+		{"nil", nil, ""},
+		{"wrapped", fmt.Errorf("外层: %w", New(CodeBudget, "超预算")), CodeBudget},
+		{"http 401", FromHTTPStatus(401, errors.New("HTTP 401")), CodeAuth},
+		{"http 429", FromHTTPStatus(429, errors.New("HTTP 429")), CodeRateLimited},
+		{"http 400", FromHTTPStatus(400, errors.New("HTTP 400")), CodeUnknown},
+		{"deadline", fmt.Errorf("请求失败: %w", context.DeadlineExceeded), CodeNetwork},
+		{"json", syntaxErr, CodeProviderParse},
+		{"plain", errors.New("boom"), CodeUnknown},
+	}
+	for _, tt := range tests {
+		if got := CodeOf(tt.err); got != tt.want {
+			t.Errorf("%s: CodeOf = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+	if !CodeRateLimited.Retryable() || CodeAuth.Retryable() {
+		t.Error("Retryable 判断错误")
+	}
+}
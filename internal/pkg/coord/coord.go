@@ -0,0 +1,296 @@
+// Package coord 提供同机多实例协调
+// 通过本地 unix socket 选主：成功监听的进程成为 leader 负责轮询上游，
+// 其余进程作为 follower 连接 leader 接收共享快照；leader 退出后 follower 自动接管
+package coord
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/logger"
+)
+
+var log = logger.New("coord")
+
+// Role 实例角色
+type Role string
+
+const (
+	RoleLeader   Role = "leader"
+	RoleFollower Role = "follower"
+)
+
+// writeTimeout 向单个 follower 写入快照的超时，慢消费者会被断开
+const writeTimeout = time.Second
+
+// Snapshot leader 广播的数据快照
+type Snapshot struct {
+	Event string          `json:"event"`
+	Data  json.RawMessage `json:"data"`
+}
+
+// Coordinator 多实例协调器
+type Coordinator struct {
+	path string
+
+	mu           sync.Mutex
+	role         Role
+	ln           net.Listener
+	followers    map[net.Conn]struct{}
+	last         map[string]json.RawMessage // 各事件最近一次快照，新 follower 连接时补发
+	onSnapshot   func(Snapshot)
+	onRoleChange func(Role)
+
+	stopChan chan struct{}
+	stopped  bool
+}
+
+// New 创建协调器，path 为 unix socket 路径
+func New(path string) *Coordinator {
+	return &Coordinator{
+		path:      path,
+		followers: make(map[net.Conn]struct{}),
+		last:      make(map[string]json.RawMessage),
+		stopChan:  make(chan struct{}),
+	}
+}
+
+// OnSnapshot 设置 follower 收到快照时的回调
+func (c *Coordinator) OnSnapshot(fn func(Snapshot)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onSnapshot = fn
+}
+
+// OnRoleChange 设置角色变化回调
+func (c *Coordinator) OnRoleChange(fn func(Role)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onRoleChange = fn
+}
+
+// Role 当前角色，选主完成前为空
+func (c *Coordinator) Role() Role {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.role
+}
+
+// IsFollower 是否为 follower（follower 不应轮询共享数据）
+func (c *Coordinator) IsFollower() bool {
+	return c.Role() == RoleFollower
+}
+
+// Start 开始选主，阻塞直到完成首次选主后返回，之后在后台维持角色
+func (c *Coordinator) Start() {
+	first := make(chan struct{})
+	go c.run(first)
+	<-first
+}
+
+// Stop 停止协调，leader 会关闭 socket 让 follower 接管
+func (c *Coordinator) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stopped {
+		return
+	}
+	c.stopped = true
+	close(c.stopChan)
+	if c.ln != nil {
+		c.ln.Close()
+	}
+	for conn := range c.followers {
+		conn.Close()
+	}
+}
+
+// Publish leader 广播快照，follower 调用时忽略
+func (c *Coordinator) Publish(event string, data any) {
+	c.mu.Lock()
+	if c.role != RoleLeader {
+		c.mu.Unlock()
+		return
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		c.mu.Unlock()
+		return
+	}
+	c.last[event] = raw
+	conns := make([]net.Conn, 0, len(c.followers))
+	for conn := range c.followers {
+		conns = append(conns, conn)
+	}
+	c.mu.Unlock()
+
+	for _, conn := range conns {
+		if err := writeSnapshot(conn, Snapshot{Event: event, Data: raw}); err != nil {
+			c.dropFollower(conn)
+		}
+	}
+}
+
+// run 选主循环：能监听则为 leader，否则作为 follower 直到 leader 断开后重新选主
+func (c *Coordinator) run(first chan struct{}) {
+	var once sync.Once
+	signal := func() { once.Do(func() { close(first) }) }
+	defer signal()
+
+	for {
+		if ln, err := c.listen(); err == nil {
+			c.becomeLeader(ln)
+			signal()
+			c.serve(ln)
+			return // leader 仅在停止时退出
+		}
+
+		conn, err := net.Dial("unix", c.path)
+		if err == nil {
+			c.setRole(RoleFollower)
+			signal()
+			c.follow(conn)
+		}
+
+		// leader 断开或暂时无法连接，随机等待后重新选主，避免多个 follower 同时抢占
+		select {
+		case <-c.stopChan:
+			return
+		case <-time.After(time.Duration(300+rand.IntN(700)) * time.Millisecond):
+		}
+	}
+}
+
+// listen 尝试监听 socket；socket 文件残留（leader 异常退出）时清理后重试
+// 探测、清理与监听在 socket 旁的锁文件上加独占锁串行执行，
+// 避免两个实例同时判定 socket 残留，后者删除前者刚创建的 socket 导致出现两个 leader
+func (c *Coordinator) listen() (net.Listener, error) {
+	lock, err := os.OpenFile(c.path+".lock", os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+	defer lock.Close()
+	if err := lockFile(lock); err != nil {
+		return nil, err
+	}
+	defer unlockFile(lock)
+
+	ln, err := net.Listen("unix", c.path)
+	if err == nil {
+		return ln, nil
+	}
+	if conn, dialErr := net.Dial("unix", c.path); dialErr == nil {
+		conn.Close()
+		return nil, err // leader 存活
+	}
+	if rmErr := os.Remove(c.path); rmErr != nil && !errors.Is(rmErr, os.ErrNotExist) {
+		return nil, rmErr
+	}
+	return net.Listen("unix", c.path)
+}
+
+// becomeLeader 切换为 leader
+func (c *Coordinator) becomeLeader(ln net.Listener) {
+	c.mu.Lock()
+	c.ln = ln
+	c.mu.Unlock()
+	c.setRole(RoleLeader)
+}
+
+// setRole 更新角色并通知
+func (c *Coordinator) setRole(role Role) {
+	c.mu.Lock()
+	changed := c.role != role
+	c.role = role
+	fn := c.onRoleChange
+	c.mu.Unlock()
+
+	if changed {
+		log.Info("多实例协调: 当前实例为 %s", role)
+		if fn != nil {
+			fn(role)
+		}
+	}
+}
+
+// serve leader 接受 follower 连接，并补发最近快照
+func (c *Coordinator) serve(ln net.Listener) {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+
+		c.mu.Lock()
+		if c.stopped {
+			c.mu.Unlock()
+			conn.Close()
+			return
+		}
+		c.followers[conn] = struct{}{}
+		backlog := make([]Snapshot, 0, len(c.last))
+		for event, raw := range c.last {
+			backlog = append(backlog, Snapshot{Event: event, Data: raw})
+		}
+		c.mu.Unlock()
+
+		for _, s := range backlog {
+			if err := writeSnapshot(conn, s); err != nil {
+				c.dropFollower(conn)
+				break
+			}
+		}
+	}
+}
+
+// follow follower 持续接收快照，连接断开时返回
+func (c *Coordinator) follow(conn net.Conn) {
+	defer conn.Close()
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-c.stopChan:
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	dec := json.NewDecoder(conn)
+	for {
+		var s Snapshot
+		if err := dec.Decode(&s); err != nil {
+			return
+		}
+		c.mu.Lock()
+		fn := c.onSnapshot
+		c.mu.Unlock()
+		if fn != nil {
+			fn(s)
+		}
+	}
+}
+
+// dropFollower 断开 follower
+func (c *Coordinator) dropFollower(conn net.Conn) {
+	c.mu.Lock()
+	delete(c.followers, conn)
+	c.mu.Unlock()
+	conn.Close()
+}
+
+// writeSnapshot 写入一条快照（JSON 行）
+func writeSnapshot(conn net.Conn, s Snapshot) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	_, err = conn.Write(append(data, '\n'))
+	return err
+}
@@ -0,0 +1,93 @@
+package coord
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestLeaderElectionAndFailover 测试选主、快照共享与 leader 退出后接管
+func TestLeaderElectionAndFailover(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jcp.sock")
+
+	leader := New(path)
+	leader.Start()
+	defer leader.Stop()
+	if leader.Role() != RoleLeader {
+		t.Fatalf("首个实例应为 leader, got %q", leader.Role())
+	}
+	leader.Publish("market:indices:update", []int{1, 2})
+
+	follower := New(path)
+	received := make(chan Snapshot, 4)
+	follower.OnSnapshot(func(s Snapshot) { received <- s })
+	follower.Start()
+	defer follower.Stop()
+	if follower.Role() != RoleFollower {
+		t.Fatalf("第二个实例应为 follower, got %q", follower.Role())
+	}
+
+	// 连接时补发最近快照
+	select {
+	case s := <-received:
+		if s.Event != "market:indices:update" || string(s.Data) != "[1,2]" {
+			t.Errorf("snapshot = %s %s", s.Event, s.Data)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("follower 未收到补发快照")
+	}
+
+	// follower 发布无效
+	follower.Publish("x", 1)
+
+	// leader 退出后 follower 接管
+	leader.Stop()
+	deadline := time.Now().Add(5 * time.Second)
+	for follower.Role() != RoleLeader {
+		if time.Now().After(deadline) {
+			t.Fatal("leader 退出后 follower 未接管")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// TestListenStaleSocketConcurrent 测试多个实例同时清理残留 socket 时只有一个成为 leader
+func TestListenStaleSocketConcurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jcp.sock")
+	for round := 0; round < 20; round++ {
+		// 模拟 leader 异常退出留下的 socket 文件
+		os.Remove(path)
+		stale, err := net.Listen("unix", path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stale.(*net.UnixListener).SetUnlinkOnClose(false)
+		stale.Close()
+
+		var (
+			wg        sync.WaitGroup
+			mu        sync.Mutex
+			listeners []net.Listener
+		)
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if ln, err := New(path).listen(); err == nil {
+					mu.Lock()
+					listeners = append(listeners, ln)
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+		if len(listeners) != 1 {
+			t.Fatalf("round %d: %d 个实例同时成为 leader", round, len(listeners))
+		}
+		listeners[0].(*net.UnixListener).SetUnlinkOnClose(false)
+		listeners[0].Close()
+	}
+}
@@ -0,0 +1,18 @@
+//go:build !windows
+
+package coord
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile 对文件加独占锁，阻塞直到获得锁
+func lockFile(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+}
+
+// unlockFile 释放文件锁
+func unlockFile(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
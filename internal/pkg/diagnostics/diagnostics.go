@@ -0,0 +1,195 @@
+// Package diagnostics 生成诊断包：收集日志、脱敏配置、版本与系统信息、协程堆栈，打包为 zip 便于反馈问题
+package diagnostics
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/logger"
+)
+
+const (
+	maxLogFiles = 3                 // 附带最近的日志文件数
+	maxLogBytes = 10 << 20          // 附带日志文件的总大小上限
+	bundlePerm  = os.FileMode(0644) // 诊断包文件权限
+)
+
+// Options 诊断包内容
+type Options struct {
+	DataDir string // 数据目录，诊断包写入 diagnostics 子目录，并从 logs 子目录读取日志
+	Version string
+	Config  any    // 应用配置，写入前脱敏
+	Reason  string // 生成原因，如 panic 信息；为空表示手动生成
+	Stack   []byte // panic 时的调用栈
+}
+
+// Info 诊断包中的运行环境信息
+type Info struct {
+	Version      string `json:"version"`
+	Reason       string `json:"reason"`
+	Time         string `json:"time"`
+	GoVersion    string `json:"goVersion"`
+	OS           string `json:"os"`
+	Arch         string `json:"arch"`
+	NumCPU       int    `json:"numCpu"`
+	NumGoroutine int    `json:"numGoroutine"`
+	HeapAllocMB  uint64 `json:"heapAllocMB"`
+	SysMB        uint64 `json:"sysMB"`
+}
+
+// WriteBundle 生成诊断包，返回 zip 文件路径
+// 单项收集失败不影响其他内容，失败原因写入 errors.txt
+func WriteBundle(opts Options) (string, error) {
+	now := time.Now()
+	dir := filepath.Join(opts.DataDir, "diagnostics")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("创建诊断目录失败: %w", err)
+	}
+	path := filepath.Join(dir, "jcp-diagnostics-"+now.Format("20060102-150405")+".zip")
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, bundlePerm)
+	if err != nil {
+		return "", fmt.Errorf("创建诊断包失败: %w", err)
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	var errs []string
+	add := func(name string, write func(io.Writer) error) {
+		w, err := zw.Create(name)
+		if err == nil {
+			err = write(w)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", name, err))
+		}
+	}
+
+	add("info.json", func(w io.Writer) error { return writeJSON(w, collectInfo(opts, now)) })
+	if opts.Reason != "" || len(opts.Stack) > 0 {
+		add("panic.txt", func(w io.Writer) error {
+			_, err := fmt.Fprintf(w, "%s\n\n%s", opts.Reason, opts.Stack)
+			return err
+		})
+	}
+	add("goroutines.txt", func(w io.Writer) error { return pprof.Lookup("goroutine").WriteTo(w, 2) })
+	add("recent.log", writeRecentLogs)
+	if opts.Config != nil {
+		add("config.json", func(w io.Writer) error {
+			cfg, err := RedactConfig(opts.Config)
+			if err != nil {
+				return err
+			}
+			return writeJSON(w, cfg)
+		})
+	}
+	for _, logFile := range recentLogFiles(filepath.Join(opts.DataDir, "logs")) {
+		add("logs/"+filepath.Base(logFile), func(w io.Writer) error { return copyFile(w, logFile) })
+	}
+	if len(errs) > 0 {
+		add("errors.txt", func(w io.Writer) error {
+			_, err := io.WriteString(w, strings.Join(errs, "\n")+"\n")
+			return err
+		})
+	}
+
+	if err := zw.Close(); err != nil {
+		os.Remove(path)
+		return "", fmt.Errorf("写入诊断包失败: %w", err)
+	}
+	return path, nil
+}
+
+// collectInfo 收集版本与系统信息
+func collectInfo(opts Options, now time.Time) Info {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	reason := opts.Reason
+	if reason == "" {
+		reason = "manual"
+	}
+	return Info{
+		Version:      opts.Version,
+		Reason:       reason,
+		Time:         now.Format(time.RFC3339),
+		GoVersion:    runtime.Version(),
+		OS:           runtime.GOOS,
+		Arch:         runtime.GOARCH,
+		NumCPU:       runtime.NumCPU(),
+		NumGoroutine: runtime.NumGoroutine(),
+		HeapAllocMB:  mem.HeapAlloc >> 20,
+		SysMB:        mem.Sys >> 20,
+	}
+}
+
+// writeRecentLogs 写入内存中的最近日志（包含尚未落盘或被级别过滤前的上下文）
+func writeRecentLogs(w io.Writer) error {
+	for _, e := range logger.Recent(logger.DEBUG, "", 0) {
+		ts := time.UnixMilli(e.Time).Format("2006-01-02 15:04:05.000")
+		if _, err := fmt.Fprintf(w, "%s [%s] %s: %s\n", e.Level, ts, e.Module, e.Message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recentLogFiles 按修改时间取最近的日志文件，总大小不超过上限
+func recentLogFiles(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	type logFile struct {
+		path string
+		info os.FileInfo
+	}
+	var files []logFile
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".log") {
+			continue
+		}
+		if info, err := entry.Info(); err == nil {
+			files = append(files, logFile{filepath.Join(dir, entry.Name()), info})
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].info.ModTime().After(files[j].info.ModTime())
+	})
+
+	var result []string
+	var total int64
+	for _, f := range files {
+		if len(result) >= maxLogFiles || total+f.info.Size() > maxLogBytes {
+			break
+		}
+		result = append(result, f.path)
+		total += f.info.Size()
+	}
+	return result
+}
+
+// writeJSON 写入缩进 JSON
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// copyFile 复制文件内容
+func copyFile(w io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
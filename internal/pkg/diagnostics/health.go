@@ -0,0 +1,122 @@
+package diagnostics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// 检查结果状态
+const (
+	StatusOK   = "ok"
+	StatusWarn = "warn"
+	StatusFail = "fail"
+	StatusSkip = "skip"
+)
+
+// 检查类别
+const (
+	CategoryNetwork = "network"
+	CategoryProxy   = "proxy"
+	CategoryStorage = "storage"
+	CategoryLLM     = "llm"
+)
+
+// Check 单项检查结果
+type Check struct {
+	Category  string `json:"category"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Message   string `json:"message,omitempty"`
+	LatencyMs int64  `json:"latencyMs,omitempty"`
+}
+
+// Report 健康检查报告
+type Report struct {
+	Time     int64   `json:"time"`
+	OK       bool    `json:"ok"`       // 没有失败项
+	FirstRun bool    `json:"firstRun"` // 尚未完成初始配置，前端据此展示设置向导
+	Checks   []Check `json:"checks"`
+}
+
+// NewReport 汇总检查结果
+func NewReport(firstRun bool, checks []Check) Report {
+	r := Report{Time: time.Now().UnixMilli(), OK: true, FirstRun: firstRun, Checks: checks}
+	for _, c := range checks {
+		if c.Status == StatusFail {
+			r.OK = false
+			break
+		}
+	}
+	return r
+}
+
+// Endpoint 需要检查连通性的上游地址
+type Endpoint struct {
+	Name string
+	URL  string
+}
+
+// CheckEndpoints 并发检查上游连通性，收到任何 HTTP 响应即视为可达，5xx 记为警告
+func CheckEndpoints(ctx context.Context, client *http.Client, endpoints []Endpoint) []Check {
+	checks := make([]Check, len(endpoints))
+	var wg sync.WaitGroup
+	for i, ep := range endpoints {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checks[i] = checkEndpoint(ctx, client, ep)
+		}()
+	}
+	wg.Wait()
+	return checks
+}
+
+// checkEndpoint 检查单个上游
+func checkEndpoint(ctx context.Context, client *http.Client, ep Endpoint) Check {
+	c := Check{Category: CategoryNetwork, Name: ep.Name}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep.URL, nil)
+	if err != nil {
+		c.Status, c.Message = StatusFail, err.Error()
+		return c
+	}
+	start := time.Now()
+	resp, err := client.Do(req)
+	c.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		c.Status, c.Message = StatusFail, err.Error()
+		return c
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 500 {
+		c.Status, c.Message = StatusWarn, fmt.Sprintf("HTTP %d", resp.StatusCode)
+		return c
+	}
+	c.Status = StatusOK
+	return c
+}
+
+// CheckWritable 检查目录可创建并可写入
+func CheckWritable(name, dir string) Check {
+	c := Check{Category: CategoryStorage, Name: name}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		c.Status, c.Message = StatusFail, err.Error()
+		return c
+	}
+	f, err := os.CreateTemp(dir, ".write-test-*")
+	if err == nil {
+		_, err = f.Write([]byte("ok"))
+		f.Close()
+		os.Remove(f.Name())
+	}
+	if err != nil {
+		c.Status, c.Message = StatusFail, err.Error()
+		return c
+	}
+	c.Status, c.Message = StatusOK, filepath.Clean(dir)
+	return c
+}
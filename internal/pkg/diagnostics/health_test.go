@@ -0,0 +1,41 @@
+package diagnostics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestHealthChecks(t *testing.T) {
+	ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ok.Close()
+	bad := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer bad.Close()
+
+	checks := CheckEndpoints(context.Background(), http.DefaultClient, []Endpoint{
+		{Name: "ok", URL: ok.URL},
+		{Name: "bad", URL: bad.URL},
+		{Name: "down", URL: "http://127.0.0.1:1"},
+	})
+	want := []string{StatusOK, StatusWarn, StatusFail}
+	for i, c := range checks {
+		if c.Status != want[i] {
+			t.Errorf("%s: status = %s, want %s", c.Name, c.Status, want[i])
+		}
+	}
+
+	if c := CheckWritable("data", filepath.Join(t.TempDir(), "a", "b")); c.Status != StatusOK {
+		t.Errorf("临时目录应可写: %+v", c)
+	}
+
+	if r := NewReport(false, checks); r.OK {
+		t.Error("存在失败项时 OK 应为 false")
+	}
+	if r := NewReport(true, checks[:2]); !r.OK || !r.FirstRun {
+		t.Errorf("report = %+v", r)
+	}
+}
@@ -0,0 +1,221 @@
+package diagnostics
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+// redacted 诊断包中的脱敏占位
+const redacted = "***"
+
+// secretKeys 需要脱敏的配置字段（小写，按包含匹配）
+var secretKeys = []string{"apikey", "token", "password", "secret", "credential", "authorization"}
+
+// RedactConfig 将配置转为通用 JSON 结构并隐去密钥、令牌、密码，以及 URL 中的账号与查询参数
+func RedactConfig(cfg any) (any, error) {
+	v, err := toJSONValue(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return walkStrings("", v, func(key, s string) string {
+		if isSecretKey(key) {
+			return redacted
+		}
+		return redactURL(s)
+	}), nil
+}
+
+// StripSecrets 清空配置中的密钥、令牌、密码与 URL 中的密码，用于导出设置
+// 与 RedactConfig 使用相同的敏感字段规则；URL 保留账号，便于导入时与本机配置对应
+func StripSecrets[T any](cfg *T) (*T, error) {
+	v, err := toJSONValue(cfg)
+	if err != nil {
+		return nil, err
+	}
+	v = walkStrings("", v, func(key, s string) string {
+		if isSecretKey(key) {
+			return ""
+		}
+		return stripURLPassword(s)
+	})
+	return fromJSONValue[T](v)
+}
+
+// RestoreSecrets 将 StripSecrets 清空的字段按路径用 local 中的值补回
+// 数组元素带 id 时按 id 对应，否则按下标；同一对象的 username 不同时不补回密码；
+// URL 仅缺少密码且账号、主机一致时沿用本机地址
+func RestoreSecrets[T any](cfg, local *T) (*T, error) {
+	if local == nil {
+		return cfg, nil
+	}
+	v, err := toJSONValue(cfg)
+	if err != nil {
+		return nil, err
+	}
+	lv, err := toJSONValue(local)
+	if err != nil {
+		return nil, err
+	}
+	return fromJSONValue[T](restoreValue("", v, lv, true))
+}
+
+// toJSONValue 转为通用 JSON 结构
+func toJSONValue(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// fromJSONValue 通用 JSON 结构转回具体类型
+func fromJSONValue[T any](v any) (*T, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out T
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// walkStrings 递归替换非空字符串，fn 接收所属字段名（数组元素沿用数组的字段名）
+func walkStrings(key string, v any, fn func(key, s string) string) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, item := range val {
+			val[k] = walkStrings(k, item, fn)
+		}
+		return val
+	case []any:
+		for i, item := range val {
+			val[i] = walkStrings(key, item, fn)
+		}
+		return val
+	case string:
+		if val == "" {
+			return val
+		}
+		return fn(key, val)
+	}
+	return v
+}
+
+// restoreValue 递归补回被清空的密钥，allowSecret 为 false 时不补回敏感字段
+func restoreValue(key string, v, local any, allowSecret bool) any {
+	switch val := v.(type) {
+	case map[string]any:
+		lm, _ := local.(map[string]any)
+		if lm == nil {
+			return val
+		}
+		sameAccount := val["username"] == lm["username"]
+		for k, item := range val {
+			val[k] = restoreValue(k, item, lm[k], sameAccount)
+		}
+		return val
+	case []any:
+		la, _ := local.([]any)
+		byID := make(map[any]any, len(la))
+		for _, item := range la {
+			if m, ok := item.(map[string]any); ok && m["id"] != nil {
+				byID[m["id"]] = item
+			}
+		}
+		for i, item := range val {
+			var match any
+			if m, ok := item.(map[string]any); ok && m["id"] != nil {
+				match = byID[m["id"]]
+			} else if i < len(la) {
+				match = la[i]
+			}
+			val[i] = restoreValue(key, item, match, allowSecret)
+		}
+		return val
+	case string:
+		ls, _ := local.(string)
+		if val == "" && allowSecret && isSecretKey(key) {
+			return ls
+		}
+		if sameURLAccount(val, ls) {
+			return ls
+		}
+		return val
+	}
+	return v
+}
+
+// isSecretKey 字段名是否为敏感字段
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range secretKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseURL 解析带主机的 URL，非 URL 返回 nil
+func parseURL(s string) *url.URL {
+	if !strings.Contains(s, "://") {
+		return nil
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.Host == "" {
+		return nil
+	}
+	return u
+}
+
+// redactURL 隐去 URL 中的账号密码与查询参数值，非 URL 原样返回
+func redactURL(s string) string {
+	u := parseURL(s)
+	if u == nil {
+		return s
+	}
+	if u.User != nil {
+		u.User = url.User(redacted)
+	}
+	if u.RawQuery != "" {
+		q := u.Query()
+		for k := range q {
+			q.Set(k, redacted)
+		}
+		u.RawQuery = q.Encode()
+	}
+	return u.String()
+}
+
+// stripURLPassword 去掉 URL 中的密码，保留账号
+func stripURLPassword(s string) string {
+	u := parseURL(s)
+	if u == nil || u.User == nil {
+		return s
+	}
+	if _, ok := u.User.Password(); !ok {
+		return s
+	}
+	u.User = url.User(u.User.Username())
+	return u.String()
+}
+
+// sameURLAccount stripped 为去掉密码的 URL，且与 local 的账号、主机一致而 local 带密码
+func sameURLAccount(stripped, local string) bool {
+	u, old := parseURL(stripped), parseURL(local)
+	if u == nil || old == nil || u.User == nil || old.User == nil {
+		return false
+	}
+	if _, ok := u.User.Password(); ok {
+		return false
+	}
+	_, hasPassword := old.User.Password()
+	return hasPassword && old.Host == u.Host && old.User.Username() == u.User.Username()
+}
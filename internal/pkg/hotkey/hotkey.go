@@ -0,0 +1,136 @@
+// Package hotkey 系统级全局快捷键，应用未获得焦点时也能响应
+// 目前仅实现 Windows（RegisterHotKey），其他平台注册时返回 ErrUnsupported
+package hotkey
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrUnsupported 当前平台不支持全局快捷键
+var ErrUnsupported = errors.New("当前系统暂不支持全局快捷键")
+
+// Modifier 修饰键，取值与 Windows MOD_* 一致
+type Modifier uint32
+
+const (
+	ModAlt Modifier = 1 << iota
+	ModCtrl
+	ModShift
+	ModWin
+)
+
+// Key 快捷键组合，Code 为 Windows 虚拟键码
+type Key struct {
+	Mods Modifier
+	Code uint32
+}
+
+// namedKeys 非字母数字按键的名称与虚拟键码
+var namedKeys = map[string]uint32{
+	"space": 0x20, "enter": 0x0D, "tab": 0x09, "esc": 0x1B, "escape": 0x1B,
+	"backspace": 0x08, "insert": 0x2D, "delete": 0x2E, "home": 0x24, "end": 0x23,
+	"pageup": 0x21, "pagedown": 0x22, "left": 0x25, "up": 0x26, "right": 0x27, "down": 0x28,
+	"`": 0xC0, "-": 0xBD, "=": 0xBB, "[": 0xDB, "]": 0xDD, ";": 0xBA, "'": 0xDE,
+	",": 0xBC, ".": 0xBE, "/": 0xBF, "\\": 0xDC,
+}
+
+// Parse 解析快捷键字符串，如 "Ctrl+Alt+J"、"Ctrl+Shift+F1"，不区分大小写
+// 除 F1~F24 外必须包含修饰键，避免抢占普通输入
+func Parse(s string) (Key, error) {
+	var k Key
+	parts := strings.Split(s, "+")
+	for i, p := range parts {
+		p = strings.ToLower(strings.TrimSpace(p))
+		if i < len(parts)-1 {
+			switch p {
+			case "ctrl", "control":
+				k.Mods |= ModCtrl
+			case "alt":
+				k.Mods |= ModAlt
+			case "shift":
+				k.Mods |= ModShift
+			case "win", "super", "meta", "cmd":
+				k.Mods |= ModWin
+			default:
+				return Key{}, fmt.Errorf("快捷键 %q 中的修饰键 %q 无效", s, p)
+			}
+			continue
+		}
+		code, ok := keyCode(p)
+		if !ok {
+			return Key{}, fmt.Errorf("快捷键 %q 中的按键 %q 无效", s, p)
+		}
+		k.Code = code
+	}
+	if k.Mods == 0 && (k.Code < 0x70 || k.Code > 0x87) {
+		return Key{}, fmt.Errorf("快捷键 %q 需要包含 Ctrl、Alt、Shift 或 Win", s)
+	}
+	return k, nil
+}
+
+// keyCode 按键名称转换为虚拟键码
+func keyCode(name string) (uint32, bool) {
+	if len(name) == 1 && (name[0] >= 'a' && name[0] <= 'z' || name[0] >= '0' && name[0] <= '9') {
+		return uint32(strings.ToUpper(name)[0]), true
+	}
+	if rest, ok := strings.CutPrefix(name, "f"); ok {
+		if n, err := strconv.Atoi(rest); err == nil && n >= 1 && n <= 24 {
+			return 0x70 + uint32(n-1), true
+		}
+	}
+	code, ok := namedKeys[name]
+	return code, ok
+}
+
+// Binding 快捷键与触发时的回调
+type Binding struct {
+	Name    string // 用于错误提示
+	Key     Key
+	Handler func()
+}
+
+// Manager 全局快捷键管理，Set 整体替换已注册的快捷键
+type Manager struct {
+	mu   sync.Mutex
+	stop func()
+}
+
+// Set 注销原有快捷键并注册新的一组，bindings 为空时仅注销
+// 部分快捷键注册失败（如被其他程序占用）时其余仍然生效，错误合并返回
+func (m *Manager) Set(bindings []Binding) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.stop != nil {
+		m.stop()
+		m.stop = nil
+	}
+	if len(bindings) == 0 {
+		return nil
+	}
+
+	seen := make(map[Key]string, len(bindings))
+	var valid []Binding
+	var errs []error
+	for _, b := range bindings {
+		if other, ok := seen[b.Key]; ok {
+			errs = append(errs, fmt.Errorf("%s与%s的快捷键相同", b.Name, other))
+			continue
+		}
+		seen[b.Key] = b.Name
+		valid = append(valid, b)
+	}
+
+	stop, err := listen(valid)
+	m.stop = stop
+	return errors.Join(append(errs, err)...)
+}
+
+// Close 注销全部快捷键
+func (m *Manager) Close() {
+	m.Set(nil)
+}
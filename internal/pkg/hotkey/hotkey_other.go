@@ -0,0 +1,14 @@
+//go:build !windows
+
+package hotkey
+
+// Supported 当前平台是否支持全局快捷键
+func Supported() bool { return false }
+
+// listen 非 Windows 平台暂未实现
+func listen(bindings []Binding) (func(), error) {
+	if len(bindings) == 0 {
+		return nil, nil
+	}
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,38 @@
+package hotkey
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		in   string
+		want Key
+		ok   bool
+	}{
+		{"Ctrl+Alt+J", Key{ModCtrl | ModAlt, 'J'}, true},
+		{"ctrl + shift + f", Key{ModCtrl | ModShift, 'F'}, true},
+		{"Win+`", Key{ModWin, 0xC0}, true},
+		{"Alt+F4", Key{ModAlt, 0x73}, true},
+		{"F12", Key{0, 0x7B}, true},
+		{"Ctrl+Space", Key{ModCtrl, 0x20}, true},
+		{"J", Key{}, false},
+		{"Ctrl+Hyper+J", Key{}, false},
+		{"Ctrl+F25", Key{}, false},
+		{"Ctrl+", Key{}, false},
+	}
+	for _, c := range cases {
+		got, err := Parse(c.in)
+		if (err == nil) != c.ok || got != c.want {
+			t.Errorf("Parse(%q) = %+v, %v", c.in, got, err)
+		}
+	}
+}
+
+func TestManagerDuplicate(t *testing.T) {
+	var m Manager
+	defer m.Close()
+	k, _ := Parse("Ctrl+Alt+J")
+	err := m.Set([]Binding{{Name: "显示/隐藏窗口", Key: k}, {Name: "老板键", Key: k}})
+	if err == nil {
+		t.Fatal("重复的快捷键应报错")
+	}
+}
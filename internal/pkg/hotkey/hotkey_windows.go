@@ -0,0 +1,99 @@
+//go:build windows
+
+package hotkey
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	wmQuit      = 0x0012
+	wmHotkey    = 0x0312
+	wmUser      = 0x0400
+	modNoRepeat = 0x4000 // 按住不放时不重复触发
+)
+
+var (
+	user32                 = syscall.NewLazyDLL("user32.dll")
+	kernel32               = syscall.NewLazyDLL("kernel32.dll")
+	procRegisterHotKey     = user32.NewProc("RegisterHotKey")
+	procUnregisterHotKey   = user32.NewProc("UnregisterHotKey")
+	procGetMessageW        = user32.NewProc("GetMessageW")
+	procPeekMessageW       = user32.NewProc("PeekMessageW")
+	procPostThreadMessageW = user32.NewProc("PostThreadMessageW")
+	procGetCurrentThreadId = kernel32.NewProc("GetCurrentThreadId")
+)
+
+// winMsg Windows MSG 结构
+type winMsg struct {
+	hwnd    uintptr
+	message uint32
+	wParam  uintptr
+	lParam  uintptr
+	time    uint32
+	pt      struct{ x, y int32 }
+}
+
+// Supported 当前平台是否支持全局快捷键
+func Supported() bool { return true }
+
+// listen 在独立的系统线程上注册快捷键并运行消息循环
+// WM_HOTKEY 只投递到注册线程，注销也必须在同一线程完成
+func listen(bindings []Binding) (func(), error) {
+	type started struct {
+		tid uintptr
+		err error
+	}
+	ready := make(chan started)
+	done := make(chan struct{})
+
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		defer close(done)
+
+		// 先创建线程消息队列，确保 stop 发出的 WM_QUIT 不会丢失
+		var msg winMsg
+		procPeekMessageW.Call(uintptr(unsafe.Pointer(&msg)), 0, wmUser, wmUser, 0)
+		tid, _, _ := procGetCurrentThreadId.Call()
+
+		handlers := make(map[uintptr]func(), len(bindings))
+		var errs []error
+		for i, b := range bindings {
+			id := uintptr(i + 1)
+			if r, _, err := procRegisterHotKey.Call(0, id, uintptr(b.Key.Mods)|modNoRepeat, uintptr(b.Key.Code)); r == 0 {
+				errs = append(errs, fmt.Errorf("%s快捷键注册失败，可能已被其他程序占用: %v", b.Name, err))
+				continue
+			}
+			handlers[id] = b.Handler
+		}
+		ready <- started{tid: tid, err: errors.Join(errs...)}
+
+		for {
+			// 返回 0 为 WM_QUIT，-1 为出错
+			r, _, _ := procGetMessageW.Call(uintptr(unsafe.Pointer(&msg)), 0, 0, 0)
+			if int32(r) <= 0 {
+				break
+			}
+			if msg.message == wmHotkey {
+				if h := handlers[msg.wParam]; h != nil {
+					go h()
+				}
+			}
+		}
+		for id := range handlers {
+			procUnregisterHotKey.Call(0, id)
+		}
+	}()
+
+	s := <-ready
+	stop := func() {
+		procPostThreadMessageW.Call(s.tid, wmQuit, 0, 0)
+		<-done
+	}
+	return stop, s.err
+}
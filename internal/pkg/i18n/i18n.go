@@ -0,0 +1,92 @@
+// Package i18n 后端生成的展示文本（市场状态、交易时段、节假日、错误提示）本地化
+// 语言包按 key 索引，当前语言缺少的条目回退到简体中文
+// 返回给前端展示的校验类错误经 Errorf 从语言包取文案；上游接口的诊断错误保留原文，
+// 由 apperr 错误码提示（error.<code>）给出本地化说明
+package i18n
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+// 支持的语言
+const (
+	LangZhCN    = "zh-CN"
+	LangEnUS    = "en-US"
+	DefaultLang = LangZhCN
+)
+
+// packs 语言包：语言 -> key -> 文本
+var packs = map[string]map[string]string{
+	LangZhCN: zhCN,
+	LangEnUS: enUS,
+}
+
+var current atomic.Value // string
+
+// Supported 支持的语言列表
+func Supported() []string {
+	return []string{LangZhCN, LangEnUS}
+}
+
+// IsSupported 是否为支持的语言，空字符串视为默认语言
+func IsSupported(lang string) bool {
+	return lang == "" || slices.Contains(Supported(), lang)
+}
+
+// SetLang 设置当前语言，不支持的语言使用默认语言
+func SetLang(lang string) {
+	if _, ok := packs[lang]; !ok {
+		lang = DefaultLang
+	}
+	current.Store(lang)
+}
+
+// Lang 当前语言
+func Lang() string {
+	if lang, ok := current.Load().(string); ok {
+		return lang
+	}
+	return DefaultLang
+}
+
+// T 按当前语言翻译 key，带参数时按 fmt.Sprintf 格式化；未收录的 key 原样返回
+func T(key string, args ...any) string {
+	text := lookup(key)
+	if len(args) > 0 {
+		return fmt.Sprintf(text, args...)
+	}
+	return text
+}
+
+// Errorf 按当前语言翻译 key 并构造错误，格式串支持 %w 包装底层错误
+func Errorf(key string, args ...any) error {
+	return fmt.Errorf(lookup(key), args...)
+}
+
+// lookup 当前语言的文本，缺失时回退到简体中文，仍未收录则返回 key
+func lookup(key string) string {
+	if text, ok := packs[Lang()][key]; ok {
+		return text
+	}
+	if text, ok := packs[DefaultLang][key]; ok {
+		return text
+	}
+	return key
+}
+
+// Holiday 翻译节假日名称（数据源为中文），多个节日以"、"分隔
+func Holiday(name string) string {
+	if name == "" || Lang() == DefaultLang {
+		return name
+	}
+	parts := strings.Split(name, "、")
+	for i, p := range parts {
+		if text, ok := packs[Lang()]["holiday."+p]; ok {
+			parts[i] = text
+		}
+	}
+	return strings.Join(parts, T("holiday.sep"))
+}
@@ -0,0 +1,46 @@
+package i18n
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestTranslate(t *testing.T) {
+	defer SetLang(DefaultLang)
+
+	SetLang("fr-FR")
+	if Lang() != LangZhCN || T("market.status.trading") != "交易中" {
+		t.Errorf("不支持的语言应回退到简体中文: %s", Lang())
+	}
+	if Holiday("国庆节、中秋节") != "国庆节、中秋节" {
+		t.Errorf("简体中文不应翻译节假日")
+	}
+
+	SetLang(LangEnUS)
+	if got := T("market.status.holiday", Holiday("国庆节、中秋节")); got != "Closed for National Day & Mid-Autumn Festival" {
+		t.Errorf("holiday = %q", got)
+	}
+	if got := T("no.such.key"); got != "no.such.key" {
+		t.Errorf("未收录的 key 应原样返回: %q", got)
+	}
+	for key := range zhCN {
+		if _, ok := enUS[key]; !ok {
+			t.Errorf("英文语言包缺少 %s", key)
+		}
+	}
+}
+
+func TestErrorf(t *testing.T) {
+	defer SetLang(DefaultLang)
+
+	err := Errorf("error.symbol.invalid", "sh60")
+	if err.Error() != "无效的股票代码: sh60" {
+		t.Errorf("zh-CN = %q", err)
+	}
+	SetLang(LangEnUS)
+	err = Errorf("error.sync.failed", "config.json", os.ErrNotExist)
+	if err.Error() != "Failed to sync config.json: file does not exist" || !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("en-US = %q", err)
+	}
+}
@@ -0,0 +1,321 @@
+package i18n
+
+// zhCN 简体中文语言包
+var zhCN = map[string]string{
+	"market.status.pre_market":   "盘前",
+	"market.status.call_auction": "集合竞价",
+	"market.status.trading":      "交易中",
+	"market.status.lunch_break":  "午间休市",
+	"market.status.break":        "盘中休息",
+	"market.status.night":        "夜盘交易中",
+	"market.status.closed":       "已收盘",
+	"market.status.off":          "休市",
+	"market.status.weekend":      "周末休市",
+	"market.status.holiday":      "%s休市",
+
+	"holiday.sep": "、",
+
+	"error.NETWORK":        "网络异常，请检查网络连接或代理设置后重试",
+	"error.RATE_LIMITED":   "请求过于频繁，已被数据源限流，请稍后重试",
+	"error.PROVIDER_PARSE": "数据源返回格式异常，接口可能已变更",
+	"error.AUTH":           "鉴权失败，请检查 API Key 或令牌",
+	"error.BUDGET":         "AI 费用已超出预算",
+	"error.UNKNOWN":        "操作失败",
+
+	"error.engine.unavailable":              "远程引擎 %s 不可用: %w",
+	"error.engine.address_required":         "启用远程引擎需要填写地址",
+	"error.settings.empty":                  "未指定任何设置",
+	"error.ai.not_configured":               "未配置AI服务",
+	"error.tool.not_found":                  "工具不存在: %s",
+	"error.window.id_code_required":         "窗口 ID 与股票代码不能为空",
+	"error.window.kind_unsupported":         "不支持的窗口类型: %s",
+	"error.window.limit":                    "最多同时打开 %d 个分离窗口",
+	"error.window.start_failed":             "启动分离窗口失败: %w",
+	"error.symbol.invalid":                  "无效的股票代码: %s",
+	"error.drawing.symbol_period_required":  "股票代码和周期不能为空",
+	"error.drawing.type_unsupported":        "不支持的画线类型: %s",
+	"error.drawing.points_required":         "画线至少需要一个锚点",
+	"error.drawing.not_found":               "画线不存在: %s",
+	"error.group.not_found":                 "分组不存在: %s",
+	"error.group.name_required":             "分组名称不能为空",
+	"error.group.exists":                    "分组已存在: %s",
+	"error.group.builtin":                   "内置分组不可删除",
+	"error.watchlist.not_added":             "请先添加自选: %s",
+	"error.group.remove_watchlist":          "从自选分组移除请使用删除自选",
+	"error.group.order_mismatch":            "排序列表与分组股票不一致",
+	"error.watchlist.tag_limit":             "标签最多 %d 个",
+	"error.paper.market_closed":             "非交易时段不支持市价单",
+	"error.paper.quote_failed":              "获取 %s 行情失败",
+	"error.symbol.required":                 "股票代码不能为空",
+	"error.paper.side_invalid":              "无效的买卖方向: %s",
+	"error.paper.type_invalid":              "无效的委托类型: %s",
+	"error.paper.quantity_positive":         "委托数量必须大于0",
+	"error.paper.lot_size":                  "买入数量须为100股的整数倍",
+	"error.paper.price_positive":            "限价单价格必须大于0",
+	"error.paper.cancel_state":              "委托已%s，无法撤单",
+	"error.paper.not_found":                 "委托不存在: %s",
+	"error.condition.price_positive":        "触发价格必须大于 0",
+	"error.condition.time_format":           "时间格式应为 HH:MM",
+	"error.condition.ma_period":             "均线周期应在 2-%d 之间",
+	"error.condition.indicator_unsupported": "不支持的指标: %s",
+	"error.condition.trigger_unsupported":   "不支持的触发类型: %s",
+	"error.condition.action_unsupported":    "不支持的动作: %s",
+	"error.condition.cancel_state":          "条件单已%s，无法撤销",
+	"error.condition.not_found":             "条件单不存在",
+	"error.strategy.not_found":              "策略不存在: %s",
+	"error.strategy.exists":                 "策略ID已存在: %s",
+	"error.strategy.builtin":                "内置策略不可删除",
+	"error.strategy.active":                 "当前激活的策略不可删除，请先切换到其他策略",
+	"error.agent.id_exists":                 "专家ID已存在: %s",
+	"error.strategy.current_missing":        "当前策略不存在",
+	"error.agent.not_found":                 "专家不存在: %s",
+	"error.ai.call_failed":                  "调用LLM失败: %w",
+	"error.ai.parse_failed":                 "解析结果失败: %w",
+	"error.agent.name_required":             "专家名称不能为空",
+	"error.agent.name_too_long":             "专家名称不能超过%d个字",
+	"error.agent.role_too_long":             "专家角色不能超过%d个字",
+	"error.agent.instruction_required":      "系统提示词不能为空",
+	"error.agent.instruction_too_long":      "系统提示词不能超过%d个字",
+	"error.ai.config_not_found":             "AI 配置不存在: %s",
+	"error.mcp.not_found":                   "MCP 服务器不存在: %s",
+	"error.agent.name_exists":               "专家名称已存在: %s",
+	"error.journal.not_found":               "日志不存在: %s",
+	"error.journal.kind_unsupported":        "不支持的日志类型: %s",
+	"error.date.invalid":                    "日期格式无效: %s",
+	"error.journal.close_before_open":       "平仓日期早于建仓日期",
+	"error.risk.capital_positive":           "账户资金必须大于0",
+	"error.risk.ratio_range":                "单笔风险比例需在 0~100%% 之间",
+	"error.risk.prices_positive":            "买入价与止损价必须大于0",
+	"error.risk.stop_above_entry":           "止损价需低于买入价",
+	"error.sync.disabled":                   "未启用云同步",
+	"error.sync.failed":                     "同步 %s 失败: %w",
+	"error.sync.conflict":                   "远端文件在同步期间被修改，请重试",
+	"error.sync.reload_failed":              "重新加载配置失败: %w",
+	"error.report.format_unsupported":       "不支持的报告格式: %s",
+	"error.report.no_discussion":            "该股票暂无讨论记录",
+	"error.language.unsupported":            "不支持的语言: %s",
+	"error.target.negative":                 "价格不能为负数",
+	"error.target.stop_above_target":        "止损价必须低于目标价",
+	"error.sort.field_unsupported":          "不支持的排序字段: %s",
+	"error.sort.order_unsupported":          "不支持的排序方向: %s",
+	"error.sort.topn_negative":              "topN 不能为负数",
+	"error.compare.empty":                   "请选择要对比的股票",
+	"error.compare.limit":                   "最多同时对比 %d 只股票",
+	"error.kline.period_unsupported":        "不支持的K线周期: %s",
+	"error.compare.range_unsupported":       "不支持的对比区间: %s",
+	"error.etf.not_etf":                     "%s 不是ETF",
+	"error.symbol.not_found":                "未找到股票: %s",
+	"error.briefing.running":                "简报正在生成中",
+	"error.briefing.ai_failed":              "AI 点评失败: %w",
+	"error.watchlist.empty":                 "自选股为空",
+	"error.quote.failed":                    "获取行情失败: %w",
+	"error.discussion.running":              "讨论正在进行中",
+	"error.discussion.not_resumable":        "讨论已完成或缺少专家信息，无法恢复",
+	"error.discussion.id_invalid":           "无效的讨论ID: %s",
+	"error.discussion.not_found":            "讨论不存在: %s",
+	"error.localapi.running":                "服务已在运行",
+	"error.localapi.token_required":         "未设置访问令牌",
+	"error.localapi.port_in_use":            "端口 %d 被占用: %w",
+	"error.localapi.listen_failed":          "监听端口 %d 失败: %w",
+	"error.engine.tls_required":             "连接非本机引擎 %s 需要启用 TLS",
+	"error.engine.ca_read_failed":           "读取 CA 证书失败: %w",
+	"error.engine.ca_invalid":               "CA 证书格式错误: %s",
+	"error.export.format_unsupported":       "不支持的导出格式: %s",
+	"error.futures.unsupported":             "不支持的期货代码: %s",
+	"error.index.unsupported":               "不支持的指数: %s",
+	"error.option.underlying_unsupported":   "不支持的期权标的: %s",
+	"error.option.month_invalid":            "无效的合约月份: %s",
+	"error.push.orderbook_interval":         "盘口推送间隔需在 500~10000 毫秒之间",
+	"error.push.quote_interval":             "行情推送间隔需在 1000~60000 毫秒之间",
+	"error.push.telegraph_interval":         "快讯推送间隔需在 5000~600000 毫秒之间",
+	"error.retry.max_attempts":              "重试次数需在 1~10 次之间",
+	"error.retry.base_delay":                "首次重试等待需在 0~10000 毫秒之间",
+	"error.retry.max_delay":                 "重试等待上限需不小于首次等待且不超过 60000 毫秒",
+	"error.retry.status":                    "可重试状态码需为 4xx 或 5xx: %d",
+	"error.holiday.no_data":                 "无内置 %d 年节假日数据",
+	"error.news.end_date_invalid":           "结束日期格式错误: %s",
+	"error.news.start_date_invalid":         "起始日期格式错误: %s",
+	"error.news.range_reversed":             "起始日期晚于结束日期",
+	"error.news.range_too_long":             "检索跨度不能超过 %d 天",
+	"error.news.archive_disabled":           "未启用快讯归档",
+	"error.settings.bundle_invalid":         "备份文件格式错误: %w",
+	"error.settings.bundle_version":         "不支持的备份版本: %d",
+	"error.kline.no_daily":                  "%s 没有日K数据",
+	"error.update.no_asset":                 "未找到适用于 %s/%s 的 GitHub Release",
+	"error.update.downloading":              "正在下载更新",
+	"error.update.latest":                   "已是最新版本",
+	"error.update.failed":                   "更新失败: %w",
+	"error.update.os_unsupported":           "不支持的操作系统: %s",
+
+	"update.checking":       "正在检测最新版本...",
+	"update.ready":          "新版本 %s 已下载，重启后生效",
+	"update.downloaded_mb":  "正在下载 %s... (已下载 %.2f MB)",
+	"update.downloading":    "正在下载版本 %s...",
+	"update.failed":         "更新失败: %v",
+	"update.installing":     "正在安装更新...",
+	"update.install_failed": "安装更新失败: %v",
+}
+
+// enUS 英文语言包
+var enUS = map[string]string{
+	"market.status.pre_market":   "Pre-market",
+	"market.status.call_auction": "Call auction",
+	"market.status.trading":      "Trading",
+	"market.status.lunch_break":  "Lunch break",
+	"market.status.break":        "Intraday break",
+	"market.status.night":        "Night session",
+	"market.status.closed":       "Closed",
+	"market.status.off":          "Market closed",
+	"market.status.weekend":      "Closed for the weekend",
+	"market.status.holiday":      "Closed for %s",
+
+	"holiday.sep": " & ",
+	"holiday.元旦":  "New Year's Day",
+	"holiday.春节":  "Spring Festival",
+	"holiday.清明节": "Qingming Festival",
+	"holiday.劳动节": "Labour Day",
+	"holiday.端午节": "Dragon Boat Festival",
+	"holiday.中秋节": "Mid-Autumn Festival",
+	"holiday.国庆节": "National Day",
+	"holiday.周末":  "Weekend",
+
+	"error.NETWORK":        "Network error. Check your connection or proxy settings and try again",
+	"error.RATE_LIMITED":   "Too many requests; the data source is rate limiting. Try again later",
+	"error.PROVIDER_PARSE": "Unexpected response from the data source; its API may have changed",
+	"error.AUTH":           "Authentication failed. Check your API key or token",
+	"error.BUDGET":         "AI spending budget exceeded",
+	"error.UNKNOWN":        "Operation failed",
+
+	"error.engine.unavailable":              "Remote engine %s is unavailable: %w",
+	"error.engine.address_required":         "An address is required to enable the remote engine",
+	"error.settings.empty":                  "No settings specified",
+	"error.ai.not_configured":               "No AI service is configured",
+	"error.tool.not_found":                  "Tool not found: %s",
+	"error.window.id_code_required":         "Window ID and stock code are required",
+	"error.window.kind_unsupported":         "Unsupported window type: %s",
+	"error.window.limit":                    "At most %d detached windows can be open at once",
+	"error.window.start_failed":             "Failed to open detached window: %w",
+	"error.symbol.invalid":                  "Invalid stock code: %s",
+	"error.drawing.symbol_period_required":  "Stock code and period are required",
+	"error.drawing.type_unsupported":        "Unsupported drawing type: %s",
+	"error.drawing.points_required":         "A drawing needs at least one anchor point",
+	"error.drawing.not_found":               "Drawing not found: %s",
+	"error.group.not_found":                 "Group not found: %s",
+	"error.group.name_required":             "Group name is required",
+	"error.group.exists":                    "Group already exists: %s",
+	"error.group.builtin":                   "Built-in groups cannot be deleted",
+	"error.watchlist.not_added":             "Add %s to the watchlist first",
+	"error.group.remove_watchlist":          "Use remove from watchlist to remove a stock from the watchlist group",
+	"error.group.order_mismatch":            "The order list does not match the stocks in the group",
+	"error.watchlist.tag_limit":             "At most %d tags are allowed",
+	"error.paper.market_closed":             "Market orders are not supported outside trading hours",
+	"error.paper.quote_failed":              "Failed to get the quote for %s",
+	"error.symbol.required":                 "Stock code is required",
+	"error.paper.side_invalid":              "Invalid order side: %s",
+	"error.paper.type_invalid":              "Invalid order type: %s",
+	"error.paper.quantity_positive":         "Order quantity must be greater than 0",
+	"error.paper.lot_size":                  "Buy quantity must be a multiple of 100 shares",
+	"error.paper.price_positive":            "Limit price must be greater than 0",
+	"error.paper.cancel_state":              "The order is already %s and cannot be cancelled",
+	"error.paper.not_found":                 "Order not found: %s",
+	"error.condition.price_positive":        "Trigger price must be greater than 0",
+	"error.condition.time_format":           "Time must be in HH:MM format",
+	"error.condition.ma_period":             "Moving average period must be between 2 and %d",
+	"error.condition.indicator_unsupported": "Unsupported indicator: %s",
+	"error.condition.trigger_unsupported":   "Unsupported trigger type: %s",
+	"error.condition.action_unsupported":    "Unsupported action: %s",
+	"error.condition.cancel_state":          "The condition order is already %s and cannot be cancelled",
+	"error.condition.not_found":             "Condition order not found",
+	"error.strategy.not_found":              "Strategy not found: %s",
+	"error.strategy.exists":                 "Strategy ID already exists: %s",
+	"error.strategy.builtin":                "Built-in strategies cannot be deleted",
+	"error.strategy.active":                 "The active strategy cannot be deleted; switch to another strategy first",
+	"error.agent.id_exists":                 "Agent ID already exists: %s",
+	"error.strategy.current_missing":        "The current strategy does not exist",
+	"error.agent.not_found":                 "Agent not found: %s",
+	"error.ai.call_failed":                  "AI request failed: %w",
+	"error.ai.parse_failed":                 "Failed to parse the AI result: %w",
+	"error.agent.name_required":             "Agent name is required",
+	"error.agent.name_too_long":             "Agent name cannot exceed %d characters",
+	"error.agent.role_too_long":             "Agent role cannot exceed %d characters",
+	"error.agent.instruction_required":      "System prompt is required",
+	"error.agent.instruction_too_long":      "System prompt cannot exceed %d characters",
+	"error.ai.config_not_found":             "AI configuration not found: %s",
+	"error.mcp.not_found":                   "MCP server not found: %s",
+	"error.agent.name_exists":               "Agent name already exists: %s",
+	"error.journal.not_found":               "Journal entry not found: %s",
+	"error.journal.kind_unsupported":        "Unsupported journal entry type: %s",
+	"error.date.invalid":                    "Invalid date: %s",
+	"error.journal.close_before_open":       "The close date is earlier than the open date",
+	"error.risk.capital_positive":           "Account capital must be greater than 0",
+	"error.risk.ratio_range":                "Risk per trade must be between 0 and 100%%",
+	"error.risk.prices_positive":            "Entry and stop prices must be greater than 0",
+	"error.risk.stop_above_entry":           "The stop price must be below the entry price",
+	"error.sync.disabled":                   "Cloud sync is not enabled",
+	"error.sync.failed":                     "Failed to sync %s: %w",
+	"error.sync.conflict":                   "The remote file changed during sync; please retry",
+	"error.sync.reload_failed":              "Failed to reload the configuration: %w",
+	"error.report.format_unsupported":       "Unsupported report format: %s",
+	"error.report.no_discussion":            "No discussion found for this stock",
+	"error.language.unsupported":            "Unsupported language: %s",
+	"error.target.negative":                 "Prices cannot be negative",
+	"error.target.stop_above_target":        "The stop price must be below the target price",
+	"error.sort.field_unsupported":          "Unsupported sort field: %s",
+	"error.sort.order_unsupported":          "Unsupported sort order: %s",
+	"error.sort.topn_negative":              "topN cannot be negative",
+	"error.compare.empty":                   "Select stocks to compare",
+	"error.compare.limit":                   "At most %d stocks can be compared at once",
+	"error.kline.period_unsupported":        "Unsupported K-line period: %s",
+	"error.compare.range_unsupported":       "Unsupported comparison range: %s",
+	"error.etf.not_etf":                     "%s is not an ETF",
+	"error.symbol.not_found":                "Stock not found: %s",
+	"error.briefing.running":                "A briefing is already being generated",
+	"error.briefing.ai_failed":              "AI commentary failed: %w",
+	"error.watchlist.empty":                 "The watchlist is empty",
+	"error.quote.failed":                    "Failed to get quotes: %w",
+	"error.discussion.running":              "A discussion is already in progress",
+	"error.discussion.not_resumable":        "The discussion is finished or missing agent details and cannot be resumed",
+	"error.discussion.id_invalid":           "Invalid discussion ID: %s",
+	"error.discussion.not_found":            "Discussion not found: %s",
+	"error.localapi.running":                "The service is already running",
+	"error.localapi.token_required":         "An access token is required",
+	"error.localapi.port_in_use":            "Port %d is in use: %w",
+	"error.localapi.listen_failed":          "Failed to listen on port %d: %w",
+	"error.engine.tls_required":             "Connecting to a non-local engine %s requires TLS",
+	"error.engine.ca_read_failed":           "Failed to read the CA certificate: %w",
+	"error.engine.ca_invalid":               "Invalid CA certificate: %s",
+	"error.export.format_unsupported":       "Unsupported export format: %s",
+	"error.futures.unsupported":             "Unsupported futures code: %s",
+	"error.index.unsupported":               "Unsupported index: %s",
+	"error.option.underlying_unsupported":   "Unsupported option underlying: %s",
+	"error.option.month_invalid":            "Invalid contract month: %s",
+	"error.push.orderbook_interval":         "Order book push interval must be between 500 and 10000 ms",
+	"error.push.quote_interval":             "Quote push interval must be between 1000 and 60000 ms",
+	"error.push.telegraph_interval":         "News push interval must be between 5000 and 600000 ms",
+	"error.retry.max_attempts":              "Retry attempts must be between 1 and 10",
+	"error.retry.base_delay":                "Initial retry delay must be between 0 and 10000 ms",
+	"error.retry.max_delay":                 "Maximum retry delay must be at least the initial delay and at most 60000 ms",
+	"error.retry.status":                    "Retryable status codes must be 4xx or 5xx: %d",
+	"error.holiday.no_data":                 "No built-in holiday data for %d",
+	"error.news.end_date_invalid":           "Invalid end date: %s",
+	"error.news.start_date_invalid":         "Invalid start date: %s",
+	"error.news.range_reversed":             "The start date is after the end date",
+	"error.news.range_too_long":             "The search range cannot exceed %d days",
+	"error.news.archive_disabled":           "News archiving is not enabled",
+	"error.settings.bundle_invalid":         "Invalid backup file: %w",
+	"error.settings.bundle_version":         "Unsupported backup version: %d",
+	"error.kline.no_daily":                  "%s has no daily K-line data",
+	"error.update.no_asset":                 "No GitHub release found for %s/%s",
+	"error.update.downloading":              "An update is already downloading",
+	"error.update.latest":                   "Already on the latest version",
+	"error.update.failed":                   "Update failed: %w",
+	"error.update.os_unsupported":           "Unsupported operating system: %s",
+
+	"update.checking":       "Checking for the latest version...",
+	"update.ready":          "Version %s is downloaded and takes effect after restart",
+	"update.downloaded_mb":  "Downloading %s... (%.2f MB downloaded)",
+	"update.downloading":    "Downloading version %s...",
+	"update.failed":         "Update failed: %v",
+	"update.installing":     "Installing update...",
+	"update.install_failed": "Failed to install update: %v",
+}
@@ -0,0 +1,141 @@
+// Package lru 按条数与字节数限制的 LRU 缓存
+// 用于行情、K线等长时间运行时会不断积累的缓存，超出上限时淘汰最久未访问的条目
+package lru
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Cache 并发安全的 LRU 缓存
+// maxEntries、maxBytes 为 0 表示不限制；字节数由 sizeOf 估算
+type Cache[K comparable, V any] struct {
+	mu         sync.Mutex
+	ll         *list.List
+	items      map[K]*list.Element
+	maxEntries int
+	maxBytes   int64
+	bytes      int64
+	sizeOf     func(V) int64
+	evictions  int64
+}
+
+type entry[K comparable, V any] struct {
+	key  K
+	val  V
+	size int64
+}
+
+// New 创建缓存，sizeOf 为空时按每条 1 字节计
+func New[K comparable, V any](maxEntries int, maxBytes int64, sizeOf func(V) int64) *Cache[K, V] {
+	if sizeOf == nil {
+		sizeOf = func(V) int64 { return 1 }
+	}
+	return &Cache[K, V]{
+		ll:         list.New(),
+		items:      make(map[K]*list.Element),
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		sizeOf:     sizeOf,
+	}
+}
+
+// Get 获取缓存并标记为最近访问
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		c.ll.MoveToFront(el)
+		return el.Value.(*entry[K, V]).val, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Put 写入缓存，超出上限时淘汰最久未访问的条目
+func (c *Cache[K, V]) Put(key K, val V) {
+	size := c.sizeOf(val)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		e := el.Value.(*entry[K, V])
+		c.bytes += size - e.size
+		e.val, e.size = val, size
+		c.ll.MoveToFront(el)
+	} else {
+		c.items[key] = c.ll.PushFront(&entry[K, V]{key: key, val: val, size: size})
+		c.bytes += size
+	}
+	// 至少保留刚写入的条目，即使其单条超出字节上限
+	for c.ll.Len() > 1 && ((c.maxEntries > 0 && c.ll.Len() > c.maxEntries) || (c.maxBytes > 0 && c.bytes > c.maxBytes)) {
+		c.removeElement(c.ll.Back())
+		c.evictions++
+	}
+}
+
+// Delete 删除缓存
+func (c *Cache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		c.removeElement(el)
+	}
+}
+
+// RemoveFunc 删除满足条件的条目，返回删除条数
+func (c *Cache[K, V]) RemoveFunc(fn func(K, V) bool) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	removed := 0
+	for el := c.ll.Back(); el != nil; {
+		prev := el.Prev()
+		e := el.Value.(*entry[K, V])
+		if fn(e.key, e.val) {
+			c.removeElement(el)
+			removed++
+		}
+		el = prev
+	}
+	return removed
+}
+
+// Shrink 淘汰最久未访问的条目，直到字节数不超过 target，返回释放的字节数
+func (c *Cache[K, V]) Shrink(target int64) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	before := c.bytes
+	for c.ll.Len() > 0 && c.bytes > target {
+		c.removeElement(c.ll.Back())
+		c.evictions++
+	}
+	return before - c.bytes
+}
+
+// Len 条目数
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+// Bytes 估算占用字节数
+func (c *Cache[K, V]) Bytes() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.bytes
+}
+
+// Evictions 因超出上限或内存回收被淘汰的累计条数
+func (c *Cache[K, V]) Evictions() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.evictions
+}
+
+// removeElement 删除条目（需要已持有锁）
+func (c *Cache[K, V]) removeElement(el *list.Element) {
+	e := el.Value.(*entry[K, V])
+	c.ll.Remove(el)
+	delete(c.items, e.key)
+	c.bytes -= e.size
+}
@@ -0,0 +1,60 @@
+package lru
+
+import "testing"
+
+func TestCacheEvictsLeastRecent(t *testing.T) {
+	c := New[string, int](2, 0, nil)
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Get("a") // a 变为最近访问
+	c.Put("c", 3)
+
+	if _, ok := c.Get("b"); ok {
+		t.Error("b 应被淘汰")
+	}
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Error("a 应保留")
+	}
+	if c.Len() != 2 || c.Evictions() != 1 {
+		t.Errorf("Len=%d Evictions=%d", c.Len(), c.Evictions())
+	}
+}
+
+func TestCacheByteLimit(t *testing.T) {
+	c := New[string, []byte](0, 10, func(v []byte) int64 { return int64(len(v)) })
+	c.Put("a", make([]byte, 4))
+	c.Put("b", make([]byte, 4))
+	c.Put("a", make([]byte, 6)) // 更新后共 10 字节，不淘汰
+	if c.Bytes() != 10 || c.Len() != 2 {
+		t.Fatalf("Bytes=%d Len=%d", c.Bytes(), c.Len())
+	}
+	c.Put("c", make([]byte, 3))
+	if _, ok := c.Get("b"); ok {
+		t.Error("b 应被淘汰")
+	}
+	if c.Bytes() != 9 {
+		t.Errorf("Bytes=%d, want 9", c.Bytes())
+	}
+
+	// 单条超出上限时仍保留该条
+	c.Put("big", make([]byte, 20))
+	if _, ok := c.Get("big"); !ok || c.Len() != 1 {
+		t.Errorf("超大条目应保留且独占缓存, Len=%d", c.Len())
+	}
+}
+
+func TestCacheShrinkAndRemoveFunc(t *testing.T) {
+	c := New[int, int](0, 0, func(v int) int64 { return int64(v) })
+	for i := 1; i <= 4; i++ {
+		c.Put(i, i)
+	}
+	if freed := c.Shrink(7); freed != 3 || c.Bytes() != 7 {
+		t.Errorf("Shrink freed=%d bytes=%d", freed, c.Bytes())
+	}
+	if n := c.RemoveFunc(func(k, v int) bool { return k%2 == 0 }); n != 1 {
+		t.Errorf("RemoveFunc 删除 %d 条, want 1", n)
+	}
+	if c.Len() != 1 || c.Bytes() != 3 {
+		t.Errorf("Len=%d Bytes=%d", c.Len(), c.Bytes())
+	}
+}
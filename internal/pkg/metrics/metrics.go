@@ -0,0 +1,357 @@
+// Package metrics 内部运行指标（计数器、仪表、直方图）
+// 用于观测上游请求延迟、缓存命中率、推送轮次耗时与事件队列长度，
+// 通过 Snapshot 输出给前端调试面板，或以 Prometheus 文本格式输出给本地 /metrics
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 指标类型
+const (
+	TypeCounter   = "counter"
+	TypeGauge     = "gauge"
+	TypeHistogram = "histogram"
+)
+
+// DefaultBuckets 默认延迟分桶（秒）
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Counter 单调递增计数器
+type Counter struct{ v atomic.Int64 }
+
+// Inc 加 1
+func (c *Counter) Inc() { c.v.Add(1) }
+
+// Add 增加 n（n 应为非负数）
+func (c *Counter) Add(n int64) { c.v.Add(n) }
+
+// Value 当前值
+func (c *Counter) Value() int64 { return c.v.Load() }
+
+// Gauge 可增可减的瞬时值
+type Gauge struct{ bits atomic.Uint64 }
+
+// Set 设置当前值
+func (g *Gauge) Set(v float64) { g.bits.Store(math.Float64bits(v)) }
+
+// Add 增加 delta
+func (g *Gauge) Add(delta float64) {
+	for {
+		old := g.bits.Load()
+		if g.bits.CompareAndSwap(old, math.Float64bits(math.Float64frombits(old)+delta)) {
+			return
+		}
+	}
+}
+
+// Value 当前值
+func (g *Gauge) Value() float64 { return math.Float64frombits(g.bits.Load()) }
+
+// Histogram 分桶直方图
+type Histogram struct {
+	mu      sync.Mutex
+	buckets []float64 // 上界，升序
+	counts  []uint64  // 每个桶的非累计计数，末尾为 +Inf
+	count   uint64
+	sum     float64
+}
+
+func newHistogram(buckets []float64) *Histogram {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	b := slices.Clone(buckets)
+	slices.Sort(b)
+	return &Histogram{buckets: b, counts: make([]uint64, len(b)+1)}
+}
+
+// Observe 记录一个观测值
+func (h *Histogram) Observe(v float64) {
+	i := sort.SearchFloat64s(h.buckets, v)
+	h.mu.Lock()
+	h.counts[i]++
+	h.count++
+	h.sum += v
+	h.mu.Unlock()
+}
+
+// ObserveSince 以秒为单位记录自 start 以来的耗时
+func (h *Histogram) ObserveSince(start time.Time) {
+	h.Observe(time.Since(start).Seconds())
+}
+
+// Bucket 直方图的累计分桶
+type Bucket struct {
+	Le    float64 `json:"le"` // 上界，+Inf 桶输出为 0 并由 Count 表示
+	Count uint64  `json:"count"`
+}
+
+// Sample 单条时间序列的取值
+type Sample struct {
+	Labels  map[string]string `json:"labels,omitempty"`
+	Value   float64           `json:"value"`             // 计数器、仪表的值；直方图为平均值
+	Count   uint64            `json:"count,omitempty"`   // 直方图观测次数
+	Sum     float64           `json:"sum,omitempty"`     // 直方图观测值之和
+	Buckets []Bucket          `json:"buckets,omitempty"` // 直方图累计分桶（不含 +Inf）
+}
+
+// Family 同名指标
+type Family struct {
+	Name    string   `json:"name"`
+	Help    string   `json:"help"`
+	Type    string   `json:"type"`
+	Samples []Sample `json:"samples"`
+}
+
+// series 一条带标签的时间序列
+type series struct {
+	labels    []string // key/value 交替
+	counter   *Counter
+	gauge     *Gauge
+	gaugeFunc func() float64
+	histogram *Histogram
+}
+
+type family struct {
+	name, help, kind string
+	buckets          []float64
+	series           map[string]*series
+}
+
+// Registry 指标注册表，同名同标签的指标只创建一次
+type Registry struct {
+	mu       sync.Mutex
+	families map[string]*family
+}
+
+// NewRegistry 创建注册表
+func NewRegistry() *Registry {
+	return &Registry{families: make(map[string]*family)}
+}
+
+// Default 全局注册表
+var Default = NewRegistry()
+
+// lookup 获取或创建时间序列，labels 为 key/value 交替；同名指标类型不一致时 panic（属于编码错误）
+func (r *Registry) lookup(kind, name, help string, buckets []float64, labels []string) *series {
+	if len(labels)%2 != 0 {
+		panic("metrics: labels 必须成对出现: " + name)
+	}
+	key := strings.Join(labels, "\xff")
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f, ok := r.families[name]
+	if !ok {
+		f = &family{name: name, help: help, kind: kind, buckets: buckets, series: make(map[string]*series)}
+		r.families[name] = f
+	} else if f.kind != kind {
+		panic(fmt.Sprintf("metrics: %s 已注册为 %s", name, f.kind))
+	}
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{labels: slices.Clone(labels)}
+		switch kind {
+		case TypeCounter:
+			s.counter = &Counter{}
+		case TypeGauge:
+			s.gauge = &Gauge{}
+		case TypeHistogram:
+			s.histogram = newHistogram(f.buckets)
+		}
+		f.series[key] = s
+	}
+	return s
+}
+
+// Counter 获取计数器
+func (r *Registry) Counter(name, help string, labels ...string) *Counter {
+	return r.lookup(TypeCounter, name, help, nil, labels).counter
+}
+
+// Gauge 获取仪表
+func (r *Registry) Gauge(name, help string, labels ...string) *Gauge {
+	return r.lookup(TypeGauge, name, help, nil, labels).gauge
+}
+
+// GaugeFunc 注册在采集时计算的仪表（如队列长度），重复注册时替换函数
+func (r *Registry) GaugeFunc(name, help string, fn func() float64, labels ...string) {
+	s := r.lookup(TypeGauge, name, help, nil, labels)
+	r.mu.Lock()
+	s.gaugeFunc = fn
+	r.mu.Unlock()
+}
+
+// Histogram 获取直方图，buckets 为空时使用 DefaultBuckets（仅首次创建时生效）
+func (r *Registry) Histogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	return r.lookup(TypeHistogram, name, help, buckets, labels).histogram
+}
+
+// Snapshot 采集所有指标，按名称与标签排序
+func (r *Registry) Snapshot() []Family {
+	r.mu.Lock()
+	type entry struct {
+		f      *family
+		series []*series
+	}
+	entries := make([]entry, 0, len(r.families))
+	for _, f := range r.families {
+		e := entry{f: f}
+		for _, s := range f.series {
+			e.series = append(e.series, s)
+		}
+		entries = append(entries, e)
+	}
+	gaugeFuncs := make(map[*series]func() float64)
+	for _, e := range entries {
+		for _, s := range e.series {
+			if s.gaugeFunc != nil {
+				gaugeFuncs[s] = s.gaugeFunc
+			}
+		}
+	}
+	r.mu.Unlock()
+
+	// 在锁外调用 GaugeFunc，避免回调中再次访问注册表造成死锁
+	result := make([]Family, 0, len(entries))
+	for _, e := range entries {
+		fam := Family{Name: e.f.name, Help: e.f.help, Type: e.f.kind, Samples: make([]Sample, 0, len(e.series))}
+		for _, s := range e.series {
+			sample := Sample{Labels: labelMap(s.labels)}
+			switch {
+			case s.counter != nil:
+				sample.Value = float64(s.counter.Value())
+			case gaugeFuncs[s] != nil:
+				sample.Value = gaugeFuncs[s]()
+			case s.gauge != nil:
+				sample.Value = s.gauge.Value()
+			case s.histogram != nil:
+				h := s.histogram
+				h.mu.Lock()
+				sample.Count, sample.Sum = h.count, h.sum
+				var cumulative uint64
+				for i, le := range h.buckets {
+					cumulative += h.counts[i]
+					sample.Buckets = append(sample.Buckets, Bucket{Le: le, Count: cumulative})
+				}
+				h.mu.Unlock()
+				if sample.Count > 0 {
+					sample.Value = sample.Sum / float64(sample.Count)
+				}
+			}
+			fam.Samples = append(fam.Samples, sample)
+		}
+		sort.Slice(fam.Samples, func(i, j int) bool {
+			return labelKey(fam.Samples[i].Labels) < labelKey(fam.Samples[j].Labels)
+		})
+		result = append(result, fam)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// WritePrometheus 以 Prometheus 文本格式输出所有指标
+func (r *Registry) WritePrometheus(w io.Writer) error {
+	var sb strings.Builder
+	for _, f := range r.Snapshot() {
+		fmt.Fprintf(&sb, "# HELP %s %s\n", f.Name, escapeHelp(f.Help))
+		fmt.Fprintf(&sb, "# TYPE %s %s\n", f.Name, f.Type)
+		for _, s := range f.Samples {
+			if f.Type != TypeHistogram {
+				fmt.Fprintf(&sb, "%s%s %s\n", f.Name, formatLabels(s.Labels, "", ""), formatFloat(s.Value))
+				continue
+			}
+			for _, b := range s.Buckets {
+				fmt.Fprintf(&sb, "%s_bucket%s %d\n", f.Name, formatLabels(s.Labels, "le", formatFloat(b.Le)), b.Count)
+			}
+			fmt.Fprintf(&sb, "%s_bucket%s %d\n", f.Name, formatLabels(s.Labels, "le", "+Inf"), s.Count)
+			fmt.Fprintf(&sb, "%s_sum%s %s\n", f.Name, formatLabels(s.Labels, "", ""), formatFloat(s.Sum))
+			fmt.Fprintf(&sb, "%s_count%s %d\n", f.Name, formatLabels(s.Labels, "", ""), s.Count)
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func labelMap(labels []string) map[string]string {
+	if len(labels) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(labels)/2)
+	for i := 0; i+1 < len(labels); i += 2 {
+		m[labels[i]] = labels[i+1]
+	}
+	return m
+}
+
+// labelKey 标签排序键
+func labelKey(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var sb strings.Builder
+	for _, k := range keys {
+		sb.WriteString(k + "=" + labels[k] + ",")
+	}
+	return sb.String()
+}
+
+// formatLabels 输出 {k="v",...}，extraKey 非空时追加（直方图的 le）
+func formatLabels(labels map[string]string, extraKey, extraValue string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys)+1)
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%q", k, labels[k]))
+	}
+	if extraKey != "" {
+		parts = append(parts, fmt.Sprintf("%s=%q", extraKey, extraValue))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func escapeHelp(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(s)
+}
+
+// NewCounter 在全局注册表获取计数器
+func NewCounter(name, help string, labels ...string) *Counter {
+	return Default.Counter(name, help, labels...)
+}
+
+// NewGauge 在全局注册表获取仪表
+func NewGauge(name, help string, labels ...string) *Gauge {
+	return Default.Gauge(name, help, labels...)
+}
+
+// NewGaugeFunc 在全局注册表注册采集时计算的仪表
+func NewGaugeFunc(name, help string, fn func() float64, labels ...string) {
+	Default.GaugeFunc(name, help, fn, labels...)
+}
+
+// NewHistogram 在全局注册表获取直方图
+func NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	return Default.Histogram(name, help, buckets, labels...)
+}
@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRegistrySnapshot(t *testing.T) {
+	r := NewRegistry()
+	r.Counter("req_total", "请求数", "source", "quotes").Add(3)
+	r.Counter("req_total", "请求数", "source", "quotes").Inc()
+	r.Counter("req_total", "请求数", "source", "kline").Inc()
+	r.Gauge("queue", "队列").Set(2)
+	r.Gauge("queue", "队列").Add(-0.5)
+	n := 7
+	r.GaugeFunc("size", "大小", func() float64 { return float64(n) })
+	h := r.Histogram("latency", "延迟", []float64{0.1, 1})
+	h.Observe(0.05)
+	h.Observe(0.5)
+	h.Observe(3)
+
+	fams := r.Snapshot()
+	if len(fams) != 4 {
+		t.Fatalf("families = %d, want 4", len(fams))
+	}
+	byName := map[string]Family{}
+	for _, f := range fams {
+		byName[f.Name] = f
+	}
+
+	req := byName["req_total"]
+	if len(req.Samples) != 2 || req.Samples[0].Labels["source"] != "kline" || req.Samples[1].Value != 4 {
+		t.Errorf("req_total = %+v", req.Samples)
+	}
+	if v := byName["queue"].Samples[0].Value; v != 1.5 {
+		t.Errorf("queue = %v, want 1.5", v)
+	}
+	if v := byName["size"].Samples[0].Value; v != 7 {
+		t.Errorf("size = %v, want 7", v)
+	}
+	lat := byName["latency"].Samples[0]
+	if lat.Count != 3 || lat.Buckets[0].Count != 1 || lat.Buckets[1].Count != 2 {
+		t.Errorf("latency = %+v", lat)
+	}
+}
+
+func TestRegistryTypeMismatch(t *testing.T) {
+	r := NewRegistry()
+	r.Counter("x", "")
+	defer func() {
+		if recover() == nil {
+			t.Error("同名不同类型应 panic")
+		}
+	}()
+	r.Gauge("x", "")
+}
+
+func TestWritePrometheus(t *testing.T) {
+	r := NewRegistry()
+	r.Counter("hits_total", "命中", "cache", "kline").Add(2)
+	r.Histogram("push_seconds", "推送耗时", []float64{1}).Observe(0.5)
+
+	var sb strings.Builder
+	if err := r.WritePrometheus(&sb); err != nil {
+		t.Fatal(err)
+	}
+	out := sb.String()
+	for _, want := range []string{
+		"# TYPE hits_total counter",
+		`hits_total{cache="kline"} 2`,
+		"# TYPE push_seconds histogram",
+		`push_seconds_bucket{le="1"} 1`,
+		`push_seconds_bucket{le="+Inf"} 1`,
+		"push_seconds_sum 0.5",
+		"push_seconds_count 1",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("输出缺少 %q:\n%s", want, out)
+		}
+	}
+}
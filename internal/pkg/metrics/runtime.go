@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"runtime"
+	"sync"
+	"time"
+)
+
+// 运行时指标缓存，避免每次采集都 ReadMemStats（会短暂 STW）
+var (
+	memMu      sync.Mutex
+	memStats   runtime.MemStats
+	memUpdated time.Time
+)
+
+func readMemStats() runtime.MemStats {
+	memMu.Lock()
+	defer memMu.Unlock()
+	if time.Since(memUpdated) > time.Second {
+		runtime.ReadMemStats(&memStats)
+		memUpdated = time.Now()
+	}
+	return memStats
+}
+
+func init() {
+	NewGaugeFunc("go_goroutines", "当前 goroutine 数", func() float64 {
+		return float64(runtime.NumGoroutine())
+	})
+	NewGaugeFunc("go_memstats_heap_alloc_bytes", "堆上已分配且仍在使用的字节数", func() float64 {
+		return float64(readMemStats().HeapAlloc)
+	})
+	NewGaugeFunc("go_memstats_sys_bytes", "从操作系统获取的内存字节数", func() float64 {
+		return float64(readMemStats().Sys)
+	})
+	NewGaugeFunc("go_gc_count", "已完成的 GC 次数", func() float64 {
+		return float64(readMemStats().NumGC)
+	})
+}
@@ -0,0 +1,158 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptrace"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HostConnStats 单个主机的连接复用与延迟统计
+type HostConnStats struct {
+	Host         string  `json:"host"`
+	Requests     int64   `json:"requests"`     // 累计请求数
+	Errors       int64   `json:"errors"`       // 累计失败数（未拿到响应）
+	Active       int64   `json:"active"`       // 进行中的请求数
+	NewConns     int64   `json:"newConns"`     // 新建连接数
+	ReusedConns  int64   `json:"reusedConns"`  // 复用连接数
+	ReuseRate    float64 `json:"reuseRate"`    // 连接复用率 0-1
+	AvgLatencyMs float64 `json:"avgLatencyMs"` // 平均响应头延迟(毫秒)
+	MaxLatencyMs int64   `json:"maxLatencyMs"` // 最大响应头延迟(毫秒)
+	AvgConnectMs float64 `json:"avgConnectMs"` // 新建连接的平均耗时(毫秒，含 DNS 与 TLS)
+}
+
+// hostMetrics 累计数据
+type hostMetrics struct {
+	requests, errors, active int64
+	newConns, reusedConns    int64
+	latencyTotal, latencyMax time.Duration
+	connectTotal             time.Duration
+}
+
+// connMetrics 按主机统计连接复用与延迟
+type connMetrics struct {
+	mu    sync.Mutex
+	hosts map[string]*hostMetrics
+}
+
+// get 获取主机的统计项（调用方需持有锁）
+func (c *connMetrics) get(host string) *hostMetrics {
+	if c.hosts == nil {
+		c.hosts = make(map[string]*hostMetrics)
+	}
+	h, ok := c.hosts[host]
+	if !ok {
+		h = &hostMetrics{}
+		c.hosts[host] = h
+	}
+	return h
+}
+
+// roundTrip 发送请求并记录连接是否复用、建连耗时与响应延迟
+func (c *connMetrics) roundTrip(rt http.RoundTripper, host string, req *http.Request) (*http.Response, error) {
+	host = strings.ToLower(host)
+	var (
+		connMu       sync.Mutex
+		reused, got  bool
+		dialStart    time.Time
+		connectSpent time.Duration
+	)
+	trace := &httptrace.ClientTrace{
+		GetConn: func(string) {
+			connMu.Lock()
+			dialStart = time.Now()
+			connMu.Unlock()
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			connMu.Lock()
+			got, reused = true, info.Reused
+			if !info.Reused {
+				connectSpent = time.Since(dialStart)
+			}
+			connMu.Unlock()
+		},
+	}
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+
+	c.mu.Lock()
+	c.get(host).active++
+	c.mu.Unlock()
+
+	start := time.Now()
+	resp, err := rt.RoundTrip(req)
+	latency := time.Since(start)
+
+	connMu.Lock()
+	defer connMu.Unlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	h := c.get(host)
+	h.active--
+	h.requests++
+	if err != nil {
+		h.errors++
+	} else {
+		h.latencyTotal += latency
+		h.latencyMax = max(h.latencyMax, latency)
+	}
+	if got {
+		if reused {
+			h.reusedConns++
+		} else {
+			h.newConns++
+			h.connectTotal += connectSpent
+		}
+	}
+	return resp, err
+}
+
+// stats 获取各主机统计，按请求数降序
+func (c *connMetrics) stats() []HostConnStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result := make([]HostConnStats, 0, len(c.hosts))
+	for host, h := range c.hosts {
+		s := HostConnStats{
+			Host:         host,
+			Requests:     h.requests,
+			Errors:       h.errors,
+			Active:       h.active,
+			NewConns:     h.newConns,
+			ReusedConns:  h.reusedConns,
+			MaxLatencyMs: h.latencyMax.Milliseconds(),
+		}
+		if conns := h.newConns + h.reusedConns; conns > 0 {
+			s.ReuseRate = float64(h.reusedConns) / float64(conns)
+		}
+		if ok := h.requests - h.errors; ok > 0 {
+			s.AvgLatencyMs = float64(h.latencyTotal.Milliseconds()) / float64(ok)
+		}
+		if h.newConns > 0 {
+			s.AvgConnectMs = float64(h.connectTotal.Milliseconds()) / float64(h.newConns)
+		}
+		result = append(result, s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Requests != result[j].Requests {
+			return result[i].Requests > result[j].Requests
+		}
+		return result[i].Host < result[j].Host
+	})
+	return result
+}
+
+// reset 清空统计（保留进行中的请求计数）
+func (c *connMetrics) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for host, h := range c.hosts {
+		if h.active == 0 {
+			delete(c.hosts, host)
+			continue
+		}
+		c.hosts[host] = &hostMetrics{active: h.active}
+	}
+}
@@ -0,0 +1,382 @@
+package proxy
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dop251/goja"
+	"golang.org/x/sync/singleflight"
+)
+
+// PAC 脚本使用内嵌的 goja 引擎执行，PAC 标准函数（shExpMatch、dnsDomainIs、isInNet 等）由 Go 实现。
+// 脚本在加载时编译并试运行一次，语法错误或缺少 FindProxyForURL 时加载失败；
+// goja 运行时不能并发使用，每次执行新建运行时（含顶层语句，受 pacEvalTimeout 限制），按主机缓存的结果避免了重复执行。
+
+const pacEvalTimeout = 2 * time.Second // 单次 FindProxyForURL 执行上限（含 DNS 查询）
+
+// pacBuiltinNames 注入脚本运行时的 PAC 标准函数
+var pacBuiltinNames = []string{
+	"isPlainHostName", "dnsDomainIs", "localHostOrDomainIs", "shExpMatch", "dnsDomainLevels",
+	"isResolvable", "dnsResolve", "isInNet", "myIpAddress", "alert",
+}
+
+// pacScript 编译后的 PAC 脚本
+type pacScript struct {
+	program *goja.Program
+}
+
+// parsePAC 编译 PAC 脚本并检查 FindProxyForURL 是否存在
+func parsePAC(src string) (*pacScript, error) {
+	program, err := goja.Compile("proxy.pac", src, false)
+	if err != nil {
+		return nil, fmt.Errorf("PAC 脚本语法错误: %w", err)
+	}
+	script := &pacScript{program: program}
+	vm := newPACRuntime()
+	defer vm.stop()
+	if _, err := script.prepare(vm.Runtime); err != nil {
+		return nil, err
+	}
+	return script, nil
+}
+
+// pacRuntime 带执行时限的运行时，超时后中断脚本
+type pacRuntime struct {
+	*goja.Runtime
+	timer *time.Timer
+}
+
+func newPACRuntime() *pacRuntime {
+	vm := &pacRuntime{Runtime: goja.New()}
+	vm.timer = time.AfterFunc(pacEvalTimeout, func() { vm.Interrupt("PAC 执行超时") })
+	return vm
+}
+
+func (vm *pacRuntime) stop() { vm.timer.Stop() }
+
+// prepare 注入标准函数并执行脚本顶层语句，返回 FindProxyForURL
+func (s *pacScript) prepare(vm *goja.Runtime) (goja.Callable, error) {
+	for _, name := range pacBuiltinNames {
+		if err := vm.Set(name, func(call goja.FunctionCall) goja.Value {
+			args := make([]string, len(call.Arguments))
+			for i, a := range call.Arguments {
+				args[i] = a.String()
+			}
+			return vm.ToValue(pacBuiltin(name, args))
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := vm.RunProgram(s.program); err != nil {
+		return nil, fmt.Errorf("PAC 脚本执行失败: %w", err)
+	}
+	fn, ok := goja.AssertFunction(vm.Get("FindProxyForURL"))
+	if !ok {
+		return nil, fmt.Errorf("PAC 脚本缺少 FindProxyForURL")
+	}
+	return fn, nil
+}
+
+// FindProxy 执行 FindProxyForURL，返回 PAC 结果字符串，如 "PROXY 127.0.0.1:8080; DIRECT"
+func (s *pacScript) FindProxy(rawURL, host string) (string, error) {
+	vm := newPACRuntime()
+	defer vm.stop()
+	fn, err := s.prepare(vm.Runtime)
+	if err != nil {
+		return "", err
+	}
+	v, err := fn(goja.Undefined(), vm.ToValue(rawURL), vm.ToValue(host))
+	if err != nil {
+		return "", err
+	}
+	result, ok := v.Export().(string)
+	if !ok {
+		return "", fmt.Errorf("FindProxyForURL 返回值不是字符串: %v", v)
+	}
+	return result, nil
+}
+
+// parsePACResult 解析 PAC 结果，取第一个可用项；DIRECT 返回 nil
+func parsePACResult(result string) (*url.URL, error) {
+	for _, item := range strings.Split(result, ";") {
+		fields := strings.Fields(item)
+		if len(fields) == 0 {
+			continue
+		}
+		var scheme string
+		switch strings.ToUpper(fields[0]) {
+		case "DIRECT":
+			return nil, nil
+		case "PROXY", "HTTP":
+			scheme = "http"
+		case "HTTPS":
+			scheme = "https"
+		case "SOCKS", "SOCKS5":
+			scheme = "socks5"
+		default:
+			continue // SOCKS4 等 Go 不支持的类型跳过
+		}
+		if len(fields) < 2 {
+			continue
+		}
+		return url.Parse(scheme + "://" + fields[1])
+	}
+	return nil, nil
+}
+
+// ========== PAC 标准函数 ==========
+
+// pacBuiltin 执行 PAC 标准函数
+func pacBuiltin(name string, args []string) any {
+	arg := func(i int) string {
+		if i < len(args) {
+			return args[i]
+		}
+		return ""
+	}
+	switch name {
+	case "isPlainHostName":
+		return !strings.Contains(arg(0), ".")
+	case "dnsDomainIs":
+		return strings.HasSuffix(strings.ToLower(arg(0)), strings.ToLower(arg(1)))
+	case "localHostOrDomainIs":
+		host, hostdom := strings.ToLower(arg(0)), strings.ToLower(arg(1))
+		return host == hostdom || !strings.Contains(host, ".") && strings.HasPrefix(hostdom, host+".")
+	case "shExpMatch":
+		return shExpMatch(arg(0), arg(1))
+	case "dnsDomainLevels":
+		return float64(strings.Count(arg(0), "."))
+	case "isResolvable":
+		return pacResolve(arg(0)) != ""
+	case "dnsResolve":
+		if ip := pacResolve(arg(0)); ip != "" {
+			return ip
+		}
+		return nil
+	case "isInNet":
+		ip := net.ParseIP(pacResolve(arg(0)))
+		pattern, mask := net.ParseIP(arg(1)).To4(), net.ParseIP(arg(2)).To4()
+		if ip == nil || ip.To4() == nil || pattern == nil || mask == nil {
+			return false
+		}
+		m := net.IPMask(mask)
+		return ip.To4().Mask(m).Equal(pattern.Mask(m))
+	case "myIpAddress":
+		return localIPAddress()
+	case "alert":
+		return nil
+	}
+	return nil
+}
+
+// shExpRegexps 已编译的 shExpMatch 通配符，模式来自 PAC 脚本和系统例外列表，数量有限
+var shExpRegexps sync.Map // pattern -> *regexp.Regexp
+
+// shExpMatch shell 通配符匹配（* 与 ? 可跨越 / 和 .）
+func shExpMatch(s, pattern string) bool {
+	if re, ok := shExpRegexps.Load(pattern); ok {
+		return re.(*regexp.Regexp).MatchString(s)
+	}
+	var sb strings.Builder
+	sb.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			sb.WriteString(".*")
+		case '?':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString("$")
+	re, err := regexp.Compile(sb.String())
+	if err != nil {
+		return false
+	}
+	shExpRegexps.Store(pattern, re)
+	return re.MatchString(s)
+}
+
+// pacResolve 解析主机的 IPv4 地址，失败返回空串
+func pacResolve(host string) string {
+	if ip := net.ParseIP(host); ip != nil {
+		return ip.String()
+	}
+	addrs, err := net.LookupHost(host)
+	if err != nil {
+		return ""
+	}
+	for _, addr := range addrs {
+		if ip := net.ParseIP(addr); ip != nil && ip.To4() != nil {
+			return addr
+		}
+	}
+	return ""
+}
+
+// localIPAddress 获取本机出口 IP（UDP 连接不会实际发包）
+func localIPAddress() string {
+	conn, err := net.Dial("udp", "8.8.8.8:53")
+	if err != nil {
+		return "127.0.0.1"
+	}
+	defer conn.Close()
+	if addr, ok := conn.LocalAddr().(*net.UDPAddr); ok {
+		return addr.IP.String()
+	}
+	return "127.0.0.1"
+}
+
+// ========== 脚本加载 ==========
+
+const (
+	pacScriptTTL  = 10 * time.Minute // PAC 脚本缓存时间
+	pacResultTTL  = time.Minute      // 单个主机的代理结果缓存时间
+	pacMaxResults = 1024             // 结果缓存条数上限，超出后清空
+)
+
+// pacResult 缓存的主机代理结果
+type pacResult struct {
+	proxy *url.URL
+	at    time.Time
+}
+
+// pacLoader 下载、缓存 PAC 脚本并按主机缓存执行结果
+// 锁只保护缓存字段，下载和执行都在锁外进行，避免慢速的 PAC 服务器或 DNS 查询阻塞其他请求；
+// 同一脚本地址的并发加载通过 singleflight 合并为一次
+type pacLoader struct {
+	mu       sync.Mutex
+	url      string
+	script   *pacScript
+	loadedAt time.Time
+	results  map[string]pacResult
+	loads    singleflight.Group
+}
+
+// proxyFor 使用 PAC 脚本为请求选择代理；脚本不可用时直连
+func (l *pacLoader) proxyFor(pacURL string, req *http.Request) (*url.URL, error) {
+	host := req.URL.Hostname()
+	script, ok := l.cached(pacURL)
+	if !ok {
+		script = l.load(pacURL)
+	}
+	if script == nil {
+		return nil, nil
+	}
+
+	l.mu.Lock()
+	r, hit := l.results[host]
+	l.mu.Unlock()
+	if hit && time.Since(r.at) < pacResultTTL {
+		return r.proxy, nil
+	}
+
+	// PAC 规范中 https 地址只传递 scheme 和 host，避免泄露路径
+	rawURL := req.URL.String()
+	if req.URL.Scheme == "https" {
+		rawURL = "https://" + req.URL.Host + "/"
+	}
+	result, err := script.FindProxy(rawURL, host)
+	var proxyURL *url.URL
+	if err == nil {
+		proxyURL, err = parsePACResult(result)
+	}
+	if err != nil {
+		log.Warn("PAC 执行失败，%s 直连: %v", host, err)
+		proxyURL = nil
+	}
+
+	l.mu.Lock()
+	// 执行期间脚本可能已被重新加载，旧脚本的结果不再写入缓存
+	if l.script == script {
+		if len(l.results) >= pacMaxResults {
+			l.results = make(map[string]pacResult)
+		}
+		l.results[host] = pacResult{proxy: proxyURL, at: time.Now()}
+	}
+	l.mu.Unlock()
+	return proxyURL, nil
+}
+
+// cached 返回未过期的脚本，ok 为 false 表示需要重新加载
+func (l *pacLoader) cached(pacURL string) (*pacScript, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.url != pacURL || time.Since(l.loadedAt) > pacScriptTTL {
+		return nil, false
+	}
+	return l.script, true
+}
+
+// reset 清空缓存，下次请求重新加载脚本
+func (l *pacLoader) reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.url = ""
+	l.script = nil
+	l.results = nil
+}
+
+// load 加载并解析 PAC 脚本，失败时保留为空（直连），在缓存过期后重试
+func (l *pacLoader) load(pacURL string) *pacScript {
+	v, _, _ := l.loads.Do(pacURL, func() (any, error) {
+		src, err := fetchPAC(pacURL)
+		var script *pacScript
+		if err == nil {
+			script, err = parsePAC(src)
+		}
+		if err != nil {
+			log.Warn("加载 PAC 脚本失败 %s: %v", pacURL, err)
+		} else {
+			log.Info("已加载 PAC 脚本: %s", pacURL)
+		}
+
+		l.mu.Lock()
+		l.url = pacURL
+		l.loadedAt = time.Now()
+		l.script = script
+		l.results = make(map[string]pacResult)
+		l.mu.Unlock()
+		return script, nil
+	})
+	script, _ := v.(*pacScript)
+	return script
+}
+
+// pacHTTPClient 下载 PAC 脚本的客户端，脚本本身必须直连下载
+var pacHTTPClient = &http.Client{Timeout: 10 * time.Second, Transport: &http.Transport{Proxy: nil}}
+
+// fetchPAC 读取 PAC 脚本，支持 http(s)://、file:// 和本地路径
+func fetchPAC(pacURL string) (string, error) {
+	if strings.HasPrefix(pacURL, "http://") || strings.HasPrefix(pacURL, "https://") {
+		resp, err := pacHTTPClient.Get(pacURL)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("HTTP %d", resp.StatusCode)
+		}
+		data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		return string(data), err
+	}
+	path := pacURL
+	if u, err := url.Parse(pacURL); err == nil && u.Scheme == "file" {
+		path = u.Path
+		if runtime.GOOS == "windows" {
+			path = strings.TrimPrefix(path, "/")
+		}
+	}
+	data, err := os.ReadFile(path)
+	return string(data), err
+}
@@ -0,0 +1,217 @@
+package proxy
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testPAC = `
+// 公司内网直连，其余走代理
+var corp = ".corp.example.com";
+
+function isIntranet(host) {
+	return isPlainHostName(host) || dnsDomainIs(host, corp) || isInNet(host, "10.0.0.0", "255.0.0.0");
+}
+
+function FindProxyForURL(url, host) {
+	host = host.toLowerCase();
+	if (isIntranet(host)) return "DIRECT";
+	/* 行情接口单独代理 */
+	if (shExpMatch(host, "*.sinajs.cn") || shExpMatch(url, "http://*.eastmoney.com/*")) {
+		return "PROXY quote-proxy:3128; DIRECT";
+	} else if (host.indexOf("openai") >= 0) {
+		return "SOCKS5 127.0.0.1:1080";
+	}
+	var p = "PROXY " + "gw:" + 8080;
+	return host == "example.org" ? "DIRECT" : p;
+}
+`
+
+func TestPACFindProxy(t *testing.T) {
+	script, err := parsePAC(testPAC)
+	if err != nil {
+		t.Fatalf("parsePAC: %v", err)
+	}
+	cases := []struct{ url, host, want string }{
+		{"http://wiki/", "wiki", "DIRECT"},
+		{"https://git.CORP.example.com/", "git.CORP.example.com", "DIRECT"},
+		{"http://10.1.2.3/", "10.1.2.3", "DIRECT"},
+		{"http://hq.sinajs.cn/list=sh600519", "hq.sinajs.cn", "PROXY quote-proxy:3128; DIRECT"},
+		{"http://push2.eastmoney.com/api", "push2.eastmoney.com", "PROXY quote-proxy:3128; DIRECT"},
+		{"https://api.openai.com/", "api.openai.com", "SOCKS5 127.0.0.1:1080"},
+		{"https://example.org/", "example.org", "DIRECT"},
+		{"https://github.com/", "github.com", "PROXY gw:8080"},
+	}
+	for _, c := range cases {
+		got, err := script.FindProxy(c.url, c.host)
+		if err != nil || got != c.want {
+			t.Errorf("FindProxy(%s) = %q, %v, want %q", c.host, got, err, c.want)
+		}
+	}
+
+	for _, bad := range []string{"function foo() { return 1; }", "function FindProxyForURL(u, h) { return ", "throw new Error('boom');"} {
+		if _, err := parsePAC(bad); err == nil {
+			t.Errorf("parsePAC(%q) should fail", bad)
+		}
+	}
+}
+
+// TestPACFullSyntax 测试正则、循环、数组等完整 JS 语法
+func TestPACFullSyntax(t *testing.T) {
+	script, err := parsePAC(`
+var direct = ["localhost", /\.corp$/];
+function FindProxyForURL(url, host) {
+	for (var i = 0; i < direct.length; i++) {
+		var rule = direct[i];
+		if (typeof rule === "string" ? host === rule : rule.test(host)) return "DIRECT";
+	}
+	return ["PROXY", "gw:8080"].join(" ");
+}`)
+	if err != nil {
+		t.Fatalf("parsePAC: %v", err)
+	}
+	cases := map[string]string{"localhost": "DIRECT", "git.corp": "DIRECT", "github.com": "PROXY gw:8080"}
+	for host, want := range cases {
+		if got, err := script.FindProxy("http://"+host+"/", host); err != nil || got != want {
+			t.Errorf("FindProxy(%s) = %q, %v, want %q", host, got, err, want)
+		}
+	}
+}
+
+func TestParsePACResult(t *testing.T) {
+	cases := map[string]string{
+		"DIRECT":                       "",
+		"PROXY a:1; DIRECT":            "http://a:1",
+		"SOCKS4 s:1; HTTPS b:443":      "https://b:443",
+		"  SOCKS x:1080 ":              "socks5://x:1080",
+		"":                             "",
+		"DIRECT; PROXY unreachable:80": "",
+	}
+	for in, want := range cases {
+		u, err := parsePACResult(in)
+		got := ""
+		if u != nil {
+			got = u.String()
+		}
+		if err != nil || got != want {
+			t.Errorf("parsePACResult(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+}
+
+func TestPACLoader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "proxy.pac")
+	os.WriteFile(path, []byte(testPAC), 0644)
+
+	var l pacLoader
+	req, _ := http.NewRequest("GET", "https://github.com/run-bigpig/jcp", nil)
+	u, err := l.proxyFor(path, req)
+	if err != nil || u == nil || u.String() != "http://gw:8080" {
+		t.Fatalf("proxyFor = %v, %v", u, err)
+	}
+
+	// 脚本无法加载时直连
+	l.reset()
+	if u, err := l.proxyFor(filepath.Join(t.TempDir(), "missing.pac"), req); u != nil || err != nil {
+		t.Fatalf("missing script proxyFor = %v, %v", u, err)
+	}
+}
+
+func TestPACLoaderConcurrent(t *testing.T) {
+	var hits atomic.Int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+		w.Write([]byte(testPAC))
+	}))
+	defer srv.Close()
+
+	var l pacLoader
+	req, _ := http.NewRequest("GET", "https://github.com/", nil)
+	var wg sync.WaitGroup
+	errs := make(chan string, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if u, err := l.proxyFor(srv.URL, req); err != nil || u == nil || u.String() != "http://gw:8080" {
+				errs <- fmt.Sprintf("proxyFor = %v, %v", u, err)
+			}
+		}()
+	}
+
+	// 下载期间锁已释放，其他字段可以正常访问
+	time.Sleep(50 * time.Millisecond)
+	if _, ok := l.cached(srv.URL); ok {
+		t.Error("脚本尚未加载完成")
+	}
+	close(release)
+	wg.Wait()
+	close(errs)
+	for e := range errs {
+		t.Error(e)
+	}
+	if n := hits.Load(); n != 1 {
+		t.Errorf("并发加载应只下载一次，实际 %d 次", n)
+	}
+}
+
+func TestShExpMatchCache(t *testing.T) {
+	if !shExpMatch("hq.sinajs.cn", "*.sinajs.cn") || shExpMatch("sinajs.cn.evil.com", "*.sinajs.cn") {
+		t.Fatal("shExpMatch mismatch")
+	}
+	if _, ok := shExpRegexps.Load("*.sinajs.cn"); !ok {
+		t.Error("编译后的通配符应被缓存")
+	}
+	if !shExpMatch("a.sinajs.cn", "*.sinajs.cn") {
+		t.Error("命中缓存后匹配结果错误")
+	}
+}
+
+func TestParseSystemProxy(t *testing.T) {
+	win := parseWindowsProxy(`
+HKEY_CURRENT_USER\Software\Microsoft\Windows\CurrentVersion\Internet Settings
+    ProxyEnable    REG_DWORD    0x1
+    ProxyServer    REG_SZ    http=127.0.0.1:7890;https=127.0.0.1:7891;socks=127.0.0.1:7892
+    ProxyOverride    REG_SZ    localhost;*.corp.example.com;<local>
+`)
+	if win.Source != "windows" || win.HTTP != "http://127.0.0.1:7890" || win.HTTPS != "http://127.0.0.1:7891" || win.SOCKS != "socks5://127.0.0.1:7892" {
+		t.Errorf("parseWindowsProxy = %+v", win)
+	}
+	if !win.bypassed("git.corp.example.com") || !win.bypassed("intranet") || win.bypassed("github.com") {
+		t.Errorf("windows bypass = %v", win.Bypass)
+	}
+	if off := parseWindowsProxy("    ProxyEnable    REG_DWORD    0x0\n    ProxyServer    REG_SZ    127.0.0.1:7890\n"); off.Source != "" {
+		t.Errorf("disabled windows proxy = %+v", off)
+	}
+
+	mac := parseScutilProxy(`<dictionary> {
+  ExceptionsList : <array> {
+    0 : *.local
+    1 : 169.254.0.0/16
+  }
+  HTTPEnable : 1
+  HTTPPort : 7890
+  HTTPProxy : 127.0.0.1
+  HTTPSEnable : 0
+  ProxyAutoConfigEnable : 1
+  ProxyAutoConfigURLString : http://wpad.example.com/proxy.pac
+}`)
+	if mac.Source != "macos" || mac.HTTP != "http://127.0.0.1:7890" || mac.HTTPS != "" || mac.PacURL != "http://wpad.example.com/proxy.pac" {
+		t.Errorf("parseScutilProxy = %+v", mac)
+	}
+	if !mac.bypassed("printer.local") || !mac.bypassed("169.254.1.1") || mac.bypassed("example.com") {
+		t.Errorf("macos bypass = %v", mac.Bypass)
+	}
+	if got := mac.proxyForScheme("https"); got != "http://127.0.0.1:7890" {
+		t.Errorf("https falls back to http proxy, got %q", got)
+	}
+}
@@ -0,0 +1,107 @@
+package proxy
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+const (
+	defaultMaxIdleConnsPerHost = 8                // 未配置域名的每主机空闲连接数（Go 默认仅 2）
+	defaultIdleConnTimeout     = 90 * time.Second // 空闲连接保留时间
+)
+
+// DefaultConnPools 默认的上游连接池参数
+// 行情推送会高频轮询这些域名，空闲连接不足时每次都新建连接，
+// Windows 上大量 TIME_WAIT 会耗尽本地端口
+var DefaultConnPools = []models.HostConnPool{
+	{Host: "sinajs.cn", MaxIdleConnsPerHost: 32, MaxConnsPerHost: 64, IdleTimeout: 120},
+	{Host: "eastmoney.com", MaxIdleConnsPerHost: 16, MaxConnsPerHost: 32, IdleTimeout: 120},
+	{Host: "sina.cn", MaxIdleConnsPerHost: 8, MaxConnsPerHost: 16},
+}
+
+// hostPool 单个域名规则对应的独立 Transport
+type hostPool struct {
+	rule      models.HostConnPool
+	transport *http.Transport
+}
+
+// SetConnPools 更新上游域名连接池参数
+func (m *Manager) SetConnPools(rules []models.HostConnPool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.poolRules = rules
+	m.rebuildTransport()
+}
+
+// newTransport 创建带连接池参数的 Transport
+func newTransport(proxyFunc func(*http.Request) (*url.URL, error), rule models.HostConnPool) *http.Transport {
+	idleTimeout := defaultIdleConnTimeout
+	if rule.IdleTimeout > 0 {
+		idleTimeout = time.Duration(rule.IdleTimeout) * time.Second
+	}
+	maxIdle := rule.MaxIdleConnsPerHost
+	if maxIdle <= 0 {
+		maxIdle = defaultMaxIdleConnsPerHost
+	}
+	return &http.Transport{
+		Proxy: proxyFunc,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true, // 与 http.DefaultTransport 保持一致
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   maxIdle,
+		MaxConnsPerHost:       rule.MaxConnsPerHost,
+		IdleConnTimeout:       idleTimeout,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
+
+// buildPools 按规则创建各域名的 Transport，更具体的域名优先匹配
+func buildPools(proxyFunc func(*http.Request) (*url.URL, error), rules []models.HostConnPool) []*hostPool {
+	pools := make([]*hostPool, 0, len(rules))
+	for _, rule := range rules {
+		rule.Host = strings.ToLower(strings.TrimSpace(rule.Host))
+		if rule.Host == "" {
+			continue
+		}
+		pools = append(pools, &hostPool{rule: rule, transport: newTransport(proxyFunc, rule)})
+	}
+	sort.SliceStable(pools, func(i, j int) bool {
+		return len(pools[i].rule.Host) > len(pools[j].rule.Host)
+	})
+	return pools
+}
+
+// transportFor 按域名选择 Transport，未匹配规则时使用默认 Transport
+func (m *Manager) transportFor(host string) *http.Transport {
+	host = strings.ToLower(host)
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, p := range m.pools {
+		if host == p.rule.Host || strings.HasSuffix(host, "."+p.rule.Host) {
+			return p.transport
+		}
+	}
+	return m.transport
+}
+
+// pooledTransport 所有 Client 共享的 RoundTripper
+// 每次请求时取当前配置的 Transport，代理或连接池配置变更后已创建的 Client 也能立即生效
+type pooledTransport struct {
+	m *Manager
+}
+
+func (t *pooledTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Hostname()
+	return t.m.metrics.roundTrip(t.m.transportFor(host), host, req)
+}
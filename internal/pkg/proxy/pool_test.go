@@ -0,0 +1,59 @@
+package proxy
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+func TestConnPoolReuseMetrics(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	m := &Manager{
+		config:    &models.ProxyConfig{Mode: models.ProxyModeNone},
+		limiter:   NewHostLimiter(nil),
+		poolRules: []models.HostConnPool{{Host: "127.0.0.1", MaxIdleConnsPerHost: 4, IdleTimeout: 5}},
+	}
+	m.rebuildTransport()
+
+	if tr := m.transportFor("127.0.0.1"); tr.MaxIdleConnsPerHost != 4 || tr.IdleConnTimeout != 5*time.Second {
+		t.Fatalf("pool transport = %d, %v", tr.MaxIdleConnsPerHost, tr.IdleConnTimeout)
+	}
+	if tr := m.transportFor("example.com"); tr != m.transport || tr.MaxIdleConnsPerHost != defaultMaxIdleConnsPerHost {
+		t.Fatal("unmatched host should use default transport")
+	}
+
+	client := m.GetClientWithTimeout(5 * time.Second)
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+
+	stats := m.GetConnStats()
+	if len(stats) != 1 {
+		t.Fatalf("stats = %+v", stats)
+	}
+	s := stats[0]
+	if s.Host != "127.0.0.1" || s.Requests != 3 || s.NewConns != 1 || s.ReusedConns != 2 || s.Active != 0 {
+		t.Errorf("stats = %+v", s)
+	}
+	if s.ReuseRate < 0.66 || s.ReuseRate > 0.67 {
+		t.Errorf("reuse rate = %v", s.ReuseRate)
+	}
+
+	m.ResetConnStats()
+	if got := m.GetConnStats(); len(got) != 0 {
+		t.Errorf("after reset = %+v", got)
+	}
+}
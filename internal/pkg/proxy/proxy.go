@@ -1,26 +1,38 @@
 // Package proxy 提供应用级别的代理管理
-// 支持三种模式：无代理、系统代理、自定义代理
+// 支持四种模式：无代理、系统代理（自动检测，含系统 PAC）、自定义代理、PAC 脚本
 package proxy
 
 import (
 	"net"
 	"net/http"
 	"net/url"
-	"os/exec"
-	"runtime"
-	"strings"
 	"sync"
 	"time"
 
+	"github.com/run-bigpig/jcp/internal/logger"
 	"github.com/run-bigpig/jcp/internal/models"
 )
 
+var log = logger.New("proxy")
+
+// systemProxyTTL 系统代理检测结果缓存时间，避免每个请求都调用系统命令
+const systemProxyTTL = 30 * time.Second
+
 // Manager 代理管理器（单例）
 type Manager struct {
 	mu        sync.RWMutex
 	config    *models.ProxyConfig
 	transport *http.Transport
 	client    *http.Client
+	limiter   *HostLimiter
+	poolRules []models.HostConnPool
+	pools     []*hostPool
+	metrics   connMetrics
+
+	sysMu      sync.Mutex
+	sysProxy   SystemProxy
+	sysProxyAt time.Time
+	pac        pacLoader
 }
 
 var (
@@ -32,7 +44,9 @@ var (
 func GetManager() *Manager {
 	once.Do(func() {
 		instance = &Manager{
-			config: &models.ProxyConfig{Mode: models.ProxyModeNone},
+			config:    &models.ProxyConfig{Mode: models.ProxyModeNone},
+			limiter:   NewHostLimiter(DefaultRateLimits),
+			poolRules: DefaultConnPools,
 		}
 		instance.rebuildTransport()
 	})
@@ -46,6 +60,31 @@ func (m *Manager) SetConfig(cfg *models.ProxyConfig) {
 
 	m.config = cfg
 	m.rebuildTransport()
+
+	// 配置变更后重新检测系统代理、重新加载 PAC
+	m.sysMu.Lock()
+	m.sysProxyAt = time.Time{}
+	m.sysMu.Unlock()
+	m.pac.reset()
+}
+
+// DetectSystemProxy 立即重新检测并返回系统代理设置
+func (m *Manager) DetectSystemProxy() SystemProxy {
+	sp := detectSystemProxy()
+	m.sysMu.Lock()
+	m.sysProxy, m.sysProxyAt = sp, time.Now()
+	m.sysMu.Unlock()
+	return sp
+}
+
+// systemProxy 获取缓存的系统代理设置，过期后重新检测
+func (m *Manager) systemProxy() SystemProxy {
+	m.sysMu.Lock()
+	defer m.sysMu.Unlock()
+	if time.Since(m.sysProxyAt) > systemProxyTTL {
+		m.sysProxy, m.sysProxyAt = detectSystemProxy(), time.Now()
+	}
+	return m.sysProxy
 }
 
 // GetConfig 获取当前代理配置
@@ -55,7 +94,27 @@ func (m *Manager) GetConfig() *models.ProxyConfig {
 	return m.config
 }
 
-// GetTransport 获取配置好代理的 Transport（用于自定义 Client）
+// SetRateLimits 更新上游域名限流规则
+func (m *Manager) SetRateLimits(rules []models.HostRateLimit) {
+	m.limiter.SetRules(rules)
+}
+
+// GetRateLimitStats 获取上游域名限流使用情况
+func (m *Manager) GetRateLimitStats() []HostRateStats {
+	return m.limiter.Stats()
+}
+
+// GetConnStats 获取各上游主机的连接复用与延迟统计
+func (m *Manager) GetConnStats() []HostConnStats {
+	return m.metrics.stats()
+}
+
+// ResetConnStats 清空连接统计
+func (m *Manager) ResetConnStats() {
+	m.metrics.reset()
+}
+
+// GetTransport 获取配置好代理的 Transport（用于自定义 Client，不经过限流）
 func (m *Manager) GetTransport() *http.Transport {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -69,152 +128,66 @@ func (m *Manager) GetClient() *http.Client {
 	return m.client
 }
 
-// GetClientWithTimeout 获取带自定义超时的 HTTP Client
+// GetClientWithTimeout 获取带自定义超时的 HTTP Client（按域名限流，共享连接池）
 func (m *Manager) GetClientWithTimeout(timeout time.Duration) *http.Client {
 	return &http.Client{
-		Transport: m.GetTransport(),
+		Transport: &limitedTransport{base: &pooledTransport{m: m}, limiter: m.limiter},
 		Timeout:   timeout,
 	}
 }
 
-// rebuildTransport 根据当前配置重建 Transport
+// rebuildTransport 根据当前配置重建 Transport（调用方需持有写锁）
 func (m *Manager) rebuildTransport() {
-	m.transport = &http.Transport{
-		DialContext: (&net.Dialer{
-			Timeout:   30 * time.Second,
-			KeepAlive: 30 * time.Second,
-		}).DialContext,
-		ForceAttemptHTTP2:     true, // 与 http.DefaultTransport 保持一致
-		MaxIdleConns:          100,
-		IdleConnTimeout:       90 * time.Second,
-		TLSHandshakeTimeout:   10 * time.Second,
-		ExpectContinueTimeout: 1 * time.Second,
-	}
-
+	var proxyFunc func(*http.Request) (*url.URL, error)
 	switch m.config.Mode {
-	case models.ProxyModeNone:
-		m.transport.Proxy = nil
-
 	case models.ProxyModeSystem:
-		m.transport.Proxy = m.systemProxyFunc
+		proxyFunc = m.systemProxyFunc
 
 	case models.ProxyModeCustom:
 		if m.config.CustomURL != "" {
 			if proxyURL, err := url.Parse(m.config.CustomURL); err == nil {
-				m.transport.Proxy = http.ProxyURL(proxyURL)
+				proxyFunc = http.ProxyURL(proxyURL)
+			}
+		}
+
+	case models.ProxyModePAC:
+		if pacURL := m.config.PacURL; pacURL != "" {
+			proxyFunc = func(req *http.Request) (*url.URL, error) {
+				return m.pac.proxyFor(pacURL, req)
 			}
 		}
 	}
 
+	// 旧连接可能仍指向之前的代理，释放空闲连接
+	if m.transport != nil {
+		m.transport.CloseIdleConnections()
+	}
+	for _, p := range m.pools {
+		p.transport.CloseIdleConnections()
+	}
+
+	m.transport = newTransport(proxyFunc, models.HostConnPool{})
+	m.pools = buildPools(proxyFunc, m.poolRules)
 	m.client = &http.Client{
-		Transport: m.transport,
+		Transport: &limitedTransport{base: &pooledTransport{m: m}, limiter: m.limiter},
 		Timeout:   30 * time.Second,
 	}
 }
 
 // systemProxyFunc 获取系统代理（作为 Transport.Proxy 函数）
+// 系统配置了自动配置脚本时优先按 PAC 选择，否则按协议选择并排除例外主机
 func (m *Manager) systemProxyFunc(req *http.Request) (*url.URL, error) {
-	// 优先使用环境变量
-	if proxy, err := http.ProxyFromEnvironment(req); proxy != nil || err != nil {
-		return proxy, err
+	sp := m.systemProxy()
+	if sp.PacURL != "" {
+		return m.pac.proxyFor(sp.PacURL, req)
 	}
-
-	// 根据操作系统获取系统级代理
-	proxyStr := m.getOSProxy()
+	host := req.URL.Hostname()
+	if host == "localhost" || net.ParseIP(host).IsLoopback() || sp.bypassed(host) {
+		return nil, nil
+	}
+	proxyStr := sp.proxyForScheme(req.URL.Scheme)
 	if proxyStr == "" {
 		return nil, nil
 	}
 	return url.Parse(proxyStr)
 }
-
-// getOSProxy 根据操作系统获取系统代理设置
-func (m *Manager) getOSProxy() string {
-	switch runtime.GOOS {
-	case "windows":
-		return m.getWindowsProxy()
-	case "darwin":
-		return m.getMacOSProxy()
-	default:
-		return "" // Linux 通常依赖环境变量
-	}
-}
-
-// getWindowsProxy 从 Windows 注册表读取系统代理
-func (m *Manager) getWindowsProxy() string {
-	// 检查代理是否启用
-	enableCmd := exec.Command("reg", "query",
-		`HKCU\Software\Microsoft\Windows\CurrentVersion\Internet Settings`,
-		"/v", "ProxyEnable")
-	enableOut, err := enableCmd.Output()
-	if err != nil {
-		return ""
-	}
-	// ProxyEnable 为 0x1 表示启用
-	if !strings.Contains(string(enableOut), "0x1") {
-		return ""
-	}
-
-	// 获取代理服务器地址
-	serverCmd := exec.Command("reg", "query",
-		`HKCU\Software\Microsoft\Windows\CurrentVersion\Internet Settings`,
-		"/v", "ProxyServer")
-	serverOut, err := serverCmd.Output()
-	if err != nil {
-		return ""
-	}
-
-	// 解析输出，格式: "    ProxyServer    REG_SZ    127.0.0.1:7890"
-	lines := strings.Split(string(serverOut), "\n")
-	for _, line := range lines {
-		if strings.Contains(line, "ProxyServer") {
-			fields := strings.Fields(line)
-			if len(fields) >= 3 {
-				proxy := fields[len(fields)-1]
-				if !strings.HasPrefix(proxy, "http") {
-					proxy = "http://" + proxy
-				}
-				return proxy
-			}
-		}
-	}
-	return ""
-}
-
-// getMacOSProxy 从 macOS 系统偏好设置读取代理
-func (m *Manager) getMacOSProxy() string {
-	cmd := exec.Command("scutil", "--proxy")
-	output, err := cmd.Output()
-	if err != nil {
-		return ""
-	}
-
-	lines := strings.Split(string(output), "\n")
-	var httpEnabled, host, port string
-
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if strings.HasPrefix(line, "HTTPEnable") {
-			parts := strings.Split(line, ":")
-			if len(parts) >= 2 {
-				httpEnabled = strings.TrimSpace(parts[1])
-			}
-		}
-		if strings.HasPrefix(line, "HTTPProxy") {
-			parts := strings.Split(line, ":")
-			if len(parts) >= 2 {
-				host = strings.TrimSpace(parts[1])
-			}
-		}
-		if strings.HasPrefix(line, "HTTPPort") {
-			parts := strings.Split(line, ":")
-			if len(parts) >= 2 {
-				port = strings.TrimSpace(parts[1])
-			}
-		}
-	}
-
-	if httpEnabled == "1" && host != "" && port != "" {
-		return "http://" + host + ":" + port
-	}
-	return ""
-}
@@ -0,0 +1,178 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// DefaultRateLimits 默认的上游限流规则，避免推送与用户操作叠加导致IP被封
+var DefaultRateLimits = []models.HostRateLimit{
+	{Host: "sinajs.cn", Rate: 5, Burst: 10},
+	{Host: "sina.cn", Rate: 3, Burst: 6},
+	{Host: "eastmoney.com", Rate: 5, Burst: 10},
+}
+
+// HostRateStats 单个限流规则的使用情况
+type HostRateStats struct {
+	Host       string  `json:"host"`
+	Rate       float64 `json:"rate"`       // 每秒请求数
+	Burst      int     `json:"burst"`      // 突发容量
+	Tokens     float64 `json:"tokens"`     // 当前剩余令牌
+	Requests   int64   `json:"requests"`   // 累计放行请求数
+	Throttled  int64   `json:"throttled"`  // 累计需要等待的请求数
+	WaitMillis int64   `json:"waitMillis"` // 累计等待时长(毫秒)
+}
+
+// tokenBucket 令牌桶
+type tokenBucket struct {
+	rule   models.HostRateLimit
+	tokens float64
+	last   time.Time
+	stats  HostRateStats
+}
+
+// reserve 取一个令牌，返回需要等待的时长（调用方需持有锁）
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.tokens += now.Sub(b.last).Seconds() * b.rule.Rate
+	if burst := float64(b.rule.Burst); b.tokens > burst {
+		b.tokens = burst
+	}
+	b.last = now
+	b.tokens--
+	b.stats.Requests++
+	if b.tokens >= 0 {
+		return 0
+	}
+	wait := time.Duration(-b.tokens / b.rule.Rate * float64(time.Second))
+	b.stats.Throttled++
+	b.stats.WaitMillis += wait.Milliseconds()
+	return wait
+}
+
+// cancel 取消一次未生效的预约，归还令牌，使排在后面的请求不必为已放弃的请求等待（调用方需持有锁）
+func (b *tokenBucket) cancel() {
+	b.tokens = min(b.tokens+1, float64(b.rule.Burst))
+	b.stats.Requests--
+}
+
+// HostLimiter 按上游域名限流，规则按域名后缀匹配
+type HostLimiter struct {
+	mu      sync.Mutex
+	buckets []*tokenBucket
+}
+
+// NewHostLimiter 创建域名限流器
+func NewHostLimiter(rules []models.HostRateLimit) *HostLimiter {
+	l := &HostLimiter{}
+	l.SetRules(rules)
+	return l
+}
+
+// SetRules 更新限流规则，已有规则保留当前令牌数
+func (l *HostLimiter) SetRules(rules []models.HostRateLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	old := make(map[string]*tokenBucket, len(l.buckets))
+	for _, b := range l.buckets {
+		old[b.rule.Host] = b
+	}
+
+	now := time.Now()
+	buckets := make([]*tokenBucket, 0, len(rules))
+	for _, rule := range rules {
+		rule.Host = strings.ToLower(strings.TrimSpace(rule.Host))
+		if rule.Host == "" || rule.Rate <= 0 {
+			continue
+		}
+		if rule.Burst <= 0 {
+			rule.Burst = 1
+		}
+		b, ok := old[rule.Host]
+		if !ok {
+			b = &tokenBucket{tokens: float64(rule.Burst), last: now}
+		}
+		b.rule = rule
+		buckets = append(buckets, b)
+	}
+	// 更长（更具体）的域名优先匹配
+	sort.SliceStable(buckets, func(i, j int) bool {
+		return len(buckets[i].rule.Host) > len(buckets[j].rule.Host)
+	})
+	l.buckets = buckets
+}
+
+// match 查找域名对应的令牌桶（调用方需持有锁）
+func (l *HostLimiter) match(host string) *tokenBucket {
+	host = strings.ToLower(host)
+	for _, b := range l.buckets {
+		if host == b.rule.Host || strings.HasSuffix(host, "."+b.rule.Host) {
+			return b
+		}
+	}
+	return nil
+}
+
+// Wait 等待直到允许向 host 发起请求，未配置规则的域名不限流
+func (l *HostLimiter) Wait(ctx context.Context, host string) error {
+	l.mu.Lock()
+	b := l.match(host)
+	if b == nil {
+		l.mu.Unlock()
+		return nil
+	}
+	wait := b.reserve(time.Now())
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		l.mu.Lock()
+		b.cancel()
+		l.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Stats 获取各规则当前使用情况
+func (l *HostLimiter) Stats() []HostRateStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	result := make([]HostRateStats, 0, len(l.buckets))
+	for _, b := range l.buckets {
+		s := b.stats
+		s.Host = b.rule.Host
+		s.Rate = b.rule.Rate
+		s.Burst = b.rule.Burst
+		s.Tokens = min(b.tokens+now.Sub(b.last).Seconds()*b.rule.Rate, float64(b.rule.Burst))
+		result = append(result, s)
+	}
+	return result
+}
+
+// limitedTransport 在发送请求前按域名限流
+type limitedTransport struct {
+	base    http.RoundTripper
+	limiter *HostLimiter
+}
+
+func (t *limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context(), req.URL.Hostname()); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
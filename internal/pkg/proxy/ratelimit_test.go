@@ -0,0 +1,63 @@
+package proxy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+func TestHostLimiterMatchAndReserve(t *testing.T) {
+	l := NewHostLimiter([]models.HostRateLimit{
+		{Host: "sina.cn", Rate: 1, Burst: 1},
+		{Host: "quotes.sina.cn", Rate: 2, Burst: 2},
+	})
+
+	if b := l.match("quotes.sina.cn"); b == nil || b.rule.Host != "quotes.sina.cn" {
+		t.Fatalf("应优先匹配更具体的域名")
+	}
+	if b := l.match("finance.sina.cn"); b == nil || b.rule.Host != "sina.cn" {
+		t.Fatalf("子域名应匹配后缀规则")
+	}
+	if l.match("notsina.cn") != nil || l.match("api.openai.com") != nil {
+		t.Fatalf("未配置的域名不应限流")
+	}
+
+	b := l.match("quotes.sina.cn")
+	now := b.last
+	// 突发容量内不需要等待
+	if b.reserve(now) != 0 || b.reserve(now) != 0 {
+		t.Fatal("突发容量内不应等待")
+	}
+	if wait := b.reserve(now); wait != 500*time.Millisecond {
+		t.Errorf("超出突发后应等待 500ms, got %v", wait)
+	}
+	// 1 秒后补充 2 个令牌，抵消之前透支的 1 个
+	if wait := b.reserve(now.Add(time.Second)); wait != 0 {
+		t.Errorf("令牌补充后不应等待, got %v", wait)
+	}
+
+	stats := l.Stats()
+	if stats[0].Requests != 4 || stats[0].Throttled != 1 {
+		t.Errorf("统计错误: %+v", stats[0])
+	}
+}
+
+func TestHostLimiterWaitCancel(t *testing.T) {
+	l := NewHostLimiter([]models.HostRateLimit{{Host: "sina.cn", Rate: 1, Burst: 1}})
+	if err := l.Wait(context.Background(), "sina.cn"); err != nil {
+		t.Fatal(err)
+	}
+
+	// 等待期间取消应归还令牌，不占用后续请求的配额
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx, "sina.cn"); err == nil {
+		t.Fatal("取消后应返回错误")
+	}
+	stats := l.Stats()
+	if stats[0].Requests != 1 || stats[0].Tokens < 0 {
+		t.Errorf("取消的预约未归还: %+v", stats[0])
+	}
+}
@@ -0,0 +1,263 @@
+package proxy
+
+import (
+	"net"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// SystemProxy 检测到的操作系统代理设置
+type SystemProxy struct {
+	Source string   `json:"source"` // 来源：env / windows / macos / gnome，为空表示未检测到
+	HTTP   string   `json:"http"`   // HTTP 代理地址
+	HTTPS  string   `json:"https"`  // HTTPS 代理地址
+	SOCKS  string   `json:"socks"`  // SOCKS5 代理地址
+	PacURL string   `json:"pacUrl"` // 自动配置脚本地址
+	Bypass []string `json:"bypass"` // 不走代理的主机
+}
+
+// detectSystemProxy 按环境变量、操作系统设置的顺序检测系统代理
+func detectSystemProxy() SystemProxy {
+	if sp := envProxy(); sp.Source != "" {
+		return sp
+	}
+	switch runtime.GOOS {
+	case "windows":
+		out, err := exec.Command("reg", "query",
+			`HKCU\Software\Microsoft\Windows\CurrentVersion\Internet Settings`).Output()
+		if err != nil {
+			return SystemProxy{}
+		}
+		return parseWindowsProxy(string(out))
+	case "darwin":
+		out, err := exec.Command("scutil", "--proxy").Output()
+		if err != nil {
+			return SystemProxy{}
+		}
+		return parseScutilProxy(string(out))
+	default:
+		return gnomeProxy()
+	}
+}
+
+// envProxy 读取 HTTP_PROXY / HTTPS_PROXY / ALL_PROXY / NO_PROXY 环境变量
+func envProxy() SystemProxy {
+	get := func(name string) string {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+		return os.Getenv(strings.ToLower(name))
+	}
+	sp := SystemProxy{HTTP: get("HTTP_PROXY"), HTTPS: get("HTTPS_PROXY"), SOCKS: get("ALL_PROXY")}
+	if sp.HTTP == "" && sp.HTTPS == "" && sp.SOCKS == "" {
+		return SystemProxy{}
+	}
+	sp.Source = "env"
+	sp.HTTP = normalizeProxyURL(sp.HTTP, "http")
+	sp.HTTPS = normalizeProxyURL(sp.HTTPS, "http")
+	sp.SOCKS = normalizeProxyURL(sp.SOCKS, "socks5")
+	sp.Bypass = splitList(get("NO_PROXY"), ",")
+	return sp
+}
+
+// parseWindowsProxy 解析 reg query Internet Settings 的输出
+// ProxyServer 可能是 "host:port"，也可能是 "http=h:p;https=h:p;socks=h:p"
+func parseWindowsProxy(out string) SystemProxy {
+	values := map[string]string{}
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 3 && strings.HasPrefix(fields[1], "REG_") {
+			values[fields[0]] = strings.Join(fields[2:], " ")
+		}
+	}
+
+	sp := SystemProxy{PacURL: values["AutoConfigURL"]}
+	if values["ProxyEnable"] == "0x1" {
+		server := values["ProxyServer"]
+		if strings.Contains(server, "=") {
+			for _, part := range splitList(server, ";") {
+				k, v, _ := strings.Cut(part, "=")
+				switch strings.ToLower(k) {
+				case "http":
+					sp.HTTP = normalizeProxyURL(v, "http")
+				case "https":
+					sp.HTTPS = normalizeProxyURL(v, "http")
+				case "socks":
+					sp.SOCKS = normalizeProxyURL(v, "socks5")
+				}
+			}
+		} else if server != "" {
+			sp.HTTP = normalizeProxyURL(server, "http")
+			sp.HTTPS = sp.HTTP
+		}
+		sp.Bypass = splitList(values["ProxyOverride"], ";")
+	}
+	if sp.HTTP != "" || sp.HTTPS != "" || sp.SOCKS != "" || sp.PacURL != "" {
+		sp.Source = "windows"
+	}
+	return sp
+}
+
+// parseScutilProxy 解析 macOS scutil --proxy 的输出
+func parseScutilProxy(out string) SystemProxy {
+	values := map[string]string{}
+	var bypass []string
+	inExceptions := false
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if inExceptions {
+			if line == "}" {
+				inExceptions = false
+			} else if _, v, ok := strings.Cut(line, " : "); ok {
+				bypass = append(bypass, strings.TrimSpace(v))
+			}
+			continue
+		}
+		k, v, ok := strings.Cut(line, " : ")
+		if !ok {
+			continue
+		}
+		k, v = strings.TrimSpace(k), strings.TrimSpace(v)
+		if k == "ExceptionsList" {
+			inExceptions = strings.HasSuffix(v, "{")
+			continue
+		}
+		values[k] = v
+	}
+
+	server := func(prefix, scheme string) string {
+		host, port := values[prefix+"Proxy"], values[prefix+"Port"]
+		if values[prefix+"Enable"] != "1" || host == "" {
+			return ""
+		}
+		if port != "" {
+			host = net.JoinHostPort(host, port)
+		}
+		return scheme + "://" + host
+	}
+	sp := SystemProxy{
+		HTTP:   server("HTTP", "http"),
+		HTTPS:  server("HTTPS", "http"),
+		SOCKS:  server("SOCKS", "socks5"),
+		Bypass: bypass,
+	}
+	if values["ProxyAutoConfigEnable"] == "1" {
+		sp.PacURL = values["ProxyAutoConfigURLString"]
+	}
+	if sp.HTTP != "" || sp.HTTPS != "" || sp.SOCKS != "" || sp.PacURL != "" {
+		sp.Source = "macos"
+	}
+	return sp
+}
+
+// gnomeProxy 通过 gsettings 读取 GNOME 桌面代理设置
+func gnomeProxy() SystemProxy {
+	get := func(schema, key string) string {
+		out, err := exec.Command("gsettings", "get", schema, key).Output()
+		if err != nil {
+			return ""
+		}
+		return strings.Trim(strings.TrimSpace(string(out)), "'")
+	}
+
+	sp := SystemProxy{}
+	switch get("org.gnome.system.proxy", "mode") {
+	case "auto":
+		sp.PacURL = get("org.gnome.system.proxy", "autoconfig-url")
+	case "manual":
+		server := func(kind, scheme string) string {
+			host := get("org.gnome.system.proxy."+kind, "host")
+			port := get("org.gnome.system.proxy."+kind, "port")
+			if host == "" || port == "" || port == "0" {
+				return ""
+			}
+			return scheme + "://" + net.JoinHostPort(host, port)
+		}
+		sp.HTTP = server("http", "http")
+		sp.HTTPS = server("https", "http")
+		sp.SOCKS = server("socks", "socks5")
+		// 格式：['localhost', '127.0.0.0/8']
+		ignore := strings.Trim(get("org.gnome.system.proxy", "ignore-hosts"), "[]")
+		for _, h := range splitList(ignore, ",") {
+			sp.Bypass = append(sp.Bypass, strings.Trim(h, "'"))
+		}
+	default:
+		return sp
+	}
+	if sp.HTTP != "" || sp.HTTPS != "" || sp.SOCKS != "" || sp.PacURL != "" {
+		sp.Source = "gnome"
+	}
+	return sp
+}
+
+// proxyForScheme 按请求协议选择代理地址
+func (sp SystemProxy) proxyForScheme(scheme string) string {
+	if scheme == "https" && sp.HTTPS != "" {
+		return sp.HTTPS
+	}
+	if sp.HTTP != "" {
+		return sp.HTTP
+	}
+	if sp.HTTPS != "" {
+		return sp.HTTPS
+	}
+	return sp.SOCKS
+}
+
+// bypassed 判断主机是否在代理例外列表中
+// 支持 <local>（不含点的主机名）、CIDR、通配符（*.example.com）和域名后缀（.example.com）
+func (sp SystemProxy) bypassed(host string) bool {
+	host = strings.ToLower(host)
+	ip := net.ParseIP(host)
+	for _, rule := range sp.Bypass {
+		rule = strings.ToLower(strings.TrimSpace(rule))
+		switch {
+		case rule == "":
+		case rule == "*":
+			return true
+		case rule == "<local>":
+			if !strings.Contains(host, ".") {
+				return true
+			}
+		case strings.Contains(rule, "/"):
+			if _, cidr, err := net.ParseCIDR(rule); err == nil && ip != nil && cidr.Contains(ip) {
+				return true
+			}
+		case strings.ContainsAny(rule, "*?"):
+			if shExpMatch(host, rule) {
+				return true
+			}
+		case strings.HasPrefix(rule, "."):
+			if strings.HasSuffix(host, rule) {
+				return true
+			}
+		default:
+			if host == rule || strings.HasSuffix(host, "."+rule) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// normalizeProxyURL 补全代理地址的协议前缀
+func normalizeProxyURL(addr, scheme string) string {
+	addr = strings.TrimSpace(addr)
+	if addr == "" || strings.Contains(addr, "://") {
+		return addr
+	}
+	return scheme + "://" + addr
+}
+
+// splitList 按分隔符切分并去除空项
+func splitList(s, sep string) []string {
+	var result []string
+	for _, item := range strings.Split(s, sep) {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -0,0 +1,149 @@
+// Package sound 提示音播放：内置合成提示音与自定义音频文件
+// 通过系统自带播放器异步播放（Windows PowerShell MediaPlayer、macOS afplay、Linux paplay/aplay），不依赖 cgo
+package sound
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+)
+
+// 内置提示音
+const (
+	ToneDefault = "default" // 单音提示
+	ToneRising  = "rising"  // 上行双音（涨停）
+	ToneFalling = "falling" // 下行双音（跌停）
+)
+
+const (
+	sampleRate    = 22050
+	defaultVolume = 80
+)
+
+// tones 内置提示音的音高序列(Hz)，每个音 150ms
+var tones = map[string][]float64{
+	ToneDefault: {880},
+	ToneRising:  {660, 990},
+	ToneFalling: {990, 660},
+}
+
+var (
+	toneMu    sync.Mutex
+	toneFiles = map[string]string{} // tone@volume -> 临时文件路径
+)
+
+// PlayTone 播放内置提示音，volume 0-100（0 使用默认音量）
+func PlayTone(tone string, volume int) error {
+	path, err := toneFile(tone, normalizeVolume(volume))
+	if err != nil {
+		return err
+	}
+	// 音量已写入采样，播放器按满音量播放
+	return start(path, 100)
+}
+
+// PlayFile 播放自定义音频文件（wav，Windows/macOS 另支持 mp3），volume 0-100（0 使用默认音量）
+func PlayFile(path string, volume int) error {
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("音频文件不可用: %w", err)
+	}
+	return start(path, normalizeVolume(volume))
+}
+
+// start 启动播放进程，不等待播放结束
+func start(path string, volume int) error {
+	cmd, err := playCommand(path, volume)
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
+
+func normalizeVolume(volume int) int {
+	if volume <= 0 {
+		return defaultVolume
+	}
+	return min(volume, 100)
+}
+
+// toneFile 生成内置提示音的 WAV 临时文件（按音量缓存）
+func toneFile(tone string, volume int) (string, error) {
+	freqs, ok := tones[tone]
+	if !ok {
+		return "", fmt.Errorf("未知提示音: %s", tone)
+	}
+	key := fmt.Sprintf("%s@%d", tone, volume)
+
+	toneMu.Lock()
+	defer toneMu.Unlock()
+	if path, ok := toneFiles[key]; ok {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	dir := filepath.Join(os.TempDir(), "jcp-sound")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, key+".wav")
+	if err := os.WriteFile(path, synthesize(freqs, float64(volume)/100), 0644); err != nil {
+		return "", err
+	}
+	toneFiles[key] = path
+	return path, nil
+}
+
+// synthesize 合成 16 位单声道 WAV：依次播放各音高，每个音首尾做淡入淡出避免爆音
+func synthesize(freqs []float64, gain float64) []byte {
+	perTone := sampleRate * 150 / 1000
+	fade := sampleRate * 10 / 1000
+	samples := make([]int16, 0, perTone*len(freqs))
+	for _, f := range freqs {
+		for i := 0; i < perTone; i++ {
+			env := 1.0
+			if i < fade {
+				env = float64(i) / float64(fade)
+			} else if i > perTone-fade {
+				env = float64(perTone-i) / float64(fade)
+			}
+			v := math.Sin(2*math.Pi*f*float64(i)/sampleRate) * env * gain * 0.6
+			samples = append(samples, int16(v*math.MaxInt16))
+		}
+	}
+
+	var buf bytes.Buffer
+	dataSize := uint32(len(samples) * 2)
+	buf.WriteString("RIFF")
+	binary.Write(&buf, binary.LittleEndian, 36+dataSize)
+	buf.WriteString("WAVEfmt ")
+	binary.Write(&buf, binary.LittleEndian, uint32(16))           // fmt 块大小
+	binary.Write(&buf, binary.LittleEndian, uint16(1))            // PCM
+	binary.Write(&buf, binary.LittleEndian, uint16(1))            // 单声道
+	binary.Write(&buf, binary.LittleEndian, uint32(sampleRate))   // 采样率
+	binary.Write(&buf, binary.LittleEndian, uint32(sampleRate*2)) // 字节率
+	binary.Write(&buf, binary.LittleEndian, uint16(2))            // 块对齐
+	binary.Write(&buf, binary.LittleEndian, uint16(16))           // 位深
+	buf.WriteString("data")
+	binary.Write(&buf, binary.LittleEndian, dataSize)
+	binary.Write(&buf, binary.LittleEndian, samples)
+	return buf.Bytes()
+}
+
+// lookPath 查找第一个可用的播放器
+func lookPath(names ...string) (string, error) {
+	for _, name := range names {
+		if path, err := exec.LookPath(name); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("未找到音频播放器（%v）", names)
+}
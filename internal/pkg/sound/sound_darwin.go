@@ -0,0 +1,13 @@
+//go:build darwin
+
+package sound
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// playCommand 使用系统自带 afplay 播放，-v 取值 0-1
+func playCommand(path string, volume int) (*exec.Cmd, error) {
+	return exec.Command("afplay", "-v", fmt.Sprintf("%.2f", float64(volume)/100), path), nil
+}
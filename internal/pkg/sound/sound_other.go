@@ -0,0 +1,25 @@
+//go:build !windows && !darwin
+
+package sound
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strconv"
+)
+
+// playCommand 优先使用 PulseAudio/PipeWire 的 paplay（支持音量），否则回退到 ALSA aplay（仅 wav，无音量）
+func playCommand(path string, volume int) (*exec.Cmd, error) {
+	player, err := lookPath("paplay", "pw-play", "aplay")
+	if err != nil {
+		return nil, err
+	}
+	switch filepath.Base(player) {
+	case "paplay":
+		return exec.Command(player, "--volume="+strconv.Itoa(volume*65536/100), path), nil
+	case "pw-play":
+		return exec.Command(player, "--volume="+strconv.FormatFloat(float64(volume)/100, 'f', 2, 64), path), nil
+	default:
+		return exec.Command(player, "-q", path), nil
+	}
+}
@@ -0,0 +1,44 @@
+package sound
+
+import (
+	"encoding/binary"
+	"os"
+	"testing"
+)
+
+func TestSynthesize(t *testing.T) {
+	data := synthesize(tones[ToneRising], 0.5)
+	if string(data[:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		t.Fatalf("WAV 头错误: %q", data[:12])
+	}
+	dataSize := binary.LittleEndian.Uint32(data[40:44])
+	if want := uint32(2 * (sampleRate * 150 / 1000) * 2); dataSize != want {
+		t.Errorf("数据长度 = %d, want %d", dataSize, want)
+	}
+	if int(dataSize)+44 != len(data) {
+		t.Errorf("文件长度 = %d, want %d", len(data), dataSize+44)
+	}
+}
+
+func TestToneFile(t *testing.T) {
+	path, err := toneFile(ToneDefault, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(path)
+	if again, _ := toneFile(ToneDefault, 50); again != path {
+		t.Errorf("同音量应复用缓存文件: %s != %s", again, path)
+	}
+	if _, err := toneFile("unknown", 50); err == nil {
+		t.Error("未知提示音应报错")
+	}
+	if normalizeVolume(0) != defaultVolume || normalizeVolume(150) != 100 {
+		t.Error("音量归一化错误")
+	}
+}
+
+func TestPlayFileMissing(t *testing.T) {
+	if err := PlayFile("/nonexistent/alert.wav", 80); err == nil {
+		t.Error("文件不存在应报错")
+	}
+}
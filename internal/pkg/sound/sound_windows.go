@@ -0,0 +1,24 @@
+//go:build windows
+
+package sound
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"syscall"
+)
+
+// playCommand 使用 PowerShell 调用 WPF MediaPlayer 播放（支持 wav/mp3 与音量），隐藏窗口
+func playCommand(path string, volume int) (*exec.Cmd, error) {
+	script := fmt.Sprintf(`Add-Type -AssemblyName PresentationCore;`+
+		`$p = New-Object System.Windows.Media.MediaPlayer;`+
+		`$p.Open([uri]'%s'); $p.Volume = %.2f; $p.Play();`+
+		`Start-Sleep -Milliseconds 300;`+
+		`while ($p.NaturalDuration.HasTimeSpan -and $p.Position -lt $p.NaturalDuration.TimeSpan) { Start-Sleep -Milliseconds 100 };`+
+		`$p.Close()`,
+		strings.ReplaceAll(path, "'", "''"), float64(volume)/100)
+	cmd := exec.Command("powershell.exe", "-NoProfile", "-NonInteractive", "-Command", script)
+	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true}
+	return cmd, nil
+}
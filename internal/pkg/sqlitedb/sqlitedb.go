@@ -0,0 +1,48 @@
+// Package sqlitedb 打开本地 SQLite 数据库
+// 使用纯 Go 驱动 modernc.org/sqlite（内置 FTS5），各平台无需 CGO 即可构建
+package sqlitedb
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	_ "modernc.org/sqlite"
+)
+
+// pragmas 每个连接打开时执行：WAL 允许读写并发，busy_timeout 避免多实例同时写入时直接报错
+const pragmas = "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=synchronous(NORMAL)&_pragma=foreign_keys(1)"
+
+// Open 打开（不存在时创建）数据库并执行建表语句
+// 只保留一个连接，写入由 SQLite 串行化，调用方无需额外加锁
+func Open(path string, schema ...string) (*sql.DB, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("sqlite", path+pragmas)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+	for _, stmt := range schema {
+		if _, err := db.Exec(stmt); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("初始化数据库 %s 失败: %w", filepath.Base(path), err)
+		}
+	}
+	return db, nil
+}
+
+// InTx 在事务中执行 fn，fn 返回错误时回滚
+func InTx(db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
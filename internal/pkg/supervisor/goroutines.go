@@ -0,0 +1,167 @@
+package supervisor
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	leakCheckInterval = time.Minute
+	leakMinGoroutines = 200 // 总数低于该值时不判定泄漏
+	leakGrowthRounds  = 5   // 连续增长的检查轮数
+	maxGroups         = 30
+)
+
+// GoroutineGroup 按入口函数聚合的 goroutine 数
+type GoroutineGroup struct {
+	Function string `json:"function"` // 栈上第一个非 runtime 函数
+	Count    int    `json:"count"`
+	Growth   int    `json:"growth"` // 相对上一次统计的增量
+}
+
+// Report goroutine 调试报告
+type Report struct {
+	Total       int              `json:"total"`
+	Tasks       []Status         `json:"tasks"`       // 受监管的常驻任务
+	Groups      []GoroutineGroup `json:"groups"`      // 数量最多的分组
+	LeakSuspect bool             `json:"leakSuspect"` // 总数持续增长，疑似泄漏
+}
+
+// leakDetector 记录上一次分组统计与连续增长轮数
+type leakDetector struct {
+	mu        sync.Mutex
+	last      map[string]int
+	lastTotal int
+	growing   int
+}
+
+var detector = &leakDetector{}
+
+// Debug 生成 goroutine 调试报告
+func (s *Supervisor) Debug() Report {
+	groups := GoroutineGroups()
+	total := runtime.NumGoroutine()
+
+	detector.mu.Lock()
+	for i := range groups {
+		if detector.last != nil {
+			groups[i].Growth = groups[i].Count - detector.last[groups[i].Function]
+		}
+	}
+	detector.last = make(map[string]int, len(groups))
+	for _, g := range groups {
+		detector.last[g.Function] = g.Count
+	}
+	suspect := detector.lastTotal >= leakMinGoroutines && detector.growing >= leakGrowthRounds
+	detector.mu.Unlock()
+
+	if len(groups) > maxGroups {
+		groups = groups[:maxGroups]
+	}
+	return Report{Total: total, Tasks: s.Status(), Groups: groups, LeakSuspect: suspect}
+}
+
+// WatchLeaks 定期检查 goroutine 总数，连续多轮增长且超过阈值时输出数量最多的分组
+func WatchLeaks(ctx context.Context) {
+	Go(ctx, "goroutine-leak-check", func(ctx context.Context) {
+		ticker := time.NewTicker(leakCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				checkLeak(runtime.NumGoroutine())
+			}
+		}
+	})
+}
+
+// checkLeak 记录一次总数，返回是否疑似泄漏
+func checkLeak(total int) bool {
+	detector.mu.Lock()
+	if total > detector.lastTotal && detector.lastTotal > 0 {
+		detector.growing++
+	} else {
+		detector.growing = 0
+	}
+	detector.lastTotal = total
+	suspect := total >= leakMinGoroutines && detector.growing >= leakGrowthRounds
+	detector.mu.Unlock()
+
+	if suspect {
+		groups := GoroutineGroups()
+		var top []string
+		for i := 0; i < len(groups) && i < 5; i++ {
+			top = append(top, groups[i].Function+"="+strconv.Itoa(groups[i].Count))
+		}
+		log.Warn("goroutine 数连续 %d 轮增长至 %d，疑似泄漏: %s", leakGrowthRounds, total, strings.Join(top, ", "))
+	}
+	return suspect
+}
+
+// GoroutineGroups 按入口函数聚合当前所有 goroutine，按数量降序
+func GoroutineGroups() []GoroutineGroup {
+	var buf bytes.Buffer
+	if err := pprof.Lookup("goroutine").WriteTo(&buf, 1); err != nil {
+		return nil
+	}
+	return parseGoroutineProfile(buf.String())
+}
+
+// parseGoroutineProfile 解析 debug=1 格式的 goroutine profile：
+// "N @ 0x..." 开头的块，随后是 "#\t0x...\tfunc+0x..\tfile:line" 栈帧
+func parseGoroutineProfile(profile string) []GoroutineGroup {
+	counts := make(map[string]int)
+	sc := bufio.NewScanner(strings.NewReader(profile))
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	count, fn := 0, ""
+	flush := func() {
+		if count > 0 {
+			counts[fn] += count
+		}
+		count, fn = 0, ""
+	}
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case strings.Contains(line, " @ ") && !strings.HasPrefix(line, "#"):
+			flush()
+			count, _ = strconv.Atoi(strings.TrimSpace(line[:strings.Index(line, " @ ")]))
+			fn = "unknown"
+		case strings.HasPrefix(line, "#") && fn == "unknown":
+			fields := strings.Fields(line)
+			if len(fields) < 3 {
+				continue
+			}
+			name := fields[2]
+			if i := strings.LastIndex(name, "+0x"); i > 0 {
+				name = name[:i]
+			}
+			if !strings.HasPrefix(name, "runtime.") && !strings.HasPrefix(name, "runtime/") {
+				fn = name
+			}
+		}
+	}
+	flush()
+
+	groups := make([]GoroutineGroup, 0, len(counts))
+	for f, c := range counts {
+		groups = append(groups, GoroutineGroup{Function: f, Count: c})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].Function < groups[j].Function
+	})
+	return groups
+}
@@ -0,0 +1,178 @@
+// Package supervisor 常驻 goroutine 监管
+// 统一登记推送循环、缓存清理、定时任务等长期运行的 goroutine，
+// panic 后按指数退避自动重启，并提供运行状态与 goroutine 分组统计用于排查泄漏
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/logger"
+)
+
+var log = logger.New("supervisor")
+
+// 任务状态
+const (
+	StateRunning    = "running"
+	StateRestarting = "restarting" // panic 后等待重启
+	StateStopped    = "stopped"    // 正常退出（ctx 取消或任务结束）
+)
+
+const (
+	minBackoff = time.Second
+	maxBackoff = time.Minute
+	// 稳定运行超过该时长后再次 panic，退避重新从最小值开始
+	backoffReset = 5 * time.Minute
+)
+
+// Status 任务运行状态
+type Status struct {
+	Name        string `json:"name"`
+	State       string `json:"state"`
+	Restarts    int    `json:"restarts"`            // panic 后重启次数
+	LastError   string `json:"lastError,omitempty"` // 最近一次 panic 信息
+	StartedAt   int64  `json:"startedAt"`           // 本次启动时间（毫秒）
+	LastPanicAt int64  `json:"lastPanicAt,omitempty"`
+}
+
+type task struct {
+	status Status
+}
+
+// Supervisor 常驻 goroutine 监管器
+type Supervisor struct {
+	mu    sync.Mutex
+	tasks map[string]*task
+	wg    sync.WaitGroup
+	// 重启前等待，测试时可替换
+	sleep func(ctx context.Context, d time.Duration) bool
+}
+
+// New 创建监管器
+func New() *Supervisor {
+	return &Supervisor{tasks: make(map[string]*task), sleep: sleepCtx}
+}
+
+// Default 全局监管器
+var Default = New()
+
+// Go 在全局监管器中启动任务
+func Go(ctx context.Context, name string, fn func(ctx context.Context)) {
+	Default.Go(ctx, name, fn)
+}
+
+// Go 启动受监管的 goroutine：fn 正常返回视为结束，panic 时记录并按退避重启，ctx 取消后不再重启
+// 同名任务再次启动时覆盖其状态（如配置变更后重启的循环）
+func (s *Supervisor) Go(ctx context.Context, name string, fn func(ctx context.Context)) {
+	t := &task{status: Status{Name: name, State: StateRunning, StartedAt: time.Now().UnixMilli()}}
+	s.mu.Lock()
+	s.tasks[name] = t
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		backoff := minBackoff
+		for {
+			started := time.Now()
+			err := runSafe(ctx, fn)
+			if err == nil || ctx.Err() != nil {
+				s.update(t, func(st *Status) { st.State = StateStopped })
+				return
+			}
+
+			if time.Since(started) > backoffReset {
+				backoff = minBackoff
+			}
+			log.Error("任务 %s 异常退出，%v 后重启: %v", name, backoff, err)
+			s.update(t, func(st *Status) {
+				st.State = StateRestarting
+				st.Restarts++
+				st.LastError = err.Error()
+				st.LastPanicAt = time.Now().UnixMilli()
+			})
+			if !s.sleep(ctx, backoff) {
+				s.update(t, func(st *Status) { st.State = StateStopped })
+				return
+			}
+			backoff = min(backoff*2, maxBackoff)
+			s.update(t, func(st *Status) {
+				st.State = StateRunning
+				st.StartedAt = time.Now().UnixMilli()
+			})
+		}
+	}()
+}
+
+// Wait 等待所有任务退出（需先取消其 ctx），超时返回 false
+func (s *Supervisor) Wait(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// Running 仍在运行或等待重启的任务名
+func (s *Supervisor) Running() []string {
+	var names []string
+	for _, st := range s.Status() {
+		if st.State != StateStopped {
+			names = append(names, st.Name)
+		}
+	}
+	return names
+}
+
+// Status 获取所有任务的状态，按名称排序
+func (s *Supervisor) Status() []Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := make([]Status, 0, len(s.tasks))
+	for _, t := range s.tasks {
+		result = append(result, t.status)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+func (s *Supervisor) update(t *task, fn func(*Status)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(&t.status)
+}
+
+// runSafe 执行任务并将 panic 转为错误
+func runSafe(ctx context.Context, fn func(ctx context.Context)) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+			log.Debug("panic 堆栈:\n%s", debug.Stack())
+		}
+	}()
+	fn(ctx)
+	return nil
+}
+
+// sleepCtx 等待 d，ctx 取消时返回 false
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
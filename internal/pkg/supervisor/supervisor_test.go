@@ -0,0 +1,137 @@
+package supervisor
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestRestartOnPanic 测试 panic 后按退避重启，正常返回后标记为停止
+func TestRestartOnPanic(t *testing.T) {
+	s := New()
+	var backoffs []time.Duration
+	s.sleep = func(ctx context.Context, d time.Duration) bool {
+		backoffs = append(backoffs, d)
+		return true
+	}
+
+	var runs atomic.Int32
+	done := make(chan struct{})
+	s.Go(context.Background(), "loop", func(ctx context.Context) {
+		if runs.Add(1) < 3 {
+			panic("boom")
+		}
+		close(done)
+	})
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("任务未重启")
+	}
+	waitState(t, s, StateStopped)
+
+	st := s.Status()[0]
+	if st.Restarts != 2 || st.LastError != "panic: boom" {
+		t.Errorf("status = %+v", st)
+	}
+	if len(backoffs) != 2 || backoffs[0] != time.Second || backoffs[1] != 2*time.Second {
+		t.Errorf("退避应指数增长: %v", backoffs)
+	}
+}
+
+// TestNoRestartAfterCancel 测试 ctx 取消后不再重启
+func TestNoRestartAfterCancel(t *testing.T) {
+	s := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	var runs atomic.Int32
+	s.sleep = sleepCtx
+	s.Go(ctx, "loop", func(ctx context.Context) {
+		runs.Add(1)
+		cancel()
+		panic("boom")
+	})
+	waitState(t, s, StateStopped)
+	if runs.Load() != 1 {
+		t.Errorf("取消后不应重启, runs=%d", runs.Load())
+	}
+}
+
+func waitState(t *testing.T, s *Supervisor, state string) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if st := s.Status(); len(st) == 1 && st[0].State == state {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("状态未变为 %s: %+v", state, s.Status())
+}
+
+func TestParseGoroutineProfile(t *testing.T) {
+	profile := `goroutine profile: total 6
+3 @ 0x43a 0x44b
+#	0x43a	runtime.gopark+0x1	/go/src/runtime/proc.go:1
+#	0x44b	github.com/run-bigpig/jcp/internal/services.(*MarketDataPusher).pushLoop+0x2	/x/market_pusher.go:10
+
+2 @ 0x43a 0x55c
+#	0x43a	runtime.gopark+0x1	/go/src/runtime/proc.go:1
+#	0x55c	net/http.(*persistConn).readLoop+0x3	/go/src/net/http/transport.go:2
+
+1 @ 0x43a 0x44b
+#	0x43a	runtime.gopark+0x1	/go/src/runtime/proc.go:1
+#	0x44b	github.com/run-bigpig/jcp/internal/services.(*MarketDataPusher).pushLoop+0x9	/x/market_pusher.go:12
+`
+	groups := parseGoroutineProfile(profile)
+	if len(groups) != 2 {
+		t.Fatalf("groups = %+v", groups)
+	}
+	if groups[0].Function != "github.com/run-bigpig/jcp/internal/services.(*MarketDataPusher).pushLoop" || groups[0].Count != 4 {
+		t.Errorf("groups[0] = %+v", groups[0])
+	}
+	if groups[1].Function != "net/http.(*persistConn).readLoop" || groups[1].Count != 2 {
+		t.Errorf("groups[1] = %+v", groups[1])
+	}
+}
+
+func TestCheckLeak(t *testing.T) {
+	detector.mu.Lock()
+	detector.lastTotal, detector.growing = 0, 0
+	detector.mu.Unlock()
+
+	suspect := false
+	for i := 0; i <= leakGrowthRounds; i++ {
+		suspect = checkLeak(leakMinGoroutines + i)
+	}
+	if !suspect {
+		t.Error("连续增长应判定疑似泄漏")
+	}
+	if checkLeak(leakMinGoroutines) {
+		t.Error("回落后不应判定泄漏")
+	}
+}
+
+// TestWait 测试取消后等待所有任务退出
+func TestWait(t *testing.T) {
+	s := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	release := make(chan struct{})
+	s.Go(ctx, "slow", func(ctx context.Context) {
+		<-ctx.Done()
+		<-release // 模拟退出前的收尾写入
+	})
+
+	cancel()
+	if s.Wait(20 * time.Millisecond) {
+		t.Fatal("收尾未完成时 Wait 应超时")
+	}
+	if names := s.Running(); len(names) != 1 || names[0] != "slow" {
+		t.Errorf("Running = %v", names)
+	}
+	close(release)
+	if !s.Wait(time.Second) {
+		t.Fatal("任务退出后 Wait 应返回 true")
+	}
+}
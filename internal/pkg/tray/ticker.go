@@ -0,0 +1,89 @@
+package tray
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+const (
+	appName        = "韭菜盘"
+	alertRounds    = 5   // 提醒在托盘标题上停留的轮数
+	maxAlerts      = 3   // 待显示提醒上限，超出丢弃最早的
+	maxTooltipRune = 120 // Windows 托盘提示最长 127 个字符
+)
+
+// Ticker 托盘滚动行情：每轮显示一只自选股，有提醒时优先显示提醒
+type Ticker struct {
+	index  int
+	alerts []string
+	shown  int // 当前提醒已显示的轮数
+}
+
+// Alert 加入一条提醒，随后几轮在托盘标题上显示
+func (t *Ticker) Alert(text string) {
+	if text = strings.TrimSpace(text); text == "" {
+		return
+	}
+	t.alerts = append(t.alerts, text)
+	if len(t.alerts) > maxAlerts {
+		t.alerts = t.alerts[len(t.alerts)-maxAlerts:]
+		t.shown = 0
+	}
+}
+
+// Next 前进一轮，返回托盘标题（macOS/Linux 菜单栏显示）与提示文字（所有平台悬停显示）
+func (t *Ticker) Next(stocks []models.Stock, paused bool) (title, tooltip string) {
+	header := appName
+	if paused {
+		header += "（行情推送已暂停）"
+	}
+
+	if len(t.alerts) > 0 {
+		alert := t.alerts[0]
+		t.shown++
+		if t.shown >= alertRounds {
+			t.alerts = t.alerts[1:]
+			t.shown = 0
+		}
+		return "⚠ " + alert, truncateRunes(header+"\n"+alert, maxTooltipRune)
+	}
+
+	if len(stocks) == 0 {
+		return "", header
+	}
+	t.index %= len(stocks)
+	title = quoteLine(stocks[t.index])
+	t.index++
+
+	lines := []string{header}
+	for _, s := range stocks {
+		lines = append(lines, quoteLine(s))
+	}
+	return title, truncateRunes(strings.Join(lines, "\n"), maxTooltipRune)
+}
+
+// quoteLine 单只股票的行情摘要，如 "贵州茅台 1688.00 +1.23%"
+func quoteLine(s models.Stock) string {
+	name := s.Name
+	if name == "" {
+		name = s.Symbol
+	}
+	switch s.Status {
+	case models.StockStatusSuspended:
+		return name + " 停牌"
+	case models.StockStatusDelisted:
+		return name + " 退市"
+	}
+	return fmt.Sprintf("%s %.2f %+.2f%%", name, s.Price, s.ChangePercent)
+}
+
+// truncateRunes 按字符截断，超出时以省略号结尾
+func truncateRunes(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit-1]) + "…"
+}
@@ -0,0 +1,61 @@
+package tray
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+func TestTickerRotate(t *testing.T) {
+	stocks := []models.Stock{
+		{Symbol: "sh600519", Name: "贵州茅台", Price: 1688, ChangePercent: 1.234},
+		{Symbol: "sz000001", Name: "平安银行", Price: 10.5, ChangePercent: -0.5},
+		{Symbol: "sh600000", Name: "浦发银行", Status: models.StockStatusSuspended},
+	}
+	var tk Ticker
+	var titles []string
+	for range 4 {
+		title, _ := tk.Next(stocks, false)
+		titles = append(titles, title)
+	}
+	want := []string{"贵州茅台 1688.00 +1.23%", "平安银行 10.50 -0.50%", "浦发银行 停牌", "贵州茅台 1688.00 +1.23%"}
+	for i := range want {
+		if titles[i] != want[i] {
+			t.Errorf("第 %d 轮标题 = %q, want %q", i, titles[i], want[i])
+		}
+	}
+
+	// 自选股减少后不越界
+	if title, _ := tk.Next(stocks[:1], false); title != want[0] {
+		t.Errorf("自选股减少后标题 = %q", title)
+	}
+	if title, tip := tk.Next(nil, true); title != "" || !strings.Contains(tip, "已暂停") {
+		t.Errorf("无自选股且暂停时 = %q %q", title, tip)
+	}
+}
+
+func TestTickerAlert(t *testing.T) {
+	stocks := []models.Stock{{Symbol: "sh600519", Name: "贵州茅台", Price: 1688}}
+	var tk Ticker
+	tk.Alert("条件单触发：贵州茅台 @ 1688.00")
+	for i := range alertRounds {
+		if title, _ := tk.Next(stocks, false); title != "⚠ 条件单触发：贵州茅台 @ 1688.00" {
+			t.Fatalf("第 %d 轮应显示提醒: %q", i, title)
+		}
+	}
+	if title, _ := tk.Next(stocks, false); !strings.HasPrefix(title, "贵州茅台") {
+		t.Errorf("提醒显示完后应恢复行情: %q", title)
+	}
+
+	for i := range maxAlerts + 2 {
+		tk.Alert(strings.Repeat("长", 200) + string(rune('A'+i)))
+	}
+	if len(tk.alerts) != maxAlerts {
+		t.Errorf("待显示提醒应限制为 %d 条, got %d", maxAlerts, len(tk.alerts))
+	}
+	if _, tip := tk.Next(stocks, false); utf8.RuneCountInString(tip) > maxTooltipRune {
+		t.Errorf("提示文字超长: %d", utf8.RuneCountInString(tip))
+	}
+}
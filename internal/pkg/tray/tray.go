@@ -0,0 +1,143 @@
+// Package tray 系统托盘：滚动显示自选股行情，提供显示/隐藏窗口、暂停推送、退出等快捷菜单，
+// 并在托盘上提示已触发的提醒
+package tray
+
+import (
+	_ "embed"
+	"runtime"
+	"sync"
+
+	"fyne.io/systray"
+	"github.com/run-bigpig/jcp/internal/logger"
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+var log = logger.New("tray")
+
+var (
+	//go:embed icon.png
+	iconPNG []byte
+	//go:embed icon.ico
+	iconICO []byte
+)
+
+// Actions 托盘菜单回调
+type Actions struct {
+	ToggleWindow func()            // 左键单击或“显示/隐藏窗口”
+	SetPaused    func(paused bool) // “暂停行情推送”勾选变化
+	Quit         func()
+}
+
+// Tray 系统托盘图标，Start 之前的 Update、Alert 会在托盘就绪后生效
+type Tray struct {
+	actions Actions
+
+	mu        sync.Mutex
+	ticker    Ticker
+	ready     bool
+	stop      func()
+	pauseItem *systray.MenuItem
+	paused    bool
+	title     string
+	tooltip   string
+}
+
+// New 创建系统托盘
+func New(actions Actions) *Tray {
+	return &Tray{actions: actions}
+}
+
+// Start 显示托盘图标，重复调用无效
+func (t *Tray) Start() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.stop != nil {
+		return
+	}
+	t.stop = start(t.onReady, func() { log.Info("托盘已退出") })
+}
+
+// Stop 移除托盘图标
+func (t *Tray) Stop() {
+	t.mu.Lock()
+	stop := t.stop
+	t.stop = nil
+	t.ready = false
+	t.mu.Unlock()
+	if stop != nil {
+		stop()
+	}
+}
+
+// Update 滚动到下一只自选股，并同步暂停推送的勾选状态
+func (t *Tray) Update(stocks []models.Stock, paused bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.title, t.tooltip = t.ticker.Next(stocks, paused)
+	t.paused = paused
+	if t.ready {
+		t.applyLocked()
+	}
+}
+
+// Alert 在托盘标题上提示一条已触发的提醒，下一次 Update 时显示
+func (t *Tray) Alert(text string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.ticker.Alert(text)
+}
+
+// onReady 托盘就绪后设置图标与菜单，并转发菜单点击
+func (t *Tray) onReady() {
+	if runtime.GOOS == "windows" {
+		systray.SetIcon(iconICO)
+	} else {
+		systray.SetIcon(iconPNG)
+	}
+	systray.SetTooltip(appName)
+	systray.SetOnTapped(t.actions.ToggleWindow)
+
+	toggle := systray.AddMenuItem("显示/隐藏窗口", "")
+	t.mu.Lock()
+	pause := systray.AddMenuItemCheckbox("暂停行情推送", "", t.paused)
+	t.pauseItem = pause
+	t.ready = true
+	t.applyLocked()
+	t.mu.Unlock()
+	systray.AddSeparator()
+	quit := systray.AddMenuItem("退出", "")
+
+	go func() {
+		for {
+			select {
+			case <-toggle.ClickedCh:
+				t.actions.ToggleWindow()
+			case <-pause.ClickedCh:
+				paused := !pause.Checked()
+				t.mu.Lock()
+				t.paused = paused
+				t.applyLocked()
+				t.mu.Unlock()
+				t.actions.SetPaused(paused)
+			case <-quit.ClickedCh:
+				t.actions.Quit()
+				return
+			}
+		}
+	}()
+	log.Info("托盘已启动")
+}
+
+// applyLocked 将当前标题、提示与勾选状态应用到托盘
+func (t *Tray) applyLocked() {
+	systray.SetTitle(t.title)
+	systray.SetTooltip(t.tooltip)
+	if t.pauseItem == nil || t.pauseItem.Checked() == t.paused {
+		return
+	}
+	if t.paused {
+		t.pauseItem.Check()
+	} else {
+		t.pauseItem.Uncheck()
+	}
+}
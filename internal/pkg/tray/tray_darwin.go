@@ -0,0 +1,13 @@
+//go:build darwin
+
+package tray
+
+import "fyne.io/systray"
+
+// start macOS 的主事件循环由 Wails 持有，托盘只注册到已有的 NSApplication
+// 返回的 end 会停止 NSApp 事件循环，因此不调用；菜单栏图标随进程退出一起移除
+func start(onReady, onExit func()) func() {
+	run, _ := systray.RunWithExternalLoop(onReady, onExit)
+	run()
+	return func() {}
+}
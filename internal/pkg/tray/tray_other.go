@@ -0,0 +1,18 @@
+//go:build !darwin
+
+package tray
+
+import (
+	"runtime"
+
+	"fyne.io/systray"
+)
+
+// start 托盘在独立的系统线程上运行自己的消息循环（Windows 托盘窗口的消息必须由创建它的线程处理）
+func start(onReady, onExit func()) func() {
+	go func() {
+		runtime.LockOSThread()
+		systray.Run(onReady, onExit)
+	}()
+	return systray.Quit
+}
@@ -0,0 +1,365 @@
+// Package updater 基于 GitHub Releases 的自动更新：检测新版本、下载当前平台的安装包并校验 SHA256，
+// 下载完成后暂存到本地，退出或重启时替换可执行文件
+package updater
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/inconshreveable/go-update"
+	"github.com/run-bigpig/go-github-selfupdate/selfupdate"
+)
+
+const (
+	defaultAPIBase = "https://api.github.com"
+	pendingFile    = "pending.json"
+	maxChecksumLen = 64 << 10 // 校验文件大小上限
+)
+
+// checksumNames 发布页中汇总校验文件的常见命名
+var checksumNames = []string{"checksums.txt", "sha256sums.txt", "SHA256SUMS"}
+
+// Asset 发布附件
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+	Size int64  `json:"size"`
+}
+
+// Release 适用于当前平台的发布版本
+type Release struct {
+	Version     semver.Version
+	Tag         string
+	Name        string
+	Notes       string // 更新日志（Release 正文）
+	URL         string // 发布页地址
+	PublishedAt time.Time
+	Asset       Asset // 当前平台安装包
+	Checksum    Asset // 校验文件：<安装包>.sha256 或 checksums.txt
+}
+
+// githubRelease GitHub Releases API 返回结构
+type githubRelease struct {
+	TagName     string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	Body        string    `json:"body"`
+	HTMLURL     string    `json:"html_url"`
+	Draft       bool      `json:"draft"`
+	Prerelease  bool      `json:"prerelease"`
+	PublishedAt time.Time `json:"published_at"`
+	Assets      []Asset   `json:"assets"`
+}
+
+// Pending 已下载并校验、等待替换的更新
+type Pending struct {
+	Version   string `json:"version"`
+	File      string `json:"file"`      // 暂存的安装包路径
+	AssetName string `json:"assetName"` // 原始文件名，用于识别压缩格式
+}
+
+// Client GitHub Releases 更新客户端
+type Client struct {
+	Repo    string // owner/name
+	HTTP    *http.Client
+	APIBase string // 为空使用 api.github.com
+	Dir     string // 安装包暂存目录
+}
+
+// Latest 获取适用于当前平台的最新正式版本，没有可用版本时返回 nil
+func (c *Client) Latest(ctx context.Context) (*Release, error) {
+	base := c.APIBase
+	if base == "" {
+		base = defaultAPIBase
+	}
+	var releases []githubRelease
+	if err := c.getJSON(ctx, fmt.Sprintf("%s/repos/%s/releases?per_page=20", base, c.Repo), &releases); err != nil {
+		return nil, err
+	}
+
+	var latest *Release
+	for _, r := range releases {
+		if r.Draft || r.Prerelease {
+			continue
+		}
+		rel, ok := pickRelease(r, runtime.GOOS, runtime.GOARCH)
+		if ok && (latest == nil || rel.Version.GT(latest.Version)) {
+			latest = rel
+		}
+	}
+	return latest, nil
+}
+
+// pickRelease 从发布中选出指定平台的安装包与校验文件
+func pickRelease(r githubRelease, goos, goarch string) (*Release, bool) {
+	ver, err := semver.ParseTolerant(r.TagName)
+	if err != nil {
+		return nil, false
+	}
+	asset, ok := matchAsset(r.Assets, goos, goarch)
+	if !ok {
+		return nil, false
+	}
+	rel := &Release{
+		Version:     ver,
+		Tag:         r.TagName,
+		Name:        r.Name,
+		Notes:       r.Body,
+		URL:         r.HTMLURL,
+		PublishedAt: r.PublishedAt,
+		Asset:       asset,
+	}
+	for _, a := range r.Assets {
+		if a.Name == asset.Name+".sha256" {
+			rel.Checksum = a
+			return rel, true
+		}
+	}
+	for _, a := range r.Assets {
+		for _, name := range checksumNames {
+			if strings.EqualFold(a.Name, name) {
+				rel.Checksum = a
+				return rel, true
+			}
+		}
+	}
+	return rel, true
+}
+
+// matchAsset 按 <os>[-_]<arch> 后缀匹配安装包，支持 zip/tar.gz/gz/xz 压缩
+func matchAsset(assets []Asset, goos, goarch string) (Asset, bool) {
+	exts := []string{"", ".zip", ".tar.gz", ".tgz", ".gz", ".tar.xz", ".xz"}
+	for _, a := range assets {
+		name := strings.ToLower(a.Name)
+		for _, sep := range []string{"-", "_"} {
+			platform := goos + sep + goarch
+			for _, ext := range exts {
+				if strings.HasSuffix(name, platform+ext) ||
+					(goos == "windows" && strings.HasSuffix(name, platform+".exe"+ext)) {
+					return a, true
+				}
+			}
+		}
+	}
+	return Asset{}, false
+}
+
+// Download 下载安装包到暂存目录并校验 SHA256，发布中没有校验文件时拒绝更新
+// progress 在下载过程中回调已下载与总字节数（总数未知时为 -1）
+func (c *Client) Download(ctx context.Context, rel *Release, progress func(done, total int64)) (*Pending, error) {
+	if rel.Checksum.URL == "" {
+		return nil, fmt.Errorf("发布 %s 缺少校验文件，已取消更新", rel.Tag)
+	}
+	want, err := c.fetchChecksum(ctx, rel)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		return nil, err
+	}
+	path := filepath.Join(c.Dir, rel.Asset.Name)
+	tmp := path + ".download"
+	if err := c.downloadFile(ctx, rel.Asset, tmp, want, progress); err != nil {
+		os.Remove(tmp)
+		return nil, err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return nil, err
+	}
+
+	pending := &Pending{Version: rel.Version.String(), File: path, AssetName: rel.Asset.Name}
+	data, _ := json.MarshalIndent(pending, "", "  ")
+	if err := os.WriteFile(filepath.Join(c.Dir, pendingFile), data, 0644); err != nil {
+		return nil, err
+	}
+	return pending, nil
+}
+
+// downloadFile 下载文件并边写边计算 SHA256
+func (c *Client) downloadFile(ctx context.Context, asset Asset, path, wantSum string, progress func(done, total int64)) error {
+	resp, err := c.get(ctx, asset.URL, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	total := resp.ContentLength
+	if total <= 0 {
+		total = -1
+	}
+	h := sha256.New()
+	w := io.MultiWriter(f, h)
+	buf := make([]byte, 64<<10)
+	var done int64
+	for {
+		n, err := resp.Body.Read(buf)
+		if n > 0 {
+			if _, werr := w.Write(buf[:n]); werr != nil {
+				return werr
+			}
+			done += int64(n)
+			if progress != nil {
+				progress(done, total)
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("下载安装包失败: %w", err)
+		}
+	}
+
+	if got := hex.EncodeToString(h.Sum(nil)); got != wantSum {
+		return fmt.Errorf("安装包校验失败: sha256 期望 %s，实际 %s", wantSum, got)
+	}
+	return nil
+}
+
+// fetchChecksum 下载校验文件并取出安装包的 SHA256
+func (c *Client) fetchChecksum(ctx context.Context, rel *Release) (string, error) {
+	resp, err := c.get(ctx, rel.Checksum.URL, "")
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxChecksumLen))
+	if err != nil {
+		return "", err
+	}
+	sum, ok := parseChecksum(data, rel.Asset.Name)
+	if !ok {
+		return "", fmt.Errorf("校验文件 %s 中没有 %s 的 SHA256", rel.Checksum.Name, rel.Asset.Name)
+	}
+	return sum, nil
+}
+
+// parseChecksum 解析 sha256sum 格式（"<hash>  <文件名>"，二进制模式文件名前带 *），
+// 单独的 .sha256 文件可以只有哈希
+func parseChecksum(data []byte, assetName string) (string, bool) {
+	var lines [][]string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if fields := strings.Fields(scanner.Text()); len(fields) > 0 {
+			lines = append(lines, fields)
+		}
+	}
+	valid := func(s string) bool {
+		_, err := hex.DecodeString(s)
+		return len(s) == sha256.Size*2 && err == nil
+	}
+	for _, fields := range lines {
+		if len(fields) >= 2 && strings.TrimPrefix(fields[len(fields)-1], "*") == assetName && valid(fields[0]) {
+			return strings.ToLower(fields[0]), true
+		}
+	}
+	if len(lines) == 1 && len(lines[0]) == 1 && valid(lines[0][0]) {
+		return strings.ToLower(lines[0][0]), true
+	}
+	return "", false
+}
+
+// LoadPending 读取等待替换的更新，没有时返回 nil
+func (c *Client) LoadPending() *Pending {
+	data, err := os.ReadFile(filepath.Join(c.Dir, pendingFile))
+	if err != nil {
+		return nil
+	}
+	var p Pending
+	if json.Unmarshal(data, &p) != nil || p.File == "" {
+		return nil
+	}
+	if _, err := os.Stat(p.File); err != nil {
+		return nil
+	}
+	return &p
+}
+
+// ClearPending 删除暂存的安装包与待更新记录
+func (c *Client) ClearPending() {
+	if p := c.LoadPending(); p != nil {
+		os.Remove(p.File)
+	}
+	os.Remove(filepath.Join(c.Dir, pendingFile))
+}
+
+// Apply 用暂存的安装包替换可执行文件，成功后清除暂存
+// 替换失败时 go-update 会回滚到原文件
+func (c *Client) Apply(p *Pending, exe string) error {
+	f, err := os.Open(p.File)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	cmd := strings.TrimSuffix(filepath.Base(exe), ".exe")
+	bin, err := selfupdate.UncompressCommand(f, p.AssetName, cmd)
+	if err != nil {
+		return fmt.Errorf("解压安装包失败: %w", err)
+	}
+	if err := update.Apply(bin, update.Options{TargetPath: exe}); err != nil {
+		if rerr := update.RollbackError(err); rerr != nil {
+			return fmt.Errorf("替换失败且回滚失败: %v (%w)", rerr, err)
+		}
+		return fmt.Errorf("替换可执行文件失败: %w", err)
+	}
+	f.Close() // Windows 下需先关闭才能删除
+	c.ClearPending()
+	return nil
+}
+
+// getJSON 请求 GitHub API 并解析 JSON
+func (c *Client) getJSON(ctx context.Context, url string, v any) error {
+	resp, err := c.get(ctx, url, "application/vnd.github+json")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// get 发送 GET 请求，非 2xx 状态视为失败
+func (c *Client) get(ctx context.Context, url, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" && strings.HasPrefix(url, defaultAPIBase) {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	client := c.HTTP
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("请求 %s 失败: HTTP %d", url, resp.StatusCode)
+	}
+	return resp, nil
+}
@@ -0,0 +1,99 @@
+package updater
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"testing"
+)
+
+func TestLatestAndDownload(t *testing.T) {
+	binary := []byte("new jcp binary")
+	sum := sha256.Sum256(binary)
+	assetName := fmt.Sprintf("jcp-%s-%s", runtime.GOOS, runtime.GOARCH)
+	checksums := hex.EncodeToString(sum[:]) + "  " + assetName + "\n"
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/run-bigpig/jcp/releases":
+			asset := func(name string) map[string]any {
+				return map[string]any{"name": name, "browser_download_url": srv.URL + "/dl/" + name}
+			}
+			json.NewEncoder(w).Encode([]map[string]any{
+				{"tag_name": "v2.0.0-beta", "prerelease": true, "assets": []any{asset(assetName)}},
+				{"tag_name": "v1.2.0", "body": "修复若干问题", "assets": []any{asset(assetName), asset("checksums.txt")}},
+				{"tag_name": "v1.1.0", "assets": []any{asset(assetName)}},
+				{"tag_name": "v9.0.0", "assets": []any{asset("jcp-plan9-mips")}},
+			})
+		case "/dl/" + assetName:
+			w.Write(binary)
+		case "/dl/checksums.txt":
+			w.Write([]byte(checksums))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := &Client{Repo: "run-bigpig/jcp", APIBase: srv.URL, Dir: t.TempDir()}
+	rel, err := c.Latest(context.Background())
+	if err != nil || rel == nil {
+		t.Fatalf("Latest = %v, %v", rel, err)
+	}
+	if rel.Version.String() != "1.2.0" || rel.Notes != "修复若干问题" || rel.Checksum.Name != "checksums.txt" {
+		t.Fatalf("Latest = %+v", rel)
+	}
+
+	p, err := c.Download(context.Background(), rel, nil)
+	if err != nil {
+		t.Fatalf("Download: %v", err)
+	}
+	if data, _ := os.ReadFile(p.File); string(data) != string(binary) {
+		t.Errorf("downloaded = %q", data)
+	}
+	if got := c.LoadPending(); got == nil || got.Version != "1.2.0" {
+		t.Errorf("LoadPending = %+v", got)
+	}
+
+	// 校验不一致时拒绝并清理临时文件
+	checksums = hex.EncodeToString(make([]byte, sha256.Size)) + "  " + assetName + "\n"
+	c.ClearPending()
+	if _, err := c.Download(context.Background(), rel, nil); err == nil {
+		t.Fatal("checksum mismatch should fail")
+	}
+	if c.LoadPending() != nil {
+		t.Error("failed download should not be pending")
+	}
+
+	rel.Checksum = Asset{}
+	if _, err := c.Download(context.Background(), rel, nil); err == nil {
+		t.Error("release without checksum should be rejected")
+	}
+}
+
+func TestParseChecksum(t *testing.T) {
+	hash := "E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855"
+	cases := []struct {
+		data string
+		ok   bool
+	}{
+		{hash + "  jcp-windows-amd64.exe\n", true},
+		{hash + " *jcp-windows-amd64.exe\n", true},
+		{hash + "\n", true},
+		{hash + "  jcp-linux-amd64\n", false},
+		{"not-a-hash  jcp-windows-amd64.exe\n", false},
+	}
+	for _, c := range cases {
+		got, ok := parseChecksum([]byte(c.data), "jcp-windows-amd64.exe")
+		if ok != c.ok || (ok && got != "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855") {
+			t.Errorf("parseChecksum(%q) = %q, %v", c.data, got, ok)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"sync"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+const (
+	// quietAfterRounds 连续多少轮行情无变化视为平静
+	quietAfterRounds = 5
+	// quietPollEvery 平静股票每隔多少轮拉取一次，其余轮次沿用上次行情
+	quietPollEvery = 4
+	// suspendedPollEvery 停牌股票每隔多少轮拉取一次，用于发现复牌
+	suspendedPollEvery = 20
+)
+
+// symbolActivity 单只股票的行情变化记录
+type symbolActivity struct {
+	last      models.Stock
+	unchanged int // 连续无变化的轮数
+}
+
+// adaptivePoller 按股票活跃度自适应轮询
+// 连续多轮价格与成交量都未变化的股票降低拉取频率，一旦有变化立即恢复每轮拉取，
+// 减少行情清淡时段对上游的请求量；停牌股票进一步降频，复牌后恢复
+type adaptivePoller struct {
+	mu      sync.Mutex
+	round   int
+	symbols map[string]*symbolActivity
+}
+
+func newAdaptivePoller() *adaptivePoller {
+	return &adaptivePoller{symbols: make(map[string]*symbolActivity)}
+}
+
+// Select 进入下一轮，返回本轮需要拉取的代码与沿用上次行情的平静股票
+// 不在 codes 中的记录会被清理
+func (a *adaptivePoller) Select(codes []string) (fetch []string, cached []models.Stock) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.round++
+	pollQuiet := a.round%quietPollEvery == 0
+	pollSuspended := a.round%suspendedPollEvery == 0
+
+	keep := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		keep[code] = true
+		act, ok := a.symbols[code]
+		if ok && act.last.Status == models.StockStatusSuspended && !pollSuspended {
+			cached = append(cached, act.last)
+			continue
+		}
+		if ok && act.unchanged >= quietAfterRounds && !pollQuiet {
+			cached = append(cached, act.last)
+			continue
+		}
+		fetch = append(fetch, code)
+	}
+	for code := range a.symbols {
+		if !keep[code] {
+			delete(a.symbols, code)
+		}
+	}
+	return fetch, cached
+}
+
+// Observe 记录本轮拉取到的行情，返回交易状态发生变化的股票
+func (a *adaptivePoller) Observe(stocks []models.Stock) []StockStatusChange {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var changes []StockStatusChange
+	for _, s := range stocks {
+		act, ok := a.symbols[s.Symbol]
+		if !ok {
+			a.symbols[s.Symbol] = &symbolActivity{last: s}
+			continue
+		}
+		if act.last.Price == s.Price && act.last.Volume == s.Volume {
+			act.unchanged++
+		} else {
+			act.unchanged = 0
+		}
+		if change, ok := stockStatusChange(act.last, s); ok {
+			changes = append(changes, change)
+		}
+		act.last = s
+	}
+	return changes
+}
+
+// Quiet 当前处于平静状态的股票数
+func (a *adaptivePoller) Quiet() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	n := 0
+	for _, act := range a.symbols {
+		if act.unchanged >= quietAfterRounds {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// TestAdaptivePoller 测试平静股票降频拉取，行情变化后恢复每轮拉取
+func TestAdaptivePoller(t *testing.T) {
+	a := newAdaptivePoller()
+	codes := []string{"sh600000", "sz000001"}
+	quiet := models.Stock{Symbol: "sh600000", Price: 10, Volume: 100}
+	price := 20.0
+
+	// 连续多轮无变化后进入平静状态
+	for i := 0; i <= quietAfterRounds; i++ {
+		fetch, _ := a.Select(codes)
+		if len(fetch) != 2 {
+			t.Fatalf("第 %d 轮应全部拉取: %v", i+1, fetch)
+		}
+		price += 0.01
+		a.Observe([]models.Stock{quiet, {Symbol: "sz000001", Price: price, Volume: int64(i)}})
+	}
+	if a.Quiet() != 1 {
+		t.Fatalf("Quiet = %d", a.Quiet())
+	}
+
+	skipped, polled := 0, 0
+	for i := 0; i < quietPollEvery; i++ {
+		fetch, cached := a.Select(codes)
+		if slices.Contains(fetch, "sh600000") {
+			polled++
+		} else if len(cached) == 1 && cached[0] == quiet {
+			skipped++
+		}
+		if !slices.Contains(fetch, "sz000001") {
+			t.Fatal("活跃股票应每轮拉取")
+		}
+	}
+	if polled != 1 || skipped != quietPollEvery-1 {
+		t.Errorf("平静股票应每 %d 轮拉取一次: polled=%d skipped=%d", quietPollEvery, polled, skipped)
+	}
+
+	// 行情变化后恢复
+	moved := quiet
+	moved.Price = 10.5
+	a.Observe([]models.Stock{moved})
+	if fetch, _ := a.Select(codes); !slices.Contains(fetch, "sh600000") {
+		t.Error("行情变化后应恢复每轮拉取")
+	}
+
+	// 取消订阅后清理记录
+	a.Select([]string{"sz000001"})
+	if _, ok := a.symbols["sh600000"]; ok {
+		t.Error("取消订阅的股票应被清理")
+	}
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+const (
+	consensusReportCount = 50 // 拉取最近研报篇数
+	consensusWindowDays  = 90 // 参与一致预期统计的研报时间窗口
+)
+
+// consensusCacheEntry 一致预期缓存条目
+type consensusCacheEntry struct {
+	date string
+	data *models.AnalystConsensus
+}
+
+// ratingRank 评级强弱（用于判断上调/下调），未知评级为 0
+var ratingRank = map[string]int{
+	"买入": 5, "强烈推荐": 5,
+	"增持": 4, "推荐": 4, "优于大市": 4, "跑赢行业": 4,
+	"中性": 3, "持有": 3, "同步大市": 3,
+	"减持": 2, "弱于大市": 2, "跑输行业": 2,
+	"卖出": 1,
+}
+
+// GetAnalystConsensus 获取个股研报评级与目标价汇总（按自然日缓存）
+func (s *ResearchReportService) GetAnalystConsensus(symbol string) (*models.AnalystConsensus, error) {
+	today := time.Now().Format("2006-01-02")
+
+	s.consensusCacheMu.Lock()
+	if e, ok := s.consensusCache[symbol]; ok && e.date == today {
+		s.consensusCacheMu.Unlock()
+		return e.data, nil
+	}
+	s.consensusCacheMu.Unlock()
+
+	resp, err := s.GetResearchReports(symbol, consensusReportCount, 1)
+	if err != nil {
+		return nil, err
+	}
+	consensus := buildAnalystConsensus(resp.Data, time.Now())
+	consensus.Symbol = symbol
+
+	s.consensusCacheMu.Lock()
+	s.consensusCache[symbol] = &consensusCacheEntry{date: today, data: consensus}
+	s.consensusCacheMu.Unlock()
+	return consensus, nil
+}
+
+// buildAnalystConsensus 汇总研报：时间窗口内每家券商取最新一篇统计评级分布与目标价
+func buildAnalystConsensus(reports []ResearchReport, now time.Time) *models.AnalystConsensus {
+	c := &models.AnalystConsensus{
+		Reports:      make([]models.AnalystRating, 0, len(reports)),
+		RatingCounts: map[string]int{},
+		UpdatedAt:    now.UnixMilli(),
+	}
+	for _, r := range reports {
+		c.Reports = append(c.Reports, toAnalystRating(r))
+	}
+	sort.SliceStable(c.Reports, func(i, j int) bool { return c.Reports[i].PublishDate > c.Reports[j].PublishDate })
+
+	since := now.AddDate(0, 0, -consensusWindowDays).Format("2006-01-02")
+	counted := map[string]bool{}
+	var targetSum float64
+	targets := 0
+	for _, r := range c.Reports {
+		if r.PublishDate < since || counted[r.OrgName] {
+			continue
+		}
+		counted[r.OrgName] = true
+		c.OrgCount++
+		if r.Rating != "" {
+			c.RatingCounts[r.Rating]++
+		}
+		switch r.RatingChange {
+		case models.RatingChangeUpgrade:
+			c.UpgradeCount++
+		case models.RatingChangeDowngrade:
+			c.DowngradeCount++
+		}
+		if r.TargetPrice > 0 {
+			targetSum += r.TargetPrice
+			targets++
+			if r.TargetPrice > c.HighTargetPrice {
+				c.HighTargetPrice = r.TargetPrice
+			}
+			if c.LowTargetPrice == 0 || r.TargetPrice < c.LowTargetPrice {
+				c.LowTargetPrice = r.TargetPrice
+			}
+		}
+	}
+	if targets > 0 {
+		c.AvgTargetPrice = roundPrice(targetSum / float64(targets))
+	}
+	return c
+}
+
+// toAnalystRating 研报转评级条目，目标价取上下限均值
+func toAnalystRating(r ResearchReport) models.AnalystRating {
+	high, low := reportPrice(r.AimPriceHigh), reportPrice(r.AimPriceLow)
+	target := high
+	if high > 0 && low > 0 {
+		target = roundPrice((high + low) / 2)
+	} else if target == 0 {
+		target = low
+	}
+	date, _, _ := strings.Cut(r.PublishDate, " ")
+	return models.AnalystRating{
+		Title:        r.Title,
+		OrgName:      r.OrgSName,
+		Researcher:   r.Researcher,
+		PublishDate:  date,
+		Rating:       r.EmRatingName,
+		LastRating:   r.LastEmRatingName,
+		RatingChange: ratingChange(r.EmRatingName, r.LastEmRatingName),
+		TargetPrice:  target,
+		InfoCode:     r.InfoCode,
+	}
+}
+
+// ratingChange 比较本次与上次评级
+func ratingChange(rating, last string) string {
+	if last == "" {
+		return models.RatingChangeNew
+	}
+	cur, prev := ratingRank[rating], ratingRank[last]
+	switch {
+	case cur == 0 || prev == 0 || cur == prev:
+		return models.RatingChangeMaintain
+	case cur > prev:
+		return models.RatingChangeUpgrade
+	default:
+		return models.RatingChangeDowngrade
+	}
+}
+
+// reportPrice 解析研报目标价（接口可能返回字符串或数字）
+func reportPrice(v any) float64 {
+	switch p := v.(type) {
+	case float64:
+		return p
+	case string:
+		f, _ := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		return f
+	}
+	return 0
+}
@@ -0,0 +1,524 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+	"github.com/run-bigpig/jcp/internal/pkg/supervisor"
+
+	"google.golang.org/adk/model"
+	"google.golang.org/genai"
+)
+
+const (
+	defaultBriefingTime          = "15:30"
+	defaultBriefingMaxStocks     = 20
+	defaultBriefingNearTargetPct = 2.0
+	briefingMarketClose          = 15 * 60 // 收盘时间（分钟）
+	briefingNewsPerStock         = 5
+	briefingSessionTopK          = 3 // 每只股票的复盘时点数
+	briefingCheckInterval        = time.Minute
+)
+
+// BriefingLLMProvider 按 AI 配置ID创建模型，空ID使用默认AI
+type BriefingLLMProvider func(ctx context.Context, aiConfigID string) (model.LLM, error)
+
+// BriefingSessionProvider 生成单只股票的盘面复盘数据
+type BriefingSessionProvider func(ctx context.Context, code string, topK int) *models.SessionHeatReport
+
+// BriefingNearTargetsProvider 获取当日进入跟踪线 pct% 以内的股票
+type BriefingNearTargetsProvider func(pct float64) []models.TargetProximity
+
+// BriefingService 每日收盘简报服务
+// 交易日收盘后对自选股依次执行：行情汇总 → 快讯收集 → 盘面复盘 → AI 点评，结果保存到数据目录 briefings 下
+type BriefingService struct {
+	dir           string
+	configService *ConfigService
+	marketService *MarketService
+	newsService   *NewsService
+	llmProvider   BriefingLLMProvider
+	sessions      BriefingSessionProvider
+	nearTargets   BriefingNearTargetsProvider
+	onReady       func(models.DailyBriefing)
+
+	mu          sync.Mutex
+	running     bool
+	lastAttempt string // 最近一次自动生成的日期，失败后当日不再自动重试
+}
+
+// NewBriefingService 创建每日简报服务
+func NewBriefingService(dataDir string, configService *ConfigService, marketService *MarketService, newsService *NewsService) *BriefingService {
+	return &BriefingService{
+		dir:           filepath.Join(dataDir, "briefings"),
+		configService: configService,
+		marketService: marketService,
+		newsService:   newsService,
+	}
+}
+
+// SetLLMProvider 设置模型创建方法
+func (s *BriefingService) SetLLMProvider(provider BriefingLLMProvider) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.llmProvider = provider
+}
+
+// SetSessionProvider 设置盘面复盘数据来源，未设置时简报不含复盘
+func (s *BriefingService) SetSessionProvider(provider BriefingSessionProvider) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions = provider
+}
+
+// SetNearTargetsProvider 设置接近跟踪线的股票来源，未设置时简报不含该部分
+func (s *BriefingService) SetNearTargetsProvider(provider BriefingNearTargetsProvider) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nearTargets = provider
+}
+
+// SetOnReady 设置简报生成完成回调
+func (s *BriefingService) SetOnReady(fn func(models.DailyBriefing)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onReady = fn
+}
+
+// Start 启动定时检查，交易日到达设定时间后自动生成当日简报
+func (s *BriefingService) Start(ctx context.Context) {
+	supervisor.Go(ctx, "briefing", func(ctx context.Context) {
+		ticker := time.NewTicker(briefingCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.checkSchedule(ctx)
+			}
+		}
+	})
+}
+
+// checkSchedule 判断是否需要生成当日简报
+func (s *BriefingService) checkSchedule(ctx context.Context) {
+	cfg := s.configService.GetConfig().Briefing
+	if !cfg.Enabled {
+		return
+	}
+	now := time.Now().In(time.FixedZone("CST", 8*60*60))
+	today := now.Format("2006-01-02")
+	if !briefingDue(now, cfg.Time) || s.exists(today) {
+		return
+	}
+	s.mu.Lock()
+	attempted := s.lastAttempt == today
+	s.lastAttempt = today
+	s.mu.Unlock()
+	if attempted || !s.marketService.GetMarketStatus().IsTradeDay {
+		return
+	}
+	if _, err := s.Generate(ctx); err != nil {
+		fmt.Printf("生成每日简报失败: %v\n", err)
+	}
+}
+
+// briefingDue 当前时间是否已到生成时间，设定时间早于收盘时按收盘时间处理
+func briefingDue(now time.Time, at string) bool {
+	t, err := time.Parse("15:04", strings.TrimSpace(at))
+	if err != nil {
+		t, _ = time.Parse("15:04", defaultBriefingTime)
+	}
+	due := max(t.Hour()*60+t.Minute(), briefingMarketClose)
+	return now.Hour()*60+now.Minute() >= due
+}
+
+// Generate 立即生成当日简报，完成后触发回调
+func (s *BriefingService) Generate(ctx context.Context) (*models.DailyBriefing, error) {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return nil, i18n.Errorf("error.briefing.running")
+	}
+	s.running = true
+	provider, sessions, nearTargets := s.llmProvider, s.sessions, s.nearTargets
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.running = false
+		s.mu.Unlock()
+	}()
+
+	if provider == nil {
+		return nil, i18n.Errorf("error.ai.not_configured")
+	}
+	cfg := s.configService.GetConfig().Briefing
+	llm, err := provider(ctx, cfg.AIConfigID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().In(time.FixedZone("CST", 8*60*60))
+	briefing := &models.DailyBriefing{Date: now.Format("2006-01-02")}
+	briefing.Stocks, err = s.collectStocks(ctx, cfg.MaxStocks)
+	if err != nil {
+		return nil, err
+	}
+	s.collectNews(ctx, briefing.Date, briefing.Stocks)
+	briefing.NearTargets = []models.TargetProximity{}
+	if nearTargets != nil {
+		briefing.NearTargets = nearTargets(cmp.Or(cfg.NearTargetPct, defaultBriefingNearTargetPct))
+	}
+	if sessions != nil {
+		for i := range briefing.Stocks {
+			briefing.Stocks[i].Session = sessions(ctx, briefing.Stocks[i].Code, briefingSessionTopK)
+		}
+	}
+
+	response, err := callBriefingLLM(ctx, llm, buildBriefingPrompt(briefing))
+	if err != nil {
+		return nil, i18n.Errorf("error.briefing.ai_failed", err)
+	}
+	applyBriefingResponse(briefing, response)
+	briefing.GeneratedAt = now.UnixMilli()
+
+	if err := s.save(briefing); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	onReady := s.onReady
+	s.mu.Unlock()
+	if onReady != nil {
+		onReady(*briefing)
+	}
+	return briefing, nil
+}
+
+// collectStocks 汇总自选股当日表现，按涨跌幅绝对值排序
+func (s *BriefingService) collectStocks(ctx context.Context, limit int) ([]models.BriefingStock, error) {
+	watchlist := s.configService.GetWatchlist()
+	if len(watchlist) == 0 {
+		return nil, i18n.Errorf("error.watchlist.empty")
+	}
+	if limit <= 0 {
+		limit = defaultBriefingMaxStocks
+	}
+	if len(watchlist) > limit {
+		watchlist = watchlist[:limit]
+	}
+	codes := make([]string, len(watchlist))
+	for i, st := range watchlist {
+		codes[i] = st.Symbol
+	}
+	quotes, err := s.marketService.GetStockRealTimeData(ctx, codes...)
+	if err != nil {
+		return nil, i18n.Errorf("error.quote.failed", err)
+	}
+
+	quoteMap := make(map[string]models.Stock, len(quotes))
+	for _, q := range quotes {
+		quoteMap[q.Symbol] = q
+	}
+	stocks := make([]models.BriefingStock, 0, len(watchlist))
+	for _, st := range watchlist {
+		item := models.BriefingStock{Code: st.Symbol, Name: st.Name}
+		if q, ok := quoteMap[st.Symbol]; ok {
+			if q.Name != "" {
+				item.Name = q.Name
+			}
+			item.Price = q.Price
+			item.ChangePercent = q.ChangePercent
+			item.Amount = q.Amount
+		}
+		stocks = append(stocks, item)
+	}
+	sort.SliceStable(stocks, func(i, j int) bool {
+		return math.Abs(stocks[i].ChangePercent) > math.Abs(stocks[j].ChangePercent)
+	})
+	return stocks, nil
+}
+
+// collectNews 收集当日提及股票名称的快讯，优先使用本地归档，未启用归档时使用实时快讯
+func (s *BriefingService) collectNews(ctx context.Context, date string, stocks []models.BriefingStock) {
+	var live []Telegraph
+	for i := range stocks {
+		name := stocks[i].Name
+		if name == "" {
+			continue
+		}
+		items, err := s.newsService.SearchHistory(name, date, date)
+		if err != nil {
+			if live == nil {
+				if live, _ = s.newsService.GetTelegraphList(ctx); live == nil {
+					live = []Telegraph{}
+				}
+			}
+			items = nil
+			for _, t := range live {
+				if strings.Contains(t.Content, name) {
+					items = append(items, t)
+				}
+			}
+		}
+		for _, t := range items {
+			if len(stocks[i].News) >= briefingNewsPerStock {
+				break
+			}
+			stocks[i].News = append(stocks[i].News, strings.TrimSpace(t.Content))
+		}
+	}
+}
+
+// buildBriefingPrompt 构建简报点评提示词
+func buildBriefingPrompt(b *models.DailyBriefing) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "今天是 %s，A股已收盘。以下是用户自选股的当日表现与相关快讯：\n\n", b.Date)
+	for _, st := range b.Stocks {
+		fmt.Fprintf(&sb, "- %s（%s）收盘 %.2f，涨跌幅 %+.2f%%，成交额 %.0f 万\n", st.Name, st.Code, st.Price, st.ChangePercent, st.Amount/10000)
+		for _, n := range st.News {
+			fmt.Fprintf(&sb, "  - 快讯：%s\n", clipNews(n))
+		}
+		if line := sessionSummary(st.Session); line != "" {
+			fmt.Fprintf(&sb, "  - 盘面：%s\n", line)
+		}
+	}
+	if len(b.NearTargets) > 0 {
+		sb.WriteString("\n今日盘中接近用户设定跟踪线的股票：\n")
+	}
+	for _, p := range b.NearTargets {
+		fmt.Fprintf(&sb, "- %s 盘中最接近%s %.2f（最近价 %.2f，距离 %.2f%%）\n", p.Symbol, targetKindName(p.Kind), p.Line, p.Price, p.Distance)
+	}
+	sb.WriteString(`
+请作为资深A股分析师，完成以下工作：
+1. 用 150 字以内总结自选股今日整体表现与值得注意的消息
+2. 为每只股票给出 60 字以内的次日关注点（关键价位、消息催化或风险提示）
+
+请严格按以下 JSON 格式返回，不要输出其他内容：
+{"summary": "整体总结", "stocks": [{"code": "股票代码", "watchPoints": "次日关注点"}]}`)
+	return sb.String()
+}
+
+// clipNews 截断过长的快讯，避免提示词过长
+func clipNews(s string) string {
+	if r := []rune(s); len(r) > 200 {
+		return string(r[:200]) + "..."
+	}
+	return s
+}
+
+// briefingResponse AI 点评结果
+type briefingResponse struct {
+	Summary string `json:"summary"`
+	Stocks  []struct {
+		Code        string `json:"code"`
+		WatchPoints string `json:"watchPoints"`
+	} `json:"stocks"`
+}
+
+// applyBriefingResponse 解析 AI 点评并写入简报，JSON 无法解析时将原文作为总结
+func applyBriefingResponse(b *models.DailyBriefing, response string) {
+	var resp briefingResponse
+	jsonStr := extractJSON(response)
+	if jsonStr == "" || json.Unmarshal([]byte(jsonStr), &resp) != nil {
+		b.Summary = strings.TrimSpace(response)
+		return
+	}
+	b.Summary = strings.TrimSpace(resp.Summary)
+	points := make(map[string]string, len(resp.Stocks))
+	for _, st := range resp.Stocks {
+		points[strings.ToLower(strings.TrimSpace(st.Code))] = strings.TrimSpace(st.WatchPoints)
+	}
+	for i := range b.Stocks {
+		b.Stocks[i].WatchPoints = points[strings.ToLower(b.Stocks[i].Code)]
+	}
+}
+
+// callBriefingLLM 调用模型，忽略思考内容
+func callBriefingLLM(ctx context.Context, llm model.LLM, prompt string) (string, error) {
+	req := &model.LLMRequest{
+		Contents: []*genai.Content{
+			{
+				Role:  "user",
+				Parts: []*genai.Part{{Text: prompt}},
+			},
+		},
+	}
+
+	var result string
+	for resp, err := range llm.GenerateContent(ctx, req, false) {
+		if err != nil {
+			return "", err
+		}
+		if resp != nil && resp.Content != nil {
+			for _, part := range resp.Content.Parts {
+				if !part.Thought && part.Text != "" {
+					result += part.Text
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+// renderBriefing 将简报渲染为 Markdown
+func renderBriefing(b *models.DailyBriefing) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s 自选股收盘简报\n\n", b.Date)
+	sb.WriteString("> 本简报由 AI 生成，仅供参考，不构成投资建议。\n\n")
+	if b.Summary != "" {
+		sb.WriteString("## 今日总览\n\n")
+		sb.WriteString(b.Summary + "\n\n")
+	}
+
+	sb.WriteString("## 当日表现\n\n")
+	sb.WriteString("| 股票 | 收盘价 | 涨跌幅 | 成交额(万) |\n")
+	sb.WriteString("| --- | --- | --- | --- |\n")
+	for _, st := range b.Stocks {
+		fmt.Fprintf(&sb, "| %s（%s） | %.2f | %+.2f%% | %.0f |\n", st.Name, st.Code, st.Price, st.ChangePercent, st.Amount/10000)
+	}
+	if len(b.NearTargets) > 0 {
+		names := make(map[string]string, len(b.Stocks))
+		for _, st := range b.Stocks {
+			names[st.Code] = st.Name
+		}
+		sb.WriteString("\n## 接近跟踪线\n\n")
+		sb.WriteString("| 股票 | 跟踪线 | 价位 | 最近价 | 距离 | 时间 |\n")
+		sb.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+		for _, p := range b.NearTargets {
+			name := p.Symbol
+			if n := names[p.Symbol]; n != "" {
+				name = fmt.Sprintf("%s（%s）", n, p.Symbol)
+			}
+			fmt.Fprintf(&sb, "| %s | %s | %.2f | %.2f | %.2f%% | %s |\n", name, targetKindName(p.Kind), p.Line, p.Price, p.Distance,
+				time.UnixMilli(p.Time).Format("15:04"))
+		}
+	}
+	sb.WriteString("\n## 次日关注\n\n")
+	for _, st := range b.Stocks {
+		fmt.Fprintf(&sb, "### %s（%s）\n\n", st.Name, st.Code)
+		if st.WatchPoints != "" {
+			sb.WriteString(st.WatchPoints + "\n\n")
+		}
+		if line := sessionSummary(st.Session); line != "" {
+			fmt.Fprintf(&sb, "- 盘面复盘：%s\n", line)
+		}
+		for _, n := range st.News {
+			fmt.Fprintf(&sb, "- %s\n", n)
+		}
+		if len(st.News) > 0 || sessionSummary(st.Session) != "" {
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+// targetKindName 跟踪线类型名称
+func targetKindName(kind string) string {
+	if kind == TargetKindStop {
+		return "止损价"
+	}
+	return "目标价"
+}
+
+// sessionSummary 盘面复盘摘要：最活跃分钟、盘口突变与提醒，无数据时返回空
+func sessionSummary(r *models.SessionHeatReport) string {
+	if r == nil {
+		return ""
+	}
+	var parts []string
+	if len(r.HotMinutes) > 0 {
+		times := make([]string, len(r.HotMinutes))
+		for i, m := range r.HotMinutes {
+			times[i] = fmt.Sprintf("%s（量比 %.1f，%+.2f%%）", clockMinute(m.Time), m.VolumeRatio, m.ChangePercent)
+		}
+		parts = append(parts, "放量时段 "+strings.Join(times, "、"))
+	}
+	if len(r.OrderBookShifts) > 0 {
+		times := make([]string, len(r.OrderBookShifts))
+		for i, sh := range r.OrderBookShifts {
+			times[i] = clockMinute(sh.Time)
+		}
+		parts = append(parts, "盘口突变 "+strings.Join(times, "、"))
+	}
+	if len(r.Timeline) > 0 {
+		events := make([]string, len(r.Timeline))
+		for i, e := range r.Timeline {
+			events[i] = clockMinute(e.Time) + " " + e.Title
+		}
+		parts = append(parts, "提醒 "+strings.Join(events, "、"))
+	}
+	return strings.Join(parts, "；")
+}
+
+// clockMinute 取时间中的 HH:MM
+func clockMinute(t string) string {
+	if i := strings.LastIndex(t, " "); i >= 0 {
+		t = t[i+1:]
+	}
+	if len(t) > 5 {
+		t = t[:5]
+	}
+	return t
+}
+
+// save 保存 Markdown 报告与 JSON 数据
+func (s *BriefingService) save(b *models.DailyBriefing) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return err
+	}
+	b.Path = filepath.Join(s.dir, b.Date+".md")
+	if err := os.WriteFile(b.Path, []byte(renderBriefing(b)), 0644); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileTracked(filepath.Join(s.dir, b.Date+".json"), data, 0644)
+}
+
+// exists 当日简报是否已生成
+func (s *BriefingService) exists(date string) bool {
+	_, err := os.Stat(filepath.Join(s.dir, date+".json"))
+	return err == nil
+}
+
+// GetLatest 获取最近一份简报，没有时返回 nil
+func (s *BriefingService) GetLatest() *models.DailyBriefing {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil
+	}
+	latest := ""
+	for _, entry := range entries {
+		if date, ok := strings.CutSuffix(entry.Name(), ".json"); ok && date > latest {
+			latest = date
+		}
+	}
+	if latest == "" {
+		return nil
+	}
+	data, err := os.ReadFile(filepath.Join(s.dir, latest+".json"))
+	if err != nil {
+		fmt.Printf("读取简报失败 %s: %v\n", latest, err)
+		return nil
+	}
+	var b models.DailyBriefing
+	if err := json.Unmarshal(data, &b); err != nil {
+		fmt.Printf("解析简报失败 %s: %v\n", latest, err)
+		return nil
+	}
+	return &b
+}
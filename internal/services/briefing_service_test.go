@@ -0,0 +1,118 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+func TestBriefingDue(t *testing.T) {
+	loc := time.FixedZone("CST", 8*60*60)
+	at := func(h, m int) time.Time { return time.Date(2026, 3, 2, h, m, 0, 0, loc) }
+	cases := []struct {
+		now  time.Time
+		time string
+		want bool
+	}{
+		{at(15, 29), "15:30", false},
+		{at(15, 30), "15:30", true},
+		{at(14, 0), "10:00", false}, // 早于收盘按收盘处理
+		{at(15, 0), "10:00", true},
+		{at(15, 40), "bad", true}, // 非法时间使用默认值
+	}
+	for _, c := range cases {
+		if got := briefingDue(c.now, c.time); got != c.want {
+			t.Errorf("briefingDue(%s, %q) = %v, want %v", c.now.Format("15:04"), c.time, got, c.want)
+		}
+	}
+}
+
+func TestApplyBriefingResponse(t *testing.T) {
+	b := &models.DailyBriefing{Stocks: []models.BriefingStock{{Code: "sh600519"}, {Code: "sz000001"}}}
+	applyBriefingResponse(b, "```json\n{\"summary\": \"整体震荡\", \"stocks\": [{\"code\": \"SH600519\", \"watchPoints\": \"关注1700支撑\"}]}\n```")
+	if b.Summary != "整体震荡" || b.Stocks[0].WatchPoints != "关注1700支撑" || b.Stocks[1].WatchPoints != "" {
+		t.Fatalf("unexpected briefing: %+v", b)
+	}
+
+	applyBriefingResponse(b, "今日自选股普涨")
+	if b.Summary != "今日自选股普涨" {
+		t.Fatalf("fallback summary = %q", b.Summary)
+	}
+}
+
+func TestBriefingSaveAndLatest(t *testing.T) {
+	s := NewBriefingService(t.TempDir(), nil, nil, nil)
+	if s.GetLatest() != nil {
+		t.Fatal("GetLatest on empty dir should be nil")
+	}
+	for _, date := range []string{"2026-03-02", "2026-03-03"} {
+		b := &models.DailyBriefing{
+			Date:    date,
+			Summary: "总结",
+			Stocks:  []models.BriefingStock{{Code: "sh600519", Name: "贵州茅台", News: []string{"茅台发布公告"}}},
+		}
+		if err := s.save(b); err != nil {
+			t.Fatalf("save: %v", err)
+		}
+	}
+	latest := s.GetLatest()
+	if latest == nil || latest.Date != "2026-03-03" || !strings.HasSuffix(latest.Path, "2026-03-03.md") {
+		t.Fatalf("unexpected latest: %+v", latest)
+	}
+	if !s.exists("2026-03-02") || s.exists("2026-03-04") {
+		t.Fatal("exists mismatch")
+	}
+	md := renderBriefing(latest)
+	if !strings.Contains(md, "贵州茅台（sh600519）") || !strings.Contains(md, "- 茅台发布公告") {
+		t.Fatalf("unexpected markdown:\n%s", md)
+	}
+}
+
+func TestSessionSummary(t *testing.T) {
+	if sessionSummary(nil) != "" || sessionSummary(&models.SessionHeatReport{}) != "" {
+		t.Fatal("empty session should render nothing")
+	}
+	r := &models.SessionHeatReport{
+		HotMinutes:      []models.HeatMinute{{Time: "2026-03-03 10:31:00", VolumeRatio: 3.24, ChangePercent: 1.2}},
+		OrderBookShifts: []models.OrderBookShift{{Time: "10:32:05"}},
+		Timeline:        []models.SessionEvent{{Time: "10:40:00", Title: "条件单触发"}},
+	}
+	want := "放量时段 10:31（量比 3.2，+1.20%）；盘口突变 10:32；提醒 10:40 条件单触发"
+	if got := sessionSummary(r); got != want {
+		t.Fatalf("sessionSummary = %q", got)
+	}
+	md := renderBriefing(&models.DailyBriefing{Stocks: []models.BriefingStock{{Code: "sh600519", Name: "贵州茅台", Session: r}}})
+	if !strings.Contains(md, "- 盘面复盘："+want) {
+		t.Fatalf("session missing from markdown:\n%s", md)
+	}
+}
+
+func TestRenderBriefingNearTargets(t *testing.T) {
+	at := time.Date(2026, 3, 3, 10, 25, 0, 0, time.Local).UnixMilli()
+	b := &models.DailyBriefing{
+		Date:   "2026-03-03",
+		Stocks: []models.BriefingStock{{Code: "sh600519", Name: "贵州茅台"}},
+		NearTargets: []models.TargetProximity{
+			{Symbol: "sh600519", Kind: TargetKindStop, Line: 1500, Price: 1512, Distance: 0.8, Time: at},
+			{Symbol: "sz000001", Kind: TargetKindTarget, Line: 12, Price: 11.9, Distance: 0.84, Time: at},
+		},
+	}
+	md := renderBriefing(b)
+	for _, want := range []string{
+		"## 接近跟踪线",
+		"| 贵州茅台（sh600519） | 止损价 | 1500.00 | 1512.00 | 0.80% | 10:25 |",
+		"| sz000001 | 目标价 | 12.00 | 11.90 | 0.84% | 10:25 |",
+	} {
+		if !strings.Contains(md, want) {
+			t.Fatalf("missing %q in markdown:\n%s", want, md)
+		}
+	}
+	if prompt := buildBriefingPrompt(b); !strings.Contains(prompt, "sh600519 盘中最接近止损价 1500.00") {
+		t.Fatalf("near targets missing from prompt:\n%s", prompt)
+	}
+	if strings.Contains(renderBriefing(&models.DailyBriefing{}), "接近跟踪线") {
+		t.Fatal("empty near targets should not render a section")
+	}
+}
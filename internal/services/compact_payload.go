@@ -0,0 +1,97 @@
+package services
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// 推送编码
+const (
+	PushEncodingJSON    = "json"    // 对象数组（默认）
+	PushEncodingCompact = "compact" // 字段数组：字段名只输出一次，每行按字段顺序输出值
+)
+
+// compactMinRows 行数少于该值时不压缩，节省的字段名不足以抵消编码开销
+const compactMinRows = 20
+
+// CompactTable 紧凑编码的对象数组
+// 前端按 Fields 还原为对象；值为 null 的列表示该字段被 omitempty 省略
+type CompactTable struct {
+	Version int      `json:"$c"`
+	Fields  []string `json:"fields"`
+	Rows    [][]any  `json:"rows"`
+}
+
+// compactField 结构体字段的编码计划
+type compactField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+}
+
+// compactPlans 按类型缓存字段计划
+var compactPlans sync.Map // reflect.Type -> []compactField
+
+// encodeRows 按当前编码输出切片：compact 且行数足够时输出 CompactTable，否则原样返回
+func encodeRows[T any](encoding string, rows []T) any {
+	if encoding != PushEncodingCompact || len(rows) < compactMinRows {
+		return rows
+	}
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		return rows
+	}
+	plan := compactPlan(t)
+	table := CompactTable{Version: 1, Fields: make([]string, len(plan)), Rows: make([][]any, len(rows))}
+	for i, f := range plan {
+		table.Fields[i] = f.name
+	}
+	for i := range rows {
+		v := reflect.ValueOf(&rows[i]).Elem()
+		row := make([]any, len(plan))
+		for j, f := range plan {
+			fv := v.FieldByIndex(f.index)
+			if f.omitEmpty && fv.IsZero() {
+				continue
+			}
+			row[j] = fv.Interface()
+		}
+		table.Rows[i] = row
+	}
+	return table
+}
+
+// compactPlan 按 encoding/json 规则列出导出字段：使用 json 标签名，展开匿名嵌入结构体，跳过 "-"
+func compactPlan(t reflect.Type) []compactField {
+	if plan, ok := compactPlans.Load(t); ok {
+		return plan.([]compactField)
+	}
+	var plan []compactField
+	var walk func(t reflect.Type, index []int)
+	walk = func(t reflect.Type, index []int) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			idx := append(append([]int(nil), index...), i)
+			tag := f.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			name, opts, _ := strings.Cut(tag, ",")
+			if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+				walk(f.Type, idx)
+				continue
+			}
+			if !f.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			plan = append(plan, compactField{name: name, index: idx, omitEmpty: strings.Contains(opts, "omitempty")})
+		}
+	}
+	walk(t, nil)
+	compactPlans.Store(t, plan)
+	return plan
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// decodeCompact 按前端解码规则还原对象数组
+func decodeCompact(t *testing.T, data []byte) []map[string]any {
+	t.Helper()
+	var table CompactTable
+	if err := json.Unmarshal(data, &table); err != nil {
+		t.Fatalf("解析紧凑编码失败: %v", err)
+	}
+	rows := make([]map[string]any, len(table.Rows))
+	for i, row := range table.Rows {
+		obj := map[string]any{}
+		for j, v := range row {
+			if v != nil {
+				obj[table.Fields[j]] = v
+			}
+		}
+		rows[i] = obj
+	}
+	return rows
+}
+
+// TestEncodeRowsRoundTrip 测试紧凑编码还原后与普通 JSON 一致
+func TestEncodeRowsRoundTrip(t *testing.T) {
+	klines := make([]models.KLineData, compactMinRows)
+	for i := range klines {
+		klines[i] = models.KLineData{Time: fmt.Sprintf("2024-01-%02d", i+1), Open: 10, Close: 10.5, Volume: int64(i * 100)}
+		if i%2 == 0 {
+			klines[i].MA5 = 10.2
+		}
+	}
+
+	plain, _ := json.Marshal(klines)
+	var want []map[string]any
+	json.Unmarshal(plain, &want)
+
+	compact, _ := json.Marshal(encodeRows(PushEncodingCompact, klines))
+	got := decodeCompact(t, compact)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("还原结果不一致:\n got %v\nwant %v", got[0], want[0])
+	}
+	if len(compact) >= len(plain) {
+		t.Errorf("紧凑编码应更小: %d >= %d", len(compact), len(plain))
+	}
+}
+
+// TestEncodeRowsFallback 测试 json 编码或行数较少时原样返回
+func TestEncodeRowsFallback(t *testing.T) {
+	small := make([]models.KLineData, compactMinRows-1)
+	if _, ok := encodeRows(PushEncodingCompact, small).([]models.KLineData); !ok {
+		t.Error("行数不足时不应压缩")
+	}
+	large := make([]models.KLineData, compactMinRows)
+	if _, ok := encodeRows(PushEncodingJSON, large).([]models.KLineData); !ok {
+		t.Error("json 编码不应压缩")
+	}
+}
+
+// TestCompactPlanEmbedded 测试匿名嵌入结构体字段被展开
+func TestCompactPlanEmbedded(t *testing.T) {
+	type inner struct {
+		A int `json:"a"`
+	}
+	type outer struct {
+		inner
+		B      string `json:"b,omitempty"`
+		Hidden int    `json:"-"`
+	}
+	var names []string
+	for _, f := range compactPlan(reflect.TypeFor[outer]()) {
+		names = append(names, f.name)
+	}
+	if !reflect.DeepEqual(names, []string{"a", "b"}) {
+		t.Errorf("fields = %v", names)
+	}
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+)
+
+const (
+	maxComparisonSymbols = 8
+	maxComparisonBars    = 1000 // 数据源单次最多返回约1023根
+	defaultCompareRange  = "6m"
+)
+
+// comparisonRanges 对比区间 -> 起始日期偏移（年, 月）
+var comparisonRanges = map[string][2]int{
+	"1m": {0, -1},
+	"3m": {0, -3},
+	"6m": {0, -6},
+	"1y": {-1, 0},
+	"3y": {-3, 0},
+	"5y": {-5, 0},
+}
+
+// GetComparisonSeries 获取多只股票按时间对齐、以共同基准归一化为涨跌幅的K线序列
+// period: 1m（当日分时，忽略 rng）/1d/1w/1mo；rng: 1m/3m/6m/1y/3y/5y/ytd，空为近6个月
+func (ms *MarketService) GetComparisonSeries(ctx context.Context, codes []string, period, rng string) (*models.ComparisonSeries, error) {
+	codes = uniqueCodes(codes)
+	if len(codes) == 0 {
+		return nil, i18n.Errorf("error.compare.empty")
+	}
+	if len(codes) > maxComparisonSymbols {
+		return nil, i18n.Errorf("error.compare.limit", maxComparisonSymbols)
+	}
+	if rng == "" {
+		rng = defaultCompareRange
+	}
+	start, bars, err := comparisonWindow(period, rng, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	klines := make([][]models.KLineData, len(codes))
+	errs := make([]error, len(codes))
+	var wg sync.WaitGroup
+	for i, code := range codes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			klines[i], errs[i] = ms.GetKLineData(ctx, code, period, bars)
+		}()
+	}
+	wg.Wait()
+
+	series := buildComparisonSeries(codes, klines, start)
+	series.Period, series.Range = period, rng
+	for i, err := range errs {
+		if err != nil {
+			log.Warn("获取 %s 对比K线失败: %v", codes[i], err)
+			series.Failed = append(series.Failed, codes[i])
+		}
+	}
+	if len(series.Failed) == len(codes) {
+		return nil, errs[0]
+	}
+	return series, nil
+}
+
+// comparisonWindow 计算区间起始日期与需要拉取的K线数
+func comparisonWindow(period, rng string, now time.Time) (string, int, error) {
+	var perBar float64 // 每根K线平均覆盖的自然日数
+	switch period {
+	case "1m":
+		return "", 250, nil
+	case "1d":
+		perBar = 7.0 / 5
+	case "1w":
+		perBar = 7
+	case "1mo":
+		perBar = 30
+	default:
+		return "", 0, i18n.Errorf("error.kline.period_unsupported", period)
+	}
+
+	var start time.Time
+	if rng == "ytd" {
+		start = time.Date(now.Year(), 1, 1, 0, 0, 0, 0, now.Location())
+	} else if offset, ok := comparisonRanges[rng]; ok {
+		start = now.AddDate(offset[0], offset[1], 0)
+	} else {
+		return "", 0, i18n.Errorf("error.compare.range_unsupported", rng)
+	}
+	bars := int(now.Sub(start).Hours()/24/perBar) + 5
+	return start.Format("2006-01-02"), min(max(bars, 10), maxComparisonBars), nil
+}
+
+// buildComparisonSeries 按时间对齐多只股票的K线并归一化
+// 时间轴取各股票在区间内时间点的并集，从所有股票都有数据的第一个时间点开始；停牌等缺失沿用前值
+func buildComparisonSeries(codes []string, klines [][]models.KLineData, start string) *models.ComparisonSeries {
+	series := &models.ComparisonSeries{Times: []string{}, Lines: []models.ComparisonLine{}}
+
+	closes := make([]map[string]float64, len(codes))
+	base := ""
+	var times []string
+	for i := range codes {
+		closes[i] = map[string]float64{}
+		first := ""
+		for _, k := range klines[i] {
+			if k.Time < start || k.Close <= 0 {
+				continue
+			}
+			if first == "" || k.Time < first {
+				first = k.Time
+			}
+			if _, ok := closes[i][k.Time]; !ok {
+				times = append(times, k.Time)
+			}
+			closes[i][k.Time] = k.Close
+		}
+		if first != "" && first > base {
+			base = first
+		}
+	}
+	if base == "" {
+		return series
+	}
+	slices.Sort(times)
+	times = slices.Compact(times)
+	times = times[slices.Index(times, base):]
+
+	series.BaseTime = base
+	series.Times = times
+	for i, code := range codes {
+		if len(closes[i]) == 0 {
+			continue
+		}
+		// 基准价：基准时间点及之前最近的收盘价
+		baseClose := closes[i][lastTimeBefore(closes[i], base)]
+		line := models.ComparisonLine{Code: code, Values: make([]float64, len(times))}
+		last := baseClose
+		for j, t := range times {
+			if c, ok := closes[i][t]; ok {
+				last = c
+			}
+			line.Values[j] = (last/baseClose - 1) * 100
+		}
+		series.Lines = append(series.Lines, line)
+	}
+	return series
+}
+
+// lastTimeBefore 不晚于 t 的最近时间点
+func lastTimeBefore(closes map[string]float64, t string) string {
+	best := ""
+	for k := range closes {
+		if k <= t && k > best {
+			best = k
+		}
+	}
+	return best
+}
+
+// uniqueCodes 去除空代码与重复代码，保持原顺序
+func uniqueCodes(codes []string) []string {
+	seen := make(map[string]bool, len(codes))
+	result := make([]string, 0, len(codes))
+	for _, c := range codes {
+		if c != "" && !seen[c] {
+			seen[c] = true
+			result = append(result, c)
+		}
+	}
+	return result
+}
@@ -0,0 +1,41 @@
+package services
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+func TestBuildComparisonSeries(t *testing.T) {
+	klines := [][]models.KLineData{
+		{{Time: "2026-01-05", Close: 10}, {Time: "2026-01-06", Close: 11}, {Time: "2026-01-07", Close: 12}, {Time: "2026-01-08", Close: 9}},
+		{{Time: "2026-01-06", Close: 20}, {Time: "2026-01-08", Close: 25}}, // 01-06 起上市，01-07 停牌
+	}
+	s := buildComparisonSeries([]string{"sh600000", "sz000001"}, klines, "2026-01-01")
+	if s.BaseTime != "2026-01-06" || !slices.Equal(s.Times, []string{"2026-01-06", "2026-01-07", "2026-01-08"}) {
+		t.Fatalf("时间轴错误: base=%s times=%v", s.BaseTime, s.Times)
+	}
+	round := func(v []float64) []float64 {
+		out := make([]float64, len(v))
+		for i, x := range v {
+			out[i] = math.Round(x*100) / 100
+		}
+		return out
+	}
+	if got := round(s.Lines[0].Values); !slices.Equal(got, []float64{0, 9.09, -18.18}) {
+		t.Errorf("sh600000 = %v", got)
+	}
+	if got := round(s.Lines[1].Values); !slices.Equal(got, []float64{0, 0, 25}) {
+		t.Errorf("停牌应沿用前值: %v", got)
+	}
+
+	if _, bars, err := comparisonWindow("1d", "1y", time.Date(2026, 6, 1, 0, 0, 0, 0, time.Local)); err != nil || bars < 250 || bars > 270 {
+		t.Errorf("1y 日K bars = %d, %v", bars, err)
+	}
+	if _, _, err := comparisonWindow("1d", "2w", time.Now()); err == nil {
+		t.Error("不支持的区间应返回错误")
+	}
+}
@@ -0,0 +1,378 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/logger"
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+
+	"github.com/google/uuid"
+)
+
+var conditionLog = logger.New("condition")
+
+const conditionMaxMAPeriod = 250
+
+// conditionOrdersFile 条件单文件（数据目录下），云同步与设置备份也读写该文件
+const conditionOrdersFile = "condition_orders.json"
+
+// ConditionOrderService 条件单服务
+// 在实时行情推送时评估触发条件（仅交易时段），触发后执行通知或提交模拟委托，每个条件单只触发一次；
+// 价格条件按穿越判断：需观察到价格从条件一侧移动到另一侧，避免创建时已满足条件立即触发
+type ConditionOrderService struct {
+	path          string
+	marketService *MarketService
+	paperService  *PaperTradingService
+	orders        []models.ConditionOrder
+	onTrigger     func(models.ConditionOrder)
+	mu            sync.Mutex
+	now           func() time.Time
+}
+
+// NewConditionOrderService 创建条件单服务
+func NewConditionOrderService(dataDir string, marketService *MarketService, paperService *PaperTradingService) *ConditionOrderService {
+	s := &ConditionOrderService{
+		path:          filepath.Join(dataDir, conditionOrdersFile),
+		marketService: marketService,
+		paperService:  paperService,
+		orders:        []models.ConditionOrder{},
+		now:           time.Now,
+	}
+	if data, err := os.ReadFile(s.path); err == nil {
+		if err := json.Unmarshal(data, &s.orders); err != nil {
+			conditionLog.Warn("解析条件单失败: %v", err)
+			s.orders = []models.ConditionOrder{}
+		}
+	}
+	return s
+}
+
+// saveLocked 保存条件单(需要已持有锁)
+func (s *ConditionOrderService) saveLocked() error {
+	data, err := json.MarshalIndent(s.orders, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileTracked(s.path, data, 0644)
+}
+
+// Reload 重新读取条件单文件（云同步拉取后调用）
+// 本机已有的条件单保留本机观察到的上一价格，新增的条件单重新观察，避免其他设备的旧价格造成误触发
+func (s *ConditionOrderService) Reload() error {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return err
+	}
+	orders := []models.ConditionOrder{}
+	if err := json.Unmarshal(data, &orders); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lastPrices := make(map[string]float64, len(s.orders))
+	for _, o := range s.orders {
+		lastPrices[o.ID] = o.LastPrice
+	}
+	for i := range orders {
+		orders[i].LastPrice = lastPrices[orders[i].ID]
+	}
+	s.orders = orders
+	return nil
+}
+
+// SetOnTrigger 设置触发回调（推送通知）
+func (s *ConditionOrderService) SetOnTrigger(fn func(models.ConditionOrder)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onTrigger = fn
+}
+
+// Create 创建条件单
+func (s *ConditionOrderService) Create(req models.ConditionOrderRequest) (models.ConditionOrder, error) {
+	if err := validateConditionOrder(req); err != nil {
+		return models.ConditionOrder{}, err
+	}
+	order := models.ConditionOrder{
+		ID:        uuid.New().String(),
+		Symbol:    req.Symbol,
+		Trigger:   req.Trigger,
+		Action:    req.Action,
+		Note:      req.Note,
+		Status:    models.ConditionActive,
+		CreatedAt: s.now().UnixMilli(),
+	}
+	order.Action.Order.Symbol = req.Symbol
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.orders = append(s.orders, order)
+	return order, s.saveLocked()
+}
+
+// validateConditionOrder 校验条件单参数
+func validateConditionOrder(req models.ConditionOrderRequest) error {
+	if req.Symbol == "" {
+		return i18n.Errorf("error.symbol.required")
+	}
+	t := req.Trigger
+	switch t.Type {
+	case models.TriggerPriceAbove, models.TriggerPriceBelow:
+		if t.Price <= 0 {
+			return i18n.Errorf("error.condition.price_positive")
+		}
+	case models.TriggerTime:
+		if _, err := time.Parse("15:04", t.Time); err != nil {
+			return i18n.Errorf("error.condition.time_format")
+		}
+	case models.TriggerIndicator:
+		switch t.Indicator {
+		case models.IndicatorChangePercent:
+		case models.IndicatorMA:
+			if t.Period < 2 || t.Period > conditionMaxMAPeriod {
+				return i18n.Errorf("error.condition.ma_period", conditionMaxMAPeriod)
+			}
+		default:
+			return i18n.Errorf("error.condition.indicator_unsupported", t.Indicator)
+		}
+	default:
+		return i18n.Errorf("error.condition.trigger_unsupported", t.Type)
+	}
+
+	switch req.Action.Type {
+	case models.ConditionActionNotify:
+	case models.ConditionActionPaper:
+		order := req.Action.Order
+		order.Symbol = req.Symbol
+		return validatePaperOrder(order)
+	default:
+		return i18n.Errorf("error.condition.action_unsupported", req.Action.Type)
+	}
+	return nil
+}
+
+// Cancel 撤销未触发的条件单
+func (s *ConditionOrderService) Cancel(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.orders {
+		if s.orders[i].ID != id {
+			continue
+		}
+		if s.orders[i].Status != models.ConditionActive {
+			return i18n.Errorf("error.condition.cancel_state", conditionStatusText(s.orders[i].Status))
+		}
+		s.orders[i].Status = models.ConditionCancelled
+		return s.saveLocked()
+	}
+	return i18n.Errorf("error.condition.not_found")
+}
+
+// Delete 删除条件单记录
+func (s *ConditionOrderService) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := len(s.orders)
+	s.orders = slices.DeleteFunc(s.orders, func(o models.ConditionOrder) bool { return o.ID == id })
+	if len(s.orders) == n {
+		return i18n.Errorf("error.condition.not_found")
+	}
+	return s.saveLocked()
+}
+
+// List 获取所有条件单（最新在前）
+func (s *ConditionOrderService) List() []models.ConditionOrder {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := make([]models.ConditionOrder, len(s.orders))
+	for i, o := range s.orders {
+		result[len(s.orders)-1-i] = o
+	}
+	return result
+}
+
+// ActiveSymbols 未触发条件单涉及的股票代码（供推送服务一并拉取行情）
+func (s *ConditionOrderService) ActiveSymbols() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var codes []string
+	for _, o := range s.orders {
+		if o.Status == models.ConditionActive && !slices.Contains(codes, o.Symbol) {
+			codes = append(codes, o.Symbol)
+		}
+	}
+	return codes
+}
+
+// Evaluate 用最新行情评估条件单，仅交易时段生效
+func (s *ConditionOrderService) Evaluate(ctx context.Context, stocks []models.Stock) {
+	if s.marketService.GetMarketStatus().Status != "trading" {
+		return
+	}
+	s.evaluate(ctx, stocks, s.maValues(ctx))
+}
+
+// evaluate 评估并执行触发的条件单，ma 为各股票的日均线值
+func (s *ConditionOrderService) evaluate(ctx context.Context, stocks []models.Stock, ma map[maKey]float64) {
+	quotes := make(map[string]models.Stock, len(stocks))
+	for _, st := range stocks {
+		if st.Price > 0 {
+			quotes[st.Symbol] = st
+		}
+	}
+
+	s.mu.Lock()
+	now := s.now()
+	var fired []models.ConditionOrder
+	for i := range s.orders {
+		o := &s.orders[i]
+		quote, ok := quotes[o.Symbol]
+		if o.Status != models.ConditionActive || !ok {
+			continue
+		}
+		hit := conditionHit(o, quote, now, ma)
+		o.LastPrice = quote.Price
+		if !hit {
+			continue
+		}
+		o.Name = quote.Name
+		o.Status = models.ConditionTriggered
+		o.TriggeredAt = now.UnixMilli()
+		o.TriggerPrice = quote.Price
+		fired = append(fired, *o)
+	}
+	if len(fired) > 0 {
+		// 先落盘触发状态，避免动作执行期间重启导致重复触发
+		if err := s.saveLocked(); err != nil {
+			conditionLog.Warn("保存条件单失败: %v", err)
+		}
+	}
+	notify := s.onTrigger
+	s.mu.Unlock()
+
+	for i, o := range fired {
+		if o.Action.Type == models.ConditionActionPaper {
+			placed, err := s.paperService.PlaceOrder(ctx, o.Action.Order)
+			if err != nil {
+				fired[i].Status = models.ConditionFailed
+				fired[i].Result = err.Error()
+			} else {
+				fired[i].Result = placed.ID
+			}
+			s.recordResult(fired[i])
+		}
+		conditionLog.Info("条件单触发: %s %s 价格%.2f %s", o.Symbol, o.Trigger.Type, o.TriggerPrice, fired[i].Result)
+		if notify != nil {
+			notify(fired[i])
+		}
+	}
+}
+
+// recordResult 记录动作执行结果
+func (s *ConditionOrderService) recordResult(order models.ConditionOrder) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.orders {
+		if s.orders[i].ID == order.ID {
+			s.orders[i].Status = order.Status
+			s.orders[i].Result = order.Result
+			if err := s.saveLocked(); err != nil {
+				conditionLog.Warn("保存条件单失败: %v", err)
+			}
+			return
+		}
+	}
+}
+
+// conditionHit 判断条件是否满足；价格条件要求上一次评估价格在条件另一侧
+func conditionHit(o *models.ConditionOrder, quote models.Stock, now time.Time, ma map[maKey]float64) bool {
+	t := o.Trigger
+	switch t.Type {
+	case models.TriggerPriceAbove:
+		return o.LastPrice > 0 && o.LastPrice < t.Price && quote.Price >= t.Price
+	case models.TriggerPriceBelow:
+		return o.LastPrice > t.Price && quote.Price <= t.Price
+	case models.TriggerTime:
+		// 创建时已过当日时间点的，顺延到下一交易日
+		hm, err := time.ParseInLocation("15:04", t.Time, now.Location())
+		if err != nil {
+			return false
+		}
+		at := time.Date(now.Year(), now.Month(), now.Day(), hm.Hour(), hm.Minute(), 0, 0, now.Location())
+		return !now.Before(at) && o.CreatedAt < at.UnixMilli()
+	case models.TriggerIndicator:
+		var value, threshold float64
+		switch t.Indicator {
+		case models.IndicatorChangePercent:
+			value, threshold = quote.ChangePercent, t.Value
+		case models.IndicatorMA:
+			avg, ok := ma[maKey{o.Symbol, t.Period}]
+			if !ok {
+				return false
+			}
+			value, threshold = quote.Price, avg
+		default:
+			return false
+		}
+		if t.Above {
+			return value > threshold
+		}
+		return value < threshold
+	}
+	return false
+}
+
+// maValues 获取未触发均线条件所需的日均线
+func (s *ConditionOrderService) maValues(ctx context.Context) map[maKey]float64 {
+	s.mu.Lock()
+	var need []maKey
+	for _, o := range s.orders {
+		t := o.Trigger
+		key := maKey{o.Symbol, t.Period}
+		if o.Status == models.ConditionActive && t.Type == models.TriggerIndicator &&
+			t.Indicator == models.IndicatorMA && !slices.Contains(need, key) {
+			need = append(need, key)
+		}
+	}
+	s.mu.Unlock()
+
+	result := make(map[maKey]float64, len(need))
+	for _, key := range need {
+		klines, err := s.marketService.GetKLineData(ctx, key.symbol, "1d", key.period)
+		if err != nil || len(klines) < key.period {
+			continue
+		}
+		sum := 0.0
+		for _, k := range klines[len(klines)-key.period:] {
+			sum += k.Close
+		}
+		result[key] = sum / float64(key.period)
+	}
+	return result
+}
+
+// maKey 均线缓存键
+type maKey struct {
+	symbol string
+	period int
+}
+
+// conditionStatusText 状态中文描述
+func conditionStatusText(status string) string {
+	switch status {
+	case models.ConditionTriggered:
+		return "触发"
+	case models.ConditionFailed:
+		return "触发（执行失败）"
+	case models.ConditionCancelled:
+		return "撤销"
+	}
+	return status
+}
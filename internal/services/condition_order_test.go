@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// TestConditionOrderEvaluate 测试条件单穿越触发、时间条件与持久化
+func TestConditionOrderEvaluate(t *testing.T) {
+	dir := t.TempDir()
+	s := NewConditionOrderService(dir, nil, nil)
+	now := time.Date(2026, 3, 2, 14, 0, 0, 0, time.Local)
+	s.now = func() time.Time { return now }
+
+	notify := models.ConditionAction{Type: models.ConditionActionNotify}
+	above, err := s.Create(models.ConditionOrderRequest{Symbol: "sh600000", Trigger: models.ConditionTrigger{Type: models.TriggerPriceAbove, Price: 10}, Action: notify})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	s.Create(models.ConditionOrderRequest{Symbol: "sh600000", Trigger: models.ConditionTrigger{Type: models.TriggerTime, Time: "14:50"}, Action: notify})
+	if _, err := s.Create(models.ConditionOrderRequest{Symbol: "sh600000", Trigger: models.ConditionTrigger{Type: models.TriggerTime, Time: "25:00"}, Action: notify}); err == nil {
+		t.Error("非法时间应报错")
+	}
+
+	var fired []string
+	s.SetOnTrigger(func(o models.ConditionOrder) { fired = append(fired, o.Trigger.Type) })
+	quote := func(price float64) []models.Stock {
+		return []models.Stock{{Symbol: "sh600000", Name: "浦发银行", Price: price}}
+	}
+
+	// 首次观察已高于触发价，不算穿越
+	s.evaluate(context.Background(), quote(10.5), nil)
+	s.evaluate(context.Background(), quote(9.8), nil)
+	if len(fired) != 0 {
+		t.Fatalf("未穿越不应触发, got %v", fired)
+	}
+	s.evaluate(context.Background(), quote(10.1), nil)
+	now = now.Add(50 * time.Minute)
+	s.evaluate(context.Background(), quote(10.2), nil)
+	if !slices.Equal(fired, []string{models.TriggerPriceAbove, models.TriggerTime}) {
+		t.Fatalf("fired = %v", fired)
+	}
+	s.evaluate(context.Background(), quote(9), nil)
+	if len(fired) != 2 {
+		t.Errorf("条件单只应触发一次, got %v", fired)
+	}
+
+	// 重启后状态保留
+	reloaded := NewConditionOrderService(dir, nil, nil)
+	if len(reloaded.ActiveSymbols()) != 0 {
+		t.Error("已触发条件单不应再参与评估")
+	}
+	orders := reloaded.List()
+	if len(orders) != 2 || orders[1].ID != above.ID || orders[1].TriggerPrice != 10.1 {
+		t.Errorf("持久化结果不符: %+v", orders)
+	}
+}
+
+// TestConditionOrderReload 测试云同步拉取后重新加载：本机已有的条件单保留观察价，新条件单重新观察
+func TestConditionOrderReload(t *testing.T) {
+	dir := t.TempDir()
+	s := NewConditionOrderService(dir, nil, nil)
+	s.now = func() time.Time { return time.Date(2026, 3, 2, 14, 0, 0, 0, time.Local) }
+	trigger := models.ConditionTrigger{Type: models.TriggerPriceAbove, Price: 10}
+	notify := models.ConditionAction{Type: models.ConditionActionNotify}
+	local, _ := s.Create(models.ConditionOrderRequest{Symbol: "sh600000", Trigger: trigger, Action: notify})
+	s.evaluate(context.Background(), []models.Stock{{Symbol: "sh600000", Price: 9.5}}, nil)
+
+	// 模拟其他设备写入的文件：已有条件单带旧观察价，另有一个新条件单
+	remote := NewConditionOrderService(t.TempDir(), nil, nil)
+	remote.orders = []models.ConditionOrder{
+		{ID: local.ID, Symbol: "sh600000", Trigger: trigger, Action: notify, Status: models.ConditionActive, LastPrice: 8},
+		{ID: "remote", Symbol: "sz000001", Trigger: trigger, Action: notify, Status: models.ConditionActive, LastPrice: 9},
+	}
+	remote.path = s.path
+	if err := remote.saveLocked(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if len(s.orders) != 2 || s.orders[0].LastPrice != 9.5 || s.orders[1].LastPrice != 0 {
+		t.Fatalf("orders = %+v", s.orders)
+	}
+}
@@ -1,25 +1,44 @@
 package services
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"os"
 	"path/filepath"
-	"strings"
+	"slices"
+	"sort"
 	"sync"
+	"time"
 
-	"github.com/run-bigpig/jcp/internal/embed"
+	"github.com/run-bigpig/jcp/internal/memory"
 	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/hotkey"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+	"github.com/run-bigpig/jcp/internal/pkg/proxy"
+	"github.com/run-bigpig/jcp/internal/pkg/supervisor"
 )
 
 // ConfigService 配置服务
 type ConfigService struct {
 	configPath    string
 	watchlistPath string
+	targetsPath   string
+	groupsPath    string
 	config        *models.AppConfig
 	watchlist     []models.Stock
+	targets       map[string]models.PriceTarget
+	groups        models.WatchlistGroups
+	search        *SearchService
 	mu            sync.RWMutex
+
+	lastData []byte                           // 最近一次读写的配置文件内容，用于忽略自身写入
+	onChange func(old, new *models.AppConfig) // 配置变更回调
 }
 
+// configWatchInterval 配置文件变更检测间隔
+const configWatchInterval = 2 * time.Second
+
 // NewConfigService 创建配置服务
 func NewConfigService(dataDir string) (*ConfigService, error) {
 	if err := os.MkdirAll(dataDir, 0755); err != nil {
@@ -29,6 +48,9 @@ func NewConfigService(dataDir string) (*ConfigService, error) {
 	cs := &ConfigService{
 		configPath:    filepath.Join(dataDir, "config.json"),
 		watchlistPath: filepath.Join(dataDir, "watchlist.json"),
+		targetsPath:   filepath.Join(dataDir, "price_targets.json"),
+		groupsPath:    filepath.Join(dataDir, "watchlist_groups.json"),
+		search:        NewSearchService(dataDir),
 	}
 
 	if err := cs.loadConfig(); err != nil {
@@ -37,10 +59,102 @@ func NewConfigService(dataDir string) (*ConfigService, error) {
 	if err := cs.loadWatchlist(); err != nil {
 		return nil, err
 	}
+	if err := cs.loadPriceTargets(); err != nil {
+		return nil, err
+	}
+	if err := cs.loadWatchlistGroups(); err != nil {
+		return nil, err
+	}
 
 	return cs, nil
 }
 
+// SetOnChange 设置配置变更回调，UpdateConfig、Reload 与外部修改配置文件时触发
+func (cs *ConfigService) SetOnChange(fn func(old, new *models.AppConfig)) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.onChange = fn
+}
+
+// notifyChange 通知配置变更（不能持有锁）
+func (cs *ConfigService) notifyChange(old *models.AppConfig) {
+	cs.mu.RLock()
+	fn, cfg := cs.onChange, cs.config
+	cs.mu.RUnlock()
+	if fn != nil && cfg != old {
+		fn(old, cfg)
+	}
+}
+
+// Watch 定时检测配置文件，被外部编辑后重新加载并触发变更回调
+// 文件内容无法解析时保留当前配置，等待下一次修改
+func (cs *ConfigService) Watch(ctx context.Context) {
+	supervisor.Go(ctx, "config-watch", func(ctx context.Context) {
+		ticker := time.NewTicker(configWatchInterval)
+		defer ticker.Stop()
+		var lastMod time.Time
+		if info, err := os.Stat(cs.configPath); err == nil {
+			lastMod = info.ModTime()
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			info, err := os.Stat(cs.configPath)
+			if err != nil || info.ModTime().Equal(lastMod) {
+				continue
+			}
+			lastMod = info.ModTime()
+			if err := cs.reloadIfChanged(); err != nil {
+				log.Warn("重新加载配置文件失败: %v", err)
+			}
+		}
+	})
+}
+
+// reloadIfChanged 配置文件内容与最近一次读写不同时重新加载
+func (cs *ConfigService) reloadIfChanged() error {
+	data, err := os.ReadFile(cs.configPath)
+	if err != nil {
+		return err
+	}
+	cs.mu.RLock()
+	same := bytes.Equal(data, cs.lastData)
+	cs.mu.RUnlock()
+	if same {
+		return nil
+	}
+	// 先校验能否解析，避免编辑到一半的文件覆盖当前配置
+	var probe models.AppConfig
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return err
+	}
+	old := cs.GetConfig()
+	if err := cs.loadConfig(); err != nil {
+		return err
+	}
+	cs.notifyChange(old)
+	return nil
+}
+
+// Reload 重新从磁盘加载配置、自选股、目标价与分组（云同步拉取后调用）
+func (cs *ConfigService) Reload() error {
+	old := cs.GetConfig()
+	if err := cs.loadConfig(); err != nil {
+		return err
+	}
+	cs.notifyChange(old)
+	if err := cs.loadWatchlist(); err != nil {
+		return err
+	}
+	if err := cs.loadPriceTargets(); err != nil {
+		return err
+	}
+	return cs.loadWatchlistGroups()
+}
+
 // loadConfig 加载配置
 func (cs *ConfigService) loadConfig() error {
 	cs.mu.Lock()
@@ -59,6 +173,7 @@ func (cs *ConfigService) loadConfig() error {
 	if err := json.Unmarshal(data, &config); err != nil {
 		return err
 	}
+	cs.lastData = data
 
 	// 用于识别字段是否在 JSON 中显式存在（避免把用户明确设置的 false 当成缺失字段）
 	var raw struct {
@@ -142,6 +257,18 @@ func (cs *ConfigService) loadConfig() error {
 	if ind.KDJ.D == 0 {
 		ind.KDJ.D = d.KDJ.D
 	}
+	if config.MarketIndices == nil {
+		config.MarketIndices = cs.defaultConfig().MarketIndices
+	}
+	if config.RateLimits == nil {
+		config.RateLimits = cs.defaultConfig().RateLimits
+	}
+	if config.ConnPools == nil {
+		config.ConnPools = cs.defaultConfig().ConnPools
+	}
+	if config.Briefing.Time == "" {
+		config.Briefing.Time = defaultBriefingTime
+	}
 	cs.config = &config
 	return nil
 }
@@ -159,6 +286,12 @@ func (cs *ConfigService) defaultConfig() *models.AppConfig {
 			MaxKeyFacts:       20,
 			MaxSummaryLength:  300,
 			CompressThreshold: 5,
+			Prompts: models.MemoryPromptConfig{
+				SummaryLength:  150,
+				FactLength:     50,
+				MaxFacts:       5,
+				KeyPointLength: 30,
+			},
 		},
 		Indicators: models.IndicatorConfig{
 			MA:   models.MAConfig{Enabled: true, Periods: []int{5, 10, 20}},
@@ -168,6 +301,10 @@ func (cs *ConfigService) defaultConfig() *models.AppConfig {
 			RSI:  models.RSIConfig{Enabled: false, Period: 14},
 			KDJ:  models.KDJConfig{Enabled: false, Period: 9, K: 3, D: 3},
 		},
+		MarketIndices: append([]string(nil), DefaultIndexCodes...),
+		RateLimits:    append([]models.HostRateLimit(nil), proxy.DefaultRateLimits...),
+		ConnPools:     append([]models.HostConnPool(nil), proxy.DefaultConnPools...),
+		Briefing:      models.BriefingConfig{Time: defaultBriefingTime, MaxStocks: defaultBriefingMaxStocks, NearTargetPct: defaultBriefingNearTargetPct},
 	}
 }
 
@@ -177,7 +314,11 @@ func (cs *ConfigService) saveConfigLocked() error {
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(cs.configPath, data, 0644)
+	if err := writeFileTracked(cs.configPath, data, 0644); err != nil {
+		return err
+	}
+	cs.lastData = data
+	return nil
 }
 
 // GetConfig 获取配置
@@ -189,9 +330,60 @@ func (cs *ConfigService) GetConfig() *models.AppConfig {
 
 // UpdateConfig 更新配置
 func (cs *ConfigService) UpdateConfig(config *models.AppConfig) error {
+	prompts := config.Memory.Prompts
+	if err := memory.ValidatePromptTemplates(memory.PromptConfig{
+		SummarizeTemplate:   prompts.SummarizeTemplate,
+		ExtractTemplate:     prompts.ExtractTemplate,
+		KeyPointsTemplate:   prompts.KeyPointsTemplate,
+		ConsolidateTemplate: prompts.ConsolidateTemplate,
+	}); err != nil {
+		return err
+	}
+	for _, persona := range config.Personas {
+		if err := ValidatePersona(persona, config); err != nil {
+			return err
+		}
+	}
+	if !i18n.IsSupported(config.Language) {
+		return i18n.Errorf("error.language.unsupported", config.Language)
+	}
+	for _, spec := range []string{config.Hotkeys.ShowHide, config.Hotkeys.BossKey, config.Hotkeys.QuickSearch} {
+		if spec == "" {
+			continue
+		}
+		if _, err := hotkey.Parse(spec); err != nil {
+			return err
+		}
+	}
+
 	cs.mu.Lock()
-	defer cs.mu.Unlock()
+	old := cs.config
 	cs.config = config
+	err := cs.saveConfigLocked()
+	cs.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	cs.notifyChange(old)
+	return nil
+}
+
+// SetWatchlistSort 更新自选股推送排序配置
+func (cs *ConfigService) SetWatchlistSort(sortCfg models.WatchlistSortConfig) error {
+	if err := ValidateWatchlistSort(sortCfg); err != nil {
+		return err
+	}
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.config.WatchlistSort = sortCfg
+	return cs.saveConfigLocked()
+}
+
+// SetUpdateConfig 更新自动更新配置
+func (cs *ConfigService) SetUpdateConfig(updateCfg models.UpdateConfig) error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.config.Update = updateCfg
 	return cs.saveConfigLocked()
 }
 
@@ -225,7 +417,7 @@ func (cs *ConfigService) saveWatchlistLocked() error {
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(cs.watchlistPath, data, 0644)
+	return writeFileTracked(cs.watchlistPath, data, 0644)
 }
 
 // GetWatchlist 获取自选股列表
@@ -236,6 +428,8 @@ func (cs *ConfigService) GetWatchlist() []models.Stock {
 }
 
 // AddToWatchlist 添加自选股
+// 先保存自选股列表，失败时撤销内存中的修改；分组以自选股列表为准，
+// 分组保存失败时下次加载会重新补齐，只记录日志
 func (cs *ConfigService) AddToWatchlist(stock models.Stock) error {
 	cs.mu.Lock()
 	defer cs.mu.Unlock()
@@ -245,24 +439,122 @@ func (cs *ConfigService) AddToWatchlist(stock models.Stock) error {
 			return nil
 		}
 	}
-	cs.watchlist = append(cs.watchlist, stock)
-	return cs.saveWatchlistLocked()
+	old := cs.watchlist
+	cs.watchlist = append(slices.Clip(old), stock)
+	if err := cs.saveWatchlistLocked(); err != nil {
+		cs.watchlist = old
+		return err
+	}
+	if g := cs.groupLocked(models.WatchlistGroupDefault); g != nil {
+		g.Symbols = append(g.Symbols, stock.Symbol)
+		if err := cs.saveWatchlistGroupsLocked(); err != nil {
+			log.Warn("保存自选股分组失败: %v", err)
+		}
+	}
+	return nil
 }
 
-// RemoveFromWatchlist 移除自选股
+// RemoveFromWatchlist 移除自选股，保存失败时撤销内存中的修改
 func (cs *ConfigService) RemoveFromWatchlist(symbol string) error {
 	cs.mu.Lock()
 	defer cs.mu.Unlock()
 
-	for i, s := range cs.watchlist {
-		if s.Symbol == symbol {
-			cs.watchlist = append(cs.watchlist[:i], cs.watchlist[i+1:]...)
-			return cs.saveWatchlistLocked()
-		}
+	i := slices.IndexFunc(cs.watchlist, func(s models.Stock) bool { return s.Symbol == symbol })
+	if i < 0 {
+		return nil
+	}
+	old := cs.watchlist
+	cs.watchlist = slices.Delete(slices.Clone(old), i, i+1)
+	if err := cs.saveWatchlistLocked(); err != nil {
+		cs.watchlist = old
+		return err
+	}
+	cs.removeFromGroupsLocked(symbol)
+	if err := cs.saveWatchlistGroupsLocked(); err != nil {
+		log.Warn("保存自选股分组失败: %v", err)
+	}
+	if _, ok := cs.targets[symbol]; ok {
+		delete(cs.targets, symbol)
+		return cs.savePriceTargetsLocked()
 	}
 	return nil
 }
 
+// loadPriceTargets 加载目标价/止损价
+func (cs *ConfigService) loadPriceTargets() error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	cs.targets = make(map[string]models.PriceTarget)
+	data, err := os.ReadFile(cs.targetsPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var targets []models.PriceTarget
+	if err := json.Unmarshal(data, &targets); err != nil {
+		return err
+	}
+	for _, t := range targets {
+		cs.targets[t.Symbol] = t
+	}
+	return nil
+}
+
+// savePriceTargetsLocked 保存目标价/止损价(需要已持有锁)
+func (cs *ConfigService) savePriceTargetsLocked() error {
+	data, err := json.MarshalIndent(cs.priceTargetsLocked(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileTracked(cs.targetsPath, data, 0644)
+}
+
+// priceTargetsLocked 按代码排序的目标价列表(需要已持有锁)
+func (cs *ConfigService) priceTargetsLocked() []models.PriceTarget {
+	result := make([]models.PriceTarget, 0, len(cs.targets))
+	for _, t := range cs.targets {
+		result = append(result, t)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Symbol < result[j].Symbol
+	})
+	return result
+}
+
+// GetPriceTargets 获取所有目标价/止损价
+func (cs *ConfigService) GetPriceTargets() []models.PriceTarget {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.priceTargetsLocked()
+}
+
+// SetPriceTarget 设置目标价/止损价，两者均为 0 时删除
+func (cs *ConfigService) SetPriceTarget(symbol string, targetPrice, stopPrice float64) error {
+	if targetPrice < 0 || stopPrice < 0 {
+		return i18n.Errorf("error.target.negative")
+	}
+	if targetPrice > 0 && stopPrice > 0 && stopPrice >= targetPrice {
+		return i18n.Errorf("error.target.stop_above_target")
+	}
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if targetPrice == 0 && stopPrice == 0 {
+		delete(cs.targets, symbol)
+	} else {
+		cs.targets[symbol] = models.PriceTarget{
+			Symbol:      symbol,
+			TargetPrice: targetPrice,
+			StopPrice:   stopPrice,
+		}
+	}
+	return cs.savePriceTargetsLocked()
+}
+
 // stockBasicData stock_basic.json 的数据结构
 type stockBasicData struct {
 	Data struct {
@@ -279,80 +571,12 @@ type StockSearchResult struct {
 	Market   string `json:"market"`
 }
 
-// SearchStocks 搜索股票
+// SearchStocks 搜索股票（代码、名称、拼音首字母）
 func (cs *ConfigService) SearchStocks(keyword string, limit int) []StockSearchResult {
-	if keyword == "" {
-		return []StockSearchResult{}
-	}
-
-	keyword = strings.ToUpper(keyword)
-
-	// 使用嵌入的股票数据
-	var basicData stockBasicData
-	if err := json.Unmarshal(embed.StockBasicJSON, &basicData); err != nil {
-		return []StockSearchResult{}
-	}
-
-	// 找到字段索引
-	var symbolIdx, nameIdx, industryIdx, tsCodeIdx int = -1, -1, -1, -1
-	for i, field := range basicData.Data.Fields {
-		switch field {
-		case "symbol":
-			symbolIdx = i
-		case "name":
-			nameIdx = i
-		case "industry":
-			industryIdx = i
-		case "ts_code":
-			tsCodeIdx = i
-		}
-	}
-
-	if symbolIdx < 0 || nameIdx < 0 {
-		return []StockSearchResult{}
-	}
-
-	var results []StockSearchResult
-	for _, item := range basicData.Data.Items {
-		if len(results) >= limit {
-			break
-		}
-
-		symbol, _ := item[symbolIdx].(string)
-		name, _ := item[nameIdx].(string)
-
-		// 匹配代码或名称
-		upperSymbol := strings.ToUpper(symbol)
-		upperName := strings.ToUpper(name)
-
-		if strings.Contains(upperSymbol, keyword) || strings.Contains(upperName, keyword) {
-			var industry, market, fullSymbol string
-			if industryIdx >= 0 && industryIdx < len(item) {
-				industry, _ = item[industryIdx].(string)
-			}
-			// 从 ts_code 获取市场前缀
-			if tsCodeIdx >= 0 && tsCodeIdx < len(item) {
-				tsCode, _ := item[tsCodeIdx].(string)
-				if strings.HasSuffix(tsCode, ".SH") {
-					market = "上海"
-					fullSymbol = "sh" + symbol
-				} else if strings.HasSuffix(tsCode, ".SZ") {
-					market = "深圳"
-					fullSymbol = "sz" + symbol
-				}
-			}
-			if fullSymbol == "" {
-				fullSymbol = symbol
-			}
-
-			results = append(results, StockSearchResult{
-				Symbol:   fullSymbol,
-				Name:     name,
-				Industry: industry,
-				Market:   market,
-			})
-		}
-	}
+	return cs.search.Search(keyword, limit)
+}
 
-	return results
+// LookupStock 按完整代码（sh600519）查找股票名称、行业等信息
+func (cs *ConfigService) LookupStock(symbol string) (StockSearchResult, bool) {
+	return cs.search.Lookup(symbol)
 }
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"net/url"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+	"github.com/run-bigpig/jcp/internal/pkg/metrics"
+)
+
+// detachedArgPrefix 分离窗口子进程的启动参数前缀，参数值为 URL 编码的窗口订阅
+const detachedArgPrefix = "--detached="
+
+// DetachedArg 生成分离窗口子进程的启动参数
+func DetachedArg(sub WindowSubscription) string {
+	v := url.Values{}
+	v.Set("id", sub.ID)
+	v.Set("kind", sub.Kind)
+	v.Set("code", sub.Code)
+	if sub.Name != "" {
+		v.Set("name", sub.Name)
+	}
+	if sub.Period != "" {
+		v.Set("period", sub.Period)
+	}
+	return detachedArgPrefix + v.Encode()
+}
+
+// ParseDetachedArgs 从命令行参数解析分离窗口订阅，不是分离窗口进程时返回 false
+func ParseDetachedArgs(args []string) (WindowSubscription, bool) {
+	for _, arg := range args {
+		raw, ok := strings.CutPrefix(arg, detachedArgPrefix)
+		if !ok {
+			continue
+		}
+		v, err := url.ParseQuery(raw)
+		if err != nil {
+			return WindowSubscription{}, false
+		}
+		sub := WindowSubscription{ID: v.Get("id"), Kind: v.Get("kind"), Code: v.Get("code"), Name: v.Get("name"), Period: v.Get("period")}
+		if sub.Validate() != nil {
+			return WindowSubscription{}, false
+		}
+		return sub, true
+	}
+	return WindowSubscription{}, false
+}
+
+// detachedProcess 分离窗口子进程
+type detachedProcess interface {
+	Wait() error
+	Kill() error
+}
+
+// DetachedWindows 分离窗口进程管理
+// Wails v2 每个进程只能创建一个原生窗口，分离的K线图/盘口以 --detached 参数启动本程序的新进程，
+// 子进程只运行本窗口的行情推送；主进程退出时关闭全部分离窗口
+type DetachedWindows struct {
+	start func(sub WindowSubscription) (detachedProcess, error)
+
+	mu    sync.Mutex
+	procs map[string]detachedProcess
+	seq   int
+}
+
+// NewDetachedWindows 创建分离窗口进程管理
+func NewDetachedWindows() *DetachedWindows {
+	d := &DetachedWindows{
+		start: startDetachedProcess,
+		procs: make(map[string]detachedProcess),
+	}
+	metrics.NewGaugeFunc("jcp_detached_windows", "分离窗口数", func() float64 {
+		return float64(d.Count())
+	})
+	return d
+}
+
+// startDetachedProcess 以分离窗口参数启动本程序
+func startDetachedProcess(sub WindowSubscription) (detachedProcess, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command(exe, DetachedArg(sub))
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return cmdProcess{cmd}, nil
+}
+
+// cmdProcess exec.Cmd 适配 detachedProcess
+type cmdProcess struct{ cmd *exec.Cmd }
+
+func (p cmdProcess) Wait() error { return p.cmd.Wait() }
+func (p cmdProcess) Kill() error { return p.cmd.Process.Kill() }
+
+// Open 打开分离窗口，返回窗口 ID；窗口关闭（子进程退出）后自动移除
+func (d *DetachedWindows) Open(kind, code, name string) (string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.procs) >= maxDetachedWindows {
+		return "", i18n.Errorf("error.window.limit", maxDetachedWindows)
+	}
+	d.seq++
+	sub := WindowSubscription{
+		ID:   kind + "-" + code + "-" + strconv.FormatInt(time.Now().UnixMilli(), 36) + "-" + strconv.Itoa(d.seq),
+		Kind: kind,
+		Code: code,
+		Name: name,
+	}
+	if err := sub.Validate(); err != nil {
+		return "", err
+	}
+	proc, err := d.start(sub)
+	if err != nil {
+		return "", i18n.Errorf("error.window.start_failed", err)
+	}
+	d.procs[sub.ID] = proc
+	go func() {
+		proc.Wait()
+		d.mu.Lock()
+		delete(d.procs, sub.ID)
+		d.mu.Unlock()
+	}()
+	return sub.ID, nil
+}
+
+// Count 当前打开的分离窗口数
+func (d *DetachedWindows) Count() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.procs)
+}
+
+// CloseAll 关闭全部分离窗口
+func (d *DetachedWindows) CloseAll() {
+	d.mu.Lock()
+	procs := make([]detachedProcess, 0, len(d.procs))
+	for _, p := range d.procs {
+		procs = append(procs, p)
+	}
+	d.mu.Unlock()
+	for _, p := range procs {
+		p.Kill()
+	}
+}
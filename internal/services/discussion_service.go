@@ -0,0 +1,291 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+)
+
+// DiscussionService 讨论记录服务：按讨论持久化完整发言、思考与工具调用
+type DiscussionService struct {
+	dir    string
+	active map[string]*models.Discussion // 进行中的讨论
+	mu     sync.Mutex
+}
+
+// NewDiscussionService 创建讨论记录服务
+func NewDiscussionService(dataDir string) *DiscussionService {
+	ds := &DiscussionService{
+		dir:    filepath.Join(dataDir, "discussions"),
+		active: make(map[string]*models.Discussion),
+	}
+	if err := os.MkdirAll(ds.dir, 0755); err != nil {
+		fmt.Printf("创建discussions目录失败: %v\n", err)
+	}
+	return ds
+}
+
+// path 讨论记录文件路径
+func (ds *DiscussionService) path(id string) string {
+	return filepath.Join(ds.dir, id+".json")
+}
+
+// Start 开始记录一次讨论，返回讨论ID
+func (ds *DiscussionService) Start(stockCode, stockName, query string, orchestration *models.OrchestrationConfig) (string, error) {
+	now := time.Now()
+	d := &models.Discussion{
+		ID:            fmt.Sprintf("%s-%s-%s", stockCode, now.Format("20060102150405"), uuid.New().String()[:8]),
+		StockCode:     stockCode,
+		StockName:     stockName,
+		Query:         query,
+		Orchestration: orchestration,
+		Status:        models.DiscussionRunning,
+		Messages:      []models.ChatMessage{},
+		Events:        []models.DiscussionEvent{},
+		CreatedAt:     now.UnixMilli(),
+		UpdatedAt:     now.UnixMilli(),
+	}
+
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	ds.active[d.ID] = d
+	return d.ID, ds.saveLocked(d)
+}
+
+// SetPlan 记录小韭菜选中的专家与分配的任务
+func (ds *DiscussionService) SetPlan(id string, selected []string, topic string, tasks map[string]string) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	d, ok := ds.active[id]
+	if !ok {
+		return
+	}
+	d.SelectedAgents = selected
+	d.Topic = topic
+	d.Tasks = tasks
+	ds.saveLocked(d)
+}
+
+// AddMessage 追加一条发言并落盘
+func (ds *DiscussionService) AddMessage(id string, msg models.ChatMessage) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	d, ok := ds.active[id]
+	if !ok {
+		return
+	}
+	if msg.Timestamp == 0 {
+		msg.Timestamp = time.Now().UnixMilli()
+	}
+	d.Messages = append(d.Messages, msg)
+	ds.saveLocked(d)
+}
+
+// AddEvent 追加过程事件，同一专家连续的思考片段合并为一条
+// 思考片段只在内存中累积，随下一次发言或结束时落盘
+func (ds *DiscussionService) AddEvent(id string, event models.DiscussionEvent) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	d, ok := ds.active[id]
+	if !ok {
+		return
+	}
+	if event.Type == "thinking" && len(d.Events) > 0 {
+		last := &d.Events[len(d.Events)-1]
+		if last.Type == "thinking" && last.AgentID == event.AgentID {
+			last.Content += event.Content
+			return
+		}
+	}
+	if event.Timestamp == 0 {
+		event.Timestamp = time.Now().UnixMilli()
+	}
+	d.Events = append(d.Events, event)
+	if event.Type != "thinking" {
+		ds.saveLocked(d)
+	}
+}
+
+// Finish 结束讨论记录
+func (ds *DiscussionService) Finish(id, status, errMsg string) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	d, ok := ds.active[id]
+	if !ok {
+		return
+	}
+	delete(ds.active, id)
+	d.Status = status
+	d.Error = errMsg
+	ds.saveLocked(d)
+}
+
+// Resume 将可恢复的讨论重新标记为进行中，返回讨论记录副本
+func (ds *DiscussionService) Resume(id string) (*models.Discussion, error) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	if _, ok := ds.active[id]; ok {
+		return nil, i18n.Errorf("error.discussion.running")
+	}
+	d, err := ds.load(id)
+	if err != nil {
+		return nil, err
+	}
+	if !ds.resumableLocked(d) {
+		return nil, i18n.Errorf("error.discussion.not_resumable")
+	}
+
+	d.Status = models.DiscussionRunning
+	d.Error = ""
+	d.ResumeCount++
+	ds.active[d.ID] = d
+	if err := ds.saveLocked(d); err != nil {
+		return nil, err
+	}
+	snapshot := *d
+	snapshot.Messages = append([]models.ChatMessage(nil), d.Messages...)
+	return &snapshot, nil
+}
+
+// Get 获取讨论记录
+func (ds *DiscussionService) Get(id string) (*models.Discussion, error) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	d, err := ds.load(id)
+	if err != nil {
+		return nil, err
+	}
+	ds.fixStatusLocked(d)
+	return d, nil
+}
+
+// List 列出讨论记录（按更新时间倒序），stockCode 为空时返回全部
+func (ds *DiscussionService) List(stockCode string) []models.DiscussionSummary {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	entries, err := os.ReadDir(ds.dir)
+	if err != nil {
+		return []models.DiscussionSummary{}
+	}
+	result := make([]models.DiscussionSummary, 0, len(entries))
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok || (stockCode != "" && !strings.HasPrefix(id, stockCode+"-")) {
+			continue
+		}
+		d, err := ds.load(id)
+		if err != nil {
+			fmt.Printf("读取讨论记录失败 %s: %v\n", id, err)
+			continue
+		}
+		ds.fixStatusLocked(d)
+		result = append(result, ds.summarizeLocked(d))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].UpdatedAt > result[j].UpdatedAt
+	})
+	return result
+}
+
+// load 读取讨论记录，进行中的讨论直接返回内存中的副本
+func (ds *DiscussionService) load(id string) (*models.Discussion, error) {
+	if d, ok := ds.active[id]; ok {
+		snapshot := *d
+		return &snapshot, nil
+	}
+	if strings.ContainsAny(id, `/\`) {
+		return nil, i18n.Errorf("error.discussion.id_invalid", id)
+	}
+	data, err := os.ReadFile(ds.path(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, i18n.Errorf("error.discussion.not_found", id)
+		}
+		return nil, err
+	}
+	var d models.Discussion
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// fixStatusLocked 文件中仍为进行中但不在内存里的讨论，说明应用在讨论中途退出
+func (ds *DiscussionService) fixStatusLocked(d *models.Discussion) {
+	if _, ok := ds.active[d.ID]; !ok && d.Status == models.DiscussionRunning {
+		d.Status = models.DiscussionInterrupted
+	}
+}
+
+// resumableLocked 判断讨论是否可恢复：已中断或被停止、有选中专家且尚未总结
+func (ds *DiscussionService) resumableLocked(d *models.Discussion) bool {
+	ds.fixStatusLocked(d)
+	if d.Status != models.DiscussionInterrupted && d.Status != models.DiscussionCancelled {
+		return false
+	}
+	if len(d.SelectedAgents) == 0 {
+		return false
+	}
+	for _, m := range d.Messages {
+		if m.MsgType == "summary" {
+			return false
+		}
+	}
+	return true
+}
+
+// summarizeLocked 生成列表项
+func (ds *DiscussionService) summarizeLocked(d *models.Discussion) models.DiscussionSummary {
+	rounds := 0
+	for _, m := range d.Messages {
+		if m.AgentID != "moderator" && m.Round > rounds {
+			rounds = m.Round
+		}
+	}
+	mode := models.OrchestrationRoundRobin
+	if d.Orchestration != nil && d.Orchestration.Mode != "" {
+		mode = d.Orchestration.Mode
+	}
+	return models.DiscussionSummary{
+		ID:           d.ID,
+		StockCode:    d.StockCode,
+		StockName:    d.StockName,
+		Query:        d.Query,
+		Mode:         mode,
+		Status:       d.Status,
+		Rounds:       rounds,
+		MessageCount: len(d.Messages),
+		Resumable:    ds.resumableLocked(d),
+		CreatedAt:    d.CreatedAt,
+		UpdatedAt:    d.UpdatedAt,
+	}
+}
+
+// saveLocked 先写临时文件再替换，避免写入中途退出导致记录损坏
+func (ds *DiscussionService) saveLocked(d *models.Discussion) error {
+	d.UpdatedAt = time.Now().UnixMilli()
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := ds.path(d.ID) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		fmt.Printf("保存讨论记录失败 %s: %v\n", d.ID, err)
+		return err
+	}
+	if err := os.Rename(tmp, ds.path(d.ID)); err != nil {
+		fmt.Printf("保存讨论记录失败 %s: %v\n", d.ID, err)
+		return err
+	}
+	return nil
+}
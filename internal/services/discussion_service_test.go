@@ -0,0 +1,65 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+func TestDiscussionServiceLifecycle(t *testing.T) {
+	dir := t.TempDir()
+	ds := NewDiscussionService(dir)
+
+	id, err := ds.Start("sh600519", "贵州茅台", "估值是否合理", nil)
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	ds.SetPlan(id, []string{"a", "b"}, "估值", map[string]string{"a": "看PE"})
+	ds.AddMessage(id, models.ChatMessage{AgentID: "a", Content: "PE 偏高", Round: 1, MsgType: "opinion"})
+	ds.AddEvent(id, models.DiscussionEvent{Type: "thinking", AgentID: "a", Content: "先看"})
+	ds.AddEvent(id, models.DiscussionEvent{Type: "thinking", AgentID: "a", Content: "估值"})
+	ds.AddEvent(id, models.DiscussionEvent{Type: "tool_call", AgentID: "a", Detail: "get_kline"})
+	ds.Finish(id, models.DiscussionCancelled, "")
+
+	d, err := NewDiscussionService(dir).Get(id)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if len(d.Messages) != 1 || len(d.Events) != 2 || d.Events[0].Content != "先看估值" {
+		t.Fatalf("unexpected transcript: %+v", d)
+	}
+
+	list := ds.List("sh600519")
+	if len(list) != 1 || !list[0].Resumable || list[0].Rounds != 1 {
+		t.Fatalf("unexpected list: %+v", list)
+	}
+	if got := ds.List("sz000001"); len(got) != 0 {
+		t.Fatalf("List filtered by stock = %d, want 0", len(got))
+	}
+
+	resumed, err := ds.Resume(id)
+	if err != nil || resumed.ResumeCount != 1 {
+		t.Fatalf("Resume: %v %+v", err, resumed)
+	}
+	if _, err := ds.Resume(id); err == nil {
+		t.Error("resuming a running discussion should fail")
+	}
+	ds.AddMessage(id, models.ChatMessage{AgentID: "moderator", Content: "总结", MsgType: "summary"})
+	ds.Finish(id, models.DiscussionCompleted, "")
+	if ds.List("")[0].Resumable {
+		t.Error("completed discussion should not be resumable")
+	}
+}
+
+func TestDiscussionRunningAfterRestartIsInterrupted(t *testing.T) {
+	dir := t.TempDir()
+	ds := NewDiscussionService(dir)
+	id, _ := ds.Start("sh600519", "贵州茅台", "q", nil)
+	ds.SetPlan(id, []string{"a"}, "", nil)
+
+	// 模拟应用退出后重新加载
+	list := NewDiscussionService(dir).List("")
+	if len(list) != 1 || list[0].Status != models.DiscussionInterrupted || !list[0].Resumable {
+		t.Fatalf("unexpected list after restart: %+v", list)
+	}
+}
@@ -0,0 +1,267 @@
+package services
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/logger"
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+
+	"github.com/google/uuid"
+)
+
+var drawingLog = logger.New("drawing")
+
+// EventDrawingsUpdate 画线变更事件，参数为 DrawingsUpdate，用于多窗口同步
+const EventDrawingsUpdate = "chart:drawings:update"
+
+// DrawingsUpdate 某只股票某周期的最新画线，Period 为空表示该股票所有周期已清空
+type DrawingsUpdate struct {
+	Symbol   string                `json:"symbol"`
+	Period   string                `json:"period"`
+	Drawings []models.ChartDrawing `json:"drawings"`
+}
+
+// 默认斐波那契回撤分位
+var defaultFibLevels = []float64{0, 0.236, 0.382, 0.5, 0.618, 0.786, 1}
+
+// drawingSymbolRe 画线按股票代码存储为文件，只接受 sh/sz/bj 加 6 位数字，防止路径穿越
+var drawingSymbolRe = regexp.MustCompile(`^(sh|sz|bj)\d{6}$`)
+
+// drawingTypes 支持的画线类型
+var drawingTypes = []models.DrawingType{
+	models.DrawingTrendLine, models.DrawingRay, models.DrawingHLine,
+	models.DrawingRect, models.DrawingFib, models.DrawingText,
+}
+
+// validateDrawingSymbol 校验股票代码
+func validateDrawingSymbol(symbol string) error {
+	if !drawingSymbolRe.MatchString(symbol) {
+		return i18n.Errorf("error.symbol.invalid", symbol)
+	}
+	return nil
+}
+
+// drawingFile 单只股票的画线存储结构（按周期分组）
+type drawingFile struct {
+	Symbol   string                           `json:"symbol"`
+	Drawings map[string][]models.ChartDrawing `json:"drawings"` // period -> drawings
+}
+
+// DrawingService 图表画线持久化服务
+type DrawingService struct {
+	drawingsDir string
+	cache       map[string]*drawingFile
+	mu          sync.Mutex
+	onChange    func(DrawingsUpdate)
+}
+
+// NewDrawingService 创建画线服务
+func NewDrawingService(dataDir string) *DrawingService {
+	ds := &DrawingService{
+		drawingsDir: filepath.Join(dataDir, "drawings"),
+		cache:       make(map[string]*drawingFile),
+	}
+	if err := os.MkdirAll(ds.drawingsDir, 0755); err != nil {
+		drawingLog.Error("创建drawings目录失败: %v", err)
+	}
+	return ds
+}
+
+// SetOnChange 设置画线变更回调（保存成功后调用，不持有锁）
+func (ds *DrawingService) SetOnChange(fn func(DrawingsUpdate)) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	ds.onChange = fn
+}
+
+// notifyLocked 生成变更通知(需要已持有锁)，返回的函数在释放锁后调用
+func (ds *DrawingService) notifyLocked(f *drawingFile, period string) func() {
+	fn := ds.onChange
+	if fn == nil {
+		return func() {}
+	}
+	update := DrawingsUpdate{Symbol: f.Symbol, Period: period, Drawings: make([]models.ChartDrawing, len(f.Drawings[period]))}
+	copy(update.Drawings, f.Drawings[period])
+	return func() { fn(update) }
+}
+
+// getFilePath 获取画线文件路径
+func (ds *DrawingService) getFilePath(symbol string) string {
+	return filepath.Join(ds.drawingsDir, symbol+".json")
+}
+
+// withPeriod 返回替换某周期画线后的副本，list 为空时移除该周期
+func (f *drawingFile) withPeriod(period string, list []models.ChartDrawing) *drawingFile {
+	next := &drawingFile{Symbol: f.Symbol, Drawings: make(map[string][]models.ChartDrawing, len(f.Drawings)+1)}
+	for p, l := range f.Drawings {
+		next.Drawings[p] = l
+	}
+	if len(list) == 0 {
+		delete(next.Drawings, period)
+	} else {
+		next.Drawings[period] = list
+	}
+	return next
+}
+
+// loadLocked 加载股票画线(需要已持有锁)
+func (ds *DrawingService) loadLocked(symbol string) *drawingFile {
+	if f, ok := ds.cache[symbol]; ok {
+		return f
+	}
+
+	f := &drawingFile{Symbol: symbol, Drawings: make(map[string][]models.ChartDrawing)}
+	if data, err := os.ReadFile(ds.getFilePath(symbol)); err == nil {
+		if err := json.Unmarshal(data, f); err != nil {
+			drawingLog.Warn("解析画线文件失败 [%s]: %v", symbol, err)
+		}
+		if f.Drawings == nil {
+			f.Drawings = make(map[string][]models.ChartDrawing)
+		}
+	}
+	ds.cache[symbol] = f
+	return f
+}
+
+// saveLocked 保存股票画线(需要已持有锁)，使用紧凑格式减少体积
+func (ds *DrawingService) saveLocked(f *drawingFile) error {
+	path := ds.getFilePath(f.Symbol)
+	if len(f.Drawings) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	data, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	return writeFileTracked(path, data, 0644)
+}
+
+// commitLocked 先写入文件，成功后再替换缓存，写入失败时缓存保持不变(需要已持有锁)
+// 返回的通知函数在释放锁后调用
+func (ds *DrawingService) commitLocked(next *drawingFile, period string) (func(), error) {
+	if err := ds.saveLocked(next); err != nil {
+		return nil, err
+	}
+	ds.cache[next.Symbol] = next
+	return ds.notifyLocked(next, period), nil
+}
+
+// GetDrawings 获取指定股票和周期的画线，股票代码无效时返回空列表
+func (ds *DrawingService) GetDrawings(symbol, period string) []models.ChartDrawing {
+	if validateDrawingSymbol(symbol) != nil {
+		return []models.ChartDrawing{}
+	}
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	drawings := ds.loadLocked(symbol).Drawings[period]
+	result := make([]models.ChartDrawing, len(drawings))
+	copy(result, drawings)
+	return result
+}
+
+// SaveDrawing 新增或更新画线（ID 为空时新增）
+func (ds *DrawingService) SaveDrawing(drawing models.ChartDrawing) (models.ChartDrawing, error) {
+	if drawing.Symbol == "" || drawing.Period == "" {
+		return drawing, i18n.Errorf("error.drawing.symbol_period_required")
+	}
+	if err := validateDrawingSymbol(drawing.Symbol); err != nil {
+		return drawing, err
+	}
+	if !slices.Contains(drawingTypes, drawing.Type) {
+		return drawing, i18n.Errorf("error.drawing.type_unsupported", drawing.Type)
+	}
+	if len(drawing.Points) == 0 {
+		return drawing, i18n.Errorf("error.drawing.points_required")
+	}
+	if drawing.Type == models.DrawingFib && len(drawing.Levels) == 0 {
+		drawing.Levels = defaultFibLevels
+	}
+
+	ds.mu.Lock()
+	f := ds.loadLocked(drawing.Symbol)
+	list := slices.Clone(f.Drawings[drawing.Period])
+	now := time.Now().UnixMilli()
+	drawing.UpdatedAt = now
+
+	updated := false
+	if drawing.ID != "" {
+		for i := range list {
+			if list[i].ID == drawing.ID {
+				drawing.CreatedAt = list[i].CreatedAt
+				list[i] = drawing
+				updated = true
+				break
+			}
+		}
+	} else {
+		drawing.ID = uuid.New().String()
+	}
+	if !updated {
+		drawing.CreatedAt = now
+		list = append(list, drawing)
+	}
+	notify, err := ds.commitLocked(f.withPeriod(drawing.Period, list), drawing.Period)
+	ds.mu.Unlock()
+
+	if err != nil {
+		return drawing, err
+	}
+	notify()
+	return drawing, nil
+}
+
+// DeleteDrawing 删除画线
+func (ds *DrawingService) DeleteDrawing(symbol, period, id string) error {
+	if err := validateDrawingSymbol(symbol); err != nil {
+		return err
+	}
+	ds.mu.Lock()
+	f := ds.loadLocked(symbol)
+	list := f.Drawings[period]
+	idx := slices.IndexFunc(list, func(d models.ChartDrawing) bool { return d.ID == id })
+	if idx < 0 {
+		ds.mu.Unlock()
+		return i18n.Errorf("error.drawing.not_found", id)
+	}
+	list = slices.Delete(slices.Clone(list), idx, idx+1)
+	notify, err := ds.commitLocked(f.withPeriod(period, list), period)
+	ds.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+	notify()
+	return nil
+}
+
+// ClearDrawings 清空画线，period 为空时清空该股票所有周期
+func (ds *DrawingService) ClearDrawings(symbol, period string) error {
+	if err := validateDrawingSymbol(symbol); err != nil {
+		return err
+	}
+	ds.mu.Lock()
+	f := ds.loadLocked(symbol)
+	next := &drawingFile{Symbol: symbol, Drawings: make(map[string][]models.ChartDrawing)}
+	if period != "" {
+		next = f.withPeriod(period, nil)
+	}
+	notify, err := ds.commitLocked(next, period)
+	ds.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+	notify()
+	return nil
+}
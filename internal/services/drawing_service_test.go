@@ -0,0 +1,123 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// TestDrawingServiceOnChange 测试画线增删改后广播最新列表，并在重启后保留
+func TestDrawingServiceOnChange(t *testing.T) {
+	dir := t.TempDir()
+	ds := NewDrawingService(dir)
+	var updates []DrawingsUpdate
+	ds.SetOnChange(func(u DrawingsUpdate) { updates = append(updates, u) })
+
+	saved, err := ds.SaveDrawing(models.ChartDrawing{
+		Symbol: "sh600519", Period: "1d", Type: models.DrawingHLine,
+		Points: []models.DrawingPoint{{Time: "2024-01-05", Price: 1688}}, Text: "压力位",
+	})
+	if err != nil || saved.ID == "" {
+		t.Fatalf("保存失败: %v %+v", err, saved)
+	}
+	if len(updates) != 1 || updates[0].Period != "1d" || len(updates[0].Drawings) != 1 {
+		t.Fatalf("新增后应广播: %+v", updates)
+	}
+
+	saved.Text = "强压力位"
+	if _, err := ds.SaveDrawing(saved); err != nil {
+		t.Fatal(err)
+	}
+	if got := updates[len(updates)-1].Drawings; len(got) != 1 || got[0].Text != "强压力位" {
+		t.Errorf("更新后广播内容错误: %+v", got)
+	}
+
+	// 重启后从文件恢复
+	restored := NewDrawingService(dir)
+	if got := restored.GetDrawings("sh600519", "1d"); len(got) != 1 || got[0].ID != saved.ID {
+		t.Fatalf("重启后画线丢失: %+v", got)
+	}
+
+	if err := ds.DeleteDrawing("sh600519", "1d", saved.ID); err != nil {
+		t.Fatal(err)
+	}
+	if last := updates[len(updates)-1]; len(last.Drawings) != 0 {
+		t.Errorf("删除后应广播空列表: %+v", last)
+	}
+	if err := ds.DeleteDrawing("sh600519", "1d", saved.ID); err == nil {
+		t.Error("删除不存在的画线应返回错误")
+	}
+
+	n := len(updates)
+	if err := ds.ClearDrawings("sh600519", ""); err != nil {
+		t.Fatal(err)
+	}
+	if len(updates) != n+1 || updates[n].Period != "" {
+		t.Errorf("清空全部周期应广播空周期: %+v", updates[n:])
+	}
+}
+
+// TestDrawingServiceValidate 测试股票代码与画线类型校验，拒绝路径穿越
+func TestDrawingServiceValidate(t *testing.T) {
+	dir := t.TempDir()
+	ds := NewDrawingService(dir)
+	point := []models.DrawingPoint{{Time: "2024-01-05", Price: 10}}
+
+	for _, symbol := range []string{"../evil", "sh600519/../../x", "600519", "SH600519"} {
+		if _, err := ds.SaveDrawing(models.ChartDrawing{Symbol: symbol, Period: "1d", Type: models.DrawingHLine, Points: point}); err == nil {
+			t.Errorf("无效股票代码 %q 应拒绝保存", symbol)
+		}
+		if err := ds.ClearDrawings(symbol, ""); err == nil {
+			t.Errorf("无效股票代码 %q 应拒绝清空", symbol)
+		}
+		if got := ds.GetDrawings(symbol, "1d"); len(got) != 0 {
+			t.Errorf("无效股票代码 %q 应返回空列表: %+v", symbol, got)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "evil.json")); !os.IsNotExist(err) {
+		t.Error("不应在画线目录外写入文件")
+	}
+
+	if _, err := ds.SaveDrawing(models.ChartDrawing{Symbol: "sh600519", Period: "1d", Type: "circle", Points: point}); err == nil {
+		t.Error("不支持的画线类型应拒绝保存")
+	}
+}
+
+// TestDrawingServiceSaveFailure 测试写入失败时缓存保持不变
+func TestDrawingServiceSaveFailure(t *testing.T) {
+	ds := NewDrawingService(t.TempDir())
+	first, err := ds.SaveDrawing(models.ChartDrawing{
+		Symbol: "sh600519", Period: "1d", Type: models.DrawingHLine,
+		Points: []models.DrawingPoint{{Time: "2024-01-05", Price: 1688}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 用非空目录占住画线文件路径，使后续写入失败
+	path := ds.getFilePath("sh600519")
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(path, "x"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ds.SaveDrawing(models.ChartDrawing{
+		Symbol: "sh600519", Period: "1d", Type: models.DrawingHLine,
+		Points: []models.DrawingPoint{{Time: "2024-01-08", Price: 1700}},
+	}); err == nil {
+		t.Fatal("写入失败应返回错误")
+	}
+	if got := ds.GetDrawings("sh600519", "1d"); len(got) != 1 || got[0].ID != first.ID {
+		t.Errorf("写入失败后缓存不应变化: %+v", got)
+	}
+	if err := ds.DeleteDrawing("sh600519", "1d", first.ID); err == nil {
+		t.Fatal("写入失败应返回错误")
+	}
+	if got := ds.GetDrawings("sh600519", "1d"); len(got) != 1 {
+		t.Errorf("删除失败后缓存不应变化: %+v", got)
+	}
+}
@@ -0,0 +1,310 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+	"github.com/run-bigpig/jcp/internal/pkg/proxy"
+)
+
+const (
+	// 天天基金持仓API：最新披露的前十大重仓股
+	etfHoldingsURL = "https://fundmobapi.eastmoney.com/FundMApi/FundInverstPositionNew.ashx?FCODE=%s&deviceid=Wap&plat=Wap&product=EFund&version=2.0.0"
+	// 东方财富个股信息：f57代码 f58名称 f127行业
+	stockSectorURL = "https://push2.eastmoney.com/api/qt/stock/get?secid=%s&fields=f57,f58,f127"
+)
+
+// ETF持仓按季度披露，行业归属也很少变化
+const lookThroughCacheTTL = 24 * time.Hour
+
+// 未披露持仓与未知行业的归类名称
+const (
+	undisclosedSector = "ETF其他持仓"
+	unknownSector     = "未知行业"
+)
+
+// LookThroughService ETF穿透分析服务
+type LookThroughService struct {
+	client *http.Client
+
+	etfCache    map[string]etfHoldingsCache
+	sectorCache map[string]string
+	cacheMu     sync.RWMutex
+}
+
+type etfHoldingsCache struct {
+	data      []models.ETFConstituent
+	timestamp time.Time
+}
+
+// NewLookThroughService 创建ETF穿透分析服务
+func NewLookThroughService() *LookThroughService {
+	return &LookThroughService{
+		client:      proxy.GetManager().GetClientWithTimeout(10 * time.Second),
+		etfCache:    make(map[string]etfHoldingsCache),
+		sectorCache: make(map[string]string),
+	}
+}
+
+// IsETF 根据代码判断是否为场内ETF/LOF（沪市5开头、深市15/16开头）
+func IsETF(symbol string) bool {
+	return strings.HasPrefix(symbol, "sh5") || strings.HasPrefix(symbol, "sz15") || strings.HasPrefix(symbol, "sz16")
+}
+
+// GetETFConstituents 获取ETF最新披露的重仓股
+func (s *LookThroughService) GetETFConstituents(symbol string) ([]models.ETFConstituent, error) {
+	if !IsETF(symbol) {
+		return nil, i18n.Errorf("error.etf.not_etf", symbol)
+	}
+
+	s.cacheMu.RLock()
+	if cached, ok := s.etfCache[symbol]; ok && time.Since(cached.timestamp) < lookThroughCacheTTL {
+		s.cacheMu.RUnlock()
+		return cached.data, nil
+	}
+	s.cacheMu.RUnlock()
+
+	holdings, err := s.fetchETFHoldings(symbol[2:])
+	if err != nil {
+		return nil, err
+	}
+
+	s.cacheMu.Lock()
+	s.etfCache[symbol] = etfHoldingsCache{data: holdings, timestamp: time.Now()}
+	for _, h := range holdings {
+		if h.Sector != "" {
+			s.sectorCache[h.Symbol] = h.Sector
+		}
+	}
+	s.cacheMu.Unlock()
+	return holdings, nil
+}
+
+// Analyze 计算组合的穿透敞口：ETF按成分股权重拆分到个股与行业
+func (s *LookThroughService) Analyze(holdings []models.PortfolioHolding) *models.PortfolioExposure {
+	constituents, sectors := s.resolve(holdings)
+	return computeLookThrough(holdings, constituents, sectors)
+}
+
+// resolve 获取持仓中ETF的成分股与个股的行业
+func (s *LookThroughService) resolve(holdings []models.PortfolioHolding) (map[string][]models.ETFConstituent, map[string]string) {
+	constituents := make(map[string][]models.ETFConstituent)
+	sectors := make(map[string]string)
+	for _, h := range holdings {
+		if IsETF(h.Symbol) {
+			items, err := s.GetETFConstituents(h.Symbol)
+			if err != nil {
+				log.Warn("获取ETF持仓失败 %s: %v", h.Symbol, err)
+				continue
+			}
+			constituents[h.Symbol] = items
+			continue
+		}
+		sectors[h.Symbol] = s.getSector(h.Symbol)
+	}
+	return constituents, sectors
+}
+
+// getSector 获取个股所属行业（带缓存），失败时返回空
+func (s *LookThroughService) getSector(symbol string) string {
+	s.cacheMu.RLock()
+	sector, ok := s.sectorCache[symbol]
+	s.cacheMu.RUnlock()
+	if ok {
+		return sector
+	}
+
+	sector, err := s.fetchSector(symbol)
+	if err != nil {
+		log.Warn("获取行业失败 %s: %v", symbol, err)
+		return ""
+	}
+	s.cacheMu.Lock()
+	s.sectorCache[symbol] = sector
+	s.cacheMu.Unlock()
+	return sector
+}
+
+// computeLookThrough 合并直接持仓与ETF成分股敞口
+// ETF未披露的部分（前十大以外）按ETF单独归入“ETF其他持仓”
+func computeLookThrough(holdings []models.PortfolioHolding, constituents map[string][]models.ETFConstituent, sectors map[string]string) *models.PortfolioExposure {
+	stocks := make(map[string]*models.StockExposure)
+	add := func(symbol, name, sector, source string, value float64) {
+		if value <= 0 {
+			return
+		}
+		if sector == "" {
+			sector = unknownSector
+		}
+		e, ok := stocks[symbol]
+		if !ok {
+			e = &models.StockExposure{Symbol: symbol, Name: name, Sector: sector}
+			stocks[symbol] = e
+		}
+		e.Value += value
+		if !slices.Contains(e.Sources, source) {
+			e.Sources = append(e.Sources, source)
+		}
+	}
+
+	var total float64
+	for _, h := range holdings {
+		value := float64(h.Shares) * h.Price
+		if value <= 0 {
+			continue
+		}
+		total += value
+
+		items, isETF := constituents[h.Symbol]
+		if !isETF {
+			add(h.Symbol, h.Name, sectors[h.Symbol], h.Symbol, value)
+			continue
+		}
+		var disclosed float64
+		for _, c := range items {
+			add(c.Symbol, c.Name, c.Sector, h.Symbol, value*c.Weight/100)
+			disclosed += c.Weight
+		}
+		if disclosed < 100 {
+			add(h.Symbol, h.Name, undisclosedSector, h.Symbol, value*(100-disclosed)/100)
+		}
+	}
+
+	result := &models.PortfolioExposure{
+		TotalValue: total,
+		ByStock:    make([]models.StockExposure, 0, len(stocks)),
+		BySector:   []models.SectorExposure{},
+	}
+	if total == 0 {
+		return result
+	}
+
+	sectorValues := make(map[string]float64)
+	for _, e := range stocks {
+		e.Weight = e.Value / total * 100
+		result.ByStock = append(result.ByStock, *e)
+		sectorValues[e.Sector] += e.Value
+	}
+	for sector, value := range sectorValues {
+		result.BySector = append(result.BySector, models.SectorExposure{
+			Sector: sector,
+			Value:  value,
+			Weight: value / total * 100,
+		})
+	}
+	sort.Slice(result.ByStock, func(i, j int) bool {
+		return result.ByStock[i].Value > result.ByStock[j].Value
+	})
+	sort.Slice(result.BySector, func(i, j int) bool {
+		return result.BySector[i].Value > result.BySector[j].Value
+	})
+	return result
+}
+
+// etfHoldingsResponse 天天基金持仓API响应结构
+type etfHoldingsResponse struct {
+	Datas *struct {
+		FundStocks []struct {
+			GPDM      string `json:"GPDM"`      // 股票代码
+			GPJC      string `json:"GPJC"`      // 股票简称
+			JZBL      string `json:"JZBL"`      // 占净值比例(%)
+			NewTExch  string `json:"NEWTEXCH"`  // 交易所: 1沪 0深
+			IndexName string `json:"INDEXNAME"` // 所属行业
+		} `json:"fundStocks"`
+	} `json:"Datas"`
+	ErrCode int    `json:"ErrCode"`
+	ErrMsg  string `json:"ErrMsg"`
+}
+
+// fetchETFHoldings 从天天基金获取ETF重仓股
+func (s *LookThroughService) fetchETFHoldings(fundCode string) ([]models.ETFConstituent, error) {
+	body, err := s.get(fmt.Sprintf(etfHoldingsURL, fundCode), "https://fund.eastmoney.com/")
+	if err != nil {
+		return nil, err
+	}
+	return parseETFHoldings(body)
+}
+
+// parseETFHoldings 解析ETF重仓股
+func parseETFHoldings(body []byte) ([]models.ETFConstituent, error) {
+	var resp etfHoldingsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("解析ETF持仓失败: %w", err)
+	}
+	if resp.Datas == nil {
+		return nil, fmt.Errorf("获取ETF持仓失败: %s", resp.ErrMsg)
+	}
+
+	result := make([]models.ETFConstituent, 0, len(resp.Datas.FundStocks))
+	for _, item := range resp.Datas.FundStocks {
+		var weight float64
+		fmt.Sscanf(item.JZBL, "%f", &weight)
+		prefix := "sz"
+		switch {
+		case item.NewTExch == "1":
+			prefix = "sh"
+		case isBSECode(item.GPDM):
+			prefix = "bj"
+		}
+		result = append(result, models.ETFConstituent{
+			Symbol: prefix + item.GPDM,
+			Name:   item.GPJC,
+			Weight: weight,
+			Sector: item.IndexName,
+		})
+	}
+	return result, nil
+}
+
+// fetchSector 从东方财富获取个股所属行业
+func (s *LookThroughService) fetchSector(symbol string) (string, error) {
+	if len(symbol) < 3 {
+		return "", i18n.Errorf("error.symbol.invalid", symbol)
+	}
+	market := "0"
+	if strings.HasPrefix(symbol, "sh") {
+		market = "1"
+	}
+	body, err := s.get(fmt.Sprintf(stockSectorURL, market+"."+symbol[2:]), "https://quote.eastmoney.com/")
+	if err != nil {
+		return "", err
+	}
+
+	var resp struct {
+		Data *struct {
+			Sector string `json:"f127"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return "", err
+	}
+	if resp.Data == nil {
+		return "", i18n.Errorf("error.symbol.not_found", symbol)
+	}
+	return resp.Data.Sector, nil
+}
+
+func (s *LookThroughService) get(url, referer string) ([]byte, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
+	req.Header.Set("Referer", referer)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
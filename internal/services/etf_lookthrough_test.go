@@ -0,0 +1,45 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// TestComputeLookThrough 测试ETF穿透后的个股与行业敞口合并
+func TestComputeLookThrough(t *testing.T) {
+	holdings := []models.PortfolioHolding{
+		{Symbol: "sh512000", Name: "券商ETF", Shares: 10000, Price: 1.0}, // 1万元
+		{Symbol: "sz300059", Name: "东方财富", Shares: 1000, Price: 10.0},  // 1万元
+	}
+	constituents := map[string][]models.ETFConstituent{
+		"sh512000": {
+			{Symbol: "sz300059", Name: "东方财富", Weight: 15, Sector: "证券"},
+			{Symbol: "sh600030", Name: "中信证券", Weight: 25, Sector: "证券"},
+		},
+	}
+	sectors := map[string]string{"sz300059": "证券"}
+
+	exp := computeLookThrough(holdings, constituents, sectors)
+	if exp.TotalValue != 20000 {
+		t.Fatalf("总市值 = %.0f, want 20000", exp.TotalValue)
+	}
+
+	top := exp.ByStock[0]
+	if top.Symbol != "sz300059" || top.Value != 11500 || len(top.Sources) != 2 {
+		t.Errorf("东方财富穿透敞口错误: %+v", top)
+	}
+	if exp.BySector[0].Sector != "证券" || exp.BySector[0].Value != 14000 {
+		t.Errorf("行业敞口错误: %+v", exp.BySector)
+	}
+
+	var undisclosed float64
+	for _, s := range exp.BySector {
+		if s.Sector == undisclosedSector {
+			undisclosed = s.Value
+		}
+	}
+	if undisclosed != 6000 {
+		t.Errorf("ETF未披露部分 = %.0f, want 6000", undisclosed)
+	}
+}
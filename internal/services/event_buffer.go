@@ -0,0 +1,109 @@
+package services
+
+import (
+	"sync"
+)
+
+// 默认每个事件通道保留的消息条数
+const defaultRetainSize = 1
+
+// EventBuffer 事件保留缓冲区
+// 记录每个事件通道最近推送的消息，供中途挂载的前端面板订阅时立即回放
+type EventBuffer struct {
+	mu       sync.RWMutex
+	channels map[string][]any
+	sizes    map[string]int // 事件通道 -> 保留条数（未设置则使用默认值）
+}
+
+// NewEventBuffer 创建事件保留缓冲区
+func NewEventBuffer() *EventBuffer {
+	return &EventBuffer{
+		channels: make(map[string][]any),
+		sizes:    make(map[string]int),
+	}
+}
+
+// SetRetainSize 设置指定事件通道的保留条数
+func (b *EventBuffer) SetRetainSize(event string, size int) {
+	if size <= 0 {
+		size = defaultRetainSize
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sizes[event] = size
+	if msgs := b.channels[event]; len(msgs) > size {
+		b.channels[event] = msgs[len(msgs)-size:]
+	}
+}
+
+// Retain 记录一条事件消息，超出保留条数时丢弃最旧的
+func (b *EventBuffer) Retain(event string, data any) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	size := b.sizes[event]
+	if size <= 0 {
+		size = defaultRetainSize
+	}
+	msgs := append(b.channels[event], data)
+	if len(msgs) > size {
+		// 重新分配，避免底层数组持续增长
+		msgs = append([]any(nil), msgs[len(msgs)-size:]...)
+	}
+	b.channels[event] = msgs
+}
+
+// Replay 获取指定事件通道保留的消息（按推送顺序）
+func (b *EventBuffer) Replay(event string) []any {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	msgs := b.channels[event]
+	if len(msgs) == 0 {
+		return nil
+	}
+	result := make([]any, len(msgs))
+	copy(result, msgs)
+	return result
+}
+
+// Latest 获取指定事件通道最近一条消息
+func (b *EventBuffer) Latest(event string) (any, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	msgs := b.channels[event]
+	if len(msgs) == 0 {
+		return nil, false
+	}
+	return msgs[len(msgs)-1], true
+}
+
+// Events 获取所有有保留消息的事件通道
+func (b *EventBuffer) Events() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	events := make([]string, 0, len(b.channels))
+	for event, msgs := range b.channels {
+		if len(msgs) > 0 {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// Len 所有事件通道保留的消息总数
+func (b *EventBuffer) Len() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	n := 0
+	for _, msgs := range b.channels {
+		n += len(msgs)
+	}
+	return n
+}
+
+// Clear 清空指定事件通道的保留消息
+func (b *EventBuffer) Clear(event string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.channels, event)
+}
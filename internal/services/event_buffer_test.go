@@ -0,0 +1,50 @@
+package services
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestEventBufferRetain 测试按通道保留最近消息与回放
+func TestEventBufferRetain(t *testing.T) {
+	b := NewEventBuffer()
+	b.SetRetainSize("news", 2)
+	for _, v := range []int{1, 2, 3} {
+		b.Retain("news", v)
+		b.Retain("quote", v)
+	}
+
+	if got := b.Replay("news"); !slices.Equal(got, []any{2, 3}) {
+		t.Errorf("Replay(news) = %v, want [2 3]", got)
+	}
+	if got := b.Replay("quote"); !slices.Equal(got, []any{3}) {
+		t.Errorf("默认只保留最近一条, got %v", got)
+	}
+	if latest, ok := b.Latest("news"); !ok || latest != 3 {
+		t.Errorf("Latest(news) = %v, %v", latest, ok)
+	}
+	if b.Len() != 3 {
+		t.Errorf("Len = %d, want 3", b.Len())
+	}
+
+	// 回放结果是副本，修改不影响缓冲区
+	replay := b.Replay("news")
+	replay[0] = 99
+	if got := b.Replay("news"); got[0] != 2 {
+		t.Errorf("回放结果不应共享底层数组: %v", got)
+	}
+
+	// 缩小保留条数时立即截断
+	b.SetRetainSize("news", 1)
+	if got := b.Replay("news"); !slices.Equal(got, []any{3}) {
+		t.Errorf("缩小保留条数后 = %v, want [3]", got)
+	}
+
+	b.Clear("quote")
+	if _, ok := b.Latest("quote"); ok || b.Replay("quote") != nil {
+		t.Error("清空后不应回放")
+	}
+	if events := b.Events(); !slices.Equal(events, []string{"news"}) {
+		t.Errorf("Events = %v, want [news]", events)
+	}
+}
@@ -0,0 +1,243 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+)
+
+// 导出文件格式
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatXLSX = "xlsx"
+)
+
+// ExportTable 导出表格：表头 + 行，单元格为 string / 数值类型
+type ExportTable struct {
+	Sheet   string
+	Headers []string
+	Rows    [][]any
+}
+
+// ExportService 数据导出服务（自选行情、K线、模拟交易成交记录）
+type ExportService struct {
+	marketService *MarketService
+	configService *ConfigService
+	paperService  *PaperTradingService
+}
+
+// NewExportService 创建导出服务
+func NewExportService(marketService *MarketService, configService *ConfigService, paperService *PaperTradingService) *ExportService {
+	return &ExportService{
+		marketService: marketService,
+		configService: configService,
+		paperService:  paperService,
+	}
+}
+
+// QuotesTable 当前分组自选股实时行情
+func (s *ExportService) QuotesTable(ctx context.Context) (ExportTable, error) {
+	table := ExportTable{
+		Sheet:   "自选行情",
+		Headers: []string{"代码", "名称", "最新价", "涨跌额", "涨跌幅(%)", "今开", "最高", "最低", "昨收", "成交量", "成交额"},
+	}
+	codes := s.configService.GetActiveGroupSymbols()
+	if len(codes) == 0 {
+		return table, nil
+	}
+	stocks, err := s.marketService.GetStockRealTimeData(ctx, codes...)
+	if err != nil {
+		return table, err
+	}
+	for _, st := range stocks {
+		table.Rows = append(table.Rows, []any{
+			st.Symbol, st.Name, st.Price, st.Change, st.ChangePercent,
+			st.Open, st.High, st.Low, st.PreClose, st.Volume, st.Amount,
+		})
+	}
+	return table, nil
+}
+
+// KLineTable K线数据
+func (s *ExportService) KLineTable(ctx context.Context, code, period string, days int) (ExportTable, error) {
+	table := ExportTable{
+		Sheet:   "K线",
+		Headers: []string{"时间", "开盘", "最高", "最低", "收盘", "成交量", "成交额"},
+	}
+	klines, err := s.marketService.GetKLineData(ctx, code, period, days)
+	if err != nil {
+		return table, err
+	}
+	for _, k := range klines {
+		table.Rows = append(table.Rows, []any{k.Time, k.Open, k.High, k.Low, k.Close, k.Volume, k.Amount})
+	}
+	return table, nil
+}
+
+// TransactionsTable 模拟交易成交记录（按成交时间先后）
+func (s *ExportService) TransactionsTable() ExportTable {
+	table := ExportTable{
+		Sheet:   "成交记录",
+		Headers: []string{"成交时间", "代码", "名称", "方向", "成交价", "数量", "成交金额", "费用"},
+	}
+	orders := s.paperService.GetOrders()
+	for i := len(orders) - 1; i >= 0; i-- {
+		o := orders[i]
+		if o.Status != models.PaperStatusFilled {
+			continue
+		}
+		side := "买入"
+		if o.Side == models.PaperSideSell {
+			side = "卖出"
+		}
+		table.Rows = append(table.Rows, []any{
+			time.UnixMilli(o.FilledAt).Format("2006-01-02 15:04:05"),
+			o.Symbol, o.Name, side, o.FilledPrice, o.Shares,
+			roundPrice(o.FilledPrice * float64(o.Shares)), o.Fee,
+		})
+	}
+	return table
+}
+
+// EncodeTable 按格式编码表格
+func EncodeTable(table ExportTable, format string) ([]byte, error) {
+	switch format {
+	case ExportFormatCSV:
+		return encodeCSV(table)
+	case ExportFormatXLSX:
+		return encodeXLSX(table)
+	}
+	return nil, i18n.Errorf("error.export.format_unsupported", format)
+}
+
+// encodeCSV 编码 CSV，带 UTF-8 BOM 以便 Excel 正确识别中文
+func encodeCSV(table ExportTable) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("\ufeff")
+	w := csv.NewWriter(&buf)
+	w.Write(table.Headers)
+	for _, row := range table.Rows {
+		record := make([]string, len(row))
+		for i, v := range row {
+			record[i] = formatCell(v)
+		}
+		w.Write(record)
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// formatCell 单元格转文本
+func formatCell(v any) string {
+	switch x := v.(type) {
+	case string:
+		return x
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case int64:
+		return strconv.FormatInt(x, 10)
+	case int:
+		return strconv.Itoa(x)
+	}
+	return fmt.Sprint(v)
+}
+
+// xlsx 最小文件结构：单工作表，字符串使用内联字符串，无样式
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+)
+
+// encodeXLSX 编码为 xlsx（Office Open XML）
+func encodeXLSX(table ExportTable) ([]byte, error) {
+	var sheet bytes.Buffer
+	sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	sheet.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	writeXLSXRow(&sheet, 1, stringsToCells(table.Headers))
+	for i, row := range table.Rows {
+		writeXLSXRow(&sheet, i+2, row)
+	}
+	sheet.WriteString(`</sheetData></worksheet>`)
+
+	sheetName := table.Sheet
+	if sheetName == "" {
+		sheetName = "Sheet1"
+	}
+	files := []struct {
+		name, body string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, xmlEscape(sheetName))},
+		{"xl/worksheets/sheet1.xml", sheet.String()},
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range files {
+		w, err := zw.Create(f.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write([]byte(f.body)); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeXLSXRow 写入一行，数值写为数字单元格，其余为内联字符串
+func writeXLSXRow(buf *bytes.Buffer, rowNum int, cells []any) {
+	fmt.Fprintf(buf, `<row r="%d">`, rowNum)
+	for col, v := range cells {
+		ref := xlsxColumn(col) + strconv.Itoa(rowNum)
+		switch v.(type) {
+		case float64, int64, int:
+			fmt.Fprintf(buf, `<c r="%s"><v>%s</v></c>`, ref, formatCell(v))
+		default:
+			fmt.Fprintf(buf, `<c r="%s" t="inlineStr"><is><t>%s</t></is></c>`, ref, xmlEscape(formatCell(v)))
+		}
+	}
+	buf.WriteString(`</row>`)
+}
+
+// xlsxColumn 列序号（从0开始）转列名：0→A，26→AA
+func xlsxColumn(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+func stringsToCells(values []string) []any {
+	cells := make([]any, len(values))
+	for i, v := range values {
+		cells[i] = v
+	}
+	return cells
+}
+
+func xmlEscape(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+// TestEncodeTable 测试 CSV/XLSX 导出编码
+func TestEncodeTable(t *testing.T) {
+	table := ExportTable{
+		Sheet:   "K线",
+		Headers: []string{"时间", "收盘", "成交量"},
+		Rows:    [][]any{{"2026-03-02", 10.5, int64(1200)}, {"a<b", 0.0, int64(0)}},
+	}
+
+	data, err := EncodeTable(table, ExportFormatCSV)
+	if err != nil {
+		t.Fatalf("csv: %v", err)
+	}
+	if want := "\ufeff时间,收盘,成交量\n2026-03-02,10.5,1200\n"; !strings.HasPrefix(string(data), want) {
+		t.Errorf("csv = %q", data)
+	}
+
+	data, err = EncodeTable(table, ExportFormatXLSX)
+	if err != nil {
+		t.Fatalf("xlsx: %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("xlsx 不是有效的 zip: %v", err)
+	}
+	var sheet string
+	for _, f := range zr.File {
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			rc, _ := f.Open()
+			b, _ := io.ReadAll(rc)
+			rc.Close()
+			sheet = string(b)
+		}
+	}
+	for _, want := range []string{`<c r="B2"><v>10.5</v></c>`, `<t>a&lt;b</t>`, `<c r="C3"><v>0</v></c>`} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet 缺少 %s", want)
+		}
+	}
+	if _, err := EncodeTable(table, "pdf"); err == nil {
+		t.Error("不支持的格式应报错")
+	}
+}
@@ -0,0 +1,321 @@
+package services
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+)
+
+// futuresPrefix 新浪期货行情代码前缀，如 nf_IF0（主力连续）、nf_RB2410
+const futuresPrefix = "nf_"
+
+// 交易所代码
+const (
+	ExchangeCFFEX = "CFFEX" // 中国金融期货交易所
+	ExchangeSHFE  = "SHFE"  // 上海期货交易所
+	ExchangeINE   = "INE"   // 上海国际能源交易中心
+	ExchangeDCE   = "DCE"   // 大连商品交易所
+	ExchangeCZCE  = "CZCE"  // 郑州商品交易所
+)
+
+// futuresExchangeNames 交易所简称（搜索结果展示）
+var futuresExchangeNames = map[string]string{
+	ExchangeCFFEX: "中金所",
+	ExchangeSHFE:  "上期所",
+	ExchangeINE:   "上期能源",
+	ExchangeDCE:   "大商所",
+	ExchangeCZCE:  "郑商所",
+}
+
+// futuresProduct 期货品种
+type futuresProduct struct {
+	code     string // 品种代码
+	name     string
+	exchange string
+	spot     string // 股指期货对应的现货指数
+	nightEnd string // 夜盘收盘时间 HH:MM，早于 21:00 表示跨午夜；空表示无夜盘
+}
+
+// futuresProducts 支持的期货品种：中金所股指期货与主要商品期货
+var futuresProducts = []futuresProduct{
+	{"IF", "沪深300股指期货", ExchangeCFFEX, "sh000300", ""},
+	{"IH", "上证50股指期货", ExchangeCFFEX, "sh000016", ""},
+	{"IC", "中证500股指期货", ExchangeCFFEX, "sh000905", ""},
+	{"IM", "中证1000股指期货", ExchangeCFFEX, "sh000852", ""},
+	{"AU", "黄金", ExchangeSHFE, "", "02:30"},
+	{"AG", "白银", ExchangeSHFE, "", "02:30"},
+	{"CU", "沪铜", ExchangeSHFE, "", "01:00"},
+	{"AL", "沪铝", ExchangeSHFE, "", "01:00"},
+	{"RB", "螺纹钢", ExchangeSHFE, "", "23:00"},
+	{"SC", "原油", ExchangeINE, "", "02:30"},
+	{"I", "铁矿石", ExchangeDCE, "", "23:00"},
+	{"M", "豆粕", ExchangeDCE, "", "23:00"},
+	{"TA", "PTA", ExchangeCZCE, "", "23:00"},
+	{"MA", "甲醇", ExchangeCZCE, "", "23:00"},
+}
+
+// IsFutures 是否为期货代码
+func IsFutures(symbol string) bool {
+	return strings.HasPrefix(symbol, futuresPrefix)
+}
+
+// futuresProductOf 根据期货代码查找品种，如 nf_IF2406 → IF
+func futuresProductOf(symbol string) (futuresProduct, bool) {
+	if !IsFutures(symbol) {
+		return futuresProduct{}, false
+	}
+	code := strings.TrimRightFunc(strings.ToUpper(strings.TrimPrefix(symbol, futuresPrefix)), unicode.IsDigit)
+	for _, p := range futuresProducts {
+		if p.code == code {
+			return p, true
+		}
+	}
+	return futuresProduct{}, false
+}
+
+// futuresIndexEntries 期货主力连续合约的搜索条目，按品种代码（IF、RB）或名称搜索
+func futuresIndexEntries() []stockIndexEntry {
+	entries := make([]stockIndexEntry, 0, len(futuresProducts))
+	for _, p := range futuresProducts {
+		name := p.name + "主连"
+		entries = append(entries, stockIndexEntry{
+			result: StockSearchResult{Symbol: futuresPrefix + p.code + "0", Name: name, Industry: "期货", Market: futuresExchangeNames[p.exchange]},
+			code:   p.code + "0",
+			name:   strings.ToUpper(name),
+			spell:  p.code,
+		})
+	}
+	return entries
+}
+
+// GetFuturesQuotes 获取期货行情，股指期货同时计算与现货指数的基差
+func (ms *MarketService) GetFuturesQuotes(ctx context.Context, symbols ...string) ([]models.FuturesQuote, error) {
+	var codes []string
+	spots := map[string]bool{}
+	for _, s := range symbols {
+		p, ok := futuresProductOf(s)
+		if !ok {
+			return nil, i18n.Errorf("error.futures.unsupported", s)
+		}
+		codes = append(codes, s)
+		if p.spot != "" && !spots[p.spot] {
+			spots[p.spot] = true
+			codes = append(codes, toSinaIndexCode(p.spot))
+		}
+	}
+	if len(codes) == 0 {
+		return nil, nil
+	}
+
+	body, err := ms.fetchSinaQuotes(ctx, codes)
+	if err != nil {
+		return nil, err
+	}
+	return parseFuturesQuotes(body), nil
+}
+
+// parseFuturesQuotes 解析期货行情，并用同一响应中的现货指数计算基差
+func parseFuturesQuotes(data string) []models.FuturesQuote {
+	spotPrices := map[string]float64{}
+	for _, line := range strings.Split(data, "\n") {
+		if index, ok := parseCNIndex(sinaIndexRegex.FindStringSubmatch(line)); ok {
+			spotPrices[index.Code] = index.Price
+		}
+	}
+
+	var quotes []models.FuturesQuote
+	for _, match := range sinaStockRegex.FindAllStringSubmatch(data, -1) {
+		if !IsFutures(match[1]) || match[2] == "" {
+			continue
+		}
+		q, ok := parseFuturesFields(match[1], strings.Split(match[2], ","))
+		if !ok {
+			continue
+		}
+		if spot := spotPrices[q.SpotCode]; spot > 0 && q.Price > 0 {
+			q.SpotPrice = spot
+			q.Basis = math.Round((q.Price-spot)*100) / 100
+			q.BasisPercent = q.Basis / spot * 100
+		}
+		quotes = append(quotes, q)
+	}
+	return quotes
+}
+
+// parseFuturesFields 解析新浪期货行情字段，中金所与商品期货格式不同
+//
+// 中金所: 开盘,最高,最低,最新,成交量,成交额,持仓量,收盘,结算,涨停,跌停,...,[13]昨收,[14]昨结算,...,[36]日期,[37]时间,...,名称(末尾)
+// 商品期货: 名称,时间(HHMMSS),开盘,最高,最低,昨收,买价,卖价,最新,结算,昨结算,买量,卖量,持仓量,成交量,交易所,品种,日期
+func parseFuturesFields(symbol string, parts []string) (models.FuturesQuote, bool) {
+	p, ok := futuresProductOf(symbol)
+	if !ok {
+		return models.FuturesQuote{}, false
+	}
+	f := func(i int) float64 {
+		if i >= len(parts) {
+			return 0
+		}
+		v, _ := strconv.ParseFloat(parts[i], 64)
+		return v
+	}
+	q := models.FuturesQuote{Symbol: symbol, Product: p.code, Exchange: p.exchange, SpotCode: p.spot}
+
+	if p.exchange == ExchangeCFFEX {
+		if len(parts) < 38 {
+			return q, false
+		}
+		q.Open, q.High, q.Low, q.Price = f(0), f(1), f(2), f(3)
+		q.Volume = int64(f(4))
+		q.OpenInterest = f(6)
+		q.PreClose, q.PreSettle = f(13), f(14)
+		q.Date, q.Time = parts[36], parts[37]
+		q.Name = strings.TrimSpace(parts[len(parts)-1])
+	} else {
+		if len(parts) < 18 {
+			return q, false
+		}
+		q.Name = parts[0]
+		q.Open, q.High, q.Low, q.PreClose = f(2), f(3), f(4), f(5)
+		q.Price, q.PreSettle = f(8), f(10)
+		q.OpenInterest = f(13)
+		q.Volume = int64(f(14))
+		q.Date = parts[17]
+		if t := parts[1]; len(t) == 6 {
+			q.Time = t[0:2] + ":" + t[2:4] + ":" + t[4:6]
+		}
+	}
+	if q.Name == "" {
+		q.Name = p.name
+	}
+	if q.PreSettle > 0 && q.Price > 0 {
+		q.Change = q.Price - q.PreSettle
+		q.ChangePercent = q.Change / q.PreSettle * 100
+	}
+	return q, true
+}
+
+// futuresToStock 期货行情转换为通用行情（自选股列表展示）
+func futuresToStock(q models.FuturesQuote) models.Stock {
+	preClose := q.PreSettle
+	if preClose <= 0 {
+		preClose = q.PreClose
+	}
+	return models.Stock{
+		Symbol:        q.Symbol,
+		Name:          q.Name,
+		Price:         q.Price,
+		Open:          q.Open,
+		High:          q.High,
+		Low:           q.Low,
+		PreClose:      preClose,
+		Change:        q.Change,
+		ChangePercent: q.ChangePercent,
+		Volume:        q.Volume,
+		Sector:        q.Exchange,
+	}
+}
+
+// futuresSession 期货日盘时段（分钟数，左闭右开）
+type futuresSession struct {
+	status string
+	text   string
+	start  int
+	end    int
+}
+
+// futuresDaySessions 日盘时段：中金所股指期货与A股同步，商品期货 10:15-10:30 小节休息、13:30 开盘
+func futuresDaySessions(p futuresProduct) []futuresSession {
+	if p.exchange == ExchangeCFFEX {
+		return []futuresSession{
+			{"pre_market", "market.status.pre_market", 0, 9*60 + 25},
+			{"pre_market", "market.status.call_auction", 9*60 + 25, 9*60 + 30},
+			{"trading", "market.status.trading", 9*60 + 30, 11*60 + 30},
+			{"lunch_break", "market.status.lunch_break", 11*60 + 30, 13 * 60},
+			{"trading", "market.status.trading", 13 * 60, 15 * 60},
+		}
+	}
+	return []futuresSession{
+		{"pre_market", "market.status.pre_market", 0, 8*60 + 55},
+		{"pre_market", "market.status.call_auction", 8*60 + 55, 9 * 60},
+		{"trading", "market.status.trading", 9 * 60, 10*60 + 15},
+		{"lunch_break", "market.status.break", 10*60 + 15, 10*60 + 30},
+		{"trading", "market.status.trading", 10*60 + 30, 11*60 + 30},
+		{"lunch_break", "market.status.lunch_break", 11*60 + 30, 13*60 + 30},
+		{"trading", "market.status.trading", 13*60 + 30, 15 * 60},
+	}
+}
+
+// GetMarketStatusFor 获取指定代码的交易状态，期货按各品种交易时段（含夜盘）判断，其余同 GetMarketStatus
+func (ms *MarketService) GetMarketStatusFor(symbol string) MarketStatus {
+	p, ok := futuresProductOf(symbol)
+	if !ok {
+		return ms.GetMarketStatus()
+	}
+	return ms.futuresMarketStatus(time.Now().In(time.FixedZone("CST", 8*60*60)), p)
+}
+
+// futuresMarketStatus 期货交易状态
+// 夜盘 21:00 开始（20:55 集合竞价），跨午夜的部分归属前一自然日的夜盘
+func (ms *MarketService) futuresMarketStatus(now time.Time, p futuresProduct) MarketStatus {
+	minutes := now.Hour()*60 + now.Minute()
+	isTradeDay, holidayName := ms.isTradeDay(now)
+
+	if p.nightEnd != "" {
+		nightEnd := clockMinutes(p.nightEnd)
+		crossMidnight := nightEnd < 21*60
+		if crossMidnight && minutes < nightEnd && ms.hasNightSession(now.AddDate(0, 0, -1)) {
+			return MarketStatus{Status: "trading", StatusText: i18n.T("market.status.night"), IsTradeDay: isTradeDay}
+		}
+		if minutes >= 20*60+55 && isTradeDay && ms.hasNightSession(now) {
+			switch {
+			case minutes < 21*60:
+				return MarketStatus{Status: "pre_market", StatusText: i18n.T("market.status.call_auction"), IsTradeDay: true}
+			case crossMidnight || minutes < nightEnd:
+				return MarketStatus{Status: "trading", StatusText: i18n.T("market.status.night"), IsTradeDay: true}
+			}
+		}
+	}
+
+	if !isTradeDay {
+		return MarketStatus{
+			Status:      "closed",
+			StatusText:  closedStatusText(now, holidayName),
+			IsTradeDay:  false,
+			HolidayName: i18n.Holiday(holidayName),
+		}
+	}
+	for _, s := range futuresDaySessions(p) {
+		if minutes >= s.start && minutes < s.end {
+			return MarketStatus{Status: s.status, StatusText: i18n.T(s.text), IsTradeDay: true}
+		}
+	}
+	return MarketStatus{Status: "closed", StatusText: i18n.T("market.status.closed"), IsTradeDay: true}
+}
+
+// hasNightSession 指定交易日晚间是否有夜盘：节假日前最后一个交易日不开夜盘，普通周五照常
+func (ms *MarketService) hasNightSession(date time.Time) bool {
+	if ok, _ := ms.isTradeDay(date); !ok {
+		return false
+	}
+	next := date.AddDate(0, 0, 1)
+	if date.Weekday() == time.Friday {
+		next = date.AddDate(0, 0, 3)
+	}
+	ok, _ := ms.isTradeDay(next)
+	return ok
+}
+
+// clockMinutes HH:MM 转换为当日分钟数
+func clockMinutes(clock string) int {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0
+	}
+	return t.Hour()*60 + t.Minute()
+}
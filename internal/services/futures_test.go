@@ -0,0 +1,62 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFuturesQuotesAndStatus(t *testing.T) {
+	data := `var hq_str_s_sh000300="沪深300,3600.00,10.00,0.28,100,200";
+var hq_str_nf_IF0="3606.000,3620.800,3598.000,3610.000,60712,65782003.000,156786.000,3611.800,0.000,3984.600,3260.200,0.000,0.000,3600.000,3605.000,153541.000,3611.800,7,3611.600,1,3612.000,4,0,0,0,0,0,0,0,0,0,0,0,0,0,0,2024-05-31,15:00:00,0,0,0,0,0,0,0,0,0,0,0,IF主连";
+var hq_str_nf_RB0="螺纹钢连续,145959,3611.000,3640.000,3596.000,3606.000,3623.000,3624.000,3623.000,3617.000,3600.000,1,95,1680085.000,1437085,沪,螺纹钢,2024-05-31,1";`
+	quotes := parseFuturesQuotes(data)
+	if len(quotes) != 2 {
+		t.Fatalf("quotes = %+v", quotes)
+	}
+	if q := quotes[0]; q.Product != "IF" || q.Price != 3610 || q.PreSettle != 3605 || q.SpotPrice != 3600 || q.Basis != 10 || q.Name != "IF主连" {
+		t.Errorf("IF = %+v", q)
+	}
+	if q := quotes[1]; q.Product != "RB" || q.Price != 3623 || q.Change != 23 || q.Volume != 1437085 || q.Time != "14:59:59" || q.Basis != 0 {
+		t.Errorf("RB = %+v", q)
+	}
+	if _, ok := futuresProductOf("nf_AU2412"); !ok {
+		t.Error("nf_AU2412 应识别为黄金期货")
+	}
+
+	// 2030 年使用空节假日表：工作日均为交易日
+	holidayCacheMu.Lock()
+	holidayCacheData[2030] = map[string]bool{}
+	holidayCacheMu.Unlock()
+	defer func() {
+		holidayCacheMu.Lock()
+		delete(holidayCacheData, 2030)
+		holidayCacheMu.Unlock()
+	}()
+
+	ms := &MarketService{}
+	cst := time.FixedZone("CST", 8*60*60)
+	at := func(day, hour, minute int) time.Time { return time.Date(2030, 3, day, hour, minute, 0, 0, cst) }
+	ifp, _ := futuresProductOf("nf_IF0")
+	rb, _ := futuresProductOf("nf_RB0")
+	cu, _ := futuresProductOf("nf_CU0")
+	cases := []struct {
+		name string
+		p    futuresProduct
+		now  time.Time
+		want string
+	}{
+		{"股指日盘", ifp, at(15, 10, 20), "trading"},
+		{"股指无夜盘", ifp, at(15, 21, 30), "closed"},
+		{"商品小节休息", rb, at(15, 10, 20), "lunch_break"},
+		{"商品下午开盘前", rb, at(15, 13, 15), "lunch_break"},
+		{"周五夜盘", rb, at(15, 21, 30), "trading"},
+		{"夜盘收盘后", rb, at(15, 23, 30), "closed"},
+		{"周六凌晨跨午夜夜盘", cu, at(16, 0, 30), "trading"},
+		{"周六凌晨夜盘结束", cu, at(16, 1, 30), "closed"},
+	}
+	for _, c := range cases {
+		if got := ms.futuresMarketStatus(c.now, c.p).Status; got != c.want {
+			t.Errorf("%s: status = %s, want %s", c.name, got, c.want)
+		}
+	}
+}
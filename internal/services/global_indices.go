@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// globalMarket 海外市场交易时段
+// 使用固定时区偏移并手动计算夏令时，避免 Windows 缺少时区数据库的问题
+type globalMarket struct {
+	name     string
+	offset   int                  // 标准时 UTC 偏移(小时)
+	dst      func(time.Time) bool // 是否处于夏令时，nil 表示不实行夏令时
+	sessions [][2]int             // 当地交易时段（分钟数，左闭右开）
+}
+
+// globalMarkets 各市场交易时段（当地时间）
+var globalMarkets = map[string]globalMarket{
+	"us": {name: "美股", offset: -5, dst: usDST, sessions: [][2]int{{9*60 + 30, 16 * 60}}},
+	"uk": {name: "英股", offset: 0, dst: euDST, sessions: [][2]int{{8 * 60, 16*60 + 30}}},
+	"de": {name: "德股", offset: 1, dst: euDST, sessions: [][2]int{{9 * 60, 17*60 + 30}}},
+	"hk": {name: "港股", offset: 8, sessions: [][2]int{{9*60 + 30, 12 * 60}, {13 * 60, 16 * 60}}},
+	"jp": {name: "日股", offset: 9, sessions: [][2]int{{9 * 60, 11*60 + 30}, {12*60 + 30, 15*60 + 30}}},
+}
+
+// globalIndexCodes 全球指数（新浪 int_ 行情），按美洲、欧洲、亚洲排列
+var globalIndexCodes = []struct{ code, region string }{
+	{"int_sp500", "us"},
+	{"int_nasdaq", "us"},
+	{"int_dji", "us"},
+	{"int_ftse", "uk"},
+	{"int_dax", "de"},
+	{"int_hangseng", "hk"},
+	{"int_nikkei", "jp"},
+}
+
+// GetGlobalIndices 获取全球主要指数及其当地交易状态
+func (ms *MarketService) GetGlobalIndices(ctx context.Context) ([]models.GlobalIndex, error) {
+	codes := make([]string, len(globalIndexCodes))
+	regions := make(map[string]string, len(globalIndexCodes))
+	for i, g := range globalIndexCodes {
+		codes[i] = g.code
+		regions[g.code] = g.region
+	}
+	indices, err := ms.GetMarketIndicesByCodes(ctx, codes)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result := make([]models.GlobalIndex, 0, len(indices))
+	for _, index := range indices {
+		region := regions[index.Code]
+		market, ok := globalMarkets[region]
+		if !ok {
+			continue
+		}
+		local := market.localTime(now)
+		result = append(result, models.GlobalIndex{
+			MarketIndex: index,
+			Region:      region,
+			Market:      market.name,
+			Status:      market.status(local),
+			LocalTime:   local.Format("15:04"),
+		})
+	}
+	return result, nil
+}
+
+// localTime 当地时间
+func (m globalMarket) localTime(now time.Time) time.Time {
+	offset := m.offset
+	if m.dst != nil && m.dst(now) {
+		offset++
+	}
+	return now.In(time.FixedZone(m.name, offset*60*60))
+}
+
+// status 按当地时间判断交易状态（周末休市，不含当地节假日）
+func (m globalMarket) status(local time.Time) string {
+	if local.Weekday() == time.Saturday || local.Weekday() == time.Sunday {
+		return "closed"
+	}
+	minutes := local.Hour()*60 + local.Minute()
+	for i, s := range m.sessions {
+		if minutes >= s[0] && minutes < s[1] {
+			return "trading"
+		}
+		if i > 0 && minutes >= m.sessions[i-1][1] && minutes < s[0] {
+			return "lunch_break"
+		}
+	}
+	return "closed"
+}
+
+// usDST 美国夏令时：3 月第二个周日 2:00 至 11 月第一个周日 2:00（当地时间）
+func usDST(t time.Time) bool {
+	t = t.UTC()
+	start := nthSunday(t.Year(), time.March, 2).Add(7 * time.Hour)  // 2:00 EST
+	end := nthSunday(t.Year(), time.November, 1).Add(6 * time.Hour) // 2:00 EDT
+	return !t.Before(start) && t.Before(end)
+}
+
+// euDST 欧洲夏令时：3 月最后一个周日至 10 月最后一个周日，均为 1:00 UTC 切换
+func euDST(t time.Time) bool {
+	t = t.UTC()
+	start := nthSunday(t.Year(), time.March, -1).Add(time.Hour)
+	end := nthSunday(t.Year(), time.October, -1).Add(time.Hour)
+	return !t.Before(start) && t.Before(end)
+}
+
+// nthSunday 某月第 n 个周日（UTC 零点），n 为 -1 时表示最后一个周日
+func nthSunday(year int, month time.Month, n int) time.Time {
+	if n < 0 {
+		last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+		return last.AddDate(0, 0, -int(last.Weekday()))
+	}
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	offset := (7 - int(first.Weekday())) % 7
+	return first.AddDate(0, 0, offset+(n-1)*7)
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGlobalMarketStatus(t *testing.T) {
+	if d := nthSunday(2024, time.March, 2); d.Day() != 10 {
+		t.Errorf("2024年3月第二个周日 = %v", d)
+	}
+	if d := nthSunday(2024, time.October, -1); d.Day() != 27 {
+		t.Errorf("2024年10月最后一个周日 = %v", d)
+	}
+
+	cases := []struct {
+		region string
+		utc    time.Time
+		want   string
+	}{
+		{"us", time.Date(2024, 7, 1, 14, 0, 0, 0, time.UTC), "trading"},     // 夏令时 10:00
+		{"us", time.Date(2024, 1, 2, 14, 0, 0, 0, time.UTC), "closed"},      // 冬令时 09:00
+		{"hk", time.Date(2024, 7, 1, 4, 30, 0, 0, time.UTC), "lunch_break"}, // 12:30
+		{"jp", time.Date(2024, 7, 6, 1, 0, 0, 0, time.UTC), "closed"},       // 周六
+		{"de", time.Date(2024, 7, 1, 15, 0, 0, 0, time.UTC), "trading"},     // 夏令时 17:00
+	}
+	for _, c := range cases {
+		m := globalMarkets[c.region]
+		if got := m.status(m.localTime(c.utc)); got != c.want {
+			t.Errorf("%s %v: status = %s, want %s", c.region, c.utc, got, c.want)
+		}
+	}
+}
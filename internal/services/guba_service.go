@@ -0,0 +1,217 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/logger"
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+	"github.com/run-bigpig/jcp/internal/pkg/proxy"
+)
+
+var gubaLog = logger.New("guba")
+
+// 东方财富股吧列表页（f_ 按发帖时间排序），页面内嵌 article_list JSON
+const gubaListURL = "https://guba.eastmoney.com/list,%s,f_%d.html"
+
+const (
+	gubaPagesPerFetch = 3                // 每次抓取的页数（每页约80帖）
+	gubaRequestGap    = 1 * time.Second  // 请求最小间隔，避免触发风控
+	gubaCacheTTL      = 30 * time.Minute // 关注度数据缓存时间
+	gubaLatestPosts   = 20               // 返回的最新帖子数
+)
+
+var gubaArticleListRegex = regexp.MustCompile(`var article_list\s*=\s*(\{.*?\});\s*(?:var |</script>)`)
+
+// gubaCache 股吧关注度缓存
+type gubaCache struct {
+	data      *models.GubaSentiment
+	timestamp time.Time
+}
+
+// GubaService 股吧关注度服务（只读抓取，全局限流）
+type GubaService struct {
+	client *http.Client
+
+	cache   map[string]*gubaCache
+	cacheMu sync.RWMutex
+
+	// 串行化请求并保证最小间隔
+	throttleMu  sync.Mutex
+	lastRequest time.Time
+}
+
+// NewGubaService 创建股吧关注度服务
+func NewGubaService() *GubaService {
+	return &GubaService{
+		client: proxy.GetManager().GetClientWithTimeout(15 * time.Second),
+		cache:  make(map[string]*gubaCache),
+	}
+}
+
+// GetSentiment 获取个股股吧发帖量趋势与最新帖子
+// code: 股票代码，支持 sh600519 / 600519
+func (s *GubaService) GetSentiment(code string) (*models.GubaSentiment, error) {
+	code = strings.TrimPrefix(strings.TrimPrefix(strings.ToLower(code), "sh"), "sz")
+	code = strings.TrimPrefix(code, "bj")
+	if code == "" {
+		return nil, i18n.Errorf("error.symbol.required")
+	}
+
+	s.cacheMu.RLock()
+	if cached, ok := s.cache[code]; ok && time.Since(cached.timestamp) < gubaCacheTTL {
+		s.cacheMu.RUnlock()
+		return cached.data, nil
+	}
+	s.cacheMu.RUnlock()
+
+	var posts []models.GubaPost
+	var total int
+	for page := 1; page <= gubaPagesPerFetch; page++ {
+		pagePosts, count, err := s.fetchPage(code, page)
+		if err != nil {
+			if page == 1 {
+				return nil, err
+			}
+			gubaLog.Warn("抓取股吧第%d页失败: %v", page, err)
+			break
+		}
+		if page == 1 {
+			total = count
+		}
+		posts = append(posts, pagePosts...)
+		if len(pagePosts) == 0 {
+			break
+		}
+	}
+
+	result := buildGubaSentiment(code, total, posts)
+	result.UpdatedAt = time.Now().UnixMilli()
+
+	s.cacheMu.Lock()
+	s.cache[code] = &gubaCache{data: result, timestamp: time.Now()}
+	s.cacheMu.Unlock()
+
+	return result, nil
+}
+
+// throttle 等待至距离上次请求满足最小间隔
+func (s *GubaService) throttle() {
+	if wait := gubaRequestGap - time.Since(s.lastRequest); wait > 0 {
+		time.Sleep(wait)
+	}
+	s.lastRequest = time.Now()
+}
+
+// fetchPage 抓取股吧列表单页
+func (s *GubaService) fetchPage(code string, page int) ([]models.GubaPost, int, error) {
+	s.throttleMu.Lock()
+	s.throttle()
+	s.throttleMu.Unlock()
+
+	req, err := http.NewRequest("GET", fmt.Sprintf(gubaListURL, code, page), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
+	req.Header.Set("Referer", "https://guba.eastmoney.com/")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("股吧请求失败: HTTP %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+	return parseGubaPage(string(body))
+}
+
+// gubaArticleList 股吧页面内嵌的帖子列表结构
+type gubaArticleList struct {
+	Count int `json:"count"`
+	Re    []struct {
+		PostID           json.Number `json:"post_id"`
+		PostTitle        string      `json:"post_title"`
+		PostPublishTime  string      `json:"post_publish_time"`
+		PostClickCount   int         `json:"post_click_count"`
+		PostCommentCount int         `json:"post_comment_count"`
+	} `json:"re"`
+}
+
+// parseGubaPage 从股吧列表页 HTML 中提取帖子
+func parseGubaPage(html string) ([]models.GubaPost, int, error) {
+	m := gubaArticleListRegex.FindStringSubmatch(html)
+	if m == nil {
+		return nil, 0, fmt.Errorf("未找到股吧帖子数据")
+	}
+
+	var list gubaArticleList
+	if err := json.Unmarshal([]byte(m[1]), &list); err != nil {
+		return nil, 0, fmt.Errorf("解析股吧帖子失败: %w", err)
+	}
+
+	posts := make([]models.GubaPost, 0, len(list.Re))
+	for _, item := range list.Re {
+		posts = append(posts, models.GubaPost{
+			ID:          item.PostID.String(),
+			Title:       item.PostTitle,
+			PublishTime: item.PostPublishTime,
+			ClickCount:  item.PostClickCount,
+			ReplyCount:  item.PostCommentCount,
+		})
+	}
+	return posts, list.Count, nil
+}
+
+// buildGubaSentiment 按发帖日期统计每日发帖量
+func buildGubaSentiment(code string, total int, posts []models.GubaPost) *models.GubaSentiment {
+	daily := make(map[string]int)
+	for _, p := range posts {
+		if len(p.PublishTime) < 10 {
+			continue
+		}
+		daily[p.PublishTime[:10]]++
+	}
+
+	trend := make([]models.GubaDailyCount, 0, len(daily))
+	for date, n := range daily {
+		trend = append(trend, models.GubaDailyCount{Date: date, Posts: n})
+	}
+	sort.Slice(trend, func(i, j int) bool {
+		return trend[i].Date < trend[j].Date
+	})
+	// 抓取窗口的最早一天通常不完整，多于一天时丢弃
+	if len(trend) > 1 {
+		trend = trend[1:]
+	}
+
+	latest := posts
+	if len(latest) > gubaLatestPosts {
+		latest = latest[:gubaLatestPosts]
+	}
+	if latest == nil {
+		latest = []models.GubaPost{}
+	}
+
+	return &models.GubaSentiment{
+		Code:       code,
+		TotalPosts: total,
+		Trend:      trend,
+		Latest:     latest,
+	}
+}
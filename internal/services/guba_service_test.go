@@ -0,0 +1,68 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// TestParseGubaPage 测试从股吧列表页内嵌脚本中提取帖子
+func TestParseGubaPage(t *testing.T) {
+	html := `<html><head><script>var pageConfig = {};
+var article_list={"re":[{"post_id":1403258865,"post_title":"茅台又要涨了？","post_publish_time":"2026-02-09 14:31:02","post_click_count":1520,"post_comment_count":12},{"post_id":"1403258001","post_title":"年报预告","post_publish_time":"2026-02-08 09:15:40","post_click_count":88,"post_comment_count":0}],"count":215034};
+var other_list = [];</script></head></html>`
+	posts, total, err := parseGubaPage(html)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 215034 || len(posts) != 2 {
+		t.Fatalf("total = %d, posts = %+v", total, posts)
+	}
+	if posts[0].ID != "1403258865" || posts[0].Title != "茅台又要涨了？" || posts[0].ClickCount != 1520 || posts[0].ReplyCount != 12 {
+		t.Errorf("posts[0] = %+v", posts[0])
+	}
+	if posts[1].ID != "1403258001" || posts[1].PublishTime != "2026-02-08 09:15:40" {
+		t.Errorf("字符串 post_id 也应解析: %+v", posts[1])
+	}
+
+	// 页面结构以 </script> 结束脚本
+	if posts, _, err := parseGubaPage(`<script>var article_list = {"re":[],"count":0};</script>`); err != nil || len(posts) != 0 {
+		t.Errorf("空列表 = %+v, %v", posts, err)
+	}
+	if _, _, err := parseGubaPage(`<html>访问过于频繁</html>`); err == nil {
+		t.Error("缺少帖子数据应返回错误")
+	}
+}
+
+// TestBuildGubaSentiment 测试按日统计发帖量、丢弃不完整的首日并截取最新帖子
+func TestBuildGubaSentiment(t *testing.T) {
+	var posts []models.GubaPost
+	for i := range 25 {
+		day := "2026-02-09"
+		if i >= 15 {
+			day = "2026-02-08"
+		}
+		if i >= 22 {
+			day = "2026-02-07"
+		}
+		posts = append(posts, models.GubaPost{ID: fmt.Sprint(i), PublishTime: day + " 10:00:00"})
+	}
+	posts = append(posts, models.GubaPost{ID: "bad", PublishTime: "刚刚"})
+
+	s := buildGubaSentiment("600519", 1000, posts)
+	if s.Code != "600519" || s.TotalPosts != 1000 {
+		t.Errorf("sentiment = %+v", s)
+	}
+	want := []models.GubaDailyCount{{Date: "2026-02-08", Posts: 7}, {Date: "2026-02-09", Posts: 15}}
+	if len(s.Trend) != len(want) || s.Trend[0] != want[0] || s.Trend[1] != want[1] {
+		t.Errorf("Trend = %+v, want %+v", s.Trend, want)
+	}
+	if len(s.Latest) != gubaLatestPosts || s.Latest[0].ID != "0" {
+		t.Errorf("Latest = %d 条", len(s.Latest))
+	}
+
+	if empty := buildGubaSentiment("600519", 0, nil); empty.Latest == nil || len(empty.Trend) != 0 {
+		t.Errorf("无帖子时应返回空列表: %+v", empty)
+	}
+}
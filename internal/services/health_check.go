@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/diagnostics"
+	"github.com/run-bigpig/jcp/internal/pkg/proxy"
+)
+
+// UpstreamEndpoints 启动自检时检查连通性的上游数据源
+var UpstreamEndpoints = []diagnostics.Endpoint{
+	{Name: "新浪行情", URL: "http://hq.sinajs.cn"},
+	{Name: "东方财富行情", URL: "https://push2.eastmoney.com"},
+	{Name: "东方财富K线", URL: "https://push2his.eastmoney.com"},
+	{Name: "东方财富数据中心", URL: "https://data.eastmoney.com"},
+	{Name: "财联社快讯", URL: "https://www.cls.cn"},
+	{Name: "GitHub（软件更新）", URL: "https://api.github.com"},
+}
+
+// healthCheckTimeout 单项网络检查超时
+const healthCheckTimeout = 8 * time.Second
+
+// RunHealthCheck 启动自检：上游连通性、代理、数据目录可写与 AI 模型配置
+// AI 配置只做静态检查，不发起模型请求
+func RunHealthCheck(ctx context.Context, cfg *models.AppConfig, dataDir string) diagnostics.Report {
+	client := proxy.GetManager().GetClientWithTimeout(healthCheckTimeout)
+	var checks []diagnostics.Check
+	checks = append(checks, checkProxy(ctx, cfg.Proxy))
+	checks = append(checks, diagnostics.CheckEndpoints(ctx, client, UpstreamEndpoints)...)
+	checks = append(checks,
+		diagnostics.CheckWritable("数据目录", dataDir),
+		diagnostics.CheckWritable("缓存目录", filepath.Join(dataDir, "cache")),
+		diagnostics.CheckWritable("日志目录", filepath.Join(dataDir, "logs")),
+	)
+	checks = append(checks, checkAIConfigs(cfg)...)
+	return diagnostics.NewReport(len(cfg.AIConfigs) == 0, checks)
+}
+
+// checkProxy 按当前代理配置解析行情请求使用的代理，并检查代理服务器能否连接
+func checkProxy(ctx context.Context, cfg models.ProxyConfig) diagnostics.Check {
+	c := diagnostics.Check{Category: diagnostics.CategoryProxy, Name: "代理"}
+	if cfg.Mode == "" || cfg.Mode == models.ProxyModeNone {
+		c.Status, c.Message = diagnostics.StatusOK, "直连"
+		return c
+	}
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, UpstreamEndpoints[1].URL, nil)
+	transport := proxy.GetManager().GetTransport()
+	var proxyURL *url.URL
+	var err error
+	if transport.Proxy != nil {
+		proxyURL, err = transport.Proxy(req)
+	}
+	switch {
+	case err != nil:
+		c.Status, c.Message = diagnostics.StatusFail, fmt.Sprintf("解析代理失败: %v", err)
+		return c
+	case proxyURL == nil:
+		c.Status, c.Message = diagnostics.StatusOK, fmt.Sprintf("%s 模式，行情请求直连", cfg.Mode)
+		return c
+	}
+
+	host := proxyURL.Host
+	if proxyURL.Port() == "" {
+		port := map[string]string{"https": "443", "socks5": "1080", "socks5h": "1080"}[proxyURL.Scheme]
+		if port == "" {
+			port = "80"
+		}
+		host = net.JoinHostPort(proxyURL.Hostname(), port)
+	}
+	dialer := net.Dialer{Timeout: 3 * time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", host)
+	if err != nil {
+		c.Status, c.Message = diagnostics.StatusFail, fmt.Sprintf("无法连接代理 %s: %v", proxyURL.Host, err)
+		return c
+	}
+	conn.Close()
+	c.Status, c.Message = diagnostics.StatusOK, fmt.Sprintf("%s 模式，经由 %s", cfg.Mode, proxyURL.Host)
+	return c
+}
+
+// checkAIConfigs 检查 AI 模型配置是否完整（API Key、地址、模型名）
+func checkAIConfigs(cfg *models.AppConfig) []diagnostics.Check {
+	if len(cfg.AIConfigs) == 0 {
+		return []diagnostics.Check{{
+			Category: diagnostics.CategoryLLM, Name: "AI 模型",
+			Status: diagnostics.StatusWarn, Message: "尚未配置 AI 模型，专家讨论与策略生成不可用",
+		}}
+	}
+	checks := make([]diagnostics.Check, 0, len(cfg.AIConfigs))
+	for _, ai := range cfg.AIConfigs {
+		c := diagnostics.Check{Category: diagnostics.CategoryLLM, Name: ai.Name, Status: diagnostics.StatusOK}
+		if ai.ID == cfg.DefaultAIID {
+			c.Message = "默认模型"
+		}
+		switch {
+		case ai.ModelName == "":
+			c.Status, c.Message = diagnostics.StatusFail, "未填写模型名称"
+		case ai.Provider == models.AIProviderVertexAI && ai.CredentialsJSON == "":
+			c.Status, c.Message = diagnostics.StatusWarn, "未填写服务账号凭据，将使用默认应用凭据"
+		case ai.Provider != models.AIProviderOllama && ai.Provider != models.AIProviderVertexAI && ai.APIKey == "":
+			c.Status, c.Message = diagnostics.StatusFail, "未填写 API Key"
+		case ai.BaseURL != "":
+			if u, err := url.Parse(ai.BaseURL); err != nil || u.Host == "" {
+				c.Status, c.Message = diagnostics.StatusFail, "API 地址无效"
+			}
+		}
+		checks = append(checks, c)
+	}
+	return checks
+}
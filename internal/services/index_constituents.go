@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+)
+
+// 东方财富行情列表API（fltt=2 返回浮点数，缺失值为 "-"）
+// 字段: f2最新价, f3涨跌幅, f12代码, f13市场(0深 1沪), f14名称, f18昨收, f21流通市值
+const emClistURL = "https://push2.eastmoney.com/api/qt/clist/get?pn=1&pz=%d&po=1&np=1&fltt=2&invt=2&fid=f21&fs=%s&fields=f2,f3,f12,f13,f14,f18,f21,f100"
+
+const indexConstituentsCacheTTL = 10 * time.Second
+
+// 指数代码 -> 东方财富成分股筛选条件
+var indexConstituentFilters = map[string]string{
+	"sh000001": "m:1+t:2,m:1+t:23", // 上证指数：沪市全部A股（含科创板）
+	"sz399006": "b:BK0638",         // 创业板指
+	"sh000300": "b:BK0500",         // 沪深300
+	"sh000016": "b:BK0611",         // 上证50
+	"sh000905": "b:BK0701",         // 中证500
+}
+
+// indexConstituentsCache 成分股缓存
+type indexConstituentsCache struct {
+	data      []models.IndexConstituent
+	timestamp time.Time
+}
+
+// emClistResponse 东方财富列表API响应结构
+type emClistResponse struct {
+	Data *struct {
+		Total int           `json:"total"`
+		Diff  []emClistItem `json:"diff"`
+	} `json:"data"`
+}
+
+type emClistItem struct {
+	Price         any    `json:"f2"`
+	ChangePercent any    `json:"f3"`
+	Code          string `json:"f12"`
+	Market        int    `json:"f13"`
+	Name          string `json:"f14"`
+	PreClose      any    `json:"f18"`
+	FloatCap      any    `json:"f21"`
+	Industry      any    `json:"f100"` // 所属行业，缺失时为 "-"
+}
+
+// emFloat 解析东方财富数值字段（缺失时为 "-"）
+func emFloat(v any) float64 {
+	if f, ok := v.(float64); ok {
+		return f
+	}
+	return 0
+}
+
+// GetIndexConstituents 获取指数成分股及其对指数的涨跌贡献
+// indexCode: 指数代码，如 sh000001、sz399006
+// 权重按流通市值占比近似，贡献点数 = 指数昨收 × 权重 × 个股涨跌幅
+func (ms *MarketService) GetIndexConstituents(ctx context.Context, indexCode string) ([]models.IndexConstituent, error) {
+	indexCode = strings.TrimPrefix(indexCode, "s_")
+	filter, ok := indexConstituentFilters[indexCode]
+	if !ok {
+		return nil, i18n.Errorf("error.index.unsupported", indexCode)
+	}
+
+	// 检查缓存
+	ms.indexConsCacheMu.RLock()
+	if cached, ok := ms.indexConsCache[indexCode]; ok && time.Since(cached.timestamp) < indexConstituentsCacheTTL {
+		ms.indexConsCacheMu.RUnlock()
+		return cached.data, nil
+	}
+	ms.indexConsCacheMu.RUnlock()
+
+	items, err := ms.fetchEastmoneyClist(ctx, filter, 5000)
+	if err != nil {
+		return nil, err
+	}
+
+	// 指数昨收点位，用于把权重贡献换算成点数
+	var indexPreClose float64
+	if indices, err := ms.getIndicesByCodes(ctx, []string{indexCode}); err == nil && len(indices) > 0 {
+		indexPreClose = indices[0].Price - indices[0].Change
+	}
+
+	result := calculateIndexContribution(items, indexPreClose)
+
+	ms.indexConsCacheMu.Lock()
+	ms.indexConsCache[indexCode] = &indexConstituentsCache{
+		data:      result,
+		timestamp: time.Now(),
+	}
+	ms.indexConsCacheMu.Unlock()
+
+	return result, nil
+}
+
+// fetchEastmoneyClist 从东方财富获取行情列表（成分股、全市场等）
+func (ms *MarketService) fetchEastmoneyClist(ctx context.Context, filter string, pageSize int) ([]emClistItem, error) {
+	url := fmt.Sprintf(emClistURL, pageSize, filter)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
+	req.Header.Set("Referer", "https://quote.eastmoney.com/")
+
+	resp, err := ms.do(SourceEastmoney, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result emClistResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("解析行情列表失败: %w", err)
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("未获取到行情列表数据")
+	}
+	return result.Data.Diff, nil
+}
+
+// calculateIndexContribution 计算成分股权重与贡献，按贡献点数降序排列
+func calculateIndexContribution(items []emClistItem, indexPreClose float64) []models.IndexConstituent {
+	var totalCap float64
+	for _, item := range items {
+		totalCap += emFloat(item.FloatCap)
+	}
+
+	result := make([]models.IndexConstituent, 0, len(items))
+	for _, item := range items {
+		prefix := "sz"
+		if item.Market == 1 {
+			prefix = "sh"
+		}
+		floatCap := emFloat(item.FloatCap)
+		changePercent := emFloat(item.ChangePercent)
+
+		var weight float64
+		if totalCap > 0 {
+			weight = floatCap / totalCap * 100
+		}
+		contribution := weight * changePercent / 100 // 对指数涨跌幅的贡献(%)
+
+		result = append(result, models.IndexConstituent{
+			Symbol:             prefix + item.Code,
+			Name:               item.Name,
+			Price:              emFloat(item.Price),
+			PreClose:           emFloat(item.PreClose),
+			ChangePercent:      changePercent,
+			FloatCap:           floatCap,
+			Weight:             weight,
+			Contribution:       contribution,
+			ContributionPoints: indexPreClose * contribution / 100,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Contribution > result[j].Contribution
+	})
+	return result
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"testing"
+)
+
+// TestCalculateIndexContribution 测试成分股权重与贡献计算
+func TestCalculateIndexContribution(t *testing.T) {
+	items := []emClistItem{
+		{Code: "600519", Market: 1, Name: "贵州茅台", ChangePercent: 2.0, FloatCap: 300.0},
+		{Code: "000001", Market: 0, Name: "平安银行", ChangePercent: -1.0, FloatCap: 100.0},
+		{Code: "600000", Market: 1, Name: "浦发银行", ChangePercent: "-", FloatCap: "-"},
+	}
+
+	result := calculateIndexContribution(items, 3000)
+	if len(result) != 3 {
+		t.Fatalf("期望3只成分股，实际 %d 只", len(result))
+	}
+
+	top := result[0]
+	if top.Symbol != "sh600519" {
+		t.Errorf("贡献最大的应为 sh600519，实际 %s", top.Symbol)
+	}
+	if top.Weight != 75 {
+		t.Errorf("权重计算错误: %.2f", top.Weight)
+	}
+	if top.Contribution != 1.5 || top.ContributionPoints != 45 {
+		t.Errorf("贡献计算错误: %.2f%% / %.2f点", top.Contribution, top.ContributionPoints)
+	}
+
+	last := result[len(result)-1]
+	if last.Symbol != "sz000001" || last.Contribution >= 0 {
+		t.Errorf("拖累最大的应为 sz000001，实际 %s (%.2f)", last.Symbol, last.Contribution)
+	}
+}
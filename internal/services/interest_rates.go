@@ -0,0 +1,227 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+const (
+	// 东方财富 SHIBOR（按日期降序，每个交易日 8 个期限）
+	shiborURL = "https://datacenter-web.eastmoney.com/api/data/v1/get?reportName=RPT_IMP_INTRESTRATEN&columns=REPORT_DATE,IR_RATE,CHANGE_RATE,INDICATOR_ID&filter=(MARKET_CODE%3D%22001%22)(CURRENCY_CODE%3D%22CNY%22)&sortColumns=REPORT_DATE&sortTypes=-1&pageNumber=1&pageSize=16&source=WEB&client=WEB"
+	// 东方财富 10 年期国债收益率：f43 最新、f60 昨收（按 f152 位小数放大），f86 更新时间(秒)
+	treasuryYieldURL = "https://push2.eastmoney.com/api/qt/stock/get?secid=171.CN10Y&fields=f43,f60,f86,f152"
+
+	interestRatesCacheTTL = 60 * time.Second
+)
+
+// repoRateCodes 国债逆回购代码与名称（行情缺失名称时使用）
+var repoRateCodes = []struct{ code, name string }{
+	{"sh204001", "GC001"},
+	{"sz131810", "R-001"},
+}
+
+// shiborTenors SHIBOR 期限，按期限由短到长
+var shiborTenors = []struct{ id, code, name string }{
+	{"001", "shibor_on", "隔夜"},
+	{"101", "shibor_1w", "1周"},
+	{"102", "shibor_2w", "2周"},
+	{"201", "shibor_1m", "1个月"},
+	{"203", "shibor_3m", "3个月"},
+	{"206", "shibor_6m", "6个月"},
+	{"209", "shibor_9m", "9个月"},
+	{"301", "shibor_1y", "1年"},
+}
+
+// interestRatesCache 资金面利率缓存
+type interestRatesCache struct {
+	data      *models.InterestRates
+	timestamp time.Time
+}
+
+// GetInterestRates 获取国债逆回购、SHIBOR 与 10 年期国债收益率
+// 各来源相互独立，部分失败时返回其余数据，全部失败才返回错误
+func (ms *MarketService) GetInterestRates(ctx context.Context) (*models.InterestRates, error) {
+	ms.ratesCacheMu.RLock()
+	if ms.ratesCache != nil && time.Since(ms.ratesCache.timestamp) < interestRatesCacheTTL {
+		data := ms.ratesCache.data
+		ms.ratesCacheMu.RUnlock()
+		return data, nil
+	}
+	ms.ratesCacheMu.RUnlock()
+
+	var (
+		wg       sync.WaitGroup
+		repo     []models.InterestRate
+		shibor   []models.InterestRate
+		treasury []models.InterestRate
+		errs     [3]error
+	)
+	wg.Add(3)
+	go func() { defer wg.Done(); repo, errs[0] = ms.fetchRepoRates(ctx) }()
+	go func() { defer wg.Done(); shibor, errs[1] = ms.fetchShibor(ctx) }()
+	go func() { defer wg.Done(); treasury, errs[2] = ms.fetchTreasuryYield(ctx) }()
+	wg.Wait()
+
+	items := append(append(repo, shibor...), treasury...)
+	if len(items) == 0 {
+		for _, err := range errs {
+			if err != nil {
+				return nil, fmt.Errorf("获取利率数据失败: %w", err)
+			}
+		}
+		return nil, fmt.Errorf("未获取到利率数据")
+	}
+
+	rates := &models.InterestRates{Items: items, UpdatedAt: time.Now().UnixMilli()}
+	ms.ratesCacheMu.Lock()
+	ms.ratesCache = &interestRatesCache{data: rates, timestamp: time.Now()}
+	ms.ratesCacheMu.Unlock()
+	return rates, nil
+}
+
+// fetchRepoRates 国债逆回购年化利率（行情价格即利率，日期为空表示实时报价）
+func (ms *MarketService) fetchRepoRates(ctx context.Context) ([]models.InterestRate, error) {
+	codes := make([]string, len(repoRateCodes))
+	for i, r := range repoRateCodes {
+		codes[i] = r.code
+	}
+	stocks, err := ms.GetStockRealTimeData(ctx, codes...)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []models.InterestRate
+	for _, r := range repoRateCodes {
+		for _, s := range stocks {
+			if s.Symbol != r.code || s.Price <= 0 {
+				continue
+			}
+			name := strings.TrimSpace(s.Name)
+			if name == "" {
+				name = r.name
+			}
+			rate := models.InterestRate{Code: r.code, Name: name, Category: models.RateCategoryRepo, Rate: s.Price}
+			if s.PreClose > 0 {
+				rate.ChangeBP = basisPoints(s.Price - s.PreClose)
+			}
+			result = append(result, rate)
+		}
+	}
+	return result, nil
+}
+
+// fetchShibor 最新一个交易日的各期限 SHIBOR
+func (ms *MarketService) fetchShibor(ctx context.Context) ([]models.InterestRate, error) {
+	body, err := ms.getEastmoney(ctx, shiborURL, "https://data.eastmoney.com/")
+	if err != nil {
+		return nil, err
+	}
+	return parseShibor(body)
+}
+
+// parseShibor 解析 SHIBOR 响应，仅保留最新日期的报价并按期限排序
+func parseShibor(body []byte) ([]models.InterestRate, error) {
+	var resp struct {
+		Result *struct {
+			Data []struct {
+				Date      string  `json:"REPORT_DATE"`
+				Rate      float64 `json:"IR_RATE"`
+				Change    float64 `json:"CHANGE_RATE"` // 基点
+				Indicator string  `json:"INDICATOR_ID"`
+			} `json:"data"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("解析SHIBOR失败: %w", err)
+	}
+	if resp.Result == nil || len(resp.Result.Data) == 0 {
+		return nil, fmt.Errorf("未获取到SHIBOR数据")
+	}
+
+	latest := trimDate(resp.Result.Data[0].Date)
+	var result []models.InterestRate
+	for _, tenor := range shiborTenors {
+		for _, item := range resp.Result.Data {
+			if item.Indicator != tenor.id || trimDate(item.Date) != latest {
+				continue
+			}
+			result = append(result, models.InterestRate{
+				Code: tenor.code, Name: tenor.name, Category: models.RateCategoryShibor,
+				Rate: item.Rate, ChangeBP: item.Change, Date: latest,
+			})
+			break
+		}
+	}
+	return result, nil
+}
+
+// fetchTreasuryYield 10 年期国债到期收益率
+func (ms *MarketService) fetchTreasuryYield(ctx context.Context) ([]models.InterestRate, error) {
+	body, err := ms.getEastmoney(ctx, treasuryYieldURL, "https://quote.eastmoney.com/")
+	if err != nil {
+		return nil, err
+	}
+	return parseTreasuryYield(body)
+}
+
+// parseTreasuryYield 解析国债收益率行情
+func parseTreasuryYield(body []byte) ([]models.InterestRate, error) {
+	var resp struct {
+		Data *struct {
+			Price    any `json:"f43"`
+			PreClose any `json:"f60"`
+			Time     any `json:"f86"`
+			Decimals any `json:"f152"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("解析国债收益率失败: %w", err)
+	}
+	if resp.Data == nil || emFloat(resp.Data.Price) <= 0 {
+		return nil, fmt.Errorf("未获取到国债收益率数据")
+	}
+
+	scale := math.Pow(10, emFloat(resp.Data.Decimals))
+	rate := models.InterestRate{
+		Code: "cn10y", Name: "10年期国债", Category: models.RateCategoryTreasury,
+		Rate: emFloat(resp.Data.Price) / scale,
+	}
+	if preClose := emFloat(resp.Data.PreClose) / scale; preClose > 0 {
+		rate.ChangeBP = basisPoints(rate.Rate - preClose)
+	}
+	if ts := int64(emFloat(resp.Data.Time)); ts > 0 {
+		rate.Date = time.Unix(ts, 0).Format("2006-01-02")
+	}
+	return []models.InterestRate{rate}, nil
+}
+
+// getEastmoney 请求东方财富接口
+func (ms *MarketService) getEastmoney(ctx context.Context, url, referer string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
+	req.Header.Set("Referer", referer)
+
+	resp, err := ms.do(SourceEastmoney, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+// basisPoints 百分比差值换算为基点，保留两位小数
+func basisPoints(diff float64) float64 {
+	return math.Round(diff*100*100) / 100
+}
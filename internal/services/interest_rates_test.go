@@ -0,0 +1,31 @@
+package services
+
+import (
+	"testing"
+)
+
+func TestParseInterestRates(t *testing.T) {
+	shibor := `{"result":{"data":[
+		{"REPORT_DATE":"2024-06-04 00:00:00","IR_RATE":1.83,"CHANGE_RATE":2.1,"INDICATOR_ID":"203"},
+		{"REPORT_DATE":"2024-06-04 00:00:00","IR_RATE":1.75,"CHANGE_RATE":-0.5,"INDICATOR_ID":"001"},
+		{"REPORT_DATE":"2024-06-03 00:00:00","IR_RATE":1.755,"CHANGE_RATE":1,"INDICATOR_ID":"001"}
+	]}}`
+	rates, err := parseShibor([]byte(shibor))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rates) != 2 || rates[0].Code != "shibor_on" || rates[0].Rate != 1.75 || rates[0].Date != "2024-06-04" || rates[1].Code != "shibor_3m" {
+		t.Errorf("shibor = %+v", rates)
+	}
+
+	treasury, err := parseTreasuryYield([]byte(`{"data":{"f43":22950,"f60":23000,"f86":1717488000,"f152":4}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if treasury[0].Rate != 2.295 || treasury[0].ChangeBP != -0.5 {
+		t.Errorf("treasury = %+v", treasury[0])
+	}
+	if _, err := parseTreasuryYield([]byte(`{"data":null}`)); err == nil {
+		t.Error("无数据时应返回错误")
+	}
+}
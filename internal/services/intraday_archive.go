@@ -0,0 +1,339 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/logger"
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+	"github.com/run-bigpig/jcp/internal/pkg/sqlitedb"
+	"github.com/run-bigpig/jcp/internal/pkg/supervisor"
+)
+
+var intradayLog = logger.New("intraday")
+
+const (
+	intradayArchiveAfter     = 15*60 + 5 // 收盘后归档时间（分钟），留出尾盘数据落地时间
+	intradayArchiveInterval  = time.Minute
+	intradayArchiveKeepDays  = 365 // 归档保留天数
+	intradayArchiveFetchBars = 250 // 单日分时约 240 根
+)
+
+// intradayArchiveSchema 历史分时表，每根 1 分钟K线一行
+var intradayArchiveSchema = []string{
+	`CREATE TABLE IF NOT EXISTS intraday_bars (
+		code   TEXT NOT NULL,
+		date   TEXT NOT NULL,
+		time   TEXT NOT NULL,
+		open   REAL NOT NULL,
+		high   REAL NOT NULL,
+		low    REAL NOT NULL,
+		close  REAL NOT NULL,
+		volume INTEGER NOT NULL,
+		amount REAL NOT NULL,
+		avg    REAL NOT NULL,
+		PRIMARY KEY (code, date, time)
+	)`,
+	`CREATE INDEX IF NOT EXISTS intraday_bars_date ON intraday_bars(date)`,
+}
+
+// IntradayArchive 历史分时归档
+// 交易日收盘后把自选股当日的 1 分钟K线保存到 <dataDir>/intraday/intraday.db（SQLite），供复盘查看历史分时
+type IntradayArchive struct {
+	dir           string
+	marketService *MarketService
+	symbols       func() []string // 需归档的股票，默认为自选股
+	now           func() time.Time
+	tradeDay      func(time.Time) bool
+
+	dbMu sync.Mutex
+	db   *sql.DB
+
+	mu           sync.Mutex
+	lastArchived string                      // 最近一次完成归档的日期
+	integrity    models.KLineIntegrityStatus // 最近一次完整性检查结果
+}
+
+// NewIntradayArchive 创建历史分时归档
+func NewIntradayArchive(dataDir string, configService *ConfigService, marketService *MarketService) *IntradayArchive {
+	a := &IntradayArchive{
+		dir:           filepath.Join(dataDir, "intraday"),
+		marketService: marketService,
+		now:           time.Now,
+		integrity:     models.KLineIntegrityStatus{Gaps: []models.KLineGap{}},
+	}
+	if configService != nil {
+		a.symbols = func() []string {
+			watchlist := configService.GetWatchlist()
+			symbols := make([]string, 0, len(watchlist))
+			for _, stock := range watchlist {
+				symbols = append(symbols, stock.Symbol)
+			}
+			return symbols
+		}
+	}
+	if marketService != nil {
+		a.tradeDay = func(date time.Time) bool {
+			ok, _ := marketService.isTradeDay(date)
+			return ok
+		}
+	}
+	return a
+}
+
+// SetSymbolSource 设置需归档的股票来源（无自选股的远程引擎按客户端同步的列表归档）
+func (a *IntradayArchive) SetSymbolSource(fn func() []string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.symbols = fn
+}
+
+// archiveSymbols 获取需归档的股票
+func (a *IntradayArchive) archiveSymbols() []string {
+	a.mu.Lock()
+	fn := a.symbols
+	a.mu.Unlock()
+	if fn == nil {
+		return nil
+	}
+	return fn()
+}
+
+// open 打开数据库，首次打开时导入旧版按日期目录存储的 JSON 归档
+func (a *IntradayArchive) open() (*sql.DB, error) {
+	a.dbMu.Lock()
+	defer a.dbMu.Unlock()
+	if a.db != nil {
+		return a.db, nil
+	}
+	db, err := sqlitedb.Open(filepath.Join(a.dir, "intraday.db"), intradayArchiveSchema...)
+	if err != nil {
+		return nil, err
+	}
+	if err := importLegacyIntraday(db, a.dir); err != nil {
+		intradayLog.Warn("导入旧版历史分时归档失败: %v", err)
+	}
+	a.db = db
+	return db, nil
+}
+
+// Close 关闭数据库
+func (a *IntradayArchive) Close() error {
+	a.dbMu.Lock()
+	defer a.dbMu.Unlock()
+	if a.db == nil {
+		return nil
+	}
+	err := a.db.Close()
+	a.db = nil
+	return err
+}
+
+// Start 启动收盘归档检查
+func (a *IntradayArchive) Start(ctx context.Context) {
+	supervisor.Go(ctx, "intraday-archive", func(ctx context.Context) {
+		ticker := time.NewTicker(intradayArchiveInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				a.checkSchedule(ctx)
+			}
+		}
+	})
+}
+
+// checkSchedule 交易日收盘后归档一次
+func (a *IntradayArchive) checkSchedule(ctx context.Context) {
+	now := a.now().In(time.FixedZone("CST", 8*60*60))
+	today := now.Format("2006-01-02")
+	if now.Hour()*60+now.Minute() < intradayArchiveAfter {
+		return
+	}
+	a.mu.Lock()
+	done := a.lastArchived == today
+	a.mu.Unlock()
+	if done || !a.marketService.GetMarketStatus().IsTradeDay {
+		return
+	}
+	if err := a.ArchiveToday(ctx); err != nil {
+		intradayLog.Warn("历史分时归档失败: %v", err)
+		return
+	}
+	a.mu.Lock()
+	a.lastArchived = today
+	a.mu.Unlock()
+	a.prune(now)
+	a.CheckIntegrity(ctx)
+}
+
+// ArchiveToday 归档全部自选股当日分时，单只失败不影响其余股票
+func (a *IntradayArchive) ArchiveToday(ctx context.Context) error {
+	today := a.now().Format("2006-01-02")
+	var failed []string
+	for _, symbol := range a.archiveSymbols() {
+		klines, err := a.marketService.GetKLineData(ctx, symbol, "1m", intradayArchiveFetchBars)
+		if err != nil {
+			failed = append(failed, symbol)
+			continue
+		}
+		if err := a.Save(symbol, today, klines); err != nil {
+			return err
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%d 只股票获取分时失败: %s", len(failed), strings.Join(failed, ","))
+	}
+	return nil
+}
+
+// Save 保存某日分时，只保留属于该日期的K线并整体替换当日已有记录；没有当日数据（停牌）时跳过
+func (a *IntradayArchive) Save(code, date string, klines []models.KLineData) error {
+	bars := make([]models.KLineData, 0, len(klines))
+	for _, k := range klines {
+		if strings.HasPrefix(k.Time, date) {
+			bars = append(bars, k)
+		}
+	}
+	if len(bars) == 0 {
+		return nil
+	}
+	db, err := a.open()
+	if err != nil {
+		return err
+	}
+	inflightWrites.begin()
+	defer inflightWrites.done()
+	return sqlitedb.InTx(db, func(tx *sql.Tx) error {
+		return saveIntradayBars(tx, code, date, bars)
+	})
+}
+
+// saveIntradayBars 在事务中替换某只股票某日的分时
+func saveIntradayBars(tx *sql.Tx, code, date string, bars []models.KLineData) error {
+	if _, err := tx.Exec(`DELETE FROM intraday_bars WHERE code = ? AND date = ?`, code, date); err != nil {
+		return err
+	}
+	for _, k := range bars {
+		if _, err := tx.Exec(`INSERT OR REPLACE INTO intraday_bars(code, date, time, open, high, low, close, volume, amount, avg) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			code, date, k.Time, k.Open, k.High, k.Low, k.Close, k.Volume, k.Amount, k.Avg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Get 读取某日的归档分时，未归档时返回空列表
+func (a *IntradayArchive) Get(code, date string) ([]models.KLineData, error) {
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return nil, i18n.Errorf("error.date.invalid", date)
+	}
+	db, err := a.open()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.Query(`SELECT time, open, high, low, close, volume, amount, avg FROM intraday_bars
+		WHERE code = ? AND date = ? ORDER BY time`, code, date)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	klines := []models.KLineData{}
+	for rows.Next() {
+		var k models.KLineData
+		if err := rows.Scan(&k.Time, &k.Open, &k.High, &k.Low, &k.Close, &k.Volume, &k.Amount, &k.Avg); err != nil {
+			return nil, err
+		}
+		klines = append(klines, k)
+	}
+	return klines, rows.Err()
+}
+
+// Dates 获取指定股票已归档的日期，按日期倒序
+func (a *IntradayArchive) Dates(code string) []string {
+	dates := []string{}
+	db, err := a.open()
+	if err != nil {
+		intradayLog.Warn("打开历史分时数据库失败: %v", err)
+		return dates
+	}
+	rows, err := db.Query(`SELECT DISTINCT date FROM intraday_bars WHERE code = ? ORDER BY date DESC`, code)
+	if err != nil {
+		return dates
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var date string
+		if rows.Scan(&date) == nil {
+			dates = append(dates, date)
+		}
+	}
+	return dates
+}
+
+// prune 删除超过保留天数的归档
+func (a *IntradayArchive) prune(now time.Time) {
+	cutoff := now.AddDate(0, 0, -intradayArchiveKeepDays).Format("2006-01-02")
+	db, err := a.open()
+	if err != nil {
+		return
+	}
+	if _, err := db.Exec(`DELETE FROM intraday_bars WHERE date < ?`, cutoff); err != nil {
+		intradayLog.Warn("清理过期历史分时失败: %v", err)
+	}
+}
+
+// importLegacyIntraday 导入旧版 <dir>/2006-01-02/<code>.json 归档，导入成功的日期目录随后删除
+func importLegacyIntraday(db *sql.DB, dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	imported := 0
+	for _, e := range entries {
+		date := e.Name()
+		if !e.IsDir() {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			continue
+		}
+		files, _ := filepath.Glob(filepath.Join(dir, date, "*.json"))
+		if err := sqlitedb.InTx(db, func(tx *sql.Tx) error {
+			for _, path := range files {
+				data, err := os.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				var bars []models.KLineData
+				if err := json.Unmarshal(data, &bars); err != nil {
+					intradayLog.Warn("跳过无法解析的旧版分时文件 %s/%s: %v", date, filepath.Base(path), err)
+					continue
+				}
+				code := strings.TrimSuffix(filepath.Base(path), ".json")
+				if err := saveIntradayBars(tx, code, date, bars); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		os.RemoveAll(filepath.Join(dir, date))
+		imported++
+	}
+	if imported > 0 {
+		intradayLog.Info("已导入 %d 天旧版历史分时归档", imported)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+func TestIntradayArchive(t *testing.T) {
+	a := NewIntradayArchive(t.TempDir(), nil, nil)
+	t.Cleanup(func() { a.Close() })
+	klines := []models.KLineData{
+		{Time: "2026-02-06 14:59:00", Close: 9.9},
+		{Time: "2026-02-09 09:31:00", Close: 10.1},
+		{Time: "2026-02-09 09:32:00", Close: 10.2},
+	}
+	if err := a.Save("sh600000", "2026-02-09", klines); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Save("sh600000", "2026-02-10", klines); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := a.Get("sh600000", "2026-02-09")
+	if err != nil || len(got) != 2 || got[0].Close != 10.1 {
+		t.Fatalf("应只保留当日分时: %+v, %v", got, err)
+	}
+	if got, err := a.Get("sh600000", "2026-02-10"); err != nil || len(got) != 0 {
+		t.Errorf("无当日数据时不应归档: %+v, %v", got, err)
+	}
+	if _, err := a.Get("sh600000", "../x"); err == nil {
+		t.Error("非法日期应返回错误")
+	}
+	if dates := a.Dates("sh600000"); !slices.Equal(dates, []string{"2026-02-09"}) {
+		t.Errorf("dates = %v", dates)
+	}
+	if dates := a.Dates("sz000001"); len(dates) != 0 {
+		t.Errorf("未归档股票 dates = %v", dates)
+	}
+}
+
+func TestIntradayArchiveImportLegacy(t *testing.T) {
+	dataDir := t.TempDir()
+	legacy := filepath.Join(dataDir, "intraday", "2026-02-09")
+	if err := os.MkdirAll(legacy, 0755); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.Marshal([]models.KLineData{{Time: "2026-02-09 09:31:00", Close: 10.1, Volume: 100}})
+	if err := os.WriteFile(filepath.Join(legacy, "sh600000.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	a := NewIntradayArchive(dataDir, nil, nil)
+	t.Cleanup(func() { a.Close() })
+	got, err := a.Get("sh600000", "2026-02-09")
+	if err != nil || len(got) != 1 || got[0].Close != 10.1 || got[0].Volume != 100 {
+		t.Fatalf("应导入旧版分时: %+v, %v", got, err)
+	}
+	if _, err := os.Stat(legacy); !os.IsNotExist(err) {
+		t.Error("导入后应删除旧版目录")
+	}
+
+	a.prune(time.Date(2027, 3, 1, 0, 0, 0, 0, time.Local))
+	if dates := a.Dates("sh600000"); len(dates) != 0 {
+		t.Errorf("过期归档应被清理: %v", dates)
+	}
+}
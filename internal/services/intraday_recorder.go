@@ -0,0 +1,286 @@
+package services
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// 每只股票单日最多保留的盘口快照数（约4小时交易 × 每3秒一次）
+const maxOrderBookSnapshots = 5000
+
+// orderBookSnapshot 盘口快照摘要
+type orderBookSnapshot struct {
+	time      time.Time
+	bidPrice  float64
+	bidVolume int64
+	askVolume int64
+}
+
+// IntradayRecorder 盘中记录器
+// 记录当日交易时段推送过的盘口快照，收盘后用于生成复盘数据，跨日自动清空
+type IntradayRecorder struct {
+	mu        sync.RWMutex
+	date      string
+	orderBook map[string][]orderBookSnapshot
+	proximity map[string]models.TargetProximity // key: 代码:target/stop，当日最接近跟踪线的时刻
+}
+
+// NewIntradayRecorder 创建盘中记录器
+func NewIntradayRecorder() *IntradayRecorder {
+	return &IntradayRecorder{
+		orderBook: make(map[string][]orderBookSnapshot),
+		proximity: make(map[string]models.TargetProximity),
+	}
+}
+
+// RecordOrderBook 记录一次盘口快照
+func (r *IntradayRecorder) RecordOrderBook(code string, ob models.OrderBook) {
+	now := time.Now()
+	snap := orderBookSnapshot{time: now}
+	if len(ob.Bids) > 0 {
+		snap.bidPrice = ob.Bids[0].Price
+	}
+	for _, item := range ob.Bids {
+		snap.bidVolume += item.Size
+	}
+	for _, item := range ob.Asks {
+		snap.askVolume += item.Size
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.resetIfNewDay(now)
+	snaps := append(r.orderBook[code], snap)
+	if len(snaps) > maxOrderBookSnapshots {
+		snaps = snaps[len(snaps)-maxOrderBookSnapshots:]
+	}
+	r.orderBook[code] = snaps
+}
+
+// RecordTargetDistances 记录已填充跟踪线的行情，保留当日最接近目标价/止损价的时刻
+func (r *IntradayRecorder) RecordTargetDistances(stocks []models.Stock) {
+	now := time.Now()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.resetIfNewDay(now)
+
+	for _, s := range stocks {
+		if s.TargetPrice > 0 {
+			r.recordProximity(now, s, TargetKindTarget, s.TargetPrice, s.TargetDistance)
+		}
+		if s.StopPrice > 0 {
+			r.recordProximity(now, s, TargetKindStop, s.StopPrice, s.StopDistance)
+		}
+	}
+}
+
+// recordProximity 更新单条跟踪线的最近距离，已触及按 0 计（调用方需持有写锁）
+func (r *IntradayRecorder) recordProximity(now time.Time, s models.Stock, kind string, line, distance float64) {
+	distance = math.Max(distance, 0)
+	key := s.Symbol + ":" + kind
+	if prev, ok := r.proximity[key]; ok && prev.Line == line && prev.Distance <= distance {
+		return
+	}
+	r.proximity[key] = models.TargetProximity{
+		Symbol:   s.Symbol,
+		Kind:     kind,
+		Line:     line,
+		Price:    s.Price,
+		Distance: distance,
+		Time:     now.UnixMilli(),
+	}
+}
+
+// NearTargets 获取当日价格曾进入跟踪线 thresholdPct% 以内的股票，按距离升序
+func (r *IntradayRecorder) NearTargets(thresholdPct float64) []models.TargetProximity {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := []models.TargetProximity{}
+	if r.date != time.Now().Format("2006-01-02") {
+		return result
+	}
+	for _, p := range r.proximity {
+		if p.Distance <= thresholdPct {
+			result = append(result, p)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Distance != result[j].Distance {
+			return result[i].Distance < result[j].Distance
+		}
+		return result[i].Symbol < result[j].Symbol
+	})
+	return result
+}
+
+// resetIfNewDay 跨日清空记录（调用方需持有写锁）
+func (r *IntradayRecorder) resetIfNewDay(now time.Time) {
+	today := now.Format("2006-01-02")
+	if r.date != today {
+		r.date = today
+		r.orderBook = make(map[string][]orderBookSnapshot)
+		r.proximity = make(map[string]models.TargetProximity)
+	}
+}
+
+// orderBookSnapshots 获取当日指定股票的盘口快照
+func (r *IntradayRecorder) orderBookSnapshots(code string) []orderBookSnapshot {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.date != time.Now().Format("2006-01-02") {
+		return nil
+	}
+	snaps := r.orderBook[code]
+	result := make([]orderBookSnapshot, len(snaps))
+	copy(result, snaps)
+	return result
+}
+
+// BuildSessionHeatReport 生成收盘复盘数据
+// minutes: 当日分时K线；alerts: 通知中心历史，取该股当日的条目组成时间线；topK: 最活跃分钟与盘口突变各取前K个
+func (r *IntradayRecorder) BuildSessionHeatReport(code string, minutes []models.KLineData, alerts []models.Notification, topK int) *models.SessionHeatReport {
+	if topK <= 0 {
+		topK = 10
+	}
+	report := &models.SessionHeatReport{
+		Code:            code,
+		Date:            time.Now().Format("2006-01-02"),
+		HotMinutes:      topHeatMinutes(minutes, topK),
+		OrderBookShifts: topOrderBookShifts(r.orderBookSnapshots(code), topK),
+	}
+	if len(minutes) > 0 && len(minutes[0].Time) >= 10 {
+		report.Date = minutes[0].Time[:10]
+	}
+	report.Timeline = sessionTimeline(alerts, code, report.Date)
+	report.JumpPoints = sessionJumpPoints(report)
+	return report
+}
+
+// sessionTimeline 筛选指定股票当日的通知，按时间排序
+func sessionTimeline(alerts []models.Notification, code, date string) []models.SessionEvent {
+	result := []models.SessionEvent{}
+	for _, n := range alerts {
+		at := time.UnixMilli(n.CreatedAt)
+		if n.Symbol != code || at.Format("2006-01-02") != date {
+			continue
+		}
+		result = append(result, models.SessionEvent{
+			Time:     at.Format("15:04:05"),
+			Category: n.Category,
+			Kind:     n.Kind,
+			Title:    n.Title,
+		})
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Time < result[j].Time
+	})
+	return result
+}
+
+// sessionJumpPoints 汇总活跃分钟、盘口突变与时间线的时点（精确到分钟），供分时回放跳转
+func sessionJumpPoints(report *models.SessionHeatReport) []string {
+	seen := make(map[string]bool)
+	add := func(t string) {
+		if t = clockMinute(t); len(t) == 5 {
+			seen[t] = true
+		}
+	}
+	for _, m := range report.HotMinutes {
+		add(m.Time)
+	}
+	for _, s := range report.OrderBookShifts {
+		add(s.Time)
+	}
+	for _, e := range report.Timeline {
+		add(e.Time)
+	}
+	points := make([]string, 0, len(seen))
+	for t := range seen {
+		points = append(points, t)
+	}
+	sort.Strings(points)
+	return points
+}
+
+// topHeatMinutes 按成交量取最活跃的K个分钟，结果按时间排序
+func topHeatMinutes(minutes []models.KLineData, k int) []models.HeatMinute {
+	if len(minutes) == 0 {
+		return []models.HeatMinute{}
+	}
+
+	var totalVolume int64
+	for _, m := range minutes {
+		totalVolume += m.Volume
+	}
+	avgVolume := float64(totalVolume) / float64(len(minutes))
+
+	result := make([]models.HeatMinute, 0, len(minutes))
+	for i, m := range minutes {
+		base := m.Open
+		if i > 0 {
+			base = minutes[i-1].Close
+		}
+		hm := models.HeatMinute{
+			Time:   m.Time,
+			Volume: m.Volume,
+			Amount: m.Amount,
+		}
+		if base > 0 {
+			hm.ChangePercent = (m.Close - base) / base * 100
+		}
+		if avgVolume > 0 {
+			hm.VolumeRatio = float64(m.Volume) / avgVolume
+		}
+		result = append(result, hm)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Volume > result[j].Volume
+	})
+	if len(result) > k {
+		result = result[:k]
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Time < result[j].Time
+	})
+	return result
+}
+
+// topOrderBookShifts 按买卖力量差变化幅度取前K个时点，结果按时间排序
+func topOrderBookShifts(snaps []orderBookSnapshot, k int) []models.OrderBookShift {
+	result := make([]models.OrderBookShift, 0, len(snaps))
+	var prevImbalance float64
+	for i, s := range snaps {
+		var imbalance float64
+		if total := s.bidVolume + s.askVolume; total > 0 {
+			imbalance = float64(s.bidVolume-s.askVolume) / float64(total)
+		}
+		if i > 0 {
+			result = append(result, models.OrderBookShift{
+				Time:      s.time.Format("15:04:05"),
+				Price:     s.bidPrice,
+				BidVolume: s.bidVolume,
+				AskVolume: s.askVolume,
+				Imbalance: imbalance,
+				Delta:     imbalance - prevImbalance,
+			})
+		}
+		prevImbalance = imbalance
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return math.Abs(result[i].Delta) > math.Abs(result[j].Delta)
+	})
+	if len(result) > k {
+		result = result[:k]
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Time < result[j].Time
+	})
+	return result
+}
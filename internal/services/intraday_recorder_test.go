@@ -0,0 +1,75 @@
+package services
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// TestBuildSessionHeatReport 测试最活跃分钟与盘口突变的筛选
+func TestBuildSessionHeatReport(t *testing.T) {
+	minutes := []models.KLineData{
+		{Time: "2026-02-09 09:31:00", Open: 10, Close: 10.1, Volume: 100},
+		{Time: "2026-02-09 09:32:00", Open: 10.1, Close: 10.5, Volume: 900},
+		{Time: "2026-02-09 09:33:00", Open: 10.5, Close: 10.4, Volume: 200},
+		{Time: "2026-02-09 09:34:00", Open: 10.4, Close: 10.2, Volume: 600},
+	}
+
+	r := NewIntradayRecorder()
+	books := []models.OrderBook{
+		{Bids: []models.OrderBookItem{{Price: 10, Size: 500}}, Asks: []models.OrderBookItem{{Price: 10.01, Size: 500}}},
+		{Bids: []models.OrderBookItem{{Price: 10, Size: 520}}, Asks: []models.OrderBookItem{{Price: 10.01, Size: 480}}},
+		{Bids: []models.OrderBookItem{{Price: 10, Size: 900}}, Asks: []models.OrderBookItem{{Price: 10.01, Size: 100}}},
+	}
+	for _, ob := range books {
+		r.RecordOrderBook("sh600000", ob)
+	}
+
+	at := func(clock string) int64 {
+		ts, _ := time.ParseInLocation("2006-01-02 15:04", clock, time.Local)
+		return ts.UnixMilli()
+	}
+	alerts := []models.Notification{
+		{Category: models.NotificationLimit, Kind: models.NotificationLimitUp, Title: "涨停", Symbol: "sh600000", CreatedAt: at("2026-02-09 10:15")},
+		{Category: models.NotificationPrice, Title: "条件单触发", Symbol: "sh600000", CreatedAt: at("2026-02-09 09:40")},
+		{Category: models.NotificationPrice, Title: "其他股票", Symbol: "sz000001", CreatedAt: at("2026-02-09 09:50")},
+		{Category: models.NotificationPrice, Title: "前一日", Symbol: "sh600000", CreatedAt: at("2026-02-06 10:00")},
+	}
+
+	report := r.BuildSessionHeatReport("sh600000", minutes, alerts, 2)
+	if report.Date != "2026-02-09" {
+		t.Errorf("日期错误: %s", report.Date)
+	}
+	if len(report.HotMinutes) != 2 || report.HotMinutes[0].Time != "2026-02-09 09:32:00" || report.HotMinutes[1].Time != "2026-02-09 09:34:00" {
+		t.Fatalf("最活跃分钟错误: %+v", report.HotMinutes)
+	}
+	if report.HotMinutes[0].VolumeRatio != 2 {
+		t.Errorf("量比计算错误: %.2f", report.HotMinutes[0].VolumeRatio)
+	}
+	if len(report.OrderBookShifts) != 2 {
+		t.Fatalf("盘口突变数量错误: %d", len(report.OrderBookShifts))
+	}
+	var maxDelta float64
+	for _, s := range report.OrderBookShifts {
+		if s.Delta > maxDelta {
+			maxDelta = s.Delta
+		}
+	}
+	if maxDelta < 0.7 {
+		t.Errorf("未识别到最大盘口突变: %+v", report.OrderBookShifts)
+	}
+
+	if len(report.Timeline) != 2 || report.Timeline[0].Title != "条件单触发" || report.Timeline[1].Kind != models.NotificationLimitUp {
+		t.Errorf("时间线错误: %+v", report.Timeline)
+	}
+	for _, want := range []string{"09:32", "09:34", "09:40", "10:15"} {
+		if !slices.Contains(report.JumpPoints, want) {
+			t.Errorf("跳转点缺少 %s: %v", want, report.JumpPoints)
+		}
+	}
+	if !slices.IsSorted(report.JumpPoints) {
+		t.Errorf("跳转点未排序: %v", report.JumpPoints)
+	}
+}
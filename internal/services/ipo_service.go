@@ -0,0 +1,248 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/proxy"
+	"github.com/run-bigpig/jcp/internal/pkg/supervisor"
+)
+
+// 东方财富新股申购API（按申购日期降序）
+const ipoCalendarURL = "https://datacenter-web.eastmoney.com/api/data/v1/get?sortColumns=APPLY_DATE,SECURITY_CODE&sortTypes=-1,-1&pageSize=%d&pageNumber=1&reportName=RPTA_APP_IPOAPPLY&columns=SECURITY_CODE,SECURITY_NAME,TRADE_MARKET,APPLY_CODE,APPLY_DATE,BALLOT_NUM_DATE,LISTING_DATE,ISSUE_PRICE,AFTER_ISSUE_PE,INDUSTRY_PE_NEW,ONLINE_APPLY_UPPER,ONLINE_ISSUE_LWR&quoteColumns=&source=WEB&client=WEB"
+
+const (
+	ipoReminderAfter    = 9 * 60 // 申购提醒时间（分钟），开盘前提醒
+	ipoReminderInterval = 10 * time.Minute
+)
+
+// IPOService 新股日历服务
+type IPOService struct {
+	client        *http.Client
+	configService *ConfigService
+	cache         []models.IPOItem
+	cacheAt       time.Time
+	cacheMu       sync.RWMutex
+	cacheTTL      time.Duration
+
+	mu           sync.Mutex
+	lastReminded string // 最近一次完成申购提醒的日期
+	onReminder   func([]models.IPOItem)
+	now          func() time.Time
+}
+
+// NewIPOService 创建新股日历服务
+func NewIPOService(configService *ConfigService) *IPOService {
+	return &IPOService{
+		client:        proxy.GetManager().GetClientWithTimeout(15 * time.Second),
+		configService: configService,
+		cacheTTL:      30 * time.Minute, // 发行日历变化不频繁
+		now:           time.Now,
+	}
+}
+
+// SetOnReminder 设置申购日提醒回调，每天最多回调一次
+func (s *IPOService) SetOnReminder(fn func([]models.IPOItem)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onReminder = fn
+}
+
+// Start 启动申购日提醒：每天开盘前检查当日可申购的新股
+func (s *IPOService) Start(ctx context.Context) {
+	supervisor.Go(ctx, "ipo-reminder", func(ctx context.Context) {
+		s.checkReminder()
+		ticker := time.NewTicker(ipoReminderInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.checkReminder()
+			}
+		}
+	})
+}
+
+// checkReminder 到达提醒时间后检查当日申购，每天最多提醒一次，获取失败时下次重试
+func (s *IPOService) checkReminder() {
+	if s.configService == nil || !s.configService.GetConfig().IPOReminder {
+		return
+	}
+	now := s.now().In(time.FixedZone("CST", 8*60*60))
+	if now.Hour()*60+now.Minute() < ipoReminderAfter {
+		return
+	}
+	today := now.Format("2006-01-02")
+	s.mu.Lock()
+	done := s.lastReminded == today
+	s.mu.Unlock()
+	if done {
+		return
+	}
+
+	items, err := s.subscriptionsOn(today)
+	if err != nil {
+		log.Warn("获取今日新股申购失败: %v", err)
+		return
+	}
+	s.mu.Lock()
+	s.lastReminded = today
+	onReminder := s.onReminder
+	s.mu.Unlock()
+	if len(items) > 0 && onReminder != nil {
+		onReminder(items)
+	}
+}
+
+// GetIPOCalendar 获取新股日历：今日及之后申购、或尚未上市的新股，按申购日期升序
+func (s *IPOService) GetIPOCalendar() ([]models.IPOItem, error) {
+	items, err := s.getRecentIPOs()
+	if err != nil {
+		return nil, err
+	}
+	return filterUpcomingIPOs(items, time.Now().Format("2006-01-02")), nil
+}
+
+// subscriptionsOn 获取指定日期可申购的新股
+func (s *IPOService) subscriptionsOn(date string) ([]models.IPOItem, error) {
+	items, err := s.getRecentIPOs()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.IPOItem, 0)
+	for _, item := range items {
+		if item.ApplyDate == date {
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+// getRecentIPOs 获取最近的新股发行列表（带缓存）
+func (s *IPOService) getRecentIPOs() ([]models.IPOItem, error) {
+	s.cacheMu.RLock()
+	if s.cache != nil && time.Since(s.cacheAt) < s.cacheTTL {
+		items := s.cache
+		s.cacheMu.RUnlock()
+		return items, nil
+	}
+	s.cacheMu.RUnlock()
+
+	items, err := s.fetchIPOs(50)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cacheMu.Lock()
+	s.cache = items
+	s.cacheAt = time.Now()
+	s.cacheMu.Unlock()
+	return items, nil
+}
+
+// ipoAPIResponse 东方财富新股申购API响应结构
+type ipoAPIResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Result  *struct {
+		Data []ipoAPIItem `json:"data"`
+	} `json:"result"`
+}
+
+type ipoAPIItem struct {
+	SecurityCode     string  `json:"SECURITY_CODE"`
+	SecurityName     string  `json:"SECURITY_NAME"`
+	TradeMarket      string  `json:"TRADE_MARKET"`
+	ApplyCode        string  `json:"APPLY_CODE"`
+	ApplyDate        string  `json:"APPLY_DATE"`
+	BallotNumDate    string  `json:"BALLOT_NUM_DATE"`
+	ListingDate      string  `json:"LISTING_DATE"`
+	IssuePrice       float64 `json:"ISSUE_PRICE"`
+	AfterIssuePE     float64 `json:"AFTER_ISSUE_PE"`
+	IndustryPE       float64 `json:"INDUSTRY_PE_NEW"`
+	OnlineApplyUpper float64 `json:"ONLINE_APPLY_UPPER"`
+	OnlineIssueLwr   float64 `json:"ONLINE_ISSUE_LWR"`
+}
+
+// fetchIPOs 从东方财富获取新股申购列表
+func (s *IPOService) fetchIPOs(pageSize int) ([]models.IPOItem, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf(ipoCalendarURL, pageSize), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
+	req.Header.Set("Referer", "https://data.eastmoney.com/")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return parseIPOResponse(body)
+}
+
+// parseIPOResponse 解析新股申购API响应
+func parseIPOResponse(body []byte) ([]models.IPOItem, error) {
+	var resp ipoAPIResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("解析新股数据失败: %w", err)
+	}
+	if !resp.Success || resp.Result == nil {
+		return nil, fmt.Errorf("获取新股数据失败: %s", resp.Message)
+	}
+
+	items := make([]models.IPOItem, 0, len(resp.Result.Data))
+	for _, item := range resp.Result.Data {
+		items = append(items, models.IPOItem{
+			Code:        item.SecurityCode,
+			Name:        item.SecurityName,
+			Market:      item.TradeMarket,
+			ApplyCode:   item.ApplyCode,
+			ApplyDate:   trimDate(item.ApplyDate),
+			BallotDate:  trimDate(item.BallotNumDate),
+			ListingDate: trimDate(item.ListingDate),
+			IssuePrice:  item.IssuePrice,
+			IssuePE:     item.AfterIssuePE,
+			IndustryPE:  item.IndustryPE,
+			ApplyUpper:  item.OnlineApplyUpper,
+			LotRate:     item.OnlineIssueLwr,
+		})
+	}
+	return items, nil
+}
+
+// filterUpcomingIPOs 保留申购日不早于今日或尚未上市的新股，按申购日期升序
+func filterUpcomingIPOs(items []models.IPOItem, today string) []models.IPOItem {
+	result := make([]models.IPOItem, 0, len(items))
+	for _, item := range items {
+		if item.ApplyDate >= today || item.ListingDate == "" || item.ListingDate >= today {
+			result = append(result, item)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].ApplyDate < result[j].ApplyDate
+	})
+	return result
+}
+
+// trimDate 截取日期部分，"2026-02-09 00:00:00" -> "2026-02-09"
+func trimDate(s string) string {
+	if len(s) > 10 {
+		return s[:10]
+	}
+	return s
+}
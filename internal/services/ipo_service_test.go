@@ -0,0 +1,101 @@
+package services
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// TestParseIPOResponse 测试新股申购数据解析与日期截取
+func TestParseIPOResponse(t *testing.T) {
+	body := []byte(`{"success":true,"message":"ok","result":{"data":[
+		{"SECURITY_CODE":"301589","SECURITY_NAME":"诺瓦星云","TRADE_MARKET":"深圳证券交易所创业板","APPLY_CODE":"301589","APPLY_DATE":"2026-02-10 00:00:00","BALLOT_NUM_DATE":"2026-02-12 00:00:00","LISTING_DATE":null,"ISSUE_PRICE":126.89,"AFTER_ISSUE_PE":37.21,"INDUSTRY_PE_NEW":42.5,"ONLINE_APPLY_UPPER":4500,"ONLINE_ISSUE_LWR":0.0238},
+		{"SECURITY_CODE":"688720","SECURITY_NAME":"艾森股份","TRADE_MARKET":"上海证券交易所科创板","APPLY_CODE":"787720","APPLY_DATE":"2026-02-02 00:00:00","BALLOT_NUM_DATE":"2026-02-04 00:00:00","LISTING_DATE":"2026-02-09 00:00:00","ISSUE_PRICE":28.03}
+	]}}`)
+	items, err := parseIPOResponse(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("items = %+v", items)
+	}
+	first := items[0]
+	if first.Code != "301589" || first.Name != "诺瓦星云" || first.ApplyDate != "2026-02-10" || first.BallotDate != "2026-02-12" ||
+		first.ListingDate != "" || first.IssuePrice != 126.89 || first.ApplyUpper != 4500 || first.LotRate != 0.0238 {
+		t.Errorf("items[0] = %+v", first)
+	}
+	if items[1].ApplyCode != "787720" || items[1].ListingDate != "2026-02-09" || items[1].IssuePE != 0 {
+		t.Errorf("items[1] = %+v", items[1])
+	}
+
+	if _, err := parseIPOResponse([]byte(`{"success":false,"message":"返回数据为空","result":null}`)); err == nil {
+		t.Error("接口失败应返回错误")
+	}
+	if _, err := parseIPOResponse([]byte(`<html>`)); err == nil {
+		t.Error("非 JSON 响应应返回错误")
+	}
+}
+
+// TestFilterUpcomingIPOs 测试保留待申购或未上市新股并按申购日期排序
+func TestFilterUpcomingIPOs(t *testing.T) {
+	items := []models.IPOItem{
+		{Code: "c", ApplyDate: "2026-02-12"},
+		{Code: "listed", ApplyDate: "2026-01-20", ListingDate: "2026-02-01"},
+		{Code: "pending", ApplyDate: "2026-02-02"},
+		{Code: "listing-today", ApplyDate: "2026-02-01", ListingDate: "2026-02-09"},
+	}
+	got := filterUpcomingIPOs(items, "2026-02-09")
+	var codes []string
+	for _, item := range got {
+		codes = append(codes, item.Code)
+	}
+	if want := []string{"listing-today", "pending", "c"}; !slices.Equal(codes, want) {
+		t.Errorf("codes = %v, want %v", codes, want)
+	}
+}
+
+// TestIPOCheckReminder 测试申购提醒只在开启时、开盘前时间之后每天提醒一次
+func TestIPOCheckReminder(t *testing.T) {
+	cs, err := NewConfigService(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cst := time.FixedZone("CST", 8*60*60)
+	now := time.Date(2026, 2, 10, 8, 30, 0, 0, cst)
+	s := NewIPOService(cs)
+	s.now = func() time.Time { return now }
+	s.cache = []models.IPOItem{{Code: "301589", ApplyDate: "2026-02-10"}, {Code: "688720", ApplyDate: "2026-02-11"}}
+	s.cacheAt = time.Now()
+	var reminded [][]models.IPOItem
+	s.SetOnReminder(func(items []models.IPOItem) { reminded = append(reminded, items) })
+
+	s.checkReminder()
+	if len(reminded) != 0 {
+		t.Fatalf("未开启时不应提醒: %v", reminded)
+	}
+	cfg := *cs.GetConfig()
+	cfg.IPOReminder = true
+	if err := cs.UpdateConfig(&cfg); err != nil {
+		t.Fatal(err)
+	}
+	s.checkReminder()
+	if len(reminded) != 0 {
+		t.Fatalf("提醒时间前不应提醒: %v", reminded)
+	}
+
+	now = now.Add(time.Hour)
+	s.checkReminder()
+	s.checkReminder()
+	if len(reminded) != 1 || len(reminded[0]) != 1 || reminded[0][0].Code != "301589" {
+		t.Fatalf("reminded = %v", reminded)
+	}
+
+	// 次日再次提醒当日申购
+	now = now.AddDate(0, 0, 1)
+	s.checkReminder()
+	if len(reminded) != 2 || reminded[1][0].Code != "688720" {
+		t.Errorf("reminded = %v", reminded)
+	}
+}
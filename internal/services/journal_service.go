@@ -0,0 +1,335 @@
+package services
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/logger"
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+
+	"github.com/google/uuid"
+)
+
+var journalLog = logger.New("journal")
+
+// JournalService 交易日志服务
+// 记录交易与想法（标签、情绪、结果），保存到数据目录 journal.json，并按标签与情绪统计胜率和持仓周期
+type JournalService struct {
+	path    string
+	mu      sync.Mutex
+	entries []models.JournalEntry
+	loaded  bool
+}
+
+// NewJournalService 创建交易日志服务
+func NewJournalService(dataDir string) *JournalService {
+	return &JournalService{path: filepath.Join(dataDir, "journal.json")}
+}
+
+// loadLocked 加载日志(需要已持有锁)
+func (s *JournalService) loadLocked() {
+	if s.loaded {
+		return
+	}
+	s.loaded = true
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return
+	}
+	if err := json.Unmarshal(data, &s.entries); err != nil {
+		journalLog.Warn("解析交易日志失败: %v", err)
+	}
+}
+
+// saveLocked 保存日志(需要已持有锁)
+func (s *JournalService) saveLocked() error {
+	data, err := json.MarshalIndent(s.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileTracked(s.path, data, 0644)
+}
+
+// List 按条件筛选日志，按记录日期倒序
+func (s *JournalService) List(filter models.JournalFilter) []models.JournalEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loadLocked()
+
+	result := []models.JournalEntry{}
+	for _, e := range s.entries {
+		if matchJournal(e, filter) {
+			result = append(result, e)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Date != result[j].Date {
+			return result[i].Date > result[j].Date
+		}
+		return result[i].CreatedAt > result[j].CreatedAt
+	})
+	return result
+}
+
+// Save 新增或更新日志条目（ID 为空时新增）
+func (s *JournalService) Save(entry models.JournalEntry) (models.JournalEntry, error) {
+	if err := normalizeJournal(&entry); err != nil {
+		return entry, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loadLocked()
+
+	now := time.Now().UnixMilli()
+	entry.UpdatedAt = now
+	if entry.ID != "" {
+		i := slices.IndexFunc(s.entries, func(e models.JournalEntry) bool { return e.ID == entry.ID })
+		if i < 0 {
+			return entry, i18n.Errorf("error.journal.not_found", entry.ID)
+		}
+		entry.CreatedAt = s.entries[i].CreatedAt
+		s.entries[i] = entry
+	} else {
+		entry.ID = uuid.New().String()
+		entry.CreatedAt = now
+		s.entries = append(s.entries, entry)
+	}
+	return entry, s.saveLocked()
+}
+
+// Delete 删除日志条目
+func (s *JournalService) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loadLocked()
+
+	n := len(s.entries)
+	s.entries = slices.DeleteFunc(s.entries, func(e models.JournalEntry) bool { return e.ID == id })
+	if len(s.entries) == n {
+		return i18n.Errorf("error.journal.not_found", id)
+	}
+	return s.saveLocked()
+}
+
+// Tags 获取已使用的全部标签，按使用次数降序
+func (s *JournalService) Tags() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loadLocked()
+
+	counts := map[string]int{}
+	for _, e := range s.entries {
+		for _, t := range e.Tags {
+			counts[t]++
+		}
+	}
+	tags := make([]string, 0, len(counts))
+	for t := range counts {
+		tags = append(tags, t)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if counts[tags[i]] != counts[tags[j]] {
+			return counts[tags[i]] > counts[tags[j]]
+		}
+		return tags[i] < tags[j]
+	})
+	return tags
+}
+
+// Stats 统计筛选范围内的交易：整体及按标签、情绪分组的胜率、盈亏与平均持仓天数
+func (s *JournalService) Stats(filter models.JournalFilter) models.JournalStats {
+	return journalStats(s.List(filter))
+}
+
+// Table 导出筛选范围内的日志
+func (s *JournalService) Table(filter models.JournalFilter) ExportTable {
+	table := ExportTable{
+		Sheet:   "交易日志",
+		Headers: []string{"日期", "类型", "代码", "名称", "方向", "建仓日期", "建仓价", "平仓日期", "平仓价", "数量", "盈亏", "结果", "标签", "情绪", "备注"},
+	}
+	for _, e := range s.List(filter) {
+		kind := "交易"
+		if e.Kind == models.JournalKindIdea {
+			kind = "想法"
+		}
+		table.Rows = append(table.Rows, []any{
+			e.Date, kind, e.Symbol, e.Name, e.Side, e.EntryDate, e.EntryPrice, e.ExitDate, e.ExitPrice,
+			e.Shares, e.PnL, e.Outcome, strings.Join(e.Tags, ","), e.Emotion, e.Notes,
+		})
+	}
+	return table
+}
+
+// normalizeJournal 校验并补全日志条目：去重标签、计算盈亏、推断结果
+func normalizeJournal(e *models.JournalEntry) error {
+	if e.Kind == "" {
+		e.Kind = models.JournalKindTrade
+	}
+	if e.Kind != models.JournalKindTrade && e.Kind != models.JournalKindIdea {
+		return i18n.Errorf("error.journal.kind_unsupported", e.Kind)
+	}
+	if e.Date == "" {
+		e.Date = time.Now().Format("2006-01-02")
+	}
+	for _, d := range []string{e.Date, e.EntryDate, e.ExitDate} {
+		if d == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", d); err != nil {
+			return i18n.Errorf("error.date.invalid", d)
+		}
+	}
+	if e.EntryDate != "" && e.ExitDate != "" && e.ExitDate < e.EntryDate {
+		return i18n.Errorf("error.journal.close_before_open")
+	}
+
+	tags := make([]string, 0, len(e.Tags))
+	for _, t := range e.Tags {
+		if t = strings.TrimSpace(t); t != "" && !slices.Contains(tags, t) {
+			tags = append(tags, t)
+		}
+	}
+	e.Tags = tags
+	e.Emotion = strings.TrimSpace(e.Emotion)
+
+	if e.Kind != models.JournalKindTrade {
+		return nil
+	}
+	if e.PnL == 0 && e.EntryPrice > 0 && e.ExitPrice > 0 && e.Shares > 0 {
+		diff := e.ExitPrice - e.EntryPrice
+		if e.Side == models.PaperSideSell {
+			diff = -diff
+		}
+		e.PnL = math.Round(diff*float64(e.Shares)*100) / 100
+	}
+	if e.Outcome == "" {
+		switch {
+		case e.ExitDate == "" && e.ExitPrice == 0 && e.PnL == 0:
+			e.Outcome = models.JournalOutcomeOpen
+		case e.PnL > 0:
+			e.Outcome = models.JournalOutcomeWin
+		case e.PnL < 0:
+			e.Outcome = models.JournalOutcomeLoss
+		default:
+			e.Outcome = models.JournalOutcomeBreakeven
+		}
+	}
+	return nil
+}
+
+// matchJournal 日志是否满足筛选条件
+func matchJournal(e models.JournalEntry, f models.JournalFilter) bool {
+	switch {
+	case f.Symbol != "" && e.Symbol != f.Symbol:
+		return false
+	case f.Kind != "" && e.Kind != f.Kind:
+		return false
+	case f.Tag != "" && !slices.Contains(e.Tags, f.Tag):
+		return false
+	case f.StartDate != "" && e.Date < f.StartDate:
+		return false
+	case f.EndDate != "" && e.Date > f.EndDate:
+		return false
+	}
+	return true
+}
+
+// journalGroup 分组统计累加器
+type journalGroup struct {
+	stats       models.JournalGroupStats
+	holdingDays []float64
+}
+
+// add 累加一笔已有结果的交易
+func (g *journalGroup) add(e models.JournalEntry) {
+	g.stats.Trades++
+	g.stats.TotalPnL += e.PnL
+	switch e.Outcome {
+	case models.JournalOutcomeWin:
+		g.stats.Wins++
+	case models.JournalOutcomeLoss:
+		g.stats.Losses++
+	}
+	if e.EntryDate != "" && e.ExitDate != "" {
+		in, _ := time.Parse("2006-01-02", e.EntryDate)
+		out, _ := time.Parse("2006-01-02", e.ExitDate)
+		g.holdingDays = append(g.holdingDays, out.Sub(in).Hours()/24)
+	}
+}
+
+// result 计算胜率与平均持仓天数
+func (g *journalGroup) result() models.JournalGroupStats {
+	st := g.stats
+	if decided := st.Wins + st.Losses; decided > 0 {
+		st.WinRate = float64(st.Wins) / float64(decided) * 100
+	}
+	if len(g.holdingDays) > 0 {
+		var total float64
+		for _, d := range g.holdingDays {
+			total += d
+		}
+		st.AvgHoldingDays = total / float64(len(g.holdingDays))
+	}
+	st.TotalPnL = math.Round(st.TotalPnL*100) / 100
+	return st
+}
+
+// journalStats 汇总交易统计，未平仓与想法不计入胜率
+func journalStats(entries []models.JournalEntry) models.JournalStats {
+	stats := models.JournalStats{Entries: len(entries), ByTag: []models.JournalGroupStats{}, ByEmotion: []models.JournalGroupStats{}}
+	overall := &journalGroup{}
+	byTag := map[string]*journalGroup{}
+	byEmotion := map[string]*journalGroup{}
+	group := func(m map[string]*journalGroup, key string) *journalGroup {
+		if m[key] == nil {
+			m[key] = &journalGroup{stats: models.JournalGroupStats{Key: key}}
+		}
+		return m[key]
+	}
+
+	for _, e := range entries {
+		if e.Kind != models.JournalKindTrade {
+			continue
+		}
+		if e.Outcome == models.JournalOutcomeOpen {
+			stats.Open++
+			continue
+		}
+		overall.add(e)
+		for _, t := range e.Tags {
+			group(byTag, t).add(e)
+		}
+		if e.Emotion != "" {
+			group(byEmotion, e.Emotion).add(e)
+		}
+	}
+
+	stats.Overall = overall.result()
+	stats.ByTag = sortedJournalGroups(byTag)
+	stats.ByEmotion = sortedJournalGroups(byEmotion)
+	return stats
+}
+
+// sortedJournalGroups 分组结果按交易数降序
+func sortedJournalGroups(m map[string]*journalGroup) []models.JournalGroupStats {
+	result := make([]models.JournalGroupStats, 0, len(m))
+	for _, g := range m {
+		result = append(result, g.result())
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Trades != result[j].Trades {
+			return result[i].Trades > result[j].Trades
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+func TestJournalService(t *testing.T) {
+	dir := t.TempDir()
+	s := NewJournalService(dir)
+	entries := []models.JournalEntry{
+		{Symbol: "sh600000", Date: "2026-03-02", EntryDate: "2026-03-02", ExitDate: "2026-03-06", EntryPrice: 10, ExitPrice: 11, Shares: 1000, Tags: []string{"突破", " 突破", ""}, Emotion: "冷静"},
+		{Symbol: "sh600000", Date: "2026-03-09", EntryDate: "2026-03-09", ExitDate: "2026-03-11", EntryPrice: 10, ExitPrice: 9.5, Shares: 1000, Tags: []string{"突破", "追高"}, Emotion: "冲动"},
+		{Symbol: "sz000001", Date: "2026-03-10", EntryPrice: 12, Shares: 500, Tags: []string{"低吸"}},
+		{Kind: models.JournalKindIdea, Symbol: "sz000001", Date: "2026-03-12", Tags: []string{"低吸"}},
+	}
+	for _, e := range entries {
+		if _, err := s.Save(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.Save(models.JournalEntry{EntryDate: "2026-03-05", ExitDate: "2026-03-01"}); err == nil {
+		t.Error("平仓日期早于建仓日期应返回错误")
+	}
+
+	// 重新加载验证持久化
+	s = NewJournalService(dir)
+	list := s.List(models.JournalFilter{Tag: "突破"})
+	if len(list) != 2 || list[0].Date != "2026-03-09" || list[0].PnL != -500 || list[0].Outcome != models.JournalOutcomeLoss {
+		t.Fatalf("list = %+v", list)
+	}
+	if len(list[1].Tags) != 1 {
+		t.Errorf("标签应去重: %v", list[1].Tags)
+	}
+
+	stats := s.Stats(models.JournalFilter{})
+	if stats.Entries != 4 || stats.Open != 1 || stats.Overall.Trades != 2 || stats.Overall.WinRate != 50 || stats.Overall.TotalPnL != 500 || stats.Overall.AvgHoldingDays != 3 {
+		t.Errorf("overall = %+v", stats)
+	}
+	if len(stats.ByTag) != 2 || stats.ByTag[0].Key != "突破" || stats.ByTag[0].Trades != 2 || stats.ByTag[1].WinRate != 0 {
+		t.Errorf("byTag = %+v", stats.ByTag)
+	}
+	if len(stats.ByEmotion) != 2 {
+		t.Errorf("byEmotion = %+v", stats.ByEmotion)
+	}
+	if tags := s.Tags(); len(tags) != 3 || tags[0] != "突破" && tags[0] != "低吸" {
+		t.Errorf("tags = %v", tags)
+	}
+
+	if err := s.Delete(list[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	if table := s.Table(models.JournalFilter{}); len(table.Rows) != 3 {
+		t.Errorf("导出行数 = %d", len(table.Rows))
+	}
+}
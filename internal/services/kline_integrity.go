@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+const (
+	integrityScanDays     = 30   // 检查最近多少自然日的归档
+	integrityRefetchBars  = 1200 // 补数据时拉取的分钟K线数（约5个交易日，数据源只保留最近数日）
+	integrityLunchMinutes = 90   // 11:30-13:00 午间休市
+	sessionOpenMinute     = 9*60 + 31
+	sessionCloseMinute    = 15 * 60
+)
+
+// IntegrityStatus 获取最近一次完整性检查结果
+func (a *IntradayArchive) IntegrityStatus() models.KLineIntegrityStatus {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	status := a.integrity
+	status.Gaps = append([]models.KLineGap{}, a.integrity.Gaps...)
+	return status
+}
+
+// CheckIntegrity 对照交易日历检查自选股历史分时归档：整日缺失、日内缺少分钟K线、零成交量异常
+// 有问题的日期重新拉取一次数据源，仍无法补齐的缺口写入日志并记录在检查结果中
+func (a *IntradayArchive) CheckIntegrity(ctx context.Context) models.KLineIntegrityStatus {
+	a.mu.Lock()
+	if a.integrity.Running {
+		a.mu.Unlock()
+		return a.IntegrityStatus()
+	}
+	a.integrity.Running = true
+	a.mu.Unlock()
+
+	status := models.KLineIntegrityStatus{Gaps: []models.KLineGap{}}
+	dates := a.expectedTradeDates()
+	for _, symbol := range a.archiveSymbols() {
+		if ctx.Err() != nil {
+			break
+		}
+		scanned, repaired, gaps := a.checkSymbol(ctx, symbol, dates)
+		status.Scanned += scanned
+		status.Repaired += repaired
+		status.Gaps = append(status.Gaps, gaps...)
+	}
+	for _, g := range status.Gaps {
+		intradayLog.Warn("历史分时缺口无法修复 %s %s [%s] %s", g.Code, g.Date, g.Kind, g.Detail)
+	}
+	status.LastRun = a.now().UnixMilli()
+
+	a.mu.Lock()
+	a.integrity = status
+	a.mu.Unlock()
+	return status
+}
+
+// checkSymbol 检查单只股票，返回检查的日数、修复的日数与剩余缺口
+// 股票首次归档之前的日期不算缺失
+func (a *IntradayArchive) checkSymbol(ctx context.Context, code string, dates []string) (int, int, []models.KLineGap) {
+	archived := a.Dates(code)
+	if len(archived) == 0 {
+		return 0, 0, nil
+	}
+	first := archived[len(archived)-1]
+
+	broken := map[string][]models.KLineGap{}
+	scanned := 0
+	for _, date := range dates {
+		if date < first {
+			continue
+		}
+		scanned++
+		bars, err := a.Get(code, date)
+		if err != nil {
+			bars = nil
+		}
+		if gaps := scanIntradayDay(code, date, bars); len(gaps) > 0 {
+			broken[date] = gaps
+		}
+	}
+	if len(broken) == 0 {
+		return scanned, 0, nil
+	}
+
+	// 数据源只保留最近数日的分钟数据，拉取一次后按日替换
+	byDay := map[string][]models.KLineData{}
+	if klines, err := a.marketService.GetMinuteHistory(ctx, code, integrityRefetchBars); err != nil {
+		intradayLog.Warn("补拉 %s 分时失败: %v", code, err)
+	} else {
+		for _, k := range klines {
+			if len(k.Time) >= 10 {
+				byDay[k.Time[:10]] = append(byDay[k.Time[:10]], k)
+			}
+		}
+	}
+
+	repaired := 0
+	var remaining []models.KLineGap
+	for _, date := range dates {
+		gaps, ok := broken[date]
+		if !ok {
+			continue
+		}
+		fresh := byDay[date]
+		if len(fresh) > 0 {
+			freshGaps := scanIntradayDay(code, date, fresh)
+			if gapBars(freshGaps) < gapBars(gaps) {
+				if err := a.Save(code, date, fresh); err != nil {
+					intradayLog.Warn("保存补拉的分时失败 %s %s: %v", code, date, err)
+				} else {
+					gaps = freshGaps
+					if len(gaps) == 0 {
+						repaired++
+					}
+				}
+			}
+		}
+		remaining = append(remaining, gaps...)
+	}
+	return scanned, repaired, remaining
+}
+
+// expectedTradeDates 最近 integrityScanDays 天内应有归档的交易日，当日收盘归档前不计入
+func (a *IntradayArchive) expectedTradeDates() []string {
+	now := a.now().In(time.FixedZone("CST", 8*60*60))
+	end := now
+	if now.Hour()*60+now.Minute() < intradayArchiveAfter {
+		end = now.AddDate(0, 0, -1)
+	}
+	var dates []string
+	for d := now.AddDate(0, 0, -integrityScanDays); !d.After(end); d = d.AddDate(0, 0, 1) {
+		if a.tradeDay == nil || a.tradeDay(d) {
+			dates = append(dates, d.Format("2006-01-02"))
+		}
+	}
+	return dates
+}
+
+// scanIntradayDay 检查单日分时：整日缺失、相邻K线间隔、开收盘缺失与零成交量异常
+func scanIntradayDay(code, date string, bars []models.KLineData) []models.KLineGap {
+	if len(bars) == 0 {
+		return []models.KLineGap{{Code: code, Date: date, Kind: models.KLineGapMissingDay, Count: 240}}
+	}
+
+	var gaps []models.KLineGap
+	missing, detail := 0, ""
+	addMissing := func(n int, from, to string) {
+		if n <= 0 {
+			return
+		}
+		if missing == 0 {
+			detail = fmt.Sprintf("%s-%s", from, to)
+		}
+		missing += n
+	}
+
+	prev := -1
+	zero := 0
+	for _, k := range bars {
+		m, ok := klineMinute(k.Time)
+		if !ok {
+			continue
+		}
+		if prev < 0 {
+			addMissing(m-sessionOpenMinute, "09:31", formatMinute(m))
+		} else {
+			gap := m - prev - 1
+			if prev <= 11*60+30 && m >= 13*60 {
+				gap -= integrityLunchMinutes
+			}
+			addMissing(gap, formatMinute(prev+1), formatMinute(m-1))
+		}
+		prev = m
+		if k.Volume == 0 && k.High != k.Low {
+			zero++
+		}
+	}
+	if prev >= 0 {
+		addMissing(sessionCloseMinute-prev, formatMinute(prev+1), "15:00")
+	}
+
+	if missing > 0 {
+		gaps = append(gaps, models.KLineGap{Code: code, Date: date, Kind: models.KLineGapMissingBars, Count: missing, Detail: detail})
+	}
+	if zero > 0 {
+		gaps = append(gaps, models.KLineGap{Code: code, Date: date, Kind: models.KLineGapZeroVolume, Count: zero})
+	}
+	return gaps
+}
+
+// gapBars 缺口涉及的K线总数
+func gapBars(gaps []models.KLineGap) int {
+	n := 0
+	for _, g := range gaps {
+		n += g.Count
+	}
+	return n
+}
+
+// klineMinute 解析K线时间（2006-01-02 15:04:05）中的分钟数
+func klineMinute(t string) (int, bool) {
+	if len(t) < 16 {
+		return 0, false
+	}
+	parsed, err := time.Parse("15:04", t[11:16])
+	if err != nil {
+		return 0, false
+	}
+	return parsed.Hour()*60 + parsed.Minute(), true
+}
+
+// formatMinute 分钟数格式化为 HH:MM
+func formatMinute(m int) string {
+	return fmt.Sprintf("%02d:%02d", m/60, m%60)
+}
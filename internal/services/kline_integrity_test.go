@@ -0,0 +1,43 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+func TestScanIntradayDay(t *testing.T) {
+	minute := func(m int) models.KLineData {
+		return models.KLineData{Time: "2026-02-09 " + formatMinute(m) + ":00", High: 10, Low: 10, Volume: 100}
+	}
+	var full []models.KLineData
+	for m := 9*60 + 31; m <= 11*60+30; m++ {
+		full = append(full, minute(m))
+	}
+	for m := 13*60 + 1; m <= 15*60; m++ {
+		full = append(full, minute(m))
+	}
+	if len(full) != 240 {
+		t.Fatalf("bars = %d", len(full))
+	}
+	if gaps := scanIntradayDay("sh600000", "2026-02-09", full); len(gaps) != 0 {
+		t.Fatalf("完整分时不应有缺口: %+v", gaps)
+	}
+
+	broken := append([]models.KLineData{}, full[:10]...)
+	broken = append(broken, full[15:238]...)
+	broken[0].Volume, broken[0].High = 0, 10.2
+	gaps := scanIntradayDay("sh600000", "2026-02-09", broken)
+	if len(gaps) != 2 {
+		t.Fatalf("gaps = %+v", gaps)
+	}
+	if g := gaps[0]; g.Kind != models.KLineGapMissingBars || g.Count != 7 || g.Detail != "09:41-09:45" {
+		t.Errorf("缺失K线 = %+v", g)
+	}
+	if g := gaps[1]; g.Kind != models.KLineGapZeroVolume || g.Count != 1 {
+		t.Errorf("零成交量 = %+v", g)
+	}
+	if gaps := scanIntradayDay("sh600000", "2026-02-09", nil); len(gaps) != 1 || gaps[0].Kind != models.KLineGapMissingDay {
+		t.Errorf("整日缺失 = %+v", gaps)
+	}
+}
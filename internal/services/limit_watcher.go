@@ -0,0 +1,85 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// LimitEvent 自选股新封涨停/跌停
+type LimitEvent struct {
+	Stock models.Stock
+	Up    bool // true 涨停，false 跌停
+}
+
+// LimitWatcher 自选股涨跌停检测
+// 记录每只股票上一轮是否处于涨停/跌停，仅在新封板时产生事件；首轮只记录状态，避免启动时对已封板股票集中提醒
+type LimitWatcher struct {
+	mu      sync.Mutex
+	state   map[string]int // 代码 -> 1 涨停 / -1 跌停 / 0 未封板
+	date    string
+	started bool
+	now     func() time.Time
+}
+
+// NewLimitWatcher 创建涨跌停检测
+func NewLimitWatcher() *LimitWatcher {
+	return &LimitWatcher{state: map[string]int{}, now: time.Now}
+}
+
+// Observe 检测一轮行情，返回新封涨停/跌停的股票
+func (w *LimitWatcher) Observe(stocks []models.Stock) []LimitEvent {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// 跨交易日重置状态
+	if date := w.now().In(time.FixedZone("CST", 8*60*60)).Format("2006-01-02"); date != w.date {
+		w.date = date
+		clear(w.state)
+	}
+
+	var events []LimitEvent
+	for _, st := range stocks {
+		cur := limitState(st)
+		prev := w.state[st.Symbol]
+		w.state[st.Symbol] = cur
+		if w.started && cur != 0 && cur != prev {
+			events = append(events, LimitEvent{Stock: st, Up: cur > 0})
+		}
+	}
+	w.started = true
+	return events
+}
+
+// applyLimitPrices 填充 A 股行情的涨停价与跌停价
+func applyLimitPrices(stocks []models.Stock) {
+	for i := range stocks {
+		st := &stocks[i]
+		st.LimitUpPrice, st.LimitDownPrice = 0, 0
+		if !isStockSymbol(st.Symbol) {
+			continue
+		}
+		if up, down, ok := limitPrices(st.Symbol[2:], st.Name, st.PreClose); ok {
+			st.LimitUpPrice, st.LimitDownPrice = up, down
+		}
+	}
+}
+
+// limitState 行情是否处于涨停(1)/跌停(-1)，无涨跌幅限制或数据不足时返回 0
+func limitState(st models.Stock) int {
+	if st.Price <= 0 || st.PreClose <= 0 || len(st.Symbol) <= 6 {
+		return 0
+	}
+	up, down, ok := limitPrices(st.Symbol[len(st.Symbol)-6:], st.Name, st.PreClose)
+	switch {
+	case !ok:
+		return 0
+	case st.Price >= up:
+		return 1
+	case st.Price <= down:
+		return -1
+	default:
+		return 0
+	}
+}
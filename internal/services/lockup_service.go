@@ -0,0 +1,294 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/proxy"
+	"github.com/run-bigpig/jcp/internal/pkg/supervisor"
+)
+
+// 东方财富限售解禁API（按解禁日期升序），filter 需 URL 编码
+const lockupURL = "https://datacenter-web.eastmoney.com/api/data/v1/get?reportName=RPT_LIFT_STAGE&columns=ALL&source=WEB&client=WEB&sortColumns=FREE_DATE&sortTypes=1&pageNumber=1&pageSize=500&filter=%s"
+
+const (
+	defaultLockupDays          = 90 // 日历默认查询天数
+	maxLockupDays              = 365
+	defaultLockupAlertDays     = 7
+	defaultLockupAlertMinRatio = 5.0
+	lockupCheckInterval        = time.Hour
+	lockupCacheTTL             = 6 * time.Hour
+)
+
+type lockupCache struct {
+	data     []models.LockupExpiry
+	expireAt time.Time
+}
+
+// LockupService 限售解禁日历与提醒
+type LockupService struct {
+	client        *http.Client
+	configService *ConfigService
+	alertsPath    string // 已提醒记录，避免重启后重复提醒
+
+	mu        sync.Mutex
+	cache     map[string]*lockupCache
+	alerted   map[string]string // 代码|解禁日 -> 解禁日，解禁日过后清理
+	loaded    bool
+	lastCheck string // 最近一次检查提醒的日期
+	onAlert   func([]models.LockupExpiry)
+	now       func() time.Time
+}
+
+// NewLockupService 创建限售解禁服务
+func NewLockupService(dataDir string, configService *ConfigService) *LockupService {
+	return &LockupService{
+		client:        proxy.GetManager().GetClientWithTimeout(15 * time.Second),
+		configService: configService,
+		alertsPath:    filepath.Join(dataDir, "lockup_alerts.json"),
+		cache:         make(map[string]*lockupCache),
+		alerted:       make(map[string]string),
+		now:           time.Now,
+	}
+}
+
+// SetOnAlert 设置大额解禁提醒回调，每个解禁事件只回调一次
+func (s *LockupService) SetOnAlert(fn func([]models.LockupExpiry)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onAlert = fn
+}
+
+// GetLockupCalendar 获取股票未来 days 天内的限售解禁（按日期升序）
+func (s *LockupService) GetLockupCalendar(ctx context.Context, symbols []string, days int) ([]models.LockupExpiry, error) {
+	if len(symbols) == 0 {
+		return []models.LockupExpiry{}, nil
+	}
+	if days <= 0 {
+		days = defaultLockupDays
+	}
+	days = min(days, maxLockupDays)
+
+	// 代码（不含市场前缀）-> 原始代码
+	bySecurity := make(map[string]string, len(symbols))
+	for _, sym := range symbols {
+		code := strings.ToLower(sym)
+		for _, prefix := range []string{"sh", "sz", "bj"} {
+			code = strings.TrimPrefix(code, prefix)
+		}
+		if len(code) == 6 {
+			bySecurity[code] = sym
+		}
+	}
+	codes := make([]string, 0, len(bySecurity))
+	for code := range bySecurity {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	now := s.now().In(time.FixedZone("CST", 8*60*60))
+	today := now.Format("2006-01-02")
+	cacheKey := fmt.Sprintf("%s:%d:%s", today, days, strings.Join(codes, ","))
+	s.mu.Lock()
+	cached, ok := s.cache[cacheKey]
+	s.mu.Unlock()
+	if ok && now.Before(cached.expireAt) {
+		return cached.data, nil
+	}
+
+	end := now.AddDate(0, 0, days).Format("2006-01-02")
+	quoted := make([]string, len(codes))
+	for i, code := range codes {
+		quoted[i] = `"` + code + `"`
+	}
+	filter := fmt.Sprintf(`(FREE_DATE>='%s')(FREE_DATE<='%s')(SECURITY_CODE in (%s))`, today, end, strings.Join(quoted, ","))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf(lockupURL, url.QueryEscape(filter)), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
+	req.Header.Set("Referer", "https://data.eastmoney.com/")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	items, err := parseLockupResponse(body, bySecurity, now)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	for key, c := range s.cache {
+		if !now.Before(c.expireAt) {
+			delete(s.cache, key)
+		}
+	}
+	s.cache[cacheKey] = &lockupCache{data: items, expireAt: now.Add(lockupCacheTTL)}
+	s.mu.Unlock()
+	return items, nil
+}
+
+type lockupItem struct {
+	Code        string  `json:"SECURITY_CODE"`
+	Name        string  `json:"SECURITY_NAME_ABBR"`
+	FreeDate    string  `json:"FREE_DATE"`
+	FreeShares  float64 `json:"CURRENT_FREE_SHARES"` // 本期解禁股数（万股）
+	FreeRatio   float64 `json:"FREE_RATIO"`          // 占解禁前流通股比例（小数）
+	MarketValue float64 `json:"LIFT_MARKET_CAP"`     // 解禁市值（元）
+	SharesType  string  `json:"FREE_SHARES_TYPE"`
+}
+
+// parseLockupResponse 解析限售解禁响应，bySecurity 将六位代码映射回带市场前缀的代码
+func parseLockupResponse(body []byte, bySecurity map[string]string, now time.Time) ([]models.LockupExpiry, error) {
+	items, err := parseDatacenter[lockupItem](body, "限售解禁数据")
+	if err != nil {
+		return nil, err
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	result := make([]models.LockupExpiry, 0, len(items))
+	for _, item := range items {
+		date := trimDate(item.FreeDate)
+		t, err := time.ParseInLocation("2006-01-02", date, now.Location())
+		if err != nil {
+			continue
+		}
+		symbol := bySecurity[item.Code]
+		if symbol == "" {
+			symbol = item.Code
+		}
+		result = append(result, models.LockupExpiry{
+			Symbol:      symbol,
+			Name:        item.Name,
+			Date:        date,
+			DaysUntil:   int(t.Sub(today).Hours() / 24),
+			Shares:      item.FreeShares * 1e4,
+			FloatRatio:  item.FreeRatio * 100,
+			MarketValue: item.MarketValue,
+			Type:        item.SharesType,
+		})
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Date < result[j].Date })
+	return result, nil
+}
+
+// Start 启动解禁提醒检查：每天首次检查时查询自选股的大额解禁
+func (s *LockupService) Start(ctx context.Context) {
+	supervisor.Go(ctx, "lockup-alert", func(ctx context.Context) {
+		s.checkAlerts(ctx)
+		ticker := time.NewTicker(lockupCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.checkAlerts(ctx)
+			}
+		}
+	})
+}
+
+// checkAlerts 检查自选股的大额解禁，每天最多一次，每个解禁事件只提醒一次
+func (s *LockupService) checkAlerts(ctx context.Context) {
+	cfg := s.configService.GetConfig().LockupAlert
+	if !cfg.Enabled {
+		return
+	}
+	today := s.now().In(time.FixedZone("CST", 8*60*60)).Format("2006-01-02")
+	s.mu.Lock()
+	if s.lastCheck == today {
+		s.mu.Unlock()
+		return
+	}
+	s.mu.Unlock()
+
+	var symbols []string
+	for _, st := range s.configService.GetWatchlist() {
+		symbols = append(symbols, st.Symbol)
+	}
+	days := cfg.DaysBefore
+	if days <= 0 {
+		days = defaultLockupAlertDays
+	}
+	items, err := s.GetLockupCalendar(ctx, symbols, days)
+	if err != nil {
+		log.Warn("检查限售解禁失败: %v", err)
+		return
+	}
+
+	s.mu.Lock()
+	s.lastCheck = today
+	due := s.dueAlertsLocked(items, cfg, today)
+	onAlert := s.onAlert
+	s.mu.Unlock()
+	if len(due) > 0 && onAlert != nil {
+		onAlert(due)
+	}
+}
+
+// dueAlertsLocked 筛选达到提醒阈值且未提醒过的解禁，并记录为已提醒
+func (s *LockupService) dueAlertsLocked(items []models.LockupExpiry, cfg models.LockupAlertConfig, today string) []models.LockupExpiry {
+	s.loadAlertsLocked()
+	minRatio := cfg.MinFloatRatio
+	if minRatio <= 0 {
+		minRatio = defaultLockupAlertMinRatio
+	}
+
+	changed := false
+	for key, date := range s.alerted {
+		if date < today {
+			delete(s.alerted, key)
+			changed = true
+		}
+	}
+	var due []models.LockupExpiry
+	for _, item := range items {
+		key := item.Symbol + "|" + item.Date
+		if item.FloatRatio < minRatio || s.alerted[key] != "" {
+			continue
+		}
+		s.alerted[key] = item.Date
+		due = append(due, item)
+		changed = true
+	}
+	if changed {
+		if data, err := json.Marshal(s.alerted); err == nil {
+			if err := writeFileTracked(s.alertsPath, data, 0644); err != nil {
+				log.Warn("保存解禁提醒记录失败: %v", err)
+			}
+		}
+	}
+	return due
+}
+
+func (s *LockupService) loadAlertsLocked() {
+	if s.loaded {
+		return
+	}
+	s.loaded = true
+	data, err := os.ReadFile(s.alertsPath)
+	if err != nil {
+		return
+	}
+	if err := json.Unmarshal(data, &s.alerted); err != nil || s.alerted == nil {
+		s.alerted = make(map[string]string)
+	}
+}
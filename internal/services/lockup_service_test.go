@@ -0,0 +1,68 @@
+package services
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// TestParseLockupResponse 测试解禁数据单位换算、代码映射与距今天数
+func TestParseLockupResponse(t *testing.T) {
+	body := []byte(`{"success":true,"result":{"data":[
+		{"SECURITY_CODE":"688111","SECURITY_NAME_ABBR":"金山办公","FREE_DATE":"2024-06-20 00:00:00","CURRENT_FREE_SHARES":1200.5,"FREE_RATIO":0.0832,"LIFT_MARKET_CAP":2800000000,"FREE_SHARES_TYPE":"首发原股东限售股份"},
+		{"SECURITY_CODE":"600519","SECURITY_NAME_ABBR":"贵州茅台","FREE_DATE":"2024-06-15 00:00:00","CURRENT_FREE_SHARES":10,"FREE_RATIO":0.001,"LIFT_MARKET_CAP":17000000,"FREE_SHARES_TYPE":"股权激励限售股份"},
+		{"SECURITY_CODE":"000001","FREE_DATE":"bad"}
+	]}}`)
+	cst := time.FixedZone("CST", 8*60*60)
+	now := time.Date(2024, 6, 13, 15, 30, 0, 0, cst)
+	items, err := parseLockupResponse(body, map[string]string{"688111": "sh688111", "600519": "sh600519"}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("items = %+v", items)
+	}
+	if items[0].Symbol != "sh600519" || items[0].Date != "2024-06-15" || items[0].DaysUntil != 2 {
+		t.Errorf("items[0] = %+v", items[0])
+	}
+	if items[1].Shares != 12005000 || items[1].FloatRatio < 8.31 || items[1].FloatRatio > 8.33 || items[1].DaysUntil != 7 {
+		t.Errorf("items[1] = %+v", items[1])
+	}
+
+	if items, err := parseLockupResponse([]byte(`{"success":false,"result":null}`), nil, now); err != nil || len(items) != 0 {
+		t.Errorf("无数据应返回空列表: %v %v", items, err)
+	}
+}
+
+// TestLockupDueAlerts 测试提醒阈值、同一解禁只提醒一次及过期记录清理
+func TestLockupDueAlerts(t *testing.T) {
+	dir := t.TempDir()
+	s := &LockupService{alertsPath: filepath.Join(dir, "lockup_alerts.json"), alerted: make(map[string]string)}
+	items := []models.LockupExpiry{
+		{Symbol: "sh688111", Date: "2024-06-20", FloatRatio: 8.3},
+		{Symbol: "sh600519", Date: "2024-06-15", FloatRatio: 0.1},
+	}
+	cfg := models.LockupAlertConfig{Enabled: true}
+
+	due := s.dueAlertsLocked(items, cfg, "2024-06-13")
+	if len(due) != 1 || due[0].Symbol != "sh688111" {
+		t.Fatalf("due = %+v", due)
+	}
+	if due := s.dueAlertsLocked(items, cfg, "2024-06-14"); len(due) != 0 {
+		t.Errorf("重复提醒: %+v", due)
+	}
+
+	// 重启后从文件恢复已提醒记录
+	restored := &LockupService{alertsPath: s.alertsPath, alerted: make(map[string]string)}
+	if due := restored.dueAlertsLocked(items, cfg, "2024-06-14"); len(due) != 0 {
+		t.Errorf("重启后重复提醒: %+v", due)
+	}
+
+	// 解禁日过后清理记录
+	restored.dueAlertsLocked(nil, cfg, "2024-06-21")
+	if len(restored.alerted) != 0 {
+		t.Errorf("过期记录未清理: %v", restored.alerted)
+	}
+}
@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+	"github.com/run-bigpig/jcp/internal/pkg/proxy"
+)
+
+// 东方财富融资融券API（按日期降序）
+const (
+	// 个股融资融券明细
+	marginStockURL = "https://datacenter-web.eastmoney.com/api/data/v1/get?reportName=RPTA_WEB_RZRQ_GGMX&columns=ALL&source=WEB&client=WEB&sortColumns=DATE&sortTypes=-1&pageNumber=1&pageSize=%d&filter=(SCODE%%3D%%22%s%%22)"
+	// 沪深两市融资融券汇总
+	marginMarketURL = "https://datacenter-web.eastmoney.com/api/data/v1/get?reportName=RPTA_RZRQ_LSHJ&columns=ALL&source=WEB&client=WEB&sortColumns=DIM_DATE&sortTypes=-1&pageNumber=1&pageSize=%d"
+)
+
+// 交易所于下一交易日早间公布上一交易日的两融数据
+const marginPublishHour = 9
+
+// marginCache 融资融券缓存，到下次公布时间失效
+type marginCache struct {
+	data     []models.MarginRecord
+	expireAt time.Time
+}
+
+// MarginService 融资融券服务
+type MarginService struct {
+	client        *http.Client
+	marketService *MarketService
+
+	cache   map[string]*marginCache
+	cacheMu sync.RWMutex
+}
+
+// NewMarginService 创建融资融券服务
+func NewMarginService(marketService *MarketService) *MarginService {
+	return &MarginService{
+		client:        proxy.GetManager().GetClientWithTimeout(15 * time.Second),
+		marketService: marketService,
+		cache:         make(map[string]*marginCache),
+	}
+}
+
+// GetMarginData 获取个股融资融券数据（按日期升序）
+// symbol: 股票代码，支持 sh600519 / 600519；days: 最近交易日数
+func (s *MarginService) GetMarginData(ctx context.Context, symbol string, days int) ([]models.MarginRecord, error) {
+	code := strings.ToLower(symbol)
+	for _, prefix := range []string{"sh", "sz", "bj"} {
+		code = strings.TrimPrefix(code, prefix)
+	}
+	if code == "" {
+		return nil, i18n.Errorf("error.symbol.required")
+	}
+	days = normalizeMarginDays(days)
+
+	cacheKey := fmt.Sprintf("stock:%s:%d", code, days)
+	if data, ok := s.getCache(cacheKey); ok {
+		return data, nil
+	}
+
+	records, err := s.fetch(ctx, fmt.Sprintf(marginStockURL, days, code))
+	if err != nil {
+		return nil, err
+	}
+	s.fillFinancingBuyRatio(ctx, symbol, records)
+
+	s.setCache(cacheKey, records)
+	return records, nil
+}
+
+// GetMarketMarginTrend 获取沪深两市融资融券余额走势（按日期升序）
+func (s *MarginService) GetMarketMarginTrend(ctx context.Context, days int) ([]models.MarginRecord, error) {
+	days = normalizeMarginDays(days)
+
+	cacheKey := fmt.Sprintf("market:%d", days)
+	if data, ok := s.getCache(cacheKey); ok {
+		return data, nil
+	}
+
+	records, err := s.fetch(ctx, fmt.Sprintf(marginMarketURL, days))
+	if err != nil {
+		return nil, err
+	}
+
+	s.setCache(cacheKey, records)
+	return records, nil
+}
+
+// fillFinancingBuyRatio 用日K成交额计算融资买入占比
+func (s *MarginService) fillFinancingBuyRatio(ctx context.Context, symbol string, records []models.MarginRecord) {
+	if s.marketService == nil || len(records) == 0 {
+		return
+	}
+	if !strings.HasPrefix(symbol, "sh") && !strings.HasPrefix(symbol, "sz") && !strings.HasPrefix(symbol, "bj") {
+		return // 无市场前缀时无法查询K线
+	}
+	klines, err := s.marketService.GetKLineData(ctx, symbol, "1d", len(records)+5)
+	if err != nil {
+		return
+	}
+	amounts := make(map[string]float64, len(klines))
+	for _, k := range klines {
+		amounts[k.Time] = k.Amount
+	}
+	for i := range records {
+		if amount := amounts[records[i].Date]; amount > 0 {
+			records[i].FinancingBuyRatio = records[i].FinancingBuy / amount * 100
+		}
+	}
+}
+
+func (s *MarginService) getCache(key string) ([]models.MarginRecord, bool) {
+	s.cacheMu.RLock()
+	defer s.cacheMu.RUnlock()
+	if cached, ok := s.cache[key]; ok && time.Now().Before(cached.expireAt) {
+		return cached.data, true
+	}
+	return nil, false
+}
+
+func (s *MarginService) setCache(key string, data []models.MarginRecord) {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	s.cache[key] = &marginCache{data: data, expireAt: nextMarginPublishTime(time.Now())}
+}
+
+// nextMarginPublishTime 下一次两融数据公布时间（当日或次日早间）
+func nextMarginPublishTime(now time.Time) time.Time {
+	publish := time.Date(now.Year(), now.Month(), now.Day(), marginPublishHour, 0, 0, 0, now.Location())
+	if !now.Before(publish) {
+		publish = publish.AddDate(0, 0, 1)
+	}
+	return publish
+}
+
+func normalizeMarginDays(days int) int {
+	if days <= 0 {
+		return 30
+	}
+	if days > 250 {
+		return 250
+	}
+	return days
+}
+
+// 东方财富两融API响应结构（个股与汇总字段基本一致）
+type marginAPIResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Result  *struct {
+		Data []marginAPIItem `json:"data"`
+	} `json:"result"`
+}
+
+type marginAPIItem struct {
+	Date     string  `json:"DATE"`
+	DimDate  string  `json:"DIM_DATE"`
+	Close    float64 `json:"SPJ"`
+	IndexNew float64 `json:"NEW"`
+	ZDF      float64 `json:"ZDF"`
+	RZYE     float64 `json:"RZYE"`
+	RZYEZB   float64 `json:"RZYEZB"`
+	RZMRE    float64 `json:"RZMRE"`
+	RZCHE    float64 `json:"RZCHE"`
+	RZJME    float64 `json:"RZJME"`
+	RQYE     float64 `json:"RQYE"`
+	RQYL     float64 `json:"RQYL"`
+	RZRQYE   float64 `json:"RZRQYE"`
+}
+
+// fetch 请求两融数据
+func (s *MarginService) fetch(ctx context.Context, url string) ([]models.MarginRecord, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
+	req.Header.Set("Referer", "https://data.eastmoney.com/")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return parseMarginResponse(body)
+}
+
+// parseMarginResponse 解析两融API响应，结果按日期升序
+func parseMarginResponse(body []byte) ([]models.MarginRecord, error) {
+	var resp marginAPIResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("解析融资融券数据失败: %w", err)
+	}
+	// 非两融标的或无数据
+	if !resp.Success || resp.Result == nil {
+		return []models.MarginRecord{}, nil
+	}
+
+	n := len(resp.Result.Data)
+	records := make([]models.MarginRecord, n)
+	for i, item := range resp.Result.Data {
+		date := item.Date
+		if date == "" {
+			date = item.DimDate
+		}
+		closePrice := item.Close
+		if closePrice == 0 {
+			closePrice = item.IndexNew
+		}
+		records[n-1-i] = models.MarginRecord{
+			Date:                  trimDate(date),
+			Close:                 closePrice,
+			ChangePercent:         item.ZDF,
+			FinancingBalance:      item.RZYE,
+			FinancingBuy:          item.RZMRE,
+			FinancingRepay:        item.RZCHE,
+			FinancingNetBuy:       item.RZJME,
+			SecuritiesBalance:     item.RQYE,
+			SecuritiesVolume:      item.RQYL,
+			TotalBalance:          item.RZRQYE,
+			FinancingBalanceRatio: item.RZYEZB,
+		}
+	}
+	return records, nil
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+// TestParseMarginResponse 测试个股与两市汇总两融数据解析，结果按日期升序
+func TestParseMarginResponse(t *testing.T) {
+	stock := []byte(`{"success":true,"message":"ok","result":{"data":[
+		{"DATE":"2026-02-09 00:00:00","SPJ":1688.5,"ZDF":1.25,"RZYE":18500000000,"RZYEZB":0.87,"RZMRE":520000000,"RZCHE":480000000,"RZJME":40000000,"RQYE":95000000,"RQYL":56000,"RZRQYE":18595000000},
+		{"DATE":"2026-02-06 00:00:00","SPJ":1667.7,"ZDF":-0.4,"RZYE":18460000000,"RZMRE":300000000}
+	]}}`)
+	records, err := parseMarginResponse(stock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[0].Date != "2026-02-06" || records[1].Date != "2026-02-09" {
+		t.Fatalf("records = %+v", records)
+	}
+	last := records[1]
+	if last.Close != 1688.5 || last.FinancingBalance != 18500000000 || last.FinancingBuy != 520000000 || last.FinancingNetBuy != 40000000 ||
+		last.SecuritiesVolume != 56000 || last.TotalBalance != 18595000000 || last.FinancingBalanceRatio != 0.87 {
+		t.Errorf("records[1] = %+v", last)
+	}
+
+	// 两市汇总使用 DIM_DATE 与指数点位 NEW
+	market := []byte(`{"success":true,"result":{"data":[{"DIM_DATE":"2026-02-09 00:00:00","NEW":3350.12,"ZDF":0.8,"RZYE":1850000000000}]}}`)
+	records, err = parseMarginResponse(market)
+	if err != nil || len(records) != 1 {
+		t.Fatalf("汇总解析失败: %+v %v", records, err)
+	}
+	if records[0].Date != "2026-02-09" || records[0].Close != 3350.12 {
+		t.Errorf("汇总 = %+v", records[0])
+	}
+
+	if records, err := parseMarginResponse([]byte(`{"success":false,"message":"返回数据为空","result":null}`)); err != nil || records == nil || len(records) != 0 {
+		t.Errorf("非两融标的应返回空列表: %v %v", records, err)
+	}
+	if _, err := parseMarginResponse([]byte(`<html>`)); err == nil {
+		t.Error("非 JSON 响应应返回错误")
+	}
+}
+
+// TestNextMarginPublishTime 测试两融缓存在次日早间公布时失效
+func TestNextMarginPublishTime(t *testing.T) {
+	cst := time.FixedZone("CST", 8*60*60)
+	cases := []struct{ now, want time.Time }{
+		{time.Date(2026, 2, 9, 8, 30, 0, 0, cst), time.Date(2026, 2, 9, 9, 0, 0, 0, cst)},
+		{time.Date(2026, 2, 9, 9, 0, 0, 0, cst), time.Date(2026, 2, 10, 9, 0, 0, 0, cst)},
+		{time.Date(2026, 2, 9, 20, 0, 0, 0, cst), time.Date(2026, 2, 10, 9, 0, 0, 0, cst)},
+	}
+	for _, c := range cases {
+		if got := nextMarginPublishTime(c.now); !got.Equal(c.want) {
+			t.Errorf("nextMarginPublishTime(%v) = %v, want %v", c.now, got, c.want)
+		}
+	}
+	for days, want := range map[int]int{0: 30, -1: 30, 60: 60, 1000: 250} {
+		if got := normalizeMarginDays(days); got != want {
+			t.Errorf("normalizeMarginDays(%d) = %d, want %d", days, got, want)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// 全市场A股筛选条件：深市主板、创业板、沪市主板、科创板、北交所
+const allAShareFilter = "m:0+t:6,m:0+t:80,m:1+t:2,m:1+t:23,m:0+t:81+s:2048"
+
+const marketBreadthCacheTTL = 10 * time.Second
+
+// breadthBucketBounds 涨跌幅分布区间边界(%)，涨停/跌停单独成桶
+var breadthBucketBounds = []float64{-7, -5, -3, 0, 3, 5, 7}
+
+// breadthBucketLabels 与分布直方图各桶一一对应
+var breadthBucketLabels = []string{
+	"跌停", "<-7%", "-7~-5%", "-5~-3%", "-3~0%", "平盘", "0~3%", "3~5%", "5~7%", ">7%", "涨停",
+}
+
+// marketBreadthCache 涨跌家数缓存
+type marketBreadthCache struct {
+	data      *models.MarketBreadth
+	timestamp time.Time
+}
+
+// GetMarketBreadth 获取全市场涨跌家数、涨跌停家数及涨跌幅分布
+func (ms *MarketService) GetMarketBreadth(ctx context.Context) (*models.MarketBreadth, error) {
+	ms.breadthCacheMu.RLock()
+	if ms.breadthCache != nil && time.Since(ms.breadthCache.timestamp) < marketBreadthCacheTTL {
+		data := ms.breadthCache.data
+		ms.breadthCacheMu.RUnlock()
+		return data, nil
+	}
+	ms.breadthCacheMu.RUnlock()
+
+	items, err := ms.fetchEastmoneyClist(ctx, allAShareFilter, 6000)
+	if err != nil {
+		return nil, err
+	}
+
+	breadth := calculateMarketBreadth(items)
+	breadth.UpdatedAt = time.Now().UnixMilli()
+
+	ms.breadthCacheMu.Lock()
+	ms.breadthCache = &marketBreadthCache{data: breadth, timestamp: time.Now()}
+	ms.breadthCacheMu.Unlock()
+
+	return breadth, nil
+}
+
+// calculateMarketBreadth 统计涨跌家数与涨跌幅分布
+func calculateMarketBreadth(items []emClistItem) *models.MarketBreadth {
+	counts := make([]int, len(breadthBucketLabels))
+	breadth := &models.MarketBreadth{}
+
+	for _, item := range items {
+		price := emFloat(item.Price)
+		preClose := emFloat(item.PreClose)
+		if price <= 0 || preClose <= 0 {
+			breadth.Suspended++
+			continue
+		}
+		changePercent := emFloat(item.ChangePercent)
+
+		up, down, limited := limitPrices(item.Code, item.Name, preClose)
+		switch {
+		case limited && price >= up:
+			breadth.LimitUp++
+			breadth.Advancers++
+			counts[len(counts)-1]++
+			continue
+		case limited && price <= down:
+			breadth.LimitDown++
+			breadth.Decliners++
+			counts[0]++
+			continue
+		case changePercent > 0:
+			breadth.Advancers++
+		case changePercent < 0:
+			breadth.Decliners++
+		default:
+			breadth.Unchanged++
+		}
+		counts[breadthBucketIndex(changePercent)]++
+	}
+
+	breadth.Distribution = make([]models.BreadthBucket, len(breadthBucketLabels))
+	for i, label := range breadthBucketLabels {
+		breadth.Distribution[i] = models.BreadthBucket{Label: label, Count: counts[i]}
+	}
+	return breadth
+}
+
+// breadthBucketIndex 返回非涨跌停个股所在的分布桶下标
+func breadthBucketIndex(changePercent float64) int {
+	switch {
+	case changePercent == 0:
+		return 5
+	case changePercent < 0:
+		// 负区间: <-7, -7~-5, -5~-3, -3~0 → 1..4
+		for i := 0; i < 3; i++ {
+			if changePercent < breadthBucketBounds[i] {
+				return i + 1
+			}
+		}
+		return 4
+	default:
+		// 正区间: 0~3, 3~5, 5~7, >7 → 6..9
+		for i := 4; i < len(breadthBucketBounds); i++ {
+			if changePercent <= breadthBucketBounds[i] {
+				return i + 2
+			}
+		}
+		return 9
+	}
+}
+
+// priceLimitRatio 按板块返回涨跌幅限制比例，0 表示无涨跌幅限制
+func priceLimitRatio(code, name string) float64 {
+	// 新股上市初期无涨跌幅限制
+	if newListingNoLimit(code, name) {
+		return 0
+	}
+	switch boardOf(code) {
+	case boardBSE:
+		return 0.30
+	case boardChiNext, boardSTAR:
+		return 0.20
+	}
+	if isSTName(name) {
+		return 0.05 // 主板风险警示股
+	}
+	return 0.10
+}
+
+// limitPrices 计算涨停价与跌停价，无涨跌幅限制或数据不足时 ok 为 false
+func limitPrices(code, name string, preClose float64) (up, down float64, ok bool) {
+	limit := priceLimitRatio(code, name)
+	if limit <= 0 || preClose <= 0 {
+		return 0, 0, false
+	}
+	return roundPrice(preClose * (1 + limit)), roundPrice(preClose * (1 - limit)), true
+}
+
+// roundPrice 按交易所规则四舍五入到分
+func roundPrice(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"testing"
+)
+
+// TestCalculateMarketBreadth 测试涨跌家数与涨跌停统计
+func TestCalculateMarketBreadth(t *testing.T) {
+	items := []emClistItem{
+		{Code: "600519", Name: "贵州茅台", Price: 11.0, PreClose: 10.0, ChangePercent: 10.0},
+		{Code: "300750", Name: "宁德时代", Price: 12.0, PreClose: 10.0, ChangePercent: 20.0},
+		{Code: "600000", Name: "ST浦发", Price: 9.5, PreClose: 10.0, ChangePercent: -5.0},
+		{Code: "000001", Name: "平安银行", Price: 10.2, PreClose: 10.0, ChangePercent: 2.0},
+		{Code: "000002", Name: "万科A", Price: 10.0, PreClose: 10.0, ChangePercent: 0.0},
+		{Code: "000003", Name: "停牌股", Price: "-", PreClose: 10.0, ChangePercent: "-"},
+	}
+
+	b := calculateMarketBreadth(items)
+	if b.Advancers != 3 || b.Decliners != 1 || b.Unchanged != 1 || b.Suspended != 1 {
+		t.Errorf("涨跌家数错误: %+v", b)
+	}
+	if b.LimitUp != 2 || b.LimitDown != 1 {
+		t.Errorf("涨跌停家数错误: 涨停%d 跌停%d", b.LimitUp, b.LimitDown)
+	}
+
+	counts := map[string]int{}
+	for _, bucket := range b.Distribution {
+		counts[bucket.Label] = bucket.Count
+	}
+	if counts["涨停"] != 2 || counts["跌停"] != 1 || counts["0~3%"] != 1 || counts["平盘"] != 1 {
+		t.Errorf("分布统计错误: %+v", b.Distribution)
+	}
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+const (
+	heatmapCacheTTL       = 30 * time.Second
+	heatmapStocksPerGroup = 20 // 全市场范围每个行业保留的个股数
+	heatmapUnknownSector  = "其他"
+)
+
+// heatmapCache 全市场行情快照缓存（热力图两种范围共用）
+type heatmapCache struct {
+	items     []emClistItem
+	timestamp time.Time
+}
+
+// GetMarketHeatmap 获取涨跌幅热力图
+// scope 为 market 时按行业汇总全市场；为 watchlist 时仅包含 symbols 中的个股
+func (ms *MarketService) GetMarketHeatmap(ctx context.Context, scope string, symbols []string) (*models.MarketHeatmap, error) {
+	items, err := ms.allShareSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := heatmapStocksPerGroup
+	if scope == models.HeatmapScopeWatchlist {
+		wanted := make(map[string]bool, len(symbols))
+		for _, s := range symbols {
+			wanted[s] = true
+		}
+		filtered := make([]emClistItem, 0, len(symbols))
+		for _, item := range items {
+			if wanted[emSymbol(item)] {
+				filtered = append(filtered, item)
+			}
+		}
+		items, limit = filtered, 0
+	} else {
+		scope = models.HeatmapScopeMarket
+	}
+
+	heatmap := buildHeatmap(items, limit)
+	heatmap.Scope = scope
+	heatmap.UpdatedAt = time.Now().UnixMilli()
+	return heatmap, nil
+}
+
+// allShareSnapshot 获取全市场A股行情快照（带缓存）
+func (ms *MarketService) allShareSnapshot(ctx context.Context) ([]emClistItem, error) {
+	ms.heatmapCacheMu.RLock()
+	if ms.heatmapCache != nil && time.Since(ms.heatmapCache.timestamp) < heatmapCacheTTL {
+		items := ms.heatmapCache.items
+		ms.heatmapCacheMu.RUnlock()
+		return items, nil
+	}
+	ms.heatmapCacheMu.RUnlock()
+
+	items, err := ms.fetchEastmoneyClist(ctx, allAShareFilter, 6000)
+	if err != nil {
+		return nil, err
+	}
+
+	ms.heatmapCacheMu.Lock()
+	ms.heatmapCache = &heatmapCache{items: items, timestamp: time.Now()}
+	ms.heatmapCacheMu.Unlock()
+	return items, nil
+}
+
+// buildHeatmap 按行业分组，行业涨跌幅按流通市值加权；limit > 0 时每个行业仅保留市值最大的 limit 只
+func buildHeatmap(items []emClistItem, limit int) *models.MarketHeatmap {
+	groups := map[string]*models.HeatmapSector{}
+	weighted := map[string]float64{}
+	for _, item := range items {
+		price, floatCap := emFloat(item.Price), emFloat(item.FloatCap)
+		if price <= 0 || floatCap <= 0 {
+			continue // 停牌或无市值数据
+		}
+		sector, _ := item.Industry.(string)
+		if sector == "" || sector == "-" {
+			sector = heatmapUnknownSector
+		}
+		g, ok := groups[sector]
+		if !ok {
+			g = &models.HeatmapSector{Name: sector}
+			groups[sector] = g
+		}
+		change := emFloat(item.ChangePercent)
+		g.Cap += floatCap
+		g.Count++
+		weighted[sector] += floatCap * change
+		g.Stocks = append(g.Stocks, models.HeatmapStock{
+			Symbol:        emSymbol(item),
+			Name:          item.Name,
+			Cap:           floatCap,
+			ChangePercent: change,
+		})
+	}
+
+	heatmap := &models.MarketHeatmap{Sectors: make([]models.HeatmapSector, 0, len(groups))}
+	for name, g := range groups {
+		g.ChangePercent = roundPrice(weighted[name] / g.Cap)
+		sort.Slice(g.Stocks, func(i, j int) bool { return g.Stocks[i].Cap > g.Stocks[j].Cap })
+		if limit > 0 && len(g.Stocks) > limit {
+			g.Stocks = g.Stocks[:limit]
+		}
+		heatmap.Sectors = append(heatmap.Sectors, *g)
+	}
+	sort.Slice(heatmap.Sectors, func(i, j int) bool { return heatmap.Sectors[i].Cap > heatmap.Sectors[j].Cap })
+	return heatmap
+}
+
+// emSymbol 东方财富条目转带市场前缀的代码（北交所与深圳同为市场 0）
+func emSymbol(item emClistItem) string {
+	switch {
+	case item.Market == 1:
+		return "sh" + item.Code
+	case isBSECode(item.Code):
+		return "bj" + item.Code
+	default:
+		return "sz" + item.Code
+	}
+}
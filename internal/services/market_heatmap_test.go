@@ -0,0 +1,29 @@
+package services
+
+import (
+	"testing"
+)
+
+// TestBuildHeatmap 测试行业分组、市值加权涨跌幅与个股截断
+func TestBuildHeatmap(t *testing.T) {
+	items := []emClistItem{
+		{Code: "600036", Market: 1, Name: "招商银行", Price: 35.0, ChangePercent: 2.0, FloatCap: 300.0, Industry: "银行"},
+		{Code: "000001", Market: 0, Name: "平安银行", Price: 10.0, ChangePercent: -1.0, FloatCap: 100.0, Industry: "银行"},
+		{Code: "300750", Market: 0, Name: "宁德时代", Price: 200.0, ChangePercent: 1.0, FloatCap: 800.0, Industry: "电池"},
+		{Code: "000003", Market: 0, Name: "停牌股", Price: "-", ChangePercent: "-", FloatCap: 50.0, Industry: "银行"},
+		{Code: "000004", Market: 0, Name: "新股", Price: 5.0, ChangePercent: 3.0, FloatCap: 10.0, Industry: "-"},
+	}
+
+	h := buildHeatmap(items, 1)
+	if len(h.Sectors) != 3 || h.Sectors[0].Name != "电池" || h.Sectors[2].Name != heatmapUnknownSector {
+		t.Fatalf("行业应按市值降序: %+v", h.Sectors)
+	}
+	bank := h.Sectors[1]
+	// (300*2 + 100*-1) / 400 = 1.25，停牌股不计入
+	if bank.Count != 2 || bank.Cap != 400 || bank.ChangePercent != 1.25 {
+		t.Errorf("银行汇总错误: %+v", bank)
+	}
+	if len(bank.Stocks) != 1 || bank.Stocks[0].Symbol != "sh600036" {
+		t.Errorf("应仅保留市值最大的 1 只: %+v", bank.Stocks)
+	}
+}
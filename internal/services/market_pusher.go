@@ -5,10 +5,15 @@ import (
 	"fmt"
 	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/run-bigpig/jcp/internal/logger"
 	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/coord"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+	"github.com/run-bigpig/jcp/internal/pkg/metrics"
+	"github.com/run-bigpig/jcp/internal/pkg/supervisor"
 
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
@@ -20,13 +25,22 @@ const (
 	EventStockUpdate         = "market:stock:update"
 	EventOrderBookUpdate     = "market:orderbook:update"
 	EventTelegraphUpdate     = "market:telegraph:update"
+	EventTelegraphAlert      = "market:telegraph:alert"
 	EventMarketIndicesUpdate = "market:indices:update"
+	EventMarketBreadthUpdate = "market:breadth:update"
+	EventMarketHeatmapUpdate = "market:heatmap:update"
+	EventInterestRatesUpdate = "market:rates:update"
+	EventGlobalIndicesUpdate = "market:global:update"
 	EventMarketSubscribe     = "market:subscribe"
 	EventOrderBookSubscribe  = "market:orderbook:subscribe"
 	EventKLineUpdate         = "market:kline:update"
 	EventKLineSubscribe      = "market:kline:subscribe"
+	EventReplay              = "market:replay"
 )
 
+// 快讯保留条数（其余事件通道仅保留最新一条）
+const telegraphRetainSize = 20
+
 // 推送频率常量
 const (
 	tickerFast     = 1 * time.Second  // 盘口（交易时段）
@@ -35,6 +49,28 @@ const (
 	tickerKLineDay = 5 * time.Minute  // 日/周/月K线
 )
 
+// DefaultPushIntervals 默认推送频率
+func DefaultPushIntervals() models.PushIntervals {
+	return models.PushIntervals{
+		FastMs:   int(tickerFast / time.Millisecond),
+		NormalMs: int(tickerNormal / time.Millisecond),
+		SlowMs:   int(tickerSlow / time.Millisecond),
+	}
+}
+
+// ValidatePushIntervals 校验推送频率，过高会触发上游限流，过低则失去实时性
+func ValidatePushIntervals(iv models.PushIntervals) error {
+	switch {
+	case iv.FastMs < 500 || iv.FastMs > 10_000:
+		return i18n.Errorf("error.push.orderbook_interval")
+	case iv.NormalMs < 1000 || iv.NormalMs > 60_000:
+		return i18n.Errorf("error.push.quote_interval")
+	case iv.SlowMs < 5000 || iv.SlowMs > 600_000:
+		return i18n.Errorf("error.push.telegraph_interval")
+	}
+	return nil
+}
+
 // safeCall 安全调用，捕获 panic 避免崩溃
 func safeCall(fn func()) {
 	defer func() {
@@ -66,14 +102,66 @@ type MarketDataPusher struct {
 	// K线订阅管理
 	klineSub      KLineSubscription
 	klineSubMu    sync.RWMutex
-	lastKLineTime int64 // 最后一根K线的时间戳，用于增量推送
+	lastKLineTime int64            // 最后一根K线的时间戳，用于增量推送
+	lastKLineBar  models.KLineData // 日/周/月K线最近推送的最后一根，作为增量对比基准
+	klineSeq      uint64           // K线推送序号，前端发现断号时重新订阅获取全量
 
 	// 快讯缓存（用于检测新快讯）
 	lastTelegraphContent string
+	// 上一轮快讯列表的内容哈希（用于检测新出现的关键词命中，nil 表示尚未拉取过）
+	seenTelegraphs map[string]bool
 
 	// 盘口缓存（用于diff检测）
 	lastOrderBookHash string
 
+	// 上一轮推送的自选股顺序（排序同值时保持稳定）
+	lastStockRank map[string]int
+	// 上一轮推送的自选股行情（托盘滚动行情使用）
+	lastStocks []models.Stock
+
+	// 行情观察者：额外拉取的代码（如条件单标的）与每轮行情回调
+	quoteCodes    func() []string
+	quoteObserver func(context.Context, []models.Stock)
+	// 快讯关键词提醒回调（如写入通知中心）
+	alertObserver func(Telegraph)
+	// 图表画线，随K线全量推送下发
+	drawings *DrawingService
+
+	// 订阅租约（前端心跳续期，过期订阅停止推送）
+	leases *subscriptionLeases
+
+	// 按股票活跃度自适应轮询
+	poller *adaptivePoller
+
+	// 事件保留缓冲区（供新挂载面板回放）
+	retained *EventBuffer
+
+	// 向前端推送事件（测试时替换）
+	emitEvent func(event string, data any)
+
+	// 盘中记录器（收盘复盘用）
+	recorder  *IntradayRecorder
+	recording atomic.Bool // 是否记录盘中数据
+
+	// 暂停推送（窗口隐藏或用户手动暂停时停止轮询上游）
+	paused         atomic.Bool
+	onPausedChange func(bool)
+
+	// 大列表推送使用紧凑编码（前端协商后开启）
+	compact atomic.Bool
+
+	// 推送频率（运行时可调整，变更后通知推送循环重置 ticker）
+	intervals        models.PushIntervals
+	intervalsMu      sync.RWMutex
+	intervalsChanged chan struct{}
+
+	// 多实例协调：follower 不轮询共享数据，改为接收 leader 的快照
+	coordinator *coord.Coordinator
+
+	// 订阅上下文：按订阅类型（自选股、盘口、K线）分别维护，某类订阅变更只中断该类进行中的请求
+	subCtxs  map[string]subscriptionCtx
+	subCtxMu sync.Mutex
+
 	// 控制
 	stopChan  chan struct{}
 	stopped   bool
@@ -85,16 +173,228 @@ type MarketDataPusher struct {
 	pushMu sync.Mutex
 }
 
+// eventsEmit 默认的前端事件推送（测试时替换为桩）
+var eventsEmit = runtime.EventsEmit
+
 // NewMarketDataPusher 创建市场数据推送服务
 func NewMarketDataPusher(marketService *MarketService, configService *ConfigService, newsService *NewsService) *MarketDataPusher {
-	return &MarketDataPusher{
-		marketService:   marketService,
-		configService:   configService,
-		newsService:     newsService,
-		subscribedCodes: make([]string, 0),
-		stopChan:        make(chan struct{}),
-		readyChan:       make(chan struct{}),
+	retained := NewEventBuffer()
+	retained.SetRetainSize(EventTelegraphUpdate, telegraphRetainSize)
+	retained.SetRetainSize(EventTelegraphAlert, telegraphRetainSize)
+	p := &MarketDataPusher{
+		marketService:    marketService,
+		configService:    configService,
+		newsService:      newsService,
+		subscribedCodes:  make([]string, 0),
+		subCtxs:          make(map[string]subscriptionCtx),
+		leases:           newSubscriptionLeases(),
+		poller:           newAdaptivePoller(),
+		retained:         retained,
+		recorder:         NewIntradayRecorder(),
+		intervals:        DefaultPushIntervals(),
+		intervalsChanged: make(chan struct{}, 1),
+		stopChan:         make(chan struct{}),
+		readyChan:        make(chan struct{}),
 	}
+	p.emitEvent = func(event string, data any) { eventsEmit(p.ctx, event, data) }
+	p.recording.Store(true)
+	metrics.NewGaugeFunc("jcp_event_retained", "事件保留缓冲区中的消息数", func() float64 {
+		return float64(retained.Len())
+	})
+	metrics.NewGaugeFunc("jcp_subscribed_symbols", "自选股行情订阅数", func() float64 {
+		p.mu.RLock()
+		defer p.mu.RUnlock()
+		return float64(len(p.subscribedCodes))
+	})
+	metrics.NewGaugeFunc("jcp_quiet_symbols", "行情平静而降频拉取的股票数", func() float64 {
+		return float64(p.poller.Quiet())
+	})
+	return p
+}
+
+// SetQuoteObserver 设置行情观察者，codes 返回需额外拉取的代码（不推送到前端），
+// fn 在每轮行情拉取后以全部行情回调
+func (p *MarketDataPusher) SetQuoteObserver(codes func() []string, fn func(context.Context, []models.Stock)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.quoteCodes = codes
+	p.quoteObserver = fn
+}
+
+// SetTelegraphAlertObserver 设置快讯关键词提醒回调，每条新命中的快讯回调一次
+func (p *MarketDataPusher) SetTelegraphAlertObserver(fn func(Telegraph)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.alertObserver = fn
+}
+
+// SetDrawingService 设置画线服务，K线全量推送时附带该股票该周期的画线
+func (p *MarketDataPusher) SetDrawingService(ds *DrawingService) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.drawings = ds
+}
+
+// chartDrawings 获取K线订阅对应的画线，未设置画线服务时为空
+func (p *MarketDataPusher) chartDrawings(code, period string) []models.ChartDrawing {
+	p.mu.RLock()
+	ds := p.drawings
+	p.mu.RUnlock()
+	if ds == nil {
+		return []models.ChartDrawing{}
+	}
+	return ds.GetDrawings(code, period)
+}
+
+// emit 推送事件并保留最近消息
+func (p *MarketDataPusher) emit(event string, data any) {
+	p.retained.Retain(event, data)
+	p.emitEvent(event, data)
+}
+
+// replay 向前端回放指定事件通道的保留消息，未指定则回放全部
+func (p *MarketDataPusher) replay(events ...string) {
+	if len(events) == 0 {
+		events = p.retained.Events()
+	}
+	for _, event := range events {
+		for _, data := range p.retained.Replay(event) {
+			p.emitEvent(event, data)
+		}
+	}
+}
+
+// sharedEvents 多实例间共享的事件（与自选股、订阅无关的全市场数据）
+var sharedEvents = map[string]bool{
+	EventTelegraphUpdate:     true,
+	EventTelegraphAlert:      true,
+	EventMarketIndicesUpdate: true,
+	EventMarketBreadthUpdate: true,
+	EventMarketHeatmapUpdate: true,
+	EventInterestRatesUpdate: true,
+	EventGlobalIndicesUpdate: true,
+}
+
+// SetCoordinator 设置多实例协调器（需在 Start 前调用）
+func (p *MarketDataPusher) SetCoordinator(c *coord.Coordinator) {
+	p.coordinator = c
+	c.OnSnapshot(func(s coord.Snapshot) {
+		if sharedEvents[s.Event] {
+			p.emit(s.Event, s.Data)
+		}
+	})
+}
+
+// isFollower 当前实例是否为 follower
+func (p *MarketDataPusher) isFollower() bool {
+	return p.coordinator != nil && p.coordinator.IsFollower()
+}
+
+// emitShared 推送共享事件，leader 同时广播给其他实例
+func (p *MarketDataPusher) emitShared(event string, data any) {
+	p.emit(event, data)
+	if p.coordinator != nil {
+		p.coordinator.Publish(event, data)
+	}
+}
+
+// Recorder 获取盘中记录器
+func (p *MarketDataPusher) Recorder() *IntradayRecorder {
+	return p.recorder
+}
+
+// SetRecording 开启或关闭盘中记录（关闭期间已记录的数据保留）
+func (p *MarketDataPusher) SetRecording(enabled bool) {
+	p.recording.Store(enabled)
+}
+
+// RecordingEnabled 是否正在记录盘中数据
+func (p *MarketDataPusher) RecordingEnabled() bool {
+	return p.recording.Load()
+}
+
+// shouldRecord 交易时段且开启记录时才写入记录器
+func (p *MarketDataPusher) shouldRecord() bool {
+	return p.recording.Load() && p.getMarketPhase() == "trading"
+}
+
+// SetOnPausedChange 设置暂停状态变化回调（前端按钮、托盘菜单等多处切换时保持同步）
+func (p *MarketDataPusher) SetOnPausedChange(fn func(paused bool)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.onPausedChange = fn
+}
+
+// SetPaused 暂停或恢复行情推送，恢复时立即推送一次自选股与指数
+func (p *MarketDataPusher) SetPaused(paused bool) {
+	if p.paused.Swap(paused) == paused {
+		return
+	}
+	p.mu.RLock()
+	onChange := p.onPausedChange
+	p.mu.RUnlock()
+	if onChange != nil {
+		onChange(paused)
+	}
+	if paused {
+		pusherLog.Info("行情推送已暂停")
+		return
+	}
+	pusherLog.Info("行情推送已恢复")
+	p.leases.RenewAll()
+	p.RefreshStockData()
+	p.RefreshMarketIndices()
+}
+
+// Paused 行情推送是否已暂停
+func (p *MarketDataPusher) Paused() bool {
+	return p.paused.Load()
+}
+
+// SetEncoding 设置大列表推送编码（json/compact），不支持的编码回退为 json，返回实际生效的编码
+func (p *MarketDataPusher) SetEncoding(encoding string) string {
+	compact := encoding == PushEncodingCompact
+	p.compact.Store(compact)
+	if compact {
+		return PushEncodingCompact
+	}
+	return PushEncodingJSON
+}
+
+// encoding 当前推送编码
+func (p *MarketDataPusher) encoding() string {
+	if p.compact.Load() {
+		return PushEncodingCompact
+	}
+	return PushEncodingJSON
+}
+
+// GetPushIntervals 获取当前推送频率
+func (p *MarketDataPusher) GetPushIntervals() models.PushIntervals {
+	p.intervalsMu.RLock()
+	defer p.intervalsMu.RUnlock()
+	return p.intervals
+}
+
+// SetPushIntervals 调整推送频率，推送循环在下一次 select 时生效
+func (p *MarketDataPusher) SetPushIntervals(iv models.PushIntervals) error {
+	if err := ValidatePushIntervals(iv); err != nil {
+		return err
+	}
+	p.intervalsMu.Lock()
+	p.intervals = iv
+	p.intervalsMu.Unlock()
+
+	select {
+	case p.intervalsChanged <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// GetRetainedEvents 获取指定事件通道保留的消息
+func (p *MarketDataPusher) GetRetainedEvents(event string) []any {
+	return p.retained.Replay(event)
 }
 
 // Start 启动推送服务
@@ -106,10 +406,11 @@ func (p *MarketDataPusher) Start(ctx context.Context) {
 	}
 	p.ctx = ctx
 	p.ctrlMu.Unlock()
+	p.cancelSubscriptionContexts()
 
 	p.setupEventListeners()
 	p.initSubscriptions()
-	go p.pushLoop()
+	supervisor.Go(ctx, "market-pusher", func(context.Context) { p.pushLoop() })
 }
 
 // SetReady 设置前端已准备好，开始推送数据
@@ -120,6 +421,8 @@ func (p *MarketDataPusher) SetReady() {
 		return
 	}
 	p.ready = true
+	// 前端刚就绪，给心跳留出完整的租约期
+	p.leases.RenewAll()
 	close(p.readyChan)
 	pusherLog.Info("前端已就绪，开始推送数据")
 }
@@ -133,30 +436,98 @@ func (p *MarketDataPusher) Stop() {
 	}
 	p.stopped = true
 	close(p.stopChan)
+	p.cancelSubscriptionContexts()
 	// 清理事件监听
 	runtime.EventsOff(p.ctx, EventMarketSubscribe)
 	runtime.EventsOff(p.ctx, EventOrderBookSubscribe)
 	runtime.EventsOff(p.ctx, EventKLineSubscribe)
+	runtime.EventsOff(p.ctx, EventReplay)
+	runtime.EventsOff(p.ctx, EventSubscriptionHeartbeat)
+}
+
+// subscriptionCtx 某类订阅的上下文
+type subscriptionCtx struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// baseContext 推送服务的根上下文，启动前为 Background
+func (p *MarketDataPusher) baseContext() context.Context {
+	p.ctrlMu.Lock()
+	defer p.ctrlMu.Unlock()
+	if p.ctx == nil {
+		return context.Background()
+	}
+	return p.ctx
+}
+
+// resetSubscriptionContext 某类订阅（LeaseStocks/LeaseOrderBook/LeaseKLine）变更时取消该类进行中的请求，并创建新的上下文
+func (p *MarketDataPusher) resetSubscriptionContext(kind string) {
+	parent := p.baseContext()
+	p.subCtxMu.Lock()
+	defer p.subCtxMu.Unlock()
+	if sc, ok := p.subCtxs[kind]; ok {
+		sc.cancel()
+	}
+	ctx, cancel := context.WithCancel(parent)
+	p.subCtxs[kind] = subscriptionCtx{ctx: ctx, cancel: cancel}
+}
+
+// subscriptionContext 获取某类订阅的当前上下文
+func (p *MarketDataPusher) subscriptionContext(kind string) context.Context {
+	parent := p.baseContext()
+	p.subCtxMu.Lock()
+	defer p.subCtxMu.Unlock()
+	sc, ok := p.subCtxs[kind]
+	if !ok {
+		ctx, cancel := context.WithCancel(parent)
+		sc = subscriptionCtx{ctx: ctx, cancel: cancel}
+		p.subCtxs[kind] = sc
+	}
+	return sc.ctx
+}
+
+// cancelSubscriptionContexts 取消全部订阅上下文（启动与停止时调用）
+func (p *MarketDataPusher) cancelSubscriptionContexts() {
+	p.subCtxMu.Lock()
+	defer p.subCtxMu.Unlock()
+	for kind, sc := range p.subCtxs {
+		sc.cancel()
+		delete(p.subCtxs, kind)
+	}
+}
+
+// withSubscription 派生同时受 ctx 与某类订阅上下文约束的上下文，订阅变更时中断本轮该类请求
+func (p *MarketDataPusher) withSubscription(ctx context.Context, kind string) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(p.subscriptionContext(kind), cancel)
+	return ctx, func() {
+		stop()
+		cancel()
+	}
 }
 
 // setupEventListeners 设置事件监听
 func (p *MarketDataPusher) setupEventListeners() {
 	// 监听订阅请求
-	runtime.EventsOn(p.ctx, EventMarketSubscribe, func(data ...any) {
-		if len(data) > 0 {
-			if codes, ok := data[0].([]any); ok {
-				p.updateSubscriptions(codes)
-			}
-		}
-	})
+	runtime.EventsOn(p.ctx, EventMarketSubscribe, p.onMarketSubscribe)
 
 	// 监听盘口订阅请求
 	runtime.EventsOn(p.ctx, EventOrderBookSubscribe, func(data ...any) {
 		if len(data) > 0 {
 			if code, ok := data[0].(string); ok {
 				p.mu.Lock()
+				changed := p.currentOrderBook != code
 				p.currentOrderBook = code
 				p.mu.Unlock()
+				p.leases.Grant(LeaseOrderBook)
+				// 切换股票后旧盘口不再有效，相同股票则立即回放
+				if changed {
+					p.resetSubscriptionContext(LeaseOrderBook)
+					p.retained.Clear(EventOrderBookUpdate)
+				} else {
+					p.replay(EventOrderBookUpdate)
+				}
 			}
 		}
 	})
@@ -170,20 +541,49 @@ func (p *MarketDataPusher) setupEventListeners() {
 				p.klineSubMu.Lock()
 				p.klineSub = KLineSubscription{Code: code, Period: period}
 				p.lastKLineTime = 0 // 重置增量时间戳
+				p.lastKLineBar = models.KLineData{}
 				p.klineSubMu.Unlock()
-				go safeCall(p.pushKLineData)
+				p.leases.Grant(LeaseKLine)
+				p.resetSubscriptionContext(LeaseKLine)
+				p.retained.Clear(EventKLineUpdate)
+				ctx := p.subscriptionContext(LeaseKLine)
+				go safeCall(func() { p.pushKLineData(ctx) })
 			}
 		}
 	})
+
+	// 监听回放请求：面板挂载时请求指定事件通道的最近消息
+	runtime.EventsOn(p.ctx, EventReplay, func(data ...any) {
+		p.replay(stringArgs(data)...)
+	})
+
+	// 监听订阅心跳：续期租约
+	runtime.EventsOn(p.ctx, EventSubscriptionHeartbeat, func(data ...any) {
+		p.Heartbeat(stringArgs(data)...)
+	})
 }
 
-// initSubscriptions 从自选股初始化订阅
-func (p *MarketDataPusher) initSubscriptions() {
-	watchlist := p.configService.GetWatchlist()
-	codes := make([]string, len(watchlist))
-	for i, stock := range watchlist {
-		codes[i] = stock.Symbol
+// stringArgs 解析事件参数中的字符串，支持多个字符串参数或单个字符串数组
+func stringArgs(data []any) []string {
+	var result []string
+	for _, d := range data {
+		switch v := d.(type) {
+		case string:
+			result = append(result, v)
+		case []any:
+			for _, e := range v {
+				if s, ok := e.(string); ok {
+					result = append(result, s)
+				}
+			}
+		}
 	}
+	return result
+}
+
+// initSubscriptions 从当前自选分组初始化订阅
+func (p *MarketDataPusher) initSubscriptions() {
+	codes := p.configService.GetActiveGroupSymbols()
 
 	p.mu.Lock()
 	p.subscribedCodes = codes
@@ -192,19 +592,37 @@ func (p *MarketDataPusher) initSubscriptions() {
 		p.currentOrderBook = codes[0]
 	}
 	p.mu.Unlock()
+	p.leases.Grant(LeaseStocks)
+	p.leases.Grant(LeaseOrderBook)
+}
+
+// onMarketSubscribe 处理自选股订阅请求，新订阅方立即收到最近一次行情
+// 自选股变化后保留的行情属于旧列表，丢弃后等待下一轮推送
+func (p *MarketDataPusher) onMarketSubscribe(data ...any) {
+	if len(data) > 0 {
+		if codes, ok := data[0].([]any); ok && p.updateSubscriptions(codes) {
+			p.retained.Clear(EventStockUpdate)
+		}
+	}
+	p.replay(EventStockUpdate, EventMarketIndicesUpdate, EventMarketBreadthUpdate, EventMarketHeatmapUpdate, EventInterestRatesUpdate, EventGlobalIndicesUpdate)
 }
 
-// updateSubscriptions 更新订阅列表
-func (p *MarketDataPusher) updateSubscriptions(codes []any) {
+// updateSubscriptions 更新订阅列表，返回订阅的股票是否变化
+func (p *MarketDataPusher) updateSubscriptions(codes []any) bool {
+	p.resetSubscriptionContext(LeaseStocks)
+	p.leases.Grant(LeaseStocks)
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	p.subscribedCodes = make([]string, 0, len(codes))
+	subscribed := make([]string, 0, len(codes))
 	for _, code := range codes {
 		if s, ok := code.(string); ok {
-			p.subscribedCodes = append(p.subscribedCodes, s)
+			subscribed = append(subscribed, s)
 		}
 	}
+	changed := !slices.Equal(p.subscribedCodes, subscribed)
+	p.subscribedCodes = subscribed
+	return changed
 }
 
 // pushLoop 数据推送循环（并行推送 + 超时控制 + 时段感知）
@@ -217,19 +635,22 @@ func (p *MarketDataPusher) pushLoop() {
 		return
 	}
 
-	fastTicker := time.NewTicker(tickerFast)
-	normalTicker := time.NewTicker(tickerNormal)
-	slowTicker := time.NewTicker(tickerSlow)
+	iv := p.GetPushIntervals()
+	fastTicker := time.NewTicker(time.Duration(iv.FastMs) * time.Millisecond)
+	normalTicker := time.NewTicker(time.Duration(iv.NormalMs) * time.Millisecond)
+	slowTicker := time.NewTicker(time.Duration(iv.SlowMs) * time.Millisecond)
 	klineDayTicker := time.NewTicker(tickerKLineDay)
+	leaseTicker := time.NewTicker(leaseCheckInterval)
 
 	defer fastTicker.Stop()
 	defer normalTicker.Stop()
 	defer slowTicker.Stop()
 	defer klineDayTicker.Stop()
+	defer leaseTicker.Stop()
 
 	// 立即并行推送一次（启动时5个并发请求，冷启动给足时间）
 	p.runParallel(15*time.Second, p.pushStockData, p.pushOrderBookData,
-		p.pushTelegraphData, p.pushMarketIndices, p.pushKLineData)
+		p.pushTelegraphData, p.pushMarketIndices, p.pushMarketBreadth, p.pushKLineData)
 
 	var normalCount int
 
@@ -237,6 +658,12 @@ func (p *MarketDataPusher) pushLoop() {
 		select {
 		case <-p.stopChan:
 			return
+		case <-p.intervalsChanged:
+			iv := p.GetPushIntervals()
+			fastTicker.Reset(time.Duration(iv.FastMs) * time.Millisecond)
+			normalTicker.Reset(time.Duration(iv.NormalMs) * time.Millisecond)
+			slowTicker.Reset(time.Duration(iv.SlowMs) * time.Millisecond)
+			pusherLog.Info("推送频率已调整: 盘口%dms 行情%dms 快讯%dms", iv.FastMs, iv.NormalMs, iv.SlowMs)
 		case <-fastTicker.C:
 			status := p.getMarketPhase()
 			// 仅交易时段高频推送盘口
@@ -250,7 +677,7 @@ func (p *MarketDataPusher) pushLoop() {
 			switch status {
 			case "trading":
 				// 交易时段：正常频率
-				p.runParallel(8*time.Second, p.pushStockData, p.pushMarketIndices, p.pushKLineMinute)
+				p.runParallel(8*time.Second, p.pushStockData, p.pushMarketIndices, p.pushMarketBreadth, p.pushKLineMinute)
 			case "pre_market":
 				// 集合竞价：推送盘口（虚拟撮合价）和股票，降频
 				if normalCount%3 == 0 {
@@ -259,32 +686,40 @@ func (p *MarketDataPusher) pushLoop() {
 			case "lunch_break":
 				// 午休：低频推送
 				if normalCount%5 == 0 {
-					p.runParallel(8*time.Second, p.pushStockData, p.pushMarketIndices)
+					p.runParallel(8*time.Second, p.pushStockData, p.pushMarketIndices, p.pushMarketBreadth)
 				}
 			default:
 				// 收盘：30秒一次
 				if normalCount%10 == 0 {
 					p.runParallel(8*time.Second, p.pushStockData, p.pushMarketIndices,
-						p.pushOrderBookData, p.pushKLineData)
+						p.pushMarketBreadth, p.pushOrderBookData, p.pushKLineData)
 				}
 			}
 		case <-slowTicker.C:
-			p.runParallel(8*time.Second, p.pushTelegraphData)
+			p.runParallel(8*time.Second, p.pushTelegraphData, p.pushMarketHeatmap, p.pushInterestRates, p.pushGlobalIndices)
 		case <-klineDayTicker.C:
 			if p.getMarketPhase() == "trading" {
 				p.runParallel(8*time.Second, p.pushKLineDay)
 			}
+		case <-leaseTicker.C:
+			p.expireLeases()
 		}
 	}
 }
 
 // runParallel 带超时的并行执行，防止协程堆积
-// 使用 TryLock 防止重入：上一轮未完成则跳过本轮
-func (p *MarketDataPusher) runParallel(timeout time.Duration, fns ...func()) {
+// 使用 TryLock 防止重入：上一轮未完成则跳过本轮，暂停推送时直接跳过
+// 超时或订阅变更时取消 ctx，中断进行中的 HTTP 请求
+func (p *MarketDataPusher) runParallel(timeout time.Duration, fns ...func(context.Context)) {
+	if p.paused.Load() {
+		return
+	}
 	if !p.pushMu.TryLock() {
 		// 上一轮推送还未完成，跳过本轮避免 goroutine 堆积
+		pushSkipped.Inc()
 		return
 	}
+	start := time.Now()
 	var unlockOnce sync.Once
 	unlock := func() {
 		unlockOnce.Do(func() {
@@ -292,12 +727,14 @@ func (p *MarketDataPusher) runParallel(timeout time.Duration, fns ...func()) {
 		})
 	}
 
+	ctx, cancel := context.WithTimeout(p.baseContext(), timeout)
+
 	var wg sync.WaitGroup
 	wg.Add(len(fns))
 	for _, fn := range fns {
-		go func(f func()) {
+		go func(f func(context.Context)) {
 			defer wg.Done()
-			safeCall(f)
+			safeCall(func() { f(ctx) })
 		}(fn)
 	}
 
@@ -309,9 +746,13 @@ func (p *MarketDataPusher) runParallel(timeout time.Duration, fns ...func()) {
 
 	select {
 	case <-done:
+		cancel()
 		unlock()
+		pushRoundSeconds.ObserveSince(start)
 	case <-time.After(timeout):
-		pusherLog.Warn("推送超时，后台等待当前轮次结束后再释放锁")
+		pusherLog.Warn("推送超时，取消进行中的请求，后台等待当前轮次结束后再释放锁")
+		pushTimeouts.Inc()
+		cancel()
 		// 超时后不阻塞调用方，但保持锁直到本轮任务结束，避免重入
 		go func() {
 			<-done
@@ -326,27 +767,93 @@ func (p *MarketDataPusher) getMarketPhase() string {
 }
 
 // pushStockData 推送股票实时数据
-func (p *MarketDataPusher) pushStockData() {
+func (p *MarketDataPusher) pushStockData(ctx context.Context) {
+	ctx, cancel := p.withSubscription(ctx, LeaseStocks)
+	defer cancel()
 	p.mu.RLock()
 	codes := make([]string, len(p.subscribedCodes))
 	copy(codes, p.subscribedCodes)
+	quoteCodes, observer := p.quoteCodes, p.quoteObserver
 	p.mu.RUnlock()
 
+	// 多实例共享数据目录，仅 leader 回调观察者，避免条件单等被重复执行
+	if p.isFollower() {
+		quoteCodes, observer = nil, nil
+	}
+
+	subscribed := len(codes)
+	if quoteCodes != nil {
+		for _, code := range quoteCodes() {
+			if !slices.Contains(codes, code) {
+				codes = append(codes, code)
+			}
+		}
+	}
 	if len(codes) == 0 {
 		return
 	}
 
-	stocks, err := p.marketService.GetStockRealTimeData(codes...)
-	if err != nil {
+	// 平静股票降频拉取，其余轮次沿用上次行情
+	fetch, cached := p.poller.Select(codes)
+	var stocks []models.Stock
+	if len(fetch) > 0 {
+		fresh, err := p.marketService.GetStockRealTimeData(ctx, fetch...)
+		if err != nil {
+			return
+		}
+		if changes := p.poller.Observe(fresh); len(changes) > 0 {
+			pusherLog.Info("股票交易状态变化: %+v", changes)
+			p.emitEvent(EventStockStatus, changes)
+		}
+		if observer != nil {
+			observer(ctx, fresh)
+		}
+		stocks = fresh
+	}
+	if len(cached) > 0 {
+		// 合并后恢复订阅顺序
+		stocks = append(stocks, cached...)
+		order := make(map[string]int, len(codes))
+		for i, code := range codes {
+			order[code] = i
+		}
+		slices.SortStableFunc(stocks, func(a, b models.Stock) int { return order[a.Symbol] - order[b.Symbol] })
+	}
+	if len(codes) > subscribed {
+		stocks = slices.DeleteFunc(stocks, func(s models.Stock) bool {
+			return !slices.Contains(codes[:subscribed], s.Symbol)
+		})
+	}
+	if len(stocks) == 0 {
 		return
 	}
 
+	// 填充目标价/止损价跟踪线，交易时段记录当日接近情况
+	applyPriceTargets(stocks, p.configService.GetPriceTargets())
+	applyLimitPrices(stocks)
+	markStaleQuotes(stocks, time.Now(), p.getMarketPhase() == "trading")
+	if p.shouldRecord() {
+		p.recorder.RecordTargetDistances(stocks)
+	}
+
+	// 后端排序，减轻前端大列表重排压力
+	p.mu.Lock()
+	stocks = sortWatchlist(stocks, p.configService.GetConfig().WatchlistSort, p.lastStockRank)
+	p.lastStockRank = make(map[string]int, len(stocks))
+	for i, s := range stocks {
+		p.lastStockRank[s.Symbol] = i
+	}
+	p.lastStocks = stocks
+	p.mu.Unlock()
+
 	// 推送到前端
-	runtime.EventsEmit(p.ctx, EventStockUpdate, stocks)
+	p.emit(EventStockUpdate, encodeRows(p.encoding(), stocks))
 }
 
 // pushOrderBookData 推送盘口数据（带diff检测）
-func (p *MarketDataPusher) pushOrderBookData() {
+func (p *MarketDataPusher) pushOrderBookData(ctx context.Context) {
+	ctx, cancel := p.withSubscription(ctx, LeaseOrderBook)
+	defer cancel()
 	p.mu.RLock()
 	code := p.currentOrderBook
 	lastHash := p.lastOrderBookHash
@@ -356,7 +863,7 @@ func (p *MarketDataPusher) pushOrderBookData() {
 		return
 	}
 
-	orderBook, err := p.marketService.GetRealOrderBook(code)
+	orderBook, err := p.marketService.GetRealOrderBook(ctx, code)
 	if err != nil {
 		return
 	}
@@ -371,20 +878,25 @@ func (p *MarketDataPusher) pushOrderBookData() {
 	p.lastOrderBookHash = hash
 	p.mu.Unlock()
 
-	runtime.EventsEmit(p.ctx, EventOrderBookUpdate, orderBook)
+	if p.shouldRecord() {
+		p.recorder.RecordOrderBook(code, orderBook)
+	}
+	p.emit(EventOrderBookUpdate, orderBook)
 }
 
 // pushTelegraphData 推送快讯数据
-func (p *MarketDataPusher) pushTelegraphData() {
-	if p.newsService == nil {
+func (p *MarketDataPusher) pushTelegraphData(ctx context.Context) {
+	if p.newsService == nil || p.isFollower() {
 		return
 	}
 
-	telegraphs, err := p.newsService.GetTelegraphList()
+	telegraphs, err := p.newsService.GetTelegraphList(ctx)
 	if err != nil || len(telegraphs) == 0 {
 		return
 	}
 
+	p.pushTelegraphAlerts(telegraphs)
+
 	// 获取最新一条快讯
 	latest := telegraphs[0]
 
@@ -398,20 +910,132 @@ func (p *MarketDataPusher) pushTelegraphData() {
 	p.mu.Unlock()
 
 	// 推送到前端
-	runtime.EventsEmit(p.ctx, EventTelegraphUpdate, latest)
+	p.emitShared(EventTelegraphUpdate, latest)
+}
+
+// pushTelegraphAlerts 推送新出现的关键词命中快讯
+// 首次拉取只记录不提醒，避免启动时对历史快讯集中提醒；已出现过的快讯在关键词变更后也不再提醒
+func (p *MarketDataPusher) pushTelegraphAlerts(telegraphs []Telegraph) {
+	seen := make(map[string]bool, len(telegraphs))
+	for _, t := range telegraphs {
+		seen[telegraphHash(t.Content)] = true
+	}
+
+	p.mu.Lock()
+	prev := p.seenTelegraphs
+	p.seenTelegraphs = seen
+	observer := p.alertObserver
+	p.mu.Unlock()
+	if prev == nil {
+		return
+	}
+
+	// 按时间正序推送，前端按到达顺序插入
+	for i := len(telegraphs) - 1; i >= 0; i-- {
+		t := telegraphs[i]
+		if len(t.Keywords) > 0 && !prev[telegraphHash(t.Content)] {
+			p.emitShared(EventTelegraphAlert, t)
+			if observer != nil {
+				observer(t)
+			}
+		}
+	}
 }
 
 // pushMarketIndices 推送大盘指数
-func (p *MarketDataPusher) pushMarketIndices() {
-	indices, err := p.marketService.GetMarketIndices()
+func (p *MarketDataPusher) pushMarketIndices(ctx context.Context) {
+	if p.isFollower() {
+		return
+	}
+	indices, err := p.marketService.GetMarketIndices(ctx)
+	if err != nil {
+		return
+	}
+	p.emitShared(EventMarketIndicesUpdate, indices)
+}
+
+// pushMarketBreadth 推送全市场涨跌家数统计
+func (p *MarketDataPusher) pushMarketBreadth(ctx context.Context) {
+	if p.isFollower() {
+		return
+	}
+	breadth, err := p.marketService.GetMarketBreadth(ctx)
+	if err != nil {
+		return
+	}
+	p.emitShared(EventMarketBreadthUpdate, breadth)
+}
+
+// pushMarketHeatmap 推送全市场行业热力图（慢速频率，非交易时段仅在无缓存时推送一次）
+func (p *MarketDataPusher) pushMarketHeatmap(ctx context.Context) {
+	if p.isFollower() {
+		return
+	}
+	if _, ok := p.retained.Latest(EventMarketHeatmapUpdate); ok && p.getMarketPhase() != "trading" {
+		return
+	}
+	heatmap, err := p.marketService.GetMarketHeatmap(ctx, models.HeatmapScopeMarket, nil)
+	if err != nil {
+		return
+	}
+	p.emitShared(EventMarketHeatmapUpdate, heatmap)
+}
+
+// pushInterestRates 推送资金面利率（慢速频率，非交易时段仅在无缓存时推送一次）
+func (p *MarketDataPusher) pushInterestRates(ctx context.Context) {
+	if p.isFollower() {
+		return
+	}
+	if _, ok := p.retained.Latest(EventInterestRatesUpdate); ok && p.getMarketPhase() != "trading" {
+		return
+	}
+	rates, err := p.marketService.GetInterestRates(ctx)
 	if err != nil {
 		return
 	}
-	runtime.EventsEmit(p.ctx, EventMarketIndicesUpdate, indices)
+	p.emitShared(EventInterestRatesUpdate, rates)
+}
+
+// pushGlobalIndices 推送全球主要指数（慢速频率，海外市场夜间交易时段 A 股已收盘）
+func (p *MarketDataPusher) pushGlobalIndices(ctx context.Context) {
+	if p.isFollower() {
+		return
+	}
+	indices, err := p.marketService.GetGlobalIndices(ctx)
+	if err != nil {
+		return
+	}
+	p.emitShared(EventGlobalIndicesUpdate, indices)
+}
+
+// RefreshStockData 立即推送一次自选股行情（排序配置变更后调用）
+func (p *MarketDataPusher) RefreshStockData() {
+	p.ctrlMu.Lock()
+	ready := p.ready && !p.stopped
+	p.ctrlMu.Unlock()
+	if !ready {
+		return
+	}
+	ctx := p.subscriptionContext(LeaseStocks)
+	go safeCall(func() { p.pushStockData(ctx) })
+}
+
+// RefreshMarketIndices 立即推送一次大盘指数（指数配置变更后调用）
+func (p *MarketDataPusher) RefreshMarketIndices() {
+	p.ctrlMu.Lock()
+	ready := p.ready && !p.stopped
+	p.ctrlMu.Unlock()
+	if !ready {
+		return
+	}
+	ctx := p.baseContext()
+	go safeCall(func() { p.pushMarketIndices(ctx) })
 }
 
 // pushKLineData 推送K线数据（初始化时调用）
-func (p *MarketDataPusher) pushKLineData() {
+func (p *MarketDataPusher) pushKLineData(ctx context.Context) {
+	ctx, cancel := p.withSubscription(ctx, LeaseKLine)
+	defer cancel()
 	p.klineSubMu.RLock()
 	sub := p.klineSub
 	p.klineSubMu.RUnlock()
@@ -420,20 +1044,37 @@ func (p *MarketDataPusher) pushKLineData() {
 		return
 	}
 
-	klines, err := p.marketService.GetKLineData(sub.Code, sub.Period, 240)
+	klines, err := p.marketService.GetKLineData(ctx, sub.Code, sub.Period, 240)
 	if err != nil {
 		return
 	}
 
-	runtime.EventsEmit(p.ctx, EventKLineUpdate, map[string]any{
-		"code":   sub.Code,
-		"period": sub.Period,
-		"data":   klines,
+	p.klineSubMu.Lock()
+	if p.klineSub != sub {
+		// 拉取期间订阅已切换
+		p.klineSubMu.Unlock()
+		return
+	}
+	p.klineSeq++
+	seq := p.klineSeq
+	if len(klines) > 0 {
+		p.lastKLineBar = klines[len(klines)-1]
+	}
+	p.klineSubMu.Unlock()
+
+	p.emit(EventKLineUpdate, map[string]any{
+		"code":     sub.Code,
+		"period":   sub.Period,
+		"data":     encodeRows(p.encoding(), klines),
+		"seq":      seq,
+		"drawings": p.chartDrawings(sub.Code, sub.Period),
 	})
 }
 
 // pushKLineMinute 推送分时K线（增量模式，仅推送最新1根）
-func (p *MarketDataPusher) pushKLineMinute() {
+func (p *MarketDataPusher) pushKLineMinute(ctx context.Context) {
+	ctx, cancel := p.withSubscription(ctx, LeaseKLine)
+	defer cancel()
 	p.klineSubMu.RLock()
 	sub := p.klineSub
 	lastTime := p.lastKLineTime
@@ -444,7 +1085,7 @@ func (p *MarketDataPusher) pushKLineMinute() {
 	}
 
 	// 只获取最新几根用于增量判断
-	klines, err := p.marketService.GetKLineData(sub.Code, "1m", 5)
+	klines, err := p.marketService.GetKLineData(ctx, sub.Code, "1m", 5)
 	if err != nil || len(klines) == 0 {
 		return
 	}
@@ -459,7 +1100,7 @@ func (p *MarketDataPusher) pushKLineMinute() {
 
 	// 首次或时间变化才推送
 	if lastTime == 0 || latestTime != lastTime {
-		runtime.EventsEmit(p.ctx, EventKLineUpdate, map[string]any{
+		p.emitEvent(EventKLineUpdate, map[string]any{
 			"code":        sub.Code,
 			"period":      "1m",
 			"data":        []models.KLineData{latest},
@@ -488,8 +1129,33 @@ func orderBookHash(ob models.OrderBook) string {
 	return fmt.Sprintf("%.2f:%.0f:%.2f:%.0f", b1Price, b1Size, a1Price, a1Size)
 }
 
-// pushKLineDay 推送日/周/月K线（5分钟间隔，仅当订阅周期非1m时推送）
-func (p *MarketDataPusher) pushKLineDay() {
+// klineDeltaBars 日/周/月K线增量轮询拉取的根数
+// 需覆盖上次推送的最后一根，长时间休眠后缺口超过该值时回退为全量刷新
+const klineDeltaBars = 5
+
+// klineDelta 对比上次推送的最后一根K线，返回需要增量推送的K线（变化的最后一根及其后新增的）
+// full 为 true 表示无法增量（尚未全量推送或基准已不在本次数据中），需要全量刷新
+func klineDelta(last models.KLineData, klines []models.KLineData) (delta []models.KLineData, full bool) {
+	if last.Time == "" {
+		return nil, true
+	}
+	for i := len(klines) - 1; i >= 0; i-- {
+		if klines[i].Time != last.Time {
+			continue
+		}
+		if klines[i] == last {
+			i++
+		}
+		return klines[i:], false
+	}
+	return nil, true
+}
+
+// pushKLineDay 增量推送日/周/月K线（5分钟间隔，仅当订阅周期非1m时推送）
+// 只推送相对上次有变化的K线并附带序号，无法增量时回退为全量推送
+func (p *MarketDataPusher) pushKLineDay(ctx context.Context) {
+	ctx, cancel := p.withSubscription(ctx, LeaseKLine)
+	defer cancel()
 	p.klineSubMu.RLock()
 	sub := p.klineSub
 	p.klineSubMu.RUnlock()
@@ -499,18 +1165,51 @@ func (p *MarketDataPusher) pushKLineDay() {
 		return
 	}
 
-	klines, err := p.marketService.GetKLineData(sub.Code, sub.Period, 120)
+	klines, err := p.marketService.GetKLineData(ctx, sub.Code, sub.Period, klineDeltaBars)
 	if err != nil {
 		return
 	}
 
-	runtime.EventsEmit(p.ctx, EventKLineUpdate, map[string]any{
-		"code":   sub.Code,
-		"period": sub.Period,
-		"data":   klines,
+	p.klineSubMu.Lock()
+	if p.klineSub != sub {
+		p.klineSubMu.Unlock()
+		return
+	}
+	delta, full := klineDelta(p.lastKLineBar, klines)
+	if full {
+		p.klineSubMu.Unlock()
+		p.pushKLineData(ctx)
+		return
+	}
+	if len(delta) == 0 {
+		p.klineSubMu.Unlock()
+		return
+	}
+	p.klineSeq++
+	seq := p.klineSeq
+	p.lastKLineBar = delta[len(delta)-1]
+	p.klineSubMu.Unlock()
+
+	// 增量消息不进入保留缓冲区，回放时始终拿到全量数据
+	p.emitEvent(EventKLineUpdate, map[string]any{
+		"code":        sub.Code,
+		"period":      sub.Period,
+		"data":        delta,
+		"incremental": true,
+		"seq":         seq,
 	})
 }
 
+// SetSubscriptions 替换订阅列表（切换自选分组时调用），并立即推送一次
+func (p *MarketDataPusher) SetSubscriptions(codes []string) {
+	p.resetSubscriptionContext(LeaseStocks)
+	p.leases.Grant(LeaseStocks)
+	p.mu.Lock()
+	p.subscribedCodes = slices.Clone(codes)
+	p.mu.Unlock()
+	p.RefreshStockData()
+}
+
 // AddSubscription 添加订阅
 func (p *MarketDataPusher) AddSubscription(code string) {
 	p.mu.Lock()
@@ -534,8 +1233,15 @@ func (p *MarketDataPusher) RemoveSubscription(code string) {
 	}
 }
 
+// LatestStocks 最近一轮推送的自选股行情（按推送顺序），尚未推送时为空
+func (p *MarketDataPusher) LatestStocks() []models.Stock {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return slices.Clone(p.lastStocks)
+}
+
 // GetSubscribedStocks 获取当前订阅的股票数据
-func (p *MarketDataPusher) GetSubscribedStocks() []models.Stock {
+func (p *MarketDataPusher) GetSubscribedStocks(ctx context.Context) []models.Stock {
 	p.mu.RLock()
 	codes := make([]string, len(p.subscribedCodes))
 	copy(codes, p.subscribedCodes)
@@ -545,6 +1251,6 @@ func (p *MarketDataPusher) GetSubscribedStocks() []models.Stock {
 		return []models.Stock{}
 	}
 
-	stocks, _ := p.marketService.GetStockRealTimeData(codes...)
+	stocks, _ := p.marketService.GetStockRealTimeData(ctx, codes...)
 	return stocks
 }
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// TestKLineDelta 测试日K增量对比：仅推送变化的最后一根及新增K线
+func TestKLineDelta(t *testing.T) {
+	bars := []models.KLineData{
+		{Time: "2024-01-02", Close: 10},
+		{Time: "2024-01-03", Close: 11},
+		{Time: "2024-01-04", Close: 12},
+	}
+
+	if _, full := klineDelta(models.KLineData{}, bars); !full {
+		t.Error("无基准时应全量推送")
+	}
+	if delta, full := klineDelta(bars[2], bars); full || len(delta) != 0 {
+		t.Errorf("无变化时不应推送: %v %v", delta, full)
+	}
+
+	// 盘中最后一根价格变化
+	last := bars[2]
+	last.Close = 11.5
+	if delta, full := klineDelta(last, bars); full || len(delta) != 1 || delta[0].Close != 12 {
+		t.Errorf("最后一根变化应推送 1 根: %v %v", delta, full)
+	}
+
+	// 新交易日：上次最后一根未变，只推送新增
+	if delta, full := klineDelta(bars[1], bars); full || len(delta) != 1 || delta[0].Time != "2024-01-04" {
+		t.Errorf("新增K线应只推送新增部分: %v %v", delta, full)
+	}
+
+	// 基准已滚出本次数据（长时间休眠）
+	if _, full := klineDelta(models.KLineData{Time: "2023-12-01"}, bars); !full {
+		t.Error("基准不在数据中时应全量刷新")
+	}
+}
+
+// TestSetPaused 测试暂停推送：跳过轮询、重复设置不重复回调
+func TestSetPaused(t *testing.T) {
+	p := NewMarketDataPusher(nil, nil, nil)
+	var changes []bool
+	p.SetOnPausedChange(func(paused bool) { changes = append(changes, paused) })
+
+	if p.Paused() {
+		t.Fatal("默认不应暂停")
+	}
+	p.SetPaused(true)
+	p.SetPaused(true)
+	if !p.Paused() || len(changes) != 1 || !changes[0] {
+		t.Fatalf("暂停后 Paused=%v changes=%v", p.Paused(), changes)
+	}
+
+	ran := false
+	p.runParallel(time.Second, func(context.Context) { ran = true })
+	if ran {
+		t.Error("暂停期间不应执行推送")
+	}
+
+	// 尚未就绪时恢复不会触发立即推送
+	p.SetPaused(false)
+	if p.Paused() || len(changes) != 2 || changes[1] {
+		t.Fatalf("恢复后 Paused=%v changes=%v", p.Paused(), changes)
+	}
+	p.runParallel(time.Second, func(context.Context) { ran = true })
+	if !ran {
+		t.Error("恢复后应执行推送")
+	}
+}
+
+func TestSubscriptionContextsIndependent(t *testing.T) {
+	p := NewMarketDataPusher(nil, nil, nil)
+	stocksCtx, stopStocks := p.withSubscription(context.Background(), LeaseStocks)
+	defer stopStocks()
+	klineCtx, stopKLine := p.withSubscription(context.Background(), LeaseKLine)
+	defer stopKLine()
+
+	// 切换K线订阅只中断K线请求
+	p.resetSubscriptionContext(LeaseKLine)
+	select {
+	case <-klineCtx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("K线订阅变更后进行中的K线请求应被取消")
+	}
+	if stocksCtx.Err() != nil {
+		t.Error("K线订阅变更不应取消自选股请求")
+	}
+	if p.subscriptionContext(LeaseKLine).Err() != nil {
+		t.Error("新的K线订阅上下文不应已取消")
+	}
+
+	p.cancelSubscriptionContexts()
+	select {
+	case <-stocksCtx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("停止推送后全部订阅请求应被取消")
+	}
+}
+
+// TestSetPushIntervals 测试运行时调整推送频率
+func TestSetPushIntervals(t *testing.T) {
+	p := NewMarketDataPusher(nil, nil, nil)
+	if err := p.SetPushIntervals(models.PushIntervals{FastMs: 100, NormalMs: 3000, SlowMs: 30000}); err == nil {
+		t.Error("过快的盘口间隔应校验失败")
+	}
+	if got := p.GetPushIntervals(); got != DefaultPushIntervals() {
+		t.Errorf("校验失败时不应修改频率, got %+v", got)
+	}
+
+	iv := models.PushIntervals{FastMs: 2000, NormalMs: 5000, SlowMs: 60000}
+	if err := p.SetPushIntervals(iv); err != nil {
+		t.Fatalf("SetPushIntervals 失败: %v", err)
+	}
+	if got := p.GetPushIntervals(); got != iv {
+		t.Errorf("GetPushIntervals = %+v, want %+v", got, iv)
+	}
+	select {
+	case <-p.intervalsChanged:
+	default:
+		t.Error("调整频率后应通知推送循环")
+	}
+}
+
+// TestMarketSubscribeReplay 测试自选股订阅变化后丢弃旧列表的行情，不变时立即回放
+func TestMarketSubscribeReplay(t *testing.T) {
+	p := NewMarketDataPusher(nil, nil, nil)
+	var emitted []string
+	p.emitEvent = func(event string, data any) { emitted = append(emitted, event) }
+	t.Cleanup(p.cancelSubscriptionContexts)
+
+	p.onMarketSubscribe([]any{"sh600519"})
+	p.emit(EventStockUpdate, "sh600519")
+	p.emit(EventMarketIndicesUpdate, "indices")
+
+	emitted = nil
+	p.onMarketSubscribe([]any{"sh600519"})
+	if !slices.Equal(emitted, []string{EventStockUpdate, EventMarketIndicesUpdate}) {
+		t.Errorf("订阅不变时应回放行情与指数: %v", emitted)
+	}
+
+	emitted = nil
+	p.onMarketSubscribe([]any{"sz000001"})
+	if !slices.Equal(emitted, []string{EventMarketIndicesUpdate}) {
+		t.Errorf("订阅变化后不应回放旧列表的行情: %v", emitted)
+	}
+	if _, ok := p.retained.Latest(EventStockUpdate); ok {
+		t.Error("订阅变化后应丢弃保留的行情")
+	}
+}
+
+// TestDefaultEmitEvent 测试默认推送钩子直接调用运行时推送，不递归调用自身
+func TestDefaultEmitEvent(t *testing.T) {
+	var calls []string
+	var gotCtx context.Context
+	orig := eventsEmit
+	eventsEmit = func(ctx context.Context, event string, data ...any) {
+		gotCtx = ctx
+		calls = append(calls, event)
+	}
+	t.Cleanup(func() { eventsEmit = orig })
+
+	p := NewMarketDataPusher(nil, nil, nil)
+	ctx := context.WithValue(context.Background(), struct{}{}, "wails")
+	p.ctx = ctx
+	p.emit(EventStockUpdate, "sh600519")
+	if !slices.Equal(calls, []string{EventStockUpdate}) {
+		t.Fatalf("默认钩子应调用运行时推送一次: %v", calls)
+	}
+	if gotCtx != ctx {
+		t.Error("默认钩子应使用推送服务的上下文")
+	}
+}
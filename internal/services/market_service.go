@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -13,11 +14,16 @@ import (
 	"strings"
 	"sync"
 	"time"
+	"unsafe"
 
+	"github.com/run-bigpig/jcp/internal/embed"
 	"github.com/run-bigpig/jcp/internal/logger"
 	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+	"github.com/run-bigpig/jcp/internal/pkg/lru"
 	"github.com/run-bigpig/jcp/internal/pkg/paths"
 	"github.com/run-bigpig/jcp/internal/pkg/proxy"
+	"github.com/run-bigpig/jcp/internal/pkg/supervisor"
 
 	"golang.org/x/text/encoding/simplifiedchinese"
 	"golang.org/x/text/transform"
@@ -29,6 +35,7 @@ var log = logger.New("market")
 var (
 	sinaStockRegex = regexp.MustCompile(`var hq_str_(\w+)="([^"]*)"`)
 	sinaIndexRegex = regexp.MustCompile(`var hq_str_s_(\w+)="([^"]*)"`)
+	sinaIntlRegex  = regexp.MustCompile(`var hq_str_(int_\w+)="([^"]*)"`)
 )
 
 const (
@@ -41,11 +48,34 @@ const (
 	klineCacheTTLDefault  = 30 * time.Second
 )
 
-// 默认大盘指数代码
-var defaultIndexCodes = []string{
-	"s_sh000001", // 上证指数
-	"s_sz399001", // 深证成指
-	"s_sz399006", // 创业板指
+// DefaultIndexCodes 默认大盘指数代码
+var DefaultIndexCodes = []string{
+	"sh000001", // 上证指数
+	"sz399001", // 深证成指
+	"sz399006", // 创业板指
+}
+
+// MarketIndexOption 可选大盘指数
+type MarketIndexOption struct {
+	Code   string `json:"code"`   // 指数代码，A股如 sh000001，海外如 int_dji
+	Name   string `json:"name"`   // 指数名称
+	Region string `json:"region"` // 所属市场: cn/hk/us
+}
+
+// AvailableMarketIndices 支持配置的大盘指数列表
+var AvailableMarketIndices = []MarketIndexOption{
+	{Code: "sh000001", Name: "上证指数", Region: "cn"},
+	{Code: "sz399001", Name: "深证成指", Region: "cn"},
+	{Code: "sz399006", Name: "创业板指", Region: "cn"},
+	{Code: "sh000688", Name: "科创50", Region: "cn"},
+	{Code: "sh000016", Name: "上证50", Region: "cn"},
+	{Code: "sh000300", Name: "沪深300", Region: "cn"},
+	{Code: "sh000905", Name: "中证500", Region: "cn"},
+	{Code: "sh000852", Name: "中证1000", Region: "cn"},
+	{Code: "int_hangseng", Name: "恒生指数", Region: "hk"},
+	{Code: "int_dji", Name: "道琼斯", Region: "us"},
+	{Code: "int_nasdaq", Name: "纳斯达克", Region: "us"},
+	{Code: "int_sp500", Name: "标普500", Region: "us"},
 }
 
 // StockWithOrderBook 包含盘口数据的股票信息
@@ -67,10 +97,41 @@ type klineCache struct {
 	ttl       time.Duration
 }
 
+// 行情与K线缓存上限：长时间运行、浏览大量股票时按 LRU 淘汰
+const (
+	quoteCacheMaxEntries = 256
+	quoteCacheMaxBytes   = 8 << 20
+	klineCacheMaxEntries = 512
+	klineCacheMaxBytes   = 64 << 20
+)
+
+var (
+	stockWithOrderBookSize = int64(unsafe.Sizeof(StockWithOrderBook{}))
+	klineDataSize          = int64(unsafe.Sizeof(models.KLineData{}))
+)
+
+// size 估算行情缓存占用字节数（结构体大小 + 字符串内容）
+func (c *stockCache) size() int64 {
+	n := int64(unsafe.Sizeof(*c))
+	for i := range c.data {
+		n += stockWithOrderBookSize + int64(len(c.data[i].Symbol)+len(c.data[i].Name))
+	}
+	return n
+}
+
+// size 估算K线缓存占用字节数（结构体大小 + 时间字符串）
+func (c *klineCache) size() int64 {
+	n := int64(unsafe.Sizeof(*c))
+	for i := range c.data {
+		n += klineDataSize + int64(len(c.data[i].Time))
+	}
+	return n
+}
+
 // MarketStatus 市场交易状态
 type MarketStatus struct {
 	Status      string `json:"status"`      // trading, closed, pre_market, lunch_break
-	StatusText  string `json:"statusText"`  // 中文状态描述
+	StatusText  string `json:"statusText"`  // 状态描述（按界面语言）
 	IsTradeDay  bool   `json:"isTradeDay"`  // 是否交易日
 	HolidayName string `json:"holidayName"` // 节假日名称（如有）
 }
@@ -78,7 +139,7 @@ type MarketStatus struct {
 // TradingPeriod 交易时段
 type TradingPeriod struct {
 	Status    string `json:"status"`    // 状态标识
-	Text      string `json:"text"`      // 中文描述
+	Text      string `json:"text"`      // 描述（按界面语言）
 	StartTime string `json:"startTime"` // 开始时间 HH:MM
 	EndTime   string `json:"endTime"`   // 结束时间 HH:MM
 }
@@ -87,6 +148,7 @@ type TradingPeriod struct {
 type TradingSchedule struct {
 	IsTradeDay  bool            `json:"isTradeDay"`  // 今天是否交易日
 	HolidayName string          `json:"holidayName"` // 节假日名称
+	ClosedText  string          `json:"closedText"`  // 非交易日的状态描述
 	Periods     []TradingPeriod `json:"periods"`     // 时段列表
 }
 
@@ -94,37 +156,134 @@ type TradingSchedule struct {
 type MarketService struct {
 	client *http.Client
 
-	// 股票数据缓存
-	cache    map[string]*stockCache
-	cacheMu  sync.RWMutex
+	// 股票数据缓存（LRU，按条数与估算字节数限制）
+	cache    *lru.Cache[string, *stockCache]
 	cacheTTL time.Duration
 
-	// K线数据缓存
-	klineCache    map[string]*klineCache
-	klineCacheMu  sync.RWMutex
+	// K线数据缓存（LRU，按条数与估算字节数限制）
+	klineCache    *lru.Cache[string, *klineCache]
 	klineCacheTTL time.Duration
+
+	// 指数成分股缓存
+	indexConsCache   map[string]*indexConstituentsCache
+	indexConsCacheMu sync.RWMutex
+
+	// 全市场涨跌家数缓存
+	breadthCache   *marketBreadthCache
+	breadthCacheMu sync.RWMutex
+
+	// 热力图全市场快照缓存
+	heatmapCache   *heatmapCache
+	heatmapCacheMu sync.RWMutex
+
+	// 资金面利率缓存
+	ratesCache   *interestRatesCache
+	ratesCacheMu sync.RWMutex
+
+	// 大盘指数配置
+	indexCodes   []string
+	indexCodesMu sync.RWMutex
+
+	// HTTP 重试策略
+	retryPolicy   RetryPolicy
+	retryPolicyMu sync.RWMutex
+
+	// 远程数据引擎（为空时直连上游）
+	remote   RemoteMarketSource
+	remoteMu sync.RWMutex
+
+	// 关闭时停止缓存清理
+	stopClean context.CancelFunc
 }
 
 // NewMarketService 创建市场数据服务
 func NewMarketService() *MarketService {
 	ms := &MarketService{
-		client:        proxy.GetManager().GetClientWithTimeout(5 * time.Second),
-		cache:         make(map[string]*stockCache),
-		cacheTTL:      2 * time.Second, // 股票缓存2秒
-		klineCache:    make(map[string]*klineCache),
-		klineCacheTTL: klineCacheTTLDefault, // 日/周/月K使用较长缓存，减少API调用
-	}
+		client:         proxy.GetManager().GetClientWithTimeout(5 * time.Second),
+		cache:          lru.New[string](quoteCacheMaxEntries, quoteCacheMaxBytes, (*stockCache).size),
+		cacheTTL:       2 * time.Second, // 股票缓存2秒
+		klineCache:     lru.New[string](klineCacheMaxEntries, klineCacheMaxBytes, (*klineCache).size),
+		klineCacheTTL:  klineCacheTTLDefault, // 日/周/月K使用较长缓存，减少API调用
+		indexConsCache: make(map[string]*indexConstituentsCache),
+		indexCodes:     DefaultIndexCodes,
+		retryPolicy:    DefaultRetryPolicy,
+	}
+	cacheGauges("quotes", ms.cache.Len, ms.cache.Bytes, ms.cache.Evictions)
+	cacheGauges("kline", ms.klineCache.Len, ms.klineCache.Bytes, ms.klineCache.Evictions)
 	// 启动缓存清理协程
-	go ms.cleanCacheLoop()
+	cleanCtx, stopClean := context.WithCancel(context.Background())
+	ms.stopClean = stopClean
+	supervisor.Go(cleanCtx, "market-cache-clean", ms.cleanCacheLoop)
 	return ms
 }
 
+// TrimCaches 内存紧张时将行情与K线缓存收缩到一半，返回释放的估算字节数
+func (ms *MarketService) TrimCaches() int64 {
+	return ms.cache.Shrink(ms.cache.Bytes()/2) + ms.klineCache.Shrink(ms.klineCache.Bytes()/2)
+}
+
+// GetRetryPolicy 获取当前行情请求的重试策略
+func (ms *MarketService) GetRetryPolicy() RetryPolicy {
+	ms.retryPolicyMu.RLock()
+	defer ms.retryPolicyMu.RUnlock()
+	return ms.retryPolicy
+}
+
+// SetRetryPolicy 设置行情请求的重试策略
+func (ms *MarketService) SetRetryPolicy(policy RetryPolicy) {
+	ms.retryPolicyMu.Lock()
+	defer ms.retryPolicyMu.Unlock()
+	ms.retryPolicy = policy
+}
+
+// do 按当前重试策略发送请求，并记录数据源健康状态（连续失败时熔断）
+func (ms *MarketService) do(source string, req *http.Request) (*http.Response, error) {
+	if err := dataSourceHealth.Allow(source); err != nil {
+		return nil, err
+	}
+
+	ms.retryPolicyMu.RLock()
+	policy := ms.retryPolicy
+	ms.retryPolicyMu.RUnlock()
+
+	start := time.Now()
+	resp, err := doWithRetry(ms.client, req, policy)
+	if err != nil && req.Context().Err() != nil {
+		// 调用方主动取消（推送超时、订阅变更）不计入数据源失败
+		dataSourceHealth.Abort(source)
+		return nil, err
+	}
+	dataSourceHealth.Record(source, time.Since(start), err)
+	fetchSeconds(source).ObserveSince(start)
+	if err != nil {
+		fetchErrors(source).Inc()
+	}
+	return resp, err
+}
+
+// GetDataSourceHealth 获取各上游数据源的健康状态
+func (ms *MarketService) GetDataSourceHealth() []SourceHealth {
+	return dataSourceHealth.Snapshot()
+}
+
+// Close 停止缓存清理并释放缓存
+func (ms *MarketService) Close() {
+	ms.stopClean()
+	ms.cache.Shrink(0)
+	ms.klineCache.Shrink(0)
+}
+
 // cleanCacheLoop 定期清理过期缓存，防止内存泄漏
-func (ms *MarketService) cleanCacheLoop() {
+func (ms *MarketService) cleanCacheLoop(ctx context.Context) {
 	ticker := time.NewTicker(30 * time.Second)
 	defer ticker.Stop()
-	for range ticker.C {
-		ms.cleanExpiredCache()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			ms.cleanExpiredCache()
+		}
 	}
 }
 
@@ -133,27 +292,28 @@ func (ms *MarketService) cleanExpiredCache() {
 	now := time.Now()
 
 	// 清理股票缓存
-	ms.cacheMu.Lock()
-	for key, cached := range ms.cache {
-		if now.Sub(cached.timestamp) > 10*time.Second {
-			delete(ms.cache, key)
-		}
-	}
-	ms.cacheMu.Unlock()
+	ms.cache.RemoveFunc(func(_ string, cached *stockCache) bool {
+		return now.Sub(cached.timestamp) > 10*time.Second
+	})
 
 	// 清理K线缓存
-	ms.klineCacheMu.Lock()
-	for key, cached := range ms.klineCache {
+	ms.klineCache.RemoveFunc(func(_ string, cached *klineCache) bool {
 		ttl := cached.ttl
 		if ttl <= 0 {
 			ttl = ms.klineCacheTTL
 		}
 		// 使用 3 倍 TTL 做内存回收，避免活跃缓存被过早清理
-		if now.Sub(cached.timestamp) > ttl*3 {
-			delete(ms.klineCache, key)
+		return now.Sub(cached.timestamp) > ttl*3
+	})
+
+	// 清理指数成分股缓存
+	ms.indexConsCacheMu.Lock()
+	for key, cached := range ms.indexConsCache {
+		if now.Sub(cached.timestamp) > indexConstituentsCacheTTL*3 {
+			delete(ms.indexConsCache, key)
 		}
 	}
-	ms.klineCacheMu.Unlock()
+	ms.indexConsCacheMu.Unlock()
 }
 
 // getKLineCacheTTL 返回不同周期的缓存策略
@@ -166,7 +326,7 @@ func (ms *MarketService) getKLineCacheTTL(period string) time.Duration {
 }
 
 // GetStockDataWithOrderBook 获取股票实时数据（含真实盘口），带缓存
-func (ms *MarketService) GetStockDataWithOrderBook(codes ...string) ([]StockWithOrderBook, error) {
+func (ms *MarketService) GetStockDataWithOrderBook(ctx context.Context, codes ...string) ([]StockWithOrderBook, error) {
 	if len(codes) == 0 {
 		return nil, nil
 	}
@@ -178,44 +338,43 @@ func (ms *MarketService) GetStockDataWithOrderBook(codes ...string) ([]StockWith
 	cacheKey := strings.Join(sortedCodes, ",")
 
 	// 检查缓存
-	ms.cacheMu.RLock()
-	if cached, ok := ms.cache[cacheKey]; ok {
-		if time.Since(cached.timestamp) < ms.cacheTTL {
-			ms.cacheMu.RUnlock()
-			return cached.data, nil
-		}
+	if cached, ok := ms.cache.Get(cacheKey); ok && time.Since(cached.timestamp) < ms.cacheTTL {
+		cacheLookup("quotes", true)
+		return cached.data, nil
 	}
-	ms.cacheMu.RUnlock()
+	cacheLookup("quotes", false)
 
 	// 从API获取数据
-	data, err := ms.fetchStockDataWithOrderBook(codes...)
+	data, err := ms.fetchStockDataWithOrderBook(ctx, codes...)
 	if err != nil {
 		return nil, err
 	}
 
 	// 更新缓存
-	ms.cacheMu.Lock()
-	ms.cache[cacheKey] = &stockCache{
+	ms.cache.Put(cacheKey, &stockCache{
 		data:      data,
 		timestamp: time.Now(),
-	}
-	ms.cacheMu.Unlock()
+	})
 
 	return data, nil
 }
 
 // fetchStockDataWithOrderBook 从API获取股票数据（含盘口）
-func (ms *MarketService) fetchStockDataWithOrderBook(codes ...string) ([]StockWithOrderBook, error) {
+func (ms *MarketService) fetchStockDataWithOrderBook(ctx context.Context, codes ...string) ([]StockWithOrderBook, error) {
+	if remote := ms.remoteSource(); remote != nil {
+		return remote.GetStockDataWithOrderBook(ctx, codes...)
+	}
+
 	codeList := strings.Join(codes, ",")
 	url := fmt.Sprintf(sinaStockURL, time.Now().UnixNano(), codeList)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Referer", "http://finance.sina.com.cn")
 
-	resp, err := ms.client.Do(req)
+	resp, err := ms.do(SourceQuotes, req)
 	if err != nil {
 		return nil, err
 	}
@@ -250,21 +409,24 @@ func (ms *MarketService) parseSinaStockDataWithOrderBook(data string) ([]StockWi
 }
 
 // GetStockRealTimeData 获取股票实时数据
-func (ms *MarketService) GetStockRealTimeData(codes ...string) ([]models.Stock, error) {
+func (ms *MarketService) GetStockRealTimeData(ctx context.Context, codes ...string) ([]models.Stock, error) {
 	if len(codes) == 0 {
 		return nil, nil
 	}
+	if remote := ms.remoteSource(); remote != nil {
+		return remote.GetStockRealTimeData(ctx, codes...)
+	}
 
 	codeList := strings.Join(codes, ",")
 	url := fmt.Sprintf(sinaStockURL, time.Now().UnixNano(), codeList)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Referer", "http://finance.sina.com.cn")
 
-	resp, err := ms.client.Do(req)
+	resp, err := ms.do(SourceQuotes, req)
 	if err != nil {
 		return nil, err
 	}
@@ -279,6 +441,28 @@ func (ms *MarketService) GetStockRealTimeData(codes ...string) ([]models.Stock,
 	return ms.parseSinaStockData(string(body), codes)
 }
 
+// fetchSinaQuotes 请求新浪行情接口，返回 GBK 解码后的原始响应（期货、期权等非股票行情使用）
+func (ms *MarketService) fetchSinaQuotes(ctx context.Context, codes []string) (string, error) {
+	url := fmt.Sprintf(sinaStockURL, time.Now().UnixNano(), strings.Join(codes, ","))
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Referer", "http://finance.sina.com.cn")
+
+	resp, err := ms.do(SourceQuotes, req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(transform.NewReader(resp.Body, simplifiedchinese.GBK.NewDecoder()))
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
 // parseSinaStockData 解析新浪股票数据
 func (ms *MarketService) parseSinaStockData(data string, codes []string) ([]models.Stock, error) {
 	var stocks []models.Stock
@@ -289,6 +473,12 @@ func (ms *MarketService) parseSinaStockData(data string, codes []string) ([]mode
 			continue
 		}
 		parts := strings.Split(match[2], ",")
+		if IsFutures(match[1]) {
+			if q, ok := parseFuturesFields(match[1], parts); ok {
+				stocks = append(stocks, futuresToStock(q))
+			}
+			continue
+		}
 		if len(parts) < 32 {
 			continue
 		}
@@ -309,6 +499,12 @@ func (ms *MarketService) parseStockFields(code string, parts []string) models.St
 	volume, _ := strconv.ParseInt(parts[8], 10, 64)
 	amount, _ := strconv.ParseFloat(parts[9], 64)
 
+	// 停牌时现价为 0，以昨收展示，避免显示为跌 100%
+	status := parseSinaStatus(parts[0], parts)
+	if status == models.StockStatusSuspended && price == 0 {
+		price = preClose
+	}
+
 	change := price - preClose
 	changePercent := 0.0
 	if preClose > 0 {
@@ -327,9 +523,24 @@ func (ms *MarketService) parseStockFields(code string, parts []string) models.St
 		ChangePercent: changePercent,
 		Volume:        volume,
 		Amount:        amount,
+		QuoteTime:     parseSinaQuoteTime(parts),
+		Status:        status,
+		ST:            isSTName(parts[0]),
 	}
 }
 
+// parseSinaQuoteTime 解析行情日期与时间字段（索引 30、31，北京时间），返回毫秒时间戳
+func parseSinaQuoteTime(parts []string) int64 {
+	if len(parts) < 32 {
+		return 0
+	}
+	t, err := time.ParseInLocation("2006-01-02 15:04:05", parts[30]+" "+parts[31], time.FixedZone("CST", 8*60*60))
+	if err != nil {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
 // parseStockWithOrderBook 解析股票字段和真实盘口数据
 // 新浪API返回数据格式: 名称,今开,昨收,当前价,最高,最低,买一价,卖一价,成交量,成交额,
 // 买一量,买一价,买二量,买二价,买三量,买三价,买四量,买四价,买五量,买五价,
@@ -410,48 +621,68 @@ func (ms *MarketService) calculateOrderBookTotals(items []models.OrderBookItem)
 }
 
 // GetKLineData 获取K线数据（带缓存）
-func (ms *MarketService) GetKLineData(code string, period string, days int) ([]models.KLineData, error) {
+func (ms *MarketService) GetKLineData(ctx context.Context, code string, period string, days int) ([]models.KLineData, error) {
 	cacheKey := fmt.Sprintf("%s:%s:%d", code, period, days)
 	ttl := ms.getKLineCacheTTL(period)
 
 	// 检查缓存
-	ms.klineCacheMu.RLock()
-	if cached, ok := ms.klineCache[cacheKey]; ok {
+	if cached, ok := ms.klineCache.Get(cacheKey); ok {
 		cachedTTL := cached.ttl
 		if cachedTTL <= 0 {
 			cachedTTL = ttl
 		}
 		if time.Since(cached.timestamp) < cachedTTL {
-			ms.klineCacheMu.RUnlock()
+			cacheLookup("kline", true)
 			return cached.data, nil
 		}
 	}
-	ms.klineCacheMu.RUnlock()
+	cacheLookup("kline", false)
 
 	// 从API获取数据
-	klines, err := ms.fetchKLineData(code, period, days)
+	klines, err := ms.fetchKLineData(ctx, code, period, days)
 	if err != nil {
 		return nil, err
 	}
 
 	// 更新缓存
-	ms.klineCacheMu.Lock()
-	ms.klineCache[cacheKey] = &klineCache{
+	ms.klineCache.Put(cacheKey, &klineCache{
 		data:      klines,
 		timestamp: time.Now(),
 		ttl:       ttl,
-	}
-	ms.klineCacheMu.Unlock()
+	})
 
 	return klines, nil
 }
 
 // fetchKLineData 从API获取K线数据
-func (ms *MarketService) fetchKLineData(code string, period string, days int) ([]models.KLineData, error) {
-	scale := ms.periodToScale(period)
-	url := fmt.Sprintf(sinaKLineURL, code, scale, days)
+func (ms *MarketService) fetchKLineData(ctx context.Context, code string, period string, days int) ([]models.KLineData, error) {
+	if remote := ms.remoteSource(); remote != nil {
+		return remote.GetKLineData(ctx, code, period, days)
+	}
 
-	resp, err := ms.client.Get(url)
+	klines, err := ms.fetchSinaKLines(ctx, code, ms.periodToScale(period), days)
+	if err != nil {
+		return nil, err
+	}
+
+	// 分时模式下只返回当天的数据，并计算均价线
+	if period == "1m" {
+		klines = ms.filterTodayKLines(klines)
+		klines = ms.calculateAvgLine(klines)
+	}
+
+	return klines, nil
+}
+
+// fetchSinaKLines 从新浪获取原始K线（不过滤日期）
+func (ms *MarketService) fetchSinaKLines(ctx context.Context, code string, scale string, count int) ([]models.KLineData, error) {
+	url := fmt.Sprintf(sinaKLineURL, code, scale, count)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ms.do(SourceKLine, req)
 	if err != nil {
 		return nil, err
 	}
@@ -462,20 +693,35 @@ func (ms *MarketService) fetchKLineData(code string, period string, days int) ([
 		return nil, err
 	}
 
-	klines, err := ms.parseKLineData(string(body))
+	return ms.parseKLineData(string(body))
+}
+
+// GetMinuteHistory 获取最近 bars 根 1 分钟K线（跨多个交易日，不走缓存），按日分别计算均价线
+// 数据源仅保留最近数个交易日的分钟数据，用于补齐历史分时归档
+func (ms *MarketService) GetMinuteHistory(ctx context.Context, code string, bars int) ([]models.KLineData, error) {
+	if remote := ms.remoteSource(); remote != nil {
+		return remote.GetKLineData(ctx, code, "1m", bars)
+	}
+	klines, err := ms.fetchSinaKLines(ctx, code, ms.periodToScale("1m"), bars)
 	if err != nil {
 		return nil, err
 	}
-
-	// 分时模式下只返回当天的数据，并计算均价线
-	if period == "1m" {
-		klines = ms.filterTodayKLines(klines)
-		klines = ms.calculateAvgLine(klines)
+	for start := 0; start < len(klines); {
+		end := start + 1
+		for end < len(klines) && sameKLineDay(klines[end].Time, klines[start].Time) {
+			end++
+		}
+		ms.calculateAvgLine(klines[start:end])
+		start = end
 	}
-
 	return klines, nil
 }
 
+// sameKLineDay 两根K线是否属于同一日（时间格式 2006-01-02 15:04:05）
+func sameKLineDay(a, b string) bool {
+	return len(a) >= 10 && len(b) >= 10 && a[:10] == b[:10]
+}
+
 // periodToScale 周期转换为新浪API的scale参数
 func (ms *MarketService) periodToScale(period string) string {
 	switch period {
@@ -589,8 +835,8 @@ func (ms *MarketService) parseKLineData(data string) ([]models.KLineData, error)
 }
 
 // GetRealOrderBook 获取真实盘口数据
-func (ms *MarketService) GetRealOrderBook(code string) (models.OrderBook, error) {
-	data, err := ms.GetStockDataWithOrderBook(code)
+func (ms *MarketService) GetRealOrderBook(ctx context.Context, code string) (models.OrderBook, error) {
+	data, err := ms.GetStockDataWithOrderBook(ctx, code)
 	if err != nil || len(data) == 0 {
 		return models.OrderBook{}, err
 	}
@@ -631,17 +877,11 @@ func (ms *MarketService) GetMarketStatus() MarketStatus {
 	// 检查是否为交易日
 	isTradeDay, holidayName := ms.isTradeDay(now)
 	if !isTradeDay {
-		statusText := "休市"
-		if holidayName != "" {
-			statusText = holidayName + "休市"
-		} else if now.Weekday() == time.Saturday || now.Weekday() == time.Sunday {
-			statusText = "周末休市"
-		}
 		result := MarketStatus{
 			Status:      "closed",
-			StatusText:  statusText,
+			StatusText:  closedStatusText(now, holidayName),
 			IsTradeDay:  false,
-			HolidayName: holidayName,
+			HolidayName: i18n.Holiday(holidayName),
 		}
 		return result
 	}
@@ -654,21 +894,33 @@ func (ms *MarketService) GetMarketStatus() MarketStatus {
 	var result MarketStatus
 	switch {
 	case currentMinutes < 9*60+15:
-		result = MarketStatus{Status: "pre_market", StatusText: "盘前", IsTradeDay: true}
+		result = MarketStatus{Status: "pre_market", StatusText: i18n.T("market.status.pre_market"), IsTradeDay: true}
 	case currentMinutes < 9*60+30:
-		result = MarketStatus{Status: "pre_market", StatusText: "集合竞价", IsTradeDay: true}
+		result = MarketStatus{Status: "pre_market", StatusText: i18n.T("market.status.call_auction"), IsTradeDay: true}
 	case currentMinutes < 11*60+30:
-		result = MarketStatus{Status: "trading", StatusText: "交易中", IsTradeDay: true}
+		result = MarketStatus{Status: "trading", StatusText: i18n.T("market.status.trading"), IsTradeDay: true}
 	case currentMinutes < 13*60:
-		result = MarketStatus{Status: "lunch_break", StatusText: "午间休市", IsTradeDay: true}
+		result = MarketStatus{Status: "lunch_break", StatusText: i18n.T("market.status.lunch_break"), IsTradeDay: true}
 	case currentMinutes < 15*60:
-		result = MarketStatus{Status: "trading", StatusText: "交易中", IsTradeDay: true}
+		result = MarketStatus{Status: "trading", StatusText: i18n.T("market.status.trading"), IsTradeDay: true}
 	default:
-		result = MarketStatus{Status: "closed", StatusText: "已收盘", IsTradeDay: true}
+		result = MarketStatus{Status: "closed", StatusText: i18n.T("market.status.closed"), IsTradeDay: true}
 	}
 	return result
 }
 
+// closedStatusText 非交易日的状态描述
+func closedStatusText(date time.Time, holidayName string) string {
+	switch {
+	case date.Weekday() == time.Saturday || date.Weekday() == time.Sunday:
+		return i18n.T("market.status.weekend")
+	case holidayName != "":
+		return i18n.T("market.status.holiday", i18n.Holiday(holidayName))
+	default:
+		return i18n.T("market.status.off")
+	}
+}
+
 // GetTradingSchedule 获取交易时间表（供前端判断市场状态）
 func (ms *MarketService) GetTradingSchedule() TradingSchedule {
 	now := time.Now()
@@ -679,19 +931,23 @@ func (ms *MarketService) GetTradingSchedule() TradingSchedule {
 
 	// A股交易时段配置
 	periods := []TradingPeriod{
-		{Status: "pre_market", Text: "盘前", StartTime: "00:00", EndTime: "09:15"},
-		{Status: "pre_market", Text: "集合竞价", StartTime: "09:15", EndTime: "09:30"},
-		{Status: "trading", Text: "交易中", StartTime: "09:30", EndTime: "11:30"},
-		{Status: "lunch_break", Text: "午间休市", StartTime: "11:30", EndTime: "13:00"},
-		{Status: "trading", Text: "交易中", StartTime: "13:00", EndTime: "15:00"},
-		{Status: "closed", Text: "已收盘", StartTime: "15:00", EndTime: "24:00"},
+		{Status: "pre_market", Text: i18n.T("market.status.pre_market"), StartTime: "00:00", EndTime: "09:15"},
+		{Status: "pre_market", Text: i18n.T("market.status.call_auction"), StartTime: "09:15", EndTime: "09:30"},
+		{Status: "trading", Text: i18n.T("market.status.trading"), StartTime: "09:30", EndTime: "11:30"},
+		{Status: "lunch_break", Text: i18n.T("market.status.lunch_break"), StartTime: "11:30", EndTime: "13:00"},
+		{Status: "trading", Text: i18n.T("market.status.trading"), StartTime: "13:00", EndTime: "15:00"},
+		{Status: "closed", Text: i18n.T("market.status.closed"), StartTime: "15:00", EndTime: "24:00"},
 	}
 
-	return TradingSchedule{
+	schedule := TradingSchedule{
 		IsTradeDay:  isTradeDay,
-		HolidayName: holidayName,
+		HolidayName: i18n.Holiday(holidayName),
 		Periods:     periods,
 	}
+	if !isTradeDay {
+		schedule.ClosedText = closedStatusText(now, holidayName)
+	}
+	return schedule
 }
 
 // isTradeDay 判断指定日期是否为交易日
@@ -738,15 +994,14 @@ func (ms *MarketService) getHolidayStatus(date time.Time) (isOffDay bool, inList
 
 // getHolidayNote 获取节假日名称
 func (ms *MarketService) getHolidayNote(year int, dateStr string) string {
-	cacheFile := getHolidayCacheFile(year)
-	fileData, err := os.ReadFile(cacheFile)
-	if err != nil {
-		return ""
-	}
-
 	var hd holidayData
-	if json.Unmarshal(fileData, &hd) != nil {
-		return ""
+	fileData, err := os.ReadFile(getHolidayCacheFile(year))
+	if err != nil || json.Unmarshal(fileData, &hd) != nil {
+		embedded, err := loadEmbeddedHolidayData(year)
+		if err != nil {
+			return ""
+		}
+		hd = *embedded
 	}
 
 	for _, day := range hd.Days {
@@ -781,7 +1036,13 @@ var (
 	holidayCacheData = make(map[int]map[string]bool) // year -> date -> isOffDay
 )
 
-const holidayCDNURL = "https://cdn.jsdelivr.net/gh/NateScarlet/holiday-cn@master/%d.json"
+// holidayMirrorURLs 节假日数据源，按顺序尝试，全部失败时使用内置数据
+var holidayMirrorURLs = []string{
+	"https://cdn.jsdelivr.net/gh/NateScarlet/holiday-cn@master/%d.json",
+	"https://fastly.jsdelivr.net/gh/NateScarlet/holiday-cn@master/%d.json",
+	"https://gcore.jsdelivr.net/gh/NateScarlet/holiday-cn@master/%d.json",
+	"https://raw.githubusercontent.com/NateScarlet/holiday-cn/master/%d.json",
+}
 
 // getHolidayCacheFile 获取节假日缓存文件路径
 func getHolidayCacheFile(year int) string {
@@ -811,35 +1072,64 @@ func (ms *MarketService) loadHolidayData(year int) (map[string]bool, error) {
 		}
 	}
 
-	// 从CDN获取
-	return ms.fetchHolidayData(year)
-}
-
-// fetchHolidayData 从CDN获取节假日数据
-func (ms *MarketService) fetchHolidayData(year int) (map[string]bool, error) {
-	url := fmt.Sprintf(holidayCDNURL, year)
-	resp, err := ms.client.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("获取节假日数据失败: %w", err)
+	// 从CDN及镜像获取，全部失败时使用内置数据
+	data, err := ms.fetchHolidayData(year)
+	if err == nil {
+		return data, nil
 	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
+	hd, embedErr := loadEmbeddedHolidayData(year)
+	if embedErr != nil {
 		return nil, err
 	}
+	log.Warn("%v，使用内置 %d 年节假日数据", err, year)
+	data = ms.parseHolidayData(hd)
+	holidayCacheMu.Lock()
+	holidayCacheData[year] = data
+	holidayCacheMu.Unlock()
+	return data, nil
+}
 
+// loadEmbeddedHolidayData 读取内置的节假日数据
+func loadEmbeddedHolidayData(year int) (*holidayData, error) {
+	body, err := embed.HolidayFS.ReadFile(fmt.Sprintf("holiday/%d.json", year))
+	if err != nil {
+		return nil, i18n.Errorf("error.holiday.no_data", year)
+	}
 	var hd holidayData
 	if err := json.Unmarshal(body, &hd); err != nil {
 		return nil, err
 	}
+	return &hd, nil
+}
+
+// fetchHolidayData 依次从CDN及镜像获取节假日数据
+// 结果按年份全局共享缓存，不跟随单个调用方取消，仅受客户端超时约束
+func (ms *MarketService) fetchHolidayData(year int) (map[string]bool, error) {
+	var hd *holidayData
+	var body []byte
+	var lastErr error
+	for _, mirror := range holidayMirrorURLs {
+		body, lastErr = ms.fetchHolidayFrom(fmt.Sprintf(mirror, year))
+		if lastErr != nil {
+			log.Warn("节假日数据源不可用 %s: %v", mirror, lastErr)
+			continue
+		}
+		hd = &holidayData{}
+		if lastErr = json.Unmarshal(body, hd); lastErr == nil {
+			break
+		}
+		hd = nil
+	}
+	if hd == nil {
+		return nil, fmt.Errorf("获取节假日数据失败: %w", lastErr)
+	}
 
 	// 保存到文件缓存
 	cacheFile := getHolidayCacheFile(year)
 	os.WriteFile(cacheFile, body, 0644)
 
 	// 解析并缓存到内存
-	data := ms.parseHolidayData(&hd)
+	data := ms.parseHolidayData(hd)
 	holidayCacheMu.Lock()
 	holidayCacheData[year] = data
 	holidayCacheMu.Unlock()
@@ -848,6 +1138,24 @@ func (ms *MarketService) fetchHolidayData(year int) (map[string]bool, error) {
 	return data, nil
 }
 
+// fetchHolidayFrom 从单个数据源获取节假日原始数据
+func (ms *MarketService) fetchHolidayFrom(url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(context.Background(), "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ms.do(SourceHoliday, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
 // parseHolidayData 解析节假日数据为 map
 func (ms *MarketService) parseHolidayData(hd *holidayData) map[string]bool {
 	data := make(map[string]bool)
@@ -964,18 +1272,65 @@ func (ms *MarketService) fetchTradeDates(days int) ([]string, error) {
 	return tradeDates, nil
 }
 
+// SetIndexCodes 设置大盘指数代码列表，为空时使用默认指数
+func (ms *MarketService) SetIndexCodes(codes []string) {
+	ms.indexCodesMu.Lock()
+	defer ms.indexCodesMu.Unlock()
+	if len(codes) == 0 {
+		ms.indexCodes = DefaultIndexCodes
+		return
+	}
+	ms.indexCodes = append([]string(nil), codes...)
+}
+
+// GetIndexCodes 获取当前大盘指数代码列表
+func (ms *MarketService) GetIndexCodes() []string {
+	ms.indexCodesMu.RLock()
+	defer ms.indexCodesMu.RUnlock()
+	return append([]string(nil), ms.indexCodes...)
+}
+
 // GetMarketIndices 获取大盘指数数据
-func (ms *MarketService) GetMarketIndices() ([]models.MarketIndex, error) {
-	codeList := strings.Join(defaultIndexCodes, ",")
+func (ms *MarketService) GetMarketIndices(ctx context.Context) ([]models.MarketIndex, error) {
+	return ms.GetMarketIndicesByCodes(ctx, ms.GetIndexCodes())
+}
+
+// GetMarketIndicesByCodes 获取指定大盘指数数据
+func (ms *MarketService) GetMarketIndicesByCodes(ctx context.Context, codes []string) ([]models.MarketIndex, error) {
+	if remote := ms.remoteSource(); remote != nil {
+		return remote.GetMarketIndicesByCodes(ctx, codes)
+	}
+	return ms.getIndicesByCodes(ctx, codes)
+}
+
+// toSinaIndexCode 指数代码转换为新浪行情代码
+// A股指数使用简化行情 s_ 前缀，海外指数(int_)原样使用
+func toSinaIndexCode(code string) string {
+	if strings.HasPrefix(code, "int_") || strings.HasPrefix(code, "s_") {
+		return code
+	}
+	return "s_" + code
+}
+
+// getIndicesByCodes 获取指定指数数据
+func (ms *MarketService) getIndicesByCodes(ctx context.Context, codes []string) ([]models.MarketIndex, error) {
+	if len(codes) == 0 {
+		return nil, nil
+	}
+	sinaCodes := make([]string, len(codes))
+	for i, code := range codes {
+		sinaCodes[i] = toSinaIndexCode(code)
+	}
+	codeList := strings.Join(sinaCodes, ",")
 	url := fmt.Sprintf(sinaStockURL, time.Now().UnixNano(), codeList)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Referer", "http://finance.sina.com.cn")
 
-	resp, err := ms.client.Do(req)
+	resp, err := ms.do(SourceQuotes, req)
 	if err != nil {
 		return nil, err
 	}
@@ -993,34 +1348,71 @@ func (ms *MarketService) GetMarketIndices() ([]models.MarketIndex, error) {
 // parseMarketIndices 解析大盘指数数据
 // 新浪简化指数数据格式: var hq_str_s_sh000001="上证指数,3094.668,-128.073,-3.97,436653,5458126"
 // 字段: 名称,当前点位,涨跌点数,涨跌幅(%),成交量(手),成交额(万元)
+// 海外指数数据格式: var hq_str_int_dji="道琼斯,38000.12,120.50,0.32"
+// 字段: 名称,当前点位,涨跌点数,涨跌幅(%)
 func (ms *MarketService) parseMarketIndices(data string) ([]models.MarketIndex, error) {
 	var indices []models.MarketIndex
-	matches := sinaIndexRegex.FindAllStringSubmatch(data, -1)
-
-	for _, match := range matches {
-		if len(match) < 3 || match[2] == "" {
+	// 按行解析以保持配置顺序（A股与海外指数混排）
+	for _, line := range strings.Split(data, "\n") {
+		if match := sinaIntlRegex.FindStringSubmatch(line); match != nil {
+			if index, ok := parseIntlIndex(match); ok {
+				indices = append(indices, index)
+			}
 			continue
 		}
-		parts := strings.Split(match[2], ",")
-		if len(parts) < 6 {
-			continue
+		if index, ok := parseCNIndex(sinaIndexRegex.FindStringSubmatch(line)); ok {
+			indices = append(indices, index)
 		}
-
-		price, _ := strconv.ParseFloat(parts[1], 64)
-		change, _ := strconv.ParseFloat(parts[2], 64)
-		changePercent, _ := strconv.ParseFloat(parts[3], 64)
-		volume, _ := strconv.ParseInt(parts[4], 10, 64)
-		amount, _ := strconv.ParseFloat(parts[5], 64)
-
-		indices = append(indices, models.MarketIndex{
-			Code:          match[1],
-			Name:          parts[0],
-			Price:         price,
-			Change:        change,
-			ChangePercent: changePercent,
-			Volume:        volume,
-			Amount:        amount,
-		})
 	}
 	return indices, nil
 }
+
+// parseCNIndex 解析A股简化指数行情
+func parseCNIndex(match []string) (models.MarketIndex, bool) {
+	if len(match) < 3 || match[2] == "" {
+		return models.MarketIndex{}, false
+	}
+	parts := strings.Split(match[2], ",")
+	if len(parts) < 6 {
+		return models.MarketIndex{}, false
+	}
+
+	price, _ := strconv.ParseFloat(parts[1], 64)
+	change, _ := strconv.ParseFloat(parts[2], 64)
+	changePercent, _ := strconv.ParseFloat(parts[3], 64)
+	volume, _ := strconv.ParseInt(parts[4], 10, 64)
+	amount, _ := strconv.ParseFloat(parts[5], 64)
+
+	return models.MarketIndex{
+		Code:          match[1],
+		Name:          parts[0],
+		Price:         price,
+		Change:        change,
+		ChangePercent: changePercent,
+		Volume:        volume,
+		Amount:        amount,
+	}, true
+}
+
+// parseIntlIndex 解析海外指数行情
+func parseIntlIndex(match []string) (models.MarketIndex, bool) {
+	if len(match) < 3 || match[2] == "" {
+		return models.MarketIndex{}, false
+	}
+	parts := strings.Split(match[2], ",")
+	if len(parts) < 4 {
+		return models.MarketIndex{}, false
+	}
+
+	price, _ := strconv.ParseFloat(parts[1], 64)
+	change, _ := strconv.ParseFloat(parts[2], 64)
+	changePercent, _ := strconv.ParseFloat(parts[3], 64)
+
+	return models.MarketIndex{
+		Code:          match[1],
+		Name:          parts[0],
+		Price:         price,
+		Change:        change,
+		ChangePercent: changePercent,
+	}, true
+}
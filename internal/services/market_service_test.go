@@ -1,7 +1,11 @@
 package services
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 // TestGetStockRealTimeData 测试获取实时股票数据
@@ -10,7 +14,7 @@ func TestGetStockRealTimeData(t *testing.T) {
 
 	// 测试上海股票 (贵州茅台)
 	t.Run("上海股票", func(t *testing.T) {
-		stocks, err := ms.GetStockRealTimeData("sh600519")
+		stocks, err := ms.GetStockRealTimeData(context.Background(), "sh600519")
 		if err != nil {
 			t.Fatalf("获取上海股票数据失败: %v", err)
 		}
@@ -35,7 +39,7 @@ func TestGetStockRealTimeData(t *testing.T) {
 
 	// 测试深圳股票 (平安银行)
 	t.Run("深圳股票", func(t *testing.T) {
-		stocks, err := ms.GetStockRealTimeData("sz000001")
+		stocks, err := ms.GetStockRealTimeData(context.Background(), "sz000001")
 		if err != nil {
 			t.Fatalf("获取深圳股票数据失败: %v", err)
 		}
@@ -54,7 +58,7 @@ func TestGetStockRealTimeData(t *testing.T) {
 
 	// 测试多只股票
 	t.Run("多只股票", func(t *testing.T) {
-		stocks, err := ms.GetStockRealTimeData("sh600519", "sz000001", "sh601318")
+		stocks, err := ms.GetStockRealTimeData(context.Background(), "sh600519", "sz000001", "sh601318")
 		if err != nil {
 			t.Fatalf("获取多只股票数据失败: %v", err)
 		}
@@ -73,7 +77,7 @@ func TestGetStockDataWithOrderBook(t *testing.T) {
 	ms := NewMarketService()
 
 	t.Run("获取盘口数据", func(t *testing.T) {
-		data, err := ms.GetStockDataWithOrderBook("sh600519")
+		data, err := ms.GetStockDataWithOrderBook(context.Background(), "sh600519")
 		if err != nil {
 			t.Fatalf("获取盘口数据失败: %v", err)
 		}
@@ -111,7 +115,7 @@ func TestGetKLineData(t *testing.T) {
 	ms := NewMarketService()
 
 	t.Run("日K线", func(t *testing.T) {
-		data, err := ms.GetKLineData("sh600519", "1d", 10)
+		data, err := ms.GetKLineData(context.Background(), "sh600519", "1d", 10)
 		if err != nil {
 			t.Fatalf("获取K线数据失败: %v", err)
 		}
@@ -140,3 +144,79 @@ func TestGetKLineData(t *testing.T) {
 		}
 	})
 }
+
+// TestParseMarketIndices 测试A股与海外指数混合解析
+func TestParseMarketIndices(t *testing.T) {
+	ms := &MarketService{}
+	data := `var hq_str_s_sh000001="上证指数,3094.668,-128.073,-3.97,436653,5458126";
+var hq_str_int_dji="道琼斯,38000.12,120.50,0.32";
+var hq_str_s_sh000688="科创50,980.12,5.10,0.52,12345,67890";`
+
+	indices, err := ms.parseMarketIndices(data)
+	if err != nil {
+		t.Fatalf("解析指数失败: %v", err)
+	}
+	if len(indices) != 3 {
+		t.Fatalf("期望3个指数，实际 %d 个", len(indices))
+	}
+
+	want := []string{"sh000001", "int_dji", "sh000688"}
+	for i, code := range want {
+		if indices[i].Code != code {
+			t.Errorf("第%d个指数期望 %s，实际 %s", i+1, code, indices[i].Code)
+		}
+	}
+	if indices[1].Price != 38000.12 || indices[1].ChangePercent != 0.32 {
+		t.Errorf("海外指数解析错误: %+v", indices[1])
+	}
+}
+
+// TestDoCancelledByContext 测试调用方取消时请求立即返回且不计入数据源失败
+func TestDoCancelledByContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	ms := NewMarketService()
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", server.URL, nil)
+
+	start := time.Now()
+	if _, err := ms.do("test_cancel", req); err == nil {
+		t.Fatal("取消后应返回错误")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("取消后请求未及时返回: %v", elapsed)
+	}
+	for _, h := range ms.GetDataSourceHealth() {
+		if h.Source == "test_cancel" && h.Failures > 0 {
+			t.Errorf("取消的请求不应计入失败: %+v", h)
+		}
+	}
+}
+
+// TestEmbeddedHolidayData 测试内置节假日数据可用（CDN 不可用时的兜底）
+func TestEmbeddedHolidayData(t *testing.T) {
+	hd, err := loadEmbeddedHolidayData(2026)
+	if err != nil {
+		t.Fatalf("加载内置节假日数据失败: %v", err)
+	}
+	if hd.Year != 2026 {
+		t.Errorf("year = %d, want 2026", hd.Year)
+	}
+	data := NewMarketService().parseHolidayData(hd)
+	if off, ok := data["2026-02-17"]; !ok || !off {
+		t.Error("2026-02-17 春节应为休息日")
+	}
+	if off, ok := data["2026-02-28"]; !ok || off {
+		t.Error("2026-02-28 应为调休上班日")
+	}
+	if _, err := loadEmbeddedHolidayData(1990); err == nil {
+		t.Error("不存在的年份应返回错误")
+	}
+}
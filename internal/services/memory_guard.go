@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/pkg/supervisor"
+)
+
+const (
+	defaultMemoryLimitMB   = 512
+	memoryGuardInterval    = 30 * time.Second
+	memoryFreeOSMinBetween = time.Minute // 两次归还内存的最小间隔，FreeOSMemory 会触发完整 GC
+)
+
+// memoryTrimmer 内存紧张时收缩缓存的回调，返回释放的估算字节数
+type memoryTrimmer struct {
+	name string
+	trim func() int64
+}
+
+// MemoryGuard 内存守护
+// 定期检查堆内存，超过软上限时依次收缩已注册的缓存并调用 debug.FreeOSMemory 归还系统，
+// 避免长时间运行、浏览大量股票后 RSS 持续增长
+type MemoryGuard struct {
+	mu       sync.Mutex
+	limit    uint64
+	trimmers []memoryTrimmer
+	lastFree time.Time
+
+	heapInUse func() uint64
+	freeOS    func()
+	now       func() time.Time
+}
+
+// NewMemoryGuard 创建内存守护，limitMB 为 0 时使用默认上限
+func NewMemoryGuard(limitMB int) *MemoryGuard {
+	g := &MemoryGuard{
+		heapInUse: readHeapInUse,
+		freeOS:    debug.FreeOSMemory,
+		now:       time.Now,
+	}
+	g.SetLimit(limitMB)
+	return g
+}
+
+// SetLimit 调整内存软上限（MB），0 使用默认值
+func (g *MemoryGuard) SetLimit(limitMB int) {
+	if limitMB <= 0 {
+		limitMB = defaultMemoryLimitMB
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.limit = uint64(limitMB) << 20
+}
+
+// Register 注册可收缩的缓存
+func (g *MemoryGuard) Register(name string, trim func() int64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.trimmers = append(g.trimmers, memoryTrimmer{name: name, trim: trim})
+}
+
+// Start 启动定期检查
+func (g *MemoryGuard) Start(ctx context.Context) {
+	supervisor.Go(ctx, "memory-guard", func(ctx context.Context) {
+		ticker := time.NewTicker(memoryGuardInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				g.Check()
+			}
+		}
+	})
+}
+
+// Check 检查一次内存，超过上限时收缩缓存并归还内存，返回是否执行了收缩
+func (g *MemoryGuard) Check() bool {
+	heap := g.heapInUse()
+	g.mu.Lock()
+	limit := g.limit
+	trimmers := append([]memoryTrimmer(nil), g.trimmers...)
+	g.mu.Unlock()
+	if heap <= limit {
+		return false
+	}
+
+	var freed int64
+	for _, t := range trimmers {
+		n := t.trim()
+		freed += n
+		log.Debug("内存超限，收缩缓存 %s: 释放约 %d KB", t.name, n>>10)
+	}
+
+	g.mu.Lock()
+	now := g.now()
+	freeOS := now.Sub(g.lastFree) >= memoryFreeOSMinBetween
+	if freeOS {
+		g.lastFree = now
+	}
+	g.mu.Unlock()
+	if freeOS {
+		g.freeOS()
+	}
+	log.Warn("堆内存 %d MB 超过上限 %d MB，已收缩缓存约 %d KB", heap>>20, limit>>20, freed>>10)
+	memoryTrims.Inc()
+	return true
+}
+
+// readHeapInUse 当前堆内存占用
+func readHeapInUse() uint64 {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	return ms.HeapInuse
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// TestMemoryGuard 测试超过上限时收缩缓存，且归还内存有最小间隔
+func TestMemoryGuard(t *testing.T) {
+	g := NewMemoryGuard(1)
+	heap := uint64(512 << 10)
+	now := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	frees, trims := 0, 0
+	g.heapInUse = func() uint64 { return heap }
+	g.freeOS = func() { frees++ }
+	g.now = func() time.Time { return now }
+	g.Register("test", func() int64 { trims++; return 1024 })
+
+	if g.Check() || trims != 0 {
+		t.Fatal("未超过上限不应收缩")
+	}
+
+	heap = 2 << 20
+	if !g.Check() || trims != 1 || frees != 1 {
+		t.Fatalf("超过上限应收缩并归还内存: trims=%d frees=%d", trims, frees)
+	}
+	now = now.Add(10 * time.Second)
+	g.Check()
+	if trims != 2 || frees != 1 {
+		t.Errorf("间隔内只收缩缓存不归还内存: trims=%d frees=%d", trims, frees)
+	}
+	now = now.Add(time.Minute)
+	g.Check()
+	if frees != 2 {
+		t.Errorf("超过最小间隔应再次归还内存: frees=%d", frees)
+	}
+}
+
+// TestMarketServiceTrimCaches 测试行情与K线缓存按 LRU 收缩
+func TestMarketServiceTrimCaches(t *testing.T) {
+	ms := NewMarketService()
+	for _, code := range []string{"sh600000", "sh600001", "sh600002", "sh600003"} {
+		ms.klineCache.Put(code, &klineCache{data: make([]models.KLineData, 100), timestamp: time.Now()})
+	}
+	before := ms.klineCache.Bytes()
+	freed := ms.TrimCaches()
+	if freed <= 0 || ms.klineCache.Bytes() > before/2 {
+		t.Errorf("收缩后应不超过一半: before=%d after=%d freed=%d", before, ms.klineCache.Bytes(), freed)
+	}
+	if _, ok := ms.klineCache.Get("sh600003"); !ok {
+		t.Error("最近写入的K线缓存应保留")
+	}
+}
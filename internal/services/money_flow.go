@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+)
+
+// emMoneyFlowURL 东方财富个股日度资金流向
+const emMoneyFlowURL = "https://push2his.eastmoney.com/api/qt/stock/fflow/daykline/get?lmt=%d&klt=101&secid=%s&fields1=f1,f2,f3,f7&fields2=f51,f52,f53,f54,f55,f56,f57,f58,f59,f60,f61,f62,f63,f64,f65"
+
+const maxMoneyFlowDays = 120
+
+// GetMoneyFlow 获取个股最近 days 个交易日的资金流向（按日期正序）
+func (ms *MarketService) GetMoneyFlow(ctx context.Context, code string, days int) ([]models.MoneyFlow, error) {
+	secid, err := emSecID(code)
+	if err != nil {
+		return nil, err
+	}
+	if days <= 0 || days > maxMoneyFlowDays {
+		days = maxMoneyFlowDays
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf(emMoneyFlowURL, days, secid), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
+	req.Header.Set("Referer", "https://data.eastmoney.com/")
+
+	resp, err := ms.do(SourceEastmoney, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Data *struct {
+			Klines []string `json:"klines"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("解析资金流向失败: %w", err)
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("未获取到资金流向数据: %s", code)
+	}
+	return parseMoneyFlowKLines(result.Data.Klines), nil
+}
+
+// parseMoneyFlowKLines 解析资金流向行：
+// 日期,主力净流入,小单净流入,中单净流入,大单净流入,超大单净流入,主力净占比,...,收盘价,涨跌幅
+func parseMoneyFlowKLines(lines []string) []models.MoneyFlow {
+	flows := make([]models.MoneyFlow, 0, len(lines))
+	for _, line := range lines {
+		parts := strings.Split(line, ",")
+		if len(parts) < 13 {
+			continue
+		}
+		num := func(i int) float64 {
+			v, _ := strconv.ParseFloat(parts[i], 64)
+			return v
+		}
+		flows = append(flows, models.MoneyFlow{
+			Date:           parts[0],
+			MainNet:        num(1),
+			SmallNet:       num(2),
+			MediumNet:      num(3),
+			LargeNet:       num(4),
+			SuperLargeNet:  num(5),
+			MainNetPercent: num(6),
+			Close:          num(11),
+			ChangePercent:  num(12),
+		})
+	}
+	return flows
+}
+
+// emSecID 带市场前缀的代码转东方财富 secid（1.上海 / 0.深圳、北京）
+func emSecID(code string) (string, error) {
+	if len(code) != 8 || !isDigits(code[2:]) {
+		return "", i18n.Errorf("error.symbol.invalid", code)
+	}
+	switch code[:2] {
+	case "sh":
+		return "1." + code[2:], nil
+	case "sz", "bj":
+		return "0." + code[2:], nil
+	}
+	return "", i18n.Errorf("error.symbol.invalid", code)
+}
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"testing"
+)
+
+// TestParseMoneyFlowKLines 测试资金流向解析与 secid 转换
+func TestParseMoneyFlowKLines(t *testing.T) {
+	flows := parseMoneyFlowKLines([]string{
+		"2026-03-09,-12345678.0,2000000.0,10345678.0,-4000000.0,-8345678.0,-5.12,0.83,4.29,-1.66,-3.46,10.52,-1.22,0.00,0.00",
+		"bad,line",
+	})
+	if len(flows) != 1 {
+		t.Fatalf("应解析 1 条, got %d", len(flows))
+	}
+	f := flows[0]
+	if f.Date != "2026-03-09" || f.MainNet != -12345678 || f.SuperLargeNet != -8345678 || f.Close != 10.52 || f.ChangePercent != -1.22 {
+		t.Errorf("解析结果不符: %+v", f)
+	}
+
+	for code, want := range map[string]string{"sh600519": "1.600519", "sz000001": "0.000001"} {
+		if got, err := emSecID(code); err != nil || got != want {
+			t.Errorf("emSecID(%s) = %s, %v; want %s", code, got, err, want)
+		}
+	}
+	if _, err := emSecID("600519"); err == nil {
+		t.Error("缺少市场前缀应报错")
+	}
+}
@@ -0,0 +1,285 @@
+package services
+
+import (
+	"bufio"
+	"database/sql"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+	"github.com/run-bigpig/jcp/internal/pkg/sqlitedb"
+)
+
+const (
+	newsArchiveDateLayout  = "2006-01-02"
+	newsSearchDefaultDays  = 30  // 未指定起始日期时默认检索最近天数
+	newsSearchMaxDays      = 366 // 单次检索最大跨度
+	newsSearchLimit        = 200 // 单次检索最多返回条数
+	newsArchiveMaxLineSize = 1 << 20
+	newsFTSMinTermRunes    = 3 // trigram 分词器可检索的最短词长，更短的词退回 LIKE
+)
+
+// newsArchiveSchema 快讯表与 FTS5 全文索引
+// 中文没有空格分词，使用 trigram 分词器支持任意子串检索；外部内容表由触发器同步
+var newsArchiveSchema = []string{
+	`CREATE TABLE IF NOT EXISTS telegraphs (
+		id        INTEGER PRIMARY KEY,
+		date      TEXT NOT NULL,
+		hash      TEXT NOT NULL,
+		timestamp INTEGER NOT NULL,
+		time      TEXT NOT NULL,
+		content   TEXT NOT NULL,
+		url       TEXT NOT NULL,
+		source    TEXT NOT NULL,
+		UNIQUE(date, hash)
+	)`,
+	`CREATE INDEX IF NOT EXISTS telegraphs_date ON telegraphs(date, timestamp)`,
+	`CREATE VIRTUAL TABLE IF NOT EXISTS telegraphs_fts USING fts5(content, content='telegraphs', content_rowid='id', tokenize='trigram')`,
+	`CREATE TRIGGER IF NOT EXISTS telegraphs_ai AFTER INSERT ON telegraphs BEGIN
+		INSERT INTO telegraphs_fts(rowid, content) VALUES (new.id, new.content);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS telegraphs_ad AFTER DELETE ON telegraphs BEGIN
+		INSERT INTO telegraphs_fts(telegraphs_fts, rowid, content) VALUES ('delete', old.id, old.content);
+	END`,
+}
+
+// NewsArchive 快讯本地归档
+// 存储在 <dataDir>/news/news.db（SQLite），按日期与内容哈希去重，关键词检索使用 FTS5 全文索引
+type NewsArchive struct {
+	dir string
+	mu  sync.Mutex
+	db  *sql.DB
+	now func() time.Time
+}
+
+// NewNewsArchive 创建快讯归档，数据库在首次使用时打开
+func NewNewsArchive(dataDir string) *NewsArchive {
+	return &NewsArchive{
+		dir: filepath.Join(dataDir, "news"),
+		now: time.Now,
+	}
+}
+
+// open 打开数据库，首次打开时导入旧版按日期存储的 jsonl 归档
+func (a *NewsArchive) open() (*sql.DB, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.db != nil {
+		return a.db, nil
+	}
+	db, err := sqlitedb.Open(filepath.Join(a.dir, "news.db"), newsArchiveSchema...)
+	if err != nil {
+		return nil, err
+	}
+	if err := importLegacyNews(db, a.dir); err != nil {
+		log.Warn("导入旧版快讯归档失败: %v", err)
+	}
+	a.db = db
+	return db, nil
+}
+
+// Close 关闭数据库
+func (a *NewsArchive) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.db == nil {
+		return nil
+	}
+	err := a.db.Close()
+	a.db = nil
+	return err
+}
+
+// Add 归档快讯，已归档的内容跳过
+func (a *NewsArchive) Add(telegraphs []Telegraph) error {
+	if len(telegraphs) == 0 {
+		return nil
+	}
+	db, err := a.open()
+	if err != nil {
+		return err
+	}
+	now := a.now()
+
+	inflightWrites.begin()
+	defer inflightWrites.done()
+	return sqlitedb.InTx(db, func(tx *sql.Tx) error {
+		for _, t := range telegraphs {
+			date := now.Format(newsArchiveDateLayout)
+			if t.Timestamp > 0 {
+				date = time.UnixMilli(t.Timestamp).In(now.Location()).Format(newsArchiveDateLayout)
+			}
+			if err := insertTelegraph(tx, date, t); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// insertTelegraph 写入一条快讯，同日相同内容忽略
+// 提醒关键词随配置变化，不归档
+func insertTelegraph(tx *sql.Tx, date string, t Telegraph) error {
+	_, err := tx.Exec(`INSERT OR IGNORE INTO telegraphs(date, hash, timestamp, time, content, url, source) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		date, telegraphHash(t.Content), t.Timestamp, t.Time, t.Content, t.URL, t.Source)
+	return err
+}
+
+// Search 按关键词与日期范围检索归档快讯（按时间倒序）
+// keyword 以空格分隔多个词，需全部命中；startDate/endDate 格式 2006-01-02，为空时默认最近 30 天
+func (a *NewsArchive) Search(keyword, startDate, endDate string) ([]Telegraph, error) {
+	now := a.now()
+	end := now
+	if endDate != "" {
+		t, err := time.ParseInLocation(newsArchiveDateLayout, endDate, now.Location())
+		if err != nil {
+			return nil, i18n.Errorf("error.news.end_date_invalid", endDate)
+		}
+		end = t
+	}
+	start := end.AddDate(0, 0, -(newsSearchDefaultDays - 1))
+	if startDate != "" {
+		t, err := time.ParseInLocation(newsArchiveDateLayout, startDate, now.Location())
+		if err != nil {
+			return nil, i18n.Errorf("error.news.start_date_invalid", startDate)
+		}
+		start = t
+	}
+	if start.After(end) {
+		return nil, i18n.Errorf("error.news.range_reversed")
+	}
+	if end.Sub(start) > newsSearchMaxDays*24*time.Hour {
+		return nil, i18n.Errorf("error.news.range_too_long", newsSearchMaxDays)
+	}
+
+	db, err := a.open()
+	if err != nil {
+		return nil, err
+	}
+	where := []string{"t.date BETWEEN ? AND ?"}
+	args := []any{start.Format(newsArchiveDateLayout), end.Format(newsArchiveDateLayout)}
+	var ftsTerms []string
+	for _, term := range strings.Fields(keyword) {
+		if utf8.RuneCountInString(term) >= newsFTSMinTermRunes {
+			ftsTerms = append(ftsTerms, `"`+strings.ReplaceAll(term, `"`, `""`)+`"`)
+			continue
+		}
+		where = append(where, `t.content LIKE ? ESCAPE '\'`)
+		args = append(args, "%"+escapeLike(term)+"%")
+	}
+	if len(ftsTerms) > 0 {
+		where = append(where, "t.id IN (SELECT rowid FROM telegraphs_fts WHERE telegraphs_fts MATCH ?)")
+		args = append(args, strings.Join(ftsTerms, " AND "))
+	}
+	args = append(args, newsSearchLimit)
+
+	rows, err := db.Query(`SELECT t.time, t.content, t.url, t.source, t.timestamp FROM telegraphs t
+		WHERE `+strings.Join(where, " AND ")+` ORDER BY t.date DESC, t.timestamp DESC LIMIT ?`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	result := make([]Telegraph, 0)
+	for rows.Next() {
+		t, err := scanTelegraph(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, t)
+	}
+	return result, rows.Err()
+}
+
+// scan 按时间倒序遍历日期范围内的归档，返回满足 match 的快讯，凑满 limit 条即停止
+func (a *NewsArchive) scan(start, end time.Time, limit int, match func(content string) bool) ([]Telegraph, error) {
+	db, err := a.open()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.Query(`SELECT time, content, url, source, timestamp FROM telegraphs
+		WHERE date BETWEEN ? AND ? ORDER BY date DESC, timestamp DESC`,
+		start.Format(newsArchiveDateLayout), end.Format(newsArchiveDateLayout))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]Telegraph, 0)
+	for rows.Next() && len(result) < limit {
+		t, err := scanTelegraph(rows)
+		if err != nil {
+			return nil, err
+		}
+		if match(t.Content) {
+			result = append(result, t)
+		}
+	}
+	return result, rows.Err()
+}
+
+// scanTelegraph 读取一行快讯
+func scanTelegraph(rows *sql.Rows) (Telegraph, error) {
+	var t Telegraph
+	err := rows.Scan(&t.Time, &t.Content, &t.URL, &t.Source, &t.Timestamp)
+	return t, err
+}
+
+// escapeLike 转义 LIKE 通配符
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// importLegacyNews 导入旧版 <dir>/2006-01-02.jsonl 归档，导入成功的文件随后删除
+func importLegacyNews(db *sql.DB, dir string) error {
+	files, _ := filepath.Glob(filepath.Join(dir, "*.jsonl"))
+	for _, path := range files {
+		date := strings.TrimSuffix(filepath.Base(path), ".jsonl")
+		if _, err := time.Parse(newsArchiveDateLayout, date); err != nil {
+			continue
+		}
+		list, err := readNewsFile(path)
+		if err != nil {
+			return err
+		}
+		if err := sqlitedb.InTx(db, func(tx *sql.Tx) error {
+			for _, t := range list {
+				if err := insertTelegraph(tx, date, t); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		os.Remove(path)
+	}
+	if len(files) > 0 {
+		log.Info("已导入 %d 个旧版快讯归档文件", len(files))
+	}
+	return nil
+}
+
+// readNewsFile 读取旧版某日归档文件，跳过损坏的行
+func readNewsFile(path string) ([]Telegraph, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var list []Telegraph
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), newsArchiveMaxLineSize)
+	for scanner.Scan() {
+		var t Telegraph
+		if err := json.Unmarshal(scanner.Bytes(), &t); err == nil {
+			list = append(list, t)
+		}
+	}
+	return list, scanner.Err()
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/supervisor"
+)
+
+// EventTelegraphDigest 快讯 AI 摘要事件
+const EventTelegraphDigest = "market:telegraph:digest"
+
+const (
+	newsDigestCheckInterval = time.Minute
+	newsDigestMinItems      = 3  // 新增快讯少于该条数时跳过本轮
+	newsDigestMaxItems      = 50 // 单次摘要最多使用的快讯条数
+)
+
+// newsDigestCodeRe 摘要中引用的股票代码：6 位数字，可带 sh/sz/bj 前缀
+var newsDigestCodeRe = regexp.MustCompile(`(?i)^(sh|sz|bj)?\d{6}$`)
+
+// TelegraphDigest 快讯 AI 摘要
+type TelegraphDigest struct {
+	Summary     string   `json:"summary"`     // 市场脉络
+	Codes       []string `json:"codes"`       // 涉及的股票代码
+	Count       int      `json:"count"`       // 汇总的快讯条数
+	From        int64    `json:"from"`        // 汇总区间起（毫秒）
+	To          int64    `json:"to"`          // 汇总区间止（毫秒）
+	GeneratedAt int64    `json:"generatedAt"` // 生成时间（毫秒）
+}
+
+// SetDigestConfig 设置快讯 AI 摘要间隔与模型，间隔为 0 时关闭
+func (s *NewsService) SetDigestConfig(cfg models.NewsDigestConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.digestConfig = cfg
+}
+
+// SetDigestLLMProvider 设置快讯摘要使用的模型创建方法
+func (s *NewsService) SetDigestLLMProvider(provider BriefingLLMProvider) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.digestLLM = provider
+}
+
+// StartDigest 启动快讯摘要定时任务，每次生成摘要后调用 onDigest
+func (s *NewsService) StartDigest(ctx context.Context, onDigest func(TelegraphDigest)) {
+	supervisor.Go(ctx, "news-digest", func(ctx context.Context) {
+		ticker := time.NewTicker(newsDigestCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				digest, err := s.digestDue(ctx, now)
+				if err != nil {
+					log.Warn("生成快讯摘要失败: %v", err)
+					continue
+				}
+				if digest != nil && onDigest != nil {
+					onDigest(*digest)
+				}
+			}
+		}
+	})
+}
+
+// digestDue 到达汇总间隔时汇总上次摘要之后的新增快讯，未到间隔或新增过少时返回 nil
+func (s *NewsService) digestDue(ctx context.Context, now time.Time) (*TelegraphDigest, error) {
+	s.mu.Lock()
+	cfg, provider, last := s.digestConfig, s.digestLLM, s.lastDigest
+	if cfg.Interval <= 0 || provider == nil {
+		s.mu.Unlock()
+		return nil, nil
+	}
+	interval := time.Duration(cfg.Interval) * time.Minute
+	if last.IsZero() {
+		// 首次启用时以一个间隔前为起点，避免汇总过旧的快讯
+		last = now.Add(-interval)
+		s.lastDigest = last
+	}
+	if now.Sub(last) < interval {
+		s.mu.Unlock()
+		return nil, nil
+	}
+	s.mu.Unlock()
+
+	telegraphs, err := s.GetTelegraphList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	batch := digestBatch(telegraphs, last)
+	if len(batch) < newsDigestMinItems {
+		return nil, nil
+	}
+
+	llm, err := provider(ctx, cfg.AIConfigID)
+	if err != nil {
+		return nil, err
+	}
+	response, err := callBriefingLLM(ctx, llm, buildDigestPrompt(batch))
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.lastDigest = now
+	s.mu.Unlock()
+
+	digest := parseDigestResponse(response)
+	digest.Count = len(batch)
+	digest.From = batch[len(batch)-1].Timestamp
+	digest.To = batch[0].Timestamp
+	digest.GeneratedAt = now.UnixMilli()
+	return &digest, nil
+}
+
+// digestBatch 选出晚于 since 的快讯（保持时间倒序），最多 newsDigestMaxItems 条
+func digestBatch(telegraphs []Telegraph, since time.Time) []Telegraph {
+	var batch []Telegraph
+	for _, t := range telegraphs {
+		if t.Timestamp > since.UnixMilli() {
+			batch = append(batch, t)
+			if len(batch) >= newsDigestMaxItems {
+				break
+			}
+		}
+	}
+	return batch
+}
+
+// buildDigestPrompt 构建快讯摘要提示词（按时间正序排列，便于梳理脉络）
+func buildDigestPrompt(batch []Telegraph) string {
+	var sb strings.Builder
+	sb.WriteString("以下是最近一段时间的财经快讯（按时间先后排列）：\n\n")
+	for i := len(batch) - 1; i >= 0; i-- {
+		fmt.Fprintf(&sb, "- [%s] %s\n", batch[i].Time, clipNews(batch[i].Content))
+	}
+	sb.WriteString(`
+请作为资深A股分析师，用 200 字以内梳理这段时间的市场主线与重要事件，忽略重复和无关紧要的消息，
+并列出快讯中明确涉及的A股股票代码（6位数字）。
+
+请严格按以下 JSON 格式返回，不要输出其他内容：
+{"summary": "市场脉络", "codes": ["600519"]}`)
+	return sb.String()
+}
+
+// parseDigestResponse 解析摘要结果，JSON 无法解析时将原文作为摘要；股票代码去重并过滤非法值
+func parseDigestResponse(response string) TelegraphDigest {
+	var resp struct {
+		Summary string   `json:"summary"`
+		Codes   []string `json:"codes"`
+	}
+	jsonStr := extractJSON(response)
+	if jsonStr == "" || json.Unmarshal([]byte(jsonStr), &resp) != nil {
+		return TelegraphDigest{Summary: strings.TrimSpace(response), Codes: []string{}}
+	}
+	digest := TelegraphDigest{Summary: strings.TrimSpace(resp.Summary), Codes: []string{}}
+	for _, code := range resp.Codes {
+		code = strings.ToLower(strings.TrimSpace(code))
+		if newsDigestCodeRe.MatchString(code) && !slices.Contains(digest.Codes, code) {
+			digest.Codes = append(digest.Codes, code)
+		}
+	}
+	return digest
+}
@@ -0,0 +1,244 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// 快讯来源
+const (
+	NewsSourceCLS       = "cls"       // 财联社电报
+	NewsSourceSina      = "sina"      // 新浪财经 7x24
+	NewsSourceEastmoney = "eastmoney" // 东方财富快讯
+)
+
+// NewsProvider 快讯来源
+type NewsProvider interface {
+	// Name 来源标识，用于配置启用/禁用
+	Name() string
+	// Fetch 获取最新快讯（按时间倒序）
+	Fetch(ctx context.Context, client *http.Client) ([]Telegraph, error)
+}
+
+// defaultNewsProviders 内置快讯来源
+func defaultNewsProviders() []NewsProvider {
+	return []NewsProvider{clsProvider{}, sinaProvider{}, eastmoneyNewsProvider{}}
+}
+
+// doNewsRequest 发送请求并记录数据源健康状态
+func doNewsRequest(ctx context.Context, client *http.Client, source string, req *http.Request) (*http.Response, error) {
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
+	if err := dataSourceHealth.Allow(source); err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil && ctx.Err() != nil {
+		dataSourceHealth.Abort(source)
+		return nil, err
+	}
+	if err == nil && resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		err = fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	dataSourceHealth.Record(source, time.Since(start), err)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// clsProvider 财联社电报（解析网页）
+type clsProvider struct{}
+
+func (clsProvider) Name() string { return NewsSourceCLS }
+
+func (clsProvider) Fetch(ctx context.Context, client *http.Client) ([]Telegraph, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://www.cls.cn/telegraph", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+	req.Header.Set("Accept-Language", "zh-CN,zh;q=0.9")
+
+	resp, err := doNewsRequest(ctx, client, SourceNews, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	telegraphs := make([]Telegraph, 0, 20)
+	now := time.Now()
+
+	// 解析快讯内容 - 查找包含 telegraph-content-box 的父级元素
+	// 父级元素同时包含内容和 subject-bottom-box（含详情链接）
+	doc.Find("div.telegraph-content-box").Each(func(i int, sel *goquery.Selection) {
+		if i >= 20 {
+			return
+		}
+
+		timeStr := strings.TrimSpace(sel.Find("span.telegraph-time-box").Text())
+
+		// 获取内容 - 内容在 span > div 结构中
+		content := cleanContent(strings.TrimSpace(sel.Find("span > div").Text()))
+
+		// 获取详情链接 - 链接在父级的兄弟元素 subject-bottom-box 中
+		url := ""
+		if href, exists := sel.Parent().Find("div.subject-bottom-box a[href^='/detail/']").Attr("href"); exists {
+			url = "https://www.cls.cn" + href
+		}
+
+		if content != "" {
+			telegraphs = append(telegraphs, Telegraph{
+				Time:      timeStr,
+				Content:   content,
+				URL:       url,
+				Source:    NewsSourceCLS,
+				Timestamp: parseTelegraphTime(timeStr, now),
+			})
+		}
+	})
+	return telegraphs, nil
+}
+
+// sinaProvider 新浪财经 7x24 直播
+type sinaProvider struct{}
+
+func (sinaProvider) Name() string { return NewsSourceSina }
+
+func (sinaProvider) Fetch(ctx context.Context, client *http.Client) ([]Telegraph, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET",
+		"https://zhibo.sina.com.cn/api/zhibo/feed?zhibo_id=152&page=1&page_size=20&tag_id=0&dire=f&dpc=1", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Referer", "https://finance.sina.com.cn/7x24/")
+
+	resp, err := doNewsRequest(ctx, client, SourceNewsSina, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Result struct {
+			Data struct {
+				Feed struct {
+					List []struct {
+						ID         int64  `json:"id"`
+						RichText   string `json:"rich_text"`
+						CreateTime string `json:"create_time"` // 2006-01-02 15:04:05
+					} `json:"list"`
+				} `json:"feed"`
+			} `json:"data"`
+		} `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("解析新浪快讯失败: %w", err)
+	}
+
+	now := time.Now()
+	telegraphs := make([]Telegraph, 0, len(result.Result.Data.Feed.List))
+	for _, item := range result.Result.Data.Feed.List {
+		content := cleanContent(item.RichText)
+		if content == "" {
+			continue
+		}
+		telegraphs = append(telegraphs, Telegraph{
+			Time:      shortTelegraphTime(item.CreateTime),
+			Content:   content,
+			URL:       fmt.Sprintf("https://finance.sina.com.cn/7x24/?id=%d", item.ID),
+			Source:    NewsSourceSina,
+			Timestamp: parseTelegraphTime(item.CreateTime, now),
+		})
+	}
+	return telegraphs, nil
+}
+
+// eastmoneyNewsProvider 东方财富 7x24 快讯
+type eastmoneyNewsProvider struct{}
+
+func (eastmoneyNewsProvider) Name() string { return NewsSourceEastmoney }
+
+func (eastmoneyNewsProvider) Fetch(ctx context.Context, client *http.Client) ([]Telegraph, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET",
+		"https://np-weblist.eastmoney.com/comm/web/getFastNewsList?client=web&biz=web_724&fastColumn=102&sortEnd=&pageSize=20", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Referer", "https://kuaixun.eastmoney.com/")
+
+	resp, err := doNewsRequest(ctx, client, SourceNewsEastmoney, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Data struct {
+			FastNewsList []struct {
+				Code     string `json:"code"`
+				Title    string `json:"title"`
+				Summary  string `json:"summary"`
+				ShowTime string `json:"showTime"` // 2006-01-02 15:04:05
+			} `json:"fastNewsList"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("解析东方财富快讯失败: %w", err)
+	}
+
+	now := time.Now()
+	telegraphs := make([]Telegraph, 0, len(result.Data.FastNewsList))
+	for _, item := range result.Data.FastNewsList {
+		content := item.Summary
+		if item.Title != "" && !strings.Contains(item.Summary, item.Title) {
+			content = "【" + item.Title + "】" + item.Summary
+		}
+		content = cleanContent(content)
+		if content == "" {
+			continue
+		}
+		telegraphs = append(telegraphs, Telegraph{
+			Time:      shortTelegraphTime(item.ShowTime),
+			Content:   content,
+			URL:       "https://finance.eastmoney.com/a/" + item.Code + ".html",
+			Source:    NewsSourceEastmoney,
+			Timestamp: parseTelegraphTime(item.ShowTime, now),
+		})
+	}
+	return telegraphs, nil
+}
+
+// parseTelegraphTime 解析快讯时间为毫秒时间戳，仅有时分秒时视为当天
+func parseTelegraphTime(s string, now time.Time) int64 {
+	s = strings.TrimSpace(s)
+	if t, err := time.ParseInLocation("2006-01-02 15:04:05", s, now.Location()); err == nil {
+		return t.UnixMilli()
+	}
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		if t, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+			return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), t.Second(), 0, now.Location()).UnixMilli()
+		}
+	}
+	return 0
+}
+
+// shortTelegraphTime 完整时间截取为时分秒，与财联社展示格式一致
+func shortTelegraphTime(s string) string {
+	if _, hms, ok := strings.Cut(strings.TrimSpace(s), " "); ok {
+		return hms
+	}
+	return s
+}
@@ -1,26 +1,55 @@
 package services
 
 import (
-	"io"
+	"cmp"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
 	"net/http"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
 	"time"
+	"unicode"
 
-	"github.com/PuerkitoBio/goquery"
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
 	"github.com/run-bigpig/jcp/internal/pkg/proxy"
 )
 
 // Telegraph 快讯数据结构
 type Telegraph struct {
-	Time    string `json:"time"`
-	Content string `json:"content"`
-	URL     string `json:"url"`
+	Time      string   `json:"time"`
+	Content   string   `json:"content"`
+	URL       string   `json:"url"`
+	Source    string   `json:"source"`             // 来源: cls / sina / eastmoney
+	Timestamp int64    `json:"timestamp"`          // 发布时间(毫秒)，用于多来源合并排序
+	Keywords  []string `json:"keywords,omitempty"` // 命中的提醒关键词
 }
 
+const (
+	telegraphCacheTTL  = 30 * time.Second
+	telegraphMergeSize = 50 // 合并后保留的快讯条数
+)
+
 // NewsService 资讯服务
+// 并发拉取多个快讯来源，按内容哈希去重后按时间倒序合并
 type NewsService struct {
-	client *http.Client
+	client    *http.Client
+	providers []NewsProvider
+
+	// 禁用的来源（config.news.disabledSources）
+	disabled map[string]bool
+	// 提醒关键词（config.news.alertKeywords）
+	keywords []string
+	// 本地归档（可选），每次拉取后写入供历史检索
+	archive *NewsArchive
+	// 快讯 AI 摘要（config.news.digest）
+	digestConfig models.NewsDigestConfig
+	digestLLM    BriefingLLMProvider
+	lastDigest   time.Time
 
 	// 缓存
 	telegraphs    []Telegraph
@@ -32,83 +61,126 @@ type NewsService struct {
 func NewNewsService() *NewsService {
 	return &NewsService{
 		client:     proxy.GetManager().GetClientWithTimeout(10 * time.Second),
+		providers:  defaultNewsProviders(),
+		disabled:   map[string]bool{},
 		telegraphs: make([]Telegraph, 0),
 	}
 }
 
-// GetTelegraphList 获取财联社快讯列表
-func (s *NewsService) GetTelegraphList() ([]Telegraph, error) {
+// SetArchive 设置快讯本地归档
+func (s *NewsService) SetArchive(archive *NewsArchive) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.archive = archive
+}
+
+// CloseArchive 关闭快讯归档数据库（应用退出时调用）
+func (s *NewsService) CloseArchive() {
+	s.mu.RLock()
+	archive := s.archive
+	s.mu.RUnlock()
+	if archive != nil {
+		if err := archive.Close(); err != nil {
+			log.Warn("关闭快讯归档失败: %v", err)
+		}
+	}
+}
+
+// SearchHistory 检索已归档的历史快讯
+func (s *NewsService) SearchHistory(keyword, startDate, endDate string) ([]Telegraph, error) {
+	s.mu.RLock()
+	archive := s.archive
+	s.mu.RUnlock()
+	if archive == nil {
+		return nil, i18n.Errorf("error.news.archive_disabled")
+	}
+	return archive.Search(keyword, startDate, endDate)
+}
+
+// SetDisabledSources 设置禁用的快讯来源，变更后清空缓存
+func (s *NewsService) SetDisabledSources(sources []string) {
+	disabled := make(map[string]bool, len(sources))
+	for _, src := range sources {
+		disabled[src] = true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.disabled = disabled
+	s.lastFetchTime = time.Time{}
+}
+
+// SetAlertKeywords 设置提醒关键词，变更后清空缓存以便重新标记
+func (s *NewsService) SetAlertKeywords(keywords []string) {
+	var cleaned []string
+	for _, kw := range keywords {
+		if kw = strings.TrimSpace(kw); kw != "" && !slices.Contains(cleaned, kw) {
+			cleaned = append(cleaned, kw)
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keywords = cleaned
+	s.lastFetchTime = time.Time{}
+}
+
+// GetTelegraphList 获取快讯列表（多来源合并去重）
+func (s *NewsService) GetTelegraphList(ctx context.Context) ([]Telegraph, error) {
 	// 检查缓存，30秒内不重复请求
 	s.mu.RLock()
-	if time.Since(s.lastFetchTime) < 30*time.Second && len(s.telegraphs) > 0 {
+	if time.Since(s.lastFetchTime) < telegraphCacheTTL && len(s.telegraphs) > 0 {
 		result := make([]Telegraph, len(s.telegraphs))
 		copy(result, s.telegraphs)
 		s.mu.RUnlock()
 		return result, nil
 	}
+	var providers []NewsProvider
+	for _, p := range s.providers {
+		if !s.disabled[p.Name()] {
+			providers = append(providers, p)
+		}
+	}
+	keywords, archive := s.keywords, s.archive
 	s.mu.RUnlock()
 
-	// 请求财联社快讯页面
-	req, err := http.NewRequest("GET", "https://www.cls.cn/telegraph", nil)
-	if err != nil {
-		return nil, err
+	if len(providers) == 0 {
+		return []Telegraph{}, nil
 	}
 
-	// 设置请求头，模拟浏览器
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
-	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
-	req.Header.Set("Accept-Language", "zh-CN,zh;q=0.9")
-
-	resp, err := s.client.Do(req)
-	if err != nil {
-		return nil, err
+	// 并发拉取，部分来源失败时仍返回其余来源的数据
+	lists := make([][]Telegraph, len(providers))
+	errs := make([]error, len(providers))
+	var wg sync.WaitGroup
+	for i, p := range providers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			lists[i], errs[i] = p.Fetch(ctx, s.client)
+		}()
 	}
-	defer resp.Body.Close()
+	wg.Wait()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+	var firstErr error
+	failed := 0
+	for i, err := range errs {
+		if err != nil {
+			failed++
+			firstErr = cmp.Or(firstErr, fmt.Errorf("%s: %w", providers[i].Name(), err))
+		}
 	}
-
-	// 解析 HTML
-	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(body)))
-	if err != nil {
-		return nil, err
+	if failed == len(providers) {
+		return nil, firstErr
 	}
 
-	telegraphs := make([]Telegraph, 0, 20)
-
-	// 解析快讯内容 - 查找包含 telegraph-content-box 的父级元素
-	// 父级元素同时包含内容和 subject-bottom-box（含详情链接）
-	doc.Find("div.telegraph-content-box").Each(func(i int, sel *goquery.Selection) {
-		if i >= 20 {
-			return
-		}
-
-		// 获取时间
-		timeStr := sel.Find("span.telegraph-time-box").Text()
-		timeStr = strings.TrimSpace(timeStr)
-
-		// 获取内容 - 内容在 span > div 结构中
-		content := sel.Find("span > div").Text()
-		content = strings.TrimSpace(content)
-		content = cleanContent(content)
-
-		// 获取详情链接 - 链接在父级的兄弟元素 subject-bottom-box 中
-		url := ""
-		parent := sel.Parent()
-		if href, exists := parent.Find("div.subject-bottom-box a[href^='/detail/']").Attr("href"); exists {
-			url = "https://www.cls.cn" + href
-		}
+	telegraphs := mergeTelegraphs(lists, telegraphMergeSize)
+	for i := range telegraphs {
+		telegraphs[i].Keywords = matchKeywords(telegraphs[i].Content, keywords)
+	}
 
-		if content != "" {
-			telegraphs = append(telegraphs, Telegraph{
-				Time:    timeStr,
-				Content: content,
-				URL:     url,
-			})
+	if archive != nil {
+		if err := archive.Add(telegraphs); err != nil {
+			log.Warn("快讯归档失败: %v", err)
 		}
-	})
+	}
 
 	// 更新缓存
 	s.mu.Lock()
@@ -119,6 +191,60 @@ func (s *NewsService) GetTelegraphList() ([]Telegraph, error) {
 	return telegraphs, nil
 }
 
+// mergeTelegraphs 合并多来源快讯：按内容哈希去重（保留先出现的来源），按时间倒序
+func mergeTelegraphs(lists [][]Telegraph, limit int) []Telegraph {
+	seen := map[string]bool{}
+	merged := make([]Telegraph, 0, limit)
+	for _, list := range lists {
+		for _, t := range list {
+			key := telegraphHash(t.Content)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged = append(merged, t)
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool { return merged[i].Timestamp > merged[j].Timestamp })
+	if len(merged) > limit {
+		merged = merged[:limit]
+	}
+	return merged
+}
+
+// matchKeywords 返回内容中命中的关键词（忽略英文大小写）
+func matchKeywords(content string, keywords []string) []string {
+	var matched []string
+	lower := strings.ToLower(content)
+	for _, kw := range keywords {
+		if strings.Contains(lower, strings.ToLower(kw)) {
+			matched = append(matched, kw)
+		}
+	}
+	return matched
+}
+
+// telegraphHash 快讯内容哈希：去掉【标题】、空白与标点后取前 60 个字符，
+// 使不同来源对同一事件的转述（标点、标题格式不同）得到相同的哈希
+func telegraphHash(content string) string {
+	if i := strings.Index(content, "】"); strings.HasPrefix(content, "【") && i > 0 && i+len("】") < len(content) {
+		content = content[i+len("】"):]
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range content {
+		if unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r) {
+			continue
+		}
+		b.WriteRune(r)
+		if n++; n >= 60 {
+			break
+		}
+	}
+	sum := sha1.Sum([]byte(b.String()))
+	return hex.EncodeToString(sum[:])
+}
+
 // GetLatestTelegraph 获取最新一条快讯
 func (s *NewsService) GetLatestTelegraph() *Telegraph {
 	s.mu.RLock()
@@ -1,13 +1,17 @@
 package services
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestGetTelegraphList(t *testing.T) {
 	service := NewNewsService()
 
-	telegraphs, err := service.GetTelegraphList()
+	telegraphs, err := service.GetTelegraphList(context.Background())
 	if err != nil {
 		t.Fatalf("获取快讯失败: %v", err)
 	}
@@ -46,7 +50,7 @@ func TestGetLatestTelegraph(t *testing.T) {
 	service := NewNewsService()
 
 	// 先获取列表填充缓存
-	_, err := service.GetTelegraphList()
+	_, err := service.GetTelegraphList(context.Background())
 	if err != nil {
 		t.Fatalf("获取快讯失败: %v", err)
 	}
@@ -69,3 +73,207 @@ func truncate(s string, maxLen int) string {
 	}
 	return string(runes[:maxLen]) + "..."
 }
+
+// TestMergeTelegraphs 测试多来源快讯去重与按时间合并
+func TestMergeTelegraphs(t *testing.T) {
+	cls := []Telegraph{
+		{Content: "【央行】央行今日开展1000亿元逆回购操作。", Source: NewsSourceCLS, Timestamp: 3000},
+		{Content: "沪指午盘涨0.5%", Source: NewsSourceCLS, Timestamp: 1000},
+	}
+	sina := []Telegraph{
+		{Content: "央行今日开展1000亿元逆回购操作", Source: NewsSourceSina, Timestamp: 2900},
+		{Content: "美元指数跌0.2%", Source: NewsSourceSina, Timestamp: 2000},
+	}
+
+	merged := mergeTelegraphs([][]Telegraph{cls, sina}, 50)
+	if len(merged) != 3 {
+		t.Fatalf("应去重为 3 条, got %d", len(merged))
+	}
+	if merged[0].Source != NewsSourceCLS || merged[1].Timestamp != 2000 || merged[2].Timestamp != 1000 {
+		t.Errorf("合并顺序不符: %+v", merged)
+	}
+	if got := mergeTelegraphs([][]Telegraph{cls, sina}, 2); len(got) != 2 {
+		t.Errorf("应截断为 2 条, got %d", len(got))
+	}
+}
+
+// TestMatchKeywords 测试快讯关键词匹配
+func TestMatchKeywords(t *testing.T) {
+	got := matchKeywords("某公司股东拟减持不超过2%股份，ST 摘帽在即", []string{"减持", "重组", "st"})
+	if len(got) != 2 || got[0] != "减持" || got[1] != "st" {
+		t.Errorf("匹配结果不符: %v", got)
+	}
+	if got := matchKeywords("沪指午盘涨0.5%", nil); got != nil {
+		t.Errorf("无关键词时应返回 nil, got %v", got)
+	}
+}
+
+// TestNewsArchive 测试快讯归档去重与按日期范围检索
+func TestNewsArchive(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.Local)
+	archive := NewNewsArchive(t.TempDir())
+	archive.now = func() time.Time { return now }
+	t.Cleanup(func() { archive.Close() })
+
+	yesterday := now.AddDate(0, 0, -1).UnixMilli()
+	list := []Telegraph{
+		{Content: "某公司股东拟减持股份", Timestamp: now.UnixMilli()},
+		{Content: "某公司筹划重大资产重组", Timestamp: yesterday},
+	}
+	if err := archive.Add(list); err != nil {
+		t.Fatal(err)
+	}
+	if err := archive.Add(list[:1]); err != nil { // 重复归档应跳过
+		t.Fatal(err)
+	}
+
+	all, err := archive.Search("", "", "")
+	if err != nil || len(all) != 2 {
+		t.Fatalf("应检索到 2 条, got %d err=%v", len(all), err)
+	}
+	if all[0].Timestamp != now.UnixMilli() {
+		t.Errorf("应按时间倒序, got %+v", all)
+	}
+	if got, _ := archive.Search("重组", "", ""); len(got) != 1 {
+		t.Errorf("关键词检索应命中 1 条, got %d", len(got))
+	}
+	if got, _ := archive.Search("", "2026-03-10", "2026-03-10"); len(got) != 1 {
+		t.Errorf("日期范围检索应命中 1 条, got %d", len(got))
+	}
+	if _, err := archive.Search("", "2026-03-11", "2026-03-10"); err == nil {
+		t.Error("起始日期晚于结束日期应报错")
+	}
+}
+
+// TestNewsArchiveFullText 测试全文检索：长词走 FTS5，短词与通配符走 LIKE，多词需全部命中
+func TestNewsArchiveFullText(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.Local)
+	archive := NewNewsArchive(t.TempDir())
+	archive.now = func() time.Time { return now }
+	t.Cleanup(func() { archive.Close() })
+
+	if err := archive.Add([]Telegraph{
+		{Content: "英伟达发布新一代 GPU，算力芯片板块走强", Timestamp: now.UnixMilli()},
+		{Content: "央行开展逆回购操作，利率维持不变", Timestamp: now.Add(-time.Minute).UnixMilli()},
+		{Content: "某公司 100% 控股子公司完成增资", Timestamp: now.Add(-2 * time.Minute).UnixMilli()},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	cases := map[string]int{
+		"算力芯片":     1,
+		"gpu":      1, // 不区分大小写
+		"芯片 英伟达":   1,
+		"芯片 逆回购":   0,
+		"利率":       1, // 两个字走 LIKE
+		"100%":     1,
+		"%":        1, // 通配符按字面匹配
+		`"逆回购"`:    0,
+		"公司 子公司增资": 0,
+	}
+	for keyword, want := range cases {
+		got, err := archive.Search(keyword, "", "")
+		if err != nil || len(got) != want {
+			t.Errorf("Search(%q) = %d 条, err=%v, want %d", keyword, len(got), err, want)
+		}
+	}
+}
+
+// TestNewsArchiveImportLegacy 测试旧版 jsonl 归档导入数据库后删除
+func TestNewsArchiveImportLegacy(t *testing.T) {
+	dataDir := t.TempDir()
+	dir := filepath.Join(dataDir, "news")
+	os.MkdirAll(dir, 0755)
+	legacy := filepath.Join(dir, "2026-03-09.jsonl")
+	os.WriteFile(legacy, []byte(`{"content":"旧版归档的重组公告","timestamp":1773000000000}
+损坏的行
+{"content":"旧版归档的重组公告","timestamp":1773000000000}
+`), 0644)
+
+	archive := NewNewsArchive(dataDir)
+	archive.now = func() time.Time { return time.Date(2026, 3, 10, 15, 0, 0, 0, time.Local) }
+	t.Cleanup(func() { archive.Close() })
+	got, err := archive.Search("重组公告", "", "")
+	if err != nil || len(got) != 1 {
+		t.Fatalf("应导入 1 条旧版快讯, got %d err=%v", len(got), err)
+	}
+	if _, err := os.Stat(legacy); !os.IsNotExist(err) {
+		t.Error("导入后应删除旧版文件")
+	}
+}
+
+// TestStockNewsMatcher 测试个股资讯按代码与名称匹配
+func TestStockNewsMatcher(t *testing.T) {
+	match := stockNewsMatcher("sz000001", "平安银行")
+	cases := map[string]bool{
+		"平安银行发布年报，净利润同比增长":   true,
+		"000001 午后拉升":        true,
+		"成交额 10000001 元":     false,
+		"沪指午盘涨0.5%":          false,
+		"（000001.SZ）获北向资金增持": true,
+	}
+	for content, want := range cases {
+		if got := match(content); got != want {
+			t.Errorf("match(%q) = %v, want %v", content, got, want)
+		}
+	}
+
+	st := stockNewsMatcher("sh600000", "*ST 某某A")
+	if !st("某某公司公告撤销退市风险警示") {
+		t.Error("应匹配去掉 *ST 前缀与 A 后缀的简称")
+	}
+}
+
+// TestGetStockNewsFromArchive 测试个股资讯从归档检索并去重排序
+func TestGetStockNewsFromArchive(t *testing.T) {
+	now := time.Now()
+	archive := NewNewsArchive(t.TempDir())
+	t.Cleanup(func() { archive.Close() })
+	if err := archive.Add([]Telegraph{
+		{Content: "平安银行发布年报", Timestamp: now.Add(-time.Hour).UnixMilli()},
+		{Content: "【公告】000001 平安银行董事会决议", Timestamp: now.UnixMilli()},
+		{Content: "沪指午盘涨0.5%", Timestamp: now.UnixMilli()},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	service := NewNewsService()
+	service.providers = nil // 不请求网络
+	service.SetArchive(archive)
+
+	got, err := service.GetStockNews(context.Background(), "sz000001", "平安银行", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Timestamp < got[1].Timestamp {
+		t.Errorf("应按时间倒序返回 2 条, got %+v", got)
+	}
+	if got, _ := service.GetStockNews(context.Background(), "sz000001", "平安银行", 1); len(got) != 1 {
+		t.Errorf("应截断为 1 条, got %d", len(got))
+	}
+}
+
+// TestDigestBatch 测试快讯摘要按起始时间选取新增快讯
+func TestDigestBatch(t *testing.T) {
+	since := time.UnixMilli(2000)
+	list := []Telegraph{{Timestamp: 3000}, {Timestamp: 2500}, {Timestamp: 2000}, {Timestamp: 1000}}
+	batch := digestBatch(list, since)
+	if len(batch) != 2 || batch[0].Timestamp != 3000 || batch[1].Timestamp != 2500 {
+		t.Errorf("应选出晚于起始时间的 2 条, got %+v", batch)
+	}
+}
+
+// TestParseDigestResponse 测试快讯摘要解析与股票代码过滤
+func TestParseDigestResponse(t *testing.T) {
+	d := parseDigestResponse("```json\n{\"summary\": \" 市场主线为算力 \", \"codes\": [\"600519\", \"SZ000001\", \"600519\", \"abc\"]}\n```")
+	if d.Summary != "市场主线为算力" {
+		t.Errorf("summary = %q", d.Summary)
+	}
+	if len(d.Codes) != 2 || d.Codes[0] != "600519" || d.Codes[1] != "sz000001" {
+		t.Errorf("codes = %v", d.Codes)
+	}
+
+	raw := parseDigestResponse("今日市场震荡")
+	if raw.Summary != "今日市场震荡" || raw.Codes == nil {
+		t.Errorf("无法解析时应使用原文, got %+v", raw)
+	}
+}
@@ -0,0 +1,256 @@
+package services
+
+import (
+	"cmp"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/logger"
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/sound"
+
+	"github.com/google/uuid"
+)
+
+var notifyLog = logger.New("notify")
+
+// EventNotification 新通知事件（免打扰时段内不推送）
+const EventNotification = "notification:new"
+
+const defaultNotificationHistory = 500
+
+// NotificationService 通知中心
+// 汇总条件单、快讯提醒与 AI 简报等通知，保存到数据目录 notifications.json，
+// 支持已读/未读、按分类关闭、提示音与免打扰时段（期间仅记录不弹出、不响铃）
+type NotificationService struct {
+	path     string
+	mu       sync.Mutex
+	items    []models.Notification // 按时间正序
+	loaded   bool
+	cfg      models.NotificationConfig
+	onNotify func(models.Notification)
+	now      func() time.Time
+	// 提示音播放，tone 为内置提示音，path 非空时播放自定义音频
+	play func(tone, path string, volume int) error
+}
+
+// NewNotificationService 创建通知中心
+func NewNotificationService(dataDir string) *NotificationService {
+	return &NotificationService{
+		path: filepath.Join(dataDir, "notifications.json"),
+		now:  time.Now,
+		play: playSound,
+	}
+}
+
+// SetConfig 设置分类开关与免打扰时段
+func (s *NotificationService) SetConfig(cfg models.NotificationConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cfg = cfg
+}
+
+// SetOnNotify 设置通知回调（免打扰时段与分类关闭时不回调）
+func (s *NotificationService) SetOnNotify(fn func(models.Notification)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onNotify = fn
+}
+
+// loadLocked 加载历史(需要已持有锁)
+func (s *NotificationService) loadLocked() {
+	if s.loaded {
+		return
+	}
+	s.loaded = true
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return
+	}
+	if err := json.Unmarshal(data, &s.items); err != nil {
+		notifyLog.Warn("解析通知历史失败: %v", err)
+	}
+}
+
+// saveLocked 保存历史(需要已持有锁)
+func (s *NotificationService) saveLocked() error {
+	data, err := json.MarshalIndent(s.items, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileTracked(s.path, data, 0644)
+}
+
+// Notify 记录一条通知，分类已关闭时丢弃；返回是否已记录
+func (s *NotificationService) Notify(n models.Notification) bool {
+	s.mu.Lock()
+	if slices.Contains(s.cfg.DisabledCategories, n.Category) {
+		s.mu.Unlock()
+		return false
+	}
+	s.loadLocked()
+
+	now := s.now()
+	n.ID = uuid.New().String()
+	n.CreatedAt = now.UnixMilli()
+	n.Read = false
+	n.Silent = inQuietHours(s.cfg.QuietHours, now)
+	s.items = append(s.items, n)
+	limit := s.cfg.MaxHistory
+	if limit <= 0 {
+		limit = defaultNotificationHistory
+	}
+	if len(s.items) > limit {
+		s.items = slices.Delete(s.items, 0, len(s.items)-limit)
+	}
+	if err := s.saveLocked(); err != nil {
+		notifyLog.Warn("保存通知历史失败: %v", err)
+	}
+	onNotify, soundCfg := s.onNotify, s.cfg.Sound
+	s.mu.Unlock()
+
+	if n.Silent {
+		return true
+	}
+	if onNotify != nil {
+		onNotify(n)
+	}
+	if soundCfg.Enabled {
+		key := cmp.Or(n.Kind, n.Category)
+		if tone, path, ok := soundFor(soundCfg, key); ok {
+			if err := s.play(tone, path, soundCfg.Volume); err != nil {
+				notifyLog.Warn("播放提示音失败: %v", err)
+			}
+		}
+	}
+	return true
+}
+
+// PreviewSound 试听某个分类的提示音（不受提示音开关影响）
+func (s *NotificationService) PreviewSound(key string) error {
+	s.mu.Lock()
+	cfg := s.cfg.Sound
+	s.mu.Unlock()
+	tone, path, ok := soundFor(cfg, key)
+	if !ok {
+		return nil
+	}
+	return s.play(tone, path, cfg.Volume)
+}
+
+// soundFor 查找分类对应的提示音：自定义文件优先，未配置时使用内置提示音，配置为 none 时不播放
+func soundFor(cfg models.SoundConfig, key string) (tone, path string, ok bool) {
+	switch v := strings.TrimSpace(cfg.Sounds[key]); v {
+	case "none":
+		return "", "", false
+	case "":
+		switch key {
+		case models.NotificationLimitUp:
+			return sound.ToneRising, "", true
+		case models.NotificationLimitDown:
+			return sound.ToneFalling, "", true
+		default:
+			return sound.ToneDefault, "", true
+		}
+	default:
+		return "", v, true
+	}
+}
+
+// playSound 播放内置提示音或自定义音频
+func playSound(tone, path string, volume int) error {
+	if path != "" {
+		return sound.PlayFile(path, volume)
+	}
+	return sound.PlayTone(tone, volume)
+}
+
+// List 按条件筛选通知，按时间倒序
+func (s *NotificationService) List(filter models.NotificationFilter) []models.Notification {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loadLocked()
+
+	result := []models.Notification{}
+	for i := len(s.items) - 1; i >= 0; i-- {
+		n := s.items[i]
+		if filter.Category != "" && n.Category != filter.Category {
+			continue
+		}
+		if filter.UnreadOnly && n.Read {
+			continue
+		}
+		result = append(result, n)
+		if filter.Limit > 0 && len(result) >= filter.Limit {
+			break
+		}
+	}
+	return result
+}
+
+// UnreadCount 未读通知数
+func (s *NotificationService) UnreadCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loadLocked()
+
+	count := 0
+	for _, n := range s.items {
+		if !n.Read {
+			count++
+		}
+	}
+	return count
+}
+
+// MarkRead 标记通知为已读，ids 为空时全部标记
+func (s *NotificationService) MarkRead(ids []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loadLocked()
+
+	changed := false
+	for i := range s.items {
+		if !s.items[i].Read && (len(ids) == 0 || slices.Contains(ids, s.items[i].ID)) {
+			s.items[i].Read = true
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return s.saveLocked()
+}
+
+// Clear 清空通知历史
+func (s *NotificationService) Clear() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loaded = true
+	s.items = nil
+	return s.saveLocked()
+}
+
+// inQuietHours 当前是否处于免打扰时段（按北京时间），时间格式错误时视为未开启
+func inQuietHours(q models.QuietHours, now time.Time) bool {
+	if !q.Enabled {
+		return false
+	}
+	start, err1 := time.Parse("15:04", strings.TrimSpace(q.Start))
+	end, err2 := time.Parse("15:04", strings.TrimSpace(q.End))
+	if err1 != nil || err2 != nil {
+		return false
+	}
+	now = now.In(time.FixedZone("CST", 8*60*60))
+	m := now.Hour()*60 + now.Minute()
+	from, to := start.Hour()*60+start.Minute(), end.Hour()*60+end.Minute()
+	if from <= to {
+		return m >= from && m < to
+	}
+	return m >= from || m < to
+}
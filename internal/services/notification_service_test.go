@@ -0,0 +1,134 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+func TestNotificationService(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 3, 10, 10, 0, 0, 0, time.FixedZone("CST", 8*60*60))
+	s := NewNotificationService(dir)
+	s.now = func() time.Time { return now }
+	s.SetConfig(models.NotificationConfig{DisabledCategories: []string{models.NotificationBriefing}, MaxHistory: 3})
+
+	var pushed []models.Notification
+	s.SetOnNotify(func(n models.Notification) { pushed = append(pushed, n) })
+
+	if s.Notify(models.Notification{Category: models.NotificationBriefing, Title: "简报"}) {
+		t.Error("已关闭的分类不应记录")
+	}
+	for _, title := range []string{"a", "b", "c", "d"} {
+		s.Notify(models.Notification{Category: models.NotificationPrice, Title: title})
+	}
+	list := s.List(models.NotificationFilter{})
+	if len(list) != 3 || list[0].Title != "d" || list[2].Title != "b" {
+		t.Fatalf("应按上限保留最近 3 条并倒序, got %+v", list)
+	}
+	if len(pushed) != 4 {
+		t.Errorf("应推送 4 条, got %d", len(pushed))
+	}
+
+	if err := s.MarkRead([]string{list[0].ID}); err != nil {
+		t.Fatal(err)
+	}
+	if n := s.UnreadCount(); n != 2 {
+		t.Errorf("未读数 = %d, want 2", n)
+	}
+
+	// 重新加载后已读状态保持
+	reloaded := NewNotificationService(dir)
+	if got := reloaded.List(models.NotificationFilter{UnreadOnly: true}); len(got) != 2 {
+		t.Errorf("重新加载后未读应为 2 条, got %d", len(got))
+	}
+	if err := reloaded.MarkRead(nil); err != nil || reloaded.UnreadCount() != 0 {
+		t.Errorf("全部标记已读失败: %v", err)
+	}
+	if err := reloaded.Clear(); err != nil || len(reloaded.List(models.NotificationFilter{})) != 0 {
+		t.Errorf("清空失败: %v", err)
+	}
+}
+
+func TestNotificationQuietHours(t *testing.T) {
+	cst := time.FixedZone("CST", 8*60*60)
+	q := models.QuietHours{Enabled: true, Start: "22:00", End: "08:00"}
+	cases := []struct {
+		at   time.Time
+		want bool
+	}{
+		{time.Date(2026, 3, 10, 23, 0, 0, 0, cst), true},
+		{time.Date(2026, 3, 10, 7, 59, 0, 0, cst), true},
+		{time.Date(2026, 3, 10, 8, 0, 0, 0, cst), false},
+		{time.Date(2026, 3, 10, 14, 0, 0, 0, time.UTC), true}, // 北京时间 22:00
+	}
+	for _, c := range cases {
+		if got := inQuietHours(q, c.at); got != c.want {
+			t.Errorf("inQuietHours(%v) = %v, want %v", c.at, got, c.want)
+		}
+	}
+
+	s := NewNotificationService(t.TempDir())
+	s.now = func() time.Time { return time.Date(2026, 3, 10, 23, 0, 0, 0, cst) }
+	s.SetConfig(models.NotificationConfig{QuietHours: q})
+	pushed := 0
+	s.SetOnNotify(func(models.Notification) { pushed++ })
+	s.Notify(models.Notification{Category: models.NotificationNews, Title: "快讯"})
+	if list := s.List(models.NotificationFilter{}); pushed != 0 || len(list) != 1 || !list[0].Silent {
+		t.Errorf("免打扰时段应仅记录不推送, pushed=%d list=%+v", pushed, list)
+	}
+}
+
+func TestNotificationSound(t *testing.T) {
+	s := NewNotificationService(t.TempDir())
+	var played []string
+	s.play = func(tone, path string, volume int) error {
+		played = append(played, tone+path)
+		return nil
+	}
+	s.SetConfig(models.NotificationConfig{Sound: models.SoundConfig{
+		Enabled: true,
+		Sounds:  map[string]string{models.NotificationNews: "none", models.NotificationPrice: "/tmp/ding.wav"},
+	}})
+
+	s.Notify(models.Notification{Category: models.NotificationNews})
+	s.Notify(models.Notification{Category: models.NotificationPrice})
+	s.Notify(models.Notification{Category: models.NotificationLimit, Kind: models.NotificationLimitUp})
+	s.Notify(models.Notification{Category: models.NotificationLimit, Kind: models.NotificationLimitDown})
+	want := []string{"/tmp/ding.wav", "rising", "falling"}
+	if len(played) != len(want) {
+		t.Fatalf("played = %v, want %v", played, want)
+	}
+	for i := range want {
+		if played[i] != want[i] {
+			t.Errorf("played[%d] = %s, want %s", i, played[i], want[i])
+		}
+	}
+}
+
+func TestLimitWatcher(t *testing.T) {
+	w := NewLimitWatcher()
+	up := models.Stock{Symbol: "sh600000", Name: "浦发银行", PreClose: 10, Price: 11}
+	flat := models.Stock{Symbol: "sz300750", Name: "宁德时代", PreClose: 200, Price: 201}
+
+	if ev := w.Observe([]models.Stock{up, flat}); len(ev) != 0 {
+		t.Errorf("首轮只记录状态, got %+v", ev)
+	}
+	if ev := w.Observe([]models.Stock{up}); len(ev) != 0 {
+		t.Errorf("持续封板不应重复提醒, got %+v", ev)
+	}
+
+	flat.Price = 240 // 创业板 20% 涨停
+	down := up
+	down.Price = 9
+	ev := w.Observe([]models.Stock{down, flat})
+	if len(ev) != 2 || ev[0].Up || !ev[1].Up {
+		t.Errorf("应产生跌停与涨停事件, got %+v", ev)
+	}
+
+	st := models.Stock{Symbol: "sh600001", Name: "ST某某", PreClose: 10, Price: 10.5}
+	if limitState(st) != 1 {
+		t.Error("ST 股 5% 应为涨停")
+	}
+}
@@ -0,0 +1,406 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+)
+
+// 新浪期权接口：合约月份与到期日
+const (
+	optionMonthsURL = "https://stock.finance.sina.com.cn/futures/api/openapi.php/StockOptionService.getStockName?exchange=null&cate=%s"
+	optionExpiryURL = "https://stock.finance.sina.com.cn/futures/api/openapi.php/StockOptionService.getRemainderDay?exchange=null&cate=%s&date=%s"
+)
+
+const (
+	optionChainTradingTTL = 3 * time.Second  // 交易时段T型报价缓存
+	optionChainIdleTTL    = 5 * time.Minute  // 非交易时段T型报价缓存
+	optionMetaTTL         = 1 * time.Hour    // 合约月份与到期日缓存
+	defaultRiskFreeRate   = 2.0              // 无 SHIBOR 时使用的无风险利率(%)
+	optionMinExpiryYears  = 1.0 / (365 * 24) // 到期日当天收盘前按至少 1 小时计算
+)
+
+// optionUnderlying 期权标的
+type optionUnderlying struct {
+	cate string // 新浪品种参数
+	name string
+}
+
+// optionUnderlyings 支持的ETF期权标的
+var optionUnderlyings = map[string]optionUnderlying{
+	"sh510050": {"50ETF", "上证50ETF"},
+	"sh510300": {"300ETF", "沪深300ETF"},
+}
+
+// optionCacheEntry 期权缓存条目
+type optionCacheEntry struct {
+	value    any
+	expireAt time.Time
+}
+
+// OptionService ETF期权T型报价服务
+// 行情来自新浪，隐含波动率按 Black-Scholes（欧式、无分红）以 3 个月 SHIBOR 为无风险利率求解
+type OptionService struct {
+	marketService *MarketService
+
+	cache   map[string]optionCacheEntry
+	cacheMu sync.Mutex
+}
+
+// NewOptionService 创建期权服务
+func NewOptionService(marketService *MarketService) *OptionService {
+	return &OptionService{marketService: marketService, cache: make(map[string]optionCacheEntry)}
+}
+
+// GetOptionChain 获取期权T型报价
+// underlying: 标的代码 sh510050 / sh510300；month: 合约月份 YYYY-MM，为空时取最近月份
+func (s *OptionService) GetOptionChain(ctx context.Context, underlying, month string) (*models.OptionChain, error) {
+	u, ok := optionUnderlyings[underlying]
+	if !ok {
+		return nil, i18n.Errorf("error.option.underlying_unsupported", underlying)
+	}
+	months, err := s.months(ctx, u.cate)
+	if err != nil {
+		return nil, err
+	}
+	if month == "" {
+		month = months[0]
+	}
+	if !slices.Contains(months, month) {
+		return nil, i18n.Errorf("error.option.month_invalid", month)
+	}
+
+	key := "chain:" + underlying + ":" + month
+	if v, ok := s.getCache(key); ok {
+		return v.(*models.OptionChain), nil
+	}
+
+	expiry, err := s.expiry(ctx, u.cate, month)
+	if err != nil {
+		return nil, err
+	}
+	chain, err := s.fetchChain(ctx, underlying, month, expiry)
+	if err != nil {
+		return nil, err
+	}
+	chain.UnderlyingName = u.name
+	chain.Months = months
+
+	ttl := optionChainIdleTTL
+	if s.marketService.GetMarketStatus().Status == "trading" {
+		ttl = optionChainTradingTTL
+	}
+	s.setCache(key, chain, ttl)
+	return chain, nil
+}
+
+// fetchChain 拉取认购/认沽合约列表及行情并组装T型报价
+func (s *OptionService) fetchChain(ctx context.Context, underlying, month, expiry string) (*models.OptionChain, error) {
+	suffix := strings.TrimPrefix(underlying, "sh") + strings.ReplaceAll(month, "-", "")[2:]
+	listBody, err := s.marketService.fetchSinaQuotes(ctx, []string{"OP_UP_" + suffix, "OP_DOWN_" + suffix})
+	if err != nil {
+		return nil, err
+	}
+	calls, puts := parseOptionCodeLists(listBody)
+	if len(calls)+len(puts) == 0 {
+		return nil, fmt.Errorf("未获取到 %s 期权合约", month)
+	}
+
+	quoteBody, err := s.marketService.fetchSinaQuotes(ctx, append(append([]string{underlying}, calls...), puts...))
+	if err != nil {
+		return nil, err
+	}
+	chain := &models.OptionChain{
+		Underlying:   underlying,
+		Month:        month,
+		Expiry:       expiry,
+		RiskFreeRate: s.riskFreeRate(ctx),
+		UpdatedAt:    time.Now().UnixMilli(),
+	}
+	stocks, _ := s.marketService.parseSinaStockData(quoteBody, []string{underlying})
+	for _, st := range stocks {
+		if st.Symbol == underlying {
+			chain.UnderlyingPrice = st.Price
+		}
+	}
+
+	now := time.Now().In(time.FixedZone("CST", 8*60*60))
+	years := optionYearsToExpiry(now, expiry)
+	if exp, err := time.ParseInLocation("2006-01-02", expiry, now.Location()); err == nil {
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		chain.DaysToExpiry = int(math.Round(exp.Sub(today).Hours() / 24))
+	}
+
+	chain.Rows = buildOptionRows(parseOptionQuotes(quoteBody, calls, puts), chain.UnderlyingPrice, years, chain.RiskFreeRate/100)
+	return chain, nil
+}
+
+// riskFreeRate 3 个月 SHIBOR，获取失败时使用默认值
+func (s *OptionService) riskFreeRate(ctx context.Context) float64 {
+	if rates, err := s.marketService.GetInterestRates(ctx); err == nil {
+		for _, r := range rates.Items {
+			if r.Code == "shibor_3m" && r.Rate > 0 {
+				return r.Rate
+			}
+		}
+	}
+	return defaultRiskFreeRate
+}
+
+// months 可选合约月份（去重、升序）
+func (s *OptionService) months(ctx context.Context, cate string) ([]string, error) {
+	key := "months:" + cate
+	if v, ok := s.getCache(key); ok {
+		return v.([]string), nil
+	}
+	var resp struct {
+		Result struct {
+			Data struct {
+				ContractMonth []string `json:"contractMonth"`
+			} `json:"data"`
+		} `json:"result"`
+	}
+	if err := s.getJSON(ctx, fmt.Sprintf(optionMonthsURL, cate), &resp); err != nil {
+		return nil, fmt.Errorf("获取期权合约月份失败: %w", err)
+	}
+	months := slices.Compact(slices.Sorted(slices.Values(resp.Result.Data.ContractMonth)))
+	if len(months) == 0 {
+		return nil, fmt.Errorf("未获取到期权合约月份")
+	}
+	s.setCache(key, months, optionMetaTTL)
+	return months, nil
+}
+
+// expiry 合约月份的到期日
+func (s *OptionService) expiry(ctx context.Context, cate, month string) (string, error) {
+	key := "expiry:" + cate + ":" + month
+	if v, ok := s.getCache(key); ok {
+		return v.(string), nil
+	}
+	var resp struct {
+		Result struct {
+			Data struct {
+				ExpireDay string `json:"expireDay"`
+			} `json:"data"`
+		} `json:"result"`
+	}
+	if err := s.getJSON(ctx, fmt.Sprintf(optionExpiryURL, cate, month), &resp); err != nil {
+		return "", fmt.Errorf("获取期权到期日失败: %w", err)
+	}
+	expiry := trimDate(resp.Result.Data.ExpireDay)
+	if expiry == "" {
+		return "", fmt.Errorf("未获取到 %s 到期日", month)
+	}
+	s.setCache(key, expiry, optionMetaTTL)
+	return expiry, nil
+}
+
+func (s *OptionService) getJSON(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Referer", "https://stock.finance.sina.com.cn/")
+
+	resp, err := s.marketService.do(SourceQuotes, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+func (s *OptionService) getCache(key string) (any, bool) {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	if e, ok := s.cache[key]; ok && time.Now().Before(e.expireAt) {
+		return e.value, true
+	}
+	return nil, false
+}
+
+func (s *OptionService) setCache(key string, value any, ttl time.Duration) {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	s.cache[key] = optionCacheEntry{value: value, expireAt: time.Now().Add(ttl)}
+}
+
+// parseOptionCodeLists 解析认购(OP_UP_)与认沽(OP_DOWN_)合约代码列表
+// 格式: var hq_str_OP_UP_5100502406="CON_OP_10007001,CON_OP_10007002,";
+func parseOptionCodeLists(data string) (calls, puts []string) {
+	for _, match := range sinaStockRegex.FindAllStringSubmatch(data, -1) {
+		var codes []string
+		for _, c := range strings.Split(match[2], ",") {
+			if c = strings.TrimSpace(c); c != "" {
+				codes = append(codes, c)
+			}
+		}
+		switch {
+		case strings.HasPrefix(match[1], "OP_UP_"):
+			calls = codes
+		case strings.HasPrefix(match[1], "OP_DOWN_"):
+			puts = codes
+		}
+	}
+	return calls, puts
+}
+
+// parseOptionQuotes 解析期权合约行情
+// 字段: [0]买量,[1]买价,[2]最新价,[3]卖价,[4]卖量,[5]持仓量,[6]涨幅,[7]行权价,[8]昨收,[9]开盘,...,[37]合约简称,...,[41]成交量
+func parseOptionQuotes(data string, calls, puts []string) []models.OptionQuote {
+	types := map[string]string{}
+	for _, c := range calls {
+		types[c] = models.OptionTypeCall
+	}
+	for _, c := range puts {
+		types[c] = models.OptionTypePut
+	}
+
+	var quotes []models.OptionQuote
+	for _, match := range sinaStockRegex.FindAllStringSubmatch(data, -1) {
+		typ, ok := types[match[1]]
+		if !ok {
+			continue
+		}
+		parts := strings.Split(match[2], ",")
+		if len(parts) < 42 {
+			continue
+		}
+		f := func(i int) float64 {
+			v, _ := strconv.ParseFloat(parts[i], 64)
+			return v
+		}
+		q := models.OptionQuote{
+			Code:         match[1],
+			Name:         parts[37],
+			Type:         typ,
+			Strike:       f(7),
+			Price:        f(2),
+			Bid:          f(1),
+			Ask:          f(3),
+			PreClose:     f(8),
+			Volume:       int64(f(41)),
+			OpenInterest: int64(f(5)),
+		}
+		if q.PreClose > 0 && q.Price > 0 {
+			q.Change = q.Price - q.PreClose
+			q.ChangePercent = q.Change / q.PreClose * 100
+		}
+		quotes = append(quotes, q)
+	}
+	return quotes
+}
+
+// buildOptionRows 按行权价组装T型报价，并计算隐含波动率与 Delta
+// 无成交时以买卖中间价求解隐含波动率
+func buildOptionRows(quotes []models.OptionQuote, spot, years, rate float64) []models.OptionStrikeRow {
+	rows := map[float64]*models.OptionStrikeRow{}
+	for i := range quotes {
+		q := &quotes[i]
+		price := q.Price
+		if q.Bid > 0 && q.Ask > 0 && (price <= 0 || price < q.Bid || price > q.Ask) {
+			price = (q.Bid + q.Ask) / 2
+		}
+		if spot > 0 && price > 0 {
+			if iv := impliedVolatility(q.Type, price, spot, q.Strike, years, rate); iv > 0 {
+				q.IV = math.Round(iv*10000) / 100
+				q.Delta = math.Round(bsDelta(q.Type, spot, q.Strike, years, rate, iv)*10000) / 10000
+			}
+		}
+
+		row := rows[q.Strike]
+		if row == nil {
+			row = &models.OptionStrikeRow{Strike: q.Strike}
+			rows[q.Strike] = row
+		}
+		if q.Type == models.OptionTypeCall {
+			row.Call = q
+		} else {
+			row.Put = q
+		}
+	}
+
+	result := make([]models.OptionStrikeRow, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, *row)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Strike < result[j].Strike })
+	return result
+}
+
+// optionYearsToExpiry 距到期日 15:00 的年化时间
+func optionYearsToExpiry(now time.Time, expiry string) float64 {
+	exp, err := time.ParseInLocation("2006-01-02", expiry, now.Location())
+	if err != nil {
+		return optionMinExpiryYears
+	}
+	years := exp.Add(15*time.Hour).Sub(now).Hours() / (365 * 24)
+	return math.Max(years, optionMinExpiryYears)
+}
+
+// normCDF 标准正态分布累积函数
+func normCDF(x float64) float64 {
+	return 0.5 * (1 + math.Erf(x/math.Sqrt2))
+}
+
+// bsD1 Black-Scholes d1
+func bsD1(spot, strike, years, rate, vol float64) float64 {
+	return (math.Log(spot/strike) + (rate+vol*vol/2)*years) / (vol * math.Sqrt(years))
+}
+
+// bsPrice Black-Scholes 欧式期权理论价格
+func bsPrice(typ string, spot, strike, years, rate, vol float64) float64 {
+	d1 := bsD1(spot, strike, years, rate, vol)
+	d2 := d1 - vol*math.Sqrt(years)
+	discount := strike * math.Exp(-rate*years)
+	if typ == models.OptionTypeCall {
+		return spot*normCDF(d1) - discount*normCDF(d2)
+	}
+	return discount*normCDF(-d2) - spot*normCDF(-d1)
+}
+
+// bsDelta Black-Scholes Delta
+func bsDelta(typ string, spot, strike, years, rate, vol float64) float64 {
+	d := normCDF(bsD1(spot, strike, years, rate, vol))
+	if typ == models.OptionTypeCall {
+		return d
+	}
+	return d - 1
+}
+
+// impliedVolatility 二分法求解隐含波动率（年化，小数），价格低于内在价值或无解时返回 0
+func impliedVolatility(typ string, price, spot, strike, years, rate float64) float64 {
+	lo, hi := 0.0001, 5.0
+	if price < bsPrice(typ, spot, strike, years, rate, lo) || price > bsPrice(typ, spot, strike, years, rate, hi) {
+		return 0
+	}
+	for range 100 {
+		mid := (lo + hi) / 2
+		if bsPrice(typ, spot, strike, years, rate, mid) > price {
+			hi = mid
+		} else {
+			lo = mid
+		}
+		if hi-lo < 1e-6 {
+			break
+		}
+	}
+	return (lo + hi) / 2
+}
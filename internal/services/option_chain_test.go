@@ -0,0 +1,46 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+func TestOptionChainIV(t *testing.T) {
+	// 已知波动率定价后应能反解出相同的隐含波动率
+	for _, typ := range []string{models.OptionTypeCall, models.OptionTypePut} {
+		price := bsPrice(typ, 2.5, 2.45, 0.1, 0.02, 0.2)
+		if iv := impliedVolatility(typ, price, 2.5, 2.45, 0.1, 0.02); math.Abs(iv-0.2) > 1e-4 {
+			t.Errorf("%s iv = %f, want 0.2", typ, iv)
+		}
+	}
+	if iv := impliedVolatility(models.OptionTypeCall, 0.01, 2.5, 2.45, 0.1, 0.02); iv != 0 {
+		t.Errorf("低于内在价值时应返回0, got %f", iv)
+	}
+
+	quote := func(code string) string {
+		fields := make([]string, 43)
+		for i := range fields {
+			fields[i] = "0"
+		}
+		fields[1], fields[2], fields[3], fields[5], fields[7], fields[8] = "0.0860", "0.0870", "0.0880", "1200", "2.450", "0.0800"
+		fields[37], fields[41] = "50ETF购6月2450", "3500"
+		return fmt.Sprintf(`var hq_str_%s="%s";`, code, strings.Join(fields, ","))
+	}
+	calls, puts := parseOptionCodeLists(`var hq_str_OP_UP_5100502406="CON_OP_1,";
+var hq_str_OP_DOWN_5100502406="CON_OP_2,";`)
+	if len(calls) != 1 || len(puts) != 1 {
+		t.Fatalf("calls = %v, puts = %v", calls, puts)
+	}
+	quotes := parseOptionQuotes(quote("CON_OP_1")+"\n"+quote("CON_OP_2"), calls, puts)
+	rows := buildOptionRows(quotes, 2.5, 0.1, 0.02)
+	if len(rows) != 1 || rows[0].Strike != 2.45 || rows[0].Call == nil || rows[0].Put == nil {
+		t.Fatalf("rows = %+v", rows)
+	}
+	if c := rows[0].Call; c.Volume != 3500 || c.OpenInterest != 1200 || c.IV <= 0 || c.Delta <= 0.5 {
+		t.Errorf("call = %+v", c)
+	}
+}
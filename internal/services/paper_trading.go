@@ -0,0 +1,544 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/logger"
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+	"github.com/run-bigpig/jcp/internal/pkg/supervisor"
+
+	"github.com/google/uuid"
+)
+
+var paperLog = logger.New("paper")
+
+const (
+	paperDefaultCash   = 1_000_000.0
+	paperCommission    = 0.00025 // 佣金费率（双向）
+	paperMinCommission = 5.0     // 最低佣金
+	paperStampTax      = 0.0005  // 印花税（仅卖出）
+	paperMaxOrders     = 1000    // 保留的委托记录条数
+	paperMatchInterval = 3 * time.Second
+)
+
+// paperHolding 持仓内部状态
+type paperHolding struct {
+	Symbol      string  `json:"symbol"`
+	Name        string  `json:"name"`
+	Shares      int64   `json:"shares"`
+	TodayBought int64   `json:"todayBought"` // 当日买入（T+1 不可卖）
+	Cost        float64 `json:"cost"`        // 总成本（含费用）
+}
+
+// paperState 模拟账户持久化状态（paper_trading.json）
+type paperState struct {
+	InitialCash   float64                  `json:"initialCash"`
+	Cash          float64                  `json:"cash"`
+	Frozen        float64                  `json:"frozen"`
+	Holdings      map[string]*paperHolding `json:"holdings"`
+	Orders        []models.PaperOrder      `json:"orders"`
+	History       []models.PaperDailyPnL   `json:"history"`
+	Date          string                   `json:"date"`          // 当前交易日
+	DayStartAsset float64                  `json:"dayStartAsset"` // 当日开始时总资产
+	LastAsset     float64                  `json:"lastAsset"`     // 最近一次估值
+}
+
+// PaperTradingService 模拟交易服务
+// 撮合规则（简化）：买单按卖一价、卖单按买一价全部成交，不考虑盘口深度；
+// 市价单无对手盘（涨跌停）时拒绝，限价单挂单至当日收盘，未成交则自动撤销
+type PaperTradingService struct {
+	path          string
+	marketService *MarketService
+	state         *paperState
+	onFill        func(models.PaperOrder)
+	mu            sync.Mutex
+}
+
+// NewPaperTradingService 创建模拟交易服务
+func NewPaperTradingService(dataDir string, marketService *MarketService) *PaperTradingService {
+	s := &PaperTradingService{
+		path:          filepath.Join(dataDir, "paper_trading.json"),
+		marketService: marketService,
+	}
+	s.state = s.load()
+	return s
+}
+
+// load 加载账户状态，不存在或损坏时新建默认账户
+func (s *PaperTradingService) load() *paperState {
+	data, err := os.ReadFile(s.path)
+	if err == nil {
+		var st paperState
+		if err := json.Unmarshal(data, &st); err == nil {
+			if st.Holdings == nil {
+				st.Holdings = map[string]*paperHolding{}
+			}
+			return &st
+		}
+		paperLog.Warn("解析模拟账户失败，将重建账户: %v", err)
+	}
+	return newPaperState(paperDefaultCash)
+}
+
+func newPaperState(cash float64) *paperState {
+	return &paperState{
+		InitialCash:   cash,
+		Cash:          cash,
+		Holdings:      map[string]*paperHolding{},
+		Orders:        []models.PaperOrder{},
+		History:       []models.PaperDailyPnL{},
+		DayStartAsset: cash,
+		LastAsset:     cash,
+	}
+}
+
+// saveLocked 保存账户状态(需要已持有锁)
+func (s *PaperTradingService) saveLocked() error {
+	data, err := json.MarshalIndent(s.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileTracked(s.path, data, 0644)
+}
+
+// SetOnFill 设置成交回调（推送成交通知）
+func (s *PaperTradingService) SetOnFill(fn func(models.PaperOrder)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onFill = fn
+}
+
+// Start 启动挂单撮合循环，ctx 取消时退出
+func (s *PaperTradingService) Start(ctx context.Context) {
+	supervisor.Go(ctx, "paper-trading", func(ctx context.Context) {
+		ticker := time.NewTicker(paperMatchInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if s.marketService.GetMarketStatus().Status == "trading" {
+					s.MatchPending(ctx)
+				}
+			}
+		}
+	})
+}
+
+// rolloverLocked 跨交易日：记录上一日盈亏，撤销未成交委托，解除 T+1 限制(需要已持有锁)
+func (s *PaperTradingService) rolloverLocked(now time.Time) {
+	today := now.Format("2006-01-02")
+	st := s.state
+	if st.Date == today {
+		return
+	}
+	if st.Date != "" {
+		pnl := st.LastAsset - st.DayStartAsset
+		st.History = append(st.History, models.PaperDailyPnL{
+			Date:       st.Date,
+			TotalAsset: roundPrice(st.LastAsset),
+			PnL:        roundPrice(pnl),
+			PnLPercent: pctOf(pnl, st.DayStartAsset),
+		})
+	}
+	for i := range st.Orders {
+		if st.Orders[i].Status == models.PaperStatusPending {
+			s.cancelLocked(&st.Orders[i], "当日未成交，自动撤销")
+		}
+	}
+	for _, h := range st.Holdings {
+		h.TodayBought = 0
+	}
+	st.Date = today
+	st.DayStartAsset = st.LastAsset
+}
+
+// PlaceOrder 下单：校验资金/可卖数量后尝试立即撮合，限价单未成交则挂单
+func (s *PaperTradingService) PlaceOrder(ctx context.Context, req models.PaperOrderRequest) (models.PaperOrder, error) {
+	if err := validatePaperOrder(req); err != nil {
+		return models.PaperOrder{}, err
+	}
+	trading := s.marketService.GetMarketStatus().Status == "trading"
+	if req.Type == models.PaperOrderMarket && !trading {
+		return models.PaperOrder{}, i18n.Errorf("error.paper.market_closed")
+	}
+
+	// 行情与盘口在锁外获取
+	stocks, err := s.marketService.GetStockRealTimeData(ctx, req.Symbol)
+	if err != nil || len(stocks) == 0 {
+		return models.PaperOrder{}, i18n.Errorf("error.paper.quote_failed", req.Symbol)
+	}
+	var orderBook *models.OrderBook
+	if trading {
+		if ob, err := s.marketService.GetRealOrderBook(ctx, req.Symbol); err == nil {
+			orderBook = &ob
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rolloverLocked(time.Now())
+
+	order := models.PaperOrder{
+		ID:        uuid.New().String(),
+		Symbol:    req.Symbol,
+		Name:      stocks[0].Name,
+		Side:      req.Side,
+		Type:      req.Type,
+		Price:     req.Price,
+		Shares:    req.Shares,
+		Status:    models.PaperStatusPending,
+		CreatedAt: time.Now().UnixMilli(),
+	}
+
+	fillPrice, canFill := 0.0, false
+	if orderBook != nil {
+		fillPrice, canFill = matchPaperOrder(order, *orderBook)
+	}
+
+	// 资金与持仓检查
+	if req.Side == models.PaperSideBuy {
+		need := paperFrozenAmount(order)
+		if req.Type == models.PaperOrderMarket {
+			need = fillPrice*float64(req.Shares) + paperFee(models.PaperSideBuy, fillPrice*float64(req.Shares))
+		}
+		if need > s.state.Cash {
+			return s.rejectLocked(order, fmt.Sprintf("可用资金不足，需要 %.2f", need))
+		}
+	} else if avail := s.availableLocked(req.Symbol); req.Shares > avail {
+		return s.rejectLocked(order, fmt.Sprintf("可卖数量不足，可卖 %d 股", avail))
+	}
+
+	if req.Type == models.PaperOrderMarket && !canFill {
+		return s.rejectLocked(order, "无对手盘，市价单无法成交")
+	}
+
+	if req.Side == models.PaperSideBuy && req.Type == models.PaperOrderLimit {
+		frozen := paperFrozenAmount(order)
+		s.state.Cash -= frozen
+		s.state.Frozen += frozen
+	}
+	if canFill {
+		s.fillLocked(&order, fillPrice)
+	}
+	s.appendOrderLocked(order)
+	return order, s.saveLocked()
+}
+
+// validatePaperOrder 校验委托参数（A股买入须为100股整数倍）
+func validatePaperOrder(req models.PaperOrderRequest) error {
+	switch {
+	case req.Symbol == "":
+		return i18n.Errorf("error.symbol.required")
+	case req.Side != models.PaperSideBuy && req.Side != models.PaperSideSell:
+		return i18n.Errorf("error.paper.side_invalid", req.Side)
+	case req.Type != models.PaperOrderMarket && req.Type != models.PaperOrderLimit:
+		return i18n.Errorf("error.paper.type_invalid", req.Type)
+	case req.Shares <= 0:
+		return i18n.Errorf("error.paper.quantity_positive")
+	case req.Side == models.PaperSideBuy && req.Shares%100 != 0:
+		return i18n.Errorf("error.paper.lot_size")
+	case req.Type == models.PaperOrderLimit && req.Price <= 0:
+		return i18n.Errorf("error.paper.price_positive")
+	}
+	return nil
+}
+
+// matchPaperOrder 按对手方一档价格判断能否成交
+func matchPaperOrder(order models.PaperOrder, ob models.OrderBook) (float64, bool) {
+	if order.Side == models.PaperSideBuy {
+		if len(ob.Asks) == 0 || ob.Asks[0].Price <= 0 {
+			return 0, false
+		}
+		ask := ob.Asks[0].Price
+		if order.Type == models.PaperOrderLimit && ask > order.Price {
+			return 0, false
+		}
+		return ask, true
+	}
+	if len(ob.Bids) == 0 || ob.Bids[0].Price <= 0 {
+		return 0, false
+	}
+	bid := ob.Bids[0].Price
+	if order.Type == models.PaperOrderLimit && bid < order.Price {
+		return 0, false
+	}
+	return bid, true
+}
+
+// paperFee 交易费用：佣金（最低5元）+ 卖出印花税
+func paperFee(side string, amount float64) float64 {
+	fee := math.Max(amount*paperCommission, paperMinCommission)
+	if side == models.PaperSideSell {
+		fee += amount * paperStampTax
+	}
+	return roundPrice(fee)
+}
+
+// paperFrozenAmount 限价买单冻结资金（按限价计算，含佣金）
+func paperFrozenAmount(order models.PaperOrder) float64 {
+	if order.Side != models.PaperSideBuy || order.Type != models.PaperOrderLimit {
+		return 0
+	}
+	amount := order.Price * float64(order.Shares)
+	return amount + paperFee(models.PaperSideBuy, amount)
+}
+
+// availableLocked 可卖数量 = 持仓 - 当日买入 - 未成交卖单(需要已持有锁)
+func (s *PaperTradingService) availableLocked(symbol string) int64 {
+	h, ok := s.state.Holdings[symbol]
+	if !ok {
+		return 0
+	}
+	avail := h.Shares - h.TodayBought
+	for _, o := range s.state.Orders {
+		if o.Symbol == symbol && o.Side == models.PaperSideSell && o.Status == models.PaperStatusPending {
+			avail -= o.Shares
+		}
+	}
+	return max(avail, 0)
+}
+
+// fillLocked 成交：更新资金与持仓(需要已持有锁)
+func (s *PaperTradingService) fillLocked(order *models.PaperOrder, price float64) {
+	st := s.state
+	amount := price * float64(order.Shares)
+	fee := paperFee(order.Side, amount)
+
+	if order.Side == models.PaperSideBuy {
+		frozen := paperFrozenAmount(*order)
+		st.Frozen -= frozen
+		st.Cash += frozen - amount - fee
+
+		h, ok := st.Holdings[order.Symbol]
+		if !ok {
+			h = &paperHolding{Symbol: order.Symbol, Name: order.Name}
+			st.Holdings[order.Symbol] = h
+		}
+		h.Shares += order.Shares
+		h.TodayBought += order.Shares
+		h.Cost += amount + fee
+	} else {
+		st.Cash += amount - fee
+		h := st.Holdings[order.Symbol]
+		h.Cost -= h.Cost * float64(order.Shares) / float64(h.Shares)
+		h.Shares -= order.Shares
+		if h.Shares <= 0 {
+			delete(st.Holdings, order.Symbol)
+		}
+	}
+
+	order.Status = models.PaperStatusFilled
+	order.FilledPrice = price
+	order.Fee = fee
+	order.FilledAt = time.Now().UnixMilli()
+	paperLog.Info("模拟成交 %s %s %d股 @ %.2f", order.Side, order.Symbol, order.Shares, price)
+	if s.onFill != nil {
+		go s.onFill(*order)
+	}
+}
+
+// rejectLocked 记录被拒绝的委托(需要已持有锁)
+func (s *PaperTradingService) rejectLocked(order models.PaperOrder, reason string) (models.PaperOrder, error) {
+	order.Status = models.PaperStatusRejected
+	order.Reason = reason
+	s.appendOrderLocked(order)
+	if err := s.saveLocked(); err != nil {
+		return order, err
+	}
+	return order, fmt.Errorf("%s", reason)
+}
+
+// cancelLocked 撤销挂单并解冻资金(需要已持有锁)
+func (s *PaperTradingService) cancelLocked(order *models.PaperOrder, reason string) {
+	frozen := paperFrozenAmount(*order)
+	s.state.Frozen -= frozen
+	s.state.Cash += frozen
+	order.Status = models.PaperStatusCancelled
+	order.Reason = reason
+}
+
+// appendOrderLocked 追加委托记录，超出上限时丢弃最早的已完结委托(需要已持有锁)
+func (s *PaperTradingService) appendOrderLocked(order models.PaperOrder) {
+	s.state.Orders = append(s.state.Orders, order)
+	if len(s.state.Orders) > paperMaxOrders {
+		s.state.Orders = s.state.Orders[len(s.state.Orders)-paperMaxOrders:]
+	}
+}
+
+// CancelOrder 撤单
+func (s *PaperTradingService) CancelOrder(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.state.Orders {
+		o := &s.state.Orders[i]
+		if o.ID != id {
+			continue
+		}
+		if o.Status != models.PaperStatusPending {
+			return i18n.Errorf("error.paper.cancel_state", o.Status)
+		}
+		s.cancelLocked(o, "用户撤单")
+		return s.saveLocked()
+	}
+	return i18n.Errorf("error.paper.not_found", id)
+}
+
+// MatchPending 按最新盘口撮合挂单
+func (s *PaperTradingService) MatchPending(ctx context.Context) {
+	s.mu.Lock()
+	s.rolloverLocked(time.Now())
+	symbols := map[string]bool{}
+	for _, o := range s.state.Orders {
+		if o.Status == models.PaperStatusPending {
+			symbols[o.Symbol] = true
+		}
+	}
+	s.mu.Unlock()
+	if len(symbols) == 0 {
+		return
+	}
+
+	books := make(map[string]models.OrderBook, len(symbols))
+	for symbol := range symbols {
+		if ob, err := s.marketService.GetRealOrderBook(ctx, symbol); err == nil {
+			books[symbol] = ob
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	filled := false
+	for i := range s.state.Orders {
+		o := &s.state.Orders[i]
+		ob, ok := books[o.Symbol]
+		if o.Status != models.PaperStatusPending || !ok {
+			continue
+		}
+		if price, ok := matchPaperOrder(*o, ob); ok {
+			s.fillLocked(o, price)
+			filled = true
+		}
+	}
+	if filled {
+		if err := s.saveLocked(); err != nil {
+			paperLog.Error("保存模拟账户失败: %v", err)
+		}
+	}
+}
+
+// GetOrders 获取委托记录（最新在前）
+func (s *PaperTradingService) GetOrders() []models.PaperOrder {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	orders := make([]models.PaperOrder, len(s.state.Orders))
+	for i, o := range s.state.Orders {
+		orders[len(orders)-1-i] = o
+	}
+	return orders
+}
+
+// GetAccount 获取账户概览，持仓按实时行情估值
+func (s *PaperTradingService) GetAccount(ctx context.Context) models.PaperAccount {
+	s.mu.Lock()
+	symbols := make([]string, 0, len(s.state.Holdings))
+	for symbol := range s.state.Holdings {
+		symbols = append(symbols, symbol)
+	}
+	s.mu.Unlock()
+
+	prices := map[string]float64{}
+	if len(symbols) > 0 {
+		if stocks, err := s.marketService.GetStockRealTimeData(ctx, symbols...); err == nil {
+			for _, stock := range stocks {
+				prices[stock.Symbol] = stock.Price
+			}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rolloverLocked(time.Now())
+	return s.valueLocked(prices)
+}
+
+// valueLocked 计算账户估值并记录为最近估值(需要已持有锁)
+// 缺少行情的持仓按成本价估值
+func (s *PaperTradingService) valueLocked(prices map[string]float64) models.PaperAccount {
+	st := s.state
+	account := models.PaperAccount{
+		InitialCash: st.InitialCash,
+		Cash:        roundPrice(st.Cash),
+		Frozen:      roundPrice(st.Frozen),
+		Positions:   make([]models.PaperPosition, 0, len(st.Holdings)),
+		History:     st.History,
+	}
+	for _, h := range st.Holdings {
+		costPrice := h.Cost / float64(h.Shares)
+		price := prices[h.Symbol]
+		if price <= 0 {
+			price = costPrice
+		}
+		value := price * float64(h.Shares)
+		account.MarketValue += value
+		account.Positions = append(account.Positions, models.PaperPosition{
+			Symbol:      h.Symbol,
+			Name:        h.Name,
+			Shares:      h.Shares,
+			Available:   h.Shares - h.TodayBought,
+			CostPrice:   roundPrice(costPrice),
+			Price:       price,
+			MarketValue: roundPrice(value),
+			PnL:         roundPrice(value - h.Cost),
+			PnLPercent:  pctOf(value-h.Cost, h.Cost),
+		})
+	}
+	sort.Slice(account.Positions, func(i, j int) bool {
+		return account.Positions[i].MarketValue > account.Positions[j].MarketValue
+	})
+
+	total := st.Cash + st.Frozen + account.MarketValue
+	st.LastAsset = total
+	account.MarketValue = roundPrice(account.MarketValue)
+	account.TotalAsset = roundPrice(total)
+	account.TotalPnL = roundPrice(total - st.InitialCash)
+	account.DailyPnL = roundPrice(total - st.DayStartAsset)
+	account.DailyPnLPercent = pctOf(total-st.DayStartAsset, st.DayStartAsset)
+	return account
+}
+
+// Flush 保存账户状态（含最近一次估值），应用退出时调用
+func (s *PaperTradingService) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.saveLocked()
+}
+
+// Reset 重置模拟账户（清空持仓与委托）
+func (s *PaperTradingService) Reset(initialCash float64) error {
+	if initialCash <= 0 {
+		initialCash = paperDefaultCash
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = newPaperState(initialCash)
+	return s.saveLocked()
+}
+
+// pctOf 百分比（保留两位小数），base 为 0 时返回 0
+func pctOf(v, base float64) float64 {
+	if base == 0 {
+		return 0
+	}
+	return roundPrice(v / base * 100)
+}
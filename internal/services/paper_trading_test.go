@@ -0,0 +1,64 @@
+package services
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// TestPaperTradingFill 测试模拟交易撮合、费用、T+1 与日切
+func TestPaperTradingFill(t *testing.T) {
+	s := NewPaperTradingService(t.TempDir(), nil)
+	ob := models.OrderBook{
+		Bids: []models.OrderBookItem{{Price: 9.99}},
+		Asks: []models.OrderBookItem{{Price: 10.00}},
+	}
+
+	limitBuy := models.PaperOrder{Symbol: "sz000001", Side: models.PaperSideBuy, Type: models.PaperOrderLimit, Price: 9.95, Shares: 1000}
+	if _, ok := matchPaperOrder(limitBuy, ob); ok {
+		t.Error("限价低于卖一不应成交")
+	}
+	limitBuy.Price = 10.05
+	price, ok := matchPaperOrder(limitBuy, ob)
+	if !ok || price != 10.00 {
+		t.Fatalf("限价高于卖一应按卖一成交, got %.2f %v", price, ok)
+	}
+
+	// 冻结后成交：多冻结的资金退回
+	s.mu.Lock()
+	s.rolloverLocked(time.Date(2026, 3, 2, 10, 0, 0, 0, time.Local))
+	frozen := paperFrozenAmount(limitBuy)
+	s.state.Cash -= frozen
+	s.state.Frozen += frozen
+	s.fillLocked(&limitBuy, price)
+	wantCash := paperDefaultCash - 10000 - 5 // 佣金不足5元按5元
+	if s.state.Frozen != 0 || math.Abs(s.state.Cash-wantCash) > 1e-6 {
+		t.Errorf("cash/frozen = %.2f/%.2f, want %.2f/0", s.state.Cash, s.state.Frozen, wantCash)
+	}
+	if avail := s.availableLocked("sz000001"); avail != 0 {
+		t.Errorf("当日买入不可卖, available = %d", avail)
+	}
+
+	// 次日：T+1 解除，收盘价 10.5 估值
+	s.rolloverLocked(time.Date(2026, 3, 3, 10, 0, 0, 0, time.Local))
+	if avail := s.availableLocked("sz000001"); avail != 1000 {
+		t.Errorf("次日可卖 = %d, want 1000", avail)
+	}
+	account := s.valueLocked(map[string]float64{"sz000001": 10.5})
+	if account.TotalPnL != 495 || account.Positions[0].Available != 1000 {
+		t.Errorf("TotalPnL = %.2f, want 495; position %+v", account.TotalPnL, account.Positions[0])
+	}
+
+	// 卖出含印花税，清仓后持仓删除
+	sell := models.PaperOrder{Symbol: "sz000001", Side: models.PaperSideSell, Type: models.PaperOrderMarket, Shares: 1000}
+	s.fillLocked(&sell, 10.5)
+	if sell.Fee != 5+5.25 {
+		t.Errorf("卖出费用 = %.2f, want 10.25", sell.Fee)
+	}
+	if len(s.state.Holdings) != 0 {
+		t.Error("清仓后应删除持仓")
+	}
+	s.mu.Unlock()
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+)
+
+const (
+	maxPersonaNameLen        = 20
+	maxPersonaRoleLen        = 30
+	maxPersonaInstructionLen = 8000
+	personaIDPrefix          = "persona-"
+)
+
+// ValidatePersona 校验专家人设：名称与提示词必填，引用的 AI 配置和 MCP 服务器必须存在
+func ValidatePersona(p models.AgentPersona, cfg *models.AppConfig) error {
+	name := strings.TrimSpace(p.Name)
+	if name == "" {
+		return i18n.Errorf("error.agent.name_required")
+	}
+	if utf8.RuneCountInString(name) > maxPersonaNameLen {
+		return i18n.Errorf("error.agent.name_too_long", maxPersonaNameLen)
+	}
+	if utf8.RuneCountInString(p.Role) > maxPersonaRoleLen {
+		return i18n.Errorf("error.agent.role_too_long", maxPersonaRoleLen)
+	}
+	if strings.TrimSpace(p.Instruction) == "" {
+		return i18n.Errorf("error.agent.instruction_required")
+	}
+	if utf8.RuneCountInString(p.Instruction) > maxPersonaInstructionLen {
+		return i18n.Errorf("error.agent.instruction_too_long", maxPersonaInstructionLen)
+	}
+	if cfg == nil {
+		return nil
+	}
+
+	if p.AIConfigID != "" && !slices.ContainsFunc(cfg.AIConfigs, func(c models.AIConfig) bool {
+		return c.ID == p.AIConfigID
+	}) {
+		return i18n.Errorf("error.ai.config_not_found", p.AIConfigID)
+	}
+	for _, id := range p.MCPServers {
+		if !slices.ContainsFunc(cfg.MCPServers, func(s models.MCPServerConfig) bool {
+			return s.ID == id
+		}) {
+			return i18n.Errorf("error.mcp.not_found", id)
+		}
+	}
+	for _, other := range cfg.Personas {
+		if other.ID != p.ID && strings.TrimSpace(other.Name) == name {
+			return i18n.Errorf("error.agent.name_exists", name)
+		}
+	}
+	return nil
+}
+
+// GetPersonas 获取自定义专家人设列表
+func (cs *ConfigService) GetPersonas() []models.AgentPersona {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return slices.Clone(cs.config.Personas)
+}
+
+// CreatePersona 新建专家人设，返回带 ID 的人设
+func (cs *ConfigService) CreatePersona(p models.AgentPersona) (models.AgentPersona, error) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	p.ID = personaIDPrefix + uuid.New().String()[:8]
+	p.Name = strings.TrimSpace(p.Name)
+	if err := ValidatePersona(p, cs.config); err != nil {
+		return models.AgentPersona{}, err
+	}
+	now := time.Now().UnixMilli()
+	p.CreatedAt = now
+	p.UpdatedAt = now
+	cs.config.Personas = append(cs.config.Personas, p)
+	return p, cs.saveConfigLocked()
+}
+
+// UpdatePersona 更新专家人设
+func (cs *ConfigService) UpdatePersona(p models.AgentPersona) error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	idx := slices.IndexFunc(cs.config.Personas, func(x models.AgentPersona) bool {
+		return x.ID == p.ID
+	})
+	if idx < 0 {
+		return i18n.Errorf("error.agent.not_found", p.ID)
+	}
+	p.Name = strings.TrimSpace(p.Name)
+	if err := ValidatePersona(p, cs.config); err != nil {
+		return err
+	}
+	p.CreatedAt = cs.config.Personas[idx].CreatedAt
+	p.UpdatedAt = time.Now().UnixMilli()
+	cs.config.Personas[idx] = p
+	return cs.saveConfigLocked()
+}
+
+// DeletePersona 删除专家人设
+func (cs *ConfigService) DeletePersona(id string) error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	idx := slices.IndexFunc(cs.config.Personas, func(x models.AgentPersona) bool {
+		return x.ID == id
+	})
+	if idx < 0 {
+		return i18n.Errorf("error.agent.not_found", id)
+	}
+	cs.config.Personas = slices.Delete(cs.config.Personas, idx, idx+1)
+	return cs.saveConfigLocked()
+}
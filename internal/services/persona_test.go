@@ -0,0 +1,54 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+func TestValidatePersona(t *testing.T) {
+	cfg := &models.AppConfig{
+		AIConfigs: []models.AIConfig{{ID: "ai-1"}},
+		Personas:  []models.AgentPersona{{ID: "persona-a", Name: "宏观分析师", Instruction: "x"}},
+	}
+	valid := models.AgentPersona{ID: "persona-b", Name: "量化分析师", Instruction: "你是量化分析师", AIConfigID: "ai-1"}
+	if err := ValidatePersona(valid, cfg); err != nil {
+		t.Fatalf("valid persona rejected: %v", err)
+	}
+
+	cases := map[string]func(p *models.AgentPersona){
+		"empty name":        func(p *models.AgentPersona) { p.Name = " " },
+		"empty instruction": func(p *models.AgentPersona) { p.Instruction = "" },
+		"unknown ai":        func(p *models.AgentPersona) { p.AIConfigID = "missing" },
+		"unknown mcp":       func(p *models.AgentPersona) { p.MCPServers = []string{"missing"} },
+		"duplicate name":    func(p *models.AgentPersona) { p.Name = "宏观分析师" },
+	}
+	for name, mutate := range cases {
+		p := valid
+		mutate(&p)
+		if err := ValidatePersona(p, cfg); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestStrategyAgentsIncludePersonas(t *testing.T) {
+	s := NewStrategyService(t.TempDir())
+	builtin := len(s.GetAllAgents())
+
+	s.SetPersonas([]models.AgentPersona{
+		{ID: "persona-a", Name: "宏观分析师", Instruction: "x", Enabled: true},
+		{ID: "persona-b", Name: "量化分析师", Instruction: "x"},
+	})
+	if got := len(s.GetAllAgents()); got != builtin+2 {
+		t.Fatalf("GetAllAgents = %d, want %d", got, builtin+2)
+	}
+	if s.GetAgentByID("persona-a") == nil {
+		t.Error("enabled persona should be resolvable by id")
+	}
+	for _, a := range s.GetEnabledAgents() {
+		if a.ID == "persona-b" {
+			t.Error("disabled persona should not join discussions")
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// Attribution 生成持仓组合当日收益归因报告，行业贡献与集中度按ETF穿透计算
+func (s *LookThroughService) Attribution(holdings []models.PortfolioHolding) *models.PortfolioAttribution {
+	constituents, sectors := s.resolve(holdings)
+	result := computeAttribution(holdings, constituents, sectors)
+	result.Date = time.Now().Format("2006-01-02")
+	result.Markdown = renderAttribution(result)
+	return result
+}
+
+// computeAttribution 计算个股与行业的当日收益贡献
+// 贡献 = 当日盈亏 / 昨日组合市值；ETF 的当日盈亏按成分股权重分摊到行业，未披露部分归入“ETF其他持仓”
+func computeAttribution(holdings []models.PortfolioHolding, constituents map[string][]models.ETFConstituent, sectors map[string]string) *models.PortfolioAttribution {
+	exposure := computeLookThrough(holdings, constituents, sectors)
+	result := &models.PortfolioAttribution{
+		TotalValue: exposure.TotalValue,
+		ByHolding:  []models.HoldingAttribution{},
+		BySector:   []models.SectorAttribution{},
+		Exposure:   exposure,
+	}
+	if exposure.TotalValue == 0 {
+		return result
+	}
+
+	var prevTotal float64
+	sectorPnL := make(map[string]float64)
+	for _, h := range holdings {
+		value := float64(h.Shares) * h.Price
+		if value <= 0 || h.ChangePercent <= -100 {
+			continue
+		}
+		prev := value / (1 + h.ChangePercent/100)
+		prevTotal += prev
+		pnl := value - prev
+		result.DayPnL += pnl
+		result.ByHolding = append(result.ByHolding, models.HoldingAttribution{
+			Symbol:        h.Symbol,
+			Name:          h.Name,
+			Value:         value,
+			Weight:        value / exposure.TotalValue * 100,
+			ChangePercent: h.ChangePercent,
+			DayPnL:        pnl,
+		})
+		// 单只持仓穿透后的行业占比即其盈亏的分摊比例
+		for _, se := range computeLookThrough([]models.PortfolioHolding{h}, constituents, sectors).BySector {
+			sectorPnL[se.Sector] += pnl * se.Weight / 100
+		}
+	}
+	if prevTotal == 0 {
+		return result
+	}
+	result.ReturnPercent = result.DayPnL / prevTotal * 100
+	for i := range result.ByHolding {
+		result.ByHolding[i].Contribution = result.ByHolding[i].DayPnL / prevTotal * 100
+	}
+	for _, se := range exposure.BySector {
+		result.BySector = append(result.BySector, models.SectorAttribution{
+			Sector:       se.Sector,
+			Weight:       se.Weight,
+			DayPnL:       sectorPnL[se.Sector],
+			Contribution: sectorPnL[se.Sector] / prevTotal * 100,
+		})
+	}
+
+	sort.SliceStable(result.ByHolding, func(i, j int) bool {
+		return math.Abs(result.ByHolding[i].Contribution) > math.Abs(result.ByHolding[j].Contribution)
+	})
+	sort.SliceStable(result.BySector, func(i, j int) bool {
+		return math.Abs(result.BySector[i].Contribution) > math.Abs(result.BySector[j].Contribution)
+	})
+	return result
+}
+
+// renderAttribution 将归因报告渲染为 Markdown，集中度部分列出穿透后的前十大个股
+func renderAttribution(a *models.PortfolioAttribution) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s 持仓收益归因\n\n", a.Date)
+	fmt.Fprintf(&sb, "> 组合市值 %.0f 元，当日盈亏 %+.0f 元（%+.2f%%）\n\n", a.TotalValue, a.DayPnL, a.ReturnPercent)
+
+	sb.WriteString("## 个股贡献\n\n")
+	sb.WriteString("| 股票 | 仓位 | 涨跌幅 | 盈亏(元) | 贡献 |\n")
+	sb.WriteString("| --- | --- | --- | --- | --- |\n")
+	for _, h := range a.ByHolding {
+		fmt.Fprintf(&sb, "| %s（%s） | %.1f%% | %+.2f%% | %+.0f | %+.2f%% |\n", h.Name, h.Symbol, h.Weight, h.ChangePercent, h.DayPnL, h.Contribution)
+	}
+
+	sb.WriteString("\n## 行业贡献（ETF穿透）\n\n")
+	sb.WriteString("| 行业 | 穿透仓位 | 盈亏(元) | 贡献 |\n")
+	sb.WriteString("| --- | --- | --- | --- |\n")
+	for _, se := range a.BySector {
+		fmt.Fprintf(&sb, "| %s | %.1f%% | %+.0f | %+.2f%% |\n", se.Sector, se.Weight, se.DayPnL, se.Contribution)
+	}
+
+	if a.Exposure != nil && len(a.Exposure.ByStock) > 0 {
+		sb.WriteString("\n## 真实集中度\n\n")
+		sb.WriteString("| 股票 | 行业 | 穿透仓位 | 来源 |\n")
+		sb.WriteString("| --- | --- | --- | --- |\n")
+		for i, e := range a.Exposure.ByStock {
+			if i >= 10 {
+				break
+			}
+			fmt.Fprintf(&sb, "| %s（%s） | %s | %.1f%% | %s |\n", e.Name, e.Symbol, e.Sector, e.Weight, strings.Join(e.Sources, "、"))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+func TestComputeAttribution(t *testing.T) {
+	holdings := []models.PortfolioHolding{
+		{Symbol: "sh512000", Name: "券商ETF", Shares: 10000, Price: 1, ChangePercent: 2},
+		{Symbol: "sz300059", Name: "东方财富", Shares: 250, Price: 20, ChangePercent: -1},
+	}
+	constituents := map[string][]models.ETFConstituent{
+		"sh512000": {
+			{Symbol: "sz300059", Name: "东方财富", Sector: "证券", Weight: 15},
+			{Symbol: "sh600030", Name: "中信证券", Sector: "证券", Weight: 10},
+		},
+	}
+	sectors := map[string]string{"sz300059": "证券"}
+
+	a := computeAttribution(holdings, constituents, sectors)
+	etfPnL := 10000 - 10000/1.02
+	stockPnL := 5000 - 5000/0.99
+	prevTotal := 10000/1.02 + 5000/0.99
+	near := func(got, want float64) bool { return math.Abs(got-want) < 1e-6 }
+
+	if !near(a.DayPnL, etfPnL+stockPnL) || !near(a.ReturnPercent, (etfPnL+stockPnL)/prevTotal*100) {
+		t.Fatalf("组合盈亏错误: %.4f %.4f", a.DayPnL, a.ReturnPercent)
+	}
+	if len(a.ByHolding) != 2 || a.ByHolding[0].Symbol != "sh512000" || !near(a.ByHolding[0].Contribution, etfPnL/prevTotal*100) {
+		t.Fatalf("个股贡献错误: %+v", a.ByHolding)
+	}
+
+	bySector := make(map[string]float64)
+	var sum float64
+	for _, se := range a.BySector {
+		bySector[se.Sector] = se.DayPnL
+		sum += se.DayPnL
+	}
+	if !near(bySector["证券"], etfPnL*0.25+stockPnL) || !near(bySector[undisclosedSector], etfPnL*0.75) {
+		t.Errorf("行业贡献错误: %+v", a.BySector)
+	}
+	if !near(sum, a.DayPnL) {
+		t.Errorf("行业盈亏合计 %.4f 应等于组合盈亏 %.4f", sum, a.DayPnL)
+	}
+
+	a.Date = "2026-03-03"
+	md := renderAttribution(a)
+	if !strings.Contains(md, "| 东方财富（sz300059） | 证券 | 43.3% | sh512000、sz300059 |") {
+		t.Errorf("集中度缺失:\n%s", md)
+	}
+}
+
+func TestComputeAttributionEmpty(t *testing.T) {
+	a := computeAttribution(nil, nil, nil)
+	if a.TotalValue != 0 || len(a.ByHolding) != 0 || len(a.BySector) != 0 {
+		t.Fatalf("空组合 = %+v", a)
+	}
+}
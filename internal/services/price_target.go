@@ -0,0 +1,44 @@
+package services
+
+import (
+	"math"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// 跟踪线类型
+const (
+	TargetKindTarget = "target"
+	TargetKindStop   = "stop"
+)
+
+// applyPriceTargets 为推送的行情填充目标价/止损价及距离
+func applyPriceTargets(stocks []models.Stock, targets []models.PriceTarget) {
+	if len(targets) == 0 {
+		return
+	}
+	byCode := make(map[string]models.PriceTarget, len(targets))
+	for _, t := range targets {
+		byCode[t.Symbol] = t
+	}
+
+	for i := range stocks {
+		t, ok := byCode[stocks[i].Symbol]
+		if !ok || stocks[i].Price <= 0 {
+			continue
+		}
+		stocks[i].TargetPrice = t.TargetPrice
+		stocks[i].StopPrice = t.StopPrice
+		if t.TargetPrice > 0 {
+			stocks[i].TargetDistance = targetDistance(stocks[i].Price, t.TargetPrice)
+		}
+		if t.StopPrice > 0 {
+			stocks[i].StopDistance = -targetDistance(stocks[i].Price, t.StopPrice)
+		}
+	}
+}
+
+// targetDistance 价格到跟踪线的距离(%)，跟踪线高于现价为正
+func targetDistance(price, line float64) float64 {
+	return math.Round((line-price)/price*10000) / 100
+}
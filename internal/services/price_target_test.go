@@ -0,0 +1,44 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// TestPriceTargetTracking 测试目标价/止损价距离计算与当日接近记录
+func TestPriceTargetTracking(t *testing.T) {
+	stocks := []models.Stock{
+		{Symbol: "sh600519", Price: 100},
+		{Symbol: "sz000001", Price: 10},
+		{Symbol: "sz000002", Price: 8},
+	}
+	targets := []models.PriceTarget{
+		{Symbol: "sh600519", TargetPrice: 110, StopPrice: 95},
+		{Symbol: "sz000001", StopPrice: 10.5},
+	}
+	applyPriceTargets(stocks, targets)
+
+	if stocks[0].TargetDistance != 10 || stocks[0].StopDistance != 5 {
+		t.Errorf("sh600519 distance = %.2f/%.2f, want 10/5", stocks[0].TargetDistance, stocks[0].StopDistance)
+	}
+	if stocks[1].StopDistance != -5 {
+		t.Errorf("sz000001 stopDistance = %.2f, want -5（已跌破止损）", stocks[1].StopDistance)
+	}
+	if stocks[2].TargetPrice != 0 || stocks[2].StopPrice != 0 {
+		t.Errorf("未设置跟踪线的股票不应填充: %+v", stocks[2])
+	}
+
+	r := NewIntradayRecorder()
+	r.RecordTargetDistances(stocks)
+	near := r.NearTargets(5)
+	if len(near) != 2 {
+		t.Fatalf("len(near) = %d, want 2: %+v", len(near), near)
+	}
+	if near[0].Symbol != "sz000001" || near[0].Distance != 0 {
+		t.Errorf("near[0] = %+v, want sz000001 触及止损", near[0])
+	}
+	if near[1].Symbol != "sh600519" || near[1].Kind != TargetKindStop {
+		t.Errorf("near[1] = %+v, want sh600519 stop", near[1])
+	}
+}
@@ -0,0 +1,28 @@
+package services
+
+import (
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// staleQuoteAfter 交易时段行情时间落后本机时钟超过该值视为行情冻结
+const staleQuoteAfter = time.Minute
+
+// markStaleQuotes 计算行情延迟，交易时段延迟过大时标记为过期，便于界面提示而非静默显示旧价格
+// 停牌、退市股票行情本就不更新，不标记
+func markStaleQuotes(stocks []models.Stock, now time.Time, trading bool) {
+	for i := range stocks {
+		s := &stocks[i]
+		s.StaleSeconds, s.Stale = 0, false
+		if s.QuoteTime <= 0 || s.Status != "" {
+			continue
+		}
+		lag := now.Sub(time.UnixMilli(s.QuoteTime))
+		if lag < 0 {
+			lag = 0 // 本机时钟偏慢
+		}
+		s.StaleSeconds = int64(lag / time.Second)
+		s.Stale = trading && lag > staleQuoteAfter
+	}
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// TestParseSinaQuoteTime 测试解析新浪行情的日期与时间字段
+func TestParseSinaQuoteTime(t *testing.T) {
+	data := `var hq_str_sh600000="浦发银行,7.10,7.09,7.12,7.15,7.05,7.11,7.12,1000,7100,` +
+		`100,7.11,0,0,0,0,0,0,0,0,200,7.12,0,0,0,0,0,0,0,0,2024-01-05,14:30:03,00";`
+	ms := &MarketService{}
+	stocks, err := ms.parseSinaStockData(data, []string{"sh600000"})
+	if err != nil || len(stocks) != 1 {
+		t.Fatalf("解析失败: %v %v", stocks, err)
+	}
+	want := time.Date(2024, 1, 5, 14, 30, 3, 0, time.FixedZone("CST", 8*60*60)).UnixMilli()
+	if stocks[0].QuoteTime != want {
+		t.Errorf("QuoteTime = %d, want %d", stocks[0].QuoteTime, want)
+	}
+
+	if got := parseSinaQuoteTime(strings.Split("a,b", ",")); got != 0 {
+		t.Errorf("字段不足时应返回 0: %d", got)
+	}
+}
+
+// TestMarkStaleQuotes 测试交易时段行情冻结标记
+func TestMarkStaleQuotes(t *testing.T) {
+	now := time.Unix(1704436203, 0)
+	stocks := []models.Stock{
+		{Symbol: "fresh", QuoteTime: now.Add(-3 * time.Second).UnixMilli()},
+		{Symbol: "frozen", QuoteTime: now.Add(-5 * time.Minute).UnixMilli()},
+		{Symbol: "ahead", QuoteTime: now.Add(2 * time.Second).UnixMilli()},
+		{Symbol: "unknown"},
+	}
+
+	markStaleQuotes(stocks, now, true)
+	if stocks[0].Stale || stocks[0].StaleSeconds != 3 {
+		t.Errorf("fresh = %+v", stocks[0])
+	}
+	if !stocks[1].Stale || stocks[1].StaleSeconds != 300 {
+		t.Errorf("frozen = %+v", stocks[1])
+	}
+	if stocks[2].Stale || stocks[2].StaleSeconds != 0 {
+		t.Errorf("本机时钟偏慢时延迟应为 0: %+v", stocks[2])
+	}
+	if stocks[3].Stale || stocks[3].StaleSeconds != 0 {
+		t.Errorf("无行情时间不应标记: %+v", stocks[3])
+	}
+
+	markStaleQuotes(stocks, now, false)
+	if stocks[1].Stale {
+		t.Error("非交易时段不应标记过期")
+	}
+}
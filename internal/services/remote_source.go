@@ -0,0 +1,30 @@
+package services
+
+import (
+	"context"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// RemoteMarketSource 远程行情数据源（远程数据引擎客户端）
+// 设置后 MarketService 的行情、盘口、K线、指数请求转发到远程引擎，本地缓存照常生效
+type RemoteMarketSource interface {
+	GetStockRealTimeData(ctx context.Context, codes ...string) ([]models.Stock, error)
+	GetStockDataWithOrderBook(ctx context.Context, codes ...string) ([]StockWithOrderBook, error)
+	GetKLineData(ctx context.Context, code string, period string, days int) ([]models.KLineData, error)
+	GetMarketIndicesByCodes(ctx context.Context, codes []string) ([]models.MarketIndex, error)
+}
+
+// SetRemoteSource 设置远程数据源，传 nil 恢复直连上游
+func (ms *MarketService) SetRemoteSource(remote RemoteMarketSource) {
+	ms.remoteMu.Lock()
+	defer ms.remoteMu.Unlock()
+	ms.remote = remote
+}
+
+// remoteSource 获取当前远程数据源，未设置返回 nil
+func (ms *MarketService) remoteSource() RemoteMarketSource {
+	ms.remoteMu.RLock()
+	defer ms.remoteMu.RUnlock()
+	return ms.remote
+}
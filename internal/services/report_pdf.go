@@ -0,0 +1,319 @@
+package services
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+)
+
+// PDF 版面参数（A4，单位 pt）
+const (
+	pdfPageWidth   = 595.0
+	pdfPageHeight  = 842.0
+	pdfMargin      = 50.0
+	pdfContentW    = pdfPageWidth - 2*pdfMargin
+	pdfBodySize    = 10.5
+	pdfTableSize   = 9.0
+	pdfLineSpacing = 1.5
+	pdfChartHeight = 120.0
+)
+
+// pdfHeadingSizes 各级标题字号
+var pdfHeadingSizes = map[int]float64{1: 18, 2: 14, 3: 12, 4: 11}
+
+// pdfWriter 极简 PDF 生成器
+// 使用 PDF 阅读器内置的 STSong-Light（Adobe-GB1）CJK 字体，无需嵌入字体文件即可显示中文
+type pdfWriter struct {
+	pages []*bytes.Buffer
+	cur   *bytes.Buffer
+	y     float64 // 当前书写位置（自页面底部起算的纵坐标）
+}
+
+func newPDFWriter() *pdfWriter {
+	w := &pdfWriter{}
+	w.newPage()
+	return w
+}
+
+// newPage 开始新页
+func (w *pdfWriter) newPage() {
+	w.cur = &bytes.Buffer{}
+	w.pages = append(w.pages, w.cur)
+	w.y = pdfPageHeight - pdfMargin
+}
+
+// ensure 剩余高度不足 h 时换页
+func (w *pdfWriter) ensure(h float64) {
+	if w.y-h < pdfMargin {
+		w.newPage()
+	}
+}
+
+// space 垂直留白
+func (w *pdfWriter) space(h float64) {
+	w.y -= h
+}
+
+// text 在指定位置输出单行文本
+func (w *pdfWriter) text(x, y, size float64, s string) {
+	fmt.Fprintf(w.cur, "BT /F1 %.1f Tf %.2f %.2f Td <%s> Tj ET\n", size, x, y, pdfHexUTF16(s))
+}
+
+// paragraph 输出自动换行的段落，gray 为引用样式
+func (w *pdfWriter) paragraph(s string, size, indent float64, gray bool) {
+	lineHeight := size * pdfLineSpacing
+	if gray {
+		w.cur.WriteString("0.4 g\n")
+	}
+	for _, line := range pdfWrap(s, size, pdfContentW-indent) {
+		w.ensure(lineHeight)
+		w.y -= lineHeight
+		w.text(pdfMargin+indent, w.y, size, line)
+	}
+	if gray {
+		w.cur.WriteString("0 g\n")
+	}
+}
+
+// table 输出等宽列表格，首行为表头，单元格超宽时截断
+func (w *pdfWriter) table(rows [][]string) {
+	if len(rows) == 0 {
+		return
+	}
+	cols := 0
+	for _, r := range rows {
+		cols = max(cols, len(r))
+	}
+	colW := pdfContentW / float64(cols)
+	lineHeight := pdfTableSize * 1.8
+	for i, r := range rows {
+		w.ensure(lineHeight)
+		w.y -= lineHeight
+		for j, cell := range r {
+			if lines := pdfWrap(cell, pdfTableSize, colW-4); len(lines) > 0 {
+				w.text(pdfMargin+float64(j)*colW, w.y, pdfTableSize, lines[0])
+			}
+		}
+		if i == 0 {
+			fmt.Fprintf(w.cur, "0.6 G 0.5 w %.2f %.2f m %.2f %.2f l S 0 G\n",
+				pdfMargin, w.y-4, pdfMargin+pdfContentW, w.y-4)
+		}
+	}
+}
+
+// lineChart 绘制收盘价折线图，标注区间最高与最低
+func (w *pdfWriter) lineChart(values []float64) {
+	if len(values) < 2 {
+		return
+	}
+	w.ensure(pdfChartHeight + 10)
+	top := w.y - 5
+	bottom := top - pdfChartHeight
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	fmt.Fprintf(w.cur, "0.8 G 0.5 w %.2f %.2f %.2f %.2f re S\n", pdfMargin, bottom, pdfContentW, pdfChartHeight)
+	w.cur.WriteString("0.16 0.42 0.85 RG 1.2 w\n")
+	step := pdfContentW / float64(len(values)-1)
+	for i, v := range values {
+		y := bottom + pdfChartHeight/2
+		if hi > lo {
+			y = bottom + 8 + (v-lo)/(hi-lo)*(pdfChartHeight-16)
+		}
+		op := "l"
+		if i == 0 {
+			op = "m"
+		}
+		fmt.Fprintf(w.cur, "%.2f %.2f %s\n", pdfMargin+float64(i)*step, y, op)
+	}
+	w.cur.WriteString("S 0 G\n0.4 g\n")
+	w.text(pdfMargin+4, top-pdfTableSize-2, pdfTableSize, fmt.Sprintf("%.2f", hi))
+	w.text(pdfMargin+4, bottom+4, pdfTableSize, fmt.Sprintf("%.2f", lo))
+	w.cur.WriteString("0 g\n")
+	w.y = bottom - 5
+}
+
+// bytes 输出完整 PDF 文件
+func (w *pdfWriter) bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+	obj := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	// 1 目录 2 页面树 3-5 字体，之后每页占页面与内容两个对象
+	kids := make([]string, len(w.pages))
+	for i := range w.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 6+2*i)
+	}
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(w.pages)))
+	obj("<< /Type /Font /Subtype /Type0 /BaseFont /STSong-Light /Encoding /UniGB-UTF16-H /DescendantFonts [4 0 R] >>")
+	obj("<< /Type /Font /Subtype /CIDFontType0 /BaseFont /STSong-Light " +
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (GB1) /Supplement 2 >> /FontDescriptor 5 0 R /DW 1000 /W [1 95 500] >>")
+	obj("<< /Type /FontDescriptor /FontName /STSong-Light /Flags 6 /FontBBox [-25 -254 1000 880] " +
+		"/ItalicAngle 0 /Ascent 880 /Descent -120 /CapHeight 880 /StemV 93 >>")
+	for i, page := range w.pages {
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, 7+2*i))
+		var z bytes.Buffer
+		zw := zlib.NewWriter(&z)
+		zw.Write(page.Bytes())
+		zw.Close()
+		obj(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", z.Len(), z.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// pdfRuneWidth 字符宽度（千分之一字号）：ASCII 半角，其余全角
+func pdfRuneWidth(r rune) float64 {
+	if r < 0x80 {
+		return 500
+	}
+	return 1000
+}
+
+// pdfWrap 按可用宽度折行，英文单词尽量不拆开
+func pdfWrap(s string, size, width float64) []string {
+	var lines []string
+	var line []rune
+	w := 0.0
+	for _, r := range s {
+		if r > 0xFFFF || r < 0x20 {
+			continue // 内置字体不含 BMP 以外的字符（如 emoji）
+		}
+		rw := pdfRuneWidth(r) * size / 1000
+		if w+rw > width && len(line) > 0 {
+			cut := len(line)
+			if r < 0x80 && r != ' ' {
+				if i := lastWordBreak(line); i > 0 {
+					cut = i
+				}
+			}
+			lines = append(lines, strings.TrimRight(string(line[:cut]), " "))
+			line = append([]rune(nil), line[cut:]...)
+			w = 0
+			for _, lr := range line {
+				w += pdfRuneWidth(lr) * size / 1000
+			}
+		}
+		line = append(line, r)
+		w += rw
+	}
+	if len(line) > 0 || len(lines) == 0 {
+		lines = append(lines, string(line))
+	}
+	return lines
+}
+
+// lastWordBreak 行内最后一个可断行位置：空格或非 ASCII 字符之后，没有时返回 0
+func lastWordBreak(rs []rune) int {
+	for i := len(rs) - 1; i >= 0; i-- {
+		if rs[i] == ' ' || rs[i] >= 0x80 {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// pdfHexUTF16 文本编码为 UTF-16BE 十六进制串（对应 UniGB-UTF16-H 编码）
+func pdfHexUTF16(s string) string {
+	var b strings.Builder
+	for _, u := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&b, "%04X", u)
+	}
+	return b.String()
+}
+
+// markdownToPDF 将报告 Markdown 排版为 PDF
+// 支持标题、引用、列表、表格与段落；字符走势图替换为收盘价折线图
+func markdownToPDF(md string, closes []float64) []byte {
+	w := newPDFWriter()
+	var table [][]string
+	flushTable := func() {
+		if len(table) > 0 {
+			w.table(table)
+			w.space(pdfBodySize)
+			table = nil
+		}
+	}
+
+	for _, raw := range strings.Split(md, "\n") {
+		line := strings.TrimSpace(raw)
+		if strings.HasPrefix(line, "|") {
+			if !isMarkdownTableRule(line) {
+				table = append(table, splitMarkdownRow(line))
+			}
+			continue
+		}
+		flushTable()
+
+		switch {
+		case line == "":
+			w.space(pdfBodySize * 0.5)
+		case isSparklineLine(line):
+			w.lineChart(closes)
+		case strings.HasPrefix(line, "#"):
+			level := len(line) - len(strings.TrimLeft(line, "#"))
+			size, ok := pdfHeadingSizes[level]
+			if !ok {
+				size = pdfBodySize
+			}
+			w.space(size * 0.4)
+			w.paragraph(stripInlineMarkdown(strings.TrimSpace(line[level:])), size, 0, false)
+		case strings.HasPrefix(line, ">"):
+			w.paragraph(stripInlineMarkdown(strings.TrimSpace(line[1:])), pdfBodySize, 8, true)
+		case strings.HasPrefix(line, "- "), strings.HasPrefix(line, "* "):
+			w.paragraph("· "+stripInlineMarkdown(line[2:]), pdfBodySize, 8, false)
+		default:
+			w.paragraph(stripInlineMarkdown(line), pdfBodySize, 0, false)
+		}
+	}
+	flushTable()
+	return w.bytes()
+}
+
+// isMarkdownTableRule 是否为表格分隔行（| --- | --- |）
+func isMarkdownTableRule(line string) bool {
+	return strings.Trim(line, "|-: ") == ""
+}
+
+// splitMarkdownRow 拆分表格行的单元格
+func splitMarkdownRow(line string) []string {
+	cells := strings.Split(strings.Trim(line, "|"), "|")
+	for i, c := range cells {
+		cells[i] = stripInlineMarkdown(strings.TrimSpace(c))
+	}
+	return cells
+}
+
+// isSparklineLine 是否为 sparkline 生成的字符走势图行
+func isSparklineLine(line string) bool {
+	inner := strings.Trim(line, "`")
+	if inner == "" || len(inner) == len(line) {
+		return false
+	}
+	for _, r := range inner {
+		if !strings.ContainsRune(string(sparkBars), r) {
+			return false
+		}
+	}
+	return true
+}
+
+// stripInlineMarkdown 去除加粗、斜体与行内代码标记
+func stripInlineMarkdown(s string) string {
+	return strings.NewReplacer("**", "", "__", "", "`", "").Replace(s)
+}
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+)
+
+// reportKLineDays 报告中附带的日K数量
+const reportKLineDays = 30
+
+// 报告格式
+const (
+	ReportFormatMarkdown = "md"
+	ReportFormatPDF      = "pdf"
+)
+
+// sparkBars 迷你走势图字符（由低到高）
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// ReportService AI 分析报告服务
+// 将股票最近一次讨论的结论、关键行情与近期走势整理为 Markdown 或 PDF 报告，保存到数据目录 reports 下
+type ReportService struct {
+	dir            string
+	sessionService *SessionService
+	marketService  *MarketService
+}
+
+// NewReportService 创建报告服务
+func NewReportService(dataDir string, sessionService *SessionService, marketService *MarketService) *ReportService {
+	return &ReportService{
+		dir:            filepath.Join(dataDir, "reports"),
+		sessionService: sessionService,
+		marketService:  marketService,
+	}
+}
+
+// stockReportData 生成报告所需数据
+type stockReportData struct {
+	Code     string
+	Name     string
+	Messages []models.ChatMessage // 最近一次讨论（从最后一条用户提问开始）
+	Quote    *models.Stock
+	Position *models.StockPosition
+	KLines   []models.KLineData
+	Time     time.Time
+}
+
+// Generate 生成股票分析报告，返回文件路径；format 为 md（默认）或 pdf
+func (s *ReportService) Generate(ctx context.Context, code, format string) (string, error) {
+	if format == "" {
+		format = ReportFormatMarkdown
+	}
+	if format != ReportFormatMarkdown && format != ReportFormatPDF {
+		return "", i18n.Errorf("error.report.format_unsupported", format)
+	}
+	session := s.sessionService.GetSession(code)
+	if session == nil {
+		return "", i18n.Errorf("error.report.no_discussion")
+	}
+	data := stockReportData{
+		Code:     code,
+		Name:     session.StockName,
+		Messages: latestDiscussion(s.sessionService.GetMessages(code)),
+		Position: s.sessionService.GetPosition(code),
+		Time:     time.Now(),
+	}
+	if len(data.Messages) == 0 {
+		return "", i18n.Errorf("error.report.no_discussion")
+	}
+
+	// 行情与K线获取失败不影响报告生成，对应章节留空
+	if stocks, err := s.marketService.GetStockRealTimeData(ctx, code); err == nil && len(stocks) > 0 {
+		data.Quote = &stocks[0]
+		if data.Name == "" {
+			data.Name = stocks[0].Name
+		}
+	}
+	if klines, err := s.marketService.GetKLineData(ctx, code, "1d", reportKLineDays); err == nil {
+		data.KLines = klines
+	}
+
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return "", err
+	}
+	content := []byte(renderStockReport(data))
+	if format == ReportFormatPDF {
+		content = renderStockReportPDF(data)
+	}
+	path := filepath.Join(s.dir, fmt.Sprintf("%s_%s.%s", code, data.Time.Format("20060102-150405"), format))
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// latestDiscussion 截取最后一条用户提问及之后的发言，忽略失败的发言
+func latestDiscussion(messages []models.ChatMessage) []models.ChatMessage {
+	start := 0
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].AgentID == "user" {
+			start = i
+			break
+		}
+	}
+	result := make([]models.ChatMessage, 0, len(messages)-start)
+	for _, m := range messages[start:] {
+		if m.Error == "" && strings.TrimSpace(m.Content) != "" {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+// renderStockReport 渲染 Markdown 报告：结论 → 关键行情 → 近期走势 → 各专家观点
+func renderStockReport(d stockReportData) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s（%s）AI 分析报告\n\n", d.Name, d.Code)
+	fmt.Fprintf(&b, "> 生成时间：%s。本报告由 AI 讨论生成，仅供参考，不构成投资建议。\n\n", d.Time.Format("2006-01-02 15:04"))
+
+	var question string
+	var summary *models.ChatMessage
+	var opinions []models.ChatMessage
+	for i, m := range d.Messages {
+		switch {
+		case m.AgentID == "user":
+			question = m.Content
+		case m.MsgType == "summary":
+			summary = &d.Messages[i]
+		default:
+			opinions = append(opinions, m)
+		}
+	}
+
+	if question != "" {
+		fmt.Fprintf(&b, "**讨论议题：** %s\n\n", question)
+	}
+	b.WriteString("## 结论摘要\n\n")
+	switch {
+	case summary != nil:
+		b.WriteString(strings.TrimSpace(summary.Content) + "\n\n")
+	case len(opinions) > 0:
+		b.WriteString("本次讨论未生成总结，各专家观点见下文。\n\n")
+	}
+
+	if q := d.Quote; q != nil {
+		b.WriteString("## 关键行情\n\n")
+		b.WriteString("| 最新价 | 涨跌幅 | 今开 | 最高 | 最低 | 昨收 | 成交额(万) |\n")
+		b.WriteString("| --- | --- | --- | --- | --- | --- | --- |\n")
+		fmt.Fprintf(&b, "| %.2f | %+.2f%% | %.2f | %.2f | %.2f | %.2f | %.0f |\n\n",
+			q.Price, q.ChangePercent, q.Open, q.High, q.Low, q.PreClose, q.Amount/10000)
+		if p := d.Position; p != nil && p.Shares > 0 && p.CostPrice > 0 {
+			pnl := (q.Price - p.CostPrice) * float64(p.Shares)
+			fmt.Fprintf(&b, "持仓 %d 股，成本 %.2f，浮动盈亏 %.2f（%+.2f%%）\n\n",
+				p.Shares, p.CostPrice, pnl, pctOf(q.Price-p.CostPrice, p.CostPrice))
+		}
+	}
+
+	if len(d.KLines) > 1 {
+		first, last := d.KLines[0], d.KLines[len(d.KLines)-1]
+		high, low := first.High, first.Low
+		closes := make([]float64, len(d.KLines))
+		for i, k := range d.KLines {
+			high, low = max(high, k.High), min(low, k.Low)
+			closes[i] = k.Close
+		}
+		fmt.Fprintf(&b, "## 近 %d 日走势\n\n", len(d.KLines))
+		fmt.Fprintf(&b, "`%s`\n\n", sparkline(closes))
+		fmt.Fprintf(&b, "区间 %s ~ %s，涨跌幅 %+.2f%%，最高 %.2f，最低 %.2f\n\n",
+			first.Time, last.Time, pctOf(last.Close-first.Close, first.Close), high, low)
+		b.WriteString("| 日期 | 开盘 | 最高 | 最低 | 收盘 | 成交量 |\n")
+		b.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+		for _, k := range d.KLines {
+			fmt.Fprintf(&b, "| %s | %.2f | %.2f | %.2f | %.2f | %d |\n", k.Time, k.Open, k.High, k.Low, k.Close, k.Volume)
+		}
+		b.WriteString("\n")
+	}
+
+	if len(opinions) > 0 {
+		b.WriteString("## 专家观点\n\n")
+		for _, m := range opinions {
+			title := m.AgentName
+			if m.Role != "" {
+				title += "（" + m.Role + "）"
+			}
+			fmt.Fprintf(&b, "### %s\n\n%s\n\n", title, strings.TrimSpace(m.Content))
+		}
+	}
+	return b.String()
+}
+
+// renderStockReportPDF 渲染 PDF 报告：与 Markdown 报告内容一致，走势以折线图呈现
+func renderStockReportPDF(d stockReportData) []byte {
+	closes := make([]float64, len(d.KLines))
+	for i, k := range d.KLines {
+		closes[i] = k.Close
+	}
+	return markdownToPDF(renderStockReport(d), closes)
+}
+
+// sparkline 将序列渲染为字符走势图
+func sparkline(values []float64) string {
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	out := make([]rune, len(values))
+	for i, v := range values {
+		idx := 0
+		if hi > lo {
+			idx = int((v - lo) / (hi - lo) * float64(len(sparkBars)-1))
+		}
+		out[i] = sparkBars[idx]
+	}
+	return string(out)
+}
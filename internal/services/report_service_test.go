@@ -0,0 +1,120 @@
+package services
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// TestRenderStockReport 测试分析报告取最近一次讨论并包含行情与走势
+func TestRenderStockReport(t *testing.T) {
+	messages := latestDiscussion([]models.ChatMessage{
+		{AgentID: "user", Content: "旧问题"},
+		{AgentID: "a1", AgentName: "旧观点", Content: "过时"},
+		{AgentID: "user", Content: "后市怎么看"},
+		{AgentID: "a1", AgentName: "技术派", Role: "技术分析师", Content: "放量突破", MsgType: "opinion"},
+		{AgentID: "a2", AgentName: "风控", Error: "timeout", MsgType: "opinion"},
+		{AgentID: "mod", AgentName: "主持人", Content: "整体偏多", MsgType: "summary"},
+	})
+	if len(messages) != 3 {
+		t.Fatalf("latestDiscussion 应保留最后一次提问后的有效发言, got %d", len(messages))
+	}
+
+	report := renderStockReport(stockReportData{
+		Code:     "sh600519",
+		Name:     "贵州茅台",
+		Messages: messages,
+		Quote:    &models.Stock{Price: 110, ChangePercent: 1.5},
+		Position: &models.StockPosition{Shares: 100, CostPrice: 100},
+		KLines:   []models.KLineData{{Time: "2026-03-02", Close: 100, High: 101, Low: 99}, {Time: "2026-03-03", Close: 110, High: 111, Low: 100}},
+		Time:     time.Date(2026, 3, 3, 15, 0, 0, 0, time.Local),
+	})
+	for _, want := range []string{"# 贵州茅台（sh600519）", "**讨论议题：** 后市怎么看", "整体偏多", "### 技术派（技术分析师）", "浮动盈亏 1000.00（+10.00%）", "`▁█`", "涨跌幅 +10.00%"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("报告缺少 %q", want)
+		}
+	}
+	if strings.Contains(report, "过时") || strings.Contains(report, "风控") {
+		t.Error("报告不应包含旧讨论或失败发言")
+	}
+}
+
+// TestRenderStockReportPDF 测试 PDF 报告结构完整、中文按 UTF-16 编码且走势图为折线
+func TestRenderStockReportPDF(t *testing.T) {
+	var klines []models.KLineData
+	for i := range 30 {
+		klines = append(klines, models.KLineData{Time: fmt.Sprintf("2026-03-%02d", i+1), Close: 100 + float64(i%7)})
+	}
+	pdf := renderStockReportPDF(stockReportData{
+		Code:     "sh600519",
+		Name:     "贵州茅台",
+		Messages: []models.ChatMessage{{AgentID: "user", Content: "后市怎么看"}, {AgentID: "mod", AgentName: "主持人", Content: strings.Repeat("整体偏多，关注量能 volume breakout。", 80), MsgType: "summary"}},
+		Quote:    &models.Stock{Price: 110, ChangePercent: 1.5},
+		KLines:   klines,
+		Time:     time.Date(2026, 3, 3, 15, 0, 0, 0, time.Local),
+	})
+
+	if !bytes.HasPrefix(pdf, []byte("%PDF-1.4")) || !bytes.HasSuffix(pdf, []byte("%%EOF\n")) {
+		t.Fatal("PDF 文件头尾不完整")
+	}
+	// startxref 指向交叉引用表，各对象偏移正确
+	tail := pdf[bytes.LastIndex(pdf, []byte("startxref")):]
+	var xref int
+	fmt.Sscanf(string(tail), "startxref\n%d", &xref)
+	if !bytes.HasPrefix(pdf[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref 偏移错误: %d", xref)
+	}
+	entries := strings.Split(string(pdf[xref:]), "\n")[3:]
+	for i := 1; strings.HasSuffix(entries[i-1], " n "); i++ {
+		var off int
+		fmt.Sscanf(entries[i-1], "%d", &off)
+		if !bytes.HasPrefix(pdf[off:], []byte(fmt.Sprintf("%d 0 obj", i))) {
+			t.Fatalf("对象 %d 偏移错误", i)
+		}
+	}
+
+	// 解压各页内容
+	var content strings.Builder
+	for rest := pdf; ; {
+		i := bytes.Index(rest, []byte("stream\n"))
+		if i < 0 {
+			break
+		}
+		rest = rest[i+len("stream\n"):]
+		zr, err := zlib.NewReader(bytes.NewReader(rest))
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(zr)
+		content.Write(data)
+		rest = rest[bytes.Index(rest, []byte("endstream"))+len("endstream"):]
+	}
+	if pages := strings.Count(string(pdf), "/Type /Page "); pages < 2 {
+		t.Errorf("长摘要应分页, pages = %d", pages)
+	}
+	for _, want := range []string{pdfHexUTF16("结论摘要"), pdfHexUTF16("关键行情"), " re S", " m\n"} {
+		if !strings.Contains(content.String(), want) {
+			t.Errorf("PDF 内容缺少 %q", want)
+		}
+	}
+	if strings.Contains(content.String(), pdfHexUTF16("▁")) {
+		t.Error("字符走势图应替换为折线图")
+	}
+}
+
+// TestPDFWrap 测试按宽度折行，英文单词不拆开，可在中文后断行
+func TestPDFWrap(t *testing.T) {
+	lines := pdfWrap("中文字符hello world again", 10, 60)
+	if len(lines) != 3 || lines[0] != "中文字符" || lines[1] != "hello world" || lines[2] != "again" {
+		t.Errorf("lines = %q", lines)
+	}
+	if lines := pdfWrap("", 10, 60); len(lines) != 1 || lines[0] != "" {
+		t.Errorf("空串应输出一行: %q", lines)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/run-bigpig/jcp/internal/pkg/proxy"
@@ -28,6 +29,9 @@ type ResearchReport struct {
 	PredictNextYearPe  string `json:"predictNextYearPe"`  // 明年预测PE
 	IndvInduName       string `json:"indvInduName"`       // 行业名称
 	EmRatingName       string `json:"emRatingName"`       // 评级名称
+	LastEmRatingName   string `json:"lastEmRatingName"`   // 上次评级名称
+	AimPriceHigh       any    `json:"indvAimPriceT"`      // 目标价上限（字符串或数字）
+	AimPriceLow        any    `json:"indvAimPriceL"`      // 目标价下限（字符串或数字）
 	Researcher         string `json:"researcher"`         // 研究员
 	EncodeUrl          string `json:"encodeUrl"`          // 报告链接编码
 	InfoCode           string `json:"infoCode"`           // 研报唯一标识码
@@ -49,12 +53,17 @@ type ResearchReportResponse struct {
 // ResearchReportService 研报服务
 type ResearchReportService struct {
 	client *http.Client
+
+	// 一致预期缓存（按自然日失效）
+	consensusCache   map[string]*consensusCacheEntry
+	consensusCacheMu sync.Mutex
 }
 
 // NewResearchReportService 创建研报服务
 func NewResearchReportService() *ResearchReportService {
 	return &ResearchReportService{
-		client: proxy.GetManager().GetClientWithTimeout(15 * time.Second),
+		client:         proxy.GetManager().GetClientWithTimeout(15 * time.Second),
+		consensusCache: map[string]*consensusCacheEntry{},
 	}
 }
 
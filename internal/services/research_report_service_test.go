@@ -3,6 +3,9 @@ package services
 import (
 	"fmt"
 	"testing"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
 )
 
 func TestGetResearchReports(t *testing.T) {
@@ -60,3 +63,28 @@ func TestGetReportContent(t *testing.T) {
 	fmt.Printf("PDF链接: %s\n\n", content.PDFUrl)
 	fmt.Printf("研报正文:\n%s\n", content.Content)
 }
+
+// TestBuildAnalystConsensus 测试评级变动识别与每家券商取最新一篇统计
+func TestBuildAnalystConsensus(t *testing.T) {
+	now := time.Date(2026, 6, 30, 0, 0, 0, 0, time.Local)
+	reports := []ResearchReport{
+		{OrgSName: "A证券", PublishDate: "2026-06-20 00:00:00", EmRatingName: "买入", LastEmRatingName: "增持", AimPriceHigh: "12", AimPriceLow: "10"},
+		{OrgSName: "A证券", PublishDate: "2026-05-01 00:00:00", EmRatingName: "增持", LastEmRatingName: "增持", AimPriceHigh: 9.0},
+		{OrgSName: "B证券", PublishDate: "2026-06-01 00:00:00", EmRatingName: "中性", LastEmRatingName: "买入", AimPriceHigh: ""},
+		{OrgSName: "C证券", PublishDate: "2026-01-01 00:00:00", EmRatingName: "买入", AimPriceHigh: "20"}, // 超出时间窗口
+	}
+	c := buildAnalystConsensus(reports, now)
+
+	if c.OrgCount != 2 || c.RatingCounts["买入"] != 1 || c.RatingCounts["中性"] != 1 {
+		t.Errorf("评级统计不符: orgs=%d counts=%v", c.OrgCount, c.RatingCounts)
+	}
+	if c.UpgradeCount != 1 || c.DowngradeCount != 1 {
+		t.Errorf("评级变动不符: up=%d down=%d", c.UpgradeCount, c.DowngradeCount)
+	}
+	if c.AvgTargetPrice != 11 || c.HighTargetPrice != 11 || c.LowTargetPrice != 11 {
+		t.Errorf("目标价不符: %+v", c)
+	}
+	if c.Reports[0].PublishDate != "2026-06-20" || c.Reports[3].RatingChange != models.RatingChangeNew {
+		t.Errorf("研报列表不符: %+v", c.Reports)
+	}
+}
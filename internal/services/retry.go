@@ -0,0 +1,113 @@
+package services
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/apperr"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+)
+
+// RetryPolicy HTTP 请求重试策略（指数退避 + 随机抖动）
+type RetryPolicy struct {
+	MaxAttempts     int           // 最大尝试次数（含首次请求）
+	BaseDelay       time.Duration // 首次重试前的等待时间，之后逐次翻倍
+	MaxDelay        time.Duration // 单次等待上限
+	RetryableStatus []int         // 需要重试的HTTP状态码
+}
+
+// DefaultRetryPolicy 行情接口默认重试策略
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:     3,
+	BaseDelay:       200 * time.Millisecond,
+	MaxDelay:        2 * time.Second,
+	RetryableStatus: []int{http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+}
+
+// RetryPolicyFromConfig 校验重试配置并转换为重试策略，状态码为空时使用默认列表
+func RetryPolicyFromConfig(cfg models.RetryConfig) (RetryPolicy, error) {
+	switch {
+	case cfg.MaxAttempts < 1 || cfg.MaxAttempts > 10:
+		return RetryPolicy{}, i18n.Errorf("error.retry.max_attempts")
+	case cfg.BaseDelayMs < 0 || cfg.BaseDelayMs > 10_000:
+		return RetryPolicy{}, i18n.Errorf("error.retry.base_delay")
+	case cfg.MaxDelayMs < cfg.BaseDelayMs || cfg.MaxDelayMs > 60_000:
+		return RetryPolicy{}, i18n.Errorf("error.retry.max_delay")
+	}
+	statuses := DefaultRetryPolicy.RetryableStatus
+	if len(cfg.RetryableStatus) > 0 {
+		for _, code := range cfg.RetryableStatus {
+			if code < 400 || code > 599 {
+				return RetryPolicy{}, i18n.Errorf("error.retry.status", code)
+			}
+		}
+		statuses = cfg.RetryableStatus
+	}
+	return RetryPolicy{
+		MaxAttempts:     cfg.MaxAttempts,
+		BaseDelay:       time.Duration(cfg.BaseDelayMs) * time.Millisecond,
+		MaxDelay:        time.Duration(cfg.MaxDelayMs) * time.Millisecond,
+		RetryableStatus: slices.Clone(statuses),
+	}, nil
+}
+
+// backoff 第 attempt 次重试前的等待时间（attempt 从1开始）
+// 取 [d/2, d] 区间的随机值，避免多个请求同时重试
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.BaseDelay << (attempt - 1)
+	if d <= 0 || (p.MaxDelay > 0 && d > p.MaxDelay) {
+		d = p.MaxDelay
+	}
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int64N(int64(half)+1))
+}
+
+// isRetryableStatus 判断状态码是否需要重试
+func (p RetryPolicy) isRetryableStatus(code int) bool {
+	return slices.Contains(p.RetryableStatus, code)
+}
+
+// doWithRetry 按重试策略发送请求，网络错误和可重试状态码会自动重试
+// 仅用于无请求体的 GET 请求
+func doWithRetry(client *http.Client, req *http.Request, policy RetryPolicy) (*http.Response, error) {
+	attempts := max(policy.MaxAttempts, 1)
+
+	var lastErr error
+	var lastStatus int
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			wait := policy.backoff(attempt - 1)
+			log.Warn("请求失败，%v 后第%d次重试 %s: %v", wait, attempt-1, req.URL.Host, lastErr)
+			select {
+			case <-req.Context().Done():
+				return nil, req.Context().Err()
+			case <-time.After(wait):
+			}
+		}
+
+		resp, err := client.Do(req.Clone(req.Context()))
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if policy.isRetryableStatus(resp.StatusCode) {
+			resp.Body.Close()
+			lastStatus = resp.StatusCode
+			lastErr = fmt.Errorf("HTTP %d", resp.StatusCode)
+			continue
+		}
+		return resp, nil
+	}
+	code := apperr.CodeNetwork
+	if lastStatus == http.StatusTooManyRequests {
+		code = apperr.CodeRateLimited
+	}
+	return nil, apperr.Wrap(code, lastErr, fmt.Sprintf("请求 %s 失败（已尝试%d次）", req.URL.Host, attempts))
+}
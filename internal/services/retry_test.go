@@ -0,0 +1,75 @@
+package services
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// TestDoWithRetry 测试可重试状态码的退避重试
+func TestDoWithRetry(t *testing.T) {
+	var calls int
+	failUntil := 3
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < failUntil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond, RetryableStatus: []int{http.StatusBadGateway}}
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	resp, err := doWithRetry(server.Client(), req, policy)
+	if err != nil {
+		t.Fatalf("重试后应成功: %v", err)
+	}
+	resp.Body.Close()
+	if calls != 3 {
+		t.Errorf("请求次数 = %d, want 3", calls)
+	}
+
+	calls, failUntil = 0, 100 // 始终返回 502
+	if _, err := doWithRetry(server.Client(), req, policy); err == nil {
+		t.Error("超过最大重试次数应返回错误")
+	}
+	if calls != 3 {
+		t.Errorf("请求次数 = %d, want 3", calls)
+	}
+}
+
+// TestRetryPolicyFromConfig 测试重试配置校验与转换
+func TestRetryPolicyFromConfig(t *testing.T) {
+	p, err := RetryPolicyFromConfig(models.RetryConfig{MaxAttempts: 5, BaseDelayMs: 100, MaxDelayMs: 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.MaxAttempts != 5 || p.BaseDelay != 100*time.Millisecond || p.MaxDelay != time.Second {
+		t.Errorf("policy = %+v", p)
+	}
+	if !slices.Equal(p.RetryableStatus, DefaultRetryPolicy.RetryableStatus) {
+		t.Errorf("未指定状态码时应使用默认列表, got %v", p.RetryableStatus)
+	}
+
+	p, err = RetryPolicyFromConfig(models.RetryConfig{MaxAttempts: 1, MaxDelayMs: 0, RetryableStatus: []int{429}})
+	if err != nil || !p.isRetryableStatus(429) || p.isRetryableStatus(503) {
+		t.Errorf("自定义状态码 policy = %+v, %v", p, err)
+	}
+
+	for _, bad := range []models.RetryConfig{
+		{MaxAttempts: 0, MaxDelayMs: 100},
+		{MaxAttempts: 11, MaxDelayMs: 100},
+		{MaxAttempts: 3, BaseDelayMs: 500, MaxDelayMs: 100},
+		{MaxAttempts: 3, MaxDelayMs: 100, RetryableStatus: []int{200}},
+	} {
+		if _, err := RetryPolicyFromConfig(bad); err == nil {
+			t.Errorf("RetryPolicyFromConfig(%+v) 应校验失败", bad)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+)
+
+// 组合风险默认阈值(%)
+const (
+	defaultMaxPositionPercent = 20
+	defaultMaxSectorPercent   = 40
+	defaultMaxExposurePercent = 100
+	defaultLotSize            = 100
+)
+
+// RiskService 仓位与风险计算服务
+// 仓位建议按固定比例风险模型：单笔亏损上限 / 每股止损距离，再受单只仓位上限与账户资金约束
+type RiskService struct{}
+
+// NewRiskService 创建风险计算服务
+func NewRiskService() *RiskService {
+	return &RiskService{}
+}
+
+// SuggestPositionSize 根据账户资金、单笔风险比例、买入价与止损价计算建议仓位（整手）
+func (s *RiskService) SuggestPositionSize(req models.PositionSizeRequest) (*models.PositionSizeResult, error) {
+	switch {
+	case req.AccountSize <= 0:
+		return nil, i18n.Errorf("error.risk.capital_positive")
+	case req.RiskPercent <= 0 || req.RiskPercent > 100:
+		return nil, i18n.Errorf("error.risk.ratio_range")
+	case req.EntryPrice <= 0 || req.StopPrice <= 0:
+		return nil, i18n.Errorf("error.risk.prices_positive")
+	case req.StopPrice >= req.EntryPrice:
+		return nil, i18n.Errorf("error.risk.stop_above_entry")
+	}
+	lot := req.LotSize
+	if lot <= 0 {
+		lot = defaultLotSize
+	}
+
+	result := &models.PositionSizeResult{
+		RiskPerShare: req.EntryPrice - req.StopPrice,
+		LimitedBy:    models.SizeLimitedByRisk,
+		Warnings:     []string{},
+	}
+	maxRisk := req.AccountSize * req.RiskPercent / 100
+	shares := int64(maxRisk / result.RiskPerShare)
+
+	if req.MaxPositionPercent > 0 {
+		if capped := int64(req.AccountSize * req.MaxPositionPercent / 100 / req.EntryPrice); capped < shares {
+			shares, result.LimitedBy = capped, models.SizeLimitedByPosition
+		}
+	}
+	if capped := int64(req.AccountSize / req.EntryPrice); capped < shares {
+		shares, result.LimitedBy = capped, models.SizeLimitedByCash
+	}
+
+	result.Lots = shares / lot
+	result.Shares = result.Lots * lot
+	result.PositionValue = float64(result.Shares) * req.EntryPrice
+	result.PositionPercent = result.PositionValue / req.AccountSize * 100
+	result.RiskAmount = float64(result.Shares) * result.RiskPerShare
+	result.RiskPercent = result.RiskAmount / req.AccountSize * 100
+
+	if result.Shares == 0 {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("风险预算不足一手（%d股），请放宽止损或提高单笔风险", lot))
+	}
+	if stopPct := result.RiskPerShare / req.EntryPrice * 100; stopPct < 1 {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("止损距离仅 %.2f%%，容易被日内波动触发", stopPct))
+	} else if stopPct > 10 {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("止损距离 %.2f%% 超过单日涨跌幅限制，可能无法及时止损", stopPct))
+	}
+	return result, nil
+}
+
+// CheckPortfolio 检查组合总仓位、单只与行业集中度，以及持仓止损设置
+// exposure 为 ETF 穿透后的行业敞口，可为空
+func (s *RiskService) CheckPortfolio(accountSize float64, limits models.RiskLimits, holdings []models.HoldingRisk, exposure *models.PortfolioExposure) (*models.PortfolioRisk, error) {
+	if accountSize <= 0 {
+		return nil, i18n.Errorf("error.risk.capital_positive")
+	}
+	limits = withDefaultLimits(limits)
+	risk := &models.PortfolioRisk{
+		AccountSize: accountSize,
+		Holdings:    []models.HoldingRisk{},
+		Sectors:     []models.SectorExposure{},
+		Limits:      limits,
+		Warnings:    []models.RiskWarning{},
+	}
+
+	for _, h := range holdings {
+		if h.Shares <= 0 || h.Price <= 0 {
+			continue
+		}
+		h.MarketValue = float64(h.Shares) * h.Price
+		h.Weight = h.MarketValue / accountSize * 100
+		if h.CostPrice > 0 {
+			h.PnL = (h.Price - h.CostPrice) * float64(h.Shares)
+			h.PnLPercent = (h.Price - h.CostPrice) / h.CostPrice * 100
+		}
+		if h.StopPrice > 0 {
+			h.RiskToStop = math.Max(h.Price-h.StopPrice, 0) * float64(h.Shares)
+			risk.RiskToStop += h.RiskToStop
+		}
+		risk.MarketValue += h.MarketValue
+		risk.HHI += h.Weight * h.Weight
+		risk.TopWeight = math.Max(risk.TopWeight, h.Weight)
+		risk.Holdings = append(risk.Holdings, h)
+	}
+	sort.SliceStable(risk.Holdings, func(i, j int) bool {
+		return risk.Holdings[i].MarketValue > risk.Holdings[j].MarketValue
+	})
+	risk.ExposurePercent = risk.MarketValue / accountSize * 100
+	risk.Cash = accountSize - risk.MarketValue
+	// HHI 按持仓内部权重计算，不受现金比例影响
+	if risk.ExposurePercent > 0 {
+		risk.HHI = risk.HHI / (risk.ExposurePercent * risk.ExposurePercent) * 10000
+	}
+
+	if risk.ExposurePercent > limits.MaxExposurePercent {
+		risk.Warnings = append(risk.Warnings, models.RiskWarning{
+			Kind: models.RiskWarnExposure, Value: risk.ExposurePercent, Limit: limits.MaxExposurePercent,
+			Message: fmt.Sprintf("总仓位 %.1f%% 超过上限 %.0f%%", risk.ExposurePercent, limits.MaxExposurePercent),
+		})
+	}
+	for _, h := range risk.Holdings {
+		if h.Weight > limits.MaxPositionPercent {
+			risk.Warnings = append(risk.Warnings, models.RiskWarning{
+				Kind: models.RiskWarnPosition, Target: h.Symbol, Value: h.Weight, Limit: limits.MaxPositionPercent,
+				Message: fmt.Sprintf("%s 占账户 %.1f%%，超过单只上限 %.0f%%", h.Name, h.Weight, limits.MaxPositionPercent),
+			})
+		}
+		if h.StopPrice <= 0 {
+			risk.Warnings = append(risk.Warnings, models.RiskWarning{
+				Kind: models.RiskWarnNoStop, Target: h.Symbol, Value: h.Weight,
+				Message: fmt.Sprintf("%s 未设置止损价", h.Name),
+			})
+		}
+	}
+
+	if exposure != nil {
+		for _, sec := range exposure.BySector {
+			weight := sec.Value / accountSize * 100
+			risk.Sectors = append(risk.Sectors, models.SectorExposure{Sector: sec.Sector, Value: sec.Value, Weight: weight})
+			if sec.Sector != unknownSector && sec.Sector != undisclosedSector && weight > limits.MaxSectorPercent {
+				risk.Warnings = append(risk.Warnings, models.RiskWarning{
+					Kind: models.RiskWarnSector, Target: sec.Sector, Value: weight, Limit: limits.MaxSectorPercent,
+					Message: fmt.Sprintf("%s 行业占账户 %.1f%%，超过上限 %.0f%%", sec.Sector, weight, limits.MaxSectorPercent),
+				})
+			}
+		}
+	}
+	return risk, nil
+}
+
+// withDefaultLimits 未设置的阈值使用默认值
+func withDefaultLimits(l models.RiskLimits) models.RiskLimits {
+	if l.MaxPositionPercent <= 0 {
+		l.MaxPositionPercent = defaultMaxPositionPercent
+	}
+	if l.MaxSectorPercent <= 0 {
+		l.MaxSectorPercent = defaultMaxSectorPercent
+	}
+	if l.MaxExposurePercent <= 0 {
+		l.MaxExposurePercent = defaultMaxExposurePercent
+	}
+	return l
+}
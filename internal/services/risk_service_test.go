@@ -0,0 +1,47 @@
+package services
+
+import (
+	"math"
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+func TestRiskService(t *testing.T) {
+	s := NewRiskService()
+	size, err := s.SuggestPositionSize(models.PositionSizeRequest{AccountSize: 100000, RiskPercent: 1, EntryPrice: 10, StopPrice: 9.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 风险预算 1000 元 / 每股 0.5 元 = 2000 股
+	if size.Shares != 2000 || size.Lots != 20 || size.LimitedBy != models.SizeLimitedByRisk {
+		t.Errorf("size = %+v", size)
+	}
+	size, _ = s.SuggestPositionSize(models.PositionSizeRequest{AccountSize: 100000, RiskPercent: 2, EntryPrice: 10, StopPrice: 9.9, MaxPositionPercent: 20})
+	if size.Shares != 2000 || size.LimitedBy != models.SizeLimitedByPosition {
+		t.Errorf("应受单只仓位上限约束: %+v", size)
+	}
+	if _, err := s.SuggestPositionSize(models.PositionSizeRequest{AccountSize: 100000, RiskPercent: 1, EntryPrice: 10, StopPrice: 11}); err == nil {
+		t.Error("止损价高于买入价应返回错误")
+	}
+
+	holdings := []models.HoldingRisk{
+		{Symbol: "sh600000", Name: "浦发银行", Shares: 3000, Price: 10, CostPrice: 8, StopPrice: 9},
+		{Symbol: "sz000001", Name: "平安银行", Shares: 1000, Price: 10},
+	}
+	exposure := &models.PortfolioExposure{BySector: []models.SectorExposure{{Sector: "银行", Value: 40000}}}
+	risk, err := s.CheckPortfolio(100000, models.RiskLimits{}, holdings, exposure)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if risk.ExposurePercent != 40 || risk.TopWeight != 30 || risk.RiskToStop != 3000 || math.Round(risk.HHI) != 6250 {
+		t.Errorf("risk = %+v", risk)
+	}
+	kinds := map[string]string{}
+	for _, w := range risk.Warnings {
+		kinds[w.Kind] = w.Target
+	}
+	if kinds[models.RiskWarnPosition] != "sh600000" || kinds[models.RiskWarnNoStop] != "sz000001" || len(kinds) != 2 {
+		t.Errorf("warnings = %+v", risk.Warnings)
+	}
+}
@@ -0,0 +1,246 @@
+package services
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/run-bigpig/jcp/internal/embed"
+)
+
+const (
+	searchDefaultLimit = 20
+	searchMaxLimit     = 100
+)
+
+// 匹配得分：数值越大排名越靠前
+const (
+	scoreCodeExact    = 100
+	scoreNameExact    = 95
+	scoreCodePrefix   = 90
+	scoreSpellExact   = 85
+	scoreNamePrefix   = 80
+	scoreSpellPrefix  = 75
+	scoreNameContains = 60
+	scoreSpellContain = 50
+	scoreCodeContains = 40
+	scoreFuzzy        = 20
+)
+
+// stockIndexEntry 搜索索引条目（预先计算大写代码与拼音首字母）
+type stockIndexEntry struct {
+	result StockSearchResult
+	code   string // 6位代码
+	name   string // 大写名称
+	spell  string // 大写拼音首字母，如 ZGPA
+}
+
+// SearchService 股票搜索服务
+// 支持代码前缀、中文名称、拼音首字母（zgpa → 中国平安）与模糊匹配
+type SearchService struct {
+	cachePath string
+	once      sync.Once
+	entries   []stockIndexEntry
+}
+
+// NewSearchService 创建股票搜索服务
+// 数据目录下存在 stock_basic.json 时优先使用（便于更新新股），否则使用内置列表
+func NewSearchService(dataDir string) *SearchService {
+	return &SearchService{cachePath: filepath.Join(dataDir, "stock_basic.json")}
+}
+
+// index 懒加载搜索索引
+func (s *SearchService) index() []stockIndexEntry {
+	s.once.Do(func() {
+		if data, err := os.ReadFile(s.cachePath); err == nil {
+			s.entries = buildStockIndex(data)
+		}
+		if len(s.entries) == 0 {
+			s.entries = buildStockIndex(embed.StockBasicJSON)
+		}
+		s.entries = append(s.entries, futuresIndexEntries()...)
+	})
+	return s.entries
+}
+
+// buildStockIndex 解析 stock_basic.json 构建索引
+func buildStockIndex(data []byte) []stockIndexEntry {
+	var basicData stockBasicData
+	if err := json.Unmarshal(data, &basicData); err != nil {
+		return nil
+	}
+
+	idx := map[string]int{}
+	for i, field := range basicData.Data.Fields {
+		idx[field] = i
+	}
+	field := func(item []interface{}, name string) string {
+		i, ok := idx[name]
+		if !ok || i >= len(item) {
+			return ""
+		}
+		v, _ := item[i].(string)
+		return v
+	}
+	if _, ok := idx["symbol"]; !ok {
+		return nil
+	}
+
+	entries := make([]stockIndexEntry, 0, len(basicData.Data.Items))
+	for _, item := range basicData.Data.Items {
+		symbol := field(item, "symbol")
+		name := field(item, "name")
+		if symbol == "" || name == "" {
+			continue
+		}
+
+		// 从 ts_code 获取市场前缀
+		market, fullSymbol := "", symbol
+		switch tsCode := field(item, "ts_code"); {
+		case strings.HasSuffix(tsCode, ".SH"):
+			market, fullSymbol = "上海", "sh"+symbol
+		case strings.HasSuffix(tsCode, ".SZ"):
+			market, fullSymbol = "深圳", "sz"+symbol
+		case strings.HasSuffix(tsCode, ".BJ"):
+			market, fullSymbol = "北京", "bj"+symbol
+		}
+
+		entries = append(entries, stockIndexEntry{
+			result: StockSearchResult{
+				Symbol:   fullSymbol,
+				Name:     name,
+				Industry: field(item, "industry"),
+				Market:   market,
+			},
+			code:  symbol,
+			name:  strings.ToUpper(name),
+			spell: strings.ToUpper(field(item, "cnspell")),
+		})
+	}
+	return entries
+}
+
+// Search 搜索股票，按匹配程度排序
+func (s *SearchService) Search(query string, limit int) []StockSearchResult {
+	query = normalizeSearchQuery(query)
+	if query == "" {
+		return []StockSearchResult{}
+	}
+	if limit <= 0 {
+		limit = searchDefaultLimit
+	}
+	limit = min(limit, searchMaxLimit)
+
+	type hit struct {
+		entry *stockIndexEntry
+		score int
+	}
+	var hits []hit
+	entries := s.index()
+	for i := range entries {
+		if score := matchStock(&entries[i], query); score > 0 {
+			hits = append(hits, hit{&entries[i], score})
+		}
+	}
+
+	// 同分时名称更短（更接近查询）的优先，再按代码排序保证稳定
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].score != hits[j].score {
+			return hits[i].score > hits[j].score
+		}
+		li, lj := utf8.RuneCountInString(hits[i].entry.name), utf8.RuneCountInString(hits[j].entry.name)
+		if li != lj {
+			return li < lj
+		}
+		return hits[i].entry.code < hits[j].entry.code
+	})
+
+	results := make([]StockSearchResult, 0, min(limit, len(hits)))
+	for _, h := range hits[:min(limit, len(hits))] {
+		results = append(results, h.entry.result)
+	}
+	return results
+}
+
+// Lookup 按完整代码（sh600519）查找股票信息
+func (s *SearchService) Lookup(symbol string) (StockSearchResult, bool) {
+	for _, e := range s.index() {
+		if e.result.Symbol == symbol {
+			return e.result, true
+		}
+	}
+	return StockSearchResult{}, false
+}
+
+// normalizeSearchQuery 统一大写、去空格，并去掉 sh/sz 市场前缀
+func normalizeSearchQuery(query string) string {
+	query = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(query), " ", ""))
+	for _, prefix := range []string{"SH", "SZ", "BJ"} {
+		if rest, ok := strings.CutPrefix(query, prefix); ok && rest != "" && isDigits(rest) {
+			return rest
+		}
+	}
+	return query
+}
+
+// matchStock 计算条目与查询的匹配得分，0 表示不匹配
+func matchStock(e *stockIndexEntry, query string) int {
+	if isDigits(query) {
+		switch {
+		case e.code == query:
+			return scoreCodeExact
+		case strings.HasPrefix(e.code, query):
+			return scoreCodePrefix
+		case strings.Contains(e.code, query):
+			return scoreCodeContains
+		}
+		return 0
+	}
+
+	switch {
+	case e.name == query:
+		return scoreNameExact
+	case e.spell == query:
+		return scoreSpellExact
+	case strings.HasPrefix(e.name, query):
+		return scoreNamePrefix
+	case e.spell != "" && strings.HasPrefix(e.spell, query):
+		return scoreSpellPrefix
+	case strings.Contains(e.name, query):
+		return scoreNameContains
+	case e.spell != "" && strings.Contains(e.spell, query):
+		return scoreSpellContain
+	}
+
+	// 模糊匹配：查询字符按顺序出现在名称或拼音首字母中（如 "平银" → 平安银行）
+	if utf8.RuneCountInString(query) >= 2 && (isSubsequence(query, e.name) || isSubsequence(query, e.spell)) {
+		return scoreFuzzy
+	}
+	return 0
+}
+
+// isSubsequence query 的字符是否按顺序出现在 s 中
+func isSubsequence(query, s string) bool {
+	q := []rune(query)
+	i := 0
+	for _, r := range s {
+		if i < len(q) && r == q[i] {
+			i++
+		}
+	}
+	return i == len(q)
+}
+
+// isDigits 是否全为数字
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"testing"
+)
+
+// TestSearchStocks 测试代码、名称、拼音首字母与模糊搜索
+func TestSearchStocks(t *testing.T) {
+	s := NewSearchService(t.TempDir())
+
+	tests := []struct {
+		query string
+		want  string // 期望排在第一位的股票
+	}{
+		{"000001", "sz000001"},
+		{"sz000001", "sz000001"},
+		{"600", ""},
+		{"zgpa", "sh601318"},
+		{"ZGPA", "sh601318"},
+		{"中国平安", "sh601318"},
+		{"平银", "sz000001"},
+	}
+	for _, tt := range tests {
+		results := s.Search(tt.query, 5)
+		if len(results) == 0 {
+			t.Errorf("Search(%q) 无结果", tt.query)
+			continue
+		}
+		if tt.want != "" && results[0].Symbol != tt.want {
+			t.Errorf("Search(%q)[0] = %s %s, want %s", tt.query, results[0].Symbol, results[0].Name, tt.want)
+		}
+	}
+
+	if got := s.Search("600", 0); len(got) != searchDefaultLimit {
+		t.Errorf("默认条数 = %d, want %d", len(got), searchDefaultLimit)
+	}
+	if got := s.Search("   ", 10); len(got) != 0 {
+		t.Errorf("空查询应返回空结果, got %d", len(got))
+	}
+}
@@ -96,7 +96,7 @@ func (ss *SessionService) saveSession(session *models.StockSession) error {
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(path, data, 0644)
+	return writeFileTracked(path, data, 0644)
 }
 
 // GetSession 获取Session
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/diagnostics"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+)
+
+const (
+	settingsBundleVersion = 1
+	settingsBackupDir     = "settings_backup"
+)
+
+// ExportSettings 导出设置备份，不含 API Key、令牌与密码（与诊断包使用相同的脱敏规则）
+func (cs *ConfigService) ExportSettings(appVersion string) ([]byte, error) {
+	bundle := cs.settingsBundle(appVersion)
+	cfg, err := diagnostics.StripSecrets(bundle.Config)
+	if err != nil {
+		return nil, err
+	}
+	bundle.Config = cfg
+	return json.MarshalIndent(bundle, "", "  ")
+}
+
+// settingsBundle 当前设置的完整快照（含密钥）
+func (cs *ConfigService) settingsBundle(appVersion string) models.SettingsBundle {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+
+	bundle := models.SettingsBundle{
+		Version:      settingsBundleVersion,
+		ExportedAt:   time.Now().UnixMilli(),
+		AppVersion:   appVersion,
+		Config:       cs.config,
+		Watchlist:    cs.watchlist,
+		Groups:       cs.groups,
+		PriceTargets: cs.priceTargetsLocked(),
+	}
+	// 条件单由 ConditionOrderService 维护，直接读取其文件
+	if data, err := os.ReadFile(cs.conditionOrdersPath()); err == nil {
+		json.Unmarshal(data, &bundle.ConditionOrders)
+	}
+	// 深拷贝，避免后续修改影响快照
+	data, _ := json.Marshal(bundle)
+	var snapshot models.SettingsBundle
+	json.Unmarshal(data, &snapshot)
+	return snapshot
+}
+
+// ImportSettings 导入设置备份并覆盖本机设置
+// 备份中为空的密钥保留本机的值，导入前当前设置备份到 settings_backup 目录
+func (cs *ConfigService) ImportSettings(data []byte) error {
+	var bundle models.SettingsBundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return i18n.Errorf("error.settings.bundle_invalid", err)
+	}
+	if bundle.Version < 1 || bundle.Version > settingsBundleVersion || bundle.Config == nil {
+		return i18n.Errorf("error.settings.bundle_version", bundle.Version)
+	}
+	cfg, err := diagnostics.RestoreSecrets(bundle.Config, cs.GetConfig())
+	if err != nil {
+		return err
+	}
+	bundle.Config = cfg
+	for _, persona := range bundle.Config.Personas {
+		if err := ValidatePersona(persona, bundle.Config); err != nil {
+			return err
+		}
+	}
+	if _, err := cs.backupSettings("import"); err != nil {
+		return err
+	}
+	if bundle.Watchlist == nil {
+		bundle.Watchlist = []models.Stock{}
+	}
+	if bundle.PriceTargets == nil {
+		bundle.PriceTargets = []models.PriceTarget{}
+	}
+
+	// 写入文件后由 Reload 补全缺省字段、校正分组并触发配置变更回调；条件单由调用方重新加载
+	files := map[string]any{
+		cs.configPath:    bundle.Config,
+		cs.watchlistPath: bundle.Watchlist,
+		cs.groupsPath:    bundle.Groups,
+		cs.targetsPath:   bundle.PriceTargets,
+	}
+	if bundle.ConditionOrders != nil { // 旧版备份不含条件单时保留本机的
+		files[cs.conditionOrdersPath()] = bundle.ConditionOrders
+	}
+	cs.mu.Lock()
+	for path, v := range files {
+		out, err := json.MarshalIndent(v, "", "  ")
+		if err == nil {
+			err = os.WriteFile(path, out, 0644)
+		}
+		if err != nil {
+			cs.mu.Unlock()
+			return err
+		}
+	}
+	cs.mu.Unlock()
+	return cs.Reload()
+}
+
+// ResetToDefaults 恢复默认配置，自选股、分组、目标价与条件单不受影响
+// 重置前当前设置备份到 settings_backup 目录，可通过导入恢复
+func (cs *ConfigService) ResetToDefaults() (string, error) {
+	path, err := cs.backupSettings("reset")
+	if err != nil {
+		return "", err
+	}
+	if err := cs.UpdateConfig(cs.defaultConfig()); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// backupSettings 将当前设置（含密钥）备份到 settings_backup 目录，返回备份文件路径
+func (cs *ConfigService) backupSettings(reason string) (string, error) {
+	dir := filepath.Join(filepath.Dir(cs.configPath), settingsBackupDir)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(cs.settingsBundle(""), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("settings-%s-%s.json", reason, time.Now().Format("20060102-150405"))
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// conditionOrdersPath 条件单文件路径
+func (cs *ConfigService) conditionOrdersPath() string {
+	return filepath.Join(filepath.Dir(cs.configPath), conditionOrdersFile)
+}
@@ -0,0 +1,283 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+	"github.com/run-bigpig/jcp/internal/pkg/proxy"
+)
+
+// 东方财富股东数据API（按报告期降序）
+const (
+	// 十大股东（取最近两期，用于计算变动）
+	topHoldersURL = "https://datacenter-web.eastmoney.com/api/data/v1/get?reportName=RPT_F10_EH_HOLDERS&columns=ALL&source=WEB&client=WEB&sortColumns=END_DATE,HOLDER_RANK&sortTypes=-1,1&pageNumber=1&pageSize=20&filter=(SECURITY_CODE%%3D%%22%s%%22)"
+	// 股东户数
+	holderCountURL = "https://datacenter-web.eastmoney.com/api/data/v1/get?reportName=RPT_HOLDERNUM_DET&columns=ALL&source=WEB&client=WEB&sortColumns=END_DATE&sortTypes=-1&pageNumber=1&pageSize=%d&filter=(SECURITY_CODE%%3D%%22%s%%22)"
+	// 机构持仓（按机构类型汇总）
+	institutionHoldingURL = "https://datacenter-web.eastmoney.com/api/data/v1/get?reportName=RPT_F10_MAIN_ORGHOLD&columns=ALL&source=WEB&client=WEB&sortColumns=REPORT_DATE&sortTypes=-1&pageNumber=1&pageSize=20&filter=(SECURITY_CODE%%3D%%22%s%%22)"
+)
+
+// holderCountPeriods 股东户数趋势的报告期数
+const holderCountPeriods = 12
+
+// institutionTotalType 机构持仓汇总行的类型名
+const institutionTotalType = "合计"
+
+// shareholderCache 股东数据缓存，到下次检查披露的时间失效
+type shareholderCache struct {
+	data     *models.ShareholderData
+	expireAt time.Time
+}
+
+// ShareholderService 股东数据服务：十大股东、机构持仓与股东户数趋势
+type ShareholderService struct {
+	client *http.Client
+
+	cache   map[string]*shareholderCache
+	cacheMu sync.RWMutex
+}
+
+// NewShareholderService 创建股东数据服务
+func NewShareholderService() *ShareholderService {
+	return &ShareholderService{
+		client: proxy.GetManager().GetClientWithTimeout(15 * time.Second),
+		cache:  make(map[string]*shareholderCache),
+	}
+}
+
+// GetShareholders 获取个股股东数据
+// symbol: 股票代码，支持 sh600519 / 600519；数据按季度披露，披露期内每日刷新
+func (s *ShareholderService) GetShareholders(ctx context.Context, symbol string) (*models.ShareholderData, error) {
+	code := strings.ToLower(symbol)
+	for _, prefix := range []string{"sh", "sz", "bj"} {
+		code = strings.TrimPrefix(code, prefix)
+	}
+	if code == "" {
+		return nil, i18n.Errorf("error.symbol.required")
+	}
+
+	s.cacheMu.RLock()
+	cached, ok := s.cache[code]
+	s.cacheMu.RUnlock()
+	if ok && time.Now().Before(cached.expireAt) {
+		return cached.data, nil
+	}
+
+	data := &models.ShareholderData{Symbol: symbol, UpdatedAt: time.Now().UnixMilli()}
+
+	body, err := s.get(ctx, fmt.Sprintf(topHoldersURL, code))
+	if err != nil {
+		return nil, err
+	}
+	if data.ReportDate, data.TopHolders, err = parseTopHolders(body); err != nil {
+		return nil, err
+	}
+
+	// 户数与机构持仓为补充数据，失败时保留十大股东
+	if body, err := s.get(ctx, fmt.Sprintf(holderCountURL, holderCountPeriods, code)); err == nil {
+		if counts, err := parseHolderCounts(body); err == nil {
+			data.HolderCounts = counts
+		}
+	} else {
+		log.Warn("获取股东户数失败: %v", err)
+	}
+	if body, err := s.get(ctx, fmt.Sprintf(institutionHoldingURL, code)); err == nil {
+		if date, ratio, items, err := parseInstitutionHoldings(body); err == nil {
+			data.InstitutionDate, data.InstitutionRatio, data.Institutions = date, ratio, items
+		}
+	} else {
+		log.Warn("获取机构持仓失败: %v", err)
+	}
+
+	s.cacheMu.Lock()
+	s.cache[code] = &shareholderCache{data: data, expireAt: nextShareholderRefresh(time.Now())}
+	s.cacheMu.Unlock()
+	return data, nil
+}
+
+// nextShareholderRefresh 下次刷新时间
+// 定期报告披露期（1-4 月年报与一季报、7-8 月半年报、10 月三季报）内次日早间刷新，其余时间每周刷新
+func nextShareholderRefresh(now time.Time) time.Time {
+	switch now.Month() {
+	case time.January, time.February, time.March, time.April, time.July, time.August, time.October:
+		next := time.Date(now.Year(), now.Month(), now.Day(), 9, 0, 0, 0, now.Location())
+		if !now.Before(next) {
+			next = next.AddDate(0, 0, 1)
+		}
+		return next
+	default:
+		return now.AddDate(0, 0, 7)
+	}
+}
+
+func (s *ShareholderService) get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
+	req.Header.Set("Referer", "https://data.eastmoney.com/")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+// 东方财富数据中心响应结构
+type datacenterResponse[T any] struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Result  *struct {
+		Data []T `json:"data"`
+	} `json:"result"`
+}
+
+// parseDatacenter 解析数据中心响应，无数据时返回空切片
+func parseDatacenter[T any](body []byte, what string) ([]T, error) {
+	var resp datacenterResponse[T]
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("解析%s失败: %w", what, err)
+	}
+	if !resp.Success || resp.Result == nil {
+		return []T{}, nil
+	}
+	return resp.Result.Data, nil
+}
+
+type topHolderItem struct {
+	EndDate       string  `json:"END_DATE"`
+	Rank          int     `json:"HOLDER_RANK"`
+	Name          string  `json:"HOLDER_NAME"`
+	HolderType    string  `json:"HOLDER_TYPE"`
+	HoldNum       float64 `json:"HOLD_NUM"`
+	HoldNumRatio  float64 `json:"HOLD_NUM_RATIO"`
+	HoldNumChange any     `json:"HOLD_NUM_CHANGE"` // "新进"、"不变" 或增减股数
+	ChangeRatio   float64 `json:"CHANGE_RATIO"`
+}
+
+// parseTopHolders 解析十大股东，只保留最新一期（按名次升序）
+func parseTopHolders(body []byte) (string, []models.Shareholder, error) {
+	items, err := parseDatacenter[topHolderItem](body, "十大股东")
+	if err != nil {
+		return "", nil, err
+	}
+	holders := []models.Shareholder{}
+	if len(items) == 0 {
+		return "", holders, nil
+	}
+	latest := items[0].EndDate
+	for _, item := range items {
+		if item.EndDate != latest {
+			continue
+		}
+		holders = append(holders, models.Shareholder{
+			Rank:        item.Rank,
+			Name:        item.Name,
+			Type:        item.HolderType,
+			Shares:      item.HoldNum,
+			Ratio:       item.HoldNumRatio,
+			Change:      holderChangeText(item.HoldNumChange),
+			ChangeRatio: item.ChangeRatio,
+		})
+	}
+	sort.SliceStable(holders, func(i, j int) bool { return holders[i].Rank < holders[j].Rank })
+	return trimDate(latest), holders, nil
+}
+
+// holderChangeText 持股变动统一为文本：数值格式化为带符号的股数
+func holderChangeText(v any) string {
+	switch c := v.(type) {
+	case string:
+		return c
+	case float64:
+		if c > 0 {
+			return "+" + strconv.FormatFloat(c, 'f', 0, 64)
+		}
+		return strconv.FormatFloat(c, 'f', 0, 64)
+	default:
+		return ""
+	}
+}
+
+type holderCountItem struct {
+	EndDate     string  `json:"END_DATE"`
+	HolderNum   int64   `json:"HOLDER_NUM"`
+	ChangeRatio float64 `json:"HOLDER_NUM_RATIO"`
+	AvgHoldNum  float64 `json:"AVG_HOLD_NUM"`
+}
+
+// parseHolderCounts 解析股东户数，结果按报告期升序
+func parseHolderCounts(body []byte) ([]models.HolderCount, error) {
+	items, err := parseDatacenter[holderCountItem](body, "股东户数")
+	if err != nil {
+		return nil, err
+	}
+	n := len(items)
+	counts := make([]models.HolderCount, n)
+	for i, item := range items {
+		counts[n-1-i] = models.HolderCount{
+			Date:        trimDate(item.EndDate),
+			Count:       item.HolderNum,
+			ChangeRatio: item.ChangeRatio,
+			AvgShares:   item.AvgHoldNum,
+		}
+	}
+	return counts, nil
+}
+
+type institutionHoldingItem struct {
+	ReportDate string  `json:"REPORT_DATE"`
+	OrgType    string  `json:"ORG_TYPE"`
+	OrgNum     int     `json:"TOTAL_ORG_NUM"`
+	FreeShares float64 `json:"TOTAL_FREE_SHARES"`
+	FreeRatio  float64 `json:"FREESHARES_RATIO"`
+}
+
+// parseInstitutionHoldings 解析最新一期机构持仓，返回报告期、机构合计持股比例与分类明细
+// 接口未给出合计行时按分类累加
+func parseInstitutionHoldings(body []byte) (string, float64, []models.InstitutionHolding, error) {
+	items, err := parseDatacenter[institutionHoldingItem](body, "机构持仓")
+	if err != nil {
+		return "", 0, nil, err
+	}
+	holdings := []models.InstitutionHolding{}
+	if len(items) == 0 {
+		return "", 0, holdings, nil
+	}
+	latest := items[0].ReportDate
+	var total, sum float64
+	hasTotal := false
+	for _, item := range items {
+		if item.ReportDate != latest {
+			continue
+		}
+		if item.OrgType == institutionTotalType {
+			total, hasTotal = item.FreeRatio, true
+			continue
+		}
+		sum += item.FreeRatio
+		holdings = append(holdings, models.InstitutionHolding{
+			Type:       item.OrgType,
+			OrgCount:   item.OrgNum,
+			Shares:     item.FreeShares,
+			FloatRatio: item.FreeRatio,
+		})
+	}
+	if !hasTotal {
+		total = sum
+	}
+	return trimDate(latest), total, holdings, nil
+}
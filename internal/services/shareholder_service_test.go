@@ -0,0 +1,87 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+// TestParseTopHolders 测试十大股东只保留最新一期并按名次排序
+func TestParseTopHolders(t *testing.T) {
+	body := []byte(`{"success":true,"result":{"data":[
+		{"END_DATE":"2024-09-30 00:00:00","HOLDER_RANK":2,"HOLDER_NAME":"香港中央结算有限公司","HOLDER_TYPE":"其它","HOLD_NUM":90000000,"HOLD_NUM_RATIO":7.16,"HOLD_NUM_CHANGE":-1200000,"CHANGE_RATIO":-1.32},
+		{"END_DATE":"2024-09-30 00:00:00","HOLDER_RANK":1,"HOLDER_NAME":"中国贵州茅台酒厂(集团)有限责任公司","HOLDER_TYPE":"国有法人","HOLD_NUM":678291200,"HOLD_NUM_RATIO":54.0,"HOLD_NUM_CHANGE":"不变"},
+		{"END_DATE":"2024-09-30 00:00:00","HOLDER_RANK":3,"HOLDER_NAME":"某证券投资基金","HOLD_NUM":5000000,"HOLD_NUM_RATIO":0.4,"HOLD_NUM_CHANGE":"新进"},
+		{"END_DATE":"2024-06-30 00:00:00","HOLDER_RANK":1,"HOLDER_NAME":"上期股东","HOLD_NUM":1,"HOLD_NUM_RATIO":1}
+	]}}`)
+	date, holders, err := parseTopHolders(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if date != "2024-09-30" || len(holders) != 3 {
+		t.Fatalf("date=%s holders=%+v", date, holders)
+	}
+	if holders[0].Rank != 1 || holders[0].Change != "不变" || holders[0].Type != "国有法人" {
+		t.Errorf("holders[0] = %+v", holders[0])
+	}
+	if holders[1].Change != "-1200000" || holders[2].Change != "新进" {
+		t.Errorf("变动文本错误: %q %q", holders[1].Change, holders[2].Change)
+	}
+
+	// 非A股或无数据
+	if _, holders, err := parseTopHolders([]byte(`{"success":false,"result":null}`)); err != nil || len(holders) != 0 {
+		t.Errorf("无数据应返回空列表: %v %v", holders, err)
+	}
+}
+
+// TestParseHolderCounts 测试股东户数按报告期升序
+func TestParseHolderCounts(t *testing.T) {
+	body := []byte(`{"success":true,"result":{"data":[
+		{"END_DATE":"2024-09-30 00:00:00","HOLDER_NUM":180000,"HOLDER_NUM_RATIO":-5.2,"AVG_HOLD_NUM":6980.5},
+		{"END_DATE":"2024-06-30 00:00:00","HOLDER_NUM":190000,"HOLDER_NUM_RATIO":3.1,"AVG_HOLD_NUM":6613.2}
+	]}}`)
+	counts, err := parseHolderCounts(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(counts) != 2 || counts[0].Date != "2024-06-30" || counts[1].Count != 180000 || counts[1].ChangeRatio != -5.2 {
+		t.Errorf("counts = %+v", counts)
+	}
+}
+
+// TestParseInstitutionHoldings 测试机构持仓合计：有合计行时取合计，否则按分类累加
+func TestParseInstitutionHoldings(t *testing.T) {
+	body := []byte(`{"success":true,"result":{"data":[
+		{"REPORT_DATE":"2024-09-30 00:00:00","ORG_TYPE":"基金","TOTAL_ORG_NUM":1200,"TOTAL_FREE_SHARES":60000000,"FREESHARES_RATIO":4.8},
+		{"REPORT_DATE":"2024-09-30 00:00:00","ORG_TYPE":"QFII","TOTAL_ORG_NUM":10,"TOTAL_FREE_SHARES":3000000,"FREESHARES_RATIO":0.24},
+		{"REPORT_DATE":"2024-09-30 00:00:00","ORG_TYPE":"合计","TOTAL_ORG_NUM":1300,"TOTAL_FREE_SHARES":800000000,"FREESHARES_RATIO":63.5},
+		{"REPORT_DATE":"2024-06-30 00:00:00","ORG_TYPE":"基金","TOTAL_ORG_NUM":1000,"FREESHARES_RATIO":4.0}
+	]}}`)
+	date, ratio, items, err := parseInstitutionHoldings(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if date != "2024-09-30" || ratio != 63.5 || len(items) != 2 || items[0].Type != "基金" {
+		t.Errorf("date=%s ratio=%v items=%+v", date, ratio, items)
+	}
+
+	noTotal := []byte(`{"success":true,"result":{"data":[
+		{"REPORT_DATE":"2024-09-30","ORG_TYPE":"基金","FREESHARES_RATIO":4.5},
+		{"REPORT_DATE":"2024-09-30","ORG_TYPE":"社保","FREESHARES_RATIO":1.5}
+	]}}`)
+	if _, ratio, _, _ := parseInstitutionHoldings(noTotal); ratio != 6 {
+		t.Errorf("无合计行时应累加: %v", ratio)
+	}
+}
+
+// TestNextShareholderRefresh 测试披露期内次日刷新，其余时间每周刷新
+func TestNextShareholderRefresh(t *testing.T) {
+	loc := time.FixedZone("CST", 8*60*60)
+	inSeason := time.Date(2024, 4, 20, 10, 0, 0, 0, loc)
+	if got := nextShareholderRefresh(inSeason); !got.Equal(time.Date(2024, 4, 21, 9, 0, 0, 0, loc)) {
+		t.Errorf("披露期 next = %v", got)
+	}
+	offSeason := time.Date(2024, 6, 1, 10, 0, 0, 0, loc)
+	if got := nextShareholderRefresh(offSeason); !got.Equal(offSeason.AddDate(0, 0, 7)) {
+		t.Errorf("非披露期 next = %v", got)
+	}
+}
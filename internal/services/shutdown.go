@@ -0,0 +1,71 @@
+package services
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+// writeTracker 进行中的写入计数，计数归零时唤醒等待者
+type writeTracker struct {
+	mu    sync.Mutex
+	cond  *sync.Cond
+	count int
+}
+
+func newWriteTracker() *writeTracker {
+	w := &writeTracker{}
+	w.cond = sync.NewCond(&w.mu)
+	return w
+}
+
+// begin 登记一次写入
+func (w *writeTracker) begin() {
+	w.mu.Lock()
+	w.count++
+	w.mu.Unlock()
+}
+
+// done 结束一次写入
+func (w *writeTracker) done() {
+	w.mu.Lock()
+	w.count--
+	if w.count == 0 {
+		w.cond.Broadcast()
+	}
+	w.mu.Unlock()
+}
+
+// wait 等待计数归零，超时返回 false；等待期间允许登记新的写入
+func (w *writeTracker) wait(timeout time.Duration) bool {
+	expired := false
+	timer := time.AfterFunc(timeout, func() {
+		w.mu.Lock()
+		expired = true
+		w.cond.Broadcast()
+		w.mu.Unlock()
+	})
+	defer timer.Stop()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for w.count > 0 && !expired {
+		w.cond.Wait()
+	}
+	return w.count == 0
+}
+
+// inflightWrites 进行中的状态文件写入，应用退出时等待其完成，避免写到一半的文件
+var inflightWrites = newWriteTracker()
+
+// writeFileTracked 写入状态文件并登记为进行中的写入
+func writeFileTracked(path string, data []byte, perm os.FileMode) error {
+	inflightWrites.begin()
+	defer inflightWrites.done()
+	return os.WriteFile(path, data, perm)
+}
+
+// WaitWrites 等待进行中的写入完成，超时返回 false
+func WaitWrites(timeout time.Duration) bool {
+	return inflightWrites.wait(timeout)
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestWaitWrites 测试退出时等待进行中的写入
+func TestWaitWrites(t *testing.T) {
+	inflightWrites.begin()
+	if WaitWrites(20 * time.Millisecond) {
+		t.Fatal("有进行中的写入时应超时")
+	}
+	inflightWrites.done()
+	if !WaitWrites(time.Second) {
+		t.Fatal("写入完成后应返回 true")
+	}
+
+	path := filepath.Join(t.TempDir(), "state.json")
+	if err := writeFileTracked(path, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if !WaitWrites(time.Second) {
+		t.Error("writeFileTracked 完成后不应残留计数")
+	}
+}
+
+// TestWaitWritesConcurrent 测试等待期间并发登记写入
+func TestWaitWritesConcurrent(t *testing.T) {
+	w := newWriteTracker()
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.begin()
+			time.Sleep(time.Millisecond)
+			w.done()
+		}()
+	}
+	for i := 0; i < 5; i++ {
+		w.wait(time.Millisecond)
+	}
+	wg.Wait()
+	if !w.wait(time.Second) {
+		t.Error("所有写入完成后应返回 true")
+	}
+}
@@ -0,0 +1,193 @@
+package services
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/pkg/apperr"
+)
+
+// 上游数据源
+const (
+	SourceQuotes        = "quotes"         // 新浪实时行情/指数
+	SourceKLine         = "kline"          // 新浪K线
+	SourceHoliday       = "holiday"        // 节假日CDN
+	SourceEastmoney     = "eastmoney"      // 东方财富行情列表
+	SourceNews          = "news"           // 财联社快讯
+	SourceNewsSina      = "news_sina"      // 新浪7x24快讯
+	SourceNewsEastmoney = "news_eastmoney" // 东方财富快讯
+)
+
+// 熔断器状态
+const (
+	CircuitClosed   = "closed"    // 正常
+	CircuitOpen     = "open"      // 熔断中，直接拒绝请求
+	CircuitHalfOpen = "half_open" // 冷却结束，放行一个探测请求
+)
+
+// ErrCircuitOpen 数据源熔断中
+var ErrCircuitOpen = apperr.New(apperr.CodeNetwork, "数据源暂时不可用（熔断中）")
+
+const (
+	healthWindowSize       = 50               // 错误率统计窗口（最近N次请求）
+	circuitFailThreshold   = 5                // 连续失败N次后熔断
+	circuitCooldown        = 30 * time.Second // 熔断冷却时间
+	latencySmoothingFactor = 0.2              // 延迟指数平滑系数
+)
+
+// dataSourceHealth 全局数据源健康状态（行情、资讯等服务共用）
+var dataSourceHealth = NewSourceHealthTracker()
+
+// SourceHealth 数据源健康状态
+type SourceHealth struct {
+	Source           string  `json:"source"`
+	State            string  `json:"state"`            // closed / open / half_open
+	Requests         int64   `json:"requests"`         // 累计请求数
+	Failures         int64   `json:"failures"`         // 累计失败数
+	ErrorRate        float64 `json:"errorRate"`        // 最近窗口错误率(%)
+	AvgLatencyMs     float64 `json:"avgLatencyMs"`     // 平滑后的平均延迟(毫秒)
+	LastLatencyMs    int64   `json:"lastLatencyMs"`    // 最近一次延迟(毫秒)
+	ConsecutiveFails int     `json:"consecutiveFails"` // 当前连续失败次数
+	LastError        string  `json:"lastError,omitempty"`
+	LastErrorAt      int64   `json:"lastErrorAt,omitempty"`   // 毫秒时间戳
+	LastSuccessAt    int64   `json:"lastSuccessAt,omitempty"` // 毫秒时间戳
+	OpenUntil        int64   `json:"openUntil,omitempty"`     // 熔断结束时间(毫秒)
+}
+
+// sourceState 单个数据源的统计与熔断状态
+type sourceState struct {
+	health   SourceHealth
+	window   []bool // 最近请求结果，true 表示失败
+	windowAt int
+	openedAt time.Time
+	probing  bool // 半开状态下是否已有探测请求在途
+}
+
+// SourceHealthTracker 数据源健康跟踪与熔断
+type SourceHealthTracker struct {
+	mu      sync.Mutex
+	sources map[string]*sourceState
+}
+
+// NewSourceHealthTracker 创建数据源健康跟踪器
+func NewSourceHealthTracker() *SourceHealthTracker {
+	return &SourceHealthTracker{sources: make(map[string]*sourceState)}
+}
+
+func (t *SourceHealthTracker) state(source string) *sourceState {
+	s, ok := t.sources[source]
+	if !ok {
+		s = &sourceState{
+			health: SourceHealth{Source: source, State: CircuitClosed},
+			window: make([]bool, 0, healthWindowSize),
+		}
+		t.sources[source] = s
+	}
+	return s
+}
+
+// Allow 请求前检查熔断状态，熔断中返回 ErrCircuitOpen
+func (t *SourceHealthTracker) Allow(source string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s := t.state(source)
+
+	switch s.health.State {
+	case CircuitOpen:
+		if time.Since(s.openedAt) < circuitCooldown {
+			return ErrCircuitOpen
+		}
+		s.health.State = CircuitHalfOpen
+		s.probing = true
+		return nil
+	case CircuitHalfOpen:
+		if s.probing {
+			return ErrCircuitOpen
+		}
+		s.probing = true
+	}
+	return nil
+}
+
+// Record 记录一次请求结果
+func (t *SourceHealthTracker) Record(source string, latency time.Duration, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s := t.state(source)
+	now := time.Now()
+	h := &s.health
+
+	h.Requests++
+	h.LastLatencyMs = latency.Milliseconds()
+	if h.AvgLatencyMs == 0 {
+		h.AvgLatencyMs = float64(h.LastLatencyMs)
+	} else {
+		h.AvgLatencyMs += latencySmoothingFactor * (float64(h.LastLatencyMs) - h.AvgLatencyMs)
+	}
+
+	failed := err != nil
+	if len(s.window) < healthWindowSize {
+		s.window = append(s.window, failed)
+	} else {
+		s.window[s.windowAt] = failed
+		s.windowAt = (s.windowAt + 1) % healthWindowSize
+	}
+	s.probing = false
+
+	if !failed {
+		h.ConsecutiveFails = 0
+		h.LastSuccessAt = now.UnixMilli()
+		if h.State != CircuitClosed {
+			log.Info("数据源 %s 已恢复", source)
+		}
+		h.State = CircuitClosed
+		h.OpenUntil = 0
+		return
+	}
+
+	h.Failures++
+	h.ConsecutiveFails++
+	h.LastError = err.Error()
+	h.LastErrorAt = now.UnixMilli()
+	if h.State == CircuitHalfOpen || h.ConsecutiveFails >= circuitFailThreshold {
+		if h.State != CircuitOpen {
+			log.Warn("数据源 %s 连续失败%d次，熔断 %v: %v", source, h.ConsecutiveFails, circuitCooldown, err)
+		}
+		h.State = CircuitOpen
+		s.openedAt = now
+		h.OpenUntil = now.Add(circuitCooldown).UnixMilli()
+	}
+}
+
+// Abort 请求被调用方取消，不计入统计，仅释放半开状态的探测名额
+func (t *SourceHealthTracker) Abort(source string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.state(source).probing = false
+}
+
+// Snapshot 获取所有数据源的健康状态
+func (t *SourceHealthTracker) Snapshot() []SourceHealth {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	result := make([]SourceHealth, 0, len(t.sources))
+	for _, s := range t.sources {
+		h := s.health
+		if len(s.window) > 0 {
+			var fails int
+			for _, f := range s.window {
+				if f {
+					fails++
+				}
+			}
+			h.ErrorRate = float64(fails) / float64(len(s.window)) * 100
+		}
+		result = append(result, h)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Source < result[j].Source
+	})
+	return result
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestSourceHealthCircuitBreaker 测试数据源连续失败后熔断、半开探测与恢复
+func TestSourceHealthCircuitBreaker(t *testing.T) {
+	tracker := NewSourceHealthTracker()
+	failErr := errors.New("timeout")
+
+	for i := 0; i < circuitFailThreshold; i++ {
+		if err := tracker.Allow(SourceQuotes); err != nil {
+			t.Fatalf("第%d次请求不应被熔断: %v", i+1, err)
+		}
+		tracker.Record(SourceQuotes, 100*time.Millisecond, failErr)
+	}
+	if err := tracker.Allow(SourceQuotes); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("连续失败后应熔断, got %v", err)
+	}
+
+	// 模拟冷却结束：第一个请求作为探测放行，其余继续拒绝
+	tracker.sources[SourceQuotes].openedAt = time.Now().Add(-circuitCooldown)
+	if err := tracker.Allow(SourceQuotes); err != nil {
+		t.Fatalf("冷却结束后应放行探测请求: %v", err)
+	}
+	if err := tracker.Allow(SourceQuotes); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("探测请求在途时应拒绝其他请求, got %v", err)
+	}
+	tracker.Record(SourceQuotes, 50*time.Millisecond, nil)
+
+	health := tracker.Snapshot()
+	if len(health) != 1 {
+		t.Fatalf("len(health) = %d, want 1", len(health))
+	}
+	h := health[0]
+	if h.State != CircuitClosed || h.ConsecutiveFails != 0 {
+		t.Errorf("探测成功后应恢复: state=%s consecutiveFails=%d", h.State, h.ConsecutiveFails)
+	}
+	if h.Requests != 6 || h.Failures != 5 {
+		t.Errorf("requests=%d failures=%d, want 6/5", h.Requests, h.Failures)
+	}
+	if h.ErrorRate < 83 || h.ErrorRate > 84 {
+		t.Errorf("errorRate = %.2f, want ~83.33", h.ErrorRate)
+	}
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"unicode"
+)
+
+const (
+	stockNewsDefaultLimit = 20
+	stockNewsMaxLimit     = 100
+)
+
+// GetStockNews 获取提及某只股票的资讯
+// 汇总各启用来源的最新快讯与本地归档（最近 30 天），按代码或名称匹配，去重后按时间倒序
+func (s *NewsService) GetStockNews(ctx context.Context, symbol, name string, limit int) ([]Telegraph, error) {
+	if limit <= 0 {
+		limit = stockNewsDefaultLimit
+	}
+	limit = min(limit, stockNewsMaxLimit)
+	match := stockNewsMatcher(symbol, name)
+
+	var lists [][]Telegraph
+	live, liveErr := s.GetTelegraphList(ctx)
+	if liveErr != nil {
+		log.Warn("获取最新快讯失败，仅使用归档: %v", liveErr)
+	}
+	var matched []Telegraph
+	for _, t := range live {
+		if match(t.Content) {
+			matched = append(matched, t)
+		}
+	}
+	lists = append(lists, matched)
+
+	s.mu.RLock()
+	archive := s.archive
+	s.mu.RUnlock()
+	if archive != nil {
+		end := archive.now()
+		start := end.AddDate(0, 0, -(newsSearchDefaultDays - 1))
+		archived, err := archive.scan(start, end, limit, match)
+		if err != nil {
+			log.Warn("检索归档快讯失败: %v", err)
+		} else {
+			lists = append(lists, archived)
+		}
+	} else if liveErr != nil {
+		return nil, liveErr
+	}
+
+	return mergeTelegraphs(lists, limit), nil
+}
+
+// stockNewsMatcher 构造个股资讯匹配函数：命中 6 位代码（前后不能紧邻数字）或股票名称
+// 名称同时匹配去掉 ST/*ST 前缀、A/B 后缀的简称，如 *ST 某某A → 某某
+func stockNewsMatcher(symbol, name string) func(content string) bool {
+	code := symbol
+	if len(code) > 6 {
+		code = code[len(code)-6:]
+	}
+
+	var names []string
+	name = strings.ReplaceAll(name, " ", "")
+	if name != "" {
+		names = append(names, name)
+		short := strings.TrimPrefix(strings.TrimPrefix(name, "*"), "ST")
+		short = strings.TrimRight(short, "AB")
+		if short != name && len([]rune(short)) >= 2 {
+			names = append(names, short)
+		}
+	}
+
+	return func(content string) bool {
+		if code != "" && containsCode(content, code) {
+			return true
+		}
+		for _, n := range names {
+			if strings.Contains(content, n) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// containsCode 内容中是否出现独立的股票代码（避免命中更长数字串的一部分）
+func containsCode(content, code string) bool {
+	for offset := 0; ; {
+		i := strings.Index(content[offset:], code)
+		if i < 0 {
+			return false
+		}
+		i += offset
+		end := i + len(code)
+		before := i == 0 || !unicode.IsDigit(rune(content[i-1]))
+		after := end == len(content) || !unicode.IsDigit(rune(content[end]))
+		if before && after {
+			return true
+		}
+		offset = i + 1
+	}
+}
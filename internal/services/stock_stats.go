@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+)
+
+const (
+	statsDailyBars   = 250  // 52周约250个交易日
+	statsMonthlyBars = 1000 // 月K覆盖上市以来全部历史
+)
+
+// GetStockStats 获取个股52周高低点、历史最高价与连涨连跌天数
+// 52周与连涨连跌基于日K；历史最高价同时参考月K，以覆盖日K数据范围之前的高点
+func (ms *MarketService) GetStockStats(ctx context.Context, code string) (*models.StockStats, error) {
+	daily, err := ms.GetKLineData(ctx, code, "1d", statsDailyBars)
+	if err != nil {
+		return nil, err
+	}
+	if len(daily) == 0 {
+		return nil, i18n.Errorf("error.kline.no_daily", code)
+	}
+	monthly, err := ms.GetKLineData(ctx, code, "1mo", statsMonthlyBars)
+	if err != nil {
+		log.Warn("获取 %s 月K失败，历史最高价仅参考日K: %v", code, err)
+	}
+	return calculateStockStats(code, daily, monthly), nil
+}
+
+// calculateStockStats 根据日K（按时间升序）与月K计算区间统计
+func calculateStockStats(code string, daily, monthly []models.KLineData) *models.StockStats {
+	last := daily[len(daily)-1]
+	stats := &models.StockStats{Code: code, Price: last.Close}
+
+	for i, k := range daily {
+		if i == 0 || k.High > stats.High52w {
+			stats.High52w, stats.High52wDate = k.High, k.Time
+		}
+		if i == 0 || (k.Low > 0 && k.Low < stats.Low52w) {
+			stats.Low52w, stats.Low52wDate = k.Low, k.Time
+		}
+	}
+	stats.AllTimeHigh, stats.AllTimeHighDate = stats.High52w, stats.High52wDate
+	for _, k := range monthly {
+		if k.High > stats.AllTimeHigh {
+			stats.AllTimeHigh, stats.AllTimeHighDate = k.High, k.Time
+		}
+	}
+
+	stats.FromHigh52w = percentFrom(last.Close, stats.High52w)
+	stats.FromLow52w = percentFrom(last.Close, stats.Low52w)
+	stats.FromAllTimeHigh = percentFrom(last.Close, stats.AllTimeHigh)
+	stats.Streak = closeStreak(daily)
+	return stats
+}
+
+// closeStreak 从最近一根日K往前统计连续上涨（正）或下跌（负）的天数
+func closeStreak(daily []models.KLineData) int {
+	streak := 0
+	for i := len(daily) - 1; i > 0; i-- {
+		diff := daily[i].Close - daily[i-1].Close
+		switch {
+		case diff > 0 && streak >= 0:
+			streak++
+		case diff < 0 && streak <= 0:
+			streak--
+		default:
+			return streak
+		}
+	}
+	return streak
+}
+
+// percentFrom price 相对 base 的涨跌幅(%)
+func percentFrom(price, base float64) float64 {
+	if base <= 0 {
+		return 0
+	}
+	return (price - base) / base * 100
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"math"
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+func TestCalculateStockStats(t *testing.T) {
+	daily := []models.KLineData{
+		{Time: "2026-01-05", High: 11, Low: 9, Close: 10},
+		{Time: "2026-01-06", High: 12, Low: 10, Close: 11},
+		{Time: "2026-01-07", High: 11, Low: 8, Close: 9},
+		{Time: "2026-01-08", High: 10, Low: 8.5, Close: 8.8},
+		{Time: "2026-01-09", High: 9, Low: 8.2, Close: 8.4},
+	}
+	monthly := []models.KLineData{{Time: "2021-02-26", High: 30}, {Time: "2026-01-09", High: 12}}
+
+	s := calculateStockStats("sh600000", daily, monthly)
+	if s.High52w != 12 || s.High52wDate != "2026-01-06" || s.Low52w != 8 || s.Low52wDate != "2026-01-07" {
+		t.Errorf("52周高低点错误: %+v", s)
+	}
+	if s.AllTimeHigh != 30 || s.AllTimeHighDate != "2021-02-26" {
+		t.Errorf("历史最高错误: %+v", s)
+	}
+	if math.Abs(s.FromHigh52w-(-30)) > 1e-9 || s.Streak != -3 {
+		t.Errorf("fromHigh52w=%v streak=%d", s.FromHigh52w, s.Streak)
+	}
+	if s := calculateStockStats("sh600000", daily[:2], nil); s.Streak != 1 || s.AllTimeHigh != 12 {
+		t.Errorf("无月K时 = %+v", s)
+	}
+}
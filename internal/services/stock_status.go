@@ -0,0 +1,68 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// EventStockStatus 股票交易状态变化（停牌、复牌、退市、戴帽摘帽），参数为 []StockStatusChange
+const EventStockStatus = "market:stock:status"
+
+// StockStatusChange 股票交易状态变化
+type StockStatusChange struct {
+	Symbol     string `json:"symbol"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`     // 当前状态，正常交易为空
+	PrevStatus string `json:"prevStatus"` // 变化前状态
+	ST         bool   `json:"st"`
+	PrevST     bool   `json:"prevSt"`
+	Resumed    bool   `json:"resumed"` // 停牌后复牌
+}
+
+// parseSinaStatus 解析新浪行情状态字段（索引 32）
+// 00 正常，01-05 各类停牌，07 暂停上市，-3 退市；名称以“退市”开头或以“退”结尾为退市整理
+func parseSinaStatus(name string, parts []string) string {
+	code := ""
+	if len(parts) > 32 {
+		code = strings.TrimSpace(parts[32])
+	}
+	switch code {
+	case "01", "02", "03", "04", "05", "07":
+		return models.StockStatusSuspended
+	case "-3":
+		return models.StockStatusDelisted
+	}
+	name = strings.TrimSpace(name)
+	if strings.HasPrefix(name, "退市") || strings.HasSuffix(name, "退") {
+		return models.StockStatusDelisted
+	}
+	return ""
+}
+
+// isSTName 名称是否带 ST 标记（ST、*ST、SST、S*ST）
+func isSTName(name string) bool {
+	name = strings.ToUpper(strings.ReplaceAll(name, " ", ""))
+	for _, prefix := range []string{"ST", "*ST", "SST", "S*ST"} {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// stockStatusChange 比较前后两次行情的交易状态
+func stockStatusChange(prev, cur models.Stock) (StockStatusChange, bool) {
+	if prev.Status == cur.Status && prev.ST == cur.ST {
+		return StockStatusChange{}, false
+	}
+	return StockStatusChange{
+		Symbol:     cur.Symbol,
+		Name:       cur.Name,
+		Status:     cur.Status,
+		PrevStatus: prev.Status,
+		ST:         cur.ST,
+		PrevST:     prev.ST,
+		Resumed:    prev.Status == models.StockStatusSuspended && cur.Status == "",
+	}, true
+}
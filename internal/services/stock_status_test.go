@@ -0,0 +1,82 @@
+package services
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// TestParseSinaStatus 测试从新浪行情解析停牌、退市与 ST 标记
+func TestParseSinaStatus(t *testing.T) {
+	data := `var hq_str_sz000001="平安银行,0.00,10.50,0.00,0.00,0.00,0.00,0.00,0,0.00,` +
+		`0,0.00,0,0,0,0,0,0,0,0,0,0.00,0,0,0,0,0,0,0,0,2024-01-05,15:00:00,03";` + "\n" +
+		`var hq_str_sh600001="*ST某某,3.10,3.09,3.12,3.15,3.05,3.11,3.12,1000,3100,` +
+		`100,3.11,0,0,0,0,0,0,0,0,200,3.12,0,0,0,0,0,0,0,0,2024-01-05,14:30:03,00";`
+	ms := &MarketService{}
+	stocks, err := ms.parseSinaStockData(data, nil)
+	if err != nil || len(stocks) != 2 {
+		t.Fatalf("解析失败: %v %v", stocks, err)
+	}
+	if s := stocks[0]; s.Status != models.StockStatusSuspended || s.Price != 10.5 || s.ChangePercent != 0 {
+		t.Errorf("停牌股票 = %+v", s)
+	}
+	if s := stocks[1]; s.Status != "" || !s.ST {
+		t.Errorf("ST 股票 = %+v", s)
+	}
+
+	cases := []struct {
+		name, code, want string
+	}{
+		{"某某退", "00", models.StockStatusDelisted},
+		{"退市某某", "", models.StockStatusDelisted},
+		{"某某股份", "-3", models.StockStatusDelisted},
+		{"某某股份", "07", models.StockStatusSuspended},
+		{"某某股份", "00", ""},
+	}
+	for _, c := range cases {
+		parts := make([]string, 33)
+		parts[32] = c.code
+		if got := parseSinaStatus(c.name, parts); got != c.want {
+			t.Errorf("parseSinaStatus(%q, %q) = %q, want %q", c.name, c.code, got, c.want)
+		}
+	}
+
+	for name, want := range map[string]bool{"ST某某": true, "*ST某某": true, "S*ST某某": true, "SST某某": true, "某某ST": false, "平安银行": false} {
+		if got := isSTName(name); got != want {
+			t.Errorf("isSTName(%q) = %v", name, got)
+		}
+	}
+}
+
+// TestAdaptivePollerSuspended 测试停牌股票降频拉取，复牌时报告状态变化
+func TestAdaptivePollerSuspended(t *testing.T) {
+	a := newAdaptivePoller()
+	codes := []string{"sz000001"}
+	suspended := models.Stock{Symbol: "sz000001", Price: 10.5, Status: models.StockStatusSuspended}
+
+	a.Select(codes)
+	if changes := a.Observe([]models.Stock{suspended}); len(changes) != 0 {
+		t.Fatalf("首次观察不应报告变化: %+v", changes)
+	}
+
+	polled := 0
+	for i := 0; i < suspendedPollEvery; i++ {
+		if fetch, _ := a.Select(codes); slices.Contains(fetch, "sz000001") {
+			polled++
+		}
+	}
+	if polled != 1 {
+		t.Errorf("停牌股票应每 %d 轮拉取一次: polled=%d", suspendedPollEvery, polled)
+	}
+
+	resumed := suspended
+	resumed.Status, resumed.Price = "", 10.8
+	changes := a.Observe([]models.Stock{resumed})
+	if len(changes) != 1 || !changes[0].Resumed || changes[0].PrevStatus != models.StockStatusSuspended {
+		t.Fatalf("复牌应报告状态变化: %+v", changes)
+	}
+	if fetch, _ := a.Select(codes); !slices.Contains(fetch, "sz000001") {
+		t.Error("复牌后应恢复每轮拉取")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/run-bigpig/jcp/internal/logger"
 	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
 )
 
 var strategyLog = logger.New("strategy")
@@ -63,7 +65,7 @@ func getDefaultStrategyAgents() []models.StrategyAgent {
 			Avatar:      "资",
 			Color:       "#F59E0B",
 			Instruction: "你是钱姐，私募圈出身的资金流向专家。你深谙'跟着主力走'的生存法则。\n\n【分析框架】\n1. 主力动向：大单净流入、主力持仓变化\n2. 北向资金：外资流向、重仓股变化\n3. 筹码分布：集中度、套牢盘、获利盘\n4. 盘口异动：大单托盘、压盘信号\n\n【回复风格】直白实在，150字以内。重点说清资金动向和主力意图。",
-			Tools:       []string{"get_orderbook", "get_stock_realtime", "get_kline_data"},
+			Tools:       []string{"get_money_flow", "get_orderbook", "get_stock_realtime", "get_kline_data"},
 			Enabled:     true,
 		},
 		{
@@ -103,6 +105,7 @@ func getDefaultStrategyAgents() []models.StrategyAgent {
 type StrategyService struct {
 	configPath string
 	store      models.StrategyStore
+	personas   []models.AgentPersona // 用户自定义专家，与当前策略专家合并
 	llm        model.LLM
 	mu         sync.RWMutex
 }
@@ -175,7 +178,7 @@ func (s *StrategyService) saveNoLock() error {
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(s.configPath, data, 0644)
+	return writeFileTracked(s.configPath, data, 0644)
 }
 
 // GetAllStrategies 获取所有策略
@@ -222,7 +225,7 @@ func (s *StrategyService) SetActiveStrategy(id string) error {
 		}
 	}
 	if !found {
-		return fmt.Errorf("策略不存在: %s", id)
+		return i18n.Errorf("error.strategy.not_found", id)
 	}
 
 	// 更新激活ID
@@ -243,7 +246,7 @@ func (s *StrategyService) AddStrategy(strategy models.Strategy) error {
 	// 检查ID是否重复
 	for _, st := range s.store.Strategies {
 		if st.ID == strategy.ID {
-			return fmt.Errorf("策略ID已存在: %s", strategy.ID)
+			return i18n.Errorf("error.strategy.exists", strategy.ID)
 		}
 	}
 
@@ -272,7 +275,7 @@ func (s *StrategyService) UpdateStrategy(strategy models.Strategy) error {
 			return s.saveNoLock()
 		}
 	}
-	return fmt.Errorf("策略不存在: %s", strategy.ID)
+	return i18n.Errorf("error.strategy.not_found", strategy.ID)
 }
 
 // DeleteStrategy 删除策略
@@ -283,17 +286,17 @@ func (s *StrategyService) DeleteStrategy(id string) error {
 	for i, st := range s.store.Strategies {
 		if st.ID == id {
 			if st.IsBuiltin {
-				return fmt.Errorf("内置策略不可删除")
+				return i18n.Errorf("error.strategy.builtin")
 			}
 			// 当前激活的策略不允许删除
 			if s.store.ActiveID == id {
-				return fmt.Errorf("当前激活的策略不可删除，请先切换到其他策略")
+				return i18n.Errorf("error.strategy.active")
 			}
 			s.store.Strategies = append(s.store.Strategies[:i], s.store.Strategies[i+1:]...)
 			return s.saveNoLock()
 		}
 	}
-	return fmt.Errorf("策略不存在: %s", id)
+	return i18n.Errorf("error.strategy.not_found", id)
 }
 
 // AddAgentToActiveStrategy 向当前激活策略添加专家
@@ -306,14 +309,14 @@ func (s *StrategyService) AddAgentToActiveStrategy(agent models.StrategyAgent) e
 			// 检查ID是否重复
 			for _, a := range st.Agents {
 				if a.ID == agent.ID {
-					return fmt.Errorf("专家ID已存在: %s", agent.ID)
+					return i18n.Errorf("error.agent.id_exists", agent.ID)
 				}
 			}
 			s.store.Strategies[i].Agents = append(s.store.Strategies[i].Agents, agent)
 			return s.saveNoLock()
 		}
 	}
-	return fmt.Errorf("当前策略不存在")
+	return i18n.Errorf("error.strategy.current_missing")
 }
 
 // UpdateAgentInActiveStrategy 更新当前激活策略中的专家
@@ -329,10 +332,10 @@ func (s *StrategyService) UpdateAgentInActiveStrategy(agent models.StrategyAgent
 					return s.saveNoLock()
 				}
 			}
-			return fmt.Errorf("专家不存在: %s", agent.ID)
+			return i18n.Errorf("error.agent.not_found", agent.ID)
 		}
 	}
-	return fmt.Errorf("当前策略不存在")
+	return i18n.Errorf("error.strategy.current_missing")
 }
 
 // DeleteAgentFromActiveStrategy 从当前激活策略删除专家
@@ -351,10 +354,10 @@ func (s *StrategyService) DeleteAgentFromActiveStrategy(agentID string) error {
 					return s.saveNoLock()
 				}
 			}
-			return fmt.Errorf("专家不存在: %s", agentID)
+			return i18n.Errorf("error.agent.not_found", agentID)
 		}
 	}
-	return fmt.Errorf("当前策略不存在")
+	return i18n.Errorf("error.strategy.current_missing")
 }
 
 // SetLLM 设置LLM用于AI生成策略
@@ -391,7 +394,7 @@ type MCPInfoForGen struct {
 // Generate 根据用户描述生成策略
 func (s *StrategyService) Generate(ctx context.Context, input GenerateInput) (*GenerateResult, error) {
 	if s.llm == nil {
-		return nil, fmt.Errorf("LLM未配置")
+		return nil, i18n.Errorf("error.ai.not_configured")
 	}
 	strategyLog.Info("开始生成策略, prompt=%s", input.Prompt)
 
@@ -401,13 +404,13 @@ func (s *StrategyService) Generate(ctx context.Context, input GenerateInput) (*G
 	// 调用LLM
 	response, err := s.callLLM(ctx, aiPrompt)
 	if err != nil {
-		return nil, fmt.Errorf("调用LLM失败: %w", err)
+		return nil, i18n.Errorf("error.ai.call_failed", err)
 	}
 
 	// 解析结果
 	result, err := s.parseGenerateResponse(response, input.Prompt)
 	if err != nil {
-		return nil, fmt.Errorf("解析结果失败: %w", err)
+		return nil, i18n.Errorf("error.ai.parse_failed", err)
 	}
 
 	strategyLog.Info("策略生成完成: %s", result.Strategy.Name)
@@ -570,31 +573,46 @@ func extractJSON(response string) string {
 	return ""
 }
 
-// getAgentConfigsFromStrategy 从当前策略获取Agent配置
+// getAgentConfigsFromStrategy 从当前策略获取Agent配置（含自定义专家）
 func (s *StrategyService) getAgentConfigsFromStrategy() []models.AgentConfig {
-	strategy := s.GetActiveStrategy()
-	if strategy == nil {
-		return nil
-	}
-
-	agents := make([]models.AgentConfig, len(strategy.Agents))
-	for i, sa := range strategy.Agents {
-		agents[i] = models.AgentConfig{
-			ID:          sa.ID,
-			Name:        sa.Name,
-			Role:        sa.Role,
-			Avatar:      sa.Avatar,
-			Color:       sa.Color,
-			Instruction: sa.Instruction,
-			Tools:       sa.Tools,
-			MCPServers:  sa.MCPServers,
-			Enabled:     sa.Enabled,
-			AIConfigID:  sa.AIConfigID,
+	var agents []models.AgentConfig
+	if strategy := s.GetActiveStrategy(); strategy != nil {
+		agents = make([]models.AgentConfig, len(strategy.Agents))
+		for i, sa := range strategy.Agents {
+			agents[i] = models.AgentConfig{
+				ID:          sa.ID,
+				Name:        sa.Name,
+				Role:        sa.Role,
+				Avatar:      sa.Avatar,
+				Color:       sa.Color,
+				Instruction: sa.Instruction,
+				Tools:       sa.Tools,
+				MCPServers:  sa.MCPServers,
+				Enabled:     sa.Enabled,
+				AIConfigID:  sa.AIConfigID,
+			}
+		}
+	}
+
+	// 追加自定义专家，ID 与策略专家冲突时以策略专家为准
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, p := range s.personas {
+		if slices.ContainsFunc(agents, func(a models.AgentConfig) bool { return a.ID == p.ID }) {
+			continue
 		}
+		agents = append(agents, p.ToAgentConfig())
 	}
 	return agents
 }
 
+// SetPersonas 设置参与讨论的自定义专家
+func (s *StrategyService) SetPersonas(personas []models.AgentPersona) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.personas = slices.Clone(personas)
+}
+
 // GetAllAgents 获取所有Agent配置
 func (s *StrategyService) GetAllAgents() []models.AgentConfig {
 	return s.getAgentConfigsFromStrategy()
@@ -655,7 +673,7 @@ type EnhancePromptResult struct {
 // EnhancePrompt 增强Agent提示词
 func (s *StrategyService) EnhancePrompt(ctx context.Context, input EnhancePromptInput) (*EnhancePromptResult, error) {
 	if s.llm == nil {
-		return nil, fmt.Errorf("LLM未配置")
+		return nil, i18n.Errorf("error.ai.not_configured")
 	}
 	strategyLog.Info("开始增强提示词, agent=%s, role=%s", input.AgentName, input.AgentRole)
 
@@ -665,13 +683,13 @@ func (s *StrategyService) EnhancePrompt(ctx context.Context, input EnhancePrompt
 	// 调用LLM
 	response, err := s.callLLM(ctx, aiPrompt)
 	if err != nil {
-		return nil, fmt.Errorf("调用LLM失败: %w", err)
+		return nil, i18n.Errorf("error.ai.call_failed", err)
 	}
 
 	// 解析结果
 	result, err := s.parseEnhanceResponse(response)
 	if err != nil {
-		return nil, fmt.Errorf("解析结果失败: %w", err)
+		return nil, i18n.Errorf("error.ai.parse_failed", err)
 	}
 
 	strategyLog.Info("提示词增强完成")
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// 订阅租约事件
+const (
+	// 前端定期发送心跳，参数为租约键列表
+	EventSubscriptionHeartbeat = "market:subscription:heartbeat"
+	// 租约过期或心跳的租约已不存在，参数为租约键列表，前端收到后重新订阅
+	EventSubscriptionExpired = "market:subscription:expired"
+)
+
+// 订阅租约键
+const (
+	LeaseStocks    = "stocks"
+	LeaseOrderBook = "orderbook"
+	LeaseKLine     = "kline"
+)
+
+const (
+	// subscriptionLeaseTTL 超过该时长未收到心跳的订阅视为已失效（前端心跳间隔 20 秒）
+	subscriptionLeaseTTL = 90 * time.Second
+	leaseCheckInterval   = 30 * time.Second
+)
+
+// subscriptionLeases 订阅租约表
+// 订阅时登记，心跳续期；过期的订阅由推送服务移除，避免已关闭面板的订阅继续占用上游请求
+type subscriptionLeases struct {
+	mu      sync.Mutex
+	expires map[string]time.Time
+	now     func() time.Time
+}
+
+func newSubscriptionLeases() *subscriptionLeases {
+	return &subscriptionLeases{expires: make(map[string]time.Time), now: time.Now}
+}
+
+// Grant 登记或续期租约
+func (l *subscriptionLeases) Grant(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.expires[key] = l.now().Add(subscriptionLeaseTTL)
+}
+
+// Renew 续期已有租约，返回不存在的键（已过期或从未订阅）
+func (l *subscriptionLeases) Renew(keys ...string) []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var missing []string
+	deadline := l.now().Add(subscriptionLeaseTTL)
+	for _, key := range keys {
+		if _, ok := l.expires[key]; !ok {
+			missing = append(missing, key)
+			continue
+		}
+		l.expires[key] = deadline
+	}
+	return missing
+}
+
+// RenewAll 续期全部租约（暂停恢复后前端心跳可能被节流）
+func (l *subscriptionLeases) RenewAll() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	deadline := l.now().Add(subscriptionLeaseTTL)
+	for key := range l.expires {
+		l.expires[key] = deadline
+	}
+}
+
+// Drop 移除租约
+func (l *subscriptionLeases) Drop(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.expires, key)
+}
+
+// Expire 移除并返回已过期的租约键
+func (l *subscriptionLeases) Expire() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	var expired []string
+	for key, t := range l.expires {
+		if now.After(t) {
+			expired = append(expired, key)
+			delete(l.expires, key)
+		}
+	}
+	slices.Sort(expired)
+	return expired
+}
+
+// Heartbeat 续期订阅租约，已失效的订阅通知前端重新订阅
+func (p *MarketDataPusher) Heartbeat(keys ...string) {
+	missing := p.leases.Renew(keys...)
+	if i := slices.Index(missing, LeaseStocks); i >= 0 {
+		// 自选股订阅由后端按当前分组维护，直接恢复
+		missing = slices.Delete(missing, i, i+1)
+		p.SetSubscriptions(p.configService.GetActiveGroupSymbols())
+	}
+	if len(missing) > 0 {
+		runtime.EventsEmit(p.ctx, EventSubscriptionExpired, missing)
+	}
+}
+
+// expireLeases 移除租约过期的订阅，暂停推送期间不检查
+func (p *MarketDataPusher) expireLeases() {
+	if p.paused.Load() {
+		return
+	}
+	expired := p.leases.Expire()
+	if len(expired) == 0 {
+		return
+	}
+	for _, key := range expired {
+		switch key {
+		case LeaseStocks:
+			p.mu.Lock()
+			p.subscribedCodes = nil
+			p.mu.Unlock()
+		case LeaseOrderBook:
+			p.mu.Lock()
+			p.currentOrderBook = ""
+			p.mu.Unlock()
+		case LeaseKLine:
+			p.klineSubMu.Lock()
+			p.klineSub = KLineSubscription{}
+			p.lastKLineBar = models.KLineData{}
+			p.klineSubMu.Unlock()
+		}
+	}
+	pusherLog.Info("订阅租约过期，已停止推送: %v", expired)
+	runtime.EventsEmit(p.ctx, EventSubscriptionExpired, expired)
+}
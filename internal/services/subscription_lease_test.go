@@ -0,0 +1,42 @@
+package services
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestSubscriptionLeases 测试租约续期与过期
+func TestSubscriptionLeases(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	l := newSubscriptionLeases()
+	l.now = func() time.Time { return now }
+
+	l.Grant(LeaseKLine)
+	l.Grant(LeaseStocks)
+
+	now = now.Add(subscriptionLeaseTTL / 2)
+	if missing := l.Renew(LeaseKLine, LeaseOrderBook); !slices.Equal(missing, []string{LeaseOrderBook}) {
+		t.Errorf("未登记的租约应返回: %v", missing)
+	}
+
+	now = now.Add(subscriptionLeaseTTL/2 + time.Second)
+	if expired := l.Expire(); !slices.Equal(expired, []string{LeaseStocks}) {
+		t.Errorf("未续期的租约应过期: %v", expired)
+	}
+	if missing := l.Renew(LeaseStocks); len(missing) != 1 {
+		t.Error("过期后续期应视为不存在")
+	}
+
+	// 暂停恢复后整体续期
+	now = now.Add(subscriptionLeaseTTL)
+	l.RenewAll()
+	if expired := l.Expire(); len(expired) != 0 {
+		t.Errorf("整体续期后不应过期: %v", expired)
+	}
+
+	l.Drop(LeaseKLine)
+	if missing := l.Renew(LeaseKLine); len(missing) != 1 {
+		t.Error("移除后续期应视为不存在")
+	}
+}
@@ -0,0 +1,61 @@
+package services
+
+import "strings"
+
+// 股票所属板块，决定涨跌幅限制与新股规则
+const (
+	boardMain    = "main"    // 沪深主板
+	boardChiNext = "chinext" // 创业板
+	boardSTAR    = "star"    // 科创板
+	boardBSE     = "bse"     // 北交所
+)
+
+// isBSECode 六位代码是否为北交所股票（43/83/87/88 老代码与 920 新代码）
+func isBSECode(code string) bool {
+	return strings.HasPrefix(code, "4") || strings.HasPrefix(code, "8") || strings.HasPrefix(code, "92")
+}
+
+// boardOf 按六位代码判断板块
+func boardOf(code string) string {
+	switch {
+	case isBSECode(code):
+		return boardBSE
+	case strings.HasPrefix(code, "300") || strings.HasPrefix(code, "301"):
+		return boardChiNext
+	case strings.HasPrefix(code, "688") || strings.HasPrefix(code, "689"):
+		return boardSTAR
+	default:
+		return boardMain
+	}
+}
+
+// newListingNoLimit 新股上市初期是否无涨跌幅限制
+// 交易所在简称前加 N 标记上市首日、加 C 标记注册制新股上市第 2-5 日；
+// 沪深注册制新股前 5 日均不设涨跌幅限制，北交所仅上市首日不设限制
+func newListingNoLimit(code, name string) bool {
+	switch {
+	case strings.HasPrefix(name, "N"):
+		return true
+	case strings.HasPrefix(name, "C"):
+		return boardOf(code) != boardBSE
+	default:
+		return false
+	}
+}
+
+// isStockSymbol 带市场前缀的代码是否为 A 股个股（排除指数、基金、债券等）
+func isStockSymbol(symbol string) bool {
+	if len(symbol) != 8 || !isDigits(symbol[2:]) {
+		return false
+	}
+	code := symbol[2:]
+	switch symbol[:2] {
+	case "sh":
+		return strings.HasPrefix(code, "60") || strings.HasPrefix(code, "68")
+	case "sz":
+		return strings.HasPrefix(code, "00") || strings.HasPrefix(code, "30")
+	case "bj":
+		return isBSECode(code)
+	}
+	return false
+}
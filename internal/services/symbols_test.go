@@ -0,0 +1,77 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// TestPriceLimitRatio 测试各板块与新股上市初期的涨跌幅限制
+func TestPriceLimitRatio(t *testing.T) {
+	cases := []struct {
+		code, name string
+		want       float64
+	}{
+		{"600519", "贵州茅台", 0.10},
+		{"600001", "*ST某某", 0.05},
+		{"300750", "宁德时代", 0.20},
+		{"300001", "ST某某", 0.20},
+		{"688981", "中芯国际", 0.20},
+		{"830799", "艾融软件", 0.30},
+		{"920001", "某某科技", 0.30},
+		{"688799", "N某某", 0},    // 科创板上市首日
+		{"301999", "C某某", 0},    // 创业板上市第 2-5 日
+		{"603999", "C某某", 0},    // 主板注册制新股上市第 2-5 日
+		{"920002", "N某某", 0},    // 北交所上市首日
+		{"920002", "C某某", 0.30}, // 北交所次日起恢复 30%
+	}
+	for _, c := range cases {
+		if got := priceLimitRatio(c.code, c.name); got != c.want {
+			t.Errorf("priceLimitRatio(%s, %s) = %v, want %v", c.code, c.name, got, c.want)
+		}
+	}
+
+	if up, down, ok := limitPrices("920001", "某某科技", 10); !ok || up != 13 || down != 7 {
+		t.Errorf("北交所涨跌停价 = %v %v %v", up, down, ok)
+	}
+	if _, _, ok := limitPrices("688799", "N某某", 10); ok {
+		t.Error("新股上市首日不应有涨跌停价")
+	}
+}
+
+// TestBSESymbols 测试北交所代码的市场识别
+func TestBSESymbols(t *testing.T) {
+	for code, want := range map[string]string{"920001": "bj", "830799": "bj", "430047": "bj", "900901": "sh", "600519": "sh", "000001": "sz"} {
+		if got := inferMarket(code); got != want {
+			t.Errorf("inferMarket(%s) = %s, want %s", code, got, want)
+		}
+	}
+	if got := emSymbol(emClistItem{Code: "920001", Market: 0}); got != "bj920001" {
+		t.Errorf("emSymbol = %s", got)
+	}
+	if got, ok := normalizeImportCode("920001.BJ", false); !ok || got != "bj920001" {
+		t.Errorf("normalizeImportCode = %s %v", got, ok)
+	}
+
+	for symbol, want := range map[string]bool{"bj920001": true, "sh688981": true, "sz300750": true, "sh000001": false, "sz399001": false, "sh510300": false, "AU0": false} {
+		if got := isStockSymbol(symbol); got != want {
+			t.Errorf("isStockSymbol(%s) = %v", symbol, got)
+		}
+	}
+}
+
+// TestApplyLimitPrices 测试推送行情填充涨跌停价，指数不填充
+func TestApplyLimitPrices(t *testing.T) {
+	stocks := []models.Stock{
+		{Symbol: "sz300750", Name: "宁德时代", PreClose: 200},
+		{Symbol: "sh000001", Name: "上证指数", PreClose: 3000},
+		{Symbol: "sh688799", Name: "N某某", PreClose: 30},
+	}
+	applyLimitPrices(stocks)
+	if stocks[0].LimitUpPrice != 240 || stocks[0].LimitDownPrice != 160 {
+		t.Errorf("创业板涨跌停价 = %v %v", stocks[0].LimitUpPrice, stocks[0].LimitDownPrice)
+	}
+	if stocks[1].LimitUpPrice != 0 || stocks[2].LimitUpPrice != 0 {
+		t.Errorf("指数与新股不应填充涨跌停价: %+v %+v", stocks[1], stocks[2])
+	}
+}
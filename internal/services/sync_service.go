@@ -0,0 +1,406 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/logger"
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/apperr"
+	"github.com/run-bigpig/jcp/internal/pkg/diagnostics"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+	"github.com/run-bigpig/jcp/internal/pkg/proxy"
+)
+
+var syncLog = logger.New("sync")
+
+// syncFiles 参与云同步的文件（数据目录下的相对路径）
+var syncFiles = []string{"config.json", "watchlist.json", "watchlist_groups.json", "price_targets.json", conditionOrdersFile}
+
+const (
+	syncBackupDir  = "sync_backup"
+	syncConfigFile = "config.json" // 上传前清空密钥，拉取时沿用本机密钥
+)
+
+// syncFileState 上次同步时的本地内容哈希与远端版本（ETag 或 Last-Modified）
+type syncFileState struct {
+	LocalHash     string `json:"localHash"`
+	RemoteVersion string `json:"remoteVersion"`
+}
+
+// remoteFile 远端文件
+type remoteFile struct {
+	data     []byte
+	etag     string
+	version  string // ETag，服务端不支持时退化为 Last-Modified 或内容哈希
+	modified time.Time
+}
+
+// SyncService WebDAV 云同步服务
+// 冲突检测：本地与远端自上次同步后都有修改时，按最后修改时间取新（last-writer-wins），
+// 被覆盖的一方备份到 sync_backup 目录；config.json 中的 API Key、令牌与密码不上传
+type SyncService struct {
+	dataDir       string
+	statePath     string
+	configService *ConfigService
+	client        *http.Client
+	mu            sync.Mutex // 同一时间只允许一次同步
+}
+
+// NewSyncService 创建云同步服务
+func NewSyncService(dataDir string, configService *ConfigService) *SyncService {
+	return &SyncService{
+		dataDir:       dataDir,
+		statePath:     filepath.Join(dataDir, "sync_state.json"),
+		configService: configService,
+		client:        proxy.GetManager().GetClientWithTimeout(30 * time.Second),
+	}
+}
+
+// Sync 执行一次双向同步，有文件被拉取时重新加载配置服务
+func (s *SyncService) Sync(ctx context.Context) (models.SyncResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := models.SyncResult{Pushed: []string{}, Pulled: []string{}, Conflicts: []string{}, Time: time.Now().UnixMilli()}
+	cfg := s.configService.GetConfig().Sync
+	if !cfg.Enabled || cfg.URL == "" {
+		return result, i18n.Errorf("error.sync.disabled")
+	}
+
+	if err := s.mkcol(ctx, cfg); err != nil {
+		return result, err
+	}
+	state := s.loadState()
+
+	for _, name := range syncFiles {
+		action, err := s.syncFile(ctx, cfg, name, state)
+		if err != nil {
+			return result, i18n.Errorf("error.sync.failed", name, err)
+		}
+		switch action {
+		case "push":
+			result.Pushed = append(result.Pushed, name)
+		case "pull":
+			result.Pulled = append(result.Pulled, name)
+		case "conflict-push":
+			result.Pushed = append(result.Pushed, name)
+			result.Conflicts = append(result.Conflicts, name)
+		case "conflict-pull":
+			result.Pulled = append(result.Pulled, name)
+			result.Conflicts = append(result.Conflicts, name)
+		}
+	}
+	if err := s.saveState(state); err != nil {
+		return result, err
+	}
+
+	if len(result.Pulled) > 0 {
+		if err := s.reloadKeepingSyncConfig(cfg); err != nil {
+			return result, err
+		}
+	}
+	syncLog.Info("云同步完成: 上传%v 拉取%v 冲突%v", result.Pushed, result.Pulled, result.Conflicts)
+	return result, nil
+}
+
+// syncFile 同步单个文件，返回执行的动作
+func (s *SyncService) syncFile(ctx context.Context, cfg models.SyncConfig, name string, state map[string]syncFileState) (string, error) {
+	localPath := filepath.Join(s.dataDir, name)
+	local, err := os.ReadFile(localPath)
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	// content 为参与比较与上传的内容，local 保留原文用于备份与补回密钥
+	content, err := syncContent(name, local)
+	if err != nil {
+		return "", err
+	}
+	remote, err := s.get(ctx, cfg, name)
+	if err != nil {
+		return "", err
+	}
+
+	prev := state[name]
+	localHash := hashBytes(content)
+
+	switch {
+	case local == nil && remote == nil:
+		return "", nil
+	case remote == nil:
+		return "push", s.push(ctx, cfg, name, content, "", state)
+	case local == nil:
+		return "pull", s.pull(name, localPath, nil, remote, state)
+	case localHash == hashBytes(remote.data):
+		state[name] = syncFileState{LocalHash: localHash, RemoteVersion: remote.version}
+		return "", nil
+	}
+
+	// 本机首次同步：远端已有数据时以远端为准（新设备加入），本地文件备份
+	if prev == (syncFileState{}) {
+		return "conflict-pull", s.pull(name, localPath, local, remote, state)
+	}
+
+	localChanged := localHash != prev.LocalHash
+	remoteChanged := remote.version != prev.RemoteVersion
+	switch {
+	case localChanged && !remoteChanged:
+		return "push", s.push(ctx, cfg, name, content, remote.etag, state)
+	case !localChanged && remoteChanged:
+		return "pull", s.pull(name, localPath, local, remote, state)
+	case !localChanged && !remoteChanged:
+		return "", nil
+	}
+
+	// 两端都有修改：按最后修改时间取新，旧版本备份到本地
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return "", err
+	}
+	if remote.modified.After(info.ModTime()) {
+		return "conflict-pull", s.pull(name, localPath, local, remote, state)
+	}
+	if err := s.backup(name+".remote", remote.data); err != nil {
+		return "", err
+	}
+	return "conflict-push", s.push(ctx, cfg, name, content, remote.etag, state)
+}
+
+// push 上传本地文件，etag 非空时带 If-Match 防止覆盖同步期间远端的新修改
+func (s *SyncService) push(ctx context.Context, cfg models.SyncConfig, name string, data []byte, etag string, state map[string]syncFileState) error {
+	req, err := s.newRequest(ctx, cfg, http.MethodPut, name, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if etag != "" {
+		req.Header.Set("If-Match", etag)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return apperr.Wrap(apperr.CodeNetwork, err, "")
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusPreconditionFailed {
+		return i18n.Errorf("error.sync.conflict")
+	}
+	if err := checkWebDAVStatus(resp); err != nil {
+		return err
+	}
+
+	// 重新获取远端版本，下次同步据此判断远端是否变化
+	remote, err := s.get(ctx, cfg, name)
+	if err != nil {
+		return err
+	}
+	st := syncFileState{LocalHash: hashBytes(data)}
+	if remote != nil {
+		st.RemoteVersion = remote.version
+	}
+	state[name] = st
+	return nil
+}
+
+// pull 用远端内容覆盖本地，覆盖前备份本地文件；config.json 中为空的密钥沿用本机的值
+func (s *SyncService) pull(name, localPath string, local []byte, remote *remoteFile, state map[string]syncFileState) error {
+	if local != nil {
+		if err := s.backup(name, local); err != nil {
+			return err
+		}
+	}
+	data := remote.data
+	if name == syncConfigFile {
+		restored, err := restoreConfigSecrets(remote.data, local)
+		if err != nil {
+			return err
+		}
+		data = restored
+	}
+	if err := writeFileTracked(localPath, data, 0644); err != nil {
+		return err
+	}
+	state[name] = syncFileState{LocalHash: hashBytes(remote.data), RemoteVersion: remote.version}
+	return nil
+}
+
+// backup 备份文件到 sync_backup/<name>.<时间戳>
+func (s *SyncService) backup(name string, data []byte) error {
+	dir := filepath.Join(s.dataDir, syncBackupDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s.%s", name, time.Now().Format("20060102-150405")))
+	return writeFileTracked(path, data, 0644)
+}
+
+// reloadKeepingSyncConfig 重新加载配置，同步设置保留本机的（远端配置可能来自其他账号或地址）
+func (s *SyncService) reloadKeepingSyncConfig(local models.SyncConfig) error {
+	if err := s.configService.Reload(); err != nil {
+		return i18n.Errorf("error.sync.reload_failed", err)
+	}
+	config := *s.configService.GetConfig()
+	if config.Sync == local {
+		return nil
+	}
+	config.Sync = local
+	if err := s.configService.UpdateConfig(&config); err != nil {
+		return err
+	}
+
+	// 保存后的 config.json 与远端不同，更新哈希避免下次误判为本地修改后上传
+	state := s.loadState()
+	if data, err := os.ReadFile(filepath.Join(s.dataDir, syncConfigFile)); err == nil {
+		content, err := syncContent(syncConfigFile, data)
+		if err != nil {
+			return err
+		}
+		st := state[syncConfigFile]
+		st.LocalHash = hashBytes(content)
+		state[syncConfigFile] = st
+	}
+	return s.saveState(state)
+}
+
+// get 获取远端文件，不存在时返回 nil
+func (s *SyncService) get(ctx context.Context, cfg models.SyncConfig, name string) (*remoteFile, error) {
+	req, err := s.newRequest(ctx, cfg, http.MethodGet, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, apperr.Wrap(apperr.CodeNetwork, err, "")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err := checkWebDAVStatus(resp); err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	modified, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+	etag := resp.Header.Get("ETag")
+	version := etag
+	if version == "" {
+		version = resp.Header.Get("Last-Modified")
+	}
+	if version == "" {
+		version = hashBytes(data)
+	}
+	return &remoteFile{data: data, etag: etag, version: version, modified: modified}, nil
+}
+
+// mkcol 创建远端目录（已存在时忽略）
+func (s *SyncService) mkcol(ctx context.Context, cfg models.SyncConfig) error {
+	req, err := s.newRequest(ctx, cfg, "MKCOL", "", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return apperr.Wrap(apperr.CodeNetwork, err, "连接 WebDAV 失败")
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusMethodNotAllowed {
+		return nil
+	}
+	return checkWebDAVStatus(resp)
+}
+
+// newRequest 构造带 Basic 认证的 WebDAV 请求
+func (s *SyncService) newRequest(ctx context.Context, cfg models.SyncConfig, method, name string, body io.Reader) (*http.Request, error) {
+	url := strings.TrimRight(cfg.URL, "/") + "/" + name
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Username != "" {
+		req.SetBasicAuth(cfg.Username, cfg.Password)
+	}
+	return req, nil
+}
+
+// checkWebDAVStatus 检查响应状态码
+func checkWebDAVStatus(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	return apperr.FromHTTPStatus(resp.StatusCode, fmt.Errorf("WebDAV 响应异常: HTTP %d", resp.StatusCode))
+}
+
+// loadState 加载同步状态
+func (s *SyncService) loadState() map[string]syncFileState {
+	state := map[string]syncFileState{}
+	if data, err := os.ReadFile(s.statePath); err == nil {
+		json.Unmarshal(data, &state)
+	}
+	return state
+}
+
+// saveState 保存同步状态
+func (s *SyncService) saveState(state map[string]syncFileState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileTracked(s.statePath, data, 0644)
+}
+
+// hashBytes 内容哈希，nil 返回空字符串
+func hashBytes(data []byte) string {
+	if data == nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// syncContent 获取文件参与同步的内容，config.json 清空密钥（与导出设置使用相同的脱敏规则）
+func syncContent(name string, data []byte) ([]byte, error) {
+	if name != syncConfigFile || data == nil {
+		return data, nil
+	}
+	var cfg models.AppConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	stripped, err := diagnostics.StripSecrets(&cfg)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(stripped, "", "  ")
+}
+
+// restoreConfigSecrets 用本机 config.json 补回远端配置中被清空的密钥
+func restoreConfigSecrets(remote, local []byte) ([]byte, error) {
+	if local == nil {
+		return remote, nil
+	}
+	var cfg, localCfg models.AppConfig
+	if err := json.Unmarshal(remote, &cfg); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(local, &localCfg); err != nil {
+		return nil, err
+	}
+	restored, err := diagnostics.RestoreSecrets(&cfg, &localCfg)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(restored, "", "  ")
+}
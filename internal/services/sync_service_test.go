@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// fakeWebDAV 内存 WebDAV 服务（支持 MKCOL/GET/PUT 与 ETag）
+type fakeWebDAV struct {
+	files map[string][]byte
+	revs  map[string]int
+}
+
+func (f *fakeWebDAV) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	etag := func(name string) string { return fmt.Sprintf(`"%d"`, f.revs[name]) }
+	switch r.Method {
+	case "MKCOL":
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	case http.MethodGet:
+		data, ok := f.files[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("ETag", etag(r.URL.Path))
+		w.Write(data)
+	case http.MethodPut:
+		if m := r.Header.Get("If-Match"); m != "" && m != etag(r.URL.Path) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		f.files[r.URL.Path] = data
+		f.revs[r.URL.Path]++
+		w.WriteHeader(http.StatusCreated)
+	}
+}
+
+// TestSyncService 测试两台设备通过 WebDAV 同步自选股
+func TestSyncService(t *testing.T) {
+	server := httptest.NewServer(&fakeWebDAV{files: map[string][]byte{}, revs: map[string]int{}})
+	defer server.Close()
+
+	newDevice := func() (*ConfigService, *SyncService) {
+		dir := t.TempDir()
+		cs, err := NewConfigService(dir)
+		if err != nil {
+			t.Fatalf("NewConfigService 失败: %v", err)
+		}
+		cfg := *cs.GetConfig()
+		cfg.Sync = models.SyncConfig{Enabled: true, URL: server.URL + "/jcp/"}
+		cs.UpdateConfig(&cfg)
+		return cs, NewSyncService(dir, cs)
+	}
+	ctx := context.Background()
+
+	csA, syncA := newDevice()
+	csA.AddToWatchlist(models.Stock{Symbol: "sh600519", Name: "贵州茅台"})
+	if res, err := syncA.Sync(ctx); err != nil || len(res.Pushed) == 0 {
+		t.Fatalf("A 首次同步应上传: %+v, %v", res, err)
+	}
+
+	csB, syncB := newDevice()
+	res, err := syncB.Sync(ctx)
+	if err != nil {
+		t.Fatalf("B 同步失败: %v", err)
+	}
+	if got := csB.GetWatchlist(); len(got) != 1 || got[0].Symbol != "sh600519" {
+		t.Errorf("B 应拉取到 A 的自选股, got %+v (result %+v)", got, res)
+	}
+	if csB.GetConfig().Sync.URL != server.URL+"/jcp/" {
+		t.Error("拉取配置后应保留本机同步设置")
+	}
+
+	// 未修改时再次同步不应有动作
+	res, err = syncB.Sync(ctx)
+	if err != nil || len(res.Pushed)+len(res.Pulled) != 0 {
+		t.Errorf("无修改时不应同步文件: %+v, %v", res, err)
+	}
+
+	// B 修改后 A 拉取
+	csB.AddToWatchlist(models.Stock{Symbol: "sz000001", Name: "平安银行"})
+	if _, err := syncB.Sync(ctx); err != nil {
+		t.Fatalf("B 上传失败: %v", err)
+	}
+	if _, err := syncA.Sync(ctx); err != nil {
+		t.Fatalf("A 拉取失败: %v", err)
+	}
+	if got := csA.GetWatchlist(); len(got) != 2 {
+		t.Errorf("A 应拉取到 B 新增的自选股, got %+v", got)
+	}
+}
+
+// TestSyncServiceKeepsSecretsLocal 测试 config.json 上传前清空密钥，拉取时沿用本机密钥
+func TestSyncServiceKeepsSecretsLocal(t *testing.T) {
+	dav := &fakeWebDAV{files: map[string][]byte{}, revs: map[string]int{}}
+	server := httptest.NewServer(dav)
+	defer server.Close()
+
+	newDevice := func(model, apiKey string) (string, *ConfigService, *SyncService) {
+		dir := t.TempDir()
+		cs, err := NewConfigService(dir)
+		if err != nil {
+			t.Fatalf("NewConfigService 失败: %v", err)
+		}
+		cfg := *cs.GetConfig()
+		cfg.Sync = models.SyncConfig{Enabled: true, URL: server.URL + "/jcp/", Username: "me", Password: "dav-" + apiKey}
+		cfg.AIConfigs = []models.AIConfig{{ID: "ai-1", Name: "默认", ModelName: model, APIKey: apiKey}}
+		if err := cs.UpdateConfig(&cfg); err != nil {
+			t.Fatalf("UpdateConfig 失败: %v", err)
+		}
+		return dir, cs, NewSyncService(dir, cs)
+	}
+	ctx := context.Background()
+
+	_, _, syncA := newDevice("model-a", "sk-device-a")
+	if _, err := syncA.Sync(ctx); err != nil {
+		t.Fatalf("A 同步失败: %v", err)
+	}
+	uploaded := string(dav.files["/jcp/config.json"])
+	if uploaded == "" || strings.Contains(uploaded, "sk-device-a") {
+		t.Fatalf("上传的 config.json 不应包含密钥: %s", uploaded)
+	}
+
+	dirB, csB, syncB := newDevice("model-b", "sk-device-b")
+	if res, err := syncB.Sync(ctx); err != nil || !slices.Contains(res.Pulled, "config.json") {
+		t.Fatalf("B 应拉取 config.json: %+v, %v", res, err)
+	}
+	cfgB := csB.GetConfig()
+	if len(cfgB.AIConfigs) != 1 || cfgB.AIConfigs[0].ModelName != "model-a" || cfgB.AIConfigs[0].APIKey != "sk-device-b" ||
+		cfgB.Sync.Password != "dav-sk-device-b" {
+		t.Errorf("拉取后应使用远端配置并保留本机密钥: %+v %+v", cfgB.AIConfigs, cfgB.Sync)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dirB, "config.json")); !strings.Contains(string(data), "sk-device-b") {
+		t.Error("本机 config.json 中的密钥丢失")
+	}
+
+	// 仅密钥不同不视为修改
+	if res, err := syncB.Sync(ctx); err != nil || len(res.Pushed)+len(res.Pulled) != 0 {
+		t.Errorf("无修改时不应同步文件: %+v, %v", res, err)
+	}
+}
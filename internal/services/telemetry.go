@@ -0,0 +1,37 @@
+package services
+
+import "github.com/run-bigpig/jcp/internal/pkg/metrics"
+
+// 服务层运行指标（在 GetMetrics 与本地 /metrics 中输出）
+var (
+	pushRoundSeconds = metrics.NewHistogram("jcp_push_round_seconds", "推送轮次耗时（秒）", nil)
+	pushSkipped      = metrics.NewCounter("jcp_push_skipped_total", "上一轮未完成而跳过的推送轮次")
+	pushTimeouts     = metrics.NewCounter("jcp_push_timeouts_total", "超时被取消的推送轮次")
+	memoryTrims      = metrics.NewCounter("jcp_memory_trims_total", "内存超限触发的缓存收缩次数")
+)
+
+// fetchSeconds 上游请求耗时（含重试）
+func fetchSeconds(source string) *metrics.Histogram {
+	return metrics.NewHistogram("jcp_fetch_seconds", "上游请求耗时（秒，含重试）", nil, "source", source)
+}
+
+// fetchErrors 上游请求失败次数
+func fetchErrors(source string) *metrics.Counter {
+	return metrics.NewCounter("jcp_fetch_errors_total", "上游请求失败次数", "source", source)
+}
+
+// cacheGauges 注册缓存的条数、估算字节数与淘汰数
+func cacheGauges(cache string, entries func() int, bytes, evictions func() int64) {
+	metrics.NewGaugeFunc("jcp_cache_entries", "缓存条数", func() float64 { return float64(entries()) }, "cache", cache)
+	metrics.NewGaugeFunc("jcp_cache_bytes", "缓存估算字节数", func() float64 { return float64(bytes()) }, "cache", cache)
+	metrics.NewGaugeFunc("jcp_cache_evictions", "缓存累计淘汰条数", func() float64 { return float64(evictions()) }, "cache", cache)
+}
+
+// cacheLookup 记录缓存命中或未命中
+func cacheLookup(cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	metrics.NewCounter("jcp_cache_requests_total", "缓存查询次数", "cache", cache, "result", result).Inc()
+}
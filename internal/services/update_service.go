@@ -7,23 +7,28 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"sync"
 	"time"
 
 	"github.com/blang/semver"
-	"github.com/run-bigpig/go-github-selfupdate/selfupdate"
 	"github.com/run-bigpig/jcp/internal/logger"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+	"github.com/run-bigpig/jcp/internal/pkg/proxy"
+	"github.com/run-bigpig/jcp/internal/pkg/updater"
 	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
 var updateLog = logger.New("update")
 
 // UpdateService 更新检测服务
-// 负责从 GitHub Releases 检测和下载更新
+// 负责从 GitHub Releases 检测更新，下载并校验当前平台的安装包，退出或重启时替换可执行文件
 type UpdateService struct {
 	ctx            context.Context
 	repoOwner      string // GitHub 仓库所有者
 	repoName       string // GitHub 仓库名称
 	currentVersion string // 当前版本号
+	client         *updater.Client
+	mu             sync.Mutex // 同一时间只允许一次下载
 }
 
 // UpdateInfo 更新信息
@@ -33,6 +38,8 @@ type UpdateInfo struct {
 	CurrentVersion string `json:"currentVersion"`
 	ReleaseURL     string `json:"releaseUrl"`
 	ReleaseNotes   string `json:"releaseNotes"`
+	PublishedAt    int64  `json:"publishedAt,omitempty"` // 发布时间(ms)
+	Downloaded     bool   `json:"downloaded,omitempty"`  // 已下载，重启后生效
 	Error          string `json:"error,omitempty"`
 }
 
@@ -43,12 +50,22 @@ type UpdateProgress struct {
 	Percent int    `json:"percent"` // 进度百分比 (0-100)
 }
 
-// NewUpdateService 创建更新服务实例
-func NewUpdateService(repoOwner, repoName, currentVersion string) *UpdateService {
+const (
+	updateCheckTimeout = 30 * time.Second
+	updateAutoDelay    = 20 * time.Second // 启动后延迟检查，避免与行情初始化争抢网络
+)
+
+// NewUpdateService 创建更新服务实例，安装包暂存在 dataDir/updates
+func NewUpdateService(repoOwner, repoName, currentVersion, dataDir string) *UpdateService {
 	return &UpdateService{
 		repoOwner:      repoOwner,
 		repoName:       repoName,
 		currentVersion: currentVersion,
+		client: &updater.Client{
+			Repo: repoOwner + "/" + repoName,
+			HTTP: proxy.GetManager().GetClientWithTimeout(10 * time.Minute),
+			Dir:  filepath.Join(dataDir, "updates"),
+		},
 	}
 }
 
@@ -59,6 +76,10 @@ func (u *UpdateService) Startup(ctx context.Context) {
 	if err := u.CleanupOldFiles(); err != nil {
 		updateLog.Warn("清理旧文件失败: %v", err)
 	}
+	// 已是暂存版本或更新版本时（如手动覆盖安装），丢弃暂存的安装包
+	if p := u.client.LoadPending(); p != nil && !u.newerThanCurrent(p.Version) {
+		u.client.ClearPending()
+	}
 }
 
 // GetCurrentVersion 获取当前版本
@@ -66,54 +87,74 @@ func (u *UpdateService) GetCurrentVersion() string {
 	return u.currentVersion
 }
 
-// CheckForUpdate 检查是否有可用更新
-func (u *UpdateService) CheckForUpdate() UpdateInfo {
-	repo := fmt.Sprintf("%s/%s", u.repoOwner, u.repoName)
-	updateLog.Info("检查更新: repo=%s, current=%s", repo, u.currentVersion)
-
-	latest, found, err := selfupdate.DetectLatest(repo)
+// newerThanCurrent 判断版本是否比当前版本新，当前版本无法解析（如 dev）时按字符串比较
+func (u *UpdateService) newerThanCurrent(version string) bool {
+	v, err := semver.ParseTolerant(version)
 	if err != nil {
-		updateLog.Error("检测更新失败: %v", err)
-		return UpdateInfo{
-			HasUpdate:      false,
-			CurrentVersion: u.currentVersion,
-			Error:          fmt.Sprintf("检测更新失败: %v", err),
-		}
+		return false
 	}
+	current, err := semver.ParseTolerant(u.currentVersion)
+	if err != nil {
+		return v.String() != u.currentVersion
+	}
+	return v.GT(current)
+}
 
-	if !found {
-		return UpdateInfo{
-			HasUpdate:      false,
-			CurrentVersion: u.currentVersion,
-			LatestVersion:  u.currentVersion,
-			Error:          "未找到 GitHub Release",
-		}
+// detect 检测最新版本
+func (u *UpdateService) detect() (*updater.Release, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), updateCheckTimeout)
+	defer cancel()
+	latest, err := u.client.Latest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("检测更新失败: %w", err)
+	}
+	if latest == nil {
+		return nil, i18n.Errorf("error.update.no_asset", runtime.GOOS, runtime.GOARCH)
 	}
+	return latest, nil
+}
 
-	updateLog.Info("检测到版本: %s, URL: %s", latest.Version.String(), latest.URL)
+// CheckForUpdate 检查是否有可用更新
+func (u *UpdateService) CheckForUpdate() UpdateInfo {
+	updateLog.Info("检查更新: repo=%s/%s, current=%s", u.repoOwner, u.repoName, u.currentVersion)
 
-	// 解析当前版本并比较
-	currentVer, err := semver.ParseTolerant(u.currentVersion)
+	latest, err := u.detect()
 	if err != nil {
-		hasUpdate := latest.Version.String() != u.currentVersion
-		return UpdateInfo{
-			HasUpdate:      hasUpdate,
-			CurrentVersion: u.currentVersion,
-			LatestVersion:  latest.Version.String(),
-			ReleaseURL:     latest.URL,
-			ReleaseNotes:   latest.ReleaseNotes,
-			Error:          fmt.Sprintf("版本格式解析失败: %v", err),
-		}
+		updateLog.Error("%v", err)
+		return UpdateInfo{CurrentVersion: u.currentVersion, LatestVersion: u.currentVersion, Error: err.Error()}
 	}
+	updateLog.Info("检测到版本: %s, URL: %s", latest.Version.String(), latest.URL)
 
-	hasUpdate := latest.Version.GT(currentVer)
-	return UpdateInfo{
-		HasUpdate:      hasUpdate,
+	info := UpdateInfo{
+		HasUpdate:      u.newerThanCurrent(latest.Version.String()),
 		CurrentVersion: u.currentVersion,
 		LatestVersion:  latest.Version.String(),
 		ReleaseURL:     latest.URL,
-		ReleaseNotes:   latest.ReleaseNotes,
+		ReleaseNotes:   latest.Notes,
+	}
+	if !latest.PublishedAt.IsZero() {
+		info.PublishedAt = latest.PublishedAt.UnixMilli()
+	}
+	if p := u.client.LoadPending(); p != nil && p.Version == info.LatestVersion {
+		info.Downloaded = true
 	}
+	return info
+}
+
+// AutoCheck 启动后延迟检查更新，发现未跳过的新版本时推送 update:available 事件
+func (u *UpdateService) AutoCheck(ctx context.Context, skipVersion string) {
+	go func() {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(updateAutoDelay):
+		}
+		info := u.CheckForUpdate()
+		if info.Error != "" || !info.HasUpdate || info.LatestVersion == skipVersion {
+			return
+		}
+		wailsruntime.EventsEmit(ctx, "update:available", info)
+	}()
 }
 
 // emitProgress 发送更新进度事件
@@ -129,71 +170,74 @@ func (u *UpdateService) emitProgress(status, message string, percent int) {
 	wailsruntime.EventsEmit(u.ctx, "update:progress", progress)
 }
 
-// Update 执行更新（下载并替换当前可执行文件）
+// Update 下载最新版本并校验 SHA256，暂存后在退出或重启时替换可执行文件
 func (u *UpdateService) Update() error {
-	u.emitProgress("checking", "正在检查更新...", 0)
-
-	repo := fmt.Sprintf("%s/%s", u.repoOwner, u.repoName)
-
-	u.emitProgress("checking", "正在检测最新版本...", 10)
-	latest, found, err := selfupdate.DetectLatest(repo)
-	if err != nil {
-		u.emitProgress("error", fmt.Sprintf("检测更新失败: %v", err), 0)
-		return fmt.Errorf("检测更新失败: %w", err)
-	}
-
-	if !found {
-		u.emitProgress("error", "未找到更新", 0)
-		return fmt.Errorf("未找到更新")
+	if !u.mu.TryLock() {
+		return i18n.Errorf("error.update.downloading")
 	}
+	defer u.mu.Unlock()
 
-	currentVer, err := semver.ParseTolerant(u.currentVersion)
+	u.emitProgress("checking", i18n.T("update.checking"), 10)
+	latest, err := u.detect()
 	if err != nil {
-		u.emitProgress("error", fmt.Sprintf("版本格式解析失败: %v", err), 0)
-		return fmt.Errorf("版本格式解析失败: %w", err)
+		u.emitProgress("error", err.Error(), 0)
+		return err
 	}
-
-	if !latest.Version.GT(currentVer) {
-		u.emitProgress("error", "已是最新版本", 0)
-		return fmt.Errorf("已是最新版本")
+	version := latest.Version.String()
+	if !u.newerThanCurrent(version) {
+		u.emitProgress("error", i18n.T("error.update.latest"), 0)
+		return i18n.Errorf("error.update.latest")
 	}
-
-	exe, err := os.Executable()
-	if err != nil {
-		u.emitProgress("error", fmt.Sprintf("获取可执行文件路径失败: %v", err), 0)
-		return fmt.Errorf("获取可执行文件路径失败: %w", err)
+	if p := u.client.LoadPending(); p != nil && p.Version == version {
+		u.emitProgress("completed", i18n.T("update.ready", version), 100)
+		return nil
 	}
 
 	// 下载进度回调
 	progressCallback := func(downloaded, total int64) {
+		downloadedMB := float64(downloaded) / (1024 * 1024)
 		if total > 0 {
-			downloadPercent := float64(downloaded) / float64(total)
-			currentPercent := 30 + int(downloadPercent*40)
-			downloadedMB := float64(downloaded) / (1024 * 1024)
-			totalMB := float64(total) / (1024 * 1024)
 			u.emitProgress("downloading",
-				fmt.Sprintf("正在下载 %s... (%.2f MB / %.2f MB)",
-					latest.Version.String(), downloadedMB, totalMB),
-				currentPercent)
+				fmt.Sprintf("正在下载 %s... (%.2f MB / %.2f MB)", version, downloadedMB, float64(total)/(1024*1024)),
+				20+int(float64(downloaded)/float64(total)*70))
 		} else {
-			downloadedMB := float64(downloaded) / (1024 * 1024)
-			u.emitProgress("downloading",
-				fmt.Sprintf("正在下载 %s... (已下载 %.2f MB)",
-					latest.Version.String(), downloadedMB),
-				50)
+			u.emitProgress("downloading", i18n.T("update.downloaded_mb", version, downloadedMB), 50)
 		}
 	}
 
-	u.emitProgress("downloading", fmt.Sprintf("正在下载版本 %s...", latest.Version.String()), 30)
-
-	if err := selfupdate.UpdateToWithProcess(latest.AssetURL, exe, progressCallback); err != nil {
-		u.emitProgress("error", fmt.Sprintf("更新失败: %v", err), 0)
-		return fmt.Errorf("更新失败: %w", err)
+	u.emitProgress("downloading", i18n.T("update.downloading", version), 20)
+	ctx := u.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if _, err := u.client.Download(ctx, latest, progressCallback); err != nil {
+		u.emitProgress("error", i18n.T("update.failed", err), 0)
+		return i18n.Errorf("error.update.failed", err)
 	}
 
-	u.emitProgress("installing", "正在安装更新...", 90)
-	u.emitProgress("completed", fmt.Sprintf("更新完成！新版本 %s 已安装", latest.Version.String()), 100)
+	updateLog.Info("新版本 %s 已下载并通过校验", version)
+	u.emitProgress("completed", i18n.T("update.ready", version), 100)
+	return nil
+}
 
+// ApplyPending 用已下载的安装包替换当前可执行文件（退出或重启前调用）
+func (u *UpdateService) ApplyPending() error {
+	p := u.client.LoadPending()
+	if p == nil {
+		return nil
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("获取可执行文件路径失败: %w", err)
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		return err
+	}
+	updateLog.Info("安装新版本 %s: %s", p.Version, exe)
+	if err := u.client.Apply(p, exe); err != nil {
+		updateLog.Error("安装更新失败: %v", err)
+		return err
+	}
 	return nil
 }
 
@@ -209,6 +253,12 @@ func (u *UpdateService) RestartApplication() error {
 		return fmt.Errorf("获取绝对路径失败: %w", err)
 	}
 
+	u.emitProgress("installing", i18n.T("update.installing"), 95)
+	if err := u.ApplyPending(); err != nil {
+		u.emitProgress("error", i18n.T("update.install_failed", err), 0)
+		return err
+	}
+
 	updateLog.Info("准备重启应用: %s", exePath)
 
 	var cmd *exec.Cmd
@@ -219,7 +269,7 @@ func (u *UpdateService) RestartApplication() error {
 	case "darwin", "linux":
 		cmd = exec.Command("sh", "-c", fmt.Sprintf("sleep 2 && %s", exePath))
 	default:
-		return fmt.Errorf("不支持的操作系统: %s", runtime.GOOS)
+		return i18n.Errorf("error.update.os_unsupported", runtime.GOOS)
 	}
 
 	cmd.Dir = filepath.Dir(exePath)
@@ -0,0 +1,318 @@
+package services
+
+import (
+	"encoding/json"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+)
+
+const maxStockTags = 10
+
+// defaultWatchlistGroups 内置分组：自选包含全部自选股，持仓、观察初始为空
+func defaultWatchlistGroups(symbols []string) models.WatchlistGroups {
+	return models.WatchlistGroups{
+		Active: models.WatchlistGroupDefault,
+		Groups: []models.WatchlistGroup{
+			{ID: models.WatchlistGroupDefault, Name: "自选", Symbols: symbols, BuiltIn: true},
+			{ID: models.WatchlistGroupHolding, Name: "持仓", Symbols: []string{}, BuiltIn: true},
+			{ID: models.WatchlistGroupWatch, Name: "观察", Symbols: []string{}, BuiltIn: true},
+		},
+		Meta: map[string]models.StockMeta{},
+	}
+}
+
+// loadWatchlistGroups 加载自选分组，首次运行时由现有自选股迁移
+func (cs *ConfigService) loadWatchlistGroups() error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	symbols := make([]string, len(cs.watchlist))
+	for i, s := range cs.watchlist {
+		symbols[i] = s.Symbol
+	}
+
+	data, err := os.ReadFile(cs.groupsPath)
+	if os.IsNotExist(err) {
+		cs.groups = defaultWatchlistGroups(symbols)
+		return cs.saveWatchlistGroupsLocked()
+	}
+	if err != nil {
+		return err
+	}
+
+	var groups models.WatchlistGroups
+	if err := json.Unmarshal(data, &groups); err != nil {
+		return err
+	}
+	if groups.Meta == nil {
+		groups.Meta = map[string]models.StockMeta{}
+	}
+	cs.groups = groups
+	cs.reconcileGroupsLocked(symbols)
+	return nil
+}
+
+// reconcileGroupsLocked 使分组与自选股列表一致（watchlist.json 被外部修改时）
+func (cs *ConfigService) reconcileGroupsLocked(symbols []string) {
+	if cs.groupLocked(models.WatchlistGroupDefault) == nil {
+		cs.groups.Groups = append([]models.WatchlistGroup{{
+			ID: models.WatchlistGroupDefault, Name: "自选", BuiltIn: true,
+		}}, cs.groups.Groups...)
+	}
+	for i := range cs.groups.Groups {
+		g := &cs.groups.Groups[i]
+		g.Symbols = slices.DeleteFunc(g.Symbols, func(s string) bool {
+			return !slices.Contains(symbols, s)
+		})
+		if g.ID == models.WatchlistGroupDefault {
+			for _, s := range symbols {
+				if !slices.Contains(g.Symbols, s) {
+					g.Symbols = append(g.Symbols, s)
+				}
+			}
+		}
+	}
+	if cs.groupLocked(cs.groups.Active) == nil {
+		cs.groups.Active = models.WatchlistGroupDefault
+	}
+}
+
+// saveWatchlistGroupsLocked 保存自选分组(需要已持有锁)
+func (cs *ConfigService) saveWatchlistGroupsLocked() error {
+	data, err := json.MarshalIndent(cs.groups, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileTracked(cs.groupsPath, data, 0644)
+}
+
+// groupLocked 按 ID 查找分组(需要已持有锁)
+func (cs *ConfigService) groupLocked(id string) *models.WatchlistGroup {
+	for i := range cs.groups.Groups {
+		if cs.groups.Groups[i].ID == id {
+			return &cs.groups.Groups[i]
+		}
+	}
+	return nil
+}
+
+// inWatchlistLocked 是否在自选股列表中(需要已持有锁)
+func (cs *ConfigService) inWatchlistLocked(symbol string) bool {
+	return slices.ContainsFunc(cs.watchlist, func(s models.Stock) bool {
+		return s.Symbol == symbol
+	})
+}
+
+// removeFromGroupsLocked 从所有分组移除股票并清除标签备注(需要已持有锁)
+func (cs *ConfigService) removeFromGroupsLocked(symbol string) {
+	for i := range cs.groups.Groups {
+		g := &cs.groups.Groups[i]
+		g.Symbols = slices.DeleteFunc(g.Symbols, func(s string) bool { return s == symbol })
+	}
+	delete(cs.groups.Meta, symbol)
+}
+
+// GetWatchlistGroups 获取自选分组（返回副本）
+func (cs *ConfigService) GetWatchlistGroups() models.WatchlistGroups {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+
+	result := models.WatchlistGroups{
+		Active: cs.groups.Active,
+		Groups: make([]models.WatchlistGroup, len(cs.groups.Groups)),
+		Meta:   make(map[string]models.StockMeta, len(cs.groups.Meta)),
+	}
+	for i, g := range cs.groups.Groups {
+		g.Symbols = slices.Clone(g.Symbols)
+		result.Groups[i] = g
+	}
+	for symbol, meta := range cs.groups.Meta {
+		meta.Tags = slices.Clone(meta.Tags)
+		result.Meta[symbol] = meta
+	}
+	return result
+}
+
+// GetGroupStocks 按分组顺序获取股票列表
+func (cs *ConfigService) GetGroupStocks(groupID string) []models.Stock {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+
+	g := cs.groupLocked(groupID)
+	if g == nil {
+		return []models.Stock{}
+	}
+	stocks := make([]models.Stock, 0, len(g.Symbols))
+	for _, symbol := range g.Symbols {
+		for _, s := range cs.watchlist {
+			if s.Symbol == symbol {
+				stocks = append(stocks, s)
+				break
+			}
+		}
+	}
+	return stocks
+}
+
+// GetActiveGroupSymbols 获取当前分组的股票代码（推送订阅用）
+func (cs *ConfigService) GetActiveGroupSymbols() []string {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	if g := cs.groupLocked(cs.groups.Active); g != nil {
+		return slices.Clone(g.Symbols)
+	}
+	return []string{}
+}
+
+// SetActiveGroup 切换当前分组
+func (cs *ConfigService) SetActiveGroup(groupID string) error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if cs.groupLocked(groupID) == nil {
+		return i18n.Errorf("error.group.not_found", groupID)
+	}
+	cs.groups.Active = groupID
+	return cs.saveWatchlistGroupsLocked()
+}
+
+// CreateWatchlistGroup 新建自定义分组
+func (cs *ConfigService) CreateWatchlistGroup(name string) (models.WatchlistGroup, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return models.WatchlistGroup{}, i18n.Errorf("error.group.name_required")
+	}
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	for _, g := range cs.groups.Groups {
+		if g.Name == name {
+			return models.WatchlistGroup{}, i18n.Errorf("error.group.exists", name)
+		}
+	}
+	group := models.WatchlistGroup{ID: uuid.New().String(), Name: name, Symbols: []string{}}
+	cs.groups.Groups = append(cs.groups.Groups, group)
+	return group, cs.saveWatchlistGroupsLocked()
+}
+
+// RenameWatchlistGroup 重命名分组
+func (cs *ConfigService) RenameWatchlistGroup(groupID, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return i18n.Errorf("error.group.name_required")
+	}
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	g := cs.groupLocked(groupID)
+	if g == nil {
+		return i18n.Errorf("error.group.not_found", groupID)
+	}
+	g.Name = name
+	return cs.saveWatchlistGroupsLocked()
+}
+
+// DeleteWatchlistGroup 删除自定义分组（股票仍保留在自选中）
+func (cs *ConfigService) DeleteWatchlistGroup(groupID string) error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	g := cs.groupLocked(groupID)
+	if g == nil {
+		return i18n.Errorf("error.group.not_found", groupID)
+	}
+	if g.BuiltIn {
+		return i18n.Errorf("error.group.builtin")
+	}
+	cs.groups.Groups = slices.DeleteFunc(cs.groups.Groups, func(g models.WatchlistGroup) bool {
+		return g.ID == groupID
+	})
+	if cs.groups.Active == groupID {
+		cs.groups.Active = models.WatchlistGroupDefault
+	}
+	return cs.saveWatchlistGroupsLocked()
+}
+
+// AddToGroup 将自选股加入分组
+func (cs *ConfigService) AddToGroup(groupID, symbol string) error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	g := cs.groupLocked(groupID)
+	if g == nil {
+		return i18n.Errorf("error.group.not_found", groupID)
+	}
+	if !cs.inWatchlistLocked(symbol) {
+		return i18n.Errorf("error.watchlist.not_added", symbol)
+	}
+	if slices.Contains(g.Symbols, symbol) {
+		return nil
+	}
+	g.Symbols = append(g.Symbols, symbol)
+	return cs.saveWatchlistGroupsLocked()
+}
+
+// RemoveFromGroup 将股票移出分组（"自选"分组请使用 RemoveFromWatchlist）
+func (cs *ConfigService) RemoveFromGroup(groupID, symbol string) error {
+	if groupID == models.WatchlistGroupDefault {
+		return i18n.Errorf("error.group.remove_watchlist")
+	}
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	g := cs.groupLocked(groupID)
+	if g == nil {
+		return i18n.Errorf("error.group.not_found", groupID)
+	}
+	g.Symbols = slices.DeleteFunc(g.Symbols, func(s string) bool { return s == symbol })
+	return cs.saveWatchlistGroupsLocked()
+}
+
+// ReorderGroup 保存拖拽后的分组顺序，symbols 必须与分组现有股票一致
+func (cs *ConfigService) ReorderGroup(groupID string, symbols []string) error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	g := cs.groupLocked(groupID)
+	if g == nil {
+		return i18n.Errorf("error.group.not_found", groupID)
+	}
+
+	current := slices.Clone(g.Symbols)
+	next := slices.Clone(symbols)
+	slices.Sort(current)
+	slices.Sort(next)
+	if !slices.Equal(current, next) {
+		return i18n.Errorf("error.group.order_mismatch")
+	}
+	g.Symbols = slices.Clone(symbols)
+	return cs.saveWatchlistGroupsLocked()
+}
+
+// SetStockMeta 设置自选股标签与备注，均为空时清除
+func (cs *ConfigService) SetStockMeta(symbol string, tags []string, note string) error {
+	var cleaned []string
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag != "" && !slices.Contains(cleaned, tag) {
+			cleaned = append(cleaned, tag)
+		}
+	}
+	if len(cleaned) > maxStockTags {
+		return i18n.Errorf("error.watchlist.tag_limit", maxStockTags)
+	}
+	note = strings.TrimSpace(note)
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if !cs.inWatchlistLocked(symbol) {
+		return i18n.Errorf("error.watchlist.not_added", symbol)
+	}
+	if len(cleaned) == 0 && note == "" {
+		delete(cs.groups.Meta, symbol)
+	} else {
+		cs.groups.Meta[symbol] = models.StockMeta{Tags: cleaned, Note: note}
+	}
+	return cs.saveWatchlistGroupsLocked()
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// TestWatchlistGroups 测试自选分组、排序与标签
+func TestWatchlistGroups(t *testing.T) {
+	dir := t.TempDir()
+	cs, err := NewConfigService(dir)
+	if err != nil {
+		t.Fatalf("NewConfigService 失败: %v", err)
+	}
+	for _, symbol := range []string{"sh600519", "sz000001", "sz000002"} {
+		if err := cs.AddToWatchlist(models.Stock{Symbol: symbol}); err != nil {
+			t.Fatalf("AddToWatchlist 失败: %v", err)
+		}
+	}
+
+	if err := cs.AddToGroup(models.WatchlistGroupHolding, "sz000001"); err != nil {
+		t.Fatalf("AddToGroup 失败: %v", err)
+	}
+	if err := cs.AddToGroup(models.WatchlistGroupHolding, "sh000001"); err == nil {
+		t.Error("未加入自选的股票不应能加入分组")
+	}
+	if err := cs.ReorderGroup(models.WatchlistGroupDefault, []string{"sz000002", "sh600519"}); err == nil {
+		t.Error("排序列表缺少股票时应报错")
+	}
+	if err := cs.ReorderGroup(models.WatchlistGroupDefault, []string{"sz000002", "sh600519", "sz000001"}); err != nil {
+		t.Fatalf("ReorderGroup 失败: %v", err)
+	}
+	if err := cs.SetStockMeta("sz000001", []string{"银行", " 银行 ", "高股息"}, "底仓"); err != nil {
+		t.Fatalf("SetStockMeta 失败: %v", err)
+	}
+	if err := cs.SetActiveGroup(models.WatchlistGroupHolding); err != nil {
+		t.Fatalf("SetActiveGroup 失败: %v", err)
+	}
+	if err := cs.DeleteWatchlistGroup(models.WatchlistGroupHolding); err == nil {
+		t.Error("内置分组不应能删除")
+	}
+
+	// 重新加载后持久化内容一致
+	cs, err = NewConfigService(dir)
+	if err != nil {
+		t.Fatalf("重新加载失败: %v", err)
+	}
+	groups := cs.GetWatchlistGroups()
+	if groups.Active != models.WatchlistGroupHolding {
+		t.Errorf("Active = %s, want holding", groups.Active)
+	}
+	if got := cs.GetGroupStocks(models.WatchlistGroupDefault); len(got) != 3 || got[0].Symbol != "sz000002" {
+		t.Errorf("自选分组顺序未保存: %+v", got)
+	}
+	if meta := groups.Meta["sz000001"]; len(meta.Tags) != 2 || meta.Note != "底仓" {
+		t.Errorf("标签备注 = %+v", meta)
+	}
+
+	// 删除自选后从所有分组移除
+	if err := cs.RemoveFromWatchlist("sz000001"); err != nil {
+		t.Fatalf("RemoveFromWatchlist 失败: %v", err)
+	}
+	if got := cs.GetActiveGroupSymbols(); len(got) != 0 {
+		t.Errorf("持仓分组应为空, got %v", got)
+	}
+	if _, ok := cs.GetWatchlistGroups().Meta["sz000001"]; ok {
+		t.Error("删除自选后标签备注应清除")
+	}
+}
+
+// TestWatchlistSaveFailureRollback 测试自选股保存失败时内存中的列表与分组保持不变
+func TestWatchlistSaveFailureRollback(t *testing.T) {
+	dir := t.TempDir()
+	cs, err := NewConfigService(dir)
+	if err != nil {
+		t.Fatalf("NewConfigService 失败: %v", err)
+	}
+	if err := cs.AddToWatchlist(models.Stock{Symbol: "sh600519"}); err != nil {
+		t.Fatalf("AddToWatchlist 失败: %v", err)
+	}
+
+	// 目标路径是目录，写入必然失败
+	cs.watchlistPath = dir
+	if err := cs.AddToWatchlist(models.Stock{Symbol: "sz000001"}); err == nil {
+		t.Fatal("保存失败时应返回错误")
+	}
+	if err := cs.RemoveFromWatchlist("sh600519"); err == nil {
+		t.Fatal("保存失败时应返回错误")
+	}
+	if wl := cs.GetWatchlist(); len(wl) != 1 || wl[0].Symbol != "sh600519" {
+		t.Errorf("保存失败后自选股被修改: %+v", wl)
+	}
+	if stocks := cs.GetGroupStocks(models.WatchlistGroupDefault); len(stocks) != 1 || stocks[0].Symbol != "sh600519" {
+		t.Errorf("保存失败后分组被修改: %+v", stocks)
+	}
+}
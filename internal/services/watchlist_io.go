@@ -0,0 +1,237 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+)
+
+// 自选股导入导出格式
+const (
+	WatchlistFormatAuto = "auto" // 按扩展名与内容自动识别（仅导入）
+	WatchlistFormatTxt  = "txt"  // 纯文本，每行一个代码
+	WatchlistFormatCSV  = "csv"  // CSV，含代码、名称、标签、备注
+	WatchlistFormatTHS  = "ths"  // 同花顺自选股导出（SH600519 / 600519.SH）
+	WatchlistFormatTDX  = "tdx"  // 通达信 zxg.blk（市场位 + 6位代码，1沪 0深 2北）
+)
+
+// WatchlistFormatExt 各格式导出文件扩展名
+var WatchlistFormatExt = map[string]string{
+	WatchlistFormatTxt: ".txt",
+	WatchlistFormatCSV: ".csv",
+	WatchlistFormatTHS: ".txt",
+	WatchlistFormatTDX: ".blk",
+}
+
+// ParseWatchlistFile 解析自选股文件，返回去重后的股票代码（sh600519 形式）与无法识别的条目
+func ParseWatchlistFile(filename string, data []byte, format string) (symbols, invalid []string) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if format == "" || format == WatchlistFormatAuto {
+		format = detectWatchlistFormat(filename)
+	}
+
+	var tokens []string
+	if format == WatchlistFormatCSV {
+		tokens = csvCodeColumn(data)
+	} else {
+		tokens = strings.FieldsFunc(string(data), func(r rune) bool {
+			return r == '\n' || r == '\r' || r == '\t' || r == ',' || r == ';' || r == ' '
+		})
+	}
+
+	for _, token := range tokens {
+		token = strings.Trim(token, `"' `)
+		if token == "" {
+			continue
+		}
+		symbol, ok := normalizeImportCode(token, format == WatchlistFormatTDX)
+		if !ok {
+			// 同花顺、文本导出常带名称、价格等列，仅记录像代码的无效条目
+			if looksLikeCode(token) {
+				invalid = append(invalid, token)
+			}
+			continue
+		}
+		if !slices.Contains(symbols, symbol) {
+			symbols = append(symbols, symbol)
+		}
+	}
+	return symbols, invalid
+}
+
+// detectWatchlistFormat 按扩展名识别格式
+func detectWatchlistFormat(filename string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".blk":
+		return WatchlistFormatTDX
+	case ".csv":
+		return WatchlistFormatCSV
+	default:
+		return WatchlistFormatTxt
+	}
+}
+
+// csvCodeColumn 读取 CSV 的代码列（表头含 代码/code/symbol 时取该列，否则取第一列）
+func csvCodeColumn(data []byte) []string {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	records, err := r.ReadAll()
+	if err != nil || len(records) == 0 {
+		return nil
+	}
+
+	col := 0
+	for i, h := range records[0] {
+		switch strings.ToLower(strings.TrimSpace(h)) {
+		case "代码", "股票代码", "证券代码", "code", "symbol":
+			col = i
+		}
+	}
+
+	var codes []string
+	for _, rec := range records {
+		if col < len(rec) {
+			codes = append(codes, rec[col])
+		}
+	}
+	return codes
+}
+
+// normalizeImportCode 统一各软件的代码写法为 sh600519 形式
+// 支持 600519、sh600519、SH600519、600519.SH，以及通达信的 1600519
+func normalizeImportCode(token string, tdx bool) (string, bool) {
+	code := strings.ToLower(token)
+
+	if len(code) == 7 && isDigits(code) && (tdx || strings.ContainsRune("012", rune(code[0]))) {
+		market := map[byte]string{'0': "sz", '1': "sh", '2': "bj"}[code[0]]
+		if market == "" {
+			return "", false
+		}
+		return market + code[1:], true
+	}
+
+	if prefix, rest, ok := strings.Cut(code, "."); ok {
+		// 600519.sh
+		code = rest + prefix
+	}
+	for _, market := range []string{"sh", "sz", "bj"} {
+		if rest, ok := strings.CutPrefix(code, market); ok && len(rest) == 6 && isDigits(rest) {
+			return market + rest, true
+		}
+	}
+
+	if len(code) == 6 && isDigits(code) {
+		if market := inferMarket(code); market != "" {
+			return market + code, true
+		}
+	}
+	return "", false
+}
+
+// inferMarket 根据6位代码推断交易所
+func inferMarket(code string) string {
+	if isBSECode(code) {
+		return "bj"
+	}
+	switch code[0] {
+	case '6', '9', '5':
+		return "sh"
+	case '0', '2', '3', '1':
+		return "sz"
+	}
+	return ""
+}
+
+// looksLikeCode 是否像股票代码（用于区分无效代码与名称等其他列）
+func looksLikeCode(token string) bool {
+	digits := 0
+	for _, r := range token {
+		if r >= '0' && r <= '9' {
+			digits++
+		}
+	}
+	return digits >= 5 && !strings.Contains(token, ":")
+}
+
+// FormatWatchlistFile 按格式导出自选股
+func FormatWatchlistFile(stocks []models.Stock, meta map[string]models.StockMeta, format string) ([]byte, error) {
+	var buf bytes.Buffer
+	switch format {
+	case WatchlistFormatTxt:
+		for _, s := range stocks {
+			buf.WriteString(s.Symbol + "\n")
+		}
+	case WatchlistFormatTHS:
+		for _, s := range stocks {
+			buf.WriteString(strings.ToUpper(s.Symbol) + "\r\n")
+		}
+	case WatchlistFormatTDX:
+		prefix := map[string]string{"sh": "1", "sz": "0", "bj": "2"}
+		for _, s := range stocks {
+			if len(s.Symbol) != 8 || prefix[s.Symbol[:2]] == "" {
+				continue
+			}
+			buf.WriteString(prefix[s.Symbol[:2]] + s.Symbol[2:] + "\r\n")
+		}
+	case WatchlistFormatCSV:
+		// 带 BOM，Excel 打开中文不乱码
+		buf.WriteString("\xef\xbb\xbf")
+		w := csv.NewWriter(&buf)
+		w.Write([]string{"代码", "名称", "标签", "备注"})
+		for _, s := range stocks {
+			m := meta[s.Symbol]
+			w.Write([]string{s.Symbol, s.Name, strings.Join(m.Tags, "|"), m.Note})
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, i18n.Errorf("error.export.format_unsupported", format)
+	}
+	return buf.Bytes(), nil
+}
+
+// ImportWatchlist 导入股票代码到自选，并加入指定分组
+// 名称从本地股票列表补全，列表中没有的代码（如ETF、指数）以代码作为名称
+func (cs *ConfigService) ImportWatchlist(groupID string, symbols []string) (models.WatchlistImportResult, error) {
+	result := models.WatchlistImportResult{Imported: []string{}, Skipped: []string{}}
+
+	cs.mu.RLock()
+	hasGroup := cs.groupLocked(groupID) != nil
+	cs.mu.RUnlock()
+	if !hasGroup {
+		return result, i18n.Errorf("error.group.not_found", groupID)
+	}
+
+	for _, symbol := range symbols {
+		cs.mu.RLock()
+		exists := cs.inWatchlistLocked(symbol)
+		cs.mu.RUnlock()
+
+		if exists {
+			result.Skipped = append(result.Skipped, symbol)
+		} else {
+			stock := models.Stock{Symbol: symbol, Name: symbol}
+			if info, ok := cs.search.Lookup(symbol); ok {
+				stock.Name = info.Name
+				stock.Sector = info.Industry
+			}
+			if err := cs.AddToWatchlist(stock); err != nil {
+				return result, err
+			}
+			result.Imported = append(result.Imported, symbol)
+		}
+		if groupID != models.WatchlistGroupDefault {
+			if err := cs.AddToGroup(groupID, symbol); err != nil {
+				return result, err
+			}
+		}
+	}
+	return result, nil
+}
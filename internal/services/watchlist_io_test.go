@@ -0,0 +1,42 @@
+package services
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// TestParseWatchlistFile 测试文本、CSV、同花顺、通达信格式解析
+func TestParseWatchlistFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		data     string
+		want     []string
+	}{
+		{"txt", "list.txt", "600519\nsz000001\n600519.SH\n", []string{"sh600519", "sz000001"}},
+		{"ths", "ths.txt", "代码\t名称\r\nSH600519\t贵州茅台\r\nSZ300750\t宁德时代\r\n", []string{"sh600519", "sz300750"}},
+		{"tdx", "zxg.blk", "\r\n1600519\r\n0000001\r\n2430047\r\n", []string{"sh600519", "sz000001", "bj430047"}},
+		{"csv", "list.csv", "\xef\xbb\xbf名称,代码\n贵州茅台,600519\n平安银行,000001\n", []string{"sh600519", "sz000001"}},
+	}
+	for _, tt := range tests {
+		got, invalid := ParseWatchlistFile(tt.filename, []byte(tt.data), WatchlistFormatAuto)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: got %v, want %v (invalid %v)", tt.name, got, tt.want, invalid)
+		}
+	}
+
+	// 导出再导入保持一致
+	stocks := []models.Stock{{Symbol: "sh600519", Name: "贵州茅台"}, {Symbol: "sz000001", Name: "平安银行"}}
+	for _, format := range []string{WatchlistFormatTxt, WatchlistFormatCSV, WatchlistFormatTHS, WatchlistFormatTDX} {
+		data, err := FormatWatchlistFile(stocks, nil, format)
+		if err != nil {
+			t.Fatalf("%s 导出失败: %v", format, err)
+		}
+		got, _ := ParseWatchlistFile("export"+WatchlistFormatExt[format], data, format)
+		if !slices.Equal(got, []string{"sh600519", "sz000001"}) {
+			t.Errorf("%s 往返结果 = %v", format, got)
+		}
+	}
+}
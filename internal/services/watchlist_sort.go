@@ -0,0 +1,70 @@
+package services
+
+import (
+	"sort"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+)
+
+// 排序方向
+const (
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+// watchlistSortKeys 自选股可排序字段
+var watchlistSortKeys = map[string]func(models.Stock) float64{
+	"changePercent": func(s models.Stock) float64 { return s.ChangePercent },
+	"price":         func(s models.Stock) float64 { return s.Price },
+	"change":        func(s models.Stock) float64 { return s.Change },
+	"volume":        func(s models.Stock) float64 { return float64(s.Volume) },
+	"amount":        func(s models.Stock) float64 { return s.Amount },
+}
+
+// ValidateWatchlistSort 校验排序配置，字段为空表示保持自选顺序
+func ValidateWatchlistSort(cfg models.WatchlistSortConfig) error {
+	if cfg.Field != "" {
+		if _, ok := watchlistSortKeys[cfg.Field]; !ok {
+			return i18n.Errorf("error.sort.field_unsupported", cfg.Field)
+		}
+		if cfg.Order != SortAsc && cfg.Order != SortDesc {
+			return i18n.Errorf("error.sort.order_unsupported", cfg.Order)
+		}
+	}
+	if cfg.TopN < 0 {
+		return i18n.Errorf("error.sort.topn_negative")
+	}
+	return nil
+}
+
+// sortWatchlist 按配置排序并截取前N只
+// 数值相同的股票保持上一轮的相对顺序（prevRank），避免每次推送行间跳动
+func sortWatchlist(stocks []models.Stock, cfg models.WatchlistSortConfig, prevRank map[string]int) []models.Stock {
+	result := make([]models.Stock, len(stocks))
+	copy(result, stocks)
+
+	if key, ok := watchlistSortKeys[cfg.Field]; ok {
+		rank := func(s models.Stock) int {
+			if r, ok := prevRank[s.Symbol]; ok {
+				return r
+			}
+			return len(prevRank) // 新加入的排在同值股票之后
+		}
+		sort.SliceStable(result, func(i, j int) bool {
+			vi, vj := key(result[i]), key(result[j])
+			if vi != vj {
+				if cfg.Order == SortAsc {
+					return vi < vj
+				}
+				return vi > vj
+			}
+			return rank(result[i]) < rank(result[j])
+		})
+	}
+
+	if cfg.TopN > 0 && len(result) > cfg.TopN {
+		result = result[:cfg.TopN]
+	}
+	return result
+}
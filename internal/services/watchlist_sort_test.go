@@ -0,0 +1,42 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// TestSortWatchlist 测试自选股后端排序：同值保持上一轮顺序、TopN 截取
+func TestSortWatchlist(t *testing.T) {
+	stocks := []models.Stock{
+		{Symbol: "a", ChangePercent: 1},
+		{Symbol: "b", ChangePercent: 3},
+		{Symbol: "c", ChangePercent: 1},
+		{Symbol: "d", ChangePercent: -2},
+	}
+	cfg := models.WatchlistSortConfig{Field: "changePercent", Order: SortDesc}
+
+	// 上一轮 c 排在 a 前面，同值时应保持
+	prevRank := map[string]int{"b": 0, "c": 1, "a": 2, "d": 3}
+	got := sortWatchlist(stocks, cfg, prevRank)
+	want := []string{"b", "c", "a", "d"}
+	for i, s := range got {
+		if s.Symbol != want[i] {
+			t.Fatalf("got[%d] = %s, want %v", i, s.Symbol, want)
+		}
+	}
+
+	cfg.Order = SortAsc
+	cfg.TopN = 2
+	got = sortWatchlist(stocks, cfg, nil)
+	if len(got) != 2 || got[0].Symbol != "d" || got[1].Symbol != "a" {
+		t.Errorf("asc top2 = %+v, want d, a", got)
+	}
+	if stocks[0].Symbol != "a" {
+		t.Error("排序不应修改原切片")
+	}
+
+	if err := ValidateWatchlistSort(models.WatchlistSortConfig{Field: "pe", Order: SortAsc}); err == nil {
+		t.Error("不支持的字段应返回错误")
+	}
+}
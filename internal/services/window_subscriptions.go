@@ -0,0 +1,397 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/i18n"
+	"github.com/run-bigpig/jcp/internal/pkg/supervisor"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// 分离窗口订阅事件
+const (
+	EventWindowSubscribe   = "market:window:subscribe"
+	EventWindowUnsubscribe = "market:window:unsubscribe"
+)
+
+// 分离窗口类型
+const (
+	WindowKindKLine     = "kline"
+	WindowKindOrderBook = "orderbook"
+)
+
+// maxDetachedWindows 同时打开的分离窗口上限，避免上游请求成倍增加
+const maxDetachedWindows = 8
+
+// WindowSubscription 分离窗口（独立的K线图、盘口窗口）的订阅，与主界面订阅互不影响
+type WindowSubscription struct {
+	ID     string `json:"id"`
+	Kind   string `json:"kind"`
+	Code   string `json:"code"`
+	Name   string `json:"name,omitempty"`   // 股票名称，用于窗口标题
+	Period string `json:"period,omitempty"` // K线周期，默认 1d
+}
+
+// Validate 校验订阅并补全默认周期
+func (s *WindowSubscription) Validate() error {
+	switch {
+	case s.ID == "" || s.Code == "":
+		return i18n.Errorf("error.window.id_code_required")
+	case s.Kind != WindowKindKLine && s.Kind != WindowKindOrderBook:
+		return i18n.Errorf("error.window.kind_unsupported", s.Kind)
+	}
+	if s.Kind == WindowKindKLine && s.Period == "" {
+		s.Period = "1d"
+	}
+	return nil
+}
+
+// windowState 分离窗口订阅及其增量推送状态
+// ctx 在窗口重新订阅或取消订阅时取消，中断该窗口进行中的请求
+type windowState struct {
+	sub    WindowSubscription
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	lastHash      string           // 盘口 diff
+	lastKLineTime int64            // 分时K线增量
+	lastKLineBar  models.KLineData // 日/周/月K线增量基准
+	klineSeq      uint64
+}
+
+// WindowEvent 分离窗口的事件名：<事件>@<窗口ID>
+func WindowEvent(event, windowID string) string {
+	return event + "@" + windowID
+}
+
+// WindowPusher 分离窗口行情推送
+// 每个分离窗口是独立的进程与原生窗口，进程内的推送服务只服务本窗口的订阅，按窗口 ID 路由事件
+type WindowPusher struct {
+	marketService *MarketService
+	drawings      func(code, period string) []models.ChartDrawing
+	emit          func(event string, data any)
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	windows map[string]*windowState
+}
+
+// NewWindowPusher 创建分离窗口推送服务，drawings 为空时K线不附带画线
+func NewWindowPusher(marketService *MarketService, drawings func(code, period string) []models.ChartDrawing) *WindowPusher {
+	return &WindowPusher{
+		marketService: marketService,
+		drawings:      drawings,
+		ctx:           context.Background(),
+		windows:       make(map[string]*windowState),
+	}
+}
+
+// Start 监听前端订阅事件并启动推送循环
+func (p *WindowPusher) Start(ctx context.Context) {
+	p.mu.Lock()
+	p.ctx, p.cancel = context.WithCancel(ctx)
+	p.emit = func(event string, data any) { runtime.EventsEmit(ctx, event, data) }
+	p.mu.Unlock()
+
+	runtime.EventsOn(ctx, EventWindowSubscribe, func(data ...any) {
+		if len(data) == 0 {
+			return
+		}
+		if sub, ok := parseWindowSubscription(data[0]); ok {
+			if err := p.SubscribeWindow(sub); err != nil {
+				pusherLog.Warn("分离窗口订阅失败: %v", err)
+			}
+		}
+	})
+	runtime.EventsOn(ctx, EventWindowUnsubscribe, func(data ...any) {
+		if len(data) > 0 {
+			if id, ok := data[0].(string); ok {
+				p.UnsubscribeWindow(id)
+			}
+		}
+	})
+	supervisor.Go(p.ctx, "window-pusher", p.run)
+}
+
+// Stop 停止推送并取消全部窗口订阅
+func (p *WindowPusher) Stop() {
+	p.mu.Lock()
+	cancel := p.cancel
+	ctx := p.ctx
+	for id, w := range p.windows {
+		w.cancel()
+		delete(p.windows, id)
+	}
+	p.mu.Unlock()
+	if cancel == nil {
+		return
+	}
+	cancel()
+	runtime.EventsOff(ctx, EventWindowSubscribe, EventWindowUnsubscribe)
+}
+
+// SubscribeWindow 新建或更新分离窗口订阅，并立即推送一次
+// 同一窗口重新订阅时取消上一次订阅进行中的请求
+func (p *WindowPusher) SubscribeWindow(sub WindowSubscription) error {
+	if err := sub.Validate(); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	old, exists := p.windows[sub.ID]
+	if !exists && len(p.windows) >= maxDetachedWindows {
+		p.mu.Unlock()
+		return i18n.Errorf("error.window.limit", maxDetachedWindows)
+	}
+	if exists {
+		old.cancel()
+	}
+	w := &windowState{sub: sub}
+	w.ctx, w.cancel = context.WithCancel(p.ctx)
+	p.windows[sub.ID] = w
+	p.mu.Unlock()
+
+	go safeCall(func() {
+		if sub.Kind == WindowKindKLine {
+			p.pushWindowKLine(w, 240)
+		} else {
+			p.pushWindowOrderBook(w)
+		}
+	})
+	return nil
+}
+
+// UnsubscribeWindow 关闭分离窗口时取消订阅，并中断该窗口进行中的请求
+func (p *WindowPusher) UnsubscribeWindow(id string) {
+	p.mu.Lock()
+	w, ok := p.windows[id]
+	delete(p.windows, id)
+	p.mu.Unlock()
+	if ok {
+		w.cancel()
+	}
+}
+
+// windowStates 获取指定类型的分离窗口
+func (p *WindowPusher) windowStates(kind string) []*windowState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var states []*windowState
+	for _, w := range p.windows {
+		if w.sub.Kind == kind {
+			states = append(states, w)
+		}
+	}
+	return states
+}
+
+// run 推送循环：交易时段高频推送盘口与分时，日/周/月K线低频增量刷新，收盘后降频
+func (p *WindowPusher) run(ctx context.Context) {
+	fastTicker := time.NewTicker(tickerFast)
+	normalTicker := time.NewTicker(tickerNormal)
+	klineDayTicker := time.NewTicker(tickerKLineDay)
+	defer fastTicker.Stop()
+	defer normalTicker.Stop()
+	defer klineDayTicker.Stop()
+
+	var normalCount int
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-fastTicker.C:
+			if p.marketService.GetMarketStatus().Status == "trading" {
+				p.pushWindowOrderBooks(ctx)
+			}
+		case <-normalTicker.C:
+			normalCount++
+			switch p.marketService.GetMarketStatus().Status {
+			case "trading":
+				p.pushWindowKLineMinute()
+			case "pre_market":
+				if normalCount%3 == 0 {
+					p.pushWindowOrderBooks(ctx)
+				}
+			case "lunch_break":
+				// 午休：行情不变，不推送
+			default:
+				if normalCount%10 == 0 {
+					p.pushWindowOrderBooks(ctx)
+				}
+			}
+		case <-klineDayTicker.C:
+			if p.marketService.GetMarketStatus().Status == "trading" {
+				p.pushWindowKLineDay()
+			}
+		}
+	}
+}
+
+// pushWindowOrderBooks 推送各分离窗口的盘口（相同股票只请求一次）
+func (p *WindowPusher) pushWindowOrderBooks(ctx context.Context) {
+	byCode := make(map[string][]*windowState)
+	for _, w := range p.windowStates(WindowKindOrderBook) {
+		byCode[w.sub.Code] = append(byCode[w.sub.Code], w)
+	}
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	var wg sync.WaitGroup
+	for code, states := range byCode {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			safeCall(func() {
+				orderBook, err := p.marketService.GetRealOrderBook(ctx, code)
+				if err != nil {
+					return
+				}
+				for _, w := range states {
+					p.emitWindowOrderBook(w, orderBook)
+				}
+			})
+		}()
+	}
+	wg.Wait()
+}
+
+// pushWindowOrderBook 推送单个分离窗口的盘口
+func (p *WindowPusher) pushWindowOrderBook(w *windowState) {
+	orderBook, err := p.marketService.GetRealOrderBook(w.ctx, w.sub.Code)
+	if err != nil {
+		return
+	}
+	p.emitWindowOrderBook(w, orderBook)
+}
+
+// emitWindowOrderBook 盘口有变化时推送到分离窗口，已取消订阅的窗口不再推送
+func (p *WindowPusher) emitWindowOrderBook(w *windowState, orderBook models.OrderBook) {
+	hash := orderBookHash(orderBook)
+	p.mu.Lock()
+	changed := w.lastHash != hash && w.ctx.Err() == nil
+	if changed {
+		w.lastHash = hash
+	}
+	p.mu.Unlock()
+	if changed {
+		p.emit(WindowEvent(EventOrderBookUpdate, w.sub.ID), orderBook)
+	}
+}
+
+// pushWindowKLineMinute 推送分离窗口的分时K线（增量，仅推送最新1根）
+func (p *WindowPusher) pushWindowKLineMinute() {
+	for _, w := range p.windowStates(WindowKindKLine) {
+		if w.sub.Period != "1m" {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(w.ctx, 8*time.Second)
+		klines, err := p.marketService.GetKLineData(ctx, w.sub.Code, "1m", 5)
+		cancel()
+		if err != nil || len(klines) == 0 {
+			continue
+		}
+		latest := klines[len(klines)-1]
+		latestTime := parseKLineTime(latest.Time)
+
+		p.mu.Lock()
+		changed := w.lastKLineTime != latestTime && w.ctx.Err() == nil
+		w.lastKLineTime = latestTime
+		p.mu.Unlock()
+		if changed {
+			p.emit(WindowEvent(EventKLineUpdate, w.sub.ID), map[string]any{
+				"code":        w.sub.Code,
+				"period":      "1m",
+				"data":        []models.KLineData{latest},
+				"incremental": true,
+			})
+		}
+	}
+}
+
+// pushWindowKLineDay 增量推送分离窗口的日/周/月K线
+func (p *WindowPusher) pushWindowKLineDay() {
+	for _, w := range p.windowStates(WindowKindKLine) {
+		if w.sub.Period == "1m" {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(w.ctx, 8*time.Second)
+		klines, err := p.marketService.GetKLineData(ctx, w.sub.Code, w.sub.Period, klineDeltaBars)
+		cancel()
+		if err != nil {
+			continue
+		}
+		p.mu.Lock()
+		delta, full := klineDelta(w.lastKLineBar, klines)
+		if full || len(delta) == 0 || w.ctx.Err() != nil {
+			p.mu.Unlock()
+			if full {
+				p.pushWindowKLine(w, 240)
+			}
+			continue
+		}
+		w.klineSeq++
+		seq := w.klineSeq
+		w.lastKLineBar = delta[len(delta)-1]
+		p.mu.Unlock()
+		p.emit(WindowEvent(EventKLineUpdate, w.sub.ID), map[string]any{
+			"code":        w.sub.Code,
+			"period":      w.sub.Period,
+			"data":        delta,
+			"incremental": true,
+			"seq":         seq,
+		})
+	}
+}
+
+// pushWindowKLine 全量推送分离窗口的K线
+func (p *WindowPusher) pushWindowKLine(w *windowState, days int) {
+	klines, err := p.marketService.GetKLineData(w.ctx, w.sub.Code, w.sub.Period, days)
+	if err != nil {
+		return
+	}
+	p.mu.Lock()
+	if w.ctx.Err() != nil {
+		p.mu.Unlock()
+		return
+	}
+	if len(klines) > 0 {
+		last := klines[len(klines)-1]
+		if w.sub.Period == "1m" {
+			w.lastKLineTime = parseKLineTime(last.Time)
+		} else {
+			w.lastKLineBar = last
+		}
+	}
+	w.klineSeq++
+	seq := w.klineSeq
+	p.mu.Unlock()
+
+	drawings := []models.ChartDrawing{}
+	if p.drawings != nil {
+		drawings = p.drawings(w.sub.Code, w.sub.Period)
+	}
+	p.emit(WindowEvent(EventKLineUpdate, w.sub.ID), map[string]any{
+		"code":     w.sub.Code,
+		"period":   w.sub.Period,
+		"data":     klines,
+		"seq":      seq,
+		"drawings": drawings,
+	})
+}
+
+// parseWindowSubscription 解析前端事件中的窗口订阅
+func parseWindowSubscription(data any) (WindowSubscription, bool) {
+	m, ok := data.(map[string]any)
+	if !ok {
+		return WindowSubscription{}, false
+	}
+	str := func(key string) string {
+		s, _ := m[key].(string)
+		return s
+	}
+	return WindowSubscription{ID: str("id"), Kind: str("kind"), Code: str("code"), Name: str("name"), Period: str("period")}, true
+}
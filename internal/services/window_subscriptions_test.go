@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// windowTestSource 分离窗口测试数据源：盘口买一价可调，K线请求可阻塞到 ctx 取消
+type windowTestSource struct {
+	mu       sync.Mutex
+	bidPrice float64
+	fetches  int
+	block    bool
+	canceled chan struct{}
+}
+
+func (s *windowTestSource) GetStockRealTimeData(ctx context.Context, codes ...string) ([]models.Stock, error) {
+	return nil, nil
+}
+
+func (s *windowTestSource) GetStockDataWithOrderBook(ctx context.Context, codes ...string) ([]StockWithOrderBook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fetches++
+	return []StockWithOrderBook{{
+		Stock:     models.Stock{Symbol: codes[0]},
+		OrderBook: models.OrderBook{Bids: []models.OrderBookItem{{Price: s.bidPrice, Size: 100}}},
+	}}, nil
+}
+
+func (s *windowTestSource) GetKLineData(ctx context.Context, code string, period string, days int) ([]models.KLineData, error) {
+	s.mu.Lock()
+	block := s.block
+	s.mu.Unlock()
+	if block {
+		<-ctx.Done()
+		close(s.canceled)
+		return nil, ctx.Err()
+	}
+	return []models.KLineData{{Time: "2026-02-09", Close: 10}}, nil
+}
+
+func (s *windowTestSource) GetMarketIndicesByCodes(ctx context.Context, codes []string) ([]models.MarketIndex, error) {
+	return nil, nil
+}
+
+// windowEvents 记录推送的事件名
+type windowEvents struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (e *windowEvents) emit(event string, data any) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.events = append(e.events, event)
+}
+
+func (e *windowEvents) count(event string) int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	n := 0
+	for _, ev := range e.events {
+		if ev == event {
+			n++
+		}
+	}
+	return n
+}
+
+func newTestWindowPusher(source *windowTestSource) (*WindowPusher, *windowEvents) {
+	ms := NewMarketService()
+	ms.SetRemoteSource(source)
+	p := NewWindowPusher(ms, nil)
+	events := &windowEvents{}
+	p.emit = events.emit
+	return p, events
+}
+
+func TestWindowPusherLimit(t *testing.T) {
+	p, _ := newTestWindowPusher(&windowTestSource{bidPrice: 10})
+	for i := range maxDetachedWindows {
+		sub := WindowSubscription{ID: "w" + string(rune('a'+i)), Kind: WindowKindOrderBook, Code: "sh600000"}
+		if err := p.SubscribeWindow(sub); err != nil {
+			t.Fatalf("第 %d 个窗口订阅失败: %v", i+1, err)
+		}
+	}
+	if err := p.SubscribeWindow(WindowSubscription{ID: "extra", Kind: WindowKindOrderBook, Code: "sh600000"}); err == nil {
+		t.Error("超过窗口上限应报错")
+	}
+	// 已有窗口重新订阅不受上限限制
+	if err := p.SubscribeWindow(WindowSubscription{ID: "wa", Kind: WindowKindKLine, Code: "sz000001"}); err != nil {
+		t.Errorf("已有窗口重新订阅失败: %v", err)
+	}
+	if err := p.SubscribeWindow(WindowSubscription{ID: "x", Kind: "depth", Code: "sh600000"}); err == nil || !strings.Contains(err.Error(), "depth") {
+		t.Errorf("不支持的窗口类型应报错: %v", err)
+	}
+
+	p.UnsubscribeWindow("wb")
+	if err := p.SubscribeWindow(WindowSubscription{ID: "extra", Kind: WindowKindOrderBook, Code: "sh600000"}); err != nil {
+		t.Errorf("关闭窗口后应可打开新窗口: %v", err)
+	}
+}
+
+func TestWindowPusherUnsubscribe(t *testing.T) {
+	source := &windowTestSource{block: true, canceled: make(chan struct{})}
+	p, events := newTestWindowPusher(source)
+	if err := p.SubscribeWindow(WindowSubscription{ID: "k1", Kind: WindowKindKLine, Code: "sh600000"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 取消订阅中断该窗口进行中的首次推送
+	p.UnsubscribeWindow("k1")
+	select {
+	case <-source.canceled:
+	case <-time.After(2 * time.Second):
+		t.Fatal("取消订阅后进行中的请求应被中断")
+	}
+	if len(p.windowStates(WindowKindKLine)) != 0 {
+		t.Error("取消订阅后不应保留窗口")
+	}
+	p.pushWindowKLineDay()
+	if n := events.count(WindowEvent(EventKLineUpdate, "k1")); n != 0 {
+		t.Errorf("已关闭的窗口不应收到推送: %d", n)
+	}
+}
+
+func TestWindowPusherOrderBookDiff(t *testing.T) {
+	source := &windowTestSource{bidPrice: 10}
+	p, events := newTestWindowPusher(source)
+	for _, id := range []string{"o1", "o2"} {
+		w := &windowState{sub: WindowSubscription{ID: id, Kind: WindowKindOrderBook, Code: "sh600000"}}
+		w.ctx, w.cancel = context.WithCancel(context.Background())
+		p.windows[id] = w
+	}
+
+	// 相同股票的窗口共享一次请求，各自推送
+	p.pushWindowOrderBooks(context.Background())
+	if source.fetches != 1 || events.count(WindowEvent(EventOrderBookUpdate, "o1")) != 1 || events.count(WindowEvent(EventOrderBookUpdate, "o2")) != 1 {
+		t.Fatalf("fetches = %d, events = %v", source.fetches, events.events)
+	}
+
+	w := p.windows["o1"]
+	event := WindowEvent(EventOrderBookUpdate, "o1")
+	book := func(price float64) models.OrderBook {
+		return models.OrderBook{Bids: []models.OrderBookItem{{Price: price, Size: 100}}}
+	}
+	p.emitWindowOrderBook(w, book(10))
+	if n := events.count(event); n != 1 {
+		t.Fatalf("盘口未变化不应重复推送: %d", n)
+	}
+	p.emitWindowOrderBook(w, book(10.01))
+	if n := events.count(event); n != 2 {
+		t.Errorf("盘口变化后应推送: %d", n)
+	}
+
+	p.UnsubscribeWindow("o1")
+	p.emitWindowOrderBook(w, book(10.02))
+	if n := events.count(event); n != 2 {
+		t.Errorf("取消订阅后不应推送: %d", n)
+	}
+}
+
+func TestDetachedArgs(t *testing.T) {
+	sub := WindowSubscription{ID: "kline-sh600519-1", Kind: WindowKindKLine, Code: "sh600519", Name: "贵州茅台 A&B"}
+	got, ok := ParseDetachedArgs([]string{"-foo", DetachedArg(sub)})
+	sub.Period = "1d"
+	if !ok || got != sub {
+		t.Fatalf("ParseDetachedArgs = %+v, %v", got, ok)
+	}
+	if _, ok := ParseDetachedArgs([]string{"--detached=kind=x&code=sh600519&id=1"}); ok {
+		t.Error("无效的窗口类型不应解析")
+	}
+	if _, ok := ParseDetachedArgs(nil); ok {
+		t.Error("主进程不应解析为分离窗口")
+	}
+}
+
+// fakeDetachedProcess 测试用子进程，Kill 后 Wait 返回
+type fakeDetachedProcess struct{ done chan struct{} }
+
+func (p *fakeDetachedProcess) Wait() error { <-p.done; return nil }
+func (p *fakeDetachedProcess) Kill() error { close(p.done); return nil }
+
+func TestDetachedWindowsOpen(t *testing.T) {
+	d := NewDetachedWindows()
+	var started []WindowSubscription
+	procs := map[string]*fakeDetachedProcess{}
+	d.start = func(sub WindowSubscription) (detachedProcess, error) {
+		started = append(started, sub)
+		proc := &fakeDetachedProcess{done: make(chan struct{})}
+		procs[sub.ID] = proc
+		return proc, nil
+	}
+
+	if _, err := d.Open("depth", "sh600000", ""); err == nil || len(started) != 0 {
+		t.Error("不支持的窗口类型应报错且不启动子进程")
+	}
+	var first string
+	for i := range maxDetachedWindows {
+		id, err := d.Open(WindowKindOrderBook, "sh600000", "浦发银行")
+		if err != nil {
+			t.Fatalf("第 %d 个窗口打开失败: %v", i+1, err)
+		}
+		if first == "" {
+			first = id
+		}
+	}
+	if _, err := d.Open(WindowKindKLine, "sh600000", ""); err == nil {
+		t.Error("超过窗口上限应报错")
+	}
+	if started[0].Name != "浦发银行" || started[0].ID == started[1].ID {
+		t.Errorf("子进程参数 = %+v", started[:2])
+	}
+
+	// 子进程退出后释放名额
+	procs[first].Kill()
+	deadline := time.Now().Add(2 * time.Second)
+	for d.Count() != maxDetachedWindows-1 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, err := d.Open(WindowKindKLine, "sh600000", ""); err != nil {
+		t.Errorf("窗口关闭后应可打开新窗口: %v", err)
+	}
+}
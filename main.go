@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"runtime/debug"
 
+	"github.com/run-bigpig/jcp/internal/services"
+
 	"github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/options"
 	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
@@ -19,15 +21,25 @@ var assets embed.FS
 var Version = "dev"
 
 func main() {
-	// 捕获 panic 并写入日志文件
+	// 分离窗口子进程：只显示一只股票的K线图或盘口
+	if sub, ok := services.ParseDetachedArgs(os.Args[1:]); ok {
+		if err := runDetached(sub, assets); err != nil {
+			println("Error:", err.Error())
+		}
+		return
+	}
+
+	var app *App
+
+	// 捕获 panic 并写入日志文件和诊断包
 	defer func() {
 		if r := recover(); r != nil {
-			logPanic(r)
+			logPanic(r, app)
 		}
 	}()
 
 	// Create an instance of the app structure
-	app := NewApp()
+	app = NewApp()
 
 	// Create application with options
 	err := wails.Run(&options.App{
@@ -55,8 +67,12 @@ func main() {
 	}
 }
 
-// logPanic 将 panic 信息写入日志文件
-func logPanic(r interface{}) {
+// logPanic 将 panic 信息写入日志文件，并生成诊断包
+func logPanic(r interface{}, app *App) {
+	stack := debug.Stack()
+	// 先写 crash.log，再生成诊断包（app 可能尚未创建）
+	defer app.writeDiagnostics(fmt.Sprintf("PANIC: %v", r), stack)
+
 	// 获取可执行文件所在目录
 	exe, _ := os.Executable()
 	dir := filepath.Dir(exe)
@@ -69,6 +85,6 @@ func logPanic(r interface{}) {
 	}
 	defer f.Close()
 
-	msg := fmt.Sprintf("PANIC: %v\n%s\n", r, debug.Stack())
+	msg := fmt.Sprintf("PANIC: %v\n%s\n", r, stack)
 	f.WriteString(msg)
 }